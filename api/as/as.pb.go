@@ -22,6 +22,10 @@ It has these top-level messages:
 	HandleDataDownACKResponse
 	HandleErrorRequest
 	HandleErrorResponse
+	HandleGatewayStatsRequest
+	HandleGatewayStatsResponse
+	SetDeviceLocationRequest
+	SetDeviceLocationResponse
 */
 package as
 
@@ -66,32 +70,136 @@ func (x RXWindow) String() string {
 }
 func (RXWindow) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type ADRStrategy int32
+
+const (
+	// Increase the data-rate first, then decrease the TX power.
+	ADRStrategy_MAXIMIZE_DATA_RATE ADRStrategy = 0
+	// Decrease the TX power first, then increase the data-rate.
+	ADRStrategy_MINIMIZE_TX_POWER ADRStrategy = 1
+)
+
+var ADRStrategy_name = map[int32]string{
+	0: "MAXIMIZE_DATA_RATE",
+	1: "MINIMIZE_TX_POWER",
+}
+var ADRStrategy_value = map[string]int32{
+	"MAXIMIZE_DATA_RATE": 0,
+	"MINIMIZE_TX_POWER":  1,
+}
+
+func (x ADRStrategy) String() string {
+	return proto.EnumName(ADRStrategy_name, int32(x))
+}
+func (ADRStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type Polarity int32
+
+const (
+	// The polarity is not set (inherited from the previous level).
+	Polarity_INHERIT_POLARITY Polarity = 0
+	// Normal (not inverted) polarity.
+	Polarity_NORMAL_POLARITY Polarity = 1
+	// Inverted polarity (the default for LoRa downlinks).
+	Polarity_INVERTED_POLARITY Polarity = 2
+)
+
+var Polarity_name = map[int32]string{
+	0: "INHERIT_POLARITY",
+	1: "NORMAL_POLARITY",
+	2: "INVERTED_POLARITY",
+}
+var Polarity_value = map[string]int32{
+	"INHERIT_POLARITY":  0,
+	"NORMAL_POLARITY":   1,
+	"INVERTED_POLARITY": 2,
+}
+
+func (x Polarity) String() string {
+	return proto.EnumName(Polarity_name, int32(x))
+}
+func (Polarity) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type CFListType int32
+
+const (
+	// Channel frequency list (CFList type 0), see cFList.
+	CFListType_FREQUENCIES CFListType = 0
+	// Channel mask (CFList type 1) generated by LoRa Server, see
+	// JoinRequestRequest.cFListChMask.
+	CFListType_CHANNEL_MASK CFListType = 1
+)
+
+var CFListType_name = map[int32]string{
+	0: "FREQUENCIES",
+	1: "CHANNEL_MASK",
+}
+var CFListType_value = map[string]int32{
+	"FREQUENCIES":  0,
+	"CHANNEL_MASK": 1,
+}
+
+func (x CFListType) String() string {
+	return proto.EnumName(CFListType_name, int32(x))
+}
+func (CFListType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
 type ErrorType int32
 
 const (
-	ErrorType_Generic      ErrorType = 0
-	ErrorType_OTAA         ErrorType = 1
-	ErrorType_DATA_UP_FCNT ErrorType = 2
-	ErrorType_DATA_UP_MIC  ErrorType = 3
+	ErrorType_Generic                       ErrorType = 0
+	ErrorType_OTAA                          ErrorType = 1
+	ErrorType_DATA_UP_FCNT                  ErrorType = 2
+	ErrorType_DATA_UP_MIC                   ErrorType = 3
+	ErrorType_DATA_DOWN_QUEUE_ITEM_EXPIRED  ErrorType = 4
+	ErrorType_DATA_DOWN_BATTERY_THROTTLED   ErrorType = 5
+	ErrorType_OTAA_INVALID_JOIN_RESPONSE    ErrorType = 6
+	ErrorType_OTAA_JOIN_ACCEPT_NOT_RECEIVED ErrorType = 7
+	ErrorType_DATA_DOWN_QUEUE_ITEM_DROPPED  ErrorType = 8
+	ErrorType_DATA_DOWN_FCNT_ROLLOVER       ErrorType = 9
+	ErrorType_DATA_DOWN_NOT_ACKNOWLEDGED    ErrorType = 10
+	ErrorType_OTAA_OUT_OF_AREA              ErrorType = 11
+	ErrorType_OTAA_UNSUPPORTED_CFLIST       ErrorType = 12
+	ErrorType_OTAA_JOIN_ACCEPT_REPLAY       ErrorType = 13
 )
 
 var ErrorType_name = map[int32]string{
-	0: "Generic",
-	1: "OTAA",
-	2: "DATA_UP_FCNT",
-	3: "DATA_UP_MIC",
+	0:  "Generic",
+	1:  "OTAA",
+	2:  "DATA_UP_FCNT",
+	3:  "DATA_UP_MIC",
+	4:  "DATA_DOWN_QUEUE_ITEM_EXPIRED",
+	5:  "DATA_DOWN_BATTERY_THROTTLED",
+	6:  "OTAA_INVALID_JOIN_RESPONSE",
+	7:  "OTAA_JOIN_ACCEPT_NOT_RECEIVED",
+	8:  "DATA_DOWN_QUEUE_ITEM_DROPPED",
+	9:  "DATA_DOWN_FCNT_ROLLOVER",
+	10: "DATA_DOWN_NOT_ACKNOWLEDGED",
+	11: "OTAA_OUT_OF_AREA",
+	12: "OTAA_UNSUPPORTED_CFLIST",
+	13: "OTAA_JOIN_ACCEPT_REPLAY",
 }
 var ErrorType_value = map[string]int32{
-	"Generic":      0,
-	"OTAA":         1,
-	"DATA_UP_FCNT": 2,
-	"DATA_UP_MIC":  3,
+	"Generic":                       0,
+	"OTAA":                          1,
+	"DATA_UP_FCNT":                  2,
+	"DATA_UP_MIC":                   3,
+	"DATA_DOWN_QUEUE_ITEM_EXPIRED":  4,
+	"DATA_DOWN_BATTERY_THROTTLED":   5,
+	"OTAA_INVALID_JOIN_RESPONSE":    6,
+	"OTAA_JOIN_ACCEPT_NOT_RECEIVED": 7,
+	"DATA_DOWN_QUEUE_ITEM_DROPPED":  8,
+	"DATA_DOWN_FCNT_ROLLOVER":       9,
+	"DATA_DOWN_NOT_ACKNOWLEDGED":    10,
+	"OTAA_OUT_OF_AREA":              11,
+	"OTAA_UNSUPPORTED_CFLIST":       12,
+	"OTAA_JOIN_ACCEPT_REPLAY":       13,
 }
 
 func (x ErrorType) String() string {
 	return proto.EnumName(ErrorType_name, int32(x))
 }
-func (ErrorType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (ErrorType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 type DataRate struct {
 	Modulation   string `protobuf:"bytes,1,opt,name=modulation" json:"modulation,omitempty"`
@@ -249,6 +357,12 @@ type JoinRequestRequest struct {
 	PhyPayload []byte `protobuf:"bytes,1,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
 	DevAddr    []byte `protobuf:"bytes,2,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
 	NetID      []byte `protobuf:"bytes,3,opt,name=netID,proto3" json:"netID,omitempty"`
+	// The CFList (16 bytes, including the CFList type byte) of CFList type
+	// 1 (channel mask), containing the enabled uplink channels of the band.
+	// It is only set for bands with fixed channels (US_902_928 and
+	// AU_915_928) and must be used as-is in the join-accept when the device
+	// profile supports it (see JoinRequestResponse.cFListType).
+	CFListChMask []byte `protobuf:"bytes,4,opt,name=cFListChMask,proto3" json:"cFListChMask,omitempty"`
 }
 
 func (m *JoinRequestRequest) Reset()                    { *m = JoinRequestRequest{} }
@@ -277,6 +391,13 @@ func (m *JoinRequestRequest) GetNetID() []byte {
 	return nil
 }
 
+func (m *JoinRequestRequest) GetCFListChMask() []byte {
+	if m != nil {
+		return m.CFListChMask
+	}
+	return nil
+}
+
 type JoinRequestResponse struct {
 	// The encrypted PHYPayload containing the join-accept.
 	PhyPayload []byte `protobuf:"bytes,1,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
@@ -293,6 +414,10 @@ type JoinRequestResponse struct {
 	RxWindow RXWindow `protobuf:"varint,6,opt,name=rxWindow,enum=as.RXWindow" json:"rxWindow,omitempty"`
 	// The data-rate to use for RX2 transmissions.
 	Rx2DR uint32 `protobuf:"varint,7,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The frequency (Hz) to use for RX2 transmissions (0 = the RX2 frequency
+	// of the band). As the join-accept does not contain the RX2 frequency,
+	// LoRa Server enqueues a RXParamSetupReq mac-command after the join.
+	Rx2Frequency uint32 `protobuf:"varint,25,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
 	// The data-rate to use for RX2 transmissions.
 	RelaxFCnt bool `protobuf:"varint,8,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
 	// The interval (based on frame-counter) on which to calculate the ideal
@@ -301,6 +426,95 @@ type JoinRequestResponse struct {
 	// The installation margin to take into account when calculating the ideal
 	// data-rate and tx-power. The default recommended value is 5dB.
 	InstallationMargin float64 `protobuf:"fixed64,10,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The ADR strategy to use for calculating the ideal data-rate and
+	// tx-power.
+	AdrStrategy ADRStrategy `protobuf:"varint,11,opt,name=adrStrategy,enum=as.ADRStrategy" json:"adrStrategy,omitempty"`
+	// The AppSKey, wrapped (RFC 3394) with the KEK configured in LoRa
+	// Server. When set, LoRa Server performs the FRMPayload encryption and
+	// decryption and exchanges plaintext payloads with the
+	// application-server.
+	WrappedAppSKey []byte `protobuf:"bytes,12,opt,name=wrappedAppSKey,proto3" json:"wrappedAppSKey,omitempty"`
+	// Preserve the downlink state of the node when it rejoins: the
+	// mac-commands queued for the node and the uplink history of the
+	// previous node-session are migrated to the new node-session instead
+	// of being flushed. The application-server downlink queue is retrieved
+	// by DevEUI and is therefore re-targeted to the new node-session.
+	PreserveDownlinkQueue bool `protobuf:"varint,13,opt,name=preserveDownlinkQueue" json:"preserveDownlinkQueue,omitempty"`
+	// The node uses a monotonic (counter based) DevNonce (e.g. LoRaWAN 1.1).
+	// LoRa Server keeps track of the highest DevNonce used by the node and
+	// rejects join-requests re-using a lower or equal DevNonce.
+	MonotonicDevNonce bool `protobuf:"varint,14,opt,name=monotonicDevNonce" json:"monotonicDevNonce,omitempty"`
+	// The gateway regions via which downlinks for the node may be
+	// transmitted (geofencing). When empty, all gateways may be used.
+	GatewayRegions []string `protobuf:"bytes,15,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	// The TX power (dBm) of downlinks to the node, overriding the TX power
+	// of the band and gateway (0 = not set).
+	DownlinkTXPower uint32 `protobuf:"varint,16,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks to the node, overriding the code
+	// rate of the band and gateway (empty = not set).
+	DownlinkCodeRate string `protobuf:"bytes,17,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// The polarity of downlinks to the node, overriding the polarity of the
+	// band and gateway.
+	DownlinkPolarity Polarity `protobuf:"varint,18,opt,name=downlinkPolarity,enum=as.Polarity" json:"downlinkPolarity,omitempty"`
+	// Confirmed downlinks which are not marked critical are sent as
+	// unconfirmed downlinks when the battery level last reported by the node
+	// (DevStatusAns, 1 - 254) is below this level (0 = disabled).
+	BatteryThrottleLevel uint32 `protobuf:"varint,19,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	// Uplinks on this FPort signal that the node is temporarily available as
+	// Class-C device (e.g. while mains-powered), see classCWindow
+	// (0 = disabled).
+	ClassCFPort uint32 `protobuf:"varint,20,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device after an
+	// uplink on the classCFPort.
+	ClassCWindow uint32 `protobuf:"varint,21,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Transmit critical confirmed downlinks (in response to an uplink) a
+	// second time via the second best gateway, using the other RX window
+	// (transmit diversity). This improves the delivery probability at the
+	// cost of airtime.
+	TransmitDiversity bool `protobuf:"varint,22,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day (fair
+	// use, 0 = no cap), e.g. as configured in the device profile.
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,24,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// The CFList type used in the join-accept. CHANNEL_MASK can only be
+	// used when the join-request contained a cFListChMask, in which case
+	// cFList must be empty.
+	CFListType CFListType `protobuf:"varint,23,opt,name=cFListType,enum=as.CFListType" json:"cFListType,omitempty"`
+	// Operator-defined tags of the node (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,26,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// LoRaWAN MAC version of the node (1.0.0, 1.0.1, 1.0.2 or 1.0.3,
+	// default 1.0.2), mac-commands not supported by this version are not
+	// sent to the node.
+	MacVersion string `protobuf:"bytes,27,opt,name=macVersion" json:"macVersion,omitempty"`
+	// Resolve the location of the node from the fine-timestamps of the
+	// receiving gateways (TDOA), the resolved location is sent to the
+	// application-server (SetDeviceLocation).
+	Geolocation bool `protobuf:"varint,28,opt,name=geolocation" json:"geolocation,omitempty"`
+	// ID of the channel-configuration (channel plan) to which the uplink
+	// channels of the node are reconciled after the join (0 = none).
+	ChannelConfigurationID int64 `protobuf:"varint,29,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// The gateway regions via which join-requests of the node are accepted.
+	// When set, the join is rejected (OTAA_OUT_OF_AREA) when none of the
+	// receiving gateways is within one of these regions.
+	JoinGatewayRegions []string `protobuf:"bytes,30,rep,name=joinGatewayRegions" json:"joinGatewayRegions,omitempty"`
+	// The gateway tags required for accepting join-requests of the node.
+	// When set, the join is rejected (OTAA_OUT_OF_AREA) when none of the
+	// receiving gateways has all these tags.
+	JoinGatewayTags map[string]string `protobuf:"bytes,31,rep,name=joinGatewayTags" json:"joinGatewayTags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Include the raw PHYPayload of the uplink frames of the node in the
+	// HandleDataUp calls (e.g. for auditing).
+	ForwardPHYPayload bool `protobuf:"varint,32,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	// Max. number of uplink frames of the node forwarded per hour, the
+	// uplink frames exceeding this limit are counted but not forwarded
+	// (0 = unlimited).
+	MaxUplinksPerHour uint32 `protobuf:"varint,33,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	// Legacy (LoRaWAN 1.0.1) ADRACKReq handling, for nodes continuously
+	// setting the ADRACKReq bit or only resetting their ADR_ACK_CNT on a
+	// downlink with the ADR bit set.
+	LegacyADRACKReq bool `protobuf:"varint,34,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	// ID of the device-profile of the node, defining the downlink queue size
+	// and airtime quota (0 = none).
+	DeviceProfileID int64 `protobuf:"varint,35,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 }
 
 func (m *JoinRequestResponse) Reset()                    { *m = JoinRequestResponse{} }
@@ -357,6 +571,13 @@ func (m *JoinRequestResponse) GetRx2DR() uint32 {
 	return 0
 }
 
+func (m *JoinRequestResponse) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
 func (m *JoinRequestResponse) GetRelaxFCnt() bool {
 	if m != nil {
 		return m.RelaxFCnt
@@ -378,6 +599,174 @@ func (m *JoinRequestResponse) GetInstallationMargin() float64 {
 	return 0
 }
 
+func (m *JoinRequestResponse) GetAdrStrategy() ADRStrategy {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
+}
+
+func (m *JoinRequestResponse) GetWrappedAppSKey() []byte {
+	if m != nil {
+		return m.WrappedAppSKey
+	}
+	return nil
+}
+
+func (m *JoinRequestResponse) GetPreserveDownlinkQueue() bool {
+	if m != nil {
+		return m.PreserveDownlinkQueue
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetMonotonicDevNonce() bool {
+	if m != nil {
+		return m.MonotonicDevNonce
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetGatewayRegions() []string {
+	if m != nil {
+		return m.GatewayRegions
+	}
+	return nil
+}
+
+func (m *JoinRequestResponse) GetDownlinkTXPower() uint32 {
+	if m != nil {
+		return m.DownlinkTXPower
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetDownlinkCodeRate() string {
+	if m != nil {
+		return m.DownlinkCodeRate
+	}
+	return ""
+}
+
+func (m *JoinRequestResponse) GetDownlinkPolarity() Polarity {
+	if m != nil {
+		return m.DownlinkPolarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *JoinRequestResponse) GetBatteryThrottleLevel() uint32 {
+	if m != nil {
+		return m.BatteryThrottleLevel
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetClassCFPort() uint32 {
+	if m != nil {
+		return m.ClassCFPort
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetClassCWindow() uint32 {
+	if m != nil {
+		return m.ClassCWindow
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetTransmitDiversity() bool {
+	if m != nil {
+		return m.TransmitDiversity
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetDailyDownlinkAirtimeCap() uint32 {
+	if m != nil {
+		return m.DailyDownlinkAirtimeCap
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetCFListType() CFListType {
+	if m != nil {
+		return m.CFListType
+	}
+	return CFListType_FREQUENCIES
+}
+
+func (m *JoinRequestResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *JoinRequestResponse) GetMacVersion() string {
+	if m != nil {
+		return m.MacVersion
+	}
+	return ""
+}
+
+func (m *JoinRequestResponse) GetGeolocation() bool {
+	if m != nil {
+		return m.Geolocation
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetChannelConfigurationID() int64 {
+	if m != nil {
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetJoinGatewayRegions() []string {
+	if m != nil {
+		return m.JoinGatewayRegions
+	}
+	return nil
+}
+
+func (m *JoinRequestResponse) GetJoinGatewayTags() map[string]string {
+	if m != nil {
+		return m.JoinGatewayTags
+	}
+	return nil
+}
+
+func (m *JoinRequestResponse) GetForwardPHYPayload() bool {
+	if m != nil {
+		return m.ForwardPHYPayload
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetMaxUplinksPerHour() uint32 {
+	if m != nil {
+		return m.MaxUplinksPerHour
+	}
+	return 0
+}
+
+func (m *JoinRequestResponse) GetLegacyADRACKReq() bool {
+	if m != nil {
+		return m.LegacyADRACKReq
+	}
+	return false
+}
+
+func (m *JoinRequestResponse) GetDeviceProfileID() int64 {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return 0
+}
+
 type HandleDataUpRequest struct {
 	DevEUI []byte    `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
 	AppEUI []byte    `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
@@ -386,6 +775,19 @@ type HandleDataUpRequest struct {
 	Data   []byte    `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 	TxInfo *TXInfo   `protobuf:"bytes,6,opt,name=txInfo" json:"txInfo,omitempty"`
 	RxInfo []*RXInfo `protobuf:"bytes,7,rep,name=rxInfo" json:"rxInfo,omitempty"`
+	// The data has been decrypted by LoRa Server (AppSKey encryption
+	// offload).
+	Decrypted bool `protobuf:"varint,8,opt,name=decrypted" json:"decrypted,omitempty"`
+	// The max payload size (bytes) of a downlink transmitted in RX1 (at the
+	// data-rate of the uplink and the RX1DROffset of the node), not taking
+	// mac-commands into account.
+	MaxPayloadSizeRX1 uint32 `protobuf:"varint,9,opt,name=maxPayloadSizeRX1" json:"maxPayloadSizeRX1,omitempty"`
+	// The max payload size (bytes) of a downlink transmitted in RX2 (at the
+	// RX2DR of the node), not taking mac-commands into account.
+	MaxPayloadSizeRX2 uint32 `protobuf:"varint,10,opt,name=maxPayloadSizeRX2" json:"maxPayloadSizeRX2,omitempty"`
+	// The raw PHYPayload of the uplink frame, only set when enabled for the
+	// node (forwardPHYPayload).
+	PhyPayload []byte `protobuf:"bytes,11,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
 }
 
 func (m *HandleDataUpRequest) Reset()                    { *m = HandleDataUpRequest{} }
@@ -442,6 +844,34 @@ func (m *HandleDataUpRequest) GetRxInfo() []*RXInfo {
 	return nil
 }
 
+func (m *HandleDataUpRequest) GetDecrypted() bool {
+	if m != nil {
+		return m.Decrypted
+	}
+	return false
+}
+
+func (m *HandleDataUpRequest) GetMaxPayloadSizeRX1() uint32 {
+	if m != nil {
+		return m.MaxPayloadSizeRX1
+	}
+	return 0
+}
+
+func (m *HandleDataUpRequest) GetMaxPayloadSizeRX2() uint32 {
+	if m != nil {
+		return m.MaxPayloadSizeRX2
+	}
+	return 0
+}
+
+func (m *HandleDataUpRequest) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
 type GetDataDownRequest struct {
 	DevEUI         []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
 	AppEUI         []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
@@ -487,6 +917,9 @@ type GetDataDownResponse struct {
 	Confirmed bool   `protobuf:"varint,2,opt,name=confirmed" json:"confirmed,omitempty"`
 	FPort     uint32 `protobuf:"varint,3,opt,name=fPort" json:"fPort,omitempty"`
 	MoreData  bool   `protobuf:"varint,4,opt,name=moreData" json:"moreData,omitempty"`
+	// The payload is critical and must be sent as confirmed payload, even
+	// when the battery level of the node is below its batteryThrottleLevel.
+	Critical bool `protobuf:"varint,5,opt,name=critical" json:"critical,omitempty"`
 }
 
 func (m *GetDataDownResponse) Reset()                    { *m = GetDataDownResponse{} }
@@ -522,6 +955,13 @@ func (m *GetDataDownResponse) GetMoreData() bool {
 	return false
 }
 
+func (m *GetDataDownResponse) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
 type HandleDataUpResponse struct {
 }
 
@@ -534,6 +974,8 @@ type HandleDataDownACKRequest struct {
 	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
 	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
 	FCnt   uint32 `protobuf:"varint,3,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Client reference of the acknowledged payload (when set on push).
+	Reference string `protobuf:"bytes,4,opt,name=reference" json:"reference,omitempty"`
 }
 
 func (m *HandleDataDownACKRequest) Reset()                    { *m = HandleDataDownACKRequest{} }
@@ -562,6 +1004,13 @@ func (m *HandleDataDownACKRequest) GetFCnt() uint32 {
 	return 0
 }
 
+func (m *HandleDataDownACKRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
 type HandleDataDownACKResponse struct {
 }
 
@@ -618,6 +1067,227 @@ func (m *HandleErrorResponse) String() string            { return proto.CompactT
 func (*HandleErrorResponse) ProtoMessage()               {}
 func (*HandleErrorResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
+type HandleGatewayStatsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Name of the gateway.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Start timestamp (RFC3339Nano) of the aggregation interval.
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Aggregation interval (e.g. MINUTE or HOUR).
+	Interval string `protobuf:"bytes,4,opt,name=interval" json:"interval,omitempty"`
+	// Number of radio packets received.
+	RxPacketsReceived int32 `protobuf:"varint,5,opt,name=rxPacketsReceived" json:"rxPacketsReceived,omitempty"`
+	// Number of radio packets received with valid PHY CRC.
+	RxPacketsReceivedOK int32 `protobuf:"varint,6,opt,name=rxPacketsReceivedOK" json:"rxPacketsReceivedOK,omitempty"`
+	// Number of downlink packets received for transmission.
+	TxPacketsReceived int32 `protobuf:"varint,7,opt,name=txPacketsReceived" json:"txPacketsReceived,omitempty"`
+	// Number of downlink packets emitted.
+	TxPacketsEmitted int32 `protobuf:"varint,8,opt,name=txPacketsEmitted" json:"txPacketsEmitted,omitempty"`
+	// Latitude of the gateway (only set when the location is known).
+	Latitude float64 `protobuf:"fixed64,9,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the gateway (only set when the location is known).
+	Longitude float64 `protobuf:"fixed64,10,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the gateway (only set when the altitude is known).
+	Altitude float64 `protobuf:"fixed64,11,opt,name=altitude" json:"altitude,omitempty"`
+}
+
+func (m *HandleGatewayStatsRequest) Reset()                    { *m = HandleGatewayStatsRequest{} }
+func (m *HandleGatewayStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayStatsRequest) ProtoMessage()               {}
+func (*HandleGatewayStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *HandleGatewayStatsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *HandleGatewayStatsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HandleGatewayStatsRequest) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *HandleGatewayStatsRequest) GetInterval() string {
+	if m != nil {
+		return m.Interval
+	}
+	return ""
+}
+
+func (m *HandleGatewayStatsRequest) GetRxPacketsReceived() int32 {
+	if m != nil {
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetRxPacketsReceivedOK() int32 {
+	if m != nil {
+		return m.RxPacketsReceivedOK
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetTxPacketsReceived() int32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetTxPacketsEmitted() int32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *HandleGatewayStatsRequest) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+type HandleGatewayStatsResponse struct {
+}
+
+func (m *HandleGatewayStatsResponse) Reset()                    { *m = HandleGatewayStatsResponse{} }
+func (m *HandleGatewayStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayStatsResponse) ProtoMessage()               {}
+func (*HandleGatewayStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+type SetDeviceLocationRequest struct {
+	// AppEUI of the node.
+	AppEUI []byte `protobuf:"bytes,1,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,2,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Frame-counter of the (last) uplink used for resolving the location.
+	FCnt uint32 `protobuf:"varint,3,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Latitude of the node.
+	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the node.
+	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the node.
+	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+	// Estimated accuracy (radius in meters) of the location.
+	Accuracy float64 `protobuf:"fixed64,7,opt,name=accuracy" json:"accuracy,omitempty"`
+	// Number of uplinks used for resolving the location.
+	FrameCount uint32 `protobuf:"varint,8,opt,name=frameCount" json:"frameCount,omitempty"`
+	// Number of gateways used for resolving the location.
+	GatewayCount uint32 `protobuf:"varint,9,opt,name=gatewayCount" json:"gatewayCount,omitempty"`
+	// The source of the location (e.g. TDOA).
+	Source string `protobuf:"bytes,10,opt,name=source" json:"source,omitempty"`
+}
+
+func (m *SetDeviceLocationRequest) Reset()                    { *m = SetDeviceLocationRequest{} }
+func (m *SetDeviceLocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceLocationRequest) ProtoMessage()               {}
+func (*SetDeviceLocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SetDeviceLocationRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *SetDeviceLocationRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *SetDeviceLocationRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetAccuracy() float64 {
+	if m != nil {
+		return m.Accuracy
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetFrameCount() uint32 {
+	if m != nil {
+		return m.FrameCount
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+func (m *SetDeviceLocationRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type SetDeviceLocationResponse struct {
+}
+
+func (m *SetDeviceLocationResponse) Reset()                    { *m = SetDeviceLocationResponse{} }
+func (m *SetDeviceLocationResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceLocationResponse) ProtoMessage()               {}
+func (*SetDeviceLocationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
 func init() {
 	proto.RegisterType((*DataRate)(nil), "as.DataRate")
 	proto.RegisterType((*RXInfo)(nil), "as.RXInfo")
@@ -632,7 +1302,14 @@ func init() {
 	proto.RegisterType((*HandleDataDownACKResponse)(nil), "as.HandleDataDownACKResponse")
 	proto.RegisterType((*HandleErrorRequest)(nil), "as.HandleErrorRequest")
 	proto.RegisterType((*HandleErrorResponse)(nil), "as.HandleErrorResponse")
+	proto.RegisterType((*HandleGatewayStatsRequest)(nil), "as.HandleGatewayStatsRequest")
+	proto.RegisterType((*HandleGatewayStatsResponse)(nil), "as.HandleGatewayStatsResponse")
+	proto.RegisterType((*SetDeviceLocationRequest)(nil), "as.SetDeviceLocationRequest")
+	proto.RegisterType((*SetDeviceLocationResponse)(nil), "as.SetDeviceLocationResponse")
 	proto.RegisterEnum("as.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("as.ADRStrategy", ADRStrategy_name, ADRStrategy_value)
+	proto.RegisterEnum("as.Polarity", Polarity_name, Polarity_value)
+	proto.RegisterEnum("as.CFListType", CFListType_name, CFListType_value)
 	proto.RegisterEnum("as.ErrorType", ErrorType_name, ErrorType_value)
 }
 
@@ -657,6 +1334,13 @@ type ApplicationServerClient interface {
 	HandleDataDownACK(ctx context.Context, in *HandleDataDownACKRequest, opts ...grpc.CallOption) (*HandleDataDownACKResponse, error)
 	// HandleError publishes an error message.
 	HandleError(ctx context.Context, in *HandleErrorRequest, opts ...grpc.CallOption) (*HandleErrorResponse, error)
+	// HandleGatewayStats publishes the aggregated stats of a gateway (sent on
+	// each aggregation tick when enabled).
+	HandleGatewayStats(ctx context.Context, in *HandleGatewayStatsRequest, opts ...grpc.CallOption) (*HandleGatewayStatsResponse, error)
+	// SetDeviceLocation publishes the location of a node, resolved from the
+	// fine-timestamps of the receiving gateways (when geolocation is
+	// enabled for the node).
+	SetDeviceLocation(ctx context.Context, in *SetDeviceLocationRequest, opts ...grpc.CallOption) (*SetDeviceLocationResponse, error)
 }
 
 type applicationServerClient struct {
@@ -712,6 +1396,24 @@ func (c *applicationServerClient) HandleError(ctx context.Context, in *HandleErr
 	return out, nil
 }
 
+func (c *applicationServerClient) HandleGatewayStats(ctx context.Context, in *HandleGatewayStatsRequest, opts ...grpc.CallOption) (*HandleGatewayStatsResponse, error) {
+	out := new(HandleGatewayStatsResponse)
+	err := grpc.Invoke(ctx, "/as.ApplicationServer/HandleGatewayStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServerClient) SetDeviceLocation(ctx context.Context, in *SetDeviceLocationRequest, opts ...grpc.CallOption) (*SetDeviceLocationResponse, error) {
+	out := new(SetDeviceLocationResponse)
+	err := grpc.Invoke(ctx, "/as.ApplicationServer/SetDeviceLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ApplicationServer service
 
 type ApplicationServerServer interface {
@@ -725,6 +1427,13 @@ type ApplicationServerServer interface {
 	HandleDataDownACK(context.Context, *HandleDataDownACKRequest) (*HandleDataDownACKResponse, error)
 	// HandleError publishes an error message.
 	HandleError(context.Context, *HandleErrorRequest) (*HandleErrorResponse, error)
+	// HandleGatewayStats publishes the aggregated stats of a gateway (sent on
+	// each aggregation tick when enabled).
+	HandleGatewayStats(context.Context, *HandleGatewayStatsRequest) (*HandleGatewayStatsResponse, error)
+	// SetDeviceLocation publishes the location of a node, resolved from the
+	// fine-timestamps of the receiving gateways (when geolocation is
+	// enabled for the node).
+	SetDeviceLocation(context.Context, *SetDeviceLocationRequest) (*SetDeviceLocationResponse, error)
 }
 
 func RegisterApplicationServerServer(s *grpc.Server, srv ApplicationServerServer) {
@@ -821,6 +1530,42 @@ func _ApplicationServer_HandleError_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationServer_HandleGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServer).HandleGatewayStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServer/HandleGatewayStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServer).HandleGatewayStats(ctx, req.(*HandleGatewayStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationServer_SetDeviceLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServerServer).SetDeviceLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/as.ApplicationServer/SetDeviceLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServerServer).SetDeviceLocation(ctx, req.(*SetDeviceLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ApplicationServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "as.ApplicationServer",
 	HandlerType: (*ApplicationServerServer)(nil),
@@ -845,6 +1590,14 @@ var _ApplicationServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleError",
 			Handler:    _ApplicationServer_HandleError_Handler,
 		},
+		{
+			MethodName: "HandleGatewayStats",
+			Handler:    _ApplicationServer_HandleGatewayStats_Handler,
+		},
+		{
+			MethodName: "SetDeviceLocation",
+			Handler:    _ApplicationServer_SetDeviceLocation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "as.proto",
@@ -853,63 +1606,134 @@ var _ApplicationServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("as.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 923 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdf, 0x6e, 0x1a, 0xc7,
-	0x17, 0xf6, 0x02, 0x86, 0xe5, 0x80, 0xfd, 0x23, 0xe3, 0xfc, 0x9c, 0x2d, 0xa5, 0x15, 0xdd, 0x8b,
-	0xca, 0xca, 0x85, 0xa5, 0xd0, 0x17, 0x08, 0x02, 0x3b, 0xa5, 0x69, 0x12, 0x6b, 0x8c, 0x15, 0x5f,
-	0x35, 0x1a, 0x33, 0x43, 0xb2, 0xea, 0x32, 0xb3, 0x9d, 0x1d, 0xdb, 0x50, 0xa9, 0x55, 0xaf, 0xfa,
-	0x68, 0xbd, 0xe9, 0x65, 0xdf, 0xa4, 0x4f, 0x50, 0x9d, 0x99, 0x59, 0x58, 0x8a, 0x2b, 0x55, 0x51,
-	0xaf, 0x7c, 0xbe, 0xef, 0x1c, 0xce, 0x9f, 0xef, 0x9c, 0x59, 0x19, 0x42, 0x96, 0x9f, 0x66, 0x5a,
-	0x19, 0x45, 0x2a, 0x2c, 0x8f, 0x7f, 0x0d, 0x20, 0x1c, 0x33, 0xc3, 0x28, 0x33, 0x82, 0x7c, 0x0e,
-	0xb0, 0x50, 0xfc, 0x36, 0x65, 0x26, 0x51, 0x32, 0x0a, 0xfa, 0xc1, 0x49, 0x93, 0x96, 0x18, 0xd2,
-	0x83, 0xe6, 0x0d, 0x93, 0xfc, 0x6d, 0xc2, 0xcd, 0x87, 0xa8, 0xd2, 0x0f, 0x4e, 0x0e, 0xe8, 0x86,
-	0x20, 0x31, 0xb4, 0xf3, 0x4c, 0x0b, 0xc6, 0xcf, 0xd9, 0xcc, 0x28, 0x1d, 0x55, 0x6d, 0xc0, 0x16,
-	0x47, 0x22, 0x68, 0xdc, 0x24, 0x46, 0x33, 0x23, 0xa2, 0x9a, 0x75, 0x17, 0x30, 0xfe, 0x2d, 0x80,
-	0x3a, 0xbd, 0x9e, 0xc8, 0xb9, 0x22, 0x1d, 0xa8, 0x2e, 0xd8, 0xcc, 0xd6, 0x6f, 0x53, 0x34, 0x09,
-	0x81, 0x9a, 0x49, 0x16, 0xc2, 0xd6, 0x6c, 0x52, 0x6b, 0x23, 0xa7, 0xf3, 0x3c, 0xb1, 0x65, 0xf6,
-	0xa9, 0xb5, 0x31, 0x7d, 0xaa, 0x28, 0xbb, 0x7c, 0x4d, 0x6d, 0xfa, 0x80, 0x16, 0x10, 0xa3, 0x25,
-	0x5b, 0x88, 0x68, 0xdf, 0x65, 0x40, 0x9b, 0x74, 0x21, 0xc4, 0xc1, 0xcc, 0x2d, 0x17, 0x51, 0xdd,
-	0x86, 0xaf, 0x31, 0x8e, 0x9a, 0x2a, 0xf9, 0xde, 0x39, 0x1b, 0xd6, 0xb9, 0x21, 0xf0, 0x97, 0x2c,
-	0xf5, 0xbf, 0x0c, 0xdd, 0x2f, 0x0b, 0x1c, 0xff, 0x0c, 0xf5, 0xa9, 0x9b, 0xa3, 0x07, 0xcd, 0xb9,
-	0x16, 0x3f, 0xdc, 0x0a, 0x39, 0x5b, 0xd9, 0x69, 0xaa, 0x74, 0x43, 0x90, 0x13, 0x08, 0xb9, 0x17,
-	0xde, 0xce, 0xd5, 0x1a, 0xb4, 0x4f, 0x59, 0x7e, 0x5a, 0x2c, 0x83, 0xae, 0xbd, 0xa8, 0x07, 0xe3,
-	0x4e, 0xcf, 0x90, 0xa2, 0x89, 0xf5, 0x67, 0x8a, 0x0b, 0x5a, 0xe8, 0xd8, 0xa4, 0x6b, 0x1c, 0x73,
-	0x20, 0xdf, 0xa8, 0x44, 0x52, 0xac, 0x93, 0x1b, 0xff, 0x07, 0x57, 0x9b, 0x7d, 0x58, 0x5d, 0xb0,
-	0x55, 0xaa, 0x18, 0xf7, 0xd2, 0x96, 0x18, 0x54, 0x8e, 0x8b, 0xbb, 0x21, 0xe7, 0xda, 0x36, 0xd3,
-	0xa6, 0x05, 0x24, 0x8f, 0x61, 0x5f, 0x0a, 0x33, 0x19, 0xdb, 0xfa, 0x6d, 0xea, 0x40, 0xfc, 0x47,
-	0x05, 0x8e, 0xb6, 0xca, 0xe4, 0x99, 0x92, 0xb9, 0xf8, 0x37, 0x75, 0xe4, 0xfd, 0xf7, 0x97, 0x2f,
-	0xc5, 0xaa, 0xa8, 0xe3, 0x21, 0x7a, 0xf4, 0x72, 0x2c, 0x52, 0xb6, 0xf2, 0x97, 0x53, 0x40, 0xd2,
-	0x87, 0x96, 0x5e, 0x3e, 0x1b, 0xd3, 0x37, 0xf3, 0x79, 0x2e, 0x8c, 0x3f, 0x9c, 0x32, 0x45, 0x8e,
-	0xa1, 0x3e, 0x3b, 0xff, 0x36, 0xc9, 0x4d, 0xb4, 0xdf, 0xaf, 0x9e, 0x1c, 0x50, 0x8f, 0x50, 0x63,
-	0xbd, 0x7c, 0x9b, 0x48, 0xae, 0xee, 0xed, 0x86, 0x0f, 0x9d, 0xc6, 0xf4, 0xda, 0x71, 0x74, 0xed,
-	0xc5, 0x29, 0xf5, 0x72, 0x30, 0xa6, 0x76, 0xd7, 0x07, 0xd4, 0x01, 0xdc, 0xa0, 0x16, 0x29, 0x5b,
-	0x9e, 0x8f, 0xa4, 0xb1, 0x8b, 0x0e, 0xe9, 0x86, 0xc0, 0xbe, 0x18, 0xd7, 0x13, 0x69, 0x84, 0xbe,
-	0x63, 0x69, 0xd4, 0x74, 0x7d, 0x95, 0x28, 0x72, 0x0a, 0x24, 0x91, 0xb9, 0x61, 0xa9, 0x7b, 0x40,
-	0xaf, 0x98, 0x7e, 0x9f, 0xc8, 0x08, 0xec, 0xc5, 0x3c, 0xe0, 0x89, 0x7f, 0x0f, 0xe0, 0xe8, 0x6b,
-	0x26, 0x79, 0x2a, 0xf0, 0x0c, 0xae, 0xb2, 0x62, 0x7b, 0xc7, 0x50, 0xe7, 0xe2, 0xee, 0xec, 0x6a,
-	0xe2, 0x15, 0xf5, 0x08, 0x79, 0x96, 0x65, 0xc8, 0x3b, 0x31, 0x3d, 0xc2, 0x6b, 0x9f, 0x63, 0xcb,
-	0x4e, 0x48, 0x6b, 0xe3, 0x84, 0xf3, 0x0b, 0xa5, 0x0b, 0xfd, 0x1c, 0xc0, 0x48, 0xbc, 0x33, 0xfb,
-	0x2e, 0xda, 0xd4, 0xda, 0x24, 0x86, 0xba, 0x59, 0xe2, 0x05, 0x5b, 0xcd, 0x5a, 0x03, 0x40, 0xcd,
-	0xdc, 0x4d, 0x53, 0xef, 0xc1, 0x18, 0xed, 0x62, 0x1a, 0xfd, 0x6a, 0x11, 0x43, 0x7d, 0x8c, 0xf3,
-	0xc4, 0xbf, 0x04, 0x40, 0x5e, 0x08, 0x83, 0xa3, 0x8c, 0xd5, 0xbd, 0xfc, 0xd8, 0x61, 0xbe, 0x84,
-	0xc3, 0x05, 0x5b, 0xfa, 0x03, 0xba, 0x4c, 0x7e, 0x14, 0x7e, 0xac, 0xbf, 0xb1, 0xeb, 0xa1, 0x6b,
-	0x9b, 0xa1, 0xe3, 0x15, 0x1c, 0x6d, 0x75, 0xe0, 0xaf, 0xb4, 0x98, 0x3a, 0x28, 0x4d, 0xdd, 0x83,
-	0xe6, 0x4c, 0xc9, 0x79, 0xa2, 0x17, 0x82, 0xdb, 0x0e, 0x42, 0xba, 0x21, 0x36, 0xea, 0x55, 0xcb,
-	0xea, 0x75, 0x21, 0x5c, 0x28, 0x6d, 0x97, 0x65, 0xcb, 0x86, 0x74, 0x8d, 0xe3, 0x63, 0x78, 0xbc,
-	0xbd, 0x4a, 0x57, 0x3b, 0xfe, 0x0e, 0xa2, 0x0d, 0x8f, 0x5d, 0x0d, 0x47, 0x2f, 0xff, 0xc3, 0x3d,
-	0xc7, 0x9f, 0xc2, 0x27, 0x0f, 0xe4, 0xf7, 0xc5, 0x7f, 0x02, 0xe2, 0x9c, 0x67, 0x5a, 0x2b, 0xfd,
-	0xb1, 0x65, 0xbf, 0x80, 0x9a, 0x59, 0x65, 0x6e, 0x0f, 0x87, 0x83, 0x03, 0x5c, 0xbd, 0xcd, 0x37,
-	0x5d, 0x65, 0x82, 0x5a, 0x17, 0xea, 0x25, 0x90, 0xf2, 0x9f, 0x27, 0x07, 0xe2, 0xff, 0x17, 0xe7,
-	0xed, 0xcb, 0xbb, 0xae, 0x9e, 0xf6, 0x20, 0x2c, 0x9e, 0x24, 0x69, 0x40, 0x95, 0x5e, 0x3f, 0xeb,
-	0xec, 0x39, 0x63, 0xd0, 0x09, 0x9e, 0x9e, 0x41, 0x73, 0x9d, 0x9d, 0xb4, 0xa0, 0xf1, 0x42, 0x48,
-	0xa1, 0x93, 0x59, 0x67, 0x8f, 0x84, 0x50, 0x7b, 0x33, 0x1d, 0x0e, 0x3b, 0x01, 0xe9, 0x40, 0x7b,
-	0x3c, 0x9c, 0x0e, 0xdf, 0x5d, 0x5d, 0xbc, 0x3b, 0x1f, 0xbd, 0x9e, 0x76, 0x2a, 0xe4, 0x7f, 0xd0,
-	0x2a, 0x98, 0x57, 0x93, 0x51, 0xa7, 0x3a, 0xf8, 0xb3, 0x02, 0x8f, 0x86, 0x59, 0x96, 0x26, 0x33,
-	0xfb, 0xe2, 0x2e, 0x85, 0xbe, 0x13, 0x9a, 0x3c, 0x87, 0x56, 0xe9, 0x33, 0x46, 0x8e, 0x71, 0x96,
-	0xdd, 0xcf, 0x67, 0xf7, 0xc9, 0x0e, 0xef, 0x05, 0xdd, 0x23, 0x23, 0x68, 0x97, 0xf7, 0x4c, 0x6c,
-	0xe8, 0x03, 0x8f, 0xb8, 0x1b, 0xed, 0x3a, 0xd6, 0x49, 0x9e, 0x43, 0xab, 0x74, 0xa7, 0xae, 0x8d,
-	0xdd, 0xa7, 0xe3, 0xda, 0x78, 0xe0, 0xa0, 0xe3, 0x3d, 0x42, 0xe1, 0xd1, 0xce, 0xda, 0x49, 0x6f,
-	0xbb, 0xe4, 0xf6, 0xb5, 0x75, 0x3f, 0xfb, 0x07, 0x6f, 0xb9, 0xab, 0xd2, 0xba, 0x5c, 0x57, 0xbb,
-	0xe7, 0xd3, 0x7d, 0xb2, 0xc3, 0x17, 0x19, 0x6e, 0xea, 0xf6, 0x3f, 0x8d, 0xaf, 0xfe, 0x0a, 0x00,
-	0x00, 0xff, 0xff, 0xc4, 0x55, 0xe3, 0x75, 0x75, 0x08, 0x00, 0x00,
+	// 2057 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x58, 0x5b, 0x6f, 0xdb, 0xd8,
+	0x11, 0x8e, 0xe4, 0x9b, 0x74, 0x24, 0xc7, 0xf2, 0xb1, 0x63, 0x73, 0x15, 0x27, 0x9b, 0xa8, 0xc0,
+	0x62, 0x11, 0x2c, 0xd2, 0xc6, 0xbb, 0xdb, 0x2e, 0x8a, 0x3e, 0x94, 0x2b, 0xd1, 0xb1, 0x12, 0x59,
+	0xd2, 0x1e, 0x51, 0xb1, 0xb3, 0x2f, 0x02, 0x43, 0x1d, 0xd9, 0x5c, 0xd3, 0xa4, 0x4a, 0xd2, 0x17,
+	0x15, 0x6d, 0xd1, 0xa7, 0xa2, 0x40, 0x9f, 0xfb, 0xd8, 0x7f, 0xd0, 0xdf, 0xd1, 0x7f, 0xd2, 0xfe,
+	0x88, 0x3e, 0x75, 0x66, 0x0e, 0x49, 0x51, 0x17, 0x07, 0xed, 0xa2, 0x4f, 0xe2, 0x7c, 0x33, 0x9c,
+	0x33, 0xb7, 0x33, 0x33, 0x14, 0x2b, 0x58, 0xe1, 0xcb, 0x71, 0xe0, 0x47, 0x3e, 0xcf, 0x5b, 0x61,
+	0xed, 0x4f, 0x39, 0x56, 0x68, 0x58, 0x91, 0x25, 0xac, 0x48, 0xf2, 0xa7, 0x8c, 0x5d, 0xf9, 0xc3,
+	0x6b, 0xd7, 0x8a, 0x1c, 0xdf, 0xd3, 0x72, 0xcf, 0x72, 0x9f, 0x17, 0x45, 0x06, 0xe1, 0x07, 0xac,
+	0xf8, 0xc1, 0xf2, 0x86, 0xa7, 0xce, 0x30, 0xba, 0xd0, 0xf2, 0xc0, 0xde, 0x14, 0x53, 0x80, 0xd7,
+	0x58, 0x39, 0x1c, 0x07, 0xd2, 0x1a, 0x1e, 0x59, 0x76, 0xe4, 0x07, 0xda, 0x0a, 0x09, 0xcc, 0x60,
+	0x5c, 0x63, 0x1b, 0x1f, 0x9c, 0x28, 0x80, 0xc3, 0xb4, 0x55, 0x62, 0x27, 0x64, 0xed, 0x1f, 0x39,
+	0xb6, 0x2e, 0xce, 0x9a, 0xde, 0xc8, 0xe7, 0x15, 0xb6, 0x72, 0x65, 0xd9, 0x74, 0x7e, 0x59, 0xe0,
+	0x23, 0xe7, 0x6c, 0x35, 0x72, 0xae, 0x24, 0x9d, 0x59, 0x14, 0xf4, 0x8c, 0x58, 0x10, 0x86, 0x0e,
+	0x1d, 0xb3, 0x26, 0xe8, 0x19, 0xd5, 0xbb, 0xbe, 0xb0, 0x7a, 0x6d, 0x41, 0xea, 0x73, 0x22, 0x21,
+	0x51, 0xda, 0xb3, 0x40, 0xc3, 0x9a, 0xd2, 0x80, 0xcf, 0xbc, 0xca, 0x0a, 0xe8, 0x58, 0x74, 0x3d,
+	0x94, 0xda, 0x3a, 0x89, 0xa7, 0x34, 0xba, 0xea, 0xfa, 0xde, 0xb9, 0x62, 0x6e, 0x10, 0x73, 0x0a,
+	0xe0, 0x9b, 0x96, 0x1b, 0xbf, 0x59, 0x50, 0x6f, 0x26, 0x74, 0xed, 0x0f, 0x6c, 0xdd, 0x54, 0x7e,
+	0x80, 0x8e, 0x51, 0x20, 0x7f, 0x73, 0x2d, 0x3d, 0x7b, 0x42, 0xde, 0xac, 0x88, 0x29, 0xc0, 0x3f,
+	0x67, 0x85, 0x61, 0x1c, 0x78, 0xf2, 0xab, 0x74, 0x58, 0x7e, 0x09, 0xa9, 0x49, 0x92, 0x21, 0x52,
+	0x2e, 0xc6, 0xc3, 0x1a, 0xaa, 0x78, 0x16, 0x04, 0x3e, 0xe2, 0xf9, 0xb6, 0x3f, 0x94, 0x22, 0x89,
+	0x63, 0x51, 0xa4, 0x74, 0xed, 0xcf, 0x39, 0xc6, 0xdf, 0xf8, 0x8e, 0x27, 0xf0, 0xa0, 0x30, 0x8a,
+	0x7f, 0x30, 0xb7, 0xe3, 0x8b, 0x49, 0xd7, 0x9a, 0xb8, 0xbe, 0x35, 0x8c, 0x63, 0x9b, 0x41, 0x30,
+	0x74, 0x43, 0x79, 0xa3, 0x0f, 0xe1, 0xa0, 0x3c, 0x31, 0x13, 0x92, 0xef, 0xb2, 0x35, 0x4f, 0x46,
+	0xcd, 0x06, 0x19, 0x50, 0x16, 0x8a, 0xc0, 0x6c, 0xdb, 0x47, 0x2d, 0x27, 0x8c, 0xea, 0x17, 0x27,
+	0x56, 0x78, 0x49, 0x66, 0x94, 0xc5, 0x0c, 0x56, 0xfb, 0x57, 0x99, 0xed, 0xcc, 0x98, 0x12, 0x8e,
+	0x7d, 0x2f, 0x94, 0xff, 0x8d, 0x2d, 0xde, 0xed, 0x65, 0xef, 0xad, 0x9c, 0x24, 0xb6, 0xc4, 0x24,
+	0x72, 0x82, 0xbb, 0x86, 0x74, 0xad, 0x49, 0x5c, 0x5e, 0x09, 0xc9, 0x9f, 0xb1, 0x52, 0x70, 0xf7,
+	0xaa, 0x21, 0x3a, 0xa3, 0x51, 0x28, 0xa3, 0xb8, 0xba, 0xb2, 0x10, 0xdf, 0x63, 0xeb, 0xca, 0x3a,
+	0x28, 0x82, 0x15, 0x60, 0xc6, 0x14, 0x26, 0x22, 0xb8, 0x3b, 0x75, 0xbc, 0xa1, 0x7f, 0x4b, 0x65,
+	0xf0, 0x50, 0x25, 0x42, 0x9c, 0x29, 0x4c, 0xa4, 0x5c, 0x8c, 0x44, 0x70, 0x77, 0xd8, 0x10, 0x54,
+	0x10, 0x9b, 0x42, 0x11, 0x18, 0x09, 0x78, 0x38, 0x4a, 0x33, 0xfd, 0x89, 0xaa, 0xfb, 0x2c, 0x86,
+	0xa5, 0x10, 0x80, 0x99, 0x77, 0x47, 0x75, 0x2f, 0xa2, 0x8a, 0x29, 0x88, 0x29, 0x80, 0xb6, 0x43,
+	0x56, 0x9b, 0x5e, 0x24, 0x83, 0x1b, 0xcb, 0xd5, 0x8a, 0xca, 0xf6, 0x0c, 0xc4, 0x5f, 0x32, 0xee,
+	0x78, 0x61, 0x64, 0xb9, 0xea, 0x26, 0x9e, 0x58, 0xc1, 0xb9, 0xe3, 0x69, 0x8c, 0x4a, 0x6f, 0x09,
+	0x87, 0xbf, 0x22, 0x8d, 0x3d, 0xba, 0x5a, 0xe7, 0x13, 0xad, 0x44, 0x6e, 0x6d, 0xa1, 0x5b, 0x7a,
+	0x43, 0x24, 0xb0, 0xc8, 0xca, 0xf0, 0xcf, 0xd8, 0xc3, 0xdb, 0xc0, 0x1a, 0x8f, 0xe5, 0x50, 0x1f,
+	0x8f, 0x29, 0xf6, 0x65, 0x8a, 0xfd, 0x1c, 0xca, 0xbf, 0x62, 0x8f, 0xe0, 0x46, 0x87, 0x60, 0x97,
+	0x6c, 0xf8, 0xb7, 0x9e, 0xeb, 0x78, 0x97, 0xdf, 0x5d, 0xcb, 0x6b, 0xa9, 0x6d, 0x92, 0x5b, 0xcb,
+	0x99, 0xfc, 0x0b, 0xb6, 0x7d, 0xe5, 0x7b, 0xd0, 0x75, 0x3c, 0xc7, 0x6e, 0xc8, 0x9b, 0xb6, 0xef,
+	0xd9, 0x52, 0x7b, 0x48, 0x6f, 0x2c, 0x32, 0xd0, 0x96, 0x73, 0xb0, 0xea, 0xd6, 0x9a, 0x08, 0x79,
+	0x0e, 0x5e, 0x85, 0xda, 0x16, 0xa4, 0xac, 0x28, 0xe6, 0x50, 0x48, 0xdd, 0xd6, 0x30, 0x3e, 0xc6,
+	0x3c, 0xeb, 0xfa, 0xb7, 0x32, 0xd0, 0x2a, 0x14, 0xbc, 0x79, 0x98, 0xbf, 0x60, 0x95, 0x04, 0xaa,
+	0x27, 0x37, 0x67, 0x9b, 0x6e, 0xce, 0x02, 0xce, 0xbf, 0x99, 0xca, 0x76, 0x7d, 0xd7, 0x0a, 0x9c,
+	0x68, 0xa2, 0xf1, 0x69, 0x61, 0x24, 0x98, 0x58, 0x90, 0xe2, 0x87, 0x6c, 0xf7, 0x83, 0x15, 0x41,
+	0xce, 0x26, 0xe6, 0x05, 0xb4, 0xd8, 0xc8, 0x95, 0x2d, 0x79, 0x23, 0x5d, 0x6d, 0x87, 0x8c, 0x5a,
+	0xca, 0xc3, 0xe4, 0xdb, 0xae, 0x15, 0x86, 0xf5, 0xa3, 0xae, 0x1f, 0x44, 0xda, 0xae, 0x4a, 0x7e,
+	0x06, 0xa2, 0xab, 0x46, 0x64, 0x5c, 0xa4, 0x8f, 0x54, 0x81, 0x65, 0x31, 0x8c, 0x2f, 0x24, 0xd2,
+	0x0b, 0xaf, 0x9c, 0xa8, 0xe1, 0xdc, 0xc8, 0x20, 0x44, 0xa3, 0xf7, 0x54, 0x7c, 0x17, 0x18, 0xe0,
+	0xe1, 0xfe, 0xd0, 0x72, 0xdc, 0x49, 0x92, 0x23, 0xdd, 0x09, 0xb0, 0xa7, 0xd6, 0xad, 0xb1, 0xa6,
+	0x91, 0xf2, 0xfb, 0xd8, 0x50, 0x88, 0x4c, 0x5d, 0x1b, 0x73, 0x32, 0x96, 0xda, 0x3e, 0x45, 0xe5,
+	0x21, 0x46, 0xa5, 0x9e, 0xa2, 0x22, 0x23, 0xc1, 0xbf, 0x86, 0xce, 0x6d, 0x9d, 0x87, 0x5a, 0x15,
+	0xf2, 0x57, 0x3a, 0x7c, 0x8e, 0x92, 0x4b, 0x3a, 0xc2, 0x4b, 0x13, 0x64, 0x0c, 0x2f, 0x0a, 0x26,
+	0x82, 0xc4, 0x69, 0x12, 0x59, 0xf6, 0x3b, 0x34, 0x17, 0x26, 0xd1, 0xe3, 0x78, 0x12, 0xa5, 0x08,
+	0x06, 0xed, 0x5c, 0xfa, 0xae, 0x6f, 0xab, 0x51, 0x75, 0x40, 0x8e, 0x66, 0x21, 0xfe, 0x73, 0xb6,
+	0x67, 0x5f, 0x58, 0x9e, 0x27, 0xdd, 0xba, 0xef, 0x8d, 0x9c, 0xf3, 0xeb, 0x80, 0x70, 0x68, 0x63,
+	0x4f, 0xa8, 0x13, 0xdf, 0xc3, 0xc5, 0x9b, 0xf6, 0x03, 0x18, 0xf8, 0x7a, 0xb6, 0xfc, 0x9e, 0x52,
+	0xf9, 0x2d, 0xe1, 0xf0, 0x77, 0x6c, 0x2b, 0x83, 0xa2, 0x1f, 0xda, 0xa7, 0xe4, 0xeb, 0x17, 0xf7,
+	0xf9, 0xfa, 0x66, 0x56, 0x5c, 0xb9, 0x3d, 0xaf, 0x04, 0x13, 0x3a, 0xf2, 0x83, 0x5b, 0x2b, 0x18,
+	0x76, 0x8f, 0xdf, 0x27, 0xad, 0xf2, 0x99, 0x4a, 0xe8, 0x02, 0x83, 0xae, 0x97, 0x75, 0xd7, 0x1f,
+	0x63, 0xb6, 0xc2, 0xae, 0x0c, 0x8e, 0xfd, 0xeb, 0x40, 0x7b, 0x4e, 0xa9, 0x5c, 0x64, 0xe0, 0xb5,
+	0x71, 0xe5, 0xb9, 0x65, 0x4f, 0xa0, 0x19, 0xe8, 0xf5, 0xb7, 0x60, 0xa0, 0x56, 0x23, 0xcd, 0xf3,
+	0x70, 0xf5, 0x17, 0xac, 0x98, 0xda, 0x88, 0x73, 0xe8, 0x52, 0x4e, 0xe2, 0xbd, 0x00, 0x1f, 0xb1,
+	0x21, 0x42, 0x77, 0xba, 0x4e, 0x06, 0xb3, 0x22, 0x7e, 0x99, 0xff, 0x26, 0x57, 0xfd, 0x96, 0xed,
+	0x2e, 0xf3, 0xf3, 0x7f, 0xd1, 0x41, 0xb7, 0x5b, 0xde, 0x38, 0xb6, 0xec, 0x06, 0xfe, 0xc8, 0x71,
+	0x25, 0xe4, 0xee, 0x27, 0x94, 0xbb, 0x79, 0xb8, 0xf6, 0xcf, 0x3c, 0xdb, 0x39, 0x86, 0x45, 0xc4,
+	0x95, 0x38, 0x3e, 0xfb, 0xe3, 0x64, 0xe8, 0x41, 0xcb, 0x07, 0x51, 0xa3, 0xdf, 0x8c, 0x87, 0x4c,
+	0x4c, 0x21, 0x0e, 0x3d, 0x0d, 0x71, 0x35, 0x5f, 0x62, 0x0a, 0xb7, 0x84, 0x11, 0x76, 0x68, 0x35,
+	0x5b, 0xe8, 0x19, 0xed, 0x1b, 0xd1, 0xcd, 0x54, 0x23, 0x45, 0x11, 0x28, 0x89, 0xf3, 0x99, 0xf6,
+	0x89, 0xb2, 0xa0, 0x67, 0xb8, 0xa7, 0xeb, 0xd1, 0x1d, 0x4e, 0x7e, 0x1a, 0x23, 0xa5, 0x43, 0x86,
+	0x15, 0xa0, 0x76, 0x01, 0x11, 0x73, 0x50, 0x26, 0x50, 0x32, 0x1b, 0x54, 0x25, 0x4c, 0x8d, 0x1a,
+	0x25, 0xa3, 0x38, 0x38, 0x2c, 0x86, 0xd2, 0x0e, 0x26, 0xe3, 0x48, 0x0e, 0x93, 0x61, 0x91, 0x02,
+	0x71, 0xaa, 0xe3, 0xc4, 0xf7, 0x9c, 0xdf, 0x4a, 0x71, 0xf6, 0x2a, 0x1e, 0x19, 0x8b, 0x8c, 0x65,
+	0xd2, 0x87, 0x34, 0x37, 0x96, 0x48, 0x1f, 0xce, 0x0d, 0xe6, 0xd2, 0xfc, 0x60, 0xae, 0xfd, 0x11,
+	0x76, 0x8b, 0xd7, 0x32, 0xc2, 0x20, 0x63, 0x6f, 0xf8, 0xb1, 0x61, 0x86, 0xf6, 0x3e, 0x7b, 0x76,
+	0x1c, 0xf0, 0x39, 0x34, 0x4d, 0xc7, 0xea, 0x34, 0x1d, 0xb5, 0xbf, 0xe6, 0xd8, 0xce, 0x8c, 0x09,
+	0xf1, 0x4e, 0x91, 0x24, 0x24, 0x97, 0x49, 0x08, 0x04, 0xd2, 0xc6, 0xeb, 0x1d, 0x5c, 0x41, 0x20,
+	0xf3, 0x2a, 0x90, 0x29, 0x30, 0x4d, 0xec, 0x4a, 0x36, 0xb1, 0xb0, 0x5a, 0x5d, 0xf9, 0x01, 0xd5,
+	0x11, 0x9d, 0x5b, 0x10, 0x29, 0x4d, 0x6b, 0x17, 0xf4, 0x79, 0xc7, 0x86, 0x21, 0xbd, 0xa6, 0x78,
+	0x09, 0x5d, 0xdb, 0x63, 0xbb, 0xb3, 0x15, 0xa8, 0xec, 0xaa, 0xfd, 0x8e, 0x69, 0x53, 0x1c, 0x2d,
+	0x56, 0x37, 0xeb, 0xff, 0x56, 0x9e, 0xb4, 0x59, 0x8c, 0x64, 0x20, 0x71, 0xa0, 0xaa, 0x5d, 0x70,
+	0x0a, 0xd4, 0x1e, 0xb3, 0x4f, 0x96, 0x9c, 0x1e, 0x9b, 0xf6, 0x7b, 0xc6, 0x15, 0xd3, 0x08, 0x02,
+	0x3f, 0xf8, 0xb1, 0x46, 0x3d, 0x87, 0x0e, 0x8f, 0xb3, 0x60, 0x85, 0x66, 0xc1, 0x26, 0xd6, 0x33,
+	0xe9, 0xa3, 0x51, 0x40, 0x2c, 0x8c, 0xb4, 0x44, 0x28, 0xb6, 0x4f, 0x11, 0xb5, 0x47, 0xc9, 0x9d,
+	0x8d, 0x8f, 0x8f, 0xad, 0xfa, 0xcb, 0x4a, 0x62, 0x73, 0xdc, 0x3c, 0x7a, 0x91, 0x15, 0x85, 0x89,
+	0x75, 0x4b, 0xbf, 0x0d, 0x68, 0xb3, 0xcf, 0x67, 0x36, 0x7b, 0x08, 0x0a, 0x0e, 0x2c, 0x58, 0x8b,
+	0xae, 0xc6, 0x64, 0x18, 0x04, 0x25, 0x05, 0x30, 0x8d, 0x4e, 0xb2, 0x6b, 0xc5, 0xdb, 0x73, 0x42,
+	0xe3, 0x7d, 0x09, 0xa0, 0x06, 0xed, 0x4b, 0x89, 0x67, 0xda, 0x12, 0x46, 0xe6, 0x90, 0x72, 0xbd,
+	0x26, 0x16, 0x19, 0xfc, 0x67, 0x6c, 0x67, 0x01, 0xec, 0xbc, 0xa5, 0xeb, 0xbf, 0x26, 0x96, 0xb1,
+	0x68, 0x4e, 0x2f, 0xe8, 0xdf, 0x50, 0xfa, 0x17, 0x18, 0xb8, 0xb5, 0xa4, 0xa0, 0x01, 0x13, 0x3c,
+	0x69, 0x08, 0x6b, 0x62, 0x01, 0x9f, 0xf9, 0x9a, 0x29, 0x7e, 0xec, 0x6b, 0x86, 0x7d, 0xec, 0x6b,
+	0xa6, 0x34, 0xf7, 0x35, 0x73, 0xc0, 0xaa, 0xcb, 0x92, 0x11, 0xe7, 0xea, 0xef, 0x79, 0xa6, 0xf5,
+	0xe0, 0x32, 0x52, 0x3b, 0x6e, 0xc5, 0xa3, 0x37, 0x53, 0x48, 0x71, 0xc1, 0xe4, 0x66, 0x0a, 0x66,
+	0x5a, 0x60, 0xf9, 0x99, 0x02, 0x5b, 0x56, 0xdd, 0x59, 0xa7, 0x56, 0x3f, 0xe6, 0xd4, 0xda, 0xc7,
+	0x9c, 0x5a, 0x9f, 0x75, 0x8a, 0x78, 0xb6, 0x0d, 0x33, 0x1f, 0xb6, 0xf5, 0x8d, 0x98, 0x17, 0xd3,
+	0xd8, 0x02, 0x47, 0x01, 0xd4, 0x50, 0xdd, 0xbf, 0x8e, 0x57, 0xf5, 0x4d, 0x91, 0x41, 0x70, 0x19,
+	0x8b, 0x97, 0x50, 0x25, 0xa1, 0x3a, 0xef, 0x0c, 0x86, 0x1e, 0x86, 0x30, 0x67, 0x6d, 0x15, 0xeb,
+	0xa2, 0x88, 0x29, 0xbc, 0x8d, 0x4b, 0xa2, 0xa5, 0x62, 0xf9, 0xe2, 0x80, 0x15, 0x92, 0x4f, 0x0e,
+	0xbe, 0xc1, 0x56, 0xa0, 0x79, 0x57, 0x1e, 0xa8, 0x87, 0xc3, 0x4a, 0xee, 0xc5, 0xaf, 0x58, 0x29,
+	0xb3, 0xb9, 0xc3, 0x09, 0xfc, 0x44, 0x3f, 0x6b, 0x9e, 0x34, 0xbf, 0x37, 0x06, 0x0d, 0xdd, 0xd4,
+	0x07, 0x42, 0x37, 0x0d, 0x90, 0x7f, 0xc4, 0xb6, 0x4f, 0x9a, 0x6d, 0x85, 0x9b, 0x67, 0x83, 0x6e,
+	0xe7, 0xd4, 0x10, 0xf0, 0x76, 0x8b, 0x15, 0xd2, 0x1d, 0x75, 0x97, 0x55, 0x9a, 0xed, 0x63, 0x43,
+	0x34, 0x4d, 0x60, 0xb7, 0x74, 0xf8, 0x7d, 0x0f, 0x2f, 0xee, 0xb0, 0xad, 0x76, 0x47, 0x9c, 0xe8,
+	0xad, 0x29, 0x98, 0x43, 0x6d, 0xcd, 0xf6, 0x3b, 0x43, 0x98, 0x46, 0x63, 0x0a, 0xe7, 0x5f, 0xfc,
+	0x94, 0xb1, 0xe9, 0xb6, 0xc7, 0xb7, 0x58, 0xe9, 0x48, 0x18, 0xdf, 0xf5, 0x8d, 0x76, 0xbd, 0x69,
+	0xf4, 0x40, 0x55, 0x85, 0x95, 0xeb, 0xc7, 0x7a, 0xbb, 0x6d, 0xb4, 0x06, 0x27, 0x7a, 0xef, 0x2d,
+	0x1c, 0xff, 0xef, 0x3c, 0x2b, 0xa6, 0x3d, 0x81, 0x97, 0xd8, 0xc6, 0x6b, 0xe9, 0xc9, 0xc0, 0xb1,
+	0x41, 0xb8, 0xc0, 0x56, 0x3b, 0xa6, 0xae, 0xc3, 0x61, 0xf0, 0x1a, 0x79, 0xd2, 0xef, 0x0e, 0x8e,
+	0xea, 0x6d, 0xb3, 0x92, 0x47, 0xcd, 0x09, 0x72, 0xd2, 0xac, 0x57, 0x56, 0x60, 0xeb, 0x3b, 0x20,
+	0xa0, 0xd1, 0x39, 0x6d, 0x0f, 0xe0, 0xc4, 0xbe, 0x31, 0x68, 0x9a, 0xc6, 0xc9, 0xc0, 0x38, 0xeb,
+	0x36, 0x85, 0xd1, 0xa8, 0xac, 0xf2, 0x4f, 0xd9, 0xe3, 0xa9, 0xc4, 0xb7, 0xba, 0x69, 0x1a, 0xe2,
+	0xfd, 0xc0, 0x3c, 0x16, 0x1d, 0xd3, 0x6c, 0x81, 0xc0, 0x1a, 0xa4, 0xb7, 0x8a, 0xe7, 0x0d, 0xc0,
+	0x2f, 0xbd, 0xd5, 0x6c, 0x0c, 0xde, 0x74, 0x9a, 0xed, 0x81, 0x30, 0x7a, 0xdd, 0x4e, 0xbb, 0x67,
+	0x54, 0xd6, 0xa1, 0x9b, 0x3d, 0x21, 0x3e, 0xe1, 0x7a, 0xbd, 0x6e, 0x74, 0xcd, 0x41, 0xbb, 0x63,
+	0x82, 0x48, 0xdd, 0x68, 0xbe, 0x03, 0x15, 0x1b, 0xf7, 0x5a, 0x01, 0x1f, 0x9a, 0xdd, 0x2e, 0x48,
+	0x14, 0xf8, 0x63, 0xb6, 0x3f, 0x95, 0x40, 0x67, 0x06, 0xa2, 0xd3, 0x6a, 0x75, 0x20, 0x92, 0x95,
+	0x22, 0x5a, 0x30, 0x65, 0xa2, 0x6a, 0x68, 0xc9, 0xed, 0xce, 0x29, 0x98, 0xf7, 0x1a, 0x5e, 0x66,
+	0x98, 0x1f, 0xb2, 0xa0, 0xd3, 0x37, 0x07, 0x9d, 0xa3, 0x81, 0x2e, 0x0c, 0xbd, 0x52, 0x42, 0x95,
+	0x84, 0xf6, 0xdb, 0xbd, 0x7e, 0xb7, 0xdb, 0xa1, 0x94, 0x40, 0x12, 0x9a, 0x3d, 0xb3, 0x52, 0x4e,
+	0x99, 0x59, 0xa3, 0x85, 0xd1, 0x6d, 0xe9, 0xef, 0x2b, 0x9b, 0x87, 0x7f, 0x5b, 0x65, 0xdb, 0xf0,
+	0xed, 0xe6, 0x3a, 0xaa, 0xde, 0x7a, 0xf8, 0x6d, 0x16, 0xf0, 0x5f, 0xb3, 0x52, 0x66, 0x37, 0xe5,
+	0x7b, 0x0b, 0xcb, 0x2a, 0xfd, 0x54, 0xf7, 0xef, 0x59, 0x62, 0x6b, 0x0f, 0x78, 0x9d, 0x95, 0xb3,
+	0x03, 0x8f, 0x93, 0xe8, 0x92, 0x25, 0xac, 0xaa, 0x2d, 0x32, 0x52, 0x25, 0x60, 0x46, 0x66, 0x98,
+	0x2b, 0x33, 0x16, 0x17, 0x0c, 0x65, 0xc6, 0x92, 0xa9, 0x0f, 0x1a, 0x04, 0xdb, 0x5e, 0x98, 0x70,
+	0xfc, 0x60, 0xf6, 0xc8, 0xd9, 0xb1, 0x5b, 0x7d, 0x72, 0x0f, 0x37, 0x6b, 0x55, 0x66, 0x32, 0x29,
+	0xab, 0x16, 0x27, 0x65, 0x75, 0x7f, 0x01, 0x4f, 0x35, 0xf4, 0x93, 0xd1, 0x9a, 0x6d, 0x9b, 0x3c,
+	0x73, 0xf0, 0x92, 0xd9, 0x56, 0x7d, 0x7a, 0x1f, 0x3b, 0xeb, 0xec, 0x42, 0x03, 0x51, 0xce, 0xde,
+	0xd7, 0x85, 0x95, 0xb3, 0xf7, 0x76, 0x9d, 0xda, 0x83, 0x0f, 0xeb, 0xf4, 0x67, 0xe0, 0x97, 0xff,
+	0x01, 0x0b, 0xd8, 0x3f, 0xe1, 0x18, 0x14, 0x00, 0x00,
 }
@@ -0,0 +1,3 @@
+//go:generate protoc -I . --go_out=plugins=grpc:. geo.proto
+
+package geo
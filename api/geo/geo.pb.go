@@ -0,0 +1,314 @@
+// Code generated by protoc-gen-go.
+// source: geo.proto
+// DO NOT EDIT!
+
+/*
+Package geo is a generated protocol buffer package.
+
+It is generated from these files:
+	geo.proto
+
+It has these top-level messages:
+	ResolveTDOARequest
+	FrameRXInfo
+	RXInfo
+	ResolveTDOAResponse
+*/
+package geo
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ResolveTDOARequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The receptions of the buffered uplinks of the node.
+	FrameRXInfo []*FrameRXInfo `protobuf:"bytes,2,rep,name=frameRXInfo" json:"frameRXInfo,omitempty"`
+}
+
+func (m *ResolveTDOARequest) Reset()                    { *m = ResolveTDOARequest{} }
+func (m *ResolveTDOARequest) String() string            { return proto.CompactTextString(m) }
+func (*ResolveTDOARequest) ProtoMessage()               {}
+func (*ResolveTDOARequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ResolveTDOARequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ResolveTDOARequest) GetFrameRXInfo() []*FrameRXInfo {
+	if m != nil {
+		return m.FrameRXInfo
+	}
+	return nil
+}
+
+type FrameRXInfo struct {
+	// Frame-counter of the uplink.
+	FCnt uint32 `protobuf:"varint,1,opt,name=fCnt" json:"fCnt,omitempty"`
+	// The receptions of the uplink by the gateways.
+	RxInfo []*RXInfo `protobuf:"bytes,2,rep,name=rxInfo" json:"rxInfo,omitempty"`
+}
+
+func (m *FrameRXInfo) Reset()                    { *m = FrameRXInfo{} }
+func (m *FrameRXInfo) String() string            { return proto.CompactTextString(m) }
+func (*FrameRXInfo) ProtoMessage()               {}
+func (*FrameRXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *FrameRXInfo) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *FrameRXInfo) GetRxInfo() []*RXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+type RXInfo struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Latitude of the gateway.
+	Latitude float64 `protobuf:"fixed64,2,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the gateway.
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the gateway.
+	Altitude float64 `protobuf:"fixed64,4,opt,name=altitude" json:"altitude,omitempty"`
+	// Fine-timestamp of the reception (nanoseconds since the Unix epoch).
+	FineTimestamp int64 `protobuf:"varint,5,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// RSSI of the reception.
+	Rssi int32 `protobuf:"varint,6,opt,name=rssi" json:"rssi,omitempty"`
+	// LoRa SNR of the reception.
+	LoRaSNR float64 `protobuf:"fixed64,7,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+}
+
+func (m *RXInfo) Reset()                    { *m = RXInfo{} }
+func (m *RXInfo) String() string            { return proto.CompactTextString(m) }
+func (*RXInfo) ProtoMessage()               {}
+func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *RXInfo) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *RXInfo) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *RXInfo) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFineTimestamp() int64 {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return 0
+}
+
+func (m *RXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *RXInfo) GetLoRaSNR() float64 {
+	if m != nil {
+		return m.LoRaSNR
+	}
+	return 0
+}
+
+type ResolveTDOAResponse struct {
+	// Latitude of the node.
+	Latitude float64 `protobuf:"fixed64,1,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the node.
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the node.
+	Altitude float64 `protobuf:"fixed64,3,opt,name=altitude" json:"altitude,omitempty"`
+	// Estimated accuracy (radius in meters) of the location.
+	Accuracy float64 `protobuf:"fixed64,4,opt,name=accuracy" json:"accuracy,omitempty"`
+}
+
+func (m *ResolveTDOAResponse) Reset()                    { *m = ResolveTDOAResponse{} }
+func (m *ResolveTDOAResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResolveTDOAResponse) ProtoMessage()               {}
+func (*ResolveTDOAResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ResolveTDOAResponse) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *ResolveTDOAResponse) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *ResolveTDOAResponse) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *ResolveTDOAResponse) GetAccuracy() float64 {
+	if m != nil {
+		return m.Accuracy
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ResolveTDOARequest)(nil), "geo.ResolveTDOARequest")
+	proto.RegisterType((*FrameRXInfo)(nil), "geo.FrameRXInfo")
+	proto.RegisterType((*RXInfo)(nil), "geo.RXInfo")
+	proto.RegisterType((*ResolveTDOAResponse)(nil), "geo.ResolveTDOAResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for GeolocationServer service
+
+type GeolocationServerClient interface {
+	// ResolveTDOA resolves the location of a node from the fine-timestamps
+	// (time difference of arrival) of the receiving gateways.
+	ResolveTDOA(ctx context.Context, in *ResolveTDOARequest, opts ...grpc.CallOption) (*ResolveTDOAResponse, error)
+}
+
+type geolocationServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGeolocationServerClient(cc *grpc.ClientConn) GeolocationServerClient {
+	return &geolocationServerClient{cc}
+}
+
+func (c *geolocationServerClient) ResolveTDOA(ctx context.Context, in *ResolveTDOARequest, opts ...grpc.CallOption) (*ResolveTDOAResponse, error) {
+	out := new(ResolveTDOAResponse)
+	err := grpc.Invoke(ctx, "/geo.GeolocationServer/ResolveTDOA", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for GeolocationServer service
+
+type GeolocationServerServer interface {
+	// ResolveTDOA resolves the location of a node from the fine-timestamps
+	// (time difference of arrival) of the receiving gateways.
+	ResolveTDOA(context.Context, *ResolveTDOARequest) (*ResolveTDOAResponse, error)
+}
+
+func RegisterGeolocationServerServer(s *grpc.Server, srv GeolocationServerServer) {
+	s.RegisterService(&_GeolocationServer_serviceDesc, srv)
+}
+
+func _GeolocationServer_ResolveTDOA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveTDOARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeolocationServerServer).ResolveTDOA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geo.GeolocationServer/ResolveTDOA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeolocationServerServer).ResolveTDOA(ctx, req.(*ResolveTDOARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GeolocationServer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "geo.GeolocationServer",
+	HandlerType: (*GeolocationServerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ResolveTDOA",
+			Handler:    _GeolocationServer_ResolveTDOA_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "geo.proto",
+}
+
+func init() { proto.RegisterFile("geo.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 336 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x4f, 0x4f, 0xf2, 0x40,
+	0x10, 0xc6, 0xdf, 0xa5, 0x50, 0x5e, 0xa6, 0x92, 0xe0, 0x9a, 0xe8, 0x86, 0x78, 0x68, 0xaa, 0x87,
+	0x9e, 0x38, 0xe0, 0x27, 0xf0, 0x1f, 0x86, 0x8b, 0x26, 0x0b, 0x46, 0x8f, 0xae, 0x65, 0x4a, 0x9a,
+	0xb4, 0x5d, 0xec, 0x2e, 0x44, 0x3f, 0x81, 0x5f, 0xcc, 0x0f, 0x66, 0xba, 0x5d, 0xa0, 0x48, 0xc2,
+	0x6d, 0x9e, 0x79, 0x3a, 0x4f, 0xe6, 0xd7, 0x1d, 0xe8, 0xcc, 0x51, 0x0e, 0x16, 0x85, 0xd4, 0x92,
+	0x3a, 0x73, 0x94, 0xc1, 0x1b, 0x50, 0x8e, 0x4a, 0xa6, 0x2b, 0x9c, 0xde, 0x3d, 0x5d, 0x73, 0xfc,
+	0x58, 0xa2, 0xd2, 0xf4, 0x14, 0xdc, 0x19, 0xae, 0xee, 0x9f, 0xc7, 0x8c, 0xf8, 0x24, 0x3c, 0xe2,
+	0x56, 0xd1, 0x21, 0x78, 0x71, 0x21, 0x32, 0xe4, 0xaf, 0xe3, 0x3c, 0x96, 0xac, 0xe1, 0x3b, 0xa1,
+	0x37, 0xec, 0x0d, 0xca, 0xcc, 0xd1, 0xb6, 0xcf, 0xeb, 0x1f, 0x05, 0x23, 0xf0, 0x6a, 0x1e, 0xa5,
+	0xd0, 0x8c, 0x6f, 0x73, 0x6d, 0x82, 0xbb, 0xdc, 0xd4, 0xf4, 0x02, 0xdc, 0xe2, 0xb3, 0x96, 0xe8,
+	0x99, 0x44, 0x1b, 0x66, 0xad, 0xe0, 0x87, 0x80, 0x6b, 0x33, 0x7a, 0xe0, 0x64, 0x22, 0xb2, 0xbb,
+	0x95, 0x25, 0xed, 0xc3, 0xff, 0x54, 0xe8, 0x44, 0x2f, 0x67, 0xc8, 0x1a, 0x3e, 0x09, 0x09, 0xdf,
+	0x68, 0x7a, 0x0e, 0x9d, 0x54, 0xe6, 0xf3, 0xca, 0x74, 0x8c, 0xb9, 0x6d, 0x94, 0x93, 0x22, 0xb5,
+	0x93, 0xcd, 0x6a, 0x72, 0xad, 0xe9, 0x25, 0x74, 0xe3, 0x24, 0xc7, 0x69, 0x92, 0xa1, 0xd2, 0x22,
+	0x5b, 0xb0, 0x96, 0x4f, 0x42, 0x87, 0xef, 0x36, 0x4b, 0xa2, 0x42, 0xa9, 0x84, 0xb9, 0x3e, 0x09,
+	0x5b, 0xdc, 0xd4, 0x94, 0x41, 0x3b, 0x95, 0x5c, 0x4c, 0x1e, 0x39, 0x6b, 0x9b, 0xd0, 0xb5, 0x0c,
+	0xbe, 0x09, 0x9c, 0xec, 0xfc, 0x71, 0xb5, 0x90, 0xb9, 0xc2, 0x1d, 0x02, 0x72, 0x88, 0xa0, 0x71,
+	0x88, 0xc0, 0xf9, 0x43, 0x50, 0x7a, 0x51, 0xb4, 0x2c, 0x44, 0xf4, 0xb5, 0xa1, 0xb3, 0x7a, 0xf8,
+	0x02, 0xc7, 0x0f, 0x28, 0x53, 0x19, 0x09, 0x9d, 0xc8, 0x7c, 0x82, 0xc5, 0x0a, 0x0b, 0x7a, 0x03,
+	0x5e, 0x6d, 0x3b, 0x7a, 0x56, 0xbd, 0xc4, 0xde, 0x85, 0xf4, 0xd9, 0xbe, 0x51, 0x81, 0x04, 0xff,
+	0xde, 0x5d, 0x73, 0x5f, 0x57, 0xbf, 0x03, 0x00, 0x8b, 0x35, 0xc3, 0x06, 0x6c, 0x02, 0x00, 0x00,
+}
@@ -11,6 +11,15 @@ import (
 type RXPacket struct {
 	RXInfo     RXInfo             `json:"rxInfo"`
 	PHYPayload lorawan.PHYPayload `json:"phyPayload"`
+
+	// Signature contains the HMAC-SHA256 signature of the packet, set by
+	// gateways (or bridges) having a signing key. It is computed over the
+	// rxInfo JSON object (as sent) followed by the PHYPayload bytes.
+	Signature []byte `json:"signature,omitempty"`
+
+	// SignedData contains the data covered by the signature. It is set by
+	// the backend when decoding a signed packet.
+	SignedData []byte `json:"-"`
 }
 
 // RXPacketBytes contains the PHYPayload as []byte received from the gateway.
@@ -28,17 +37,27 @@ type RXInfo struct {
 	Frequency int           `json:"frequency"`      // frequency in Hz
 	Channel   int           `json:"channel"`        // concentrator IF channel used for RX
 	RFChain   int           `json:"rfChain"`        // RF chain used for RX
+	Antenna   int           `json:"antenna"`        // antenna used for RX (gateways with multiple antennas)
 	CRCStatus int           `json:"crcStatus"`      // 1 = OK, -1 = fail, 0 = no CRC
 	CodeRate  string        `json:"codeRate"`       // ECC code rate
 	RSSI      int           `json:"rssi"`           // RSSI in dBm
 	LoRaSNR   float64       `json:"loRaSNR"`        // LoRa signal-to-noise ratio in dB
 	Size      int           `json:"size"`           // packet payload size
 	DataRate  band.DataRate `json:"dataRate"`       // RX datarate (either LoRa or FSK)
+
+	// FrequencyOffset contains the frequency error (Hz) of the received
+	// frame, reported by gateways supporting this.
+	FrequencyOffset int `json:"frequencyOffset,omitempty"`
+
+	// FineTimestamp contains the (GPS synchronized) fine receive time of
+	// gateways supporting this. It is used for geolocation (TDOA).
+	FineTimestamp *time.Time `json:"fineTimestamp,omitempty"`
 }
 
 // TXPacket contains the PHYPayload which should be send to the
 // gateway.
 type TXPacket struct {
+	Token      uint16             `json:"token"` // token identifying the packet, echoed by the gateway in the TXAck
 	TXInfo     TXInfo             `json:"txInfo"`
 	PHYPayload lorawan.PHYPayload `json:"phyPayload"`
 }
@@ -46,10 +65,19 @@ type TXPacket struct {
 // TXPacketBytes contains the PHYPayload as []byte which should be send to the
 // gateway. The JSON output is compatible with TXPacket.
 type TXPacketBytes struct {
+	Token      uint16 `json:"token"`
 	TXInfo     TXInfo `json:"txInfo"`
 	PHYPayload []byte `json:"phyPayload"`
 }
 
+// TXAck contains the acknowledgement of a TXPacket, sent by the gateway
+// when the packet has been scheduled for transmission or rejected.
+type TXAck struct {
+	MAC   lorawan.EUI64 `json:"mac"`             // MAC address of the gateway
+	Token uint16        `json:"token"`           // token of the acknowledged TXPacket
+	Error string        `json:"error,omitempty"` // reason of the rejection (empty when scheduled)
+}
+
 // TXInfo contains the information used for TX.
 type TXInfo struct {
 	MAC         lorawan.EUI64 `json:"mac"`         // MAC address of the gateway
@@ -74,4 +102,24 @@ type GatewayStatsPacket struct {
 	TXPacketsReceived   int                    `json:"txPacketsReceived"`
 	TXPacketsEmitted    int                    `json:"txPacketsEmitted"`
 	CustomData          map[string]interface{} `json:"customData"` // custom fields defined by alternative packet_forwarder versions (e.g. TTN sends platform, contactEmail, and description)
+
+	// OnboardingToken contains the one-time token (set in the gateway-bridge
+	// configuration) with which a gateway registers itself.
+	OnboardingToken string `json:"onboardingToken,omitempty"`
+}
+
+// Gateway event types reported by the gateway-bridge.
+const (
+	EventGPSLost             = "GPS_LOST"             // the GPS / PPS signal of the gateway was lost
+	EventConcentratorRestart = "CONCENTRATOR_RESTART" // the concentrator of the gateway was restarted
+	EventJITQueueFull        = "JIT_QUEUE_FULL"       // a TXPacket was rejected because the just-in-time queue was full
+)
+
+// GatewayEvent contains an error or event reported by the gateway (e.g.
+// the GPS signal was lost).
+type GatewayEvent struct {
+	MAC         lorawan.EUI64 `json:"mac"`                   // MAC address of the gateway
+	Time        time.Time     `json:"time,omitempty"`        // time of the event (set on receive when missing)
+	Type        string        `json:"type"`                  // type of the event (see the Event... constants)
+	Description string        `json:"description,omitempty"` // human readable description of the event
 }
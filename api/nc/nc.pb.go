@@ -18,6 +18,20 @@ It has these top-level messages:
 	HandleDataUpMACCommandResponse
 	HandleErrorRequest
 	HandleErrorResponse
+	HandleGatewayStatusRequest
+	HandleGatewayStatusResponse
+	HandleGatewayEventRequest
+	HandleGatewayEventResponse
+	HandleRXInfoBatchRequest
+	HandleRXInfoBatchResponse
+	HandleDataUpMACCommandBatchRequest
+	HandleDataUpMACCommandBatchResponse
+	HandleErrorBatchRequest
+	HandleErrorBatchResponse
+	HandleSLAAlertRequest
+	HandleSLAAlertResponse
+	HandleFirstJoinRequest
+	HandleFirstJoinResponse
 */
 package nc
 
@@ -41,6 +55,31 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type GatewayStatus int32
+
+const (
+	// The gateway is connected (stats were received after the gateway was
+	// unknown or timed out).
+	GatewayStatus_CONNECTED GatewayStatus = 0
+	// No stats were received from the gateway within the configured
+	// stats timeout (the gateway is considered to be disconnected).
+	GatewayStatus_STATS_TIMEOUT GatewayStatus = 1
+)
+
+var GatewayStatus_name = map[int32]string{
+	0: "CONNECTED",
+	1: "STATS_TIMEOUT",
+}
+var GatewayStatus_value = map[string]int32{
+	"CONNECTED":     0,
+	"STATS_TIMEOUT": 1,
+}
+
+func (x GatewayStatus) String() string {
+	return proto.EnumName(GatewayStatus_name, int32(x))
+}
+func (GatewayStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type DataRate struct {
 	Modulation   string `protobuf:"bytes,1,opt,name=modulation" json:"modulation,omitempty"`
 	BandWidth    uint32 `protobuf:"varint,2,opt,name=bandWidth" json:"bandWidth,omitempty"`
@@ -297,6 +336,341 @@ func (m *HandleErrorResponse) String() string            { return proto.CompactT
 func (*HandleErrorResponse) ProtoMessage()               {}
 func (*HandleErrorResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
+type HandleGatewayStatusRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Status of the gateway.
+	Status GatewayStatus `protobuf:"varint,2,opt,name=status,enum=nc.GatewayStatus" json:"status,omitempty"`
+	// Timestamp (RFC3339) when the gateway was last seen.
+	LastSeenAt string `protobuf:"bytes,3,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+}
+
+func (m *HandleGatewayStatusRequest) Reset()                    { *m = HandleGatewayStatusRequest{} }
+func (m *HandleGatewayStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayStatusRequest) ProtoMessage()               {}
+func (*HandleGatewayStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *HandleGatewayStatusRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *HandleGatewayStatusRequest) GetStatus() GatewayStatus {
+	if m != nil {
+		return m.Status
+	}
+	return GatewayStatus_CONNECTED
+}
+
+func (m *HandleGatewayStatusRequest) GetLastSeenAt() string {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return ""
+}
+
+type HandleGatewayStatusResponse struct {
+}
+
+func (m *HandleGatewayStatusResponse) Reset()                    { *m = HandleGatewayStatusResponse{} }
+func (m *HandleGatewayStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayStatusResponse) ProtoMessage()               {}
+func (*HandleGatewayStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type HandleGatewayEventRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Timestamp (RFC3339) of the event.
+	Time string `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// Type of the event (e.g. GPS_LOST, CONCENTRATOR_RESTART or
+	// JIT_QUEUE_FULL).
+	Type string `protobuf:"bytes,3,opt,name=type" json:"type,omitempty"`
+	// Description of the event.
+	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *HandleGatewayEventRequest) Reset()                    { *m = HandleGatewayEventRequest{} }
+func (m *HandleGatewayEventRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayEventRequest) ProtoMessage()               {}
+func (*HandleGatewayEventRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *HandleGatewayEventRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *HandleGatewayEventRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *HandleGatewayEventRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *HandleGatewayEventRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type HandleGatewayEventResponse struct {
+}
+
+func (m *HandleGatewayEventResponse) Reset()                    { *m = HandleGatewayEventResponse{} }
+func (m *HandleGatewayEventResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleGatewayEventResponse) ProtoMessage()               {}
+func (*HandleGatewayEventResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+type HandleRXInfoBatchRequest struct {
+	// The rx meta-data (in the order as received).
+	Items []*HandleRXInfoRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *HandleRXInfoBatchRequest) Reset()                    { *m = HandleRXInfoBatchRequest{} }
+func (m *HandleRXInfoBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleRXInfoBatchRequest) ProtoMessage()               {}
+func (*HandleRXInfoBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *HandleRXInfoBatchRequest) GetItems() []*HandleRXInfoRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type HandleRXInfoBatchResponse struct {
+}
+
+func (m *HandleRXInfoBatchResponse) Reset()                    { *m = HandleRXInfoBatchResponse{} }
+func (m *HandleRXInfoBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleRXInfoBatchResponse) ProtoMessage()               {}
+func (*HandleRXInfoBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+type HandleDataUpMACCommandBatchRequest struct {
+	// The mac-commands (in the order as received).
+	Items []*HandleDataUpMACCommandRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *HandleDataUpMACCommandBatchRequest) Reset()         { *m = HandleDataUpMACCommandBatchRequest{} }
+func (m *HandleDataUpMACCommandBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleDataUpMACCommandBatchRequest) ProtoMessage()    {}
+func (*HandleDataUpMACCommandBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{15}
+}
+
+func (m *HandleDataUpMACCommandBatchRequest) GetItems() []*HandleDataUpMACCommandRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type HandleDataUpMACCommandBatchResponse struct {
+}
+
+func (m *HandleDataUpMACCommandBatchResponse) Reset()         { *m = HandleDataUpMACCommandBatchResponse{} }
+func (m *HandleDataUpMACCommandBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*HandleDataUpMACCommandBatchResponse) ProtoMessage()    {}
+func (*HandleDataUpMACCommandBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{16}
+}
+
+type HandleErrorBatchRequest struct {
+	// The error messages (in the order as they occurred).
+	Items []*HandleErrorRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *HandleErrorBatchRequest) Reset()                    { *m = HandleErrorBatchRequest{} }
+func (m *HandleErrorBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleErrorBatchRequest) ProtoMessage()               {}
+func (*HandleErrorBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *HandleErrorBatchRequest) GetItems() []*HandleErrorRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type HandleErrorBatchResponse struct {
+}
+
+func (m *HandleErrorBatchResponse) Reset()                    { *m = HandleErrorBatchResponse{} }
+func (m *HandleErrorBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleErrorBatchResponse) ProtoMessage()               {}
+func (*HandleErrorBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type HandleSLAAlertRequest struct {
+	// Timestamp (RFC3339) of the alert.
+	Time string `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// The percentage dropped below the threshold again.
+	Resolved bool `protobuf:"varint,2,opt,name=resolved" json:"resolved,omitempty"`
+	// Window (in seconds) over which the percentage is calculated.
+	Window uint32 `protobuf:"varint,3,opt,name=window" json:"window,omitempty"`
+	// Configured threshold (percentage).
+	Threshold float64 `protobuf:"fixed64,4,opt,name=threshold" json:"threshold,omitempty"`
+	// Number of uplinks with a downlink within the window.
+	UplinkCount uint32 `protobuf:"varint,5,opt,name=uplinkCount" json:"uplinkCount,omitempty"`
+	// Number of these uplinks of which the downlink was published after
+	// the RX1 deadline (or dropped because of the deadline).
+	LateCount uint32 `protobuf:"varint,6,opt,name=lateCount" json:"lateCount,omitempty"`
+	// Percentage of late downlinks.
+	LatePercentage float64 `protobuf:"fixed64,7,opt,name=latePercentage" json:"latePercentage,omitempty"`
+	// Number of late downlinks per cause (the stage of the uplink handling
+	// which took the most time).
+	Causes map[string]uint32 `protobuf:"bytes,8,rep,name=causes" json:"causes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *HandleSLAAlertRequest) Reset()                    { *m = HandleSLAAlertRequest{} }
+func (m *HandleSLAAlertRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleSLAAlertRequest) ProtoMessage()               {}
+func (*HandleSLAAlertRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *HandleSLAAlertRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *HandleSLAAlertRequest) GetResolved() bool {
+	if m != nil {
+		return m.Resolved
+	}
+	return false
+}
+
+func (m *HandleSLAAlertRequest) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *HandleSLAAlertRequest) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *HandleSLAAlertRequest) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+func (m *HandleSLAAlertRequest) GetLateCount() uint32 {
+	if m != nil {
+		return m.LateCount
+	}
+	return 0
+}
+
+func (m *HandleSLAAlertRequest) GetLatePercentage() float64 {
+	if m != nil {
+		return m.LatePercentage
+	}
+	return 0
+}
+
+func (m *HandleSLAAlertRequest) GetCauses() map[string]uint32 {
+	if m != nil {
+		return m.Causes
+	}
+	return nil
+}
+
+type HandleSLAAlertResponse struct {
+}
+
+func (m *HandleSLAAlertResponse) Reset()                    { *m = HandleSLAAlertResponse{} }
+func (m *HandleSLAAlertResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleSLAAlertResponse) ProtoMessage()               {}
+func (*HandleSLAAlertResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+type HandleFirstJoinRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// AppEUI of the node.
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// DevAddr assigned to the node.
+	DevAddr []byte `protobuf:"bytes,3,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+	// Timestamp (RFC3339) of the join.
+	Time string `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+	// TX meta-data of the join-request.
+	TxInfo *TXInfo `protobuf:"bytes,5,opt,name=txInfo" json:"txInfo,omitempty"`
+	// RX meta-data of the gateways which received the join-request.
+	RxInfo []*RXInfo `protobuf:"bytes,6,rep,name=rxInfo" json:"rxInfo,omitempty"`
+}
+
+func (m *HandleFirstJoinRequest) Reset()                    { *m = HandleFirstJoinRequest{} }
+func (m *HandleFirstJoinRequest) String() string            { return proto.CompactTextString(m) }
+func (*HandleFirstJoinRequest) ProtoMessage()               {}
+func (*HandleFirstJoinRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *HandleFirstJoinRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *HandleFirstJoinRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *HandleFirstJoinRequest) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+func (m *HandleFirstJoinRequest) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *HandleFirstJoinRequest) GetTxInfo() *TXInfo {
+	if m != nil {
+		return m.TxInfo
+	}
+	return nil
+}
+
+func (m *HandleFirstJoinRequest) GetRxInfo() []*RXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+type HandleFirstJoinResponse struct {
+}
+
+func (m *HandleFirstJoinResponse) Reset()                    { *m = HandleFirstJoinResponse{} }
+func (m *HandleFirstJoinResponse) String() string            { return proto.CompactTextString(m) }
+func (*HandleFirstJoinResponse) ProtoMessage()               {}
+func (*HandleFirstJoinResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
 func init() {
 	proto.RegisterType((*DataRate)(nil), "nc.DataRate")
 	proto.RegisterType((*RXInfo)(nil), "nc.RXInfo")
@@ -307,6 +681,21 @@ func init() {
 	proto.RegisterType((*HandleDataUpMACCommandResponse)(nil), "nc.HandleDataUpMACCommandResponse")
 	proto.RegisterType((*HandleErrorRequest)(nil), "nc.HandleErrorRequest")
 	proto.RegisterType((*HandleErrorResponse)(nil), "nc.HandleErrorResponse")
+	proto.RegisterType((*HandleGatewayStatusRequest)(nil), "nc.HandleGatewayStatusRequest")
+	proto.RegisterType((*HandleGatewayStatusResponse)(nil), "nc.HandleGatewayStatusResponse")
+	proto.RegisterType((*HandleGatewayEventRequest)(nil), "nc.HandleGatewayEventRequest")
+	proto.RegisterType((*HandleGatewayEventResponse)(nil), "nc.HandleGatewayEventResponse")
+	proto.RegisterType((*HandleRXInfoBatchRequest)(nil), "nc.HandleRXInfoBatchRequest")
+	proto.RegisterType((*HandleRXInfoBatchResponse)(nil), "nc.HandleRXInfoBatchResponse")
+	proto.RegisterType((*HandleDataUpMACCommandBatchRequest)(nil), "nc.HandleDataUpMACCommandBatchRequest")
+	proto.RegisterType((*HandleDataUpMACCommandBatchResponse)(nil), "nc.HandleDataUpMACCommandBatchResponse")
+	proto.RegisterType((*HandleErrorBatchRequest)(nil), "nc.HandleErrorBatchRequest")
+	proto.RegisterType((*HandleErrorBatchResponse)(nil), "nc.HandleErrorBatchResponse")
+	proto.RegisterType((*HandleSLAAlertRequest)(nil), "nc.HandleSLAAlertRequest")
+	proto.RegisterType((*HandleSLAAlertResponse)(nil), "nc.HandleSLAAlertResponse")
+	proto.RegisterType((*HandleFirstJoinRequest)(nil), "nc.HandleFirstJoinRequest")
+	proto.RegisterType((*HandleFirstJoinResponse)(nil), "nc.HandleFirstJoinResponse")
+	proto.RegisterEnum("nc.GatewayStatus", GatewayStatus_name, GatewayStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -326,6 +715,28 @@ type NetworkControllerClient interface {
 	HandleDataUpMACCommand(ctx context.Context, in *HandleDataUpMACCommandRequest, opts ...grpc.CallOption) (*HandleDataUpMACCommandResponse, error)
 	// HandleError publishes an error message.
 	HandleError(ctx context.Context, in *HandleErrorRequest, opts ...grpc.CallOption) (*HandleErrorResponse, error)
+	// HandleGatewayStatus publishes a gateway connection state change.
+	HandleGatewayStatus(ctx context.Context, in *HandleGatewayStatusRequest, opts ...grpc.CallOption) (*HandleGatewayStatusResponse, error)
+	// HandleGatewayEvent publishes an error or event reported by a gateway
+	// (e.g. GPS_LOST, CONCENTRATOR_RESTART or JIT_QUEUE_FULL).
+	HandleGatewayEvent(ctx context.Context, in *HandleGatewayEventRequest, opts ...grpc.CallOption) (*HandleGatewayEventResponse, error)
+	// HandleRXInfoBatch publishes a batch of rx related meta-data (used
+	// instead of HandleRXInfo when batching is enabled).
+	HandleRXInfoBatch(ctx context.Context, in *HandleRXInfoBatchRequest, opts ...grpc.CallOption) (*HandleRXInfoBatchResponse, error)
+	// HandleDataUpMACCommandBatch publishes a batch of mac-commands received
+	// by end-devices (used instead of HandleDataUpMACCommand when batching is
+	// enabled).
+	HandleDataUpMACCommandBatch(ctx context.Context, in *HandleDataUpMACCommandBatchRequest, opts ...grpc.CallOption) (*HandleDataUpMACCommandBatchResponse, error)
+	// HandleErrorBatch publishes a batch of error messages (used instead of
+	// HandleError when batching is enabled).
+	HandleErrorBatch(ctx context.Context, in *HandleErrorBatchRequest, opts ...grpc.CallOption) (*HandleErrorBatchResponse, error)
+	// HandleSLAAlert publishes a change of the downlink SLA state (the
+	// percentage of uplinks of which the downlink was published after the
+	// RX1 deadline exceeded or dropped below the threshold).
+	HandleSLAAlert(ctx context.Context, in *HandleSLAAlertRequest, opts ...grpc.CallOption) (*HandleSLAAlertResponse, error)
+	// HandleFirstJoin publishes the first successful join of a node (e.g. for
+	// starting provisioning automations).
+	HandleFirstJoin(ctx context.Context, in *HandleFirstJoinRequest, opts ...grpc.CallOption) (*HandleFirstJoinResponse, error)
 }
 
 type networkControllerClient struct {
@@ -363,6 +774,69 @@ func (c *networkControllerClient) HandleError(ctx context.Context, in *HandleErr
 	return out, nil
 }
 
+func (c *networkControllerClient) HandleGatewayStatus(ctx context.Context, in *HandleGatewayStatusRequest, opts ...grpc.CallOption) (*HandleGatewayStatusResponse, error) {
+	out := new(HandleGatewayStatusResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleGatewayStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleGatewayEvent(ctx context.Context, in *HandleGatewayEventRequest, opts ...grpc.CallOption) (*HandleGatewayEventResponse, error) {
+	out := new(HandleGatewayEventResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleGatewayEvent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleRXInfoBatch(ctx context.Context, in *HandleRXInfoBatchRequest, opts ...grpc.CallOption) (*HandleRXInfoBatchResponse, error) {
+	out := new(HandleRXInfoBatchResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleRXInfoBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleDataUpMACCommandBatch(ctx context.Context, in *HandleDataUpMACCommandBatchRequest, opts ...grpc.CallOption) (*HandleDataUpMACCommandBatchResponse, error) {
+	out := new(HandleDataUpMACCommandBatchResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleDataUpMACCommandBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleErrorBatch(ctx context.Context, in *HandleErrorBatchRequest, opts ...grpc.CallOption) (*HandleErrorBatchResponse, error) {
+	out := new(HandleErrorBatchResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleErrorBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleSLAAlert(ctx context.Context, in *HandleSLAAlertRequest, opts ...grpc.CallOption) (*HandleSLAAlertResponse, error) {
+	out := new(HandleSLAAlertResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleSLAAlert", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkControllerClient) HandleFirstJoin(ctx context.Context, in *HandleFirstJoinRequest, opts ...grpc.CallOption) (*HandleFirstJoinResponse, error) {
+	out := new(HandleFirstJoinResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleFirstJoin", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for NetworkController service
 
 type NetworkControllerServer interface {
@@ -372,6 +846,28 @@ type NetworkControllerServer interface {
 	HandleDataUpMACCommand(context.Context, *HandleDataUpMACCommandRequest) (*HandleDataUpMACCommandResponse, error)
 	// HandleError publishes an error message.
 	HandleError(context.Context, *HandleErrorRequest) (*HandleErrorResponse, error)
+	// HandleGatewayStatus publishes a gateway connection state change.
+	HandleGatewayStatus(context.Context, *HandleGatewayStatusRequest) (*HandleGatewayStatusResponse, error)
+	// HandleGatewayEvent publishes an error or event reported by a gateway
+	// (e.g. GPS_LOST, CONCENTRATOR_RESTART or JIT_QUEUE_FULL).
+	HandleGatewayEvent(context.Context, *HandleGatewayEventRequest) (*HandleGatewayEventResponse, error)
+	// HandleRXInfoBatch publishes a batch of rx related meta-data (used
+	// instead of HandleRXInfo when batching is enabled).
+	HandleRXInfoBatch(context.Context, *HandleRXInfoBatchRequest) (*HandleRXInfoBatchResponse, error)
+	// HandleDataUpMACCommandBatch publishes a batch of mac-commands received
+	// by end-devices (used instead of HandleDataUpMACCommand when batching is
+	// enabled).
+	HandleDataUpMACCommandBatch(context.Context, *HandleDataUpMACCommandBatchRequest) (*HandleDataUpMACCommandBatchResponse, error)
+	// HandleErrorBatch publishes a batch of error messages (used instead of
+	// HandleError when batching is enabled).
+	HandleErrorBatch(context.Context, *HandleErrorBatchRequest) (*HandleErrorBatchResponse, error)
+	// HandleSLAAlert publishes a change of the downlink SLA state (the
+	// percentage of uplinks of which the downlink was published after the
+	// RX1 deadline exceeded or dropped below the threshold).
+	HandleSLAAlert(context.Context, *HandleSLAAlertRequest) (*HandleSLAAlertResponse, error)
+	// HandleFirstJoin publishes the first successful join of a node (e.g. for
+	// starting provisioning automations).
+	HandleFirstJoin(context.Context, *HandleFirstJoinRequest) (*HandleFirstJoinResponse, error)
 }
 
 func RegisterNetworkControllerServer(s *grpc.Server, srv NetworkControllerServer) {
@@ -432,6 +928,132 @@ func _NetworkController_HandleError_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkController_HandleGatewayStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleGatewayStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleGatewayStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleGatewayStatus(ctx, req.(*HandleGatewayStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleGatewayEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleGatewayEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleGatewayEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleGatewayEvent(ctx, req.(*HandleGatewayEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleRXInfoBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleRXInfoBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleRXInfoBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleRXInfoBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleRXInfoBatch(ctx, req.(*HandleRXInfoBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleDataUpMACCommandBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleDataUpMACCommandBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleDataUpMACCommandBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleDataUpMACCommandBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleDataUpMACCommandBatch(ctx, req.(*HandleDataUpMACCommandBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleErrorBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleErrorBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleErrorBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleErrorBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleErrorBatch(ctx, req.(*HandleErrorBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleSLAAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleSLAAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleSLAAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleSLAAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleSLAAlert(ctx, req.(*HandleSLAAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkController_HandleFirstJoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleFirstJoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleFirstJoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleFirstJoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleFirstJoin(ctx, req.(*HandleFirstJoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkController_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nc.NetworkController",
 	HandlerType: (*NetworkControllerServer)(nil),
@@ -448,6 +1070,34 @@ var _NetworkController_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleError",
 			Handler:    _NetworkController_HandleError_Handler,
 		},
+		{
+			MethodName: "HandleGatewayStatus",
+			Handler:    _NetworkController_HandleGatewayStatus_Handler,
+		},
+		{
+			MethodName: "HandleGatewayEvent",
+			Handler:    _NetworkController_HandleGatewayEvent_Handler,
+		},
+		{
+			MethodName: "HandleRXInfoBatch",
+			Handler:    _NetworkController_HandleRXInfoBatch_Handler,
+		},
+		{
+			MethodName: "HandleDataUpMACCommandBatch",
+			Handler:    _NetworkController_HandleDataUpMACCommandBatch_Handler,
+		},
+		{
+			MethodName: "HandleErrorBatch",
+			Handler:    _NetworkController_HandleErrorBatch_Handler,
+		},
+		{
+			MethodName: "HandleSLAAlert",
+			Handler:    _NetworkController_HandleSLAAlert_Handler,
+		},
+		{
+			MethodName: "HandleFirstJoin",
+			Handler:    _NetworkController_HandleFirstJoin_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nc.proto",
@@ -456,36 +1106,71 @@ var _NetworkController_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("nc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 491 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x4d, 0x6a, 0xec, 0x89, 0x91, 0x60, 0x29, 0xa9, 0x15, 0x95, 0x28, 0xec, 0x29, 0xa7,
-	0x1c, 0xc2, 0x0b, 0x80, 0x42, 0x11, 0x3d, 0x50, 0xa1, 0x81, 0x0a, 0x84, 0xb8, 0x6c, 0xbc, 0x1b,
-	0x61, 0x61, 0xef, 0x9a, 0xf5, 0x16, 0xe8, 0x85, 0x0b, 0x12, 0x37, 0x1e, 0x19, 0x09, 0xed, 0x8f,
-	0x13, 0x57, 0x6d, 0x39, 0xf4, 0x36, 0xf3, 0xcd, 0x78, 0xbe, 0x9f, 0x95, 0x0c, 0x89, 0x2c, 0x16,
-	0x8d, 0x56, 0x46, 0x91, 0x3d, 0x59, 0xd0, 0xdf, 0x11, 0x24, 0x2f, 0x98, 0x61, 0xc8, 0x8c, 0x20,
-	0x53, 0x80, 0x5a, 0xf1, 0xf3, 0x8a, 0x99, 0x52, 0xc9, 0x3c, 0x9a, 0x45, 0xf3, 0x14, 0x7b, 0x08,
-	0x39, 0x82, 0x74, 0xcd, 0x24, 0x7f, 0x5f, 0x72, 0xf3, 0x39, 0xdf, 0x9b, 0x45, 0xf3, 0x7b, 0xb8,
-	0x03, 0x08, 0x85, 0xac, 0x6d, 0xb4, 0x60, 0xfc, 0x25, 0x2b, 0x8c, 0xd2, 0xf9, 0xc0, 0x2d, 0x5c,
-	0xc2, 0x48, 0x0e, 0x77, 0xd7, 0xa5, 0xd1, 0xcc, 0x88, 0x7c, 0xe8, 0xc6, 0x5d, 0x4b, 0x3f, 0x41,
-	0x8c, 0x1f, 0x4e, 0xe4, 0x46, 0x91, 0xfb, 0x30, 0xa8, 0x59, 0xe1, 0xe8, 0x33, 0xb4, 0x25, 0x21,
-	0x30, 0x34, 0x65, 0x2d, 0x1c, 0x65, 0x8a, 0xae, 0xb6, 0x98, 0x6e, 0xdb, 0xd2, 0xb1, 0xec, 0xa3,
-	0xab, 0xed, 0xf5, 0x4a, 0x21, 0x7b, 0x7b, 0x8a, 0xee, 0x7a, 0x84, 0x5d, 0x4b, 0x7f, 0x42, 0xfc,
-	0xce, 0x5f, 0x3f, 0x82, 0x74, 0xa3, 0xc5, 0xd7, 0x73, 0x21, 0x8b, 0x0b, 0xc7, 0x31, 0xc0, 0x1d,
-	0x40, 0xe6, 0x90, 0xf0, 0x90, 0x86, 0x63, 0x1b, 0x2d, 0xb3, 0x85, 0x2c, 0x16, 0x5d, 0x42, 0xb8,
-	0x9d, 0x5a, 0x95, 0x8c, 0x7b, 0x93, 0x09, 0xda, 0x92, 0x4c, 0x20, 0x29, 0x14, 0x17, 0xd8, 0x99,
-	0x4b, 0x71, 0xdb, 0xd3, 0x3f, 0x11, 0x3c, 0x7c, 0xc5, 0x24, 0xaf, 0x84, 0x37, 0x89, 0x96, 0xb0,
-	0x35, 0x64, 0x0c, 0x31, 0x17, 0xdf, 0x8e, 0xcf, 0x4e, 0x82, 0xdd, 0xd0, 0x59, 0x9c, 0x35, 0x8d,
-	0xc5, 0xf7, 0x3c, 0xee, 0x3b, 0x42, 0x21, 0x36, 0x3f, 0xec, 0x01, 0x47, 0x3c, 0x5a, 0x82, 0x55,
-	0xe7, 0x9d, 0x61, 0x98, 0xd8, 0x1d, 0xed, 0x77, 0x86, 0xb3, 0x41, 0xb7, 0x13, 0x68, 0xc3, 0x84,
-	0x8e, 0xe1, 0xe0, 0xb2, 0x9c, 0xb6, 0x51, 0xb2, 0x15, 0xf4, 0x57, 0x04, 0x8f, 0xfd, 0xc0, 0x5a,
-	0x3e, 0x6b, 0x5e, 0x3f, 0x5f, 0xad, 0x54, 0x5d, 0x33, 0xc9, 0x6f, 0xab, 0x78, 0x0a, 0xb0, 0xd1,
-	0xf5, 0x1b, 0x76, 0x51, 0x29, 0xc6, 0x43, 0x5c, 0x3d, 0xc4, 0xbe, 0xa3, 0xcd, 0xd4, 0x25, 0x96,
-	0xa1, 0xab, 0xe9, 0x0c, 0xa6, 0x37, 0x89, 0x08, 0x3a, 0x3f, 0x02, 0xf1, 0x1b, 0xc7, 0x5a, 0x2b,
-	0x7d, 0x5b, 0x6d, 0x07, 0xb0, 0x2f, 0xec, 0xf7, 0x4e, 0x56, 0x8a, 0xbe, 0xa1, 0x8f, 0xba, 0xa7,
-	0x0a, 0xb7, 0x3d, 0xe5, 0xf2, 0x6f, 0x04, 0x0f, 0x4e, 0x85, 0xf9, 0xae, 0xf4, 0x97, 0x95, 0x92,
-	0x46, 0xab, 0xaa, 0x12, 0x9a, 0xac, 0x20, 0xeb, 0x07, 0x49, 0x0e, 0x6d, 0xd8, 0xd7, 0xbc, 0xf4,
-	0x24, 0xbf, 0x3a, 0x08, 0x5e, 0xee, 0x10, 0x06, 0xe3, 0xeb, 0xfd, 0x92, 0x27, 0xbb, 0xaf, 0x6e,
-	0x78, 0x90, 0x09, 0xfd, 0xdf, 0xca, 0x96, 0xe2, 0x19, 0x8c, 0x7a, 0xa6, 0xc8, 0x78, 0xf7, 0x51,
-	0x3f, 0xc1, 0xc9, 0xe1, 0x15, 0xbc, 0xbb, 0xb0, 0x8e, 0xdd, 0x4f, 0xe3, 0xe9, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xac, 0x7a, 0x89, 0x64, 0x40, 0x04, 0x00, 0x00,
+	// 1044 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9d, 0x56, 0x5b, 0x6f, 0xe3, 0x44,
+	0x14, 0x5e, 0xa7, 0x8d, 0x37, 0x39, 0x49, 0x4b, 0x3b, 0xec, 0xb6, 0xae, 0x7b, 0xa1, 0x18, 0xed,
+	0xb2, 0x20, 0xa8, 0x44, 0x79, 0xe0, 0x22, 0x21, 0x11, 0xb2, 0xd9, 0x25, 0x68, 0xb7, 0x5d, 0x4d,
+	0x52, 0xb1, 0x42, 0x20, 0x34, 0xb5, 0xa7, 0xd4, 0x5a, 0xc7, 0x36, 0xf6, 0xa4, 0x21, 0x2f, 0xbc,
+	0x20, 0xf1, 0xc6, 0x5f, 0xe2, 0xa7, 0xc0, 0x5f, 0x61, 0x66, 0x3c, 0xe3, 0xd8, 0x4e, 0xbc, 0x8b,
+	0xfa, 0xe6, 0x73, 0x99, 0xf3, 0x9d, 0xf9, 0xce, 0x65, 0x0c, 0xad, 0xd0, 0x3d, 0x89, 0x93, 0x88,
+	0x45, 0xa8, 0x11, 0xba, 0xce, 0x9f, 0x06, 0xb4, 0x1e, 0x13, 0x46, 0x30, 0x61, 0x14, 0x1d, 0x01,
+	0x4c, 0x22, 0x6f, 0x1a, 0x10, 0xe6, 0x47, 0xa1, 0x65, 0x1c, 0x1b, 0x8f, 0xda, 0xb8, 0xa0, 0x41,
+	0x07, 0xd0, 0xbe, 0x24, 0xa1, 0xf7, 0xbd, 0xef, 0xb1, 0x6b, 0xab, 0xc1, 0xcd, 0x1b, 0x78, 0xa1,
+	0x40, 0x0e, 0x74, 0xd3, 0x38, 0xa1, 0xc4, 0x7b, 0x42, 0x5c, 0x16, 0x25, 0xd6, 0x9a, 0x74, 0x28,
+	0xe9, 0x90, 0x05, 0x77, 0x2f, 0x7d, 0x96, 0x70, 0x30, 0x6b, 0x5d, 0x9a, 0xb5, 0xe8, 0xfc, 0x08,
+	0x26, 0x7e, 0x39, 0x0c, 0xaf, 0x22, 0xb4, 0x05, 0x6b, 0x13, 0xe2, 0x4a, 0xf8, 0x2e, 0x16, 0x9f,
+	0x08, 0xc1, 0x3a, 0xf3, 0x27, 0x54, 0x42, 0xb6, 0xb1, 0xfc, 0x16, 0xba, 0x24, 0x4d, 0x7d, 0x89,
+	0xd2, 0xc4, 0xf2, 0x5b, 0x44, 0x0f, 0x22, 0x4c, 0x46, 0x67, 0x58, 0x46, 0x37, 0xb0, 0x16, 0x9d,
+	0xdf, 0xc1, 0x1c, 0x67, 0xd1, 0xf9, 0x1d, 0xae, 0x12, 0xfa, 0xeb, 0x94, 0x86, 0xee, 0x5c, 0x62,
+	0xac, 0xe1, 0x85, 0x02, 0x3d, 0x82, 0x96, 0xa7, 0xd8, 0x90, 0x68, 0x9d, 0xd3, 0xee, 0x09, 0xe7,
+	0x4b, 0x33, 0x84, 0x73, 0xab, 0xc8, 0x92, 0x78, 0xd9, 0x25, 0x5b, 0x58, 0x7c, 0x22, 0x1b, 0x5a,
+	0x6e, 0xe4, 0x51, 0xac, 0x2f, 0xd7, 0xc6, 0xb9, 0xec, 0xfc, 0x65, 0xc0, 0xdb, 0xdf, 0x72, 0xa6,
+	0x02, 0x9a, 0x5d, 0x12, 0x0b, 0xc0, 0x94, 0xa1, 0x1d, 0x30, 0x3d, 0x7a, 0x33, 0xb8, 0x18, 0xaa,
+	0xeb, 0x2a, 0x49, 0xe8, 0x49, 0x1c, 0x0b, 0x7d, 0x23, 0xd3, 0x67, 0x12, 0xe7, 0xd8, 0x64, 0xbf,
+	0x89, 0x00, 0x12, 0xb8, 0x73, 0x0a, 0x22, 0xbb, 0xec, 0x66, 0x58, 0x59, 0x84, 0x4f, 0x92, 0xf9,
+	0xac, 0x1f, 0xaf, 0x69, 0x1f, 0x05, 0xab, 0x2c, 0xce, 0x0e, 0xdc, 0x2b, 0xa7, 0x93, 0xc6, 0x51,
+	0x98, 0x52, 0xe7, 0x0f, 0x03, 0x0e, 0x33, 0x83, 0xb8, 0xf2, 0x45, 0xfc, 0xbc, 0xd7, 0xef, 0x47,
+	0x93, 0x09, 0xd7, 0xdc, 0x36, 0x63, 0xde, 0x53, 0x57, 0xc9, 0xe4, 0x05, 0x99, 0x07, 0x11, 0xf1,
+	0x14, 0x5d, 0x05, 0x8d, 0xa8, 0xa3, 0xe0, 0x54, 0x32, 0xd6, 0xc5, 0xf2, 0xdb, 0x39, 0x86, 0xa3,
+	0xba, 0x24, 0x54, 0x9e, 0x3f, 0x00, 0xca, 0x3c, 0x06, 0x49, 0x12, 0x25, 0xb7, 0xcd, 0xed, 0x1e,
+	0x34, 0xa9, 0x38, 0x2f, 0xd3, 0x6a, 0xe3, 0x4c, 0x70, 0xee, 0xeb, 0x52, 0xa9, 0xd8, 0x0a, 0x72,
+	0x0e, 0x76, 0xa6, 0x7e, 0xca, 0x0b, 0x3a, 0x23, 0xf3, 0x11, 0x23, 0x6c, 0x9a, 0x6a, 0xe8, 0xe5,
+	0xa6, 0xfd, 0x00, 0xcc, 0x54, 0xba, 0x48, 0xd0, 0xcd, 0xd3, 0x6d, 0x51, 0x86, 0xf2, 0x59, 0xe5,
+	0x20, 0x38, 0x0a, 0x48, 0xca, 0x46, 0x94, 0x86, 0x3d, 0xa6, 0x92, 0x29, 0x68, 0x9c, 0x43, 0xd8,
+	0x5f, 0x09, 0xad, 0x32, 0x9b, 0xc1, 0x5e, 0xc9, 0x3c, 0xb8, 0xa1, 0x21, 0xab, 0x4f, 0xac, 0x66,
+	0x9a, 0xd8, 0x3c, 0xa6, 0x0a, 0x5b, 0x7e, 0xa3, 0x63, 0xe8, 0x78, 0x34, 0x75, 0x13, 0x3f, 0x96,
+	0xeb, 0x20, 0x6b, 0xe9, 0xa2, 0xca, 0x39, 0xa8, 0x50, 0xa2, 0x80, 0x55, 0x5a, 0x43, 0xb0, 0x8a,
+	0x3d, 0xf6, 0x0d, 0x61, 0xee, 0xb5, 0xce, 0xea, 0x63, 0x68, 0xfa, 0x8c, 0x4e, 0x52, 0x9e, 0x97,
+	0x68, 0xd1, 0x5d, 0xc1, 0xcd, 0x8a, 0xf9, 0xc0, 0x99, 0x97, 0xb3, 0xaf, 0x6f, 0x58, 0x0a, 0xa5,
+	0x70, 0x7e, 0x02, 0x67, 0x75, 0xb7, 0x94, 0x10, 0x3f, 0x2b, 0x23, 0xbe, 0xbb, 0x40, 0xac, 0xe9,
+	0x74, 0x8d, 0xfd, 0x00, 0xde, 0x7b, 0x6d, 0x78, 0x95, 0xc5, 0x53, 0xd8, 0x2d, 0x74, 0x4d, 0x09,
+	0xfa, 0xa3, 0x32, 0xf4, 0xce, 0x02, 0xba, 0xd8, 0xbd, 0x1a, 0xcf, 0xd6, 0xb4, 0x15, 0x03, 0x29,
+	0x90, 0x7f, 0x1b, 0x70, 0x3f, 0x33, 0x8e, 0x9e, 0xf5, 0x7a, 0x01, 0x4d, 0xf2, 0x32, 0xeb, 0xa2,
+	0x1a, 0x85, 0xa2, 0xf2, 0x85, 0x94, 0xd0, 0x34, 0x0a, 0x6e, 0xa8, 0x27, 0x8b, 0xdd, 0xc2, 0xb9,
+	0x2c, 0x46, 0x62, 0xe6, 0x87, 0x5e, 0x34, 0x53, 0x6b, 0x5a, 0x49, 0x62, 0x3d, 0xb2, 0x6b, 0xee,
+	0x75, 0x1d, 0x05, 0x9e, 0x5a, 0xa2, 0x0b, 0x85, 0x68, 0x89, 0x69, 0x1c, 0xf8, 0xe1, 0xab, 0x7e,
+	0x34, 0x0d, 0x99, 0xd5, 0x94, 0x47, 0x8b, 0x2a, 0x71, 0x9e, 0x3f, 0x16, 0x34, 0xb3, 0x9b, 0xd9,
+	0x13, 0x91, 0x2b, 0xd0, 0x43, 0xd8, 0x14, 0xc2, 0x0b, 0x9a, 0xb8, 0xbc, 0x53, 0xc8, 0x2f, 0xd4,
+	0xba, 0x2b, 0x21, 0x2a, 0x5a, 0xf4, 0x15, 0x98, 0x2e, 0x99, 0xa6, 0x34, 0xb5, 0x5a, 0x92, 0xb2,
+	0x07, 0x0b, 0xca, 0x2a, 0x17, 0x3f, 0xe9, 0x4b, 0xbf, 0x41, 0xc8, 0x92, 0x39, 0x56, 0x87, 0xec,
+	0x2f, 0xa0, 0x53, 0x50, 0x8b, 0x11, 0x78, 0x45, 0xe7, 0x8a, 0x1a, 0xf1, 0x29, 0x06, 0xff, 0x86,
+	0x04, 0x53, 0xaa, 0x1e, 0xb1, 0x4c, 0xf8, 0xb2, 0xf1, 0xb9, 0xe1, 0x58, 0xb0, 0x53, 0xc5, 0x51,
+	0xdc, 0xff, 0x6d, 0x68, 0xd3, 0x13, 0x3f, 0x49, 0xd9, 0x77, 0x91, 0x1f, 0xde, 0x76, 0xef, 0xf0,
+	0x77, 0x8a, 0x7b, 0xf4, 0x3c, 0xf5, 0x7e, 0x74, 0xb1, 0x16, 0xf3, 0x32, 0xae, 0x17, 0xca, 0xb8,
+	0xd8, 0xf9, 0xcd, 0xff, 0xb1, 0xf3, 0xcd, 0xda, 0x9d, 0xbf, 0xa7, 0x3b, 0xb4, 0x90, 0x7f, 0x76,
+	0xb7, 0x0f, 0x3f, 0x81, 0x8d, 0xd2, 0x6a, 0x41, 0x1b, 0xd0, 0xee, 0x9f, 0x9f, 0x9d, 0x0d, 0xfa,
+	0xe3, 0xc1, 0xe3, 0xad, 0x3b, 0x68, 0x1b, 0x36, 0x46, 0xe3, 0xde, 0x78, 0xf4, 0xf3, 0x78, 0xf8,
+	0x7c, 0x70, 0x7e, 0x31, 0xde, 0x32, 0x4e, 0xff, 0x31, 0x61, 0xfb, 0x8c, 0xb2, 0x59, 0x94, 0xf0,
+	0xca, 0x73, 0x9a, 0xa3, 0x80, 0xb3, 0x85, 0xfa, 0xd0, 0x2d, 0x0e, 0x2a, 0xaa, 0x1b, 0x6c, 0xdb,
+	0x5a, 0x36, 0x28, 0x9e, 0xef, 0x20, 0xa2, 0x89, 0xae, 0x4e, 0x1c, 0x7a, 0xf3, 0xd4, 0xda, 0xce,
+	0xeb, 0x5c, 0x72, 0x88, 0xaf, 0xa1, 0x53, 0x18, 0x32, 0x54, 0x33, 0x92, 0xf6, 0xee, 0x92, 0x3e,
+	0x8f, 0xf0, 0x52, 0xbf, 0x12, 0x65, 0xe2, 0x8e, 0x16, 0x27, 0x56, 0xbd, 0x13, 0xf6, 0x3b, 0xb5,
+	0xf6, 0x3c, 0xf2, 0x85, 0x7e, 0xdb, 0x8a, 0x5b, 0x15, 0x1d, 0x2e, 0x1d, 0x2c, 0xae, 0x79, 0xfb,
+	0xa8, 0xce, 0x9c, 0x87, 0xc5, 0xb0, 0xbd, 0xb4, 0x43, 0xd1, 0x41, 0xb5, 0x0c, 0xc5, 0xc5, 0x65,
+	0x1f, 0xd6, 0x58, 0xf3, 0x98, 0xb1, 0x7e, 0x98, 0x56, 0xee, 0x46, 0xf4, 0xb0, 0xbe, 0x16, 0x25,
+	0x9c, 0xf7, 0xdf, 0xe8, 0x97, 0x23, 0x9e, 0xc3, 0x56, 0x75, 0x3b, 0xa2, 0xfd, 0x4a, 0x95, 0x4a,
+	0xb1, 0x0f, 0x56, 0x1b, 0xf3, 0x80, 0x43, 0xd8, 0x2c, 0x0f, 0x3c, 0xda, 0xab, 0x5d, 0x36, 0xb6,
+	0xbd, 0xca, 0x94, 0x87, 0x7a, 0x06, 0x6f, 0x55, 0x06, 0x0c, 0x15, 0x0e, 0x54, 0xb7, 0x86, 0xbd,
+	0xbf, 0xd2, 0xa6, 0xa3, 0x5d, 0x9a, 0xf2, 0x27, 0xfd, 0xd3, 0xff, 0x00, 0x81, 0xc4, 0x5f, 0x55,
+	0xb0, 0x0b, 0x00, 0x00,
 }
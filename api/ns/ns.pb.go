@@ -15,6 +15,8 @@ It has these top-level messages:
 	GetNodeSessionResponse
 	UpdateNodeSessionRequest
 	UpdateNodeSessionResponse
+	PatchNodeSessionRequest
+	PatchNodeSessionResponse
 	DeleteNodeSessionRequest
 	DeleteNodeSessionResponse
 	GetRandomDevAddrRequest
@@ -23,10 +25,23 @@ It has these top-level messages:
 	EnqueueDataDownMACCommandResponse
 	PushDataDownRequest
 	PushDataDownResponse
+	BroadcastDataDownRequest
+	BroadcastDataDownResponse
+	GetDownlinkDecisionsRequest
+	DownlinkDecision
+	GetDownlinkDecisionsResponse
+	GetMACCommandHistoryRequest
+	MACCommandHistoryItem
+	GetMACCommandHistoryResponse
+	GetDownlinkFramesRequest
+	DownlinkFrame
+	DownlinkFrameSession
+	GetDownlinkFramesResponse
 	CreateGatewayRequest
 	CreateGatewayResponse
 	GetGatewayRequest
 	GetGatewayResponse
+	GatewayEvent
 	UpdateGatewayRequest
 	UpdateGatewayResponse
 	ListGatewayRequest
@@ -36,6 +51,197 @@ It has these top-level messages:
 	GatewayStats
 	GetGatewayStatsRequest
 	GetGatewayStatsResponse
+	StreamUplinkMetadataRequest
+	UplinkRXInfo
+	StreamUplinkMetadataResponse
+	GetDownlinkCapacityReportRequest
+	SubBandCapacity
+	DeviceAirtime
+	GetDownlinkCapacityReportResponse
+	GetUplinkChannelStatsRequest
+	UplinkChannelStats
+	GetUplinkChannelStatsResponse
+	GetJoinStatsRequest
+	JoinStats
+	GatewayJoinStats
+	AppJoinStats
+	GetJoinStatsResponse
+	ListGatewayDevicesRequest
+	GatewayDevice
+	ListGatewayDevicesResponse
+	GetGatewayAntennaStatsRequest
+	GatewayAntennaStats
+	GetGatewayAntennaStatsResponse
+	ChangeDeviceClassRequest
+	ChangeDeviceClassResponse
+	ImportNodeSessionsRequest
+	ImportNodeSessionError
+	ImportNodeSessionsResponse
+	ExportNodeSessionsRequest
+	ExportNodeSessionsResponse
+	GetDeviceActivationRequest
+	GetDeviceActivationResponse
+	GetADRParametersRequest
+	GetADRParametersResponse
+	UpdateADRParametersRequest
+	UpdateADRParametersResponse
+	GetADRDecisionsRequest
+	ADRUplinkHistoryItem
+	ADRDecision
+	GetADRDecisionsResponse
+	AuditRedisKeysRequest
+	RedisKeyGroup
+	AuditRedisKeysResponse
+	GetInfoRequest
+	GetInfoResponse
+	ReleaseQuarantineRequest
+	ReleaseQuarantineResponse
+	GetDeviceDownlinkAirtimeRequest
+	DailyAirtime
+	GetDeviceDownlinkAirtimeResponse
+	UplinkRule
+	CreateUplinkRuleRequest
+	CreateUplinkRuleResponse
+	GetUplinkRuleRequest
+	GetUplinkRuleResponse
+	UpdateUplinkRuleRequest
+	UpdateUplinkRuleResponse
+	DeleteUplinkRuleRequest
+	DeleteUplinkRuleResponse
+	ListUplinkRulesRequest
+	ListUplinkRulesResponse
+	GetDeviceUplinkStatsRequest
+	FPortUplinkStats
+	GetDeviceUplinkStatsResponse
+	GetTopTalkersRequest
+	TopTalker
+	GetTopTalkersResponse
+	GetLowLinkMarginNodesRequest
+	LowLinkMarginNode
+	GetLowLinkMarginNodesResponse
+	ClockDrift
+	GetDeviceClockDriftRequest
+	GetDeviceClockDriftResponse
+	GetClockDriftNodesRequest
+	GetClockDriftNodesResponse
+	RotateGatewayCUPSCredentialsRequest
+	RotateGatewayCUPSCredentialsResponse
+	GetGatewayCUPSCredentialsRequest
+	GetGatewayCUPSCredentialsResponse
+	GenerateGatewayClientCertificateRequest
+	GenerateGatewayClientCertificateResponse
+	ListRX2MismatchNodesRequest
+	RX2MismatchNode
+	ListRX2MismatchNodesResponse
+	ClearRX2MismatchRequest
+	ClearRX2MismatchResponse
+	ListFCntDownRolloverNodesRequest
+	FCntDownRolloverNode
+	ListFCntDownRolloverNodesResponse
+	GetOversizedFrameOffendersRequest
+	OversizedFrameDevice
+	OversizedFrameGateway
+	GetOversizedFrameOffendersResponse
+	DeviceQueueItem
+	EnqueueDeviceQueueItemRequest
+	EnqueueDeviceQueueItemResponse
+	GetDeviceQueueItemsRequest
+	GetDeviceQueueItemsResponse
+	FlushDeviceQueueRequest
+	FlushDeviceQueueResponse
+	GetNextDownlinkFCntRequest
+	GetNextDownlinkFCntResponse
+	MulticastGroup
+	CreateMulticastGroupRequest
+	CreateMulticastGroupResponse
+	GetMulticastGroupRequest
+	GetMulticastGroupResponse
+	UpdateMulticastGroupRequest
+	UpdateMulticastGroupResponse
+	DeleteMulticastGroupRequest
+	DeleteMulticastGroupResponse
+	ListMulticastGroupsRequest
+	ListMulticastGroupsResponse
+	EnqueueMulticastQueueItemRequest
+	EnqueueMulticastQueueItemResponse
+	FlushMulticastQueueRequest
+	FlushMulticastQueueResponse
+	FrameLogDataRate
+	FrameLogRXInfo
+	FrameLogTXInfo
+	FrameLog
+	StreamFrameLogsForDeviceRequest
+	StreamFrameLogsForGatewayRequest
+	GetFrameLogsForDeviceRequest
+	GetFrameLogsForGatewayRequest
+	GetFrameLogsResponse
+	DownlinkTemplate
+	CreateDownlinkTemplateRequest
+	CreateDownlinkTemplateResponse
+	GetDownlinkTemplateRequest
+	GetDownlinkTemplateResponse
+	UpdateDownlinkTemplateRequest
+	UpdateDownlinkTemplateResponse
+	DeleteDownlinkTemplateRequest
+	DeleteDownlinkTemplateResponse
+	ListDownlinkTemplatesRequest
+	ListDownlinkTemplatesResponse
+	TriggerDownlinkTemplateRequest
+	TriggerDownlinkTemplateError
+	TriggerDownlinkTemplateResponse
+	UplinkChannel
+	ExtraChannel
+	ChannelConfiguration
+	CreateChannelConfigurationRequest
+	CreateChannelConfigurationResponse
+	GetChannelConfigurationRequest
+	GetChannelConfigurationResponse
+	UpdateChannelConfigurationRequest
+	UpdateChannelConfigurationResponse
+	DeleteChannelConfigurationRequest
+	DeleteChannelConfigurationResponse
+	ListChannelConfigurationsRequest
+	ListChannelConfigurationsResponse
+	EnqueueBenchDownlinkRequest
+	EnqueueBenchDownlinkResponse
+	CreateGatewayOnboardingTokenRequest
+	CreateGatewayOnboardingTokenResponse
+	GatewayOnboardingToken
+	ListGatewayOnboardingTokensRequest
+	ListGatewayOnboardingTokensResponse
+	DeleteGatewayOnboardingTokenRequest
+	DeleteGatewayOnboardingTokenResponse
+	SendGatewayTestFrameRequest
+	SendGatewayTestFrameResponse
+	ExportFrameLogsForDeviceRequest
+	ExportFrameLogsForGatewayRequest
+	ExportFrameLogsResponse
+	RotateGatewaySigningKeyRequest
+	RotateGatewaySigningKeyResponse
+	DeleteGatewaySigningKeyRequest
+	DeleteGatewaySigningKeyResponse
+	SetDeviceChannelMaskRequest
+	SetDeviceChannelMaskResponse
+	DeviceProfile
+	CreateDeviceProfileRequest
+	CreateDeviceProfileResponse
+	GetDeviceProfileRequest
+	GetDeviceProfileResponse
+	UpdateDeviceProfileRequest
+	UpdateDeviceProfileResponse
+	DeleteDeviceProfileRequest
+	DeleteDeviceProfileResponse
+	ListDeviceProfilesRequest
+	ListDeviceProfilesResponse
+	GetUnknownDevAddrStatsRequest
+	UnknownDevAddrStats
+	GetUnknownDevAddrStatsResponse
+	BulkCreateOrUpdateGatewaysRequest
+	BulkGatewayResult
+	BulkCreateOrUpdateGatewaysResponse
+	CheckSessionsRequest
+	SessionIssue
+	CheckSessionsResponse
 */
 package ns
 
@@ -82,6 +288,429 @@ func (x RXWindow) String() string {
 }
 func (RXWindow) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type ADRStrategy int32
+
+const (
+	// Increase the data-rate first, then decrease the TX power.
+	ADRStrategy_MAXIMIZE_DATA_RATE ADRStrategy = 0
+	// Decrease the TX power first, then increase the data-rate.
+	ADRStrategy_MINIMIZE_TX_POWER ADRStrategy = 1
+)
+
+var ADRStrategy_name = map[int32]string{
+	0: "MAXIMIZE_DATA_RATE",
+	1: "MINIMIZE_TX_POWER",
+}
+var ADRStrategy_value = map[string]int32{
+	"MAXIMIZE_DATA_RATE": 0,
+	"MINIMIZE_TX_POWER":  1,
+}
+
+func (x ADRStrategy) String() string {
+	return proto.EnumName(ADRStrategy_name, int32(x))
+}
+func (ADRStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// ErrorCode contains the machine-readable cause of an API error. It is sent
+// as the "error-code" trailer metadata of the failed call, next to the
+// gRPC status code.
+type ErrorCode int32
+
+const (
+	// The cause of the error is unknown.
+	ErrorCode_UNKNOWN_ERROR ErrorCode = 0
+	// The node-session does not exist or the frame-counter or MIC is invalid.
+	ErrorCode_NODE_SESSION_DOES_NOT_EXIST ErrorCode = 1
+	// The node-session has been updated concurrently.
+	ErrorCode_NODE_SESSION_CONCURRENT_UPDATE ErrorCode = 2
+	// The patch would change the DevEUI or DevAddr of the node-session.
+	ErrorCode_NODE_SESSION_INVALID_PATCH ErrorCode = 3
+	// A wrapped AppSKey was given, but no KEK is configured.
+	ErrorCode_APP_SKEY_KEK_NOT_CONFIGURED ErrorCode = 4
+	// The wrapped AppSKey could not be unwrapped.
+	ErrorCode_INVALID_WRAPPED_APP_SKEY ErrorCode = 5
+	// The FPort is invalid for the given payload.
+	ErrorCode_INVALID_FPORT ErrorCode = 6
+	// No RX-Info set is available for the node (no uplink received yet).
+	ErrorCode_NO_LAST_RX_INFO_SET ErrorCode = 7
+	// The data-rate is invalid for the configured band.
+	ErrorCode_INVALID_DATA_RATE ErrorCode = 8
+	// The maximum payload size for the data-rate has been exceeded.
+	ErrorCode_MAX_PAYLOAD_SIZE_EXCEEDED ErrorCode = 9
+	// The RX window is unknown.
+	ErrorCode_UNKNOWN_RX_WINDOW ErrorCode = 10
+	// The mac-command is handled by the network-server.
+	ErrorCode_MAC_COMMAND_HANDLED_BY_NETWORK_SERVER ErrorCode = 11
+	// The gateway does not exist.
+	ErrorCode_GATEWAY_DOES_NOT_EXIST ErrorCode = 12
+	// The gateway already exists.
+	ErrorCode_GATEWAY_ALREADY_EXISTS ErrorCode = 13
+	// The gateway name is invalid.
+	ErrorCode_INVALID_GATEWAY_NAME ErrorCode = 14
+	// The stats aggregation interval is invalid.
+	ErrorCode_INVALID_AGGREGATION_INTERVAL ErrorCode = 15
+	// The device class is invalid.
+	ErrorCode_INVALID_DEVICE_CLASS ErrorCode = 16
+	// A device class change of the node is pending.
+	ErrorCode_DEVICE_CLASS_CHANGE_PENDING ErrorCode = 17
+	// The node is not a Class-C device.
+	ErrorCode_NOT_CLASS_C_DEVICE ErrorCode = 18
+	// The node-session already exists.
+	ErrorCode_NODE_SESSION_ALREADY_EXISTS ErrorCode = 19
+	// None of the gateways that received the node is within the gateway
+	// regions of the node and capable of transmitting downlinks.
+	ErrorCode_NO_ALLOWED_GATEWAY ErrorCode = 20
+	// The ADR parameters are invalid.
+	ErrorCode_INVALID_ADR_PARAMETERS ErrorCode = 21
+	// The TX parameters (TX power or code rate) are invalid.
+	ErrorCode_INVALID_TX_PARAMETERS ErrorCode = 22
+	// The AppSKey encryption of the node is not offloaded to LoRa Server.
+	ErrorCode_APP_SKEY_NOT_OFFLOADED ErrorCode = 23
+	// The daily downlink airtime cap of the node has been reached.
+	ErrorCode_DAILY_AIRTIME_CAP_REACHED ErrorCode = 24
+	// The uplink rule does not exist.
+	ErrorCode_UPLINK_RULE_DOES_NOT_EXIST ErrorCode = 25
+	// The uplink rule (name, condition, action or action parameters) is
+	// invalid.
+	ErrorCode_INVALID_UPLINK_RULE ErrorCode = 26
+	// The gateway does not have CUPS credentials.
+	ErrorCode_GATEWAY_CUPS_CREDENTIALS_DO_NOT_EXIST ErrorCode = 27
+	// The tags are invalid (max. 32 tags, keys of 1 - 64 characters
+	// a-z, A-Z, 0-9, _, ., : or -, values of max. 255 characters).
+	ErrorCode_INVALID_TAGS ErrorCode = 28
+	// The LoRaWAN MAC version is invalid or not supported.
+	ErrorCode_INVALID_MAC_VERSION ErrorCode = 29
+	// The mac-command is not supported by the LoRaWAN MAC version of the
+	// node.
+	ErrorCode_MAC_COMMAND_NOT_SUPPORTED ErrorCode = 30
+	// The multicast group does not exist.
+	ErrorCode_MULTICAST_GROUP_DOES_NOT_EXIST ErrorCode = 31
+	// The multicast group (name, data-rate, frequency or gateways) is
+	// invalid.
+	ErrorCode_INVALID_MULTICAST_GROUP ErrorCode = 32
+	// The frame-counter of the multicast payload is lower than the
+	// frame-counter of the multicast group.
+	ErrorCode_INVALID_MULTICAST_FCNT ErrorCode = 33
+	// LoRa Server runs in read-only (maintenance) mode.
+	ErrorCode_READ_ONLY_MODE ErrorCode = 34
+	// Not enough uplinks have been received for the clock drift estimation.
+	ErrorCode_NOT_ENOUGH_UPLINKS ErrorCode = 35
+	// The deadline of the API call has been exceeded before it completed.
+	ErrorCode_DEADLINE_EXCEEDED ErrorCode = 36
+	// The API call has been cancelled by the client.
+	ErrorCode_REQUEST_CANCELLED ErrorCode = 37
+	// The CA for signing the gateway client certificates has not been
+	// configured.
+	ErrorCode_GATEWAY_CLIENT_CA_NOT_CONFIGURED ErrorCode = 38
+	// The downlink template does not exist.
+	ErrorCode_DOWNLINK_TEMPLATE_DOES_NOT_EXIST ErrorCode = 39
+	// The downlink template (name, fPort or payload pattern) is invalid.
+	ErrorCode_INVALID_DOWNLINK_TEMPLATE ErrorCode = 40
+	// A tag referenced by the payload pattern of the downlink template does
+	// not exist for the node.
+	ErrorCode_DOWNLINK_TEMPLATE_TAG_DOES_NOT_EXIST ErrorCode = 41
+	// The channel-configuration does not exist.
+	ErrorCode_CHANNEL_CONFIGURATION_DOES_NOT_EXIST ErrorCode = 42
+	// The channel-configuration (name, band, channels or extra channels) is
+	// invalid.
+	ErrorCode_INVALID_CHANNEL_CONFIGURATION ErrorCode = 43
+	// The NwkID of the DevAddr does not match the NetID of the network.
+	ErrorCode_INVALID_DEV_ADDR ErrorCode = 44
+	// Bench mode (downlinks for unprovisioned test devices) is disabled.
+	ErrorCode_BENCH_MODE_DISABLED ErrorCode = 45
+	// The mac-command could not be decoded.
+	ErrorCode_INVALID_MAC_COMMAND ErrorCode = 46
+	// The gateway onboarding token does not exist.
+	ErrorCode_GATEWAY_ONBOARDING_TOKEN_DOES_NOT_EXIST ErrorCode = 47
+	// The channel mask is invalid.
+	ErrorCode_INVALID_CHANNEL_MASK ErrorCode = 48
+	// The device-profile does not exist.
+	ErrorCode_DEVICE_PROFILE_DOES_NOT_EXIST ErrorCode = 49
+	// The device-profile is invalid.
+	ErrorCode_INVALID_DEVICE_PROFILE ErrorCode = 50
+	// The device-queue of the node holds the max. number of items of its
+	// device-profile.
+	ErrorCode_DEVICE_QUEUE_FULL ErrorCode = 51
+	// The node consumed the daily airtime quota of its device-profile.
+	ErrorCode_AIRTIME_QUOTA_EXCEEDED ErrorCode = 52
+)
+
+var ErrorCode_name = map[int32]string{
+	0:  "UNKNOWN_ERROR",
+	1:  "NODE_SESSION_DOES_NOT_EXIST",
+	2:  "NODE_SESSION_CONCURRENT_UPDATE",
+	3:  "NODE_SESSION_INVALID_PATCH",
+	4:  "APP_SKEY_KEK_NOT_CONFIGURED",
+	5:  "INVALID_WRAPPED_APP_SKEY",
+	6:  "INVALID_FPORT",
+	7:  "NO_LAST_RX_INFO_SET",
+	8:  "INVALID_DATA_RATE",
+	9:  "MAX_PAYLOAD_SIZE_EXCEEDED",
+	10: "UNKNOWN_RX_WINDOW",
+	11: "MAC_COMMAND_HANDLED_BY_NETWORK_SERVER",
+	12: "GATEWAY_DOES_NOT_EXIST",
+	13: "GATEWAY_ALREADY_EXISTS",
+	14: "INVALID_GATEWAY_NAME",
+	15: "INVALID_AGGREGATION_INTERVAL",
+	16: "INVALID_DEVICE_CLASS",
+	17: "DEVICE_CLASS_CHANGE_PENDING",
+	18: "NOT_CLASS_C_DEVICE",
+	19: "NODE_SESSION_ALREADY_EXISTS",
+	20: "NO_ALLOWED_GATEWAY",
+	21: "INVALID_ADR_PARAMETERS",
+	22: "INVALID_TX_PARAMETERS",
+	23: "APP_SKEY_NOT_OFFLOADED",
+	24: "DAILY_AIRTIME_CAP_REACHED",
+	25: "UPLINK_RULE_DOES_NOT_EXIST",
+	26: "INVALID_UPLINK_RULE",
+	27: "GATEWAY_CUPS_CREDENTIALS_DO_NOT_EXIST",
+	28: "INVALID_TAGS",
+	29: "INVALID_MAC_VERSION",
+	30: "MAC_COMMAND_NOT_SUPPORTED",
+	31: "MULTICAST_GROUP_DOES_NOT_EXIST",
+	32: "INVALID_MULTICAST_GROUP",
+	33: "INVALID_MULTICAST_FCNT",
+	34: "READ_ONLY_MODE",
+	35: "NOT_ENOUGH_UPLINKS",
+	36: "DEADLINE_EXCEEDED",
+	37: "REQUEST_CANCELLED",
+	38: "GATEWAY_CLIENT_CA_NOT_CONFIGURED",
+	39: "DOWNLINK_TEMPLATE_DOES_NOT_EXIST",
+	40: "INVALID_DOWNLINK_TEMPLATE",
+	41: "DOWNLINK_TEMPLATE_TAG_DOES_NOT_EXIST",
+	42: "CHANNEL_CONFIGURATION_DOES_NOT_EXIST",
+	43: "INVALID_CHANNEL_CONFIGURATION",
+	44: "INVALID_DEV_ADDR",
+	45: "BENCH_MODE_DISABLED",
+	46: "INVALID_MAC_COMMAND",
+	47: "GATEWAY_ONBOARDING_TOKEN_DOES_NOT_EXIST",
+	48: "INVALID_CHANNEL_MASK",
+	49: "DEVICE_PROFILE_DOES_NOT_EXIST",
+	50: "INVALID_DEVICE_PROFILE",
+	51: "DEVICE_QUEUE_FULL",
+	52: "AIRTIME_QUOTA_EXCEEDED",
+}
+var ErrorCode_value = map[string]int32{
+	"UNKNOWN_ERROR":                           0,
+	"NODE_SESSION_DOES_NOT_EXIST":             1,
+	"NODE_SESSION_CONCURRENT_UPDATE":          2,
+	"NODE_SESSION_INVALID_PATCH":              3,
+	"APP_SKEY_KEK_NOT_CONFIGURED":             4,
+	"INVALID_WRAPPED_APP_SKEY":                5,
+	"INVALID_FPORT":                           6,
+	"NO_LAST_RX_INFO_SET":                     7,
+	"INVALID_DATA_RATE":                       8,
+	"MAX_PAYLOAD_SIZE_EXCEEDED":               9,
+	"UNKNOWN_RX_WINDOW":                       10,
+	"MAC_COMMAND_HANDLED_BY_NETWORK_SERVER":   11,
+	"GATEWAY_DOES_NOT_EXIST":                  12,
+	"GATEWAY_ALREADY_EXISTS":                  13,
+	"INVALID_GATEWAY_NAME":                    14,
+	"INVALID_AGGREGATION_INTERVAL":            15,
+	"INVALID_DEVICE_CLASS":                    16,
+	"DEVICE_CLASS_CHANGE_PENDING":             17,
+	"NOT_CLASS_C_DEVICE":                      18,
+	"NODE_SESSION_ALREADY_EXISTS":             19,
+	"NO_ALLOWED_GATEWAY":                      20,
+	"INVALID_ADR_PARAMETERS":                  21,
+	"INVALID_TX_PARAMETERS":                   22,
+	"APP_SKEY_NOT_OFFLOADED":                  23,
+	"DAILY_AIRTIME_CAP_REACHED":               24,
+	"UPLINK_RULE_DOES_NOT_EXIST":              25,
+	"INVALID_UPLINK_RULE":                     26,
+	"GATEWAY_CUPS_CREDENTIALS_DO_NOT_EXIST":   27,
+	"INVALID_TAGS":                            28,
+	"INVALID_MAC_VERSION":                     29,
+	"MAC_COMMAND_NOT_SUPPORTED":               30,
+	"MULTICAST_GROUP_DOES_NOT_EXIST":          31,
+	"INVALID_MULTICAST_GROUP":                 32,
+	"INVALID_MULTICAST_FCNT":                  33,
+	"READ_ONLY_MODE":                          34,
+	"NOT_ENOUGH_UPLINKS":                      35,
+	"DEADLINE_EXCEEDED":                       36,
+	"REQUEST_CANCELLED":                       37,
+	"GATEWAY_CLIENT_CA_NOT_CONFIGURED":        38,
+	"DOWNLINK_TEMPLATE_DOES_NOT_EXIST":        39,
+	"INVALID_DOWNLINK_TEMPLATE":               40,
+	"DOWNLINK_TEMPLATE_TAG_DOES_NOT_EXIST":    41,
+	"CHANNEL_CONFIGURATION_DOES_NOT_EXIST":    42,
+	"INVALID_CHANNEL_CONFIGURATION":           43,
+	"INVALID_DEV_ADDR":                        44,
+	"BENCH_MODE_DISABLED":                     45,
+	"INVALID_MAC_COMMAND":                     46,
+	"GATEWAY_ONBOARDING_TOKEN_DOES_NOT_EXIST": 47,
+	"INVALID_CHANNEL_MASK":                    48,
+	"DEVICE_PROFILE_DOES_NOT_EXIST":           49,
+	"INVALID_DEVICE_PROFILE":                  50,
+	"DEVICE_QUEUE_FULL":                       51,
+	"AIRTIME_QUOTA_EXCEEDED":                  52,
+}
+
+func (x ErrorCode) String() string {
+	return proto.EnumName(ErrorCode_name, int32(x))
+}
+func (ErrorCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type TopTalkersOrderBy int32
+
+const (
+	// Order by the number of uplink frames.
+	TopTalkersOrderBy_ORDER_BY_FRAMES TopTalkersOrderBy = 0
+	// Order by the number of uplink (PHYPayload) bytes.
+	TopTalkersOrderBy_ORDER_BY_BYTES TopTalkersOrderBy = 1
+)
+
+var TopTalkersOrderBy_name = map[int32]string{
+	0: "ORDER_BY_FRAMES",
+	1: "ORDER_BY_BYTES",
+}
+var TopTalkersOrderBy_value = map[string]int32{
+	"ORDER_BY_FRAMES": 0,
+	"ORDER_BY_BYTES":  1,
+}
+
+func (x TopTalkersOrderBy) String() string {
+	return proto.EnumName(TopTalkersOrderBy_name, int32(x))
+}
+func (TopTalkersOrderBy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type UplinkRuleAction int32
+
+const (
+	// Enqueue a LinkADRReq mac-command, requesting the dataRate and txPower
+	// of the rule.
+	UplinkRuleAction_ENQUEUE_LINK_ADR_REQ UplinkRuleAction = 0
+	// Enqueue the macCommand of the rule.
+	UplinkRuleAction_ENQUEUE_MAC_COMMAND UplinkRuleAction = 1
+	// Handle the node as Class-C device for the classCWindow of the rule.
+	UplinkRuleAction_OPEN_CLASS_C_WINDOW UplinkRuleAction = 2
+)
+
+var UplinkRuleAction_name = map[int32]string{
+	0: "ENQUEUE_LINK_ADR_REQ",
+	1: "ENQUEUE_MAC_COMMAND",
+	2: "OPEN_CLASS_C_WINDOW",
+}
+var UplinkRuleAction_value = map[string]int32{
+	"ENQUEUE_LINK_ADR_REQ": 0,
+	"ENQUEUE_MAC_COMMAND":  1,
+	"OPEN_CLASS_C_WINDOW":  2,
+}
+
+func (x UplinkRuleAction) String() string {
+	return proto.EnumName(UplinkRuleAction_name, int32(x))
+}
+func (UplinkRuleAction) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type Polarity int32
+
+const (
+	// The polarity is not set (inherited from the previous level).
+	Polarity_INHERIT_POLARITY Polarity = 0
+	// Normal (not inverted) polarity.
+	Polarity_NORMAL_POLARITY Polarity = 1
+	// Inverted polarity (the default for LoRa downlinks).
+	Polarity_INVERTED_POLARITY Polarity = 2
+)
+
+var Polarity_name = map[int32]string{
+	0: "INHERIT_POLARITY",
+	1: "NORMAL_POLARITY",
+	2: "INVERTED_POLARITY",
+}
+var Polarity_value = map[string]int32{
+	"INHERIT_POLARITY":  0,
+	"NORMAL_POLARITY":   1,
+	"INVERTED_POLARITY": 2,
+}
+
+func (x Polarity) String() string {
+	return proto.EnumName(Polarity_name, int32(x))
+}
+func (Polarity) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type DeviceClass int32
+
+const (
+	// The device class is not known (e.g. it has never been changed).
+	DeviceClass_UNKNOWN_CLASS DeviceClass = 0
+	// Class-A.
+	DeviceClass_CLASS_A DeviceClass = 1
+	// Class-C.
+	DeviceClass_CLASS_C DeviceClass = 2
+)
+
+var DeviceClass_name = map[int32]string{
+	0: "UNKNOWN_CLASS",
+	1: "CLASS_A",
+	2: "CLASS_C",
+}
+var DeviceClass_value = map[string]int32{
+	"UNKNOWN_CLASS": 0,
+	"CLASS_A":       1,
+	"CLASS_C":       2,
+}
+
+func (x DeviceClass) String() string {
+	return proto.EnumName(DeviceClass_name, int32(x))
+}
+func (DeviceClass) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type SortOrder int32
+
+const (
+	// Ascending sort order.
+	SortOrder_ASC SortOrder = 0
+	// Descending sort order.
+	SortOrder_DESC SortOrder = 1
+)
+
+var SortOrder_name = map[int32]string{
+	0: "ASC",
+	1: "DESC",
+}
+var SortOrder_value = map[string]int32{
+	"ASC":  0,
+	"DESC": 1,
+}
+
+func (x SortOrder) String() string {
+	return proto.EnumName(SortOrder_name, int32(x))
+}
+func (SortOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type GatewayOrderBy int32
+
+const (
+	// Order by MAC address.
+	GatewayOrderBy_ORDER_BY_MAC GatewayOrderBy = 0
+	// Order by name.
+	GatewayOrderBy_ORDER_BY_NAME GatewayOrderBy = 1
+	// Order by creation timestamp.
+	GatewayOrderBy_ORDER_BY_CREATED_AT GatewayOrderBy = 2
+	// Order by last-seen timestamp (gateways which have never been seen
+	// are returned last).
+	GatewayOrderBy_ORDER_BY_LAST_SEEN_AT GatewayOrderBy = 3
+)
+
+var GatewayOrderBy_name = map[int32]string{
+	0: "ORDER_BY_MAC",
+	1: "ORDER_BY_NAME",
+	2: "ORDER_BY_CREATED_AT",
+	3: "ORDER_BY_LAST_SEEN_AT",
+}
+var GatewayOrderBy_value = map[string]int32{
+	"ORDER_BY_MAC":          0,
+	"ORDER_BY_NAME":         1,
+	"ORDER_BY_CREATED_AT":   2,
+	"ORDER_BY_LAST_SEEN_AT": 3,
+}
+
+func (x GatewayOrderBy) String() string {
+	return proto.EnumName(GatewayOrderBy_name, int32(x))
+}
+func (GatewayOrderBy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
 type AggregationInterval int32
 
 const (
@@ -119,7 +748,7 @@ var AggregationInterval_value = map[string]int32{
 func (x AggregationInterval) String() string {
 	return proto.EnumName(AggregationInterval_name, int32(x))
 }
-func (AggregationInterval) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (AggregationInterval) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 type CreateNodeSessionRequest struct {
 	// The address of the device (4 bytes).
@@ -145,6 +774,9 @@ type CreateNodeSessionRequest struct {
 	RxWindow RXWindow `protobuf:"varint,10,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
 	// The data-rate to use for RX2 transmissions.
 	Rx2DR uint32 `protobuf:"varint,11,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The frequency (Hz) to use for RX2 transmissions (0 = the RX2 frequency
+	// of the band).
+	Rx2Frequency uint32 `protobuf:"varint,27,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
 	// Use relax frame-counter mode for ABP devices (this is insecure!).
 	RelaxFCnt bool `protobuf:"varint,12,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
 	// The interval (based on frame-counter) on which to calculate the ideal
@@ -153,6 +785,78 @@ type CreateNodeSessionRequest struct {
 	// The installation margin to take into account when calculating the ideal
 	// data-rate and tx-power. The default recommended value is 5dB.
 	InstallationMargin float64 `protobuf:"fixed64,14,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The ADR strategy to use for calculating the ideal data-rate and
+	// tx-power.
+	AdrStrategy ADRStrategy `protobuf:"varint,15,opt,name=adrStrategy,enum=ns.ADRStrategy" json:"adrStrategy,omitempty"`
+	// The node is a relay (LoRaWAN TS011). Uplinks on the relay FPort (226)
+	// are handled as forwarded uplinks of end-devices.
+	Relay bool `protobuf:"varint,16,opt,name=relay" json:"relay,omitempty"`
+	// The AppSKey, wrapped (RFC 3394) with the KEK configured in LoRa
+	// Server. When set, LoRa Server performs the FRMPayload encryption and
+	// decryption and exchanges plaintext payloads with the
+	// application-server.
+	WrappedAppSKey []byte `protobuf:"bytes,17,opt,name=wrappedAppSKey,proto3" json:"wrappedAppSKey,omitempty"`
+	// The gateway regions via which downlinks for the node may be
+	// transmitted (geofencing). When empty, all gateways may be used.
+	GatewayRegions []string `protobuf:"bytes,18,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	// The TX power (dBm) of downlinks to the node, overriding the TX power
+	// of the band and gateway (0 = not set).
+	DownlinkTXPower uint32 `protobuf:"varint,19,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks to the node, overriding the code
+	// rate of the band and gateway (empty = not set).
+	DownlinkCodeRate string `protobuf:"bytes,20,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// The polarity of downlinks to the node, overriding the polarity of the
+	// band and gateway.
+	DownlinkPolarity Polarity `protobuf:"varint,21,opt,name=downlinkPolarity,enum=ns.Polarity" json:"downlinkPolarity,omitempty"`
+	// Confirmed downlinks which are not marked critical are sent as
+	// unconfirmed downlinks when the battery level last reported by the node
+	// (DevStatusAns, 1 - 254) is below this level (0 = disabled).
+	BatteryThrottleLevel uint32 `protobuf:"varint,22,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	// Uplinks on this FPort signal that the node is temporarily available as
+	// Class-C device (e.g. while mains-powered), see classCWindow
+	// (0 = disabled).
+	ClassCFPort uint32 `protobuf:"varint,23,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device after an
+	// uplink on the classCFPort.
+	ClassCWindow uint32 `protobuf:"varint,24,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Transmit critical confirmed downlinks (in response to an uplink) a
+	// second time via the second best gateway, using the other RX window
+	// (transmit diversity). This improves the delivery probability at the
+	// cost of airtime.
+	TransmitDiversity bool `protobuf:"varint,25,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day (fair
+	// use, 0 = no cap). When reached, the application payloads are left in
+	// the queue until the next day (see GetDeviceDownlinkAirtime).
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,26,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// Operator-defined tags of the node (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,28,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// LoRaWAN MAC version of the node (1.0.0, 1.0.1, 1.0.2 or 1.0.3,
+	// default 1.0.2), mac-commands not supported by this version are not
+	// sent to the node.
+	MacVersion string `protobuf:"bytes,29,opt,name=macVersion" json:"macVersion,omitempty"`
+	// Resolve the location of the node from the fine-timestamps of the
+	// receiving gateways (TDOA), the resolved location is sent to the
+	// application-server (SetDeviceLocation).
+	Geolocation bool `protobuf:"varint,30,opt,name=geolocation" json:"geolocation,omitempty"`
+	// ID of the channel-configuration (channel plan) to which the uplink
+	// channels of the node are reconciled (0 = none).
+	ChannelConfigurationID int64 `protobuf:"varint,31,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Include the raw PHYPayload of the uplink frames in the HandleDataUp
+	// calls to the application-server.
+	ForwardPHYPayload bool `protobuf:"varint,32,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	// Max. number of uplink frames forwarded per hour to the
+	// application-server, the uplink frames exceeding this limit are
+	// counted but not forwarded (0 = unlimited).
+	MaxUplinksPerHour uint32 `protobuf:"varint,33,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	// Legacy (LoRaWAN 1.0.1) ADRACKReq handling, for nodes continuously
+	// setting the ADRACKReq bit or only resetting their ADR_ACK_CNT on a
+	// downlink with the ADR bit set. An ADRACKReq is then responded at most
+	// once per 32 uplinks and the ADR bit is set in all downlinks.
+	LegacyADRACKReq bool `protobuf:"varint,34,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	// ID of the device-profile of the node, defining the downlink queue size
+	// and airtime quota (0 = none).
+	DeviceProfileID int64 `protobuf:"varint,35,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 }
 
 func (m *CreateNodeSessionRequest) Reset()                    { *m = CreateNodeSessionRequest{} }
@@ -237,6 +941,13 @@ func (m *CreateNodeSessionRequest) GetRx2DR() uint32 {
 	return 0
 }
 
+func (m *CreateNodeSessionRequest) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
 func (m *CreateNodeSessionRequest) GetRelaxFCnt() bool {
 	if m != nil {
 		return m.RelaxFCnt
@@ -258,6 +969,146 @@ func (m *CreateNodeSessionRequest) GetInstallationMargin() float64 {
 	return 0
 }
 
+func (m *CreateNodeSessionRequest) GetAdrStrategy() ADRStrategy {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
+}
+
+func (m *CreateNodeSessionRequest) GetRelay() bool {
+	if m != nil {
+		return m.Relay
+	}
+	return false
+}
+
+func (m *CreateNodeSessionRequest) GetWrappedAppSKey() []byte {
+	if m != nil {
+		return m.WrappedAppSKey
+	}
+	return nil
+}
+
+func (m *CreateNodeSessionRequest) GetGatewayRegions() []string {
+	if m != nil {
+		return m.GatewayRegions
+	}
+	return nil
+}
+
+func (m *CreateNodeSessionRequest) GetDownlinkTXPower() uint32 {
+	if m != nil {
+		return m.DownlinkTXPower
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetDownlinkCodeRate() string {
+	if m != nil {
+		return m.DownlinkCodeRate
+	}
+	return ""
+}
+
+func (m *CreateNodeSessionRequest) GetDownlinkPolarity() Polarity {
+	if m != nil {
+		return m.DownlinkPolarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *CreateNodeSessionRequest) GetBatteryThrottleLevel() uint32 {
+	if m != nil {
+		return m.BatteryThrottleLevel
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetClassCFPort() uint32 {
+	if m != nil {
+		return m.ClassCFPort
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetClassCWindow() uint32 {
+	if m != nil {
+		return m.ClassCWindow
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetTransmitDiversity() bool {
+	if m != nil {
+		return m.TransmitDiversity
+	}
+	return false
+}
+
+func (m *CreateNodeSessionRequest) GetDailyDownlinkAirtimeCap() uint32 {
+	if m != nil {
+		return m.DailyDownlinkAirtimeCap
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *CreateNodeSessionRequest) GetMacVersion() string {
+	if m != nil {
+		return m.MacVersion
+	}
+	return ""
+}
+
+func (m *CreateNodeSessionRequest) GetGeolocation() bool {
+	if m != nil {
+		return m.Geolocation
+	}
+	return false
+}
+
+func (m *CreateNodeSessionRequest) GetChannelConfigurationID() int64 {
+	if m != nil {
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetForwardPHYPayload() bool {
+	if m != nil {
+		return m.ForwardPHYPayload
+	}
+	return false
+}
+
+func (m *CreateNodeSessionRequest) GetMaxUplinksPerHour() uint32 {
+	if m != nil {
+		return m.MaxUplinksPerHour
+	}
+	return 0
+}
+
+func (m *CreateNodeSessionRequest) GetLegacyADRACKReq() bool {
+	if m != nil {
+		return m.LegacyADRACKReq
+	}
+	return false
+}
+
+func (m *CreateNodeSessionRequest) GetDeviceProfileID() int64 {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return 0
+}
+
 type CreateNodeSessionResponse struct {
 }
 
@@ -306,6 +1157,9 @@ type GetNodeSessionResponse struct {
 	RxWindow RXWindow `protobuf:"varint,10,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
 	// The data-rate to use for RX2 transmissions.
 	Rx2DR uint32 `protobuf:"varint,11,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The frequency (Hz) to use for RX2 transmissions (0 = the RX2 frequency
+	// of the band).
+	Rx2Frequency uint32 `protobuf:"varint,34,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
 	// Use relax frame-counter mode for ABP devices (this is insecure!).
 	RelaxFCnt bool `protobuf:"varint,12,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
 	// The interval (based on frame-counter) on which to calculate the ideal
@@ -319,6 +1173,90 @@ type GetNodeSessionResponse struct {
 	NbTrans uint32 `protobuf:"varint,15,opt,name=nbTrans" json:"nbTrans,omitempty"`
 	// The TX power of the node. This is controlled by the ADR engine.
 	TxPower uint32 `protobuf:"varint,16,opt,name=txPower" json:"txPower,omitempty"`
+	// The ADR strategy to use for calculating the ideal data-rate and
+	// tx-power.
+	AdrStrategy ADRStrategy `protobuf:"varint,17,opt,name=adrStrategy,enum=ns.ADRStrategy" json:"adrStrategy,omitempty"`
+	// The node is a relay (LoRaWAN TS011). Uplinks on the relay FPort (226)
+	// are handled as forwarded uplinks of end-devices.
+	Relay bool `protobuf:"varint,18,opt,name=relay" json:"relay,omitempty"`
+	// The (confirmed) device class of the node.
+	DeviceClass DeviceClass `protobuf:"varint,19,opt,name=deviceClass,enum=ns.DeviceClass" json:"deviceClass,omitempty"`
+	// The device class the node is switching to (UNKNOWN_CLASS when no
+	// change is pending).
+	PendingDeviceClass DeviceClass `protobuf:"varint,20,opt,name=pendingDeviceClass,enum=ns.DeviceClass" json:"pendingDeviceClass,omitempty"`
+	// The gateway regions via which downlinks for the node may be
+	// transmitted (geofencing). When empty, all gateways may be used.
+	GatewayRegions []string `protobuf:"bytes,21,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	// The TX power (dBm) of downlinks to the node, overriding the TX power
+	// of the band and gateway (0 = not set).
+	DownlinkTXPower uint32 `protobuf:"varint,22,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks to the node, overriding the code
+	// rate of the band and gateway (empty = not set).
+	DownlinkCodeRate string `protobuf:"bytes,23,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// The polarity of downlinks to the node, overriding the polarity of the
+	// band and gateway.
+	DownlinkPolarity Polarity `protobuf:"varint,24,opt,name=downlinkPolarity,enum=ns.Polarity" json:"downlinkPolarity,omitempty"`
+	// Confirmed downlinks which are not marked critical are sent as
+	// unconfirmed downlinks when the battery level last reported by the node
+	// (DevStatusAns, 1 - 254) is below this level (0 = disabled).
+	BatteryThrottleLevel uint32 `protobuf:"varint,25,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	// The battery level last reported by the node (DevStatusAns). 0 = the
+	// node is connected to an external power source, 1 - 254 = the battery
+	// level, 255 = the node was not able to measure the battery level.
+	BatteryLevel uint32 `protobuf:"varint,26,opt,name=batteryLevel" json:"batteryLevel,omitempty"`
+	// Timestamp (RFC3339Nano) of the last reported battery level (empty
+	// when no battery level has been reported).
+	BatteryLevelUpdatedAt string `protobuf:"bytes,27,opt,name=batteryLevelUpdatedAt" json:"batteryLevelUpdatedAt,omitempty"`
+	// Uplinks on this FPort signal that the node is temporarily available as
+	// Class-C device (e.g. while mains-powered), see classCWindow
+	// (0 = disabled).
+	ClassCFPort uint32 `protobuf:"varint,28,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device after an
+	// uplink on the classCFPort.
+	ClassCWindow uint32 `protobuf:"varint,29,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Timestamp (RFC3339Nano) until which the node is handled as Class-C
+	// device after an uplink on the classCFPort (empty when not set).
+	ClassCUntil string `protobuf:"bytes,30,opt,name=classCUntil" json:"classCUntil,omitempty"`
+	// Transmit critical confirmed downlinks (in response to an uplink) a
+	// second time via the second best gateway, using the other RX window
+	// (transmit diversity). This improves the delivery probability at the
+	// cost of airtime.
+	TransmitDiversity bool `protobuf:"varint,31,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day (fair
+	// use, 0 = no cap). When reached, the application payloads are left in
+	// the queue until the next day (see GetDeviceDownlinkAirtime).
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,32,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// The version of the node-session, incremented on every update.
+	Version uint64 `protobuf:"varint,33,opt,name=version" json:"version,omitempty"`
+	// Operator-defined tags of the node (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,35,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// LoRaWAN MAC version of the node.
+	MacVersion string `protobuf:"bytes,36,opt,name=macVersion" json:"macVersion,omitempty"`
+	// The location of the node is resolved from the fine-timestamps of the
+	// receiving gateways.
+	Geolocation bool `protobuf:"varint,37,opt,name=geolocation" json:"geolocation,omitempty"`
+	// ID of the channel-configuration of the node (0 = none).
+	ChannelConfigurationID int64 `protobuf:"varint,38,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// The uplink channels of the node, as acknowledged by the node.
+	UplinkChannels []*UplinkChannel `protobuf:"bytes,39,rep,name=uplinkChannels" json:"uplinkChannels,omitempty"`
+	// The raw PHYPayload of the uplink frames is included in the
+	// HandleDataUp calls to the application-server.
+	ForwardPHYPayload bool `protobuf:"varint,40,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	// Max. number of uplink frames forwarded per hour to the
+	// application-server, the uplink frames exceeding this limit are
+	// counted but not forwarded (0 = unlimited).
+	MaxUplinksPerHour uint32 `protobuf:"varint,41,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	// Legacy (LoRaWAN 1.0.1) ADRACKReq handling, for nodes continuously
+	// setting the ADRACKReq bit or only resetting their ADR_ACK_CNT on a
+	// downlink with the ADR bit set. An ADRACKReq is then responded at most
+	// once per 32 uplinks and the ADR bit is set in all downlinks.
+	LegacyADRACKReq bool `protobuf:"varint,42,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	// Indices of the uplink channels to which the channels of the node are
+	// restricted (see SetDeviceChannelMask), empty when not set.
+	ChannelMask []uint32 `protobuf:"varint,43,rep,packed,name=channelMask" json:"channelMask,omitempty"`
+	// ID of the device-profile of the node (0 = none).
+	DeviceProfileID int64 `protobuf:"varint,44,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 }
 
 func (m *GetNodeSessionResponse) Reset()                    { *m = GetNodeSessionResponse{} }
@@ -403,6 +1341,13 @@ func (m *GetNodeSessionResponse) GetRx2DR() uint32 {
 	return 0
 }
 
+func (m *GetNodeSessionResponse) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
 func (m *GetNodeSessionResponse) GetRelaxFCnt() bool {
 	if m != nil {
 		return m.RelaxFCnt
@@ -438,1215 +1383,12290 @@ func (m *GetNodeSessionResponse) GetTxPower() uint32 {
 	return 0
 }
 
-type UpdateNodeSessionRequest struct {
-	// The address of the device (4 bytes).
-	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
-	// The application EUI (8 bytes).
-	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
-	// The device EUI (8 bytes).
-	DevEUI []byte `protobuf:"bytes,3,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
-	// The network-session key (16 bytes).
-	NwkSKey []byte `protobuf:"bytes,4,opt,name=nwkSKey,proto3" json:"nwkSKey,omitempty"`
-	// The next expected uplink frame-counter.
-	FCntUp uint32 `protobuf:"varint,5,opt,name=fCntUp" json:"fCntUp,omitempty"`
-	// The frame-counter used for the next downlink frame.
-	FCntDown uint32 `protobuf:"varint,6,opt,name=fCntDown" json:"fCntDown,omitempty"`
-	// the RX delay value (0 = 1 sec, 1 = 1 sec, 2 = 2 sec ...).
-	RxDelay uint32 `protobuf:"varint,7,opt,name=rxDelay" json:"rxDelay,omitempty"`
-	// The data-rate offset used for RX1 (see LoRaWAN specs for valid values).
-	Rx1DROffset uint32 `protobuf:"varint,8,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
-	// The custom channel frequency list (this is not supported for every
-	// ISM band, see the LoRaWAN specs for more information).
-	CFList []uint32 `protobuf:"varint,9,rep,packed,name=cFList" json:"cFList,omitempty"`
-	// The RX window to use for downlink transmissions.
-	RxWindow RXWindow `protobuf:"varint,10,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
-	// The data-rate to use for RX2 transmissions.
-	Rx2DR uint32 `protobuf:"varint,11,opt,name=rx2DR" json:"rx2DR,omitempty"`
-	// Use relax frame-counter mode for ABP devices (this is insecure!).
-	RelaxFCnt bool `protobuf:"varint,12,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
-	// The interval (based on frame-counter) on which to calculate the ideal
-	// data-rate and tx-power of the node and if needed, request an adaption.
-	AdrInterval uint32 `protobuf:"varint,13,opt,name=adrInterval" json:"adrInterval,omitempty"`
-	// The installation margin to take into account when calculating the ideal
-	// data-rate and tx-power. The default recommended value is 5dB.
-	InstallationMargin float64 `protobuf:"fixed64,14,opt,name=installationMargin" json:"installationMargin,omitempty"`
+func (m *GetNodeSessionResponse) GetAdrStrategy() ADRStrategy {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
 }
 
-func (m *UpdateNodeSessionRequest) Reset()                    { *m = UpdateNodeSessionRequest{} }
-func (m *UpdateNodeSessionRequest) String() string            { return proto.CompactTextString(m) }
-func (*UpdateNodeSessionRequest) ProtoMessage()               {}
-func (*UpdateNodeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
-
-func (m *UpdateNodeSessionRequest) GetDevAddr() []byte {
+func (m *GetNodeSessionResponse) GetRelay() bool {
 	if m != nil {
-		return m.DevAddr
+		return m.Relay
 	}
-	return nil
+	return false
 }
 
-func (m *UpdateNodeSessionRequest) GetAppEUI() []byte {
+func (m *GetNodeSessionResponse) GetDeviceClass() DeviceClass {
 	if m != nil {
-		return m.AppEUI
+		return m.DeviceClass
 	}
-	return nil
+	return DeviceClass_UNKNOWN_CLASS
 }
 
-func (m *UpdateNodeSessionRequest) GetDevEUI() []byte {
+func (m *GetNodeSessionResponse) GetPendingDeviceClass() DeviceClass {
 	if m != nil {
-		return m.DevEUI
+		return m.PendingDeviceClass
 	}
-	return nil
+	return DeviceClass_UNKNOWN_CLASS
 }
 
-func (m *UpdateNodeSessionRequest) GetNwkSKey() []byte {
+func (m *GetNodeSessionResponse) GetGatewayRegions() []string {
 	if m != nil {
-		return m.NwkSKey
+		return m.GatewayRegions
 	}
 	return nil
 }
 
-func (m *UpdateNodeSessionRequest) GetFCntUp() uint32 {
+func (m *GetNodeSessionResponse) GetDownlinkTXPower() uint32 {
 	if m != nil {
-		return m.FCntUp
+		return m.DownlinkTXPower
 	}
 	return 0
 }
 
-func (m *UpdateNodeSessionRequest) GetFCntDown() uint32 {
+func (m *GetNodeSessionResponse) GetDownlinkCodeRate() string {
 	if m != nil {
-		return m.FCntDown
+		return m.DownlinkCodeRate
 	}
-	return 0
+	return ""
 }
 
-func (m *UpdateNodeSessionRequest) GetRxDelay() uint32 {
+func (m *GetNodeSessionResponse) GetDownlinkPolarity() Polarity {
 	if m != nil {
-		return m.RxDelay
+		return m.DownlinkPolarity
 	}
-	return 0
+	return Polarity_INHERIT_POLARITY
 }
 
-func (m *UpdateNodeSessionRequest) GetRx1DROffset() uint32 {
+func (m *GetNodeSessionResponse) GetBatteryThrottleLevel() uint32 {
 	if m != nil {
-		return m.Rx1DROffset
+		return m.BatteryThrottleLevel
 	}
 	return 0
 }
 
-func (m *UpdateNodeSessionRequest) GetCFList() []uint32 {
+func (m *GetNodeSessionResponse) GetBatteryLevel() uint32 {
 	if m != nil {
-		return m.CFList
+		return m.BatteryLevel
 	}
-	return nil
+	return 0
 }
 
-func (m *UpdateNodeSessionRequest) GetRxWindow() RXWindow {
+func (m *GetNodeSessionResponse) GetBatteryLevelUpdatedAt() string {
 	if m != nil {
-		return m.RxWindow
+		return m.BatteryLevelUpdatedAt
 	}
-	return RXWindow_RX1
+	return ""
 }
 
-func (m *UpdateNodeSessionRequest) GetRx2DR() uint32 {
+func (m *GetNodeSessionResponse) GetClassCFPort() uint32 {
 	if m != nil {
-		return m.Rx2DR
+		return m.ClassCFPort
 	}
 	return 0
 }
 
-func (m *UpdateNodeSessionRequest) GetRelaxFCnt() bool {
+func (m *GetNodeSessionResponse) GetClassCWindow() uint32 {
 	if m != nil {
-		return m.RelaxFCnt
+		return m.ClassCWindow
 	}
-	return false
+	return 0
 }
 
-func (m *UpdateNodeSessionRequest) GetAdrInterval() uint32 {
+func (m *GetNodeSessionResponse) GetClassCUntil() string {
 	if m != nil {
-		return m.AdrInterval
+		return m.ClassCUntil
 	}
-	return 0
+	return ""
 }
 
-func (m *UpdateNodeSessionRequest) GetInstallationMargin() float64 {
+func (m *GetNodeSessionResponse) GetTransmitDiversity() bool {
 	if m != nil {
-		return m.InstallationMargin
+		return m.TransmitDiversity
+	}
+	return false
+}
+
+func (m *GetNodeSessionResponse) GetDailyDownlinkAirtimeCap() uint32 {
+	if m != nil {
+		return m.DailyDownlinkAirtimeCap
 	}
 	return 0
 }
 
-type UpdateNodeSessionResponse struct {
+func (m *GetNodeSessionResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
 
-func (m *UpdateNodeSessionResponse) Reset()                    { *m = UpdateNodeSessionResponse{} }
-func (m *UpdateNodeSessionResponse) String() string            { return proto.CompactTextString(m) }
-func (*UpdateNodeSessionResponse) ProtoMessage()               {}
-func (*UpdateNodeSessionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *GetNodeSessionResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
 
-type DeleteNodeSessionRequest struct {
-	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+func (m *GetNodeSessionResponse) GetMacVersion() string {
+	if m != nil {
+		return m.MacVersion
+	}
+	return ""
 }
 
-func (m *DeleteNodeSessionRequest) Reset()                    { *m = DeleteNodeSessionRequest{} }
-func (m *DeleteNodeSessionRequest) String() string            { return proto.CompactTextString(m) }
-func (*DeleteNodeSessionRequest) ProtoMessage()               {}
-func (*DeleteNodeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (m *GetNodeSessionResponse) GetGeolocation() bool {
+	if m != nil {
+		return m.Geolocation
+	}
+	return false
+}
 
-func (m *DeleteNodeSessionRequest) GetDevEUI() []byte {
+func (m *GetNodeSessionResponse) GetChannelConfigurationID() int64 {
 	if m != nil {
-		return m.DevEUI
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *GetNodeSessionResponse) GetUplinkChannels() []*UplinkChannel {
+	if m != nil {
+		return m.UplinkChannels
 	}
 	return nil
 }
 
-type DeleteNodeSessionResponse struct {
+func (m *GetNodeSessionResponse) GetForwardPHYPayload() bool {
+	if m != nil {
+		return m.ForwardPHYPayload
+	}
+	return false
 }
 
-func (m *DeleteNodeSessionResponse) Reset()                    { *m = DeleteNodeSessionResponse{} }
-func (m *DeleteNodeSessionResponse) String() string            { return proto.CompactTextString(m) }
-func (*DeleteNodeSessionResponse) ProtoMessage()               {}
-func (*DeleteNodeSessionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (m *GetNodeSessionResponse) GetMaxUplinksPerHour() uint32 {
+	if m != nil {
+		return m.MaxUplinksPerHour
+	}
+	return 0
+}
 
-type GetRandomDevAddrRequest struct {
+func (m *GetNodeSessionResponse) GetLegacyADRACKReq() bool {
+	if m != nil {
+		return m.LegacyADRACKReq
+	}
+	return false
 }
 
-func (m *GetRandomDevAddrRequest) Reset()                    { *m = GetRandomDevAddrRequest{} }
-func (m *GetRandomDevAddrRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetRandomDevAddrRequest) ProtoMessage()               {}
-func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (m *GetNodeSessionResponse) GetChannelMask() []uint32 {
+	if m != nil {
+		return m.ChannelMask
+	}
+	return nil
+}
 
-type GetRandomDevAddrResponse struct {
+func (m *GetNodeSessionResponse) GetDeviceProfileID() int64 {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return 0
+}
+
+type UpdateNodeSessionRequest struct {
+	// The address of the device (4 bytes).
 	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+	// The application EUI (8 bytes).
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,3,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The network-session key (16 bytes).
+	NwkSKey []byte `protobuf:"bytes,4,opt,name=nwkSKey,proto3" json:"nwkSKey,omitempty"`
+	// The next expected uplink frame-counter.
+	FCntUp uint32 `protobuf:"varint,5,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	// The frame-counter used for the next downlink frame.
+	FCntDown uint32 `protobuf:"varint,6,opt,name=fCntDown" json:"fCntDown,omitempty"`
+	// the RX delay value (0 = 1 sec, 1 = 1 sec, 2 = 2 sec ...).
+	RxDelay uint32 `protobuf:"varint,7,opt,name=rxDelay" json:"rxDelay,omitempty"`
+	// The data-rate offset used for RX1 (see LoRaWAN specs for valid values).
+	Rx1DROffset uint32 `protobuf:"varint,8,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
+	// The custom channel frequency list (this is not supported for every
+	// ISM band, see the LoRaWAN specs for more information).
+	CFList []uint32 `protobuf:"varint,9,rep,packed,name=cFList" json:"cFList,omitempty"`
+	// The RX window to use for downlink transmissions.
+	RxWindow RXWindow `protobuf:"varint,10,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
+	// The data-rate to use for RX2 transmissions.
+	Rx2DR uint32 `protobuf:"varint,11,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The frequency (Hz) to use for RX2 transmissions (0 = the RX2 frequency
+	// of the band).
+	Rx2Frequency uint32 `protobuf:"varint,28,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
+	// Use relax frame-counter mode for ABP devices (this is insecure!).
+	RelaxFCnt bool `protobuf:"varint,12,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
+	// The interval (based on frame-counter) on which to calculate the ideal
+	// data-rate and tx-power of the node and if needed, request an adaption.
+	AdrInterval uint32 `protobuf:"varint,13,opt,name=adrInterval" json:"adrInterval,omitempty"`
+	// The installation margin to take into account when calculating the ideal
+	// data-rate and tx-power. The default recommended value is 5dB.
+	InstallationMargin float64 `protobuf:"fixed64,14,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The ADR strategy to use for calculating the ideal data-rate and
+	// tx-power.
+	AdrStrategy ADRStrategy `protobuf:"varint,15,opt,name=adrStrategy,enum=ns.ADRStrategy" json:"adrStrategy,omitempty"`
+	// The node is a relay (LoRaWAN TS011). Uplinks on the relay FPort (226)
+	// are handled as forwarded uplinks of end-devices.
+	Relay bool `protobuf:"varint,16,opt,name=relay" json:"relay,omitempty"`
+	// The AppSKey, wrapped (RFC 3394) with the KEK configured in LoRa
+	// Server. When set, LoRa Server performs the FRMPayload encryption and
+	// decryption and exchanges plaintext payloads with the
+	// application-server.
+	WrappedAppSKey []byte `protobuf:"bytes,17,opt,name=wrappedAppSKey,proto3" json:"wrappedAppSKey,omitempty"`
+	// The gateway regions via which downlinks for the node may be
+	// transmitted (geofencing). When empty, all gateways may be used.
+	GatewayRegions []string `protobuf:"bytes,18,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	// The TX power (dBm) of downlinks to the node, overriding the TX power
+	// of the band and gateway (0 = not set).
+	DownlinkTXPower uint32 `protobuf:"varint,19,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks to the node, overriding the code
+	// rate of the band and gateway (empty = not set).
+	DownlinkCodeRate string `protobuf:"bytes,20,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// The polarity of downlinks to the node, overriding the polarity of the
+	// band and gateway.
+	DownlinkPolarity Polarity `protobuf:"varint,21,opt,name=downlinkPolarity,enum=ns.Polarity" json:"downlinkPolarity,omitempty"`
+	// Confirmed downlinks which are not marked critical are sent as
+	// unconfirmed downlinks when the battery level last reported by the node
+	// (DevStatusAns, 1 - 254) is below this level (0 = disabled).
+	BatteryThrottleLevel uint32 `protobuf:"varint,22,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	// Uplinks on this FPort signal that the node is temporarily available as
+	// Class-C device (e.g. while mains-powered), see classCWindow
+	// (0 = disabled).
+	ClassCFPort uint32 `protobuf:"varint,23,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device after an
+	// uplink on the classCFPort.
+	ClassCWindow uint32 `protobuf:"varint,24,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Transmit critical confirmed downlinks (in response to an uplink) a
+	// second time via the second best gateway, using the other RX window
+	// (transmit diversity). This improves the delivery probability at the
+	// cost of airtime.
+	TransmitDiversity bool `protobuf:"varint,25,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day (fair
+	// use, 0 = no cap). When reached, the application payloads are left in
+	// the queue until the next day (see GetDeviceDownlinkAirtime).
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,26,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// The version of the node-session (as returned by GetNodeSession) on
+	// which the update is based. When set, the update is rejected with
+	// NODE_SESSION_CONCURRENT_UPDATE when the node-session has been updated
+	// in the meantime (optional).
+	Version uint64 `protobuf:"varint,27,opt,name=version" json:"version,omitempty"`
+	// Operator-defined tags of the node (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,29,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// LoRaWAN MAC version of the node (1.0.0, 1.0.1, 1.0.2 or 1.0.3,
+	// default 1.0.2), mac-commands not supported by this version are not
+	// sent to the node.
+	MacVersion string `protobuf:"bytes,30,opt,name=macVersion" json:"macVersion,omitempty"`
+	// Resolve the location of the node from the fine-timestamps of the
+	// receiving gateways (TDOA), the resolved location is sent to the
+	// application-server (SetDeviceLocation).
+	Geolocation bool `protobuf:"varint,31,opt,name=geolocation" json:"geolocation,omitempty"`
+	// ID of the channel-configuration (channel plan) to which the uplink
+	// channels of the node are reconciled (0 = none).
+	ChannelConfigurationID int64 `protobuf:"varint,32,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Include the raw PHYPayload of the uplink frames in the HandleDataUp
+	// calls to the application-server.
+	ForwardPHYPayload bool `protobuf:"varint,33,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	// Max. number of uplink frames forwarded per hour to the
+	// application-server, the uplink frames exceeding this limit are
+	// counted but not forwarded (0 = unlimited).
+	MaxUplinksPerHour uint32 `protobuf:"varint,34,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	// Legacy (LoRaWAN 1.0.1) ADRACKReq handling, for nodes continuously
+	// setting the ADRACKReq bit or only resetting their ADR_ACK_CNT on a
+	// downlink with the ADR bit set. An ADRACKReq is then responded at most
+	// once per 32 uplinks and the ADR bit is set in all downlinks.
+	LegacyADRACKReq bool `protobuf:"varint,35,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	// ID of the device-profile of the node, defining the downlink queue size
+	// and airtime quota (0 = none).
+	DeviceProfileID int64 `protobuf:"varint,36,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 }
 
-func (m *GetRandomDevAddrResponse) Reset()                    { *m = GetRandomDevAddrResponse{} }
-func (m *GetRandomDevAddrResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetRandomDevAddrResponse) ProtoMessage()               {}
-func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (m *UpdateNodeSessionRequest) Reset()                    { *m = UpdateNodeSessionRequest{} }
+func (m *UpdateNodeSessionRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeSessionRequest) ProtoMessage()               {}
+func (*UpdateNodeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+func (m *UpdateNodeSessionRequest) GetDevAddr() []byte {
 	if m != nil {
 		return m.DevAddr
 	}
 	return nil
 }
 
-type EnqueueDataDownMACCommandRequest struct {
-	DevEUI     []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
-	FrmPayload bool   `protobuf:"varint,2,opt,name=frmPayload" json:"frmPayload,omitempty"`
-	Data       []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+func (m *UpdateNodeSessionRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
 }
 
-func (m *EnqueueDataDownMACCommandRequest) Reset()         { *m = EnqueueDataDownMACCommandRequest{} }
-func (m *EnqueueDataDownMACCommandRequest) String() string { return proto.CompactTextString(m) }
-func (*EnqueueDataDownMACCommandRequest) ProtoMessage()    {}
-func (*EnqueueDataDownMACCommandRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10}
+func (m *UpdateNodeSessionRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
 }
 
-func (m *EnqueueDataDownMACCommandRequest) GetDevEUI() []byte {
+func (m *UpdateNodeSessionRequest) GetNwkSKey() []byte {
 	if m != nil {
-		return m.DevEUI
+		return m.NwkSKey
 	}
 	return nil
 }
 
-func (m *EnqueueDataDownMACCommandRequest) GetFrmPayload() bool {
+func (m *UpdateNodeSessionRequest) GetFCntUp() uint32 {
 	if m != nil {
-		return m.FrmPayload
+		return m.FCntUp
 	}
-	return false
+	return 0
 }
 
-func (m *EnqueueDataDownMACCommandRequest) GetData() []byte {
+func (m *UpdateNodeSessionRequest) GetFCntDown() uint32 {
 	if m != nil {
-		return m.Data
+		return m.FCntDown
 	}
-	return nil
+	return 0
 }
 
-type EnqueueDataDownMACCommandResponse struct {
+func (m *UpdateNodeSessionRequest) GetRxDelay() uint32 {
+	if m != nil {
+		return m.RxDelay
+	}
+	return 0
 }
 
-func (m *EnqueueDataDownMACCommandResponse) Reset()         { *m = EnqueueDataDownMACCommandResponse{} }
-func (m *EnqueueDataDownMACCommandResponse) String() string { return proto.CompactTextString(m) }
-func (*EnqueueDataDownMACCommandResponse) ProtoMessage()    {}
-func (*EnqueueDataDownMACCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{11}
+func (m *UpdateNodeSessionRequest) GetRx1DROffset() uint32 {
+	if m != nil {
+		return m.Rx1DROffset
+	}
+	return 0
 }
 
-type PushDataDownRequest struct {
-	// DevEUI of the node to which to push the data.
-	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
-	// Data (encrypted with the AppSKey) to push to the node.
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	// Payload must be acknowledged by the node.
-	Confirmed bool `protobuf:"varint,3,opt,name=confirmed" json:"confirmed,omitempty"`
-	// FPort to use for transmitting the payload.
-	FPort uint32 `protobuf:"varint,4,opt,name=fPort" json:"fPort,omitempty"`
-	// FCnt used for encrypting the data. When this does not match the FCntDown
-	// of the network-server, an error is returned.
-	FCnt uint32 `protobuf:"varint,5,opt,name=fCnt" json:"fCnt,omitempty"`
+func (m *UpdateNodeSessionRequest) GetCFList() []uint32 {
+	if m != nil {
+		return m.CFList
+	}
+	return nil
 }
 
-func (m *PushDataDownRequest) Reset()                    { *m = PushDataDownRequest{} }
-func (m *PushDataDownRequest) String() string            { return proto.CompactTextString(m) }
-func (*PushDataDownRequest) ProtoMessage()               {}
-func (*PushDataDownRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *UpdateNodeSessionRequest) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
+}
 
-func (m *PushDataDownRequest) GetDevEUI() []byte {
+func (m *UpdateNodeSessionRequest) GetRx2DR() uint32 {
 	if m != nil {
-		return m.DevEUI
+		return m.Rx2DR
 	}
-	return nil
+	return 0
 }
 
-func (m *PushDataDownRequest) GetData() []byte {
+func (m *UpdateNodeSessionRequest) GetRx2Frequency() uint32 {
 	if m != nil {
-		return m.Data
+		return m.Rx2Frequency
 	}
-	return nil
+	return 0
 }
 
-func (m *PushDataDownRequest) GetConfirmed() bool {
+func (m *UpdateNodeSessionRequest) GetRelaxFCnt() bool {
 	if m != nil {
-		return m.Confirmed
+		return m.RelaxFCnt
 	}
 	return false
 }
 
-func (m *PushDataDownRequest) GetFPort() uint32 {
+func (m *UpdateNodeSessionRequest) GetAdrInterval() uint32 {
 	if m != nil {
-		return m.FPort
+		return m.AdrInterval
 	}
 	return 0
 }
 
-func (m *PushDataDownRequest) GetFCnt() uint32 {
+func (m *UpdateNodeSessionRequest) GetInstallationMargin() float64 {
 	if m != nil {
-		return m.FCnt
+		return m.InstallationMargin
 	}
 	return 0
 }
 
-type PushDataDownResponse struct {
+func (m *UpdateNodeSessionRequest) GetAdrStrategy() ADRStrategy {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
 }
 
-func (m *PushDataDownResponse) Reset()                    { *m = PushDataDownResponse{} }
-func (m *PushDataDownResponse) String() string            { return proto.CompactTextString(m) }
-func (*PushDataDownResponse) ProtoMessage()               {}
-func (*PushDataDownResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
-
-type CreateGatewayRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-	// Name of the gateway.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// Description for the gateway.
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// Latitude of the gateway.
-	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
-	// Longitude of the gateway.
-	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
-	// Altitude of the gateway.
-	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+func (m *UpdateNodeSessionRequest) GetRelay() bool {
+	if m != nil {
+		return m.Relay
+	}
+	return false
 }
 
-func (m *CreateGatewayRequest) Reset()                    { *m = CreateGatewayRequest{} }
-func (m *CreateGatewayRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateGatewayRequest) ProtoMessage()               {}
-func (*CreateGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *UpdateNodeSessionRequest) GetWrappedAppSKey() []byte {
+	if m != nil {
+		return m.WrappedAppSKey
+	}
+	return nil
+}
 
-func (m *CreateGatewayRequest) GetMac() []byte {
+func (m *UpdateNodeSessionRequest) GetGatewayRegions() []string {
 	if m != nil {
-		return m.Mac
+		return m.GatewayRegions
 	}
 	return nil
 }
 
-func (m *CreateGatewayRequest) GetName() string {
+func (m *UpdateNodeSessionRequest) GetDownlinkTXPower() uint32 {
 	if m != nil {
-		return m.Name
+		return m.DownlinkTXPower
 	}
-	return ""
+	return 0
 }
 
-func (m *CreateGatewayRequest) GetDescription() string {
+func (m *UpdateNodeSessionRequest) GetDownlinkCodeRate() string {
 	if m != nil {
-		return m.Description
+		return m.DownlinkCodeRate
 	}
 	return ""
 }
 
-func (m *CreateGatewayRequest) GetLatitude() float64 {
+func (m *UpdateNodeSessionRequest) GetDownlinkPolarity() Polarity {
 	if m != nil {
-		return m.Latitude
+		return m.DownlinkPolarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *UpdateNodeSessionRequest) GetBatteryThrottleLevel() uint32 {
+	if m != nil {
+		return m.BatteryThrottleLevel
 	}
 	return 0
 }
 
-func (m *CreateGatewayRequest) GetLongitude() float64 {
+func (m *UpdateNodeSessionRequest) GetClassCFPort() uint32 {
 	if m != nil {
-		return m.Longitude
+		return m.ClassCFPort
 	}
 	return 0
 }
 
-func (m *CreateGatewayRequest) GetAltitude() float64 {
+func (m *UpdateNodeSessionRequest) GetClassCWindow() uint32 {
 	if m != nil {
-		return m.Altitude
+		return m.ClassCWindow
 	}
 	return 0
 }
 
-type CreateGatewayResponse struct {
+func (m *UpdateNodeSessionRequest) GetTransmitDiversity() bool {
+	if m != nil {
+		return m.TransmitDiversity
+	}
+	return false
 }
 
-func (m *CreateGatewayResponse) Reset()                    { *m = CreateGatewayResponse{} }
-func (m *CreateGatewayResponse) String() string            { return proto.CompactTextString(m) }
-func (*CreateGatewayResponse) ProtoMessage()               {}
-func (*CreateGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
-
-type GetGatewayRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-}
-
-func (m *GetGatewayRequest) Reset()                    { *m = GetGatewayRequest{} }
-func (m *GetGatewayRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetGatewayRequest) ProtoMessage()               {}
-func (*GetGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
-
-func (m *GetGatewayRequest) GetMac() []byte {
+func (m *UpdateNodeSessionRequest) GetDailyDownlinkAirtimeCap() uint32 {
 	if m != nil {
-		return m.Mac
+		return m.DailyDownlinkAirtimeCap
 	}
-	return nil
+	return 0
 }
 
-type GetGatewayResponse struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-	// Name of the gateway.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// Description for the gateway.
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// Latitude of the gateway.
-	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
-	// Longitude of the gateway.
-	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
-	// Altitude of the gateway.
-	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
-	// The timestamp when the gateway was created.
-	CreatedAt string `protobuf:"bytes,7,opt,name=createdAt" json:"createdAt,omitempty"`
-	// The timestamp when the gateway was last updated.
-	UpdatedAt string `protobuf:"bytes,8,opt,name=updatedAt" json:"updatedAt,omitempty"`
-	// The timestamp when the gateway was first seen.
-	FirstSeenAt string `protobuf:"bytes,9,opt,name=firstSeenAt" json:"firstSeenAt,omitempty"`
-	// The timestamp when the gateway was last seen.
-	LastSeenAt string `protobuf:"bytes,10,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+func (m *UpdateNodeSessionRequest) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
 
-func (m *GetGatewayResponse) Reset()                    { *m = GetGatewayResponse{} }
-func (m *GetGatewayResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetGatewayResponse) ProtoMessage()               {}
-func (*GetGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
-
-func (m *GetGatewayResponse) GetMac() []byte {
+func (m *UpdateNodeSessionRequest) GetTags() map[string]string {
 	if m != nil {
-		return m.Mac
+		return m.Tags
 	}
 	return nil
 }
 
-func (m *GetGatewayResponse) GetName() string {
+func (m *UpdateNodeSessionRequest) GetMacVersion() string {
 	if m != nil {
-		return m.Name
+		return m.MacVersion
 	}
 	return ""
 }
 
-func (m *GetGatewayResponse) GetDescription() string {
+func (m *UpdateNodeSessionRequest) GetGeolocation() bool {
 	if m != nil {
-		return m.Description
+		return m.Geolocation
 	}
-	return ""
+	return false
 }
 
-func (m *GetGatewayResponse) GetLatitude() float64 {
+func (m *UpdateNodeSessionRequest) GetChannelConfigurationID() int64 {
 	if m != nil {
-		return m.Latitude
+		return m.ChannelConfigurationID
 	}
 	return 0
 }
 
-func (m *GetGatewayResponse) GetLongitude() float64 {
+func (m *UpdateNodeSessionRequest) GetForwardPHYPayload() bool {
 	if m != nil {
-		return m.Longitude
+		return m.ForwardPHYPayload
 	}
-	return 0
+	return false
 }
 
-func (m *GetGatewayResponse) GetAltitude() float64 {
+func (m *UpdateNodeSessionRequest) GetMaxUplinksPerHour() uint32 {
 	if m != nil {
-		return m.Altitude
+		return m.MaxUplinksPerHour
 	}
 	return 0
 }
 
-func (m *GetGatewayResponse) GetCreatedAt() string {
+func (m *UpdateNodeSessionRequest) GetLegacyADRACKReq() bool {
 	if m != nil {
-		return m.CreatedAt
+		return m.LegacyADRACKReq
 	}
-	return ""
+	return false
 }
 
-func (m *GetGatewayResponse) GetUpdatedAt() string {
+func (m *UpdateNodeSessionRequest) GetDeviceProfileID() int64 {
 	if m != nil {
-		return m.UpdatedAt
+		return m.DeviceProfileID
 	}
-	return ""
+	return 0
 }
 
-func (m *GetGatewayResponse) GetFirstSeenAt() string {
-	if m != nil {
-		return m.FirstSeenAt
-	}
-	return ""
+type UpdateNodeSessionResponse struct {
 }
 
-func (m *GetGatewayResponse) GetLastSeenAt() string {
+func (m *UpdateNodeSessionResponse) Reset()                    { *m = UpdateNodeSessionResponse{} }
+func (m *UpdateNodeSessionResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateNodeSessionResponse) ProtoMessage()               {}
+func (*UpdateNodeSessionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type PatchNodeSessionRequest struct {
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The application EUI (8 bytes).
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// The fields to update (e.g. rxDelay). Valid fields are: fCntUp,
+	// fCntDown, rxDelay, rx1DROffset, rxWindow, rx2DR, rx2Frequency,
+	// relaxFCnt, adrInterval, installationMargin, adrStrategy, relay,
+	// gatewayRegions, downlinkTXPower, downlinkCodeRate, downlinkPolarity,
+	// batteryThrottleLevel, classCFPort, classCWindow, transmitDiversity,
+	// dailyDownlinkAirtimeCap, tags, macVersion, geolocation,
+	// channelConfigurationID, forwardPHYPayload, maxUplinksPerHour,
+	// legacyADRACKReq and deviceProfileID.
+	UpdateMask []string `protobuf:"bytes,3,rep,name=updateMask" json:"updateMask,omitempty"`
+	// The next expected uplink frame-counter.
+	FCntUp uint32 `protobuf:"varint,4,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	// The frame-counter used for the next downlink frame.
+	FCntDown uint32 `protobuf:"varint,5,opt,name=fCntDown" json:"fCntDown,omitempty"`
+	// the RX delay value (0 = 1 sec, 1 = 1 sec, 2 = 2 sec ...).
+	RxDelay uint32 `protobuf:"varint,6,opt,name=rxDelay" json:"rxDelay,omitempty"`
+	// The data-rate offset used for RX1 (see LoRaWAN specs for valid values).
+	Rx1DROffset uint32 `protobuf:"varint,7,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
+	// The RX window to use for downlink transmissions.
+	RxWindow RXWindow `protobuf:"varint,8,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
+	// The data-rate to use for RX2 transmissions.
+	Rx2DR uint32 `protobuf:"varint,9,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The frequency (Hz) to use for RX2 transmissions (0 = the RX2 frequency
+	// of the band).
+	Rx2Frequency uint32 `protobuf:"varint,24,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
+	// Use relax frame-counter mode for ABP devices (this is insecure!).
+	RelaxFCnt bool `protobuf:"varint,10,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
+	// The interval (based on frame-counter) on which to calculate the ideal
+	// data-rate and tx-power of the node and if needed, request an adaption.
+	AdrInterval uint32 `protobuf:"varint,11,opt,name=adrInterval" json:"adrInterval,omitempty"`
+	// The installation margin to take into account when calculating the ideal
+	// data-rate and tx-power.
+	InstallationMargin float64 `protobuf:"fixed64,12,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The ADR strategy to use for calculating the ideal data-rate and
+	// tx-power.
+	AdrStrategy ADRStrategy `protobuf:"varint,13,opt,name=adrStrategy,enum=ns.ADRStrategy" json:"adrStrategy,omitempty"`
+	// The node is a relay (LoRaWAN TS011).
+	Relay bool `protobuf:"varint,14,opt,name=relay" json:"relay,omitempty"`
+	// The gateway regions via which downlinks for the node may be
+	// transmitted (geofencing). When empty, all gateways may be used.
+	GatewayRegions []string `protobuf:"bytes,15,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	// The TX power (dBm) of downlinks to the node, overriding the TX power
+	// of the band and gateway (0 = not set).
+	DownlinkTXPower uint32 `protobuf:"varint,16,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks to the node, overriding the code
+	// rate of the band and gateway (empty = not set).
+	DownlinkCodeRate string `protobuf:"bytes,17,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// The polarity of downlinks to the node, overriding the polarity of the
+	// band and gateway.
+	DownlinkPolarity Polarity `protobuf:"varint,18,opt,name=downlinkPolarity,enum=ns.Polarity" json:"downlinkPolarity,omitempty"`
+	// Confirmed downlinks which are not marked critical are sent as
+	// unconfirmed downlinks when the battery level last reported by the node
+	// (DevStatusAns, 1 - 254) is below this level (0 = disabled).
+	BatteryThrottleLevel uint32 `protobuf:"varint,19,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	// Uplinks on this FPort signal that the node is temporarily available as
+	// Class-C device (e.g. while mains-powered), see classCWindow
+	// (0 = disabled).
+	ClassCFPort uint32 `protobuf:"varint,20,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device after an
+	// uplink on the classCFPort.
+	ClassCWindow uint32 `protobuf:"varint,21,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Transmit critical confirmed downlinks (in response to an uplink) a
+	// second time via the second best gateway, using the other RX window
+	// (transmit diversity). This improves the delivery probability at the
+	// cost of airtime.
+	TransmitDiversity bool `protobuf:"varint,22,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day (fair
+	// use, 0 = no cap). When reached, the application payloads are left in
+	// the queue until the next day (see GetDeviceDownlinkAirtime).
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,23,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// Operator-defined tags of the node (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,25,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// LoRaWAN MAC version of the node (1.0.0, 1.0.1, 1.0.2 or 1.0.3,
+	// default 1.0.2), mac-commands not supported by this version are not
+	// sent to the node.
+	MacVersion string `protobuf:"bytes,26,opt,name=macVersion" json:"macVersion,omitempty"`
+	// Resolve the location of the node from the fine-timestamps of the
+	// receiving gateways (TDOA), the resolved location is sent to the
+	// application-server (SetDeviceLocation).
+	Geolocation bool `protobuf:"varint,27,opt,name=geolocation" json:"geolocation,omitempty"`
+	// ID of the channel-configuration (channel plan) to which the uplink
+	// channels of the node are reconciled (0 = none).
+	ChannelConfigurationID int64 `protobuf:"varint,28,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Include the raw PHYPayload of the uplink frames in the HandleDataUp
+	// calls to the application-server.
+	ForwardPHYPayload bool `protobuf:"varint,29,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	// Max. number of uplink frames forwarded per hour to the
+	// application-server, the uplink frames exceeding this limit are
+	// counted but not forwarded (0 = unlimited).
+	MaxUplinksPerHour uint32 `protobuf:"varint,30,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	// Legacy (LoRaWAN 1.0.1) ADRACKReq handling, for nodes continuously
+	// setting the ADRACKReq bit or only resetting their ADR_ACK_CNT on a
+	// downlink with the ADR bit set. An ADRACKReq is then responded at most
+	// once per 32 uplinks and the ADR bit is set in all downlinks.
+	LegacyADRACKReq bool `protobuf:"varint,31,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	// ID of the device-profile of the node, defining the downlink queue size
+	// and airtime quota (0 = none).
+	DeviceProfileID int64 `protobuf:"varint,32,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
+}
+
+func (m *PatchNodeSessionRequest) Reset()                    { *m = PatchNodeSessionRequest{} }
+func (m *PatchNodeSessionRequest) String() string            { return proto.CompactTextString(m) }
+func (*PatchNodeSessionRequest) ProtoMessage()               {}
+func (*PatchNodeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *PatchNodeSessionRequest) GetDevEUI() []byte {
 	if m != nil {
-		return m.LastSeenAt
+		return m.DevEUI
 	}
-	return ""
-}
-
-type UpdateGatewayRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-	// Name of the gateway.
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	// Description for the gateway.
-	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	// Latitude of the gateway.
-	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
-	// Longitude of the gateway.
-	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
-	// Altitude of the gateway.
-	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+	return nil
 }
 
-func (m *UpdateGatewayRequest) Reset()                    { *m = UpdateGatewayRequest{} }
-func (m *UpdateGatewayRequest) String() string            { return proto.CompactTextString(m) }
-func (*UpdateGatewayRequest) ProtoMessage()               {}
-func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
-
-func (m *UpdateGatewayRequest) GetMac() []byte {
+func (m *PatchNodeSessionRequest) GetAppEUI() []byte {
 	if m != nil {
-		return m.Mac
+		return m.AppEUI
 	}
 	return nil
 }
 
-func (m *UpdateGatewayRequest) GetName() string {
+func (m *PatchNodeSessionRequest) GetUpdateMask() []string {
 	if m != nil {
-		return m.Name
+		return m.UpdateMask
 	}
-	return ""
+	return nil
 }
 
-func (m *UpdateGatewayRequest) GetDescription() string {
+func (m *PatchNodeSessionRequest) GetFCntUp() uint32 {
 	if m != nil {
-		return m.Description
+		return m.FCntUp
 	}
-	return ""
+	return 0
 }
 
-func (m *UpdateGatewayRequest) GetLatitude() float64 {
+func (m *PatchNodeSessionRequest) GetFCntDown() uint32 {
 	if m != nil {
-		return m.Latitude
+		return m.FCntDown
 	}
 	return 0
 }
 
-func (m *UpdateGatewayRequest) GetLongitude() float64 {
+func (m *PatchNodeSessionRequest) GetRxDelay() uint32 {
 	if m != nil {
-		return m.Longitude
+		return m.RxDelay
 	}
 	return 0
 }
 
-func (m *UpdateGatewayRequest) GetAltitude() float64 {
+func (m *PatchNodeSessionRequest) GetRx1DROffset() uint32 {
 	if m != nil {
-		return m.Altitude
+		return m.Rx1DROffset
 	}
 	return 0
 }
 
-type UpdateGatewayResponse struct {
-}
-
-func (m *UpdateGatewayResponse) Reset()                    { *m = UpdateGatewayResponse{} }
-func (m *UpdateGatewayResponse) String() string            { return proto.CompactTextString(m) }
-func (*UpdateGatewayResponse) ProtoMessage()               {}
-func (*UpdateGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
-
-type ListGatewayRequest struct {
-	// Max number of gateways to return in the result-set.
-	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
-	// Offset in the result-set (for pagination).
-	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+func (m *PatchNodeSessionRequest) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
 }
 
-func (m *ListGatewayRequest) Reset()                    { *m = ListGatewayRequest{} }
-func (m *ListGatewayRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListGatewayRequest) ProtoMessage()               {}
-func (*ListGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
-
-func (m *ListGatewayRequest) GetLimit() int32 {
+func (m *PatchNodeSessionRequest) GetRx2DR() uint32 {
 	if m != nil {
-		return m.Limit
+		return m.Rx2DR
 	}
 	return 0
 }
 
-func (m *ListGatewayRequest) GetOffset() int32 {
+func (m *PatchNodeSessionRequest) GetRx2Frequency() uint32 {
 	if m != nil {
-		return m.Offset
+		return m.Rx2Frequency
 	}
 	return 0
 }
 
-type ListGatewayResponse struct {
-	// Total number of gateways.
-	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
-	// Result-set.
-	Result []*GetGatewayResponse `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+func (m *PatchNodeSessionRequest) GetRelaxFCnt() bool {
+	if m != nil {
+		return m.RelaxFCnt
+	}
+	return false
 }
 
-func (m *ListGatewayResponse) Reset()                    { *m = ListGatewayResponse{} }
-func (m *ListGatewayResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListGatewayResponse) ProtoMessage()               {}
-func (*ListGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
-
-func (m *ListGatewayResponse) GetTotalCount() int32 {
+func (m *PatchNodeSessionRequest) GetAdrInterval() uint32 {
 	if m != nil {
-		return m.TotalCount
+		return m.AdrInterval
 	}
 	return 0
 }
 
-func (m *ListGatewayResponse) GetResult() []*GetGatewayResponse {
+func (m *PatchNodeSessionRequest) GetInstallationMargin() float64 {
 	if m != nil {
-		return m.Result
+		return m.InstallationMargin
 	}
-	return nil
+	return 0
 }
 
-type DeleteGatewayRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+func (m *PatchNodeSessionRequest) GetAdrStrategy() ADRStrategy {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
 }
 
-func (m *DeleteGatewayRequest) Reset()                    { *m = DeleteGatewayRequest{} }
-func (m *DeleteGatewayRequest) String() string            { return proto.CompactTextString(m) }
-func (*DeleteGatewayRequest) ProtoMessage()               {}
-func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
-
-func (m *DeleteGatewayRequest) GetMac() []byte {
+func (m *PatchNodeSessionRequest) GetRelay() bool {
 	if m != nil {
-		return m.Mac
+		return m.Relay
 	}
-	return nil
+	return false
 }
 
-type DeleteGatewayResponse struct {
+func (m *PatchNodeSessionRequest) GetGatewayRegions() []string {
+	if m != nil {
+		return m.GatewayRegions
+	}
+	return nil
 }
 
-func (m *DeleteGatewayResponse) Reset()                    { *m = DeleteGatewayResponse{} }
-func (m *DeleteGatewayResponse) String() string            { return proto.CompactTextString(m) }
-func (*DeleteGatewayResponse) ProtoMessage()               {}
-func (*DeleteGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
-
-type GatewayStats struct {
-	// Timestamp of the (aggregated) measurement.
-	Timestamp string `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
-	// Packets received by the gateway.
-	RxPacketsReceived int32 `protobuf:"varint,2,opt,name=rxPacketsReceived" json:"rxPacketsReceived,omitempty"`
-	// Packets received by the gateway that passed the CRC check.
-	RxPacketsReceivedOK int32 `protobuf:"varint,3,opt,name=rxPacketsReceivedOK" json:"rxPacketsReceivedOK,omitempty"`
-	// Packets received by the gateway for transmission.
-	TxPacketsReceived int32 `protobuf:"varint,4,opt,name=txPacketsReceived" json:"txPacketsReceived,omitempty"`
-	// Packets transmitted by the gateway.
-	TxPacketsEmitted int32 `protobuf:"varint,5,opt,name=txPacketsEmitted" json:"txPacketsEmitted,omitempty"`
+func (m *PatchNodeSessionRequest) GetDownlinkTXPower() uint32 {
+	if m != nil {
+		return m.DownlinkTXPower
+	}
+	return 0
 }
 
-func (m *GatewayStats) Reset()                    { *m = GatewayStats{} }
-func (m *GatewayStats) String() string            { return proto.CompactTextString(m) }
-func (*GatewayStats) ProtoMessage()               {}
-func (*GatewayStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
-
-func (m *GatewayStats) GetTimestamp() string {
+func (m *PatchNodeSessionRequest) GetDownlinkCodeRate() string {
 	if m != nil {
-		return m.Timestamp
+		return m.DownlinkCodeRate
 	}
 	return ""
 }
 
-func (m *GatewayStats) GetRxPacketsReceived() int32 {
+func (m *PatchNodeSessionRequest) GetDownlinkPolarity() Polarity {
 	if m != nil {
-		return m.RxPacketsReceived
+		return m.DownlinkPolarity
 	}
-	return 0
+	return Polarity_INHERIT_POLARITY
 }
 
-func (m *GatewayStats) GetRxPacketsReceivedOK() int32 {
+func (m *PatchNodeSessionRequest) GetBatteryThrottleLevel() uint32 {
 	if m != nil {
-		return m.RxPacketsReceivedOK
+		return m.BatteryThrottleLevel
 	}
 	return 0
 }
 
-func (m *GatewayStats) GetTxPacketsReceived() int32 {
+func (m *PatchNodeSessionRequest) GetClassCFPort() uint32 {
 	if m != nil {
-		return m.TxPacketsReceived
+		return m.ClassCFPort
 	}
 	return 0
 }
 
-func (m *GatewayStats) GetTxPacketsEmitted() int32 {
+func (m *PatchNodeSessionRequest) GetClassCWindow() uint32 {
 	if m != nil {
-		return m.TxPacketsEmitted
+		return m.ClassCWindow
 	}
 	return 0
 }
 
-type GetGatewayStatsRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-	// Aggregation interval.
-	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,enum=ns.AggregationInterval" json:"interval,omitempty"`
-	// Timestamp to start from.
-	StartTimestamp string `protobuf:"bytes,3,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
-	// Timestamp until to get from.
-	EndTimestamp string `protobuf:"bytes,4,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+func (m *PatchNodeSessionRequest) GetTransmitDiversity() bool {
+	if m != nil {
+		return m.TransmitDiversity
+	}
+	return false
 }
 
-func (m *GetGatewayStatsRequest) Reset()                    { *m = GetGatewayStatsRequest{} }
-func (m *GetGatewayStatsRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetGatewayStatsRequest) ProtoMessage()               {}
-func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
-
-func (m *GetGatewayStatsRequest) GetMac() []byte {
+func (m *PatchNodeSessionRequest) GetDailyDownlinkAirtimeCap() uint32 {
 	if m != nil {
-		return m.Mac
+		return m.DailyDownlinkAirtimeCap
 	}
-	return nil
+	return 0
 }
 
-func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+func (m *PatchNodeSessionRequest) GetTags() map[string]string {
 	if m != nil {
-		return m.Interval
+		return m.Tags
 	}
-	return AggregationInterval_SECOND
+	return nil
 }
 
-func (m *GetGatewayStatsRequest) GetStartTimestamp() string {
+func (m *PatchNodeSessionRequest) GetMacVersion() string {
 	if m != nil {
-		return m.StartTimestamp
+		return m.MacVersion
 	}
 	return ""
 }
 
-func (m *GetGatewayStatsRequest) GetEndTimestamp() string {
+func (m *PatchNodeSessionRequest) GetGeolocation() bool {
 	if m != nil {
-		return m.EndTimestamp
+		return m.Geolocation
+	}
+	return false
+}
+
+func (m *PatchNodeSessionRequest) GetChannelConfigurationID() int64 {
+	if m != nil {
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *PatchNodeSessionRequest) GetForwardPHYPayload() bool {
+	if m != nil {
+		return m.ForwardPHYPayload
+	}
+	return false
+}
+
+func (m *PatchNodeSessionRequest) GetMaxUplinksPerHour() uint32 {
+	if m != nil {
+		return m.MaxUplinksPerHour
+	}
+	return 0
+}
+
+func (m *PatchNodeSessionRequest) GetLegacyADRACKReq() bool {
+	if m != nil {
+		return m.LegacyADRACKReq
+	}
+	return false
+}
+
+func (m *PatchNodeSessionRequest) GetDeviceProfileID() int64 {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return 0
+}
+
+type PatchNodeSessionResponse struct {
+}
+
+func (m *PatchNodeSessionResponse) Reset()                    { *m = PatchNodeSessionResponse{} }
+func (m *PatchNodeSessionResponse) String() string            { return proto.CompactTextString(m) }
+func (*PatchNodeSessionResponse) ProtoMessage()               {}
+func (*PatchNodeSessionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+type DeleteNodeSessionRequest struct {
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *DeleteNodeSessionRequest) Reset()                    { *m = DeleteNodeSessionRequest{} }
+func (m *DeleteNodeSessionRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNodeSessionRequest) ProtoMessage()               {}
+func (*DeleteNodeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DeleteNodeSessionRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type DeleteNodeSessionResponse struct {
+}
+
+func (m *DeleteNodeSessionResponse) Reset()                    { *m = DeleteNodeSessionResponse{} }
+func (m *DeleteNodeSessionResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNodeSessionResponse) ProtoMessage()               {}
+func (*DeleteNodeSessionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+type GetRandomDevAddrRequest struct {
+}
+
+func (m *GetRandomDevAddrRequest) Reset()                    { *m = GetRandomDevAddrRequest{} }
+func (m *GetRandomDevAddrRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRandomDevAddrRequest) ProtoMessage()               {}
+func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type GetRandomDevAddrResponse struct {
+	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+}
+
+func (m *GetRandomDevAddrResponse) Reset()                    { *m = GetRandomDevAddrResponse{} }
+func (m *GetRandomDevAddrResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetRandomDevAddrResponse) ProtoMessage()               {}
+func (*GetRandomDevAddrResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *GetRandomDevAddrResponse) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+type EnqueueDataDownMACCommandRequest struct {
+	DevEUI     []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	FrmPayload bool   `protobuf:"varint,2,opt,name=frmPayload" json:"frmPayload,omitempty"`
+	Data       []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Timestamp (RFC3339) after which the mac-command must not be sent
+	// anymore (optional). Expired mac-commands are dropped at scheduling
+	// time and reported to the network-controller.
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp (RFC3339) before which the mac-command must not be sent
+	// (optional), e.g. for time-of-use commands. Until then, the
+	// mac-command is held in the queue, also for Class-C pushed downlinks.
+	NotBefore string `protobuf:"bytes,5,opt,name=notBefore" json:"notBefore,omitempty"`
+}
+
+func (m *EnqueueDataDownMACCommandRequest) Reset()         { *m = EnqueueDataDownMACCommandRequest{} }
+func (m *EnqueueDataDownMACCommandRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDataDownMACCommandRequest) ProtoMessage()    {}
+func (*EnqueueDataDownMACCommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{12}
+}
+
+func (m *EnqueueDataDownMACCommandRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *EnqueueDataDownMACCommandRequest) GetFrmPayload() bool {
+	if m != nil {
+		return m.FrmPayload
+	}
+	return false
+}
+
+func (m *EnqueueDataDownMACCommandRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueDataDownMACCommandRequest) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
 	}
 	return ""
 }
 
-type GetGatewayStatsResponse struct {
-	Result []*GatewayStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+func (m *EnqueueDataDownMACCommandRequest) GetNotBefore() string {
+	if m != nil {
+		return m.NotBefore
+	}
+	return ""
 }
 
-func (m *GetGatewayStatsResponse) Reset()                    { *m = GetGatewayStatsResponse{} }
-func (m *GetGatewayStatsResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetGatewayStatsResponse) ProtoMessage()               {}
-func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+type EnqueueDataDownMACCommandResponse struct {
+}
 
-func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
+func (m *EnqueueDataDownMACCommandResponse) Reset()         { *m = EnqueueDataDownMACCommandResponse{} }
+func (m *EnqueueDataDownMACCommandResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDataDownMACCommandResponse) ProtoMessage()    {}
+func (*EnqueueDataDownMACCommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{13}
+}
+
+type PushDataDownRequest struct {
+	// DevEUI of the node to which to push the data.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Data (encrypted with the AppSKey) to push to the node.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Payload must be acknowledged by the node.
+	Confirmed bool `protobuf:"varint,3,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FPort to use for transmitting the payload.
+	FPort uint32 `protobuf:"varint,4,opt,name=fPort" json:"fPort,omitempty"`
+	// FCnt used for encrypting the data. When this does not match the FCntDown
+	// of the network-server, an error is returned.
+	FCnt uint32 `protobuf:"varint,5,opt,name=fCnt" json:"fCnt,omitempty"`
+	// The TX power (dBm) to use for this downlink, overriding the TX power
+	// of the band, gateway and node-session (0 = not set).
+	TxPower uint32 `protobuf:"varint,6,opt,name=txPower" json:"txPower,omitempty"`
+	// The code rate (e.g. 4/5) to use for this downlink, overriding the code
+	// rate of the band, gateway and node-session (empty = not set).
+	CodeRate string `protobuf:"bytes,7,opt,name=codeRate" json:"codeRate,omitempty"`
+	// The polarity to use for this downlink, overriding the polarity of the
+	// band, gateway and node-session.
+	Polarity Polarity `protobuf:"varint,8,opt,name=polarity,enum=ns.Polarity" json:"polarity,omitempty"`
+	// Optional client reference (idempotency key). A push with a reference
+	// which has already been used for the node within the last 24 hours is
+	// not transmitted again (e.g. a retry after a network error). For
+	// confirmed payloads, the reference is included in the ACK notification.
+	Reference string `protobuf:"bytes,9,opt,name=reference" json:"reference,omitempty"`
+	// The payload is critical and must be sent as confirmed payload, even
+	// when the battery level of the node is below its batteryThrottleLevel.
+	Critical bool `protobuf:"varint,10,opt,name=critical" json:"critical,omitempty"`
+}
+
+func (m *PushDataDownRequest) Reset()                    { *m = PushDataDownRequest{} }
+func (m *PushDataDownRequest) String() string            { return proto.CompactTextString(m) }
+func (*PushDataDownRequest) ProtoMessage()               {}
+func (*PushDataDownRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *PushDataDownRequest) GetDevEUI() []byte {
 	if m != nil {
-		return m.Result
+		return m.DevEUI
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*CreateNodeSessionRequest)(nil), "ns.CreateNodeSessionRequest")
-	proto.RegisterType((*CreateNodeSessionResponse)(nil), "ns.CreateNodeSessionResponse")
-	proto.RegisterType((*GetNodeSessionRequest)(nil), "ns.GetNodeSessionRequest")
-	proto.RegisterType((*GetNodeSessionResponse)(nil), "ns.GetNodeSessionResponse")
-	proto.RegisterType((*UpdateNodeSessionRequest)(nil), "ns.UpdateNodeSessionRequest")
-	proto.RegisterType((*UpdateNodeSessionResponse)(nil), "ns.UpdateNodeSessionResponse")
-	proto.RegisterType((*DeleteNodeSessionRequest)(nil), "ns.DeleteNodeSessionRequest")
-	proto.RegisterType((*DeleteNodeSessionResponse)(nil), "ns.DeleteNodeSessionResponse")
-	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "ns.GetRandomDevAddrRequest")
-	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
-	proto.RegisterType((*EnqueueDataDownMACCommandRequest)(nil), "ns.EnqueueDataDownMACCommandRequest")
-	proto.RegisterType((*EnqueueDataDownMACCommandResponse)(nil), "ns.EnqueueDataDownMACCommandResponse")
-	proto.RegisterType((*PushDataDownRequest)(nil), "ns.PushDataDownRequest")
-	proto.RegisterType((*PushDataDownResponse)(nil), "ns.PushDataDownResponse")
-	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
-	proto.RegisterType((*CreateGatewayResponse)(nil), "ns.CreateGatewayResponse")
-	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
-	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
-	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
-	proto.RegisterType((*UpdateGatewayResponse)(nil), "ns.UpdateGatewayResponse")
-	proto.RegisterType((*ListGatewayRequest)(nil), "ns.ListGatewayRequest")
-	proto.RegisterType((*ListGatewayResponse)(nil), "ns.ListGatewayResponse")
-	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
-	proto.RegisterType((*DeleteGatewayResponse)(nil), "ns.DeleteGatewayResponse")
-	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
-	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
-	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
-	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
-	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+func (m *PushDataDownRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *PushDataDownRequest) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *PushDataDownRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
 
-// Client API for NetworkServer service
+func (m *PushDataDownRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
 
-type NetworkServerClient interface {
-	// CreateNodeSession creates the given node-session.
-	// The DevAddr must contain the same NwkID as the configured NetID.
-	// Node-sessions will expire automatically after the configured TTL.
-	CreateNodeSession(ctx context.Context, in *CreateNodeSessionRequest, opts ...grpc.CallOption) (*CreateNodeSessionResponse, error)
-	// GetNodeSession returns the node-session matching the given DevEUI.
-	GetNodeSession(ctx context.Context, in *GetNodeSessionRequest, opts ...grpc.CallOption) (*GetNodeSessionResponse, error)
-	// UpdateNodeSession updates the given node-session.
-	UpdateNodeSession(ctx context.Context, in *UpdateNodeSessionRequest, opts ...grpc.CallOption) (*UpdateNodeSessionResponse, error)
-	// DeleteNodeSession deletes the node-session matching the given DevAddr.
-	DeleteNodeSession(ctx context.Context, in *DeleteNodeSessionRequest, opts ...grpc.CallOption) (*DeleteNodeSessionResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
-	// EnqueueDataDownMACCommand adds the downlink mac-command to the queue.
-	EnqueueDataDownMACCommand(ctx context.Context, in *EnqueueDataDownMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDataDownMACCommandResponse, error)
-	// PushDataDown pushes the given downlink payload to the node (only works for Class-C nodes).
-	PushDataDown(ctx context.Context, in *PushDataDownRequest, opts ...grpc.CallOption) (*PushDataDownResponse, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error)
-	// ListGateways returns the existing gateways.
-	ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+func (m *PushDataDownRequest) GetTxPower() uint32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *PushDataDownRequest) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *PushDataDownRequest) GetPolarity() Polarity {
+	if m != nil {
+		return m.Polarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *PushDataDownRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *PushDataDownRequest) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+type PushDataDownResponse struct {
+	// The push was not transmitted as its reference has already been used.
+	Duplicate bool `protobuf:"varint,1,opt,name=duplicate" json:"duplicate,omitempty"`
+}
+
+func (m *PushDataDownResponse) Reset()                    { *m = PushDataDownResponse{} }
+func (m *PushDataDownResponse) String() string            { return proto.CompactTextString(m) }
+func (*PushDataDownResponse) ProtoMessage()               {}
+func (*PushDataDownResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *PushDataDownResponse) GetDuplicate() bool {
+	if m != nil {
+		return m.Duplicate
+	}
+	return false
+}
+
+type BroadcastDataDownRequest struct {
+	// Only broadcast to the nodes of this application EUI (8 bytes). When
+	// empty, the payload is broadcasted to the nodes of all applications.
+	AppEUI []byte `protobuf:"bytes,1,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// FPort to use for transmitting the payload.
+	FPort uint32 `protobuf:"varint,2,opt,name=fPort" json:"fPort,omitempty"`
+	// Data (plaintext) to broadcast. It is encrypted with the AppSKey of
+	// each node.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Period (in seconds) over which the downlinks are randomly spread per
+	// gateway, to avoid saturating the downlink capacity in bursts. When 0,
+	// the configured default dispersal period is used.
+	DispersalPeriod uint32 `protobuf:"varint,4,opt,name=dispersalPeriod" json:"dispersalPeriod,omitempty"`
+}
+
+func (m *BroadcastDataDownRequest) Reset()                    { *m = BroadcastDataDownRequest{} }
+func (m *BroadcastDataDownRequest) String() string            { return proto.CompactTextString(m) }
+func (*BroadcastDataDownRequest) ProtoMessage()               {}
+func (*BroadcastDataDownRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *BroadcastDataDownRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *BroadcastDataDownRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *BroadcastDataDownRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BroadcastDataDownRequest) GetDispersalPeriod() uint32 {
+	if m != nil {
+		return m.DispersalPeriod
+	}
+	return 0
+}
+
+type BroadcastDataDownResponse struct {
+	// Number of nodes to which the payload will be sent.
+	ScheduledCount uint32 `protobuf:"varint,1,opt,name=scheduledCount" json:"scheduledCount,omitempty"`
+	// Number of matching Class-C nodes which are not eligible (e.g. the
+	// AppSKey encryption is not offloaded or the payload exceeds the max
+	// payload size).
+	SkippedCount uint32 `protobuf:"varint,2,opt,name=skippedCount" json:"skippedCount,omitempty"`
+}
+
+func (m *BroadcastDataDownResponse) Reset()                    { *m = BroadcastDataDownResponse{} }
+func (m *BroadcastDataDownResponse) String() string            { return proto.CompactTextString(m) }
+func (*BroadcastDataDownResponse) ProtoMessage()               {}
+func (*BroadcastDataDownResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *BroadcastDataDownResponse) GetScheduledCount() uint32 {
+	if m != nil {
+		return m.ScheduledCount
+	}
+	return 0
+}
+
+func (m *BroadcastDataDownResponse) GetSkippedCount() uint32 {
+	if m != nil {
+		return m.SkippedCount
+	}
+	return 0
+}
+
+type GetDownlinkDecisionsRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Max number of decisions to return in the result-set (0 = all).
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *GetDownlinkDecisionsRequest) Reset()                    { *m = GetDownlinkDecisionsRequest{} }
+func (m *GetDownlinkDecisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkDecisionsRequest) ProtoMessage()               {}
+func (*GetDownlinkDecisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *GetDownlinkDecisionsRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetDownlinkDecisionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetDownlinkDecisionsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type DownlinkDecision struct {
+	// Timestamp of the decision.
+	Time string `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// Frame-counter of the uplink.
+	FCntUp uint32 `protobuf:"varint,2,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	// The decision: TRANSMITTED, NOTHING_TO_SEND (no application payload,
+	// no mac-commands and no ACK or ADRACKReq response needed) or
+	// NO_ALLOWED_GATEWAY (see gateway geofencing), DEADLINE_EXCEEDED (the
+	// downlink would arrive too late at the gateway), GATEWAY_BUSY (the
+	// gateway reached the max downlinks per second),
+	// DAILY_AIRTIME_CAP_REACHED (nothing to send besides the application
+	// payload, see dailyDownlinkAirtimeCap), PAYLOAD_TOO_LARGE (the
+	// application payload exceeds the max payload size of the data-rate)
+	// or PUBLISH_ERROR (the downlink could not be published to the
+	// gateway).
+	Decision string `protobuf:"bytes,3,opt,name=decision" json:"decision,omitempty"`
+	// An application payload (or application-layer package response) was
+	// pending.
+	ApplicationPayload bool `protobuf:"varint,4,opt,name=applicationPayload" json:"applicationPayload,omitempty"`
+	// The uplink was a confirmed uplink.
+	AckRequired bool `protobuf:"varint,5,opt,name=ackRequired" json:"ackRequired,omitempty"`
+	// The uplink had the ADRACKReq bit set (and ADRACKReq uplinks are
+	// responded).
+	AdrACKReq bool `protobuf:"varint,6,opt,name=adrACKReq" json:"adrACKReq,omitempty"`
+	// Number of mac-commands to send.
+	MacCommandCount uint32 `protobuf:"varint,7,opt,name=macCommandCount" json:"macCommandCount,omitempty"`
+	// RX window of the downlink.
+	RxWindow RXWindow `protobuf:"varint,8,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
+	// Data-rate of the downlink.
+	DataRate uint32 `protobuf:"varint,9,opt,name=dataRate" json:"dataRate,omitempty"`
+	// MAC address of the gateway selected for the downlink.
+	Mac []byte `protobuf:"bytes,10,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *DownlinkDecision) Reset()                    { *m = DownlinkDecision{} }
+func (m *DownlinkDecision) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkDecision) ProtoMessage()               {}
+func (*DownlinkDecision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *DownlinkDecision) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *DownlinkDecision) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
+
+func (m *DownlinkDecision) GetDecision() string {
+	if m != nil {
+		return m.Decision
+	}
+	return ""
+}
+
+func (m *DownlinkDecision) GetApplicationPayload() bool {
+	if m != nil {
+		return m.ApplicationPayload
+	}
+	return false
+}
+
+func (m *DownlinkDecision) GetAckRequired() bool {
+	if m != nil {
+		return m.AckRequired
+	}
+	return false
+}
+
+func (m *DownlinkDecision) GetAdrACKReq() bool {
+	if m != nil {
+		return m.AdrACKReq
+	}
+	return false
+}
+
+func (m *DownlinkDecision) GetMacCommandCount() uint32 {
+	if m != nil {
+		return m.MacCommandCount
+	}
+	return 0
+}
+
+func (m *DownlinkDecision) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
+}
+
+func (m *DownlinkDecision) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *DownlinkDecision) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GetDownlinkDecisionsResponse struct {
+	// Downlink decisions (newest first).
+	Result []*DownlinkDecision `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// Total number of logged decisions.
+	TotalCount int32 `protobuf:"varint,2,opt,name=totalCount" json:"totalCount,omitempty"`
+}
+
+func (m *GetDownlinkDecisionsResponse) Reset()                    { *m = GetDownlinkDecisionsResponse{} }
+func (m *GetDownlinkDecisionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkDecisionsResponse) ProtoMessage()               {}
+func (*GetDownlinkDecisionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *GetDownlinkDecisionsResponse) GetResult() []*DownlinkDecision {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetDownlinkDecisionsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type GetMACCommandHistoryRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Max number of items to return in the result-set (0 = all).
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *GetMACCommandHistoryRequest) Reset()                    { *m = GetMACCommandHistoryRequest{} }
+func (m *GetMACCommandHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetMACCommandHistoryRequest) ProtoMessage()               {}
+func (*GetMACCommandHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *GetMACCommandHistoryRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetMACCommandHistoryRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetMACCommandHistoryRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type MACCommandHistoryItem struct {
+	// Timestamp of the transmission or reception.
+	Time string `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// The mac-command was received from the node (e.g. an answer), else it
+	// was sent to the node.
+	Uplink bool `protobuf:"varint,2,opt,name=uplink" json:"uplink,omitempty"`
+	// Command identifier of the mac-command.
+	Cid uint32 `protobuf:"varint,3,opt,name=cid" json:"cid,omitempty"`
+	// Binary encoded mac-command (including the CID).
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// Frame-counter of the frame containing the mac-command.
+	FCnt uint32 `protobuf:"varint,5,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *MACCommandHistoryItem) Reset()                    { *m = MACCommandHistoryItem{} }
+func (m *MACCommandHistoryItem) String() string            { return proto.CompactTextString(m) }
+func (*MACCommandHistoryItem) ProtoMessage()               {}
+func (*MACCommandHistoryItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *MACCommandHistoryItem) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *MACCommandHistoryItem) GetUplink() bool {
+	if m != nil {
+		return m.Uplink
+	}
+	return false
+}
+
+func (m *MACCommandHistoryItem) GetCid() uint32 {
+	if m != nil {
+		return m.Cid
+	}
+	return 0
+}
+
+func (m *MACCommandHistoryItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *MACCommandHistoryItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type GetMACCommandHistoryResponse struct {
+	// MAC-command history (newest first).
+	Result []*MACCommandHistoryItem `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// Total number of items in the history.
+	TotalCount int32 `protobuf:"varint,2,opt,name=totalCount" json:"totalCount,omitempty"`
+}
+
+func (m *GetMACCommandHistoryResponse) Reset()                    { *m = GetMACCommandHistoryResponse{} }
+func (m *GetMACCommandHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetMACCommandHistoryResponse) ProtoMessage()               {}
+func (*GetMACCommandHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *GetMACCommandHistoryResponse) GetResult() []*MACCommandHistoryItem {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetMACCommandHistoryResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type GetDownlinkFramesRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Downlink frame-counter.
+	FCnt uint32 `protobuf:"varint,2,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *GetDownlinkFramesRequest) Reset()                    { *m = GetDownlinkFramesRequest{} }
+func (m *GetDownlinkFramesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkFramesRequest) ProtoMessage()               {}
+func (*GetDownlinkFramesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *GetDownlinkFramesRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetDownlinkFramesRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type DownlinkFrame struct {
+	// Token of the TXPacket.
+	Token uint32 `protobuf:"varint,1,opt,name=token" json:"token,omitempty"`
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Transmission attempt of the frame-counter (1 = first transmission).
+	Attempt uint32 `protobuf:"varint,3,opt,name=attempt" json:"attempt,omitempty"`
+	// Timestamp of sending the frame to the gateway.
+	SentAt string `protobuf:"bytes,4,opt,name=sentAt" json:"sentAt,omitempty"`
+	// Timestamp of receiving the TX acknowledgement of the gateway (empty
+	// when not yet received).
+	AckedAt string `protobuf:"bytes,5,opt,name=ackedAt" json:"ackedAt,omitempty"`
+	// Reason of the rejection by the gateway (empty when not rejected).
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	// The frame is the second transmission of the frame via an other
+	// gateway (transmit diversity).
+	Diversity bool `protobuf:"varint,7,opt,name=diversity" json:"diversity,omitempty"`
+	// Node-session state at the time the frame was sent.
+	Session *DownlinkFrameSession `protobuf:"bytes,8,opt,name=session" json:"session,omitempty"`
+	// Classification of the rejection by the gateway: TOO_LATE, TOO_EARLY,
+	// COLLISION, TX_FREQ, TX_POWER, GPS_UNLOCKED, DUTY_CYCLE_EXCEEDED or
+	// OTHER (empty when not rejected).
+	ErrorCode string `protobuf:"bytes,9,opt,name=errorCode" json:"errorCode,omitempty"`
+}
+
+func (m *DownlinkFrame) Reset()                    { *m = DownlinkFrame{} }
+func (m *DownlinkFrame) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkFrame) ProtoMessage()               {}
+func (*DownlinkFrame) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *DownlinkFrame) GetToken() uint32 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *DownlinkFrame) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *DownlinkFrame) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *DownlinkFrame) GetSentAt() string {
+	if m != nil {
+		return m.SentAt
+	}
+	return ""
+}
+
+func (m *DownlinkFrame) GetAckedAt() string {
+	if m != nil {
+		return m.AckedAt
+	}
+	return ""
+}
+
+func (m *DownlinkFrame) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DownlinkFrame) GetDiversity() bool {
+	if m != nil {
+		return m.Diversity
+	}
+	return false
+}
+
+func (m *DownlinkFrame) GetSession() *DownlinkFrameSession {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
+func (m *DownlinkFrame) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+type DownlinkFrameSession struct {
+	// Next expected uplink frame-counter.
+	FCntUp uint32 `protobuf:"varint,1,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	// Downlink frame-counter.
+	FCntDown uint32 `protobuf:"varint,2,opt,name=fCntDown" json:"fCntDown,omitempty"`
+	// Data-rate of the last uplink (-1 when unknown).
+	DataRate int32 `protobuf:"varint,3,opt,name=dataRate" json:"dataRate,omitempty"`
+	// TX power of the node.
+	TxPower int32 `protobuf:"varint,4,opt,name=txPower" json:"txPower,omitempty"`
+	// Number of transmissions of each unconfirmed uplink.
+	NbTrans uint32 `protobuf:"varint,5,opt,name=nbTrans" json:"nbTrans,omitempty"`
+	// RX window used for the downlinks.
+	RxWindow RXWindow `protobuf:"varint,6,opt,name=rxWindow,enum=ns.RXWindow" json:"rxWindow,omitempty"`
+	// RX delay.
+	RxDelay uint32 `protobuf:"varint,7,opt,name=rxDelay" json:"rxDelay,omitempty"`
+	// RX1 data-rate offset.
+	Rx1DROffset uint32 `protobuf:"varint,8,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
+	// RX2 data-rate.
+	Rx2DR uint32 `protobuf:"varint,9,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// Extra uplink channels (frequencies) of the node set by the CFList.
+	CFList []uint32 `protobuf:"varint,10,rep,packed,name=cFList" json:"cFList,omitempty"`
+	// RX2 frequency (Hz).
+	Rx2Frequency uint32 `protobuf:"varint,11,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
+}
+
+func (m *DownlinkFrameSession) Reset()                    { *m = DownlinkFrameSession{} }
+func (m *DownlinkFrameSession) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkFrameSession) ProtoMessage()               {}
+func (*DownlinkFrameSession) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *DownlinkFrameSession) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetFCntDown() uint32 {
+	if m != nil {
+		return m.FCntDown
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetDataRate() int32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetRxWindow() RXWindow {
+	if m != nil {
+		return m.RxWindow
+	}
+	return RXWindow_RX1
+}
+
+func (m *DownlinkFrameSession) GetRxDelay() uint32 {
+	if m != nil {
+		return m.RxDelay
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetRx1DROffset() uint32 {
+	if m != nil {
+		return m.Rx1DROffset
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetRx2DR() uint32 {
+	if m != nil {
+		return m.Rx2DR
+	}
+	return 0
+}
+
+func (m *DownlinkFrameSession) GetCFList() []uint32 {
+	if m != nil {
+		return m.CFList
+	}
+	return nil
+}
+
+func (m *DownlinkFrameSession) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
+type GetDownlinkFramesResponse struct {
+	// Frame log entries (oldest first).
+	Result []*DownlinkFrame `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetDownlinkFramesResponse) Reset()                    { *m = GetDownlinkFramesResponse{} }
+func (m *GetDownlinkFramesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkFramesResponse) ProtoMessage()               {}
+func (*GetDownlinkFramesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *GetDownlinkFramesResponse) GetResult() []*DownlinkFrame {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type CreateGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Name of the gateway.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Description for the gateway.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// Latitude of the gateway.
+	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the gateway.
+	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the gateway.
+	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+	// Region tag of the gateway (e.g. EU or NL), used for restricting the
+	// gateways via which downlinks may be transmitted (geofencing).
+	Region string `protobuf:"bytes,7,opt,name=region" json:"region,omitempty"`
+	// The TX power (dBm) of downlinks transmitted by the gateway, overriding
+	// the TX power of the band (0 = not set).
+	TxPower uint32 `protobuf:"varint,8,opt,name=txPower" json:"txPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks transmitted by the gateway,
+	// overriding the code rate of the band (empty = not set).
+	CodeRate string `protobuf:"bytes,9,opt,name=codeRate" json:"codeRate,omitempty"`
+	// The polarity of downlinks transmitted by the gateway, overriding the
+	// polarity of the band.
+	Polarity Polarity `protobuf:"varint,10,opt,name=polarity,enum=ns.Polarity" json:"polarity,omitempty"`
+	// The offset (dB) added to the RSSI reported by the gateway, correcting
+	// for known biases of the gateway model.
+	RssiOffset int32 `protobuf:"varint,11,opt,name=rssiOffset" json:"rssiOffset,omitempty"`
+	// The offset (dB) added to the LoRa SNR reported by the gateway,
+	// correcting for known biases of the gateway model.
+	LoRaSNROffset float64 `protobuf:"fixed64,12,opt,name=loRaSNROffset" json:"loRaSNROffset,omitempty"`
+	// The gateway has a GPS / PPS (required for Class-B beacons).
+	HasGPS bool `protobuf:"varint,13,opt,name=hasGPS" json:"hasGPS,omitempty"`
+	// The gateway is capable of fine-timestamping (required for TDOA
+	// geolocation).
+	FineTimestamp bool `protobuf:"varint,14,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// The gateway is not capable of transmitting downlinks.
+	ReceiveOnly bool `protobuf:"varint,15,opt,name=receiveOnly" json:"receiveOnly,omitempty"`
+	// Operator-defined tags of the gateway (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,16,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CreateGatewayRequest) Reset()                    { *m = CreateGatewayRequest{} }
+func (m *CreateGatewayRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateGatewayRequest) ProtoMessage()               {}
+func (*CreateGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *CreateGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *CreateGatewayRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateGatewayRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CreateGatewayRequest) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *CreateGatewayRequest) GetTxPower() uint32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *CreateGatewayRequest) GetPolarity() Polarity {
+	if m != nil {
+		return m.Polarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *CreateGatewayRequest) GetRssiOffset() int32 {
+	if m != nil {
+		return m.RssiOffset
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetLoRaSNROffset() float64 {
+	if m != nil {
+		return m.LoRaSNROffset
+	}
+	return 0
+}
+
+func (m *CreateGatewayRequest) GetHasGPS() bool {
+	if m != nil {
+		return m.HasGPS
+	}
+	return false
+}
+
+func (m *CreateGatewayRequest) GetFineTimestamp() bool {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return false
+}
+
+func (m *CreateGatewayRequest) GetReceiveOnly() bool {
+	if m != nil {
+		return m.ReceiveOnly
+	}
+	return false
+}
+
+func (m *CreateGatewayRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type CreateGatewayResponse struct {
+}
+
+func (m *CreateGatewayResponse) Reset()                    { *m = CreateGatewayResponse{} }
+func (m *CreateGatewayResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateGatewayResponse) ProtoMessage()               {}
+func (*CreateGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+type GetGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *GetGatewayRequest) Reset()                    { *m = GetGatewayRequest{} }
+func (m *GetGatewayRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayRequest) ProtoMessage()               {}
+func (*GetGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *GetGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GetGatewayResponse struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Name of the gateway.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Description for the gateway.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// Latitude of the gateway.
+	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the gateway.
+	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the gateway.
+	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+	// The timestamp when the gateway was created.
+	CreatedAt string `protobuf:"bytes,7,opt,name=createdAt" json:"createdAt,omitempty"`
+	// The timestamp when the gateway was last updated.
+	UpdatedAt string `protobuf:"bytes,8,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// The timestamp when the gateway was first seen.
+	FirstSeenAt string `protobuf:"bytes,9,opt,name=firstSeenAt" json:"firstSeenAt,omitempty"`
+	// The timestamp when the gateway was last seen.
+	LastSeenAt string `protobuf:"bytes,10,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+	// The number of uplink frames reported by the gateway on a frequency
+	// outside the channel plan (e.g. caused by a misconfigured gateway).
+	OutOfPlanRXPacketCount uint32 `protobuf:"varint,11,opt,name=outOfPlanRXPacketCount" json:"outOfPlanRXPacketCount,omitempty"`
+	// Region tag of the gateway (e.g. EU or NL), used for restricting the
+	// gateways via which downlinks may be transmitted (geofencing).
+	Region string `protobuf:"bytes,12,opt,name=region" json:"region,omitempty"`
+	// The TX power (dBm) of downlinks transmitted by the gateway, overriding
+	// the TX power of the band (0 = not set).
+	TxPower uint32 `protobuf:"varint,13,opt,name=txPower" json:"txPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks transmitted by the gateway,
+	// overriding the code rate of the band (empty = not set).
+	CodeRate string `protobuf:"bytes,14,opt,name=codeRate" json:"codeRate,omitempty"`
+	// The polarity of downlinks transmitted by the gateway, overriding the
+	// polarity of the band.
+	Polarity Polarity `protobuf:"varint,15,opt,name=polarity,enum=ns.Polarity" json:"polarity,omitempty"`
+	// The reachability score (0 - 1) of the gateway, based on the regularity
+	// of the received gateway stats (1 = no missed stats).
+	ReachabilityScore float64 `protobuf:"fixed64,16,opt,name=reachabilityScore" json:"reachabilityScore,omitempty"`
+	// The offset (dB) added to the RSSI reported by the gateway, correcting
+	// for known biases of the gateway model.
+	RssiOffset int32 `protobuf:"varint,17,opt,name=rssiOffset" json:"rssiOffset,omitempty"`
+	// The offset (dB) added to the LoRa SNR reported by the gateway,
+	// correcting for known biases of the gateway model.
+	LoRaSNROffset float64 `protobuf:"fixed64,18,opt,name=loRaSNROffset" json:"loRaSNROffset,omitempty"`
+	// The gateway has a GPS / PPS (required for Class-B beacons).
+	HasGPS bool `protobuf:"varint,19,opt,name=hasGPS" json:"hasGPS,omitempty"`
+	// The gateway is capable of fine-timestamping (required for TDOA
+	// geolocation).
+	FineTimestamp bool `protobuf:"varint,20,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// The gateway is not capable of transmitting downlinks.
+	ReceiveOnly bool `protobuf:"varint,21,opt,name=receiveOnly" json:"receiveOnly,omitempty"`
+	// The last errors / events reported by the gateway (newest first, only
+	// set by GetGateway).
+	Events []*GatewayEvent `protobuf:"bytes,22,rep,name=events" json:"events,omitempty"`
+	// Operator-defined tags of the gateway (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,23,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *GetGatewayResponse) Reset()                    { *m = GetGatewayResponse{} }
+func (m *GetGatewayResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayResponse) ProtoMessage()               {}
+func (*GetGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *GetGatewayResponse) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetFirstSeenAt() string {
+	if m != nil {
+		return m.FirstSeenAt
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetLastSeenAt() string {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetOutOfPlanRXPacketCount() uint32 {
+	if m != nil {
+		return m.OutOfPlanRXPacketCount
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetTxPower() uint32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetPolarity() Polarity {
+	if m != nil {
+		return m.Polarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *GetGatewayResponse) GetReachabilityScore() float64 {
+	if m != nil {
+		return m.ReachabilityScore
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetRssiOffset() int32 {
+	if m != nil {
+		return m.RssiOffset
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetLoRaSNROffset() float64 {
+	if m != nil {
+		return m.LoRaSNROffset
+	}
+	return 0
+}
+
+func (m *GetGatewayResponse) GetHasGPS() bool {
+	if m != nil {
+		return m.HasGPS
+	}
+	return false
+}
+
+func (m *GetGatewayResponse) GetFineTimestamp() bool {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return false
+}
+
+func (m *GetGatewayResponse) GetReceiveOnly() bool {
+	if m != nil {
+		return m.ReceiveOnly
+	}
+	return false
+}
+
+func (m *GetGatewayResponse) GetEvents() []*GatewayEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *GetGatewayResponse) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type GatewayEvent struct {
+	// Timestamp (RFC3339) of the event.
+	Time string `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// Type of the event (e.g. GPS_LOST, CONCENTRATOR_RESTART or
+	// JIT_QUEUE_FULL).
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// Description of the event.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *GatewayEvent) Reset()                    { *m = GatewayEvent{} }
+func (m *GatewayEvent) String() string            { return proto.CompactTextString(m) }
+func (*GatewayEvent) ProtoMessage()               {}
+func (*GatewayEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *GatewayEvent) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *GatewayEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *GatewayEvent) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type UpdateGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Name of the gateway.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Description for the gateway.
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	// Latitude of the gateway.
+	Latitude float64 `protobuf:"fixed64,4,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the gateway.
+	Longitude float64 `protobuf:"fixed64,5,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude of the gateway.
+	Altitude float64 `protobuf:"fixed64,6,opt,name=altitude" json:"altitude,omitempty"`
+	// Region tag of the gateway (e.g. EU or NL), used for restricting the
+	// gateways via which downlinks may be transmitted (geofencing).
+	Region string `protobuf:"bytes,7,opt,name=region" json:"region,omitempty"`
+	// The TX power (dBm) of downlinks transmitted by the gateway, overriding
+	// the TX power of the band (0 = not set).
+	TxPower uint32 `protobuf:"varint,8,opt,name=txPower" json:"txPower,omitempty"`
+	// The code rate (e.g. 4/5) of downlinks transmitted by the gateway,
+	// overriding the code rate of the band (empty = not set).
+	CodeRate string `protobuf:"bytes,9,opt,name=codeRate" json:"codeRate,omitempty"`
+	// The polarity of downlinks transmitted by the gateway, overriding the
+	// polarity of the band.
+	Polarity Polarity `protobuf:"varint,10,opt,name=polarity,enum=ns.Polarity" json:"polarity,omitempty"`
+	// The offset (dB) added to the RSSI reported by the gateway, correcting
+	// for known biases of the gateway model.
+	RssiOffset int32 `protobuf:"varint,11,opt,name=rssiOffset" json:"rssiOffset,omitempty"`
+	// The offset (dB) added to the LoRa SNR reported by the gateway,
+	// correcting for known biases of the gateway model.
+	LoRaSNROffset float64 `protobuf:"fixed64,12,opt,name=loRaSNROffset" json:"loRaSNROffset,omitempty"`
+	// The gateway has a GPS / PPS (required for Class-B beacons).
+	HasGPS bool `protobuf:"varint,13,opt,name=hasGPS" json:"hasGPS,omitempty"`
+	// The gateway is capable of fine-timestamping (required for TDOA
+	// geolocation).
+	FineTimestamp bool `protobuf:"varint,14,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// The gateway is not capable of transmitting downlinks.
+	ReceiveOnly bool `protobuf:"varint,15,opt,name=receiveOnly" json:"receiveOnly,omitempty"`
+	// Operator-defined tags of the gateway (e.g. customer, site or hardware
+	// batch).
+	Tags map[string]string `protobuf:"bytes,16,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *UpdateGatewayRequest) Reset()                    { *m = UpdateGatewayRequest{} }
+func (m *UpdateGatewayRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateGatewayRequest) ProtoMessage()               {}
+func (*UpdateGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *UpdateGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *UpdateGatewayRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateGatewayRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *UpdateGatewayRequest) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *UpdateGatewayRequest) GetTxPower() uint32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *UpdateGatewayRequest) GetPolarity() Polarity {
+	if m != nil {
+		return m.Polarity
+	}
+	return Polarity_INHERIT_POLARITY
+}
+
+func (m *UpdateGatewayRequest) GetRssiOffset() int32 {
+	if m != nil {
+		return m.RssiOffset
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetLoRaSNROffset() float64 {
+	if m != nil {
+		return m.LoRaSNROffset
+	}
+	return 0
+}
+
+func (m *UpdateGatewayRequest) GetHasGPS() bool {
+	if m != nil {
+		return m.HasGPS
+	}
+	return false
+}
+
+func (m *UpdateGatewayRequest) GetFineTimestamp() bool {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return false
+}
+
+func (m *UpdateGatewayRequest) GetReceiveOnly() bool {
+	if m != nil {
+		return m.ReceiveOnly
+	}
+	return false
+}
+
+func (m *UpdateGatewayRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type UpdateGatewayResponse struct {
+}
+
+func (m *UpdateGatewayResponse) Reset()                    { *m = UpdateGatewayResponse{} }
+func (m *UpdateGatewayResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateGatewayResponse) ProtoMessage()               {}
+func (*UpdateGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type ListGatewayRequest struct {
+	// Max number of gateways to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	// Only return the gateways of which the MAC (HEX encoded) or name
+	// contains the given string (case-insensitive, optional).
+	Search string `protobuf:"bytes,3,opt,name=search" json:"search,omitempty"`
+	// Field to order the result-set by.
+	OrderBy GatewayOrderBy `protobuf:"varint,4,opt,name=orderBy,enum=ns.GatewayOrderBy" json:"orderBy,omitempty"`
+	// Sort order of the result-set.
+	SortOrder SortOrder `protobuf:"varint,5,opt,name=sortOrder,enum=ns.SortOrder" json:"sortOrder,omitempty"`
+	// Only return the gateways having all the given tags (optional).
+	Tags map[string]string `protobuf:"bytes,6,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ListGatewayRequest) Reset()                    { *m = ListGatewayRequest{} }
+func (m *ListGatewayRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayRequest) ProtoMessage()               {}
+func (*ListGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ListGatewayRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewayRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListGatewayRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
+func (m *ListGatewayRequest) GetOrderBy() GatewayOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return GatewayOrderBy_ORDER_BY_MAC
+}
+
+func (m *ListGatewayRequest) GetSortOrder() SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return SortOrder_ASC
+}
+
+func (m *ListGatewayRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ListGatewayResponse struct {
+	// Total number of gateways (matching the search filter).
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set.
+	Result []*GetGatewayResponse `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListGatewayResponse) Reset()                    { *m = ListGatewayResponse{} }
+func (m *ListGatewayResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayResponse) ProtoMessage()               {}
+func (*ListGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ListGatewayResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewayResponse) GetResult() []*GetGatewayResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DeleteGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *DeleteGatewayRequest) Reset()                    { *m = DeleteGatewayRequest{} }
+func (m *DeleteGatewayRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGatewayRequest) ProtoMessage()               {}
+func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *DeleteGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type DeleteGatewayResponse struct {
+}
+
+func (m *DeleteGatewayResponse) Reset()                    { *m = DeleteGatewayResponse{} }
+func (m *DeleteGatewayResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGatewayResponse) ProtoMessage()               {}
+func (*DeleteGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+type GatewayStats struct {
+	// Timestamp of the (aggregated) measurement.
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Packets received by the gateway.
+	RxPacketsReceived int32 `protobuf:"varint,2,opt,name=rxPacketsReceived" json:"rxPacketsReceived,omitempty"`
+	// Packets received by the gateway that passed the CRC check.
+	RxPacketsReceivedOK int32 `protobuf:"varint,3,opt,name=rxPacketsReceivedOK" json:"rxPacketsReceivedOK,omitempty"`
+	// Packets received by the gateway for transmission.
+	TxPacketsReceived int32 `protobuf:"varint,4,opt,name=txPacketsReceived" json:"txPacketsReceived,omitempty"`
+	// Packets transmitted by the gateway.
+	TxPacketsEmitted int32 `protobuf:"varint,5,opt,name=txPacketsEmitted" json:"txPacketsEmitted,omitempty"`
+}
+
+func (m *GatewayStats) Reset()                    { *m = GatewayStats{} }
+func (m *GatewayStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewayStats) ProtoMessage()               {}
+func (*GatewayStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *GatewayStats) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *GatewayStats) GetRxPacketsReceived() int32 {
+	if m != nil {
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetRxPacketsReceivedOK() int32 {
+	if m != nil {
+		return m.RxPacketsReceivedOK
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsReceived() int32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsEmitted() int32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+type GetGatewayStatsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Aggregation interval.
+	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,enum=ns.AggregationInterval" json:"interval,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp string `protobuf:"bytes,3,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp string `protobuf:"bytes,4,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+	// Max number of intervals to return in the result-set (0 = all).
+	Limit int32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,6,opt,name=offset" json:"offset,omitempty"`
+	// Sort order (by timestamp) of the result-set.
+	SortOrder SortOrder `protobuf:"varint,7,opt,name=sortOrder,enum=ns.SortOrder" json:"sortOrder,omitempty"`
+}
+
+func (m *GetGatewayStatsRequest) Reset()                    { *m = GetGatewayStatsRequest{} }
+func (m *GetGatewayStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayStatsRequest) ProtoMessage()               {}
+func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *GetGatewayStatsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+	if m != nil {
+		return m.Interval
+	}
+	return AggregationInterval_SECOND
+}
+
+func (m *GetGatewayStatsRequest) GetStartTimestamp() string {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return ""
+}
+
+func (m *GetGatewayStatsRequest) GetEndTimestamp() string {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return ""
+}
+
+func (m *GetGatewayStatsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetGatewayStatsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetGatewayStatsRequest) GetSortOrder() SortOrder {
+	if m != nil {
+		return m.SortOrder
+	}
+	return SortOrder_ASC
+}
+
+type GetGatewayStatsResponse struct {
+	Result []*GatewayStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// Total number of intervals between the start and end timestamp.
+	TotalCount int32 `protobuf:"varint,2,opt,name=totalCount" json:"totalCount,omitempty"`
+}
+
+func (m *GetGatewayStatsResponse) Reset()                    { *m = GetGatewayStatsResponse{} }
+func (m *GetGatewayStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayStatsResponse) ProtoMessage()               {}
+func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type StreamUplinkMetadataRequest struct {
+	// DevEUI to filter on (optional). When not set, the meta-data of all
+	// nodes will be streamed.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *StreamUplinkMetadataRequest) Reset()                    { *m = StreamUplinkMetadataRequest{} }
+func (m *StreamUplinkMetadataRequest) String() string            { return proto.CompactTextString(m) }
+func (*StreamUplinkMetadataRequest) ProtoMessage()               {}
+func (*StreamUplinkMetadataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *StreamUplinkMetadataRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type UplinkRXInfo struct {
+	// MAC address of the receiving gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Time when the frame was received (RFC3339).
+	Time string `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// RSSI of the received frame.
+	Rssi int32 `protobuf:"varint,3,opt,name=rssi" json:"rssi,omitempty"`
+	// LoRa SNR of the received frame.
+	LoRaSNR float64 `protobuf:"fixed64,4,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+}
+
+func (m *UplinkRXInfo) Reset()                    { *m = UplinkRXInfo{} }
+func (m *UplinkRXInfo) String() string            { return proto.CompactTextString(m) }
+func (*UplinkRXInfo) ProtoMessage()               {}
+func (*UplinkRXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *UplinkRXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *UplinkRXInfo) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *UplinkRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetLoRaSNR() float64 {
+	if m != nil {
+		return m.LoRaSNR
+	}
+	return 0
+}
+
+type StreamUplinkMetadataResponse struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// AppEUI of the node.
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// DevAddr of the node.
+	DevAddr []byte `protobuf:"bytes,3,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+	// Message-type of the frame (as defined by the LoRaWAN specification).
+	MType uint32 `protobuf:"varint,4,opt,name=mType" json:"mType,omitempty"`
+	// FPort of the frame (0 when not set or when it contains mac-commands).
+	FPort uint32 `protobuf:"varint,5,opt,name=fPort" json:"fPort,omitempty"`
+	// Frame-counter of the frame.
+	FCnt uint32 `protobuf:"varint,6,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Data-rate of the frame.
+	DataRate uint32 `protobuf:"varint,7,opt,name=dataRate" json:"dataRate,omitempty"`
+	// Frequency (Hz) of the frame.
+	Frequency int64 `protobuf:"varint,8,opt,name=frequency" json:"frequency,omitempty"`
+	// ADR bit of the frame.
+	Adr bool `protobuf:"varint,9,opt,name=adr" json:"adr,omitempty"`
+	// Gateways which received the frame.
+	RxInfo []*UplinkRXInfo `protobuf:"bytes,10,rep,name=rxInfo" json:"rxInfo,omitempty"`
+}
+
+func (m *StreamUplinkMetadataResponse) Reset()                    { *m = StreamUplinkMetadataResponse{} }
+func (m *StreamUplinkMetadataResponse) String() string            { return proto.CompactTextString(m) }
+func (*StreamUplinkMetadataResponse) ProtoMessage()               {}
+func (*StreamUplinkMetadataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *StreamUplinkMetadataResponse) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *StreamUplinkMetadataResponse) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *StreamUplinkMetadataResponse) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+func (m *StreamUplinkMetadataResponse) GetMType() uint32 {
+	if m != nil {
+		return m.MType
+	}
+	return 0
+}
+
+func (m *StreamUplinkMetadataResponse) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *StreamUplinkMetadataResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *StreamUplinkMetadataResponse) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *StreamUplinkMetadataResponse) GetFrequency() int64 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *StreamUplinkMetadataResponse) GetAdr() bool {
+	if m != nil {
+		return m.Adr
+	}
+	return false
+}
+
+func (m *StreamUplinkMetadataResponse) GetRxInfo() []*UplinkRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+type GetDownlinkCapacityReportRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Timestamp to start from (the report has a granularity of one hour).
+	StartTimestamp string `protobuf:"bytes,2,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp string `protobuf:"bytes,3,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+	// Max number of busiest devices to return (0 = all).
+	BusiestDevicesLimit uint32 `protobuf:"varint,4,opt,name=busiestDevicesLimit" json:"busiestDevicesLimit,omitempty"`
+}
+
+func (m *GetDownlinkCapacityReportRequest) Reset()         { *m = GetDownlinkCapacityReportRequest{} }
+func (m *GetDownlinkCapacityReportRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDownlinkCapacityReportRequest) ProtoMessage()    {}
+func (*GetDownlinkCapacityReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{45}
+}
+
+func (m *GetDownlinkCapacityReportRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetDownlinkCapacityReportRequest) GetStartTimestamp() string {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return ""
+}
+
+func (m *GetDownlinkCapacityReportRequest) GetEndTimestamp() string {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return ""
+}
+
+func (m *GetDownlinkCapacityReportRequest) GetBusiestDevicesLimit() uint32 {
+	if m != nil {
+		return m.BusiestDevicesLimit
+	}
+	return 0
+}
+
+type SubBandCapacity struct {
+	// Name of the sub-band (e.g. h1.5).
+	SubBand string `protobuf:"bytes,1,opt,name=subBand" json:"subBand,omitempty"`
+	// Duty-cycle limit of the sub-band (e.g. 0.01 for 1%). 0 means that
+	// there is no duty-cycle limit.
+	DutyCycle float64 `protobuf:"fixed64,2,opt,name=dutyCycle" json:"dutyCycle,omitempty"`
+	// Total downlink airtime in milliseconds.
+	Airtime uint32 `protobuf:"varint,3,opt,name=airtime" json:"airtime,omitempty"`
+	// Fraction of the duty-cycle budget consumed over the requested time
+	// range.
+	DutyCycleConsumed float64 `protobuf:"fixed64,4,opt,name=dutyCycleConsumed" json:"dutyCycleConsumed,omitempty"`
+	// Number of transmitted downlinks.
+	DownlinkCount uint32 `protobuf:"varint,5,opt,name=downlinkCount" json:"downlinkCount,omitempty"`
+	// Number of downlinks which could not be transmitted.
+	RejectedCount uint32 `protobuf:"varint,6,opt,name=rejectedCount" json:"rejectedCount,omitempty"`
+}
+
+func (m *SubBandCapacity) Reset()                    { *m = SubBandCapacity{} }
+func (m *SubBandCapacity) String() string            { return proto.CompactTextString(m) }
+func (*SubBandCapacity) ProtoMessage()               {}
+func (*SubBandCapacity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *SubBandCapacity) GetSubBand() string {
+	if m != nil {
+		return m.SubBand
+	}
+	return ""
+}
+
+func (m *SubBandCapacity) GetDutyCycle() float64 {
+	if m != nil {
+		return m.DutyCycle
+	}
+	return 0
+}
+
+func (m *SubBandCapacity) GetAirtime() uint32 {
+	if m != nil {
+		return m.Airtime
+	}
+	return 0
+}
+
+func (m *SubBandCapacity) GetDutyCycleConsumed() float64 {
+	if m != nil {
+		return m.DutyCycleConsumed
+	}
+	return 0
+}
+
+func (m *SubBandCapacity) GetDownlinkCount() uint32 {
+	if m != nil {
+		return m.DownlinkCount
+	}
+	return 0
+}
+
+func (m *SubBandCapacity) GetRejectedCount() uint32 {
+	if m != nil {
+		return m.RejectedCount
+	}
+	return 0
+}
+
+type DeviceAirtime struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Total downlink airtime in milliseconds.
+	Airtime uint32 `protobuf:"varint,2,opt,name=airtime" json:"airtime,omitempty"`
+}
+
+func (m *DeviceAirtime) Reset()                    { *m = DeviceAirtime{} }
+func (m *DeviceAirtime) String() string            { return proto.CompactTextString(m) }
+func (*DeviceAirtime) ProtoMessage()               {}
+func (*DeviceAirtime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *DeviceAirtime) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *DeviceAirtime) GetAirtime() uint32 {
+	if m != nil {
+		return m.Airtime
+	}
+	return 0
+}
+
+type GetDownlinkCapacityReportResponse struct {
+	// Capacity usage per sub-band.
+	SubBands []*SubBandCapacity `protobuf:"bytes,1,rep,name=subBands" json:"subBands,omitempty"`
+	// Nodes consuming the most downlink airtime.
+	BusiestDevices []*DeviceAirtime `protobuf:"bytes,2,rep,name=busiestDevices" json:"busiestDevices,omitempty"`
+}
+
+func (m *GetDownlinkCapacityReportResponse) Reset()         { *m = GetDownlinkCapacityReportResponse{} }
+func (m *GetDownlinkCapacityReportResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDownlinkCapacityReportResponse) ProtoMessage()    {}
+func (*GetDownlinkCapacityReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{48}
+}
+
+func (m *GetDownlinkCapacityReportResponse) GetSubBands() []*SubBandCapacity {
+	if m != nil {
+		return m.SubBands
+	}
+	return nil
+}
+
+func (m *GetDownlinkCapacityReportResponse) GetBusiestDevices() []*DeviceAirtime {
+	if m != nil {
+		return m.BusiestDevices
+	}
+	return nil
+}
+
+type GetUplinkChannelStatsRequest struct {
+	// Timestamp to start from (the stats have a granularity of one hour).
+	StartTimestamp string `protobuf:"bytes,1,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp string `protobuf:"bytes,2,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+}
+
+func (m *GetUplinkChannelStatsRequest) Reset()                    { *m = GetUplinkChannelStatsRequest{} }
+func (m *GetUplinkChannelStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUplinkChannelStatsRequest) ProtoMessage()               {}
+func (*GetUplinkChannelStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *GetUplinkChannelStatsRequest) GetStartTimestamp() string {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return ""
+}
+
+func (m *GetUplinkChannelStatsRequest) GetEndTimestamp() string {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return ""
+}
+
+type UplinkChannelStats struct {
+	// Timestamp of the start of the hour.
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Frequency in Hz.
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate (index of the band).
+	DataRate uint32 `protobuf:"varint,3,opt,name=dataRate" json:"dataRate,omitempty"`
+	// Number of received uplinks.
+	UplinkCount uint32 `protobuf:"varint,4,opt,name=uplinkCount" json:"uplinkCount,omitempty"`
+	// The frequency is one of the default uplink channels of the band (and
+	// not an extra channel set by CFList or NewChannelReq).
+	BandChannel bool `protobuf:"varint,5,opt,name=bandChannel" json:"bandChannel,omitempty"`
+}
+
+func (m *UplinkChannelStats) Reset()                    { *m = UplinkChannelStats{} }
+func (m *UplinkChannelStats) String() string            { return proto.CompactTextString(m) }
+func (*UplinkChannelStats) ProtoMessage()               {}
+func (*UplinkChannelStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *UplinkChannelStats) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *UplinkChannelStats) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *UplinkChannelStats) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *UplinkChannelStats) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+func (m *UplinkChannelStats) GetBandChannel() bool {
+	if m != nil {
+		return m.BandChannel
+	}
+	return false
+}
+
+type GetUplinkChannelStatsResponse struct {
+	// Stats per hour, frequency and data-rate.
+	Result []*UplinkChannelStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetUplinkChannelStatsResponse) Reset()                    { *m = GetUplinkChannelStatsResponse{} }
+func (m *GetUplinkChannelStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetUplinkChannelStatsResponse) ProtoMessage()               {}
+func (*GetUplinkChannelStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *GetUplinkChannelStatsResponse) GetResult() []*UplinkChannelStats {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetJoinStatsRequest struct {
+}
+
+func (m *GetJoinStatsRequest) Reset()                    { *m = GetJoinStatsRequest{} }
+func (m *GetJoinStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetJoinStatsRequest) ProtoMessage()               {}
+func (*GetJoinStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type JoinStats struct {
+	// Number of handled join-requests.
+	RequestCount uint32 `protobuf:"varint,1,opt,name=requestCount" json:"requestCount,omitempty"`
+	// Number of join-requests resulting in a join-accept.
+	AcceptCount uint32 `protobuf:"varint,2,opt,name=acceptCount" json:"acceptCount,omitempty"`
+	// Number of join-requests not resulting in a join-accept.
+	FailureCount uint32 `protobuf:"varint,3,opt,name=failureCount" json:"failureCount,omitempty"`
+	// Average join-request to join-accept latency in milliseconds.
+	AvgLatency uint32 `protobuf:"varint,4,opt,name=avgLatency" json:"avgLatency,omitempty"`
+}
+
+func (m *JoinStats) Reset()                    { *m = JoinStats{} }
+func (m *JoinStats) String() string            { return proto.CompactTextString(m) }
+func (*JoinStats) ProtoMessage()               {}
+func (*JoinStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *JoinStats) GetRequestCount() uint32 {
+	if m != nil {
+		return m.RequestCount
+	}
+	return 0
+}
+
+func (m *JoinStats) GetAcceptCount() uint32 {
+	if m != nil {
+		return m.AcceptCount
+	}
+	return 0
+}
+
+func (m *JoinStats) GetFailureCount() uint32 {
+	if m != nil {
+		return m.FailureCount
+	}
+	return 0
+}
+
+func (m *JoinStats) GetAvgLatency() uint32 {
+	if m != nil {
+		return m.AvgLatency
+	}
+	return 0
+}
+
+type GatewayJoinStats struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Join stats of the join-requests received by the gateway.
+	Stats *JoinStats `protobuf:"bytes,2,opt,name=stats" json:"stats,omitempty"`
+}
+
+func (m *GatewayJoinStats) Reset()                    { *m = GatewayJoinStats{} }
+func (m *GatewayJoinStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewayJoinStats) ProtoMessage()               {}
+func (*GatewayJoinStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *GatewayJoinStats) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GatewayJoinStats) GetStats() *JoinStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type AppJoinStats struct {
+	// AppEUI.
+	AppEUI []byte `protobuf:"bytes,1,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// Join stats of the join-requests of the AppEUI.
+	Stats *JoinStats `protobuf:"bytes,2,opt,name=stats" json:"stats,omitempty"`
+}
+
+func (m *AppJoinStats) Reset()                    { *m = AppJoinStats{} }
+func (m *AppJoinStats) String() string            { return proto.CompactTextString(m) }
+func (*AppJoinStats) ProtoMessage()               {}
+func (*AppJoinStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *AppJoinStats) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *AppJoinStats) GetStats() *JoinStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type GetJoinStatsResponse struct {
+	// Join stats per gateway.
+	Gateways []*GatewayJoinStats `protobuf:"bytes,1,rep,name=gateways" json:"gateways,omitempty"`
+	// Join stats per AppEUI.
+	Apps []*AppJoinStats `protobuf:"bytes,2,rep,name=apps" json:"apps,omitempty"`
+}
+
+func (m *GetJoinStatsResponse) Reset()                    { *m = GetJoinStatsResponse{} }
+func (m *GetJoinStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetJoinStatsResponse) ProtoMessage()               {}
+func (*GetJoinStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *GetJoinStatsResponse) GetGateways() []*GatewayJoinStats {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+func (m *GetJoinStatsResponse) GetApps() []*AppJoinStats {
+	if m != nil {
+		return m.Apps
+	}
+	return nil
+}
+
+type ListGatewayDevicesRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Max number of nodes to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListGatewayDevicesRequest) Reset()                    { *m = ListGatewayDevicesRequest{} }
+func (m *ListGatewayDevicesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayDevicesRequest) ProtoMessage()               {}
+func (*ListGatewayDevicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ListGatewayDevicesRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *ListGatewayDevicesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewayDevicesRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type GatewayDevice struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Number of uplink frames of the node received by the gateway.
+	RxPacketCount uint32 `protobuf:"varint,2,opt,name=rxPacketCount" json:"rxPacketCount,omitempty"`
+	// Last-seen timestamp (RFC3339Nano) of the node by the gateway.
+	LastSeenAt string `protobuf:"bytes,3,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+}
+
+func (m *GatewayDevice) Reset()                    { *m = GatewayDevice{} }
+func (m *GatewayDevice) String() string            { return proto.CompactTextString(m) }
+func (*GatewayDevice) ProtoMessage()               {}
+func (*GatewayDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *GatewayDevice) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GatewayDevice) GetRxPacketCount() uint32 {
+	if m != nil {
+		return m.RxPacketCount
+	}
+	return 0
+}
+
+func (m *GatewayDevice) GetLastSeenAt() string {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return ""
+}
+
+type ListGatewayDevicesResponse struct {
+	// Total number of nodes.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by last-seen timestamp (most recent first).
+	Result []*GatewayDevice `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListGatewayDevicesResponse) Reset()                    { *m = ListGatewayDevicesResponse{} }
+func (m *ListGatewayDevicesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayDevicesResponse) ProtoMessage()               {}
+func (*ListGatewayDevicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *ListGatewayDevicesResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewayDevicesResponse) GetResult() []*GatewayDevice {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetGatewayAntennaStatsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *GetGatewayAntennaStatsRequest) Reset()                    { *m = GetGatewayAntennaStatsRequest{} }
+func (m *GetGatewayAntennaStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayAntennaStatsRequest) ProtoMessage()               {}
+func (*GetGatewayAntennaStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *GetGatewayAntennaStatsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GatewayAntennaStats struct {
+	// Antenna of the gateway.
+	Antenna uint32 `protobuf:"varint,1,opt,name=antenna" json:"antenna,omitempty"`
+	// Number of uplink frames received by the antenna.
+	RxPacketCount uint32 `protobuf:"varint,2,opt,name=rxPacketCount" json:"rxPacketCount,omitempty"`
+	// Number of uplink frames for which the antenna had the best metrics
+	// of all antennas of the gateway (and of which the rx-info was used).
+	SelectedCount uint32 `protobuf:"varint,3,opt,name=selectedCount" json:"selectedCount,omitempty"`
+	// Average RSSI of the received uplink frames.
+	AvgRSSI float64 `protobuf:"fixed64,4,opt,name=avgRSSI" json:"avgRSSI,omitempty"`
+	// Average LoRa SNR of the received uplink frames.
+	AvgLoRaSNR float64 `protobuf:"fixed64,5,opt,name=avgLoRaSNR" json:"avgLoRaSNR,omitempty"`
+}
+
+func (m *GatewayAntennaStats) Reset()                    { *m = GatewayAntennaStats{} }
+func (m *GatewayAntennaStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewayAntennaStats) ProtoMessage()               {}
+func (*GatewayAntennaStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *GatewayAntennaStats) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *GatewayAntennaStats) GetRxPacketCount() uint32 {
+	if m != nil {
+		return m.RxPacketCount
+	}
+	return 0
+}
+
+func (m *GatewayAntennaStats) GetSelectedCount() uint32 {
+	if m != nil {
+		return m.SelectedCount
+	}
+	return 0
+}
+
+func (m *GatewayAntennaStats) GetAvgRSSI() float64 {
+	if m != nil {
+		return m.AvgRSSI
+	}
+	return 0
+}
+
+func (m *GatewayAntennaStats) GetAvgLoRaSNR() float64 {
+	if m != nil {
+		return m.AvgLoRaSNR
+	}
+	return 0
+}
+
+type GetGatewayAntennaStatsResponse struct {
+	// Stats per antenna.
+	Result []*GatewayAntennaStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetGatewayAntennaStatsResponse) Reset()         { *m = GetGatewayAntennaStatsResponse{} }
+func (m *GetGatewayAntennaStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayAntennaStatsResponse) ProtoMessage()    {}
+func (*GetGatewayAntennaStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{62}
+}
+
+func (m *GetGatewayAntennaStatsResponse) GetResult() []*GatewayAntennaStats {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ChangeDeviceClassRequest struct {
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The device class the node is switching to.
+	DeviceClass DeviceClass `protobuf:"varint,2,opt,name=deviceClass,enum=ns.DeviceClass" json:"deviceClass,omitempty"`
+}
+
+func (m *ChangeDeviceClassRequest) Reset()                    { *m = ChangeDeviceClassRequest{} }
+func (m *ChangeDeviceClassRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChangeDeviceClassRequest) ProtoMessage()               {}
+func (*ChangeDeviceClassRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *ChangeDeviceClassRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ChangeDeviceClassRequest) GetDeviceClass() DeviceClass {
+	if m != nil {
+		return m.DeviceClass
+	}
+	return DeviceClass_UNKNOWN_CLASS
+}
+
+type ChangeDeviceClassResponse struct {
+}
+
+func (m *ChangeDeviceClassResponse) Reset()                    { *m = ChangeDeviceClassResponse{} }
+func (m *ChangeDeviceClassResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChangeDeviceClassResponse) ProtoMessage()               {}
+func (*ChangeDeviceClassResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+type ImportNodeSessionsRequest struct {
+	// The node-sessions to create.
+	NodeSessions []*CreateNodeSessionRequest `protobuf:"bytes,1,rep,name=nodeSessions" json:"nodeSessions,omitempty"`
+}
+
+func (m *ImportNodeSessionsRequest) Reset()                    { *m = ImportNodeSessionsRequest{} }
+func (m *ImportNodeSessionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportNodeSessionsRequest) ProtoMessage()               {}
+func (*ImportNodeSessionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *ImportNodeSessionsRequest) GetNodeSessions() []*CreateNodeSessionRequest {
+	if m != nil {
+		return m.NodeSessions
+	}
+	return nil
+}
+
+type ImportNodeSessionError struct {
+	// The index of the node-session in the request.
+	Index int32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,2,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The machine-readable error code.
+	ErrorCode ErrorCode `protobuf:"varint,3,opt,name=errorCode,enum=ns.ErrorCode" json:"errorCode,omitempty"`
+	// The error message.
+	Error string `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ImportNodeSessionError) Reset()                    { *m = ImportNodeSessionError{} }
+func (m *ImportNodeSessionError) String() string            { return proto.CompactTextString(m) }
+func (*ImportNodeSessionError) ProtoMessage()               {}
+func (*ImportNodeSessionError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ImportNodeSessionError) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportNodeSessionError) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ImportNodeSessionError) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (m *ImportNodeSessionError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ImportNodeSessionsResponse struct {
+	// The number of created node-sessions.
+	CreatedCount int32 `protobuf:"varint,1,opt,name=createdCount" json:"createdCount,omitempty"`
+	// The node-sessions which could not be created.
+	Errors []*ImportNodeSessionError `protobuf:"bytes,2,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *ImportNodeSessionsResponse) Reset()                    { *m = ImportNodeSessionsResponse{} }
+func (m *ImportNodeSessionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportNodeSessionsResponse) ProtoMessage()               {}
+func (*ImportNodeSessionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *ImportNodeSessionsResponse) GetCreatedCount() int32 {
+	if m != nil {
+		return m.CreatedCount
+	}
+	return 0
+}
+
+func (m *ImportNodeSessionsResponse) GetErrors() []*ImportNodeSessionError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type ExportNodeSessionsRequest struct {
+	// The cursor returned by the previous call (0 for the first batch).
+	Cursor uint64 `protobuf:"varint,1,opt,name=cursor" json:"cursor,omitempty"`
+	// The (approximate) number of node-sessions to return.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Only return the node-sessions having all the given tags (optional).
+	Tags map[string]string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ExportNodeSessionsRequest) Reset()                    { *m = ExportNodeSessionsRequest{} }
+func (m *ExportNodeSessionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportNodeSessionsRequest) ProtoMessage()               {}
+func (*ExportNodeSessionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *ExportNodeSessionsRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *ExportNodeSessionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ExportNodeSessionsRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type ExportNodeSessionsResponse struct {
+	// The node-sessions.
+	NodeSessions []*CreateNodeSessionRequest `protobuf:"bytes,1,rep,name=nodeSessions" json:"nodeSessions,omitempty"`
+	// The cursor to use for the next batch (0 when all node-sessions have
+	// been returned).
+	Cursor uint64 `protobuf:"varint,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *ExportNodeSessionsResponse) Reset()                    { *m = ExportNodeSessionsResponse{} }
+func (m *ExportNodeSessionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportNodeSessionsResponse) ProtoMessage()               {}
+func (*ExportNodeSessionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *ExportNodeSessionsResponse) GetNodeSessions() []*CreateNodeSessionRequest {
+	if m != nil {
+		return m.NodeSessions
+	}
+	return nil
+}
+
+func (m *ExportNodeSessionsResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+type GetDeviceActivationRequest struct {
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *GetDeviceActivationRequest) Reset()                    { *m = GetDeviceActivationRequest{} }
+func (m *GetDeviceActivationRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceActivationRequest) ProtoMessage()               {}
+func (*GetDeviceActivationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *GetDeviceActivationRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type GetDeviceActivationResponse struct {
+	// The activation parameters (DevAddr, frame-counters, RX parameters and
+	// enabled uplink channels) as JSON document.
+	Activation string `protobuf:"bytes,1,opt,name=activation" json:"activation,omitempty"`
+}
+
+func (m *GetDeviceActivationResponse) Reset()                    { *m = GetDeviceActivationResponse{} }
+func (m *GetDeviceActivationResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceActivationResponse) ProtoMessage()               {}
+func (*GetDeviceActivationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *GetDeviceActivationResponse) GetActivation() string {
+	if m != nil {
+		return m.Activation
+	}
+	return ""
+}
+
+type GetADRParametersRequest struct {
+}
+
+func (m *GetADRParametersRequest) Reset()                    { *m = GetADRParametersRequest{} }
+func (m *GetADRParametersRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetADRParametersRequest) ProtoMessage()               {}
+func (*GetADRParametersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+type GetADRParametersResponse struct {
+	// The installation margin used for nodes without an installation margin
+	// in their node-session.
+	InstallationMargin float64 `protobuf:"fixed64,1,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The max data-rate the ADR engine will request.
+	MaxDR uint32 `protobuf:"varint,2,opt,name=maxDR" json:"maxDR,omitempty"`
+	// Respond to ADRACKReq uplinks with a downlink. When disabled, nodes
+	// fall back to a lower data-rate after ADR_ACK_LIMIT + ADR_ACK_DELAY
+	// uplinks without downlink.
+	RespondToADRACKReq bool `protobuf:"varint,3,opt,name=respondToADRACKReq" json:"respondToADRACKReq,omitempty"`
+	// Timestamp (RFC3339) of the last update (empty when the defaults are
+	// used).
+	UpdatedAt string `protobuf:"bytes,4,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// Noise (dB) above the quietest channel of the serving gateway from
+	// which a channel is considered noisy (0 = disabled).
+	NoisyChannelThreshold float64 `protobuf:"fixed64,5,opt,name=noisyChannelThreshold" json:"noisyChannelThreshold,omitempty"`
+	// Frequency error (Hz) of the uplinks of a node above which the
+	// data-rate is not increased (0 = disabled).
+	MaxFrequencyOffset uint32 `protobuf:"varint,6,opt,name=maxFrequencyOffset" json:"maxFrequencyOffset,omitempty"`
+}
+
+func (m *GetADRParametersResponse) Reset()                    { *m = GetADRParametersResponse{} }
+func (m *GetADRParametersResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetADRParametersResponse) ProtoMessage()               {}
+func (*GetADRParametersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *GetADRParametersResponse) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *GetADRParametersResponse) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *GetADRParametersResponse) GetRespondToADRACKReq() bool {
+	if m != nil {
+		return m.RespondToADRACKReq
+	}
+	return false
+}
+
+func (m *GetADRParametersResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+func (m *GetADRParametersResponse) GetNoisyChannelThreshold() float64 {
+	if m != nil {
+		return m.NoisyChannelThreshold
+	}
+	return 0
+}
+
+func (m *GetADRParametersResponse) GetMaxFrequencyOffset() uint32 {
+	if m != nil {
+		return m.MaxFrequencyOffset
+	}
+	return 0
+}
+
+type UpdateADRParametersRequest struct {
+	// The installation margin used for nodes without an installation margin
+	// in their node-session.
+	InstallationMargin float64 `protobuf:"fixed64,1,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// The max data-rate the ADR engine will request.
+	MaxDR uint32 `protobuf:"varint,2,opt,name=maxDR" json:"maxDR,omitempty"`
+	// Respond to ADRACKReq uplinks with a downlink. When disabled, nodes
+	// fall back to a lower data-rate after ADR_ACK_LIMIT + ADR_ACK_DELAY
+	// uplinks without downlink.
+	RespondToADRACKReq bool `protobuf:"varint,3,opt,name=respondToADRACKReq" json:"respondToADRACKReq,omitempty"`
+	// Noise (dB) above the quietest channel of the serving gateway from
+	// which a channel is considered noisy (0 = disabled).
+	NoisyChannelThreshold float64 `protobuf:"fixed64,4,opt,name=noisyChannelThreshold" json:"noisyChannelThreshold,omitempty"`
+	// Frequency error (Hz) of the uplinks of a node above which the
+	// data-rate is not increased (0 = disabled).
+	MaxFrequencyOffset uint32 `protobuf:"varint,5,opt,name=maxFrequencyOffset" json:"maxFrequencyOffset,omitempty"`
+}
+
+func (m *UpdateADRParametersRequest) Reset()                    { *m = UpdateADRParametersRequest{} }
+func (m *UpdateADRParametersRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateADRParametersRequest) ProtoMessage()               {}
+func (*UpdateADRParametersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+func (m *UpdateADRParametersRequest) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *UpdateADRParametersRequest) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *UpdateADRParametersRequest) GetRespondToADRACKReq() bool {
+	if m != nil {
+		return m.RespondToADRACKReq
+	}
+	return false
+}
+
+func (m *UpdateADRParametersRequest) GetNoisyChannelThreshold() float64 {
+	if m != nil {
+		return m.NoisyChannelThreshold
+	}
+	return 0
+}
+
+func (m *UpdateADRParametersRequest) GetMaxFrequencyOffset() uint32 {
+	if m != nil {
+		return m.MaxFrequencyOffset
+	}
+	return 0
+}
+
+type UpdateADRParametersResponse struct {
+}
+
+func (m *UpdateADRParametersResponse) Reset()                    { *m = UpdateADRParametersResponse{} }
+func (m *UpdateADRParametersResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateADRParametersResponse) ProtoMessage()               {}
+func (*UpdateADRParametersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+type GetADRDecisionsRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Max number of decisions to return in the result-set (0 = all).
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *GetADRDecisionsRequest) Reset()                    { *m = GetADRDecisionsRequest{} }
+func (m *GetADRDecisionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetADRDecisionsRequest) ProtoMessage()               {}
+func (*GetADRDecisionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+func (m *GetADRDecisionsRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetADRDecisionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetADRDecisionsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ADRUplinkHistoryItem struct {
+	// Frame-counter of the uplink.
+	FCnt uint32 `protobuf:"varint,1,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Max SNR of the receiving gateways.
+	MaxSNR float64 `protobuf:"fixed64,2,opt,name=maxSNR" json:"maxSNR,omitempty"`
+	// Max RSSI of the receiving gateways.
+	MaxRSSI int32 `protobuf:"varint,3,opt,name=maxRSSI" json:"maxRSSI,omitempty"`
+	// Number of receiving gateways.
+	GatewayCount uint32 `protobuf:"varint,4,opt,name=gatewayCount" json:"gatewayCount,omitempty"`
+	// Frequency error (Hz) of the best reception (0 when not reported by
+	// the gateway).
+	FrequencyOffset int32 `protobuf:"zigzag32,5,opt,name=frequencyOffset" json:"frequencyOffset,omitempty"`
+}
+
+func (m *ADRUplinkHistoryItem) Reset()                    { *m = ADRUplinkHistoryItem{} }
+func (m *ADRUplinkHistoryItem) String() string            { return proto.CompactTextString(m) }
+func (*ADRUplinkHistoryItem) ProtoMessage()               {}
+func (*ADRUplinkHistoryItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+func (m *ADRUplinkHistoryItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *ADRUplinkHistoryItem) GetMaxSNR() float64 {
+	if m != nil {
+		return m.MaxSNR
+	}
+	return 0
+}
+
+func (m *ADRUplinkHistoryItem) GetMaxRSSI() int32 {
+	if m != nil {
+		return m.MaxRSSI
+	}
+	return 0
+}
+
+func (m *ADRUplinkHistoryItem) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+func (m *ADRUplinkHistoryItem) GetFrequencyOffset() int32 {
+	if m != nil {
+		return m.FrequencyOffset
+	}
+	return 0
+}
+
+type ADRDecision struct {
+	// Timestamp of the decision.
+	Time string `protobuf:"bytes,1,opt,name=time" json:"time,omitempty"`
+	// Frame-counter of the uplink.
+	FCntUp uint32 `protobuf:"varint,2,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	// The decision: LINK_ADR_REQ_ENQUEUED (a LinkADRReq mac-command has
+	// been enqueued) or NOTHING_TO_ADJUST (the ideal data-rate and TX power
+	// equal the current data-rate and TX power and there are no noisy
+	// channels to disable).
+	Decision string `protobuf:"bytes,3,opt,name=decision" json:"decision,omitempty"`
+	// ADR strategy of the node.
+	Strategy ADRStrategy `protobuf:"varint,4,opt,name=strategy,enum=ns.ADRStrategy" json:"strategy,omitempty"`
+	// Uplink history of the node (oldest first), from which the max. SNR
+	// and packet-loss are computed.
+	UplinkHistory []*ADRUplinkHistoryItem `protobuf:"bytes,5,rep,name=uplinkHistory" json:"uplinkHistory,omitempty"`
+	// Max SNR of the uplink history.
+	MaxSNR float64 `protobuf:"fixed64,6,opt,name=maxSNR" json:"maxSNR,omitempty"`
+	// Required SNR (demodulation floor) of the current data-rate.
+	RequiredSNR float64 `protobuf:"fixed64,7,opt,name=requiredSNR" json:"requiredSNR,omitempty"`
+	// Installation margin used (of the node-session or the global ADR
+	// parameters).
+	InstallationMargin float64 `protobuf:"fixed64,8,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	// SNR margin (maxSNR - requiredSNR - installationMargin -
+	// channelNoise), every 3 dB of margin is a step of the data-rate or TX
+	// power.
+	SnrMargin float64 `protobuf:"fixed64,9,opt,name=snrMargin" json:"snrMargin,omitempty"`
+	// Number of steps (negative = increase the TX power).
+	NStep int32 `protobuf:"varint,10,opt,name=nStep" json:"nStep,omitempty"`
+	// Packet-loss percentage of the uplink history.
+	PacketLossPercentage float64 `protobuf:"fixed64,11,opt,name=packetLossPercentage" json:"packetLossPercentage,omitempty"`
+	// Max data-rate of the global ADR parameters.
+	MaxDR uint32 `protobuf:"varint,12,opt,name=maxDR" json:"maxDR,omitempty"`
+	// Current data-rate, TX power (dBm) and number of transmissions.
+	DataRate uint32 `protobuf:"varint,13,opt,name=dataRate" json:"dataRate,omitempty"`
+	TxPower  int32  `protobuf:"varint,14,opt,name=txPower" json:"txPower,omitempty"`
+	NbTrans  uint32 `protobuf:"varint,15,opt,name=nbTrans" json:"nbTrans,omitempty"`
+	// Requested data-rate, TX power (dBm) and number of transmissions.
+	ReqDataRate uint32 `protobuf:"varint,16,opt,name=reqDataRate" json:"reqDataRate,omitempty"`
+	ReqTXPower  int32  `protobuf:"varint,17,opt,name=reqTXPower" json:"reqTXPower,omitempty"`
+	ReqNbTrans  uint32 `protobuf:"varint,18,opt,name=reqNbTrans" json:"reqNbTrans,omitempty"`
+	// The enqueued LinkADRReq mac-command (CID + payload), empty when
+	// there was nothing to adjust.
+	LinkADRReq []byte `protobuf:"bytes,19,opt,name=linkADRReq,proto3" json:"linkADRReq,omitempty"`
+	// Noise (dB) above the quietest channel of the serving gateway of the
+	// noisiest channel which could not be disabled, subtracted from the SNR
+	// margin (0 when there are no such noisy channels).
+	ChannelNoise float64 `protobuf:"fixed64,20,opt,name=channelNoise" json:"channelNoise,omitempty"`
+	// Max frequency error (Hz) of the uplink history.
+	MaxFrequencyOffset uint32 `protobuf:"varint,21,opt,name=maxFrequencyOffset" json:"maxFrequencyOffset,omitempty"`
+	// Frequencies (Hz) of the channels disabled in the channel mask, as
+	// these are noisy at the serving gateway.
+	NoisyChannels []uint32 `protobuf:"varint,22,rep,packed,name=noisyChannels" json:"noisyChannels,omitempty"`
+}
+
+func (m *ADRDecision) Reset()                    { *m = ADRDecision{} }
+func (m *ADRDecision) String() string            { return proto.CompactTextString(m) }
+func (*ADRDecision) ProtoMessage()               {}
+func (*ADRDecision) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+func (m *ADRDecision) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *ADRDecision) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetDecision() string {
+	if m != nil {
+		return m.Decision
+	}
+	return ""
+}
+
+func (m *ADRDecision) GetStrategy() ADRStrategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return ADRStrategy_MAXIMIZE_DATA_RATE
+}
+
+func (m *ADRDecision) GetUplinkHistory() []*ADRUplinkHistoryItem {
+	if m != nil {
+		return m.UplinkHistory
+	}
+	return nil
+}
+
+func (m *ADRDecision) GetMaxSNR() float64 {
+	if m != nil {
+		return m.MaxSNR
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetRequiredSNR() float64 {
+	if m != nil {
+		return m.RequiredSNR
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetSnrMargin() float64 {
+	if m != nil {
+		return m.SnrMargin
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetNStep() int32 {
+	if m != nil {
+		return m.NStep
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetPacketLossPercentage() float64 {
+	if m != nil {
+		return m.PacketLossPercentage
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetReqDataRate() uint32 {
+	if m != nil {
+		return m.ReqDataRate
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetReqTXPower() int32 {
+	if m != nil {
+		return m.ReqTXPower
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetReqNbTrans() uint32 {
+	if m != nil {
+		return m.ReqNbTrans
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetLinkADRReq() []byte {
+	if m != nil {
+		return m.LinkADRReq
+	}
+	return nil
+}
+
+func (m *ADRDecision) GetChannelNoise() float64 {
+	if m != nil {
+		return m.ChannelNoise
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetMaxFrequencyOffset() uint32 {
+	if m != nil {
+		return m.MaxFrequencyOffset
+	}
+	return 0
+}
+
+func (m *ADRDecision) GetNoisyChannels() []uint32 {
+	if m != nil {
+		return m.NoisyChannels
+	}
+	return nil
+}
+
+type GetADRDecisionsResponse struct {
+	// ADR decisions (newest first).
+	Result []*ADRDecision `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// Total number of logged decisions.
+	TotalCount int32 `protobuf:"varint,2,opt,name=totalCount" json:"totalCount,omitempty"`
+}
+
+func (m *GetADRDecisionsResponse) Reset()                    { *m = GetADRDecisionsResponse{} }
+func (m *GetADRDecisionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetADRDecisionsResponse) ProtoMessage()               {}
+func (*GetADRDecisionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *GetADRDecisionsResponse) GetResult() []*ADRDecision {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetADRDecisionsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+type AuditRedisKeysRequest struct {
+	// Remove the de-duplication / collection keys without TTL.
+	Cleanup bool `protobuf:"varint,1,opt,name=cleanup" json:"cleanup,omitempty"`
+}
+
+func (m *AuditRedisKeysRequest) Reset()                    { *m = AuditRedisKeysRequest{} }
+func (m *AuditRedisKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*AuditRedisKeysRequest) ProtoMessage()               {}
+func (*AuditRedisKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
+func (m *AuditRedisKeysRequest) GetCleanup() bool {
+	if m != nil {
+		return m.Cleanup
+	}
+	return false
+}
+
+type RedisKeyGroup struct {
+	// Name of the key group.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Pattern matching the keys of the group.
+	Pattern string `protobuf:"bytes,2,opt,name=pattern" json:"pattern,omitempty"`
+	// The number of keys.
+	KeyCount uint32 `protobuf:"varint,3,opt,name=keyCount" json:"keyCount,omitempty"`
+	// The memory footprint of the keys in bytes (0 when not supported by
+	// Redis).
+	MemoryBytes int64 `protobuf:"varint,4,opt,name=memoryBytes" json:"memoryBytes,omitempty"`
+	// The number of keys without TTL.
+	WithoutTTLCount uint32 `protobuf:"varint,5,opt,name=withoutTTLCount" json:"withoutTTLCount,omitempty"`
+	// The number of removed keys without TTL.
+	RemovedCount uint32 `protobuf:"varint,6,opt,name=removedCount" json:"removedCount,omitempty"`
+}
+
+func (m *RedisKeyGroup) Reset()                    { *m = RedisKeyGroup{} }
+func (m *RedisKeyGroup) String() string            { return proto.CompactTextString(m) }
+func (*RedisKeyGroup) ProtoMessage()               {}
+func (*RedisKeyGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+func (m *RedisKeyGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RedisKeyGroup) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *RedisKeyGroup) GetKeyCount() uint32 {
+	if m != nil {
+		return m.KeyCount
+	}
+	return 0
+}
+
+func (m *RedisKeyGroup) GetMemoryBytes() int64 {
+	if m != nil {
+		return m.MemoryBytes
+	}
+	return 0
+}
+
+func (m *RedisKeyGroup) GetWithoutTTLCount() uint32 {
+	if m != nil {
+		return m.WithoutTTLCount
+	}
+	return 0
+}
+
+func (m *RedisKeyGroup) GetRemovedCount() uint32 {
+	if m != nil {
+		return m.RemovedCount
+	}
+	return 0
+}
+
+type AuditRedisKeysResponse struct {
+	Result []*RedisKeyGroup `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *AuditRedisKeysResponse) Reset()                    { *m = AuditRedisKeysResponse{} }
+func (m *AuditRedisKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*AuditRedisKeysResponse) ProtoMessage()               {}
+func (*AuditRedisKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *AuditRedisKeysResponse) GetResult() []*RedisKeyGroup {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetInfoRequest struct {
+}
+
+func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
+func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetInfoRequest) ProtoMessage()               {}
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+type GetInfoResponse struct {
+	// Version of LoRa Server.
+	Version string `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	// The ISM band (region) LoRa Server is configured with (e.g. EU_863_870).
+	Band string `protobuf:"bytes,2,opt,name=band" json:"band,omitempty"`
+	// NetID of the network.
+	NetID []byte `protobuf:"bytes,3,opt,name=netID,proto3" json:"netID,omitempty"`
+	// Class-B devices are supported.
+	ClassB bool `protobuf:"varint,4,opt,name=classB" json:"classB,omitempty"`
+	// Class-C devices are supported.
+	ClassC bool `protobuf:"varint,5,opt,name=classC" json:"classC,omitempty"`
+	// Adaptive data-rate is supported.
+	Adr bool `protobuf:"varint,6,opt,name=adr" json:"adr,omitempty"`
+	// The AppSKey encryption can be offloaded to LoRa Server.
+	AppSKeyOffload bool `protobuf:"varint,7,opt,name=appSKeyOffload" json:"appSKeyOffload,omitempty"`
+	// The max. data-rate of the band.
+	MaxDR uint32 `protobuf:"varint,8,opt,name=maxDR" json:"maxDR,omitempty"`
+	// The max. application payload size (FRMPayload) per data-rate.
+	MaxPayloadSize []uint32 `protobuf:"varint,9,rep,packed,name=maxPayloadSize" json:"maxPayloadSize,omitempty"`
+	// The RX2 frequency (Hz) of the band.
+	Rx2Frequency uint32 `protobuf:"varint,10,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
+	// The RX2 data-rate of the band.
+	Rx2DR uint32 `protobuf:"varint,11,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// The max. frame-counter gap (MAX_FCNT_GAP) of the band.
+	MaxFCntGap uint32 `protobuf:"varint,12,opt,name=maxFCntGap" json:"maxFCntGap,omitempty"`
+	// LoRa Server runs in read-only (maintenance) mode, the node-sessions
+	// are not updated and no downlinks are sent.
+	ReadOnly bool `protobuf:"varint,13,opt,name=readOnly" json:"readOnly,omitempty"`
+}
+
+func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
+func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetInfoResponse) ProtoMessage()               {}
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+func (m *GetInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetBand() string {
+	if m != nil {
+		return m.Band
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetNetID() []byte {
+	if m != nil {
+		return m.NetID
+	}
+	return nil
+}
+
+func (m *GetInfoResponse) GetClassB() bool {
+	if m != nil {
+		return m.ClassB
+	}
+	return false
+}
+
+func (m *GetInfoResponse) GetClassC() bool {
+	if m != nil {
+		return m.ClassC
+	}
+	return false
+}
+
+func (m *GetInfoResponse) GetAdr() bool {
+	if m != nil {
+		return m.Adr
+	}
+	return false
+}
+
+func (m *GetInfoResponse) GetAppSKeyOffload() bool {
+	if m != nil {
+		return m.AppSKeyOffload
+	}
+	return false
+}
+
+func (m *GetInfoResponse) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetMaxPayloadSize() []uint32 {
+	if m != nil {
+		return m.MaxPayloadSize
+	}
+	return nil
+}
+
+func (m *GetInfoResponse) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetRx2DR() uint32 {
+	if m != nil {
+		return m.Rx2DR
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetMaxFCntGap() uint32 {
+	if m != nil {
+		return m.MaxFCntGap
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type ReleaseQuarantineRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *ReleaseQuarantineRequest) Reset()                    { *m = ReleaseQuarantineRequest{} }
+func (m *ReleaseQuarantineRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseQuarantineRequest) ProtoMessage()               {}
+func (*ReleaseQuarantineRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+func (m *ReleaseQuarantineRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type ReleaseQuarantineResponse struct {
+}
+
+func (m *ReleaseQuarantineResponse) Reset()                    { *m = ReleaseQuarantineResponse{} }
+func (m *ReleaseQuarantineResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseQuarantineResponse) ProtoMessage()               {}
+func (*ReleaseQuarantineResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+type GetDeviceDownlinkAirtimeRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Number of days to return, including today (0 = only today). The
+	// airtime is kept for 31 days.
+	Days uint32 `protobuf:"varint,2,opt,name=days" json:"days,omitempty"`
+}
+
+func (m *GetDeviceDownlinkAirtimeRequest) Reset()         { *m = GetDeviceDownlinkAirtimeRequest{} }
+func (m *GetDeviceDownlinkAirtimeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceDownlinkAirtimeRequest) ProtoMessage()    {}
+func (*GetDeviceDownlinkAirtimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{87}
+}
+
+func (m *GetDeviceDownlinkAirtimeRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetDeviceDownlinkAirtimeRequest) GetDays() uint32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+type DailyAirtime struct {
+	// Date (YYYY-MM-DD, in the configured timezone).
+	Date string `protobuf:"bytes,1,opt,name=date" json:"date,omitempty"`
+	// Total downlink airtime in milliseconds.
+	Airtime uint32 `protobuf:"varint,2,opt,name=airtime" json:"airtime,omitempty"`
+}
+
+func (m *DailyAirtime) Reset()                    { *m = DailyAirtime{} }
+func (m *DailyAirtime) String() string            { return proto.CompactTextString(m) }
+func (*DailyAirtime) ProtoMessage()               {}
+func (*DailyAirtime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *DailyAirtime) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+func (m *DailyAirtime) GetAirtime() uint32 {
+	if m != nil {
+		return m.Airtime
+	}
+	return 0
+}
+
+type GetDeviceDownlinkAirtimeResponse struct {
+	// Downlink airtime per day (newest first).
+	Result []*DailyAirtime `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// The max. downlink airtime (in milliseconds) of the node per day
+	// (0 = no cap).
+	DailyDownlinkAirtimeCap uint32 `protobuf:"varint,2,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	// The remaining downlink airtime (in milliseconds) of today (0 when
+	// there is no cap or when the cap has been reached).
+	RemainingAirtime uint32 `protobuf:"varint,3,opt,name=remainingAirtime" json:"remainingAirtime,omitempty"`
+	// The cap has been reached, application payloads are left in the queue
+	// until the next day.
+	CapReached bool `protobuf:"varint,4,opt,name=capReached" json:"capReached,omitempty"`
+}
+
+func (m *GetDeviceDownlinkAirtimeResponse) Reset()         { *m = GetDeviceDownlinkAirtimeResponse{} }
+func (m *GetDeviceDownlinkAirtimeResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDeviceDownlinkAirtimeResponse) ProtoMessage()    {}
+func (*GetDeviceDownlinkAirtimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{89}
+}
+
+func (m *GetDeviceDownlinkAirtimeResponse) GetResult() []*DailyAirtime {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetDeviceDownlinkAirtimeResponse) GetDailyDownlinkAirtimeCap() uint32 {
+	if m != nil {
+		return m.DailyDownlinkAirtimeCap
+	}
+	return 0
+}
+
+func (m *GetDeviceDownlinkAirtimeResponse) GetRemainingAirtime() uint32 {
+	if m != nil {
+		return m.RemainingAirtime
+	}
+	return 0
+}
+
+func (m *GetDeviceDownlinkAirtimeResponse) GetCapReached() bool {
+	if m != nil {
+		return m.CapReached
+	}
+	return false
+}
+
+type UplinkRule struct {
+	// ID of the rule (ignored on create).
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the rule.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// The rule is enabled.
+	Enabled bool `protobuf:"varint,3,opt,name=enabled" json:"enabled,omitempty"`
+	// DevEUI of the node to which the rule applies (when empty, the rule
+	// applies to all nodes).
+	DevEUI []byte `protobuf:"bytes,4,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Only match uplinks on the given fPort.
+	MatchFPort bool   `protobuf:"varint,5,opt,name=matchFPort" json:"matchFPort,omitempty"`
+	FPort      uint32 `protobuf:"varint,6,opt,name=fPort" json:"fPort,omitempty"`
+	// Only match uplinks with a data-rate <= maxDR.
+	MatchMaxDR bool   `protobuf:"varint,7,opt,name=matchMaxDR" json:"matchMaxDR,omitempty"`
+	MaxDR      uint32 `protobuf:"varint,8,opt,name=maxDR" json:"maxDR,omitempty"`
+	// Number of consecutive matching uplinks needed to trigger the action
+	// (0 and 1 trigger the action on every matching uplink).
+	ConsecutiveFrames uint32 `protobuf:"varint,9,opt,name=consecutiveFrames" json:"consecutiveFrames,omitempty"`
+	// Action to execute when the rule is triggered.
+	Action UplinkRuleAction `protobuf:"varint,10,opt,name=action,enum=ns.UplinkRuleAction" json:"action,omitempty"`
+	// Data-rate to request (ENQUEUE_LINK_ADR_REQ).
+	DataRate uint32 `protobuf:"varint,11,opt,name=dataRate" json:"dataRate,omitempty"`
+	// TX power index to request (ENQUEUE_LINK_ADR_REQ).
+	TxPower uint32 `protobuf:"varint,12,opt,name=txPower" json:"txPower,omitempty"`
+	// The mac-command (CID + payload) to enqueue (ENQUEUE_MAC_COMMAND).
+	MacCommand []byte `protobuf:"bytes,13,opt,name=macCommand,proto3" json:"macCommand,omitempty"`
+	// The time (in seconds) the node is handled as Class-C device
+	// (OPEN_CLASS_C_WINDOW).
+	ClassCWindow uint32 `protobuf:"varint,14,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	// Created-at timestamp (RFC3339Nano, ignored on create and update).
+	CreatedAt string `protobuf:"bytes,15,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Updated-at timestamp (RFC3339Nano, ignored on create and update).
+	UpdatedAt string `protobuf:"bytes,16,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *UplinkRule) Reset()                    { *m = UplinkRule{} }
+func (m *UplinkRule) String() string            { return proto.CompactTextString(m) }
+func (*UplinkRule) ProtoMessage()               {}
+func (*UplinkRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *UplinkRule) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UplinkRule) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *UplinkRule) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *UplinkRule) GetMatchFPort() bool {
+	if m != nil {
+		return m.MatchFPort
+	}
+	return false
+}
+
+func (m *UplinkRule) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetMatchMaxDR() bool {
+	if m != nil {
+		return m.MatchMaxDR
+	}
+	return false
+}
+
+func (m *UplinkRule) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetConsecutiveFrames() uint32 {
+	if m != nil {
+		return m.ConsecutiveFrames
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetAction() UplinkRuleAction {
+	if m != nil {
+		return m.Action
+	}
+	return UplinkRuleAction_ENQUEUE_LINK_ADR_REQ
+}
+
+func (m *UplinkRule) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetTxPower() uint32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetMacCommand() []byte {
+	if m != nil {
+		return m.MacCommand
+	}
+	return nil
+}
+
+func (m *UplinkRule) GetClassCWindow() uint32 {
+	if m != nil {
+		return m.ClassCWindow
+	}
+	return 0
+}
+
+func (m *UplinkRule) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *UplinkRule) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type CreateUplinkRuleRequest struct {
+	// The rule to create.
+	Rule *UplinkRule `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (m *CreateUplinkRuleRequest) Reset()                    { *m = CreateUplinkRuleRequest{} }
+func (m *CreateUplinkRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateUplinkRuleRequest) ProtoMessage()               {}
+func (*CreateUplinkRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *CreateUplinkRuleRequest) GetRule() *UplinkRule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type CreateUplinkRuleResponse struct {
+	// ID of the created rule.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateUplinkRuleResponse) Reset()                    { *m = CreateUplinkRuleResponse{} }
+func (m *CreateUplinkRuleResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateUplinkRuleResponse) ProtoMessage()               {}
+func (*CreateUplinkRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+
+func (m *CreateUplinkRuleResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetUplinkRuleRequest struct {
+	// ID of the rule.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetUplinkRuleRequest) Reset()                    { *m = GetUplinkRuleRequest{} }
+func (m *GetUplinkRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetUplinkRuleRequest) ProtoMessage()               {}
+func (*GetUplinkRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+
+func (m *GetUplinkRuleRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetUplinkRuleResponse struct {
+	// The rule.
+	Rule *UplinkRule `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (m *GetUplinkRuleResponse) Reset()                    { *m = GetUplinkRuleResponse{} }
+func (m *GetUplinkRuleResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetUplinkRuleResponse) ProtoMessage()               {}
+func (*GetUplinkRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+
+func (m *GetUplinkRuleResponse) GetRule() *UplinkRule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type UpdateUplinkRuleRequest struct {
+	// The rule to update (matched by id).
+	Rule *UplinkRule `protobuf:"bytes,1,opt,name=rule" json:"rule,omitempty"`
+}
+
+func (m *UpdateUplinkRuleRequest) Reset()                    { *m = UpdateUplinkRuleRequest{} }
+func (m *UpdateUplinkRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUplinkRuleRequest) ProtoMessage()               {}
+func (*UpdateUplinkRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+
+func (m *UpdateUplinkRuleRequest) GetRule() *UplinkRule {
+	if m != nil {
+		return m.Rule
+	}
+	return nil
+}
+
+type UpdateUplinkRuleResponse struct {
+}
+
+func (m *UpdateUplinkRuleResponse) Reset()                    { *m = UpdateUplinkRuleResponse{} }
+func (m *UpdateUplinkRuleResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateUplinkRuleResponse) ProtoMessage()               {}
+func (*UpdateUplinkRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+type DeleteUplinkRuleRequest struct {
+	// ID of the rule.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteUplinkRuleRequest) Reset()                    { *m = DeleteUplinkRuleRequest{} }
+func (m *DeleteUplinkRuleRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUplinkRuleRequest) ProtoMessage()               {}
+func (*DeleteUplinkRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+func (m *DeleteUplinkRuleRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteUplinkRuleResponse struct {
+}
+
+func (m *DeleteUplinkRuleResponse) Reset()                    { *m = DeleteUplinkRuleResponse{} }
+func (m *DeleteUplinkRuleResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteUplinkRuleResponse) ProtoMessage()               {}
+func (*DeleteUplinkRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+type ListUplinkRulesRequest struct {
+	// Max number of rules to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListUplinkRulesRequest) Reset()                    { *m = ListUplinkRulesRequest{} }
+func (m *ListUplinkRulesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUplinkRulesRequest) ProtoMessage()               {}
+func (*ListUplinkRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *ListUplinkRulesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListUplinkRulesRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListUplinkRulesResponse struct {
+	// Total number of rules.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by id.
+	Result []*UplinkRule `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListUplinkRulesResponse) Reset()                    { *m = ListUplinkRulesResponse{} }
+func (m *ListUplinkRulesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUplinkRulesResponse) ProtoMessage()               {}
+func (*ListUplinkRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *ListUplinkRulesResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListUplinkRulesResponse) GetResult() []*UplinkRule {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetDeviceUplinkStatsRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Number of days to aggregate, including today (0 = only today). The
+	// stats are kept for 31 days.
+	Days uint32 `protobuf:"varint,2,opt,name=days" json:"days,omitempty"`
+}
+
+func (m *GetDeviceUplinkStatsRequest) Reset()                    { *m = GetDeviceUplinkStatsRequest{} }
+func (m *GetDeviceUplinkStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceUplinkStatsRequest) ProtoMessage()               {}
+func (*GetDeviceUplinkStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+func (m *GetDeviceUplinkStatsRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetDeviceUplinkStatsRequest) GetDays() uint32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+type FPortUplinkStats struct {
+	// FPort (0 for uplinks without FPort).
+	FPort uint32 `protobuf:"varint,1,opt,name=fPort" json:"fPort,omitempty"`
+	// Number of uplink frames.
+	Frames uint32 `protobuf:"varint,2,opt,name=frames" json:"frames,omitempty"`
+	// Number of uplink (PHYPayload) bytes.
+	Bytes uint32 `protobuf:"varint,3,opt,name=bytes" json:"bytes,omitempty"`
+}
+
+func (m *FPortUplinkStats) Reset()                    { *m = FPortUplinkStats{} }
+func (m *FPortUplinkStats) String() string            { return proto.CompactTextString(m) }
+func (*FPortUplinkStats) ProtoMessage()               {}
+func (*FPortUplinkStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+func (m *FPortUplinkStats) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *FPortUplinkStats) GetFrames() uint32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *FPortUplinkStats) GetBytes() uint32 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type GetDeviceUplinkStatsResponse struct {
+	// Uplink stats per FPort (ordered by FPort).
+	Result []*FPortUplinkStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// Total number of uplink frames.
+	Frames uint32 `protobuf:"varint,2,opt,name=frames" json:"frames,omitempty"`
+	// Total number of uplink (PHYPayload) bytes.
+	Bytes uint32 `protobuf:"varint,3,opt,name=bytes" json:"bytes,omitempty"`
+}
+
+func (m *GetDeviceUplinkStatsResponse) Reset()                    { *m = GetDeviceUplinkStatsResponse{} }
+func (m *GetDeviceUplinkStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceUplinkStatsResponse) ProtoMessage()               {}
+func (*GetDeviceUplinkStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+func (m *GetDeviceUplinkStatsResponse) GetResult() []*FPortUplinkStats {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetDeviceUplinkStatsResponse) GetFrames() uint32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *GetDeviceUplinkStatsResponse) GetBytes() uint32 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type GetTopTalkersRequest struct {
+	// Number of days to aggregate, including today (0 = only today). The
+	// stats are kept for 31 days.
+	Days uint32 `protobuf:"varint,1,opt,name=days" json:"days,omitempty"`
+	// Max number of nodes to return.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Field to order the nodes by (descending).
+	OrderBy TopTalkersOrderBy `protobuf:"varint,3,opt,name=orderBy,enum=ns.TopTalkersOrderBy" json:"orderBy,omitempty"`
+}
+
+func (m *GetTopTalkersRequest) Reset()                    { *m = GetTopTalkersRequest{} }
+func (m *GetTopTalkersRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetTopTalkersRequest) ProtoMessage()               {}
+func (*GetTopTalkersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *GetTopTalkersRequest) GetDays() uint32 {
+	if m != nil {
+		return m.Days
+	}
+	return 0
+}
+
+func (m *GetTopTalkersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetTopTalkersRequest) GetOrderBy() TopTalkersOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return TopTalkersOrderBy_ORDER_BY_FRAMES
+}
+
+type TopTalker struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Number of uplink frames.
+	Frames uint32 `protobuf:"varint,2,opt,name=frames" json:"frames,omitempty"`
+	// Number of uplink (PHYPayload) bytes.
+	Bytes uint32 `protobuf:"varint,3,opt,name=bytes" json:"bytes,omitempty"`
+}
+
+func (m *TopTalker) Reset()                    { *m = TopTalker{} }
+func (m *TopTalker) String() string            { return proto.CompactTextString(m) }
+func (*TopTalker) ProtoMessage()               {}
+func (*TopTalker) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+func (m *TopTalker) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *TopTalker) GetFrames() uint32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *TopTalker) GetBytes() uint32 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type GetTopTalkersResponse struct {
+	// The nodes, ordered by the requested field (descending).
+	Result []*TopTalker `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetTopTalkersResponse) Reset()                    { *m = GetTopTalkersResponse{} }
+func (m *GetTopTalkersResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetTopTalkersResponse) ProtoMessage()               {}
+func (*GetTopTalkersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+func (m *GetTopTalkersResponse) GetResult() []*TopTalker {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetLowLinkMarginNodesRequest struct {
+	// Link margin (dB) below which an uplink is counted as low.
+	Threshold float64 `protobuf:"fixed64,1,opt,name=threshold" json:"threshold,omitempty"`
+	// Min. number of uplinks in the uplink history of the node.
+	MinFrames uint32 `protobuf:"varint,2,opt,name=minFrames" json:"minFrames,omitempty"`
+	// Min. percentage of the uplinks with a link margin below the threshold
+	// (0 = at least one uplink).
+	Percentage uint32 `protobuf:"varint,3,opt,name=percentage" json:"percentage,omitempty"`
+	// Only return nodes of which the last uplink was received by this
+	// gateway (optional, 8 bytes).
+	Mac []byte `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Only return nodes of this AppEUI (optional, 8 bytes).
+	AppEUI []byte `protobuf:"bytes,5,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// The cursor returned by the previous call (0 for the first batch).
+	Cursor uint64 `protobuf:"varint,6,opt,name=cursor" json:"cursor,omitempty"`
+	// The (approximate) number of node-sessions to scan.
+	Limit int32 `protobuf:"varint,7,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetLowLinkMarginNodesRequest) Reset()                    { *m = GetLowLinkMarginNodesRequest{} }
+func (m *GetLowLinkMarginNodesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetLowLinkMarginNodesRequest) ProtoMessage()               {}
+func (*GetLowLinkMarginNodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+func (m *GetLowLinkMarginNodesRequest) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetMinFrames() uint32 {
+	if m != nil {
+		return m.MinFrames
+	}
+	return 0
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetPercentage() uint32 {
+	if m != nil {
+		return m.Percentage
+	}
+	return 0
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *GetLowLinkMarginNodesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type LowLinkMarginNode struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// AppEUI of the node.
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// MAC of the gateway with the best reception of the last uplink.
+	Mac []byte `protobuf:"bytes,3,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Spreading-factor of the last uplink.
+	SpreadFactor uint32 `protobuf:"varint,4,opt,name=spreadFactor" json:"spreadFactor,omitempty"`
+	// Number of uplinks in the uplink history.
+	Frames uint32 `protobuf:"varint,5,opt,name=frames" json:"frames,omitempty"`
+	// Number of uplinks with a link margin below the threshold.
+	LowFrames uint32 `protobuf:"varint,6,opt,name=lowFrames" json:"lowFrames,omitempty"`
+	// Min. link margin (dB).
+	MinMargin float64 `protobuf:"fixed64,7,opt,name=minMargin" json:"minMargin,omitempty"`
+	// Average link margin (dB).
+	AverageMargin float64 `protobuf:"fixed64,8,opt,name=averageMargin" json:"averageMargin,omitempty"`
+}
+
+func (m *LowLinkMarginNode) Reset()                    { *m = LowLinkMarginNode{} }
+func (m *LowLinkMarginNode) String() string            { return proto.CompactTextString(m) }
+func (*LowLinkMarginNode) ProtoMessage()               {}
+func (*LowLinkMarginNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+func (m *LowLinkMarginNode) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *LowLinkMarginNode) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *LowLinkMarginNode) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *LowLinkMarginNode) GetSpreadFactor() uint32 {
+	if m != nil {
+		return m.SpreadFactor
+	}
+	return 0
+}
+
+func (m *LowLinkMarginNode) GetFrames() uint32 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *LowLinkMarginNode) GetLowFrames() uint32 {
+	if m != nil {
+		return m.LowFrames
+	}
+	return 0
+}
+
+func (m *LowLinkMarginNode) GetMinMargin() float64 {
+	if m != nil {
+		return m.MinMargin
+	}
+	return 0
+}
+
+func (m *LowLinkMarginNode) GetAverageMargin() float64 {
+	if m != nil {
+		return m.AverageMargin
+	}
+	return 0
+}
+
+type GetLowLinkMarginNodesResponse struct {
+	// The nodes matching the filters.
+	Result []*LowLinkMarginNode `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// The cursor to use for the next batch (0 when all node-sessions have
+	// been scanned).
+	Cursor uint64 `protobuf:"varint,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *GetLowLinkMarginNodesResponse) Reset()         { *m = GetLowLinkMarginNodesResponse{} }
+func (m *GetLowLinkMarginNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLowLinkMarginNodesResponse) ProtoMessage()    {}
+func (*GetLowLinkMarginNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{109}
+}
+
+func (m *GetLowLinkMarginNodesResponse) GetResult() []*LowLinkMarginNode {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetLowLinkMarginNodesResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+type ClockDrift struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// AppEUI of the node (only set by GetClockDriftNodes).
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// Number of uplinks used for the estimation.
+	Samples uint32 `protobuf:"varint,3,opt,name=samples" json:"samples,omitempty"`
+	// Estimated time (in milliseconds) between two frame-counters.
+	Period float64 `protobuf:"fixed64,4,opt,name=period" json:"period,omitempty"`
+	// Nominal period (in milliseconds) of the node.
+	NominalPeriod float64 `protobuf:"fixed64,5,opt,name=nominalPeriod" json:"nominalPeriod,omitempty"`
+	// Deviation (in ppm) of the estimated period from the nominal period.
+	DriftPPM float64 `protobuf:"fixed64,6,opt,name=driftPPM" json:"driftPPM,omitempty"`
+	// Standard deviation (in milliseconds) of the receive time of the
+	// uplinks from the estimated period.
+	Jitter float64 `protobuf:"fixed64,7,opt,name=jitter" json:"jitter,omitempty"`
+	// The node transmits periodically (the jitter is small compared to the
+	// period). The drift of non-periodic nodes is meaningless.
+	Periodic bool `protobuf:"varint,8,opt,name=periodic" json:"periodic,omitempty"`
+	// Timing error (in milliseconds) of the node accumulated over a Class-B
+	// beacon period (128 seconds).
+	BeaconPeriodError float64 `protobuf:"fixed64,9,opt,name=beaconPeriodError" json:"beaconPeriodError,omitempty"`
+}
+
+func (m *ClockDrift) Reset()                    { *m = ClockDrift{} }
+func (m *ClockDrift) String() string            { return proto.CompactTextString(m) }
+func (*ClockDrift) ProtoMessage()               {}
+func (*ClockDrift) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+func (m *ClockDrift) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ClockDrift) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *ClockDrift) GetSamples() uint32 {
+	if m != nil {
+		return m.Samples
+	}
+	return 0
+}
+
+func (m *ClockDrift) GetPeriod() float64 {
+	if m != nil {
+		return m.Period
+	}
+	return 0
+}
+
+func (m *ClockDrift) GetNominalPeriod() float64 {
+	if m != nil {
+		return m.NominalPeriod
+	}
+	return 0
+}
+
+func (m *ClockDrift) GetDriftPPM() float64 {
+	if m != nil {
+		return m.DriftPPM
+	}
+	return 0
+}
+
+func (m *ClockDrift) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *ClockDrift) GetPeriodic() bool {
+	if m != nil {
+		return m.Periodic
+	}
+	return false
+}
+
+func (m *ClockDrift) GetBeaconPeriodError() float64 {
+	if m != nil {
+		return m.BeaconPeriodError
+	}
+	return 0
+}
+
+type GetDeviceClockDriftRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Nominal period (in seconds) of the node, 0 to use the estimated
+	// period rounded to the nearest second.
+	NominalPeriod uint32 `protobuf:"varint,2,opt,name=nominalPeriod" json:"nominalPeriod,omitempty"`
+}
+
+func (m *GetDeviceClockDriftRequest) Reset()                    { *m = GetDeviceClockDriftRequest{} }
+func (m *GetDeviceClockDriftRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceClockDriftRequest) ProtoMessage()               {}
+func (*GetDeviceClockDriftRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+func (m *GetDeviceClockDriftRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetDeviceClockDriftRequest) GetNominalPeriod() uint32 {
+	if m != nil {
+		return m.NominalPeriod
+	}
+	return 0
+}
+
+type GetDeviceClockDriftResponse struct {
+	// The estimated period and clock drift.
+	Result *ClockDrift `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *GetDeviceClockDriftResponse) Reset()                    { *m = GetDeviceClockDriftResponse{} }
+func (m *GetDeviceClockDriftResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceClockDriftResponse) ProtoMessage()               {}
+func (*GetDeviceClockDriftResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *GetDeviceClockDriftResponse) GetResult() *ClockDrift {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetClockDriftNodesRequest struct {
+	// Min. absolute clock drift (in ppm).
+	MinDriftPPM float64 `protobuf:"fixed64,1,opt,name=minDriftPPM" json:"minDriftPPM,omitempty"`
+	// Only return nodes of this AppEUI (optional, 8 bytes).
+	AppEUI []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	// The cursor returned by the previous call (0 for the first batch).
+	Cursor uint64 `protobuf:"varint,3,opt,name=cursor" json:"cursor,omitempty"`
+	// The (approximate) number of node-sessions to scan.
+	Limit int32 `protobuf:"varint,4,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetClockDriftNodesRequest) Reset()                    { *m = GetClockDriftNodesRequest{} }
+func (m *GetClockDriftNodesRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetClockDriftNodesRequest) ProtoMessage()               {}
+func (*GetClockDriftNodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *GetClockDriftNodesRequest) GetMinDriftPPM() float64 {
+	if m != nil {
+		return m.MinDriftPPM
+	}
+	return 0
+}
+
+func (m *GetClockDriftNodesRequest) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *GetClockDriftNodesRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *GetClockDriftNodesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetClockDriftNodesResponse struct {
+	// The nodes matching the filters.
+	Result []*ClockDrift `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+	// The cursor to use for the next batch (0 when all node-sessions have
+	// been scanned).
+	Cursor uint64 `protobuf:"varint,2,opt,name=cursor" json:"cursor,omitempty"`
+}
+
+func (m *GetClockDriftNodesResponse) Reset()                    { *m = GetClockDriftNodesResponse{} }
+func (m *GetClockDriftNodesResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetClockDriftNodesResponse) ProtoMessage()               {}
+func (*GetClockDriftNodesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+func (m *GetClockDriftNodesResponse) GetResult() []*ClockDrift {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *GetClockDriftNodesResponse) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+type RotateGatewayCUPSCredentialsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *RotateGatewayCUPSCredentialsRequest) Reset()         { *m = RotateGatewayCUPSCredentialsRequest{} }
+func (m *RotateGatewayCUPSCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateGatewayCUPSCredentialsRequest) ProtoMessage()    {}
+func (*RotateGatewayCUPSCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{115}
+}
+
+func (m *RotateGatewayCUPSCredentialsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type RotateGatewayCUPSCredentialsResponse struct {
+	// Token used by the gateway to authenticate to the CUPS endpoint.
+	CupsToken string `protobuf:"bytes,1,opt,name=cupsToken" json:"cupsToken,omitempty"`
+	// Token used by the gateway to authenticate to the LNS.
+	TcToken string `protobuf:"bytes,2,opt,name=tcToken" json:"tcToken,omitempty"`
+}
+
+func (m *RotateGatewayCUPSCredentialsResponse) Reset()         { *m = RotateGatewayCUPSCredentialsResponse{} }
+func (m *RotateGatewayCUPSCredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateGatewayCUPSCredentialsResponse) ProtoMessage()    {}
+func (*RotateGatewayCUPSCredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{116}
+}
+
+func (m *RotateGatewayCUPSCredentialsResponse) GetCupsToken() string {
+	if m != nil {
+		return m.CupsToken
+	}
+	return ""
+}
+
+func (m *RotateGatewayCUPSCredentialsResponse) GetTcToken() string {
+	if m != nil {
+		return m.TcToken
+	}
+	return ""
+}
+
+type GetGatewayCUPSCredentialsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *GetGatewayCUPSCredentialsRequest) Reset()         { *m = GetGatewayCUPSCredentialsRequest{} }
+func (m *GetGatewayCUPSCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayCUPSCredentialsRequest) ProtoMessage()    {}
+func (*GetGatewayCUPSCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{117}
+}
+
+func (m *GetGatewayCUPSCredentialsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GetGatewayCUPSCredentialsResponse struct {
+	// Token used by the gateway to authenticate to the CUPS endpoint.
+	CupsToken string `protobuf:"bytes,1,opt,name=cupsToken" json:"cupsToken,omitempty"`
+	// Token used by the gateway to authenticate to the LNS.
+	TcToken string `protobuf:"bytes,2,opt,name=tcToken" json:"tcToken,omitempty"`
+	// True when the gateway has not yet fetched its rotated credentials
+	// (the previous CUPS token is still accepted).
+	RotationPending bool `protobuf:"varint,3,opt,name=rotationPending" json:"rotationPending,omitempty"`
+	// Last update (rotation) timestamp of the credentials.
+	UpdatedAt string `protobuf:"bytes,4,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *GetGatewayCUPSCredentialsResponse) Reset()         { *m = GetGatewayCUPSCredentialsResponse{} }
+func (m *GetGatewayCUPSCredentialsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGatewayCUPSCredentialsResponse) ProtoMessage()    {}
+func (*GetGatewayCUPSCredentialsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{118}
+}
+
+func (m *GetGatewayCUPSCredentialsResponse) GetCupsToken() string {
+	if m != nil {
+		return m.CupsToken
+	}
+	return ""
+}
+
+func (m *GetGatewayCUPSCredentialsResponse) GetTcToken() string {
+	if m != nil {
+		return m.TcToken
+	}
+	return ""
+}
+
+func (m *GetGatewayCUPSCredentialsResponse) GetRotationPending() bool {
+	if m != nil {
+		return m.RotationPending
+	}
+	return false
+}
+
+func (m *GetGatewayCUPSCredentialsResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type GenerateGatewayClientCertificateRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *GenerateGatewayClientCertificateRequest) Reset() {
+	*m = GenerateGatewayClientCertificateRequest{}
+}
+func (m *GenerateGatewayClientCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*GenerateGatewayClientCertificateRequest) ProtoMessage()    {}
+func (*GenerateGatewayClientCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{119}
+}
+
+func (m *GenerateGatewayClientCertificateRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GenerateGatewayClientCertificateResponse struct {
+	// TLS certificate (PEM encoded).
+	TlsCert string `protobuf:"bytes,1,opt,name=tlsCert" json:"tlsCert,omitempty"`
+	// TLS key (PEM encoded).
+	TlsKey string `protobuf:"bytes,2,opt,name=tlsKey" json:"tlsKey,omitempty"`
+	// CA certificate (PEM encoded) by which the certificate has been signed.
+	CaCert string `protobuf:"bytes,3,opt,name=caCert" json:"caCert,omitempty"`
+	// Expiration timestamp of the certificate.
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *GenerateGatewayClientCertificateResponse) Reset() {
+	*m = GenerateGatewayClientCertificateResponse{}
+}
+func (m *GenerateGatewayClientCertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*GenerateGatewayClientCertificateResponse) ProtoMessage()    {}
+func (*GenerateGatewayClientCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{120}
+}
+
+func (m *GenerateGatewayClientCertificateResponse) GetTlsCert() string {
+	if m != nil {
+		return m.TlsCert
+	}
+	return ""
+}
+
+func (m *GenerateGatewayClientCertificateResponse) GetTlsKey() string {
+	if m != nil {
+		return m.TlsKey
+	}
+	return ""
+}
+
+func (m *GenerateGatewayClientCertificateResponse) GetCaCert() string {
+	if m != nil {
+		return m.CaCert
+	}
+	return ""
+}
+
+func (m *GenerateGatewayClientCertificateResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type ListRX2MismatchNodesRequest struct {
+}
+
+func (m *ListRX2MismatchNodesRequest) Reset()                    { *m = ListRX2MismatchNodesRequest{} }
+func (m *ListRX2MismatchNodesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRX2MismatchNodesRequest) ProtoMessage()               {}
+func (*ListRX2MismatchNodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+type RX2MismatchNode struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// RX2 data-rate of the node-session.
+	Rx2DR uint32 `protobuf:"varint,2,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	// Default RX2 data-rate of the band (acknowledged by the node).
+	DefaultRX2DR uint32 `protobuf:"varint,3,opt,name=defaultRX2DR" json:"defaultRX2DR,omitempty"`
+	// Timestamp of the detection.
+	DetectedAt string `protobuf:"bytes,4,opt,name=detectedAt" json:"detectedAt,omitempty"`
+}
+
+func (m *RX2MismatchNode) Reset()                    { *m = RX2MismatchNode{} }
+func (m *RX2MismatchNode) String() string            { return proto.CompactTextString(m) }
+func (*RX2MismatchNode) ProtoMessage()               {}
+func (*RX2MismatchNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
+
+func (m *RX2MismatchNode) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *RX2MismatchNode) GetRx2DR() uint32 {
+	if m != nil {
+		return m.Rx2DR
+	}
+	return 0
+}
+
+func (m *RX2MismatchNode) GetDefaultRX2DR() uint32 {
+	if m != nil {
+		return m.DefaultRX2DR
+	}
+	return 0
+}
+
+func (m *RX2MismatchNode) GetDetectedAt() string {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return ""
+}
+
+type ListRX2MismatchNodesResponse struct {
+	// The flagged nodes, most recently detected first.
+	Result []*RX2MismatchNode `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListRX2MismatchNodesResponse) Reset()                    { *m = ListRX2MismatchNodesResponse{} }
+func (m *ListRX2MismatchNodesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListRX2MismatchNodesResponse) ProtoMessage()               {}
+func (*ListRX2MismatchNodesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
+
+func (m *ListRX2MismatchNodesResponse) GetResult() []*RX2MismatchNode {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ClearRX2MismatchRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *ClearRX2MismatchRequest) Reset()                    { *m = ClearRX2MismatchRequest{} }
+func (m *ClearRX2MismatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClearRX2MismatchRequest) ProtoMessage()               {}
+func (*ClearRX2MismatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+func (m *ClearRX2MismatchRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type ClearRX2MismatchResponse struct {
+}
+
+func (m *ClearRX2MismatchResponse) Reset()                    { *m = ClearRX2MismatchResponse{} }
+func (m *ClearRX2MismatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*ClearRX2MismatchResponse) ProtoMessage()               {}
+func (*ClearRX2MismatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+type ListFCntDownRolloverNodesRequest struct {
+}
+
+func (m *ListFCntDownRolloverNodesRequest) Reset()         { *m = ListFCntDownRolloverNodesRequest{} }
+func (m *ListFCntDownRolloverNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFCntDownRolloverNodesRequest) ProtoMessage()    {}
+func (*ListFCntDownRolloverNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{126}
+}
+
+type FCntDownRolloverNode struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The next downlink frame-counter of the node.
+	FCntDown uint32 `protobuf:"varint,2,opt,name=fCntDown" json:"fCntDown,omitempty"`
+	// Timestamp of the detection.
+	DetectedAt string `protobuf:"bytes,3,opt,name=detectedAt" json:"detectedAt,omitempty"`
+}
+
+func (m *FCntDownRolloverNode) Reset()                    { *m = FCntDownRolloverNode{} }
+func (m *FCntDownRolloverNode) String() string            { return proto.CompactTextString(m) }
+func (*FCntDownRolloverNode) ProtoMessage()               {}
+func (*FCntDownRolloverNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+func (m *FCntDownRolloverNode) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *FCntDownRolloverNode) GetFCntDown() uint32 {
+	if m != nil {
+		return m.FCntDown
+	}
+	return 0
+}
+
+func (m *FCntDownRolloverNode) GetDetectedAt() string {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return ""
+}
+
+type ListFCntDownRolloverNodesResponse struct {
+	// The flagged nodes, most recently detected first.
+	Result []*FCntDownRolloverNode `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListFCntDownRolloverNodesResponse) Reset()         { *m = ListFCntDownRolloverNodesResponse{} }
+func (m *ListFCntDownRolloverNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFCntDownRolloverNodesResponse) ProtoMessage()    {}
+func (*ListFCntDownRolloverNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{128}
+}
+
+func (m *ListFCntDownRolloverNodesResponse) GetResult() []*FCntDownRolloverNode {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetOversizedFrameOffendersRequest struct {
+	// Max number of nodes and gateways to return.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Min number of oversized frames (default 1).
+	MinCount uint32 `protobuf:"varint,2,opt,name=minCount" json:"minCount,omitempty"`
+}
+
+func (m *GetOversizedFrameOffendersRequest) Reset()         { *m = GetOversizedFrameOffendersRequest{} }
+func (m *GetOversizedFrameOffendersRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOversizedFrameOffendersRequest) ProtoMessage()    {}
+func (*GetOversizedFrameOffendersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{129}
+}
+
+func (m *GetOversizedFrameOffendersRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetOversizedFrameOffendersRequest) GetMinCount() uint32 {
+	if m != nil {
+		return m.MinCount
+	}
+	return 0
+}
+
+type OversizedFrameDevice struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Number of oversized frames.
+	Count uint32 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *OversizedFrameDevice) Reset()                    { *m = OversizedFrameDevice{} }
+func (m *OversizedFrameDevice) String() string            { return proto.CompactTextString(m) }
+func (*OversizedFrameDevice) ProtoMessage()               {}
+func (*OversizedFrameDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
+
+func (m *OversizedFrameDevice) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *OversizedFrameDevice) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type OversizedFrameGateway struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Number of oversized frames received by the gateway.
+	Count uint32 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *OversizedFrameGateway) Reset()                    { *m = OversizedFrameGateway{} }
+func (m *OversizedFrameGateway) String() string            { return proto.CompactTextString(m) }
+func (*OversizedFrameGateway) ProtoMessage()               {}
+func (*OversizedFrameGateway) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
+
+func (m *OversizedFrameGateway) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *OversizedFrameGateway) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type GetOversizedFrameOffendersResponse struct {
+	// Nodes with the most oversized frames (most first).
+	Devices []*OversizedFrameDevice `protobuf:"bytes,1,rep,name=devices" json:"devices,omitempty"`
+	// Gateways which received the most oversized frames (most first).
+	Gateways []*OversizedFrameGateway `protobuf:"bytes,2,rep,name=gateways" json:"gateways,omitempty"`
+}
+
+func (m *GetOversizedFrameOffendersResponse) Reset()         { *m = GetOversizedFrameOffendersResponse{} }
+func (m *GetOversizedFrameOffendersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOversizedFrameOffendersResponse) ProtoMessage()    {}
+func (*GetOversizedFrameOffendersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{132}
+}
+
+func (m *GetOversizedFrameOffendersResponse) GetDevices() []*OversizedFrameDevice {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *GetOversizedFrameOffendersResponse) GetGateways() []*OversizedFrameGateway {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+type DeviceQueueItem struct {
+	// Data (encrypted with the AppSKey, unless the AppSKey encryption is
+	// offloaded to LoRa Server).
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Payload must be acknowledged by the node.
+	Confirmed bool `protobuf:"varint,2,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FPort to use for transmitting the payload.
+	FPort uint32 `protobuf:"varint,3,opt,name=fPort" json:"fPort,omitempty"`
+	// FCnt used for encrypting the data. When the AppSKey encryption is not
+	// offloaded and this does not match the FCntDown at transmission, the
+	// payload is dropped and reported to the application-server.
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+	// The payload is critical and must be sent as confirmed payload, even
+	// when the battery level of the node is below its batteryThrottleLevel.
+	Critical bool `protobuf:"varint,5,opt,name=critical" json:"critical,omitempty"`
+	// Client reference of the payload (optional), included in the ACK
+	// notification of a confirmed payload.
+	Reference string `protobuf:"bytes,6,opt,name=reference" json:"reference,omitempty"`
+	// Timestamp (RFC3339) of enqueueing the payload (ignored on enqueue).
+	EnqueuedAt string `protobuf:"bytes,7,opt,name=enqueuedAt" json:"enqueuedAt,omitempty"`
+	// Timestamp (RFC3339) after which the payload must not be sent anymore
+	// (optional). Expired payloads are dropped at scheduling time and reported
+	// to the application-server.
+	ExpiresAt string `protobuf:"bytes,8,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp (RFC3339) before which the payload must not be sent (optional).
+	// Held payloads are skipped when scheduling a downlink and are sent to
+	// Class-C nodes once due.
+	NotBefore string `protobuf:"bytes,9,opt,name=notBefore" json:"notBefore,omitempty"`
+}
+
+func (m *DeviceQueueItem) Reset()                    { *m = DeviceQueueItem{} }
+func (m *DeviceQueueItem) String() string            { return proto.CompactTextString(m) }
+func (*DeviceQueueItem) ProtoMessage()               {}
+func (*DeviceQueueItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
+
+func (m *DeviceQueueItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *DeviceQueueItem) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *DeviceQueueItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DeviceQueueItem) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetEnqueuedAt() string {
+	if m != nil {
+		return m.EnqueuedAt
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetNotBefore() string {
+	if m != nil {
+		return m.NotBefore
+	}
+	return ""
+}
+
+type EnqueueDeviceQueueItemRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Payload to enqueue.
+	Item *DeviceQueueItem `protobuf:"bytes,2,opt,name=item" json:"item,omitempty"`
+}
+
+func (m *EnqueueDeviceQueueItemRequest) Reset()         { *m = EnqueueDeviceQueueItemRequest{} }
+func (m *EnqueueDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{134}
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetItem() *DeviceQueueItem {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+type EnqueueDeviceQueueItemResponse struct {
+}
+
+func (m *EnqueueDeviceQueueItemResponse) Reset()         { *m = EnqueueDeviceQueueItemResponse{} }
+func (m *EnqueueDeviceQueueItemResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueItemResponse) ProtoMessage()    {}
+func (*EnqueueDeviceQueueItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{135}
+}
+
+type GetDeviceQueueItemsRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *GetDeviceQueueItemsRequest) Reset()                    { *m = GetDeviceQueueItemsRequest{} }
+func (m *GetDeviceQueueItemsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemsRequest) ProtoMessage()               {}
+func (*GetDeviceQueueItemsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *GetDeviceQueueItemsRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type GetDeviceQueueItemsResponse struct {
+	// Downlink payloads (the first is sent first).
+	Items []*DeviceQueueItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *GetDeviceQueueItemsResponse) Reset()                    { *m = GetDeviceQueueItemsResponse{} }
+func (m *GetDeviceQueueItemsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemsResponse) ProtoMessage()               {}
+func (*GetDeviceQueueItemsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+func (m *GetDeviceQueueItemsResponse) GetItems() []*DeviceQueueItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type FlushDeviceQueueRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *FlushDeviceQueueRequest) Reset()                    { *m = FlushDeviceQueueRequest{} }
+func (m *FlushDeviceQueueRequest) String() string            { return proto.CompactTextString(m) }
+func (*FlushDeviceQueueRequest) ProtoMessage()               {}
+func (*FlushDeviceQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *FlushDeviceQueueRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type FlushDeviceQueueResponse struct {
+}
+
+func (m *FlushDeviceQueueResponse) Reset()                    { *m = FlushDeviceQueueResponse{} }
+func (m *FlushDeviceQueueResponse) String() string            { return proto.CompactTextString(m) }
+func (*FlushDeviceQueueResponse) ProtoMessage()               {}
+func (*FlushDeviceQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+type GetNextDownlinkFCntRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Reserve the returned frame-counter, so that it is not returned to
+	// other callers.
+	Reserve bool `protobuf:"varint,2,opt,name=reserve" json:"reserve,omitempty"`
+	// Validity of the reservation in seconds (0 = 60 seconds).
+	ReservationTTL uint32 `protobuf:"varint,3,opt,name=reservationTTL" json:"reservationTTL,omitempty"`
+}
+
+func (m *GetNextDownlinkFCntRequest) Reset()                    { *m = GetNextDownlinkFCntRequest{} }
+func (m *GetNextDownlinkFCntRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNextDownlinkFCntRequest) ProtoMessage()               {}
+func (*GetNextDownlinkFCntRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *GetNextDownlinkFCntRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetNextDownlinkFCntRequest) GetReserve() bool {
+	if m != nil {
+		return m.Reserve
+	}
+	return false
+}
+
+func (m *GetNextDownlinkFCntRequest) GetReservationTTL() uint32 {
+	if m != nil {
+		return m.ReservationTTL
+	}
+	return 0
+}
+
+type GetNextDownlinkFCntResponse struct {
+	// Frame-counter of the next enqueued payload.
+	FCnt uint32 `protobuf:"varint,1,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *GetNextDownlinkFCntResponse) Reset()                    { *m = GetNextDownlinkFCntResponse{} }
+func (m *GetNextDownlinkFCntResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetNextDownlinkFCntResponse) ProtoMessage()               {}
+func (*GetNextDownlinkFCntResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *GetNextDownlinkFCntResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type MulticastGroup struct {
+	// ID of the multicast group (ignored on create).
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the multicast group.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Multicast address (DevAddr) of the group.
+	McAddr []byte `protobuf:"bytes,3,opt,name=mcAddr,proto3" json:"mcAddr,omitempty"`
+	// Multicast network session key of the group.
+	McNwkSKey []byte `protobuf:"bytes,4,opt,name=mcNwkSKey,proto3" json:"mcNwkSKey,omitempty"`
+	// Multicast application session key of the group. When set, the
+	// payloads are encrypted by LoRa Server, else the payloads must be
+	// encrypted by the application-server.
+	McAppSKey []byte `protobuf:"bytes,5,opt,name=mcAppSKey,proto3" json:"mcAppSKey,omitempty"`
+	// Frequency (Hz) on which the payloads are transmitted.
+	Frequency uint32 `protobuf:"varint,6,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate at which the payloads are transmitted.
+	Dr uint32 `protobuf:"varint,7,opt,name=dr" json:"dr,omitempty"`
+	// Frame-counter of the next payload (it can only be increased on
+	// update).
+	FCnt uint32 `protobuf:"varint,8,opt,name=fCnt" json:"fCnt,omitempty"`
+	// MAC addresses of the gateways via which the payloads are
+	// transmitted.
+	Gateways [][]byte `protobuf:"bytes,9,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	// Created-at timestamp (RFC3339Nano, ignored on create and update).
+	CreatedAt string `protobuf:"bytes,10,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Updated-at timestamp (RFC3339Nano, ignored on create and update).
+	UpdatedAt string `protobuf:"bytes,11,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *MulticastGroup) Reset()                    { *m = MulticastGroup{} }
+func (m *MulticastGroup) String() string            { return proto.CompactTextString(m) }
+func (*MulticastGroup) ProtoMessage()               {}
+func (*MulticastGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
+
+func (m *MulticastGroup) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MulticastGroup) GetMcAddr() []byte {
+	if m != nil {
+		return m.McAddr
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetMcNwkSKey() []byte {
+	if m != nil {
+		return m.McNwkSKey
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetMcAppSKey() []byte {
+	if m != nil {
+		return m.McAppSKey
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetGateways() [][]byte {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *MulticastGroup) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type CreateMulticastGroupRequest struct {
+	// The multicast group to create.
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+}
+
+func (m *CreateMulticastGroupRequest) Reset()                    { *m = CreateMulticastGroupRequest{} }
+func (m *CreateMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupRequest) ProtoMessage()               {}
+func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
+
+func (m *CreateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type CreateMulticastGroupResponse struct {
+	// ID of the created multicast group.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateMulticastGroupResponse) Reset()                    { *m = CreateMulticastGroupResponse{} }
+func (m *CreateMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupResponse) ProtoMessage()               {}
+func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
+
+func (m *CreateMulticastGroupResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetMulticastGroupRequest struct {
+	// ID of the multicast group.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetMulticastGroupRequest) Reset()                    { *m = GetMulticastGroupRequest{} }
+func (m *GetMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetMulticastGroupRequest) ProtoMessage()               {}
+func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
+
+func (m *GetMulticastGroupRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetMulticastGroupResponse struct {
+	// The multicast group.
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+}
+
+func (m *GetMulticastGroupResponse) Reset()                    { *m = GetMulticastGroupResponse{} }
+func (m *GetMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetMulticastGroupResponse) ProtoMessage()               {}
+func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{146} }
+
+func (m *GetMulticastGroupResponse) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type UpdateMulticastGroupRequest struct {
+	// The multicast group to update (matched by id).
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+}
+
+func (m *UpdateMulticastGroupRequest) Reset()                    { *m = UpdateMulticastGroupRequest{} }
+func (m *UpdateMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupRequest) ProtoMessage()               {}
+func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{147} }
+
+func (m *UpdateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type UpdateMulticastGroupResponse struct {
+}
+
+func (m *UpdateMulticastGroupResponse) Reset()                    { *m = UpdateMulticastGroupResponse{} }
+func (m *UpdateMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupResponse) ProtoMessage()               {}
+func (*UpdateMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{148} }
+
+type DeleteMulticastGroupRequest struct {
+	// ID of the multicast group.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteMulticastGroupRequest) Reset()                    { *m = DeleteMulticastGroupRequest{} }
+func (m *DeleteMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupRequest) ProtoMessage()               {}
+func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{149} }
+
+func (m *DeleteMulticastGroupRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteMulticastGroupResponse struct {
+}
+
+func (m *DeleteMulticastGroupResponse) Reset()                    { *m = DeleteMulticastGroupResponse{} }
+func (m *DeleteMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupResponse) ProtoMessage()               {}
+func (*DeleteMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{150} }
+
+type ListMulticastGroupsRequest struct {
+	// Max number of multicast groups to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListMulticastGroupsRequest) Reset()                    { *m = ListMulticastGroupsRequest{} }
+func (m *ListMulticastGroupsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListMulticastGroupsRequest) ProtoMessage()               {}
+func (*ListMulticastGroupsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{151} }
+
+func (m *ListMulticastGroupsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListMulticastGroupsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListMulticastGroupsResponse struct {
+	// Total number of multicast groups.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by id.
+	Result []*MulticastGroup `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListMulticastGroupsResponse) Reset()                    { *m = ListMulticastGroupsResponse{} }
+func (m *ListMulticastGroupsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListMulticastGroupsResponse) ProtoMessage()               {}
+func (*ListMulticastGroupsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{152} }
+
+func (m *ListMulticastGroupsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListMulticastGroupsResponse) GetResult() []*MulticastGroup {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type EnqueueMulticastQueueItemRequest struct {
+	// ID of the multicast group.
+	MulticastGroupID int64 `protobuf:"varint,1,opt,name=multicastGroupID" json:"multicastGroupID,omitempty"`
+	// FPort of the payload (must be > 0).
+	FPort uint32 `protobuf:"varint,2,opt,name=fPort" json:"fPort,omitempty"`
+	// Payload to transmit.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Frame-counter used for encrypting the payload (ignored when the
+	// multicast group has a mcAppSKey).
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMulticastQueueItemRequest{} }
+func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{153}
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetMulticastGroupID() int64 {
+	if m != nil {
+		return m.MulticastGroupID
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type EnqueueMulticastQueueItemResponse struct {
+	// Frame-counter of the enqueued payload.
+	FCnt uint32 `protobuf:"varint,1,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *EnqueueMulticastQueueItemResponse) Reset()         { *m = EnqueueMulticastQueueItemResponse{} }
+func (m *EnqueueMulticastQueueItemResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemResponse) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{154}
+}
+
+func (m *EnqueueMulticastQueueItemResponse) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type FlushMulticastQueueRequest struct {
+	// ID of the multicast group.
+	MulticastGroupID int64 `protobuf:"varint,1,opt,name=multicastGroupID" json:"multicastGroupID,omitempty"`
+}
+
+func (m *FlushMulticastQueueRequest) Reset()                    { *m = FlushMulticastQueueRequest{} }
+func (m *FlushMulticastQueueRequest) String() string            { return proto.CompactTextString(m) }
+func (*FlushMulticastQueueRequest) ProtoMessage()               {}
+func (*FlushMulticastQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{155} }
+
+func (m *FlushMulticastQueueRequest) GetMulticastGroupID() int64 {
+	if m != nil {
+		return m.MulticastGroupID
+	}
+	return 0
+}
+
+type FlushMulticastQueueResponse struct {
+}
+
+func (m *FlushMulticastQueueResponse) Reset()                    { *m = FlushMulticastQueueResponse{} }
+func (m *FlushMulticastQueueResponse) String() string            { return proto.CompactTextString(m) }
+func (*FlushMulticastQueueResponse) ProtoMessage()               {}
+func (*FlushMulticastQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{156} }
+
+type FrameLogDataRate struct {
+	// Modulation (LORA or FSK).
+	Modulation string `protobuf:"bytes,1,opt,name=modulation" json:"modulation,omitempty"`
+	// Bandwidth (kHz).
+	BandWidth uint32 `protobuf:"varint,2,opt,name=bandWidth" json:"bandWidth,omitempty"`
+	// Spreading-factor (LoRa modulation).
+	SpreadFactor uint32 `protobuf:"varint,3,opt,name=spreadFactor" json:"spreadFactor,omitempty"`
+	// Bitrate (FSK modulation).
+	Bitrate uint32 `protobuf:"varint,4,opt,name=bitrate" json:"bitrate,omitempty"`
+}
+
+func (m *FrameLogDataRate) Reset()                    { *m = FrameLogDataRate{} }
+func (m *FrameLogDataRate) String() string            { return proto.CompactTextString(m) }
+func (*FrameLogDataRate) ProtoMessage()               {}
+func (*FrameLogDataRate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{157} }
+
+func (m *FrameLogDataRate) GetModulation() string {
+	if m != nil {
+		return m.Modulation
+	}
+	return ""
+}
+
+func (m *FrameLogDataRate) GetBandWidth() uint32 {
+	if m != nil {
+		return m.BandWidth
+	}
+	return 0
+}
+
+func (m *FrameLogDataRate) GetSpreadFactor() uint32 {
+	if m != nil {
+		return m.SpreadFactor
+	}
+	return 0
+}
+
+func (m *FrameLogDataRate) GetBitrate() uint32 {
+	if m != nil {
+		return m.Bitrate
+	}
+	return 0
+}
+
+type FrameLogRXInfo struct {
+	// MAC address of the receiving gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Time when the frame was received (RFC3339Nano, empty when not
+	// provided by the gateway).
+	Time string `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// Gateway internal receive timestamp (microseconds).
+	Timestamp uint32 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Frequency (Hz).
+	Frequency int64 `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	// Concentrator IF channel.
+	Channel int32 `protobuf:"varint,5,opt,name=channel" json:"channel,omitempty"`
+	// RF chain.
+	RfChain int32 `protobuf:"varint,6,opt,name=rfChain" json:"rfChain,omitempty"`
+	// Antenna.
+	Antenna int32 `protobuf:"varint,7,opt,name=antenna" json:"antenna,omitempty"`
+	// CRC status (1 = OK, -1 = fail, 0 = no CRC).
+	CrcStatus int32 `protobuf:"varint,8,opt,name=crcStatus" json:"crcStatus,omitempty"`
+	// ECC code rate.
+	CodeRate string `protobuf:"bytes,9,opt,name=codeRate" json:"codeRate,omitempty"`
+	// RSSI (dBm).
+	Rssi int32 `protobuf:"varint,10,opt,name=rssi" json:"rssi,omitempty"`
+	// LoRa SNR (dB).
+	LoRaSNR float64 `protobuf:"fixed64,11,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+	// Data-rate.
+	DataRate *FrameLogDataRate `protobuf:"bytes,12,opt,name=dataRate" json:"dataRate,omitempty"`
+}
+
+func (m *FrameLogRXInfo) Reset()                    { *m = FrameLogRXInfo{} }
+func (m *FrameLogRXInfo) String() string            { return proto.CompactTextString(m) }
+func (*FrameLogRXInfo) ProtoMessage()               {}
+func (*FrameLogRXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{158} }
+
+func (m *FrameLogRXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *FrameLogRXInfo) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *FrameLogRXInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetFrequency() int64 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetChannel() int32 {
+	if m != nil {
+		return m.Channel
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetRfChain() int32 {
+	if m != nil {
+		return m.RfChain
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetAntenna() int32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetCrcStatus() int32 {
+	if m != nil {
+		return m.CrcStatus
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *FrameLogRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetLoRaSNR() float64 {
+	if m != nil {
+		return m.LoRaSNR
+	}
+	return 0
+}
+
+func (m *FrameLogRXInfo) GetDataRate() *FrameLogDataRate {
+	if m != nil {
+		return m.DataRate
+	}
+	return nil
+}
+
+type FrameLogTXInfo struct {
+	// MAC address of the transmitting gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// The frame is transmitted immediately.
+	Immediately bool `protobuf:"varint,2,opt,name=immediately" json:"immediately,omitempty"`
+	// Gateway internal timestamp (microseconds) at which the frame is
+	// transmitted.
+	Timestamp uint32 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Frequency (Hz).
+	Frequency int64 `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	// TX power (dBm).
+	Power int32 `protobuf:"varint,5,opt,name=power" json:"power,omitempty"`
+	// Data-rate.
+	DataRate *FrameLogDataRate `protobuf:"bytes,6,opt,name=dataRate" json:"dataRate,omitempty"`
+	// ECC code rate.
+	CodeRate string `protobuf:"bytes,7,opt,name=codeRate" json:"codeRate,omitempty"`
+	// Token of the frame (echoed by the gateway in the TX acknowledgement).
+	Token uint32 `protobuf:"varint,8,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *FrameLogTXInfo) Reset()                    { *m = FrameLogTXInfo{} }
+func (m *FrameLogTXInfo) String() string            { return proto.CompactTextString(m) }
+func (*FrameLogTXInfo) ProtoMessage()               {}
+func (*FrameLogTXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{159} }
+
+func (m *FrameLogTXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *FrameLogTXInfo) GetImmediately() bool {
+	if m != nil {
+		return m.Immediately
+	}
+	return false
+}
+
+func (m *FrameLogTXInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *FrameLogTXInfo) GetFrequency() int64 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *FrameLogTXInfo) GetPower() int32 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+func (m *FrameLogTXInfo) GetDataRate() *FrameLogDataRate {
+	if m != nil {
+		return m.DataRate
+	}
+	return nil
+}
+
+func (m *FrameLogTXInfo) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *FrameLogTXInfo) GetToken() uint32 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+type FrameLog struct {
+	// Time when the frame was logged (RFC3339Nano).
+	CreatedAt string `protobuf:"bytes,1,opt,name=createdAt" json:"createdAt,omitempty"`
+	// DevEUI of the node (not set for frames of which the node is not
+	// known, e.g. in the frame logs of a gateway).
+	DevEUI []byte `protobuf:"bytes,2,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Message-type of the frame (as defined by the LoRaWAN specification).
+	MType uint32 `protobuf:"varint,3,opt,name=mType" json:"mType,omitempty"`
+	// PHYPayload of the frame.
+	PhyPayload []byte `protobuf:"bytes,4,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
+	// RX info of the receiving gateway(s) (uplink frames).
+	RxInfo []*FrameLogRXInfo `protobuf:"bytes,5,rep,name=rxInfo" json:"rxInfo,omitempty"`
+	// TX info (downlink frames).
+	TxInfo *FrameLogTXInfo `protobuf:"bytes,6,opt,name=txInfo" json:"txInfo,omitempty"`
+}
+
+func (m *FrameLog) Reset()                    { *m = FrameLog{} }
+func (m *FrameLog) String() string            { return proto.CompactTextString(m) }
+func (*FrameLog) ProtoMessage()               {}
+func (*FrameLog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{160} }
+
+func (m *FrameLog) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *FrameLog) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *FrameLog) GetMType() uint32 {
+	if m != nil {
+		return m.MType
+	}
+	return 0
+}
+
+func (m *FrameLog) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
+func (m *FrameLog) GetRxInfo() []*FrameLogRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+func (m *FrameLog) GetTxInfo() *FrameLogTXInfo {
+	if m != nil {
+		return m.TxInfo
+	}
+	return nil
+}
+
+type StreamFrameLogsForDeviceRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *StreamFrameLogsForDeviceRequest) Reset()         { *m = StreamFrameLogsForDeviceRequest{} }
+func (m *StreamFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamFrameLogsForDeviceRequest) ProtoMessage()    {}
+func (*StreamFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{161}
+}
+
+func (m *StreamFrameLogsForDeviceRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type StreamFrameLogsForGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *StreamFrameLogsForGatewayRequest) Reset()         { *m = StreamFrameLogsForGatewayRequest{} }
+func (m *StreamFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamFrameLogsForGatewayRequest) ProtoMessage()    {}
+func (*StreamFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{162}
+}
+
+func (m *StreamFrameLogsForGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type GetFrameLogsForDeviceRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Max number of frames to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *GetFrameLogsForDeviceRequest) Reset()                    { *m = GetFrameLogsForDeviceRequest{} }
+func (m *GetFrameLogsForDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetFrameLogsForDeviceRequest) ProtoMessage()               {}
+func (*GetFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{163} }
+
+func (m *GetFrameLogsForDeviceRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *GetFrameLogsForDeviceRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetFrameLogsForDeviceRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type GetFrameLogsForGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Max number of frames to return in the result-set.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *GetFrameLogsForGatewayRequest) Reset()         { *m = GetFrameLogsForGatewayRequest{} }
+func (m *GetFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFrameLogsForGatewayRequest) ProtoMessage()    {}
+func (*GetFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{164}
+}
+
+func (m *GetFrameLogsForGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetFrameLogsForGatewayRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetFrameLogsForGatewayRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type GetFrameLogsResponse struct {
+	// Total number of logged frames.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set (newest first).
+	Result []*FrameLog `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetFrameLogsResponse) Reset()                    { *m = GetFrameLogsResponse{} }
+func (m *GetFrameLogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetFrameLogsResponse) ProtoMessage()               {}
+func (*GetFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{165} }
+
+func (m *GetFrameLogsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *GetFrameLogsResponse) GetResult() []*FrameLog {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DownlinkTemplate struct {
+	// ID of the downlink template (ignored on create).
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the downlink template.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// FPort of the payload (1 - 223).
+	FPort uint32 `protobuf:"varint,3,opt,name=fPort" json:"fPort,omitempty"`
+	// Payload pattern: HEX encoded bytes and variables between double curly
+	// braces, e.g. 01{{time}}{{tag:site}}. See the documentation for the
+	// available variables.
+	Payload string `protobuf:"bytes,4,opt,name=payload" json:"payload,omitempty"`
+	// Payload must be acknowledged by the node.
+	Confirmed bool `protobuf:"varint,5,opt,name=confirmed" json:"confirmed,omitempty"`
+	// Created-at timestamp (RFC3339Nano, ignored on create and update).
+	CreatedAt string `protobuf:"bytes,6,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Updated-at timestamp (RFC3339Nano, ignored on create and update).
+	UpdatedAt string `protobuf:"bytes,7,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *DownlinkTemplate) Reset()                    { *m = DownlinkTemplate{} }
+func (m *DownlinkTemplate) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkTemplate) ProtoMessage()               {}
+func (*DownlinkTemplate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{166} }
+
+func (m *DownlinkTemplate) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DownlinkTemplate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DownlinkTemplate) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *DownlinkTemplate) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
+}
+
+func (m *DownlinkTemplate) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *DownlinkTemplate) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *DownlinkTemplate) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type CreateDownlinkTemplateRequest struct {
+	// The downlink template to create.
+	Template *DownlinkTemplate `protobuf:"bytes,1,opt,name=template" json:"template,omitempty"`
+}
+
+func (m *CreateDownlinkTemplateRequest) Reset()         { *m = CreateDownlinkTemplateRequest{} }
+func (m *CreateDownlinkTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateDownlinkTemplateRequest) ProtoMessage()    {}
+func (*CreateDownlinkTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{167}
+}
+
+func (m *CreateDownlinkTemplateRequest) GetTemplate() *DownlinkTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type CreateDownlinkTemplateResponse struct {
+	// ID of the created downlink template.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateDownlinkTemplateResponse) Reset()         { *m = CreateDownlinkTemplateResponse{} }
+func (m *CreateDownlinkTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateDownlinkTemplateResponse) ProtoMessage()    {}
+func (*CreateDownlinkTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{168}
+}
+
+func (m *CreateDownlinkTemplateResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetDownlinkTemplateRequest struct {
+	// ID of the downlink template.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetDownlinkTemplateRequest) Reset()                    { *m = GetDownlinkTemplateRequest{} }
+func (m *GetDownlinkTemplateRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkTemplateRequest) ProtoMessage()               {}
+func (*GetDownlinkTemplateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{169} }
+
+func (m *GetDownlinkTemplateRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetDownlinkTemplateResponse struct {
+	// The downlink template.
+	Template *DownlinkTemplate `protobuf:"bytes,1,opt,name=template" json:"template,omitempty"`
+}
+
+func (m *GetDownlinkTemplateResponse) Reset()                    { *m = GetDownlinkTemplateResponse{} }
+func (m *GetDownlinkTemplateResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDownlinkTemplateResponse) ProtoMessage()               {}
+func (*GetDownlinkTemplateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{170} }
+
+func (m *GetDownlinkTemplateResponse) GetTemplate() *DownlinkTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type UpdateDownlinkTemplateRequest struct {
+	// The downlink template to update (matched by id).
+	Template *DownlinkTemplate `protobuf:"bytes,1,opt,name=template" json:"template,omitempty"`
+}
+
+func (m *UpdateDownlinkTemplateRequest) Reset()         { *m = UpdateDownlinkTemplateRequest{} }
+func (m *UpdateDownlinkTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDownlinkTemplateRequest) ProtoMessage()    {}
+func (*UpdateDownlinkTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{171}
+}
+
+func (m *UpdateDownlinkTemplateRequest) GetTemplate() *DownlinkTemplate {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type UpdateDownlinkTemplateResponse struct {
+}
+
+func (m *UpdateDownlinkTemplateResponse) Reset()         { *m = UpdateDownlinkTemplateResponse{} }
+func (m *UpdateDownlinkTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateDownlinkTemplateResponse) ProtoMessage()    {}
+func (*UpdateDownlinkTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{172}
+}
+
+type DeleteDownlinkTemplateRequest struct {
+	// ID of the downlink template.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteDownlinkTemplateRequest) Reset()         { *m = DeleteDownlinkTemplateRequest{} }
+func (m *DeleteDownlinkTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDownlinkTemplateRequest) ProtoMessage()    {}
+func (*DeleteDownlinkTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{173}
+}
+
+func (m *DeleteDownlinkTemplateRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteDownlinkTemplateResponse struct {
+}
+
+func (m *DeleteDownlinkTemplateResponse) Reset()         { *m = DeleteDownlinkTemplateResponse{} }
+func (m *DeleteDownlinkTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteDownlinkTemplateResponse) ProtoMessage()    {}
+func (*DeleteDownlinkTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{174}
+}
+
+type ListDownlinkTemplatesRequest struct {
+	// Max number of downlink templates to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListDownlinkTemplatesRequest) Reset()                    { *m = ListDownlinkTemplatesRequest{} }
+func (m *ListDownlinkTemplatesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListDownlinkTemplatesRequest) ProtoMessage()               {}
+func (*ListDownlinkTemplatesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{175} }
+
+func (m *ListDownlinkTemplatesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListDownlinkTemplatesRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListDownlinkTemplatesResponse struct {
+	// Total number of downlink templates.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by id.
+	Result []*DownlinkTemplate `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListDownlinkTemplatesResponse) Reset()         { *m = ListDownlinkTemplatesResponse{} }
+func (m *ListDownlinkTemplatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDownlinkTemplatesResponse) ProtoMessage()    {}
+func (*ListDownlinkTemplatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{176}
+}
+
+func (m *ListDownlinkTemplatesResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListDownlinkTemplatesResponse) GetResult() []*DownlinkTemplate {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type TriggerDownlinkTemplateRequest struct {
+	// ID of the downlink template.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// DevEUI of the node to trigger the template for.
+	DevEUI []byte `protobuf:"bytes,2,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Trigger the template for all the nodes having all these tags (used
+	// when devEUI is not set).
+	Tags map[string]string `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TriggerDownlinkTemplateRequest) Reset()         { *m = TriggerDownlinkTemplateRequest{} }
+func (m *TriggerDownlinkTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*TriggerDownlinkTemplateRequest) ProtoMessage()    {}
+func (*TriggerDownlinkTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{177}
+}
+
+func (m *TriggerDownlinkTemplateRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TriggerDownlinkTemplateRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *TriggerDownlinkTemplateRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type TriggerDownlinkTemplateError struct {
+	// The device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// The machine-readable error code.
+	ErrorCode ErrorCode `protobuf:"varint,2,opt,name=errorCode,enum=ns.ErrorCode" json:"errorCode,omitempty"`
+	// The error message.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TriggerDownlinkTemplateError) Reset()                    { *m = TriggerDownlinkTemplateError{} }
+func (m *TriggerDownlinkTemplateError) String() string            { return proto.CompactTextString(m) }
+func (*TriggerDownlinkTemplateError) ProtoMessage()               {}
+func (*TriggerDownlinkTemplateError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{178} }
+
+func (m *TriggerDownlinkTemplateError) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *TriggerDownlinkTemplateError) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (m *TriggerDownlinkTemplateError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type TriggerDownlinkTemplateResponse struct {
+	// The number of nodes for which the payload has been enqueued.
+	EnqueuedCount int32 `protobuf:"varint,1,opt,name=enqueuedCount" json:"enqueuedCount,omitempty"`
+	// The nodes for which the payload could not be enqueued (e.g. as the
+	// AppSKey encryption is not offloaded).
+	Errors []*TriggerDownlinkTemplateError `protobuf:"bytes,2,rep,name=errors" json:"errors,omitempty"`
+}
+
+func (m *TriggerDownlinkTemplateResponse) Reset()         { *m = TriggerDownlinkTemplateResponse{} }
+func (m *TriggerDownlinkTemplateResponse) String() string { return proto.CompactTextString(m) }
+func (*TriggerDownlinkTemplateResponse) ProtoMessage()    {}
+func (*TriggerDownlinkTemplateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{179}
+}
+
+func (m *TriggerDownlinkTemplateResponse) GetEnqueuedCount() int32 {
+	if m != nil {
+		return m.EnqueuedCount
+	}
+	return 0
+}
+
+func (m *TriggerDownlinkTemplateResponse) GetErrors() []*TriggerDownlinkTemplateError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type UplinkChannel struct {
+	// Index of the channel.
+	Index uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency" json:"frequency,omitempty"`
+	// Min. data-rate.
+	MinDR uint32 `protobuf:"varint,3,opt,name=minDR" json:"minDR,omitempty"`
+	// Max. data-rate.
+	MaxDR uint32 `protobuf:"varint,4,opt,name=maxDR" json:"maxDR,omitempty"`
+	// The channel is enabled.
+	Enabled bool `protobuf:"varint,5,opt,name=enabled" json:"enabled,omitempty"`
+}
+
+func (m *UplinkChannel) Reset()                    { *m = UplinkChannel{} }
+func (m *UplinkChannel) String() string            { return proto.CompactTextString(m) }
+func (*UplinkChannel) ProtoMessage()               {}
+func (*UplinkChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{180} }
+
+func (m *UplinkChannel) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetMinDR() uint32 {
+	if m != nil {
+		return m.MinDR
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type ExtraChannel struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency" json:"frequency,omitempty"`
+	// Min. data-rate.
+	MinDR uint32 `protobuf:"varint,2,opt,name=minDR" json:"minDR,omitempty"`
+	// Max. data-rate.
+	MaxDR uint32 `protobuf:"varint,3,opt,name=maxDR" json:"maxDR,omitempty"`
+}
+
+func (m *ExtraChannel) Reset()                    { *m = ExtraChannel{} }
+func (m *ExtraChannel) String() string            { return proto.CompactTextString(m) }
+func (*ExtraChannel) ProtoMessage()               {}
+func (*ExtraChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{181} }
+
+func (m *ExtraChannel) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *ExtraChannel) GetMinDR() uint32 {
+	if m != nil {
+		return m.MinDR
+	}
+	return 0
+}
+
+func (m *ExtraChannel) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+type ChannelConfiguration struct {
+	// ID of the channel-configuration (ignored on create).
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the channel-configuration.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Band of the channel-configuration, must match the band of LoRa
+	// Server (e.g. EU_863_870).
+	Band string `protobuf:"bytes,3,opt,name=band" json:"band,omitempty"`
+	// Indices of the enabled uplink channels. The default channels of the
+	// band are numbered first, followed by the extra channels.
+	Channels []uint32 `protobuf:"varint,4,rep,packed,name=channels" json:"channels,omitempty"`
+	// Channels added on top of the default channels of the band (only for
+	// bands implementing the CFList).
+	ExtraChannels []*ExtraChannel `protobuf:"bytes,5,rep,name=extraChannels" json:"extraChannels,omitempty"`
+	// Created-at timestamp (RFC3339Nano, ignored on create and update).
+	CreatedAt string `protobuf:"bytes,6,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Updated-at timestamp (RFC3339Nano, ignored on create and update).
+	UpdatedAt string `protobuf:"bytes,7,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *ChannelConfiguration) Reset()                    { *m = ChannelConfiguration{} }
+func (m *ChannelConfiguration) String() string            { return proto.CompactTextString(m) }
+func (*ChannelConfiguration) ProtoMessage()               {}
+func (*ChannelConfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{182} }
+
+func (m *ChannelConfiguration) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ChannelConfiguration) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChannelConfiguration) GetBand() string {
+	if m != nil {
+		return m.Band
+	}
+	return ""
+}
+
+func (m *ChannelConfiguration) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *ChannelConfiguration) GetExtraChannels() []*ExtraChannel {
+	if m != nil {
+		return m.ExtraChannels
+	}
+	return nil
+}
+
+func (m *ChannelConfiguration) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *ChannelConfiguration) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type CreateChannelConfigurationRequest struct {
+	// The channel-configuration to create.
+	Configuration *ChannelConfiguration `protobuf:"bytes,1,opt,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *CreateChannelConfigurationRequest) Reset()         { *m = CreateChannelConfigurationRequest{} }
+func (m *CreateChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateChannelConfigurationRequest) ProtoMessage()    {}
+func (*CreateChannelConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{183}
+}
+
+func (m *CreateChannelConfigurationRequest) GetConfiguration() *ChannelConfiguration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+type CreateChannelConfigurationResponse struct {
+	// ID of the created channel-configuration.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateChannelConfigurationResponse) Reset()         { *m = CreateChannelConfigurationResponse{} }
+func (m *CreateChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateChannelConfigurationResponse) ProtoMessage()    {}
+func (*CreateChannelConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{184}
+}
+
+func (m *CreateChannelConfigurationResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetChannelConfigurationRequest struct {
+	// ID of the channel-configuration.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetChannelConfigurationRequest) Reset()         { *m = GetChannelConfigurationRequest{} }
+func (m *GetChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetChannelConfigurationRequest) ProtoMessage()    {}
+func (*GetChannelConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{185}
+}
+
+func (m *GetChannelConfigurationRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetChannelConfigurationResponse struct {
+	// The channel-configuration.
+	Configuration *ChannelConfiguration `protobuf:"bytes,1,opt,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *GetChannelConfigurationResponse) Reset()         { *m = GetChannelConfigurationResponse{} }
+func (m *GetChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetChannelConfigurationResponse) ProtoMessage()    {}
+func (*GetChannelConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{186}
+}
+
+func (m *GetChannelConfigurationResponse) GetConfiguration() *ChannelConfiguration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+type UpdateChannelConfigurationRequest struct {
+	// The channel-configuration to update (matched by id).
+	Configuration *ChannelConfiguration `protobuf:"bytes,1,opt,name=configuration" json:"configuration,omitempty"`
+}
+
+func (m *UpdateChannelConfigurationRequest) Reset()         { *m = UpdateChannelConfigurationRequest{} }
+func (m *UpdateChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateChannelConfigurationRequest) ProtoMessage()    {}
+func (*UpdateChannelConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{187}
+}
+
+func (m *UpdateChannelConfigurationRequest) GetConfiguration() *ChannelConfiguration {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
+type UpdateChannelConfigurationResponse struct {
+}
+
+func (m *UpdateChannelConfigurationResponse) Reset()         { *m = UpdateChannelConfigurationResponse{} }
+func (m *UpdateChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateChannelConfigurationResponse) ProtoMessage()    {}
+func (*UpdateChannelConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{188}
+}
+
+type DeleteChannelConfigurationRequest struct {
+	// ID of the channel-configuration.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteChannelConfigurationRequest) Reset()         { *m = DeleteChannelConfigurationRequest{} }
+func (m *DeleteChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteChannelConfigurationRequest) ProtoMessage()    {}
+func (*DeleteChannelConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{189}
+}
+
+func (m *DeleteChannelConfigurationRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteChannelConfigurationResponse struct {
+}
+
+func (m *DeleteChannelConfigurationResponse) Reset()         { *m = DeleteChannelConfigurationResponse{} }
+func (m *DeleteChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteChannelConfigurationResponse) ProtoMessage()    {}
+func (*DeleteChannelConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{190}
+}
+
+type ListChannelConfigurationsRequest struct {
+	// Max number of channel-configurations to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListChannelConfigurationsRequest) Reset()         { *m = ListChannelConfigurationsRequest{} }
+func (m *ListChannelConfigurationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListChannelConfigurationsRequest) ProtoMessage()    {}
+func (*ListChannelConfigurationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{191}
+}
+
+func (m *ListChannelConfigurationsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListChannelConfigurationsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListChannelConfigurationsResponse struct {
+	// Total number of channel-configurations.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by id.
+	Result []*ChannelConfiguration `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListChannelConfigurationsResponse) Reset()         { *m = ListChannelConfigurationsResponse{} }
+func (m *ListChannelConfigurationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListChannelConfigurationsResponse) ProtoMessage()    {}
+func (*ListChannelConfigurationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{192}
+}
+
+func (m *ListChannelConfigurationsResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListChannelConfigurationsResponse) GetResult() []*ChannelConfiguration {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type EnqueueBenchDownlinkRequest struct {
+	// DevAddr of the test device.
+	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+	// NwkSKey of the test device, used for validating the MIC of the
+	// uplink and for signing (and encrypting the FPort 0 payload of) the
+	// downlink. It is not stored as node-session.
+	NwkSKey []byte `protobuf:"bytes,2,opt,name=nwkSKey,proto3" json:"nwkSKey,omitempty"`
+	// AppSKey of the test device (optional). When set, the data is
+	// encrypted with this key, else the data is sent as-is.
+	AppSKey []byte `protobuf:"bytes,3,opt,name=appSKey,proto3" json:"appSKey,omitempty"`
+	// FCnt of the downlink.
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+	// FPort of the downlink (0 = mac-commands only).
+	FPort uint32 `protobuf:"varint,5,opt,name=fPort" json:"fPort,omitempty"`
+	// Data of the downlink (FPort must be > 0).
+	Data []byte `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	// The mac-commands to send (each containing the CID and payload). These
+	// are sent as FOpts, or in the FRMPayload when FPort is 0.
+	MacCommands [][]byte `protobuf:"bytes,7,rep,name=macCommands,proto3" json:"macCommands,omitempty"`
+	// The downlink must be acknowledged by the device.
+	Confirmed bool `protobuf:"varint,8,opt,name=confirmed" json:"confirmed,omitempty"`
+}
+
+func (m *EnqueueBenchDownlinkRequest) Reset()                    { *m = EnqueueBenchDownlinkRequest{} }
+func (m *EnqueueBenchDownlinkRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueBenchDownlinkRequest) ProtoMessage()               {}
+func (*EnqueueBenchDownlinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{193} }
+
+func (m *EnqueueBenchDownlinkRequest) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetNwkSKey() []byte {
+	if m != nil {
+		return m.NwkSKey
+	}
+	return nil
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetAppSKey() []byte {
+	if m != nil {
+		return m.AppSKey
+	}
+	return nil
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetMacCommands() [][]byte {
+	if m != nil {
+		return m.MacCommands
+	}
+	return nil
+}
+
+func (m *EnqueueBenchDownlinkRequest) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+type EnqueueBenchDownlinkResponse struct {
+}
+
+func (m *EnqueueBenchDownlinkResponse) Reset()                    { *m = EnqueueBenchDownlinkResponse{} }
+func (m *EnqueueBenchDownlinkResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnqueueBenchDownlinkResponse) ProtoMessage()               {}
+func (*EnqueueBenchDownlinkResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{194} }
+
+type CreateGatewayOnboardingTokenRequest struct {
+	// Validity of the token in seconds (0 = 24 hours).
+	Ttl uint32 `protobuf:"varint,1,opt,name=ttl" json:"ttl,omitempty"`
+	// Description of the gateway registered using the token.
+	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	// Region tag of the gateway registered using the token.
+	Region string `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	// Tags of the gateway registered using the token.
+	Tags map[string]string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CreateGatewayOnboardingTokenRequest) Reset()         { *m = CreateGatewayOnboardingTokenRequest{} }
+func (m *CreateGatewayOnboardingTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayOnboardingTokenRequest) ProtoMessage()    {}
+func (*CreateGatewayOnboardingTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{195}
+}
+
+func (m *CreateGatewayOnboardingTokenRequest) GetTtl() uint32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *CreateGatewayOnboardingTokenRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CreateGatewayOnboardingTokenRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *CreateGatewayOnboardingTokenRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type CreateGatewayOnboardingTokenResponse struct {
+	// The one-time token, to be set in the gateway-bridge configuration.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// Expiration timestamp (RFC3339) of the token.
+	ExpiresAt string `protobuf:"bytes,2,opt,name=expiresAt" json:"expiresAt,omitempty"`
+}
+
+func (m *CreateGatewayOnboardingTokenResponse) Reset()         { *m = CreateGatewayOnboardingTokenResponse{} }
+func (m *CreateGatewayOnboardingTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateGatewayOnboardingTokenResponse) ProtoMessage()    {}
+func (*CreateGatewayOnboardingTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{196}
+}
+
+func (m *CreateGatewayOnboardingTokenResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CreateGatewayOnboardingTokenResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type GatewayOnboardingToken struct {
+	// The one-time token.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// Description, region tag and tags of the gateway registered using the
+	// token.
+	Description string            `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Region      string            `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	Tags        map[string]string `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Creation and expiration timestamp (RFC3339) of the token.
+	CreatedAt string `protobuf:"bytes,5,opt,name=createdAt" json:"createdAt,omitempty"`
+	ExpiresAt string `protobuf:"bytes,6,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp (RFC3339) on which the token has been used (empty when
+	// unused).
+	UsedAt string `protobuf:"bytes,7,opt,name=usedAt" json:"usedAt,omitempty"`
+	// MAC address of the gateway which registered itself using the token.
+	UsedBy []byte `protobuf:"bytes,8,opt,name=usedBy,proto3" json:"usedBy,omitempty"`
+}
+
+func (m *GatewayOnboardingToken) Reset()                    { *m = GatewayOnboardingToken{} }
+func (m *GatewayOnboardingToken) String() string            { return proto.CompactTextString(m) }
+func (*GatewayOnboardingToken) ProtoMessage()               {}
+func (*GatewayOnboardingToken) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{197} }
+
+func (m *GatewayOnboardingToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *GatewayOnboardingToken) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetUsedAt() string {
+	if m != nil {
+		return m.UsedAt
+	}
+	return ""
+}
+
+func (m *GatewayOnboardingToken) GetUsedBy() []byte {
+	if m != nil {
+		return m.UsedBy
+	}
+	return nil
+}
+
+type ListGatewayOnboardingTokensRequest struct {
+	// Max number of tokens to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListGatewayOnboardingTokensRequest) Reset()         { *m = ListGatewayOnboardingTokensRequest{} }
+func (m *ListGatewayOnboardingTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayOnboardingTokensRequest) ProtoMessage()    {}
+func (*ListGatewayOnboardingTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{198}
+}
+
+func (m *ListGatewayOnboardingTokensRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListGatewayOnboardingTokensRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListGatewayOnboardingTokensResponse struct {
+	// Total number of tokens.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, newest first.
+	Result []*GatewayOnboardingToken `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListGatewayOnboardingTokensResponse) Reset()         { *m = ListGatewayOnboardingTokensResponse{} }
+func (m *ListGatewayOnboardingTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGatewayOnboardingTokensResponse) ProtoMessage()    {}
+func (*ListGatewayOnboardingTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{199}
+}
+
+func (m *ListGatewayOnboardingTokensResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListGatewayOnboardingTokensResponse) GetResult() []*GatewayOnboardingToken {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DeleteGatewayOnboardingTokenRequest struct {
+	// The token to delete.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *DeleteGatewayOnboardingTokenRequest) Reset()         { *m = DeleteGatewayOnboardingTokenRequest{} }
+func (m *DeleteGatewayOnboardingTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewayOnboardingTokenRequest) ProtoMessage()    {}
+func (*DeleteGatewayOnboardingTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{200}
+}
+
+func (m *DeleteGatewayOnboardingTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type DeleteGatewayOnboardingTokenResponse struct {
+}
+
+func (m *DeleteGatewayOnboardingTokenResponse) Reset()         { *m = DeleteGatewayOnboardingTokenResponse{} }
+func (m *DeleteGatewayOnboardingTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewayOnboardingTokenResponse) ProtoMessage()    {}
+func (*DeleteGatewayOnboardingTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{201}
+}
+
+type SendGatewayTestFrameRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Frequency (Hz) of the test frame.
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate of the test frame.
+	Dr uint32 `protobuf:"varint,3,opt,name=dr" json:"dr,omitempty"`
+	// TX power (dBm) of the test frame (0 = the TX power of the band and
+	// gateway).
+	TxPower int32 `protobuf:"varint,4,opt,name=txPower" json:"txPower,omitempty"`
+	// Max. number of seconds to wait for the TX acknowledgement of the
+	// gateway (0 = 5 seconds).
+	Timeout uint32 `protobuf:"varint,5,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *SendGatewayTestFrameRequest) Reset()                    { *m = SendGatewayTestFrameRequest{} }
+func (m *SendGatewayTestFrameRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendGatewayTestFrameRequest) ProtoMessage()               {}
+func (*SendGatewayTestFrameRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{202} }
+
+func (m *SendGatewayTestFrameRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *SendGatewayTestFrameRequest) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *SendGatewayTestFrameRequest) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *SendGatewayTestFrameRequest) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *SendGatewayTestFrameRequest) GetTimeout() uint32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type SendGatewayTestFrameResponse struct {
+	// Token of the TXPacket.
+	Token uint32 `protobuf:"varint,1,opt,name=token" json:"token,omitempty"`
+	// The TX acknowledgement of the gateway has been received.
+	Acked bool `protobuf:"varint,2,opt,name=acked" json:"acked,omitempty"`
+	// Timestamp of sending the frame to the gateway.
+	SentAt string `protobuf:"bytes,3,opt,name=sentAt" json:"sentAt,omitempty"`
+	// Timestamp of receiving the TX acknowledgement of the gateway (empty
+	// when not received).
+	AckedAt string `protobuf:"bytes,4,opt,name=ackedAt" json:"ackedAt,omitempty"`
+	// Reason of the rejection by the gateway (empty when not rejected).
+	Error string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+	// Classification of the rejection by the gateway (see DownlinkFrame).
+	ErrorCode string `protobuf:"bytes,6,opt,name=errorCode" json:"errorCode,omitempty"`
+}
+
+func (m *SendGatewayTestFrameResponse) Reset()                    { *m = SendGatewayTestFrameResponse{} }
+func (m *SendGatewayTestFrameResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendGatewayTestFrameResponse) ProtoMessage()               {}
+func (*SendGatewayTestFrameResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{203} }
+
+func (m *SendGatewayTestFrameResponse) GetToken() uint32 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *SendGatewayTestFrameResponse) GetAcked() bool {
+	if m != nil {
+		return m.Acked
+	}
+	return false
+}
+
+func (m *SendGatewayTestFrameResponse) GetSentAt() string {
+	if m != nil {
+		return m.SentAt
+	}
+	return ""
+}
+
+func (m *SendGatewayTestFrameResponse) GetAckedAt() string {
+	if m != nil {
+		return m.AckedAt
+	}
+	return ""
+}
+
+func (m *SendGatewayTestFrameResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *SendGatewayTestFrameResponse) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+type ExportFrameLogsForDeviceRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Max number of (newest) frames to export.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ExportFrameLogsForDeviceRequest) Reset()         { *m = ExportFrameLogsForDeviceRequest{} }
+func (m *ExportFrameLogsForDeviceRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportFrameLogsForDeviceRequest) ProtoMessage()    {}
+func (*ExportFrameLogsForDeviceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{204}
+}
+
+func (m *ExportFrameLogsForDeviceRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ExportFrameLogsForDeviceRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ExportFrameLogsForGatewayRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Max number of (newest) frames to export.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ExportFrameLogsForGatewayRequest) Reset()         { *m = ExportFrameLogsForGatewayRequest{} }
+func (m *ExportFrameLogsForGatewayRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportFrameLogsForGatewayRequest) ProtoMessage()    {}
+func (*ExportFrameLogsForGatewayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{205}
+}
+
+func (m *ExportFrameLogsForGatewayRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *ExportFrameLogsForGatewayRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ExportFrameLogsResponse struct {
+	// Number of exported frames.
+	Count int32 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	// Exported frames in the pcap format (LoRaTap link-type, oldest
+	// first), to be opened with Wireshark.
+	Pcap []byte `protobuf:"bytes,2,opt,name=pcap,proto3" json:"pcap,omitempty"`
+}
+
+func (m *ExportFrameLogsResponse) Reset()                    { *m = ExportFrameLogsResponse{} }
+func (m *ExportFrameLogsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportFrameLogsResponse) ProtoMessage()               {}
+func (*ExportFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{206} }
+
+func (m *ExportFrameLogsResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ExportFrameLogsResponse) GetPcap() []byte {
+	if m != nil {
+		return m.Pcap
+	}
+	return nil
+}
+
+type RotateGatewaySigningKeyRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *RotateGatewaySigningKeyRequest) Reset()         { *m = RotateGatewaySigningKeyRequest{} }
+func (m *RotateGatewaySigningKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateGatewaySigningKeyRequest) ProtoMessage()    {}
+func (*RotateGatewaySigningKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{207}
+}
+
+func (m *RotateGatewaySigningKeyRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type RotateGatewaySigningKeyResponse struct {
+	// The (new) HMAC-SHA256 key with which the gateway must sign its
+	// uplink packets.
+	SigningKey []byte `protobuf:"bytes,1,opt,name=signingKey,proto3" json:"signingKey,omitempty"`
+}
+
+func (m *RotateGatewaySigningKeyResponse) Reset()         { *m = RotateGatewaySigningKeyResponse{} }
+func (m *RotateGatewaySigningKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateGatewaySigningKeyResponse) ProtoMessage()    {}
+func (*RotateGatewaySigningKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{208}
+}
+
+func (m *RotateGatewaySigningKeyResponse) GetSigningKey() []byte {
+	if m != nil {
+		return m.SigningKey
+	}
+	return nil
+}
+
+type DeleteGatewaySigningKeyRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *DeleteGatewaySigningKeyRequest) Reset()         { *m = DeleteGatewaySigningKeyRequest{} }
+func (m *DeleteGatewaySigningKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewaySigningKeyRequest) ProtoMessage()    {}
+func (*DeleteGatewaySigningKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{209}
+}
+
+func (m *DeleteGatewaySigningKeyRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type DeleteGatewaySigningKeyResponse struct {
+}
+
+func (m *DeleteGatewaySigningKeyResponse) Reset()         { *m = DeleteGatewaySigningKeyResponse{} }
+func (m *DeleteGatewaySigningKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteGatewaySigningKeyResponse) ProtoMessage()    {}
+func (*DeleteGatewaySigningKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{210}
+}
+
+type SetDeviceChannelMaskRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Indices of the uplink channels to keep enabled. An empty list
+	// removes the channel mask.
+	Channels []uint32 `protobuf:"varint,2,rep,packed,name=channels" json:"channels,omitempty"`
+}
+
+func (m *SetDeviceChannelMaskRequest) Reset()                    { *m = SetDeviceChannelMaskRequest{} }
+func (m *SetDeviceChannelMaskRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceChannelMaskRequest) ProtoMessage()               {}
+func (*SetDeviceChannelMaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{211} }
+
+func (m *SetDeviceChannelMaskRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *SetDeviceChannelMaskRequest) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type SetDeviceChannelMaskResponse struct {
+}
+
+func (m *SetDeviceChannelMaskResponse) Reset()                    { *m = SetDeviceChannelMaskResponse{} }
+func (m *SetDeviceChannelMaskResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetDeviceChannelMaskResponse) ProtoMessage()               {}
+func (*SetDeviceChannelMaskResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{212} }
+
+type DeviceProfile struct {
+	// ID of the device-profile (ignored on create).
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// Name of the device-profile.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Max. number of items in the device-queue of a node (0 = unlimited).
+	MaxQueueSize uint32 `protobuf:"varint,3,opt,name=maxQueueSize" json:"maxQueueSize,omitempty"`
+	// Max. downlink airtime (ms) per node per day (0 = unlimited). The
+	// quota resets at midnight (see timezone setting).
+	DailyAirtimeQuota uint32 `protobuf:"varint,4,opt,name=dailyAirtimeQuota" json:"dailyAirtimeQuota,omitempty"`
+	// Created-at timestamp (RFC3339Nano, ignored on create and update).
+	CreatedAt string `protobuf:"bytes,5,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Updated-at timestamp (RFC3339Nano, ignored on create and update).
+	UpdatedAt string `protobuf:"bytes,6,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *DeviceProfile) Reset()                    { *m = DeviceProfile{} }
+func (m *DeviceProfile) String() string            { return proto.CompactTextString(m) }
+func (*DeviceProfile) ProtoMessage()               {}
+func (*DeviceProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{213} }
+
+func (m *DeviceProfile) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeviceProfile) GetMaxQueueSize() uint32 {
+	if m != nil {
+		return m.MaxQueueSize
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetDailyAirtimeQuota() uint32 {
+	if m != nil {
+		return m.DailyAirtimeQuota
+	}
+	return 0
+}
+
+func (m *DeviceProfile) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *DeviceProfile) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type CreateDeviceProfileRequest struct {
+	// The device-profile to create.
+	Profile *DeviceProfile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
+}
+
+func (m *CreateDeviceProfileRequest) Reset()                    { *m = CreateDeviceProfileRequest{} }
+func (m *CreateDeviceProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateDeviceProfileRequest) ProtoMessage()               {}
+func (*CreateDeviceProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{214} }
+
+func (m *CreateDeviceProfileRequest) GetProfile() *DeviceProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+type CreateDeviceProfileResponse struct {
+	// ID of the created device-profile.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateDeviceProfileResponse) Reset()                    { *m = CreateDeviceProfileResponse{} }
+func (m *CreateDeviceProfileResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateDeviceProfileResponse) ProtoMessage()               {}
+func (*CreateDeviceProfileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{215} }
+
+func (m *CreateDeviceProfileResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetDeviceProfileRequest struct {
+	// ID of the device-profile.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetDeviceProfileRequest) Reset()                    { *m = GetDeviceProfileRequest{} }
+func (m *GetDeviceProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceProfileRequest) ProtoMessage()               {}
+func (*GetDeviceProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{216} }
+
+func (m *GetDeviceProfileRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type GetDeviceProfileResponse struct {
+	// The device-profile.
+	Profile *DeviceProfile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
+}
+
+func (m *GetDeviceProfileResponse) Reset()                    { *m = GetDeviceProfileResponse{} }
+func (m *GetDeviceProfileResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceProfileResponse) ProtoMessage()               {}
+func (*GetDeviceProfileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{217} }
+
+func (m *GetDeviceProfileResponse) GetProfile() *DeviceProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+type UpdateDeviceProfileRequest struct {
+	// The device-profile to update (matched by id).
+	Profile *DeviceProfile `protobuf:"bytes,1,opt,name=profile" json:"profile,omitempty"`
+}
+
+func (m *UpdateDeviceProfileRequest) Reset()                    { *m = UpdateDeviceProfileRequest{} }
+func (m *UpdateDeviceProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceProfileRequest) ProtoMessage()               {}
+func (*UpdateDeviceProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{218} }
+
+func (m *UpdateDeviceProfileRequest) GetProfile() *DeviceProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+type UpdateDeviceProfileResponse struct {
+}
+
+func (m *UpdateDeviceProfileResponse) Reset()                    { *m = UpdateDeviceProfileResponse{} }
+func (m *UpdateDeviceProfileResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceProfileResponse) ProtoMessage()               {}
+func (*UpdateDeviceProfileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{219} }
+
+type DeleteDeviceProfileRequest struct {
+	// ID of the device-profile.
+	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteDeviceProfileRequest) Reset()                    { *m = DeleteDeviceProfileRequest{} }
+func (m *DeleteDeviceProfileRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDeviceProfileRequest) ProtoMessage()               {}
+func (*DeleteDeviceProfileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{220} }
+
+func (m *DeleteDeviceProfileRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type DeleteDeviceProfileResponse struct {
+}
+
+func (m *DeleteDeviceProfileResponse) Reset()                    { *m = DeleteDeviceProfileResponse{} }
+func (m *DeleteDeviceProfileResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDeviceProfileResponse) ProtoMessage()               {}
+func (*DeleteDeviceProfileResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{221} }
+
+type ListDeviceProfilesRequest struct {
+	// Max number of device-profiles to return in the result-set.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+	// Offset in the result-set (for pagination).
+	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *ListDeviceProfilesRequest) Reset()                    { *m = ListDeviceProfilesRequest{} }
+func (m *ListDeviceProfilesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListDeviceProfilesRequest) ProtoMessage()               {}
+func (*ListDeviceProfilesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{222} }
+
+func (m *ListDeviceProfilesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListDeviceProfilesRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+type ListDeviceProfilesResponse struct {
+	// Total number of device-profiles.
+	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
+	// Result-set, ordered by id.
+	Result []*DeviceProfile `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListDeviceProfilesResponse) Reset()                    { *m = ListDeviceProfilesResponse{} }
+func (m *ListDeviceProfilesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListDeviceProfilesResponse) ProtoMessage()               {}
+func (*ListDeviceProfilesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{223} }
+
+func (m *ListDeviceProfilesResponse) GetTotalCount() int32 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
+func (m *ListDeviceProfilesResponse) GetResult() []*DeviceProfile {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type GetUnknownDevAddrStatsRequest struct {
+	// Timestamp to start from (the stats have a granularity of one hour).
+	StartTimestamp string `protobuf:"bytes,1,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp string `protobuf:"bytes,2,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+}
+
+func (m *GetUnknownDevAddrStatsRequest) Reset()         { *m = GetUnknownDevAddrStatsRequest{} }
+func (m *GetUnknownDevAddrStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetUnknownDevAddrStatsRequest) ProtoMessage()    {}
+func (*GetUnknownDevAddrStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{224}
+}
+
+func (m *GetUnknownDevAddrStatsRequest) GetStartTimestamp() string {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return ""
+}
+
+func (m *GetUnknownDevAddrStatsRequest) GetEndTimestamp() string {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return ""
+}
+
+type UnknownDevAddrStats struct {
+	// Timestamp of the start of the hour.
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// MAC address of the receiving gateway.
+	Mac []byte `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Frequency in Hz.
+	Frequency uint32 `protobuf:"varint,3,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate (index of the band).
+	DataRate uint32 `protobuf:"varint,4,opt,name=dataRate" json:"dataRate,omitempty"`
+	// NwkID (7 MSB) of the DevAddrs.
+	NwkID uint32 `protobuf:"varint,5,opt,name=nwkID" json:"nwkID,omitempty"`
+	// The NwkID does not match the NetID of the network (e.g. the uplinks
+	// of a foreign network).
+	Foreign bool `protobuf:"varint,6,opt,name=foreign" json:"foreign,omitempty"`
+	// Number of received uplinks.
+	UplinkCount uint32 `protobuf:"varint,7,opt,name=uplinkCount" json:"uplinkCount,omitempty"`
+}
+
+func (m *UnknownDevAddrStats) Reset()                    { *m = UnknownDevAddrStats{} }
+func (m *UnknownDevAddrStats) String() string            { return proto.CompactTextString(m) }
+func (*UnknownDevAddrStats) ProtoMessage()               {}
+func (*UnknownDevAddrStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{225} }
+
+func (m *UnknownDevAddrStats) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *UnknownDevAddrStats) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *UnknownDevAddrStats) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *UnknownDevAddrStats) GetDataRate() uint32 {
+	if m != nil {
+		return m.DataRate
+	}
+	return 0
+}
+
+func (m *UnknownDevAddrStats) GetNwkID() uint32 {
+	if m != nil {
+		return m.NwkID
+	}
+	return 0
+}
+
+func (m *UnknownDevAddrStats) GetForeign() bool {
+	if m != nil {
+		return m.Foreign
+	}
+	return false
+}
+
+func (m *UnknownDevAddrStats) GetUplinkCount() uint32 {
+	if m != nil {
+		return m.UplinkCount
+	}
+	return 0
+}
+
+type GetUnknownDevAddrStatsResponse struct {
+	// Stats per hour, gateway, frequency, data-rate and NwkID.
+	Result []*UnknownDevAddrStats `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetUnknownDevAddrStatsResponse) Reset()         { *m = GetUnknownDevAddrStatsResponse{} }
+func (m *GetUnknownDevAddrStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUnknownDevAddrStatsResponse) ProtoMessage()    {}
+func (*GetUnknownDevAddrStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{226}
+}
+
+func (m *GetUnknownDevAddrStatsResponse) GetResult() []*UnknownDevAddrStats {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type BulkCreateOrUpdateGatewaysRequest struct {
+	// The gateways to create or update.
+	Gateways []*CreateGatewayRequest `protobuf:"bytes,1,rep,name=gateways" json:"gateways,omitempty"`
+}
+
+func (m *BulkCreateOrUpdateGatewaysRequest) Reset()         { *m = BulkCreateOrUpdateGatewaysRequest{} }
+func (m *BulkCreateOrUpdateGatewaysRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkCreateOrUpdateGatewaysRequest) ProtoMessage()    {}
+func (*BulkCreateOrUpdateGatewaysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{227}
+}
+
+func (m *BulkCreateOrUpdateGatewaysRequest) GetGateways() []*CreateGatewayRequest {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+type BulkGatewayResult struct {
+	// The index of the gateway in the request.
+	Index int32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	// The gateway has been created (false when an existing gateway has been
+	// updated or on error).
+	Created bool `protobuf:"varint,3,opt,name=created" json:"created,omitempty"`
+	// The machine-readable error code (only set on error).
+	ErrorCode ErrorCode `protobuf:"varint,4,opt,name=errorCode,enum=ns.ErrorCode" json:"errorCode,omitempty"`
+	// The error message (empty on success).
+	Error string `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *BulkGatewayResult) Reset()                    { *m = BulkGatewayResult{} }
+func (m *BulkGatewayResult) String() string            { return proto.CompactTextString(m) }
+func (*BulkGatewayResult) ProtoMessage()               {}
+func (*BulkGatewayResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{228} }
+
+func (m *BulkGatewayResult) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BulkGatewayResult) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *BulkGatewayResult) GetCreated() bool {
+	if m != nil {
+		return m.Created
+	}
+	return false
+}
+
+func (m *BulkGatewayResult) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNKNOWN_ERROR
+}
+
+func (m *BulkGatewayResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type BulkCreateOrUpdateGatewaysResponse struct {
+	// The number of created gateways.
+	CreatedCount int32 `protobuf:"varint,1,opt,name=createdCount" json:"createdCount,omitempty"`
+	// The number of updated gateways.
+	UpdatedCount int32 `protobuf:"varint,2,opt,name=updatedCount" json:"updatedCount,omitempty"`
+	// The result per gateway, in the order of the request.
+	Results []*BulkGatewayResult `protobuf:"bytes,3,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *BulkCreateOrUpdateGatewaysResponse) Reset()         { *m = BulkCreateOrUpdateGatewaysResponse{} }
+func (m *BulkCreateOrUpdateGatewaysResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkCreateOrUpdateGatewaysResponse) ProtoMessage()    {}
+func (*BulkCreateOrUpdateGatewaysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{229}
+}
+
+func (m *BulkCreateOrUpdateGatewaysResponse) GetCreatedCount() int32 {
+	if m != nil {
+		return m.CreatedCount
+	}
+	return 0
+}
+
+func (m *BulkCreateOrUpdateGatewaysResponse) GetUpdatedCount() int32 {
+	if m != nil {
+		return m.UpdatedCount
+	}
+	return 0
+}
+
+func (m *BulkCreateOrUpdateGatewaysResponse) GetResults() []*BulkGatewayResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type CheckSessionsRequest struct {
+	// Repair the orphaned and missing DevAddr pointers and the mismatched
+	// mac-command queues.
+	Repair bool `protobuf:"varint,1,opt,name=repair" json:"repair,omitempty"`
+}
+
+func (m *CheckSessionsRequest) Reset()                    { *m = CheckSessionsRequest{} }
+func (m *CheckSessionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckSessionsRequest) ProtoMessage()               {}
+func (*CheckSessionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{230} }
+
+func (m *CheckSessionsRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+type SessionIssue struct {
+	// The Redis key containing the issue.
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	// Human-readable description of the issue.
+	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	// The issue has been repaired.
+	Repaired bool `protobuf:"varint,3,opt,name=repaired" json:"repaired,omitempty"`
+}
+
+func (m *SessionIssue) Reset()                    { *m = SessionIssue{} }
+func (m *SessionIssue) String() string            { return proto.CompactTextString(m) }
+func (*SessionIssue) ProtoMessage()               {}
+func (*SessionIssue) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{231} }
+
+func (m *SessionIssue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SessionIssue) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *SessionIssue) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+type CheckSessionsResponse struct {
+	// The number of checked node-sessions.
+	NodeSessionCount uint32 `protobuf:"varint,1,opt,name=nodeSessionCount" json:"nodeSessionCount,omitempty"`
+	// The number of checked DevAddr pointer sets.
+	DevAddrCount uint32 `protobuf:"varint,2,opt,name=devAddrCount" json:"devAddrCount,omitempty"`
+	// The number of checked mac-command queues.
+	MacQueueCount uint32 `protobuf:"varint,3,opt,name=macQueueCount" json:"macQueueCount,omitempty"`
+	// DevAddr -> DevEUI pointers for which the node-session does not exist
+	// or uses an other DevAddr.
+	OrphanedDevAddrPointers []*SessionIssue `protobuf:"bytes,4,rep,name=orphanedDevAddrPointers" json:"orphanedDevAddrPointers,omitempty"`
+	// Node-sessions which are not referenced by the DevAddr -> DevEUI
+	// pointer set.
+	MissingDevAddrPointers []*SessionIssue `protobuf:"bytes,5,rep,name=missingDevAddrPointers" json:"missingDevAddrPointers,omitempty"`
+	// Node-sessions with an impossible MAC state (e.g. data-rate out of band
+	// range). These are never repaired, as this would de-sync the node.
+	InvalidNodeSessions []*SessionIssue `protobuf:"bytes,6,rep,name=invalidNodeSessions" json:"invalidNodeSessions,omitempty"`
+	// Mac-command queues without node-session or containing items for an
+	// other DevEUI.
+	MismatchedMACQueues []*SessionIssue `protobuf:"bytes,7,rep,name=mismatchedMACQueues" json:"mismatchedMACQueues,omitempty"`
+}
+
+func (m *CheckSessionsResponse) Reset()                    { *m = CheckSessionsResponse{} }
+func (m *CheckSessionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CheckSessionsResponse) ProtoMessage()               {}
+func (*CheckSessionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{232} }
+
+func (m *CheckSessionsResponse) GetNodeSessionCount() uint32 {
+	if m != nil {
+		return m.NodeSessionCount
+	}
+	return 0
+}
+
+func (m *CheckSessionsResponse) GetDevAddrCount() uint32 {
+	if m != nil {
+		return m.DevAddrCount
+	}
+	return 0
+}
+
+func (m *CheckSessionsResponse) GetMacQueueCount() uint32 {
+	if m != nil {
+		return m.MacQueueCount
+	}
+	return 0
+}
+
+func (m *CheckSessionsResponse) GetOrphanedDevAddrPointers() []*SessionIssue {
+	if m != nil {
+		return m.OrphanedDevAddrPointers
+	}
+	return nil
+}
+
+func (m *CheckSessionsResponse) GetMissingDevAddrPointers() []*SessionIssue {
+	if m != nil {
+		return m.MissingDevAddrPointers
+	}
+	return nil
+}
+
+func (m *CheckSessionsResponse) GetInvalidNodeSessions() []*SessionIssue {
+	if m != nil {
+		return m.InvalidNodeSessions
+	}
+	return nil
+}
+
+func (m *CheckSessionsResponse) GetMismatchedMACQueues() []*SessionIssue {
+	if m != nil {
+		return m.MismatchedMACQueues
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateNodeSessionRequest)(nil), "ns.CreateNodeSessionRequest")
+	proto.RegisterType((*CreateNodeSessionResponse)(nil), "ns.CreateNodeSessionResponse")
+	proto.RegisterType((*GetNodeSessionRequest)(nil), "ns.GetNodeSessionRequest")
+	proto.RegisterType((*GetNodeSessionResponse)(nil), "ns.GetNodeSessionResponse")
+	proto.RegisterType((*UpdateNodeSessionRequest)(nil), "ns.UpdateNodeSessionRequest")
+	proto.RegisterType((*UpdateNodeSessionResponse)(nil), "ns.UpdateNodeSessionResponse")
+	proto.RegisterType((*PatchNodeSessionRequest)(nil), "ns.PatchNodeSessionRequest")
+	proto.RegisterType((*PatchNodeSessionResponse)(nil), "ns.PatchNodeSessionResponse")
+	proto.RegisterType((*DeleteNodeSessionRequest)(nil), "ns.DeleteNodeSessionRequest")
+	proto.RegisterType((*DeleteNodeSessionResponse)(nil), "ns.DeleteNodeSessionResponse")
+	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "ns.GetRandomDevAddrRequest")
+	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
+	proto.RegisterType((*EnqueueDataDownMACCommandRequest)(nil), "ns.EnqueueDataDownMACCommandRequest")
+	proto.RegisterType((*EnqueueDataDownMACCommandResponse)(nil), "ns.EnqueueDataDownMACCommandResponse")
+	proto.RegisterType((*PushDataDownRequest)(nil), "ns.PushDataDownRequest")
+	proto.RegisterType((*PushDataDownResponse)(nil), "ns.PushDataDownResponse")
+	proto.RegisterType((*BroadcastDataDownRequest)(nil), "ns.BroadcastDataDownRequest")
+	proto.RegisterType((*BroadcastDataDownResponse)(nil), "ns.BroadcastDataDownResponse")
+	proto.RegisterType((*GetDownlinkDecisionsRequest)(nil), "ns.GetDownlinkDecisionsRequest")
+	proto.RegisterType((*DownlinkDecision)(nil), "ns.DownlinkDecision")
+	proto.RegisterType((*GetDownlinkDecisionsResponse)(nil), "ns.GetDownlinkDecisionsResponse")
+	proto.RegisterType((*GetMACCommandHistoryRequest)(nil), "ns.GetMACCommandHistoryRequest")
+	proto.RegisterType((*MACCommandHistoryItem)(nil), "ns.MACCommandHistoryItem")
+	proto.RegisterType((*GetMACCommandHistoryResponse)(nil), "ns.GetMACCommandHistoryResponse")
+	proto.RegisterType((*GetDownlinkFramesRequest)(nil), "ns.GetDownlinkFramesRequest")
+	proto.RegisterType((*DownlinkFrame)(nil), "ns.DownlinkFrame")
+	proto.RegisterType((*DownlinkFrameSession)(nil), "ns.DownlinkFrameSession")
+	proto.RegisterType((*GetDownlinkFramesResponse)(nil), "ns.GetDownlinkFramesResponse")
+	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
+	proto.RegisterType((*CreateGatewayResponse)(nil), "ns.CreateGatewayResponse")
+	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
+	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
+	proto.RegisterType((*GatewayEvent)(nil), "ns.GatewayEvent")
+	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
+	proto.RegisterType((*UpdateGatewayResponse)(nil), "ns.UpdateGatewayResponse")
+	proto.RegisterType((*ListGatewayRequest)(nil), "ns.ListGatewayRequest")
+	proto.RegisterType((*ListGatewayResponse)(nil), "ns.ListGatewayResponse")
+	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
+	proto.RegisterType((*DeleteGatewayResponse)(nil), "ns.DeleteGatewayResponse")
+	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
+	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
+	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
+	proto.RegisterType((*StreamUplinkMetadataRequest)(nil), "ns.StreamUplinkMetadataRequest")
+	proto.RegisterType((*UplinkRXInfo)(nil), "ns.UplinkRXInfo")
+	proto.RegisterType((*StreamUplinkMetadataResponse)(nil), "ns.StreamUplinkMetadataResponse")
+	proto.RegisterType((*GetDownlinkCapacityReportRequest)(nil), "ns.GetDownlinkCapacityReportRequest")
+	proto.RegisterType((*SubBandCapacity)(nil), "ns.SubBandCapacity")
+	proto.RegisterType((*DeviceAirtime)(nil), "ns.DeviceAirtime")
+	proto.RegisterType((*GetDownlinkCapacityReportResponse)(nil), "ns.GetDownlinkCapacityReportResponse")
+	proto.RegisterType((*GetUplinkChannelStatsRequest)(nil), "ns.GetUplinkChannelStatsRequest")
+	proto.RegisterType((*UplinkChannelStats)(nil), "ns.UplinkChannelStats")
+	proto.RegisterType((*GetUplinkChannelStatsResponse)(nil), "ns.GetUplinkChannelStatsResponse")
+	proto.RegisterType((*GetJoinStatsRequest)(nil), "ns.GetJoinStatsRequest")
+	proto.RegisterType((*JoinStats)(nil), "ns.JoinStats")
+	proto.RegisterType((*GatewayJoinStats)(nil), "ns.GatewayJoinStats")
+	proto.RegisterType((*AppJoinStats)(nil), "ns.AppJoinStats")
+	proto.RegisterType((*GetJoinStatsResponse)(nil), "ns.GetJoinStatsResponse")
+	proto.RegisterType((*ListGatewayDevicesRequest)(nil), "ns.ListGatewayDevicesRequest")
+	proto.RegisterType((*GatewayDevice)(nil), "ns.GatewayDevice")
+	proto.RegisterType((*ListGatewayDevicesResponse)(nil), "ns.ListGatewayDevicesResponse")
+	proto.RegisterType((*GetGatewayAntennaStatsRequest)(nil), "ns.GetGatewayAntennaStatsRequest")
+	proto.RegisterType((*GatewayAntennaStats)(nil), "ns.GatewayAntennaStats")
+	proto.RegisterType((*GetGatewayAntennaStatsResponse)(nil), "ns.GetGatewayAntennaStatsResponse")
+	proto.RegisterType((*ChangeDeviceClassRequest)(nil), "ns.ChangeDeviceClassRequest")
+	proto.RegisterType((*ChangeDeviceClassResponse)(nil), "ns.ChangeDeviceClassResponse")
+	proto.RegisterType((*ImportNodeSessionsRequest)(nil), "ns.ImportNodeSessionsRequest")
+	proto.RegisterType((*ImportNodeSessionError)(nil), "ns.ImportNodeSessionError")
+	proto.RegisterType((*ImportNodeSessionsResponse)(nil), "ns.ImportNodeSessionsResponse")
+	proto.RegisterType((*ExportNodeSessionsRequest)(nil), "ns.ExportNodeSessionsRequest")
+	proto.RegisterType((*ExportNodeSessionsResponse)(nil), "ns.ExportNodeSessionsResponse")
+	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
+	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*GetADRParametersRequest)(nil), "ns.GetADRParametersRequest")
+	proto.RegisterType((*GetADRParametersResponse)(nil), "ns.GetADRParametersResponse")
+	proto.RegisterType((*UpdateADRParametersRequest)(nil), "ns.UpdateADRParametersRequest")
+	proto.RegisterType((*UpdateADRParametersResponse)(nil), "ns.UpdateADRParametersResponse")
+	proto.RegisterType((*GetADRDecisionsRequest)(nil), "ns.GetADRDecisionsRequest")
+	proto.RegisterType((*ADRUplinkHistoryItem)(nil), "ns.ADRUplinkHistoryItem")
+	proto.RegisterType((*ADRDecision)(nil), "ns.ADRDecision")
+	proto.RegisterType((*GetADRDecisionsResponse)(nil), "ns.GetADRDecisionsResponse")
+	proto.RegisterType((*AuditRedisKeysRequest)(nil), "ns.AuditRedisKeysRequest")
+	proto.RegisterType((*RedisKeyGroup)(nil), "ns.RedisKeyGroup")
+	proto.RegisterType((*AuditRedisKeysResponse)(nil), "ns.AuditRedisKeysResponse")
+	proto.RegisterType((*GetInfoRequest)(nil), "ns.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "ns.GetInfoResponse")
+	proto.RegisterType((*ReleaseQuarantineRequest)(nil), "ns.ReleaseQuarantineRequest")
+	proto.RegisterType((*ReleaseQuarantineResponse)(nil), "ns.ReleaseQuarantineResponse")
+	proto.RegisterType((*GetDeviceDownlinkAirtimeRequest)(nil), "ns.GetDeviceDownlinkAirtimeRequest")
+	proto.RegisterType((*DailyAirtime)(nil), "ns.DailyAirtime")
+	proto.RegisterType((*GetDeviceDownlinkAirtimeResponse)(nil), "ns.GetDeviceDownlinkAirtimeResponse")
+	proto.RegisterType((*UplinkRule)(nil), "ns.UplinkRule")
+	proto.RegisterType((*CreateUplinkRuleRequest)(nil), "ns.CreateUplinkRuleRequest")
+	proto.RegisterType((*CreateUplinkRuleResponse)(nil), "ns.CreateUplinkRuleResponse")
+	proto.RegisterType((*GetUplinkRuleRequest)(nil), "ns.GetUplinkRuleRequest")
+	proto.RegisterType((*GetUplinkRuleResponse)(nil), "ns.GetUplinkRuleResponse")
+	proto.RegisterType((*UpdateUplinkRuleRequest)(nil), "ns.UpdateUplinkRuleRequest")
+	proto.RegisterType((*UpdateUplinkRuleResponse)(nil), "ns.UpdateUplinkRuleResponse")
+	proto.RegisterType((*DeleteUplinkRuleRequest)(nil), "ns.DeleteUplinkRuleRequest")
+	proto.RegisterType((*DeleteUplinkRuleResponse)(nil), "ns.DeleteUplinkRuleResponse")
+	proto.RegisterType((*ListUplinkRulesRequest)(nil), "ns.ListUplinkRulesRequest")
+	proto.RegisterType((*ListUplinkRulesResponse)(nil), "ns.ListUplinkRulesResponse")
+	proto.RegisterType((*GetDeviceUplinkStatsRequest)(nil), "ns.GetDeviceUplinkStatsRequest")
+	proto.RegisterType((*FPortUplinkStats)(nil), "ns.FPortUplinkStats")
+	proto.RegisterType((*GetDeviceUplinkStatsResponse)(nil), "ns.GetDeviceUplinkStatsResponse")
+	proto.RegisterType((*GetTopTalkersRequest)(nil), "ns.GetTopTalkersRequest")
+	proto.RegisterType((*TopTalker)(nil), "ns.TopTalker")
+	proto.RegisterType((*GetTopTalkersResponse)(nil), "ns.GetTopTalkersResponse")
+	proto.RegisterType((*GetLowLinkMarginNodesRequest)(nil), "ns.GetLowLinkMarginNodesRequest")
+	proto.RegisterType((*LowLinkMarginNode)(nil), "ns.LowLinkMarginNode")
+	proto.RegisterType((*GetLowLinkMarginNodesResponse)(nil), "ns.GetLowLinkMarginNodesResponse")
+	proto.RegisterType((*ClockDrift)(nil), "ns.ClockDrift")
+	proto.RegisterType((*GetDeviceClockDriftRequest)(nil), "ns.GetDeviceClockDriftRequest")
+	proto.RegisterType((*GetDeviceClockDriftResponse)(nil), "ns.GetDeviceClockDriftResponse")
+	proto.RegisterType((*GetClockDriftNodesRequest)(nil), "ns.GetClockDriftNodesRequest")
+	proto.RegisterType((*GetClockDriftNodesResponse)(nil), "ns.GetClockDriftNodesResponse")
+	proto.RegisterType((*RotateGatewayCUPSCredentialsRequest)(nil), "ns.RotateGatewayCUPSCredentialsRequest")
+	proto.RegisterType((*RotateGatewayCUPSCredentialsResponse)(nil), "ns.RotateGatewayCUPSCredentialsResponse")
+	proto.RegisterType((*GetGatewayCUPSCredentialsRequest)(nil), "ns.GetGatewayCUPSCredentialsRequest")
+	proto.RegisterType((*GetGatewayCUPSCredentialsResponse)(nil), "ns.GetGatewayCUPSCredentialsResponse")
+	proto.RegisterType((*GenerateGatewayClientCertificateRequest)(nil), "ns.GenerateGatewayClientCertificateRequest")
+	proto.RegisterType((*GenerateGatewayClientCertificateResponse)(nil), "ns.GenerateGatewayClientCertificateResponse")
+	proto.RegisterType((*ListRX2MismatchNodesRequest)(nil), "ns.ListRX2MismatchNodesRequest")
+	proto.RegisterType((*RX2MismatchNode)(nil), "ns.RX2MismatchNode")
+	proto.RegisterType((*ListRX2MismatchNodesResponse)(nil), "ns.ListRX2MismatchNodesResponse")
+	proto.RegisterType((*ClearRX2MismatchRequest)(nil), "ns.ClearRX2MismatchRequest")
+	proto.RegisterType((*ClearRX2MismatchResponse)(nil), "ns.ClearRX2MismatchResponse")
+	proto.RegisterType((*ListFCntDownRolloverNodesRequest)(nil), "ns.ListFCntDownRolloverNodesRequest")
+	proto.RegisterType((*FCntDownRolloverNode)(nil), "ns.FCntDownRolloverNode")
+	proto.RegisterType((*ListFCntDownRolloverNodesResponse)(nil), "ns.ListFCntDownRolloverNodesResponse")
+	proto.RegisterType((*GetOversizedFrameOffendersRequest)(nil), "ns.GetOversizedFrameOffendersRequest")
+	proto.RegisterType((*OversizedFrameDevice)(nil), "ns.OversizedFrameDevice")
+	proto.RegisterType((*OversizedFrameGateway)(nil), "ns.OversizedFrameGateway")
+	proto.RegisterType((*GetOversizedFrameOffendersResponse)(nil), "ns.GetOversizedFrameOffendersResponse")
+	proto.RegisterType((*DeviceQueueItem)(nil), "ns.DeviceQueueItem")
+	proto.RegisterType((*EnqueueDeviceQueueItemRequest)(nil), "ns.EnqueueDeviceQueueItemRequest")
+	proto.RegisterType((*EnqueueDeviceQueueItemResponse)(nil), "ns.EnqueueDeviceQueueItemResponse")
+	proto.RegisterType((*GetDeviceQueueItemsRequest)(nil), "ns.GetDeviceQueueItemsRequest")
+	proto.RegisterType((*GetDeviceQueueItemsResponse)(nil), "ns.GetDeviceQueueItemsResponse")
+	proto.RegisterType((*FlushDeviceQueueRequest)(nil), "ns.FlushDeviceQueueRequest")
+	proto.RegisterType((*FlushDeviceQueueResponse)(nil), "ns.FlushDeviceQueueResponse")
+	proto.RegisterType((*GetNextDownlinkFCntRequest)(nil), "ns.GetNextDownlinkFCntRequest")
+	proto.RegisterType((*GetNextDownlinkFCntResponse)(nil), "ns.GetNextDownlinkFCntResponse")
+	proto.RegisterType((*MulticastGroup)(nil), "ns.MulticastGroup")
+	proto.RegisterType((*CreateMulticastGroupRequest)(nil), "ns.CreateMulticastGroupRequest")
+	proto.RegisterType((*CreateMulticastGroupResponse)(nil), "ns.CreateMulticastGroupResponse")
+	proto.RegisterType((*GetMulticastGroupRequest)(nil), "ns.GetMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastGroupResponse)(nil), "ns.GetMulticastGroupResponse")
+	proto.RegisterType((*UpdateMulticastGroupRequest)(nil), "ns.UpdateMulticastGroupRequest")
+	proto.RegisterType((*UpdateMulticastGroupResponse)(nil), "ns.UpdateMulticastGroupResponse")
+	proto.RegisterType((*DeleteMulticastGroupRequest)(nil), "ns.DeleteMulticastGroupRequest")
+	proto.RegisterType((*DeleteMulticastGroupResponse)(nil), "ns.DeleteMulticastGroupResponse")
+	proto.RegisterType((*ListMulticastGroupsRequest)(nil), "ns.ListMulticastGroupsRequest")
+	proto.RegisterType((*ListMulticastGroupsResponse)(nil), "ns.ListMulticastGroupsResponse")
+	proto.RegisterType((*EnqueueMulticastQueueItemRequest)(nil), "ns.EnqueueMulticastQueueItemRequest")
+	proto.RegisterType((*EnqueueMulticastQueueItemResponse)(nil), "ns.EnqueueMulticastQueueItemResponse")
+	proto.RegisterType((*FlushMulticastQueueRequest)(nil), "ns.FlushMulticastQueueRequest")
+	proto.RegisterType((*FlushMulticastQueueResponse)(nil), "ns.FlushMulticastQueueResponse")
+	proto.RegisterType((*FrameLogDataRate)(nil), "ns.FrameLogDataRate")
+	proto.RegisterType((*FrameLogRXInfo)(nil), "ns.FrameLogRXInfo")
+	proto.RegisterType((*FrameLogTXInfo)(nil), "ns.FrameLogTXInfo")
+	proto.RegisterType((*FrameLog)(nil), "ns.FrameLog")
+	proto.RegisterType((*StreamFrameLogsForDeviceRequest)(nil), "ns.StreamFrameLogsForDeviceRequest")
+	proto.RegisterType((*StreamFrameLogsForGatewayRequest)(nil), "ns.StreamFrameLogsForGatewayRequest")
+	proto.RegisterType((*GetFrameLogsForDeviceRequest)(nil), "ns.GetFrameLogsForDeviceRequest")
+	proto.RegisterType((*GetFrameLogsForGatewayRequest)(nil), "ns.GetFrameLogsForGatewayRequest")
+	proto.RegisterType((*GetFrameLogsResponse)(nil), "ns.GetFrameLogsResponse")
+	proto.RegisterType((*DownlinkTemplate)(nil), "ns.DownlinkTemplate")
+	proto.RegisterType((*CreateDownlinkTemplateRequest)(nil), "ns.CreateDownlinkTemplateRequest")
+	proto.RegisterType((*CreateDownlinkTemplateResponse)(nil), "ns.CreateDownlinkTemplateResponse")
+	proto.RegisterType((*GetDownlinkTemplateRequest)(nil), "ns.GetDownlinkTemplateRequest")
+	proto.RegisterType((*GetDownlinkTemplateResponse)(nil), "ns.GetDownlinkTemplateResponse")
+	proto.RegisterType((*UpdateDownlinkTemplateRequest)(nil), "ns.UpdateDownlinkTemplateRequest")
+	proto.RegisterType((*UpdateDownlinkTemplateResponse)(nil), "ns.UpdateDownlinkTemplateResponse")
+	proto.RegisterType((*DeleteDownlinkTemplateRequest)(nil), "ns.DeleteDownlinkTemplateRequest")
+	proto.RegisterType((*DeleteDownlinkTemplateResponse)(nil), "ns.DeleteDownlinkTemplateResponse")
+	proto.RegisterType((*ListDownlinkTemplatesRequest)(nil), "ns.ListDownlinkTemplatesRequest")
+	proto.RegisterType((*ListDownlinkTemplatesResponse)(nil), "ns.ListDownlinkTemplatesResponse")
+	proto.RegisterType((*TriggerDownlinkTemplateRequest)(nil), "ns.TriggerDownlinkTemplateRequest")
+	proto.RegisterType((*TriggerDownlinkTemplateError)(nil), "ns.TriggerDownlinkTemplateError")
+	proto.RegisterType((*TriggerDownlinkTemplateResponse)(nil), "ns.TriggerDownlinkTemplateResponse")
+	proto.RegisterType((*UplinkChannel)(nil), "ns.UplinkChannel")
+	proto.RegisterType((*ExtraChannel)(nil), "ns.ExtraChannel")
+	proto.RegisterType((*ChannelConfiguration)(nil), "ns.ChannelConfiguration")
+	proto.RegisterType((*CreateChannelConfigurationRequest)(nil), "ns.CreateChannelConfigurationRequest")
+	proto.RegisterType((*CreateChannelConfigurationResponse)(nil), "ns.CreateChannelConfigurationResponse")
+	proto.RegisterType((*GetChannelConfigurationRequest)(nil), "ns.GetChannelConfigurationRequest")
+	proto.RegisterType((*GetChannelConfigurationResponse)(nil), "ns.GetChannelConfigurationResponse")
+	proto.RegisterType((*UpdateChannelConfigurationRequest)(nil), "ns.UpdateChannelConfigurationRequest")
+	proto.RegisterType((*UpdateChannelConfigurationResponse)(nil), "ns.UpdateChannelConfigurationResponse")
+	proto.RegisterType((*DeleteChannelConfigurationRequest)(nil), "ns.DeleteChannelConfigurationRequest")
+	proto.RegisterType((*DeleteChannelConfigurationResponse)(nil), "ns.DeleteChannelConfigurationResponse")
+	proto.RegisterType((*ListChannelConfigurationsRequest)(nil), "ns.ListChannelConfigurationsRequest")
+	proto.RegisterType((*ListChannelConfigurationsResponse)(nil), "ns.ListChannelConfigurationsResponse")
+	proto.RegisterType((*EnqueueBenchDownlinkRequest)(nil), "ns.EnqueueBenchDownlinkRequest")
+	proto.RegisterType((*EnqueueBenchDownlinkResponse)(nil), "ns.EnqueueBenchDownlinkResponse")
+	proto.RegisterType((*CreateGatewayOnboardingTokenRequest)(nil), "ns.CreateGatewayOnboardingTokenRequest")
+	proto.RegisterType((*CreateGatewayOnboardingTokenResponse)(nil), "ns.CreateGatewayOnboardingTokenResponse")
+	proto.RegisterType((*GatewayOnboardingToken)(nil), "ns.GatewayOnboardingToken")
+	proto.RegisterType((*ListGatewayOnboardingTokensRequest)(nil), "ns.ListGatewayOnboardingTokensRequest")
+	proto.RegisterType((*ListGatewayOnboardingTokensResponse)(nil), "ns.ListGatewayOnboardingTokensResponse")
+	proto.RegisterType((*DeleteGatewayOnboardingTokenRequest)(nil), "ns.DeleteGatewayOnboardingTokenRequest")
+	proto.RegisterType((*DeleteGatewayOnboardingTokenResponse)(nil), "ns.DeleteGatewayOnboardingTokenResponse")
+	proto.RegisterType((*SendGatewayTestFrameRequest)(nil), "ns.SendGatewayTestFrameRequest")
+	proto.RegisterType((*SendGatewayTestFrameResponse)(nil), "ns.SendGatewayTestFrameResponse")
+	proto.RegisterType((*ExportFrameLogsForDeviceRequest)(nil), "ns.ExportFrameLogsForDeviceRequest")
+	proto.RegisterType((*ExportFrameLogsForGatewayRequest)(nil), "ns.ExportFrameLogsForGatewayRequest")
+	proto.RegisterType((*ExportFrameLogsResponse)(nil), "ns.ExportFrameLogsResponse")
+	proto.RegisterType((*RotateGatewaySigningKeyRequest)(nil), "ns.RotateGatewaySigningKeyRequest")
+	proto.RegisterType((*RotateGatewaySigningKeyResponse)(nil), "ns.RotateGatewaySigningKeyResponse")
+	proto.RegisterType((*DeleteGatewaySigningKeyRequest)(nil), "ns.DeleteGatewaySigningKeyRequest")
+	proto.RegisterType((*DeleteGatewaySigningKeyResponse)(nil), "ns.DeleteGatewaySigningKeyResponse")
+	proto.RegisterType((*SetDeviceChannelMaskRequest)(nil), "ns.SetDeviceChannelMaskRequest")
+	proto.RegisterType((*SetDeviceChannelMaskResponse)(nil), "ns.SetDeviceChannelMaskResponse")
+	proto.RegisterType((*DeviceProfile)(nil), "ns.DeviceProfile")
+	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
+	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
+	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
+	proto.RegisterType((*GetDeviceProfileResponse)(nil), "ns.GetDeviceProfileResponse")
+	proto.RegisterType((*UpdateDeviceProfileRequest)(nil), "ns.UpdateDeviceProfileRequest")
+	proto.RegisterType((*UpdateDeviceProfileResponse)(nil), "ns.UpdateDeviceProfileResponse")
+	proto.RegisterType((*DeleteDeviceProfileRequest)(nil), "ns.DeleteDeviceProfileRequest")
+	proto.RegisterType((*DeleteDeviceProfileResponse)(nil), "ns.DeleteDeviceProfileResponse")
+	proto.RegisterType((*ListDeviceProfilesRequest)(nil), "ns.ListDeviceProfilesRequest")
+	proto.RegisterType((*ListDeviceProfilesResponse)(nil), "ns.ListDeviceProfilesResponse")
+	proto.RegisterType((*GetUnknownDevAddrStatsRequest)(nil), "ns.GetUnknownDevAddrStatsRequest")
+	proto.RegisterType((*UnknownDevAddrStats)(nil), "ns.UnknownDevAddrStats")
+	proto.RegisterType((*GetUnknownDevAddrStatsResponse)(nil), "ns.GetUnknownDevAddrStatsResponse")
+	proto.RegisterType((*BulkCreateOrUpdateGatewaysRequest)(nil), "ns.BulkCreateOrUpdateGatewaysRequest")
+	proto.RegisterType((*BulkGatewayResult)(nil), "ns.BulkGatewayResult")
+	proto.RegisterType((*BulkCreateOrUpdateGatewaysResponse)(nil), "ns.BulkCreateOrUpdateGatewaysResponse")
+	proto.RegisterType((*CheckSessionsRequest)(nil), "ns.CheckSessionsRequest")
+	proto.RegisterType((*SessionIssue)(nil), "ns.SessionIssue")
+	proto.RegisterType((*CheckSessionsResponse)(nil), "ns.CheckSessionsResponse")
+	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("ns.ADRStrategy", ADRStrategy_name, ADRStrategy_value)
+	proto.RegisterEnum("ns.ErrorCode", ErrorCode_name, ErrorCode_value)
+	proto.RegisterEnum("ns.TopTalkersOrderBy", TopTalkersOrderBy_name, TopTalkersOrderBy_value)
+	proto.RegisterEnum("ns.UplinkRuleAction", UplinkRuleAction_name, UplinkRuleAction_value)
+	proto.RegisterEnum("ns.Polarity", Polarity_name, Polarity_value)
+	proto.RegisterEnum("ns.DeviceClass", DeviceClass_name, DeviceClass_value)
+	proto.RegisterEnum("ns.SortOrder", SortOrder_name, SortOrder_value)
+	proto.RegisterEnum("ns.GatewayOrderBy", GatewayOrderBy_name, GatewayOrderBy_value)
+	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for NetworkServer service
+
+type NetworkServerClient interface {
+	// CreateNodeSession creates the given node-session.
+	// The DevAddr must contain the same NwkID as the configured NetID.
+	// Node-sessions will expire automatically after the configured TTL.
+	CreateNodeSession(ctx context.Context, in *CreateNodeSessionRequest, opts ...grpc.CallOption) (*CreateNodeSessionResponse, error)
+	// GetNodeSession returns the node-session matching the given DevEUI.
+	GetNodeSession(ctx context.Context, in *GetNodeSessionRequest, opts ...grpc.CallOption) (*GetNodeSessionResponse, error)
+	// UpdateNodeSession updates the given node-session.
+	UpdateNodeSession(ctx context.Context, in *UpdateNodeSessionRequest, opts ...grpc.CallOption) (*UpdateNodeSessionResponse, error)
+	// PatchNodeSession updates the fields of the given update mask of an
+	// existing node-session, without overwriting concurrent updates.
+	PatchNodeSession(ctx context.Context, in *PatchNodeSessionRequest, opts ...grpc.CallOption) (*PatchNodeSessionResponse, error)
+	// DeleteNodeSession deletes the node-session matching the given DevAddr.
+	DeleteNodeSession(ctx context.Context, in *DeleteNodeSessionRequest, opts ...grpc.CallOption) (*DeleteNodeSessionResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
+	// EnqueueDataDownMACCommand adds the downlink mac-command to the queue.
+	EnqueueDataDownMACCommand(ctx context.Context, in *EnqueueDataDownMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDataDownMACCommandResponse, error)
+	// PushDataDown pushes the given downlink payload to the node (only works for Class-C nodes).
+	PushDataDown(ctx context.Context, in *PushDataDownRequest, opts ...grpc.CallOption) (*PushDataDownResponse, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error)
+	// ListGateways returns the existing gateways.
+	ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+	// StreamUplinkMetadata streams the meta-data of the received uplink
+	// frames in real-time. Note that the payload itself is not included.
+	StreamUplinkMetadata(ctx context.Context, in *StreamUplinkMetadataRequest, opts ...grpc.CallOption) (NetworkServer_StreamUplinkMetadataClient, error)
+	// GetDownlinkCapacityReport returns the downlink capacity usage (airtime
+	// and duty-cycle) per sub-band of an existing gateway.
+	GetDownlinkCapacityReport(ctx context.Context, in *GetDownlinkCapacityReportRequest, opts ...grpc.CallOption) (*GetDownlinkCapacityReportResponse, error)
+	// GetUplinkChannelStats returns the number of uplinks per hour, frequency
+	// and data-rate (e.g. to verify that nodes use the extra channels
+	// distributed by CFList or NewChannelReq).
+	GetUplinkChannelStats(ctx context.Context, in *GetUplinkChannelStatsRequest, opts ...grpc.CallOption) (*GetUplinkChannelStatsResponse, error)
+	// GetJoinStats returns the number of handled, accepted and failed
+	// join-requests and the average join-request to join-accept latency per
+	// gateway and per AppEUI (e.g. to find activation problems in specific
+	// areas).
+	GetJoinStats(ctx context.Context, in *GetJoinStatsRequest, opts ...grpc.CallOption) (*GetJoinStatsResponse, error)
+	// BroadcastDataDown broadcasts the given (plaintext) payload to all
+	// Class-C nodes matching the filter (e.g. for alarm or recall
+	// scenarios). Only nodes of which the AppSKey encryption is offloaded to
+	// LoRa Server are eligible. The downlinks are sent in the background,
+	// paced per gateway by its duty-cycle budget.
+	BroadcastDataDown(ctx context.Context, in *BroadcastDataDownRequest, opts ...grpc.CallOption) (*BroadcastDataDownResponse, error)
+	// GetDownlinkDecisions returns the last decisions (and their inputs) on
+	// the downlink opportunities following the uplinks of the given node,
+	// e.g. to find out why a node did not receive a downlink.
+	GetDownlinkDecisions(ctx context.Context, in *GetDownlinkDecisionsRequest, opts ...grpc.CallOption) (*GetDownlinkDecisionsResponse, error)
+	// GetMACCommandHistory returns the last mac-commands sent to and
+	// received from the given node (e.g. to find out if the node ever
+	// acknowledged a channel-mask).
+	GetMACCommandHistory(ctx context.Context, in *GetMACCommandHistoryRequest, opts ...grpc.CallOption) (*GetMACCommandHistoryResponse, error)
+	// GetDownlinkFrames returns the frame log entries of the (re)transmissions
+	// of the given downlink frame-counter of the given node, including the
+	// token and the TX acknowledgement of the gateway.
+	GetDownlinkFrames(ctx context.Context, in *GetDownlinkFramesRequest, opts ...grpc.CallOption) (*GetDownlinkFramesResponse, error)
+	// ImportNodeSessions creates the given node-sessions in a single call
+	// (e.g. when migrating ABP nodes from an other network-server). The
+	// node-sessions which could not be created are reported per row.
+	ImportNodeSessions(ctx context.Context, in *ImportNodeSessionsRequest, opts ...grpc.CallOption) (*ImportNodeSessionsResponse, error)
+	// ExportNodeSessions returns a batch of node-sessions, in the format
+	// accepted by ImportNodeSessions.
+	ExportNodeSessions(ctx context.Context, in *ExportNodeSessionsRequest, opts ...grpc.CallOption) (*ExportNodeSessionsResponse, error)
+	// GetDeviceActivation returns the activation parameters of the given
+	// node as JSON document, using the field names of the LoRaWAN Backend
+	// Interfaces specification (e.g. for migrating the node to an other
+	// network-server).
+	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// ChangeDeviceClass initiates a device class change of the node (e.g.
+	// when the node indicated a new device mode). Class-C downlinks are
+	// paused until the change has been confirmed by an uplink of the node.
+	ChangeDeviceClass(ctx context.Context, in *ChangeDeviceClassRequest, opts ...grpc.CallOption) (*ChangeDeviceClassResponse, error)
+	// ListGatewayDevices returns the nodes recently received by the given
+	// gateway (e.g. to assess the coverage impact of decommissioning a
+	// gateway).
+	ListGatewayDevices(ctx context.Context, in *ListGatewayDevicesRequest, opts ...grpc.CallOption) (*ListGatewayDevicesResponse, error)
+	// GetGatewayAntennaStats returns the uplink stats per antenna of the
+	// given gateway (for gateways with multiple antennas).
+	GetGatewayAntennaStats(ctx context.Context, in *GetGatewayAntennaStatsRequest, opts ...grpc.CallOption) (*GetGatewayAntennaStatsResponse, error)
+	// GetADRParameters returns the global ADR parameters.
+	GetADRParameters(ctx context.Context, in *GetADRParametersRequest, opts ...grpc.CallOption) (*GetADRParametersResponse, error)
+	// UpdateADRParameters updates the global ADR parameters. The parameters
+	// are persisted and take effect without restarting LoRa Server.
+	UpdateADRParameters(ctx context.Context, in *UpdateADRParametersRequest, opts ...grpc.CallOption) (*UpdateADRParametersResponse, error)
+	// GetADRDecisions returns the last ADR decisions of the given node,
+	// together with the inputs (uplink SNR history and margin) from which
+	// the requested data-rate, TX power and number of transmissions were
+	// computed, e.g. to find out why ADR lowered the data-rate of a node.
+	GetADRDecisions(ctx context.Context, in *GetADRDecisionsRequest, opts ...grpc.CallOption) (*GetADRDecisionsResponse, error)
+	// AuditRedisKeys reports the cardinality and memory footprint of the
+	// de-duplication / collection keys and mac-command queues stored in
+	// Redis and optionally removes the keys left behind without TTL.
+	AuditRedisKeys(ctx context.Context, in *AuditRedisKeysRequest, opts ...grpc.CallOption) (*AuditRedisKeysResponse, error)
+	// GetInfo returns the version, region (band), NetID, enabled features
+	// and limits of LoRa Server, so that application-servers can adapt their
+	// behavior and deployments can be verified.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// ReleaseQuarantine releases a node which has been quarantined in strict
+	// security mode, after repeatedly triggering security events.
+	ReleaseQuarantine(ctx context.Context, in *ReleaseQuarantineRequest, opts ...grpc.CallOption) (*ReleaseQuarantineResponse, error)
+	// GetDeviceDownlinkAirtime returns the downlink airtime consumed by a
+	// node per day and its remaining daily budget (see
+	// dailyDownlinkAirtimeCap).
+	GetDeviceDownlinkAirtime(ctx context.Context, in *GetDeviceDownlinkAirtimeRequest, opts ...grpc.CallOption) (*GetDeviceDownlinkAirtimeResponse, error)
+	// CreateUplinkRule creates the given uplink automation rule.
+	CreateUplinkRule(ctx context.Context, in *CreateUplinkRuleRequest, opts ...grpc.CallOption) (*CreateUplinkRuleResponse, error)
+	// GetUplinkRule returns the uplink automation rule for the given id.
+	GetUplinkRule(ctx context.Context, in *GetUplinkRuleRequest, opts ...grpc.CallOption) (*GetUplinkRuleResponse, error)
+	// UpdateUplinkRule updates the given uplink automation rule.
+	UpdateUplinkRule(ctx context.Context, in *UpdateUplinkRuleRequest, opts ...grpc.CallOption) (*UpdateUplinkRuleResponse, error)
+	// DeleteUplinkRule deletes the uplink automation rule for the given id.
+	DeleteUplinkRule(ctx context.Context, in *DeleteUplinkRuleRequest, opts ...grpc.CallOption) (*DeleteUplinkRuleResponse, error)
+	// ListUplinkRules returns the uplink automation rules.
+	ListUplinkRules(ctx context.Context, in *ListUplinkRulesRequest, opts ...grpc.CallOption) (*ListUplinkRulesResponse, error)
+	// GetDeviceUplinkStats returns the uplink frames and bytes of a node per
+	// FPort over the given number of days.
+	GetDeviceUplinkStats(ctx context.Context, in *GetDeviceUplinkStatsRequest, opts ...grpc.CallOption) (*GetDeviceUplinkStatsResponse, error)
+	// GetTopTalkers returns the nodes with the most uplink frames or bytes
+	// over the given number of days.
+	GetTopTalkers(ctx context.Context, in *GetTopTalkersRequest, opts ...grpc.CallOption) (*GetTopTalkersResponse, error)
+	// GetLowLinkMarginNodes returns a batch of nodes of which the link margin
+	// (max. SNR above the demodulation floor) of the uplink history is
+	// consistently below the given threshold.
+	GetLowLinkMarginNodes(ctx context.Context, in *GetLowLinkMarginNodesRequest, opts ...grpc.CallOption) (*GetLowLinkMarginNodesResponse, error)
+	// GetDeviceClockDrift returns the transmission period and clock drift
+	// of a periodic node, estimated from the receive time of its last
+	// uplinks.
+	GetDeviceClockDrift(ctx context.Context, in *GetDeviceClockDriftRequest, opts ...grpc.CallOption) (*GetDeviceClockDriftResponse, error)
+	// GetClockDriftNodes returns a batch of periodic nodes of which the
+	// estimated clock drift exceeds the given threshold.
+	GetClockDriftNodes(ctx context.Context, in *GetClockDriftNodesRequest, opts ...grpc.CallOption) (*GetClockDriftNodesResponse, error)
+	// RotateGatewayCUPSCredentials generates new CUPS and LNS tokens for the
+	// given gateway. The previous CUPS token stays valid until the gateway
+	// fetched its new credentials from the CUPS endpoint.
+	RotateGatewayCUPSCredentials(ctx context.Context, in *RotateGatewayCUPSCredentialsRequest, opts ...grpc.CallOption) (*RotateGatewayCUPSCredentialsResponse, error)
+	// GetGatewayCUPSCredentials returns the CUPS and LNS tokens of the given
+	// gateway.
+	GetGatewayCUPSCredentials(ctx context.Context, in *GetGatewayCUPSCredentialsRequest, opts ...grpc.CallOption) (*GetGatewayCUPSCredentialsResponse, error)
+	// GenerateGatewayClientCertificate generates a TLS client certificate
+	// for the given gateway, used by the gateway to authenticate to the
+	// MQTT broker. The common name of the certificate is the MAC of the
+	// gateway.
+	GenerateGatewayClientCertificate(ctx context.Context, in *GenerateGatewayClientCertificateRequest, opts ...grpc.CallOption) (*GenerateGatewayClientCertificateResponse, error)
+	// ListRX2MismatchNodes returns the nodes of which the RX2 parameters
+	// were detected not to match the node-session.
+	ListRX2MismatchNodes(ctx context.Context, in *ListRX2MismatchNodesRequest, opts ...grpc.CallOption) (*ListRX2MismatchNodesResponse, error)
+	// ClearRX2Mismatch removes the RX2 mismatch flag and the collected
+	// downlink outcomes of the given node.
+	ClearRX2Mismatch(ctx context.Context, in *ClearRX2MismatchRequest, opts ...grpc.CallOption) (*ClearRX2MismatchResponse, error)
+	// ListFCntDownRolloverNodes returns the nodes of which the downlink
+	// frame-counter is approaching the 16-bit rollover.
+	ListFCntDownRolloverNodes(ctx context.Context, in *ListFCntDownRolloverNodesRequest, opts ...grpc.CallOption) (*ListFCntDownRolloverNodesResponse, error)
+	// GetOversizedFrameOffenders returns the nodes and gateways with the
+	// most uplink frames exceeding the max. payload size of the data-rate
+	// (usually caused by a node or gateway firmware bug).
+	GetOversizedFrameOffenders(ctx context.Context, in *GetOversizedFrameOffendersRequest, opts ...grpc.CallOption) (*GetOversizedFrameOffendersResponse, error)
+	// EnqueueDeviceQueueItem adds the given downlink payload to the
+	// device-queue of the node. The payload is sent in response to the next
+	// uplink of the node.
+	EnqueueDeviceQueueItem(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error)
+	// GetDeviceQueueItems returns the downlink payloads in the device-queue
+	// of the node.
+	GetDeviceQueueItems(ctx context.Context, in *GetDeviceQueueItemsRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsResponse, error)
+	// FlushDeviceQueue removes all the downlink payloads from the
+	// device-queue of the node.
+	FlushDeviceQueue(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error)
+	// GetNextDownlinkFCnt returns the frame-counter with which the next
+	// payload enqueued in the device-queue will be transmitted, e.g. for
+	// encrypting the payload with the AppSKey. The frame-counter can be
+	// reserved to avoid races between concurrent callers.
+	GetNextDownlinkFCnt(ctx context.Context, in *GetNextDownlinkFCntRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntResponse, error)
+	// CreateMulticastGroup creates the given multicast group.
+	CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast group for the given id.
+	GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast group.
+	UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*UpdateMulticastGroupResponse, error)
+	// DeleteMulticastGroup deletes the multicast group for the given id
+	// (including its queue).
+	DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*DeleteMulticastGroupResponse, error)
+	// ListMulticastGroups returns the multicast groups.
+	ListMulticastGroups(ctx context.Context, in *ListMulticastGroupsRequest, opts ...grpc.CallOption) (*ListMulticastGroupsResponse, error)
+	// EnqueueMulticastQueueItem adds the given payload to the queue of the
+	// multicast group. The payload is transmitted via the gateways of the
+	// group.
+	EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*EnqueueMulticastQueueItemResponse, error)
+	// FlushMulticastQueue removes all the payloads from the queue of the
+	// multicast group.
+	FlushMulticastQueue(ctx context.Context, in *FlushMulticastQueueRequest, opts ...grpc.CallOption) (*FlushMulticastQueueResponse, error)
+	// StreamFrameLogsForDevice streams the uplink and downlink frames of the
+	// given node.
+	StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServer_StreamFrameLogsForDeviceClient, error)
+	// StreamFrameLogsForGateway streams the uplink and downlink frames of
+	// the given gateway.
+	StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServer_StreamFrameLogsForGatewayClient, error)
+	// GetFrameLogsForDevice returns the last logged uplink and downlink
+	// frames of the given node.
+	GetFrameLogsForDevice(ctx context.Context, in *GetFrameLogsForDeviceRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
+	// GetFrameLogsForGateway returns the last logged uplink and downlink
+	// frames of the given gateway.
+	GetFrameLogsForGateway(ctx context.Context, in *GetFrameLogsForGatewayRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
+	// CreateDownlinkTemplate creates the given downlink template.
+	CreateDownlinkTemplate(ctx context.Context, in *CreateDownlinkTemplateRequest, opts ...grpc.CallOption) (*CreateDownlinkTemplateResponse, error)
+	// GetDownlinkTemplate returns the downlink template for the given id.
+	GetDownlinkTemplate(ctx context.Context, in *GetDownlinkTemplateRequest, opts ...grpc.CallOption) (*GetDownlinkTemplateResponse, error)
+	// UpdateDownlinkTemplate updates the given downlink template.
+	UpdateDownlinkTemplate(ctx context.Context, in *UpdateDownlinkTemplateRequest, opts ...grpc.CallOption) (*UpdateDownlinkTemplateResponse, error)
+	// DeleteDownlinkTemplate deletes the downlink template for the given id.
+	DeleteDownlinkTemplate(ctx context.Context, in *DeleteDownlinkTemplateRequest, opts ...grpc.CallOption) (*DeleteDownlinkTemplateResponse, error)
+	// ListDownlinkTemplates returns the downlink templates.
+	ListDownlinkTemplates(ctx context.Context, in *ListDownlinkTemplatesRequest, opts ...grpc.CallOption) (*ListDownlinkTemplatesResponse, error)
+	// TriggerDownlinkTemplate renders the downlink template for the given
+	// node, or for all the nodes having the given tags, and adds the
+	// payload to the device-queue of these nodes.
+	TriggerDownlinkTemplate(ctx context.Context, in *TriggerDownlinkTemplateRequest, opts ...grpc.CallOption) (*TriggerDownlinkTemplateResponse, error)
+	// CreateChannelConfiguration creates the given channel-configuration.
+	CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error)
+	// GetChannelConfiguration returns the channel-configuration for the
+	// given id.
+	GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error)
+	// UpdateChannelConfiguration updates the given channel-configuration.
+	// The nodes using it are reconciled on their next uplink.
+	UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error)
+	// DeleteChannelConfiguration deletes the channel-configuration for the
+	// given id.
+	DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error)
+	// ListChannelConfigurations returns the channel-configurations.
+	ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error)
+	// EnqueueBenchDownlink enqueues a downlink for an unprovisioned test
+	// device (lab / bench testing), using the given DevAddr and session keys.
+	// It is sent in response to the next uplink of the DevAddr for which no
+	// node-session exists. Only available when bench mode is enabled.
+	EnqueueBenchDownlink(ctx context.Context, in *EnqueueBenchDownlinkRequest, opts ...grpc.CallOption) (*EnqueueBenchDownlinkResponse, error)
+	// CreateGatewayOnboardingToken creates a one-time token with which a
+	// gateway, which does not exist yet, registers itself on its first
+	// stats message.
+	CreateGatewayOnboardingToken(ctx context.Context, in *CreateGatewayOnboardingTokenRequest, opts ...grpc.CallOption) (*CreateGatewayOnboardingTokenResponse, error)
+	// ListGatewayOnboardingTokens returns the gateway onboarding tokens.
+	ListGatewayOnboardingTokens(ctx context.Context, in *ListGatewayOnboardingTokensRequest, opts ...grpc.CallOption) (*ListGatewayOnboardingTokensResponse, error)
+	// DeleteGatewayOnboardingToken deletes (revokes) the given gateway
+	// onboarding token.
+	DeleteGatewayOnboardingToken(ctx context.Context, in *DeleteGatewayOnboardingTokenRequest, opts ...grpc.CallOption) (*DeleteGatewayOnboardingTokenResponse, error)
+	// SendGatewayTestFrame transmits a proprietary test frame via the given
+	// gateway and returns the TX acknowledgement of the gateway, e.g. for
+	// verifying the downlink path of a newly installed gateway.
+	SendGatewayTestFrame(ctx context.Context, in *SendGatewayTestFrameRequest, opts ...grpc.CallOption) (*SendGatewayTestFrameResponse, error)
+	// ExportFrameLogsForDevice exports the last logged uplink and downlink
+	// frames of the given node in the pcap format (LoRaTap link-type).
+	ExportFrameLogsForDevice(ctx context.Context, in *ExportFrameLogsForDeviceRequest, opts ...grpc.CallOption) (*ExportFrameLogsResponse, error)
+	// ExportFrameLogsForGateway exports the last logged uplink and downlink
+	// frames of the given gateway in the pcap format (LoRaTap link-type).
+	ExportFrameLogsForGateway(ctx context.Context, in *ExportFrameLogsForGatewayRequest, opts ...grpc.CallOption) (*ExportFrameLogsResponse, error)
+	// RotateGatewaySigningKey generates a new signing key for the given
+	// gateway. Once set, only uplink packets carrying a valid signature are
+	// accepted from the gateway.
+	RotateGatewaySigningKey(ctx context.Context, in *RotateGatewaySigningKeyRequest, opts ...grpc.CallOption) (*RotateGatewaySigningKeyResponse, error)
+	// DeleteGatewaySigningKey deletes the signing key of the given gateway.
+	DeleteGatewaySigningKey(ctx context.Context, in *DeleteGatewaySigningKeyRequest, opts ...grpc.CallOption) (*DeleteGatewaySigningKeyResponse, error)
+	// SetDeviceChannelMask restricts the enabled uplink channels of the given
+	// node to the given channels (e.g. to keep a noisy channel off for this
+	// node). The mask is applied to the channel mask of the LinkADRReq
+	// mac-commands sent to the node.
+	SetDeviceChannelMask(ctx context.Context, in *SetDeviceChannelMaskRequest, opts ...grpc.CallOption) (*SetDeviceChannelMaskResponse, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile for the given id.
+	GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error)
+	// DeleteDeviceProfile deletes the device-profile for the given id.
+	DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error)
+	// ListDeviceProfiles returns the device-profiles.
+	ListDeviceProfiles(ctx context.Context, in *ListDeviceProfilesRequest, opts ...grpc.CallOption) (*ListDeviceProfilesResponse, error)
+	// GetUnknownDevAddrStats returns the number of uplinks of DevAddrs without
+	// node-session per hour, gateway, frequency, data-rate and NwkID (passive
+	// monitoring, requires --passive-monitoring).
+	GetUnknownDevAddrStats(ctx context.Context, in *GetUnknownDevAddrStatsRequest, opts ...grpc.CallOption) (*GetUnknownDevAddrStatsResponse, error)
+	// BulkCreateOrUpdateGateways creates the given gateways or updates them
+	// when they already exist (e.g. when onboarding gateways from an asset
+	// database). The result is returned per gateway.
+	BulkCreateOrUpdateGateways(ctx context.Context, in *BulkCreateOrUpdateGatewaysRequest, opts ...grpc.CallOption) (*BulkCreateOrUpdateGatewaysResponse, error)
+	// CheckSessions checks the node-session storage for orphaned DevAddr
+	// pointers, node-sessions with an impossible MAC state and mismatched
+	// mac-command queues and optionally repairs these (see the check-sessions
+	// command).
+	CheckSessions(ctx context.Context, in *CheckSessionsRequest, opts ...grpc.CallOption) (*CheckSessionsResponse, error)
+}
+
+type networkServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkServerClient(cc *grpc.ClientConn) NetworkServerClient {
+	return &networkServerClient{cc}
+}
+
+func (c *networkServerClient) CreateNodeSession(ctx context.Context, in *CreateNodeSessionRequest, opts ...grpc.CallOption) (*CreateNodeSessionResponse, error) {
+	out := new(CreateNodeSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateNodeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetNodeSession(ctx context.Context, in *GetNodeSessionRequest, opts ...grpc.CallOption) (*GetNodeSessionResponse, error) {
+	out := new(GetNodeSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetNodeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateNodeSession(ctx context.Context, in *UpdateNodeSessionRequest, opts ...grpc.CallOption) (*UpdateNodeSessionResponse, error) {
+	out := new(UpdateNodeSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateNodeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) PatchNodeSession(ctx context.Context, in *PatchNodeSessionRequest, opts ...grpc.CallOption) (*PatchNodeSessionResponse, error) {
+	out := new(PatchNodeSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/PatchNodeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteNodeSession(ctx context.Context, in *DeleteNodeSessionRequest, opts ...grpc.CallOption) (*DeleteNodeSessionResponse, error) {
+	out := new(DeleteNodeSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteNodeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
+	out := new(GetRandomDevAddrResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRandomDevAddr", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) EnqueueDataDownMACCommand(ctx context.Context, in *EnqueueDataDownMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDataDownMACCommandResponse, error) {
+	out := new(EnqueueDataDownMACCommandResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueDataDownMACCommand", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) PushDataDown(ctx context.Context, in *PushDataDownRequest, opts ...grpc.CallOption) (*PushDataDownResponse, error) {
+	out := new(PushDataDownResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/PushDataDown", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error) {
+	out := new(CreateGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
+	out := new(GetGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error) {
+	out := new(UpdateGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error) {
+	out := new(ListGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGateways", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error) {
+	out := new(DeleteGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
+	out := new(GetGatewayStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) StreamUplinkMetadata(ctx context.Context, in *StreamUplinkMetadataRequest, opts ...grpc.CallOption) (NetworkServer_StreamUplinkMetadataClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NetworkServer_serviceDesc.Streams[0], c.cc, "/ns.NetworkServer/StreamUplinkMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerStreamUplinkMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServer_StreamUplinkMetadataClient interface {
+	Recv() (*StreamUplinkMetadataResponse, error)
+	grpc.ClientStream
+}
+
+type networkServerStreamUplinkMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerStreamUplinkMetadataClient) Recv() (*StreamUplinkMetadataResponse, error) {
+	m := new(StreamUplinkMetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerClient) GetDownlinkCapacityReport(ctx context.Context, in *GetDownlinkCapacityReportRequest, opts ...grpc.CallOption) (*GetDownlinkCapacityReportResponse, error) {
+	out := new(GetDownlinkCapacityReportResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDownlinkCapacityReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetUplinkChannelStats(ctx context.Context, in *GetUplinkChannelStatsRequest, opts ...grpc.CallOption) (*GetUplinkChannelStatsResponse, error) {
+	out := new(GetUplinkChannelStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetUplinkChannelStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetJoinStats(ctx context.Context, in *GetJoinStatsRequest, opts ...grpc.CallOption) (*GetJoinStatsResponse, error) {
+	out := new(GetJoinStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetJoinStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) BroadcastDataDown(ctx context.Context, in *BroadcastDataDownRequest, opts ...grpc.CallOption) (*BroadcastDataDownResponse, error) {
+	out := new(BroadcastDataDownResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/BroadcastDataDown", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDownlinkDecisions(ctx context.Context, in *GetDownlinkDecisionsRequest, opts ...grpc.CallOption) (*GetDownlinkDecisionsResponse, error) {
+	out := new(GetDownlinkDecisionsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDownlinkDecisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetMACCommandHistory(ctx context.Context, in *GetMACCommandHistoryRequest, opts ...grpc.CallOption) (*GetMACCommandHistoryResponse, error) {
+	out := new(GetMACCommandHistoryResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetMACCommandHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDownlinkFrames(ctx context.Context, in *GetDownlinkFramesRequest, opts ...grpc.CallOption) (*GetDownlinkFramesResponse, error) {
+	out := new(GetDownlinkFramesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDownlinkFrames", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ImportNodeSessions(ctx context.Context, in *ImportNodeSessionsRequest, opts ...grpc.CallOption) (*ImportNodeSessionsResponse, error) {
+	out := new(ImportNodeSessionsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ImportNodeSessions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ExportNodeSessions(ctx context.Context, in *ExportNodeSessionsRequest, opts ...grpc.CallOption) (*ExportNodeSessionsResponse, error) {
+	out := new(ExportNodeSessionsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ExportNodeSessions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
+	out := new(GetDeviceActivationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceActivation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ChangeDeviceClass(ctx context.Context, in *ChangeDeviceClassRequest, opts ...grpc.CallOption) (*ChangeDeviceClassResponse, error) {
+	out := new(ChangeDeviceClassResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ChangeDeviceClass", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListGatewayDevices(ctx context.Context, in *ListGatewayDevicesRequest, opts ...grpc.CallOption) (*ListGatewayDevicesResponse, error) {
+	out := new(ListGatewayDevicesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGatewayDevices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGatewayAntennaStats(ctx context.Context, in *GetGatewayAntennaStatsRequest, opts ...grpc.CallOption) (*GetGatewayAntennaStatsResponse, error) {
+	out := new(GetGatewayAntennaStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayAntennaStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetADRParameters(ctx context.Context, in *GetADRParametersRequest, opts ...grpc.CallOption) (*GetADRParametersResponse, error) {
+	out := new(GetADRParametersResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetADRParameters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateADRParameters(ctx context.Context, in *UpdateADRParametersRequest, opts ...grpc.CallOption) (*UpdateADRParametersResponse, error) {
+	out := new(UpdateADRParametersResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateADRParameters", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetADRDecisions(ctx context.Context, in *GetADRDecisionsRequest, opts ...grpc.CallOption) (*GetADRDecisionsResponse, error) {
+	out := new(GetADRDecisionsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetADRDecisions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) AuditRedisKeys(ctx context.Context, in *AuditRedisKeysRequest, opts ...grpc.CallOption) (*AuditRedisKeysResponse, error) {
+	out := new(AuditRedisKeysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/AuditRedisKeys", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ReleaseQuarantine(ctx context.Context, in *ReleaseQuarantineRequest, opts ...grpc.CallOption) (*ReleaseQuarantineResponse, error) {
+	out := new(ReleaseQuarantineResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ReleaseQuarantine", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceDownlinkAirtime(ctx context.Context, in *GetDeviceDownlinkAirtimeRequest, opts ...grpc.CallOption) (*GetDeviceDownlinkAirtimeResponse, error) {
+	out := new(GetDeviceDownlinkAirtimeResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceDownlinkAirtime", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateUplinkRule(ctx context.Context, in *CreateUplinkRuleRequest, opts ...grpc.CallOption) (*CreateUplinkRuleResponse, error) {
+	out := new(CreateUplinkRuleResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateUplinkRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetUplinkRule(ctx context.Context, in *GetUplinkRuleRequest, opts ...grpc.CallOption) (*GetUplinkRuleResponse, error) {
+	out := new(GetUplinkRuleResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetUplinkRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateUplinkRule(ctx context.Context, in *UpdateUplinkRuleRequest, opts ...grpc.CallOption) (*UpdateUplinkRuleResponse, error) {
+	out := new(UpdateUplinkRuleResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateUplinkRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteUplinkRule(ctx context.Context, in *DeleteUplinkRuleRequest, opts ...grpc.CallOption) (*DeleteUplinkRuleResponse, error) {
+	out := new(DeleteUplinkRuleResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteUplinkRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListUplinkRules(ctx context.Context, in *ListUplinkRulesRequest, opts ...grpc.CallOption) (*ListUplinkRulesResponse, error) {
+	out := new(ListUplinkRulesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListUplinkRules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceUplinkStats(ctx context.Context, in *GetDeviceUplinkStatsRequest, opts ...grpc.CallOption) (*GetDeviceUplinkStatsResponse, error) {
+	out := new(GetDeviceUplinkStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceUplinkStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetTopTalkers(ctx context.Context, in *GetTopTalkersRequest, opts ...grpc.CallOption) (*GetTopTalkersResponse, error) {
+	out := new(GetTopTalkersResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetTopTalkers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetLowLinkMarginNodes(ctx context.Context, in *GetLowLinkMarginNodesRequest, opts ...grpc.CallOption) (*GetLowLinkMarginNodesResponse, error) {
+	out := new(GetLowLinkMarginNodesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetLowLinkMarginNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceClockDrift(ctx context.Context, in *GetDeviceClockDriftRequest, opts ...grpc.CallOption) (*GetDeviceClockDriftResponse, error) {
+	out := new(GetDeviceClockDriftResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceClockDrift", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetClockDriftNodes(ctx context.Context, in *GetClockDriftNodesRequest, opts ...grpc.CallOption) (*GetClockDriftNodesResponse, error) {
+	out := new(GetClockDriftNodesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetClockDriftNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) RotateGatewayCUPSCredentials(ctx context.Context, in *RotateGatewayCUPSCredentialsRequest, opts ...grpc.CallOption) (*RotateGatewayCUPSCredentialsResponse, error) {
+	out := new(RotateGatewayCUPSCredentialsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/RotateGatewayCUPSCredentials", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGatewayCUPSCredentials(ctx context.Context, in *GetGatewayCUPSCredentialsRequest, opts ...grpc.CallOption) (*GetGatewayCUPSCredentialsResponse, error) {
+	out := new(GetGatewayCUPSCredentialsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayCUPSCredentials", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GenerateGatewayClientCertificate(ctx context.Context, in *GenerateGatewayClientCertificateRequest, opts ...grpc.CallOption) (*GenerateGatewayClientCertificateResponse, error) {
+	out := new(GenerateGatewayClientCertificateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GenerateGatewayClientCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListRX2MismatchNodes(ctx context.Context, in *ListRX2MismatchNodesRequest, opts ...grpc.CallOption) (*ListRX2MismatchNodesResponse, error) {
+	out := new(ListRX2MismatchNodesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListRX2MismatchNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ClearRX2Mismatch(ctx context.Context, in *ClearRX2MismatchRequest, opts ...grpc.CallOption) (*ClearRX2MismatchResponse, error) {
+	out := new(ClearRX2MismatchResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ClearRX2Mismatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListFCntDownRolloverNodes(ctx context.Context, in *ListFCntDownRolloverNodesRequest, opts ...grpc.CallOption) (*ListFCntDownRolloverNodesResponse, error) {
+	out := new(ListFCntDownRolloverNodesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListFCntDownRolloverNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetOversizedFrameOffenders(ctx context.Context, in *GetOversizedFrameOffendersRequest, opts ...grpc.CallOption) (*GetOversizedFrameOffendersResponse, error) {
+	out := new(GetOversizedFrameOffendersResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetOversizedFrameOffenders", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) EnqueueDeviceQueueItem(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error) {
+	out := new(EnqueueDeviceQueueItemResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueDeviceQueueItem", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceQueueItems(ctx context.Context, in *GetDeviceQueueItemsRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsResponse, error) {
+	out := new(GetDeviceQueueItemsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceQueueItems", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) FlushDeviceQueue(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error) {
+	out := new(FlushDeviceQueueResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/FlushDeviceQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetNextDownlinkFCnt(ctx context.Context, in *GetNextDownlinkFCntRequest, opts ...grpc.CallOption) (*GetNextDownlinkFCntResponse, error) {
+	out := new(GetNextDownlinkFCntResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetNextDownlinkFCnt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error) {
+	out := new(CreateMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error) {
+	out := new(GetMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*UpdateMulticastGroupResponse, error) {
+	out := new(UpdateMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*DeleteMulticastGroupResponse, error) {
+	out := new(DeleteMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteMulticastGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListMulticastGroups(ctx context.Context, in *ListMulticastGroupsRequest, opts ...grpc.CallOption) (*ListMulticastGroupsResponse, error) {
+	out := new(ListMulticastGroupsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListMulticastGroups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*EnqueueMulticastQueueItemResponse, error) {
+	out := new(EnqueueMulticastQueueItemResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueMulticastQueueItem", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) FlushMulticastQueue(ctx context.Context, in *FlushMulticastQueueRequest, opts ...grpc.CallOption) (*FlushMulticastQueueResponse, error) {
+	out := new(FlushMulticastQueueResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/FlushMulticastQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) StreamFrameLogsForDevice(ctx context.Context, in *StreamFrameLogsForDeviceRequest, opts ...grpc.CallOption) (NetworkServer_StreamFrameLogsForDeviceClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NetworkServer_serviceDesc.Streams[1], c.cc, "/ns.NetworkServer/StreamFrameLogsForDevice", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerStreamFrameLogsForDeviceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServer_StreamFrameLogsForDeviceClient interface {
+	Recv() (*FrameLog, error)
+	grpc.ClientStream
+}
+
+type networkServerStreamFrameLogsForDeviceClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerStreamFrameLogsForDeviceClient) Recv() (*FrameLog, error) {
+	m := new(FrameLog)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerClient) StreamFrameLogsForGateway(ctx context.Context, in *StreamFrameLogsForGatewayRequest, opts ...grpc.CallOption) (NetworkServer_StreamFrameLogsForGatewayClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NetworkServer_serviceDesc.Streams[2], c.cc, "/ns.NetworkServer/StreamFrameLogsForGateway", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServerStreamFrameLogsForGatewayClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServer_StreamFrameLogsForGatewayClient interface {
+	Recv() (*FrameLog, error)
+	grpc.ClientStream
+}
+
+type networkServerStreamFrameLogsForGatewayClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServerStreamFrameLogsForGatewayClient) Recv() (*FrameLog, error) {
+	m := new(FrameLog)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *networkServerClient) GetFrameLogsForDevice(ctx context.Context, in *GetFrameLogsForDeviceRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
+	out := new(GetFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetFrameLogsForDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetFrameLogsForGateway(ctx context.Context, in *GetFrameLogsForGatewayRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
+	out := new(GetFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetFrameLogsForGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateDownlinkTemplate(ctx context.Context, in *CreateDownlinkTemplateRequest, opts ...grpc.CallOption) (*CreateDownlinkTemplateResponse, error) {
+	out := new(CreateDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDownlinkTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDownlinkTemplate(ctx context.Context, in *GetDownlinkTemplateRequest, opts ...grpc.CallOption) (*GetDownlinkTemplateResponse, error) {
+	out := new(GetDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDownlinkTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateDownlinkTemplate(ctx context.Context, in *UpdateDownlinkTemplateRequest, opts ...grpc.CallOption) (*UpdateDownlinkTemplateResponse, error) {
+	out := new(UpdateDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDownlinkTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteDownlinkTemplate(ctx context.Context, in *DeleteDownlinkTemplateRequest, opts ...grpc.CallOption) (*DeleteDownlinkTemplateResponse, error) {
+	out := new(DeleteDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDownlinkTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListDownlinkTemplates(ctx context.Context, in *ListDownlinkTemplatesRequest, opts ...grpc.CallOption) (*ListDownlinkTemplatesResponse, error) {
+	out := new(ListDownlinkTemplatesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListDownlinkTemplates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) TriggerDownlinkTemplate(ctx context.Context, in *TriggerDownlinkTemplateRequest, opts ...grpc.CallOption) (*TriggerDownlinkTemplateResponse, error) {
+	out := new(TriggerDownlinkTemplateResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/TriggerDownlinkTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error) {
+	out := new(CreateChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateChannelConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error) {
+	out := new(GetChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetChannelConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error) {
+	out := new(UpdateChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateChannelConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error) {
+	out := new(DeleteChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteChannelConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error) {
+	out := new(ListChannelConfigurationsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListChannelConfigurations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) EnqueueBenchDownlink(ctx context.Context, in *EnqueueBenchDownlinkRequest, opts ...grpc.CallOption) (*EnqueueBenchDownlinkResponse, error) {
+	out := new(EnqueueBenchDownlinkResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueBenchDownlink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateGatewayOnboardingToken(ctx context.Context, in *CreateGatewayOnboardingTokenRequest, opts ...grpc.CallOption) (*CreateGatewayOnboardingTokenResponse, error) {
+	out := new(CreateGatewayOnboardingTokenResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateGatewayOnboardingToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListGatewayOnboardingTokens(ctx context.Context, in *ListGatewayOnboardingTokensRequest, opts ...grpc.CallOption) (*ListGatewayOnboardingTokensResponse, error) {
+	out := new(ListGatewayOnboardingTokensResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGatewayOnboardingTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteGatewayOnboardingToken(ctx context.Context, in *DeleteGatewayOnboardingTokenRequest, opts ...grpc.CallOption) (*DeleteGatewayOnboardingTokenResponse, error) {
+	out := new(DeleteGatewayOnboardingTokenResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGatewayOnboardingToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) SendGatewayTestFrame(ctx context.Context, in *SendGatewayTestFrameRequest, opts ...grpc.CallOption) (*SendGatewayTestFrameResponse, error) {
+	out := new(SendGatewayTestFrameResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/SendGatewayTestFrame", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ExportFrameLogsForDevice(ctx context.Context, in *ExportFrameLogsForDeviceRequest, opts ...grpc.CallOption) (*ExportFrameLogsResponse, error) {
+	out := new(ExportFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ExportFrameLogsForDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ExportFrameLogsForGateway(ctx context.Context, in *ExportFrameLogsForGatewayRequest, opts ...grpc.CallOption) (*ExportFrameLogsResponse, error) {
+	out := new(ExportFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ExportFrameLogsForGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) RotateGatewaySigningKey(ctx context.Context, in *RotateGatewaySigningKeyRequest, opts ...grpc.CallOption) (*RotateGatewaySigningKeyResponse, error) {
+	out := new(RotateGatewaySigningKeyResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/RotateGatewaySigningKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteGatewaySigningKey(ctx context.Context, in *DeleteGatewaySigningKeyRequest, opts ...grpc.CallOption) (*DeleteGatewaySigningKeyResponse, error) {
+	out := new(DeleteGatewaySigningKeyResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGatewaySigningKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) SetDeviceChannelMask(ctx context.Context, in *SetDeviceChannelMaskRequest, opts ...grpc.CallOption) (*SetDeviceChannelMaskResponse, error) {
+	out := new(SetDeviceChannelMaskResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/SetDeviceChannelMask", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
+	out := new(CreateDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error) {
+	out := new(GetDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error) {
+	out := new(UpdateDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error) {
+	out := new(DeleteDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListDeviceProfiles(ctx context.Context, in *ListDeviceProfilesRequest, opts ...grpc.CallOption) (*ListDeviceProfilesResponse, error) {
+	out := new(ListDeviceProfilesResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListDeviceProfiles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetUnknownDevAddrStats(ctx context.Context, in *GetUnknownDevAddrStatsRequest, opts ...grpc.CallOption) (*GetUnknownDevAddrStatsResponse, error) {
+	out := new(GetUnknownDevAddrStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetUnknownDevAddrStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) BulkCreateOrUpdateGateways(ctx context.Context, in *BulkCreateOrUpdateGatewaysRequest, opts ...grpc.CallOption) (*BulkCreateOrUpdateGatewaysResponse, error) {
+	out := new(BulkCreateOrUpdateGatewaysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/BulkCreateOrUpdateGateways", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CheckSessions(ctx context.Context, in *CheckSessionsRequest, opts ...grpc.CallOption) (*CheckSessionsResponse, error) {
+	out := new(CheckSessionsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CheckSessions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for NetworkServer service
+
+type NetworkServerServer interface {
+	// CreateNodeSession creates the given node-session.
+	// The DevAddr must contain the same NwkID as the configured NetID.
+	// Node-sessions will expire automatically after the configured TTL.
+	CreateNodeSession(context.Context, *CreateNodeSessionRequest) (*CreateNodeSessionResponse, error)
+	// GetNodeSession returns the node-session matching the given DevEUI.
+	GetNodeSession(context.Context, *GetNodeSessionRequest) (*GetNodeSessionResponse, error)
+	// UpdateNodeSession updates the given node-session.
+	UpdateNodeSession(context.Context, *UpdateNodeSessionRequest) (*UpdateNodeSessionResponse, error)
+	// PatchNodeSession updates the fields of the given update mask of an
+	// existing node-session, without overwriting concurrent updates.
+	PatchNodeSession(context.Context, *PatchNodeSessionRequest) (*PatchNodeSessionResponse, error)
+	// DeleteNodeSession deletes the node-session matching the given DevAddr.
+	DeleteNodeSession(context.Context, *DeleteNodeSessionRequest) (*DeleteNodeSessionResponse, error)
+	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
+	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
+	// EnqueueDataDownMACCommand adds the downlink mac-command to the queue.
+	EnqueueDataDownMACCommand(context.Context, *EnqueueDataDownMACCommandRequest) (*EnqueueDataDownMACCommandResponse, error)
+	// PushDataDown pushes the given downlink payload to the node (only works for Class-C nodes).
+	PushDataDown(context.Context, *PushDataDownRequest) (*PushDataDownResponse, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(context.Context, *CreateGatewayRequest) (*CreateGatewayResponse, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(context.Context, *UpdateGatewayRequest) (*UpdateGatewayResponse, error)
+	// ListGateways returns the existing gateways.
+	ListGateways(context.Context, *ListGatewayRequest) (*ListGatewayResponse, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(context.Context, *DeleteGatewayRequest) (*DeleteGatewayResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+	// StreamUplinkMetadata streams the meta-data of the received uplink
+	// frames in real-time. Note that the payload itself is not included.
+	StreamUplinkMetadata(*StreamUplinkMetadataRequest, NetworkServer_StreamUplinkMetadataServer) error
+	// GetDownlinkCapacityReport returns the downlink capacity usage (airtime
+	// and duty-cycle) per sub-band of an existing gateway.
+	GetDownlinkCapacityReport(context.Context, *GetDownlinkCapacityReportRequest) (*GetDownlinkCapacityReportResponse, error)
+	// GetUplinkChannelStats returns the number of uplinks per hour, frequency
+	// and data-rate (e.g. to verify that nodes use the extra channels
+	// distributed by CFList or NewChannelReq).
+	GetUplinkChannelStats(context.Context, *GetUplinkChannelStatsRequest) (*GetUplinkChannelStatsResponse, error)
+	// GetJoinStats returns the number of handled, accepted and failed
+	// join-requests and the average join-request to join-accept latency per
+	// gateway and per AppEUI (e.g. to find activation problems in specific
+	// areas).
+	GetJoinStats(context.Context, *GetJoinStatsRequest) (*GetJoinStatsResponse, error)
+	// BroadcastDataDown broadcasts the given (plaintext) payload to all
+	// Class-C nodes matching the filter (e.g. for alarm or recall
+	// scenarios). Only nodes of which the AppSKey encryption is offloaded to
+	// LoRa Server are eligible. The downlinks are sent in the background,
+	// paced per gateway by its duty-cycle budget.
+	BroadcastDataDown(context.Context, *BroadcastDataDownRequest) (*BroadcastDataDownResponse, error)
+	// GetDownlinkDecisions returns the last decisions (and their inputs) on
+	// the downlink opportunities following the uplinks of the given node,
+	// e.g. to find out why a node did not receive a downlink.
+	GetDownlinkDecisions(context.Context, *GetDownlinkDecisionsRequest) (*GetDownlinkDecisionsResponse, error)
+	// GetMACCommandHistory returns the last mac-commands sent to and
+	// received from the given node (e.g. to find out if the node ever
+	// acknowledged a channel-mask).
+	GetMACCommandHistory(context.Context, *GetMACCommandHistoryRequest) (*GetMACCommandHistoryResponse, error)
+	// GetDownlinkFrames returns the frame log entries of the (re)transmissions
+	// of the given downlink frame-counter of the given node, including the
+	// token and the TX acknowledgement of the gateway.
+	GetDownlinkFrames(context.Context, *GetDownlinkFramesRequest) (*GetDownlinkFramesResponse, error)
+	// ImportNodeSessions creates the given node-sessions in a single call
+	// (e.g. when migrating ABP nodes from an other network-server). The
+	// node-sessions which could not be created are reported per row.
+	ImportNodeSessions(context.Context, *ImportNodeSessionsRequest) (*ImportNodeSessionsResponse, error)
+	// ExportNodeSessions returns a batch of node-sessions, in the format
+	// accepted by ImportNodeSessions.
+	ExportNodeSessions(context.Context, *ExportNodeSessionsRequest) (*ExportNodeSessionsResponse, error)
+	// GetDeviceActivation returns the activation parameters of the given
+	// node as JSON document, using the field names of the LoRaWAN Backend
+	// Interfaces specification (e.g. for migrating the node to an other
+	// network-server).
+	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// ChangeDeviceClass initiates a device class change of the node (e.g.
+	// when the node indicated a new device mode). Class-C downlinks are
+	// paused until the change has been confirmed by an uplink of the node.
+	ChangeDeviceClass(context.Context, *ChangeDeviceClassRequest) (*ChangeDeviceClassResponse, error)
+	// ListGatewayDevices returns the nodes recently received by the given
+	// gateway (e.g. to assess the coverage impact of decommissioning a
+	// gateway).
+	ListGatewayDevices(context.Context, *ListGatewayDevicesRequest) (*ListGatewayDevicesResponse, error)
+	// GetGatewayAntennaStats returns the uplink stats per antenna of the
+	// given gateway (for gateways with multiple antennas).
+	GetGatewayAntennaStats(context.Context, *GetGatewayAntennaStatsRequest) (*GetGatewayAntennaStatsResponse, error)
+	// GetADRParameters returns the global ADR parameters.
+	GetADRParameters(context.Context, *GetADRParametersRequest) (*GetADRParametersResponse, error)
+	// UpdateADRParameters updates the global ADR parameters. The parameters
+	// are persisted and take effect without restarting LoRa Server.
+	UpdateADRParameters(context.Context, *UpdateADRParametersRequest) (*UpdateADRParametersResponse, error)
+	// GetADRDecisions returns the last ADR decisions of the given node,
+	// together with the inputs (uplink SNR history and margin) from which
+	// the requested data-rate, TX power and number of transmissions were
+	// computed, e.g. to find out why ADR lowered the data-rate of a node.
+	GetADRDecisions(context.Context, *GetADRDecisionsRequest) (*GetADRDecisionsResponse, error)
+	// AuditRedisKeys reports the cardinality and memory footprint of the
+	// de-duplication / collection keys and mac-command queues stored in
+	// Redis and optionally removes the keys left behind without TTL.
+	AuditRedisKeys(context.Context, *AuditRedisKeysRequest) (*AuditRedisKeysResponse, error)
+	// GetInfo returns the version, region (band), NetID, enabled features
+	// and limits of LoRa Server, so that application-servers can adapt their
+	// behavior and deployments can be verified.
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// ReleaseQuarantine releases a node which has been quarantined in strict
+	// security mode, after repeatedly triggering security events.
+	ReleaseQuarantine(context.Context, *ReleaseQuarantineRequest) (*ReleaseQuarantineResponse, error)
+	// GetDeviceDownlinkAirtime returns the downlink airtime consumed by a
+	// node per day and its remaining daily budget (see
+	// dailyDownlinkAirtimeCap).
+	GetDeviceDownlinkAirtime(context.Context, *GetDeviceDownlinkAirtimeRequest) (*GetDeviceDownlinkAirtimeResponse, error)
+	// CreateUplinkRule creates the given uplink automation rule.
+	CreateUplinkRule(context.Context, *CreateUplinkRuleRequest) (*CreateUplinkRuleResponse, error)
+	// GetUplinkRule returns the uplink automation rule for the given id.
+	GetUplinkRule(context.Context, *GetUplinkRuleRequest) (*GetUplinkRuleResponse, error)
+	// UpdateUplinkRule updates the given uplink automation rule.
+	UpdateUplinkRule(context.Context, *UpdateUplinkRuleRequest) (*UpdateUplinkRuleResponse, error)
+	// DeleteUplinkRule deletes the uplink automation rule for the given id.
+	DeleteUplinkRule(context.Context, *DeleteUplinkRuleRequest) (*DeleteUplinkRuleResponse, error)
+	// ListUplinkRules returns the uplink automation rules.
+	ListUplinkRules(context.Context, *ListUplinkRulesRequest) (*ListUplinkRulesResponse, error)
+	// GetDeviceUplinkStats returns the uplink frames and bytes of a node per
+	// FPort over the given number of days.
+	GetDeviceUplinkStats(context.Context, *GetDeviceUplinkStatsRequest) (*GetDeviceUplinkStatsResponse, error)
+	// GetTopTalkers returns the nodes with the most uplink frames or bytes
+	// over the given number of days.
+	GetTopTalkers(context.Context, *GetTopTalkersRequest) (*GetTopTalkersResponse, error)
+	// GetLowLinkMarginNodes returns a batch of nodes of which the link margin
+	// (max. SNR above the demodulation floor) of the uplink history is
+	// consistently below the given threshold.
+	GetLowLinkMarginNodes(context.Context, *GetLowLinkMarginNodesRequest) (*GetLowLinkMarginNodesResponse, error)
+	// GetDeviceClockDrift returns the transmission period and clock drift
+	// of a periodic node, estimated from the receive time of its last
+	// uplinks.
+	GetDeviceClockDrift(context.Context, *GetDeviceClockDriftRequest) (*GetDeviceClockDriftResponse, error)
+	// GetClockDriftNodes returns a batch of periodic nodes of which the
+	// estimated clock drift exceeds the given threshold.
+	GetClockDriftNodes(context.Context, *GetClockDriftNodesRequest) (*GetClockDriftNodesResponse, error)
+	// RotateGatewayCUPSCredentials generates new CUPS and LNS tokens for the
+	// given gateway. The previous CUPS token stays valid until the gateway
+	// fetched its new credentials from the CUPS endpoint.
+	RotateGatewayCUPSCredentials(context.Context, *RotateGatewayCUPSCredentialsRequest) (*RotateGatewayCUPSCredentialsResponse, error)
+	// GetGatewayCUPSCredentials returns the CUPS and LNS tokens of the given
+	// gateway.
+	GetGatewayCUPSCredentials(context.Context, *GetGatewayCUPSCredentialsRequest) (*GetGatewayCUPSCredentialsResponse, error)
+	// GenerateGatewayClientCertificate generates a TLS client certificate
+	// for the given gateway, used by the gateway to authenticate to the
+	// MQTT broker. The common name of the certificate is the MAC of the
+	// gateway.
+	GenerateGatewayClientCertificate(context.Context, *GenerateGatewayClientCertificateRequest) (*GenerateGatewayClientCertificateResponse, error)
+	// ListRX2MismatchNodes returns the nodes of which the RX2 parameters
+	// were detected not to match the node-session.
+	ListRX2MismatchNodes(context.Context, *ListRX2MismatchNodesRequest) (*ListRX2MismatchNodesResponse, error)
+	// ClearRX2Mismatch removes the RX2 mismatch flag and the collected
+	// downlink outcomes of the given node.
+	ClearRX2Mismatch(context.Context, *ClearRX2MismatchRequest) (*ClearRX2MismatchResponse, error)
+	// ListFCntDownRolloverNodes returns the nodes of which the downlink
+	// frame-counter is approaching the 16-bit rollover.
+	ListFCntDownRolloverNodes(context.Context, *ListFCntDownRolloverNodesRequest) (*ListFCntDownRolloverNodesResponse, error)
+	// GetOversizedFrameOffenders returns the nodes and gateways with the
+	// most uplink frames exceeding the max. payload size of the data-rate
+	// (usually caused by a node or gateway firmware bug).
+	GetOversizedFrameOffenders(context.Context, *GetOversizedFrameOffendersRequest) (*GetOversizedFrameOffendersResponse, error)
+	// EnqueueDeviceQueueItem adds the given downlink payload to the
+	// device-queue of the node. The payload is sent in response to the next
+	// uplink of the node.
+	EnqueueDeviceQueueItem(context.Context, *EnqueueDeviceQueueItemRequest) (*EnqueueDeviceQueueItemResponse, error)
+	// GetDeviceQueueItems returns the downlink payloads in the device-queue
+	// of the node.
+	GetDeviceQueueItems(context.Context, *GetDeviceQueueItemsRequest) (*GetDeviceQueueItemsResponse, error)
+	// FlushDeviceQueue removes all the downlink payloads from the
+	// device-queue of the node.
+	FlushDeviceQueue(context.Context, *FlushDeviceQueueRequest) (*FlushDeviceQueueResponse, error)
+	// GetNextDownlinkFCnt returns the frame-counter with which the next
+	// payload enqueued in the device-queue will be transmitted, e.g. for
+	// encrypting the payload with the AppSKey. The frame-counter can be
+	// reserved to avoid races between concurrent callers.
+	GetNextDownlinkFCnt(context.Context, *GetNextDownlinkFCntRequest) (*GetNextDownlinkFCntResponse, error)
+	// CreateMulticastGroup creates the given multicast group.
+	CreateMulticastGroup(context.Context, *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast group for the given id.
+	GetMulticastGroup(context.Context, *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast group.
+	UpdateMulticastGroup(context.Context, *UpdateMulticastGroupRequest) (*UpdateMulticastGroupResponse, error)
+	// DeleteMulticastGroup deletes the multicast group for the given id
+	// (including its queue).
+	DeleteMulticastGroup(context.Context, *DeleteMulticastGroupRequest) (*DeleteMulticastGroupResponse, error)
+	// ListMulticastGroups returns the multicast groups.
+	ListMulticastGroups(context.Context, *ListMulticastGroupsRequest) (*ListMulticastGroupsResponse, error)
+	// EnqueueMulticastQueueItem adds the given payload to the queue of the
+	// multicast group. The payload is transmitted via the gateways of the
+	// group.
+	EnqueueMulticastQueueItem(context.Context, *EnqueueMulticastQueueItemRequest) (*EnqueueMulticastQueueItemResponse, error)
+	// FlushMulticastQueue removes all the payloads from the queue of the
+	// multicast group.
+	FlushMulticastQueue(context.Context, *FlushMulticastQueueRequest) (*FlushMulticastQueueResponse, error)
+	// StreamFrameLogsForDevice streams the uplink and downlink frames of the
+	// given node.
+	StreamFrameLogsForDevice(*StreamFrameLogsForDeviceRequest, NetworkServer_StreamFrameLogsForDeviceServer) error
+	// StreamFrameLogsForGateway streams the uplink and downlink frames of
+	// the given gateway.
+	StreamFrameLogsForGateway(*StreamFrameLogsForGatewayRequest, NetworkServer_StreamFrameLogsForGatewayServer) error
+	// GetFrameLogsForDevice returns the last logged uplink and downlink
+	// frames of the given node.
+	GetFrameLogsForDevice(context.Context, *GetFrameLogsForDeviceRequest) (*GetFrameLogsResponse, error)
+	// GetFrameLogsForGateway returns the last logged uplink and downlink
+	// frames of the given gateway.
+	GetFrameLogsForGateway(context.Context, *GetFrameLogsForGatewayRequest) (*GetFrameLogsResponse, error)
+	// CreateDownlinkTemplate creates the given downlink template.
+	CreateDownlinkTemplate(context.Context, *CreateDownlinkTemplateRequest) (*CreateDownlinkTemplateResponse, error)
+	// GetDownlinkTemplate returns the downlink template for the given id.
+	GetDownlinkTemplate(context.Context, *GetDownlinkTemplateRequest) (*GetDownlinkTemplateResponse, error)
+	// UpdateDownlinkTemplate updates the given downlink template.
+	UpdateDownlinkTemplate(context.Context, *UpdateDownlinkTemplateRequest) (*UpdateDownlinkTemplateResponse, error)
+	// DeleteDownlinkTemplate deletes the downlink template for the given id.
+	DeleteDownlinkTemplate(context.Context, *DeleteDownlinkTemplateRequest) (*DeleteDownlinkTemplateResponse, error)
+	// ListDownlinkTemplates returns the downlink templates.
+	ListDownlinkTemplates(context.Context, *ListDownlinkTemplatesRequest) (*ListDownlinkTemplatesResponse, error)
+	// TriggerDownlinkTemplate renders the downlink template for the given
+	// node, or for all the nodes having the given tags, and adds the
+	// payload to the device-queue of these nodes.
+	TriggerDownlinkTemplate(context.Context, *TriggerDownlinkTemplateRequest) (*TriggerDownlinkTemplateResponse, error)
+	// CreateChannelConfiguration creates the given channel-configuration.
+	CreateChannelConfiguration(context.Context, *CreateChannelConfigurationRequest) (*CreateChannelConfigurationResponse, error)
+	// GetChannelConfiguration returns the channel-configuration for the
+	// given id.
+	GetChannelConfiguration(context.Context, *GetChannelConfigurationRequest) (*GetChannelConfigurationResponse, error)
+	// UpdateChannelConfiguration updates the given channel-configuration.
+	// The nodes using it are reconciled on their next uplink.
+	UpdateChannelConfiguration(context.Context, *UpdateChannelConfigurationRequest) (*UpdateChannelConfigurationResponse, error)
+	// DeleteChannelConfiguration deletes the channel-configuration for the
+	// given id.
+	DeleteChannelConfiguration(context.Context, *DeleteChannelConfigurationRequest) (*DeleteChannelConfigurationResponse, error)
+	// ListChannelConfigurations returns the channel-configurations.
+	ListChannelConfigurations(context.Context, *ListChannelConfigurationsRequest) (*ListChannelConfigurationsResponse, error)
+	// EnqueueBenchDownlink enqueues a downlink for an unprovisioned test
+	// device (lab / bench testing), using the given DevAddr and session keys.
+	// It is sent in response to the next uplink of the DevAddr for which no
+	// node-session exists. Only available when bench mode is enabled.
+	EnqueueBenchDownlink(context.Context, *EnqueueBenchDownlinkRequest) (*EnqueueBenchDownlinkResponse, error)
+	// CreateGatewayOnboardingToken creates a one-time token with which a
+	// gateway, which does not exist yet, registers itself on its first
+	// stats message.
+	CreateGatewayOnboardingToken(context.Context, *CreateGatewayOnboardingTokenRequest) (*CreateGatewayOnboardingTokenResponse, error)
+	// ListGatewayOnboardingTokens returns the gateway onboarding tokens.
+	ListGatewayOnboardingTokens(context.Context, *ListGatewayOnboardingTokensRequest) (*ListGatewayOnboardingTokensResponse, error)
+	// DeleteGatewayOnboardingToken deletes (revokes) the given gateway
+	// onboarding token.
+	DeleteGatewayOnboardingToken(context.Context, *DeleteGatewayOnboardingTokenRequest) (*DeleteGatewayOnboardingTokenResponse, error)
+	// SendGatewayTestFrame transmits a proprietary test frame via the given
+	// gateway and returns the TX acknowledgement of the gateway, e.g. for
+	// verifying the downlink path of a newly installed gateway.
+	SendGatewayTestFrame(context.Context, *SendGatewayTestFrameRequest) (*SendGatewayTestFrameResponse, error)
+	// ExportFrameLogsForDevice exports the last logged uplink and downlink
+	// frames of the given node in the pcap format (LoRaTap link-type).
+	ExportFrameLogsForDevice(context.Context, *ExportFrameLogsForDeviceRequest) (*ExportFrameLogsResponse, error)
+	// ExportFrameLogsForGateway exports the last logged uplink and downlink
+	// frames of the given gateway in the pcap format (LoRaTap link-type).
+	ExportFrameLogsForGateway(context.Context, *ExportFrameLogsForGatewayRequest) (*ExportFrameLogsResponse, error)
+	// RotateGatewaySigningKey generates a new signing key for the given
+	// gateway. Once set, only uplink packets carrying a valid signature are
+	// accepted from the gateway.
+	RotateGatewaySigningKey(context.Context, *RotateGatewaySigningKeyRequest) (*RotateGatewaySigningKeyResponse, error)
+	// DeleteGatewaySigningKey deletes the signing key of the given gateway.
+	DeleteGatewaySigningKey(context.Context, *DeleteGatewaySigningKeyRequest) (*DeleteGatewaySigningKeyResponse, error)
+	// SetDeviceChannelMask restricts the enabled uplink channels of the given
+	// node to the given channels (e.g. to keep a noisy channel off for this
+	// node). The mask is applied to the channel mask of the LinkADRReq
+	// mac-commands sent to the node.
+	SetDeviceChannelMask(context.Context, *SetDeviceChannelMaskRequest) (*SetDeviceChannelMaskResponse, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile for the given id.
+	GetDeviceProfile(context.Context, *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(context.Context, *UpdateDeviceProfileRequest) (*UpdateDeviceProfileResponse, error)
+	// DeleteDeviceProfile deletes the device-profile for the given id.
+	DeleteDeviceProfile(context.Context, *DeleteDeviceProfileRequest) (*DeleteDeviceProfileResponse, error)
+	// ListDeviceProfiles returns the device-profiles.
+	ListDeviceProfiles(context.Context, *ListDeviceProfilesRequest) (*ListDeviceProfilesResponse, error)
+	// GetUnknownDevAddrStats returns the number of uplinks of DevAddrs without
+	// node-session per hour, gateway, frequency, data-rate and NwkID (passive
+	// monitoring, requires --passive-monitoring).
+	GetUnknownDevAddrStats(context.Context, *GetUnknownDevAddrStatsRequest) (*GetUnknownDevAddrStatsResponse, error)
+	// BulkCreateOrUpdateGateways creates the given gateways or updates them
+	// when they already exist (e.g. when onboarding gateways from an asset
+	// database). The result is returned per gateway.
+	BulkCreateOrUpdateGateways(context.Context, *BulkCreateOrUpdateGatewaysRequest) (*BulkCreateOrUpdateGatewaysResponse, error)
+	// CheckSessions checks the node-session storage for orphaned DevAddr
+	// pointers, node-sessions with an impossible MAC state and mismatched
+	// mac-command queues and optionally repairs these (see the check-sessions
+	// command).
+	CheckSessions(context.Context, *CheckSessionsRequest) (*CheckSessionsResponse, error)
+}
+
+func RegisterNetworkServerServer(s *grpc.Server, srv NetworkServerServer) {
+	s.RegisterService(&_NetworkServer_serviceDesc, srv)
+}
+
+func _NetworkServer_CreateNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNodeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateNodeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateNodeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateNodeSession(ctx, req.(*CreateNodeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetNodeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetNodeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetNodeSession(ctx, req.(*GetNodeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateNodeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateNodeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateNodeSession(ctx, req.(*UpdateNodeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_PatchNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchNodeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).PatchNodeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/PatchNodeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).PatchNodeSession(ctx, req.(*PatchNodeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_DeleteNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNodeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteNodeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteNodeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteNodeSession(ctx, req.(*DeleteNodeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRandomDevAddrRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetRandomDevAddr",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_EnqueueDataDownMACCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDataDownMACCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).EnqueueDataDownMACCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/EnqueueDataDownMACCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).EnqueueDataDownMACCommand(ctx, req.(*EnqueueDataDownMACCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_PushDataDown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushDataDownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).PushDataDown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/PushDataDown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).PushDataDown(ctx, req.(*PushDataDownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetGateway(ctx, req.(*GetGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListGateways(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListGateways",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListGateways(ctx, req.(*ListGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetGatewayStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetGatewayStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_StreamUplinkMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamUplinkMetadataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServer).StreamUplinkMetadata(m, &networkServerStreamUplinkMetadataServer{stream})
+}
+
+type NetworkServer_StreamUplinkMetadataServer interface {
+	Send(*StreamUplinkMetadataResponse) error
+	grpc.ServerStream
+}
+
+type networkServerStreamUplinkMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerStreamUplinkMetadataServer) Send(m *StreamUplinkMetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServer_GetDownlinkCapacityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownlinkCapacityReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDownlinkCapacityReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDownlinkCapacityReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDownlinkCapacityReport(ctx, req.(*GetDownlinkCapacityReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetUplinkChannelStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUplinkChannelStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetUplinkChannelStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetUplinkChannelStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetUplinkChannelStats(ctx, req.(*GetUplinkChannelStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetJoinStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJoinStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetJoinStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetJoinStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetJoinStats(ctx, req.(*GetJoinStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_BroadcastDataDown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastDataDownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).BroadcastDataDown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/BroadcastDataDown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).BroadcastDataDown(ctx, req.(*BroadcastDataDownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDownlinkDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownlinkDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDownlinkDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDownlinkDecisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDownlinkDecisions(ctx, req.(*GetDownlinkDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetMACCommandHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMACCommandHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetMACCommandHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetMACCommandHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetMACCommandHistory(ctx, req.(*GetMACCommandHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDownlinkFrames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownlinkFramesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDownlinkFrames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDownlinkFrames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDownlinkFrames(ctx, req.(*GetDownlinkFramesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ImportNodeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportNodeSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ImportNodeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ImportNodeSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ImportNodeSessions(ctx, req.(*ImportNodeSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ExportNodeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportNodeSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ExportNodeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ExportNodeSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ExportNodeSessions(ctx, req.(*ExportNodeSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceActivationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceActivation(ctx, req.(*GetDeviceActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ChangeDeviceClass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeviceClassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ChangeDeviceClass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ChangeDeviceClass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ChangeDeviceClass(ctx, req.(*ChangeDeviceClassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListGatewayDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListGatewayDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListGatewayDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListGatewayDevices(ctx, req.(*ListGatewayDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetGatewayAntennaStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayAntennaStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetGatewayAntennaStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetGatewayAntennaStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetGatewayAntennaStats(ctx, req.(*GetGatewayAntennaStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetADRParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetADRParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetADRParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetADRParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetADRParameters(ctx, req.(*GetADRParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateADRParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateADRParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateADRParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateADRParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateADRParameters(ctx, req.(*UpdateADRParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetADRDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetADRDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetADRDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetADRDecisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetADRDecisions(ctx, req.(*GetADRDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_AuditRedisKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditRedisKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).AuditRedisKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/AuditRedisKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).AuditRedisKeys(ctx, req.(*AuditRedisKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ReleaseQuarantine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseQuarantineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ReleaseQuarantine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ReleaseQuarantine",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ReleaseQuarantine(ctx, req.(*ReleaseQuarantineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceDownlinkAirtime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceDownlinkAirtimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceDownlinkAirtime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceDownlinkAirtime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceDownlinkAirtime(ctx, req.(*GetDeviceDownlinkAirtimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_CreateUplinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUplinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateUplinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateUplinkRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateUplinkRule(ctx, req.(*CreateUplinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetUplinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUplinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetUplinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetUplinkRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetUplinkRule(ctx, req.(*GetUplinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateUplinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUplinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateUplinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateUplinkRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateUplinkRule(ctx, req.(*UpdateUplinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_DeleteUplinkRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUplinkRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteUplinkRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteUplinkRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteUplinkRule(ctx, req.(*DeleteUplinkRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListUplinkRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUplinkRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListUplinkRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListUplinkRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListUplinkRules(ctx, req.(*ListUplinkRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceUplinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceUplinkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceUplinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceUplinkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceUplinkStats(ctx, req.(*GetDeviceUplinkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetTopTalkers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopTalkersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetTopTalkers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetTopTalkers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetTopTalkers(ctx, req.(*GetTopTalkersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetLowLinkMarginNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLowLinkMarginNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetLowLinkMarginNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetLowLinkMarginNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetLowLinkMarginNodes(ctx, req.(*GetLowLinkMarginNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceClockDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceClockDriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceClockDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceClockDrift",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceClockDrift(ctx, req.(*GetDeviceClockDriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetClockDriftNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClockDriftNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetClockDriftNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetClockDriftNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetClockDriftNodes(ctx, req.(*GetClockDriftNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_RotateGatewayCUPSCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateGatewayCUPSCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).RotateGatewayCUPSCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/RotateGatewayCUPSCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).RotateGatewayCUPSCredentials(ctx, req.(*RotateGatewayCUPSCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetGatewayCUPSCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayCUPSCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetGatewayCUPSCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetGatewayCUPSCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetGatewayCUPSCredentials(ctx, req.(*GetGatewayCUPSCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GenerateGatewayClientCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateGatewayClientCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GenerateGatewayClientCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GenerateGatewayClientCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GenerateGatewayClientCertificate(ctx, req.(*GenerateGatewayClientCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListRX2MismatchNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRX2MismatchNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListRX2MismatchNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListRX2MismatchNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListRX2MismatchNodes(ctx, req.(*ListRX2MismatchNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ClearRX2Mismatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearRX2MismatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ClearRX2Mismatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ClearRX2Mismatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ClearRX2Mismatch(ctx, req.(*ClearRX2MismatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListFCntDownRolloverNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFCntDownRolloverNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListFCntDownRolloverNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListFCntDownRolloverNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListFCntDownRolloverNodes(ctx, req.(*ListFCntDownRolloverNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetOversizedFrameOffenders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOversizedFrameOffendersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetOversizedFrameOffenders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetOversizedFrameOffenders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetOversizedFrameOffenders(ctx, req.(*GetOversizedFrameOffendersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_EnqueueDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).EnqueueDeviceQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/EnqueueDeviceQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).EnqueueDeviceQueueItem(ctx, req.(*EnqueueDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceQueueItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceQueueItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceQueueItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceQueueItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceQueueItems(ctx, req.(*GetDeviceQueueItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_FlushDeviceQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushDeviceQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).FlushDeviceQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/FlushDeviceQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).FlushDeviceQueue(ctx, req.(*FlushDeviceQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetNextDownlinkFCnt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNextDownlinkFCntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetNextDownlinkFCnt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetNextDownlinkFCnt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetNextDownlinkFCnt(ctx, req.(*GetNextDownlinkFCntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_CreateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateMulticastGroup(ctx, req.(*CreateMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetMulticastGroup(ctx, req.(*GetMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateMulticastGroup(ctx, req.(*UpdateMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMulticastGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteMulticastGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteMulticastGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteMulticastGroup(ctx, req.(*DeleteMulticastGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListMulticastGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMulticastGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListMulticastGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListMulticastGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListMulticastGroups(ctx, req.(*ListMulticastGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_EnqueueMulticastQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).EnqueueMulticastQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/EnqueueMulticastQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).EnqueueMulticastQueueItem(ctx, req.(*EnqueueMulticastQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_FlushMulticastQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushMulticastQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).FlushMulticastQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/FlushMulticastQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).FlushMulticastQueue(ctx, req.(*FlushMulticastQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_StreamFrameLogsForDevice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFrameLogsForDeviceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServer).StreamFrameLogsForDevice(m, &networkServerStreamFrameLogsForDeviceServer{stream})
+}
+
+type NetworkServer_StreamFrameLogsForDeviceServer interface {
+	Send(*FrameLog) error
+	grpc.ServerStream
+}
+
+type networkServerStreamFrameLogsForDeviceServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServerStreamFrameLogsForDeviceServer) Send(m *FrameLog) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServer_StreamFrameLogsForGateway_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFrameLogsForGatewayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServerServer).StreamFrameLogsForGateway(m, &networkServerStreamFrameLogsForGatewayServer{stream})
 }
 
-type networkServerClient struct {
-	cc *grpc.ClientConn
+type NetworkServer_StreamFrameLogsForGatewayServer interface {
+	Send(*FrameLog) error
+	grpc.ServerStream
 }
 
-func NewNetworkServerClient(cc *grpc.ClientConn) NetworkServerClient {
-	return &networkServerClient{cc}
+type networkServerStreamFrameLogsForGatewayServer struct {
+	grpc.ServerStream
 }
 
-func (c *networkServerClient) CreateNodeSession(ctx context.Context, in *CreateNodeSessionRequest, opts ...grpc.CallOption) (*CreateNodeSessionResponse, error) {
-	out := new(CreateNodeSessionResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateNodeSession", in, out, c.cc, opts...)
-	if err != nil {
+func (x *networkServerStreamFrameLogsForGatewayServer) Send(m *FrameLog) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NetworkServer_GetFrameLogsForDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrameLogsForDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetFrameLogsForDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetFrameLogsForDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetFrameLogsForDevice(ctx, req.(*GetFrameLogsForDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetNodeSession(ctx context.Context, in *GetNodeSessionRequest, opts ...grpc.CallOption) (*GetNodeSessionResponse, error) {
-	out := new(GetNodeSessionResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetNodeSession", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetFrameLogsForGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrameLogsForGatewayRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetFrameLogsForGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetFrameLogsForGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetFrameLogsForGateway(ctx, req.(*GetFrameLogsForGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) UpdateNodeSession(ctx context.Context, in *UpdateNodeSessionRequest, opts ...grpc.CallOption) (*UpdateNodeSessionResponse, error) {
-	out := new(UpdateNodeSessionResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateNodeSession", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_CreateDownlinkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateDownlinkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateDownlinkTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateDownlinkTemplate(ctx, req.(*CreateDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) DeleteNodeSession(ctx context.Context, in *DeleteNodeSessionRequest, opts ...grpc.CallOption) (*DeleteNodeSessionResponse, error) {
-	out := new(DeleteNodeSessionResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteNodeSession", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetDownlinkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDownlinkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDownlinkTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDownlinkTemplate(ctx, req.(*GetDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
-	out := new(GetRandomDevAddrResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRandomDevAddr", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_UpdateDownlinkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateDownlinkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateDownlinkTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateDownlinkTemplate(ctx, req.(*UpdateDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) EnqueueDataDownMACCommand(ctx context.Context, in *EnqueueDataDownMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDataDownMACCommandResponse, error) {
-	out := new(EnqueueDataDownMACCommandResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueDataDownMACCommand", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteDownlinkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteDownlinkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteDownlinkTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteDownlinkTemplate(ctx, req.(*DeleteDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) PushDataDown(ctx context.Context, in *PushDataDownRequest, opts ...grpc.CallOption) (*PushDataDownResponse, error) {
-	out := new(PushDataDownResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/PushDataDown", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_ListDownlinkTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDownlinkTemplatesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListDownlinkTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListDownlinkTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListDownlinkTemplates(ctx, req.(*ListDownlinkTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error) {
-	out := new(CreateGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_TriggerDownlinkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerDownlinkTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).TriggerDownlinkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/TriggerDownlinkTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).TriggerDownlinkTemplate(ctx, req.(*TriggerDownlinkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
-	out := new(GetGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_CreateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChannelConfigurationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateChannelConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, req.(*CreateChannelConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error) {
-	out := new(UpdateGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelConfigurationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetChannelConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, req.(*GetChannelConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error) {
-	out := new(ListGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGateways", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_UpdateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChannelConfigurationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateChannelConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, req.(*UpdateChannelConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error) {
-	out := new(DeleteGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChannelConfigurationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteChannelConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, req.(*DeleteChannelConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
-	out := new(GetGatewayStatsResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayStats", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_ListChannelConfigurations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelConfigurationsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListChannelConfigurations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, req.(*ListChannelConfigurationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Server API for NetworkServer service
+func _NetworkServer_EnqueueBenchDownlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueBenchDownlinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).EnqueueBenchDownlink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/EnqueueBenchDownlink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).EnqueueBenchDownlink(ctx, req.(*EnqueueBenchDownlinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-type NetworkServerServer interface {
-	// CreateNodeSession creates the given node-session.
-	// The DevAddr must contain the same NwkID as the configured NetID.
-	// Node-sessions will expire automatically after the configured TTL.
-	CreateNodeSession(context.Context, *CreateNodeSessionRequest) (*CreateNodeSessionResponse, error)
-	// GetNodeSession returns the node-session matching the given DevEUI.
-	GetNodeSession(context.Context, *GetNodeSessionRequest) (*GetNodeSessionResponse, error)
-	// UpdateNodeSession updates the given node-session.
-	UpdateNodeSession(context.Context, *UpdateNodeSessionRequest) (*UpdateNodeSessionResponse, error)
-	// DeleteNodeSession deletes the node-session matching the given DevAddr.
-	DeleteNodeSession(context.Context, *DeleteNodeSessionRequest) (*DeleteNodeSessionResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
-	// EnqueueDataDownMACCommand adds the downlink mac-command to the queue.
-	EnqueueDataDownMACCommand(context.Context, *EnqueueDataDownMACCommandRequest) (*EnqueueDataDownMACCommandResponse, error)
-	// PushDataDown pushes the given downlink payload to the node (only works for Class-C nodes).
-	PushDataDown(context.Context, *PushDataDownRequest) (*PushDataDownResponse, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(context.Context, *CreateGatewayRequest) (*CreateGatewayResponse, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(context.Context, *UpdateGatewayRequest) (*UpdateGatewayResponse, error)
-	// ListGateways returns the existing gateways.
-	ListGateways(context.Context, *ListGatewayRequest) (*ListGatewayResponse, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(context.Context, *DeleteGatewayRequest) (*DeleteGatewayResponse, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+func _NetworkServer_CreateGatewayOnboardingToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGatewayOnboardingTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateGatewayOnboardingToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateGatewayOnboardingToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateGatewayOnboardingToken(ctx, req.(*CreateGatewayOnboardingTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_ListGatewayOnboardingTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayOnboardingTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ListGatewayOnboardingTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ListGatewayOnboardingTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ListGatewayOnboardingTokens(ctx, req.(*ListGatewayOnboardingTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_DeleteGatewayOnboardingToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewayOnboardingTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteGatewayOnboardingToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteGatewayOnboardingToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteGatewayOnboardingToken(ctx, req.(*DeleteGatewayOnboardingTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterNetworkServerServer(s *grpc.Server, srv NetworkServerServer) {
-	s.RegisterService(&_NetworkServer_serviceDesc, srv)
+func _NetworkServer_SendGatewayTestFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendGatewayTestFrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).SendGatewayTestFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/SendGatewayTestFrame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).SendGatewayTestFrame(ctx, req.(*SendGatewayTestFrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateNodeSessionRequest)
+func _NetworkServer_ExportFrameLogsForDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportFrameLogsForDeviceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateNodeSession(ctx, in)
+		return srv.(NetworkServerServer).ExportFrameLogsForDevice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateNodeSession",
+		FullMethod: "/ns.NetworkServer/ExportFrameLogsForDevice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateNodeSession(ctx, req.(*CreateNodeSessionRequest))
+		return srv.(NetworkServerServer).ExportFrameLogsForDevice(ctx, req.(*ExportFrameLogsForDeviceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeSessionRequest)
+func _NetworkServer_ExportFrameLogsForGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportFrameLogsForGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetNodeSession(ctx, in)
+		return srv.(NetworkServerServer).ExportFrameLogsForGateway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetNodeSession",
+		FullMethod: "/ns.NetworkServer/ExportFrameLogsForGateway",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetNodeSession(ctx, req.(*GetNodeSessionRequest))
+		return srv.(NetworkServerServer).ExportFrameLogsForGateway(ctx, req.(*ExportFrameLogsForGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateNodeSessionRequest)
+func _NetworkServer_RotateGatewaySigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateGatewaySigningKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateNodeSession(ctx, in)
+		return srv.(NetworkServerServer).RotateGatewaySigningKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateNodeSession",
+		FullMethod: "/ns.NetworkServer/RotateGatewaySigningKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateNodeSession(ctx, req.(*UpdateNodeSessionRequest))
+		return srv.(NetworkServerServer).RotateGatewaySigningKey(ctx, req.(*RotateGatewaySigningKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteNodeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteNodeSessionRequest)
+func _NetworkServer_DeleteGatewaySigningKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewaySigningKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteNodeSession(ctx, in)
+		return srv.(NetworkServerServer).DeleteGatewaySigningKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteNodeSession",
+		FullMethod: "/ns.NetworkServer/DeleteGatewaySigningKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteNodeSession(ctx, req.(*DeleteNodeSessionRequest))
+		return srv.(NetworkServerServer).DeleteGatewaySigningKey(ctx, req.(*DeleteGatewaySigningKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRandomDevAddrRequest)
+func _NetworkServer_SetDeviceChannelMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceChannelMaskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, in)
+		return srv.(NetworkServerServer).SetDeviceChannelMask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetRandomDevAddr",
+		FullMethod: "/ns.NetworkServer/SetDeviceChannelMask",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
+		return srv.(NetworkServerServer).SetDeviceChannelMask(ctx, req.(*SetDeviceChannelMaskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_EnqueueDataDownMACCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnqueueDataDownMACCommandRequest)
+func _NetworkServer_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).EnqueueDataDownMACCommand(ctx, in)
+		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/EnqueueDataDownMACCommand",
+		FullMethod: "/ns.NetworkServer/CreateDeviceProfile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).EnqueueDataDownMACCommand(ctx, req.(*EnqueueDataDownMACCommandRequest))
+		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, req.(*CreateDeviceProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_PushDataDown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PushDataDownRequest)
+func _NetworkServer_GetDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).PushDataDown(ctx, in)
+		return srv.(NetworkServerServer).GetDeviceProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/PushDataDown",
+		FullMethod: "/ns.NetworkServer/GetDeviceProfile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).PushDataDown(ctx, req.(*PushDataDownRequest))
+		return srv.(NetworkServerServer).GetDeviceProfile(ctx, req.(*GetDeviceProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGatewayRequest)
+func _NetworkServer_UpdateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateGateway(ctx, in)
+		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateGateway",
+		FullMethod: "/ns.NetworkServer/UpdateDeviceProfile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
+		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, req.(*UpdateDeviceProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayRequest)
+func _NetworkServer_DeleteDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetGateway(ctx, in)
+		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetGateway",
+		FullMethod: "/ns.NetworkServer/DeleteDeviceProfile",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetGateway(ctx, req.(*GetGatewayRequest))
+		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, req.(*DeleteDeviceProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGatewayRequest)
+func _NetworkServer_ListDeviceProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceProfilesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateGateway(ctx, in)
+		return srv.(NetworkServerServer).ListDeviceProfiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateGateway",
+		FullMethod: "/ns.NetworkServer/ListDeviceProfiles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
+		return srv.(NetworkServerServer).ListDeviceProfiles(ctx, req.(*ListDeviceProfilesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListGatewayRequest)
+func _NetworkServer_GetUnknownDevAddrStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnknownDevAddrStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).ListGateways(ctx, in)
+		return srv.(NetworkServerServer).GetUnknownDevAddrStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/ListGateways",
+		FullMethod: "/ns.NetworkServer/GetUnknownDevAddrStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).ListGateways(ctx, req.(*ListGatewayRequest))
+		return srv.(NetworkServerServer).GetUnknownDevAddrStats(ctx, req.(*GetUnknownDevAddrStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGatewayRequest)
+func _NetworkServer_BulkCreateOrUpdateGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateOrUpdateGatewaysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteGateway(ctx, in)
+		return srv.(NetworkServerServer).BulkCreateOrUpdateGateways(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteGateway",
+		FullMethod: "/ns.NetworkServer/BulkCreateOrUpdateGateways",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
+		return srv.(NetworkServerServer).BulkCreateOrUpdateGateways(ctx, req.(*BulkCreateOrUpdateGatewaysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayStatsRequest)
+func _NetworkServer_CheckSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetGatewayStats(ctx, in)
+		return srv.(NetworkServerServer).CheckSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetGatewayStats",
+		FullMethod: "/ns.NetworkServer/CheckSessions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
+		return srv.(NetworkServerServer).CheckSessions(ctx, req.(*CheckSessionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1667,6 +13687,10 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateNodeSession",
 			Handler:    _NetworkServer_UpdateNodeSession_Handler,
 		},
+		{
+			MethodName: "PatchNodeSession",
+			Handler:    _NetworkServer_PatchNodeSession_Handler,
+		},
 		{
 			MethodName: "DeleteNodeSession",
 			Handler:    _NetworkServer_DeleteNodeSession_Handler,
@@ -1707,94 +13731,991 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetGatewayStats",
 			Handler:    _NetworkServer_GetGatewayStats_Handler,
 		},
+		{
+			MethodName: "GetDownlinkCapacityReport",
+			Handler:    _NetworkServer_GetDownlinkCapacityReport_Handler,
+		},
+		{
+			MethodName: "GetUplinkChannelStats",
+			Handler:    _NetworkServer_GetUplinkChannelStats_Handler,
+		},
+		{
+			MethodName: "GetJoinStats",
+			Handler:    _NetworkServer_GetJoinStats_Handler,
+		},
+		{
+			MethodName: "BroadcastDataDown",
+			Handler:    _NetworkServer_BroadcastDataDown_Handler,
+		},
+		{
+			MethodName: "GetDownlinkDecisions",
+			Handler:    _NetworkServer_GetDownlinkDecisions_Handler,
+		},
+		{
+			MethodName: "GetMACCommandHistory",
+			Handler:    _NetworkServer_GetMACCommandHistory_Handler,
+		},
+		{
+			MethodName: "GetDownlinkFrames",
+			Handler:    _NetworkServer_GetDownlinkFrames_Handler,
+		},
+		{
+			MethodName: "ImportNodeSessions",
+			Handler:    _NetworkServer_ImportNodeSessions_Handler,
+		},
+		{
+			MethodName: "ExportNodeSessions",
+			Handler:    _NetworkServer_ExportNodeSessions_Handler,
+		},
+		{
+			MethodName: "GetDeviceActivation",
+			Handler:    _NetworkServer_GetDeviceActivation_Handler,
+		},
+		{
+			MethodName: "ChangeDeviceClass",
+			Handler:    _NetworkServer_ChangeDeviceClass_Handler,
+		},
+		{
+			MethodName: "ListGatewayDevices",
+			Handler:    _NetworkServer_ListGatewayDevices_Handler,
+		},
+		{
+			MethodName: "GetGatewayAntennaStats",
+			Handler:    _NetworkServer_GetGatewayAntennaStats_Handler,
+		},
+		{
+			MethodName: "GetADRParameters",
+			Handler:    _NetworkServer_GetADRParameters_Handler,
+		},
+		{
+			MethodName: "UpdateADRParameters",
+			Handler:    _NetworkServer_UpdateADRParameters_Handler,
+		},
+		{
+			MethodName: "GetADRDecisions",
+			Handler:    _NetworkServer_GetADRDecisions_Handler,
+		},
+		{
+			MethodName: "AuditRedisKeys",
+			Handler:    _NetworkServer_AuditRedisKeys_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _NetworkServer_GetInfo_Handler,
+		},
+		{
+			MethodName: "ReleaseQuarantine",
+			Handler:    _NetworkServer_ReleaseQuarantine_Handler,
+		},
+		{
+			MethodName: "GetDeviceDownlinkAirtime",
+			Handler:    _NetworkServer_GetDeviceDownlinkAirtime_Handler,
+		},
+		{
+			MethodName: "CreateUplinkRule",
+			Handler:    _NetworkServer_CreateUplinkRule_Handler,
+		},
+		{
+			MethodName: "GetUplinkRule",
+			Handler:    _NetworkServer_GetUplinkRule_Handler,
+		},
+		{
+			MethodName: "UpdateUplinkRule",
+			Handler:    _NetworkServer_UpdateUplinkRule_Handler,
+		},
+		{
+			MethodName: "DeleteUplinkRule",
+			Handler:    _NetworkServer_DeleteUplinkRule_Handler,
+		},
+		{
+			MethodName: "ListUplinkRules",
+			Handler:    _NetworkServer_ListUplinkRules_Handler,
+		},
+		{
+			MethodName: "GetDeviceUplinkStats",
+			Handler:    _NetworkServer_GetDeviceUplinkStats_Handler,
+		},
+		{
+			MethodName: "GetTopTalkers",
+			Handler:    _NetworkServer_GetTopTalkers_Handler,
+		},
+		{
+			MethodName: "GetLowLinkMarginNodes",
+			Handler:    _NetworkServer_GetLowLinkMarginNodes_Handler,
+		},
+		{
+			MethodName: "GetDeviceClockDrift",
+			Handler:    _NetworkServer_GetDeviceClockDrift_Handler,
+		},
+		{
+			MethodName: "GetClockDriftNodes",
+			Handler:    _NetworkServer_GetClockDriftNodes_Handler,
+		},
+		{
+			MethodName: "RotateGatewayCUPSCredentials",
+			Handler:    _NetworkServer_RotateGatewayCUPSCredentials_Handler,
+		},
+		{
+			MethodName: "GetGatewayCUPSCredentials",
+			Handler:    _NetworkServer_GetGatewayCUPSCredentials_Handler,
+		},
+		{
+			MethodName: "GenerateGatewayClientCertificate",
+			Handler:    _NetworkServer_GenerateGatewayClientCertificate_Handler,
+		},
+		{
+			MethodName: "ListRX2MismatchNodes",
+			Handler:    _NetworkServer_ListRX2MismatchNodes_Handler,
+		},
+		{
+			MethodName: "ClearRX2Mismatch",
+			Handler:    _NetworkServer_ClearRX2Mismatch_Handler,
+		},
+		{
+			MethodName: "ListFCntDownRolloverNodes",
+			Handler:    _NetworkServer_ListFCntDownRolloverNodes_Handler,
+		},
+		{
+			MethodName: "GetOversizedFrameOffenders",
+			Handler:    _NetworkServer_GetOversizedFrameOffenders_Handler,
+		},
+		{
+			MethodName: "EnqueueDeviceQueueItem",
+			Handler:    _NetworkServer_EnqueueDeviceQueueItem_Handler,
+		},
+		{
+			MethodName: "GetDeviceQueueItems",
+			Handler:    _NetworkServer_GetDeviceQueueItems_Handler,
+		},
+		{
+			MethodName: "FlushDeviceQueue",
+			Handler:    _NetworkServer_FlushDeviceQueue_Handler,
+		},
+		{
+			MethodName: "GetNextDownlinkFCnt",
+			Handler:    _NetworkServer_GetNextDownlinkFCnt_Handler,
+		},
+		{
+			MethodName: "CreateMulticastGroup",
+			Handler:    _NetworkServer_CreateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroup",
+			Handler:    _NetworkServer_GetMulticastGroup_Handler,
+		},
+		{
+			MethodName: "UpdateMulticastGroup",
+			Handler:    _NetworkServer_UpdateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "DeleteMulticastGroup",
+			Handler:    _NetworkServer_DeleteMulticastGroup_Handler,
+		},
+		{
+			MethodName: "ListMulticastGroups",
+			Handler:    _NetworkServer_ListMulticastGroups_Handler,
+		},
+		{
+			MethodName: "EnqueueMulticastQueueItem",
+			Handler:    _NetworkServer_EnqueueMulticastQueueItem_Handler,
+		},
+		{
+			MethodName: "FlushMulticastQueue",
+			Handler:    _NetworkServer_FlushMulticastQueue_Handler,
+		},
+		{
+			MethodName: "GetFrameLogsForDevice",
+			Handler:    _NetworkServer_GetFrameLogsForDevice_Handler,
+		},
+		{
+			MethodName: "GetFrameLogsForGateway",
+			Handler:    _NetworkServer_GetFrameLogsForGateway_Handler,
+		},
+		{
+			MethodName: "CreateDownlinkTemplate",
+			Handler:    _NetworkServer_CreateDownlinkTemplate_Handler,
+		},
+		{
+			MethodName: "GetDownlinkTemplate",
+			Handler:    _NetworkServer_GetDownlinkTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateDownlinkTemplate",
+			Handler:    _NetworkServer_UpdateDownlinkTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteDownlinkTemplate",
+			Handler:    _NetworkServer_DeleteDownlinkTemplate_Handler,
+		},
+		{
+			MethodName: "ListDownlinkTemplates",
+			Handler:    _NetworkServer_ListDownlinkTemplates_Handler,
+		},
+		{
+			MethodName: "TriggerDownlinkTemplate",
+			Handler:    _NetworkServer_TriggerDownlinkTemplate_Handler,
+		},
+		{
+			MethodName: "CreateChannelConfiguration",
+			Handler:    _NetworkServer_CreateChannelConfiguration_Handler,
+		},
+		{
+			MethodName: "GetChannelConfiguration",
+			Handler:    _NetworkServer_GetChannelConfiguration_Handler,
+		},
+		{
+			MethodName: "UpdateChannelConfiguration",
+			Handler:    _NetworkServer_UpdateChannelConfiguration_Handler,
+		},
+		{
+			MethodName: "DeleteChannelConfiguration",
+			Handler:    _NetworkServer_DeleteChannelConfiguration_Handler,
+		},
+		{
+			MethodName: "ListChannelConfigurations",
+			Handler:    _NetworkServer_ListChannelConfigurations_Handler,
+		},
+		{
+			MethodName: "EnqueueBenchDownlink",
+			Handler:    _NetworkServer_EnqueueBenchDownlink_Handler,
+		},
+		{
+			MethodName: "CreateGatewayOnboardingToken",
+			Handler:    _NetworkServer_CreateGatewayOnboardingToken_Handler,
+		},
+		{
+			MethodName: "ListGatewayOnboardingTokens",
+			Handler:    _NetworkServer_ListGatewayOnboardingTokens_Handler,
+		},
+		{
+			MethodName: "DeleteGatewayOnboardingToken",
+			Handler:    _NetworkServer_DeleteGatewayOnboardingToken_Handler,
+		},
+		{
+			MethodName: "SendGatewayTestFrame",
+			Handler:    _NetworkServer_SendGatewayTestFrame_Handler,
+		},
+		{
+			MethodName: "ExportFrameLogsForDevice",
+			Handler:    _NetworkServer_ExportFrameLogsForDevice_Handler,
+		},
+		{
+			MethodName: "ExportFrameLogsForGateway",
+			Handler:    _NetworkServer_ExportFrameLogsForGateway_Handler,
+		},
+		{
+			MethodName: "RotateGatewaySigningKey",
+			Handler:    _NetworkServer_RotateGatewaySigningKey_Handler,
+		},
+		{
+			MethodName: "DeleteGatewaySigningKey",
+			Handler:    _NetworkServer_DeleteGatewaySigningKey_Handler,
+		},
+		{
+			MethodName: "SetDeviceChannelMask",
+			Handler:    _NetworkServer_SetDeviceChannelMask_Handler,
+		},
+		{
+			MethodName: "CreateDeviceProfile",
+			Handler:    _NetworkServer_CreateDeviceProfile_Handler,
+		},
+		{
+			MethodName: "GetDeviceProfile",
+			Handler:    _NetworkServer_GetDeviceProfile_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceProfile",
+			Handler:    _NetworkServer_UpdateDeviceProfile_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceProfile",
+			Handler:    _NetworkServer_DeleteDeviceProfile_Handler,
+		},
+		{
+			MethodName: "ListDeviceProfiles",
+			Handler:    _NetworkServer_ListDeviceProfiles_Handler,
+		},
+		{
+			MethodName: "GetUnknownDevAddrStats",
+			Handler:    _NetworkServer_GetUnknownDevAddrStats_Handler,
+		},
+		{
+			MethodName: "BulkCreateOrUpdateGateways",
+			Handler:    _NetworkServer_BulkCreateOrUpdateGateways_Handler,
+		},
+		{
+			MethodName: "CheckSessions",
+			Handler:    _NetworkServer_CheckSessions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamUplinkMetadata",
+			Handler:       _NetworkServer_StreamUplinkMetadata_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFrameLogsForDevice",
+			Handler:       _NetworkServer_StreamFrameLogsForDevice_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFrameLogsForGateway",
+			Handler:       _NetworkServer_StreamFrameLogsForGateway_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ns.proto",
 }
 
 func init() { proto.RegisterFile("ns.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1291 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4b, 0x73, 0xdb, 0x36,
-	0x10, 0x36, 0x25, 0x4b, 0xa6, 0xd6, 0xb2, 0x43, 0xc3, 0x2f, 0x5a, 0x76, 0x33, 0x2a, 0xdb, 0x74,
-	0x34, 0x99, 0x8e, 0xdb, 0x38, 0xbd, 0xf6, 0xa0, 0x4a, 0x8a, 0xe3, 0x49, 0xfc, 0x28, 0x6c, 0x4f,
-	0x92, 0x23, 0x62, 0x42, 0x2e, 0x6b, 0x0a, 0x54, 0x40, 0xc8, 0x92, 0x6f, 0xbd, 0xf6, 0xb7, 0xf4,
-	0xd2, 0x43, 0xa7, 0x3f, 0xa9, 0xd3, 0x9f, 0xd1, 0xc1, 0x43, 0x14, 0x25, 0x52, 0xd5, 0x35, 0x99,
-	0xc9, 0x0d, 0xbb, 0x0b, 0x7c, 0xd8, 0x25, 0xbe, 0x7d, 0x48, 0x60, 0xb3, 0xf8, 0xb0, 0xcf, 0x23,
-	0x11, 0xa1, 0x02, 0x8b, 0xbd, 0xbf, 0x8b, 0xe0, 0xb6, 0x38, 0x25, 0x82, 0x9e, 0x45, 0x3e, 0xbd,
-	0xa4, 0x71, 0x1c, 0x44, 0x0c, 0xd3, 0x0f, 0x03, 0x1a, 0x0b, 0xe4, 0xc2, 0x8a, 0x4f, 0xef, 0x9b,
-	0xbe, 0xcf, 0x5d, 0xab, 0x6e, 0x35, 0xaa, 0x78, 0x2c, 0xa2, 0x1d, 0x28, 0x93, 0x7e, 0xbf, 0x73,
-	0x7d, 0xe2, 0x16, 0x94, 0xc1, 0x48, 0x52, 0xef, 0xd3, 0x7b, 0xa9, 0x2f, 0x6a, 0xbd, 0x96, 0x24,
-	0x12, 0x1b, 0xde, 0x5d, 0xbe, 0xa2, 0x0f, 0xee, 0xb2, 0x46, 0x32, 0xa2, 0x3c, 0xd1, 0x6d, 0x31,
-	0x71, 0xdd, 0x77, 0x4b, 0x75, 0xab, 0xb1, 0x86, 0x8d, 0x84, 0x6a, 0x60, 0xcb, 0x55, 0x3b, 0x1a,
-	0x32, 0xb7, 0xac, 0x2c, 0x89, 0x2c, 0xd1, 0xf8, 0xa8, 0x4d, 0x43, 0xf2, 0xe0, 0xae, 0x28, 0xd3,
-	0x58, 0x44, 0x75, 0x58, 0xe5, 0xa3, 0x67, 0x6d, 0x7c, 0xde, 0xed, 0xc6, 0x54, 0xb8, 0xb6, 0xb2,
-	0xa6, 0x55, 0xf2, 0xbe, 0x9b, 0x17, 0xaf, 0x83, 0x58, 0xb8, 0x95, 0x7a, 0x51, 0xde, 0xa7, 0x25,
-	0xd4, 0x00, 0x9b, 0x8f, 0xde, 0x04, 0xcc, 0x8f, 0x86, 0x2e, 0xd4, 0xad, 0xc6, 0xfa, 0x51, 0xf5,
-	0x90, 0xc5, 0x87, 0xf8, 0xad, 0xd6, 0xe1, 0xc4, 0x8a, 0xb6, 0xa0, 0xc4, 0x47, 0x47, 0x6d, 0xec,
-	0xae, 0x2a, 0x74, 0x2d, 0xa0, 0x03, 0xa8, 0x70, 0x1a, 0x92, 0xd1, 0x8b, 0x16, 0x13, 0x6e, 0xb5,
-	0x6e, 0x35, 0x6c, 0x3c, 0x51, 0x48, 0xbf, 0x88, 0xcf, 0x4f, 0x98, 0xa0, 0xfc, 0x9e, 0x84, 0xee,
-	0x9a, 0xf6, 0x2b, 0xa5, 0x42, 0x87, 0x80, 0x02, 0x16, 0x0b, 0x12, 0x86, 0x44, 0x04, 0x11, 0x3b,
-	0x25, 0xfc, 0x36, 0x60, 0xee, 0x7a, 0xdd, 0x6a, 0x58, 0x38, 0xc7, 0xe2, 0xed, 0xc3, 0x5e, 0xce,
-	0xbb, 0xc5, 0xfd, 0x88, 0xc5, 0xd4, 0xfb, 0x0e, 0xb6, 0x8f, 0xa9, 0xc8, 0x79, 0xd1, 0xc9, 0xfb,
-	0x58, 0xe9, 0xf7, 0xf1, 0xfe, 0x2d, 0xc2, 0xce, 0xec, 0x09, 0x8d, 0xf5, 0x99, 0x04, 0x1f, 0x2f,
-	0x09, 0xd4, 0x17, 0x7d, 0x7f, 0xc5, 0x09, 0x8b, 0xdd, 0x47, 0xfa, 0x1b, 0x18, 0x51, 0x5a, 0xc4,
-	0xe8, 0x22, 0x1a, 0x52, 0xee, 0x3a, 0xda, 0x62, 0x44, 0x95, 0xf1, 0xd7, 0x7d, 0xff, 0x73, 0xc6,
-	0x7f, 0x82, 0x19, 0x9f, 0xf3, 0x6e, 0x26, 0xe3, 0x8f, 0xc0, 0x6d, 0xd3, 0x90, 0xe6, 0x3e, 0xea,
-	0xbc, 0xa4, 0xdf, 0x87, 0xbd, 0x9c, 0x33, 0x06, 0x70, 0x0f, 0x76, 0x8f, 0xa9, 0xc0, 0x84, 0xf9,
-	0x51, 0xaf, 0xad, 0x39, 0x60, 0xf0, 0xbc, 0x1f, 0xc0, 0xcd, 0x9a, 0x16, 0x55, 0x0b, 0x8f, 0x41,
-	0xbd, 0xc3, 0x3e, 0x0c, 0xe8, 0x80, 0xb6, 0x89, 0x20, 0xf2, 0x55, 0x4f, 0x9b, 0xad, 0x56, 0xd4,
-	0xeb, 0x11, 0xe6, 0x2f, 0xf0, 0x14, 0x3d, 0x06, 0xe8, 0xf2, 0xde, 0x05, 0x79, 0x08, 0x23, 0xe2,
-	0x2b, 0x02, 0xda, 0x38, 0xa5, 0x41, 0x08, 0x96, 0x7d, 0x22, 0x88, 0xa1, 0xa0, 0x5a, 0x7b, 0x5f,
-	0xc1, 0x97, 0xff, 0x73, 0x9f, 0x89, 0xf2, 0x77, 0x0b, 0x36, 0x2f, 0x06, 0xf1, 0x2f, 0xe3, 0x2d,
-	0x8b, 0x1c, 0x19, 0x5f, 0x54, 0x98, 0x5c, 0x24, 0x79, 0x70, 0x13, 0xb1, 0x6e, 0xc0, 0x7b, 0xd4,
-	0x57, 0x1e, 0xd8, 0x78, 0xa2, 0x90, 0xdc, 0xe9, 0x5e, 0x44, 0x5c, 0xa8, 0x2c, 0x58, 0xc3, 0x5a,
-	0x90, 0x38, 0x92, 0xdb, 0x26, 0x03, 0xd4, 0xda, 0xdb, 0x81, 0xad, 0x69, 0x57, 0x8c, 0x8f, 0x7f,
-	0x59, 0xb0, 0xa5, 0x4b, 0xfd, 0x31, 0x11, 0x74, 0x48, 0x1e, 0xc6, 0x4e, 0x3a, 0x50, 0xec, 0x91,
-	0x1b, 0xe3, 0xa1, 0x5c, 0x4a, 0x58, 0x46, 0x7a, 0x54, 0xb9, 0x57, 0xc1, 0x6a, 0x2d, 0x89, 0xe8,
-	0xd3, 0xf8, 0x86, 0x07, 0x7d, 0xc9, 0x25, 0xe5, 0x60, 0x05, 0xa7, 0x55, 0x32, 0xf1, 0x24, 0xd1,
-	0xc4, 0xc0, 0xa7, 0xca, 0x4b, 0x0b, 0x27, 0xb2, 0x0c, 0x2e, 0x8c, 0xd8, 0xad, 0x36, 0x96, 0x94,
-	0x71, 0xa2, 0x90, 0x27, 0x49, 0x68, 0x4e, 0x96, 0xf5, 0xc9, 0xb1, 0xec, 0xed, 0xc2, 0xf6, 0x8c,
-	0xd7, 0x26, 0x9e, 0x27, 0xb0, 0x71, 0x4c, 0xc5, 0xa2, 0x58, 0xbc, 0x3f, 0x0b, 0x80, 0xd2, 0xfb,
-	0x0c, 0xc1, 0x3e, 0xea, 0xa0, 0x15, 0x17, 0x54, 0xd0, 0x7e, 0x53, 0xa8, 0x4a, 0x55, 0xc1, 0x13,
-	0x85, 0xb4, 0x0e, 0x54, 0x06, 0x4b, 0xab, 0xad, 0xad, 0x89, 0x42, 0xfa, 0xdc, 0x0d, 0x78, 0x2c,
-	0x2e, 0x29, 0x65, 0x4d, 0x59, 0xac, 0x94, 0xcf, 0x29, 0x95, 0x4c, 0x83, 0x90, 0x24, 0x1b, 0x40,
-	0x6d, 0x48, 0x69, 0x14, 0x53, 0x74, 0x89, 0xf8, 0xd4, 0x98, 0x32, 0xe3, 0xb5, 0x61, 0xca, 0x4f,
-	0x80, 0x64, 0xa5, 0x9e, 0x09, 0x66, 0x0b, 0x4a, 0x61, 0xd0, 0x0b, 0x84, 0x0a, 0xa7, 0x84, 0xb5,
-	0x20, 0x33, 0x36, 0xd2, 0x2d, 0xa0, 0xa0, 0xd4, 0x46, 0xf2, 0x28, 0x6c, 0x4e, 0x61, 0x18, 0x1a,
-	0x3d, 0x06, 0x10, 0x91, 0x20, 0x61, 0x2b, 0x1a, 0xb0, 0x31, 0x52, 0x4a, 0x83, 0x0e, 0xa1, 0xcc,
-	0x69, 0x3c, 0x08, 0x25, 0x5c, 0xb1, 0xb1, 0x7a, 0xb4, 0x23, 0x5b, 0x43, 0x96, 0x8e, 0xd8, 0xec,
-	0xf2, 0x1a, 0xb0, 0xa5, 0x6b, 0xe9, 0x42, 0x5e, 0xef, 0xc2, 0xf6, 0xcc, 0x4e, 0x13, 0xed, 0x3f,
-	0x16, 0x54, 0x8d, 0xee, 0x52, 0x10, 0x11, 0xcb, 0x2f, 0x2a, 0x82, 0x1e, 0x8d, 0x05, 0xe9, 0xf5,
-	0x15, 0x42, 0x05, 0x4f, 0x14, 0xe8, 0x5b, 0xd8, 0xe0, 0xa3, 0x0b, 0x72, 0x73, 0x47, 0x45, 0x8c,
-	0xe9, 0x0d, 0x0d, 0xee, 0xa9, 0x6f, 0x62, 0xcf, 0x1a, 0xd0, 0xf7, 0xb0, 0x99, 0x51, 0x9e, 0xbf,
-	0x52, 0x6f, 0x5c, 0xc2, 0x79, 0x26, 0x89, 0x2f, 0x32, 0xf8, 0xcb, 0x1a, 0x3f, 0x63, 0x40, 0x4f,
-	0xc1, 0x49, 0x94, 0x9d, 0x5e, 0x20, 0x04, 0xf5, 0x15, 0x09, 0x4a, 0x38, 0xa3, 0xf7, 0xfe, 0xb0,
-	0xd4, 0xb0, 0x99, 0x8e, 0x75, 0x3e, 0x51, 0x9f, 0x83, 0x1d, 0x8c, 0x9b, 0x68, 0x41, 0x75, 0xe9,
-	0x5d, 0xf9, 0x14, 0xcd, 0xdb, 0x5b, 0x4e, 0x6f, 0x55, 0x7b, 0x1c, 0x37, 0x54, 0x9c, 0x6c, 0x44,
-	0xdf, 0xc0, 0x7a, 0x2c, 0x08, 0x17, 0x57, 0xc9, 0xe7, 0xd3, 0x64, 0x9e, 0xd1, 0x22, 0x0f, 0xaa,
-	0x94, 0xf9, 0x93, 0x5d, 0xcb, 0x6a, 0xd7, 0x94, 0xce, 0x6b, 0xa9, 0x46, 0x38, 0xed, 0xac, 0x21,
-	0x51, 0x23, 0x21, 0x89, 0xa5, 0x48, 0xe2, 0x28, 0x92, 0xa4, 0x77, 0x1a, 0xfb, 0xd3, 0x03, 0xb0,
-	0xc7, 0x73, 0x05, 0x5a, 0x81, 0x22, 0x7e, 0xfb, 0xcc, 0x59, 0xd2, 0x8b, 0x23, 0xc7, 0x7a, 0x1a,
-	0xc2, 0x66, 0x4e, 0x3c, 0x08, 0xa0, 0x7c, 0xd9, 0x69, 0x9d, 0x9f, 0xb5, 0x9d, 0x25, 0xb9, 0x3e,
-	0x3d, 0x39, 0xbb, 0xbe, 0xea, 0x38, 0x16, 0xb2, 0x61, 0xf9, 0xe5, 0xf9, 0x35, 0x76, 0x0a, 0x12,
-	0xa1, 0xdd, 0x7c, 0xe7, 0x14, 0xa5, 0xea, 0x4d, 0xa7, 0xf3, 0xca, 0x59, 0x46, 0x15, 0x28, 0x9d,
-	0x9e, 0x9f, 0x5d, 0xbd, 0x74, 0x4a, 0x68, 0x15, 0x56, 0x7e, 0xbe, 0x6e, 0xe2, 0xab, 0x0e, 0x76,
-	0xca, 0x72, 0xc7, 0xbb, 0x4e, 0x13, 0x3b, 0x2b, 0x47, 0xbf, 0xd9, 0xb0, 0x76, 0x46, 0xc5, 0x30,
-	0xe2, 0x77, 0x97, 0x94, 0xdf, 0x53, 0x8e, 0x30, 0x6c, 0x64, 0x7e, 0x4b, 0xa0, 0x03, 0x19, 0xcc,
-	0xbc, 0x9f, 0x86, 0xb5, 0x2f, 0xe6, 0x58, 0x0d, 0x97, 0x97, 0xd0, 0x09, 0xac, 0x4f, 0xff, 0xa0,
-	0x40, 0x7b, 0x26, 0x85, 0x72, 0xd0, 0x6a, 0x79, 0xa6, 0x04, 0x0a, 0xc3, 0x46, 0x66, 0xf0, 0xd1,
-	0xee, 0xcd, 0x9b, 0x63, 0xb5, 0x7b, 0xf3, 0xa7, 0x25, 0x85, 0x99, 0x99, 0x7d, 0x34, 0xe6, 0xbc,
-	0x31, 0x4a, 0x63, 0xce, 0x1f, 0x98, 0x96, 0xd0, 0x39, 0x38, 0xb3, 0x73, 0x11, 0xda, 0x37, 0x91,
-	0xe5, 0x0d, 0x52, 0xb5, 0x83, 0x7c, 0x63, 0x02, 0xf8, 0x2b, 0xec, 0xcd, 0x1d, 0x61, 0xd0, 0xd7,
-	0xf2, 0xf0, 0xa2, 0x89, 0xaa, 0xf6, 0x64, 0xc1, 0xae, 0xe4, 0xae, 0x16, 0x54, 0xd3, 0xd3, 0x07,
-	0x52, 0x59, 0x96, 0x33, 0x1a, 0xd5, 0xdc, 0xac, 0x21, 0x01, 0x79, 0x01, 0x6b, 0x53, 0x3d, 0x1f,
-	0xb9, 0x13, 0x9a, 0x4c, 0x17, 0xc6, 0xda, 0x5e, 0x8e, 0x25, 0xc1, 0xf9, 0x11, 0x60, 0x92, 0x73,
-	0x68, 0x7b, 0xb6, 0xf6, 0x6a, 0x84, 0x39, 0x25, 0x59, 0xbb, 0x31, 0xd5, 0x50, 0xb4, 0x1b, 0x79,
-	0x9d, 0x51, 0xbb, 0x91, 0xdf, 0x7d, 0x96, 0x50, 0x13, 0xaa, 0xa9, 0xde, 0x11, 0x23, 0x75, 0x63,
-	0xb6, 0x23, 0xd5, 0x76, 0x33, 0xfa, 0xb4, 0x2b, 0x53, 0xd5, 0x5e, 0xbb, 0x92, 0xd7, 0x2a, 0xb4,
-	0x2b, 0xf9, 0xad, 0x61, 0x09, 0xbd, 0x86, 0x47, 0x33, 0x55, 0x08, 0xd5, 0xa6, 0xe3, 0x4f, 0xd7,
-	0xd1, 0xda, 0x7e, 0xae, 0x6d, 0x8c, 0xf6, 0xbe, 0xac, 0xfe, 0x00, 0x7a, 0xfe, 0x5f, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xbc, 0x8a, 0xa5, 0x93, 0x0c, 0x12, 0x00, 0x00,
+	// 10323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xed, 0x7d, 0x5d, 0x6c, 0x9b, 0x49,
+	0x92, 0x98, 0x49, 0xfd, 0xb7, 0x25, 0x99, 0xfa, 0x2c, 0x59, 0x14, 0x2d, 0xdb, 0xf2, 0x67, 0x8f,
+	0xc7, 0xe3, 0xf1, 0xce, 0xce, 0x78, 0x7d, 0x77, 0xfb, 0x73, 0xb7, 0x77, 0x34, 0x49, 0xd9, 0x5c,
+	0x4b, 0xa2, 0xfc, 0x91, 0x1a, 0xdb, 0x7b, 0x77, 0xa3, 0xa3, 0xc9, 0x4f, 0x32, 0xc7, 0x14, 0xc9,
+	0xe5, 0x8f, 0x6d, 0x2d, 0x10, 0x04, 0xc1, 0x05, 0x07, 0x2c, 0x10, 0xe4, 0x90, 0x43, 0x02, 0xdc,
+	0xcb, 0x2d, 0x82, 0x5c, 0x9e, 0x2e, 0x40, 0x10, 0x04, 0xc8, 0x73, 0x12, 0xe4, 0x21, 0x08, 0x90,
+	0x04, 0xc8, 0x3d, 0x06, 0x48, 0x90, 0xa7, 0x00, 0x79, 0x0c, 0x16, 0x08, 0x82, 0x3c, 0xa5, 0xba,
+	0xab, 0xbb, 0xbf, 0xee, 0xfe, 0xba, 0x3f, 0x52, 0xb6, 0x07, 0x59, 0x04, 0xf3, 0x62, 0xab, 0xab,
+	0xfb, 0xab, 0xee, 0xae, 0xae, 0xae, 0xaa, 0xae, 0xee, 0x2a, 0x92, 0xf9, 0xce, 0xe0, 0xb3, 0x5e,
+	0xbf, 0x3b, 0xec, 0x7a, 0xe9, 0xce, 0xc0, 0xff, 0xdb, 0xe7, 0x49, 0xb6, 0xd0, 0x0f, 0xeb, 0xc3,
+	0x70, 0xaf, 0xdb, 0x0c, 0xab, 0xe1, 0x60, 0xd0, 0xea, 0x76, 0x82, 0xf0, 0x67, 0xa3, 0x70, 0x30,
+	0xf4, 0xb2, 0x64, 0xae, 0x19, 0xbe, 0xce, 0x37, 0x9b, 0xfd, 0x6c, 0x6a, 0x2b, 0x75, 0x7b, 0x31,
+	0x10, 0x45, 0xef, 0x12, 0x99, 0xad, 0xf7, 0x7a, 0xa5, 0x83, 0x72, 0x36, 0xcd, 0x2a, 0x78, 0x89,
+	0xc2, 0xa1, 0x09, 0x85, 0x4f, 0x21, 0x1c, 0x4b, 0x14, 0x53, 0xe7, 0xcd, 0xab, 0xea, 0xe3, 0xf0,
+	0x34, 0x3b, 0x8d, 0x98, 0x78, 0x91, 0x7e, 0x71, 0x54, 0xe8, 0x0c, 0x0f, 0x7a, 0xd9, 0x19, 0xa8,
+	0x58, 0x0a, 0x78, 0xc9, 0xcb, 0x91, 0x79, 0xfa, 0x57, 0xb1, 0xfb, 0xa6, 0x93, 0x9d, 0x65, 0x35,
+	0xb2, 0x4c, 0xb1, 0xf5, 0xdf, 0x16, 0xc3, 0x76, 0xfd, 0x34, 0x3b, 0xc7, 0xaa, 0x44, 0xd1, 0xdb,
+	0x22, 0xe7, 0xfb, 0x6f, 0xbf, 0x28, 0x06, 0x95, 0xa3, 0xa3, 0x41, 0x38, 0xcc, 0xce, 0xb3, 0x5a,
+	0x15, 0x44, 0xfb, 0x6b, 0x6c, 0xef, 0xb4, 0x06, 0xc3, 0xec, 0xc2, 0xd6, 0x14, 0xed, 0x0f, 0x4b,
+	0xde, 0x6d, 0x32, 0xdf, 0x7f, 0xfb, 0xb4, 0xd5, 0x69, 0x76, 0xdf, 0x64, 0x09, 0x7c, 0xb6, 0x7c,
+	0x6f, 0xf1, 0x33, 0xa0, 0x54, 0xf0, 0x0c, 0x61, 0x81, 0xac, 0xf5, 0x56, 0xc9, 0x4c, 0xff, 0xed,
+	0xbd, 0x62, 0x90, 0x3d, 0xcf, 0xb0, 0x63, 0xc1, 0xf3, 0xc9, 0x22, 0xfc, 0xb1, 0xdd, 0xa7, 0xa4,
+	0xeb, 0x34, 0x4e, 0xb3, 0x97, 0x59, 0xa5, 0x06, 0xf3, 0x36, 0xc9, 0x42, 0x1f, 0x86, 0xf9, 0x76,
+	0x1b, 0x26, 0x92, 0x5d, 0x84, 0x06, 0xf3, 0x41, 0x04, 0xa0, 0x63, 0xaf, 0x37, 0xfb, 0xe5, 0xce,
+	0x30, 0xec, 0xbf, 0xae, 0xb7, 0xb3, 0x4b, 0x38, 0x76, 0x05, 0xe4, 0x7d, 0x46, 0xbc, 0x56, 0x67,
+	0x30, 0xac, 0xb7, 0xdb, 0xf5, 0x21, 0x2c, 0xd3, 0x6e, 0xbd, 0x7f, 0xdc, 0xea, 0x64, 0x97, 0xa1,
+	0x61, 0x2a, 0xb0, 0xd4, 0x78, 0x5f, 0x30, 0x8c, 0xd5, 0x61, 0x1f, 0x96, 0xf7, 0xf8, 0x34, 0x7b,
+	0x81, 0x4d, 0xeb, 0x02, 0x9d, 0x56, 0xbe, 0x18, 0x08, 0x70, 0xa0, 0xb6, 0x61, 0x93, 0x63, 0x84,
+	0xcd, 0xb0, 0xe1, 0x61, 0xc1, 0xbb, 0x45, 0x96, 0xdf, 0xf4, 0x61, 0x89, 0xc3, 0x66, 0xbe, 0xd7,
+	0x63, 0xab, 0xb8, 0xc2, 0x56, 0xd1, 0x80, 0xd2, 0x76, 0xc7, 0x80, 0xe7, 0x4d, 0xfd, 0x34, 0x08,
+	0x8f, 0x61, 0x1c, 0x83, 0xac, 0x07, 0x44, 0x5e, 0x08, 0x0c, 0x28, 0x10, 0xfb, 0x02, 0x50, 0xb2,
+	0xd3, 0x6e, 0x75, 0x5e, 0xd5, 0x9e, 0xed, 0x77, 0xdf, 0x84, 0xfd, 0xec, 0x45, 0x36, 0x5d, 0x13,
+	0xec, 0xdd, 0x21, 0x19, 0x01, 0x2a, 0x00, 0x83, 0x06, 0x80, 0x27, 0xbb, 0x0a, 0x4d, 0x17, 0x82,
+	0x18, 0xdc, 0xfb, 0x7e, 0xd4, 0x76, 0xbf, 0xdb, 0xae, 0xf7, 0x5b, 0xc3, 0xd3, 0xec, 0x5a, 0xb4,
+	0x94, 0x02, 0x16, 0xc4, 0x5a, 0x79, 0xf7, 0xc8, 0xea, 0x8b, 0xfa, 0x10, 0xa8, 0x7c, 0x5a, 0x7b,
+	0x09, 0x5b, 0x63, 0xd8, 0x0e, 0x77, 0xc2, 0xd7, 0x61, 0x3b, 0x7b, 0x89, 0x0d, 0xca, 0x5a, 0x47,
+	0x97, 0xab, 0xd1, 0xae, 0x0f, 0x06, 0x85, 0xed, 0xfd, 0x6e, 0x7f, 0x98, 0x5d, 0xc7, 0xe5, 0x52,
+	0x40, 0x94, 0x25, 0xb0, 0xc8, 0xd9, 0x2a, 0x8b, 0x2c, 0xa1, 0xc2, 0xbc, 0xbb, 0x64, 0x05, 0x48,
+	0xdf, 0x19, 0x9c, 0xb4, 0x86, 0xc5, 0xd6, 0xeb, 0xb0, 0x3f, 0xa0, 0x83, 0xde, 0x60, 0xb4, 0x8f,
+	0x57, 0xc0, 0x0c, 0xd7, 0x9b, 0xf5, 0x56, 0xfb, 0xb4, 0xc8, 0x27, 0x90, 0x6f, 0xf5, 0x87, 0xad,
+	0x93, 0xb0, 0x50, 0xef, 0x65, 0x73, 0x0c, 0xb9, 0xab, 0xda, 0xfb, 0x21, 0x99, 0x1e, 0xd6, 0x8f,
+	0x07, 0xd9, 0x4d, 0x58, 0x8f, 0xf3, 0xf7, 0x6e, 0x51, 0x7a, 0xb8, 0xb6, 0xfd, 0x67, 0x35, 0x68,
+	0x58, 0xea, 0x0c, 0xfb, 0xa7, 0x01, 0xfb, 0xc6, 0xbb, 0x4a, 0xc8, 0x49, 0xbd, 0xf1, 0x25, 0x1d,
+	0x43, 0xb7, 0x93, 0xbd, 0xc2, 0xa8, 0xaf, 0x40, 0x28, 0x25, 0x8e, 0xc3, 0x6e, 0xbb, 0xdb, 0x60,
+	0xbc, 0x97, 0xbd, 0xca, 0x46, 0xaf, 0x82, 0xbc, 0xdf, 0x24, 0x97, 0x1a, 0x2f, 0xeb, 0x9d, 0x4e,
+	0xd8, 0x2e, 0x74, 0x3b, 0x47, 0xad, 0xe3, 0x51, 0x9f, 0xc1, 0xcb, 0xc5, 0xec, 0x35, 0x68, 0x3c,
+	0x15, 0x38, 0x6a, 0x29, 0x75, 0x8e, 0xba, 0xfd, 0x37, 0xf5, 0x7e, 0x73, 0xff, 0xd1, 0xf3, 0xfd,
+	0xfa, 0x69, 0xbb, 0x5b, 0x6f, 0x66, 0xb7, 0x90, 0x3a, 0xb1, 0x0a, 0xda, 0xfa, 0xa4, 0xfe, 0xf6,
+	0xa0, 0x47, 0xa7, 0x3e, 0xd8, 0x0f, 0xfb, 0x8f, 0xba, 0xa3, 0x7e, 0xf6, 0x3a, 0xa3, 0x4b, 0xbc,
+	0x82, 0xf2, 0x60, 0x3b, 0x3c, 0xae, 0x37, 0x4e, 0x61, 0x2f, 0xe4, 0x0b, 0x8f, 0x61, 0xf2, 0x59,
+	0x9f, 0x61, 0x36, 0xc1, 0xb9, 0xdf, 0x22, 0x0b, 0x92, 0x24, 0x5e, 0x86, 0x4c, 0xbd, 0x02, 0xfe,
+	0x4f, 0x31, 0x2a, 0xd0, 0x3f, 0xe9, 0x96, 0x81, 0xcd, 0x39, 0x0a, 0x99, 0x28, 0x5c, 0x08, 0xb0,
+	0xf0, 0xc3, 0xf4, 0xf7, 0x53, 0x8c, 0xcd, 0xc3, 0xd7, 0xad, 0x46, 0xb8, 0xdf, 0xef, 0x1e, 0xb5,
+	0xda, 0x21, 0xcc, 0xf7, 0x06, 0x9b, 0xaf, 0x09, 0xf6, 0x2f, 0x93, 0x0d, 0xcb, 0x72, 0x0c, 0x7a,
+	0xb0, 0x59, 0x42, 0xff, 0xbb, 0x64, 0xed, 0x61, 0x38, 0xb4, 0xc8, 0xe7, 0x48, 0xda, 0xa6, 0x54,
+	0x69, 0xeb, 0xff, 0x6a, 0x89, 0x5c, 0x32, 0xbf, 0x40, 0x5c, 0xdf, 0x8a, 0xf4, 0xf7, 0x10, 0xe9,
+	0xfe, 0xaf, 0x81, 0x48, 0xa7, 0x54, 0x7f, 0x51, 0xa3, 0x82, 0x81, 0x89, 0x73, 0xa0, 0x13, 0x2f,
+	0xd2, 0x9a, 0xe1, 0x5b, 0x94, 0xa5, 0x19, 0xac, 0xe1, 0x45, 0x53, 0x0d, 0xac, 0x9c, 0x45, 0x0d,
+	0x78, 0xaa, 0x1a, 0x00, 0x44, 0xc8, 0xb8, 0x05, 0x2a, 0xc2, 0x98, 0xc8, 0xe6, 0x88, 0x8a, 0x11,
+	0x38, 0x50, 0xdb, 0x78, 0xbf, 0x4b, 0xbc, 0x5e, 0xd8, 0x69, 0xb6, 0x3a, 0xc7, 0x4a, 0x13, 0x26,
+	0xc1, 0x2d, 0x5f, 0x5a, 0x9a, 0x5a, 0x54, 0xca, 0xda, 0xa4, 0x2a, 0xe5, 0xd2, 0xe4, 0x2a, 0x65,
+	0xfd, 0x0c, 0x2a, 0x25, 0xfb, 0x5e, 0x2a, 0x65, 0x23, 0x41, 0xa5, 0x00, 0xc3, 0x71, 0x38, 0xb6,
+	0x45, 0x99, 0xae, 0xc1, 0xbc, 0xfb, 0x64, 0x4d, 0x2d, 0x1f, 0xf4, 0x9a, 0x30, 0xce, 0x66, 0x7e,
+	0xc8, 0x0c, 0x8e, 0x85, 0xc0, 0x5e, 0x69, 0x2a, 0xab, 0xcd, 0xf1, 0xca, 0xea, 0x8a, 0x45, 0x59,
+	0x49, 0x2c, 0x07, 0x9d, 0x61, 0xab, 0xcd, 0x04, 0xfd, 0x42, 0xa0, 0x82, 0xec, 0xea, 0xec, 0xda,
+	0x3b, 0xa8, 0xb3, 0xad, 0x64, 0x75, 0x06, 0xcc, 0xfe, 0x9a, 0xeb, 0x23, 0x2a, 0xe0, 0xa7, 0x03,
+	0x51, 0x04, 0x9c, 0xa8, 0xe8, 0x6e, 0x30, 0x45, 0x77, 0x93, 0xae, 0x92, 0x5d, 0x14, 0x8e, 0x51,
+	0x73, 0x37, 0xc7, 0xa9, 0xb9, 0x8f, 0xce, 0xa2, 0xe6, 0x6e, 0x25, 0xaa, 0xb9, 0x1f, 0x90, 0xe5,
+	0x11, 0x53, 0x4e, 0x05, 0xac, 0x1f, 0x64, 0x3f, 0x66, 0xa3, 0x5f, 0xa1, 0xa3, 0x3f, 0x50, 0x6b,
+	0x02, 0xa3, 0xa1, 0x5d, 0x43, 0xde, 0x3e, 0x93, 0x86, 0xfc, 0xe4, 0x0c, 0x1a, 0xf2, 0xce, 0x07,
+	0xd6, 0x90, 0x94, 0xa3, 0x70, 0x2a, 0xbb, 0xf5, 0xc1, 0xab, 0xec, 0xa7, 0x4c, 0x7e, 0xab, 0x20,
+	0x9b, 0x0e, 0xbd, 0x6b, 0xd7, 0xa1, 0xbf, 0x84, 0xa3, 0x0c, 0x72, 0xfc, 0xb7, 0x47, 0x99, 0x0f,
+	0xaa, 0xf7, 0x36, 0xbf, 0x3d, 0xca, 0x7c, 0x7b, 0x94, 0xf9, 0xf5, 0x39, 0xca, 0x28, 0xb2, 0xff,
+	0xb2, 0x2e, 0xfb, 0xc5, 0x21, 0xe7, 0x4a, 0x74, 0xc8, 0x71, 0x09, 0x84, 0x31, 0xd2, 0xff, 0xea,
+	0x38, 0xe9, 0x7f, 0xed, 0x2c, 0xd2, 0x7f, 0xeb, 0xec, 0x87, 0x9c, 0xeb, 0x67, 0x12, 0xe1, 0xfe,
+	0x19, 0x44, 0xf8, 0x8d, 0x6f, 0xfe, 0x90, 0x73, 0xd3, 0x79, 0xc8, 0xb1, 0x2c, 0x07, 0x3f, 0xe4,
+	0xfc, 0x53, 0x42, 0xd6, 0xf7, 0xeb, 0xc3, 0xc6, 0xcb, 0xc9, 0xcf, 0x39, 0x4e, 0xd1, 0x0d, 0x6b,
+	0x39, 0x62, 0x1d, 0x31, 0xa5, 0x32, 0xc5, 0xf6, 0xad, 0x02, 0x51, 0x04, 0xf5, 0xb4, 0x53, 0x50,
+	0xcf, 0xb8, 0x05, 0xf5, 0x6c, 0xa2, 0xa0, 0x9e, 0x8b, 0x0b, 0x6a, 0x55, 0x20, 0xcf, 0x4f, 0x26,
+	0x90, 0x17, 0x92, 0x04, 0x72, 0x76, 0x9c, 0x40, 0x26, 0x63, 0x04, 0xf2, 0xf9, 0x49, 0x05, 0xf2,
+	0xe2, 0xa4, 0x02, 0x79, 0xe9, 0x2c, 0x02, 0x79, 0xd9, 0x10, 0xc8, 0x86, 0xa0, 0xbd, 0x30, 0xa9,
+	0xa0, 0xcd, 0x4c, 0x2e, 0x68, 0x57, 0xce, 0x20, 0x68, 0xbd, 0xf7, 0x12, 0xb4, 0x17, 0x27, 0x17,
+	0xb4, 0xab, 0xe3, 0x05, 0xed, 0xda, 0xa4, 0x82, 0xf6, 0xd2, 0x3b, 0x08, 0xda, 0xf5, 0x64, 0x41,
+	0xfb, 0x03, 0x2e, 0x4e, 0x37, 0x98, 0x38, 0xfd, 0x88, 0xd1, 0xc3, 0xbe, 0x43, 0xc7, 0x48, 0xd3,
+	0xdc, 0x38, 0x69, 0x7a, 0xf9, 0x2c, 0xd2, 0x74, 0xf3, 0xec, 0xd2, 0xf4, 0xca, 0x99, 0xa4, 0xe9,
+	0xd5, 0x33, 0x48, 0xd3, 0x6b, 0xdf, 0xbc, 0x34, 0xdd, 0xb2, 0x4b, 0xd3, 0x1c, 0xc9, 0xc6, 0x57,
+	0x83, 0x0b, 0xd3, 0x7b, 0x24, 0x0b, 0xb2, 0x29, 0xb4, 0x5a, 0xc2, 0x2e, 0xa7, 0x11, 0x48, 0x67,
+	0xcb, 0x37, 0x1c, 0xe1, 0x06, 0x59, 0x87, 0x53, 0x54, 0x50, 0x07, 0xfe, 0x3b, 0x29, 0xa2, 0xe1,
+	0xcc, 0xf1, 0xf9, 0xf7, 0x49, 0x36, 0x5e, 0x35, 0xce, 0xdb, 0xe4, 0xff, 0x55, 0x8a, 0x6c, 0x95,
+	0x3a, 0x80, 0x61, 0x14, 0x16, 0xeb, 0xc3, 0x3a, 0xe5, 0xbe, 0xdd, 0x7c, 0xa1, 0xd0, 0x3d, 0x39,
+	0x01, 0x44, 0xe3, 0xe4, 0x3e, 0x70, 0xd7, 0x51, 0xff, 0x44, 0x2c, 0x6e, 0x9a, 0x2d, 0x81, 0x02,
+	0xf1, 0x3c, 0x32, 0x0d, 0xb2, 0xbe, 0xce, 0x0d, 0x77, 0xf6, 0x37, 0x95, 0x8f, 0xe1, 0xdb, 0x5e,
+	0xab, 0x1f, 0x0e, 0xe0, 0xac, 0x3c, 0xcd, 0xc8, 0x1e, 0x01, 0x68, 0x6d, 0xa7, 0x3b, 0x7c, 0x10,
+	0x02, 0x87, 0x84, 0x4c, 0xf4, 0x43, 0xad, 0x04, 0xf8, 0x37, 0xc8, 0xf5, 0x84, 0xb1, 0x72, 0x12,
+	0xfd, 0x65, 0x9a, 0x5c, 0xdc, 0x1f, 0x0d, 0x5e, 0x8a, 0x26, 0xe3, 0x26, 0x21, 0x06, 0x99, 0xd6,
+	0x07, 0xd9, 0xa0, 0xfc, 0xdc, 0x3f, 0x09, 0x9b, 0x6c, 0xf4, 0x20, 0xc4, 0x25, 0x80, 0x72, 0xcd,
+	0x11, 0x93, 0x1b, 0xa8, 0xb5, 0xb0, 0x40, 0xf1, 0x50, 0x25, 0xc5, 0x15, 0x16, 0xfb, 0x5b, 0xf5,
+	0x05, 0xcd, 0xea, 0xbe, 0x20, 0x50, 0x71, 0x0d, 0x21, 0x13, 0xe7, 0xd8, 0x3c, 0x65, 0x99, 0xaa,
+	0xa9, 0x9e, 0x90, 0x81, 0xf3, 0x16, 0x19, 0x28, 0x6b, 0x51, 0xd9, 0x1c, 0x85, 0x7d, 0xd0, 0x3c,
+	0x21, 0x53, 0x55, 0x0b, 0x41, 0x04, 0x60, 0x7d, 0x40, 0xb3, 0x56, 0x03, 0x34, 0x0d, 0x6a, 0x22,
+	0x59, 0x06, 0x6e, 0x59, 0xd5, 0x89, 0xc4, 0x39, 0x05, 0x30, 0x36, 0xe9, 0xd1, 0xb6, 0x41, 0x07,
+	0x96, 0xc2, 0x99, 0x4b, 0x80, 0xff, 0x27, 0x29, 0x92, 0x7d, 0xd0, 0x87, 0xa5, 0x6d, 0xd4, 0x07,
+	0x43, 0x0b, 0x81, 0xb9, 0x15, 0x90, 0xd2, 0xac, 0x00, 0x49, 0xae, 0xb4, 0x41, 0xae, 0x18, 0x6f,
+	0xd0, 0x4d, 0xd7, 0x1a, 0xf4, 0x40, 0x36, 0xd5, 0xdb, 0xb0, 0xd7, 0x5b, 0xdd, 0x26, 0x27, 0xb1,
+	0x09, 0xf6, 0x8f, 0xc9, 0x86, 0x65, 0x1c, 0x7c, 0x0e, 0xa0, 0xc9, 0x06, 0x8d, 0x97, 0x61, 0x73,
+	0xd4, 0x0e, 0x9b, 0x85, 0xee, 0x08, 0xd6, 0x24, 0xc5, 0xb0, 0x18, 0x50, 0x2a, 0xe3, 0x07, 0xaf,
+	0x5a, 0xf4, 0xb0, 0x81, 0xad, 0x70, 0x7c, 0x1a, 0xcc, 0x6f, 0x90, 0xcb, 0xb0, 0xab, 0x84, 0x50,
+	0x2e, 0x86, 0x8d, 0x16, 0xdd, 0x8f, 0x83, 0x71, 0x4c, 0x05, 0x73, 0x6e, 0xb7, 0x40, 0xfc, 0x33,
+	0x9c, 0x33, 0x01, 0x16, 0x68, 0xeb, 0x2e, 0x1a, 0x27, 0x53, 0x0c, 0xcc, 0x4b, 0xfe, 0xbf, 0x4f,
+	0x93, 0x8c, 0xd9, 0x05, 0x25, 0x10, 0x55, 0x00, 0x5c, 0x5c, 0xb1, 0xbf, 0x15, 0x83, 0x29, 0x6d,
+	0x1a, 0x4c, 0x4d, 0xfe, 0x1d, 0x43, 0x0d, 0xdc, 0x24, 0xca, 0xd4, 0xa0, 0x80, 0x85, 0x60, 0x0b,
+	0x08, 0x45, 0xb1, 0x59, 0xa7, 0xd9, 0xd2, 0x5a, 0x6a, 0x98, 0x89, 0xd2, 0x78, 0x45, 0x27, 0x08,
+	0x7b, 0xb2, 0xc9, 0xd8, 0x19, 0x54, 0x82, 0x02, 0xa2, 0x3c, 0x02, 0xe6, 0x04, 0x17, 0xbc, 0xb3,
+	0xc8, 0x23, 0x12, 0x40, 0x17, 0x11, 0x14, 0x0c, 0xdf, 0x95, 0x48, 0x58, 0x34, 0xc5, 0x4c, 0xf0,
+	0x19, 0xcc, 0x31, 0x3a, 0x3f, 0x58, 0x65, 0xb6, 0x5b, 0xd0, 0x22, 0x93, 0x65, 0x2a, 0xd5, 0x01,
+	0x31, 0x63, 0xf0, 0xc5, 0x80, 0xfe, 0xe9, 0xb7, 0xc9, 0xa6, 0x7d, 0xcd, 0x38, 0x7f, 0xdc, 0x25,
+	0xb3, 0x20, 0x6d, 0x46, 0x6d, 0xca, 0x17, 0x54, 0xa3, 0xae, 0x32, 0xff, 0xa7, 0xd1, 0x3c, 0xe0,
+	0x6d, 0xa8, 0x90, 0x1b, 0x76, 0xc1, 0xea, 0x8a, 0x78, 0x64, 0x26, 0x50, 0x20, 0x9c, 0x43, 0x22,
+	0x41, 0xf4, 0x08, 0x8e, 0xfe, 0x5d, 0x50, 0xc0, 0x1f, 0x94, 0x43, 0xfe, 0x06, 0x59, 0x8b, 0xf5,
+	0x50, 0x1e, 0x86, 0x27, 0x2e, 0x2e, 0x41, 0xef, 0x14, 0x17, 0xc9, 0xbc, 0x44, 0x29, 0xd5, 0x68,
+	0xa1, 0x3c, 0x5b, 0x0a, 0xe8, 0x9f, 0x72, 0x13, 0x4e, 0x2b, 0x9b, 0xd0, 0x22, 0xc7, 0xfc, 0x9f,
+	0x31, 0x8a, 0x5a, 0xe6, 0xc8, 0x29, 0xfa, 0x85, 0x41, 0xd1, 0x0d, 0x4a, 0x51, 0xeb, 0x80, 0x27,
+	0x26, 0xeb, 0x36, 0x53, 0x67, 0x62, 0x55, 0xb6, 0xfb, 0xf5, 0x93, 0x70, 0x30, 0x81, 0x28, 0x67,
+	0x43, 0x4f, 0x2b, 0x43, 0xff, 0x45, 0x9a, 0x2c, 0x69, 0x58, 0x28, 0xe5, 0x87, 0xdd, 0x57, 0x61,
+	0x87, 0x4b, 0x05, 0x2c, 0x08, 0x36, 0x4a, 0x4b, 0x36, 0xa2, 0xc2, 0x9b, 0xda, 0x8e, 0x27, 0xbd,
+	0x21, 0x27, 0x99, 0x28, 0xd2, 0xfe, 0x07, 0x61, 0x67, 0x28, 0x15, 0x18, 0x2f, 0xb1, 0x2f, 0x1a,
+	0xaf, 0x98, 0x17, 0x18, 0x75, 0x97, 0x28, 0xd2, 0x3e, 0xc3, 0x7e, 0xbf, 0x8b, 0x6a, 0x00, 0x0c,
+	0x0d, 0x56, 0x60, 0xc2, 0x56, 0x1a, 0x8e, 0x73, 0x5c, 0xd8, 0x4a, 0x83, 0xf1, 0x1e, 0x99, 0x1b,
+	0xa0, 0xfa, 0x67, 0xbb, 0xe3, 0xfc, 0xbd, 0xac, 0xca, 0xa7, 0x6c, 0x2e, 0xc2, 0x3c, 0x10, 0x0d,
+	0x99, 0x76, 0xa5, 0xa8, 0xa9, 0x5d, 0x2d, 0x14, 0x82, 0x04, 0xf8, 0x7f, 0x9d, 0x26, 0xab, 0xb6,
+	0xef, 0x15, 0xb9, 0x92, 0x72, 0x1e, 0xc4, 0xd2, 0xc6, 0x41, 0x4c, 0xdd, 0x93, 0xc8, 0xac, 0xd1,
+	0x9e, 0x54, 0xf4, 0xde, 0x34, 0xab, 0x92, 0x7a, 0x4f, 0xb9, 0x37, 0x99, 0xd1, 0xef, 0x4d, 0x54,
+	0x69, 0x30, 0x9b, 0x28, 0x0d, 0xde, 0xc7, 0x57, 0x67, 0x3f, 0xd8, 0x45, 0x1e, 0x3c, 0xa2, 0x79,
+	0xf0, 0xcc, 0x03, 0xdf, 0xf9, 0xf8, 0x81, 0x0f, 0x18, 0x75, 0xc3, 0xc2, 0xa8, 0x7c, 0x63, 0x7c,
+	0x62, 0x6c, 0x8c, 0x95, 0xd8, 0x12, 0x8a, 0x0d, 0xe1, 0xff, 0x9b, 0x69, 0xb2, 0x8a, 0x77, 0x8f,
+	0x0f, 0xc5, 0x81, 0x0b, 0xb9, 0x9d, 0x73, 0x66, 0x2a, 0xe2, 0x4c, 0xe0, 0xf3, 0x0e, 0x7c, 0xca,
+	0xad, 0x56, 0xf6, 0x37, 0x9d, 0x7a, 0x33, 0x1c, 0x80, 0x7e, 0xef, 0x0d, 0x23, 0x2d, 0xa0, 0x82,
+	0xe8, 0x82, 0xd1, 0x93, 0xe3, 0x70, 0x04, 0xac, 0x31, 0xcd, 0xce, 0x93, 0xb2, 0x4c, 0xf9, 0xa6,
+	0xdd, 0xed, 0x1c, 0x63, 0xe5, 0x0c, 0xab, 0x8c, 0x00, 0xf4, 0xcb, 0x7a, 0x9b, 0x7f, 0x39, 0x8b,
+	0x5f, 0x8a, 0x32, 0x25, 0x5d, 0x9f, 0x9d, 0x0c, 0xb9, 0x19, 0xc3, 0x4b, 0x2a, 0x0b, 0xcc, 0xbb,
+	0x4d, 0x9f, 0x85, 0x04, 0xd3, 0x87, 0x24, 0x9a, 0x3e, 0x20, 0x3f, 0xfa, 0xc0, 0xbc, 0x7c, 0xa5,
+	0xcf, 0xa3, 0xfc, 0x88, 0x20, 0xde, 0x4d, 0xb2, 0xd4, 0xee, 0x06, 0xf5, 0xea, 0x9e, 0x60, 0x06,
+	0x3c, 0x42, 0xeb, 0x40, 0x3a, 0xfa, 0x97, 0xf5, 0xc1, 0xc3, 0xfd, 0x2a, 0x3b, 0x38, 0x83, 0xa8,
+	0xc4, 0x12, 0xfd, 0xfa, 0xa8, 0xd5, 0x09, 0x6b, 0x20, 0x4e, 0xe1, 0xc4, 0x7d, 0xd2, 0xe3, 0x47,
+	0x65, 0x1d, 0xc8, 0xd8, 0x2d, 0x6c, 0x84, 0xb0, 0x63, 0x2b, 0x9d, 0x36, 0x3a, 0x43, 0x41, 0x55,
+	0x2a, 0x20, 0x38, 0x3d, 0xe1, 0xd1, 0x2d, 0xc3, 0x56, 0xdf, 0x8f, 0xae, 0xfb, 0xf5, 0x35, 0x36,
+	0xcf, 0x6d, 0xef, 0x7c, 0x6e, 0xf1, 0xd7, 0xc9, 0x9a, 0xd1, 0x01, 0x37, 0x8b, 0x3f, 0x22, 0x2b,
+	0xc0, 0xa6, 0xe3, 0x58, 0xcb, 0xff, 0x0f, 0xb3, 0xc4, 0x53, 0xdb, 0x71, 0x3e, 0xfe, 0xf5, 0xe6,
+	0x41, 0x6a, 0xae, 0xb3, 0x49, 0x53, 0xc9, 0x8b, 0x6c, 0x18, 0x01, 0x68, 0xed, 0x48, 0xde, 0xce,
+	0xcd, 0x63, 0xed, 0x48, 0xbd, 0x91, 0x03, 0xb3, 0x7e, 0x30, 0xac, 0x86, 0x61, 0x27, 0x3f, 0xe4,
+	0x0c, 0xa9, 0x82, 0x28, 0xa7, 0xc1, 0xa9, 0x5f, 0x34, 0x20, 0x78, 0x86, 0x8e, 0x20, 0xf4, 0x84,
+	0xdc, 0x1d, 0x0d, 0x2b, 0x47, 0xfb, 0xed, 0x7a, 0x27, 0x78, 0xb6, 0x4f, 0x45, 0xfe, 0x10, 0xb5,
+	0x1a, 0x8a, 0x0b, 0x47, 0xad, 0xb2, 0x73, 0x16, 0x5d, 0x3b, 0x67, 0xc9, 0xbd, 0x73, 0x96, 0x13,
+	0x76, 0xce, 0x85, 0xc4, 0x9d, 0x03, 0x67, 0x6d, 0xa0, 0x0d, 0x1c, 0xdb, 0x5f, 0xb4, 0xda, 0x50,
+	0xae, 0x36, 0xe8, 0x59, 0x2b, 0xc3, 0x48, 0x1a, 0xaf, 0x30, 0xf6, 0xd9, 0xca, 0xf8, 0x7d, 0xe6,
+	0x25, 0xef, 0xb3, 0x8b, 0xc9, 0xfb, 0x6c, 0x75, 0x82, 0x7d, 0xb6, 0x16, 0xdf, 0x67, 0xb7, 0xc9,
+	0x6c, 0xf8, 0x1a, 0x94, 0xf0, 0x20, 0x7b, 0x89, 0xed, 0xb4, 0x0c, 0xbb, 0x6f, 0x44, 0x26, 0x2e,
+	0xd1, 0x8a, 0x80, 0xd7, 0x7b, 0xf7, 0xf9, 0x8e, 0x5c, 0x67, 0xed, 0xb6, 0xf8, 0xbd, 0xa4, 0xc1,
+	0xef, 0x1f, 0x6e, 0x3f, 0x3e, 0x23, 0x8b, 0xea, 0x30, 0xac, 0xf6, 0x1a, 0x85, 0x9d, 0xf6, 0xe4,
+	0x56, 0xa2, 0x7f, 0x8f, 0xdf, 0x4a, 0x4c, 0x5f, 0xa0, 0x1b, 0xf7, 0x5b, 0x7d, 0xf1, 0xff, 0xb3,
+	0xbe, 0xb0, 0xad, 0xf1, 0x07, 0xd5, 0x17, 0x46, 0x07, 0x5c, 0x5f, 0xfc, 0xe3, 0x34, 0xf1, 0xa8,
+	0x0d, 0x64, 0x30, 0x97, 0x3c, 0xb6, 0xa4, 0xec, 0xc7, 0x96, 0xb4, 0x7a, 0x6c, 0x41, 0x43, 0xb9,
+	0xde, 0x6f, 0xbc, 0xe4, 0xfc, 0xc5, 0x4b, 0x20, 0x82, 0xe6, 0xba, 0xfd, 0x66, 0xd8, 0x7f, 0x80,
+	0x77, 0xb7, 0xcb, 0xf7, 0x3c, 0x65, 0xbf, 0x56, 0xb0, 0x26, 0x10, 0x4d, 0xbc, 0x4f, 0xc9, 0xc2,
+	0xa0, 0xdb, 0x1f, 0x32, 0x38, 0x63, 0xb6, 0xe5, 0x7b, 0x4b, 0xb4, 0x7d, 0x55, 0x00, 0x83, 0xa8,
+	0x5e, 0xee, 0xef, 0xd9, 0x68, 0x7f, 0xc7, 0xa7, 0xf1, 0xe1, 0xe8, 0x17, 0x92, 0x8b, 0x1a, 0x7a,
+	0xae, 0x2f, 0xf5, 0xd3, 0x4d, 0xca, 0x3c, 0xdd, 0xc0, 0xa1, 0x5c, 0xd8, 0x85, 0x69, 0x36, 0xce,
+	0x4b, 0x76, 0x39, 0x24, 0x8d, 0xc3, 0xdb, 0x60, 0xb8, 0x33, 0xa7, 0xe0, 0x58, 0x05, 0x0e, 0x0b,
+	0x6a, 0xb4, 0xe4, 0x0b, 0xfa, 0xdf, 0x53, 0x52, 0x14, 0x55, 0x87, 0x75, 0x90, 0x84, 0xb0, 0x87,
+	0x87, 0x92, 0x5f, 0x71, 0xb2, 0x11, 0x80, 0x69, 0x89, 0xb7, 0xa8, 0xae, 0xc0, 0x9c, 0x65, 0x1c,
+	0xda, 0xe4, 0xab, 0x1b, 0xaf, 0xf0, 0x3e, 0x27, 0x17, 0x63, 0xc0, 0xca, 0x63, 0x7e, 0x2e, 0xb0,
+	0x55, 0x31, 0xe7, 0x79, 0x0c, 0x3f, 0x1e, 0x16, 0xe2, 0x15, 0xf4, 0x2a, 0x41, 0x02, 0x4b, 0xc0,
+	0x71, 0x43, 0xee, 0x99, 0x98, 0x09, 0x62, 0x70, 0xff, 0x4f, 0xd2, 0xec, 0xd5, 0x9d, 0x3a, 0x57,
+	0xb7, 0x68, 0xfc, 0x1e, 0x99, 0x6f, 0x89, 0xdb, 0x98, 0x34, 0x63, 0xad, 0x75, 0x76, 0x77, 0x72,
+	0x7c, 0x0c, 0x72, 0x09, 0x7d, 0xd9, 0xbc, 0x3a, 0x90, 0x0d, 0x99, 0x83, 0x69, 0x58, 0xef, 0x0f,
+	0xa3, 0xed, 0x8e, 0xec, 0x6d, 0x40, 0xe9, 0xf1, 0x21, 0xec, 0x34, 0xa3, 0x56, 0x78, 0x5a, 0xd4,
+	0x60, 0xd1, 0x86, 0x9a, 0xb1, 0x6f, 0xa8, 0x59, 0x6d, 0x43, 0x69, 0x5b, 0x61, 0x2e, 0x79, 0x2b,
+	0xf8, 0x0d, 0xe6, 0x2c, 0xd6, 0xe9, 0xc0, 0xf9, 0xf3, 0xb6, 0x71, 0x2e, 0x51, 0xf5, 0x25, 0xb6,
+	0x9c, 0xf4, 0x9c, 0xfe, 0x1b, 0xe4, 0x72, 0x75, 0x08, 0x66, 0xc3, 0x09, 0xfa, 0xe8, 0x77, 0xc3,
+	0x61, 0x9d, 0x1d, 0x03, 0xc7, 0x78, 0xb9, 0x5f, 0x90, 0x45, 0xfc, 0x20, 0x78, 0x56, 0xee, 0x1c,
+	0x75, 0xed, 0x4a, 0x8b, 0x69, 0xca, 0xb4, 0xae, 0x29, 0xa9, 0xc8, 0xe6, 0x7c, 0xc5, 0xfe, 0xa6,
+	0x8a, 0x83, 0xcb, 0x68, 0xae, 0xa5, 0x44, 0xd1, 0xff, 0x65, 0x9a, 0x6c, 0xda, 0xc7, 0xc6, 0xa9,
+	0x70, 0xd6, 0xfb, 0x4c, 0xc5, 0x8d, 0x3e, 0xa5, 0x3f, 0x5e, 0x81, 0x55, 0x3c, 0xa9, 0x51, 0x1d,
+	0xce, 0x5d, 0xc2, 0xac, 0x10, 0x79, 0x3e, 0x67, 0x6c, 0x8e, 0xe2, 0x59, 0xc5, 0x51, 0xac, 0x1e,
+	0xa6, 0xe7, 0x0c, 0x07, 0x17, 0xec, 0xd3, 0x23, 0x79, 0x02, 0x9d, 0x67, 0x97, 0x10, 0x11, 0x80,
+	0x12, 0xae, 0x0e, 0xe3, 0x59, 0x60, 0xba, 0x84, 0xfe, 0xc9, 0xd6, 0xf6, 0x2d, 0x25, 0x2a, 0x3b,
+	0xcc, 0xf2, 0xb5, 0x55, 0x89, 0x1d, 0xf0, 0x7a, 0xff, 0x9f, 0xa7, 0xc8, 0x96, 0x72, 0x76, 0x2d,
+	0xd4, 0x7b, 0xf5, 0x06, 0xd5, 0x9a, 0x61, 0x0f, 0xc6, 0xe9, 0xde, 0x33, 0x71, 0xf6, 0x4f, 0x4f,
+	0xc4, 0xfe, 0x53, 0x16, 0xf6, 0x07, 0xc1, 0xf1, 0x62, 0x34, 0x68, 0x41, 0x09, 0x1f, 0x1b, 0x0e,
+	0x76, 0xd8, 0x66, 0x40, 0x32, 0xda, 0xaa, 0xfc, 0xff, 0x92, 0x22, 0x17, 0xaa, 0xa3, 0x17, 0x0f,
+	0xa8, 0x1b, 0x91, 0x0f, 0x98, 0x2e, 0xcc, 0x00, 0x41, 0x5c, 0x90, 0x89, 0x22, 0xfa, 0xb3, 0x87,
+	0xa7, 0x85, 0xd3, 0x46, 0x1b, 0x59, 0x29, 0x15, 0x44, 0x00, 0xe6, 0xb0, 0xc1, 0x7b, 0x36, 0xe9,
+	0xe2, 0xc1, 0x22, 0x15, 0x4f, 0xb2, 0x59, 0x01, 0x98, 0x65, 0x74, 0xc2, 0xc5, 0x13, 0x18, 0xc9,
+	0xb1, 0x0a, 0xaa, 0xfe, 0xa3, 0x1b, 0xcd, 0x91, 0x74, 0x9e, 0xe9, 0x40, 0xda, 0xaa, 0x1f, 0x7e,
+	0x1d, 0x36, 0x86, 0xc2, 0xe1, 0x8c, 0x1c, 0xa0, 0x03, 0xfd, 0x3c, 0x59, 0xc2, 0xf9, 0xf2, 0x1b,
+	0x40, 0x27, 0x97, 0x2a, 0x83, 0x4f, 0x6b, 0x83, 0xf7, 0xff, 0x34, 0x45, 0xae, 0x27, 0xac, 0x2b,
+	0xe7, 0xfe, 0xef, 0x92, 0x79, 0x4e, 0xa5, 0x01, 0x97, 0x02, 0x17, 0x99, 0x28, 0xd1, 0x69, 0x1b,
+	0xc8, 0x46, 0xf4, 0x79, 0x9c, 0xbe, 0x20, 0x5c, 0x79, 0xad, 0x44, 0xef, 0x47, 0xf9, 0x98, 0x03,
+	0xa3, 0xa1, 0xff, 0x35, 0x73, 0x20, 0x6a, 0x4f, 0xe8, 0x34, 0xc1, 0x1c, 0x67, 0xa9, 0xd4, 0x44,
+	0x2c, 0x95, 0x8e, 0xb3, 0x94, 0xff, 0xcf, 0x52, 0xc4, 0x8b, 0xf7, 0x34, 0x46, 0xdd, 0x69, 0x9b,
+	0x0c, 0xc9, 0xa9, 0x6c, 0x32, 0xd3, 0xd7, 0xa5, 0x6e, 0x4f, 0x30, 0xea, 0xf8, 0x5b, 0x40, 0xb6,
+	0xa6, 0xc8, 0xb9, 0x2a, 0x88, 0xb6, 0x78, 0x41, 0x29, 0x8a, 0xa3, 0x11, 0x1e, 0x75, 0x05, 0xe4,
+	0x57, 0xc8, 0x15, 0x07, 0x79, 0xf8, 0x5a, 0x7d, 0x66, 0xc8, 0xeb, 0x4b, 0xb1, 0x17, 0x89, 0x9a,
+	0xd4, 0xf6, 0xd7, 0xc8, 0x45, 0x40, 0xf8, 0x93, 0x6e, 0xab, 0xa3, 0x92, 0xd9, 0xff, 0x07, 0x29,
+	0xb2, 0x20, 0x81, 0xcc, 0xbb, 0x85, 0x15, 0xea, 0x2d, 0x89, 0x06, 0xc3, 0xdb, 0x80, 0x46, 0xd8,
+	0x1b, 0xaa, 0x57, 0x24, 0x2a, 0x88, 0x62, 0x39, 0xaa, 0xb7, 0xda, 0xa3, 0x7e, 0x88, 0x4d, 0x90,
+	0x3e, 0x1a, 0x8c, 0x2a, 0x91, 0xfa, 0xeb, 0xe3, 0x1d, 0x20, 0x17, 0x25, 0x2f, 0x92, 0x48, 0x81,
+	0xf8, 0x65, 0x92, 0xe1, 0xca, 0x27, 0x1a, 0x5d, 0x5c, 0xee, 0xdc, 0x20, 0x33, 0x03, 0x5a, 0xc5,
+	0x46, 0x71, 0x1e, 0x15, 0x5f, 0x34, 0x45, 0xac, 0xf3, 0x1f, 0x93, 0xc5, 0x7c, 0xaf, 0x17, 0xa1,
+	0x71, 0xdd, 0x4a, 0x4d, 0x84, 0xac, 0x43, 0x56, 0x75, 0x32, 0xf2, 0xe5, 0xf8, 0x9c, 0xcc, 0xf3,
+	0x57, 0x11, 0x03, 0xf5, 0x0e, 0xc1, 0x9c, 0x43, 0x20, 0x5b, 0xc1, 0xde, 0x9f, 0x86, 0x8e, 0xc5,
+	0x8e, 0x61, 0x22, 0x59, 0x1d, 0x66, 0xc0, 0x6a, 0xfd, 0xdf, 0x27, 0x1b, 0x8a, 0x35, 0xc9, 0x37,
+	0x8f, 0x5b, 0x10, 0x9f, 0xed, 0x0e, 0xe1, 0x84, 0x2c, 0x69, 0x88, 0x9d, 0x82, 0x85, 0xca, 0xa9,
+	0xb7, 0xaa, 0x1f, 0x23, 0xcd, 0xe5, 0x94, 0x0a, 0x34, 0xdc, 0x22, 0x53, 0xa6, 0x5b, 0xc4, 0x3f,
+	0x26, 0x39, 0xdb, 0x5c, 0x26, 0x34, 0x90, 0x3f, 0x31, 0x0c, 0xe4, 0x15, 0x85, 0xbe, 0x88, 0x4b,
+	0xf2, 0xfa, 0x17, 0x6c, 0xf3, 0xf0, 0xba, 0x3c, 0xd8, 0x68, 0x9d, 0x4e, 0x3d, 0xd9, 0xea, 0xf3,
+	0xff, 0x45, 0x0a, 0xf6, 0x47, 0xfc, 0x03, 0x26, 0x52, 0xb1, 0xcc, 0x37, 0x83, 0x28, 0x4e, 0x48,
+	0x13, 0x68, 0x35, 0x00, 0xe3, 0x3b, 0x92, 0xf0, 0xb8, 0x19, 0x74, 0x20, 0xeb, 0xe5, 0xf5, 0x71,
+	0x50, 0xad, 0x96, 0x85, 0xc5, 0xc2, 0x8b, 0x62, 0x9f, 0x70, 0x73, 0x06, 0xcf, 0xd5, 0x0a, 0xc4,
+	0x7f, 0x42, 0xae, 0xba, 0xa6, 0x2a, 0x85, 0xba, 0x2e, 0x28, 0xd6, 0x15, 0xba, 0x69, 0x1f, 0x08,
+	0xea, 0x85, 0x24, 0x4b, 0x25, 0xc8, 0x71, 0xa8, 0x06, 0x00, 0x8c, 0xb9, 0x67, 0x31, 0xe2, 0x0f,
+	0xd2, 0xe3, 0xe3, 0x0f, 0x58, 0x60, 0x4d, 0xbc, 0x1b, 0x7e, 0x34, 0xf9, 0x43, 0xb2, 0x51, 0x3e,
+	0xa1, 0xba, 0x49, 0x79, 0xf2, 0x20, 0x07, 0xf1, 0x7b, 0x64, 0xb1, 0xa3, 0x80, 0xf9, 0xbc, 0x36,
+	0x93, 0x22, 0xa7, 0x02, 0xed, 0x0b, 0xff, 0x17, 0x29, 0x72, 0x29, 0x86, 0xbf, 0xc4, 0x6e, 0x60,
+	0x60, 0x07, 0xb5, 0x3a, 0xcd, 0xf0, 0xad, 0x38, 0xce, 0xb2, 0x82, 0x32, 0xef, 0xb4, 0x36, 0xef,
+	0x4f, 0xd5, 0xdb, 0x95, 0xa9, 0xc8, 0xfa, 0x2e, 0x09, 0xa0, 0x72, 0xd9, 0x12, 0x5d, 0xf9, 0x4c,
+	0x2b, 0x57, 0x3e, 0xfe, 0x90, 0xe4, 0x6c, 0x53, 0xe5, 0xab, 0x47, 0x5f, 0x1d, 0xa1, 0xdf, 0x52,
+	0xdd, 0x17, 0x1a, 0xcc, 0xbb, 0x47, 0x66, 0x19, 0x2a, 0x21, 0x4b, 0x72, 0x74, 0x04, 0xf6, 0xe9,
+	0x05, 0xbc, 0xa5, 0xff, 0x2f, 0x53, 0x64, 0xa3, 0xf4, 0xd6, 0x45, 0x61, 0x7a, 0xfb, 0x31, 0xea,
+	0xc3, 0xb9, 0x81, 0xf5, 0x37, 0x1d, 0xf0, 0x92, 0x43, 0xbc, 0xfc, 0x88, 0x1f, 0xb0, 0xa7, 0x58,
+	0xef, 0x1f, 0xb3, 0xf9, 0xbb, 0x50, 0x7f, 0xb8, 0x73, 0xf6, 0x6b, 0x92, 0xb3, 0xf5, 0xc2, 0xe9,
+	0xf6, 0xde, 0x3c, 0xa2, 0xd0, 0x20, 0xad, 0xd2, 0xc0, 0xbf, 0x4f, 0x72, 0xd4, 0x92, 0x42, 0xe3,
+	0xa6, 0x31, 0x6c, 0xbd, 0x66, 0x67, 0xc2, 0x71, 0xa7, 0x9b, 0xdf, 0xc1, 0x57, 0x03, 0xb1, 0xaf,
+	0x22, 0xe1, 0x57, 0x97, 0x50, 0x3e, 0x7f, 0x05, 0xc2, 0x5f, 0xf9, 0xe4, 0x8b, 0xc1, 0x7e, 0x9d,
+	0x5e, 0x11, 0xc1, 0xa9, 0x53, 0x6a, 0xf0, 0xbf, 0x9f, 0x66, 0xf7, 0xa2, 0x46, 0x9d, 0xb4, 0x12,
+	0x6c, 0x6f, 0x07, 0x53, 0xce, 0xb7, 0x83, 0xf4, 0xd4, 0x52, 0x7f, 0x5b, 0x0c, 0xc4, 0xcb, 0x0c,
+	0x56, 0xa0, 0x58, 0xfa, 0x0c, 0x63, 0xb3, 0xd6, 0x8d, 0x1e, 0x58, 0xe1, 0x2b, 0x18, 0x4b, 0x8d,
+	0xee, 0x5f, 0x9f, 0x36, 0xfd, 0xeb, 0xf7, 0xc9, 0x5a, 0xa7, 0xdb, 0x1a, 0x9c, 0x72, 0x33, 0xa5,
+	0xf6, 0x12, 0x30, 0xbc, 0xec, 0xb6, 0x9b, 0x5c, 0xba, 0xd9, 0x2b, 0xe9, 0x18, 0x60, 0x30, 0xf2,
+	0x92, 0xad, 0x12, 0x9d, 0x85, 0x97, 0x02, 0x4b, 0x8d, 0xff, 0xbf, 0x53, 0x24, 0x87, 0x7e, 0x2c,
+	0x1b, 0xd5, 0xfe, 0x1f, 0x11, 0xc6, 0x39, 0xf5, 0xe9, 0xb3, 0x4f, 0x7d, 0xc6, 0x39, 0xf5, 0x2b,
+	0xe4, 0xb2, 0x75, 0xe6, 0x5c, 0xb6, 0x7e, 0xc5, 0x9c, 0x21, 0x50, 0xf7, 0x0d, 0xbd, 0x5d, 0xf9,
+	0xab, 0x14, 0x59, 0x05, 0xec, 0x68, 0x8b, 0x1a, 0x2f, 0x13, 0xd8, 0x31, 0x37, 0xa5, 0x1c, 0x73,
+	0x01, 0x09, 0xcc, 0x80, 0xea, 0x36, 0x3c, 0x8a, 0xf1, 0x12, 0xd5, 0x88, 0xf0, 0x17, 0xd3, 0x88,
+	0x88, 0x5d, 0x14, 0xa9, 0x44, 0xe4, 0x36, 0x94, 0x6a, 0x5e, 0x6b, 0x30, 0xfa, 0xe2, 0xe4, 0xc8,
+	0x42, 0xae, 0x95, 0xc0, 0x04, 0xfb, 0xff, 0x6b, 0x86, 0x9c, 0x57, 0x48, 0xf1, 0xc1, 0xde, 0xd8,
+	0x7c, 0x0a, 0x47, 0x29, 0xf1, 0x02, 0x77, 0xda, 0xfe, 0x02, 0x57, 0x36, 0xf0, 0x7e, 0x4c, 0x96,
+	0x46, 0x2a, 0xb5, 0x60, 0xb0, 0x53, 0xe2, 0x76, 0xdf, 0x46, 0xc9, 0x40, 0x6f, 0xae, 0x10, 0x71,
+	0x56, 0x23, 0x22, 0xf3, 0x2e, 0xe3, 0x13, 0x1d, 0x5a, 0x39, 0xc7, 0x2a, 0x55, 0x90, 0x63, 0x1b,
+	0xcc, 0x3b, 0xb7, 0x01, 0xec, 0xec, 0x41, 0xa7, 0xcf, 0x9b, 0x2d, 0xe0, 0xe1, 0x59, 0x02, 0x28,
+	0x9f, 0x80, 0xf1, 0x1a, 0xf6, 0x98, 0xe3, 0x1d, 0xf8, 0x84, 0x15, 0xe8, 0x73, 0xdc, 0x1e, 0xb3,
+	0x88, 0x76, 0xba, 0x03, 0xfa, 0x60, 0xb3, 0x11, 0x76, 0x40, 0xf2, 0x87, 0xcc, 0xe3, 0x9e, 0x0a,
+	0xac, 0x75, 0xd1, 0x76, 0x5b, 0x54, 0xb7, 0x9b, 0x7a, 0xe8, 0x5a, 0x32, 0x0e, 0x5d, 0xca, 0x6d,
+	0xc1, 0xb2, 0xf3, 0x81, 0x81, 0x11, 0x98, 0x89, 0xf4, 0x29, 0x0a, 0x94, 0x19, 0xfe, 0x38, 0x20,
+	0x02, 0xb1, 0x3b, 0x82, 0xf0, 0x67, 0xe2, 0x55, 0xb3, 0xb8, 0xeb, 0x92, 0x10, 0x5e, 0xbf, 0xc7,
+	0xd1, 0x7b, 0x78, 0x8c, 0x89, 0x20, 0xcc, 0x24, 0xa6, 0x4f, 0x77, 0x8b, 0x01, 0x15, 0x0c, 0x17,
+	0xd9, 0xae, 0x52, 0x20, 0x4c, 0xbd, 0xe3, 0x76, 0xdf, 0x83, 0xad, 0x8f, 0x51, 0x27, 0xa9, 0x40,
+	0x83, 0x39, 0xb6, 0xff, 0x9a, 0x6b, 0xfb, 0x53, 0x93, 0x53, 0x95, 0x23, 0x78, 0x01, 0x06, 0x26,
+	0xa7, 0x06, 0xf4, 0x5f, 0x08, 0x8d, 0x12, 0x7f, 0x0d, 0xf5, 0xb1, 0x61, 0x31, 0x0a, 0xce, 0x3d,
+	0xf3, 0x43, 0xa8, 0x2f, 0xc8, 0x5a, 0x7e, 0xd4, 0x6c, 0x0d, 0x83, 0xb0, 0xd9, 0x1a, 0x3c, 0x0e,
+	0x4f, 0x07, 0x4a, 0xcc, 0x57, 0xa3, 0x1d, 0xd6, 0x3b, 0xa3, 0x1e, 0x7f, 0x51, 0x28, 0x8a, 0xfe,
+	0xbf, 0x4b, 0x91, 0x25, 0xd1, 0xfc, 0x61, 0xbf, 0x3b, 0xea, 0xc9, 0xab, 0xaa, 0x94, 0x72, 0x55,
+	0x05, 0xdf, 0xf7, 0xd8, 0x2b, 0xee, 0x0e, 0xb7, 0x0b, 0x44, 0x91, 0xb2, 0x08, 0x98, 0x0d, 0xaa,
+	0xa9, 0x2d, 0xcb, 0x74, 0xb9, 0x4f, 0xc2, 0x13, 0xd8, 0x30, 0x0f, 0x4e, 0x87, 0xe1, 0x80, 0x6d,
+	0xcb, 0xa9, 0x40, 0x05, 0x51, 0xb9, 0xf1, 0xa6, 0x35, 0x7c, 0xd9, 0x1d, 0x0d, 0x6b, 0xb5, 0x1d,
+	0xd5, 0x6f, 0x63, 0x82, 0xf1, 0xa4, 0x7c, 0xd2, 0x7d, 0xad, 0x3b, 0x6e, 0x34, 0x98, 0x5f, 0x20,
+	0x97, 0xcc, 0xe9, 0x27, 0x3d, 0x02, 0xd1, 0xa6, 0x2d, 0xad, 0xf1, 0x0c, 0x59, 0x86, 0x75, 0x62,
+	0x4e, 0x3a, 0xae, 0xf0, 0x7f, 0x95, 0x26, 0x17, 0x24, 0x28, 0x7a, 0xce, 0x2b, 0x22, 0x6f, 0xb8,
+	0xbb, 0x4b, 0x44, 0xde, 0x00, 0xf9, 0xa8, 0x5f, 0x41, 0x38, 0x4d, 0xe9, 0xdf, 0x6c, 0x9f, 0x02,
+	0x82, 0x22, 0xf7, 0x59, 0x62, 0x81, 0x19, 0x3c, 0xd4, 0x08, 0x7f, 0xc0, 0x9f, 0x02, 0xf2, 0x92,
+	0x84, 0x17, 0xb8, 0x9f, 0x82, 0x97, 0x84, 0x9f, 0x71, 0x36, 0xf2, 0x33, 0xde, 0x22, 0xcb, 0x75,
+	0x0c, 0xd2, 0x02, 0x56, 0x64, 0x8f, 0x0a, 0xf1, 0x09, 0x93, 0x01, 0x8d, 0x76, 0xf7, 0xbc, 0xba,
+	0xbb, 0xe1, 0x6b, 0xf8, 0x83, 0x3f, 0x3a, 0xac, 0xb6, 0x7e, 0x1e, 0xf2, 0xe0, 0x39, 0x03, 0x1a,
+	0x7b, 0x82, 0x43, 0x2c, 0x31, 0x17, 0xf6, 0xf0, 0x39, 0xf6, 0xf6, 0x9d, 0x85, 0x5d, 0x3c, 0xac,
+	0xf7, 0xb8, 0x68, 0x51, 0x20, 0x94, 0x79, 0xc0, 0x36, 0x6c, 0xb2, 0xab, 0x38, 0xbc, 0xcd, 0x93,
+	0x65, 0xfa, 0x70, 0x3b, 0x80, 0x33, 0x5b, 0x7d, 0x10, 0x3e, 0x19, 0x81, 0x4e, 0xed, 0x0c, 0x5b,
+	0x9d, 0x70, 0x82, 0x87, 0xdb, 0x96, 0x6f, 0xb8, 0x1a, 0xde, 0x25, 0xd7, 0xa4, 0x45, 0x68, 0x3c,
+	0xf1, 0x9f, 0xe8, 0x81, 0xf2, 0xe9, 0x40, 0xbc, 0x6a, 0xa3, 0x7f, 0xfb, 0xbf, 0x4d, 0x16, 0x8b,
+	0x34, 0x5a, 0x40, 0xf8, 0x08, 0xf1, 0x21, 0x9f, 0xdc, 0x36, 0x4d, 0x2e, 0x23, 0x1d, 0xfe, 0xc1,
+	0xbf, 0xe6, 0x7e, 0x5f, 0xfb, 0x68, 0x92, 0xae, 0x08, 0xd4, 0x4e, 0xa5, 0x60, 0x48, 0x88, 0x6c,
+	0x48, 0x27, 0x47, 0x36, 0xdc, 0x21, 0x19, 0xd8, 0x43, 0xf5, 0x56, 0xa7, 0xd5, 0x39, 0xce, 0x6b,
+	0x8e, 0xd8, 0x18, 0x9c, 0x2e, 0x67, 0xa3, 0xde, 0x0b, 0xe8, 0x03, 0x85, 0x50, 0xbc, 0x5f, 0x55,
+	0x20, 0xfe, 0x7f, 0x9b, 0x22, 0x84, 0x7b, 0xb9, 0x47, 0xed, 0xd0, 0x5b, 0x26, 0xe9, 0x16, 0x7a,
+	0x83, 0xa7, 0x82, 0x34, 0x3e, 0x75, 0x8c, 0xdd, 0x81, 0x03, 0x85, 0xc2, 0x4e, 0xfd, 0x45, 0x5b,
+	0x3e, 0xf2, 0x16, 0x45, 0x65, 0x2d, 0xa6, 0xcd, 0x17, 0xef, 0x27, 0xf4, 0xb1, 0xff, 0xb6, 0x74,
+	0xeb, 0xcf, 0x07, 0x0a, 0x24, 0xf2, 0xf8, 0xcf, 0xaa, 0x1e, 0x7f, 0xf1, 0xd5, 0x2e, 0xdb, 0x06,
+	0x73, 0xca, 0x57, 0x0c, 0xe2, 0xd8, 0x21, 0x77, 0xc9, 0x4a, 0x83, 0xae, 0x44, 0x63, 0x04, 0x07,
+	0x83, 0x10, 0x1f, 0x96, 0xf1, 0x67, 0x6b, 0xf1, 0x0a, 0xfa, 0xa8, 0x95, 0x9e, 0x20, 0x40, 0x24,
+	0xe0, 0x3d, 0xf8, 0xaa, 0xe2, 0xf5, 0x07, 0x7a, 0xe4, 0x59, 0x5d, 0xc0, 0xdb, 0x68, 0xba, 0xf5,
+	0xbc, 0x5b, 0xb7, 0x2e, 0xea, 0x37, 0xf1, 0x18, 0x4d, 0xc2, 0x1f, 0x75, 0xb2, 0x3d, 0xb3, 0x18,
+	0x28, 0x90, 0x58, 0xd0, 0xcc, 0xb2, 0x25, 0x68, 0x46, 0x7b, 0xab, 0x73, 0x21, 0xf1, 0xad, 0x4e,
+	0xc6, 0x38, 0x4b, 0xc0, 0xb1, 0x6a, 0x1d, 0x8f, 0x73, 0xd1, 0xbc, 0xc4, 0xe6, 0xf1, 0xc9, 0x74,
+	0x1f, 0x8a, 0x6c, 0xc1, 0xcf, 0xdf, 0x5b, 0xd6, 0x27, 0x1f, 0xb0, 0x3a, 0xff, 0x8e, 0x48, 0xb1,
+	0xa4, 0x7e, 0xce, 0xb9, 0xdd, 0x60, 0x17, 0xff, 0x16, 0xf3, 0xfc, 0xc5, 0xfb, 0x31, 0xdb, 0xfd,
+	0x88, 0xe5, 0x04, 0xb1, 0x20, 0x9c, 0x64, 0x40, 0x30, 0x1f, 0x34, 0xdd, 0xdf, 0x6d, 0x3e, 0x39,
+	0x11, 0x67, 0x1d, 0xef, 0xde, 0xff, 0x84, 0xac, 0xe3, 0x35, 0xf0, 0xf8, 0x29, 0xe4, 0x44, 0x90,
+	0x8a, 0x05, 0xcd, 0x36, 0xb9, 0x44, 0x9d, 0x78, 0x51, 0xcd, 0xe0, 0x9d, 0x1e, 0x02, 0xf8, 0x75,
+	0xb2, 0x1e, 0xc3, 0x33, 0xa1, 0x27, 0xf0, 0x96, 0xe1, 0x09, 0x34, 0x69, 0x21, 0x54, 0x67, 0x59,
+	0x39, 0x73, 0x63, 0xb5, 0xe6, 0x04, 0x3c, 0x8b, 0x74, 0xfd, 0x92, 0x64, 0xd8, 0x76, 0x56, 0xd0,
+	0x44, 0x3b, 0x3b, 0xa5, 0xee, 0x6c, 0x7a, 0x58, 0xc0, 0x8d, 0x29, 0x0e, 0x0b, 0xb8, 0x1b, 0xa1,
+	0xf5, 0x0b, 0x66, 0x76, 0xa0, 0x34, 0xc3, 0x82, 0xff, 0x73, 0x7c, 0x98, 0x1e, 0x1f, 0x62, 0xd2,
+	0xc3, 0x74, 0x73, 0x24, 0x52, 0xec, 0x9e, 0xad, 0xef, 0x9f, 0x31, 0x86, 0xae, 0x75, 0x7b, 0xb5,
+	0x7a, 0xfb, 0x95, 0x72, 0x34, 0x16, 0xf3, 0x4f, 0x45, 0xf3, 0x77, 0x9c, 0x00, 0xbf, 0x1b, 0x3d,
+	0xda, 0x40, 0xdf, 0xd7, 0x1a, 0x1d, 0x5e, 0x84, 0xd1, 0x7c, 0xb7, 0xe1, 0x3f, 0x21, 0x0b, 0xb2,
+	0x36, 0xe9, 0xae, 0xf5, 0x0c, 0xb3, 0xf8, 0x31, 0xdb, 0x6e, 0xea, 0x2c, 0x38, 0xe9, 0x3e, 0x32,
+	0x48, 0xb7, 0xa4, 0x8d, 0x4d, 0x32, 0x09, 0x68, 0x3e, 0xba, 0x04, 0x3b, 0xdd, 0x37, 0x3b, 0xf4,
+	0x42, 0x98, 0x1d, 0x64, 0xa8, 0x6f, 0x48, 0x92, 0x83, 0xde, 0x12, 0xc9, 0x73, 0x3a, 0x3a, 0x08,
+	0x22, 0x00, 0xad, 0x3d, 0x69, 0x75, 0xb6, 0xd5, 0xf1, 0x46, 0x00, 0xca, 0xc9, 0xbd, 0xe8, 0xc0,
+	0x83, 0xe3, 0x56, 0x20, 0xc2, 0x0f, 0x3d, 0x1d, 0x39, 0xf0, 0xa3, 0xcb, 0x89, 0x19, 0x33, 0xe7,
+	0x01, 0xf7, 0x46, 0xcd, 0xda, 0x3d, 0x72, 0x73, 0xca, 0xc2, 0xf8, 0xbf, 0x4a, 0x91, 0x95, 0xd8,
+	0x8c, 0xce, 0x7c, 0xb9, 0xcd, 0x47, 0x37, 0x15, 0x8d, 0x8e, 0xc6, 0xc7, 0xf4, 0xa8, 0x49, 0xb4,
+	0x0d, 0x5a, 0x83, 0x3b, 0x32, 0x69, 0x7c, 0x8c, 0x02, 0x53, 0x96, 0x6f, 0x46, 0x5b, 0x3e, 0xf6,
+	0x40, 0xec, 0x0d, 0xa7, 0x14, 0x2a, 0xc3, 0x08, 0xc0, 0xe9, 0xc8, 0x0f, 0x96, 0x78, 0x50, 0x8d,
+	0x00, 0xf4, 0x48, 0x53, 0x07, 0x83, 0x16, 0x48, 0xa6, 0x9d, 0x50, 0x75, 0xa0, 0x7f, 0xc4, 0xdc,
+	0xfe, 0xb6, 0x95, 0xe4, 0x2c, 0xf1, 0x1d, 0x83, 0x25, 0x18, 0xbb, 0xc6, 0xda, 0xab, 0xdb, 0xc9,
+	0xea, 0x01, 0xfc, 0xb3, 0x34, 0x21, 0x85, 0x76, 0xb7, 0xf1, 0xaa, 0xd8, 0x6f, 0x1d, 0x0d, 0xdf,
+	0xe5, 0xcd, 0xc0, 0xa0, 0x7e, 0xd2, 0x6b, 0x4b, 0x4e, 0x16, 0x45, 0xfa, 0x45, 0x2f, 0x0a, 0x72,
+	0x82, 0x73, 0x3c, 0x96, 0xf0, 0x44, 0x07, 0xd4, 0x90, 0x31, 0x50, 0xe8, 0x29, 0xd3, 0x81, 0x4c,
+	0x83, 0xd3, 0x01, 0xed, 0xef, 0xef, 0x8a, 0x37, 0x76, 0xa2, 0x4c, 0x31, 0x7f, 0x4d, 0xdf, 0xc2,
+	0xf4, 0x39, 0x6d, 0x79, 0x89, 0x7e, 0x83, 0x7d, 0xb4, 0x1a, 0x8c, 0xa6, 0x60, 0xf1, 0x8a, 0x32,
+	0xb5, 0x36, 0x5e, 0x80, 0x25, 0xd5, 0xed, 0x20, 0x7e, 0xe6, 0x3f, 0xe6, 0x67, 0xfe, 0x78, 0x85,
+	0xff, 0x53, 0xc5, 0x2d, 0x1a, 0x11, 0x67, 0x9c, 0xac, 0x8d, 0xcd, 0x8c, 0x5f, 0xa2, 0x68, 0x40,
+	0xbf, 0xa4, 0x08, 0x72, 0x15, 0xb7, 0x8c, 0xee, 0x8a, 0x96, 0x55, 0xea, 0x46, 0xa5, 0x9d, 0xd8,
+	0xea, 0x7f, 0x9c, 0x62, 0x0f, 0xf3, 0xa3, 0x1a, 0x6d, 0x9f, 0xd3, 0xd3, 0x61, 0xab, 0x53, 0x14,
+	0x14, 0xc4, 0x9d, 0xae, 0x82, 0x92, 0xf2, 0x91, 0x70, 0x3e, 0x99, 0xb2, 0xef, 0xcd, 0x69, 0x75,
+	0x6f, 0xfe, 0x01, 0x23, 0x54, 0x6c, 0x10, 0x96, 0xb9, 0x4c, 0xb9, 0xe7, 0xe2, 0xe4, 0xcd, 0xdf,
+	0x22, 0x37, 0x02, 0xd0, 0x94, 0xf2, 0xb1, 0x57, 0xe1, 0x60, 0xbf, 0x0a, 0x26, 0x4e, 0x13, 0x04,
+	0x4e, 0xab, 0xde, 0x4e, 0xb8, 0x00, 0xfb, 0x8a, 0xdc, 0x4c, 0xfe, 0x30, 0x0a, 0x07, 0x6c, 0x8c,
+	0x7a, 0x83, 0x9a, 0x8c, 0x97, 0xa1, 0xd6, 0x9a, 0x00, 0x30, 0x4b, 0xb1, 0x81, 0x75, 0xfc, 0x60,
+	0xce, 0x8b, 0xfe, 0x7d, 0x76, 0xc0, 0x38, 0xeb, 0xa8, 0xfe, 0x12, 0xdf, 0x2d, 0x7c, 0x33, 0x63,
+	0xa2, 0xc7, 0xfd, 0x3e, 0x9d, 0x33, 0x8d, 0x75, 0xc3, 0xfc, 0x56, 0xdc, 0xea, 0x37, 0xc1, 0xc9,
+	0x1e, 0x6d, 0x30, 0xf9, 0x3e, 0x7e, 0x18, 0x76, 0xc2, 0xbe, 0x42, 0xbd, 0x76, 0x0b, 0x06, 0x59,
+	0x08, 0xe1, 0xa0, 0x72, 0xc4, 0x02, 0x25, 0xdd, 0x53, 0xfc, 0xb3, 0x14, 0xb9, 0x3d, 0xfe, 0xeb,
+	0xe8, 0x9c, 0x3f, 0x6c, 0x0f, 0x68, 0x8d, 0x38, 0xe7, 0xf3, 0x22, 0x65, 0x08, 0xf8, 0x93, 0x66,
+	0x4d, 0xc1, 0x49, 0xf2, 0x12, 0x63, 0x94, 0x3a, 0xfb, 0x80, 0x3f, 0xb8, 0xc4, 0x52, 0x72, 0xd4,
+	0x2d, 0x75, 0x21, 0x53, 0xeb, 0x2c, 0x78, 0x76, 0x6f, 0xb7, 0x35, 0x38, 0x11, 0xc1, 0xcc, 0xf2,
+	0xce, 0x01, 0x76, 0xd2, 0x05, 0xa3, 0x2e, 0xc9, 0x79, 0x8c, 0x47, 0xf1, 0xb4, 0x91, 0x38, 0xa1,
+	0x19, 0x1e, 0xd5, 0x81, 0x95, 0x01, 0x0f, 0x54, 0xf2, 0x37, 0x02, 0x2a, 0x8c, 0x6a, 0xcf, 0x26,
+	0x18, 0xa1, 0x0d, 0x95, 0xea, 0x0a, 0xc4, 0x7f, 0x4c, 0x36, 0xed, 0x83, 0xe4, 0xc4, 0xfa, 0xd4,
+	0xd8, 0x4b, 0x17, 0x31, 0x7a, 0x48, 0x6b, 0xad, 0xdc, 0x19, 0xaf, 0x17, 0xe0, 0xa8, 0xde, 0x57,
+	0xea, 0xc7, 0x1d, 0xef, 0xc1, 0x4c, 0x8e, 0x7f, 0xc2, 0xcd, 0x64, 0x9f, 0x6c, 0xd1, 0xb1, 0x6d,
+	0xf3, 0xd8, 0xa8, 0xa0, 0xdb, 0x6e, 0x77, 0x41, 0x59, 0x69, 0x54, 0xfc, 0x9a, 0xac, 0xda, 0xea,
+	0x9d, 0x94, 0x4c, 0x8a, 0xbd, 0xd2, 0x69, 0x35, 0x15, 0xa3, 0xd5, 0x01, 0xb9, 0x9e, 0x30, 0x1e,
+	0xf9, 0x88, 0x41, 0x27, 0x18, 0x73, 0x40, 0xdb, 0x3e, 0x91, 0x54, 0x3b, 0x60, 0xdb, 0xb3, 0xc2,
+	0x9c, 0x4d, 0x3f, 0x0f, 0x9b, 0x4c, 0x99, 0x57, 0x8e, 0x8e, 0x60, 0xd7, 0x28, 0x06, 0xa5, 0xfd,
+	0x60, 0x00, 0xb3, 0x01, 0xe1, 0xaa, 0x5e, 0x9d, 0xcb, 0xb2, 0x5f, 0x24, 0xab, 0x3a, 0xce, 0x31,
+	0xef, 0x13, 0xa0, 0x87, 0x86, 0x82, 0x08, 0x0b, 0xfe, 0xef, 0x92, 0x35, 0x1d, 0x0b, 0xdf, 0x5e,
+	0xf6, 0x77, 0x13, 0x16, 0x04, 0x7f, 0x9a, 0x22, 0x7e, 0xd2, 0xf4, 0x38, 0xd9, 0xee, 0xb1, 0x47,
+	0x80, 0xec, 0xf9, 0x93, 0x42, 0x37, 0xdb, 0x04, 0x02, 0xd1, 0xd0, 0xfb, 0x0d, 0xe5, 0xbd, 0x48,
+	0x3a, 0x8a, 0x90, 0xb4, 0x8e, 0x37, 0x7a, 0x34, 0xe2, 0xff, 0x71, 0x9a, 0x5c, 0x40, 0x54, 0x4f,
+	0x68, 0xd0, 0xbb, 0xb8, 0x56, 0x61, 0x21, 0x9b, 0x29, 0x57, 0xb8, 0x7a, 0xda, 0x19, 0xae, 0x3e,
+	0x65, 0x7b, 0x85, 0x38, 0xad, 0xbf, 0x42, 0x94, 0x01, 0xe3, 0x33, 0x7a, 0xc0, 0xb8, 0x1e, 0x6a,
+	0x3e, 0x6b, 0x86, 0x9a, 0x03, 0x43, 0x86, 0x18, 0x99, 0x1f, 0x85, 0xe0, 0x28, 0x10, 0x5d, 0xfe,
+	0xcc, 0x27, 0x46, 0xfd, 0x2f, 0x98, 0x51, 0xff, 0x7f, 0x44, 0xae, 0x88, 0xa8, 0x7f, 0x9d, 0x16,
+	0xe3, 0xcc, 0x8d, 0x8f, 0xc9, 0x74, 0x0b, 0x9a, 0xf1, 0x17, 0x3e, 0x17, 0xa3, 0xf7, 0x09, 0x11,
+	0x06, 0xd6, 0xc0, 0xdf, 0x22, 0x57, 0x5d, 0x3d, 0xf0, 0x0d, 0xae, 0x5e, 0x03, 0xcb, 0xda, 0x71,
+	0x67, 0x4b, 0xff, 0x91, 0x62, 0xc9, 0xa8, 0x5f, 0x49, 0xbf, 0xf0, 0x0c, 0xed, 0x5e, 0x7b, 0x7d,
+	0x67, 0x0e, 0x00, 0x5b, 0x50, 0x79, 0xb5, 0xdd, 0xa6, 0xf1, 0xfa, 0x51, 0xf5, 0x04, 0xf2, 0x2a,
+	0xfe, 0x09, 0x9f, 0xce, 0x6b, 0x36, 0x9d, 0xbd, 0xf0, 0x6d, 0x14, 0xb7, 0x08, 0xeb, 0x3f, 0x8e,
+	0x9e, 0x34, 0xee, 0x32, 0x1c, 0x84, 0xfd, 0xd7, 0x21, 0x67, 0x32, 0x51, 0xa4, 0xce, 0x5c, 0xfc,
+	0x93, 0xa9, 0xd1, 0x5a, 0x6d, 0x87, 0xf3, 0x9a, 0x01, 0x85, 0x69, 0x5c, 0xb6, 0xf6, 0xcb, 0x09,
+	0x62, 0xb9, 0x32, 0xf4, 0xff, 0x51, 0x9a, 0x2c, 0xef, 0x82, 0xf0, 0x69, 0xd1, 0x50, 0x7f, 0xbc,
+	0x23, 0x98, 0xc4, 0xb5, 0x47, 0x2f, 0xc9, 0x1a, 0xca, 0x4b, 0x5d, 0x5e, 0x62, 0x27, 0x8f, 0xc6,
+	0x9e, 0x96, 0x37, 0x2e, 0x02, 0x60, 0xad, 0xc8, 0x47, 0x36, 0x23, 0x6a, 0x45, 0x2a, 0x32, 0xed,
+	0x8d, 0xe0, 0xac, 0xf9, 0x46, 0x10, 0x46, 0xd5, 0xec, 0xf3, 0xc7, 0xbb, 0xf0, 0x97, 0x9c, 0xcc,
+	0xbc, 0xbe, 0xc1, 0xa4, 0x1c, 0xa0, 0xee, 0xee, 0x45, 0xe5, 0x85, 0x98, 0xe6, 0x18, 0x23, 0x89,
+	0x8e, 0xb1, 0xf3, 0xa6, 0x49, 0xf2, 0x9c, 0x5c, 0x46, 0xcf, 0x96, 0x4e, 0x29, 0xb1, 0xa0, 0x3f,
+	0x24, 0xcb, 0x27, 0x5a, 0x05, 0x37, 0x9d, 0x59, 0xd4, 0x85, 0xf1, 0x89, 0xd1, 0xd2, 0xff, 0x8c,
+	0x6c, 0xda, 0x51, 0x3b, 0x1c, 0x67, 0x77, 0xd8, 0xfb, 0x04, 0xfb, 0x38, 0xcc, 0xb6, 0x4f, 0x99,
+	0x85, 0xee, 0x40, 0xfc, 0x3e, 0x83, 0x7e, 0x2e, 0xee, 0xc4, 0x3f, 0x3c, 0x3d, 0xae, 0x92, 0x4d,
+	0x3b, 0x6a, 0xbe, 0xb5, 0xbe, 0x43, 0x2e, 0xa3, 0x37, 0x6d, 0x32, 0x12, 0x00, 0x3a, 0x7b, 0x73,
+	0x8e, 0xee, 0x27, 0xf8, 0x8a, 0x4e, 0xaf, 0x7d, 0x47, 0x27, 0x5c, 0x0b, 0xcd, 0xbc, 0x18, 0xae,
+	0x09, 0x1d, 0x71, 0x77, 0x0c, 0x47, 0x9c, 0x8d, 0x5a, 0xc2, 0x52, 0xf8, 0x3b, 0x51, 0x5a, 0x19,
+	0xd9, 0x22, 0x26, 0xb7, 0xef, 0x90, 0x8c, 0x4e, 0xdc, 0x72, 0x91, 0x53, 0x26, 0x06, 0x3f, 0x43,
+	0x12, 0x11, 0x8b, 0x62, 0x83, 0x73, 0xd2, 0xf5, 0x84, 0xd1, 0x24, 0x48, 0x9f, 0x47, 0x24, 0xc7,
+	0x84, 0xa8, 0xfe, 0xd9, 0x3b, 0x4c, 0x80, 0xda, 0xd8, 0x56, 0x4c, 0x7c, 0x9d, 0xff, 0x6e, 0x8a,
+	0x64, 0x98, 0x15, 0xb0, 0xd3, 0x3d, 0x56, 0xef, 0xa3, 0x4f, 0xba, 0xcd, 0x51, 0x5b, 0x7b, 0x27,
+	0x14, 0x41, 0xa8, 0x50, 0xa0, 0x37, 0x7c, 0x4f, 0x5b, 0xcd, 0xe1, 0x4b, 0xe1, 0x8e, 0x92, 0x80,
+	0x98, 0xfb, 0x66, 0xca, 0xe2, 0xbe, 0x01, 0x91, 0xfe, 0xa2, 0xc5, 0x1e, 0x26, 0x70, 0x7a, 0x89,
+	0xa2, 0xff, 0x9f, 0x41, 0xee, 0x8a, 0x01, 0x9d, 0x29, 0x46, 0x43, 0x7b, 0x67, 0x8d, 0x7d, 0xba,
+	0xde, 0x59, 0x4f, 0x9b, 0xc1, 0x0c, 0xf4, 0xa6, 0x58, 0x79, 0x25, 0x3d, 0x13, 0x88, 0x22, 0xd3,
+	0x3d, 0x47, 0x85, 0x97, 0xf5, 0x56, 0x87, 0x47, 0xc4, 0x88, 0xa2, 0xfa, 0x66, 0x13, 0xbd, 0x62,
+	0xf2, 0xcd, 0x26, 0x93, 0xa8, 0x0d, 0xea, 0x34, 0x1d, 0x0d, 0x98, 0x18, 0x9e, 0x09, 0x22, 0x40,
+	0x62, 0x58, 0xa1, 0x88, 0x33, 0x21, 0xf6, 0x38, 0x93, 0xf3, 0x5a, 0x9c, 0x09, 0x7d, 0x0d, 0x2c,
+	0x2f, 0x53, 0x16, 0x99, 0x20, 0x41, 0xc7, 0xad, 0xb1, 0x9c, 0xd1, 0x15, 0x8b, 0xff, 0x7f, 0x52,
+	0x11, 0x71, 0x6b, 0x2e, 0xe2, 0x6e, 0x91, 0xf3, 0xad, 0x13, 0x30, 0xe0, 0x5a, 0xf0, 0x45, 0xfb,
+	0x94, 0xab, 0x5c, 0x15, 0xf4, 0x5e, 0xa4, 0x86, 0x0d, 0xd5, 0x63, 0x77, 0x3c, 0x3c, 0xee, 0x88,
+	0x15, 0xb4, 0xa9, 0xcc, 0x4e, 0x32, 0x95, 0xc4, 0x44, 0x46, 0x32, 0xd3, 0xc6, 0xbc, 0x92, 0x69,
+	0xc3, 0xff, 0x8f, 0x29, 0x32, 0x2f, 0x10, 0xea, 0x5a, 0x2f, 0x65, 0x6a, 0x3d, 0xd7, 0x43, 0x4c,
+	0x19, 0x6e, 0x33, 0xa5, 0x86, 0xdb, 0x50, 0xff, 0xeb, 0xcb, 0x53, 0x35, 0xc3, 0xcd, 0x62, 0xa0,
+	0x40, 0x98, 0x00, 0xc3, 0xc0, 0x98, 0x99, 0x48, 0x80, 0xe9, 0x3c, 0x2e, 0x42, 0x63, 0x68, 0xdb,
+	0x21, 0xb6, 0x9d, 0x8d, 0x54, 0x83, 0xbe, 0x64, 0x01, 0x6f, 0xe1, 0xff, 0x80, 0x5c, 0xc3, 0x30,
+	0x23, 0x51, 0x3f, 0xd8, 0xee, 0xf6, 0xf9, 0x11, 0x60, 0x8c, 0x91, 0x76, 0x9f, 0x6c, 0xc5, 0x3f,
+	0x1d, 0x1b, 0xe3, 0xd7, 0x64, 0x4e, 0xec, 0x33, 0xf7, 0x76, 0xc6, 0x97, 0x5d, 0x87, 0xcc, 0xc1,
+	0x7a, 0x96, 0x81, 0x9d, 0xb1, 0x83, 0x3f, 0x60, 0x57, 0x12, 0xb2, 0x83, 0x89, 0x15, 0xd1, 0x4d,
+	0x43, 0x11, 0x2d, 0x6a, 0xeb, 0x28, 0x54, 0xd0, 0xbf, 0x4a, 0x45, 0x49, 0x95, 0x6a, 0xe1, 0x49,
+	0xaf, 0x4d, 0x39, 0x72, 0x12, 0xd3, 0xd1, 0x7e, 0x5e, 0x62, 0x8f, 0x50, 0x22, 0xce, 0x62, 0x8f,
+	0x50, 0x90, 0xad, 0xb4, 0xd3, 0xd7, 0x8c, 0x79, 0xfa, 0xd2, 0x18, 0x7c, 0x36, 0xd1, 0xac, 0x9b,
+	0x33, 0xcd, 0xba, 0x27, 0xe4, 0x0a, 0xda, 0x5e, 0xe6, 0x3c, 0xc4, 0x0a, 0xc0, 0x76, 0x1d, 0x72,
+	0x10, 0x37, 0x61, 0xb4, 0x5c, 0x46, 0xb2, 0xb9, 0x6c, 0xe5, 0x7f, 0x4e, 0xae, 0xba, 0x50, 0x3a,
+	0x0c, 0xba, 0xbb, 0x78, 0xf4, 0x71, 0x8c, 0xc0, 0x6c, 0x5d, 0xd1, 0xf2, 0x65, 0xc5, 0x90, 0x9f,
+	0x7d, 0xc0, 0x40, 0x03, 0xb4, 0xb7, 0x3e, 0x1c, 0x0d, 0xe0, 0xb8, 0xe7, 0x42, 0x29, 0x7f, 0xe9,
+	0xe1, 0x0a, 0x5a, 0x65, 0x93, 0x4e, 0x1b, 0x50, 0xba, 0x3e, 0xe0, 0x28, 0x77, 0xd0, 0x7d, 0x65,
+	0xd6, 0xbf, 0xa3, 0x29, 0x77, 0x42, 0xae, 0x38, 0xb0, 0x4d, 0xb8, 0x87, 0xee, 0x1a, 0x7b, 0xc8,
+	0x4e, 0x33, 0x99, 0x9b, 0x26, 0x45, 0xae, 0xd6, 0xfa, 0xad, 0xe3, 0xe3, 0xb0, 0x3f, 0x21, 0x45,
+	0x9c, 0xa2, 0xfb, 0xf7, 0xb4, 0xe7, 0xe3, 0x77, 0xd9, 0x35, 0x5d, 0x22, 0xe6, 0x0f, 0xf7, 0x86,
+	0xfc, 0x94, 0x6c, 0x3a, 0xba, 0xc2, 0x60, 0x00, 0x97, 0xd8, 0xd4, 0x9e, 0xfd, 0xa7, 0x27, 0x7d,
+	0xf6, 0x3f, 0xa5, 0x3e, 0xfb, 0xff, 0x5b, 0x29, 0x72, 0xcd, 0x39, 0x4d, 0xbe, 0x64, 0x37, 0xc9,
+	0x92, 0xf0, 0x98, 0xa8, 0xab, 0xa6, 0x03, 0xbd, 0xef, 0x1b, 0xcf, 0xff, 0xb7, 0x12, 0x28, 0xa8,
+	0x07, 0x01, 0xfc, 0x22, 0x45, 0x96, 0xb4, 0x90, 0x31, 0x3d, 0xfa, 0x61, 0x49, 0x44, 0x3f, 0x24,
+	0x87, 0xc2, 0x51, 0xd5, 0xdb, 0xea, 0x48, 0x1f, 0x2e, 0x16, 0xa2, 0x17, 0x2c, 0xd3, 0xea, 0x0b,
+	0x16, 0xe5, 0x7d, 0xcd, 0x8c, 0xf6, 0xbe, 0x86, 0xa6, 0xc5, 0x28, 0xbd, 0x05, 0x43, 0x53, 0x8c,
+	0x44, 0xeb, 0x33, 0xe5, 0xec, 0x33, 0x6d, 0xed, 0x73, 0x4a, 0xe9, 0xd3, 0xff, 0xaf, 0x29, 0xb2,
+	0x5a, 0xb0, 0xa4, 0x24, 0x9d, 0x48, 0xf4, 0x8b, 0xe7, 0x73, 0x53, 0xca, 0xf3, 0x39, 0x6a, 0xe0,
+	0x88, 0xb7, 0x95, 0xd3, 0xec, 0x89, 0x9a, 0x2c, 0x7b, 0xbf, 0x09, 0x4b, 0xa6, 0x4c, 0x63, 0xc0,
+	0x0d, 0x8b, 0x0c, 0x06, 0x45, 0x44, 0x15, 0x81, 0xde, 0xec, 0xbd, 0x94, 0x42, 0x83, 0x5c, 0x47,
+	0x09, 0x6e, 0x9b, 0xa5, 0xd8, 0x8d, 0x3f, 0x26, 0x4b, 0x0d, 0x15, 0xce, 0x25, 0x23, 0x73, 0x55,
+	0x5a, 0xbf, 0xd3, 0x9b, 0x83, 0x5d, 0xe2, 0x27, 0x75, 0xe2, 0x50, 0x15, 0x9f, 0xb3, 0xf0, 0xa4,
+	0xa4, 0x71, 0x99, 0x5f, 0xd4, 0xd9, 0xb3, 0xb8, 0xc4, 0x4e, 0xde, 0x77, 0x2a, 0x40, 0x2f, 0x94,
+	0xf6, 0xdf, 0x24, 0xbd, 0x6e, 0x12, 0x3f, 0xa9, 0x13, 0xae, 0x03, 0xbe, 0x47, 0xae, 0xa3, 0x96,
+	0x38, 0x0b, 0x89, 0x00, 0x75, 0xd2, 0x47, 0x1c, 0xf5, 0x3e, 0xde, 0x40, 0xd8, 0xda, 0xbc, 0xa3,
+	0x8a, 0x19, 0xe1, 0x1d, 0x82, 0x03, 0xe3, 0x84, 0x6a, 0xe6, 0x73, 0x43, 0xcd, 0xb8, 0x09, 0x2a,
+	0x54, 0xcd, 0xff, 0x48, 0x91, 0xcb, 0xfc, 0xac, 0xfe, 0x00, 0x36, 0xff, 0x4b, 0x21, 0xd3, 0xc6,
+	0xff, 0x80, 0x84, 0xf2, 0x83, 0x10, 0x69, 0xfd, 0x07, 0x21, 0xe8, 0x11, 0x91, 0x3b, 0xf5, 0x78,
+	0xdc, 0x3e, 0x2f, 0x5a, 0xbd, 0xe0, 0xce, 0xa8, 0x7d, 0xe6, 0x6a, 0x98, 0x55, 0x5c, 0x0d, 0xf4,
+	0x12, 0x59, 0xbe, 0x7e, 0x1b, 0xc0, 0x56, 0xa5, 0x1e, 0x3d, 0x15, 0xa4, 0xdb, 0x86, 0xf3, 0x86,
+	0x6d, 0x48, 0x9d, 0x3f, 0xf6, 0xa9, 0xf2, 0x45, 0xfd, 0x9f, 0x29, 0x72, 0x43, 0xcb, 0xe6, 0x55,
+	0xe9, 0xbc, 0xe8, 0xd6, 0xfb, 0xf4, 0x8e, 0x92, 0x5d, 0x69, 0x2a, 0x86, 0xf8, 0x70, 0xd8, 0xe6,
+	0x72, 0x93, 0xfe, 0x69, 0x66, 0xf7, 0x49, 0xc7, 0xb3, 0xfb, 0x44, 0x79, 0x78, 0xa6, 0xb4, 0x3c,
+	0x3c, 0x25, 0xae, 0x9f, 0xa7, 0xd9, 0x7a, 0x7d, 0x11, 0xcb, 0x58, 0x66, 0x1f, 0xc2, 0x87, 0x53,
+	0xd2, 0x3f, 0x25, 0x37, 0x93, 0xfb, 0xe3, 0x9c, 0xa7, 0x65, 0x71, 0x5c, 0x10, 0x59, 0x1c, 0xb5,
+	0x7b, 0x86, 0xb4, 0x79, 0xcf, 0xf9, 0x6f, 0x69, 0x66, 0x10, 0x2b, 0x5a, 0x07, 0xba, 0x77, 0x27,
+	0xe3, 0xf7, 0x35, 0x32, 0xde, 0x54, 0xd3, 0xdb, 0xe8, 0x3d, 0xc7, 0x52, 0x76, 0x6b, 0xba, 0x61,
+	0xc6, 0xa2, 0x1b, 0xa2, 0x09, 0xce, 0x9a, 0x17, 0x29, 0x34, 0xf3, 0xe7, 0x40, 0x51, 0x1b, 0xbc,
+	0x24, 0xe0, 0x0f, 0x30, 0x7f, 0xc4, 0x62, 0xc0, 0x4b, 0xef, 0xbe, 0x4a, 0x01, 0xf1, 0x95, 0xe0,
+	0x5e, 0x63, 0x4a, 0xef, 0x28, 0x70, 0x4e, 0xc9, 0x8d, 0x44, 0x9c, 0x13, 0x8a, 0x9c, 0x7b, 0x86,
+	0xc8, 0xc9, 0xb9, 0x69, 0x2f, 0x85, 0xce, 0x8f, 0xc8, 0x0d, 0x2d, 0x69, 0x8e, 0x63, 0x9f, 0x59,
+	0x99, 0xc4, 0xbf, 0x45, 0x6e, 0x26, 0x7f, 0xcc, 0x77, 0xf3, 0xdf, 0x03, 0xc9, 0x56, 0x0d, 0x3b,
+	0x4d, 0xde, 0xac, 0x06, 0x18, 0x31, 0x03, 0xa4, 0xf3, 0x38, 0x9d, 0x6c, 0x89, 0xe1, 0x85, 0xc3,
+	0x94, 0xbc, 0x70, 0x48, 0x4c, 0xba, 0x49, 0xdd, 0x42, 0xdd, 0x91, 0x90, 0x69, 0xa2, 0x48, 0x73,
+	0x25, 0x6c, 0xda, 0xc7, 0x64, 0xdb, 0x66, 0x32, 0x59, 0x2a, 0x40, 0x59, 0x66, 0x53, 0xee, 0x94,
+	0xc2, 0x82, 0x92, 0x16, 0x75, 0xca, 0x95, 0x16, 0x75, 0xda, 0x91, 0x16, 0x75, 0xc6, 0x48, 0x8b,
+	0x1a, 0xd9, 0xdb, 0xb3, 0x66, 0x12, 0xd3, 0x0a, 0xb9, 0x86, 0x91, 0xa0, 0x1f, 0xc8, 0xff, 0xe1,
+	0xff, 0x84, 0x6c, 0xc5, 0x11, 0xbe, 0x9b, 0xab, 0xc3, 0x2f, 0x90, 0x75, 0x03, 0x97, 0x4a, 0xc9,
+	0x86, 0xc2, 0xb2, 0x58, 0xa0, 0x6a, 0xa5, 0xd7, 0xe0, 0x0f, 0xe5, 0x41, 0xad, 0xd0, 0xbf, 0xfd,
+	0x7b, 0xe4, 0xaa, 0xf6, 0x38, 0xa7, 0xda, 0x3a, 0xa6, 0x0f, 0xe1, 0x41, 0x5f, 0xb9, 0x5d, 0x42,
+	0x79, 0x72, 0xcd, 0xf9, 0x4d, 0xb4, 0x71, 0x06, 0x12, 0xca, 0xbf, 0x55, 0x20, 0xb4, 0x5b, 0x8d,
+	0x8f, 0x27, 0xe9, 0xf6, 0x3a, 0xb9, 0xe6, 0xfc, 0x86, 0xb3, 0xfd, 0x13, 0xca, 0xf5, 0xe2, 0x39,
+	0x57, 0xf4, 0x83, 0x52, 0xe3, 0xd6, 0x4a, 0x35, 0xbb, 0xd3, 0xba, 0xd9, 0x4d, 0xf5, 0xa6, 0x1d,
+	0x25, 0xef, 0xf2, 0x5f, 0xa7, 0x44, 0x0e, 0x15, 0x9e, 0xa6, 0x7f, 0x22, 0xe3, 0xdf, 0x27, 0x8b,
+	0x70, 0x84, 0x60, 0x6e, 0x79, 0x16, 0x8f, 0xc2, 0xfd, 0xe5, 0x2a, 0x8c, 0xa5, 0x85, 0x51, 0x42,
+	0x20, 0x9e, 0x8c, 0xba, 0x3c, 0x7b, 0xf2, 0x52, 0x10, 0xaf, 0x18, 0x2f, 0xca, 0x23, 0x33, 0x7f,
+	0xd6, 0x34, 0xf3, 0xcb, 0x24, 0xc7, 0x1d, 0x35, 0xea, 0x44, 0x04, 0xd5, 0x3e, 0x25, 0x73, 0x3d,
+	0x84, 0x70, 0x4b, 0x55, 0xc9, 0xc1, 0x22, 0x9a, 0x8a, 0x16, 0xf4, 0x4a, 0xca, 0x8a, 0xca, 0x61,
+	0xc5, 0x7f, 0xc2, 0x62, 0xc5, 0xac, 0xdd, 0x9a, 0x4d, 0x1f, 0x62, 0x92, 0x66, 0x2b, 0xda, 0x33,
+	0x0d, 0xb1, 0x2c, 0xc2, 0x77, 0xdf, 0x7f, 0xb6, 0x32, 0x1e, 0xd6, 0x3a, 0x2c, 0xea, 0xce, 0xe2,
+	0x9e, 0x9a, 0x49, 0x26, 0x78, 0x45, 0xdc, 0xe6, 0xd9, 0x91, 0x95, 0x31, 0x5f, 0x87, 0x56, 0xf9,
+	0x8e, 0xda, 0x8f, 0xa7, 0xcb, 0x30, 0x51, 0xbd, 0x4f, 0xba, 0x0c, 0x7d, 0xcc, 0x42, 0xd7, 0xbd,
+	0xc2, 0x5c, 0x33, 0x9d, 0x57, 0x1d, 0x30, 0x37, 0xf9, 0x0f, 0x45, 0x7c, 0x63, 0xb9, 0x78, 0xfe,
+	0x53, 0x8a, 0x5c, 0xb4, 0x74, 0x35, 0x26, 0x19, 0x4f, 0x3c, 0x17, 0xb7, 0xa6, 0x09, 0xa7, 0x92,
+	0xd2, 0xf3, 0x4c, 0x1b, 0xd1, 0x2c, 0x34, 0xfa, 0xed, 0xcd, 0xab, 0x72, 0x51, 0x58, 0xf3, 0xac,
+	0x40, 0x55, 0x12, 0x7d, 0x5b, 0x02, 0xd2, 0x8a, 0x47, 0xb4, 0x89, 0xa2, 0x99, 0xce, 0x67, 0x2e,
+	0x96, 0xce, 0x87, 0x27, 0xe1, 0xb0, 0x12, 0x30, 0x29, 0x09, 0x87, 0xed, 0x03, 0xb1, 0x26, 0xcf,
+	0xc9, 0xf5, 0x07, 0xa3, 0xf6, 0x2b, 0xdc, 0xa5, 0x95, 0xbe, 0x96, 0x91, 0x51, 0xae, 0xcb, 0xfd,
+	0x58, 0xd2, 0x99, 0xac, 0x2b, 0x9f, 0xb0, 0xf2, 0x86, 0xe8, 0xcf, 0x53, 0x64, 0x85, 0xe2, 0x8e,
+	0xd2, 0x01, 0xd2, 0x07, 0xa5, 0xf6, 0xbc, 0x17, 0xd6, 0x1c, 0xe8, 0x5c, 0x60, 0x89, 0x08, 0x29,
+	0x5e, 0xd4, 0x9d, 0x62, 0xd3, 0x93, 0x3a, 0xc5, 0x54, 0x3d, 0xef, 0xff, 0x45, 0x8a, 0xf8, 0x49,
+	0xd3, 0x3e, 0x43, 0x52, 0x0c, 0x68, 0xc3, 0x45, 0xa7, 0x1a, 0x9d, 0xaa, 0xc1, 0x68, 0xfc, 0x02,
+	0x92, 0x5b, 0x38, 0x1f, 0xd9, 0x83, 0xf0, 0x18, 0x6d, 0x02, 0xd1, 0xca, 0xff, 0x8c, 0x7a, 0x92,
+	0xc2, 0xc6, 0x2b, 0x4b, 0xbe, 0x8c, 0x7e, 0xd8, 0xab, 0xb7, 0xfa, 0x3c, 0x9c, 0x95, 0x97, 0xfc,
+	0xaf, 0xc8, 0x22, 0x6f, 0x5a, 0x1e, 0x0c, 0x46, 0xa1, 0xc5, 0xa0, 0x1e, 0x7f, 0x9e, 0x60, 0x41,
+	0x89, 0x14, 0x9b, 0xa4, 0xb8, 0x2c, 0xfb, 0xff, 0x64, 0x8a, 0xac, 0x19, 0x03, 0xe2, 0x24, 0xba,
+	0x43, 0x32, 0x4a, 0x36, 0x0b, 0x35, 0x9b, 0x53, 0x0c, 0x8e, 0x6f, 0x31, 0x19, 0x0f, 0x6a, 0xbf,
+	0x7a, 0xa1, 0xc2, 0xa8, 0x2b, 0x12, 0x56, 0x9f, 0xa9, 0x3d, 0x2d, 0x8f, 0x8d, 0x06, 0xf4, 0x7e,
+	0x42, 0xd6, 0xbb, 0xfd, 0x1e, 0xe8, 0xdf, 0xb0, 0xc9, 0xb9, 0x7a, 0xbf, 0xcb, 0x72, 0x24, 0x8a,
+	0x63, 0x0f, 0xf3, 0x83, 0xa9, 0x24, 0x09, 0x5c, 0x1f, 0x78, 0x8f, 0xc8, 0xa5, 0x93, 0x16, 0x34,
+	0x64, 0x3f, 0x3a, 0xab, 0xa1, 0x9a, 0x71, 0xa0, 0x72, 0xb4, 0xf7, 0x1e, 0x90, 0x8b, 0xad, 0x0e,
+	0x9c, 0x54, 0x5a, 0x4d, 0x35, 0x55, 0x08, 0xcf, 0x07, 0x1a, 0x47, 0x63, 0x6b, 0x4c, 0x71, 0x9c,
+	0xf0, 0x37, 0x9e, 0x61, 0x73, 0x37, 0x5f, 0x60, 0x93, 0xc6, 0x03, 0xbe, 0x15, 0x87, 0xa5, 0xf1,
+	0x9d, 0x4d, 0x32, 0x2f, 0x72, 0xd7, 0x7b, 0x73, 0x64, 0x2a, 0x78, 0xf6, 0x45, 0xe6, 0x1c, 0xfe,
+	0x71, 0x2f, 0x93, 0xba, 0xf3, 0xdb, 0x2c, 0x11, 0x81, 0xfc, 0x49, 0xae, 0x4b, 0xc4, 0xdb, 0xcd,
+	0x3f, 0x2b, 0xef, 0x96, 0x7f, 0x5a, 0x3a, 0x2c, 0xe6, 0x6b, 0xf9, 0xc3, 0x20, 0x5f, 0x2b, 0x41,
+	0xfb, 0x35, 0xb2, 0xb2, 0x5b, 0xde, 0x43, 0x78, 0xed, 0xd9, 0xe1, 0x7e, 0xe5, 0x69, 0x29, 0x80,
+	0xaf, 0xff, 0xe1, 0x22, 0x59, 0x90, 0x1b, 0xcd, 0x5b, 0x21, 0x4b, 0x07, 0x7b, 0x8f, 0xf7, 0x2a,
+	0x4f, 0xf7, 0x0e, 0x4b, 0x41, 0x50, 0x09, 0xe0, 0xbb, 0x6b, 0xe4, 0xf2, 0x5e, 0xa5, 0x58, 0x3a,
+	0xac, 0x96, 0xaa, 0xd5, 0x72, 0x65, 0xef, 0xb0, 0x58, 0x29, 0x55, 0x0f, 0xf7, 0x2a, 0xb5, 0xc3,
+	0xd2, 0xb3, 0x72, 0xb5, 0x96, 0x49, 0x01, 0x17, 0x5c, 0xd5, 0x1a, 0x14, 0x2a, 0x7b, 0x85, 0x83,
+	0x20, 0x28, 0xed, 0xd5, 0x0e, 0x0f, 0xf6, 0x8b, 0xb4, 0xf3, 0x34, 0x28, 0x9d, 0x9c, 0xd6, 0xa6,
+	0xbc, 0xf7, 0x65, 0x7e, 0xa7, 0x5c, 0x3c, 0xdc, 0xcf, 0xd7, 0x0a, 0x8f, 0x32, 0x53, 0xb4, 0x93,
+	0xfc, 0xfe, 0xfe, 0x61, 0xf5, 0x71, 0xe9, 0xf9, 0xe1, 0xe3, 0xd2, 0x63, 0x86, 0x1f, 0xf0, 0x6c,
+	0x97, 0x1f, 0x1e, 0x04, 0xa5, 0x62, 0x66, 0x1a, 0xa4, 0x76, 0x56, 0x7c, 0xf3, 0x34, 0x80, 0xa6,
+	0xa5, 0xe2, 0xa1, 0xf8, 0x20, 0x33, 0x43, 0x87, 0x2d, 0x6a, 0xb7, 0xf7, 0x2b, 0x41, 0x2d, 0x33,
+	0xeb, 0xad, 0x93, 0x8b, 0x7b, 0x95, 0xc3, 0x9d, 0x7c, 0xb5, 0x76, 0x18, 0x3c, 0x83, 0xfe, 0xb6,
+	0x2b, 0xd0, 0x79, 0x2d, 0x33, 0x47, 0xe9, 0x20, 0xda, 0x46, 0xe4, 0x99, 0xf7, 0xae, 0x90, 0x0d,
+	0x20, 0x1b, 0x0c, 0xe8, 0xf9, 0x4e, 0x25, 0x5f, 0x3c, 0xac, 0x52, 0x32, 0x95, 0x9e, 0x15, 0x4a,
+	0xa5, 0x22, 0xf4, 0xbf, 0x40, 0xbf, 0x12, 0x84, 0x01, 0x74, 0x4f, 0xcb, 0x7b, 0xc5, 0xca, 0xd3,
+	0x0c, 0x01, 0x65, 0xf9, 0x11, 0x2c, 0x13, 0x0c, 0x75, 0x77, 0x37, 0xbf, 0x57, 0x3c, 0x7c, 0x04,
+	0xff, 0xec, 0xc0, 0xd0, 0x1e, 0x3c, 0x3f, 0xdc, 0x2b, 0xd5, 0x9e, 0x56, 0x82, 0xc7, 0xd0, 0x69,
+	0xf0, 0x25, 0x10, 0xfa, 0x3c, 0x6c, 0xc7, 0x4b, 0x0f, 0xa1, 0xab, 0xa7, 0xf9, 0xe7, 0x26, 0x09,
+	0x17, 0xd5, 0xba, 0xfc, 0x4e, 0x50, 0xca, 0x17, 0x9f, 0x63, 0x55, 0x35, 0xb3, 0x04, 0x72, 0x73,
+	0x55, 0x8c, 0x57, 0xb4, 0xd9, 0xcb, 0xef, 0x96, 0x32, 0xcb, 0x20, 0x02, 0x36, 0x45, 0x4d, 0xfe,
+	0xe1, 0xc3, 0xa0, 0x04, 0xd5, 0x48, 0xdb, 0x1a, 0xf4, 0x99, 0xdf, 0xc9, 0x5c, 0x50, 0xbf, 0x2d,
+	0x96, 0xbe, 0x2c, 0x17, 0x4a, 0x87, 0x05, 0xa0, 0x48, 0x35, 0x93, 0xa1, 0x04, 0x57, 0x21, 0x87,
+	0x05, 0x18, 0xfa, 0xc3, 0xd2, 0xe1, 0x7e, 0x69, 0xaf, 0x58, 0xde, 0x7b, 0x98, 0x59, 0xa1, 0x6c,
+	0xc4, 0x16, 0x01, 0x6b, 0xf9, 0xe7, 0x19, 0x2f, 0xc6, 0x0e, 0xc6, 0x78, 0x2f, 0xe2, 0x87, 0x00,
+	0xde, 0x01, 0x06, 0x93, 0x43, 0xce, 0xac, 0xd2, 0x39, 0xca, 0xd1, 0x16, 0x03, 0x20, 0x74, 0x00,
+	0xb3, 0x80, 0x91, 0x56, 0x33, 0x6b, 0xde, 0x06, 0x59, 0x13, 0x75, 0x94, 0x35, 0xa3, 0xaa, 0x4b,
+	0xf4, 0x33, 0xc9, 0x19, 0x74, 0x40, 0x95, 0xed, 0x6d, 0xba, 0x40, 0xb0, 0x28, 0xeb, 0x74, 0xcd,
+	0x8a, 0xf9, 0xf2, 0x0e, 0x10, 0xad, 0x1c, 0xd4, 0xca, 0xbb, 0x30, 0x97, 0xfc, 0xfe, 0x21, 0x0c,
+	0xa7, 0xf0, 0x08, 0xaa, 0xb3, 0x94, 0xe9, 0x0e, 0xf6, 0x77, 0xca, 0x7b, 0x8f, 0x0f, 0x83, 0x83,
+	0x9d, 0x92, 0x49, 0xf5, 0x0d, 0xca, 0x22, 0xa2, 0x57, 0xa5, 0x5d, 0x26, 0x47, 0x57, 0x55, 0x90,
+	0x9a, 0x46, 0x0e, 0x1c, 0x16, 0x80, 0x07, 0x81, 0x9d, 0xcb, 0xf9, 0x9d, 0x2a, 0x60, 0x51, 0x70,
+	0x5c, 0x06, 0xc1, 0xbc, 0x28, 0x47, 0x9e, 0x7f, 0x58, 0xcd, 0x6c, 0xaa, 0x58, 0x29, 0x6b, 0xc0,
+	0xe2, 0x53, 0x3a, 0x65, 0xae, 0x20, 0x87, 0x45, 0xbc, 0x42, 0xb1, 0x54, 0x0f, 0xf6, 0x29, 0xbb,
+	0xc2, 0x68, 0xaf, 0xd2, 0x6d, 0xb4, 0x7b, 0xb0, 0x53, 0x2b, 0x17, 0x28, 0xcb, 0x3e, 0x0c, 0x2a,
+	0x07, 0xfb, 0xe6, 0x88, 0xaf, 0x79, 0x97, 0xc9, 0xba, 0xc4, 0xad, 0xb7, 0xcd, 0x6c, 0xa9, 0x04,
+	0x8e, 0x2a, 0xb7, 0x0b, 0x7b, 0xb5, 0xcc, 0x75, 0x38, 0xa4, 0x2c, 0xd3, 0x65, 0x3a, 0xac, 0xec,
+	0x01, 0xb5, 0x76, 0x61, 0xfd, 0x32, 0xbe, 0x58, 0xe1, 0xd2, 0x5e, 0xe5, 0xe0, 0xe1, 0x23, 0x4e,
+	0x81, 0x6a, 0xe6, 0x06, 0x65, 0xf5, 0x22, 0xb4, 0x85, 0xa2, 0xb2, 0x03, 0x6e, 0x52, 0x70, 0x50,
+	0x7a, 0x72, 0x50, 0x02, 0xa4, 0x85, 0xfc, 0x5e, 0xa1, 0xb4, 0x03, 0x8c, 0x9e, 0xf9, 0x08, 0xe4,
+	0xfb, 0x96, 0xa4, 0xd5, 0x4e, 0x99, 0x6e, 0xfa, 0x42, 0xde, 0xdc, 0xbe, 0xb7, 0x68, 0x2b, 0xd8,
+	0x30, 0x7b, 0x8c, 0xc8, 0xb5, 0xd2, 0xee, 0xfe, 0x0e, 0x7c, 0x62, 0x4e, 0xef, 0x63, 0x4a, 0x21,
+	0xc9, 0xae, 0x66, 0xeb, 0xcc, 0x6d, 0xef, 0x36, 0xb9, 0x19, 0x47, 0x02, 0x54, 0x37, 0x11, 0x7d,
+	0x42, 0x5b, 0x52, 0x86, 0xde, 0x2b, 0xed, 0xc8, 0x61, 0xe0, 0xde, 0x30, 0x5a, 0xde, 0xf1, 0xae,
+	0x93, 0x2b, 0xa2, 0x4b, 0xeb, 0x17, 0x99, 0x4f, 0xc1, 0xe2, 0xc8, 0x28, 0x9b, 0x08, 0x98, 0xb7,
+	0x18, 0x64, 0xee, 0xd2, 0x65, 0x7e, 0x50, 0xda, 0x2b, 0x3c, 0x62, 0xd4, 0x3c, 0x2c, 0x96, 0xab,
+	0xf9, 0x07, 0x94, 0x20, 0xdf, 0x31, 0xd7, 0x9f, 0x2f, 0x77, 0xe6, 0x33, 0x30, 0x73, 0x3e, 0x16,
+	0x94, 0xaa, 0xec, 0x3d, 0xa8, 0xe4, 0x03, 0xba, 0xd3, 0x0e, 0x6b, 0x95, 0xc7, 0xa5, 0xd8, 0xb8,
+	0xbe, 0xab, 0xee, 0x5c, 0x31, 0xae, 0xdd, 0x7c, 0xf5, 0x71, 0xe6, 0x73, 0x3a, 0x62, 0xbe, 0x73,
+	0xf7, 0x83, 0xca, 0x76, 0x39, 0xce, 0xd8, 0x5f, 0xa8, 0x9c, 0xa0, 0x37, 0xcd, 0xdc, 0xc3, 0xd5,
+	0x65, 0x30, 0x58, 0xcb, 0x83, 0xd2, 0xe1, 0xf6, 0xc1, 0xce, 0x4e, 0xe6, 0x7b, 0x6c, 0x9b, 0xf1,
+	0x4d, 0xf4, 0xe4, 0xa0, 0x02, 0x62, 0x51, 0xae, 0xfc, 0x7d, 0x50, 0x30, 0x2b, 0xb1, 0xd0, 0x4c,
+	0xef, 0x22, 0xb9, 0x50, 0x09, 0x8a, 0xa5, 0x80, 0xca, 0xba, 0x6d, 0xba, 0x5f, 0xab, 0xa0, 0x2b,
+	0x80, 0xcd, 0x24, 0xf0, 0xc1, 0xf3, 0x1a, 0xc0, 0x52, 0x77, 0xbe, 0x22, 0x19, 0x33, 0x76, 0x9c,
+	0xce, 0xae, 0xb4, 0x87, 0xfd, 0xb3, 0xd5, 0xa4, 0x02, 0x01, 0x98, 0x0b, 0x30, 0x00, 0xf5, 0x44,
+	0x8d, 0x4a, 0xbd, 0x14, 0xad, 0xa8, 0x80, 0x74, 0x92, 0x02, 0x89, 0x8b, 0xe0, 0xf4, 0x9d, 0x1d,
+	0x32, 0x2f, 0x7f, 0xc0, 0x91, 0x2d, 0xd5, 0xa3, 0x52, 0x50, 0xae, 0x81, 0x7e, 0xdb, 0xc9, 0xc3,
+	0xff, 0xcf, 0x01, 0x27, 0x0c, 0x75, 0xaf, 0x12, 0xec, 0xe6, 0x77, 0x22, 0x60, 0x8a, 0xab, 0x81,
+	0x12, 0xdd, 0x7c, 0x11, 0x38, 0x7d, 0xe7, 0x87, 0xe4, 0xbc, 0xfa, 0x53, 0xf5, 0x8a, 0x3e, 0x44,
+	0xc9, 0x79, 0xce, 0x3b, 0x4f, 0xe6, 0x70, 0x0c, 0x79, 0xc0, 0x22, 0x0b, 0x05, 0xf8, 0xf6, 0x2a,
+	0x59, 0x90, 0xc9, 0x93, 0xa9, 0x7a, 0xce, 0x57, 0x0b, 0xd0, 0x7e, 0x9e, 0x4c, 0x17, 0x4b, 0xf0,
+	0x57, 0xea, 0x4e, 0x8b, 0x2c, 0xeb, 0x79, 0xc9, 0xa9, 0xf4, 0x90, 0xf4, 0x82, 0xe9, 0x42, 0x6b,
+	0xe8, 0x50, 0x42, 0x98, 0x98, 0xc7, 0x99, 0x0b, 0x10, 0x48, 0xa2, 0x3c, 0x1d, 0x71, 0xbe, 0x06,
+	0x4a, 0x15, 0xa4, 0xa6, 0xac, 0x60, 0x8a, 0xae, 0x5a, 0x02, 0x02, 0x41, 0xd5, 0xd4, 0x9d, 0x36,
+	0xb9, 0x68, 0xc9, 0x3b, 0xed, 0x11, 0x32, 0x5b, 0x2d, 0x01, 0x7f, 0x17, 0xa1, 0x27, 0xf8, 0x1b,
+	0xec, 0x81, 0x83, 0x1a, 0xed, 0x02, 0xc6, 0xf8, 0xa8, 0x72, 0x10, 0x00, 0x4e, 0x18, 0x76, 0x11,
+	0xc4, 0xf5, 0x14, 0x05, 0x3d, 0x2d, 0x95, 0x1e, 0x83, 0xea, 0x5d, 0x20, 0x33, 0xbb, 0x95, 0xbd,
+	0xda, 0x23, 0xd0, 0xb3, 0x30, 0xdd, 0x27, 0x07, 0x79, 0xa0, 0x59, 0x00, 0x1a, 0x16, 0x5a, 0x3c,
+	0x2f, 0xe5, 0x83, 0xcc, 0xdc, 0xbd, 0x5f, 0x16, 0xc9, 0xd2, 0x5e, 0x38, 0x7c, 0xd3, 0xed, 0x83,
+	0x3d, 0xd9, 0x7f, 0x0d, 0xb3, 0x0f, 0xc8, 0x4a, 0x2c, 0x5b, 0x9a, 0x97, 0x98, 0x44, 0x2d, 0x77,
+	0xc5, 0x51, 0xcb, 0xcf, 0xc7, 0xe7, 0xbc, 0x32, 0x4b, 0x68, 0xa2, 0x22, 0xdc, 0xb0, 0xfd, 0x14,
+	0x3c, 0x62, 0xcb, 0xb9, 0x7f, 0x25, 0x1e, 0x50, 0xc1, 0xf0, 0x62, 0x3f, 0x5b, 0x8b, 0xc3, 0x73,
+	0xfd, 0xb8, 0x30, 0x0e, 0xcf, 0xfd, 0x5b, 0xb7, 0xe7, 0xbc, 0x0a, 0xc9, 0x98, 0x3f, 0xde, 0xe8,
+	0x5d, 0x4e, 0xf8, 0x81, 0xcd, 0xdc, 0xa6, 0xbd, 0x52, 0x1d, 0x64, 0xec, 0xd7, 0x1b, 0x71, 0x90,
+	0xae, 0x1f, 0x82, 0xc4, 0x41, 0xba, 0x7f, 0xf2, 0x91, 0x0d, 0xd2, 0xfc, 0x65, 0x47, 0x1c, 0xa4,
+	0xe3, 0xa7, 0x20, 0x71, 0x90, 0xae, 0x1f, 0x83, 0x04, 0x84, 0x5f, 0x93, 0x0d, 0xe7, 0xef, 0x28,
+	0x7a, 0xec, 0xae, 0x62, 0xdc, 0x4f, 0x42, 0xe6, 0x3e, 0x1a, 0xd3, 0x4a, 0xf6, 0x55, 0x20, 0x8b,
+	0xea, 0x0f, 0x0d, 0x7a, 0xec, 0x2c, 0x6c, 0xf9, 0x7d, 0xc6, 0x5c, 0x36, 0x5e, 0x21, 0x91, 0x6c,
+	0x93, 0x25, 0xed, 0x98, 0xeb, 0x39, 0x4f, 0xbe, 0xb9, 0x0d, 0x4b, 0x8d, 0xc4, 0xf3, 0x3b, 0x84,
+	0x44, 0xf1, 0x85, 0xde, 0x9a, 0x99, 0x74, 0x1f, 0x31, 0x38, 0x72, 0xf1, 0xe3, 0x30, 0xb4, 0x33,
+	0x2a, 0x0e, 0xc3, 0xf6, 0x03, 0x0d, 0x38, 0x0c, 0xfb, 0x2f, 0x2b, 0x9c, 0xf3, 0xf2, 0x64, 0x51,
+	0xb9, 0xe9, 0x18, 0x78, 0x97, 0xec, 0xbf, 0x52, 0x90, 0x5b, 0x8f, 0xc1, 0xd5, 0xa1, 0x68, 0x8e,
+	0x57, 0x1c, 0x8a, 0xed, 0x37, 0x02, 0x70, 0x28, 0xf6, 0xdf, 0x04, 0x38, 0xe7, 0xed, 0xb0, 0xec,
+	0x42, 0xda, 0xef, 0x02, 0xe4, 0xf4, 0xf9, 0xab, 0xbe, 0xa1, 0xdc, 0x65, 0x6b, 0x9d, 0xc4, 0xf6,
+	0x87, 0x64, 0xd5, 0x96, 0x70, 0xdd, 0xbb, 0xc6, 0x8e, 0x4c, 0xee, 0x34, 0xf1, 0xb9, 0x2d, 0x77,
+	0x03, 0x81, 0xfc, 0xf3, 0x14, 0xe5, 0x5b, 0x67, 0x5a, 0x6b, 0xe4, 0xdb, 0x71, 0xd9, 0xcc, 0x91,
+	0x6f, 0xc7, 0xe6, 0xc6, 0x86, 0xa9, 0x7c, 0xa5, 0x24, 0xf6, 0xd0, 0xf2, 0x48, 0x8b, 0x9f, 0x8c,
+	0x71, 0x26, 0xb3, 0xce, 0x5d, 0x4f, 0x68, 0xa1, 0xee, 0x0b, 0x35, 0xb5, 0x30, 0xee, 0x0b, 0x4b,
+	0xce, 0x66, 0xdc, 0x17, 0xb6, 0x2c, 0xc4, 0x28, 0x6d, 0x62, 0x3f, 0x83, 0x89, 0xd2, 0xc6, 0xf5,
+	0x2b, 0x9d, 0x28, 0x6d, 0x9c, 0xbf, 0x9d, 0x09, 0x38, 0x7f, 0x9f, 0xbd, 0xca, 0x8c, 0xfd, 0x7a,
+	0x22, 0xae, 0x61, 0xc2, 0x6f, 0x61, 0xe6, 0xb6, 0xdc, 0x0d, 0x0c, 0xe4, 0xb1, 0x5f, 0x06, 0x94,
+	0xc8, 0x5d, 0x3f, 0xa3, 0x28, 0x91, 0x3b, 0x7f, 0x83, 0x10, 0xa9, 0x11, 0xfb, 0x25, 0x36, 0x6f,
+	0xd3, 0x18, 0x95, 0xf6, 0x4b, 0x82, 0x48, 0x0d, 0xe7, 0xcf, 0xb7, 0x01, 0xce, 0x03, 0xe2, 0xc5,
+	0xf3, 0xb5, 0x7a, 0x57, 0xac, 0x39, 0x57, 0x25, 0xd6, 0xab, 0xae, 0x6a, 0x15, 0x6d, 0x3c, 0x9d,
+	0x29, 0xa2, 0x75, 0x26, 0x53, 0x45, 0xb4, 0xee, 0x2c, 0xa8, 0x80, 0xf6, 0x19, 0x4b, 0xfb, 0x6d,
+	0xe6, 0x1d, 0xf5, 0xae, 0x8a, 0x59, 0xda, 0xd3, 0x98, 0xe6, 0xae, 0x39, 0xeb, 0x55, 0xda, 0xc6,
+	0xf2, 0xf7, 0x72, 0xdb, 0xc0, 0x91, 0x3d, 0x98, 0xdb, 0x06, 0xce, 0xa4, 0xbf, 0x8c, 0x08, 0xf1,
+	0x0c, 0xd1, 0x48, 0x04, 0x67, 0x16, 0x6c, 0x24, 0x82, 0x3b, 0xb1, 0x34, 0xa0, 0xad, 0xab, 0x3f,
+	0xff, 0xa1, 0xa5, 0x77, 0xbe, 0xae, 0x4b, 0x2f, 0x4b, 0xae, 0xe8, 0x9c, 0x9f, 0xd4, 0xc4, 0xd0,
+	0xc8, 0x5a, 0xc2, 0x4d, 0xa9, 0x91, 0x6d, 0x09, 0x48, 0xa5, 0x46, 0xb6, 0xe7, 0xe8, 0x64, 0x0b,
+	0x67, 0x49, 0xe2, 0x89, 0x0b, 0xe7, 0xce, 0x6b, 0x8a, 0x0b, 0x97, 0x94, 0xfd, 0x53, 0x08, 0x78,
+	0x35, 0xf3, 0x9f, 0x14, 0xf0, 0x96, 0xa4, 0xa0, 0xb9, 0xcb, 0xd6, 0x3a, 0xd5, 0x9c, 0xd3, 0x93,
+	0xdc, 0xa1, 0x39, 0x67, 0xcd, 0xfb, 0x87, 0xe6, 0x9c, 0x3d, 0x27, 0x1e, 0xa0, 0xba, 0x4f, 0xe6,
+	0x78, 0x5e, 0x3b, 0xcf, 0xe3, 0x9d, 0x2a, 0x79, 0xef, 0x72, 0x17, 0x35, 0x98, 0xca, 0x87, 0xb1,
+	0x24, 0x6b, 0xc8, 0x87, 0xae, 0x7c, 0x6d, 0xc8, 0x87, 0xee, 0xcc, 0x6c, 0xe7, 0xbc, 0x63, 0xe5,
+	0x16, 0xcb, 0x48, 0x52, 0xe6, 0xdd, 0xd0, 0xb6, 0x86, 0x3d, 0x73, 0x5b, 0xee, 0x66, 0x72, 0x23,
+	0x95, 0x6d, 0xcc, 0x04, 0x54, 0xc8, 0x36, 0x8e, 0xac, 0x56, 0xb9, 0x4d, 0x7b, 0xa5, 0x6a, 0x05,
+	0x68, 0xd9, 0xa7, 0xbc, 0xac, 0xa6, 0x7a, 0x54, 0x54, 0x1b, 0x96, 0x1a, 0x75, 0x60, 0x66, 0x26,
+	0x29, 0x1c, 0x98, 0x23, 0x3d, 0x55, 0x6e, 0xd3, 0x5e, 0xa9, 0x22, 0x34, 0x73, 0x4a, 0x21, 0x42,
+	0x47, 0x52, 0xaa, 0xdc, 0xa6, 0xbd, 0x52, 0x65, 0x63, 0x23, 0x81, 0x14, 0xb2, 0xb1, 0x3d, 0x3b,
+	0x15, 0xb2, 0xb1, 0x23, 0xe3, 0x54, 0xa4, 0xe3, 0xcc, 0x44, 0x4c, 0x9e, 0x2e, 0x08, 0xe3, 0x59,
+	0xa4, 0x22, 0x1d, 0xe7, 0xca, 0xe1, 0x24, 0x17, 0x25, 0x3a, 0x7c, 0xcb, 0x45, 0x89, 0x25, 0x5f,
+	0x92, 0x8b, 0x12, 0x4f, 0x68, 0x24, 0x2d, 0x90, 0x78, 0x82, 0x1b, 0x69, 0x81, 0x38, 0xb3, 0x18,
+	0x49, 0x0b, 0xc4, 0x9d, 0x1d, 0xc7, 0x50, 0x16, 0x4a, 0x82, 0x1b, 0x5d, 0x59, 0xc4, 0x92, 0xbb,
+	0x18, 0xca, 0x22, 0x9e, 0xa0, 0x05, 0x05, 0x7b, 0x3c, 0xe9, 0x89, 0x27, 0x74, 0xad, 0x3d, 0x23,
+	0x4b, 0xee, 0xaa, 0xab, 0x5a, 0xa2, 0x1d, 0x90, 0xcd, 0xa4, 0xa4, 0x25, 0x1e, 0xcb, 0x45, 0x3e,
+	0x41, 0x3e, 0x94, 0xdc, 0xed, 0xf1, 0x0d, 0xd5, 0xb3, 0x92, 0x33, 0x25, 0x89, 0xb4, 0x39, 0x93,
+	0xbb, 0xfb, 0x68, 0x4c, 0x2b, 0xd9, 0xd7, 0xdf, 0xa4, 0x59, 0x53, 0x92, 0x73, 0x83, 0x78, 0x9f,
+	0x22, 0xb2, 0x89, 0xf2, 0x8f, 0xe4, 0xee, 0x4e, 0xd6, 0x58, 0xdd, 0x17, 0xb6, 0x1c, 0x1b, 0xb8,
+	0x2f, 0x12, 0x52, 0x84, 0xe4, 0xb6, 0xdc, 0x0d, 0x34, 0xe9, 0x67, 0x24, 0xd0, 0xe0, 0xd2, 0xcf,
+	0x9e, 0x89, 0x83, 0x4b, 0x3f, 0x57, 0xce, 0x0d, 0xb6, 0x34, 0xce, 0x2c, 0x17, 0xb8, 0x34, 0xe3,
+	0x92, 0x72, 0xe0, 0xd2, 0x8c, 0x4d, 0x95, 0x01, 0x7d, 0x9d, 0xb0, 0x28, 0x18, 0x47, 0x6e, 0x08,
+	0x4f, 0xac, 0x70, 0x72, 0x6a, 0x8c, 0xdc, 0xad, 0x71, 0xcd, 0x54, 0x1b, 0xc6, 0x9e, 0x91, 0x00,
+	0x6d, 0x98, 0xc4, 0x7c, 0x08, 0x68, 0xc3, 0x8c, 0x49, 0x68, 0xa0, 0x6f, 0xff, 0x28, 0x39, 0x81,
+	0xb1, 0xfd, 0x63, 0xb9, 0x0e, 0x8c, 0xed, 0x1f, 0xcf, 0x6a, 0x80, 0x0b, 0x6d, 0x66, 0x1e, 0xc0,
+	0x85, 0x76, 0xa4, 0x30, 0xc0, 0x85, 0x76, 0x26, 0x2b, 0x10, 0x43, 0x35, 0xd3, 0x06, 0xc8, 0xa1,
+	0x3a, 0xf2, 0x18, 0xc8, 0xa1, 0xba, 0xf2, 0x0d, 0x20, 0xc3, 0xdb, 0xa2, 0xdb, 0x91, 0xe1, 0x13,
+	0x42, 0xea, 0x91, 0xe1, 0x93, 0x02, 0xe3, 0xe5, 0x79, 0xc4, 0xc0, 0x2c, 0x2c, 0x41, 0x3b, 0xda,
+	0x2b, 0x8e, 0x5a, 0x75, 0xc0, 0xb6, 0xf0, 0x73, 0x4f, 0xb1, 0x04, 0x13, 0x06, 0x9c, 0x18, 0xb9,
+	0xce, 0x90, 0xdb, 0x82, 0xd1, 0x11, 0x79, 0x42, 0x54, 0x3b, 0x22, 0x4f, 0x8c, 0x63, 0x67, 0x8b,
+	0x68, 0x89, 0x3e, 0xf7, 0xa4, 0x3d, 0x6f, 0x0f, 0x71, 0xcf, 0x5d, 0x73, 0xd6, 0x5b, 0xdc, 0x59,
+	0xf1, 0xe8, 0x6e, 0xcd, 0x9d, 0xe5, 0x0c, 0x45, 0xd7, 0xdc, 0x59, 0xee, 0x10, 0x71, 0x9c, 0x85,
+	0x25, 0x8c, 0x1b, 0x67, 0xe1, 0x8e, 0x14, 0xc7, 0x59, 0x24, 0xc5, 0x7f, 0x9f, 0xf3, 0x9e, 0x90,
+	0xac, 0x2b, 0x8a, 0x14, 0xad, 0xd0, 0x31, 0x31, 0xa6, 0x39, 0x2d, 0x0c, 0x92, 0xf9, 0x4b, 0xaa,
+	0x64, 0xc3, 0x19, 0x5d, 0x8a, 0x84, 0x19, 0x17, 0x7c, 0x6a, 0x41, 0x7a, 0xc0, 0xcc, 0x12, 0xcb,
+	0x20, 0x85, 0x59, 0xe2, 0x1e, 0x61, 0xd6, 0x6c, 0xa1, 0x4c, 0xff, 0x29, 0x3b, 0xb5, 0xd9, 0x06,
+	0x7a, 0xdd, 0x82, 0xd7, 0x18, 0x65, 0x12, 0x62, 0x10, 0xa5, 0xf6, 0x88, 0x47, 0x44, 0x9c, 0x18,
+	0x60, 0x99, 0xf3, 0x93, 0x9a, 0x98, 0xa2, 0xd4, 0xc4, 0x7f, 0xd5, 0x70, 0x2e, 0x98, 0xc8, 0xaf,
+	0x39, 0xeb, 0xd5, 0xc1, 0xdb, 0x43, 0x15, 0x71, 0xf0, 0x89, 0x91, 0x91, 0x39, 0x3f, 0xa9, 0x89,
+	0xda, 0x85, 0x3d, 0x74, 0x11, 0xbb, 0x48, 0x8c, 0x83, 0xc4, 0x2e, 0xc6, 0x44, 0x3e, 0x32, 0x4b,
+	0xd6, 0x1a, 0xad, 0xe8, 0x49, 0xb3, 0xc1, 0x15, 0x16, 0x89, 0x96, 0x6c, 0x62, 0xa8, 0x23, 0xe0,
+	0x6f, 0x92, 0x75, 0x47, 0x04, 0x9c, 0xe7, 0x8f, 0x0f, 0x30, 0xcc, 0xdd, 0x48, 0x6c, 0xa3, 0x9a,
+	0x00, 0xee, 0x98, 0x28, 0x34, 0x01, 0xc6, 0x06, 0x66, 0xa1, 0x09, 0x30, 0x3e, 0xb4, 0x0a, 0x27,
+	0xe5, 0x08, 0x8d, 0xf2, 0x84, 0x93, 0x22, 0xa9, 0xa3, 0x1b, 0x89, 0x6d, 0xd4, 0x49, 0xb9, 0x03,
+	0x97, 0x70, 0x52, 0x63, 0xa3, 0xa7, 0x70, 0x52, 0x13, 0xc4, 0x3f, 0xb1, 0xee, 0xdc, 0xc1, 0x4c,
+	0xd8, 0xdd, 0xd8, 0x08, 0x29, 0xec, 0x6e, 0x82, 0x98, 0x28, 0x69, 0x21, 0x5a, 0x63, 0x98, 0x22,
+	0x0b, 0x31, 0x29, 0x68, 0x2a, 0xb2, 0x10, 0x13, 0x03, 0xa1, 0x50, 0x79, 0xda, 0x82, 0x79, 0x50,
+	0x79, 0x26, 0x44, 0x34, 0xa1, 0xf2, 0x4c, 0x8c, 0x03, 0x62, 0x47, 0x9f, 0xa4, 0xa8, 0x18, 0x3c,
+	0xfa, 0x4c, 0x10, 0xa7, 0x83, 0x47, 0x9f, 0x49, 0x02, 0x6c, 0xa0, 0xd3, 0x1e, 0xe6, 0x8b, 0x71,
+	0x04, 0x64, 0x78, 0xb7, 0x0c, 0x4f, 0x9c, 0x23, 0x0a, 0x24, 0xf7, 0xf1, 0xd8, 0x76, 0xea, 0x34,
+	0x93, 0x42, 0x29, 0x70, 0x9a, 0x13, 0x44, 0x6a, 0xe0, 0x34, 0x27, 0x8a, 0xca, 0x60, 0x0b, 0x67,
+	0x0b, 0x81, 0xe0, 0x97, 0x16, 0xee, 0x80, 0x0d, 0x7e, 0x69, 0x91, 0x10, 0x3d, 0xc1, 0x44, 0x5f,
+	0xd6, 0x15, 0xad, 0x80, 0x5a, 0x7d, 0x4c, 0x2c, 0x03, 0x7a, 0x32, 0x1c, 0x31, 0x05, 0x80, 0xff,
+	0x8f, 0xc4, 0x0f, 0x7b, 0x39, 0x55, 0xfc, 0xb8, 0xd8, 0x86, 0x71, 0x3d, 0x80, 0x1c, 0x72, 0x44,
+	0x16, 0xa0, 0x1c, 0x4a, 0x0e, 0x55, 0x40, 0x39, 0x34, 0x26, 0x34, 0x01, 0x7b, 0x71, 0x04, 0x12,
+	0x78, 0x7e, 0x6c, 0x2d, 0x1d, 0xbd, 0x8c, 0x8b, 0x44, 0xe0, 0x4b, 0x1d, 0x0f, 0x1c, 0x10, 0x4b,
+	0xed, 0x8c, 0x52, 0x10, 0x4b, 0x9d, 0x10, 0x73, 0xc0, 0xac, 0x00, 0xcb, 0x33, 0x7b, 0xb4, 0x02,
+	0xdc, 0x4f, 0xf9, 0x73, 0xd7, 0x9c, 0xf5, 0x86, 0xbb, 0x59, 0x47, 0x7b, 0x59, 0x3b, 0x87, 0x19,
+	0x38, 0x37, 0xed, 0x95, 0x71, 0x77, 0xb3, 0x65, 0xa8, 0xee, 0x77, 0xf8, 0xaa, 0xbb, 0x39, 0x01,
+	0xb3, 0xe5, 0xc1, 0x3c, 0x62, 0x76, 0xbf, 0xbb, 0xcf, 0x5d, 0x73, 0xd6, 0x9b, 0xb7, 0x05, 0xfa,
+	0x03, 0xf9, 0xe8, 0xb6, 0xc0, 0xfa, 0x06, 0x3f, 0xba, 0x2d, 0xb0, 0xbf, 0xab, 0x97, 0xb7, 0x05,
+	0xb6, 0x37, 0xea, 0xf2, 0x1a, 0xcf, 0xf9, 0x54, 0x5e, 0xde, 0x16, 0x24, 0x3c, 0x06, 0x47, 0xa5,
+	0xe7, 0x7e, 0xe6, 0x8c, 0x4a, 0x6f, 0xec, 0xeb, 0x6f, 0x54, 0x7a, 0xe3, 0x5f, 0x4b, 0xf3, 0xcb,
+	0x72, 0xf5, 0x95, 0x30, 0xbf, 0x2c, 0xb7, 0xbc, 0x64, 0xe6, 0x97, 0xe5, 0xb6, 0x27, 0xc5, 0xfe,
+	0xb9, 0x17, 0xb3, 0xbd, 0x7e, 0x77, 0xd8, 0xfd, 0xde, 0xff, 0x05, 0xbb, 0x86, 0x0f, 0xa0, 0xa9,
+	0xaa, 0x00, 0x00,
 }
@@ -0,0 +1,19 @@
+package ns
+
+import (
+	grpc "google.golang.org/grpc"
+)
+
+// V1ServiceName is the versioned name of the NetworkServer service. The
+// service is served under both its unversioned (ns.NetworkServer) and
+// versioned name, so that clients can address version 1 explicitly next
+// to version 2 (see the nsv2 package).
+const V1ServiceName = "ns.v1.NetworkServer"
+
+// RegisterNetworkServerV1Server registers the NetworkServer service under
+// its versioned name (V1ServiceName).
+func RegisterNetworkServerV1Server(s *grpc.Server, srv NetworkServerServer) {
+	desc := _NetworkServer_serviceDesc
+	desc.ServiceName = V1ServiceName
+	s.RegisterService(&desc, srv)
+}
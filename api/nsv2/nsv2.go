@@ -0,0 +1,8 @@
+//go:generate protoc -I . --go_out=plugins=grpc:. nsv2.proto
+
+// Package nsv2 contains the version 2 of the network-server API
+// (ns.v2.NetworkServer). Version 1 is the ns.NetworkServer service of the
+// ns package, which is also served as ns.v1.NetworkServer. During a
+// rolling upgrade, clients fall back to version 1 when the network-server
+// returns UNIMPLEMENTED for a version 2 method.
+package nsv2
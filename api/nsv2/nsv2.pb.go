@@ -0,0 +1,449 @@
+// Code generated by protoc-gen-go.
+// source: nsv2.proto
+// DO NOT EDIT!
+
+/*
+Package nsv2 is a generated protocol buffer package.
+
+It is generated from these files:
+	nsv2.proto
+
+It has these top-level messages:
+	DeviceQueueItem
+	EnqueueDeviceQueueItemRequest
+	EnqueueDeviceQueueItemResponse
+	GetDeviceQueueItemsRequest
+	GetDeviceQueueItemsResponse
+	FlushDeviceQueueRequest
+	FlushDeviceQueueResponse
+*/
+package nsv2
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type DeviceQueueItem struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Data (encrypted with the AppSKey, unless the AppSKey encryption is
+	// offloaded to LoRa Server).
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Payload must be acknowledged by the node.
+	Confirmed bool `protobuf:"varint,3,opt,name=confirmed" json:"confirmed,omitempty"`
+	// FPort to use for transmitting the payload.
+	FPort uint32 `protobuf:"varint,4,opt,name=fPort" json:"fPort,omitempty"`
+	// FCnt used for encrypting the data.
+	FCnt uint32 `protobuf:"varint,5,opt,name=fCnt" json:"fCnt,omitempty"`
+	// The payload is critical and must be sent as confirmed payload, even
+	// when the battery level of the node is below its batteryThrottleLevel.
+	Critical bool `protobuf:"varint,6,opt,name=critical" json:"critical,omitempty"`
+	// Client reference of the payload (optional), included in the ACK
+	// notification of a confirmed payload.
+	Reference string `protobuf:"bytes,7,opt,name=reference" json:"reference,omitempty"`
+	// Transmit the payload immediately to the (Class-C) node instead of
+	// enqueueing it (ignored for the items returned by
+	// GetDeviceQueueItems).
+	Immediately bool `protobuf:"varint,8,opt,name=immediately" json:"immediately,omitempty"`
+	// Timestamp (RFC3339) of enqueueing the payload (ignored on enqueue).
+	EnqueuedAt string `protobuf:"bytes,9,opt,name=enqueuedAt" json:"enqueuedAt,omitempty"`
+	// Timestamp (RFC3339) after which the payload must not be sent anymore
+	// (optional). Expired payloads are dropped at scheduling time and reported
+	// to the application-server.
+	ExpiresAt string `protobuf:"bytes,10,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp (RFC3339) before which the payload must not be sent (optional).
+	// Held payloads are skipped when scheduling a downlink and are sent to
+	// Class-C nodes once due.
+	NotBefore string `protobuf:"bytes,11,opt,name=notBefore" json:"notBefore,omitempty"`
+}
+
+func (m *DeviceQueueItem) Reset()                    { *m = DeviceQueueItem{} }
+func (m *DeviceQueueItem) String() string            { return proto.CompactTextString(m) }
+func (*DeviceQueueItem) ProtoMessage()               {}
+func (*DeviceQueueItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *DeviceQueueItem) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *DeviceQueueItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *DeviceQueueItem) GetConfirmed() bool {
+	if m != nil {
+		return m.Confirmed
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *DeviceQueueItem) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *DeviceQueueItem) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetImmediately() bool {
+	if m != nil {
+		return m.Immediately
+	}
+	return false
+}
+
+func (m *DeviceQueueItem) GetEnqueuedAt() string {
+	if m != nil {
+		return m.EnqueuedAt
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *DeviceQueueItem) GetNotBefore() string {
+	if m != nil {
+		return m.NotBefore
+	}
+	return ""
+}
+
+type EnqueueDeviceQueueItemRequest struct {
+	// Payload to enqueue.
+	Item *DeviceQueueItem `protobuf:"bytes,1,opt,name=item" json:"item,omitempty"`
+}
+
+func (m *EnqueueDeviceQueueItemRequest) Reset()         { *m = EnqueueDeviceQueueItemRequest{} }
+func (m *EnqueueDeviceQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueDeviceQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{1}
+}
+
+func (m *EnqueueDeviceQueueItemRequest) GetItem() *DeviceQueueItem {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+type EnqueueDeviceQueueItemResponse struct {
+	// The payload was not transmitted as its reference has already been
+	// used (only for payloads transmitted immediately).
+	Duplicate bool `protobuf:"varint,1,opt,name=duplicate" json:"duplicate,omitempty"`
+}
+
+func (m *EnqueueDeviceQueueItemResponse) Reset()         { *m = EnqueueDeviceQueueItemResponse{} }
+func (m *EnqueueDeviceQueueItemResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueDeviceQueueItemResponse) ProtoMessage()    {}
+func (*EnqueueDeviceQueueItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{2}
+}
+
+func (m *EnqueueDeviceQueueItemResponse) GetDuplicate() bool {
+	if m != nil {
+		return m.Duplicate
+	}
+	return false
+}
+
+type GetDeviceQueueItemsRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *GetDeviceQueueItemsRequest) Reset()                    { *m = GetDeviceQueueItemsRequest{} }
+func (m *GetDeviceQueueItemsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemsRequest) ProtoMessage()               {}
+func (*GetDeviceQueueItemsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GetDeviceQueueItemsRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type GetDeviceQueueItemsResponse struct {
+	// Downlink payloads (the first is sent first).
+	Items []*DeviceQueueItem `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+}
+
+func (m *GetDeviceQueueItemsResponse) Reset()                    { *m = GetDeviceQueueItemsResponse{} }
+func (m *GetDeviceQueueItemsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceQueueItemsResponse) ProtoMessage()               {}
+func (*GetDeviceQueueItemsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *GetDeviceQueueItemsResponse) GetItems() []*DeviceQueueItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type FlushDeviceQueueRequest struct {
+	// DevEUI of the node.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *FlushDeviceQueueRequest) Reset()                    { *m = FlushDeviceQueueRequest{} }
+func (m *FlushDeviceQueueRequest) String() string            { return proto.CompactTextString(m) }
+func (*FlushDeviceQueueRequest) ProtoMessage()               {}
+func (*FlushDeviceQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *FlushDeviceQueueRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type FlushDeviceQueueResponse struct {
+}
+
+func (m *FlushDeviceQueueResponse) Reset()                    { *m = FlushDeviceQueueResponse{} }
+func (m *FlushDeviceQueueResponse) String() string            { return proto.CompactTextString(m) }
+func (*FlushDeviceQueueResponse) ProtoMessage()               {}
+func (*FlushDeviceQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func init() {
+	proto.RegisterType((*DeviceQueueItem)(nil), "ns.v2.DeviceQueueItem")
+	proto.RegisterType((*EnqueueDeviceQueueItemRequest)(nil), "ns.v2.EnqueueDeviceQueueItemRequest")
+	proto.RegisterType((*EnqueueDeviceQueueItemResponse)(nil), "ns.v2.EnqueueDeviceQueueItemResponse")
+	proto.RegisterType((*GetDeviceQueueItemsRequest)(nil), "ns.v2.GetDeviceQueueItemsRequest")
+	proto.RegisterType((*GetDeviceQueueItemsResponse)(nil), "ns.v2.GetDeviceQueueItemsResponse")
+	proto.RegisterType((*FlushDeviceQueueRequest)(nil), "ns.v2.FlushDeviceQueueRequest")
+	proto.RegisterType((*FlushDeviceQueueResponse)(nil), "ns.v2.FlushDeviceQueueResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for NetworkServer service
+
+type NetworkServerClient interface {
+	// EnqueueDeviceQueueItem adds the given downlink payload to the
+	// device-queue of the node, or transmits it immediately to the (Class-C)
+	// node when immediately is set.
+	EnqueueDeviceQueueItem(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error)
+	// GetDeviceQueueItems returns the downlink payloads in the device-queue
+	// of the node.
+	GetDeviceQueueItems(ctx context.Context, in *GetDeviceQueueItemsRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsResponse, error)
+	// FlushDeviceQueue removes all the downlink payloads from the
+	// device-queue of the node.
+	FlushDeviceQueue(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error)
+}
+
+type networkServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkServerClient(cc *grpc.ClientConn) NetworkServerClient {
+	return &networkServerClient{cc}
+}
+
+func (c *networkServerClient) EnqueueDeviceQueueItem(ctx context.Context, in *EnqueueDeviceQueueItemRequest, opts ...grpc.CallOption) (*EnqueueDeviceQueueItemResponse, error) {
+	out := new(EnqueueDeviceQueueItemResponse)
+	err := grpc.Invoke(ctx, "/ns.v2.NetworkServer/EnqueueDeviceQueueItem", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceQueueItems(ctx context.Context, in *GetDeviceQueueItemsRequest, opts ...grpc.CallOption) (*GetDeviceQueueItemsResponse, error) {
+	out := new(GetDeviceQueueItemsResponse)
+	err := grpc.Invoke(ctx, "/ns.v2.NetworkServer/GetDeviceQueueItems", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) FlushDeviceQueue(ctx context.Context, in *FlushDeviceQueueRequest, opts ...grpc.CallOption) (*FlushDeviceQueueResponse, error) {
+	out := new(FlushDeviceQueueResponse)
+	err := grpc.Invoke(ctx, "/ns.v2.NetworkServer/FlushDeviceQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for NetworkServer service
+
+type NetworkServerServer interface {
+	// EnqueueDeviceQueueItem adds the given downlink payload to the
+	// device-queue of the node, or transmits it immediately to the (Class-C)
+	// node when immediately is set.
+	EnqueueDeviceQueueItem(context.Context, *EnqueueDeviceQueueItemRequest) (*EnqueueDeviceQueueItemResponse, error)
+	// GetDeviceQueueItems returns the downlink payloads in the device-queue
+	// of the node.
+	GetDeviceQueueItems(context.Context, *GetDeviceQueueItemsRequest) (*GetDeviceQueueItemsResponse, error)
+	// FlushDeviceQueue removes all the downlink payloads from the
+	// device-queue of the node.
+	FlushDeviceQueue(context.Context, *FlushDeviceQueueRequest) (*FlushDeviceQueueResponse, error)
+}
+
+func RegisterNetworkServerServer(s *grpc.Server, srv NetworkServerServer) {
+	s.RegisterService(&_NetworkServer_serviceDesc, srv)
+}
+
+func _NetworkServer_EnqueueDeviceQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDeviceQueueItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).EnqueueDeviceQueueItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.v2.NetworkServer/EnqueueDeviceQueueItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).EnqueueDeviceQueueItem(ctx, req.(*EnqueueDeviceQueueItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetDeviceQueueItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceQueueItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceQueueItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.v2.NetworkServer/GetDeviceQueueItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceQueueItems(ctx, req.(*GetDeviceQueueItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_FlushDeviceQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushDeviceQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).FlushDeviceQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.v2.NetworkServer/FlushDeviceQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).FlushDeviceQueue(ctx, req.(*FlushDeviceQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NetworkServer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ns.v2.NetworkServer",
+	HandlerType: (*NetworkServerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EnqueueDeviceQueueItem",
+			Handler:    _NetworkServer_EnqueueDeviceQueueItem_Handler,
+		},
+		{
+			MethodName: "GetDeviceQueueItems",
+			Handler:    _NetworkServer_GetDeviceQueueItems_Handler,
+		},
+		{
+			MethodName: "FlushDeviceQueue",
+			Handler:    _NetworkServer_FlushDeviceQueue_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nsv2.proto",
+}
+
+func init() { proto.RegisterFile("nsv2.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 428 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x85, 0x53, 0x5d, 0x4f, 0xc2, 0x40,
+	0x10, 0x94, 0x4f, 0x61, 0x91, 0x68, 0x4e, 0x83, 0x97, 0xaa, 0x88, 0x8d, 0x26, 0xc6, 0x18, 0x12,
+	0xd1, 0x67, 0x13, 0x51, 0x34, 0xc4, 0xc4, 0x68, 0x0d, 0x2f, 0x3e, 0x98, 0x60, 0xbb, 0xd5, 0x46,
+	0x68, 0xf1, 0xee, 0xa8, 0xfa, 0xab, 0xfc, 0x05, 0xfe, 0x37, 0xaf, 0xd7, 0x52, 0x08, 0x52, 0x78,
+	0xbb, 0x9b, 0xd9, 0x99, 0xdd, 0x9d, 0xcb, 0x01, 0xb8, 0xdc, 0x6f, 0xd4, 0x07, 0xcc, 0x13, 0x1e,
+	0xc9, 0xb9, 0xbc, 0xee, 0x37, 0xf4, 0xdf, 0x34, 0xac, 0x5e, 0xa1, 0xef, 0x98, 0xf8, 0x30, 0xc4,
+	0x21, 0xb6, 0x05, 0xf6, 0x49, 0x05, 0xf2, 0x16, 0xfa, 0xad, 0x4e, 0x9b, 0xa6, 0x6a, 0xa9, 0xc3,
+	0x15, 0x23, 0xba, 0x11, 0x02, 0x59, 0xab, 0x2b, 0xba, 0x34, 0xad, 0x50, 0x75, 0x26, 0xdb, 0x50,
+	0x34, 0x3d, 0xd7, 0x76, 0x58, 0x1f, 0x2d, 0x9a, 0x91, 0x44, 0xc1, 0x18, 0x03, 0x64, 0x03, 0x72,
+	0xf6, 0xbd, 0xc7, 0x04, 0xcd, 0x4a, 0xa6, 0x6c, 0x84, 0x97, 0xc0, 0xc7, 0xbe, 0x74, 0x05, 0xcd,
+	0x29, 0x50, 0x9d, 0x89, 0x06, 0x05, 0x93, 0x39, 0xc2, 0x31, 0xbb, 0x3d, 0x9a, 0x57, 0x36, 0xf1,
+	0x3d, 0xe8, 0xc1, 0xd0, 0x46, 0x86, 0xae, 0x89, 0x74, 0x59, 0x92, 0x45, 0x63, 0x0c, 0x90, 0x1a,
+	0x94, 0x9c, 0xbe, 0x6c, 0xe6, 0x74, 0x05, 0xf6, 0xbe, 0x69, 0x41, 0x89, 0x27, 0x21, 0x52, 0x05,
+	0x40, 0xf7, 0x23, 0x58, 0xcf, 0xba, 0x10, 0xb4, 0xa8, 0x0c, 0x26, 0x90, 0xc0, 0x1f, 0xbf, 0x06,
+	0x0e, 0x43, 0x2e, 0x69, 0x08, 0xfd, 0x63, 0x20, 0x60, 0x5d, 0x4f, 0x34, 0xd1, 0xf6, 0x18, 0xd2,
+	0x52, 0xc8, 0xc6, 0x80, 0x7e, 0x0b, 0x3b, 0xad, 0xd0, 0x69, 0x2a, 0x45, 0x03, 0x25, 0xca, 0x05,
+	0x39, 0x82, 0xac, 0x23, 0xaf, 0x2a, 0xca, 0x52, 0xa3, 0x52, 0x57, 0xb1, 0xd7, 0xa7, 0x8b, 0x55,
+	0x8d, 0x7e, 0x0e, 0xd5, 0x24, 0x33, 0x3e, 0xf0, 0x5c, 0x8e, 0xc1, 0x30, 0xd6, 0x70, 0xd0, 0x93,
+	0xb1, 0x08, 0x54, 0x96, 0x32, 0xee, 0x18, 0xd0, 0xcf, 0x40, 0xbb, 0x41, 0x31, 0xa5, 0xe5, 0xa3,
+	0x49, 0x12, 0x9e, 0x55, 0xae, 0xb0, 0x35, 0x53, 0x15, 0xb5, 0x3c, 0x86, 0x5c, 0x30, 0x1c, 0x97,
+	0xaa, 0xcc, 0x9c, 0x0d, 0xc2, 0x22, 0xfd, 0x04, 0x36, 0xaf, 0x7b, 0x43, 0xfe, 0x36, 0x41, 0x2f,
+	0xea, 0xaf, 0x01, 0xfd, 0x2f, 0x09, 0x9b, 0x37, 0x7e, 0xd2, 0x50, 0xbe, 0x43, 0xf1, 0xe9, 0xb1,
+	0xf7, 0x47, 0x64, 0x3e, 0x32, 0xf2, 0x0a, 0x95, 0xd9, 0x19, 0x91, 0xfd, 0x68, 0xb2, 0xb9, 0xef,
+	0xa1, 0x1d, 0x2c, 0xa8, 0x0a, 0x1b, 0xeb, 0x4b, 0xe4, 0x19, 0xd6, 0x67, 0xc4, 0x42, 0xf6, 0x22,
+	0x7d, 0x72, 0xd0, 0x9a, 0x3e, 0xaf, 0x24, 0xf6, 0xef, 0xc0, 0xda, 0xf4, 0xda, 0xa4, 0x1a, 0x29,
+	0x13, 0x22, 0xd4, 0x76, 0x13, 0xf9, 0x91, 0x6d, 0x33, 0xff, 0x94, 0x0d, 0x7e, 0xf9, 0x4b, 0x5e,
+	0x7d, 0xf3, 0xd3, 0x3f, 0x85, 0xd5, 0xcd, 0x94, 0xf4, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/codegangsta/cli"
+	"google.golang.org/grpc"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/ns"
+)
+
+// adminClientFlags contains the flags of the admin commands, to connect to
+// the network-server api of a running LoRa Server instance.
+var adminClientFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "api-server",
+		Usage:  "hostname:port of the network-server api",
+		Value:  "127.0.0.1:8000",
+		EnvVar: "API_SERVER",
+	},
+	cli.StringFlag{
+		Name:   "api-ca-cert",
+		Usage:  "ca certificate used by the network-server api client (optional)",
+		EnvVar: "API_CA_CERT",
+	},
+	cli.StringFlag{
+		Name:   "api-tls-cert",
+		Usage:  "tls certificate used by the network-server api client (optional)",
+		EnvVar: "API_TLS_CERT",
+	},
+	cli.StringFlag{
+		Name:   "api-tls-key",
+		Usage:  "tls key used by the network-server api client (optional)",
+		EnvVar: "API_TLS_KEY",
+	},
+}
+
+// devEUIFlag contains the flag of the admin commands operating on a single
+// node.
+var devEUIFlag = cli.StringFlag{
+	Name:  "dev-eui",
+	Usage: "DevEUI of the node (HEX encoded)",
+}
+
+// adminCommands contains the admin commands, which talk to the network-server
+// api of a running LoRa Server instance. The results are written as json
+// to stdout.
+var adminCommands = []cli.Command{
+	{
+		Name:  "sessions",
+		Usage: "list, get or delete node-sessions",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list the node-sessions (optionally filtered by tag)",
+				Action: listSessions,
+				Flags: append([]cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "only list the node-sessions having the given tag (key=value, can be repeated)",
+					},
+				}, adminClientFlags...),
+			},
+			{
+				Name:   "get",
+				Usage:  "get the node-session of a node",
+				Action: getSession,
+				Flags:  append([]cli.Flag{devEUIFlag}, adminClientFlags...),
+			},
+			{
+				Name:   "delete",
+				Usage:  "delete the node-session of a node",
+				Action: deleteSession,
+				Flags:  append([]cli.Flag{devEUIFlag}, adminClientFlags...),
+			},
+		},
+	},
+	{
+		Name:  "queue",
+		Usage: "inspect or flush the downlink device-queue of a node",
+		Subcommands: []cli.Command{
+			{
+				Name:   "inspect",
+				Usage:  "list the device-queue items of a node",
+				Action: inspectQueue,
+				Flags:  append([]cli.Flag{devEUIFlag}, adminClientFlags...),
+			},
+			{
+				Name:   "flush",
+				Usage:  "flush the device-queue of a node",
+				Action: flushQueue,
+				Flags:  append([]cli.Flag{devEUIFlag}, adminClientFlags...),
+			},
+		},
+	},
+	{
+		Name:  "gateway",
+		Usage: "list the gateways",
+		Subcommands: []cli.Command{
+			{
+				Name:   "list",
+				Usage:  "list the gateways (optionally filtered by MAC or name)",
+				Action: listGateways,
+				Flags: append([]cli.Flag{
+					cli.StringFlag{
+						Name:  "search",
+						Usage: "only list the gateways of which the MAC or name contains the given string",
+					},
+				}, adminClientFlags...),
+			},
+		},
+	},
+	{
+		Name:   "push-downlink",
+		Usage:  "push a (class-c) downlink to a node",
+		Action: pushDownlink,
+		Flags: append([]cli.Flag{
+			devEUIFlag,
+			cli.StringFlag{
+				Name:  "data",
+				Usage: "payload (encrypted with the AppSKey) encoded as HEX",
+			},
+			cli.IntFlag{
+				Name:  "f-port",
+				Usage: "FPort of the downlink",
+				Value: 1,
+			},
+			cli.IntFlag{
+				Name:  "f-cnt",
+				Usage: "FCnt used for encrypting the payload (-1 = the current FCntDown of the node-session)",
+				Value: -1,
+			},
+			cli.BoolFlag{
+				Name:  "confirmed",
+				Usage: "the downlink must be acknowledged by the node",
+			},
+		}, adminClientFlags...),
+	},
+}
+
+// adminListBatchSize defines the number of items fetched per list call.
+const adminListBatchSize = 1000
+
+func mustGetNetworkServerClient(c *cli.Context) ns.NetworkServerClient {
+	var dialOptions []grpc.DialOption
+	if c.String("api-tls-cert") != "" && c.String("api-tls-key") != "" {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(
+			mustGetTransportCredentials(c.String("api-tls-cert"), c.String("api-tls-key"), c.String("api-ca-cert"), false),
+		))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	}
+	conn, err := grpc.Dial(c.String("api-server"), dialOptions...)
+	if err != nil {
+		log.Fatalf("network-server api dial error: %s", err)
+	}
+	return ns.NewNetworkServerClient(conn)
+}
+
+func mustGetDevEUI(c *cli.Context) []byte {
+	var devEUI lorawan.EUI64
+	if err := devEUI.UnmarshalText([]byte(c.String("dev-eui"))); err != nil {
+		log.Fatalf("invalid --dev-eui: %s", err)
+	}
+	return devEUI[:]
+}
+
+// printJSON writes the given value as json to stdout.
+func printJSON(v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("marshal json error: %s", err)
+	}
+	fmt.Fprintln(os.Stdout, string(b))
+}
+
+func listSessions(c *cli.Context) error {
+	tags := make(map[string]string)
+	for _, tag := range c.StringSlice("tag") {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			log.Fatalf("invalid --tag '%s', expected key=value", tag)
+		}
+		tags[kv[0]] = kv[1]
+	}
+
+	client := mustGetNetworkServerClient(c)
+	sessions := []*ns.CreateNodeSessionRequest{}
+	var cursor uint64
+	for {
+		resp, err := client.ExportNodeSessions(context.Background(), &ns.ExportNodeSessionsRequest{
+			Cursor: cursor,
+			Limit:  adminListBatchSize,
+			Tags:   tags,
+		})
+		if err != nil {
+			log.Fatalf("list node-sessions error: %s", err)
+		}
+		sessions = append(sessions, resp.NodeSessions...)
+
+		cursor = resp.Cursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	printJSON(sessions)
+	return nil
+}
+
+func getSession(c *cli.Context) error {
+	resp, err := mustGetNetworkServerClient(c).GetNodeSession(context.Background(), &ns.GetNodeSessionRequest{
+		DevEUI: mustGetDevEUI(c),
+	})
+	if err != nil {
+		log.Fatalf("get node-session error: %s", err)
+	}
+
+	printJSON(resp)
+	return nil
+}
+
+func deleteSession(c *cli.Context) error {
+	devEUI := mustGetDevEUI(c)
+	if _, err := mustGetNetworkServerClient(c).DeleteNodeSession(context.Background(), &ns.DeleteNodeSessionRequest{
+		DevEUI: devEUI,
+	}); err != nil {
+		log.Fatalf("delete node-session error: %s", err)
+	}
+
+	log.WithField("dev_eui", hex.EncodeToString(devEUI)).Info("node-session deleted")
+	return nil
+}
+
+func inspectQueue(c *cli.Context) error {
+	resp, err := mustGetNetworkServerClient(c).GetDeviceQueueItems(context.Background(), &ns.GetDeviceQueueItemsRequest{
+		DevEUI: mustGetDevEUI(c),
+	})
+	if err != nil {
+		log.Fatalf("get device-queue items error: %s", err)
+	}
+
+	printJSON(resp)
+	return nil
+}
+
+func flushQueue(c *cli.Context) error {
+	devEUI := mustGetDevEUI(c)
+	if _, err := mustGetNetworkServerClient(c).FlushDeviceQueue(context.Background(), &ns.FlushDeviceQueueRequest{
+		DevEUI: devEUI,
+	}); err != nil {
+		log.Fatalf("flush device-queue error: %s", err)
+	}
+
+	log.WithField("dev_eui", hex.EncodeToString(devEUI)).Info("device-queue flushed")
+	return nil
+}
+
+func listGateways(c *cli.Context) error {
+	client := mustGetNetworkServerClient(c)
+	gateways := []*ns.GetGatewayResponse{}
+	for {
+		resp, err := client.ListGateways(context.Background(), &ns.ListGatewayRequest{
+			Limit:  adminListBatchSize,
+			Offset: int32(len(gateways)),
+			Search: c.String("search"),
+		})
+		if err != nil {
+			log.Fatalf("list gateways error: %s", err)
+		}
+		gateways = append(gateways, resp.Result...)
+
+		if len(resp.Result) == 0 || len(gateways) >= int(resp.TotalCount) {
+			break
+		}
+	}
+
+	printJSON(gateways)
+	return nil
+}
+
+func pushDownlink(c *cli.Context) error {
+	devEUI := mustGetDevEUI(c)
+	data, err := hex.DecodeString(c.String("data"))
+	if err != nil {
+		log.Fatalf("invalid --data: %s", err)
+	}
+	if fPort := c.Int("f-port"); fPort < 1 || fPort > 255 {
+		log.Fatal("--f-port must be between 1 and 255")
+	}
+
+	client := mustGetNetworkServerClient(c)
+
+	fCnt := c.Int("f-cnt")
+	if fCnt < 0 {
+		resp, err := client.GetNodeSession(context.Background(), &ns.GetNodeSessionRequest{
+			DevEUI: devEUI,
+		})
+		if err != nil {
+			log.Fatalf("get node-session error: %s", err)
+		}
+		fCnt = int(resp.FCntDown)
+	}
+
+	if _, err := client.PushDataDown(context.Background(), &ns.PushDataDownRequest{
+		DevEUI:    devEUI,
+		Data:      data,
+		Confirmed: c.Bool("confirmed"),
+		FPort:     uint32(c.Int("f-port")),
+		FCnt:      uint32(fCnt),
+	}); err != nil {
+		log.Fatalf("push downlink error: %s", err)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": hex.EncodeToString(devEUI),
+		"f_cnt":   fCnt,
+	}).Info("downlink pushed")
+	return nil
+}
@@ -2,6 +2,7 @@ package adr
 
 import (
 	"fmt"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 
@@ -56,19 +57,30 @@ func getNbRep(currentNbRep uint8, pktLossRate float64) uint8 {
 // lorawan/band package.
 func HandleADR(ctx common.Context, ns *session.NodeSession, rxPacket models.RXPacket, fullFCnt uint32) error {
 	var maxSNR float64
+	var maxRSSI int
+	var frequencyOffset int
 	for i, rxInfo := range rxPacket.RXInfoSet {
-		// as the default value is 0 and the LoRaSNR can be negative, we always
-		// set it when i == 0 (the first item from the slice)
+		// as the default value is 0 and the LoRaSNR and RSSI can be negative,
+		// we always set it when i == 0 (the first item from the slice)
 		if i == 0 || rxInfo.LoRaSNR > maxSNR {
 			maxSNR = rxInfo.LoRaSNR
 		}
+		if i == 0 || rxInfo.RSSI > maxRSSI {
+			maxRSSI = rxInfo.RSSI
+		}
+		// the RXInfoSet is sorted by signal strength (best first)
+		if i == 0 {
+			frequencyOffset = rxInfo.FrequencyOffset
+		}
 	}
 
 	// append metadata to the UplinkHistory slice.
 	ns.AppendUplinkHistory(session.UplinkHistory{
-		FCnt:         fullFCnt,
-		GatewayCount: len(rxPacket.RXInfoSet),
-		MaxSNR:       maxSNR,
+		FCnt:            fullFCnt,
+		GatewayCount:    len(rxPacket.RXInfoSet),
+		MaxSNR:          maxSNR,
+		MaxRSSI:         maxRSSI,
+		FrequencyOffset: frequencyOffset,
 	})
 
 	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
@@ -82,6 +94,11 @@ func HandleADR(ctx common.Context, ns *session.NodeSession, rxPacket models.RXPa
 		return nil
 	}
 
+	// the LinkADRReq mac-commands are handled by the network-controller
+	if maccommand.IsHandledByController(lorawan.LinkADRReq) {
+		return nil
+	}
+
 	if common.BandName != band.EU_863_870 {
 		log.WithFields(log.Fields{
 			"dev_eui": ns.DevEUI,
@@ -97,7 +114,7 @@ func HandleADR(ctx common.Context, ns *session.NodeSession, rxPacket models.RXPa
 		}
 	}
 
-	currentDR, err := common.Band.GetDataRate(rxPacket.RXInfoSet[0].DataRate)
+	currentDR, err := common.GetDataRate(rxPacket.RXInfoSet[0].DataRate)
 	if err != nil {
 		return fmt.Errorf("get data-rate error: %s", err)
 	}
@@ -110,30 +127,80 @@ func HandleADR(ctx common.Context, ns *session.NodeSession, rxPacket models.RXPa
 		return nil
 	}
 
-	snrMargin := snrM - requiredSNRTable[currentDR] - ns.InstallationMargin
+	params := GetParameters()
+	installationMargin := ns.InstallationMargin
+	if installationMargin == 0 {
+		installationMargin = params.InstallationMargin
+	}
+
+	// the noisy channels (at the serving gateway) are disabled, the noise
+	// of the channels which can not be disabled reduces the SNR margin
+	chMask := GetChMask(*ns)
+	channelMaskPending := ns.ChannelMask != nil && chMask != getEnabledChMask(*ns)
+	var noisyChannels []int
+	var channelNoise float64
+	if params.NoisyChannelThreshold > 0 {
+		channels, err := getNoisyChannels(ctx.RedisPool, rxPacket.RXInfoSet[0].MAC, *ns, params.NoisyChannelThreshold)
+		if err != nil {
+			return fmt.Errorf("get noisy channels error: %s", err)
+		}
+		noisyChannels, channelNoise = disableNoisyChannels(&chMask, channels)
+	}
+
+	snrMargin := snrM - requiredSNRTable[currentDR] - installationMargin - channelNoise
 	nStep := int(snrMargin / 3)
 
+	// the data-rate is not increased for nodes with a large frequency error,
+	// a positive margin is used for decreasing the TX power only
+	maxFrequencyOffset := getMaxFrequencyOffset(ns.UplinkHistory)
+	lockDR := params.MaxFrequencyOffset > 0 && maxFrequencyOffset > params.MaxFrequencyOffset
+
 	currentTXPower := getCurrentTXPower(ns)
 	currentTXPowerIndex := getTXPowerIndex(currentTXPower)
-	idealTXPower, idealDR := getIdealTXPowerAndDR(nStep, currentTXPower, currentDR)
+	var idealTXPower, idealDR int
+	switch {
+	case ns.ADRStrategy == session.ADRMinimizeTXPower || lockDR:
+		idealTXPower, idealDR = getIdealTXPowerAndDRMinimizeTXPower(nStep, currentTXPower, currentDR)
+	default:
+		idealTXPower, idealDR = getIdealTXPowerAndDR(nStep, currentTXPower, currentDR)
+	}
+	if idealDR > params.MaxDR {
+		idealDR = params.MaxDR
+	}
+	if lockDR && idealDR > currentDR {
+		idealDR = currentDR
+	}
 	idealTXPowerIndex := getTXPowerIndex(idealTXPower)
 	idealNbRep := getNbRep(ns.NbTrans, ns.GetPacketLossPercentage())
 
-	// there is nothing to adjust
-	if currentTXPowerIndex == idealTXPowerIndex && currentDR == idealDR {
-		return nil
+	decision := Decision{
+		Time:                 time.Now(),
+		FCntUp:               fullFCnt,
+		Decision:             DecisionNothingToAdjust,
+		Strategy:             ns.ADRStrategy,
+		UplinkHistory:        append([]session.UplinkHistory(nil), ns.UplinkHistory...),
+		MaxSNR:               snrM,
+		RequiredSNR:          requiredSNRTable[currentDR],
+		InstallationMargin:   installationMargin,
+		SNRMargin:            snrMargin,
+		NStep:                nStep,
+		PacketLossPercentage: ns.GetPacketLossPercentage(),
+		MaxDR:                params.MaxDR,
+		DataRate:             currentDR,
+		TXPower:              currentTXPower,
+		NbTrans:              int(ns.NbTrans),
+		ReqDataRate:          idealDR,
+		ReqTXPower:           common.Band.TXPower[idealTXPowerIndex],
+		ReqNbTrans:           int(idealNbRep),
+		ChannelNoise:         channelNoise,
+		MaxFrequencyOffset:   maxFrequencyOffset,
+		NoisyChannels:        noisyChannels,
 	}
 
-	var chMask lorawan.ChMask
-	for i := 0; i < len(common.Band.DownlinkChannels); i++ {
-		chMask[i] = true
-	}
-
-	for i := 0; ns.CFList != nil && i < len(ns.CFList); i++ {
-		if ns.CFList[i] == 0 {
-			continue
-		}
-		chMask[i+len(common.Band.DownlinkChannels)] = true
+	// there is nothing to adjust
+	if currentTXPowerIndex == idealTXPowerIndex && currentDR == idealDR && len(noisyChannels) == 0 && !channelMaskPending {
+		recordDecision(ctx, ns.DevEUI, decision)
+		return nil
 	}
 
 	mac := lorawan.MACCommand{
@@ -176,9 +243,69 @@ func HandleADR(ctx common.Context, ns *session.NodeSession, rxPacket models.RXPa
 		"req_nb_trans": idealNbRep,
 	}).Info("adr request added to mac-command queue")
 
+	decision.Decision = DecisionLinkADRReqEnqueued
+	decision.LinkADRReq = b
+	recordDecision(ctx, ns.DevEUI, decision)
+
 	return nil
 }
 
+// GetChMask returns the LinkADRReq channel mask enabling the default
+// channels of the band and the channels of the CFList of the node, or the
+// enabled channels of the node when these are tracked (see the
+// channelplan package). When the node has a channel mask (see
+// session.SetChannelMask), only the defined channels of the node which are
+// part of this mask are enabled, unless none of these is defined.
+func GetChMask(ns session.NodeSession) lorawan.ChMask {
+	var chMask lorawan.ChMask
+	if ns.ChannelMask != nil {
+		var enabled int
+		for _, c := range ns.GetUplinkChannels() {
+			if c.Index < len(chMask) && ns.InChannelMask(c.Index) {
+				chMask[c.Index] = true
+				enabled++
+			}
+		}
+		if enabled > 0 {
+			return chMask
+		}
+		chMask = lorawan.ChMask{}
+	}
+
+	if ns.UplinkChannels != nil {
+		for _, i := range ns.GetEnabledUplinkChannels() {
+			if i < len(chMask) {
+				chMask[i] = true
+			}
+		}
+		return chMask
+	}
+
+	for i := 0; i < len(common.Band.DownlinkChannels); i++ {
+		chMask[i] = true
+	}
+
+	for i := 0; ns.CFList != nil && i < len(ns.CFList); i++ {
+		if ns.CFList[i] == 0 {
+			continue
+		}
+		chMask[i+len(common.Band.DownlinkChannels)] = true
+	}
+	return chMask
+}
+
+// getEnabledChMask returns the channel mask of the currently enabled
+// uplink channels of the node.
+func getEnabledChMask(ns session.NodeSession) lorawan.ChMask {
+	var chMask lorawan.ChMask
+	for _, i := range ns.GetEnabledUplinkChannels() {
+		if i < len(chMask) {
+			chMask[i] = true
+		}
+	}
+	return chMask
+}
+
 func getCurrentTXPower(ns *session.NodeSession) int {
 	if ns.TXPower > 0 {
 		return ns.TXPower
@@ -186,6 +313,10 @@ func getCurrentTXPower(ns *session.NodeSession) int {
 	return common.Band.DefaultTXPower
 }
 
+func getMaxDR() int {
+	return GetParameters().MaxDR
+}
+
 func getMaxTXPower() int {
 	return common.Band.TXPower[0]
 }
@@ -217,7 +348,7 @@ func getIdealTXPowerAndDR(nStep int, txPower int, dr int) (int, int) {
 	}
 
 	if nStep > 0 {
-		if dr < 5 {
+		if dr < getMaxDR() {
 			dr++
 		} else {
 			txPower -= 3
@@ -237,3 +368,32 @@ func getIdealTXPowerAndDR(nStep int, txPower int, dr int) (int, int) {
 
 	return getIdealTXPowerAndDR(nStep, txPower, dr)
 }
+
+// getIdealTXPowerAndDRMinimizeTXPower returns the ideal TX power and DR like
+// getIdealTXPowerAndDR, but in case of a positive margin it first decreases
+// the TX power before increasing the data-rate.
+func getIdealTXPowerAndDRMinimizeTXPower(nStep int, txPower int, dr int) (int, int) {
+	if nStep == 0 {
+		return txPower, dr
+	}
+
+	if nStep > 0 {
+		if txPower-3 >= getMinTXPower() {
+			txPower -= 3
+		} else if dr < getMaxDR() {
+			dr++
+		} else {
+			return txPower, dr
+		}
+		nStep--
+	} else {
+		if txPower < getMaxTXPower() {
+			txPower += 3
+			nStep++
+		} else {
+			return txPower, dr
+		}
+	}
+
+	return getIdealTXPowerAndDRMinimizeTXPower(nStep, txPower, dr)
+}
@@ -115,6 +115,34 @@ func TestADR(t *testing.T) {
 			}
 		})
 
+		Convey("Given a testtable for getIdealTXPowerAndDRMinimizeTXPower", func() {
+			testTable := []struct {
+				NStep           int
+				TXPower         int
+				DR              int
+				ExpectedTXPower int
+				ExpectedDR      int
+			}{
+				{NStep: 0, TXPower: 14, DR: 3, ExpectedTXPower: 14, ExpectedDR: 3},
+				{NStep: 1, TXPower: 14, DR: 3, ExpectedTXPower: 11, ExpectedDR: 3},
+				{NStep: 4, TXPower: 14, DR: 3, ExpectedTXPower: 2, ExpectedDR: 3},
+				{NStep: 6, TXPower: 14, DR: 3, ExpectedTXPower: 2, ExpectedDR: 5},
+				{NStep: 9, TXPower: 14, DR: 3, ExpectedTXPower: 2, ExpectedDR: 5},
+				{NStep: -1, TXPower: 14, DR: 4, ExpectedTXPower: 17, ExpectedDR: 4},
+				{NStep: -1, TXPower: 20, DR: 4, ExpectedTXPower: 20, ExpectedDR: 4},
+			}
+
+			for i, tst := range testTable {
+				Convey(fmt.Sprintf("Given NStep: %d, TXPower: %d, DR: %d [%d]", tst.NStep, tst.TXPower, tst.DR, i), func() {
+					Convey(fmt.Sprintf("Then the ideal TXPower is %d and DR %d", tst.ExpectedTXPower, tst.ExpectedDR), func() {
+						idealTXPower, idealDR := getIdealTXPowerAndDRMinimizeTXPower(tst.NStep, tst.TXPower, tst.DR)
+						So(idealTXPower, ShouldEqual, tst.ExpectedTXPower)
+						So(idealDR, ShouldEqual, tst.ExpectedDR)
+					})
+				})
+			}
+		})
+
 		Convey("Given a clean Redis database", func() {
 			p := common.NewRedisPool(conf.RedisURL)
 			test.MustFlushRedis(p)
@@ -324,9 +352,52 @@ func TestADR(t *testing.T) {
 						pending, err := maccommand.ReadPending(p, tst.NodeSession.DevEUI, lorawan.LinkADRReq)
 						So(err, ShouldBeNil)
 						So(pending, ShouldResemble, tst.ExpectedMACPending)
+
+						decisions, err := GetDecisions(p, tst.NodeSession.DevEUI, 0, 0)
+						So(err, ShouldBeNil)
+						if len(tst.ExpectedMACPayloadQueue) > 0 {
+							So(decisions, ShouldHaveLength, 1)
+							So(decisions[0].Decision, ShouldEqual, DecisionLinkADRReqEnqueued)
+							So(decisions[0].FCntUp, ShouldEqual, tst.FullFCnt)
+							So(decisions[0].LinkADRReq, ShouldResemble, tst.ExpectedMACPayloadQueue[0].Data)
+							So(decisions[0].UplinkHistory, ShouldResemble, tst.ExpectedNodeSession.UplinkHistory)
+						}
 					})
 				}
 			})
 		})
 	})
 }
+
+func TestGetChMask(t *testing.T) {
+	Convey("Given a node-session with a CFList (EU band)", t, func() {
+		ns := session.NodeSession{
+			CFList: &lorawan.CFList{867100000, 867300000},
+		}
+
+		Convey("Then all channels are enabled", func() {
+			So(GetChMask(ns), ShouldResemble, lorawan.ChMask{true, true, true, true, true})
+		})
+
+		Convey("Given a channel mask excluding channels 1 and 3", func() {
+			So(ns.SetChannelMask([]int{0, 2, 4}), ShouldBeNil)
+
+			Convey("Then only the channels of the mask are enabled", func() {
+				So(GetChMask(ns), ShouldResemble, lorawan.ChMask{true, false, true, false, true})
+			})
+
+			Convey("Then the channel mask differs from the enabled channels", func() {
+				So(GetChMask(ns), ShouldNotResemble, getEnabledChMask(ns))
+			})
+
+			Convey("When the channels of the mask are removed from the node", func() {
+				ns.SetUplinkChannel(session.UplinkChannel{Index: 4})
+				ns.ChannelMask = []int{4}
+
+				Convey("Then the channel mask is ignored", func() {
+					So(GetChMask(ns), ShouldResemble, lorawan.ChMask{true, true, true, true})
+				})
+			})
+		})
+	})
+}
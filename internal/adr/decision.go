@@ -0,0 +1,157 @@
+package adr
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// decisionLogKeyTempl contains per node a list of the last ADR decisions
+// (newest first).
+const decisionLogKeyTempl = "lora:ns:adr:decision:%s"
+
+const (
+	// decisionLogSize defines the number of decisions kept per node.
+	decisionLogSize = 20
+
+	// DecisionLogTTL defines how long the decisions of a node are kept
+	// after its last decision.
+	DecisionLogTTL = time.Hour * 24 * 30
+)
+
+// Possible ADR decisions.
+const (
+	DecisionLinkADRReqEnqueued = "LINK_ADR_REQ_ENQUEUED"
+	DecisionNothingToAdjust    = "NOTHING_TO_ADJUST"
+)
+
+// Decision contains an ADR decision following an uplink of a node, together
+// with the inputs of this decision.
+type Decision struct {
+	Time     time.Time
+	FCntUp   uint32 // the frame-counter of the uplink
+	Decision string // one of the Decision... constants
+
+	// Inputs of the decision.
+	Strategy             session.ADRStrategy
+	UplinkHistory        []session.UplinkHistory
+	MaxSNR               float64 // max SNR of the uplink history
+	RequiredSNR          float64 // required SNR of the current data-rate
+	InstallationMargin   float64
+	SNRMargin            float64
+	NStep                int
+	PacketLossPercentage float64
+	MaxDR                int
+	DataRate             int
+	TXPower              int
+	NbTrans              int
+	ChannelNoise         float64 // noise of the noisiest channel which could not be disabled
+	MaxFrequencyOffset   int     // max frequency error (Hz) of the uplink history
+
+	// Outputs of the decision.
+	ReqDataRate int
+	ReqTXPower  int
+	ReqNbTrans  int
+	LinkADRReq  []byte // the enqueued LinkADRReq mac-command
+
+	// NoisyChannels contains the frequencies of the channels disabled in
+	// the channel mask of the LinkADRReq.
+	NoisyChannels []int
+}
+
+// recordDecision logs and stores the given decision for the given node.
+// Errors are logged as they must not affect the handling of the uplink.
+func recordDecision(ctx common.Context, devEUI lorawan.EUI64, d Decision) {
+	log.WithFields(log.Fields{
+		"dev_eui":      devEUI,
+		"fcnt_up":      d.FCntUp,
+		"decision":     d.Decision,
+		"max_snr":      d.MaxSNR,
+		"required_snr": d.RequiredSNR,
+		"margin":       d.InstallationMargin,
+		"snr_margin":   d.SNRMargin,
+		"n_step":       d.NStep,
+		"dr":           d.DataRate,
+		"req_dr":       d.ReqDataRate,
+		"tx_power":     d.TXPower,
+		"req_tx_power": d.ReqTXPower,
+		"nb_trans":     d.NbTrans,
+		"req_nb_trans": d.ReqNbTrans,
+	}).Info("adr decision")
+
+	if err := saveDecision(ctx.RedisPool, devEUI, d); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("save adr decision error: %s", err)
+	}
+}
+
+// saveDecision adds the given decision to the decision log of the node.
+func saveDecision(p *redis.Pool, devEUI lorawan.EUI64, d Decision) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		return errors.Wrap(err, "gob encode decision error")
+	}
+
+	key := fmt.Sprintf(decisionLogKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, buf.Bytes())
+	c.Send("LTRIM", key, 0, decisionLogSize-1)
+	c.Send("PEXPIRE", key, int64(DecisionLogTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save decision error")
+	}
+	return nil
+}
+
+// GetDecisionCount returns the number of logged ADR decisions of the given
+// node.
+func GetDecisionCount(p *redis.Pool, devEUI lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("LLEN", fmt.Sprintf(decisionLogKeyTempl, devEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get decision count error")
+	}
+	return count, nil
+}
+
+// GetDecisions returns the last ADR decisions of the given node (newest
+// first), respecting the given limit (0 = all) and offset.
+func GetDecisions(p *redis.Pool, devEUI lorawan.EUI64, limit, offset int) ([]Decision, error) {
+	stop := -1
+	if limit > 0 {
+		stop = offset + limit - 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(decisionLogKeyTempl, devEUI), offset, stop))
+	if err != nil {
+		return nil, errors.Wrap(err, "get decisions error")
+	}
+
+	var out []Decision
+	for _, b := range values {
+		var d Decision
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&d); err != nil {
+			return nil, errors.Wrap(err, "gob decode decision error")
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
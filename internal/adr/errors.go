@@ -0,0 +1,11 @@
+package adr
+
+import "errors"
+
+// adr errors
+var (
+	ErrInvalidMaxDR                 = errors.New("invalid max data-rate")
+	ErrInvalidInstallationMargin    = errors.New("installation margin must not be negative")
+	ErrInvalidNoisyChannelThreshold = errors.New("noisy channel threshold must not be negative")
+	ErrInvalidMaxFrequencyOffset    = errors.New("max frequency offset must not be negative")
+)
@@ -0,0 +1,91 @@
+package adr
+
+import (
+	"sort"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/channelnoise"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// noisyChannel contains an uplink channel of a node which is noisy at the
+// serving gateway.
+type noisyChannel struct {
+	Index     int
+	Frequency int
+	Noise     float64 // noise (dB) above the quietest channel of the gateway
+}
+
+// getNoisyChannels returns the enabled uplink channels of the given node of
+// which the noise at the given gateway exceeds the given threshold, sorted
+// by noise (noisiest first).
+func getNoisyChannels(p *redis.Pool, mac lorawan.EUI64, ns session.NodeSession, threshold float64) ([]noisyChannel, error) {
+	floors, err := channelnoise.GetNoiseFloors(p, mac)
+	if err != nil {
+		return nil, errors.Wrap(err, "get noise floors error")
+	}
+
+	var out []noisyChannel
+	for _, c := range ns.GetUplinkChannels() {
+		if !c.Enabled {
+			continue
+		}
+		if noise := channelnoise.GetExcessNoise(floors, c.Frequency); noise > threshold {
+			out = append(out, noisyChannel{Index: c.Index, Frequency: c.Frequency, Noise: noise})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Noise > out[j].Noise })
+	return out, nil
+}
+
+// disableNoisyChannels disables the given noisy channels (noisiest first)
+// in the given channel mask (the first block of 16 channels), as long as at
+// least one channel stays enabled. It returns the frequencies of the disabled channels and the noise of the
+// noisiest channel which stays enabled (0 when all were disabled).
+func disableNoisyChannels(chMask *lorawan.ChMask, channels []noisyChannel) ([]int, float64) {
+	var enabled int
+	for _, on := range chMask {
+		if on {
+			enabled++
+		}
+	}
+
+	var disabled []int
+	for _, c := range channels {
+		// the channel is not part of the first block of 16 channels
+		if c.Index >= len(chMask) {
+			return disabled, c.Noise
+		}
+		if !chMask[c.Index] {
+			continue
+		}
+		if enabled == 1 {
+			return disabled, c.Noise
+		}
+		chMask[c.Index] = false
+		enabled--
+		disabled = append(disabled, c.Frequency)
+	}
+	return disabled, 0
+}
+
+// getMaxFrequencyOffset returns the max. (absolute) frequency error of the
+// given uplink history.
+func getMaxFrequencyOffset(history []session.UplinkHistory) int {
+	var max int
+	for _, uh := range history {
+		offset := uh.FrequencyOffset
+		if offset < 0 {
+			offset = -offset
+		}
+		if offset > max {
+			max = offset
+		}
+	}
+	return max
+}
@@ -0,0 +1,57 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+func TestDisableNoisyChannels(t *testing.T) {
+	Convey("Given a channel mask with three enabled channels", t, func() {
+		chMask := lorawan.ChMask{true, true, true}
+
+		Convey("When two channels are noisy", func() {
+			disabled, noise := disableNoisyChannels(&chMask, []noisyChannel{
+				{Index: 2, Frequency: 868500000, Noise: 12},
+				{Index: 1, Frequency: 868300000, Noise: 8},
+			})
+
+			Convey("Then both channels are disabled", func() {
+				So(disabled, ShouldResemble, []int{868500000, 868300000})
+				So(noise, ShouldEqual, 0)
+				So(chMask, ShouldResemble, lorawan.ChMask{true})
+			})
+		})
+
+		Convey("When all channels are noisy", func() {
+			disabled, noise := disableNoisyChannels(&chMask, []noisyChannel{
+				{Index: 2, Frequency: 868500000, Noise: 12},
+				{Index: 1, Frequency: 868300000, Noise: 8},
+				{Index: 0, Frequency: 868100000, Noise: 7},
+			})
+
+			Convey("Then the least noisy channel stays enabled", func() {
+				So(disabled, ShouldResemble, []int{868500000, 868300000})
+				So(noise, ShouldEqual, 7)
+				So(chMask, ShouldResemble, lorawan.ChMask{true})
+			})
+		})
+	})
+}
+
+func TestGetMaxFrequencyOffset(t *testing.T) {
+	Convey("Given an uplink history with positive and negative frequency errors", t, func() {
+		history := []session.UplinkHistory{
+			{FCnt: 1, FrequencyOffset: 1500},
+			{FCnt: 2, FrequencyOffset: -4200},
+			{FCnt: 3},
+		}
+
+		Convey("Then the max absolute frequency error is returned", func() {
+			So(getMaxFrequencyOffset(history), ShouldEqual, 4200)
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package adr
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// maxSupportedDR defines the highest data-rate supported by the ADR engine.
+const maxSupportedDR = 5
+
+// Parameters contains the global ADR parameters. These parameters are
+// persisted in the database and can be changed at runtime.
+type Parameters struct {
+	// InstallationMargin is used for nodes without an installation margin
+	// in their node-session.
+	InstallationMargin float64 `db:"installation_margin"`
+
+	// MaxDR is the max data-rate the ADR engine will request.
+	MaxDR int `db:"max_dr"`
+
+	// RespondToADRACKReq defines if ADRACKReq uplinks are responded with a
+	// downlink. When disabled, nodes fall back to a lower data-rate after
+	// ADR_ACK_LIMIT + ADR_ACK_DELAY uplinks without downlink.
+	RespondToADRACKReq bool `db:"respond_to_adr_ack_req"`
+
+	// NoisyChannelThreshold defines the noise (dB) above the quietest
+	// channel of the serving gateway from which a channel is considered
+	// noisy (0 = disabled). The excess noise of the uplink channel is
+	// subtracted from the SNR margin and the noisy channels are disabled
+	// in the LinkADRReq channel mask.
+	NoisyChannelThreshold float64 `db:"noisy_channel_threshold"`
+
+	// MaxFrequencyOffset defines the frequency error (Hz) of the uplinks
+	// of a node above which the data-rate is not increased (0 = disabled).
+	MaxFrequencyOffset int `db:"max_frequency_offset"`
+
+	// UpdatedAt is nil when the defaults are used.
+	UpdatedAt *time.Time `db:"updated_at"`
+}
+
+// Validate validates the ADR parameters.
+func (p Parameters) Validate() error {
+	if p.MaxDR < 0 || p.MaxDR > maxSupportedDR {
+		return ErrInvalidMaxDR
+	}
+	if p.InstallationMargin < 0 {
+		return ErrInvalidInstallationMargin
+	}
+	if p.NoisyChannelThreshold < 0 {
+		return ErrInvalidNoisyChannelThreshold
+	}
+	if p.MaxFrequencyOffset < 0 {
+		return ErrInvalidMaxFrequencyOffset
+	}
+	return nil
+}
+
+// DefaultParameters contains the ADR parameters used when no parameters
+// have been stored in the database.
+var DefaultParameters = Parameters{
+	MaxDR:              maxSupportedDR,
+	RespondToADRACKReq: true,
+}
+
+var (
+	parametersMux sync.RWMutex
+	parameters    = DefaultParameters
+)
+
+// GetParameters returns the current ADR parameters.
+func GetParameters() Parameters {
+	parametersMux.RLock()
+	defer parametersMux.RUnlock()
+	return parameters
+}
+
+func setParameters(p Parameters) {
+	parametersMux.Lock()
+	defer parametersMux.Unlock()
+	parameters = p
+}
+
+// LoadParameters loads the ADR parameters from the database. In case no
+// parameters have been stored, the defaults are used.
+func LoadParameters(db *sqlx.DB) error {
+	var p Parameters
+	err := db.Get(&p, `
+		select
+			installation_margin,
+			max_dr,
+			respond_to_adr_ack_req,
+			noisy_channel_threshold,
+			max_frequency_offset,
+			updated_at
+		from adr_parameters
+		where id = 1`)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			setParameters(DefaultParameters)
+			return nil
+		}
+		return errors.Wrap(err, "select error")
+	}
+	setParameters(p)
+	return nil
+}
+
+// SaveParameters validates and stores the given ADR parameters in the
+// database and uses them from then on.
+func SaveParameters(db *sqlx.DB, p Parameters) error {
+	if err := p.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	_, err := db.Exec(`
+		insert into adr_parameters (
+			id,
+			installation_margin,
+			max_dr,
+			respond_to_adr_ack_req,
+			noisy_channel_threshold,
+			max_frequency_offset,
+			updated_at
+		) values (1, $1, $2, $3, $4, $5, $6)
+		on conflict (id) do update set
+			installation_margin = excluded.installation_margin,
+			max_dr = excluded.max_dr,
+			respond_to_adr_ack_req = excluded.respond_to_adr_ack_req,
+			noisy_channel_threshold = excluded.noisy_channel_threshold,
+			max_frequency_offset = excluded.max_frequency_offset,
+			updated_at = excluded.updated_at`,
+		p.InstallationMargin,
+		p.MaxDR,
+		p.RespondToADRACKReq,
+		p.NoisyChannelThreshold,
+		p.MaxFrequencyOffset,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+	p.UpdatedAt = &now
+	setParameters(p)
+
+	log.WithFields(log.Fields{
+		"installation_margin":     p.InstallationMargin,
+		"max_dr":                  p.MaxDR,
+		"respond_to_adr_ack_req":  p.RespondToADRACKReq,
+		"noisy_channel_threshold": p.NoisyChannelThreshold,
+		"max_frequency_offset":    p.MaxFrequencyOffset,
+	}).Info("adr parameters updated")
+	return nil
+}
+
+// RefreshParameters periodically re-loads the ADR parameters from the
+// database, so that changes made through an other LoRa Server instance
+// are picked up. This function blocks.
+func RefreshParameters(db *sqlx.DB, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := LoadParameters(db); err != nil {
+			log.Errorf("load adr parameters error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package adr
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParametersValidate(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Parameters    Parameters
+			ExpectedError error
+		}{
+			{Parameters{MaxDR: 0}, nil},
+			{Parameters{MaxDR: 5, InstallationMargin: 5}, nil},
+			{Parameters{MaxDR: 6}, ErrInvalidMaxDR},
+			{Parameters{MaxDR: -1}, ErrInvalidMaxDR},
+			{Parameters{MaxDR: 5, InstallationMargin: -1}, ErrInvalidInstallationMargin},
+			{Parameters{MaxDR: 5, NoisyChannelThreshold: 6, MaxFrequencyOffset: 5000}, nil},
+			{Parameters{MaxDR: 5, NoisyChannelThreshold: -1}, ErrInvalidNoisyChannelThreshold},
+			{Parameters{MaxDR: 5, MaxFrequencyOffset: -1}, ErrInvalidMaxFrequencyOffset},
+		}
+
+		for _, tst := range testTable {
+			So(tst.Parameters.Validate(), ShouldEqual, tst.ExpectedError)
+		}
+	})
+}
+
+func TestParameters(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+		defer setParameters(DefaultParameters)
+
+		Convey("Then loading the parameters returns the defaults", func() {
+			So(LoadParameters(db), ShouldBeNil)
+			So(GetParameters(), ShouldResemble, DefaultParameters)
+		})
+
+		Convey("When saving invalid parameters", func() {
+			err := SaveParameters(db, Parameters{MaxDR: 7})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidMaxDR)
+			})
+		})
+
+		Convey("When saving the parameters", func() {
+			params := Parameters{
+				InstallationMargin: 7.5,
+				MaxDR:              3,
+				RespondToADRACKReq: false,
+			}
+			So(SaveParameters(db, params), ShouldBeNil)
+
+			Convey("Then they are used directly", func() {
+				p := GetParameters()
+				So(p.InstallationMargin, ShouldEqual, 7.5)
+				So(p.MaxDR, ShouldEqual, 3)
+				So(p.RespondToADRACKReq, ShouldBeFalse)
+				So(p.UpdatedAt, ShouldNotBeNil)
+			})
+
+			Convey("Then they can be loaded from the database", func() {
+				setParameters(DefaultParameters)
+				So(LoadParameters(db), ShouldBeNil)
+				p := GetParameters()
+				So(p.InstallationMargin, ShouldEqual, 7.5)
+				So(p.MaxDR, ShouldEqual, 3)
+				So(p.RespondToADRACKReq, ShouldBeFalse)
+			})
+
+			Convey("Then they can be updated", func() {
+				params.MaxDR = 4
+				So(SaveParameters(db, params), ShouldBeNil)
+				setParameters(DefaultParameters)
+				So(LoadParameters(db), ShouldBeNil)
+				So(GetParameters().MaxDR, ShouldEqual, 4)
+			})
+
+			Convey("Then the ideal data-rate never exceeds the max data-rate", func() {
+				_, dr := getIdealTXPowerAndDR(3, 14, 2)
+				So(dr, ShouldEqual, 3)
+			})
+		})
+	})
+}
@@ -0,0 +1,208 @@
+package airtime
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// gatewayKeyTempl contains per gateway and hour a hash with the
+	// airtime (in microseconds), downlink and rejected counters per sub-band
+	gatewayKeyTempl = "lora:ns:airtime:gw:%s:%d"
+
+	// gatewayDeviceKeyTempl contains per gateway and hour a sorted set of
+	// DevEUIs scored by their airtime (in microseconds)
+	gatewayDeviceKeyTempl = "lora:ns:airtime:gw-dev:%s:%d"
+
+	// deviceDailyKeyTempl contains per node and day (YYYY-MM-DD) the
+	// downlink airtime (in microseconds)
+	deviceDailyKeyTempl = "lora:ns:airtime:device:%s:%s"
+
+	airtimeField  = "airtime:"
+	countField    = "count:"
+	rejectedField = "rejected:"
+
+	// Retention defines how long the airtime accounting is stored.
+	Retention = time.Hour * 24 * 31
+)
+
+// SubBandReport contains the downlink capacity usage of a single sub-band.
+type SubBandReport struct {
+	SubBand SubBand
+
+	// Airtime contains the total downlink airtime.
+	Airtime time.Duration
+
+	// DutyCycleConsumed contains the fraction of the duty-cycle budget
+	// consumed over the requested time range (0 when the sub-band has no
+	// duty-cycle limit).
+	DutyCycleConsumed float64
+
+	DownlinkCount int // the number of transmitted downlinks
+	RejectedCount int // the number of downlinks rejected by the gateway backend
+}
+
+// DeviceAirtime contains the downlink airtime consumed by a node.
+type DeviceAirtime struct {
+	DevEUI  lorawan.EUI64
+	Airtime time.Duration
+}
+
+// CapacityReport contains the downlink capacity usage of a gateway.
+type CapacityReport struct {
+	SubBands       []SubBandReport
+	BusiestDevices []DeviceAirtime
+}
+
+type bySubBandName []SubBandReport
+
+func (s bySubBandName) Len() int           { return len(s) }
+func (s bySubBandName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s bySubBandName) Less(i, j int) bool { return s[i].SubBand.Name < s[j].SubBand.Name }
+
+type byAirtimeDesc []DeviceAirtime
+
+func (s byAirtimeDesc) Len() int           { return len(s) }
+func (s byAirtimeDesc) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byAirtimeDesc) Less(i, j int) bool { return s[i].Airtime > s[j].Airtime }
+
+// RecordDownlink records the airtime of the given downlink transmitted for
+// the given node.
+func RecordDownlink(p *redis.Pool, devEUI lorawan.EUI64, txPacket gw.TXPacket) error {
+	airtime, err := GetTXPacketAirtime(txPacket)
+	if err != nil {
+		return errors.Wrap(err, "get airtime error")
+	}
+
+	sb := GetSubBand(txPacket.TXInfo.Frequency)
+	hour := time.Now().Truncate(time.Hour).Unix()
+	gwKey := fmt.Sprintf(gatewayKeyTempl, txPacket.TXInfo.MAC, hour)
+	devKey := fmt.Sprintf(gatewayDeviceKeyTempl, txPacket.TXInfo.MAC, hour)
+	devDailyKey := fmt.Sprintf(deviceDailyKeyTempl, devEUI, dayOf(time.Now()))
+	exp := int64(Retention / time.Millisecond)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", gwKey, airtimeField+sb.Name, int64(airtime/time.Microsecond))
+	c.Send("HINCRBY", gwKey, countField+sb.Name, 1)
+	c.Send("PEXPIRE", gwKey, exp)
+	c.Send("ZINCRBY", devKey, int64(airtime/time.Microsecond), devEUI.String())
+	c.Send("PEXPIRE", devKey, exp)
+	c.Send("INCRBY", devDailyKey, int64(airtime/time.Microsecond))
+	c.Send("PEXPIRE", devDailyKey, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record airtime error")
+	}
+	return nil
+}
+
+// RecordRejected records a downlink for the given gateway and frequency
+// which could not be transmitted.
+func RecordRejected(p *redis.Pool, mac lorawan.EUI64, frequency int) error {
+	sb := GetSubBand(frequency)
+	gwKey := fmt.Sprintf(gatewayKeyTempl, mac, time.Now().Truncate(time.Hour).Unix())
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", gwKey, rejectedField+sb.Name, 1)
+	c.Send("PEXPIRE", gwKey, int64(Retention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record rejected downlink error")
+	}
+	return nil
+}
+
+// GetCapacityReport returns the downlink capacity report for the given
+// gateway and time range (with an hour granularity). The busiest devices
+// are limited to the given limit.
+func GetCapacityReport(p *redis.Pool, mac lorawan.EUI64, start, end time.Time, limit int) (CapacityReport, error) {
+	var report CapacityReport
+
+	if !end.After(start) {
+		return report, errors.New("end must be after start")
+	}
+	if min := time.Now().Add(-Retention); start.Before(min) {
+		start = min
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	subBands := make(map[string]*SubBandReport)
+	devices := make(map[string]int64)
+
+	for t := start.Truncate(time.Hour); t.Before(end); t = t.Add(time.Hour) {
+		values, err := redis.Int64Map(c.Do("HGETALL", fmt.Sprintf(gatewayKeyTempl, mac, t.Unix())))
+		if err != nil {
+			return report, errors.Wrap(err, "get gateway airtime error")
+		}
+
+		for field, v := range values {
+			i := strings.Index(field, ":")
+			if i == -1 {
+				continue
+			}
+			name := field[i+1:]
+
+			sbReport, ok := subBands[name]
+			if !ok {
+				sbReport = &SubBandReport{SubBand: getSubBandByName(name)}
+				subBands[name] = sbReport
+			}
+
+			switch field[:i+1] {
+			case airtimeField:
+				sbReport.Airtime += time.Duration(v) * time.Microsecond
+			case countField:
+				sbReport.DownlinkCount += int(v)
+			case rejectedField:
+				sbReport.RejectedCount += int(v)
+			}
+		}
+
+		devValues, err := redis.Int64Map(c.Do("ZRANGE", fmt.Sprintf(gatewayDeviceKeyTempl, mac, t.Unix()), 0, -1, "WITHSCORES"))
+		if err != nil {
+			return report, errors.Wrap(err, "get device airtime error")
+		}
+		for devEUI, v := range devValues {
+			devices[devEUI] += v
+		}
+	}
+
+	for _, sbReport := range subBands {
+		if sbReport.SubBand.DutyCycle > 0 {
+			sbReport.DutyCycleConsumed = float64(sbReport.Airtime) / (sbReport.SubBand.DutyCycle * float64(end.Sub(start)))
+		}
+		report.SubBands = append(report.SubBands, *sbReport)
+	}
+	sort.Sort(bySubBandName(report.SubBands))
+
+	for s, v := range devices {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(s)); err != nil {
+			return report, errors.Wrap(err, "unmarshal deveui error")
+		}
+		report.BusiestDevices = append(report.BusiestDevices, DeviceAirtime{
+			DevEUI:  devEUI,
+			Airtime: time.Duration(v) * time.Microsecond,
+		})
+	}
+	sort.Sort(byAirtimeDesc(report.BusiestDevices))
+	if limit > 0 && len(report.BusiestDevices) > limit {
+		report.BusiestDevices = report.BusiestDevices[:limit]
+	}
+
+	return report, nil
+}
@@ -0,0 +1,103 @@
+package airtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCapacityReport(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and the EU band", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.BandName = band.EU_863_870
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		devEUI1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		devEUI2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		txPacket := gw.TXPacket{
+			TXInfo: gw.TXInfo{
+				MAC:       mac,
+				Frequency: 868100000,
+				DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+		txAirtime, err := GetTXPacketAirtime(txPacket)
+		So(err, ShouldBeNil)
+
+		Convey("When recording two downlinks for one node and one for an other node and a rejected downlink", func() {
+			So(RecordDownlink(p, devEUI1, txPacket), ShouldBeNil)
+			So(RecordDownlink(p, devEUI1, txPacket), ShouldBeNil)
+			So(RecordDownlink(p, devEUI2, txPacket), ShouldBeNil)
+			So(RecordRejected(p, mac, 869525000), ShouldBeNil)
+
+			Convey("Then the capacity report contains the usage per sub-band", func() {
+				end := time.Now()
+				start := end.Add(-time.Hour)
+				report, err := GetCapacityReport(p, mac, start, end, 1)
+				So(err, ShouldBeNil)
+
+				So(report.SubBands, ShouldHaveLength, 2)
+				So(report.SubBands[0].SubBand.Name, ShouldEqual, "h1.5")
+				So(report.SubBands[0].Airtime, ShouldEqual, 3*txAirtime)
+				So(report.SubBands[0].DownlinkCount, ShouldEqual, 3)
+				So(report.SubBands[0].DutyCycleConsumed, ShouldAlmostEqual, float64(3*txAirtime)/(0.01*float64(end.Sub(start))))
+				So(report.SubBands[1].SubBand.Name, ShouldEqual, "h1.7")
+				So(report.SubBands[1].RejectedCount, ShouldEqual, 1)
+
+				So(report.BusiestDevices, ShouldResemble, []DeviceAirtime{
+					{DevEUI: devEUI1, Airtime: 2 * txAirtime},
+				})
+			})
+
+			Convey("Then the airtime of today is accounted per node", func() {
+				today, err := GetDeviceAirtimeToday(p, devEUI1)
+				So(err, ShouldBeNil)
+				So(today, ShouldEqual, 2*txAirtime)
+
+				days, err := GetDeviceDailyAirtime(p, devEUI1, 2)
+				So(err, ShouldBeNil)
+				So(days, ShouldHaveLength, 2)
+				So(days[0].Date, ShouldEqual, dayOf(time.Now()))
+				So(days[0].Airtime, ShouldEqual, 2*txAirtime)
+				So(days[1].Airtime, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestNextDayStart(t *testing.T) {
+	Convey("Given the UTC timezone", t, func() {
+		loc := common.TimeLocation
+		common.TimeLocation = time.UTC
+		Reset(func() {
+			common.TimeLocation = loc
+		})
+
+		Convey("Then the next day starts at the following midnight", func() {
+			next := NextDayStart(time.Date(2017, 12, 31, 13, 30, 0, 0, time.UTC))
+			So(next.Equal(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		})
+
+		Convey("Then the given time is converted to the timezone first", func() {
+			next := NextDayStart(time.Date(2017, 12, 31, 23, 30, 0, 0, time.FixedZone("", -2*3600)))
+			So(next.Equal(time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		})
+	})
+}
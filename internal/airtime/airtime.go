@@ -0,0 +1,91 @@
+// Package airtime implements the downlink airtime accounting per gateway,
+// sub-band and node.
+package airtime
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/brocaar/lorawan/band"
+	"github.com/joriwind/loraserver/api/gw"
+)
+
+const (
+	loRaPreambleSymbols = 8
+	loRaCodingRate      = 1 // 4/5
+
+	fskPreambleBytes = 5
+	fskSyncWordBytes = 3
+	fskLengthBytes   = 1
+	fskCRCBytes      = 2
+)
+
+// CalculateLoRaAirtime returns the time-on-air of a LoRa frame with the
+// given payload size (PHYPayload), spreading-factor and bandwidth (in kHz).
+// It assumes an explicit header, coding-rate 4/5, a preamble of 8 symbols
+// and CRC when set to true (uplink).
+func CalculateLoRaAirtime(payloadSize, spreadFactor, bandwidth int, crc bool) (time.Duration, error) {
+	if spreadFactor < 6 || spreadFactor > 12 {
+		return 0, errors.New("spread-factor must be between 6 and 12")
+	}
+	if bandwidth == 0 {
+		return 0, errors.New("bandwidth must be > 0")
+	}
+
+	// symbol duration in seconds
+	tSym := math.Pow(2, float64(spreadFactor)) / float64(bandwidth*1000)
+
+	// low data-rate optimization is mandated for symbol durations > 16ms
+	var de float64
+	if tSym > 0.016 {
+		de = 1
+	}
+
+	var crcBits float64
+	if crc {
+		crcBits = 16
+	}
+
+	sf := float64(spreadFactor)
+	payloadSymbols := 8 + math.Max(math.Ceil((8*float64(payloadSize)-4*sf+28+crcBits)/(4*(sf-2*de)))*(loRaCodingRate+4), 0)
+	tPreamble := (loRaPreambleSymbols + 4.25) * tSym
+
+	return secondsToDuration(tPreamble + payloadSymbols*tSym), nil
+}
+
+// CalculateFSKAirtime returns the time-on-air of a FSK frame with the given
+// payload size (PHYPayload) and bit-rate.
+func CalculateFSKAirtime(payloadSize, bitRate int) (time.Duration, error) {
+	if bitRate == 0 {
+		return 0, errors.New("bit-rate must be > 0")
+	}
+
+	bits := (fskPreambleBytes + fskSyncWordBytes + fskLengthBytes + payloadSize + fskCRCBytes) * 8
+	return secondsToDuration(float64(bits) / float64(bitRate)), nil
+}
+
+// secondsToDuration returns the given seconds as duration, rounded to
+// microsecond precision.
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Floor(s*1e6+0.5)) * time.Microsecond
+}
+
+// GetTXPacketAirtime returns the time-on-air of the given downlink packet.
+func GetTXPacketAirtime(txPacket gw.TXPacket) (time.Duration, error) {
+	b, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return 0, fmt.Errorf("marshal phypayload error: %s", err)
+	}
+
+	switch txPacket.TXInfo.DataRate.Modulation {
+	case band.LoRaModulation:
+		// downlink frames are sent without CRC
+		return CalculateLoRaAirtime(len(b), txPacket.TXInfo.DataRate.SpreadFactor, txPacket.TXInfo.DataRate.Bandwidth, false)
+	case band.FSKModulation:
+		return CalculateFSKAirtime(len(b), txPacket.TXInfo.DataRate.BitRate)
+	default:
+		return 0, fmt.Errorf("unknown modulation: %s", txPacket.TXInfo.DataRate.Modulation)
+	}
+}
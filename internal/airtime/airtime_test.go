@@ -0,0 +1,51 @@
+package airtime
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCalculateLoRaAirtime(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		testTable := []struct {
+			PayloadSize  int
+			SpreadFactor int
+			Bandwidth    int
+			CRC          bool
+			Expected     time.Duration
+			Error        bool
+		}{
+			{20, 7, 125, true, 56576 * time.Microsecond, false},
+			{13, 12, 125, true, 1155072 * time.Microsecond, false},
+			{12, 9, 125, false, 144384 * time.Microsecond, false},
+			{12, 12, 125, false, 991232 * time.Microsecond, false},
+			{12, 13, 125, false, 0, true},
+			{12, 7, 0, false, 0, true},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: size: %d, sf: %d, bw: %d, crc: %v [%d]", test.PayloadSize, test.SpreadFactor, test.Bandwidth, test.CRC, i), func() {
+				d, err := CalculateLoRaAirtime(test.PayloadSize, test.SpreadFactor, test.Bandwidth, test.CRC)
+				if test.Error {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(d, ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestCalculateFSKAirtime(t *testing.T) {
+	Convey("Given a FSK frame of 12 bytes at 50kbps", t, func() {
+		Convey("Then the airtime is 3.68ms", func() {
+			d, err := CalculateFSKAirtime(12, 50000)
+			So(err, ShouldBeNil)
+			So(d, ShouldEqual, 3680*time.Microsecond)
+		})
+	})
+}
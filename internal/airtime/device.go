@@ -0,0 +1,93 @@
+package airtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+// dateFormat defines the format of the day in the device airtime keys.
+const dateFormat = "2006-01-02"
+
+// DailyAirtime contains the downlink airtime consumed by a node on a
+// single day.
+type DailyAirtime struct {
+	Date    string // YYYY-MM-DD, in common.TimeLocation
+	Airtime time.Duration
+}
+
+// dayOf returns the day (YYYY-MM-DD) of the given time in the configured
+// timezone, so that the daily budgets reset at local midnight.
+func dayOf(t time.Time) string {
+	return t.In(common.TimeLocation).Format(dateFormat)
+}
+
+// NextDayStart returns the start of the day (local midnight) following the
+// given time, at which the daily budgets reset.
+func NextDayStart(t time.Time) time.Time {
+	t = t.In(common.TimeLocation)
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, common.TimeLocation)
+}
+
+// GetDeviceDailyAirtime returns the downlink airtime consumed by the given
+// node per day for the given number of days including today (newest
+// first). Days are limited to the Retention.
+func GetDeviceDailyAirtime(p *redis.Pool, devEUI lorawan.EUI64, days int) ([]DailyAirtime, error) {
+	if days < 1 {
+		days = 1
+	}
+	if max := int(Retention / (24 * time.Hour)); days > max {
+		days = max
+	}
+
+	var args []interface{}
+	var out []DailyAirtime
+	now := time.Now().In(common.TimeLocation)
+	for i := 0; i < days; i++ {
+		date := now.AddDate(0, 0, -i).Format(dateFormat)
+		args = append(args, fmt.Sprintf(deviceDailyKeyTempl, devEUI, date))
+		out = append(out, DailyAirtime{Date: date})
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("MGET", args...))
+	if err != nil {
+		return nil, errors.Wrap(err, "get device airtime error")
+	}
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		us, err := redis.Int64(v, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "get device airtime error")
+		}
+		out[i].Airtime = time.Duration(us) * time.Microsecond
+	}
+
+	return out, nil
+}
+
+// GetDeviceAirtimeToday returns the downlink airtime consumed by the given
+// node today.
+func GetDeviceAirtimeToday(p *redis.Pool, devEUI lorawan.EUI64) (time.Duration, error) {
+	c := p.Get()
+	defer c.Close()
+
+	us, err := redis.Int64(c.Do("GET", fmt.Sprintf(deviceDailyKeyTempl, devEUI, dayOf(time.Now()))))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "get device airtime error")
+	}
+	return time.Duration(us) * time.Microsecond, nil
+}
@@ -0,0 +1,51 @@
+package airtime
+
+import (
+	"github.com/brocaar/lorawan/band"
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// SubBand defines a sub-band with its duty-cycle limit.
+type SubBand struct {
+	Name         string
+	MinFrequency int     // in Hz (inclusive)
+	MaxFrequency int     // in Hz (exclusive)
+	DutyCycle    float64 // e.g. 0.01 for 1%, 0 when there is no limit
+}
+
+// subBands contains the regulatory sub-bands per ISM band. Bands without
+// duty-cycle regulations are not included.
+var subBands = map[band.Name][]SubBand{
+	band.EU_863_870: {
+		{Name: "h1.3", MinFrequency: 863000000, MaxFrequency: 865000000, DutyCycle: 0.001},
+		{Name: "h1.4", MinFrequency: 865000000, MaxFrequency: 868000000, DutyCycle: 0.01},
+		{Name: "h1.5", MinFrequency: 868000000, MaxFrequency: 868600000, DutyCycle: 0.01},
+		{Name: "h1.6", MinFrequency: 868700000, MaxFrequency: 869200000, DutyCycle: 0.001},
+		{Name: "h1.7", MinFrequency: 869400000, MaxFrequency: 869650000, DutyCycle: 0.1},
+		{Name: "h1.8", MinFrequency: 869700000, MaxFrequency: 870000000, DutyCycle: 0.01},
+	},
+}
+
+// unknownSubBand is returned for frequencies without known sub-band.
+var unknownSubBand = SubBand{Name: "other"}
+
+// GetSubBand returns the sub-band for the given frequency (in Hz) of the
+// configured ISM band.
+func GetSubBand(frequency int) SubBand {
+	for _, sb := range subBands[common.BandName] {
+		if frequency >= sb.MinFrequency && frequency < sb.MaxFrequency {
+			return sb
+		}
+	}
+	return unknownSubBand
+}
+
+// getSubBandByName returns the sub-band for the given name.
+func getSubBandByName(name string) SubBand {
+	for _, sb := range subBands[common.BandName] {
+		if sb.Name == name {
+			return sb
+		}
+	}
+	return SubBand{Name: name}
+}
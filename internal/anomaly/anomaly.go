@@ -0,0 +1,75 @@
+// Package anomaly implements the hooks for detecting anomalous uplink
+// behaviour of nodes (e.g. cloned or replayed devices).
+package anomaly
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Features contains the features of a single uplink, used as input for the
+// anomaly detector.
+type Features struct {
+	DevEUI     lorawan.EUI64
+	ReceivedAt time.Time
+	DataRate   int
+	FCnt       uint32 // full (32 bit) frame-counter of the uplink
+
+	// GatewayMACs contains the MACs of the gateways which received the
+	// uplink.
+	GatewayMACs []lorawan.EUI64
+
+	// HasPrevious is set to true when the fields below contain the
+	// features of the previous uplink.
+	HasPrevious         bool
+	InterArrival        time.Duration
+	PreviousDataRate    int
+	PreviousFCnt        uint32
+	PreviousGatewayMACs []lorawan.EUI64
+}
+
+// Anomaly describes a detected anomaly.
+type Anomaly struct {
+	Reason string
+}
+
+// Detector defines the interface of an anomaly detector.
+type Detector interface {
+	// Detect returns the detected anomaly or nil when the uplink looks
+	// normal.
+	Detect(f Features) (*Anomaly, error)
+}
+
+var (
+	detectorMu sync.RWMutex
+	detector   Detector
+)
+
+// SetDetector sets the anomaly detector to use. Setting it to nil disables
+// the anomaly detection.
+func SetDetector(d Detector) {
+	detectorMu.Lock()
+	defer detectorMu.Unlock()
+	detector = d
+}
+
+// Detect runs the configured anomaly detector on the given features. When
+// no detector has been configured, nil is returned.
+func Detect(f Features) (*Anomaly, error) {
+	detectorMu.RLock()
+	d := detector
+	detectorMu.RUnlock()
+
+	if d == nil {
+		return nil, nil
+	}
+
+	a, err := d.Detect(f)
+	if err != nil {
+		return nil, fmt.Errorf("detect anomaly error: %s", err)
+	}
+	return a, nil
+}
@@ -0,0 +1,73 @@
+package anomaly
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brocaar/lorawan"
+)
+
+// HeuristicDetector implements a simple rule based anomaly detector.
+// Rules with a zero value are disabled.
+type HeuristicDetector struct {
+	// MinInterArrival defines the minimum expected time between two
+	// uplinks. Two physical devices using the same session (clone) usually
+	// result in a much shorter inter-arrival time.
+	MinInterArrival time.Duration
+
+	// MaxFCntJump defines the max expected frame-counter increment between
+	// two uplinks.
+	MaxFCntJump uint32
+
+	// GatewaySetWindow defines the time in which the set of receiving
+	// gateways is not expected to change completely (e.g. the device
+	// is received at a different location).
+	GatewaySetWindow time.Duration
+}
+
+// NewHeuristicDetector returns a HeuristicDetector with the default rules.
+func NewHeuristicDetector() *HeuristicDetector {
+	return &HeuristicDetector{
+		MinInterArrival:  time.Second,
+		MaxFCntJump:      1000,
+		GatewaySetWindow: 10 * time.Second,
+	}
+}
+
+// Detect implements the Detector interface.
+func (d *HeuristicDetector) Detect(f Features) (*Anomaly, error) {
+	if !f.HasPrevious {
+		return nil, nil
+	}
+
+	if d.MinInterArrival > 0 && f.InterArrival < d.MinInterArrival {
+		return &Anomaly{
+			Reason: fmt.Sprintf("uplink inter-arrival time %s is below %s", f.InterArrival, d.MinInterArrival),
+		}, nil
+	}
+
+	if d.MaxFCntJump > 0 && f.FCnt-f.PreviousFCnt > d.MaxFCntJump {
+		return &Anomaly{
+			Reason: fmt.Sprintf("frame-counter jumped from %d to %d", f.PreviousFCnt, f.FCnt),
+		}, nil
+	}
+
+	if d.GatewaySetWindow > 0 && f.InterArrival < d.GatewaySetWindow && len(f.PreviousGatewayMACs) > 0 && !hasCommonGateway(f.GatewayMACs, f.PreviousGatewayMACs) {
+		return &Anomaly{
+			Reason: fmt.Sprintf("set of receiving gateways changed completely within %s", f.InterArrival),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func hasCommonGateway(a, b []lorawan.EUI64) bool {
+	for _, macA := range a {
+		for _, macB := range b {
+			if macA == macB {
+				return true
+			}
+		}
+	}
+	return false
+}
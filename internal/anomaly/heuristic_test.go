@@ -0,0 +1,95 @@
+package anomaly
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHeuristicDetector(t *testing.T) {
+	Convey("Given a HeuristicDetector with the default rules", t, func() {
+		d := NewHeuristicDetector()
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		testTable := []struct {
+			Name     string
+			Features Features
+			Anomaly  bool
+		}{
+			{
+				Name:     "first uplink",
+				Features: Features{FCnt: 10, GatewayMACs: []lorawan.EUI64{gw1}},
+			},
+			{
+				Name: "normal uplink",
+				Features: Features{
+					FCnt:                11,
+					GatewayMACs:         []lorawan.EUI64{gw1, gw2},
+					HasPrevious:         true,
+					InterArrival:        time.Minute,
+					PreviousFCnt:        10,
+					PreviousGatewayMACs: []lorawan.EUI64{gw1},
+				},
+			},
+			{
+				Name: "inter-arrival too short",
+				Features: Features{
+					FCnt:                11,
+					GatewayMACs:         []lorawan.EUI64{gw1},
+					HasPrevious:         true,
+					InterArrival:        100 * time.Millisecond,
+					PreviousFCnt:        10,
+					PreviousGatewayMACs: []lorawan.EUI64{gw1},
+				},
+				Anomaly: true,
+			},
+			{
+				Name: "frame-counter jump",
+				Features: Features{
+					FCnt:                2000,
+					GatewayMACs:         []lorawan.EUI64{gw1},
+					HasPrevious:         true,
+					InterArrival:        time.Minute,
+					PreviousFCnt:        10,
+					PreviousGatewayMACs: []lorawan.EUI64{gw1},
+				},
+				Anomaly: true,
+			},
+			{
+				Name: "gateway set changed within window",
+				Features: Features{
+					FCnt:                11,
+					GatewayMACs:         []lorawan.EUI64{gw2},
+					HasPrevious:         true,
+					InterArrival:        5 * time.Second,
+					PreviousFCnt:        10,
+					PreviousGatewayMACs: []lorawan.EUI64{gw1},
+				},
+				Anomaly: true,
+			},
+			{
+				Name: "gateway set changed outside window",
+				Features: Features{
+					FCnt:                11,
+					GatewayMACs:         []lorawan.EUI64{gw2},
+					HasPrevious:         true,
+					InterArrival:        time.Minute,
+					PreviousFCnt:        10,
+					PreviousGatewayMACs: []lorawan.EUI64{gw1},
+				},
+			},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				a, err := d.Detect(test.Features)
+				So(err, ShouldBeNil)
+				So(a != nil, ShouldEqual, test.Anomaly)
+			})
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/metrics"
+)
+
+var callDuration = metrics.NewHistogram("loraserver_api_call_duration_seconds", "Duration of the network-server API calls.", metrics.DurationBuckets, "method", "code")
+
+// UnaryDeadlineInterceptor applies common.APIRequestTimeout to the API calls
+// for which the client did not set a deadline and returns DEADLINE_EXCEEDED
+// (or CANCELLED) as soon as the context of the call is done, also when the
+// handler is still blocked on a backend which does not support cancellation
+// (e.g. Redis and PostgreSQL). In the latter case the handler completes in
+// the background, of which the result is discarded. The duration of the
+// API calls is recorded as metric.
+func UnaryDeadlineInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func(start time.Time) {
+		callDuration.ObserveDuration(start, info.FullMethod, grpc.Code(err).String())
+	}(time.Now())
+
+	if _, ok := ctx.Deadline(); !ok && common.APIRequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, common.APIRequestTimeout)
+		defer cancel()
+	}
+
+	type result struct {
+		resp interface{}
+		err  error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		resp, err := handler(ctx, req)
+		done <- result{resp: resp, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.resp, r.err
+	case <-ctx.Done():
+		log.WithField("method", info.FullMethod).Warningf("api call aborted: %s", ctx.Err())
+		return nil, errToRPCError(ctx, ctx.Err())
+	}
+}
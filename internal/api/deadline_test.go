@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/joriwind/loraserver/internal/common"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnaryDeadlineInterceptor(t *testing.T) {
+	Convey("Given a handler blocking until released", t, func() {
+		release := make(chan struct{})
+		defer close(release)
+
+		hasDeadline := make(chan bool, 1)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, ok := ctx.Deadline()
+			hasDeadline <- ok
+			<-release
+			return "done", nil
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: "/ns.NetworkServer/GetNodeSession"}
+
+		Convey("When the deadline set by the client is exceeded", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			resp, err := UnaryDeadlineInterceptor(ctx, nil, info, handler)
+
+			Convey("Then DEADLINE_EXCEEDED is returned without waiting for the handler", func() {
+				So(resp, ShouldBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.DeadlineExceeded)
+				So(time.Since(start), ShouldBeLessThan, time.Second)
+			})
+		})
+
+		Convey("When the call is cancelled by the client", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := UnaryDeadlineInterceptor(ctx, nil, info, handler)
+
+			Convey("Then CANCELLED is returned", func() {
+				So(grpc.Code(err), ShouldEqual, codes.Canceled)
+			})
+		})
+
+		Convey("Given an API request timeout and a call without deadline", func() {
+			common.APIRequestTimeout = 10 * time.Millisecond
+			defer func() { common.APIRequestTimeout = 0 }()
+
+			_, err := UnaryDeadlineInterceptor(context.Background(), nil, info, handler)
+
+			Convey("Then the timeout is applied to the call", func() {
+				So(grpc.Code(err), ShouldEqual, codes.DeadlineExceeded)
+				So(<-hasDeadline, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a handler returning directly", t, func() {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "done", nil
+		}
+
+		Convey("Then the response of the handler is returned", func() {
+			resp, err := UnaryDeadlineInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+			So(err, ShouldBeNil)
+			So(resp, ShouldEqual, "done")
+		})
+	})
+}
@@ -1,36 +1,177 @@
 package api
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/adr"
+	"github.com/joriwind/loraserver/internal/channelplan"
+	"github.com/joriwind/loraserver/internal/clockdrift"
+	"github.com/joriwind/loraserver/internal/deviceprofile"
 	"github.com/joriwind/loraserver/internal/downlink"
 	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/multicast"
+	"github.com/joriwind/loraserver/internal/rules"
 	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/templates"
 )
 
-var errToCode = map[error]codes.Code{
-	downlink.ErrFPortMustNotBeZero:     codes.InvalidArgument,
-	downlink.ErrFPortMustBeZero:        codes.InvalidArgument,
-	downlink.ErrNoLastRXInfoSet:        codes.FailedPrecondition,
-	downlink.ErrInvalidDataRate:        codes.Internal,
-	downlink.ErrMaxPayloadSizeExceeded: codes.InvalidArgument,
+// errorCodeMetadataKey defines the trailer metadata key containing the
+// machine-readable ns.ErrorCode of a failed call.
+const errorCodeMetadataKey = "error-code"
 
-	gateway.ErrDoesNotExist:               codes.NotFound,
-	gateway.ErrAlreadyExists:              codes.AlreadyExists,
-	gateway.ErrInvalidAggregationInterval: codes.InvalidArgument,
-	gateway.ErrInvalidName:                codes.InvalidArgument,
+// resetAtMetadataKey defines the trailer metadata key containing the time
+// (RFC3339) at which an exceeded quota resets (see
+// deviceprofile.QuotaError).
+const resetAtMetadataKey = "reset-at"
 
-	session.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
-	session.ErrDoesNotExist:                   codes.NotFound,
+type rpcErrorCode struct {
+	code      codes.Code
+	errorCode ns.ErrorCode
 }
 
-func errToRPCError(err error) error {
+var errToCode = map[error]rpcErrorCode{
+	adr.ErrInvalidMaxDR:                 {codes.InvalidArgument, ns.ErrorCode_INVALID_ADR_PARAMETERS},
+	adr.ErrInvalidInstallationMargin:    {codes.InvalidArgument, ns.ErrorCode_INVALID_ADR_PARAMETERS},
+	adr.ErrInvalidNoisyChannelThreshold: {codes.InvalidArgument, ns.ErrorCode_INVALID_ADR_PARAMETERS},
+	adr.ErrInvalidMaxFrequencyOffset:    {codes.InvalidArgument, ns.ErrorCode_INVALID_ADR_PARAMETERS},
+
+	clockdrift.ErrNotEnoughSamples: {codes.FailedPrecondition, ns.ErrorCode_NOT_ENOUGH_UPLINKS},
+
+	context.DeadlineExceeded: {codes.DeadlineExceeded, ns.ErrorCode_DEADLINE_EXCEEDED},
+	context.Canceled:         {codes.Canceled, ns.ErrorCode_REQUEST_CANCELLED},
+
+	downlink.ErrFPortMustNotBeZero:       {codes.InvalidArgument, ns.ErrorCode_INVALID_FPORT},
+	downlink.ErrFPortMustBeZero:          {codes.InvalidArgument, ns.ErrorCode_INVALID_FPORT},
+	downlink.ErrNoLastRXInfoSet:          {codes.FailedPrecondition, ns.ErrorCode_NO_LAST_RX_INFO_SET},
+	downlink.ErrInvalidDataRate:          {codes.Internal, ns.ErrorCode_INVALID_DATA_RATE},
+	downlink.ErrMaxPayloadSizeExceeded:   {codes.InvalidArgument, ns.ErrorCode_MAX_PAYLOAD_SIZE_EXCEEDED},
+	downlink.ErrUnknownRXWindow:          {codes.Internal, ns.ErrorCode_UNKNOWN_RX_WINDOW},
+	downlink.ErrDeviceClassChangePending: {codes.FailedPrecondition, ns.ErrorCode_DEVICE_CLASS_CHANGE_PENDING},
+	downlink.ErrNotClassC:                {codes.FailedPrecondition, ns.ErrorCode_NOT_CLASS_C_DEVICE},
+	downlink.ErrNoAllowedGateway:         {codes.FailedPrecondition, ns.ErrorCode_NO_ALLOWED_GATEWAY},
+	downlink.ErrAppSKeyNotOffloaded:      {codes.FailedPrecondition, ns.ErrorCode_APP_SKEY_NOT_OFFLOADED},
+	downlink.ErrDailyAirtimeCapReached:   {codes.ResourceExhausted, ns.ErrorCode_DAILY_AIRTIME_CAP_REACHED},
+	downlink.ErrReadOnlyMode:             {codes.Unavailable, ns.ErrorCode_READ_ONLY_MODE},
+	downlink.ErrDeadlineExceeded:         {codes.DeadlineExceeded, ns.ErrorCode_DEADLINE_EXCEEDED},
+	downlink.ErrBenchModeDisabled:        {codes.FailedPrecondition, ns.ErrorCode_BENCH_MODE_DISABLED},
+	downlink.ErrInvalidBenchMACCommand:   {codes.InvalidArgument, ns.ErrorCode_INVALID_MAC_COMMAND},
+
+	maccommand.ErrHandledByNetworkServer: {codes.FailedPrecondition, ns.ErrorCode_MAC_COMMAND_HANDLED_BY_NETWORK_SERVER},
+
+	gateway.ErrDoesNotExist:                {codes.NotFound, ns.ErrorCode_GATEWAY_DOES_NOT_EXIST},
+	gateway.ErrAlreadyExists:               {codes.AlreadyExists, ns.ErrorCode_GATEWAY_ALREADY_EXISTS},
+	gateway.ErrInvalidAggregationInterval:  {codes.InvalidArgument, ns.ErrorCode_INVALID_AGGREGATION_INTERVAL},
+	gateway.ErrInvalidName:                 {codes.InvalidArgument, ns.ErrorCode_INVALID_GATEWAY_NAME},
+	gateway.ErrCUPSCredentialsDoNotExist:   {codes.NotFound, ns.ErrorCode_GATEWAY_CUPS_CREDENTIALS_DO_NOT_EXIST},
+	gateway.ErrClientCANotConfigured:       {codes.FailedPrecondition, ns.ErrorCode_GATEWAY_CLIENT_CA_NOT_CONFIGURED},
+	gateway.ErrOnboardingTokenDoesNotExist: {codes.NotFound, ns.ErrorCode_GATEWAY_ONBOARDING_TOKEN_DOES_NOT_EXIST},
+
+	models.ErrInvalidTXParams: {codes.InvalidArgument, ns.ErrorCode_INVALID_TX_PARAMETERS},
+	models.ErrInvalidTags:     {codes.InvalidArgument, ns.ErrorCode_INVALID_TAGS},
+
+	multicast.ErrDoesNotExist:           {codes.NotFound, ns.ErrorCode_MULTICAST_GROUP_DOES_NOT_EXIST},
+	multicast.ErrInvalidName:            {codes.InvalidArgument, ns.ErrorCode_INVALID_MULTICAST_GROUP},
+	multicast.ErrInvalidDataRate:        {codes.InvalidArgument, ns.ErrorCode_INVALID_MULTICAST_GROUP},
+	multicast.ErrInvalidFrequency:       {codes.InvalidArgument, ns.ErrorCode_INVALID_MULTICAST_GROUP},
+	multicast.ErrNoGateways:             {codes.InvalidArgument, ns.ErrorCode_INVALID_MULTICAST_GROUP},
+	multicast.ErrFPortMustNotBeZero:     {codes.InvalidArgument, ns.ErrorCode_INVALID_FPORT},
+	multicast.ErrMaxPayloadSizeExceeded: {codes.InvalidArgument, ns.ErrorCode_MAX_PAYLOAD_SIZE_EXCEEDED},
+	multicast.ErrInvalidFCnt:            {codes.InvalidArgument, ns.ErrorCode_INVALID_MULTICAST_FCNT},
+
+	rules.ErrDoesNotExist:            {codes.NotFound, ns.ErrorCode_UPLINK_RULE_DOES_NOT_EXIST},
+	rules.ErrInvalidName:             {codes.InvalidArgument, ns.ErrorCode_INVALID_UPLINK_RULE},
+	rules.ErrInvalidAction:           {codes.InvalidArgument, ns.ErrorCode_INVALID_UPLINK_RULE},
+	rules.ErrInvalidCondition:        {codes.InvalidArgument, ns.ErrorCode_INVALID_UPLINK_RULE},
+	rules.ErrInvalidActionParameters: {codes.InvalidArgument, ns.ErrorCode_INVALID_UPLINK_RULE},
+
+	templates.ErrDoesNotExist:    {codes.NotFound, ns.ErrorCode_DOWNLINK_TEMPLATE_DOES_NOT_EXIST},
+	templates.ErrInvalidName:     {codes.InvalidArgument, ns.ErrorCode_INVALID_DOWNLINK_TEMPLATE},
+	templates.ErrInvalidFPort:    {codes.InvalidArgument, ns.ErrorCode_INVALID_DOWNLINK_TEMPLATE},
+	templates.ErrInvalidPayload:  {codes.InvalidArgument, ns.ErrorCode_INVALID_DOWNLINK_TEMPLATE},
+	templates.ErrUnknownVariable: {codes.InvalidArgument, ns.ErrorCode_INVALID_DOWNLINK_TEMPLATE},
+	templates.ErrTagDoesNotExist: {codes.FailedPrecondition, ns.ErrorCode_DOWNLINK_TEMPLATE_TAG_DOES_NOT_EXIST},
+
+	channelplan.ErrDoesNotExist:         {codes.NotFound, ns.ErrorCode_CHANNEL_CONFIGURATION_DOES_NOT_EXIST},
+	channelplan.ErrInvalidName:          {codes.InvalidArgument, ns.ErrorCode_INVALID_CHANNEL_CONFIGURATION},
+	channelplan.ErrInvalidBand:          {codes.InvalidArgument, ns.ErrorCode_INVALID_CHANNEL_CONFIGURATION},
+	channelplan.ErrInvalidChannels:      {codes.InvalidArgument, ns.ErrorCode_INVALID_CHANNEL_CONFIGURATION},
+	channelplan.ErrInvalidExtraChannels: {codes.InvalidArgument, ns.ErrorCode_INVALID_CHANNEL_CONFIGURATION},
+
+	deviceprofile.ErrDoesNotExist:        {codes.NotFound, ns.ErrorCode_DEVICE_PROFILE_DOES_NOT_EXIST},
+	deviceprofile.ErrInvalidName:         {codes.InvalidArgument, ns.ErrorCode_INVALID_DEVICE_PROFILE},
+	deviceprofile.ErrInvalidMaxQueueSize: {codes.InvalidArgument, ns.ErrorCode_INVALID_DEVICE_PROFILE},
+	deviceprofile.ErrInvalidAirtimeQuota: {codes.InvalidArgument, ns.ErrorCode_INVALID_DEVICE_PROFILE},
+	deviceprofile.ErrDeviceQueueFull:     {codes.ResourceExhausted, ns.ErrorCode_DEVICE_QUEUE_FULL},
+
+	session.ErrDoesNotExistOrFCntOrMICInvalid: {codes.NotFound, ns.ErrorCode_NODE_SESSION_DOES_NOT_EXIST},
+	session.ErrDoesNotExist:                   {codes.NotFound, ns.ErrorCode_NODE_SESSION_DOES_NOT_EXIST},
+	session.ErrAlreadyExists:                  {codes.AlreadyExists, ns.ErrorCode_NODE_SESSION_ALREADY_EXISTS},
+	session.ErrConcurrentUpdate:               {codes.Aborted, ns.ErrorCode_NODE_SESSION_CONCURRENT_UPDATE},
+	session.ErrInvalidPatch:                   {codes.InvalidArgument, ns.ErrorCode_NODE_SESSION_INVALID_PATCH},
+	session.ErrAppSKeyKEKNotConfigured:        {codes.FailedPrecondition, ns.ErrorCode_APP_SKEY_KEK_NOT_CONFIGURED},
+	session.ErrInvalidWrappedAppSKey:          {codes.InvalidArgument, ns.ErrorCode_INVALID_WRAPPED_APP_SKEY},
+	session.ErrInvalidDeviceClass:             {codes.InvalidArgument, ns.ErrorCode_INVALID_DEVICE_CLASS},
+	session.ErrInvalidMACVersion:              {codes.InvalidArgument, ns.ErrorCode_INVALID_MAC_VERSION},
+	session.ErrMACCommandNotSupported:         {codes.InvalidArgument, ns.ErrorCode_MAC_COMMAND_NOT_SUPPORTED},
+	session.ErrInvalidDevAddr:                 {codes.InvalidArgument, ns.ErrorCode_INVALID_DEV_ADDR},
+	session.ErrInvalidChannelMask:             {codes.InvalidArgument, ns.ErrorCode_INVALID_CHANNEL_MASK},
+}
+
+// errToRPCError maps the cause of the given (wrapped) error to a gRPC
+// error. The machine-readable ns.ErrorCode is set as trailer metadata
+// (see errorCodeMetadataKey) so that clients are able to branch on the
+// cause of the error. The deadline and cancellation errors of the backend
+// gRPC calls (e.g. to the application-server) are returned as such. For a
+// quota error, the reset time is set as trailer metadata too (see
+// resetAtMetadataKey).
+func errToRPCError(ctx context.Context, err error) error {
 	cause := errors.Cause(err)
-	code, ok := errToCode[cause]
-	if !ok {
-		code = codes.Unknown
+	code := causeToCode(cause)
+
+	kv := []string{errorCodeMetadataKey, code.errorCode.String()}
+	if qe, ok := cause.(*deviceprofile.QuotaError); ok {
+		kv = append(kv, resetAtMetadataKey, qe.ResetAt.Format(time.RFC3339))
+	}
+
+	// this fails when not called within a gRPC request context (e.g. in
+	// the tests), in which case the trailer is omitted
+	grpc.SetTrailer(ctx, metadata.Pairs(kv...))
+
+	return grpc.Errorf(code.code, grpc.ErrorDesc(cause))
+}
+
+// errToErrorCode returns the machine-readable ns.ErrorCode for the cause of
+// the given (wrapped) error.
+func errToErrorCode(err error) ns.ErrorCode {
+	return causeToCode(errors.Cause(err)).errorCode
+}
+
+func causeToCode(cause error) rpcErrorCode {
+	if code, ok := errToCode[cause]; ok {
+		return code
+	}
+	if _, ok := cause.(*deviceprofile.QuotaError); ok {
+		return rpcErrorCode{codes.ResourceExhausted, ns.ErrorCode_AIRTIME_QUOTA_EXCEEDED}
+	}
+	return grpcErrToCode(cause)
+}
+
+func grpcErrToCode(err error) rpcErrorCode {
+	switch grpc.Code(err) {
+	case codes.DeadlineExceeded:
+		return rpcErrorCode{codes.DeadlineExceeded, ns.ErrorCode_DEADLINE_EXCEEDED}
+	case codes.Canceled:
+		return rpcErrorCode{codes.Canceled, ns.ErrorCode_REQUEST_CANCELLED}
+	default:
+		return rpcErrorCode{codes.Unknown, ns.ErrorCode_UNKNOWN_ERROR}
 	}
-	return grpc.Errorf(code, cause.Error())
 }
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/deviceprofile"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/session"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErrToRPCError(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		testTable := []struct {
+			Err           error
+			ExpectedError error
+		}{
+			{
+				Err:           session.ErrDoesNotExist,
+				ExpectedError: grpc.Errorf(codes.NotFound, "node-session does not exist"),
+			},
+			{
+				Err:           errors.Wrap(errors.Wrapf(downlink.ErrInvalidDataRate, "rx2 dr: %d", 16), "get data down txinfo error"),
+				ExpectedError: grpc.Errorf(codes.Internal, "invalid data-rate"),
+			},
+			{
+				Err:           errors.Wrap(context.DeadlineExceeded, "get node-session error"),
+				ExpectedError: grpc.Errorf(codes.DeadlineExceeded, "context deadline exceeded"),
+			},
+			{
+				Err:           errors.Wrap(grpc.Errorf(codes.DeadlineExceeded, "context deadline exceeded"), "get data down error"),
+				ExpectedError: grpc.Errorf(codes.DeadlineExceeded, "context deadline exceeded"),
+			},
+			{
+				Err:           errors.Wrap(&deviceprofile.QuotaError{Quota: time.Minute, Used: time.Minute, ResetAt: time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)}, "check push error"),
+				ExpectedError: grpc.Errorf(codes.ResourceExhausted, "daily airtime quota of the device-profile exceeded (used: 1m0s, quota: 1m0s, resets at: 2017-01-02T00:00:00Z)"),
+			},
+			{
+				Err:           errors.New("unknown error"),
+				ExpectedError: grpc.Errorf(codes.Unknown, "unknown error"),
+			},
+		}
+
+		for _, test := range testTable {
+			So(errToRPCError(context.Background(), test.Err), ShouldResemble, test.ExpectedError)
+		}
+	})
+
+	Convey("Then all mapped errors have an error code", t, func() {
+		for err, code := range errToCode {
+			So(code.errorCode.String(), ShouldNotEqual, "UNKNOWN_ERROR")
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestErrToErrorCode(t *testing.T) {
+	Convey("Then the error code of the cause of a wrapped error is returned", t, func() {
+		So(errToErrorCode(errors.Wrap(session.ErrAlreadyExists, "create error")), ShouldEqual, ns.ErrorCode_NODE_SESSION_ALREADY_EXISTS)
+		So(errToErrorCode(errors.Wrap(context.Canceled, "get gateway error")), ShouldEqual, ns.ErrorCode_REQUEST_CANCELLED)
+		So(errToErrorCode(grpc.Errorf(codes.Canceled, "context canceled")), ShouldEqual, ns.ErrorCode_REQUEST_CANCELLED)
+		So(errToErrorCode(&deviceprofile.QuotaError{}), ShouldEqual, ns.ErrorCode_AIRTIME_QUOTA_EXCEEDED)
+		So(errToErrorCode(errors.New("unknown error")), ShouldEqual, ns.ErrorCode_UNKNOWN_ERROR)
+	})
+}
@@ -1,23 +1,72 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"time"
 
+	log "github.com/Sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/adr"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/channelplan"
+	"github.com/joriwind/loraserver/internal/channelstats"
+	"github.com/joriwind/loraserver/internal/check"
+	"github.com/joriwind/loraserver/internal/clockdrift"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/deviceprofile"
 	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/fcntrollover"
+	"github.com/joriwind/loraserver/internal/framelog"
 	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/joinstats"
+	"github.com/joriwind/loraserver/internal/linkmargin"
 	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/multicast"
+	"github.com/joriwind/loraserver/internal/oversize"
+	"github.com/joriwind/loraserver/internal/passive"
+	"github.com/joriwind/loraserver/internal/rules"
+	"github.com/joriwind/loraserver/internal/rx2mismatch"
+	"github.com/joriwind/loraserver/internal/security"
 	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/templates"
+	"github.com/joriwind/loraserver/internal/uplink"
+	"github.com/joriwind/loraserver/internal/uplinkstats"
 	"github.com/brocaar/lorawan"
 )
 
-// defaultCodeRate defines the default code rate
-const defaultCodeRate = "4/5"
+// exportNodeSessionsDefaultLimit defines the number of node-sessions to
+// export when no limit is given.
+const exportNodeSessionsDefaultLimit = 100
+
+// gatewayOrderByFields maps the API order by values to the gateway fields.
+var gatewayOrderByFields = map[ns.GatewayOrderBy]string{
+	ns.GatewayOrderBy_ORDER_BY_MAC:          gateway.OrderByMAC,
+	ns.GatewayOrderBy_ORDER_BY_NAME:         gateway.OrderByName,
+	ns.GatewayOrderBy_ORDER_BY_CREATED_AT:   gateway.OrderByCreatedAt,
+	ns.GatewayOrderBy_ORDER_BY_LAST_SEEN_AT: gateway.OrderByLastSeenAt,
+}
+
+// pageBounds returns the start and end index of the page of the given
+// limit (0 = all) and offset within a result-set of the given size.
+func pageBounds(total, limit, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return offset, end
+}
 
 // NetworkServerAPI defines the nework-server API.
 type NetworkServerAPI struct {
@@ -33,6 +82,19 @@ func NewNetworkServerAPI(ctx common.Context) *NetworkServerAPI {
 
 // CreateNodeSession create a node-session.
 func (n *NetworkServerAPI) CreateNodeSession(ctx context.Context, req *ns.CreateNodeSessionRequest) (*ns.CreateNodeSessionResponse, error) {
+	if err := n.createNodeSession(req); err != nil {
+		// validation errors are already rpc errors
+		if grpc.Code(err) != codes.Unknown {
+			return nil, err
+		}
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateNodeSessionResponse{}, nil
+}
+
+// createNodeSession creates the given node-session.
+func (n *NetworkServerAPI) createNodeSession(req *ns.CreateNodeSessionRequest) error {
 	sess := session.NodeSession{
 		FCntUp:             req.FCntUp,
 		FCntDown:           req.FCntDown,
@@ -40,15 +102,61 @@ func (n *NetworkServerAPI) CreateNodeSession(ctx context.Context, req *ns.Create
 		RX1DROffset:        uint8(req.Rx1DROffset),
 		RXWindow:           session.RXWindow(req.RxWindow),
 		RX2DR:              uint8(req.Rx2DR),
+		RX2Frequency:       int(req.Rx2Frequency),
 		RelaxFCnt:          req.RelaxFCnt,
 		ADRInterval:        req.AdrInterval,
 		InstallationMargin: req.InstallationMargin,
+		ADRStrategy:        session.ADRStrategy(req.AdrStrategy),
+		Relay:              req.Relay,
+		GatewayRegions:     req.GatewayRegions,
+		DownlinkTXParams: models.TXParams{
+			Power:    int(req.DownlinkTXPower),
+			CodeRate: req.DownlinkCodeRate,
+			IPol:     polarityToIPol(req.DownlinkPolarity),
+		},
+		BatteryThrottleLevel:    uint8(req.BatteryThrottleLevel),
+		ClassCFPort:             uint8(req.ClassCFPort),
+		ClassCWindow:            time.Duration(req.ClassCWindow) * time.Second,
+		TransmitDiversity:       req.TransmitDiversity,
+		DailyDownlinkAirtimeCap: time.Duration(req.DailyDownlinkAirtimeCap) * time.Millisecond,
+		Tags:                    req.Tags,
+		MACVersion:              req.MacVersion,
+		Geolocation:             req.Geolocation,
+		ChannelConfigurationID:  req.ChannelConfigurationID,
+		ForwardPHYPayload:       req.ForwardPHYPayload,
+		MaxUplinksPerHour:       req.MaxUplinksPerHour,
+		LegacyADRACKReq:         req.LegacyADRACKReq,
+		DeviceProfileID:         req.DeviceProfileID,
+	}
+
+	if err := validateRXWindow(sess); err != nil {
+		return err
+	}
+
+	if err := n.validateChannelConfiguration(sess.ChannelConfigurationID); err != nil {
+		return err
+	}
+
+	if err := n.validateDeviceProfile(sess.DeviceProfileID); err != nil {
+		return err
+	}
+
+	if err := sess.DownlinkTXParams.Validate(); err != nil {
+		return err
+	}
+
+	if err := sess.Tags.Validate(); err != nil {
+		return err
+	}
+
+	if err := session.ValidateMACVersion(sess.MACVersion); err != nil {
+		return err
 	}
 
 	if len(req.CFList) > 0 {
 		var cFList lorawan.CFList
 		if len(req.CFList) > len(cFList) {
-			return nil, grpc.Errorf(codes.InvalidArgument, "max length for CFList is %d", len(cFList))
+			return grpc.Errorf(codes.InvalidArgument, "max length for CFList is %d", len(cFList))
 		}
 
 		for i, f := range req.CFList {
@@ -63,23 +171,141 @@ func (n *NetworkServerAPI) CreateNodeSession(ctx context.Context, req *ns.Create
 	copy(sess.DevEUI[:], req.DevEUI)
 	copy(sess.NwkSKey[:], req.NwkSKey)
 
+	if err := session.ValidateDevAddr(sess.DevAddr, n.ctx.NetID); err != nil {
+		return err
+	}
+
+	appSKey, err := session.UnwrapAppSKey(req.WrappedAppSKey)
+	if err != nil {
+		return err
+	}
+	sess.AppSKey = appSKey
+
 	exists, err := session.NodeSessionExists(n.ctx.RedisPool, sess.DevEUI)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return err
 	}
 	if exists {
-		return nil, grpc.Errorf(codes.AlreadyExists, "node-session already exists")
+		return session.ErrAlreadyExists
 	}
 
 	if err := session.SaveNodeSession(n.ctx.RedisPool, sess); err != nil {
-		return nil, errToRPCError(err)
+		return err
+	}
+
+	return maccommand.FlushQueue(n.ctx.RedisPool, sess.DevEUI)
+}
+
+// ImportNodeSessions creates the given node-sessions. The node-sessions
+// which could not be created are reported per row.
+func (n *NetworkServerAPI) ImportNodeSessions(ctx context.Context, req *ns.ImportNodeSessionsRequest) (*ns.ImportNodeSessionsResponse, error) {
+	var resp ns.ImportNodeSessionsResponse
+
+	for i, nsReq := range req.NodeSessions {
+		if err := n.createNodeSession(nsReq); err != nil {
+			resp.Errors = append(resp.Errors, &ns.ImportNodeSessionError{
+				Index:     int32(i),
+				DevEUI:    nsReq.DevEUI,
+				ErrorCode: errToErrorCode(err),
+				Error:     grpc.ErrorDesc(err),
+			})
+			continue
+		}
+		resp.CreatedCount++
 	}
 
-	if err := maccommand.FlushQueue(n.ctx.RedisPool, sess.DevEUI); err != nil {
-		return nil, errToRPCError(err)
+	return &resp, nil
+}
+
+// ExportNodeSessions returns a batch of node-sessions.
+func (n *NetworkServerAPI) ExportNodeSessions(ctx context.Context, req *ns.ExportNodeSessionsRequest) (*ns.ExportNodeSessionsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = exportNodeSessionsDefaultLimit
 	}
 
-	return &ns.CreateNodeSessionResponse{}, nil
+	sessions, cursor, err := session.ListNodeSessions(n.ctx.RedisPool, req.Cursor, limit)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ExportNodeSessionsResponse{
+		Cursor: cursor,
+	}
+	for _, sess := range sessions {
+		if !sess.Tags.Matches(req.Tags) {
+			continue
+		}
+
+		wrappedAppSKey, err := session.WrapAppSKey(sess.AppSKey)
+		if err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+
+		nsReq := ns.CreateNodeSessionRequest{
+			DevAddr:            sess.DevAddr[:],
+			AppEUI:             sess.AppEUI[:],
+			DevEUI:             sess.DevEUI[:],
+			NwkSKey:            sess.NwkSKey[:],
+			FCntUp:             sess.FCntUp,
+			FCntDown:           sess.FCntDown,
+			RxDelay:            uint32(sess.RXDelay),
+			Rx1DROffset:        uint32(sess.RX1DROffset),
+			RxWindow:           ns.RXWindow(sess.RXWindow),
+			Rx2DR:              uint32(sess.RX2DR),
+			Rx2Frequency:       uint32(sess.RX2Frequency),
+			RelaxFCnt:          sess.RelaxFCnt,
+			AdrInterval:        sess.ADRInterval,
+			InstallationMargin: sess.InstallationMargin,
+			AdrStrategy:        ns.ADRStrategy(sess.ADRStrategy),
+			Relay:              sess.Relay,
+			WrappedAppSKey:     wrappedAppSKey,
+			GatewayRegions:     sess.GatewayRegions,
+			DownlinkTXPower:    uint32(sess.DownlinkTXParams.Power),
+			DownlinkCodeRate:   sess.DownlinkTXParams.CodeRate,
+			DownlinkPolarity:   iPolToPolarity(sess.DownlinkTXParams.IPol),
+
+			BatteryThrottleLevel:    uint32(sess.BatteryThrottleLevel),
+			ClassCFPort:             uint32(sess.ClassCFPort),
+			ClassCWindow:            uint32(sess.ClassCWindow / time.Second),
+			TransmitDiversity:       sess.TransmitDiversity,
+			DailyDownlinkAirtimeCap: uint32(sess.DailyDownlinkAirtimeCap / time.Millisecond),
+			Tags:                    sess.Tags,
+			MacVersion:              sess.MACVersion,
+			Geolocation:             sess.Geolocation,
+			ChannelConfigurationID:  sess.ChannelConfigurationID,
+			ForwardPHYPayload:       sess.ForwardPHYPayload,
+			MaxUplinksPerHour:       sess.MaxUplinksPerHour,
+			LegacyADRACKReq:         sess.LegacyADRACKReq,
+			DeviceProfileID:         sess.DeviceProfileID,
+		}
+		if sess.CFList != nil {
+			nsReq.CFList = sess.CFList[:]
+		}
+
+		resp.NodeSessions = append(resp.NodeSessions, &nsReq)
+	}
+
+	return &resp, nil
+}
+
+// GetDeviceActivation returns the activation parameters of a node as JSON
+// document.
+func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetDeviceActivationRequest) (*ns.GetDeviceActivationResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	b, err := json.Marshal(session.GetDeviceActivation(sess))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetDeviceActivationResponse{Activation: string(b)}, nil
 }
 
 // GetNodeSession returns a node-session.
@@ -89,7 +315,7 @@ func (n *NetworkServerAPI) GetNodeSession(ctx context.Context, req *ns.GetNodeSe
 
 	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	resp := &ns.GetNodeSessionResponse{
@@ -103,17 +329,66 @@ func (n *NetworkServerAPI) GetNodeSession(ctx context.Context, req *ns.GetNodeSe
 		Rx1DROffset:        uint32(sess.RX1DROffset),
 		RxWindow:           ns.RXWindow(sess.RXWindow),
 		Rx2DR:              uint32(sess.RX2DR),
+		Rx2Frequency:       uint32(sess.RX2Frequency),
 		RelaxFCnt:          sess.RelaxFCnt,
 		AdrInterval:        sess.ADRInterval,
 		InstallationMargin: sess.InstallationMargin,
+		AdrStrategy:        ns.ADRStrategy(sess.ADRStrategy),
+		Relay:              sess.Relay,
 		NbTrans:            uint32(sess.NbTrans),
 		TxPower:            uint32(sess.TXPower),
+		DeviceClass:        ns.DeviceClass(sess.DeviceClass),
+		PendingDeviceClass: ns.DeviceClass(sess.PendingDeviceClass),
+		GatewayRegions:     sess.GatewayRegions,
+		DownlinkTXPower:    uint32(sess.DownlinkTXParams.Power),
+		DownlinkCodeRate:   sess.DownlinkTXParams.CodeRate,
+		DownlinkPolarity:   iPolToPolarity(sess.DownlinkTXParams.IPol),
+
+		BatteryThrottleLevel:    uint32(sess.BatteryThrottleLevel),
+		BatteryLevel:            uint32(sess.BatteryLevel),
+		ClassCFPort:             uint32(sess.ClassCFPort),
+		ClassCWindow:            uint32(sess.ClassCWindow / time.Second),
+		TransmitDiversity:       sess.TransmitDiversity,
+		DailyDownlinkAirtimeCap: uint32(sess.DailyDownlinkAirtimeCap / time.Millisecond),
+		Tags:                    sess.Tags,
+		MacVersion:              sess.MACVersion,
+		Geolocation:             sess.Geolocation,
+		ChannelConfigurationID:  sess.ChannelConfigurationID,
+		ForwardPHYPayload:       sess.ForwardPHYPayload,
+		MaxUplinksPerHour:       sess.MaxUplinksPerHour,
+		LegacyADRACKReq:         sess.LegacyADRACKReq,
+		DeviceProfileID:         sess.DeviceProfileID,
+		Version:                 sess.Version,
 	}
 
 	if sess.CFList != nil {
 		resp.CFList = sess.CFList[:]
 	}
 
+	if sess.UplinkChannels != nil {
+		for _, c := range sess.GetUplinkChannels() {
+			resp.UplinkChannels = append(resp.UplinkChannels, &ns.UplinkChannel{
+				Index:     uint32(c.Index),
+				Frequency: uint32(c.Frequency),
+				MinDR:     uint32(c.MinDR),
+				MaxDR:     uint32(c.MaxDR),
+				Enabled:   c.Enabled,
+			})
+		}
+	}
+
+	for _, i := range sess.ChannelMask {
+		resp.ChannelMask = append(resp.ChannelMask, uint32(i))
+	}
+
+	if !sess.BatteryLevelUpdatedAt.IsZero() {
+		resp.BatteryLevelUpdatedAt = sess.BatteryLevelUpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	if !sess.ClassCUntil.IsZero() {
+		resp.ClassCUntil = sess.ClassCUntil.Format(time.RFC3339Nano)
+	}
+
 	return resp, nil
 }
 
@@ -128,7 +403,7 @@ func (n *NetworkServerAPI) UpdateNodeSession(ctx context.Context, req *ns.Update
 
 	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	if sess.AppEUI != appEUI {
@@ -142,14 +417,69 @@ func (n *NetworkServerAPI) UpdateNodeSession(ctx context.Context, req *ns.Update
 		RX1DROffset:        uint8(req.Rx1DROffset),
 		RXWindow:           session.RXWindow(req.RxWindow),
 		RX2DR:              uint8(req.Rx2DR),
+		RX2Frequency:       int(req.Rx2Frequency),
 		RelaxFCnt:          req.RelaxFCnt,
 		ADRInterval:        req.AdrInterval,
 		InstallationMargin: req.InstallationMargin,
+		ADRStrategy:        session.ADRStrategy(req.AdrStrategy),
+		Relay:              req.Relay,
+		GatewayRegions:     req.GatewayRegions,
+		DownlinkTXParams: models.TXParams{
+			Power:    int(req.DownlinkTXPower),
+			CodeRate: req.DownlinkCodeRate,
+			IPol:     polarityToIPol(req.DownlinkPolarity),
+		},
+		BatteryThrottleLevel:    uint8(req.BatteryThrottleLevel),
+		ClassCFPort:             uint8(req.ClassCFPort),
+		ClassCWindow:            time.Duration(req.ClassCWindow) * time.Second,
+		TransmitDiversity:       req.TransmitDiversity,
+		DailyDownlinkAirtimeCap: time.Duration(req.DailyDownlinkAirtimeCap) * time.Millisecond,
+		Tags:                    req.Tags,
+		MACVersion:              req.MacVersion,
+		Geolocation:             req.Geolocation,
+		ChannelConfigurationID:  req.ChannelConfigurationID,
+		ForwardPHYPayload:       req.ForwardPHYPayload,
+		MaxUplinksPerHour:       req.MaxUplinksPerHour,
+		LegacyADRACKReq:         req.LegacyADRACKReq,
+		DeviceProfileID:         req.DeviceProfileID,
 
 		// these values can't be overwritten
-		NbTrans:       sess.NbTrans,
-		TXPower:       sess.TXPower,
-		UplinkHistory: sess.UplinkHistory,
+		NbTrans:               sess.NbTrans,
+		TXPower:               sess.TXPower,
+		UplinkHistory:         sess.UplinkHistory,
+		DeviceClass:           sess.DeviceClass,
+		PendingDeviceClass:    sess.PendingDeviceClass,
+		DeviceClassChangedAt:  sess.DeviceClassChangedAt,
+		DownlinkReference:     sess.DownlinkReference,
+		BatteryLevel:          sess.BatteryLevel,
+		BatteryLevelUpdatedAt: sess.BatteryLevelUpdatedAt,
+		ClassCUntil:           sess.ClassCUntil,
+		UplinkChannels:        sess.UplinkChannels,
+		ChannelMask:           sess.ChannelMask,
+	}
+
+	if err := validateRXWindow(newSess); err != nil {
+		return nil, err
+	}
+
+	if err := n.validateChannelConfiguration(newSess.ChannelConfigurationID); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if err := n.validateDeviceProfile(newSess.DeviceProfileID); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if err := newSess.DownlinkTXParams.Validate(); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if err := newSess.Tags.Validate(); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if err := session.ValidateMACVersion(newSess.MACVersion); err != nil {
+		return nil, errToRPCError(ctx, err)
 	}
 
 	if len(req.CFList) > 0 {
@@ -170,20 +500,171 @@ func (n *NetworkServerAPI) UpdateNodeSession(ctx context.Context, req *ns.Update
 	copy(newSess.DevEUI[:], req.DevEUI)
 	copy(newSess.NwkSKey[:], req.NwkSKey)
 
-	if err := session.SaveNodeSession(n.ctx.RedisPool, newSess); err != nil {
-		return nil, errToRPCError(err)
+	if err := session.ValidateDevAddr(newSess.DevAddr, n.ctx.NetID); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	appSKey, err := session.UnwrapAppSKey(req.WrappedAppSKey)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	newSess.AppSKey = appSKey
+
+	// reject the update when it is based on an outdated node-session or
+	// when the node-session is updated between reading and saving it
+	if req.Version != 0 && req.Version != sess.Version {
+		return nil, errToRPCError(ctx, session.ErrConcurrentUpdate)
+	}
+	newSess.Version = sess.Version
+	if err := session.UpdateNodeSession(n.ctx.RedisPool, &newSess); err != nil {
+		return nil, errToRPCError(ctx, err)
 	}
 
 	return &ns.UpdateNodeSessionResponse{}, nil
 }
 
+// PatchNodeSession updates the fields of the given update mask of an
+// existing node-session.
+func (n *NetworkServerAPI) PatchNodeSession(ctx context.Context, req *ns.PatchNodeSessionRequest) (*ns.PatchNodeSessionResponse, error) {
+	var devEUI, appEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+	copy(appEUI[:], req.AppEUI)
+
+	if len(req.UpdateMask) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "updateMask must not be empty")
+	}
+
+	_, err := session.PatchNodeSession(n.ctx.RedisPool, devEUI, func(sess *session.NodeSession) error {
+		if sess.AppEUI != appEUI {
+			return grpc.Errorf(codes.InvalidArgument, "node-session belongs to a different AppEUI")
+		}
+
+		for _, field := range req.UpdateMask {
+			switch field {
+			case "fCntUp":
+				sess.FCntUp = req.FCntUp
+			case "fCntDown":
+				sess.FCntDown = req.FCntDown
+			case "rxDelay":
+				sess.RXDelay = uint8(req.RxDelay)
+			case "rx1DROffset":
+				sess.RX1DROffset = uint8(req.Rx1DROffset)
+			case "rxWindow":
+				sess.RXWindow = session.RXWindow(req.RxWindow)
+			case "rx2DR":
+				sess.RX2DR = uint8(req.Rx2DR)
+			case "rx2Frequency":
+				sess.RX2Frequency = int(req.Rx2Frequency)
+			case "relaxFCnt":
+				sess.RelaxFCnt = req.RelaxFCnt
+			case "adrInterval":
+				sess.ADRInterval = req.AdrInterval
+			case "installationMargin":
+				sess.InstallationMargin = req.InstallationMargin
+			case "adrStrategy":
+				sess.ADRStrategy = session.ADRStrategy(req.AdrStrategy)
+			case "relay":
+				sess.Relay = req.Relay
+			case "gatewayRegions":
+				sess.GatewayRegions = req.GatewayRegions
+			case "downlinkTXPower":
+				sess.DownlinkTXParams.Power = int(req.DownlinkTXPower)
+			case "downlinkCodeRate":
+				sess.DownlinkTXParams.CodeRate = req.DownlinkCodeRate
+			case "downlinkPolarity":
+				sess.DownlinkTXParams.IPol = polarityToIPol(req.DownlinkPolarity)
+			case "batteryThrottleLevel":
+				sess.BatteryThrottleLevel = uint8(req.BatteryThrottleLevel)
+			case "classCFPort":
+				sess.ClassCFPort = uint8(req.ClassCFPort)
+			case "classCWindow":
+				sess.ClassCWindow = time.Duration(req.ClassCWindow) * time.Second
+			case "transmitDiversity":
+				sess.TransmitDiversity = req.TransmitDiversity
+			case "dailyDownlinkAirtimeCap":
+				sess.DailyDownlinkAirtimeCap = time.Duration(req.DailyDownlinkAirtimeCap) * time.Millisecond
+			case "tags":
+				sess.Tags = req.Tags
+			case "macVersion":
+				sess.MACVersion = req.MacVersion
+			case "geolocation":
+				sess.Geolocation = req.Geolocation
+			case "channelConfigurationID":
+				if err := n.validateChannelConfiguration(req.ChannelConfigurationID); err != nil {
+					return err
+				}
+				sess.ChannelConfigurationID = req.ChannelConfigurationID
+			case "forwardPHYPayload":
+				sess.ForwardPHYPayload = req.ForwardPHYPayload
+			case "maxUplinksPerHour":
+				sess.MaxUplinksPerHour = req.MaxUplinksPerHour
+			case "legacyADRACKReq":
+				sess.LegacyADRACKReq = req.LegacyADRACKReq
+			case "deviceProfileID":
+				if err := n.validateDeviceProfile(req.DeviceProfileID); err != nil {
+					return err
+				}
+				sess.DeviceProfileID = req.DeviceProfileID
+			default:
+				return grpc.Errorf(codes.InvalidArgument, "invalid updateMask field: %s", field)
+			}
+		}
+
+		if err := validateRXWindow(*sess); err != nil {
+			return err
+		}
+		if err := sess.Tags.Validate(); err != nil {
+			return err
+		}
+		if err := session.ValidateMACVersion(sess.MACVersion); err != nil {
+			return err
+		}
+		return sess.DownlinkTXParams.Validate()
+	})
+	if err != nil {
+		// errors returned by the patch function are already rpc errors
+		if grpc.Code(err) != codes.Unknown {
+			return nil, err
+		}
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.PatchNodeSessionResponse{}, nil
+}
+
+// SetDeviceChannelMask restricts the enabled uplink channels of the given
+// node to the given channels.
+func (n *NetworkServerAPI) SetDeviceChannelMask(ctx context.Context, req *ns.SetDeviceChannelMaskRequest) (*ns.SetDeviceChannelMaskResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	var channels []int
+	for _, i := range req.Channels {
+		channels = append(channels, int(i))
+	}
+
+	_, err := session.PatchNodeSession(n.ctx.RedisPool, devEUI, func(sess *session.NodeSession) error {
+		return sess.SetChannelMask(channels)
+	})
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  devEUI,
+		"channels": channels,
+	}).Info("channel mask of node updated")
+
+	return &ns.SetDeviceChannelMaskResponse{}, nil
+}
+
 // DeleteNodeSession deletes a node-session.
 func (n *NetworkServerAPI) DeleteNodeSession(ctx context.Context, req *ns.DeleteNodeSessionRequest) (*ns.DeleteNodeSessionResponse, error) {
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEUI)
 
 	if err := session.DeleteNodeSession(n.ctx.RedisPool, devEUI); err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	return &ns.DeleteNodeSessionResponse{}, nil
@@ -193,7 +674,7 @@ func (n *NetworkServerAPI) DeleteNodeSession(ctx context.Context, req *ns.Delete
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *ns.GetRandomDevAddrRequest) (*ns.GetRandomDevAddrResponse, error) {
 	devAddr, err := session.GetRandomDevAddr(n.ctx.RedisPool, n.ctx.NetID)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	return &ns.GetRandomDevAddrResponse{
@@ -208,36 +689,334 @@ func (n *NetworkServerAPI) EnqueueDataDownMACCommand(ctx context.Context, req *n
 		Data:       req.Data,
 	}
 	copy(macPL.DevEUI[:], req.DevEUI)
+	if len(req.Data) > 0 && maccommand.IsHandledByNetworkServer(lorawan.CID(req.Data[0])) {
+		return nil, errToRPCError(ctx, maccommand.ErrHandledByNetworkServer)
+	}
+	var sess session.NodeSession
+	if len(req.Data) > 0 {
+		var err error
+		sess, err = session.GetNodeSession(n.ctx.RedisPool, macPL.DevEUI)
+		if err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		if !sess.GetMACCapabilities().SupportsMACCommand(lorawan.CID(req.Data[0])) {
+			return nil, errToRPCError(ctx, session.ErrMACCommandNotSupported)
+		}
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339Nano, req.ExpiresAt)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse expiresAt error: %s", err)
+		}
+		macPL.ExpiresAt = &expiresAt
+	}
+	if req.NotBefore != "" {
+		notBefore, err := time.Parse(time.RFC3339Nano, req.NotBefore)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse notBefore error: %s", err)
+		}
+		if macPL.ExpiresAt != nil && !notBefore.Before(*macPL.ExpiresAt) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "notBefore must be before expiresAt")
+		}
+		macPL.NotBefore = &notBefore
+	}
+	// the node-session is only updated to the requested rx parameters once
+	// the node acknowledged these
+	if err := maccommand.SetPendingSessionChanges(n.ctx.RedisPool, sess, macPL); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
 	if err := maccommand.AddToQueue(n.ctx.RedisPool, macPL); err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 	return &ns.EnqueueDataDownMACCommandResponse{}, nil
 }
 
 // PushDataDown pushes the given downlink payload to the node (only works for Class-C nodes).
 func (n *NetworkServerAPI) PushDataDown(ctx context.Context, req *ns.PushDataDownRequest) (*ns.PushDataDownResponse, error) {
+	if common.ReadOnlyMode {
+		return nil, errToRPCError(ctx, downlink.ErrReadOnlyMode)
+	}
+
 	var devEUI lorawan.EUI64
 	copy(devEUI[:], req.DevEUI)
 
 	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
+	}
+
+	// the reference is validated before the FCnt, as the FCntDown has
+	// already been incremented in case of a retry of an unconfirmed push
+	if req.Reference != "" {
+		claimed, err := downlink.ClaimPushReference(n.ctx.RedisPool, devEUI, req.Reference)
+		if err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		if !claimed {
+			log.WithFields(log.Fields{
+				"dev_eui":   devEUI,
+				"reference": req.Reference,
+			}).Warning("push data down with already used reference, ignoring")
+			return &ns.PushDataDownResponse{Duplicate: true}, nil
+		}
+	}
+
+	if err := n.pushDataDown(ctx, sess, req); err != nil {
+		// release the reference so that the push can be retried
+		if req.Reference != "" {
+			if err := downlink.ReleasePushReference(n.ctx.RedisPool, devEUI, req.Reference); err != nil {
+				log.WithField("dev_eui", devEUI).Errorf("release push reference error: %s", err)
+			}
+		}
+		return nil, err
 	}
 
+	return &ns.PushDataDownResponse{}, nil
+}
+
+// pushDataDown validates the given push request and hands it to the
+// downlink handling. The returned error is a gRPC error.
+func (n *NetworkServerAPI) pushDataDown(ctx context.Context, sess session.NodeSession, req *ns.PushDataDownRequest) error {
 	if req.FCnt != sess.FCntDown {
-		return nil, grpc.Errorf(codes.InvalidArgument, "invalid FCnt (expected: %d)", sess.FCntDown)
+		return grpc.Errorf(codes.InvalidArgument, "invalid FCnt (expected: %d)", sess.FCntDown)
+	}
+
+	txParams := models.TXParams{
+		Power:    int(req.TxPower),
+		CodeRate: req.CodeRate,
+		IPol:     polarityToIPol(req.Polarity),
+	}
+	if err := txParams.Validate(); err != nil {
+		return errToRPCError(ctx, err)
 	}
 
-	err = downlink.HandlePushDataDown(n.ctx, sess, req.Confirmed, uint8(req.FPort), req.Data)
+	if err := deviceprofile.CheckPush(n.ctx.DB, n.ctx.RedisPool, sess); err != nil {
+		return errToRPCError(ctx, err)
+	}
+
+	err := downlink.HandlePushDataDown(n.ctx.WithContext(ctx), sess, req.Confirmed, req.Critical, uint8(req.FPort), req.Data, txParams, req.Reference)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return errToRPCError(ctx, err)
 	}
 
-	return &ns.PushDataDownResponse{}, nil
+	return nil
+}
+
+// BroadcastDataDown broadcasts the given payload to the Class-C nodes
+// matching the filter.
+func (n *NetworkServerAPI) BroadcastDataDown(ctx context.Context, req *ns.BroadcastDataDownRequest) (*ns.BroadcastDataDownResponse, error) {
+	if common.ReadOnlyMode {
+		return nil, errToRPCError(ctx, downlink.ErrReadOnlyMode)
+	}
+
+	var filter downlink.BroadcastFilter
+	if len(req.AppEUI) != 0 {
+		var appEUI lorawan.EUI64
+		copy(appEUI[:], req.AppEUI)
+		filter.AppEUI = &appEUI
+	}
+
+	dispersalPeriod := common.BroadcastDispersalPeriod
+	if req.DispersalPeriod > 0 {
+		dispersalPeriod = time.Duration(req.DispersalPeriod) * time.Second
+	}
+
+	result, err := downlink.Broadcast(n.ctx.WithContext(ctx), filter, uint8(req.FPort), req.Data, dispersalPeriod)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.BroadcastDataDownResponse{
+		ScheduledCount: uint32(result.Scheduled),
+		SkippedCount:   uint32(result.Skipped),
+	}, nil
+}
+
+// GetDownlinkDecisions returns the last downlink decisions of the given
+// node.
+func (n *NetworkServerAPI) GetDownlinkDecisions(ctx context.Context, req *ns.GetDownlinkDecisionsRequest) (*ns.GetDownlinkDecisionsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	count, err := downlink.GetDecisionCount(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	decisions, err := downlink.GetDecisions(n.ctx.RedisPool, devEUI, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetDownlinkDecisionsResponse{
+		TotalCount: int32(count),
+	}
+	for _, d := range decisions {
+		// make sure we have a copy of the MAC byte slice
+		mac := make([]byte, 8)
+		copy(mac, d.MAC[:])
+
+		resp.Result = append(resp.Result, &ns.DownlinkDecision{
+			Time:               d.Time.Format(time.RFC3339Nano),
+			FCntUp:             d.FCntUp,
+			Decision:           d.Decision,
+			ApplicationPayload: d.ApplicationPayload,
+			AckRequired:        d.ACKRequired,
+			AdrACKReq:          d.ADRACKReq,
+			MacCommandCount:    uint32(d.MACCommandCount),
+			RxWindow:           ns.RXWindow(d.RXWindow),
+			DataRate:           uint32(d.DataRate),
+			Mac:                mac,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetMACCommandHistory returns the last mac-commands sent to and received
+// from the given node.
+func (n *NetworkServerAPI) GetMACCommandHistory(ctx context.Context, req *ns.GetMACCommandHistoryRequest) (*ns.GetMACCommandHistoryResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	count, err := maccommand.GetHistoryCount(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	items, err := maccommand.GetHistory(n.ctx.RedisPool, devEUI, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetMACCommandHistoryResponse{
+		TotalCount: int32(count),
+	}
+	for _, item := range items {
+		resp.Result = append(resp.Result, &ns.MACCommandHistoryItem{
+			Time:   item.Time.Format(time.RFC3339Nano),
+			Uplink: item.Uplink,
+			Cid:    uint32(item.CID),
+			Data:   item.Data,
+			FCnt:   item.FCnt,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetDownlinkFrames returns the downlink frame entries of the (re)transmissions
+// of the given downlink frame-counter.
+func (n *NetworkServerAPI) GetDownlinkFrames(ctx context.Context, req *ns.GetDownlinkFramesRequest) (*ns.GetDownlinkFramesResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	frames, err := framelog.GetDownlinkFrames(n.ctx.RedisPool, devEUI, req.FCnt)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetDownlinkFramesResponse
+	for _, f := range frames {
+		item := ns.DownlinkFrame{
+			Token:     uint32(f.Token),
+			Mac:       f.MAC[:],
+			Attempt:   uint32(f.Attempt),
+			SentAt:    f.SentAt.Format(time.RFC3339Nano),
+			Error:     f.Error,
+			ErrorCode: f.ErrorCode,
+			Diversity: f.Diversity,
+			Session: &ns.DownlinkFrameSession{
+				FCntUp:       f.Session.FCntUp,
+				FCntDown:     f.Session.FCntDown,
+				DataRate:     int32(f.Session.DR),
+				TxPower:      int32(f.Session.TXPower),
+				NbTrans:      uint32(f.Session.NbTrans),
+				RxWindow:     ns.RXWindow(f.Session.RXWindow),
+				RxDelay:      uint32(f.Session.RXDelay),
+				Rx1DROffset:  uint32(f.Session.RX1DROffset),
+				Rx2DR:        uint32(f.Session.RX2DR),
+				Rx2Frequency: uint32(f.Session.RX2Frequency),
+				CFList:       f.Session.CFList,
+			},
+		}
+		if !f.AckedAt.IsZero() {
+			item.AckedAt = f.AckedAt.Format(time.RFC3339Nano)
+		}
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// ChangeDeviceClass initiates a device class change of the node.
+func (n *NetworkServerAPI) ChangeDeviceClass(ctx context.Context, req *ns.ChangeDeviceClassRequest) (*ns.ChangeDeviceClassResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	class := session.DeviceClass(req.DeviceClass)
+	if class != session.DeviceClassA && class != session.DeviceClassC {
+		return nil, errToRPCError(ctx, session.ErrInvalidDeviceClass)
+	}
+
+	_, err := session.PatchNodeSession(n.ctx.RedisPool, devEUI, func(sess *session.NodeSession) error {
+		sess.PendingDeviceClass = class
+		sess.DeviceClassChangedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.ChangeDeviceClassResponse{}, nil
 }
 
 // CreateGateway creates the given gateway.
 func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGatewayRequest) (*ns.CreateGatewayResponse, error) {
+	gw := gatewayFromCreateRequest(req)
+	err := gateway.CreateGateway(n.ctx.DB, &gw)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateGatewayResponse{}, nil
+}
+
+// BulkCreateOrUpdateGateways creates the given gateways or updates them
+// when they already exist. The result is returned per gateway.
+func (n *NetworkServerAPI) BulkCreateOrUpdateGateways(ctx context.Context, req *ns.BulkCreateOrUpdateGatewaysRequest) (*ns.BulkCreateOrUpdateGatewaysResponse, error) {
+	var gws []gateway.Gateway
+	for _, gwReq := range req.Gateways {
+		gws = append(gws, gatewayFromCreateRequest(gwReq))
+	}
+
+	var resp ns.BulkCreateOrUpdateGatewaysResponse
+	for i, res := range gateway.CreateOrUpdateGateways(n.ctx.DB, gws) {
+		result := ns.BulkGatewayResult{
+			Index:   int32(i),
+			Mac:     req.Gateways[i].Mac,
+			Created: res.Created,
+		}
+
+		switch {
+		case res.Error != nil:
+			result.Created = false
+			result.ErrorCode = errToErrorCode(res.Error)
+			result.Error = res.Error.Error()
+		case res.Created:
+			resp.CreatedCount++
+		default:
+			resp.UpdatedCount++
+		}
+		resp.Results = append(resp.Results, &result)
+	}
+
+	return &resp, nil
+}
+
+// gatewayFromCreateRequest returns the gateway for the given create
+// request.
+func gatewayFromCreateRequest(req *ns.CreateGatewayRequest) gateway.Gateway {
 	var mac lorawan.EUI64
 	copy(mac[:], req.Mac)
 
@@ -255,19 +1034,23 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 		altitude = &req.Altitude
 	}
 
-	gw := gateway.Gateway{
-		MAC:         mac,
-		Name:        req.Name,
-		Description: req.Description,
-		Location:    location,
-		Altitude:    altitude,
-	}
-	err := gateway.CreateGateway(n.ctx.DB, &gw)
-	if err != nil {
-		return nil, errToRPCError(err)
+	return gateway.Gateway{
+		MAC:           mac,
+		Name:          req.Name,
+		Description:   req.Description,
+		Location:      location,
+		Altitude:      altitude,
+		Region:        req.Region,
+		TXPower:       int(req.TxPower),
+		CodeRate:      req.CodeRate,
+		IPol:          polarityToIPol(req.Polarity),
+		RSSIOffset:    int(req.RssiOffset),
+		LoRaSNROffset: req.LoRaSNROffset,
+		HasGPS:        req.HasGPS,
+		FineTimestamp: req.FineTimestamp,
+		ReceiveOnly:   req.ReceiveOnly,
+		Tags:          req.Tags,
 	}
-
-	return &ns.CreateGatewayResponse{}, nil
 }
 
 // GetGateway returns data for a particular gateway.
@@ -277,10 +1060,21 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 
 	gw, err := gateway.GetGateway(n.ctx.DB, mac)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := gwToResp(gw)
+	if err := n.setOutOfPlanRXPacketCount(resp, gw.MAC); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	if err := n.setReachabilityScore(resp, gw.MAC); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	if err := n.setGatewayEvents(resp, gw.MAC); err != nil {
+		return nil, errToRPCError(ctx, err)
 	}
 
-	return gwToResp(gw), nil
+	return resp, nil
 }
 
 // UpdateGateway updates an existing gateway.
@@ -290,7 +1084,7 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 
 	gw, err := gateway.GetGateway(n.ctx.DB, mac)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	var location *gateway.GPSPoint
@@ -311,10 +1105,20 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 	gw.Description = req.Description
 	gw.Location = location
 	gw.Altitude = altitude
+	gw.Region = req.Region
+	gw.TXPower = int(req.TxPower)
+	gw.CodeRate = req.CodeRate
+	gw.IPol = polarityToIPol(req.Polarity)
+	gw.RSSIOffset = int(req.RssiOffset)
+	gw.LoRaSNROffset = req.LoRaSNROffset
+	gw.HasGPS = req.HasGPS
+	gw.FineTimestamp = req.FineTimestamp
+	gw.ReceiveOnly = req.ReceiveOnly
+	gw.Tags = req.Tags
 
 	err = gateway.UpdateGateway(n.ctx.DB, &gw)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	return &ns.UpdateGatewayResponse{}, nil
@@ -322,14 +1126,21 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 
 // ListGateways returns the existing gateways.
 func (n *NetworkServerAPI) ListGateways(ctx context.Context, req *ns.ListGatewayRequest) (*ns.ListGatewayResponse, error) {
-	count, err := gateway.GetGatewayCount(n.ctx.DB)
+	filters := gateway.ListFilters{
+		Search:     req.Search,
+		Tags:       req.Tags,
+		OrderBy:    gatewayOrderByFields[req.OrderBy],
+		Descending: req.SortOrder == ns.SortOrder_DESC,
+	}
+
+	count, err := gateway.GetGatewayCount(n.ctx.DB, filters)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
-	gws, err := gateway.GetGateways(n.ctx.DB, int(req.Limit), int(req.Offset))
+	gws, err := gateway.GetGateways(n.ctx.DB, filters, int(req.Limit), int(req.Offset))
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
 	}
 
 	resp := ns.ListGatewayResponse{
@@ -337,20 +1148,95 @@ func (n *NetworkServerAPI) ListGateways(ctx context.Context, req *ns.ListGateway
 	}
 
 	for _, gw := range gws {
-		resp.Result = append(resp.Result, gwToResp(gw))
+		gwResp := gwToResp(gw)
+		if err := n.setOutOfPlanRXPacketCount(gwResp, gw.MAC); err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		if err := n.setReachabilityScore(gwResp, gw.MAC); err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		resp.Result = append(resp.Result, gwResp)
 	}
 
 	return &resp, nil
 }
 
-// DeleteGateway deletes a gateway.
-func (n *NetworkServerAPI) DeleteGateway(ctx context.Context, req *ns.DeleteGatewayRequest) (*ns.DeleteGatewayResponse, error) {
+// ListGatewayDevices returns the nodes recently received by the given
+// gateway.
+func (n *NetworkServerAPI) ListGatewayDevices(ctx context.Context, req *ns.ListGatewayDevicesRequest) (*ns.ListGatewayDevicesResponse, error) {
 	var mac lorawan.EUI64
 	copy(mac[:], req.Mac)
 
-	err := gateway.DeleteGateway(n.ctx.DB, mac)
-	if err != nil {
-		return nil, errToRPCError(err)
+	if _, err := gateway.GetGateway(n.ctx.DB, mac); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	count, err := gateway.GetDeviceCount(n.ctx.RedisPool, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	devices, err := gateway.GetDevices(n.ctx.RedisPool, mac, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListGatewayDevicesResponse{
+		TotalCount: int32(count),
+	}
+
+	for _, d := range devices {
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, d.DevEUI[:])
+
+		resp.Result = append(resp.Result, &ns.GatewayDevice{
+			DevEUI:        devEUI,
+			RxPacketCount: uint32(d.RXPacketCount),
+			LastSeenAt:    d.LastSeenAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetGatewayAntennaStats returns the uplink stats per antenna of the given
+// gateway.
+func (n *NetworkServerAPI) GetGatewayAntennaStats(ctx context.Context, req *ns.GetGatewayAntennaStatsRequest) (*ns.GetGatewayAntennaStatsResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	if _, err := gateway.GetGateway(n.ctx.DB, mac); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	stats, err := gateway.GetAntennaStats(n.ctx.RedisPool, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetGatewayAntennaStatsResponse
+	for _, s := range stats {
+		resp.Result = append(resp.Result, &ns.GatewayAntennaStats{
+			Antenna:       uint32(s.Antenna),
+			RxPacketCount: uint32(s.RXPacketCount),
+			SelectedCount: uint32(s.SelectedCount),
+			AvgRSSI:       s.AvgRSSI,
+			AvgLoRaSNR:    s.AvgLoRaSNR,
+		})
+	}
+
+	return &resp, nil
+}
+
+// DeleteGateway deletes a gateway.
+func (n *NetworkServerAPI) DeleteGateway(ctx context.Context, req *ns.DeleteGatewayRequest) (*ns.DeleteGatewayResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	err := gateway.DeleteGateway(n.ctx.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
 	}
 
 	return &ns.DeleteGatewayResponse{}, nil
@@ -373,12 +1259,21 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 
 	stats, err := gateway.GetGatewayStats(n.ctx.DB, mac, req.Interval.String(), start, end)
 	if err != nil {
-		return nil, errToRPCError(err)
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if req.SortOrder == ns.SortOrder_DESC {
+		for i, j := 0, len(stats)-1; i < j; i, j = i+1, j-1 {
+			stats[i], stats[j] = stats[j], stats[i]
+		}
 	}
 
-	var resp ns.GetGatewayStatsResponse
+	resp := ns.GetGatewayStatsResponse{
+		TotalCount: int32(len(stats)),
+	}
 
-	for _, stat := range stats {
+	first, last := pageBounds(len(stats), int(req.Limit), int(req.Offset))
+	for _, stat := range stats[first:last] {
 		resp.Result = append(resp.Result, &ns.GatewayStats{
 			Timestamp:           stat.Timestamp.Format(time.RFC3339Nano),
 			RxPacketsReceived:   int32(stat.RXPacketsReceived),
@@ -391,31 +1286,1955 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 	return &resp, nil
 }
 
-func gwToResp(gw gateway.Gateway) *ns.GetGatewayResponse {
-	resp := ns.GetGatewayResponse{
-		Mac:         gw.MAC[:],
-		Name:        gw.Name,
-		Description: gw.Description,
-		CreatedAt:   gw.CreatedAt.Format(time.RFC3339Nano),
-		UpdatedAt:   gw.UpdatedAt.Format(time.RFC3339Nano),
+// GetDownlinkCapacityReport returns the downlink capacity usage of an
+// existing gateway.
+func (n *NetworkServerAPI) GetDownlinkCapacityReport(ctx context.Context, req *ns.GetDownlinkCapacityReportRequest) (*ns.GetDownlinkCapacityReportResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	start, err := time.Parse(time.RFC3339Nano, req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse start timestamp: %s", err)
 	}
 
-	if gw.FirstSeenAt != nil {
-		resp.FirstSeenAt = gw.FirstSeenAt.Format(time.RFC3339Nano)
+	end, err := time.Parse(time.RFC3339Nano, req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse end timestamp: %s", err)
 	}
 
-	if gw.LastSeenAt != nil {
-		resp.LastSeenAt = gw.LastSeenAt.Format(time.RFC3339Nano)
+	if !end.After(start) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "end timestamp must be after start timestamp")
 	}
 
-	if gw.Location != nil {
-		resp.Latitude = gw.Location.Latitude
-		resp.Longitude = gw.Location.Longitude
+	report, err := airtime.GetCapacityReport(n.ctx.RedisPool, mac, start, end, int(req.BusiestDevicesLimit))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
 	}
 
-	if gw.Altitude != nil {
-		resp.Altitude = *gw.Altitude
+	var resp ns.GetDownlinkCapacityReportResponse
+
+	for _, sb := range report.SubBands {
+		resp.SubBands = append(resp.SubBands, &ns.SubBandCapacity{
+			SubBand:           sb.SubBand.Name,
+			DutyCycle:         sb.SubBand.DutyCycle,
+			Airtime:           uint32(sb.Airtime / time.Millisecond),
+			DutyCycleConsumed: sb.DutyCycleConsumed,
+			DownlinkCount:     uint32(sb.DownlinkCount),
+			RejectedCount:     uint32(sb.RejectedCount),
+		})
 	}
 
-	return &resp
+	for _, d := range report.BusiestDevices {
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, d.DevEUI[:])
+
+		resp.BusiestDevices = append(resp.BusiestDevices, &ns.DeviceAirtime{
+			DevEUI:  devEUI,
+			Airtime: uint32(d.Airtime / time.Millisecond),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetUplinkChannelStats returns the uplink channel utilization stats.
+func (n *NetworkServerAPI) GetUplinkChannelStats(ctx context.Context, req *ns.GetUplinkChannelStatsRequest) (*ns.GetUplinkChannelStatsResponse, error) {
+	start, err := time.Parse(time.RFC3339Nano, req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse start timestamp: %s", err)
+	}
+
+	end, err := time.Parse(time.RFC3339Nano, req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse end timestamp: %s", err)
+	}
+
+	if !end.After(start) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "end timestamp must be after start timestamp")
+	}
+
+	stats, err := channelstats.GetStats(n.ctx.RedisPool, start, end)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetUplinkChannelStatsResponse
+	for _, s := range stats {
+		resp.Result = append(resp.Result, &ns.UplinkChannelStats{
+			Timestamp:   s.Timestamp.Format(time.RFC3339Nano),
+			Frequency:   uint32(s.Frequency),
+			DataRate:    uint32(s.DataRate),
+			UplinkCount: uint32(s.UplinkCount),
+			BandChannel: s.BandChannel,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetUnknownDevAddrStats returns the passive monitoring stats of the
+// uplinks of unknown DevAddrs.
+func (n *NetworkServerAPI) GetUnknownDevAddrStats(ctx context.Context, req *ns.GetUnknownDevAddrStatsRequest) (*ns.GetUnknownDevAddrStatsResponse, error) {
+	start, err := time.Parse(time.RFC3339Nano, req.StartTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse start timestamp: %s", err)
+	}
+
+	end, err := time.Parse(time.RFC3339Nano, req.EndTimestamp)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "parse end timestamp: %s", err)
+	}
+
+	if !end.After(start) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "end timestamp must be after start timestamp")
+	}
+
+	stats, err := passive.GetStats(n.ctx.RedisPool, start, end)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetUnknownDevAddrStatsResponse
+	for _, s := range stats {
+		resp.Result = append(resp.Result, &ns.UnknownDevAddrStats{
+			Timestamp:   s.Timestamp.Format(time.RFC3339Nano),
+			Mac:         s.MAC[:],
+			Frequency:   uint32(s.Frequency),
+			DataRate:    uint32(s.DataRate),
+			NwkID:       uint32(s.NwkID),
+			Foreign:     s.NwkID != n.ctx.NetID.NwkID(),
+			UplinkCount: uint32(s.UplinkCount),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetJoinStats returns the join stats per gateway and per AppEUI.
+func (n *NetworkServerAPI) GetJoinStats(ctx context.Context, req *ns.GetJoinStatsRequest) (*ns.GetJoinStatsResponse, error) {
+	gwStats, err := joinstats.GetGatewayStats(n.ctx.RedisPool)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	appStats, err := joinstats.GetAppStats(n.ctx.RedisPool)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetJoinStatsResponse
+	for _, s := range gwStats {
+		resp.Gateways = append(resp.Gateways, &ns.GatewayJoinStats{
+			Mac:   s.MAC[:],
+			Stats: joinStatsToProto(s.Stats),
+		})
+	}
+	for _, s := range appStats {
+		resp.Apps = append(resp.Apps, &ns.AppJoinStats{
+			AppEUI: s.AppEUI[:],
+			Stats:  joinStatsToProto(s.Stats),
+		})
+	}
+
+	return &resp, nil
+}
+
+func joinStatsToProto(s joinstats.Stats) *ns.JoinStats {
+	return &ns.JoinStats{
+		RequestCount: uint32(s.RequestCount),
+		AcceptCount:  uint32(s.AcceptCount),
+		FailureCount: uint32(s.FailureCount),
+		AvgLatency:   uint32(s.AvgLatency() / time.Millisecond),
+	}
+}
+
+// StreamUplinkMetadata streams the meta-data of the received uplink frames.
+func (n *NetworkServerAPI) StreamUplinkMetadata(req *ns.StreamUplinkMetadataRequest, stream ns.NetworkServer_StreamUplinkMetadataServer) error {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+	filter := len(req.DevEUI) != 0
+
+	err := uplink.SubscribeUplinkMetadata(stream.Context(), n.ctx.RedisPool, func(md *ns.StreamUplinkMetadataResponse) error {
+		if filter && !bytes.Equal(md.DevEUI, devEUI[:]) {
+			return nil
+		}
+		return stream.Send(md)
+	})
+	if err != nil {
+		return errToRPCError(stream.Context(), err)
+	}
+	return nil
+}
+
+// GetADRParameters returns the global ADR parameters.
+func (n *NetworkServerAPI) GetADRParameters(ctx context.Context, req *ns.GetADRParametersRequest) (*ns.GetADRParametersResponse, error) {
+	params := adr.GetParameters()
+
+	resp := ns.GetADRParametersResponse{
+		InstallationMargin: params.InstallationMargin,
+		MaxDR:              uint32(params.MaxDR),
+		RespondToADRACKReq: params.RespondToADRACKReq,
+
+		NoisyChannelThreshold: params.NoisyChannelThreshold,
+		MaxFrequencyOffset:    uint32(params.MaxFrequencyOffset),
+	}
+	if params.UpdatedAt != nil {
+		resp.UpdatedAt = params.UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// UpdateADRParameters updates the global ADR parameters.
+func (n *NetworkServerAPI) UpdateADRParameters(ctx context.Context, req *ns.UpdateADRParametersRequest) (*ns.UpdateADRParametersResponse, error) {
+	err := adr.SaveParameters(n.ctx.DB, adr.Parameters{
+		InstallationMargin: req.InstallationMargin,
+		MaxDR:              int(req.MaxDR),
+		RespondToADRACKReq: req.RespondToADRACKReq,
+
+		NoisyChannelThreshold: req.NoisyChannelThreshold,
+		MaxFrequencyOffset:    int(req.MaxFrequencyOffset),
+	})
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateADRParametersResponse{}, nil
+}
+
+// GetADRDecisions returns the last ADR decisions of the given node.
+func (n *NetworkServerAPI) GetADRDecisions(ctx context.Context, req *ns.GetADRDecisionsRequest) (*ns.GetADRDecisionsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	count, err := adr.GetDecisionCount(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	decisions, err := adr.GetDecisions(n.ctx.RedisPool, devEUI, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetADRDecisionsResponse{
+		TotalCount: int32(count),
+	}
+	for _, d := range decisions {
+		decision := ns.ADRDecision{
+			Time:                 d.Time.Format(time.RFC3339Nano),
+			FCntUp:               d.FCntUp,
+			Decision:             d.Decision,
+			Strategy:             ns.ADRStrategy(d.Strategy),
+			MaxSNR:               d.MaxSNR,
+			RequiredSNR:          d.RequiredSNR,
+			InstallationMargin:   d.InstallationMargin,
+			SnrMargin:            d.SNRMargin,
+			NStep:                int32(d.NStep),
+			PacketLossPercentage: d.PacketLossPercentage,
+			MaxDR:                uint32(d.MaxDR),
+			DataRate:             uint32(d.DataRate),
+			TxPower:              int32(d.TXPower),
+			NbTrans:              uint32(d.NbTrans),
+			ReqDataRate:          uint32(d.ReqDataRate),
+			ReqTXPower:           int32(d.ReqTXPower),
+			ReqNbTrans:           uint32(d.ReqNbTrans),
+			LinkADRReq:           d.LinkADRReq,
+			ChannelNoise:         d.ChannelNoise,
+			MaxFrequencyOffset:   uint32(d.MaxFrequencyOffset),
+		}
+		for _, uh := range d.UplinkHistory {
+			decision.UplinkHistory = append(decision.UplinkHistory, &ns.ADRUplinkHistoryItem{
+				FCnt:            uh.FCnt,
+				MaxSNR:          uh.MaxSNR,
+				MaxRSSI:         int32(uh.MaxRSSI),
+				GatewayCount:    uint32(uh.GatewayCount),
+				FrequencyOffset: int32(uh.FrequencyOffset),
+			})
+		}
+		for _, f := range d.NoisyChannels {
+			decision.NoisyChannels = append(decision.NoisyChannels, uint32(f))
+		}
+		resp.Result = append(resp.Result, &decision)
+	}
+
+	return &resp, nil
+}
+
+// AuditRedisKeys reports the de-duplication / collection keys and
+// mac-command queues stored in Redis.
+func (n *NetworkServerAPI) AuditRedisKeys(ctx context.Context, req *ns.AuditRedisKeysRequest) (*ns.AuditRedisKeysResponse, error) {
+	results, err := check.AuditKeys(n.ctx.RedisPool, req.Cleanup)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.AuditRedisKeysResponse
+	for _, res := range results {
+		resp.Result = append(resp.Result, &ns.RedisKeyGroup{
+			Name:            res.Name,
+			Pattern:         res.Pattern,
+			KeyCount:        uint32(res.Keys),
+			MemoryBytes:     res.MemoryBytes,
+			WithoutTTLCount: uint32(res.WithoutTTL),
+			RemovedCount:    uint32(res.Removed),
+		})
+	}
+
+	return &resp, nil
+}
+
+// CheckSessions checks the node-session storage for consistency issues and
+// optionally repairs these.
+func (n *NetworkServerAPI) CheckSessions(ctx context.Context, req *ns.CheckSessionsRequest) (*ns.CheckSessionsResponse, error) {
+	if req.Repair && common.ReadOnlyMode {
+		return nil, errToRPCError(ctx, downlink.ErrReadOnlyMode)
+	}
+
+	res, err := check.CheckSessions(n.ctx.RedisPool, req.Repair)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CheckSessionsResponse{
+		NodeSessionCount:        uint32(res.NodeSessions),
+		DevAddrCount:            uint32(res.DevAddrs),
+		MacQueueCount:           uint32(res.MACQueues),
+		OrphanedDevAddrPointers: sessionIssuesToProto(res.OrphanedDevAddrPointers),
+		MissingDevAddrPointers:  sessionIssuesToProto(res.MissingDevAddrPointers),
+		InvalidNodeSessions:     sessionIssuesToProto(res.InvalidNodeSessions),
+		MismatchedMACQueues:     sessionIssuesToProto(res.MismatchedMACQueues),
+	}, nil
+}
+
+func sessionIssuesToProto(issues []check.Issue) []*ns.SessionIssue {
+	var out []*ns.SessionIssue
+	for _, issue := range issues {
+		out = append(out, &ns.SessionIssue{
+			Key:         issue.Key,
+			Description: issue.Description,
+			Repaired:    issue.Repaired,
+		})
+	}
+	return out
+}
+
+// GetInfo returns the version, band, NetID, enabled features and limits of
+// LoRa Server.
+func (n *NetworkServerAPI) GetInfo(ctx context.Context, req *ns.GetInfoRequest) (*ns.GetInfoResponse, error) {
+	resp := ns.GetInfoResponse{
+		Version:        common.Version,
+		Band:           string(common.BandName),
+		NetID:          n.ctx.NetID[:],
+		ClassB:         false,
+		ClassC:         true,
+		Adr:            true,
+		AppSKeyOffload: common.AppSKeyKEK != nil,
+		Rx2Frequency:   uint32(common.Band.RX2Frequency),
+		Rx2DR:          uint32(common.Band.RX2DataRate),
+		MaxFCntGap:     common.Band.MaxFCntGap,
+		ReadOnly:       common.ReadOnlyMode,
+	}
+
+	if len(common.Band.DataRates) > 0 {
+		resp.MaxDR = uint32(len(common.Band.DataRates) - 1)
+	}
+	for _, pl := range common.Band.MaxPayloadSize {
+		resp.MaxPayloadSize = append(resp.MaxPayloadSize, uint32(pl.N))
+	}
+
+	return &resp, nil
+}
+
+// ReleaseQuarantine releases the given node from quarantine.
+func (n *NetworkServerAPI) ReleaseQuarantine(ctx context.Context, req *ns.ReleaseQuarantineRequest) (*ns.ReleaseQuarantineResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	if err := security.ReleaseQuarantine(n.ctx.RedisPool, devEUI); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	return &ns.ReleaseQuarantineResponse{}, nil
+}
+
+// GetDeviceDownlinkAirtime returns the downlink airtime consumed by a node
+// per day and its remaining daily budget.
+func (n *NetworkServerAPI) GetDeviceDownlinkAirtime(ctx context.Context, req *ns.GetDeviceDownlinkAirtimeRequest) (*ns.GetDeviceDownlinkAirtimeResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	days, err := airtime.GetDeviceDailyAirtime(n.ctx.RedisPool, devEUI, int(req.Days))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetDeviceDownlinkAirtimeResponse{
+		DailyDownlinkAirtimeCap: uint32(sess.DailyDownlinkAirtimeCap / time.Millisecond),
+	}
+
+	for _, d := range days {
+		resp.Result = append(resp.Result, &ns.DailyAirtime{
+			Date:    d.Date,
+			Airtime: uint32(d.Airtime / time.Millisecond),
+		})
+	}
+
+	// the first day is today
+	if sess.DailyDownlinkAirtimeCap > 0 {
+		if used := days[0].Airtime; used < sess.DailyDownlinkAirtimeCap {
+			resp.RemainingAirtime = uint32((sess.DailyDownlinkAirtimeCap - used) / time.Millisecond)
+		} else {
+			resp.CapReached = true
+		}
+	}
+
+	return &resp, nil
+}
+
+// CreateUplinkRule creates the given uplink automation rule.
+func (n *NetworkServerAPI) CreateUplinkRule(ctx context.Context, req *ns.CreateUplinkRuleRequest) (*ns.CreateUplinkRuleResponse, error) {
+	if req.Rule == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "rule must not be nil")
+	}
+
+	r := uplinkRuleFromReq(req.Rule)
+	if err := rules.CreateRule(n.ctx.DB, &r); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateUplinkRuleResponse{Id: r.ID}, nil
+}
+
+// GetUplinkRule returns the uplink automation rule for the given id.
+func (n *NetworkServerAPI) GetUplinkRule(ctx context.Context, req *ns.GetUplinkRuleRequest) (*ns.GetUplinkRuleResponse, error) {
+	r, err := rules.GetRule(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetUplinkRuleResponse{Rule: uplinkRuleToResp(r)}, nil
+}
+
+// UpdateUplinkRule updates the given uplink automation rule.
+func (n *NetworkServerAPI) UpdateUplinkRule(ctx context.Context, req *ns.UpdateUplinkRuleRequest) (*ns.UpdateUplinkRuleResponse, error) {
+	if req.Rule == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "rule must not be nil")
+	}
+
+	r := uplinkRuleFromReq(req.Rule)
+	if err := rules.UpdateRule(n.ctx.DB, &r); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateUplinkRuleResponse{}, nil
+}
+
+// DeleteUplinkRule deletes the uplink automation rule for the given id.
+func (n *NetworkServerAPI) DeleteUplinkRule(ctx context.Context, req *ns.DeleteUplinkRuleRequest) (*ns.DeleteUplinkRuleResponse, error) {
+	if err := rules.DeleteRule(n.ctx.DB, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteUplinkRuleResponse{}, nil
+}
+
+// ListUplinkRules returns the uplink automation rules.
+func (n *NetworkServerAPI) ListUplinkRules(ctx context.Context, req *ns.ListUplinkRulesRequest) (*ns.ListUplinkRulesResponse, error) {
+	count, err := rules.GetRuleCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	items, err := rules.GetRules(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListUplinkRulesResponse{
+		TotalCount: int32(count),
+	}
+	for _, r := range items {
+		resp.Result = append(resp.Result, uplinkRuleToResp(r))
+	}
+
+	return &resp, nil
+}
+
+// validateRXWindow validates the RX window settings of the given
+// node-session, so that misconfigurations (e.g. of nodes operating in
+// RX2-only mode) are rejected on provisioning instead of on the first
+// downlink.
+func validateRXWindow(sess session.NodeSession) error {
+	switch sess.RXWindow {
+	case session.RX1, session.RX2:
+	default:
+		return grpc.Errorf(codes.InvalidArgument, "unknown rxWindow option %d", sess.RXWindow)
+	}
+
+	if maxDR := len(common.Band.DataRates) - 1; int(sess.RX2DR) > maxDR {
+		return grpc.Errorf(codes.InvalidArgument, "invalid rx2DR: %d (max dr: %d)", sess.RX2DR, maxDR)
+	}
+
+	if err := session.ValidateRX2Frequency(sess.RX2Frequency); err != nil {
+		return grpc.Errorf(codes.InvalidArgument, "%s", err)
+	}
+	return nil
+}
+
+// polarityToIPol returns the polarity inversion for the given polarity
+// (nil when the polarity is not set).
+func polarityToIPol(p ns.Polarity) *bool {
+	if p == ns.Polarity_INHERIT_POLARITY {
+		return nil
+	}
+	iPol := p == ns.Polarity_INVERTED_POLARITY
+	return &iPol
+}
+
+// iPolToPolarity returns the polarity for the given polarity inversion.
+func iPolToPolarity(iPol *bool) ns.Polarity {
+	switch {
+	case iPol == nil:
+		return ns.Polarity_INHERIT_POLARITY
+	case *iPol:
+		return ns.Polarity_INVERTED_POLARITY
+	default:
+		return ns.Polarity_NORMAL_POLARITY
+	}
+}
+
+// setOutOfPlanRXPacketCount sets the out-of-plan rx packet counter (stored
+// in Redis) of the given gateway.
+func (n *NetworkServerAPI) setOutOfPlanRXPacketCount(resp *ns.GetGatewayResponse, mac lorawan.EUI64) error {
+	count, err := gateway.GetOutOfPlanRXPacketCount(n.ctx.RedisPool, mac)
+	if err != nil {
+		return err
+	}
+	resp.OutOfPlanRXPacketCount = uint32(count)
+	return nil
+}
+
+// setReachabilityScore sets the reachability score (based on the stats
+// timestamps stored in Redis) of the given gateway.
+func (n *NetworkServerAPI) setReachabilityScore(resp *ns.GetGatewayResponse, mac lorawan.EUI64) error {
+	score, err := gateway.GetReachabilityScore(n.ctx.RedisPool, mac, time.Now())
+	if err != nil {
+		return err
+	}
+	resp.ReachabilityScore = score
+	return nil
+}
+
+func (n *NetworkServerAPI) setGatewayEvents(resp *ns.GetGatewayResponse, mac lorawan.EUI64) error {
+	events, err := gateway.GetEvents(n.ctx.RedisPool, mac)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &ns.GatewayEvent{
+			Time:        e.Time.Format(time.RFC3339Nano),
+			Type:        e.Type,
+			Description: e.Description,
+		})
+	}
+	return nil
+}
+
+func gwToResp(gw gateway.Gateway) *ns.GetGatewayResponse {
+	resp := ns.GetGatewayResponse{
+		Mac:           gw.MAC[:],
+		Name:          gw.Name,
+		Description:   gw.Description,
+		Region:        gw.Region,
+		TxPower:       uint32(gw.TXPower),
+		CodeRate:      gw.CodeRate,
+		Polarity:      iPolToPolarity(gw.IPol),
+		RssiOffset:    int32(gw.RSSIOffset),
+		LoRaSNROffset: gw.LoRaSNROffset,
+		HasGPS:        gw.HasGPS,
+		FineTimestamp: gw.FineTimestamp,
+		ReceiveOnly:   gw.ReceiveOnly,
+		Tags:          gw.Tags,
+		CreatedAt:     gw.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:     gw.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if gw.FirstSeenAt != nil {
+		resp.FirstSeenAt = gw.FirstSeenAt.Format(time.RFC3339Nano)
+	}
+
+	if gw.LastSeenAt != nil {
+		resp.LastSeenAt = gw.LastSeenAt.Format(time.RFC3339Nano)
+	}
+
+	if gw.Location != nil {
+		resp.Latitude = gw.Location.Latitude
+		resp.Longitude = gw.Location.Longitude
+	}
+
+	if gw.Altitude != nil {
+		resp.Altitude = *gw.Altitude
+	}
+
+	return &resp
+}
+
+// GetDeviceUplinkStats returns the uplink frames and bytes of a node per
+// FPort over the given number of days.
+func (n *NetworkServerAPI) GetDeviceUplinkStats(ctx context.Context, req *ns.GetDeviceUplinkStatsRequest) (*ns.GetDeviceUplinkStatsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	stats, err := uplinkstats.GetDeviceStats(n.ctx.RedisPool, devEUI, int(req.Days))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetDeviceUplinkStatsResponse
+	for _, s := range stats {
+		resp.Result = append(resp.Result, &ns.FPortUplinkStats{
+			FPort:  uint32(s.FPort),
+			Frames: uint32(s.Frames),
+			Bytes:  uint32(s.Bytes),
+		})
+		resp.Frames += uint32(s.Frames)
+		resp.Bytes += uint32(s.Bytes)
+	}
+
+	return &resp, nil
+}
+
+// GetTopTalkers returns the nodes with the most uplink frames or bytes over
+// the given number of days.
+func (n *NetworkServerAPI) GetTopTalkers(ctx context.Context, req *ns.GetTopTalkersRequest) (*ns.GetTopTalkersResponse, error) {
+	orderBy := uplinkstats.OrderByFrames
+	if req.OrderBy == ns.TopTalkersOrderBy_ORDER_BY_BYTES {
+		orderBy = uplinkstats.OrderByBytes
+	}
+
+	talkers, err := uplinkstats.GetTopTalkers(n.ctx.RedisPool, int(req.Days), int(req.Limit), orderBy)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetTopTalkersResponse
+	for _, t := range talkers {
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, t.DevEUI[:])
+
+		resp.Result = append(resp.Result, &ns.TopTalker{
+			DevEUI: devEUI,
+			Frames: uint32(t.Frames),
+			Bytes:  uint32(t.Bytes),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetLowLinkMarginNodes returns a batch of nodes of which the link margin
+// is consistently below the given threshold.
+func (n *NetworkServerAPI) GetLowLinkMarginNodes(ctx context.Context, req *ns.GetLowLinkMarginNodesRequest) (*ns.GetLowLinkMarginNodesResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = exportNodeSessionsDefaultLimit
+	}
+
+	filters := linkmargin.Filters{
+		Threshold:  req.Threshold,
+		MinFrames:  int(req.MinFrames),
+		Percentage: int(req.Percentage),
+	}
+	if len(req.Mac) != 0 {
+		var mac lorawan.EUI64
+		copy(mac[:], req.Mac)
+		filters.MAC = &mac
+	}
+	if len(req.AppEUI) != 0 {
+		var appEUI lorawan.EUI64
+		copy(appEUI[:], req.AppEUI)
+		filters.AppEUI = &appEUI
+	}
+
+	sessions, cursor, err := session.ListNodeSessions(n.ctx.RedisPool, req.Cursor, limit)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetLowLinkMarginNodesResponse{
+		Cursor: cursor,
+	}
+	for _, sess := range sessions {
+		d, ok := linkmargin.GetDevice(sess, filters)
+		if !ok {
+			continue
+		}
+
+		// make sure we have a copy of the byte slices
+		devEUI := make([]byte, 8)
+		copy(devEUI, d.DevEUI[:])
+		appEUI := make([]byte, 8)
+		copy(appEUI, d.AppEUI[:])
+		mac := make([]byte, 8)
+		copy(mac, d.MAC[:])
+
+		resp.Result = append(resp.Result, &ns.LowLinkMarginNode{
+			DevEUI:        devEUI,
+			AppEUI:        appEUI,
+			Mac:           mac,
+			SpreadFactor:  uint32(d.DataRate.SpreadFactor),
+			Frames:        uint32(d.Frames),
+			LowFrames:     uint32(d.LowFrames),
+			MinMargin:     d.MinMargin,
+			AverageMargin: d.AverageMargin,
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetDeviceClockDrift returns the estimated transmission period and clock
+// drift of the given node.
+func (n *NetworkServerAPI) GetDeviceClockDrift(ctx context.Context, req *ns.GetDeviceClockDriftRequest) (*ns.GetDeviceClockDriftResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	d, err := clockdrift.GetDrift(n.ctx.RedisPool, devEUI, time.Duration(req.NominalPeriod)*time.Second)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetDeviceClockDriftResponse{
+		Result: clockDriftToResp(d),
+	}, nil
+}
+
+// GetClockDriftNodes returns a batch of periodic nodes of which the
+// estimated clock drift exceeds the given threshold.
+func (n *NetworkServerAPI) GetClockDriftNodes(ctx context.Context, req *ns.GetClockDriftNodesRequest) (*ns.GetClockDriftNodesResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = exportNodeSessionsDefaultLimit
+	}
+
+	filters := clockdrift.Filters{
+		MinDriftPPM: req.MinDriftPPM,
+	}
+	if len(req.AppEUI) != 0 {
+		var appEUI lorawan.EUI64
+		copy(appEUI[:], req.AppEUI)
+		filters.AppEUI = &appEUI
+	}
+
+	sessions, cursor, err := session.ListNodeSessions(n.ctx.RedisPool, req.Cursor, limit)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.GetClockDriftNodesResponse{
+		Cursor: cursor,
+	}
+	for _, sess := range sessions {
+		d, ok, err := clockdrift.GetNode(n.ctx.RedisPool, sess, filters)
+		if err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		if !ok {
+			continue
+		}
+		resp.Result = append(resp.Result, clockDriftToResp(d))
+	}
+
+	return &resp, nil
+}
+
+func clockDriftToResp(d clockdrift.Drift) *ns.ClockDrift {
+	// make sure we have a copy of the byte slices
+	devEUI := make([]byte, 8)
+	copy(devEUI, d.DevEUI[:])
+
+	resp := ns.ClockDrift{
+		DevEUI:            devEUI,
+		Samples:           uint32(d.Samples),
+		Period:            durationToMillis(d.Period),
+		NominalPeriod:     durationToMillis(d.NominalPeriod),
+		DriftPPM:          d.DriftPPM,
+		Jitter:            durationToMillis(d.Jitter),
+		Periodic:          d.Periodic,
+		BeaconPeriodError: durationToMillis(d.BeaconPeriodError),
+	}
+	if d.AppEUI != (lorawan.EUI64{}) {
+		resp.AppEUI = make([]byte, 8)
+		copy(resp.AppEUI, d.AppEUI[:])
+	}
+	return &resp
+}
+
+func durationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// RotateGatewayCUPSCredentials generates new CUPS credentials for the given
+// gateway.
+func (n *NetworkServerAPI) RotateGatewayCUPSCredentials(ctx context.Context, req *ns.RotateGatewayCUPSCredentialsRequest) (*ns.RotateGatewayCUPSCredentialsResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	creds, err := gateway.RotateCUPSCredentials(n.ctx.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.RotateGatewayCUPSCredentialsResponse{
+		CupsToken: creds.CUPSToken,
+		TcToken:   creds.TCToken,
+	}, nil
+}
+
+// GetGatewayCUPSCredentials returns the CUPS credentials of the given
+// gateway.
+func (n *NetworkServerAPI) GetGatewayCUPSCredentials(ctx context.Context, req *ns.GetGatewayCUPSCredentialsRequest) (*ns.GetGatewayCUPSCredentialsResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	creds, err := gateway.GetCUPSCredentials(n.ctx.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetGatewayCUPSCredentialsResponse{
+		CupsToken:       creds.CUPSToken,
+		TcToken:         creds.TCToken,
+		RotationPending: creds.PreviousCUPSToken != nil,
+		UpdatedAt:       creds.UpdatedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// GenerateGatewayClientCertificate generates a TLS client certificate for
+// the given gateway.
+func (n *NetworkServerAPI) GenerateGatewayClientCertificate(ctx context.Context, req *ns.GenerateGatewayClientCertificateRequest) (*ns.GenerateGatewayClientCertificateResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	cert, err := gateway.GenerateClientCertificate(n.ctx.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GenerateGatewayClientCertificateResponse{
+		TlsCert:   string(cert.TLSCert),
+		TlsKey:    string(cert.TLSKey),
+		CaCert:    string(cert.CACert),
+		ExpiresAt: cert.ExpiresAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// ListRX2MismatchNodes returns the nodes flagged with mismatching RX2
+// parameters.
+func (n *NetworkServerAPI) ListRX2MismatchNodes(ctx context.Context, req *ns.ListRX2MismatchNodesRequest) (*ns.ListRX2MismatchNodesResponse, error) {
+	nodes, err := rx2mismatch.List(n.ctx.RedisPool)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.ListRX2MismatchNodesResponse
+	for _, node := range nodes {
+		sess, err := session.GetNodeSession(n.ctx.RedisPool, node.DevEUI)
+		if err != nil {
+			if err == session.ErrDoesNotExist {
+				continue
+			}
+			return nil, errToRPCError(ctx, err)
+		}
+
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, node.DevEUI[:])
+
+		resp.Result = append(resp.Result, &ns.RX2MismatchNode{
+			DevEUI:       devEUI,
+			Rx2DR:        uint32(sess.RX2DR),
+			DefaultRX2DR: uint32(common.Band.RX2DataRate),
+			DetectedAt:   node.DetectedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
+// ClearRX2Mismatch removes the RX2 mismatch flag of the given node.
+func (n *NetworkServerAPI) ClearRX2Mismatch(ctx context.Context, req *ns.ClearRX2MismatchRequest) (*ns.ClearRX2MismatchResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if err := rx2mismatch.Clear(n.ctx.RedisPool, sess); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.ClearRX2MismatchResponse{}, nil
+}
+
+// ListFCntDownRolloverNodes returns the nodes flagged with a downlink
+// frame-counter approaching the 16-bit rollover.
+func (n *NetworkServerAPI) ListFCntDownRolloverNodes(ctx context.Context, req *ns.ListFCntDownRolloverNodesRequest) (*ns.ListFCntDownRolloverNodesResponse, error) {
+	nodes, err := fcntrollover.List(n.ctx.RedisPool)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.ListFCntDownRolloverNodesResponse
+	for _, node := range nodes {
+		sess, err := session.GetNodeSession(n.ctx.RedisPool, node.DevEUI)
+		if err != nil {
+			if err == session.ErrDoesNotExist {
+				continue
+			}
+			return nil, errToRPCError(ctx, err)
+		}
+
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, node.DevEUI[:])
+
+		resp.Result = append(resp.Result, &ns.FCntDownRolloverNode{
+			DevEUI:     devEUI,
+			FCntDown:   sess.FCntDown,
+			DetectedAt: node.DetectedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
+// uplinkRuleActions maps the API rule actions to the rule actions.
+var uplinkRuleActions = map[ns.UplinkRuleAction]string{
+	ns.UplinkRuleAction_ENQUEUE_LINK_ADR_REQ: rules.ActionEnqueueLinkADRReq,
+	ns.UplinkRuleAction_ENQUEUE_MAC_COMMAND:  rules.ActionEnqueueMACCommand,
+	ns.UplinkRuleAction_OPEN_CLASS_C_WINDOW:  rules.ActionOpenClassCWindow,
+}
+
+func uplinkRuleFromReq(req *ns.UplinkRule) rules.Rule {
+	r := rules.Rule{
+		ID:                req.Id,
+		Name:              req.Name,
+		Enabled:           req.Enabled,
+		ConsecutiveFrames: int(req.ConsecutiveFrames),
+		Action:            uplinkRuleActions[req.Action],
+		DataRate:          int(req.DataRate),
+		TXPower:           int(req.TxPower),
+		MACCommand:        req.MacCommand,
+		ClassCWindow:      time.Duration(req.ClassCWindow) * time.Second,
+	}
+
+	if len(req.DevEUI) != 0 {
+		var devEUI lorawan.EUI64
+		copy(devEUI[:], req.DevEUI)
+		r.DevEUI = &devEUI
+	}
+	if req.MatchFPort {
+		fPort := int(req.FPort)
+		r.FPort = &fPort
+	}
+	if req.MatchMaxDR {
+		maxDR := int(req.MaxDR)
+		r.MaxDR = &maxDR
+	}
+
+	return r
+}
+
+func uplinkRuleToResp(r rules.Rule) *ns.UplinkRule {
+	resp := ns.UplinkRule{
+		Id:                r.ID,
+		Name:              r.Name,
+		Enabled:           r.Enabled,
+		ConsecutiveFrames: uint32(r.ConsecutiveFrames),
+		DataRate:          uint32(r.DataRate),
+		TxPower:           uint32(r.TXPower),
+		MacCommand:        r.MACCommand,
+		ClassCWindow:      uint32(r.ClassCWindow / time.Second),
+		CreatedAt:         r.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:         r.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	for action, a := range uplinkRuleActions {
+		if a == r.Action {
+			resp.Action = action
+		}
+	}
+	if r.DevEUI != nil {
+		resp.DevEUI = r.DevEUI[:]
+	}
+	if r.FPort != nil {
+		resp.MatchFPort = true
+		resp.FPort = uint32(*r.FPort)
+	}
+	if r.MaxDR != nil {
+		resp.MatchMaxDR = true
+		resp.MaxDR = uint32(*r.MaxDR)
+	}
+
+	return &resp
+}
+
+// GetOversizedFrameOffenders returns the nodes and gateways with the most
+// oversized uplink frames.
+func (n *NetworkServerAPI) GetOversizedFrameOffenders(ctx context.Context, req *ns.GetOversizedFrameOffendersRequest) (*ns.GetOversizedFrameOffendersResponse, error) {
+	devices, err := oversize.GetDevices(n.ctx.RedisPool, int(req.MinCount), int(req.Limit))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	gateways, err := oversize.GetGateways(n.ctx.RedisPool, int(req.MinCount), int(req.Limit))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetOversizedFrameOffendersResponse
+	for _, d := range devices {
+		// make sure we have a copy of the DevEUI byte slice
+		devEUI := make([]byte, 8)
+		copy(devEUI, d.DevEUI[:])
+
+		resp.Devices = append(resp.Devices, &ns.OversizedFrameDevice{
+			DevEUI: devEUI,
+			Count:  uint32(d.Count),
+		})
+	}
+	for _, gw := range gateways {
+		mac := make([]byte, 8)
+		copy(mac, gw.MAC[:])
+
+		resp.Gateways = append(resp.Gateways, &ns.OversizedFrameGateway{
+			Mac:   mac,
+			Count: uint32(gw.Count),
+		})
+	}
+
+	return &resp, nil
+}
+
+// EnqueueDeviceQueueItem adds the given downlink payload to the
+// device-queue of the node.
+func (n *NetworkServerAPI) EnqueueDeviceQueueItem(ctx context.Context, req *ns.EnqueueDeviceQueueItemRequest) (*ns.EnqueueDeviceQueueItemResponse, error) {
+	if req.Item == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+	}
+
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if req.Item.FPort > 255 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid FPort: %d", req.Item.FPort)
+	}
+
+	if err := deviceprofile.CheckEnqueue(n.ctx.DB, n.ctx.RedisPool, sess); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	item := downlink.DeviceQueueItem{
+		FPort:     uint8(req.Item.FPort),
+		Data:      req.Item.Data,
+		FCnt:      req.Item.FCnt,
+		Confirmed: req.Item.Confirmed,
+		Critical:  req.Item.Critical,
+		Reference: req.Item.Reference,
+	}
+	if req.Item.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339Nano, req.Item.ExpiresAt)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse expiresAt error: %s", err)
+		}
+		item.ExpiresAt = &expiresAt
+	}
+	if req.Item.NotBefore != "" {
+		notBefore, err := time.Parse(time.RFC3339Nano, req.Item.NotBefore)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse notBefore error: %s", err)
+		}
+		if item.ExpiresAt != nil && !notBefore.Before(*item.ExpiresAt) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "notBefore must be before expiresAt")
+		}
+		item.NotBefore = &notBefore
+	}
+
+	if err = downlink.EnqueueDeviceQueueItem(n.ctx.RedisPool, sess, item); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.EnqueueDeviceQueueItemResponse{}, nil
+}
+
+// GetDeviceQueueItems returns the downlink payloads in the device-queue of
+// the node.
+func (n *NetworkServerAPI) GetDeviceQueueItems(ctx context.Context, req *ns.GetDeviceQueueItemsRequest) (*ns.GetDeviceQueueItemsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	items, err := downlink.GetDeviceQueueItems(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	var resp ns.GetDeviceQueueItemsResponse
+	for _, item := range items {
+		qi := ns.DeviceQueueItem{
+			Data:       item.Data,
+			Confirmed:  item.Confirmed,
+			FPort:      uint32(item.FPort),
+			FCnt:       item.FCnt,
+			Critical:   item.Critical,
+			Reference:  item.Reference,
+			EnqueuedAt: item.EnqueuedAt.Format(time.RFC3339Nano),
+		}
+		if item.ExpiresAt != nil {
+			qi.ExpiresAt = item.ExpiresAt.Format(time.RFC3339Nano)
+		}
+		if item.NotBefore != nil {
+			qi.NotBefore = item.NotBefore.Format(time.RFC3339Nano)
+		}
+		resp.Items = append(resp.Items, &qi)
+	}
+
+	return &resp, nil
+}
+
+// FlushDeviceQueue removes all the downlink payloads from the device-queue
+// of the node.
+func (n *NetworkServerAPI) FlushDeviceQueue(ctx context.Context, req *ns.FlushDeviceQueueRequest) (*ns.FlushDeviceQueueResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	if err := downlink.FlushDeviceQueue(n.ctx.RedisPool, devEUI); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.FlushDeviceQueueResponse{}, nil
+}
+
+// GetNextDownlinkFCnt returns the frame-counter with which the next payload
+// enqueued in the device-queue of the node will be transmitted.
+func (n *NetworkServerAPI) GetNextDownlinkFCnt(ctx context.Context, req *ns.GetNextDownlinkFCntRequest) (*ns.GetNextDownlinkFCntResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	fCnt, err := downlink.GetNextDownlinkFCnt(n.ctx.RedisPool, sess, req.Reserve, time.Duration(req.ReservationTTL)*time.Second)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetNextDownlinkFCntResponse{FCnt: fCnt}, nil
+}
+
+// CreateMulticastGroup creates the given multicast group.
+func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.CreateMulticastGroupRequest) (*ns.CreateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup must not be nil")
+	}
+
+	g, err := multicastGroupFromReq(req.MulticastGroup)
+	if err != nil {
+		return nil, err
+	}
+	if err := multicast.CreateGroup(n.ctx.DB, &g); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateMulticastGroupResponse{Id: g.ID}, nil
+}
+
+// GetMulticastGroup returns the multicast group for the given id.
+func (n *NetworkServerAPI) GetMulticastGroup(ctx context.Context, req *ns.GetMulticastGroupRequest) (*ns.GetMulticastGroupResponse, error) {
+	g, err := multicast.GetGroup(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetMulticastGroupResponse{MulticastGroup: multicastGroupToResp(g)}, nil
+}
+
+// UpdateMulticastGroup updates the given multicast group.
+func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.UpdateMulticastGroupRequest) (*ns.UpdateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup must not be nil")
+	}
+
+	g, err := multicastGroupFromReq(req.MulticastGroup)
+	if err != nil {
+		return nil, err
+	}
+	if err := multicast.UpdateGroup(n.ctx.DB, &g); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateMulticastGroupResponse{}, nil
+}
+
+// DeleteMulticastGroup deletes the multicast group for the given id
+// (including its queue).
+func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.DeleteMulticastGroupRequest) (*ns.DeleteMulticastGroupResponse, error) {
+	if err := multicast.DeleteGroup(n.ctx.DB, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	if err := multicast.FlushQueue(n.ctx.RedisPool, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteMulticastGroupResponse{}, nil
+}
+
+// ListMulticastGroups returns the multicast groups.
+func (n *NetworkServerAPI) ListMulticastGroups(ctx context.Context, req *ns.ListMulticastGroupsRequest) (*ns.ListMulticastGroupsResponse, error) {
+	count, err := multicast.GetGroupCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	groups, err := multicast.GetGroups(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListMulticastGroupsResponse{
+		TotalCount: int32(count),
+	}
+	for _, g := range groups {
+		resp.Result = append(resp.Result, multicastGroupToResp(g))
+	}
+
+	return &resp, nil
+}
+
+// EnqueueMulticastQueueItem adds the given payload to the queue of the
+// multicast group.
+func (n *NetworkServerAPI) EnqueueMulticastQueueItem(ctx context.Context, req *ns.EnqueueMulticastQueueItemRequest) (*ns.EnqueueMulticastQueueItemResponse, error) {
+	if req.FPort > 255 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid FPort: %d", req.FPort)
+	}
+
+	item := multicast.QueueItem{
+		GroupID: req.MulticastGroupID,
+		FPort:   uint8(req.FPort),
+		Data:    req.Data,
+		FCnt:    req.FCnt,
+	}
+	if err := multicast.EnqueueQueueItem(n.ctx.DB, n.ctx.RedisPool, &item); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.EnqueueMulticastQueueItemResponse{FCnt: item.FCnt}, nil
+}
+
+// FlushMulticastQueue removes all the payloads from the queue of the
+// multicast group.
+func (n *NetworkServerAPI) FlushMulticastQueue(ctx context.Context, req *ns.FlushMulticastQueueRequest) (*ns.FlushMulticastQueueResponse, error) {
+	if err := multicast.FlushQueue(n.ctx.RedisPool, req.MulticastGroupID); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.FlushMulticastQueueResponse{}, nil
+}
+
+func multicastGroupFromReq(req *ns.MulticastGroup) (multicast.Group, error) {
+	g := multicast.Group{
+		ID:        req.Id,
+		Name:      req.Name,
+		Frequency: int(req.Frequency),
+		DR:        int(req.Dr),
+		FCnt:      req.FCnt,
+	}
+
+	if len(req.McAddr) != len(g.MCAddr) {
+		return g, grpc.Errorf(codes.InvalidArgument, "mcAddr must be exactly %d bytes", len(g.MCAddr))
+	}
+	copy(g.MCAddr[:], req.McAddr)
+
+	if len(req.McNwkSKey) != len(g.MCNwkSKey) {
+		return g, grpc.Errorf(codes.InvalidArgument, "mcNwkSKey must be exactly %d bytes", len(g.MCNwkSKey))
+	}
+	copy(g.MCNwkSKey[:], req.McNwkSKey)
+
+	if len(req.McAppSKey) != 0 {
+		var key lorawan.AES128Key
+		if len(req.McAppSKey) != len(key) {
+			return g, grpc.Errorf(codes.InvalidArgument, "mcAppSKey must be exactly %d bytes", len(key))
+		}
+		copy(key[:], req.McAppSKey)
+		g.MCAppSKey = &key
+	}
+
+	for _, b := range req.Gateways {
+		var mac lorawan.EUI64
+		if len(b) != len(mac) {
+			return g, grpc.Errorf(codes.InvalidArgument, "gateway mac must be exactly %d bytes", len(mac))
+		}
+		copy(mac[:], b)
+		g.Gateways = append(g.Gateways, mac)
+	}
+
+	return g, nil
+}
+
+func multicastGroupToResp(g multicast.Group) *ns.MulticastGroup {
+	resp := ns.MulticastGroup{
+		Id:        g.ID,
+		Name:      g.Name,
+		McAddr:    g.MCAddr[:],
+		McNwkSKey: g.MCNwkSKey[:],
+		Frequency: uint32(g.Frequency),
+		Dr:        uint32(g.DR),
+		FCnt:      g.FCnt,
+		CreatedAt: g.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: g.UpdatedAt.Format(time.RFC3339Nano),
+	}
+
+	if g.MCAppSKey != nil {
+		resp.McAppSKey = g.MCAppSKey[:]
+	}
+	for _, mac := range g.Gateways {
+		resp.Gateways = append(resp.Gateways, mac[:])
+	}
+
+	return &resp
+}
+
+// StreamFrameLogsForDevice streams the uplink and downlink frames of the
+// given node.
+func (n *NetworkServerAPI) StreamFrameLogsForDevice(req *ns.StreamFrameLogsForDeviceRequest, stream ns.NetworkServer_StreamFrameLogsForDeviceServer) error {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	err := framelog.SubscribeDevice(stream.Context(), n.ctx.RedisPool, devEUI, stream.Send)
+	if err != nil {
+		return errToRPCError(stream.Context(), err)
+	}
+	return nil
+}
+
+// StreamFrameLogsForGateway streams the uplink and downlink frames of the
+// given gateway.
+func (n *NetworkServerAPI) StreamFrameLogsForGateway(req *ns.StreamFrameLogsForGatewayRequest, stream ns.NetworkServer_StreamFrameLogsForGatewayServer) error {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	err := framelog.SubscribeGateway(stream.Context(), n.ctx.RedisPool, mac, stream.Send)
+	if err != nil {
+		return errToRPCError(stream.Context(), err)
+	}
+	return nil
+}
+
+// GetFrameLogsForDevice returns the last logged uplink and downlink frames
+// of the given node.
+func (n *NetworkServerAPI) GetFrameLogsForDevice(ctx context.Context, req *ns.GetFrameLogsForDeviceRequest) (*ns.GetFrameLogsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	count, frames, err := framelog.GetFrameLogsForDevice(n.ctx.RedisPool, devEUI, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetFrameLogsResponse{
+		TotalCount: int32(count),
+		Result:     frames,
+	}, nil
+}
+
+// GetFrameLogsForGateway returns the last logged uplink and downlink frames
+// of the given gateway.
+func (n *NetworkServerAPI) GetFrameLogsForGateway(ctx context.Context, req *ns.GetFrameLogsForGatewayRequest) (*ns.GetFrameLogsResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	count, frames, err := framelog.GetFrameLogsForGateway(n.ctx.RedisPool, mac, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetFrameLogsResponse{
+		TotalCount: int32(count),
+		Result:     frames,
+	}, nil
+}
+
+// ExportFrameLogsForDevice exports the last logged uplink and downlink
+// frames of the given node in the pcap format.
+func (n *NetworkServerAPI) ExportFrameLogsForDevice(ctx context.Context, req *ns.ExportFrameLogsForDeviceRequest) (*ns.ExportFrameLogsResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	_, frames, err := framelog.GetFrameLogsForDevice(n.ctx.RedisPool, devEUI, exportFrameLogsLimit(req.Limit), 0)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return exportFrameLogsResponse(ctx, frames)
+}
+
+// ExportFrameLogsForGateway exports the last logged uplink and downlink
+// frames of the given gateway in the pcap format.
+func (n *NetworkServerAPI) ExportFrameLogsForGateway(ctx context.Context, req *ns.ExportFrameLogsForGatewayRequest) (*ns.ExportFrameLogsResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	_, frames, err := framelog.GetFrameLogsForGateway(n.ctx.RedisPool, mac, exportFrameLogsLimit(req.Limit), 0)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return exportFrameLogsResponse(ctx, frames)
+}
+
+// exportFrameLogsLimit returns the number of frames to export, all the
+// logged frames are exported when no limit is given.
+func exportFrameLogsLimit(limit int32) int {
+	if limit <= 0 || int(limit) > common.FrameLogMaxFrames {
+		return common.FrameLogMaxFrames
+	}
+	return int(limit)
+}
+
+func exportFrameLogsResponse(ctx context.Context, frames []*ns.FrameLog) (*ns.ExportFrameLogsResponse, error) {
+	var buf bytes.Buffer
+	if err := framelog.WritePCAP(&buf, frames); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.ExportFrameLogsResponse{
+		Count: int32(len(frames)),
+		Pcap:  buf.Bytes(),
+	}, nil
+}
+
+// RotateGatewaySigningKey generates a new signing key for the given
+// gateway.
+func (n *NetworkServerAPI) RotateGatewaySigningKey(ctx context.Context, req *ns.RotateGatewaySigningKeyRequest) (*ns.RotateGatewaySigningKeyResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	key, err := gateway.RotateSigningKey(n.ctx.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.RotateGatewaySigningKeyResponse{SigningKey: key}, nil
+}
+
+// DeleteGatewaySigningKey deletes the signing key of the given gateway.
+func (n *NetworkServerAPI) DeleteGatewaySigningKey(ctx context.Context, req *ns.DeleteGatewaySigningKeyRequest) (*ns.DeleteGatewaySigningKeyResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	if err := gateway.DeleteSigningKey(n.ctx.DB, mac); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteGatewaySigningKeyResponse{}, nil
+}
+
+// CreateDownlinkTemplate creates the given downlink template.
+func (n *NetworkServerAPI) CreateDownlinkTemplate(ctx context.Context, req *ns.CreateDownlinkTemplateRequest) (*ns.CreateDownlinkTemplateResponse, error) {
+	if req.Template == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "template must not be nil")
+	}
+
+	t := downlinkTemplateFromReq(req.Template)
+	if err := templates.CreateTemplate(n.ctx.DB, &t); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateDownlinkTemplateResponse{Id: t.ID}, nil
+}
+
+// GetDownlinkTemplate returns the downlink template for the given id.
+func (n *NetworkServerAPI) GetDownlinkTemplate(ctx context.Context, req *ns.GetDownlinkTemplateRequest) (*ns.GetDownlinkTemplateResponse, error) {
+	t, err := templates.GetTemplate(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetDownlinkTemplateResponse{Template: downlinkTemplateToResp(t)}, nil
+}
+
+// UpdateDownlinkTemplate updates the given downlink template.
+func (n *NetworkServerAPI) UpdateDownlinkTemplate(ctx context.Context, req *ns.UpdateDownlinkTemplateRequest) (*ns.UpdateDownlinkTemplateResponse, error) {
+	if req.Template == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "template must not be nil")
+	}
+
+	t := downlinkTemplateFromReq(req.Template)
+	if err := templates.UpdateTemplate(n.ctx.DB, &t); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateDownlinkTemplateResponse{}, nil
+}
+
+// DeleteDownlinkTemplate deletes the downlink template for the given id.
+func (n *NetworkServerAPI) DeleteDownlinkTemplate(ctx context.Context, req *ns.DeleteDownlinkTemplateRequest) (*ns.DeleteDownlinkTemplateResponse, error) {
+	if err := templates.DeleteTemplate(n.ctx.DB, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteDownlinkTemplateResponse{}, nil
+}
+
+// ListDownlinkTemplates returns the downlink templates.
+func (n *NetworkServerAPI) ListDownlinkTemplates(ctx context.Context, req *ns.ListDownlinkTemplatesRequest) (*ns.ListDownlinkTemplatesResponse, error) {
+	count, err := templates.GetTemplateCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	items, err := templates.GetTemplates(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListDownlinkTemplatesResponse{
+		TotalCount: int32(count),
+	}
+	for _, t := range items {
+		resp.Result = append(resp.Result, downlinkTemplateToResp(t))
+	}
+
+	return &resp, nil
+}
+
+// TriggerDownlinkTemplate renders the downlink template for the given node,
+// or for all the nodes having the given tags, and adds the payload to the
+// device-queue of these nodes.
+func (n *NetworkServerAPI) TriggerDownlinkTemplate(ctx context.Context, req *ns.TriggerDownlinkTemplateRequest) (*ns.TriggerDownlinkTemplateResponse, error) {
+	t, err := templates.GetTemplate(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	if len(req.DevEUI) != 0 {
+		var devEUI lorawan.EUI64
+		copy(devEUI[:], req.DevEUI)
+
+		sess, err := session.GetNodeSession(n.ctx.RedisPool, devEUI)
+		if err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		if err := templates.Trigger(n.ctx.RedisPool, t, sess); err != nil {
+			return nil, errToRPCError(ctx, err)
+		}
+		return &ns.TriggerDownlinkTemplateResponse{EnqueuedCount: 1}, nil
+	}
+
+	if len(req.Tags) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devEUI or tags must be given")
+	}
+
+	res, err := templates.TriggerForTags(n.ctx.RedisPool, t, req.Tags)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.TriggerDownlinkTemplateResponse{
+		EnqueuedCount: int32(res.Enqueued),
+	}
+	for _, e := range res.Errors {
+		devEUI := e.DevEUI
+		resp.Errors = append(resp.Errors, &ns.TriggerDownlinkTemplateError{
+			DevEUI:    devEUI[:],
+			ErrorCode: errToErrorCode(e.Err),
+			Error:     e.Err.Error(),
+		})
+	}
+
+	return &resp, nil
+}
+
+func downlinkTemplateFromReq(req *ns.DownlinkTemplate) templates.Template {
+	return templates.Template{
+		ID:        req.Id,
+		Name:      req.Name,
+		FPort:     int(req.FPort),
+		Payload:   req.Payload,
+		Confirmed: req.Confirmed,
+	}
+}
+
+func downlinkTemplateToResp(t templates.Template) *ns.DownlinkTemplate {
+	return &ns.DownlinkTemplate{
+		Id:        t.ID,
+		Name:      t.Name,
+		FPort:     uint32(t.FPort),
+		Payload:   t.Payload,
+		Confirmed: t.Confirmed,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// CreateChannelConfiguration creates the given channel-configuration.
+func (n *NetworkServerAPI) CreateChannelConfiguration(ctx context.Context, req *ns.CreateChannelConfigurationRequest) (*ns.CreateChannelConfigurationResponse, error) {
+	if req.Configuration == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "configuration must not be nil")
+	}
+
+	c := channelConfigurationFromReq(req.Configuration)
+	if err := channelplan.CreateConfiguration(n.ctx.DB, &c); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateChannelConfigurationResponse{Id: c.ID}, nil
+}
+
+// GetChannelConfiguration returns the channel-configuration for the given id.
+func (n *NetworkServerAPI) GetChannelConfiguration(ctx context.Context, req *ns.GetChannelConfigurationRequest) (*ns.GetChannelConfigurationResponse, error) {
+	c, err := channelplan.GetConfiguration(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetChannelConfigurationResponse{Configuration: channelConfigurationToResp(c)}, nil
+}
+
+// UpdateChannelConfiguration updates the given channel-configuration.
+func (n *NetworkServerAPI) UpdateChannelConfiguration(ctx context.Context, req *ns.UpdateChannelConfigurationRequest) (*ns.UpdateChannelConfigurationResponse, error) {
+	if req.Configuration == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "configuration must not be nil")
+	}
+
+	c := channelConfigurationFromReq(req.Configuration)
+	if err := channelplan.UpdateConfiguration(n.ctx.DB, &c); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateChannelConfigurationResponse{}, nil
+}
+
+// DeleteChannelConfiguration deletes the channel-configuration for the
+// given id.
+func (n *NetworkServerAPI) DeleteChannelConfiguration(ctx context.Context, req *ns.DeleteChannelConfigurationRequest) (*ns.DeleteChannelConfigurationResponse, error) {
+	if err := channelplan.DeleteConfiguration(n.ctx.DB, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteChannelConfigurationResponse{}, nil
+}
+
+// ListChannelConfigurations returns the channel-configurations.
+func (n *NetworkServerAPI) ListChannelConfigurations(ctx context.Context, req *ns.ListChannelConfigurationsRequest) (*ns.ListChannelConfigurationsResponse, error) {
+	count, err := channelplan.GetConfigurationCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	items, err := channelplan.GetConfigurations(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListChannelConfigurationsResponse{
+		TotalCount: int32(count),
+	}
+	for _, c := range items {
+		resp.Result = append(resp.Result, channelConfigurationToResp(c))
+	}
+
+	return &resp, nil
+}
+
+// EnqueueBenchDownlink enqueues a downlink for an unprovisioned test device
+// (lab / bench testing). Only available when bench mode is enabled.
+func (n *NetworkServerAPI) EnqueueBenchDownlink(ctx context.Context, req *ns.EnqueueBenchDownlinkRequest) (*ns.EnqueueBenchDownlinkResponse, error) {
+	if len(req.DevAddr) != 4 || len(req.NwkSKey) != 16 || (len(req.AppSKey) != 0 && len(req.AppSKey) != 16) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "devAddr must be 4 bytes and nwkSKey and appSKey (optional) must be 16 bytes")
+	}
+	if req.FPort > 255 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fPort must be between 0 and 255")
+	}
+
+	d := downlink.BenchDownlink{
+		FCnt:        req.FCnt,
+		FPort:       uint8(req.FPort),
+		Data:        req.Data,
+		MACCommands: req.MacCommands,
+		Confirmed:   req.Confirmed,
+	}
+	copy(d.DevAddr[:], req.DevAddr)
+	copy(d.NwkSKey[:], req.NwkSKey)
+	if len(req.AppSKey) != 0 {
+		var appSKey lorawan.AES128Key
+		copy(appSKey[:], req.AppSKey)
+		d.AppSKey = &appSKey
+	}
+
+	if err := downlink.EnqueueBenchDownlink(n.ctx.RedisPool, d); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.EnqueueBenchDownlinkResponse{}, nil
+}
+
+// CreateGatewayOnboardingToken creates a one-time token with which a
+// gateway registers itself on its first stats message.
+func (n *NetworkServerAPI) CreateGatewayOnboardingToken(ctx context.Context, req *ns.CreateGatewayOnboardingTokenRequest) (*ns.CreateGatewayOnboardingTokenResponse, error) {
+	t := gateway.OnboardingToken{
+		Description: req.Description,
+		Region:      req.Region,
+		Tags:        req.Tags,
+	}
+	if err := gateway.CreateOnboardingToken(n.ctx.DB, &t, time.Duration(req.Ttl)*time.Second); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateGatewayOnboardingTokenResponse{
+		Token:     t.Token,
+		ExpiresAt: t.ExpiresAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// ListGatewayOnboardingTokens returns the gateway onboarding tokens.
+func (n *NetworkServerAPI) ListGatewayOnboardingTokens(ctx context.Context, req *ns.ListGatewayOnboardingTokensRequest) (*ns.ListGatewayOnboardingTokensResponse, error) {
+	count, err := gateway.GetOnboardingTokenCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	tokens, err := gateway.GetOnboardingTokens(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListGatewayOnboardingTokensResponse{
+		TotalCount: int32(count),
+	}
+	for _, t := range tokens {
+		item := ns.GatewayOnboardingToken{
+			Token:       t.Token,
+			Description: t.Description,
+			Region:      t.Region,
+			Tags:        t.Tags,
+			CreatedAt:   t.CreatedAt.Format(time.RFC3339Nano),
+			ExpiresAt:   t.ExpiresAt.Format(time.RFC3339Nano),
+		}
+		if t.UsedAt != nil {
+			item.UsedAt = t.UsedAt.Format(time.RFC3339Nano)
+		}
+		if t.UsedBy != nil {
+			item.UsedBy = t.UsedBy[:]
+		}
+		resp.Result = append(resp.Result, &item)
+	}
+
+	return &resp, nil
+}
+
+// DeleteGatewayOnboardingToken deletes (revokes) the given gateway
+// onboarding token.
+func (n *NetworkServerAPI) DeleteGatewayOnboardingToken(ctx context.Context, req *ns.DeleteGatewayOnboardingTokenRequest) (*ns.DeleteGatewayOnboardingTokenResponse, error) {
+	if err := gateway.DeleteOnboardingToken(n.ctx.DB, req.Token); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+	return &ns.DeleteGatewayOnboardingTokenResponse{}, nil
+}
+
+// validateChannelConfiguration validates that the given channel-configuration
+// of a node-session exists (0 = none).
+func (n *NetworkServerAPI) validateChannelConfiguration(id int64) error {
+	if id == 0 {
+		return nil
+	}
+	_, err := channelplan.GetConfiguration(n.ctx.DB, id)
+	return err
+}
+
+func channelConfigurationFromReq(req *ns.ChannelConfiguration) channelplan.Configuration {
+	c := channelplan.Configuration{
+		ID:   req.Id,
+		Name: req.Name,
+		Band: req.Band,
+	}
+	for _, i := range req.Channels {
+		c.Channels = append(c.Channels, int64(i))
+	}
+	for _, ec := range req.ExtraChannels {
+		c.ExtraChannels = append(c.ExtraChannels, channelplan.ExtraChannel{
+			Frequency: int(ec.Frequency),
+			MinDR:     int(ec.MinDR),
+			MaxDR:     int(ec.MaxDR),
+		})
+	}
+	return c
+}
+
+func channelConfigurationToResp(c channelplan.Configuration) *ns.ChannelConfiguration {
+	resp := ns.ChannelConfiguration{
+		Id:        c.ID,
+		Name:      c.Name,
+		Band:      c.Band,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: c.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	for _, i := range c.Channels {
+		resp.Channels = append(resp.Channels, uint32(i))
+	}
+	for _, ec := range c.ExtraChannels {
+		resp.ExtraChannels = append(resp.ExtraChannels, &ns.ExtraChannel{
+			Frequency: uint32(ec.Frequency),
+			MinDR:     uint32(ec.MinDR),
+			MaxDR:     uint32(ec.MaxDR),
+		})
+	}
+	return &resp
+}
+
+// SendGatewayTestFrame transmits a proprietary test frame via the given
+// gateway and returns the TX acknowledgement of the gateway.
+func (n *NetworkServerAPI) SendGatewayTestFrame(ctx context.Context, req *ns.SendGatewayTestFrameRequest) (*ns.SendGatewayTestFrameResponse, error) {
+	if int(req.Dr) >= len(common.Band.DataRates) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid dr: %d", req.Dr)
+	}
+	if req.Frequency == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "frequency must be set")
+	}
+
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	f, err := downlink.SendGatewayTestFrame(n.ctx.WithContext(ctx), mac, int(req.Frequency), int(req.Dr), int(req.TxPower), time.Duration(req.Timeout)*time.Second)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.SendGatewayTestFrameResponse{
+		Token:     uint32(f.Token),
+		SentAt:    f.SentAt.Format(time.RFC3339Nano),
+		Error:     f.Error,
+		ErrorCode: f.ErrorCode,
+	}
+	if !f.AckedAt.IsZero() {
+		resp.Acked = true
+		resp.AckedAt = f.AckedAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// CreateDeviceProfile creates the given device-profile.
+func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.CreateDeviceProfileRequest) (*ns.CreateDeviceProfileResponse, error) {
+	if req.Profile == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "profile must not be nil")
+	}
+
+	p := deviceProfileFromReq(req.Profile)
+	if err := deviceprofile.CreateProfile(n.ctx.DB, &p); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.CreateDeviceProfileResponse{Id: p.ID}, nil
+}
+
+// GetDeviceProfile returns the device-profile for the given id.
+func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDeviceProfileRequest) (*ns.GetDeviceProfileResponse, error) {
+	p, err := deviceprofile.GetProfile(n.ctx.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.GetDeviceProfileResponse{Profile: deviceProfileToResp(p)}, nil
+}
+
+// UpdateDeviceProfile updates the given device-profile.
+func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.UpdateDeviceProfileRequest) (*ns.UpdateDeviceProfileResponse, error) {
+	if req.Profile == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "profile must not be nil")
+	}
+
+	p := deviceProfileFromReq(req.Profile)
+	if err := deviceprofile.UpdateProfile(n.ctx.DB, &p); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.UpdateDeviceProfileResponse{}, nil
+}
+
+// DeleteDeviceProfile deletes the device-profile for the given id.
+func (n *NetworkServerAPI) DeleteDeviceProfile(ctx context.Context, req *ns.DeleteDeviceProfileRequest) (*ns.DeleteDeviceProfileResponse, error) {
+	if err := deviceprofile.DeleteProfile(n.ctx.DB, req.Id); err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	return &ns.DeleteDeviceProfileResponse{}, nil
+}
+
+// ListDeviceProfiles returns the device-profiles.
+func (n *NetworkServerAPI) ListDeviceProfiles(ctx context.Context, req *ns.ListDeviceProfilesRequest) (*ns.ListDeviceProfilesResponse, error) {
+	count, err := deviceprofile.GetProfileCount(n.ctx.DB)
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	items, err := deviceprofile.GetProfiles(n.ctx.DB, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, errToRPCError(ctx, err)
+	}
+
+	resp := ns.ListDeviceProfilesResponse{
+		TotalCount: int32(count),
+	}
+	for _, p := range items {
+		resp.Result = append(resp.Result, deviceProfileToResp(p))
+	}
+
+	return &resp, nil
+}
+
+// validateDeviceProfile validates that the given device-profile of a
+// node-session exists (0 = none).
+func (n *NetworkServerAPI) validateDeviceProfile(id int64) error {
+	if id == 0 {
+		return nil
+	}
+	_, err := deviceprofile.GetProfile(n.ctx.DB, id)
+	return err
+}
+
+func deviceProfileFromReq(req *ns.DeviceProfile) deviceprofile.Profile {
+	return deviceprofile.Profile{
+		ID:                req.Id,
+		Name:              req.Name,
+		MaxQueueSize:      int(req.MaxQueueSize),
+		DailyAirtimeQuota: time.Duration(req.DailyAirtimeQuota) * time.Millisecond,
+	}
+}
+
+func deviceProfileToResp(p deviceprofile.Profile) *ns.DeviceProfile {
+	return &ns.DeviceProfile{
+		Id:                p.ID,
+		Name:              p.Name,
+		MaxQueueSize:      uint32(p.MaxQueueSize),
+		DailyAirtimeQuota: uint32(p.DailyAirtimeQuota / time.Millisecond),
+		CreatedAt:         p.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:         p.UpdatedAt.Format(time.RFC3339Nano),
+	}
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -40,6 +41,24 @@ func TestNetworkServerAPI(t *testing.T) {
 		appEUI := [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
 		nwkSKey := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
 
+		Convey("When calling GetInfo", func() {
+			resp, err := api.GetInfo(ctx, &ns.GetInfoRequest{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the band, NetID, features and limits are returned", func() {
+				So(resp.Band, ShouldEqual, "EU_863_870")
+				So(resp.NetID, ShouldResemble, []byte{1, 2, 3})
+				So(resp.ClassB, ShouldBeFalse)
+				So(resp.ClassC, ShouldBeTrue)
+				So(resp.Adr, ShouldBeTrue)
+				So(resp.MaxDR, ShouldEqual, len(common.Band.DataRates)-1)
+				So(resp.MaxPayloadSize, ShouldHaveLength, len(common.Band.MaxPayloadSize))
+				So(resp.MaxPayloadSize[0], ShouldEqual, common.Band.MaxPayloadSize[0].N)
+				So(resp.Rx2Frequency, ShouldEqual, 869525000)
+				So(resp.Rx2DR, ShouldEqual, 0)
+			})
+		})
+
 		Convey("When creating a node-session", func() {
 			_, err := api.CreateNodeSession(ctx, &ns.CreateNodeSessionRequest{
 				DevAddr:     devAddr[:],
@@ -81,6 +100,21 @@ func TestNetworkServerAPI(t *testing.T) {
 					},
 					RxWindow: ns.RXWindow_RX2,
 					Rx2DR:    3,
+					Version:  1,
+				})
+			})
+
+			Convey("When calling CheckSessions", func() {
+				resp, err := api.CheckSessions(ctx, &ns.CheckSessionsRequest{})
+				So(err, ShouldBeNil)
+
+				Convey("Then the node-session is checked without issues", func() {
+					So(resp.NodeSessionCount, ShouldEqual, 1)
+					So(resp.DevAddrCount, ShouldEqual, 1)
+					So(resp.OrphanedDevAddrPointers, ShouldHaveLength, 0)
+					So(resp.MissingDevAddrPointers, ShouldHaveLength, 0)
+					So(resp.InvalidNodeSessions, ShouldHaveLength, 0)
+					So(resp.MismatchedMACQueues, ShouldHaveLength, 0)
 				})
 			})
 
@@ -104,6 +138,203 @@ func TestNetworkServerAPI(t *testing.T) {
 				})
 			})
 
+			Convey("When updating the node-session with an invalid RX2 data-rate", func() {
+				_, err := api.UpdateNodeSession(ctx, &ns.UpdateNodeSessionRequest{
+					DevAddr:  devAddr[:],
+					DevEUI:   devEUI[:],
+					AppEUI:   appEUI[:],
+					NwkSKey:  nwkSKey[:],
+					RxWindow: ns.RXWindow_RX2,
+					Rx2DR:    uint32(len(common.Band.DataRates)),
+				})
+				Convey("Then an error is returned", func() {
+					So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "invalid rx2DR: %d (max dr: %d)", len(common.Band.DataRates), len(common.Band.DataRates)-1))
+				})
+			})
+
+			Convey("When patching the rxDelay of the node-session", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:     devEUI[:],
+					AppEUI:     appEUI[:],
+					UpdateMask: []string{"rxDelay"},
+					RxDelay:    5,
+					FCntUp:     100,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then only the rxDelay has been updated", func() {
+					resp, err := api.GetNodeSession(ctx, &ns.GetNodeSessionRequest{
+						DevEUI: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.RxDelay, ShouldEqual, 5)
+					So(resp.FCntUp, ShouldEqual, 10)
+					So(resp.RxWindow, ShouldEqual, ns.RXWindow_RX2)
+					So(resp.Rx2DR, ShouldEqual, 3)
+				})
+			})
+
+			Convey("When patching the gateway regions of the node-session", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:         devEUI[:],
+					AppEUI:         appEUI[:],
+					UpdateMask:     []string{"gatewayRegions"},
+					GatewayRegions: []string{"EU", "NL"},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the gateway regions have been updated", func() {
+					resp, err := api.GetNodeSession(ctx, &ns.GetNodeSessionRequest{
+						DevEUI: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.GatewayRegions, ShouldResemble, []string{"EU", "NL"})
+				})
+			})
+
+			Convey("When patching the downlink TX parameters of the node-session", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:           devEUI[:],
+					AppEUI:           appEUI[:],
+					UpdateMask:       []string{"downlinkTXPower", "downlinkPolarity"},
+					DownlinkTXPower:  20,
+					DownlinkPolarity: ns.Polarity_INVERTED_POLARITY,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the downlink TX parameters have been updated", func() {
+					resp, err := api.GetNodeSession(ctx, &ns.GetNodeSessionRequest{
+						DevEUI: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.DownlinkTXPower, ShouldEqual, 20)
+					So(resp.DownlinkCodeRate, ShouldEqual, "")
+					So(resp.DownlinkPolarity, ShouldEqual, ns.Polarity_INVERTED_POLARITY)
+				})
+			})
+
+			Convey("When patching the node-session with an invalid code rate", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:           devEUI[:],
+					AppEUI:           appEUI[:],
+					UpdateMask:       []string{"downlinkCodeRate"},
+					DownlinkCodeRate: "4/9",
+				})
+
+				Convey("Then an invalid argument error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("When creating the node-session again", func() {
+				_, err := api.CreateNodeSession(ctx, &ns.CreateNodeSessionRequest{
+					DevAddr: devAddr[:],
+					DevEUI:  devEUI[:],
+					AppEUI:  appEUI[:],
+					NwkSKey: nwkSKey[:],
+				})
+				Convey("Then an already exists error is returned", func() {
+					So(err, ShouldResemble, grpc.Errorf(codes.AlreadyExists, "node-session already exists"))
+				})
+			})
+
+			Convey("When exporting the node-sessions", func() {
+				resp, err := api.ExportNodeSessions(ctx, &ns.ExportNodeSessionsRequest{})
+				So(err, ShouldBeNil)
+
+				Convey("Then the node-session is exported", func() {
+					So(resp.Cursor, ShouldEqual, 0)
+					So(resp.NodeSessions, ShouldHaveLength, 1)
+					So(resp.NodeSessions[0].DevEUI, ShouldResemble, devEUI[:])
+					So(resp.NodeSessions[0].FCntUp, ShouldEqual, 10)
+					So(resp.NodeSessions[0].Rx2DR, ShouldEqual, 3)
+				})
+
+				Convey("When importing the exported node-session and a new node-session", func() {
+					newSess := *resp.NodeSessions[0]
+					newSess.DevEUI = []byte{1, 1, 1, 1, 1, 1, 1, 1}
+
+					importResp, err := api.ImportNodeSessions(ctx, &ns.ImportNodeSessionsRequest{
+						NodeSessions: []*ns.CreateNodeSessionRequest{resp.NodeSessions[0], &newSess},
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then the new node-session is created and the existing one is reported", func() {
+						So(importResp.CreatedCount, ShouldEqual, 1)
+						So(importResp.Errors, ShouldResemble, []*ns.ImportNodeSessionError{
+							{
+								Index:     0,
+								DevEUI:    devEUI[:],
+								ErrorCode: ns.ErrorCode_NODE_SESSION_ALREADY_EXISTS,
+								Error:     "node-session already exists",
+							},
+						})
+
+						_, err := api.GetNodeSession(ctx, &ns.GetNodeSessionRequest{DevEUI: newSess.DevEUI})
+						So(err, ShouldBeNil)
+					})
+				})
+			})
+
+			Convey("When changing the device class of the node-session", func() {
+				_, err := api.ChangeDeviceClass(ctx, &ns.ChangeDeviceClassRequest{
+					DevEUI:      devEUI[:],
+					DeviceClass: ns.DeviceClass_CLASS_A,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the device class change is pending", func() {
+					resp, err := api.GetNodeSession(ctx, &ns.GetNodeSessionRequest{
+						DevEUI: devEUI[:],
+					})
+					So(err, ShouldBeNil)
+					So(resp.DeviceClass, ShouldEqual, ns.DeviceClass_UNKNOWN_CLASS)
+					So(resp.PendingDeviceClass, ShouldEqual, ns.DeviceClass_CLASS_A)
+				})
+
+				Convey("Then pushing data to the node returns an error", func() {
+					_, err := api.PushDataDown(ctx, &ns.PushDataDownRequest{
+						DevEUI: devEUI[:],
+						FCnt:   11,
+						FPort:  1,
+						Data:   []byte{1, 2, 3},
+					})
+					So(grpc.Code(err), ShouldEqual, codes.FailedPrecondition)
+				})
+			})
+
+			Convey("When changing the device class with an invalid class", func() {
+				_, err := api.ChangeDeviceClass(ctx, &ns.ChangeDeviceClassRequest{
+					DevEUI: devEUI[:],
+				})
+				Convey("Then an error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+
+			Convey("When patching the node-session with an invalid field", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:     devEUI[:],
+					AppEUI:     appEUI[:],
+					UpdateMask: []string{"nwkSKey"},
+				})
+				Convey("Then an error is returned", func() {
+					So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "invalid updateMask field: nwkSKey"))
+				})
+			})
+
+			Convey("When patching the node-session with an invalid RX2 data-rate", func() {
+				_, err := api.PatchNodeSession(ctx, &ns.PatchNodeSessionRequest{
+					DevEUI:     devEUI[:],
+					AppEUI:     appEUI[:],
+					UpdateMask: []string{"rx2DR"},
+					Rx2DR:      uint32(len(common.Band.DataRates)),
+				})
+				Convey("Then an error is returned", func() {
+					So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "invalid rx2DR: %d (max dr: %d)", len(common.Band.DataRates), len(common.Band.DataRates)-1))
+				})
+			})
+
 			Convey("When updating the node-session", func() {
 				_, err := api.UpdateNodeSession(ctx, &ns.UpdateNodeSessionRequest{
 					DevAddr:     devAddr[:],
@@ -142,10 +373,26 @@ func TestNetworkServerAPI(t *testing.T) {
 							0,
 							0,
 						},
+						Version: 2,
 					})
 				})
 			})
 
+			Convey("When updating the node-session based on an outdated version", func() {
+				_, err := api.UpdateNodeSession(ctx, &ns.UpdateNodeSessionRequest{
+					DevAddr: devAddr[:],
+					DevEUI:  devEUI[:],
+					AppEUI:  appEUI[:],
+					NwkSKey: nwkSKey[:],
+					FCntUp:  20,
+					Version: 5,
+				})
+
+				Convey("Then a concurrent update error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.Aborted)
+				})
+			})
+
 			Convey("When deleting the node-session", func() {
 				_, err := api.DeleteNodeSession(ctx, &ns.DeleteNodeSessionRequest{
 					DevEUI: devEUI[:],
@@ -166,6 +413,41 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(resp.DevAddr, ShouldHaveLength, 4)
 			})
 
+			Convey("When bulk creating or updating gateways", func() {
+				_, err := api.CreateGateway(ctx, &ns.CreateGatewayRequest{
+					Mac:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					Name: "test-gateway",
+				})
+				So(err, ShouldBeNil)
+
+				resp, err := api.BulkCreateOrUpdateGateways(ctx, &ns.BulkCreateOrUpdateGatewaysRequest{
+					Gateways: []*ns.CreateGatewayRequest{
+						{Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8}, Name: "updated-gateway"},
+						{Mac: []byte{8, 7, 6, 5, 4, 3, 2, 1}, Name: "new-gateway"},
+						{Mac: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Name: "invalid gateway"},
+					},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the result is returned per gateway", func() {
+					So(resp.CreatedCount, ShouldEqual, 1)
+					So(resp.UpdatedCount, ShouldEqual, 1)
+					So(resp.Results, ShouldHaveLength, 3)
+					So(resp.Results[0].Created, ShouldBeFalse)
+					So(resp.Results[0].Error, ShouldEqual, "")
+					So(resp.Results[1].Created, ShouldBeTrue)
+					So(resp.Results[2].Index, ShouldEqual, 2)
+					So(resp.Results[2].Mac, ShouldResemble, []byte{1, 1, 1, 1, 1, 1, 1, 1})
+					So(resp.Results[2].ErrorCode, ShouldEqual, ns.ErrorCode_INVALID_GATEWAY_NAME)
+				})
+
+				Convey("Then the existing gateway has been updated", func() {
+					gw, err := api.GetGateway(ctx, &ns.GetGatewayRequest{Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8}})
+					So(err, ShouldBeNil)
+					So(gw.Name, ShouldEqual, "updated-gateway")
+				})
+			})
+
 			Convey("When creating a gateway", func() {
 				req := ns.CreateGatewayRequest{
 					Mac:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
@@ -174,6 +456,9 @@ func TestNetworkServerAPI(t *testing.T) {
 					Latitude:    1.1234,
 					Longitude:   1.1235,
 					Altitude:    15.5,
+					Region:      "EU",
+					TxPower:     20,
+					Polarity:    ns.Polarity_NORMAL_POLARITY,
 				}
 
 				_, err := api.CreateGateway(ctx, &req)
@@ -188,6 +473,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					So(resp.Latitude, ShouldEqual, req.Latitude)
 					So(resp.Longitude, ShouldEqual, req.Longitude)
 					So(resp.Altitude, ShouldEqual, req.Altitude)
+					So(resp.Region, ShouldEqual, req.Region)
+					So(resp.TxPower, ShouldEqual, req.TxPower)
+					So(resp.CodeRate, ShouldEqual, req.CodeRate)
+					So(resp.Polarity, ShouldEqual, req.Polarity)
 					So(resp.CreatedAt, ShouldNotEqual, "")
 					So(resp.UpdatedAt, ShouldNotEqual, "")
 					So(resp.FirstSeenAt, ShouldEqual, "")
@@ -202,6 +491,8 @@ func TestNetworkServerAPI(t *testing.T) {
 						Latitude:    1.1235,
 						Longitude:   1.1236,
 						Altitude:    15.7,
+						Region:      "NL",
+						CodeRate:    "4/6",
 					}
 					_, err := api.UpdateGateway(ctx, &req)
 					So(err, ShouldBeNil)
@@ -214,6 +505,10 @@ func TestNetworkServerAPI(t *testing.T) {
 					So(resp.Latitude, ShouldEqual, req.Latitude)
 					So(resp.Longitude, ShouldEqual, req.Longitude)
 					So(resp.Altitude, ShouldEqual, req.Altitude)
+					So(resp.Region, ShouldEqual, req.Region)
+					So(resp.TxPower, ShouldEqual, req.TxPower)
+					So(resp.CodeRate, ShouldEqual, req.CodeRate)
+					So(resp.Polarity, ShouldEqual, req.Polarity)
 					So(resp.CreatedAt, ShouldNotEqual, "")
 					So(resp.UpdatedAt, ShouldNotEqual, "")
 					So(resp.FirstSeenAt, ShouldEqual, "")
@@ -289,3 +584,26 @@ func TestNetworkServerAPI(t *testing.T) {
 		})
 	})
 }
+
+func TestPageBounds(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		testTable := []struct {
+			Total, Limit, Offset int
+			First, Last          int
+		}{
+			{10, 0, 0, 0, 10},
+			{10, 3, 0, 0, 3},
+			{10, 3, 9, 9, 10},
+			{10, 3, 20, 10, 10},
+			{10, 0, 4, 4, 10},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				first, last := pageBounds(test.Total, test.Limit, test.Offset)
+				So(first, ShouldEqual, test.First)
+				So(last, ShouldEqual, test.Last)
+			})
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/api/nsv2"
+)
+
+// NetworkServerV2API implements the version 2 of the network-server API
+// as shim on top of the version 1 implementation, translating the
+// requests and responses between both versions. This keeps a single
+// implementation of the business logic, while both versions can be used
+// side by side during a rolling upgrade of the application-servers.
+type NetworkServerV2API struct {
+	v1 *NetworkServerAPI
+}
+
+// NewNetworkServerV2API returns a new NetworkServerV2API using the given
+// version 1 implementation.
+func NewNetworkServerV2API(v1 *NetworkServerAPI) *NetworkServerV2API {
+	return &NetworkServerV2API{
+		v1: v1,
+	}
+}
+
+// EnqueueDeviceQueueItem adds the given downlink payload to the
+// device-queue of the node, or pushes it to the (Class-C) node when
+// immediately is set.
+func (n *NetworkServerV2API) EnqueueDeviceQueueItem(ctx context.Context, req *nsv2.EnqueueDeviceQueueItemRequest) (*nsv2.EnqueueDeviceQueueItemResponse, error) {
+	item := req.Item
+	if item == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "item must not be nil")
+	}
+
+	if item.Immediately {
+		if item.NotBefore != "" {
+			return nil, grpc.Errorf(codes.InvalidArgument, "notBefore can not be combined with immediately")
+		}
+
+		resp, err := n.v1.PushDataDown(ctx, &ns.PushDataDownRequest{
+			DevEUI:    item.DevEUI,
+			Data:      item.Data,
+			Confirmed: item.Confirmed,
+			FPort:     item.FPort,
+			FCnt:      item.FCnt,
+			Reference: item.Reference,
+			Critical:  item.Critical,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &nsv2.EnqueueDeviceQueueItemResponse{Duplicate: resp.Duplicate}, nil
+	}
+
+	_, err := n.v1.EnqueueDeviceQueueItem(ctx, &ns.EnqueueDeviceQueueItemRequest{
+		DevEUI: item.DevEUI,
+		Item: &ns.DeviceQueueItem{
+			Data:      item.Data,
+			Confirmed: item.Confirmed,
+			FPort:     item.FPort,
+			FCnt:      item.FCnt,
+			Critical:  item.Critical,
+			Reference: item.Reference,
+			ExpiresAt: item.ExpiresAt,
+			NotBefore: item.NotBefore,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &nsv2.EnqueueDeviceQueueItemResponse{}, nil
+}
+
+// GetDeviceQueueItems returns the downlink payloads in the device-queue of
+// the node.
+func (n *NetworkServerV2API) GetDeviceQueueItems(ctx context.Context, req *nsv2.GetDeviceQueueItemsRequest) (*nsv2.GetDeviceQueueItemsResponse, error) {
+	resp, err := n.v1.GetDeviceQueueItems(ctx, &ns.GetDeviceQueueItemsRequest{
+		DevEUI: req.DevEUI,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out nsv2.GetDeviceQueueItemsResponse
+	for _, item := range resp.Items {
+		out.Items = append(out.Items, &nsv2.DeviceQueueItem{
+			DevEUI:     req.DevEUI,
+			Data:       item.Data,
+			Confirmed:  item.Confirmed,
+			FPort:      item.FPort,
+			FCnt:       item.FCnt,
+			Critical:   item.Critical,
+			Reference:  item.Reference,
+			EnqueuedAt: item.EnqueuedAt,
+			ExpiresAt:  item.ExpiresAt,
+			NotBefore:  item.NotBefore,
+		})
+	}
+	return &out, nil
+}
+
+// FlushDeviceQueue removes all the downlink payloads from the device-queue
+// of the node.
+func (n *NetworkServerV2API) FlushDeviceQueue(ctx context.Context, req *nsv2.FlushDeviceQueueRequest) (*nsv2.FlushDeviceQueueResponse, error) {
+	_, err := n.v1.FlushDeviceQueue(ctx, &ns.FlushDeviceQueueRequest{
+		DevEUI: req.DevEUI,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &nsv2.FlushDeviceQueueResponse{}, nil
+}
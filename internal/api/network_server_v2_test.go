@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/api/nsv2"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestNetworkServerV2API(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database, a node-session and a v2 api instance", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx := context.Background()
+		v1 := &NetworkServerAPI{ctx: common.Context{RedisPool: p, NetID: [3]byte{1, 2, 3}}}
+		api := NewNetworkServerV2API(v1)
+
+		devEUI := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+		_, err := v1.CreateNodeSession(ctx, &ns.CreateNodeSessionRequest{
+			DevAddr: []byte{6, 2, 3, 4},
+			DevEUI:  devEUI,
+			AppEUI:  []byte{8, 7, 6, 5, 4, 3, 2, 1},
+			NwkSKey: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When enqueueing a device-queue item", func() {
+			_, err := api.EnqueueDeviceQueueItem(ctx, &nsv2.EnqueueDeviceQueueItemRequest{
+				Item: &nsv2.DeviceQueueItem{
+					DevEUI:    devEUI,
+					Data:      []byte{1, 2, 3},
+					FPort:     10,
+					Confirmed: true,
+					Reference: "abc",
+				},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then it is returned by the v1 and v2 api", func() {
+				v1Resp, err := v1.GetDeviceQueueItems(ctx, &ns.GetDeviceQueueItemsRequest{DevEUI: devEUI})
+				So(err, ShouldBeNil)
+				So(v1Resp.Items, ShouldHaveLength, 1)
+
+				resp, err := api.GetDeviceQueueItems(ctx, &nsv2.GetDeviceQueueItemsRequest{DevEUI: devEUI})
+				So(err, ShouldBeNil)
+				So(resp.Items, ShouldResemble, []*nsv2.DeviceQueueItem{
+					{
+						DevEUI:     devEUI,
+						Data:       []byte{1, 2, 3},
+						FPort:      10,
+						Confirmed:  true,
+						Reference:  "abc",
+						EnqueuedAt: v1Resp.Items[0].EnqueuedAt,
+					},
+				})
+			})
+
+			Convey("Then the queue can be flushed", func() {
+				_, err := api.FlushDeviceQueue(ctx, &nsv2.FlushDeviceQueueRequest{DevEUI: devEUI})
+				So(err, ShouldBeNil)
+
+				resp, err := api.GetDeviceQueueItems(ctx, &nsv2.GetDeviceQueueItemsRequest{DevEUI: devEUI})
+				So(err, ShouldBeNil)
+				So(resp.Items, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When enqueueing without item", func() {
+			_, err := api.EnqueueDeviceQueueItem(ctx, &nsv2.EnqueueDeviceQueueItemRequest{})
+
+			Convey("Then an InvalidArgument error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,208 @@
+// Package applayer implements a registry of codecs for the LoRa Alliance
+// application-layer packages (e.g. clock synchronization). Uplinks on the
+// FPort of a registered package are recognized by LoRa Server and can
+// optionally be handled by LoRa Server instead of the application-server.
+package applayer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// downlinkKeyTempl contains per node the (plaintext) downlink payload in
+// response to an application-layer package uplink handled by LoRa Server.
+const downlinkKeyTempl = "lora:ns:applayer:downlink:%s"
+
+// downlinkTTL defines how long the response payload is kept when it could
+// not be sent in response to the uplink.
+const downlinkTTL = time.Minute
+
+// Command contains a single decoded application-layer package command.
+type Command struct {
+	CID     byte   // the command identifier
+	Name    string // the name of the command
+	Payload []byte // the command payload (without CID)
+}
+
+// Codec defines the interface of an application-layer package codec.
+type Codec interface {
+	// DecodeUplink decodes the given (plaintext) uplink payload into
+	// commands.
+	DecodeUplink(data []byte) ([]Command, error)
+}
+
+// Handler defines the interface of a codec which is able to handle the
+// uplinks of its package.
+type Handler interface {
+	// HandleUplink handles the given decoded uplink commands, received at
+	// the given time. It returns the (plaintext) downlink payload to send
+	// in response, or nil.
+	HandleUplink(receivedAt time.Time, commands []Command) ([]byte, error)
+}
+
+// Package describes an application-layer package.
+type Package struct {
+	Name  string
+	FPort uint8
+	Codec Codec
+}
+
+var packages = map[uint8]Package{}
+var handledFPorts = map[uint8]struct{}{}
+
+// Register registers the given package.
+func Register(p Package) {
+	packages[p.FPort] = p
+}
+
+// GetPackage returns the package registered for the given FPort.
+func GetPackage(fPort uint8) (Package, bool) {
+	p, ok := packages[fPort]
+	return p, ok
+}
+
+// MustSetHandledPackages sets the packages which are handled by LoRa
+// Server. The uplinks of these packages are not forwarded to the
+// application-server. Valid names are the names of the registered packages
+// implementing the Handler interface (e.g. clock-sync).
+func MustSetHandledPackages(names []string) {
+	handledFPorts = make(map[uint8]struct{})
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		var found bool
+		for _, p := range packages {
+			if p.Name != name {
+				continue
+			}
+			if _, ok := p.Codec.(Handler); !ok {
+				log.Fatalf("application-layer package '%s' can not be handled by LoRa Server", name)
+			}
+			handledFPorts[p.FPort] = struct{}{}
+			found = true
+		}
+		if !found {
+			log.Fatalf("'%s' is not a valid application-layer package", name)
+		}
+	}
+}
+
+// IsHandledByNetworkServer returns true when the package registered for
+// the given FPort is handled by LoRa Server.
+func IsHandledByNetworkServer(fPort uint8) bool {
+	_, ok := handledFPorts[fPort]
+	return ok
+}
+
+// Downlink contains a downlink payload in response to an application-layer
+// package uplink handled by LoRa Server.
+type Downlink struct {
+	FPort uint8
+	Data  []byte
+}
+
+// SetDownlink stores the given downlink payload for the given node,
+// replacing the previous one (if any).
+func SetDownlink(p *redis.Pool, devEUI lorawan.EUI64, dl Downlink) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(dl); err != nil {
+		return errors.Wrap(err, "gob encode downlink error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(downlinkKeyTempl, devEUI), int64(downlinkTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "set downlink error")
+	}
+	return nil
+}
+
+// PopDownlink returns and removes the downlink payload of the given node.
+// Nil is returned when there is no downlink payload.
+func PopDownlink(p *redis.Pool, devEUI lorawan.EUI64) (*Downlink, error) {
+	key := fmt.Sprintf(downlinkKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get downlink error")
+	}
+
+	b, err := redis.Bytes(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get downlink error")
+	}
+
+	var dl Downlink
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&dl); err != nil {
+		return nil, errors.Wrap(err, "gob decode downlink error")
+	}
+	return &dl, nil
+}
+
+// commandDefinition defines the name and the payload size of a command.
+// For commands with a variable payload size, sizeFunc returns the size
+// given the first payload byte.
+type commandDefinition struct {
+	name     string
+	size     int
+	sizeFunc func(b byte) int
+}
+
+// decodeCommands decodes the given payload into commands, using the given
+// command definitions.
+func decodeCommands(definitions map[byte]commandDefinition, data []byte) ([]Command, error) {
+	var out []Command
+
+	for i := 0; i < len(data); {
+		cid := data[i]
+		def, ok := definitions[cid]
+		if !ok {
+			return nil, fmt.Errorf("applayer: unknown CID: 0x%02x", cid)
+		}
+		i++
+
+		size := def.size
+		if def.sizeFunc != nil {
+			if i >= len(data) {
+				return nil, fmt.Errorf("applayer: %s: not enough bytes", def.name)
+			}
+			size = def.sizeFunc(data[i])
+		}
+		if i+size > len(data) {
+			return nil, fmt.Errorf("applayer: %s: %d bytes expected", def.name, size)
+		}
+
+		out = append(out, Command{
+			CID:     cid,
+			Name:    def.name,
+			Payload: data[i : i+size],
+		})
+		i += size
+	}
+
+	return out, nil
+}
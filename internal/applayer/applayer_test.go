@@ -0,0 +1,95 @@
+package applayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecodeUplink(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Name          string
+			FPort         uint8
+			Data          []byte
+			ExpectedNames []string
+			ExpectedError bool
+		}{
+			{"multicast-setup PackageVersionAns + McGroupSetupAns", MulticastSetupFPort, []byte{0x00, 2, 1, 0x02, 0x01}, []string{"PackageVersionAns", "McGroupSetupAns"}, false},
+			{"multicast-setup McGroupStatusAns with two groups", MulticastSetupFPort, []byte{0x01, 0x23, 0, 1, 2, 3, 4, 1, 5, 6, 7, 8}, []string{"McGroupStatusAns"}, false},
+			{"multicast-setup McClassCSessionAns", MulticastSetupFPort, []byte{0x04, 0x00, 1, 2, 3}, []string{"McClassCSessionAns"}, false},
+			{"multicast-setup McClassCSessionAns with error", MulticastSetupFPort, []byte{0x04, 0x04, 0x03, 0x01}, []string{"McClassCSessionAns", "McGroupDeleteAns"}, false},
+			{"fragmentation FragSessionStatusAns", FragmentationFPort, []byte{0x01, 1, 2, 3, 4}, []string{"FragSessionStatusAns"}, false},
+			{"clock-sync AppTimeReq", ClockSyncFPort, []byte{0x01, 1, 2, 3, 4, 0x10}, []string{"AppTimeReq"}, false},
+			{"clock-sync unknown CID", ClockSyncFPort, []byte{0x03}, nil, true},
+			{"clock-sync truncated AppTimeReq", ClockSyncFPort, []byte{0x01, 1, 2}, nil, true},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %s [%d]", tst.Name, i), func() {
+				pkg, ok := GetPackage(tst.FPort)
+				So(ok, ShouldBeTrue)
+
+				commands, err := pkg.Codec.DecodeUplink(tst.Data)
+				if tst.ExpectedError {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+
+				var names []string
+				for _, cmd := range commands {
+					names = append(names, cmd.Name)
+				}
+				So(names, ShouldResemble, tst.ExpectedNames)
+			})
+		}
+	})
+}
+
+func TestHandledPackages(t *testing.T) {
+	Convey("Given clock-sync is handled by LoRa Server", t, func() {
+		MustSetHandledPackages([]string{"clock-sync", ""})
+		defer MustSetHandledPackages(nil)
+
+		Convey("Then only the clock-sync package is handled", func() {
+			So(IsHandledByNetworkServer(ClockSyncFPort), ShouldBeTrue)
+			So(IsHandledByNetworkServer(FragmentationFPort), ShouldBeFalse)
+			So(IsHandledByNetworkServer(10), ShouldBeFalse)
+		})
+	})
+}
+
+func TestDownlink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then PopDownlink returns nil", func() {
+			dl, err := PopDownlink(p, devEUI)
+			So(err, ShouldBeNil)
+			So(dl, ShouldBeNil)
+		})
+
+		Convey("When setting a downlink", func() {
+			So(SetDownlink(p, devEUI, Downlink{FPort: ClockSyncFPort, Data: []byte{1, 2, 3}}), ShouldBeNil)
+
+			Convey("Then PopDownlink returns it only once", func() {
+				dl, err := PopDownlink(p, devEUI)
+				So(err, ShouldBeNil)
+				So(dl, ShouldResemble, &Downlink{FPort: ClockSyncFPort, Data: []byte{1, 2, 3}})
+
+				dl, err = PopDownlink(p, devEUI)
+				So(err, ShouldBeNil)
+				So(dl, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package applayer
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// ClockSyncFPort defines the FPort of the Application Layer Clock
+// Synchronization package (LoRa Alliance TS003).
+const ClockSyncFPort = 202
+
+const (
+	appTimeReqCID = 0x01
+	appTimeAnsCID = 0x01
+
+	// gpsEpochOffset defines the number of seconds between the unix epoch
+	// and the GPS epoch (1980-01-06T00:00:00Z).
+	gpsEpochOffset = 315964800
+
+	// gpsLeapSeconds defines the number of leap seconds between GPS time
+	// and UTC.
+	gpsLeapSeconds = 18
+)
+
+// clockSyncCommands contains the uplink commands of the Application Layer
+// Clock Synchronization package.
+var clockSyncCommands = map[byte]commandDefinition{
+	0x00:          {name: "PackageVersionAns", size: 2},
+	appTimeReqCID: {name: "AppTimeReq", size: 5},
+	0x02:          {name: "DeviceAppTimePeriodicityAns", size: 5},
+}
+
+// ClockSyncCodec implements the codec of the Application Layer Clock
+// Synchronization package. It is able to handle AppTimeReq commands, as
+// LoRa Server knows the time at which the uplink was received.
+type ClockSyncCodec struct{}
+
+// DecodeUplink decodes the given uplink payload.
+func (c ClockSyncCodec) DecodeUplink(data []byte) ([]Command, error) {
+	return decodeCommands(clockSyncCommands, data)
+}
+
+// HandleUplink responds to AppTimeReq commands with an AppTimeAns
+// containing the correction of the device clock. Note that an AppTimeAns
+// is only sent when the device clock must be corrected or when the device
+// requested an answer.
+func (c ClockSyncCodec) HandleUplink(receivedAt time.Time, commands []Command) ([]byte, error) {
+	var out []byte
+
+	for _, cmd := range commands {
+		if cmd.CID != appTimeReqCID {
+			continue
+		}
+
+		deviceTime := binary.LittleEndian.Uint32(cmd.Payload[0:4])
+		tokenReq := cmd.Payload[4] & 0x0f
+		ansRequired := cmd.Payload[4]&0x10 != 0
+
+		correction := int32(gpsTime(receivedAt) - deviceTime)
+		if correction == 0 && !ansRequired {
+			continue
+		}
+
+		b := make([]byte, 6)
+		b[0] = appTimeAnsCID
+		binary.LittleEndian.PutUint32(b[1:5], uint32(correction))
+		b[5] = tokenReq
+		out = append(out, b...)
+	}
+
+	return out, nil
+}
+
+// gpsTime returns the given time as the number of seconds since the GPS
+// epoch.
+func gpsTime(t time.Time) uint32 {
+	return uint32(t.Unix() - gpsEpochOffset + gpsLeapSeconds)
+}
+
+func init() {
+	Register(Package{
+		Name:  "clock-sync",
+		FPort: ClockSyncFPort,
+		Codec: ClockSyncCodec{},
+	})
+}
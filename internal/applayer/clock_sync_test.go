@@ -0,0 +1,59 @@
+package applayer
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClockSyncHandleUplink(t *testing.T) {
+	Convey("Given an uplink received at 2017-03-14T10:21:33Z", t, func() {
+		receivedAt := time.Date(2017, 3, 14, 10, 21, 33, 0, time.UTC)
+		So(gpsTime(receivedAt), ShouldEqual, 1173522111)
+
+		codec := ClockSyncCodec{}
+
+		Convey("When the device clock is 10 seconds behind", func() {
+			commands, err := codec.DecodeUplink([]byte{0x01, 0xb5, 0x86, 0xf2, 0x45, 0x03})
+			So(err, ShouldBeNil)
+
+			resp, err := codec.HandleUplink(receivedAt, commands)
+			So(err, ShouldBeNil)
+
+			Convey("Then an AppTimeAns with a correction of 10 seconds is returned", func() {
+				So(resp, ShouldResemble, []byte{0x01, 0x0a, 0x00, 0x00, 0x00, 0x03})
+			})
+		})
+
+		Convey("When the device clock is 2 seconds ahead", func() {
+			commands, err := codec.DecodeUplink([]byte{0x01, 0xc1, 0x86, 0xf2, 0x45, 0x01})
+			So(err, ShouldBeNil)
+
+			resp, err := codec.HandleUplink(receivedAt, commands)
+			So(err, ShouldBeNil)
+
+			Convey("Then an AppTimeAns with a correction of -2 seconds is returned", func() {
+				So(resp, ShouldResemble, []byte{0x01, 0xfe, 0xff, 0xff, 0xff, 0x01})
+			})
+		})
+
+		Convey("When the device clock is in sync", func() {
+			Convey("Then no AppTimeAns is returned when not required", func() {
+				commands, err := codec.DecodeUplink([]byte{0x01, 0xbf, 0x86, 0xf2, 0x45, 0x00})
+				So(err, ShouldBeNil)
+				resp, err := codec.HandleUplink(receivedAt, commands)
+				So(err, ShouldBeNil)
+				So(resp, ShouldHaveLength, 0)
+			})
+
+			Convey("Then an AppTimeAns is returned when required", func() {
+				commands, err := codec.DecodeUplink([]byte{0x01, 0xbf, 0x86, 0xf2, 0x45, 0x12})
+				So(err, ShouldBeNil)
+				resp, err := codec.HandleUplink(receivedAt, commands)
+				So(err, ShouldBeNil)
+				So(resp, ShouldResemble, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x02})
+			})
+		})
+	})
+}
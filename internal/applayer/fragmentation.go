@@ -0,0 +1,31 @@
+package applayer
+
+// FragmentationFPort defines the FPort of the Fragmented Data Block
+// Transport package (LoRa Alliance TS004).
+const FragmentationFPort = 201
+
+// fragmentationCommands contains the uplink commands of the Fragmented
+// Data Block Transport package.
+var fragmentationCommands = map[byte]commandDefinition{
+	0x00: {name: "PackageVersionAns", size: 2},
+	0x01: {name: "FragSessionStatusAns", size: 4},
+	0x02: {name: "FragSessionSetupAns", size: 1},
+	0x03: {name: "FragSessionDeleteAns", size: 1},
+}
+
+// FragmentationCodec implements the codec of the Fragmented Data Block
+// Transport package.
+type FragmentationCodec struct{}
+
+// DecodeUplink decodes the given uplink payload.
+func (c FragmentationCodec) DecodeUplink(data []byte) ([]Command, error) {
+	return decodeCommands(fragmentationCommands, data)
+}
+
+func init() {
+	Register(Package{
+		Name:  "fragmentation",
+		FPort: FragmentationFPort,
+		Codec: FragmentationCodec{},
+	})
+}
@@ -0,0 +1,56 @@
+package applayer
+
+// MulticastSetupFPort defines the FPort of the Remote Multicast Setup
+// package (LoRa Alliance TS005).
+const MulticastSetupFPort = 200
+
+// multicastSetupCommands contains the uplink commands of the Remote
+// Multicast Setup package.
+var multicastSetupCommands = map[byte]commandDefinition{
+	0x00: {name: "PackageVersionAns", size: 2},
+	0x01: {name: "McGroupStatusAns", sizeFunc: mcGroupStatusAnsSize},
+	0x02: {name: "McGroupSetupAns", size: 1},
+	0x03: {name: "McGroupDeleteAns", size: 1},
+	0x04: {name: "McClassCSessionAns", sizeFunc: mcSessionAnsSize},
+	0x05: {name: "McClassBSessionAns", sizeFunc: mcSessionAnsSize},
+}
+
+// mcGroupStatusAnsSize returns the size of the McGroupStatusAns payload:
+// the status byte followed by McGroupID + McAddr for each group of the
+// AnsGroupMask.
+func mcGroupStatusAnsSize(status byte) int {
+	size := 1
+	for i := uint(0); i < 4; i++ {
+		if status&(1<<i) != 0 {
+			size += 5
+		}
+	}
+	return size
+}
+
+// mcSessionAnsSize returns the size of the McClassCSessionAns and
+// McClassBSessionAns payload: the TimeToStart field is only present when
+// none of the error bits is set.
+func mcSessionAnsSize(status byte) int {
+	if status&0x3c != 0 {
+		return 1
+	}
+	return 4
+}
+
+// MulticastSetupCodec implements the codec of the Remote Multicast Setup
+// package.
+type MulticastSetupCodec struct{}
+
+// DecodeUplink decodes the given uplink payload.
+func (c MulticastSetupCodec) DecodeUplink(data []byte) ([]Command, error) {
+	return decodeCommands(multicastSetupCommands, data)
+}
+
+func init() {
+	Register(Package{
+		Name:  "multicast-setup",
+		FPort: MulticastSetupFPort,
+		Codec: MulticastSetupCodec{},
+	})
+}
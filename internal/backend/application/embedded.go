@@ -0,0 +1,369 @@
+// Package application implements a minimal application-server which is
+// embedded in LoRa Server, so that a (pilot) deployment can run without an
+// external application-server.
+package application
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// sessionKeyTempl contains per node the DevAddr and AppSKey of the
+// activation handled by the embedded application-server.
+const sessionKeyTempl = "lora:as:embedded:session:%s"
+
+// embedded application-server errors
+var (
+	ErrDeviceDoesNotExist = errors.New("device does not exist")
+	ErrInvalidMIC         = errors.New("invalid MIC")
+	ErrNoSession          = errors.New("no activation for device")
+)
+
+// Device contains the provisioning of an OTAA device handled by the
+// embedded application-server.
+type Device struct {
+	DevEUI lorawan.EUI64     `json:"devEUI"`
+	AppEUI lorawan.EUI64     `json:"appEUI"`
+	AppKey lorawan.AES128Key `json:"appKey"`
+}
+
+// deviceSession contains the activation of a device.
+type deviceSession struct {
+	DevAddr lorawan.DevAddr   `json:"devAddr"`
+	AppSKey lorawan.AES128Key `json:"appSKey"`
+}
+
+// LoadDevices loads the devices from the given JSON file, containing an
+// array of devices (e.g. [{"devEUI": "0102030405060708", "appEUI":
+// "0102030405060708", "appKey": "01020304050607080102030405060708"}]).
+func LoadDevices(path string) ([]Device, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read devices file error")
+	}
+
+	var devices []Device
+	if err := json.Unmarshal(b, &devices); err != nil {
+		return nil, errors.Wrap(err, "unmarshal devices error")
+	}
+	return devices, nil
+}
+
+// EmbeddedApplicationServerClient implements a minimal application-server.
+// It handles the join-requests of the provisioned devices, stores the
+// AppSKey locally (in Redis) and publishes the join, uplink, ACK, error and
+// location events using the given Publisher. It does not implement a
+// downlink queue.
+// When common.AppSKeyKEK is configured, the AppSKey encryption is offloaded
+// to LoRa Server, so that Class-C downlinks can be pushed in plaintext
+// using the PushDataDown API method.
+type EmbeddedApplicationServerClient struct {
+	p         *redis.Pool
+	devices   map[lorawan.EUI64]Device
+	publisher Publisher
+}
+
+// NewEmbeddedApplicationServerClient creates a new
+// EmbeddedApplicationServerClient.
+func NewEmbeddedApplicationServerClient(p *redis.Pool, devices []Device, publisher Publisher) *EmbeddedApplicationServerClient {
+	c := EmbeddedApplicationServerClient{
+		p:         p,
+		devices:   make(map[lorawan.EUI64]Device),
+		publisher: publisher,
+	}
+	for _, d := range devices {
+		c.devices[d.DevEUI] = d
+	}
+	return &c
+}
+
+// JoinRequest validates the join-request and returns the encrypted
+// join-accept using the band defaults.
+func (c *EmbeddedApplicationServerClient) JoinRequest(ctx context.Context, in *as.JoinRequestRequest, opts ...grpc.CallOption) (*as.JoinRequestResponse, error) {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(in.PhyPayload); err != nil {
+		return nil, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	jrPL, ok := phy.MACPayload.(*lorawan.JoinRequestPayload)
+	if !ok {
+		return nil, fmt.Errorf("expected *lorawan.JoinRequestPayload, got: %T", phy.MACPayload)
+	}
+
+	d, ok := c.devices[jrPL.DevEUI]
+	if !ok || d.AppEUI != jrPL.AppEUI {
+		return nil, errors.Wrapf(ErrDeviceDoesNotExist, "dev_eui: %s", jrPL.DevEUI)
+	}
+
+	ok, err := phy.ValidateMIC(d.AppKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "validate mic error")
+	}
+	if !ok {
+		return nil, errors.Wrapf(ErrInvalidMIC, "dev_eui: %s", jrPL.DevEUI)
+	}
+
+	var appNonce [3]byte
+	if _, err := rand.Read(appNonce[:]); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+
+	var netID lorawan.NetID
+	var devAddr lorawan.DevAddr
+	copy(netID[:], in.NetID)
+	copy(devAddr[:], in.DevAddr)
+
+	nwkSKey, err := getSessionKey(0x01, d.AppKey, netID, appNonce, jrPL.DevNonce)
+	if err != nil {
+		return nil, err
+	}
+	appSKey, err := getSessionKey(0x02, d.AppKey, netID, appNonce, jrPL.DevNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	rxDelay := uint8(common.Band.ReceiveDelay1 / time.Second)
+	jaPHY := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinAccept,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.JoinAcceptPayload{
+			AppNonce: appNonce,
+			NetID:    netID,
+			DevAddr:  devAddr,
+			DLSettings: lorawan.DLSettings{
+				RX2DataRate: uint8(common.Band.RX2DataRate),
+			},
+			RXDelay: rxDelay,
+		},
+	}
+	if err := jaPHY.SetMIC(d.AppKey); err != nil {
+		return nil, errors.Wrap(err, "set mic error")
+	}
+	if err := jaPHY.EncryptJoinAcceptPayload(d.AppKey); err != nil {
+		return nil, errors.Wrap(err, "encrypt join-accept error")
+	}
+	b, err := jaPHY.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal join-accept error")
+	}
+
+	if err := c.saveSession(d.DevEUI, deviceSession{DevAddr: devAddr, AppSKey: appSKey}); err != nil {
+		return nil, err
+	}
+
+	resp := as.JoinRequestResponse{
+		PhyPayload:         b,
+		NwkSKey:            nwkSKey[:],
+		RxDelay:            uint32(rxDelay),
+		RxWindow:           as.RXWindow_RX1,
+		Rx2DR:              uint32(common.Band.RX2DataRate),
+		InstallationMargin: 5,
+	}
+
+	if common.AppSKeyKEK != nil {
+		resp.WrappedAppSKey, err = session.WrapAppSKey(&appSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "wrap appskey error")
+		}
+	}
+
+	c.publish(Message{
+		Event:   EventJoin,
+		AppEUI:  d.AppEUI,
+		DevEUI:  d.DevEUI,
+		DevAddr: &devAddr,
+	})
+
+	return &resp, nil
+}
+
+// HandleDataUp decrypts (when not decrypted by LoRa Server) and publishes
+// the received uplink.
+func (c *EmbeddedApplicationServerClient) HandleDataUp(ctx context.Context, in *as.HandleDataUpRequest, opts ...grpc.CallOption) (*as.HandleDataUpResponse, error) {
+	var devEUI, appEUI lorawan.EUI64
+	copy(devEUI[:], in.DevEUI)
+	copy(appEUI[:], in.AppEUI)
+
+	ds, err := c.getSession(devEUI)
+	if err != nil {
+		return nil, err
+	}
+
+	data := in.Data
+	if !in.Decrypted {
+		data, err = lorawan.EncryptFRMPayload(ds.AppSKey, true, ds.DevAddr, in.FCnt, in.Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decrypt frmpayload error")
+		}
+	}
+
+	// the activation is kept as long as the node is active
+	if err := c.saveSession(devEUI, ds); err != nil {
+		return nil, err
+	}
+
+	c.publish(Message{
+		Event:   EventUp,
+		AppEUI:  appEUI,
+		DevEUI:  devEUI,
+		DevAddr: &ds.DevAddr,
+		FCnt:    in.FCnt,
+		FPort:   in.FPort,
+		Data:    data,
+		RXInfo:  in.RxInfo,
+	})
+
+	return &as.HandleDataUpResponse{}, nil
+}
+
+// GetDataDown implements the ApplicationServerClient interface. The
+// embedded application-server does not implement a downlink queue.
+func (c *EmbeddedApplicationServerClient) GetDataDown(ctx context.Context, in *as.GetDataDownRequest, opts ...grpc.CallOption) (*as.GetDataDownResponse, error) {
+	return &as.GetDataDownResponse{}, nil
+}
+
+// HandleDataDownACK publishes the ACK of a confirmed downlink.
+func (c *EmbeddedApplicationServerClient) HandleDataDownACK(ctx context.Context, in *as.HandleDataDownACKRequest, opts ...grpc.CallOption) (*as.HandleDataDownACKResponse, error) {
+	var devEUI, appEUI lorawan.EUI64
+	copy(devEUI[:], in.DevEUI)
+	copy(appEUI[:], in.AppEUI)
+
+	c.publish(Message{
+		Event:     EventACK,
+		AppEUI:    appEUI,
+		DevEUI:    devEUI,
+		FCnt:      in.FCnt,
+		Reference: in.Reference,
+	})
+	return &as.HandleDataDownACKResponse{}, nil
+}
+
+// HandleError publishes the given error.
+func (c *EmbeddedApplicationServerClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*as.HandleErrorResponse, error) {
+	var devEUI, appEUI lorawan.EUI64
+	copy(devEUI[:], in.DevEUI)
+	copy(appEUI[:], in.AppEUI)
+
+	c.publish(Message{
+		Event:  EventError,
+		AppEUI: appEUI,
+		DevEUI: devEUI,
+		Error:  fmt.Sprintf("%s: %s", in.Type, in.Error),
+	})
+	return &as.HandleErrorResponse{}, nil
+}
+
+// HandleGatewayStats implements the ApplicationServerClient interface.
+// Gateway stats are not published by the embedded application-server.
+func (c *EmbeddedApplicationServerClient) HandleGatewayStats(ctx context.Context, in *as.HandleGatewayStatsRequest, opts ...grpc.CallOption) (*as.HandleGatewayStatsResponse, error) {
+	return &as.HandleGatewayStatsResponse{}, nil
+}
+
+// SetDeviceLocation publishes the resolved location of a node.
+func (c *EmbeddedApplicationServerClient) SetDeviceLocation(ctx context.Context, in *as.SetDeviceLocationRequest, opts ...grpc.CallOption) (*as.SetDeviceLocationResponse, error) {
+	var devEUI, appEUI lorawan.EUI64
+	copy(devEUI[:], in.DevEUI)
+	copy(appEUI[:], in.AppEUI)
+
+	c.publish(Message{
+		Event:  EventLocation,
+		AppEUI: appEUI,
+		DevEUI: devEUI,
+		FCnt:   in.FCnt,
+		Location: &Location{
+			Latitude:  in.Latitude,
+			Longitude: in.Longitude,
+			Altitude:  in.Altitude,
+			Accuracy:  in.Accuracy,
+			Source:    in.Source,
+		},
+	})
+	return &as.SetDeviceLocationResponse{}, nil
+}
+
+// publish publishes the given message. Errors are logged as they must not
+// affect the handling of the node.
+func (c *EmbeddedApplicationServerClient) publish(msg Message) {
+	if err := c.publisher.Publish(msg); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": msg.DevEUI,
+			"event":   msg.Event,
+		}).Errorf("publish application event error: %s", err)
+	}
+}
+
+func (c *EmbeddedApplicationServerClient) saveSession(devEUI lorawan.EUI64, ds deviceSession) error {
+	b, err := json.Marshal(ds)
+	if err != nil {
+		return errors.Wrap(err, "marshal session error")
+	}
+
+	conn := c.p.Get()
+	defer conn.Close()
+
+	_, err = conn.Do("PSETEX", fmt.Sprintf(sessionKeyTempl, devEUI), int64(common.NodeSessionTTL/time.Millisecond), b)
+	if err != nil {
+		return errors.Wrap(err, "save session error")
+	}
+	return nil
+}
+
+func (c *EmbeddedApplicationServerClient) getSession(devEUI lorawan.EUI64) (deviceSession, error) {
+	var ds deviceSession
+
+	conn := c.p.Get()
+	defer conn.Close()
+
+	b, err := redis.Bytes(conn.Do("GET", fmt.Sprintf(sessionKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return ds, errors.Wrapf(ErrNoSession, "dev_eui: %s", devEUI)
+		}
+		return ds, errors.Wrap(err, "get session error")
+	}
+
+	if err := json.Unmarshal(b, &ds); err != nil {
+		return ds, errors.Wrap(err, "unmarshal session error")
+	}
+	return ds, nil
+}
+
+// getSessionKey derives the NwkSKey (typ 0x01) or AppSKey (typ 0x02) as
+// aes128_encrypt(AppKey, typ | AppNonce | NetID | DevNonce | pad16), using
+// the (little endian) byte order of the join messages.
+func getSessionKey(typ byte, appKey lorawan.AES128Key, netID lorawan.NetID, appNonce [3]byte, devNonce [2]byte) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	b := make([]byte, 16)
+	b[0] = typ
+	for i := 0; i < 3; i++ {
+		b[1+i] = appNonce[2-i]
+		b[4+i] = netID[2-i]
+	}
+	b[7] = devNonce[1]
+	b[8] = devNonce[0]
+
+	block, err := aes.NewCipher(appKey[:])
+	if err != nil {
+		return key, errors.Wrap(err, "new cipher error")
+	}
+	block.Encrypt(key[:], b)
+	return key, nil
+}
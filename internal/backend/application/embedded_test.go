@@ -0,0 +1,140 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testPublisher struct {
+	messages chan Message
+}
+
+func (p *testPublisher) Publish(msg Message) error {
+	p.messages <- msg
+	return nil
+}
+
+func TestEmbeddedApplicationServerClient(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and an embedded application-server with one device", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		d := Device{
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			AppKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		pub := &testPublisher{messages: make(chan Message, 10)}
+		c := NewEmbeddedApplicationServerClient(p, []Device{d}, pub)
+
+		jrPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{
+				AppEUI:   d.AppEUI,
+				DevEUI:   d.DevEUI,
+				DevNonce: [2]byte{1, 2},
+			},
+		}
+		So(jrPHY.SetMIC(d.AppKey), ShouldBeNil)
+		jrBytes, err := jrPHY.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		Convey("When the join-request has an invalid MIC", func() {
+			jrBytes[len(jrBytes)-1]++
+			_, err := c.JoinRequest(context.Background(), &as.JoinRequestRequest{PhyPayload: jrBytes})
+
+			Convey("Then ErrInvalidMIC is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidMIC)
+			})
+		})
+
+		Convey("When calling HandleDataUp before the device has joined", func() {
+			_, err := c.HandleDataUp(context.Background(), &as.HandleDataUpRequest{DevEUI: d.DevEUI[:]})
+
+			Convey("Then ErrNoSession is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrNoSession)
+			})
+		})
+
+		Convey("When calling JoinRequest", func() {
+			devAddr := lorawan.DevAddr{1, 2, 3, 4}
+			resp, err := c.JoinRequest(context.Background(), &as.JoinRequestRequest{
+				PhyPayload: jrBytes,
+				DevAddr:    devAddr[:],
+				NetID:      []byte{1, 2, 3},
+			})
+			So(err, ShouldBeNil)
+
+			Convey("Then a valid join-accept is returned", func() {
+				var jaPHY lorawan.PHYPayload
+				So(jaPHY.UnmarshalBinary(resp.PhyPayload), ShouldBeNil)
+				So(jaPHY.DecryptJoinAcceptPayload(d.AppKey), ShouldBeNil)
+				ok, err := jaPHY.ValidateMIC(d.AppKey)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				jaPL, ok := jaPHY.MACPayload.(*lorawan.JoinAcceptPayload)
+				So(ok, ShouldBeTrue)
+				So(jaPL.DevAddr, ShouldEqual, devAddr)
+				So(jaPL.NetID, ShouldEqual, lorawan.NetID{1, 2, 3})
+
+				nwkSKey, err := getSessionKey(0x01, d.AppKey, jaPL.NetID, jaPL.AppNonce, [2]byte{1, 2})
+				So(err, ShouldBeNil)
+				So(resp.NwkSKey, ShouldResemble, nwkSKey[:])
+			})
+
+			Convey("Then a join event is published", func() {
+				So(pub.messages, ShouldHaveLength, 1)
+				msg := <-pub.messages
+				So(msg.Event, ShouldEqual, EventJoin)
+				So(msg.DevEUI, ShouldEqual, d.DevEUI)
+				So(*msg.DevAddr, ShouldEqual, devAddr)
+			})
+
+			Convey("When calling HandleDataUp with an encrypted payload", func() {
+				<-pub.messages
+
+				var jaPHY lorawan.PHYPayload
+				So(jaPHY.UnmarshalBinary(resp.PhyPayload), ShouldBeNil)
+				So(jaPHY.DecryptJoinAcceptPayload(d.AppKey), ShouldBeNil)
+				jaPL := jaPHY.MACPayload.(*lorawan.JoinAcceptPayload)
+				appSKey, err := getSessionKey(0x02, d.AppKey, jaPL.NetID, jaPL.AppNonce, [2]byte{1, 2})
+				So(err, ShouldBeNil)
+
+				data, err := lorawan.EncryptFRMPayload(appSKey, true, devAddr, 10, []byte{1, 2, 3, 4})
+				So(err, ShouldBeNil)
+
+				_, err = c.HandleDataUp(context.Background(), &as.HandleDataUpRequest{
+					AppEUI: d.AppEUI[:],
+					DevEUI: d.DevEUI[:],
+					FCnt:   10,
+					FPort:  1,
+					Data:   data,
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the decrypted payload is published", func() {
+					So(pub.messages, ShouldHaveLength, 1)
+					msg := <-pub.messages
+					So(msg.Event, ShouldEqual, EventUp)
+					So(msg.FCnt, ShouldEqual, 10)
+					So(msg.FPort, ShouldEqual, 1)
+					So(msg.Data, ShouldResemble, []byte{1, 2, 3, 4})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/eclipse/paho.mqtt.golang"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/webhook"
+	"github.com/brocaar/lorawan"
+)
+
+// mqttTopicTempl defines the topic to which the messages are published
+// (AppEUI, DevEUI and event).
+const mqttTopicTempl = "application/%s/node/%s/%s"
+
+// Possible events.
+const (
+	EventJoin     = "join"
+	EventUp       = "rx"
+	EventACK      = "ack"
+	EventError    = "error"
+	EventLocation = "location"
+)
+
+// Message contains an event published by the embedded application-server.
+type Message struct {
+	Event     string           `json:"event"`
+	AppEUI    lorawan.EUI64    `json:"appEUI"`
+	DevEUI    lorawan.EUI64    `json:"devEUI"`
+	DevAddr   *lorawan.DevAddr `json:"devAddr,omitempty"`
+	FCnt      uint32           `json:"fCnt"`
+	FPort     uint32           `json:"fPort,omitempty"`
+	Data      []byte           `json:"data,omitempty"` // base64 encoded
+	RXInfo    []*as.RXInfo     `json:"rxInfo,omitempty"`
+	Reference string           `json:"reference,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	Location  *Location        `json:"location,omitempty"`
+}
+
+// Location contains the resolved location of a node.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"`
+	Accuracy  float64 `json:"accuracy"` // in meters
+	Source    string  `json:"source"`
+}
+
+// Publisher defines the interface for publishing the messages of the
+// embedded application-server.
+type Publisher interface {
+	Publish(msg Message) error
+}
+
+// HTTPPublisher publishes the messages as JSON object (HTTP POST) to the
+// given URL.
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a new HTTPPublisher.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish publishes the given message.
+func (p *HTTPPublisher) Publish(msg Message) error {
+	return webhook.Post(p.Client, p.URL, msg)
+}
+
+// MQTTPublisher publishes the messages as JSON object to the
+// application/[AppEUI]/node/[DevEUI]/[event] topic.
+type MQTTPublisher struct {
+	conn mqtt.Client
+}
+
+// NewMQTTPublisher creates a new MQTTPublisher.
+func NewMQTTPublisher(server, username, password string) *MQTTPublisher {
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(server)
+	opts.SetUsername(username)
+	opts.SetPassword(password)
+
+	log.WithField("server", server).Info("backend/application: connecting to mqtt broker")
+	p := MQTTPublisher{conn: mqtt.NewClient(opts)}
+	for {
+		if token := p.conn.Connect(); token.Wait() && token.Error() != nil {
+			log.Errorf("backend/application: connecting to mqtt broker failed, will retry in 2s: %s", token.Error())
+			time.Sleep(2 * time.Second)
+		} else {
+			break
+		}
+	}
+
+	return &p
+}
+
+// Publish publishes the given message.
+func (p *MQTTPublisher) Publish(msg Message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	topic := fmt.Sprintf(mqttTopicTempl, msg.AppEUI, msg.DevEUI, msg.Event)
+	if token := p.conn.Publish(topic, 0, false, b); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "mqtt publish error")
+	}
+	return nil
+}
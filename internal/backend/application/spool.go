@@ -0,0 +1,318 @@
+package application
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/api/as"
+)
+
+// maxRetryBackoff defines the max. time between two retries of a spooled
+// call.
+const maxRetryBackoff = 5 * time.Minute
+
+// Possible dead-letter reasons.
+const (
+	DeadLetterMaxRetries = "max_retries"
+	DeadLetterSpoolFull  = "spool_full"
+	DeadLetterShutdown   = "shutdown"
+)
+
+// DeadLetter contains a call which could not be delivered to the
+// application-server.
+type DeadLetter struct {
+	Time     time.Time   `json:"time"`
+	Method   string      `json:"method"`
+	Reason   string      `json:"reason"`
+	Attempts int         `json:"attempts"`
+	Error    string      `json:"error,omitempty"`
+	Request  interface{} `json:"request"`
+}
+
+// DeadLetterLog appends the dead letters to a file, one JSON encoded
+// DeadLetter per line.
+type DeadLetterLog struct {
+	sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewDeadLetterLog creates a new DeadLetterLog, appending to the given file.
+func NewDeadLetterLog(path string) (*DeadLetterLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "open dead-letter log error")
+	}
+	return &DeadLetterLog{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Write appends the given dead letter to the log.
+func (l *DeadLetterLog) Write(dl DeadLetter) error {
+	l.Lock()
+	defer l.Unlock()
+
+	if err := l.enc.Encode(dl); err != nil {
+		return errors.Wrap(err, "write dead-letter log error")
+	}
+	return nil
+}
+
+// Close closes the log.
+func (l *DeadLetterLog) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	if err := l.f.Sync(); err != nil {
+		return errors.Wrap(err, "sync dead-letter log error")
+	}
+	if err := l.f.Close(); err != nil {
+		return errors.Wrap(err, "close dead-letter log error")
+	}
+	return nil
+}
+
+// spoolItem holds a failed (or queued) call.
+type spoolItem struct {
+	method   string
+	req      interface{}
+	attempts int
+	err      error
+}
+
+// SpoolingApplicationServerClient wraps an application-server client and
+// spools the HandleDataUp and HandleError calls that fail. The spooled
+// calls are retried in order with an exponential backoff. Calls which
+// can't be delivered within the max. number of retries, which don't fit
+// in the spool or which are still spooled on Close are written to the
+// dead-letter log. As the failed calls are spooled, these methods never
+// return an error. The other methods are passed to the wrapped client.
+type SpoolingApplicationServerClient struct {
+	as.ApplicationServerClient
+
+	size       int
+	maxRetries int
+	backoff    time.Duration
+	deadLetter *DeadLetterLog
+
+	mu     sync.Mutex
+	items  []*spoolItem
+	notify chan struct{}
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewSpoolingApplicationServerClient creates a new
+// SpoolingApplicationServerClient, spooling at most the given number of
+// calls. The first retry is done after the given backoff, which is doubled
+// on every following retry. The dead-letter log is optional, dead letters
+// are always logged.
+func NewSpoolingApplicationServerClient(client as.ApplicationServerClient, size, maxRetries int, backoff time.Duration, deadLetter *DeadLetterLog) *SpoolingApplicationServerClient {
+	s := SpoolingApplicationServerClient{
+		ApplicationServerClient: client,
+		size:                    size,
+		maxRetries:              maxRetries,
+		backoff:                 backoff,
+		deadLetter:              deadLetter,
+		notify:                  make(chan struct{}, 1),
+		done:                    make(chan struct{}),
+	}
+
+	s.wg.Add(1)
+	go s.retryLoop()
+
+	return &s
+}
+
+// HandleDataUp publishes the uplink data to the application-server. When
+// this fails (or when older calls are still spooled), the call is spooled.
+func (s *SpoolingApplicationServerClient) HandleDataUp(ctx context.Context, in *as.HandleDataUpRequest, opts ...grpc.CallOption) (*as.HandleDataUpResponse, error) {
+	if s.Pending() == 0 {
+		resp, err := s.ApplicationServerClient.HandleDataUp(ctx, in, opts...)
+		if err == nil {
+			return resp, nil
+		}
+		s.spool(&spoolItem{method: "HandleDataUp", req: in, attempts: 1, err: err})
+	} else {
+		s.spool(&spoolItem{method: "HandleDataUp", req: in})
+	}
+	return &as.HandleDataUpResponse{}, nil
+}
+
+// HandleError publishes the error to the application-server. When this
+// fails (or when older calls are still spooled), the call is spooled.
+func (s *SpoolingApplicationServerClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*as.HandleErrorResponse, error) {
+	if s.Pending() == 0 {
+		resp, err := s.ApplicationServerClient.HandleError(ctx, in, opts...)
+		if err == nil {
+			return resp, nil
+		}
+		s.spool(&spoolItem{method: "HandleError", req: in, attempts: 1, err: err})
+	} else {
+		s.spool(&spoolItem{method: "HandleError", req: in})
+	}
+	return &as.HandleErrorResponse{}, nil
+}
+
+// Pending returns the number of spooled calls.
+func (s *SpoolingApplicationServerClient) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// Close stops the retries and writes the calls which are still spooled to
+// the dead-letter log.
+func (s *SpoolingApplicationServerClient) Close() {
+	close(s.done)
+	s.wg.Wait()
+
+	s.mu.Lock()
+	items := s.items
+	s.items = nil
+	s.mu.Unlock()
+
+	for _, item := range items {
+		s.writeDeadLetter(item, DeadLetterShutdown)
+	}
+}
+
+func (s *SpoolingApplicationServerClient) spool(item *spoolItem) {
+	s.mu.Lock()
+	if len(s.items) >= s.size {
+		s.mu.Unlock()
+		s.writeDeadLetter(item, DeadLetterSpoolFull)
+		return
+	}
+	s.items = append(s.items, item)
+	s.mu.Unlock()
+
+	log.WithFields(log.Fields{
+		"method": item.method,
+		"error":  item.err,
+	}).Warning("application-server call spooled")
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// head returns the oldest spooled call or nil when the spool is empty.
+func (s *SpoolingApplicationServerClient) head() *spoolItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.items) == 0 {
+		return nil
+	}
+	return s.items[0]
+}
+
+// pop removes the oldest spooled call.
+func (s *SpoolingApplicationServerClient) pop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = s.items[1:]
+}
+
+// retryBackoff returns the time to wait before retrying a call that
+// already failed the given number of times.
+func (s *SpoolingApplicationServerClient) retryBackoff(attempts int) time.Duration {
+	if attempts == 0 {
+		return 0
+	}
+	backoff := s.backoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+func (s *SpoolingApplicationServerClient) retryLoop() {
+	defer s.wg.Done()
+
+	for {
+		item := s.head()
+		if item == nil {
+			select {
+			case <-s.notify:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+
+		select {
+		case <-time.After(s.retryBackoff(item.attempts)):
+		case <-s.done:
+			return
+		}
+
+		err := s.send(item)
+		if err == nil {
+			s.pop()
+			log.WithFields(log.Fields{
+				"method":   item.method,
+				"attempts": item.attempts + 1,
+			}).Info("spooled application-server call delivered")
+			continue
+		}
+
+		item.attempts++
+		item.err = err
+		if item.attempts >= s.maxRetries {
+			s.pop()
+			s.writeDeadLetter(item, DeadLetterMaxRetries)
+		}
+	}
+}
+
+func (s *SpoolingApplicationServerClient) send(item *spoolItem) error {
+	var err error
+	switch req := item.req.(type) {
+	case *as.HandleDataUpRequest:
+		_, err = s.ApplicationServerClient.HandleDataUp(context.Background(), req)
+	case *as.HandleErrorRequest:
+		_, err = s.ApplicationServerClient.HandleError(context.Background(), req)
+	default:
+		err = errors.Errorf("unexpected request type %T", item.req)
+	}
+	return err
+}
+
+// writeDeadLetter logs the given call and writes it to the dead-letter log
+// (when configured).
+func (s *SpoolingApplicationServerClient) writeDeadLetter(item *spoolItem, reason string) {
+	dl := DeadLetter{
+		Time:     time.Now(),
+		Method:   item.method,
+		Reason:   reason,
+		Attempts: item.attempts,
+		Request:  item.req,
+	}
+	if item.err != nil {
+		dl.Error = item.err.Error()
+	}
+
+	log.WithFields(log.Fields{
+		"method":   dl.Method,
+		"reason":   dl.Reason,
+		"attempts": dl.Attempts,
+	}).Errorf("application-server call dead-lettered: %s", dl.Error)
+
+	if s.deadLetter != nil {
+		if err := s.deadLetter.Write(dl); err != nil {
+			log.Errorf("write dead letter error: %s", err)
+		}
+	}
+}
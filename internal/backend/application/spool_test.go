@@ -0,0 +1,130 @@
+package application
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpoolingApplicationServerClient(t *testing.T) {
+	Convey("Given a SpoolingApplicationServerClient with spool size 2 and a dead-letter log", t, func() {
+		dir, err := ioutil.TempDir("", "spool")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "dead-letter.log")
+		deadLetter, err := NewDeadLetterLog(path)
+		So(err, ShouldBeNil)
+
+		appClient := test.NewApplicationClient()
+		s := NewSpoolingApplicationServerClient(appClient, 2, 3, 10*time.Millisecond, deadLetter)
+
+		Convey("When the application-server is available", func() {
+			_, err := s.HandleDataUp(context.Background(), &as.HandleDataUpRequest{FCnt: 1})
+			So(err, ShouldBeNil)
+
+			Convey("Then the call is published directly", func() {
+				So(s.Pending(), ShouldEqual, 0)
+				So(appClient.HandleDataUpChan, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the application-server fails", func() {
+			appClient.HandleDataUpErr = errors.New("unavailable")
+			appClient.HandleErrorErr = errors.New("unavailable")
+
+			_, err := s.HandleDataUp(context.Background(), &as.HandleDataUpRequest{FCnt: 1})
+			So(err, ShouldBeNil)
+			_, err = s.HandleError(context.Background(), &as.HandleErrorRequest{Error: "boom"})
+			So(err, ShouldBeNil)
+			_, err = s.HandleDataUp(context.Background(), &as.HandleDataUpRequest{FCnt: 2})
+			So(err, ShouldBeNil)
+
+			Convey("Then the calls fitting in the spool are spooled", func() {
+				So(s.Pending(), ShouldEqual, 2)
+			})
+
+			Convey("When the application-server recovers", func() {
+				appClient.HandleDataUpErr = nil
+				appClient.HandleErrorErr = nil
+				time.Sleep(100 * time.Millisecond)
+
+				Convey("Then the spooled calls are delivered in order", func() {
+					So(s.Pending(), ShouldEqual, 0)
+					So(appClient.HandleDataUpChan, ShouldHaveLength, 1)
+					So((<-appClient.HandleDataUpChan).FCnt, ShouldEqual, 1)
+					So(appClient.HandleErrorChan, ShouldHaveLength, 1)
+				})
+
+				Convey("Then the call that did not fit in the spool is dead-lettered", func() {
+					s.Close()
+					So(deadLetter.Close(), ShouldBeNil)
+
+					dls := readDeadLetters(path)
+					So(dls, ShouldHaveLength, 1)
+					So(dls[0].Method, ShouldEqual, "HandleDataUp")
+					So(dls[0].Reason, ShouldEqual, DeadLetterSpoolFull)
+				})
+			})
+
+			Convey("When the max. number of retries has been reached", func() {
+				time.Sleep(200 * time.Millisecond)
+				s.Close()
+				So(deadLetter.Close(), ShouldBeNil)
+
+				Convey("Then all calls have been dead-lettered", func() {
+					So(s.Pending(), ShouldEqual, 0)
+
+					dls := readDeadLetters(path)
+					So(dls, ShouldHaveLength, 3)
+					So(dls[1].Reason, ShouldEqual, DeadLetterMaxRetries)
+					So(dls[1].Attempts, ShouldEqual, 3)
+					So(dls[1].Error, ShouldEqual, "unavailable")
+				})
+			})
+		})
+	})
+}
+
+func TestRetryBackoff(t *testing.T) {
+	Convey("Given a SpoolingApplicationServerClient with a 1s backoff", t, func() {
+		s := SpoolingApplicationServerClient{backoff: time.Second}
+
+		Convey("Then the backoff doubles on every retry up to the max.", func() {
+			So(s.retryBackoff(0), ShouldEqual, 0)
+			So(s.retryBackoff(1), ShouldEqual, time.Second)
+			So(s.retryBackoff(3), ShouldEqual, 4*time.Second)
+			So(s.retryBackoff(20), ShouldEqual, maxRetryBackoff)
+		})
+	})
+}
+
+func readDeadLetters(path string) []DeadLetter {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	var out []DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &dl); err != nil {
+			panic(err)
+		}
+		out = append(out, dl)
+	}
+	return out
+}
@@ -8,5 +8,7 @@ type Gateway interface {
 	SendTXPacket(gw.TXPacket) error              // send the given packet to the gateway
 	RXPacketChan() chan gw.RXPacket              // channel containing the received packets
 	StatsPacketChan() chan gw.GatewayStatsPacket // channel containing the received gateway stats
+	TXAckChan() chan gw.TXAck                    // channel containing the received tx acknowledgements
+	EventChan() chan gw.GatewayEvent             // channel containing the received gateway events
 	Close() error                                // close the gateway backend.
 }
@@ -0,0 +1,212 @@
+// Package concentrator implements a gateway backend driving a locally
+// attached (SX130x) concentrator through a HAL bridge. It is intended for
+// automated hardware-in-the-loop protocol tests and must not be used in
+// production.
+package concentrator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/api/gw"
+)
+
+// ErrBridgeClosed is returned by the HAL when the bridge has been closed.
+var ErrBridgeClosed = errors.New("backend/concentrator: bridge closed")
+
+// Backend implements a gateway backend for a single, locally attached
+// concentrator. The received frames are reported using the configured
+// gateway MAC.
+type Backend struct {
+	hal HAL
+	mac lorawan.EUI64
+
+	rxPacketChan    chan gw.RXPacket
+	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	eventChan       chan gw.GatewayEvent
+
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewBackend creates a new Backend using the given HAL bridge. mac is the
+// MAC of the gateway as known by LoRa Server.
+func NewBackend(hal HAL, mac lorawan.EUI64) *Backend {
+	b := Backend{
+		hal:             hal,
+		mac:             mac,
+		rxPacketChan:    make(chan gw.RXPacket),
+		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		eventChan:       make(chan gw.GatewayEvent),
+	}
+
+	log.WithField("mac", mac).Warning("backend/concentrator: hardware-in-the-loop test mode enabled, do not use in production")
+
+	go b.receiveFrames()
+	return &b
+}
+
+// receiveFrames forwards the frames received by the concentrator, until
+// the bridge or the backend has been closed.
+func (b *Backend) receiveFrames() {
+	for {
+		frame, err := b.hal.Receive()
+		if err != nil {
+			if err == ErrBridgeClosed {
+				log.Warning("backend/concentrator: bridge closed")
+				return
+			}
+			log.Errorf("backend/concentrator: receive frame error: %s", err)
+			continue
+		}
+
+		if frame.CRCStatus == -1 {
+			log.WithField("frequency", frame.Frequency).Info("backend/concentrator: frame with invalid crc ignored")
+			continue
+		}
+
+		rxPacket, err := b.getRXPacket(frame, time.Now())
+		if err != nil {
+			log.Errorf("backend/concentrator: %s", err)
+			continue
+		}
+
+		if !b.track() {
+			return
+		}
+		b.rxPacketChan <- rxPacket
+		b.wg.Done()
+	}
+}
+
+// track registers a message to be sent on one of the channels, it returns
+// false when the backend has been closed.
+func (b *Backend) track() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return false
+	}
+	b.wg.Add(1)
+	return true
+}
+
+// getRXPacket returns the RXPacket for the given frame.
+func (b *Backend) getRXPacket(frame RXFrame, receivedAt time.Time) (gw.RXPacket, error) {
+	rxPacket := gw.RXPacket{
+		RXInfo: gw.RXInfo{
+			MAC:       b.mac,
+			Time:      receivedAt,
+			Timestamp: frame.Timestamp,
+			Frequency: frame.Frequency,
+			Channel:   frame.Channel,
+			RFChain:   frame.RFChain,
+			CRCStatus: frame.CRCStatus,
+			CodeRate:  frame.CodeRate,
+			RSSI:      frame.RSSI,
+			LoRaSNR:   frame.LoRaSNR,
+			Size:      len(frame.Payload),
+			DataRate:  frame.DataRate,
+
+			FrequencyOffset: frame.FrequencyOffset,
+		},
+	}
+	if err := rxPacket.PHYPayload.UnmarshalBinary(frame.Payload); err != nil {
+		return rxPacket, fmt.Errorf("unmarshal phypayload error: %s", err)
+	}
+	return rxPacket, nil
+}
+
+// SendTXPacket sends the given packet to the concentrator. As the HAL
+// bridge does not report the scheduling result, the TXAck is reported once
+// the frame has been handed over to the bridge.
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	if txPacket.TXInfo.MAC != b.mac {
+		return fmt.Errorf("backend/concentrator: unknown gateway %s", txPacket.TXInfo.MAC)
+	}
+
+	payload, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("backend/concentrator: marshal phypayload error: %s", err)
+	}
+
+	// the polarity is inverted by default for LoRa modulation
+	iPol := txPacket.TXInfo.DataRate.Modulation == band.LoRaModulation
+	if txPacket.TXInfo.IPol != nil {
+		iPol = *txPacket.TXInfo.IPol
+	}
+
+	if err := b.hal.Send(TXFrame{
+		Immediately: txPacket.TXInfo.Immediately,
+		Timestamp:   txPacket.TXInfo.Timestamp,
+		Frequency:   txPacket.TXInfo.Frequency,
+		Power:       txPacket.TXInfo.Power,
+		DataRate:    txPacket.TXInfo.DataRate,
+		CodeRate:    txPacket.TXInfo.CodeRate,
+		IPol:        iPol,
+		Payload:     payload,
+	}); err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"mac":   b.mac,
+		"token": txPacket.Token,
+	}).Info("backend/concentrator: tx frame sent to bridge")
+
+	if b.track() {
+		go func() {
+			b.txAckChan <- gw.TXAck{MAC: b.mac, Token: txPacket.Token}
+			b.wg.Done()
+		}()
+	}
+	return nil
+}
+
+// Close closes the backend one-way (concentrator to backend), the HAL
+// bridge is kept open so that the pending downlinks can still be sent.
+func (b *Backend) Close() error {
+	log.Info("backend/concentrator: closing backend")
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	close(b.rxPacketChan)
+	close(b.statsPacketChan)
+	close(b.txAckChan)
+	close(b.eventChan)
+	return nil
+}
+
+// RXPacketChan returns the RXPacket channel.
+func (b *Backend) RXPacketChan() chan gw.RXPacket {
+	return b.rxPacketChan
+}
+
+// StatsPacketChan returns the gateway stats channel. No stats are reported
+// by the concentrator.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
+	return b.statsPacketChan
+}
+
+// TXAckChan returns the TXAck channel.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// EventChan returns the gateway events channel.
+func (b *Backend) EventChan() chan gw.GatewayEvent {
+	return b.eventChan
+}
@@ -0,0 +1,109 @@
+package concentrator
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+)
+
+func TestBackend(t *testing.T) {
+	Convey("Given a Backend connected to a HAL bridge", t, func() {
+		conn, bridge := net.Pipe()
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		backend := NewBackend(newSocketHAL(conn), mac)
+		bridgeReader := bufio.NewReader(bridge)
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:    10,
+				},
+			},
+		}
+		phyBytes, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+		dr := band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125}
+
+		Convey("When the bridge reports a received frame", func() {
+			b, err := json.Marshal(RXFrame{
+				Timestamp: 12345,
+				Frequency: 868100000,
+				CRCStatus: 1,
+				RSSI:      -50,
+				LoRaSNR:   5.5,
+				DataRate:  dr,
+				Payload:   phyBytes,
+			})
+			So(err, ShouldBeNil)
+			go bridge.Write(append(b, '\n'))
+
+			Convey("Then the RXPacket is reported using the gateway MAC", func() {
+				rxPacket := <-backend.RXPacketChan()
+				So(rxPacket.RXInfo.MAC, ShouldEqual, mac)
+				So(rxPacket.RXInfo.Timestamp, ShouldEqual, 12345)
+				So(rxPacket.RXInfo.Frequency, ShouldEqual, 868100000)
+				So(rxPacket.RXInfo.Size, ShouldEqual, len(phyBytes))
+				So(rxPacket.RXInfo.DataRate, ShouldResemble, dr)
+				So(rxPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.UnconfirmedDataUp)
+			})
+		})
+
+		Convey("When sending a TXPacket", func() {
+			frameChan := make(chan TXFrame)
+			go func() {
+				line, _ := bridgeReader.ReadBytes('\n')
+				var frame TXFrame
+				json.Unmarshal(line, &frame)
+				frameChan <- frame
+			}()
+
+			So(backend.SendTXPacket(gw.TXPacket{
+				Token: 123,
+				TXInfo: gw.TXInfo{
+					MAC:       mac,
+					Timestamp: 1012345,
+					Frequency: 868100000,
+					Power:     14,
+					DataRate:  dr,
+					CodeRate:  "4/5",
+				},
+				PHYPayload: phy,
+			}), ShouldBeNil)
+
+			Convey("Then the TXFrame is sent to the bridge with inverted polarity", func() {
+				So(<-frameChan, ShouldResemble, TXFrame{
+					Timestamp: 1012345,
+					Frequency: 868100000,
+					Power:     14,
+					DataRate:  dr,
+					CodeRate:  "4/5",
+					IPol:      true,
+					Payload:   phyBytes,
+				})
+
+				Convey("Then the TXAck is reported", func() {
+					So(<-backend.TXAckChan(), ShouldResemble, gw.TXAck{MAC: mac, Token: 123})
+				})
+			})
+		})
+
+		Convey("Then sending a TXPacket to an other gateway returns an error", func() {
+			So(backend.SendTXPacket(gw.TXPacket{
+				TXInfo:     gw.TXInfo{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}},
+				PHYPayload: phy,
+			}), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,127 @@
+package concentrator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+
+	"github.com/brocaar/lorawan/band"
+)
+
+// RXFrame contains a frame received by the concentrator, as reported by
+// the HAL (see lgw_pkt_rx_s of the SX130x HAL).
+type RXFrame struct {
+	Timestamp uint32        `json:"timestamp"` // concentrator internal counter (microseconds)
+	Frequency int           `json:"frequency"` // frequency in Hz
+	Channel   int           `json:"channel"`   // IF channel
+	RFChain   int           `json:"rfChain"`   // RF chain
+	CRCStatus int           `json:"crcStatus"` // 1 = OK, -1 = fail, 0 = no CRC
+	CodeRate  string        `json:"codeRate"`  // ECC code rate
+	RSSI      int           `json:"rssi"`      // RSSI in dBm
+	LoRaSNR   float64       `json:"loRaSNR"`   // LoRa signal-to-noise ratio in dB
+	DataRate  band.DataRate `json:"dataRate"`  // RX datarate (either LoRa or FSK)
+	Payload   []byte        `json:"payload"`   // PHYPayload
+
+	// FrequencyOffset contains the frequency error (Hz) of the frame, when
+	// reported by the concentrator (e.g. SX1302).
+	FrequencyOffset int `json:"frequencyOffset,omitempty"`
+}
+
+// TXFrame contains a frame to send by the concentrator (see lgw_pkt_tx_s of
+// the SX130x HAL).
+type TXFrame struct {
+	Immediately bool          `json:"immediately"` // send the frame immediately (ignore Timestamp)
+	Timestamp   uint32        `json:"timestamp"`   // concentrator internal counter (microseconds) at which to send the frame
+	Frequency   int           `json:"frequency"`   // frequency in Hz
+	Power       int           `json:"power"`       // TX power in dBm
+	DataRate    band.DataRate `json:"dataRate"`    // TX datarate (either LoRa or FSK)
+	CodeRate    string        `json:"codeRate"`    // ECC code rate
+	IPol        bool          `json:"iPol"`        // invert the polarity
+	Payload     []byte        `json:"payload"`     // PHYPayload
+}
+
+// HAL is the interface of the bridge to the HAL of a locally attached
+// concentrator.
+type HAL interface {
+	Receive() (RXFrame, error) // blocks until a frame has been received
+	Send(TXFrame) error        // send (or schedule) the given frame
+	Close() error              // close the bridge
+}
+
+// SocketHAL implements a HAL bridge talking json over a TCP or Unix socket
+// to a process driving the concentrator. Each message is a single line
+// containing a json encoded RXFrame (bridge to LoRa Server) or TXFrame
+// (LoRa Server to bridge).
+type SocketHAL struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewSocketHAL connects to the HAL bridge at the given url, e.g.
+// tcp://127.0.0.1:1780 or unix:///var/run/lora-hal.sock.
+func NewSocketHAL(bridgeURL string) (*SocketHAL, error) {
+	u, err := url.Parse(bridgeURL)
+	if err != nil {
+		return nil, fmt.Errorf("backend/concentrator: parse bridge url error: %s", err)
+	}
+
+	var conn net.Conn
+	switch u.Scheme {
+	case "tcp":
+		conn, err = net.Dial("tcp", u.Host)
+	case "unix":
+		conn, err = net.Dial("unix", u.Path)
+	default:
+		return nil, fmt.Errorf("backend/concentrator: unsupported bridge url scheme '%s'", u.Scheme)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backend/concentrator: connect to bridge error: %s", err)
+	}
+
+	return newSocketHAL(conn), nil
+}
+
+func newSocketHAL(conn net.Conn) *SocketHAL {
+	return &SocketHAL{
+		conn:    conn,
+		scanner: bufio.NewScanner(conn),
+		encoder: json.NewEncoder(conn),
+	}
+}
+
+// Receive returns the next frame received by the concentrator.
+func (h *SocketHAL) Receive() (RXFrame, error) {
+	var frame RXFrame
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			return frame, fmt.Errorf("backend/concentrator: read from bridge error: %s", err)
+		}
+		return frame, ErrBridgeClosed
+	}
+	if err := json.Unmarshal(h.scanner.Bytes(), &frame); err != nil {
+		return frame, fmt.Errorf("backend/concentrator: unmarshal rx frame error: %s", err)
+	}
+	return frame, nil
+}
+
+// Send sends the given frame to the bridge.
+func (h *SocketHAL) Send(frame TXFrame) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := h.encoder.Encode(frame); err != nil {
+		return fmt.Errorf("backend/concentrator: write to bridge error: %s", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the bridge.
+func (h *SocketHAL) Close() error {
+	return h.conn.Close()
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/api/nc"
+)
+
+// batch holds the pending items of a single notification type.
+type batch struct {
+	sync.Mutex
+	name  string
+	items []interface{}
+	send  func(items []interface{}) error
+}
+
+// add adds the given item to the batch and returns the pending items when
+// the batch has reached the given size (which must then be flushed by the
+// caller).
+func (b *batch) add(item interface{}, size int) []interface{} {
+	b.Lock()
+	defer b.Unlock()
+
+	b.items = append(b.items, item)
+	if len(b.items) < size {
+		return nil
+	}
+	items := b.items
+	b.items = nil
+	return items
+}
+
+// take returns and removes all pending items.
+func (b *batch) take() []interface{} {
+	b.Lock()
+	defer b.Unlock()
+
+	items := b.items
+	b.items = nil
+	return items
+}
+
+// flush sends the given items to the network-controller. Errors are logged.
+func (b *batch) flush(items []interface{}) {
+	if len(items) == 0 {
+		return
+	}
+	if err := b.send(items); err != nil {
+		log.WithFields(log.Fields{
+			"method": b.name,
+			"count":  len(items),
+		}).Errorf("send batch to network-controller error: %s", err)
+	}
+}
+
+// BatchingNetworkControllerClient wraps a network-controller client and
+// publishes the rx-info, mac-command and error notifications in batches
+// (using the Handle...Batch methods). A batch is flushed when it has
+// reached the batch size or when the flush interval has elapsed. As the
+// notifications are sent asynchronously, the Handle... methods never
+// return an error. The other methods are passed to the wrapped client.
+type BatchingNetworkControllerClient struct {
+	nc.NetworkControllerClient
+
+	size        int
+	rxInfo      batch
+	macCommands batch
+	errors      batch
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewBatchingNetworkControllerClient creates a new
+// BatchingNetworkControllerClient, flushing the batches when they have
+// reached the given size or every given interval.
+func NewBatchingNetworkControllerClient(client nc.NetworkControllerClient, size int, interval time.Duration) *BatchingNetworkControllerClient {
+	b := BatchingNetworkControllerClient{
+		NetworkControllerClient: client,
+		size:                    size,
+		done:                    make(chan struct{}),
+	}
+
+	b.rxInfo = batch{
+		name: "HandleRXInfoBatch",
+		send: func(items []interface{}) error {
+			var req nc.HandleRXInfoBatchRequest
+			for _, item := range items {
+				req.Items = append(req.Items, item.(*nc.HandleRXInfoRequest))
+			}
+			_, err := client.HandleRXInfoBatch(context.Background(), &req)
+			return err
+		},
+	}
+
+	b.macCommands = batch{
+		name: "HandleDataUpMACCommandBatch",
+		send: func(items []interface{}) error {
+			var req nc.HandleDataUpMACCommandBatchRequest
+			for _, item := range items {
+				req.Items = append(req.Items, item.(*nc.HandleDataUpMACCommandRequest))
+			}
+			_, err := client.HandleDataUpMACCommandBatch(context.Background(), &req)
+			return err
+		},
+	}
+
+	b.errors = batch{
+		name: "HandleErrorBatch",
+		send: func(items []interface{}) error {
+			var req nc.HandleErrorBatchRequest
+			for _, item := range items {
+				req.Items = append(req.Items, item.(*nc.HandleErrorRequest))
+			}
+			_, err := client.HandleErrorBatch(context.Background(), &req)
+			return err
+		},
+	}
+
+	b.wg.Add(1)
+	go b.flushLoop(interval)
+
+	return &b
+}
+
+// HandleRXInfo adds the rx-info to the batch.
+func (b *BatchingNetworkControllerClient) HandleRXInfo(ctx context.Context, in *nc.HandleRXInfoRequest, opts ...grpc.CallOption) (*nc.HandleRXInfoResponse, error) {
+	b.add(&b.rxInfo, in)
+	return &nc.HandleRXInfoResponse{}, nil
+}
+
+// HandleDataUpMACCommand adds the mac-command to the batch.
+func (b *BatchingNetworkControllerClient) HandleDataUpMACCommand(ctx context.Context, in *nc.HandleDataUpMACCommandRequest, opts ...grpc.CallOption) (*nc.HandleDataUpMACCommandResponse, error) {
+	b.add(&b.macCommands, in)
+	return &nc.HandleDataUpMACCommandResponse{}, nil
+}
+
+// HandleError adds the error to the batch.
+func (b *BatchingNetworkControllerClient) HandleError(ctx context.Context, in *nc.HandleErrorRequest, opts ...grpc.CallOption) (*nc.HandleErrorResponse, error) {
+	b.add(&b.errors, in)
+	return &nc.HandleErrorResponse{}, nil
+}
+
+// Close stops the flush interval and flushes the pending batches.
+func (b *BatchingNetworkControllerClient) Close() {
+	close(b.done)
+	b.wg.Wait()
+	b.flushAll()
+}
+
+func (b *BatchingNetworkControllerClient) add(bt *batch, item interface{}) {
+	if items := bt.add(item, b.size); items != nil {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			bt.flush(items)
+		}()
+	}
+}
+
+func (b *BatchingNetworkControllerClient) flushLoop(interval time.Duration) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.flushAll()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+func (b *BatchingNetworkControllerClient) flushAll() {
+	for _, bt := range []*batch{&b.rxInfo, &b.macCommands, &b.errors} {
+		bt.flush(bt.take())
+	}
+}
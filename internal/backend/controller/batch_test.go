@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBatchingNetworkControllerClient(t *testing.T) {
+	Convey("Given a BatchingNetworkControllerClient with batch size 2", t, func() {
+		client := test.NewNetworkControllerClient()
+		b := NewBatchingNetworkControllerClient(client, 2, time.Hour)
+
+		Convey("When publishing two rx-info notifications", func() {
+			for _, devEUI := range [][]byte{{1}, {2}} {
+				_, err := b.HandleRXInfo(context.Background(), &nc.HandleRXInfoRequest{DevEUI: devEUI})
+				So(err, ShouldBeNil)
+			}
+			b.Close()
+
+			Convey("Then a single batch was published in the correct order", func() {
+				So(client.HandleRXInfoChan, ShouldHaveLength, 0)
+				So(client.HandleRXInfoBatchChan, ShouldHaveLength, 1)
+				req := <-client.HandleRXInfoBatchChan
+				So(req.Items, ShouldHaveLength, 2)
+				So(req.Items[0].DevEUI, ShouldResemble, []byte{1})
+				So(req.Items[1].DevEUI, ShouldResemble, []byte{2})
+			})
+		})
+
+		Convey("When publishing a single error and mac-command notification", func() {
+			_, err := b.HandleError(context.Background(), &nc.HandleErrorRequest{Error: "boom"})
+			So(err, ShouldBeNil)
+			_, err = b.HandleDataUpMACCommand(context.Background(), &nc.HandleDataUpMACCommandRequest{Data: []byte{2}})
+			So(err, ShouldBeNil)
+
+			Convey("Then nothing is published until the client is closed", func() {
+				So(client.HandleErrorBatchChan, ShouldHaveLength, 0)
+				So(client.HandleDataUpMACCommandBatchChan, ShouldHaveLength, 0)
+
+				b.Close()
+				So(client.HandleErrorBatchChan, ShouldHaveLength, 1)
+				So(client.HandleDataUpMACCommandBatchChan, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When publishing a gateway status", func() {
+			_, err := b.HandleGatewayStatus(context.Background(), &nc.HandleGatewayStatusRequest{})
+			So(err, ShouldBeNil)
+			b.Close()
+
+			Convey("Then it is published directly", func() {
+				So(client.HandleGatewayStatusChan, ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a BatchingNetworkControllerClient with a flush interval", t, func() {
+		client := test.NewNetworkControllerClient()
+		b := NewBatchingNetworkControllerClient(client, 100, 10*time.Millisecond)
+		defer b.Close()
+
+		Convey("When publishing a rx-info notification", func() {
+			_, err := b.HandleRXInfo(context.Background(), &nc.HandleRXInfoRequest{})
+			So(err, ShouldBeNil)
+
+			Convey("Then it is published after the flush interval", func() {
+				req := <-client.HandleRXInfoBatchChan
+				So(req.Items, ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -22,3 +22,31 @@ func (n *NopNetworkControllerClient) HandleDataUpMACCommand(ctx context.Context,
 func (n *NopNetworkControllerClient) HandleError(ctx context.Context, in *nc.HandleErrorRequest, opts ...grpc.CallOption) (*nc.HandleErrorResponse, error) {
 	return &nc.HandleErrorResponse{}, nil
 }
+
+func (n *NopNetworkControllerClient) HandleGatewayStatus(ctx context.Context, in *nc.HandleGatewayStatusRequest, opts ...grpc.CallOption) (*nc.HandleGatewayStatusResponse, error) {
+	return &nc.HandleGatewayStatusResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleGatewayEvent(ctx context.Context, in *nc.HandleGatewayEventRequest, opts ...grpc.CallOption) (*nc.HandleGatewayEventResponse, error) {
+	return &nc.HandleGatewayEventResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleRXInfoBatch(ctx context.Context, in *nc.HandleRXInfoBatchRequest, opts ...grpc.CallOption) (*nc.HandleRXInfoBatchResponse, error) {
+	return &nc.HandleRXInfoBatchResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleDataUpMACCommandBatch(ctx context.Context, in *nc.HandleDataUpMACCommandBatchRequest, opts ...grpc.CallOption) (*nc.HandleDataUpMACCommandBatchResponse, error) {
+	return &nc.HandleDataUpMACCommandBatchResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleErrorBatch(ctx context.Context, in *nc.HandleErrorBatchRequest, opts ...grpc.CallOption) (*nc.HandleErrorBatchResponse, error) {
+	return &nc.HandleErrorBatchResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleSLAAlert(ctx context.Context, in *nc.HandleSLAAlertRequest, opts ...grpc.CallOption) (*nc.HandleSLAAlertResponse, error) {
+	return &nc.HandleSLAAlertResponse{}, nil
+}
+
+func (n *NopNetworkControllerClient) HandleFirstJoin(ctx context.Context, in *nc.HandleFirstJoinRequest, opts ...grpc.CallOption) (*nc.HandleFirstJoinResponse, error) {
+	return &nc.HandleFirstJoinResponse{}, nil
+}
@@ -1,49 +1,176 @@
 package gateway
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/backend"
+	"github.com/brocaar/lorawan"
 	"github.com/eclipse/paho.mqtt.golang"
 	"github.com/garyburd/redigo/redis"
 )
 
-const rxTopic = "gateway/+/rx"
-const statsTopic = "gateway/+/stats"
 const uplinkLockTTL = time.Millisecond * 500
 const statsLockTTL = time.Millisecond * 500
+const ackLockTTL = time.Millisecond * 500
+const eventLockTTL = time.Millisecond * 500
+
+// Config contains the configuration of the MQTT gateway backend.
+type Config struct {
+	Server       string
+	Username     string
+	Password     string
+	ClientID     string // assigned by the broker when empty (requires CleanSession)
+	CleanSession bool
+
+	// CACert, TLSCert and TLSKey contain the paths of the CA certificate
+	// used to verify the broker and of the TLS client certificate and key
+	// (all optional).
+	CACert  string
+	TLSCert string
+	TLSKey  string
+
+	// SubscribeQOS defines the QoS of the subscriptions and PublishQOS the
+	// QoS of the published tx packets.
+	SubscribeQOS byte
+	PublishQOS   byte
+
+	// The topics (filters) to which the backend subscribes. As the MAC of
+	// the gateway is taken from the message, these may contain wildcards
+	// and shared subscription prefixes.
+	RXTopic    string
+	StatsTopic string
+	AckTopic   string
+	EventTopic string
+
+	// TXTopicTemplate defines the topic to which the tx packets are
+	// published (text/template, with the MAC of the gateway as .MAC).
+	TXTopicTemplate string
+
+	// RXBufferSize defines the number of received packets buffered, see
+	// NewBackend.
+	RXBufferSize int
+
+	// MaxReconnectInterval defines the max. time between two reconnection
+	// attempts after the connection has been lost (0 = the mqtt client
+	// default).
+	MaxReconnectInterval time.Duration
+
+	// TXBacklogSize defines the max. number of tx packets kept while the
+	// connection is lost, which are published once reconnected (0 = tx
+	// packets fail while disconnected). When the backlog is full, the
+	// oldest tx packet is dropped. TXBacklogTTL defines after which
+	// duration a tx packet in the backlog is dropped.
+	TXBacklogSize int
+	TXBacklogTTL  time.Duration
+}
+
+// DefaultConfig returns the default configuration, using the topics of the
+// LoRa Gateway Bridge.
+func DefaultConfig() Config {
+	return Config{
+		Server:          "tcp://localhost:1883",
+		CleanSession:    true,
+		SubscribeQOS:    2,
+		RXTopic:         "gateway/+/rx",
+		StatsTopic:      "gateway/+/stats",
+		AckTopic:        "gateway/+/ack",
+		EventTopic:      "gateway/+/event",
+		TXTopicTemplate: "gateway/{{ .MAC }}/tx",
+		TXBacklogSize:   100,
+		TXBacklogTTL:    5 * time.Second,
+	}
+}
+
+// backlogItem contains a tx packet kept while the connection is lost.
+type backlogItem struct {
+	mac     lorawan.EUI64
+	token   uint16
+	topic   string
+	payload []byte
+	addedAt time.Time
+}
 
 // Backend implements a MQTT pub-sub backend.
 type Backend struct {
+	conf            Config
+	txTopicTemplate *template.Template
 	conn            mqtt.Client
 	rxPacketChan    chan gw.RXPacket
 	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	eventChan       chan gw.GatewayEvent
 	wg              sync.WaitGroup
 	redisPool       *redis.Pool
+	schemas         schemaStore
+
+	// connected is set to 1 while connected to the broker (use atomic
+	// operations). The mqtt client itself reports to be connected while
+	// reconnecting, silently dropping QoS 0 messages.
+	connected int32
+
+	backlogMu sync.Mutex
+	backlog   []backlogItem
+
+	// droppedRXPacketCount contains the number of rx packets dropped
+	// because the rx packet buffer was full (use atomic operations)
+	droppedRXPacketCount uint64
 }
 
-// NewBackend creates a new Backend.
-func NewBackend(p *redis.Pool, server, username, password string) (backend.Gateway, error) {
+// NewBackend creates a new Backend. The received packets are buffered
+// (conf.RXBufferSize), so that the MQTT client is not blocked by a
+// temporarily slow consumer. When the buffer is full, the oldest packet is
+// dropped. When the buffer size is 0, sending a received packet blocks until
+// it has been consumed.
+func NewBackend(p *redis.Pool, conf Config) (backend.Gateway, error) {
+	txTopicTemplate, err := template.New("tx").Parse(conf.TXTopicTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("backend/gateway: parse tx topic template error: %s", err)
+	}
+
 	b := Backend{
-		rxPacketChan:    make(chan gw.RXPacket),
+		conf:            conf,
+		txTopicTemplate: txTopicTemplate,
+		rxPacketChan:    make(chan gw.RXPacket, conf.RXBufferSize),
 		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		eventChan:       make(chan gw.GatewayEvent),
 		redisPool:       p,
 	}
 
 	opts := mqtt.NewClientOptions()
-	opts.AddBroker(server)
-	opts.SetUsername(username)
-	opts.SetPassword(password)
+	opts.AddBroker(conf.Server)
+	opts.SetUsername(conf.Username)
+	opts.SetPassword(conf.Password)
+	opts.SetClientID(conf.ClientID)
+	opts.SetCleanSession(conf.CleanSession)
+	opts.SetAutoReconnect(true)
+	if conf.MaxReconnectInterval > 0 {
+		opts.SetMaxReconnectInterval(conf.MaxReconnectInterval)
+	}
 	opts.SetOnConnectHandler(b.onConnected)
 	opts.SetConnectionLostHandler(b.onConnectionLost)
 
-	log.WithField("server", server).Info("backend/gateway: connecting to mqtt broker")
+	if conf.CACert != "" || conf.TLSCert != "" || conf.TLSKey != "" {
+		tlsConfig, err := newTLSConfig(conf.CACert, conf.TLSCert, conf.TLSKey)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	log.WithField("server", conf.Server).Info("backend/gateway: connecting to mqtt broker")
 	b.conn = mqtt.NewClient(opts)
 	for {
 		if token := b.conn.Connect(); token.Wait() && token.Error() != nil {
@@ -63,18 +190,18 @@ func NewBackend(p *redis.Pool, server, username, password string) (backend.Gatew
 // still packets to send back to the gateway).
 func (b *Backend) Close() error {
 	log.Info("backend/gateway: closing backend")
-	log.WithField("topic", rxTopic).Info("backend/gateway: unsubscribing from rx topic")
-	if token := b.conn.Unsubscribe(rxTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", rxTopic, token.Error())
-	}
-	log.WithField("topic", statsTopic).Info("backend/gateway: unsubscribing from stats topic")
-	if token := b.conn.Unsubscribe(statsTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", statsTopic, token.Error())
+	for _, topic := range []string{b.conf.RXTopic, b.conf.StatsTopic, b.conf.AckTopic, b.conf.EventTopic} {
+		log.WithField("topic", topic).Info("backend/gateway: unsubscribing from topic")
+		if token := b.conn.Unsubscribe(topic); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", topic, token.Error())
+		}
 	}
 	log.Info("backend/gateway: handling last messages")
 	b.wg.Wait()
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
+	close(b.txAckChan)
+	close(b.eventChan)
 	return nil
 }
 
@@ -83,40 +210,143 @@ func (b *Backend) RXPacketChan() chan gw.RXPacket {
 	return b.rxPacketChan
 }
 
+// DroppedRXPacketCount returns the number of rx packets dropped because
+// the rx packet buffer was full.
+func (b *Backend) DroppedRXPacketCount() uint64 {
+	return atomic.LoadUint64(&b.droppedRXPacketCount)
+}
+
 // StatsPacketChan returns the gateway stats channel.
 func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
-// SendTXPacket sends the given TXPacket to the gateway.
+// TXAckChan returns the TXAck channel.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// EventChan returns the gateway events channel.
+func (b *Backend) EventChan() chan gw.GatewayEvent {
+	return b.eventChan
+}
+
+// SendTXPacket sends the given TXPacket to the gateway. The packet is
+// encoded using the schema version of the last message received from the
+// gateway. While the connection is lost, the packet is added to the tx
+// backlog (when enabled).
 func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
-	bytes, err := json.Marshal(txPacket)
+	payload, err := encodeTXPacket(txPacket, b.schemas.get(txPacket.TXInfo.MAC))
 	if err != nil {
 		return fmt.Errorf("backend/gateway: tx packet marshal error: %s", err)
 	}
 
-	topic := fmt.Sprintf("gateway/%s/tx", txPacket.TXInfo.MAC)
-	log.WithField("topic", topic).Info("backend/gateway: publishing tx packet")
+	topic, err := b.getTXTopic(txPacket.TXInfo.MAC)
+	if err != nil {
+		return err
+	}
+
+	item := backlogItem{
+		mac:     txPacket.TXInfo.MAC,
+		token:   txPacket.Token,
+		topic:   topic,
+		payload: payload,
+		addedAt: time.Now(),
+	}
+
+	if atomic.LoadInt32(&b.connected) == 0 && b.conf.TXBacklogSize > 0 {
+		b.addToBacklog(item)
+		return nil
+	}
 
-	if token := b.conn.Publish(topic, 0, false, bytes); token.Wait() && token.Error() != nil {
+	return b.publishTXPacket(item)
+}
+
+// getTXTopic returns the topic to which the tx packets of the given gateway
+// are published.
+func (b *Backend) getTXTopic(mac lorawan.EUI64) (string, error) {
+	var topic bytes.Buffer
+	if err := b.txTopicTemplate.Execute(&topic, struct{ MAC lorawan.EUI64 }{mac}); err != nil {
+		return "", fmt.Errorf("backend/gateway: execute tx topic template error: %s", err)
+	}
+	return topic.String(), nil
+}
+
+func (b *Backend) publishTXPacket(item backlogItem) error {
+	log.WithFields(log.Fields{
+		"topic": item.topic,
+		"token": item.token,
+	}).Info("backend/gateway: publishing tx packet")
+
+	if token := b.conn.Publish(item.topic, b.conf.PublishQOS, false, item.payload); token.Wait() && token.Error() != nil {
 		return fmt.Errorf("backend/gateway: publish tx packet failed: %s", token.Error())
 	}
 	return nil
 }
 
+// addToBacklog adds the given tx packet to the backlog. When the backlog is
+// full, the oldest tx packet is dropped.
+func (b *Backend) addToBacklog(item backlogItem) {
+	b.backlogMu.Lock()
+	defer b.backlogMu.Unlock()
+
+	if len(b.backlog) >= b.conf.TXBacklogSize {
+		dropped := b.backlog[0]
+		b.backlog = b.backlog[1:]
+		log.WithFields(log.Fields{
+			"mac":   dropped.mac,
+			"token": dropped.token,
+		}).Warning("backend/gateway: tx backlog full, oldest tx packet dropped")
+	}
+	b.backlog = append(b.backlog, item)
+
+	log.WithFields(log.Fields{
+		"mac":          item.mac,
+		"token":        item.token,
+		"backlog_size": len(b.backlog),
+	}).Warning("backend/gateway: not connected to mqtt broker, tx packet added to backlog")
+}
+
+// publishBacklog publishes the tx packets of the backlog, dropping the
+// expired ones.
+func (b *Backend) publishBacklog() {
+	b.backlogMu.Lock()
+	backlog := b.backlog
+	b.backlog = nil
+	b.backlogMu.Unlock()
+
+	for _, item := range backlog {
+		if time.Since(item.addedAt) > b.conf.TXBacklogTTL {
+			log.WithFields(log.Fields{
+				"mac":   item.mac,
+				"token": item.token,
+			}).Warning("backend/gateway: tx packet in backlog expired")
+			continue
+		}
+
+		if err := b.publishTXPacket(item); err != nil {
+			log.WithFields(log.Fields{
+				"mac":   item.mac,
+				"token": item.token,
+			}).Error(err)
+		}
+	}
+}
+
 func (b *Backend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.wg.Add(1)
 	defer b.wg.Done()
 
 	log.Info("backend/gateway: rx packet received")
 
-	var rxPacket gw.RXPacket
-	if err := json.Unmarshal(msg.Payload(), &rxPacket); err != nil {
+	rxPacket, version, err := decodeRXPacket(msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal rx packet error: %s", err)
 		return
 	}
+	b.schemas.set(rxPacket.RXInfo.MAC, version)
 
 	// Since with MQTT all subscribers will receive the uplink messages sent
 	// by all the gatewyas, the first instance receiving the message must lock it,
@@ -141,20 +371,59 @@ func (b *Backend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 		return
 	}
 
-	b.rxPacketChan <- rxPacket
+	b.sendRXPacket(rxPacket)
+}
+
+// sendRXPacket sends the given rx packet to the rx packet channel. When the
+// buffer of the channel is full, the oldest packet is dropped to make room,
+// so that a temporarily overloaded consumer sheds load instead of blocking
+// the MQTT client.
+func (b *Backend) sendRXPacket(rxPacket gw.RXPacket) {
+	if cap(b.rxPacketChan) == 0 {
+		b.rxPacketChan <- rxPacket
+		return
+	}
+
+	select {
+	case b.rxPacketChan <- rxPacket:
+		return
+	default:
+	}
+
+	select {
+	case dropped := <-b.rxPacketChan:
+		b.logDroppedRXPacket(dropped)
+	default:
+	}
+
+	select {
+	case b.rxPacketChan <- rxPacket:
+	default:
+		// the buffer has been filled concurrently
+		b.logDroppedRXPacket(rxPacket)
+	}
+}
+
+func (b *Backend) logDroppedRXPacket(rxPacket gw.RXPacket) {
+	count := atomic.AddUint64(&b.droppedRXPacketCount, 1)
+	log.WithFields(log.Fields{
+		"mac":           rxPacket.RXInfo.MAC,
+		"dropped_count": count,
+	}).Warning("backend/gateway: rx packet buffer full, oldest rx packet dropped")
 }
 
 func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.wg.Add(1)
 	defer b.wg.Done()
 
-	var statsPacket gw.GatewayStatsPacket
-	if err := json.Unmarshal(msg.Payload(), &statsPacket); err != nil {
+	statsPacket, version, err := decodeStatsPacket(msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal stats packet error: %s", err)
 		return
 	}
+	b.schemas.set(statsPacket.MAC, version)
 
 	// Since with MQTT all subscribers will receive the uplink messages sent
 	// by all the gatewyas, the first instance receiving the message must lock it,
@@ -165,7 +434,7 @@ func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	redisConn := b.redisPool.Get()
 	defer redisConn.Close()
 
-	_, err := redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
 	if err != nil {
 		if err == redis.ErrNil {
 			// the payload is already being processed by an other instance
@@ -175,33 +444,146 @@ func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 		return
 	}
 
-	log.WithField("mac", statsPacket.MAC).Info("backend/gateway: gateway stats packet received")
+	log.WithFields(log.Fields{
+		"mac":    statsPacket.MAC,
+		"schema": version,
+	}).Info("backend/gateway: gateway stats packet received")
 	b.statsPacketChan <- statsPacket
 }
 
+func (b *Backend) ackPacketHandler(c mqtt.Client, msg mqtt.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var ack gw.TXAck
+	if err := json.Unmarshal(msg.Payload(), &ack); err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).Errorf("backend/gateway: unmarshal tx ack error: %s", err)
+		return
+	}
+
+	// Since with MQTT all subscribers will receive the acks sent by all the
+	// gateways, the first instance receiving the ack must lock it, so that
+	// other instances can ignore the same ack.
+	key := fmt.Sprintf("lora:ns:ack:lock:%s:%d", ack.MAC, ack.Token)
+	redisConn := b.redisPool.Get()
+	defer redisConn.Close()
+
+	_, err := redis.String(redisConn.Do("SET", key, "lock", "PX", int64(ackLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the ack is already being processed by an other instance
+			return
+		}
+		log.Errorf("backend/gateway: acquire ack lock error: %s", err)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"mac":   ack.MAC,
+		"token": ack.Token,
+	}).Info("backend/gateway: tx ack received")
+	b.txAckChan <- ack
+}
+
+func (b *Backend) eventHandler(c mqtt.Client, msg mqtt.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	var event gw.GatewayEvent
+	if err := json.Unmarshal(msg.Payload(), &event); err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).Errorf("backend/gateway: unmarshal gateway event error: %s", err)
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	// Since with MQTT all subscribers will receive the events sent by all
+	// the gateways, the first instance receiving the event must lock it, so
+	// that other instances can ignore the same event. As the event has no
+	// unique id, the gw mac + base64 encoded payload is used.
+	key := fmt.Sprintf("lora:ns:gw:event:lock:%s:%s", event.MAC, base64.StdEncoding.EncodeToString(msg.Payload()))
+	redisConn := b.redisPool.Get()
+	defer redisConn.Close()
+
+	_, err := redis.String(redisConn.Do("SET", key, "lock", "PX", int64(eventLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the event is already being processed by an other instance
+			return
+		}
+		log.Errorf("backend/gateway: acquire event lock error: %s", err)
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"mac":  event.MAC,
+		"type": event.Type,
+	}).Info("backend/gateway: gateway event received")
+	b.eventChan <- event
+}
+
 func (b *Backend) onConnected(c mqtt.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
-	for {
-		log.WithField("topic", rxTopic).Info("backend/gateway: subscribing to rx topic")
-		if token := b.conn.Subscribe(rxTopic, 2, b.rxPacketHandler); token.Wait() && token.Error() != nil {
-			log.WithField("topic", rxTopic).Errorf("backend/gateway: subscribe error: %s", token.Error())
-			time.Sleep(time.Second)
-			continue
-		}
-		break
+
+	subscriptions := []struct {
+		topic   string
+		handler mqtt.MessageHandler
+	}{
+		{b.conf.RXTopic, b.rxPacketHandler},
+		{b.conf.StatsTopic, b.statsPacketHandler},
+		{b.conf.AckTopic, b.ackPacketHandler},
+		{b.conf.EventTopic, b.eventHandler},
 	}
 
-	for {
-		log.WithField("topic", statsTopic).Info("backend/gateway: subscribing to stats topic")
-		if token := b.conn.Subscribe(statsTopic, 2, b.statsPacketHandler); token.Wait() && token.Error() != nil {
-			log.WithField("topic", statsTopic).Errorf("backend/gateway: subscribe error: %s", token.Error())
-			time.Sleep(time.Second)
-			continue
+	for _, sub := range subscriptions {
+		for {
+			log.WithField("topic", sub.topic).Info("backend/gateway: subscribing to topic")
+			if token := b.conn.Subscribe(sub.topic, b.conf.SubscribeQOS, sub.handler); token.Wait() && token.Error() != nil {
+				log.WithField("topic", sub.topic).Errorf("backend/gateway: subscribe error: %s", token.Error())
+				time.Sleep(time.Second)
+				continue
+			}
+			break
 		}
-		break
 	}
+
+	atomic.StoreInt32(&b.connected, 1)
+	b.publishBacklog()
 }
 
 func (b *Backend) onConnectionLost(c mqtt.Client, reason error) {
+	atomic.StoreInt32(&b.connected, 0)
 	log.Errorf("backend/gateway: mqtt connection error: %s", reason)
 }
+
+// newTLSConfig returns the TLS configuration using the given CA certificate
+// and client certificate and key (all optional).
+func newTLSConfig(caCert, tlsCert, tlsKey string) (*tls.Config, error) {
+	var tlsConfig tls.Config
+
+	if caCert != "" {
+		rawCACert, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("backend/gateway: read ca certificate error: %s", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(rawCACert) {
+			return nil, fmt.Errorf("backend/gateway: no certificates found in %s", caCert)
+		}
+	}
+
+	if tlsCert != "" || tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			return nil, fmt.Errorf("backend/gateway: load key-pair error: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &tlsConfig, nil
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"encoding/json"
 	"testing"
+	"text/template"
 	"time"
 
 	"github.com/joriwind/loraserver/api/gw"
@@ -26,7 +27,11 @@ func TestBackend(t *testing.T) {
 
 		Convey("Given a new Backend", func() {
 			test.MustFlushRedis(r)
-			backend, err := NewBackend(r, conf.Server, conf.Username, conf.Password)
+			backendConf := DefaultConfig()
+			backendConf.Server = conf.Server
+			backendConf.Username = conf.Username
+			backendConf.Password = conf.Password
+			backend, err := NewBackend(r, backendConf)
 			So(err, ShouldBeNil)
 			defer backend.Close()
 			time.Sleep(time.Millisecond * 100) // give the backend some time to subscribe to the topic
@@ -57,6 +62,7 @@ func TestBackend(t *testing.T) {
 
 				Convey("Given a TXPacket", func() {
 					txPacket := gw.TXPacket{
+						Token: 1234,
 						TXInfo: gw.TXInfo{
 							MAC: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
 						},
@@ -122,6 +128,67 @@ func TestBackend(t *testing.T) {
 					})
 				})
 
+				Convey("Given a TXAck", func() {
+					ack := gw.TXAck{
+						MAC:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						Token: 1234,
+						Error: "TOO_LATE",
+					}
+
+					Convey("When sending it twice", func() {
+						for i := 0; i < 2; i++ {
+							b, err := json.Marshal(ack)
+							So(err, ShouldBeNil)
+							token := c.Publish("gateway/0102030405060708/ack", 0, false, b)
+							token.Wait()
+							So(token.Error(), ShouldBeNil)
+						}
+
+						Convey("Then it is received only once by the backend", func() {
+							So(<-backend.TXAckChan(), ShouldResemble, ack)
+
+							var received bool
+							select {
+							case <-backend.TXAckChan():
+								received = true
+							case <-time.After(time.Millisecond * 100):
+							}
+							So(received, ShouldBeFalse)
+						})
+					})
+				})
+
+				Convey("Given a GatewayEvent", func() {
+					event := gw.GatewayEvent{
+						MAC:         lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+						Time:        time.Now().UTC().Truncate(time.Millisecond),
+						Type:        gw.EventGPSLost,
+						Description: "no pps signal",
+					}
+
+					Convey("When sending it twice", func() {
+						for i := 0; i < 2; i++ {
+							b, err := json.Marshal(event)
+							So(err, ShouldBeNil)
+							token := c.Publish("gateway/0102030405060708/event", 0, false, b)
+							token.Wait()
+							So(token.Error(), ShouldBeNil)
+						}
+
+						Convey("Then it is received only once by the backend", func() {
+							So(<-backend.EventChan(), ShouldResemble, event)
+
+							var received bool
+							select {
+							case <-backend.EventChan():
+								received = true
+							case <-time.After(time.Millisecond * 100):
+							}
+							So(received, ShouldBeFalse)
+						})
+					})
+				})
+
 				Convey("Given an RXPacket", func() {
 					rxPacket := gw.RXPacket{
 						RXInfo: gw.RXInfo{
@@ -195,3 +262,85 @@ func TestBackend(t *testing.T) {
 		})
 	})
 }
+
+func TestSendRXPacket(t *testing.T) {
+	Convey("Given a Backend with a rx packet buffer of 2", t, func() {
+		b := Backend{
+			rxPacketChan: make(chan gw.RXPacket, 2),
+		}
+
+		Convey("When sending 3 rx packets", func() {
+			for i := 1; i <= 3; i++ {
+				b.sendRXPacket(gw.RXPacket{RXInfo: gw.RXInfo{Timestamp: uint32(i)}})
+			}
+
+			Convey("Then the oldest packet has been dropped", func() {
+				So(b.DroppedRXPacketCount(), ShouldEqual, 1)
+				So((<-b.rxPacketChan).RXInfo.Timestamp, ShouldEqual, 2)
+				So((<-b.rxPacketChan).RXInfo.Timestamp, ShouldEqual, 3)
+			})
+		})
+	})
+}
+
+func TestTXBacklog(t *testing.T) {
+	Convey("Given a disconnected Backend with a tx backlog of 2", t, func() {
+		conf := DefaultConfig()
+		conf.TXTopicTemplate = "eu868/gateway/{{ .MAC }}/command/down"
+		conf.TXBacklogSize = 2
+
+		b, err := newTestBackend(conf)
+		So(err, ShouldBeNil)
+
+		txPacket := gw.TXPacket{
+			TXInfo: gw.TXInfo{
+				MAC: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+
+		Convey("When sending 3 tx packets", func() {
+			for i := 1; i <= 3; i++ {
+				txPacket.Token = uint16(i)
+				So(b.SendTXPacket(txPacket), ShouldBeNil)
+			}
+
+			Convey("Then the last 2 tx packets are in the backlog, using the tx topic template", func() {
+				So(b.backlog, ShouldHaveLength, 2)
+				So(b.backlog[0].token, ShouldEqual, 2)
+				So(b.backlog[1].token, ShouldEqual, 3)
+				So(b.backlog[0].topic, ShouldEqual, "eu868/gateway/0102030405060708/command/down")
+			})
+		})
+
+		Convey("When the backlog is disabled", func() {
+			b.conf.TXBacklogSize = 0
+
+			Convey("Then sending a tx packet fails", func() {
+				So(b.SendTXPacket(txPacket), ShouldNotBeNil)
+				So(b.backlog, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+// newTestBackend returns a Backend using the given config of which the mqtt
+// client is not connected.
+func newTestBackend(conf Config) (*Backend, error) {
+	txTopicTemplate, err := template.New("tx").Parse(conf.TXTopicTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backend{
+		conf:            conf,
+		txTopicTemplate: txTopicTemplate,
+		conn:            mqtt.NewClient(mqtt.NewClientOptions().AddBroker(getConfig().Server)),
+	}, nil
+}
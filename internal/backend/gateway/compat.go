@@ -0,0 +1,286 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// schemaVersion defines the version of the gateway-bridge message schema.
+type schemaVersion int
+
+// Supported gateway-bridge message schema versions.
+const (
+	// schemaCurrent uses the field names as defined by the gw package
+	// (e.g. rxInfo.timestamp and rxInfo.frequency in Hz).
+	schemaCurrent schemaVersion = iota
+
+	// schemaLegacy uses the packet-forwarder field names (e.g. rxInfo.tmst
+	// and rxInfo.freq in MHz).
+	schemaLegacy
+)
+
+func (v schemaVersion) String() string {
+	switch v {
+	case schemaLegacy:
+		return "legacy"
+	default:
+		return "current"
+	}
+}
+
+// schemaStore keeps per gateway the schema version of the last received
+// message, so that tx packets are sent using the same schema.
+type schemaStore struct {
+	sync.RWMutex
+	versions map[lorawan.EUI64]schemaVersion
+}
+
+func (s *schemaStore) set(mac lorawan.EUI64, v schemaVersion) {
+	s.Lock()
+	defer s.Unlock()
+	if s.versions == nil {
+		s.versions = make(map[lorawan.EUI64]schemaVersion)
+	}
+	s.versions[mac] = v
+}
+
+// get returns the schema version of the given gateway (schemaCurrent when
+// no message has been received yet).
+func (s *schemaStore) get(mac lorawan.EUI64) schemaVersion {
+	s.RLock()
+	defer s.RUnlock()
+	return s.versions[mac]
+}
+
+// legacyRXInfo contains the legacy rx-info fields.
+type legacyRXInfo struct {
+	Tmst *uint32         `json:"tmst"`
+	Freq float64         `json:"freq"` // in MHz
+	Chan int             `json:"chan"`
+	RFCh int             `json:"rfch"`
+	Stat int             `json:"stat"`
+	Modu string          `json:"modu"`
+	DatR json.RawMessage `json:"datr"` // string (LoRa) or bit rate (FSK)
+	CodR string          `json:"codr"`
+	LSNR float64         `json:"lsnr"`
+}
+
+// antennaRXInfo contains the rx-info of a single antenna (sent by
+// gateways with multiple antennas).
+type antennaRXInfo struct {
+	Antenna int     `json:"ant"`
+	Channel int     `json:"chan"`
+	RSSI    int     `json:"rssic"`
+	LoRaSNR float64 `json:"lsnr"`
+}
+
+// compatRXInfo contains the rx-info fields of all supported schema
+// versions.
+type compatRXInfo struct {
+	gw.RXInfo
+	legacyRXInfo
+	RSig []antennaRXInfo `json:"rsig"`
+}
+
+type compatRXPacket struct {
+	RXInfo     compatRXInfo       `json:"rxInfo"`
+	PHYPayload lorawan.PHYPayload `json:"phyPayload"`
+	Signature  []byte             `json:"signature"`
+}
+
+// signedRXPacket contains the (raw) fields of the rx packet covered by
+// its signature.
+type signedRXPacket struct {
+	RXInfo     json.RawMessage `json:"rxInfo"`
+	PHYPayload []byte          `json:"phyPayload"`
+}
+
+// legacyStats contains the legacy gateway stats fields.
+type legacyStats struct {
+	Lati *float64 `json:"lati"`
+	Long *float64 `json:"long"`
+	Alti *float64 `json:"alti"`
+	RXNb *int     `json:"rxnb"`
+	RXOK *int     `json:"rxok"`
+	DWNb *int     `json:"dwnb"`
+	TXNb *int     `json:"txnb"`
+}
+
+type compatStatsPacket struct {
+	gw.GatewayStatsPacket
+	legacyStats
+}
+
+// legacyTXInfo contains the tx-info using the legacy field names.
+type legacyTXInfo struct {
+	MAC  lorawan.EUI64 `json:"mac"`
+	Imme bool          `json:"imme"`
+	Tmst uint32        `json:"tmst"`
+	Freq float64       `json:"freq"` // in MHz
+	Powe int           `json:"powe"`
+	Modu string        `json:"modu"`
+	DatR interface{}   `json:"datr"`
+	CodR string        `json:"codr"`
+	IPol *bool         `json:"ipol,omitempty"`
+}
+
+type legacyTXPacket struct {
+	Token      uint16             `json:"token"`
+	TXInfo     legacyTXInfo       `json:"txInfo"`
+	PHYPayload lorawan.PHYPayload `json:"phyPayload"`
+}
+
+// decodeRXPacket decodes the given rx packet, auto-detecting its schema
+// version. In case the packet contains the rx-info of multiple antennas,
+// the RSSI, SNR, channel and antenna of the antenna with the best SNR are
+// used. For signed packets, the data covered by the signature is set as
+// SignedData.
+func decodeRXPacket(b []byte) (gw.RXPacket, schemaVersion, error) {
+	var p compatRXPacket
+	if err := json.Unmarshal(b, &p); err != nil {
+		return gw.RXPacket{}, schemaCurrent, err
+	}
+
+	version := schemaCurrent
+	rxInfo := p.RXInfo.RXInfo
+
+	if legacy := p.RXInfo.legacyRXInfo; legacy.Tmst != nil {
+		version = schemaLegacy
+
+		dr, err := parseLegacyDataRate(legacy.Modu, legacy.DatR)
+		if err != nil {
+			return gw.RXPacket{}, version, err
+		}
+
+		rxInfo.Timestamp = *legacy.Tmst
+		rxInfo.Frequency = int(math.Floor(legacy.Freq*1000000 + 0.5))
+		rxInfo.Channel = legacy.Chan
+		rxInfo.RFChain = legacy.RFCh
+		rxInfo.CRCStatus = legacy.Stat
+		rxInfo.CodeRate = legacy.CodR
+		rxInfo.LoRaSNR = legacy.LSNR
+		rxInfo.DataRate = dr
+	}
+
+	for i, ant := range p.RXInfo.RSig {
+		if i == 0 || ant.LoRaSNR > rxInfo.LoRaSNR {
+			rxInfo.RSSI = ant.RSSI
+			rxInfo.LoRaSNR = ant.LoRaSNR
+			rxInfo.Channel = ant.Channel
+			rxInfo.Antenna = ant.Antenna
+		}
+	}
+
+	rxPacket := gw.RXPacket{
+		RXInfo:     rxInfo,
+		PHYPayload: p.PHYPayload,
+	}
+
+	if len(p.Signature) != 0 {
+		var signed signedRXPacket
+		if err := json.Unmarshal(b, &signed); err != nil {
+			return gw.RXPacket{}, version, err
+		}
+		rxPacket.Signature = p.Signature
+		rxPacket.SignedData = append([]byte(signed.RXInfo), signed.PHYPayload...)
+	}
+
+	return rxPacket, version, nil
+}
+
+// decodeStatsPacket decodes the given stats packet, auto-detecting its
+// schema version.
+func decodeStatsPacket(b []byte) (gw.GatewayStatsPacket, schemaVersion, error) {
+	var p compatStatsPacket
+	if err := json.Unmarshal(b, &p); err != nil {
+		return gw.GatewayStatsPacket{}, schemaCurrent, err
+	}
+
+	stats := p.GatewayStatsPacket
+	legacy := p.legacyStats
+	if legacy.RXNb == nil && legacy.Lati == nil {
+		return stats, schemaCurrent, nil
+	}
+
+	stats.Latitude = legacy.Lati
+	stats.Longitude = legacy.Long
+	stats.Altitude = legacy.Alti
+	for _, f := range []struct {
+		dst *int
+		src *int
+	}{
+		{&stats.RXPacketsReceived, legacy.RXNb},
+		{&stats.RXPacketsReceivedOK, legacy.RXOK},
+		{&stats.TXPacketsReceived, legacy.DWNb},
+		{&stats.TXPacketsEmitted, legacy.TXNb},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+
+	return stats, schemaLegacy, nil
+}
+
+// encodeTXPacket encodes the given tx packet using the given schema
+// version.
+func encodeTXPacket(txPacket gw.TXPacket, version schemaVersion) ([]byte, error) {
+	if version != schemaLegacy {
+		return json.Marshal(txPacket)
+	}
+
+	txInfo := txPacket.TXInfo
+	p := legacyTXPacket{
+		Token: txPacket.Token,
+		TXInfo: legacyTXInfo{
+			MAC:  txInfo.MAC,
+			Imme: txInfo.Immediately,
+			Tmst: txInfo.Timestamp,
+			Freq: float64(txInfo.Frequency) / 1000000,
+			Powe: txInfo.Power,
+			Modu: string(txInfo.DataRate.Modulation),
+			CodR: txInfo.CodeRate,
+			IPol: txInfo.IPol,
+		},
+		PHYPayload: txPacket.PHYPayload,
+	}
+
+	if txInfo.DataRate.Modulation == band.FSKModulation {
+		p.TXInfo.DatR = txInfo.DataRate.BitRate
+	} else {
+		p.TXInfo.DatR = fmt.Sprintf("SF%dBW%d", txInfo.DataRate.SpreadFactor, txInfo.DataRate.Bandwidth)
+	}
+
+	return json.Marshal(p)
+}
+
+// parseLegacyDataRate parses the legacy data-rate, which is either a string
+// (e.g. SF7BW125) for LoRa or the bit rate for FSK.
+func parseLegacyDataRate(modu string, datr json.RawMessage) (band.DataRate, error) {
+	var dr band.DataRate
+
+	if band.Modulation(modu) == band.FSKModulation {
+		dr.Modulation = band.FSKModulation
+		if err := json.Unmarshal(datr, &dr.BitRate); err != nil {
+			return dr, fmt.Errorf("invalid fsk datr: %s", err)
+		}
+		return dr, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(datr, &s); err != nil {
+		return dr, fmt.Errorf("invalid lora datr: %s", err)
+	}
+
+	dr.Modulation = band.LoRaModulation
+	if _, err := fmt.Sscanf(s, "SF%dBW%d", &dr.SpreadFactor, &dr.Bandwidth); err != nil {
+		return dr, fmt.Errorf("invalid lora datr '%s': %s", s, err)
+	}
+	return dr, nil
+}
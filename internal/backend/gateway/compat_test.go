@@ -0,0 +1,190 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecodeRXPacket(t *testing.T) {
+	Convey("Given a set of rx packets in different schema versions", t, func() {
+		expected := gw.RXPacket{
+			RXInfo: gw.RXInfo{
+				MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Timestamp: 12345,
+				Frequency: 868100000,
+				Channel:   1,
+				RFChain:   1,
+				CRCStatus: 1,
+				CodeRate:  "4/5",
+				RSSI:      -60,
+				LoRaSNR:   5.5,
+				Size:      23,
+				DataRate: band.DataRate{
+					Modulation:   band.LoRaModulation,
+					SpreadFactor: 7,
+					Bandwidth:    125,
+				},
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+		phyB, err := json.Marshal(expected.PHYPayload)
+		So(err, ShouldBeNil)
+
+		tests := []struct {
+			Name            string
+			RXInfo          string
+			ExpectedVersion schemaVersion
+			ExpectedAntenna int
+		}{
+			{
+				Name:            "current schema",
+				RXInfo:          `{"mac":"0102030405060708","timestamp":12345,"frequency":868100000,"channel":1,"rfChain":1,"crcStatus":1,"codeRate":"4/5","rssi":-60,"loRaSNR":5.5,"size":23,"dataRate":{"modulation":"LORA","spreadFactor":7,"bandwidth":125}}`,
+				ExpectedVersion: schemaCurrent,
+			},
+			{
+				Name:            "legacy schema",
+				RXInfo:          `{"mac":"0102030405060708","tmst":12345,"freq":868.1,"chan":1,"rfch":1,"stat":1,"modu":"LORA","datr":"SF7BW125","codr":"4/5","rssi":-60,"lsnr":5.5,"size":23}`,
+				ExpectedVersion: schemaLegacy,
+			},
+			{
+				Name:            "legacy schema with per-antenna rx-info",
+				RXInfo:          `{"mac":"0102030405060708","tmst":12345,"freq":868.1,"rfch":1,"stat":1,"modu":"LORA","datr":"SF7BW125","codr":"4/5","size":23,"rsig":[{"ant":0,"chan":0,"rssic":-80,"lsnr":-2},{"ant":1,"chan":1,"rssic":-60,"lsnr":5.5}]}`,
+				ExpectedVersion: schemaLegacy,
+				ExpectedAntenna: 1,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				b := []byte(`{"rxInfo":` + test.RXInfo + `,"phyPayload":` + string(phyB) + `}`)
+				rxPacket, version, err := decodeRXPacket(b)
+				So(err, ShouldBeNil)
+				So(version, ShouldEqual, test.ExpectedVersion)
+
+				exp := expected
+				exp.RXInfo.Antenna = test.ExpectedAntenna
+				So(rxPacket, ShouldResemble, exp)
+			})
+		}
+
+		Convey("Given a signed rx packet", func() {
+			rxInfo := tests[0].RXInfo
+			b := []byte(`{"rxInfo":` + rxInfo + `,"phyPayload":` + string(phyB) + `,"signature":"AQID"}`)
+
+			Convey("Then the signature and signed data are set", func() {
+				phyBytes, err := expected.PHYPayload.MarshalBinary()
+				So(err, ShouldBeNil)
+
+				rxPacket, _, err := decodeRXPacket(b)
+				So(err, ShouldBeNil)
+				So(rxPacket.Signature, ShouldResemble, []byte{1, 2, 3})
+				So(rxPacket.SignedData, ShouldResemble, append([]byte(rxInfo), phyBytes...))
+			})
+		})
+
+		Convey("Given a legacy rx packet with invalid datr", func() {
+			b := []byte(`{"rxInfo":{"tmst":12345,"modu":"LORA","datr":"BW125"},"phyPayload":` + string(phyB) + `}`)
+
+			Convey("Then decoding returns an error", func() {
+				_, _, err := decodeRXPacket(b)
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestDecodeStatsPacket(t *testing.T) {
+	Convey("Given a legacy stats packet", t, func() {
+		b := []byte(`{"mac":"0102030405060708","lati":1.5,"long":2.5,"alti":10,"rxnb":10,"rxok":8,"dwnb":3,"txnb":2}`)
+
+		Convey("Then it is decoded as expected", func() {
+			lat := 1.5
+			long := 2.5
+			alt := 10.0
+
+			stats, version, err := decodeStatsPacket(b)
+			So(err, ShouldBeNil)
+			So(version, ShouldEqual, schemaLegacy)
+			So(stats, ShouldResemble, gw.GatewayStatsPacket{
+				MAC:                 lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Latitude:            &lat,
+				Longitude:           &long,
+				Altitude:            &alt,
+				RXPacketsReceived:   10,
+				RXPacketsReceivedOK: 8,
+				TXPacketsReceived:   3,
+				TXPacketsEmitted:    2,
+			})
+		})
+	})
+}
+
+func TestEncodeTXPacket(t *testing.T) {
+	Convey("Given a TXPacket", t, func() {
+		txPacket := gw.TXPacket{
+			Token: 1234,
+			TXInfo: gw.TXInfo{
+				MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Timestamp: 12345,
+				Frequency: 869525000,
+				Power:     14,
+				CodeRate:  "4/5",
+				DataRate: band.DataRate{
+					Modulation:   band.LoRaModulation,
+					SpreadFactor: 9,
+					Bandwidth:    125,
+				},
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+
+		Convey("When encoding it using the current schema", func() {
+			b, err := encodeTXPacket(txPacket, schemaCurrent)
+			So(err, ShouldBeNil)
+
+			Convey("Then it equals the JSON encoding of the TXPacket", func() {
+				exp, err := json.Marshal(txPacket)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, string(exp))
+			})
+		})
+
+		Convey("When encoding it using the legacy schema", func() {
+			b, err := encodeTXPacket(txPacket, schemaLegacy)
+			So(err, ShouldBeNil)
+
+			Convey("Then the legacy field names are used", func() {
+				var p struct {
+					Token  uint16                 `json:"token"`
+					TXInfo map[string]interface{} `json:"txInfo"`
+				}
+				So(json.Unmarshal(b, &p), ShouldBeNil)
+				So(p.Token, ShouldEqual, 1234)
+				So(p.TXInfo["tmst"], ShouldEqual, 12345.0)
+				So(p.TXInfo["freq"], ShouldEqual, 869.525)
+				So(p.TXInfo["powe"], ShouldEqual, 14.0)
+				So(p.TXInfo["modu"], ShouldEqual, "LORA")
+				So(p.TXInfo["datr"], ShouldEqual, "SF9BW125")
+				So(p.TXInfo["codr"], ShouldEqual, "4/5")
+			})
+		})
+	})
+}
@@ -0,0 +1,115 @@
+// Package channelnoise implements the per gateway and frequency noise floor
+// estimation, used by the ADR engine to avoid noisy channels.
+package channelnoise
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+)
+
+const (
+	// noiseKeyTempl contains per gateway a hash with the estimated noise
+	// floor (dBm) per frequency (field).
+	noiseKeyTempl = "lora:ns:channel:noise:%s"
+
+	// NoiseTTL defines how long the noise floors of a gateway are kept
+	// after its last uplink.
+	NoiseTTL = time.Hour * 24
+
+	// smoothing defines the weight (0 - 1) of a new measurement in the
+	// exponentially weighted moving average of the noise floor.
+	smoothing = 0.1
+)
+
+// recordNoiseScript updates the moving average of the noise floor of the
+// given frequency and refreshes the TTL of the hash.
+var recordNoiseScript = redis.NewScript(1, `
+	local noise = tonumber(ARGV[2])
+	local current = redis.call("HGET", KEYS[1], ARGV[1])
+	if current then
+		noise = tonumber(current) * (1 - tonumber(ARGV[3])) + noise * tonumber(ARGV[3])
+	end
+	redis.call("HSET", KEYS[1], ARGV[1], tostring(noise))
+	redis.call("PEXPIRE", KEYS[1], ARGV[4])
+	return tostring(noise)
+`)
+
+// GetNoiseFloor returns the noise floor (dBm) estimated from the RSSI and
+// SNR of the given reception. As the RSSI contains both the signal and the
+// noise power, the noise floor is RSSI - 10 * log10(1 + 10^(SNR / 10)).
+func GetNoiseFloor(rxInfo gw.RXInfo) float64 {
+	return float64(rxInfo.RSSI) - 10*math.Log10(1+math.Pow(10, rxInfo.LoRaSNR/10))
+}
+
+// RecordUplink updates the noise floor of the frequency and gateway of the
+// given reception.
+func RecordUplink(p *redis.Pool, rxInfo gw.RXInfo) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := recordNoiseScript.Do(c,
+		fmt.Sprintf(noiseKeyTempl, rxInfo.MAC),
+		rxInfo.Frequency,
+		strconv.FormatFloat(GetNoiseFloor(rxInfo), 'f', -1, 64),
+		smoothing,
+		int64(NoiseTTL/time.Millisecond),
+	)
+	if err != nil {
+		return errors.Wrap(err, "record noise floor error")
+	}
+	return nil
+}
+
+// GetNoiseFloors returns the noise floor (dBm) per frequency (Hz) of the
+// given gateway.
+func GetNoiseFloors(p *redis.Pool, mac lorawan.EUI64) (map[int]float64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.StringMap(c.Do("HGETALL", fmt.Sprintf(noiseKeyTempl, mac)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get noise floors error")
+	}
+
+	out := make(map[int]float64)
+	for field, v := range values {
+		frequency, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse field '%s' error", field)
+		}
+		noise, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse noise floor of %d error", frequency)
+		}
+		out[frequency] = noise
+	}
+	return out, nil
+}
+
+// GetExcessNoise returns the noise (dB) of the given frequency above the
+// noise floor of the quietest frequency of the given noise floors. It
+// returns 0 when the frequency is unknown or when less than two frequencies
+// have been measured.
+func GetExcessNoise(floors map[int]float64, frequency int) float64 {
+	noise, ok := floors[frequency]
+	if !ok || len(floors) < 2 {
+		return 0
+	}
+
+	min := noise
+	for _, n := range floors {
+		if n < min {
+			min = n
+		}
+	}
+	return noise - min
+}
@@ -0,0 +1,73 @@
+package channelnoise
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestGetNoiseFloor(t *testing.T) {
+	Convey("Given a reception with an SNR of 0 dB", t, func() {
+		rxInfo := gw.RXInfo{RSSI: -100, LoRaSNR: 0}
+
+		Convey("Then the noise floor is 3 dB below the RSSI", func() {
+			So(GetNoiseFloor(rxInfo), ShouldAlmostEqual, -103.01, 0.01)
+		})
+	})
+
+	Convey("Given a reception below the noise floor", t, func() {
+		rxInfo := gw.RXInfo{RSSI: -110, LoRaSNR: -15}
+
+		Convey("Then the noise floor is close to the RSSI", func() {
+			So(GetNoiseFloor(rxInfo), ShouldAlmostEqual, -110.14, 0.01)
+		})
+	})
+}
+
+func TestGetExcessNoise(t *testing.T) {
+	Convey("Given the noise floors of three frequencies", t, func() {
+		floors := map[int]float64{
+			868100000: -120,
+			868300000: -118,
+			868500000: -105,
+		}
+
+		Convey("Then the excess noise is relative to the quietest frequency", func() {
+			So(GetExcessNoise(floors, 868100000), ShouldEqual, 0)
+			So(GetExcessNoise(floors, 868300000), ShouldEqual, 2)
+			So(GetExcessNoise(floors, 868500000), ShouldEqual, 15)
+		})
+
+		Convey("Then the excess noise of an unknown frequency is 0", func() {
+			So(GetExcessNoise(floors, 867100000), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestRecordUplink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When recording two uplinks on the same frequency", func() {
+			So(RecordUplink(p, gw.RXInfo{MAC: mac, Frequency: 868100000, RSSI: -110, LoRaSNR: -20}), ShouldBeNil)
+			So(RecordUplink(p, gw.RXInfo{MAC: mac, Frequency: 868100000, RSSI: -100, LoRaSNR: -20}), ShouldBeNil)
+
+			Convey("Then the noise floor is the moving average", func() {
+				floors, err := GetNoiseFloors(p, mac)
+				So(err, ShouldBeNil)
+				So(floors, ShouldHaveLength, 1)
+				So(floors[868100000], ShouldAlmostEqual, -109.04, 0.01)
+			})
+		})
+	})
+}
@@ -0,0 +1,333 @@
+// Package channelplan implements the channel-configurations. A
+// channel-configuration defines the uplink channels (the extra channels
+// on top of the default channels of the band and the enabled channels) of
+// the nodes using it. The channels of these nodes are reconciled to the
+// channel-configuration using NewChannelReq and LinkADRReq mac-commands
+// (see Reconcile).
+package channelplan
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// maxDynamicChannels defines the max number of uplink channels of the
+// bands with a dynamic channel plan (a LinkADRReq ChMask block).
+const maxDynamicChannels = 16
+
+// ExtraChannel defines an uplink channel added on top of the default
+// channels of the band.
+type ExtraChannel struct {
+	Frequency int `db:"frequency"` // Hz
+	MinDR     int `db:"min_dr"`
+	MaxDR     int `db:"max_dr"`
+}
+
+// Configuration defines a channel-configuration.
+type Configuration struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+
+	// Band must match the band of LoRa Server, so that a
+	// channel-configuration can not be applied by accident to the nodes
+	// of an other band.
+	Band string `db:"band"`
+
+	// Channels contains the indices of the enabled uplink channels. The
+	// default channels of the band are numbered first, followed by the
+	// extra channels.
+	Channels pq.Int64Array `db:"channels"`
+
+	// ExtraChannels contains the channels added (NewChannelReq) on top of
+	// the default channels of the band. Only bands implementing the CFList
+	// support extra channels.
+	ExtraChannels []ExtraChannel `db:"-"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate validates the channel-configuration.
+func (c Configuration) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrInvalidName
+	}
+	if c.Band != string(common.BandName) {
+		return ErrInvalidBand
+	}
+
+	if len(c.ExtraChannels) > 0 {
+		if !common.Band.ImplementsCFlist || session.IsFixedChannelPlanBand() || len(common.Band.UplinkChannels)+len(c.ExtraChannels) > maxDynamicChannels {
+			return ErrInvalidExtraChannels
+		}
+	}
+	for _, ec := range c.ExtraChannels {
+		if ec.Frequency <= 0 || ec.Frequency%100 != 0 {
+			return ErrInvalidExtraChannels
+		}
+		if ec.MinDR < 0 || ec.MinDR > ec.MaxDR || ec.MaxDR > len(common.Band.DataRates)-1 || ec.MaxDR > 15 {
+			return ErrInvalidExtraChannels
+		}
+	}
+
+	if len(c.Channels) == 0 {
+		return ErrInvalidChannels
+	}
+	total := len(common.Band.UplinkChannels) + len(c.ExtraChannels)
+	seen := make(map[int64]struct{})
+	for _, i := range c.Channels {
+		if i < 0 || i >= int64(total) {
+			return ErrInvalidChannels
+		}
+		if _, ok := seen[i]; ok {
+			return ErrInvalidChannels
+		}
+		seen[i] = struct{}{}
+	}
+
+	return nil
+}
+
+// GetUplinkChannels returns the uplink channels defined by the
+// channel-configuration (sorted by index).
+func (c Configuration) GetUplinkChannels() []session.UplinkChannel {
+	out := session.DefaultUplinkChannels()
+	for i, ec := range c.ExtraChannels {
+		out = append(out, session.UplinkChannel{
+			Index:     len(common.Band.UplinkChannels) + i,
+			Frequency: ec.Frequency,
+			MinDR:     ec.MinDR,
+			MaxDR:     ec.MaxDR,
+		})
+	}
+
+	enabled := make(map[int]struct{})
+	for _, i := range c.Channels {
+		enabled[int(i)] = struct{}{}
+	}
+	for i := range out {
+		_, out[i].Enabled = enabled[out[i].Index]
+	}
+
+	return out
+}
+
+// CreateConfiguration validates and creates the given channel-configuration.
+// On success, the ID and timestamps of the channel-configuration are set.
+func CreateConfiguration(db *sqlx.DB, c *Configuration) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction error")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	err = tx.Get(&c.ID, `
+		insert into channel_configuration (
+			name,
+			band,
+			channels,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $4)
+		returning id`,
+		c.Name,
+		c.Band,
+		c.Channels,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+
+	if err := setExtraChannels(tx, c.ID, c.ExtraChannels); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":   c.ID,
+		"name": c.Name,
+	}).Info("channel-configuration created")
+	return nil
+}
+
+// GetConfiguration returns the channel-configuration for the given ID.
+func GetConfiguration(db *sqlx.DB, id int64) (Configuration, error) {
+	var c Configuration
+	err := db.Get(&c, "select * from channel_configuration where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c, ErrDoesNotExist
+		}
+		return c, errors.Wrap(err, "select error")
+	}
+
+	c.ExtraChannels, err = getExtraChannels(db, id)
+	if err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// UpdateConfiguration validates and updates the given
+// channel-configuration. The nodes using the channel-configuration are
+// reconciled to the updated channels on their next uplink.
+func UpdateConfiguration(db *sqlx.DB, c *Configuration) error {
+	if err := c.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction error")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.Exec(`
+		update channel_configuration set
+			name = $2,
+			band = $3,
+			channels = $4,
+			updated_at = $5
+		where id = $1`,
+		c.ID,
+		c.Name,
+		c.Band,
+		c.Channels,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	if err := setExtraChannels(tx, c.ID, c.ExtraChannels); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+	c.UpdatedAt = now
+
+	log.WithField("id", c.ID).Info("channel-configuration updated")
+	return nil
+}
+
+// DeleteConfiguration deletes the channel-configuration for the given ID.
+// The channels of the nodes using it are left as they are.
+func DeleteConfiguration(db *sqlx.DB, id int64) error {
+	res, err := db.Exec("delete from channel_configuration where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("channel-configuration deleted")
+	return nil
+}
+
+// GetConfigurationCount returns the number of channel-configurations.
+func GetConfigurationCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from channel_configuration")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetConfigurations returns a slice of channel-configurations, ordered by
+// ID and respecting the given limit and offset.
+func GetConfigurations(db *sqlx.DB, limit, offset int) ([]Configuration, error) {
+	var confs []Configuration
+	err := db.Select(&confs, "select * from channel_configuration order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range confs {
+		confs[i].ExtraChannels, err = getExtraChannels(db, confs[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return confs, nil
+}
+
+// getExtraChannels returns the extra channels of the given
+// channel-configuration.
+func getExtraChannels(db sqlx.Queryer, id int64) ([]ExtraChannel, error) {
+	var channels []ExtraChannel
+	err := sqlx.Select(db, &channels, `
+		select frequency, min_dr, max_dr
+		from channel_configuration_extra_channel
+		where channel_configuration_id = $1
+		order by position`,
+		id,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select extra channels error")
+	}
+	return channels, nil
+}
+
+// setExtraChannels replaces the extra channels of the given
+// channel-configuration.
+func setExtraChannels(tx *sqlx.Tx, id int64, channels []ExtraChannel) error {
+	if _, err := tx.Exec("delete from channel_configuration_extra_channel where channel_configuration_id = $1", id); err != nil {
+		return errors.Wrap(err, "delete extra channels error")
+	}
+	for i, ec := range channels {
+		_, err := tx.Exec(`
+			insert into channel_configuration_extra_channel (
+				channel_configuration_id,
+				position,
+				frequency,
+				min_dr,
+				max_dr
+			) values ($1, $2, $3, $4, $5)`,
+			id,
+			i,
+			ec.Frequency,
+			ec.MinDR,
+			ec.MaxDR,
+		)
+		if err != nil {
+			return errors.Wrap(err, "insert extra channel error")
+		}
+	}
+	return nil
+}
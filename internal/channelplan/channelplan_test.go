@@ -0,0 +1,128 @@
+package channelplan
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestConfigurationValidate(t *testing.T) {
+	Convey("Given a testtable (EU band)", t, func() {
+		extra := []ExtraChannel{{Frequency: 867100000, MinDR: 0, MaxDR: 5}}
+
+		testTable := []struct {
+			Configuration Configuration
+			ExpectedError error
+		}{
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0, 1, 2, 3}, ExtraChannels: extra}, nil},
+			{Configuration{Band: "EU_863_870", Channels: []int64{0}}, ErrInvalidName},
+			{Configuration{Name: "plan", Band: "US_902_928", Channels: []int64{0}}, ErrInvalidBand},
+			{Configuration{Name: "plan", Band: "EU_863_870"}, ErrInvalidChannels},
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0, 3}}, ErrInvalidChannels},
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0, 0}}, ErrInvalidChannels},
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0}, ExtraChannels: []ExtraChannel{{Frequency: 867100050}}}, ErrInvalidExtraChannels},
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0}, ExtraChannels: []ExtraChannel{{Frequency: 867100000, MinDR: 5, MaxDR: 0}}}, ErrInvalidExtraChannels},
+			{Configuration{Name: "plan", Band: "EU_863_870", Channels: []int64{0}, ExtraChannels: make([]ExtraChannel, 14)}, ErrInvalidExtraChannels},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				So(errors.Cause(tst.Configuration.Validate()), ShouldEqual, tst.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestConfigurationGetUplinkChannels(t *testing.T) {
+	Convey("Given a channel-configuration with an extra channel (EU band)", t, func() {
+		c := Configuration{
+			Channels:      []int64{0, 3},
+			ExtraChannels: []ExtraChannel{{Frequency: 867100000, MinDR: 0, MaxDR: 5}},
+		}
+
+		Convey("Then GetUplinkChannels returns the default and extra channels", func() {
+			So(c.GetUplinkChannels(), ShouldResemble, []session.UplinkChannel{
+				{Index: 0, Frequency: 868100000, MinDR: 0, MaxDR: 5, Enabled: true},
+				{Index: 1, Frequency: 868300000, MinDR: 0, MaxDR: 5},
+				{Index: 2, Frequency: 868500000, MinDR: 0, MaxDR: 5},
+				{Index: 3, Frequency: 867100000, MinDR: 0, MaxDR: 5, Enabled: true},
+			})
+		})
+	})
+}
+
+func TestConfigurations(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		Convey("When creating an invalid channel-configuration", func() {
+			err := CreateConfiguration(db, &Configuration{Name: "plan", Band: "EU_863_870"})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidChannels)
+			})
+		})
+
+		Convey("When creating a channel-configuration", func() {
+			c := Configuration{
+				Name:     "plan",
+				Band:     "EU_863_870",
+				Channels: []int64{0, 1, 2, 3, 4},
+				ExtraChannels: []ExtraChannel{
+					{Frequency: 867100000, MinDR: 0, MaxDR: 5},
+					{Frequency: 867300000, MinDR: 0, MaxDR: 5},
+				},
+			}
+			So(CreateConfiguration(db, &c), ShouldBeNil)
+			c.CreatedAt = c.CreatedAt.UTC().Truncate(time.Millisecond)
+			c.UpdatedAt = c.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+			Convey("Then it can be retrieved", func() {
+				c2, err := GetConfiguration(db, c.ID)
+				So(err, ShouldBeNil)
+				c2.CreatedAt = c2.CreatedAt.UTC().Truncate(time.Millisecond)
+				c2.UpdatedAt = c2.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(c2, ShouldResemble, c)
+
+				count, err := GetConfigurationCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				confs, err := GetConfigurations(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(confs, ShouldHaveLength, 1)
+				So(confs[0].ExtraChannels, ShouldResemble, c.ExtraChannels)
+			})
+
+			Convey("Then it can be updated", func() {
+				c.Channels = []int64{0, 1, 2}
+				c.ExtraChannels = nil
+				So(UpdateConfiguration(db, &c), ShouldBeNil)
+
+				c2, err := GetConfiguration(db, c.ID)
+				So(err, ShouldBeNil)
+				So(c2.Channels, ShouldResemble, c.Channels)
+				So(c2.ExtraChannels, ShouldHaveLength, 0)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteConfiguration(db, c.ID), ShouldBeNil)
+				_, err := GetConfiguration(db, c.ID)
+				So(err, ShouldEqual, ErrDoesNotExist)
+				So(DeleteConfiguration(db, c.ID), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package channelplan
+
+import "errors"
+
+// channelplan errors
+var (
+	ErrDoesNotExist         = errors.New("channel-configuration does not exist")
+	ErrInvalidName          = errors.New("channel-configuration name must not be empty")
+	ErrInvalidBand          = errors.New("channel-configuration band does not match the band of LoRa Server")
+	ErrInvalidChannels      = errors.New("invalid channel-configuration channels")
+	ErrInvalidExtraChannels = errors.New("invalid channel-configuration extra channels")
+)
@@ -0,0 +1,239 @@
+package channelplan
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// maxNewChannelReqs defines the max number of NewChannelReq mac-commands
+// enqueued per reconciliation (three fit within the FOpts field).
+const maxNewChannelReqs = 3
+
+// Reconcile compares the uplink channels of the given node against its
+// channel-configuration and enqueues the mac-commands to converge these:
+// first the NewChannelReq mac-commands adding, modifying or removing the
+// extra channels, then the LinkADRReq mac-command(s) enabling the channels
+// of the channel-configuration (using the given current data-rate of the
+// node). Nothing is enqueued while a previous request is pending, the
+// uplink channels of the node-session are updated once the node
+// acknowledges the requests (see the maccommand package).
+// Note: this must be called before the node-session is saved.
+func Reconcile(ctx common.Context, ns *session.NodeSession, dr int) error {
+	if ns.ChannelConfigurationID == 0 {
+		return nil
+	}
+
+	for _, cid := range []lorawan.CID{lorawan.NewChannelReq, lorawan.LinkADRReq} {
+		pending, err := maccommand.ReadPending(ctx.RedisPool, ns.DevEUI, cid)
+		if err != nil {
+			return errors.Wrap(err, "read pending mac-commands error")
+		}
+		if len(pending) > 0 {
+			return nil
+		}
+	}
+
+	conf, err := GetConfiguration(ctx.DB, ns.ChannelConfigurationID)
+	if err != nil {
+		return errors.Wrap(err, "get channel-configuration error")
+	}
+	if conf.Band != string(common.BandName) {
+		return ErrInvalidBand
+	}
+
+	current := ns.GetUplinkChannels()
+	desired := conf.GetUplinkChannels()
+
+	var cid lorawan.CID
+	var payloads []lorawan.MACCommandPayload
+	if pls := newChannelReqPayloads(current, desired); len(pls) > 0 {
+		cid = lorawan.NewChannelReq
+		for i := range pls {
+			payloads = append(payloads, &pls[i])
+		}
+	} else if pls := linkADRReqPayloads(current, desired, dr, getTXPowerIndex(ns.TXPower), ns.NbTrans); len(pls) > 0 {
+		cid = lorawan.LinkADRReq
+		for i := range pls {
+			payloads = append(payloads, &pls[i])
+		}
+	} else {
+		return nil
+	}
+
+	// the mac-commands are handled by the network-controller
+	if maccommand.IsHandledByController(cid) {
+		return nil
+	}
+
+	for _, pl := range payloads {
+		mac := lorawan.MACCommand{
+			CID:     cid,
+			Payload: pl,
+		}
+		b, err := mac.MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "marshal mac command error")
+		}
+
+		err = maccommand.AddToQueue(ctx.RedisPool, maccommand.QueueItem{
+			DevEUI: ns.DevEUI,
+			Data:   b,
+		})
+		if err != nil {
+			return errors.Wrap(err, "add mac-command to queue error")
+		}
+	}
+
+	if err := maccommand.SetPending(ctx.RedisPool, ns.DevEUI, cid, payloads); err != nil {
+		return errors.Wrap(err, "set mac-command as pending error")
+	}
+
+	// start tracking the uplink channels of the node, so that the
+	// acknowledged changes are applied
+	ns.UplinkChannels = current
+
+	log.WithFields(log.Fields{
+		"dev_eui":                  ns.DevEUI,
+		"channel_configuration_id": conf.ID,
+		"cid":                      cid,
+		"count":                    len(payloads),
+	}).Info("channel-configuration mac-commands added to mac-command queue")
+
+	return nil
+}
+
+// newChannelReqPayloads returns the NewChannelReq payloads for the extra
+// channels of which the current channel does not match the desired
+// channel. A channel is removed by a frequency of 0.
+func newChannelReqPayloads(current, desired []session.UplinkChannel) []lorawan.NewChannelReqPayload {
+	if session.IsFixedChannelPlanBand() {
+		return nil
+	}
+
+	currentByIndex := make(map[int]session.UplinkChannel)
+	for _, c := range current {
+		currentByIndex[c.Index] = c
+	}
+	desiredByIndex := make(map[int]session.UplinkChannel)
+	for _, c := range desired {
+		desiredByIndex[c.Index] = c
+	}
+
+	var out []lorawan.NewChannelReqPayload
+	for i := len(common.Band.UplinkChannels); i < maxDynamicChannels && len(out) < maxNewChannelReqs; i++ {
+		c, cOK := currentByIndex[i]
+		d, dOK := desiredByIndex[i]
+
+		switch {
+		case dOK && (!cOK || c.Frequency != d.Frequency || c.MinDR != d.MinDR || c.MaxDR != d.MaxDR):
+			out = append(out, lorawan.NewChannelReqPayload{
+				ChIndex: uint8(i),
+				Freq:    uint32(d.Frequency),
+				MinDR:   uint8(d.MinDR),
+				MaxDR:   uint8(d.MaxDR),
+			})
+		case cOK && !dOK:
+			out = append(out, lorawan.NewChannelReqPayload{
+				ChIndex: uint8(i),
+			})
+		}
+	}
+
+	return out
+}
+
+// linkADRReqPayloads returns the (block of) LinkADRReq payloads enabling
+// the desired channels, or nil when the enabled channels already match.
+func linkADRReqPayloads(current, desired []session.UplinkChannel, dr, txPowerIndex int, nbRep uint8) []lorawan.LinkADRReqPayload {
+	currentEnabled := enabledIndices(current)
+	desiredEnabled := enabledIndices(desired)
+
+	equal := len(currentEnabled) == len(desiredEnabled)
+	for i := range desired {
+		if _, ok := currentEnabled[desired[i].Index]; ok != desired[i].Enabled {
+			equal = false
+		}
+	}
+	if equal {
+		return nil
+	}
+
+	newPayload := func(cntl int) lorawan.LinkADRReqPayload {
+		return lorawan.LinkADRReqPayload{
+			DataRate: uint8(dr),
+			TXPower:  uint8(txPowerIndex),
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: uint8(cntl),
+				NbRep:      nbRep,
+			},
+		}
+	}
+
+	if !session.IsFixedChannelPlanBand() {
+		pl := newPayload(0) // first block of 16 channels
+		for i := range desiredEnabled {
+			if i < len(pl.ChMask) {
+				pl.ChMask[i] = true
+			}
+		}
+		return []lorawan.LinkADRReqPayload{pl}
+	}
+
+	// all 125 kHz channels off, the ChMask enables the 500 kHz channels
+	// 64 - 71, followed by a payload per block of 16 channels containing
+	// enabled channels
+	out := []lorawan.LinkADRReqPayload{newPayload(7)}
+	for i := range desiredEnabled {
+		if i >= 64 {
+			out[0].ChMask[i-64] = true
+		}
+	}
+	for cntl := 0; cntl < 4; cntl++ {
+		pl := newPayload(cntl)
+		var enabled bool
+		for i := range desiredEnabled {
+			if i >= cntl*16 && i < (cntl+1)*16 {
+				pl.ChMask[i-cntl*16] = true
+				enabled = true
+			}
+		}
+		if enabled {
+			out = append(out, pl)
+		}
+	}
+
+	return out
+}
+
+// enabledIndices returns the indices of the enabled channels.
+func enabledIndices(channels []session.UplinkChannel) map[int]struct{} {
+	out := make(map[int]struct{})
+	for _, c := range channels {
+		if c.Enabled {
+			out[c.Index] = struct{}{}
+		}
+	}
+	return out
+}
+
+// getTXPowerIndex returns the LinkADRReq TXPower index of the given TX
+// power (0 = the default TX power of the band).
+func getTXPowerIndex(txPower int) int {
+	if txPower == 0 {
+		txPower = common.Band.DefaultTXPower
+	}
+
+	var idx int
+	for i, p := range common.Band.TXPower {
+		if p >= txPower {
+			idx = i
+		}
+	}
+	return idx
+}
@@ -0,0 +1,154 @@
+package channelplan
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestNewChannelReqPayloads(t *testing.T) {
+	Convey("Given the default channels of the EU band and an extra channel", t, func() {
+		current := append(session.DefaultUplinkChannels(), session.UplinkChannel{Index: 3, Frequency: 867100000, MaxDR: 5, Enabled: true})
+
+		Convey("When the desired extra channels are equal", func() {
+			desired := append(session.DefaultUplinkChannels(), session.UplinkChannel{Index: 3, Frequency: 867100000, MaxDR: 5})
+
+			Convey("Then no payloads are returned", func() {
+				So(newChannelReqPayloads(current, desired), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the extra channel is modified and a channel is added", func() {
+			desired := append(session.DefaultUplinkChannels(),
+				session.UplinkChannel{Index: 3, Frequency: 867300000, MaxDR: 5},
+				session.UplinkChannel{Index: 4, Frequency: 867500000, MinDR: 1, MaxDR: 4},
+			)
+
+			Convey("Then both channels are requested", func() {
+				So(newChannelReqPayloads(current, desired), ShouldResemble, []lorawan.NewChannelReqPayload{
+					{ChIndex: 3, Freq: 867300000, MaxDR: 5},
+					{ChIndex: 4, Freq: 867500000, MinDR: 1, MaxDR: 4},
+				})
+			})
+		})
+
+		Convey("When the extra channel is not desired", func() {
+			desired := session.DefaultUplinkChannels()
+
+			Convey("Then the channel is removed", func() {
+				So(newChannelReqPayloads(current, desired), ShouldResemble, []lorawan.NewChannelReqPayload{
+					{ChIndex: 3},
+				})
+			})
+		})
+	})
+}
+
+func TestLinkADRReqPayloads(t *testing.T) {
+	Convey("Given the default channels of the EU band", t, func() {
+		current := session.DefaultUplinkChannels()
+
+		Convey("When all channels are desired", func() {
+			Convey("Then no payloads are returned", func() {
+				So(linkADRReqPayloads(current, session.DefaultUplinkChannels(), 5, 1, 1), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When channel 1 must be disabled", func() {
+			desired := session.DefaultUplinkChannels()
+			desired[1].Enabled = false
+
+			Convey("Then a LinkADRReq for the first block of channels is returned", func() {
+				So(linkADRReqPayloads(current, desired, 5, 1, 1), ShouldResemble, []lorawan.LinkADRReqPayload{
+					{
+						DataRate:   5,
+						TXPower:    1,
+						ChMask:     lorawan.ChMask{true, false, true},
+						Redundancy: lorawan.Redundancy{NbRep: 1},
+					},
+				})
+			})
+		})
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx := common.Context{
+			RedisPool: p,
+			DB:        db,
+		}
+
+		Convey("Given a channel-configuration with an extra channel and a node using it", func() {
+			c := Configuration{
+				Name:          "plan",
+				Band:          "EU_863_870",
+				Channels:      []int64{0, 1, 3},
+				ExtraChannels: []ExtraChannel{{Frequency: 867100000, MinDR: 0, MaxDR: 5}},
+			}
+			So(CreateConfiguration(db, &c), ShouldBeNil)
+
+			ns := session.NodeSession{
+				DevEUI:                 lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				ChannelConfigurationID: c.ID,
+			}
+
+			Convey("When reconciling the node", func() {
+				So(Reconcile(ctx, &ns, 5), ShouldBeNil)
+
+				Convey("Then a NewChannelReq is enqueued and pending", func() {
+					items, err := maccommand.ReadQueue(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+					So(items[0].Data[0], ShouldEqual, byte(lorawan.NewChannelReq))
+
+					pending, err := maccommand.ReadPending(p, ns.DevEUI, lorawan.NewChannelReq)
+					So(err, ShouldBeNil)
+					So(pending, ShouldResemble, []lorawan.MACCommandPayload{
+						&lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5},
+					})
+					So(ns.UplinkChannels, ShouldHaveLength, 3)
+				})
+
+				Convey("When reconciling again while the request is pending", func() {
+					So(Reconcile(ctx, &ns, 5), ShouldBeNil)
+
+					Convey("Then no mac-command is added", func() {
+						items, err := maccommand.ReadQueue(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 1)
+					})
+				})
+
+				Convey("When the node acknowledged the channel and is reconciled again", func() {
+					So(maccommand.DeletePending(p, ns.DevEUI, lorawan.NewChannelReq), ShouldBeNil)
+					So(maccommand.FlushQueue(p, ns.DevEUI), ShouldBeNil)
+					ns.SetUplinkChannel(session.UplinkChannel{Index: 3, Frequency: 867100000, MaxDR: 5, Enabled: true})
+					So(Reconcile(ctx, &ns, 5), ShouldBeNil)
+
+					Convey("Then a LinkADRReq disabling channel 2 is pending", func() {
+						pending, err := maccommand.ReadPending(p, ns.DevEUI, lorawan.LinkADRReq)
+						So(err, ShouldBeNil)
+						So(pending, ShouldHaveLength, 1)
+						So(pending[0].(*lorawan.LinkADRReqPayload).ChMask, ShouldResemble, lorawan.ChMask{true, true, false, true})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// Package channelstats implements the uplink channel utilization stats per
+// frequency and data-rate.
+package channelstats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/counters"
+)
+
+const (
+	// statsKeyTempl contains per hour a hash with the uplink counters per
+	// frequency and data-rate (field: frequency:dr)
+	statsKeyTempl = "lora:ns:channel:stats:%d"
+
+	// Retention defines how long the channel stats are stored.
+	Retention = time.Hour * 24 * 31
+)
+
+// Stats contains the number of uplinks received within an hour on a single
+// frequency and data-rate.
+type Stats struct {
+	Timestamp   time.Time // start of the hour
+	Frequency   int       // frequency in Hz
+	DataRate    int       // data-rate index of the band
+	UplinkCount int
+
+	// BandChannel is set when the frequency is one of the default uplink
+	// channels of the band (e.g. not an extra channel set by CFList or
+	// NewChannelReq).
+	BandChannel bool
+}
+
+type byFrequencyAndDR []Stats
+
+func (s byFrequencyAndDR) Len() int      { return len(s) }
+func (s byFrequencyAndDR) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byFrequencyAndDR) Less(i, j int) bool {
+	if s[i].Frequency == s[j].Frequency {
+		return s[i].DataRate < s[j].DataRate
+	}
+	return s[i].Frequency < s[j].Frequency
+}
+
+// RecordUplink records an uplink received on the given frequency and
+// data-rate.
+func RecordUplink(p *redis.Pool, frequency, dr int) error {
+	key := fmt.Sprintf(statsKeyTempl, time.Now().Truncate(time.Hour).Unix())
+
+	var b counters.Batch
+	b.HIncrBy(key, fmt.Sprintf("%d:%d", frequency, dr), 1, Retention)
+	if err := counters.Add(p, b); err != nil {
+		return errors.Wrap(err, "record uplink error")
+	}
+	return nil
+}
+
+// GetStats returns the channel stats per hour for the given time range,
+// sorted by time, frequency and data-rate.
+func GetStats(p *redis.Pool, start, end time.Time) ([]Stats, error) {
+	if !end.After(start) {
+		return nil, errors.New("end must be after start")
+	}
+	if min := time.Now().Add(-Retention); start.Before(min) {
+		start = min
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	var out []Stats
+	for t := start.Truncate(time.Hour); t.Before(end); t = t.Add(time.Hour) {
+		values, err := redis.IntMap(c.Do("HGETALL", fmt.Sprintf(statsKeyTempl, t.Unix())))
+		if err != nil {
+			return nil, errors.Wrap(err, "get channel stats error")
+		}
+
+		var stats []Stats
+		for field, v := range values {
+			s := Stats{
+				Timestamp:   t,
+				UplinkCount: v,
+			}
+			if _, err := fmt.Sscanf(field, "%d:%d", &s.Frequency, &s.DataRate); err != nil {
+				return nil, errors.Wrapf(err, "parse field '%s' error", field)
+			}
+			s.BandChannel = isBandChannel(s.Frequency)
+			stats = append(stats, s)
+		}
+		sort.Sort(byFrequencyAndDR(stats))
+		out = append(out, stats...)
+	}
+
+	return out, nil
+}
+
+// isBandChannel returns true when the given frequency is one of the
+// default uplink channels of the band.
+func isBandChannel(frequency int) bool {
+	for _, c := range common.Band.UplinkChannels {
+		if c.Frequency == frequency {
+			return true
+		}
+	}
+	return false
+}
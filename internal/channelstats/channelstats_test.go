@@ -0,0 +1,49 @@
+package channelstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChannelStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		Convey("When recording uplinks on a band channel and an extra channel", func() {
+			So(RecordUplink(p, 868100000, 5), ShouldBeNil)
+			So(RecordUplink(p, 868100000, 5), ShouldBeNil)
+			So(RecordUplink(p, 868100000, 0), ShouldBeNil)
+			So(RecordUplink(p, 867100000, 5), ShouldBeNil)
+
+			Convey("Then the stats contain the uplink count per frequency and data-rate", func() {
+				end := time.Now()
+				stats, err := GetStats(p, end.Add(-time.Hour), end)
+				So(err, ShouldBeNil)
+
+				hour := time.Now().Truncate(time.Hour)
+				for i := range stats {
+					So(stats[i].Timestamp.Equal(hour), ShouldBeTrue)
+					stats[i].Timestamp = time.Time{}
+				}
+				So(stats, ShouldResemble, []Stats{
+					{Frequency: 867100000, DataRate: 5, UplinkCount: 1, BandChannel: false},
+					{Frequency: 868100000, DataRate: 0, UplinkCount: 1, BandChannel: true},
+					{Frequency: 868100000, DataRate: 5, UplinkCount: 2, BandChannel: true},
+				})
+			})
+
+			Convey("Then requesting an invalid time range returns an error", func() {
+				now := time.Now()
+				_, err := GetStats(p, now, now.Add(-time.Hour))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,154 @@
+// Package chaos implements fault injection for resilience testing. It
+// wraps the gateway backend and the application-server client in order to
+// drop uplinks, fail gateway publishes and delay application-server
+// responses. It must not be enabled in production.
+package chaos
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/backend"
+)
+
+// ErrInjectedFailure is returned for failures injected by this package.
+var ErrInjectedFailure = errors.New("chaos: injected failure")
+
+// chance returns true with the given probability (0 - 1).
+func chance(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate
+}
+
+// Gateway wraps a gateway backend, dropping a percentage of the received
+// uplinks and failing a percentage of the downlink publishes.
+type Gateway struct {
+	backend.Gateway
+
+	uplinkDropRate     float64
+	publishFailureRate float64
+	rxPacketChan       chan gw.RXPacket
+}
+
+// NewGateway creates a new Gateway wrapping the given backend. The rates
+// are probabilities between 0 and 1.
+func NewGateway(b backend.Gateway, uplinkDropRate, publishFailureRate float64) *Gateway {
+	g := Gateway{
+		Gateway:            b,
+		uplinkDropRate:     uplinkDropRate,
+		publishFailureRate: publishFailureRate,
+		rxPacketChan:       make(chan gw.RXPacket),
+	}
+
+	log.WithFields(log.Fields{
+		"uplink_drop_rate":     uplinkDropRate,
+		"publish_failure_rate": publishFailureRate,
+	}).Warning("chaos: fault injection enabled for gateway backend, do not use in production")
+
+	go g.forwardRXPackets()
+	return &g
+}
+
+// forwardRXPackets forwards the received packets of the wrapped backend,
+// except for the dropped ones. The channel is closed once the channel of
+// the wrapped backend has been closed.
+func (g *Gateway) forwardRXPackets() {
+	for rxPacket := range g.Gateway.RXPacketChan() {
+		if chance(g.uplinkDropRate) {
+			log.WithFields(log.Fields{
+				"mac":   rxPacket.RXInfo.MAC,
+				"mtype": rxPacket.PHYPayload.MHDR.MType,
+			}).Info("chaos: uplink dropped")
+			continue
+		}
+		g.rxPacketChan <- rxPacket
+	}
+	close(g.rxPacketChan)
+}
+
+// RXPacketChan returns the channel containing the received (and not
+// dropped) packets.
+func (g *Gateway) RXPacketChan() chan gw.RXPacket {
+	return g.rxPacketChan
+}
+
+// SendTXPacket sends the given packet to the gateway, unless a failure is
+// injected.
+func (g *Gateway) SendTXPacket(txPacket gw.TXPacket) error {
+	if chance(g.publishFailureRate) {
+		log.WithField("mac", txPacket.TXInfo.MAC).Info("chaos: gateway publish failed")
+		return ErrInjectedFailure
+	}
+	return g.Gateway.SendTXPacket(txPacket)
+}
+
+// ApplicationServerClient wraps an application-server client, delaying
+// the responses of the calls on which the uplink / downlink timing
+// depends.
+type ApplicationServerClient struct {
+	as.ApplicationServerClient
+
+	delay     time.Duration
+	delayRate float64
+}
+
+// NewApplicationServerClient creates a new ApplicationServerClient, delaying
+// the given rate (0 - 1) of the JoinRequest, HandleDataUp and GetDataDown
+// calls with the given delay.
+func NewApplicationServerClient(c as.ApplicationServerClient, delay time.Duration, delayRate float64) *ApplicationServerClient {
+	log.WithFields(log.Fields{
+		"delay":      delay,
+		"delay_rate": delayRate,
+	}).Warning("chaos: fault injection enabled for application-server client, do not use in production")
+
+	return &ApplicationServerClient{
+		ApplicationServerClient: c,
+		delay:                   delay,
+		delayRate:               delayRate,
+	}
+}
+
+// sleep delays the call (for the given rate of calls), unless the context
+// is done first.
+func (c *ApplicationServerClient) sleep(ctx context.Context) error {
+	if !chance(c.delayRate) {
+		return nil
+	}
+
+	select {
+	case <-time.After(c.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JoinRequest calls the wrapped JoinRequest after the (injected) delay.
+func (c *ApplicationServerClient) JoinRequest(ctx context.Context, in *as.JoinRequestRequest, opts ...grpc.CallOption) (*as.JoinRequestResponse, error) {
+	if err := c.sleep(ctx); err != nil {
+		return nil, err
+	}
+	return c.ApplicationServerClient.JoinRequest(ctx, in, opts...)
+}
+
+// HandleDataUp calls the wrapped HandleDataUp after the (injected) delay.
+func (c *ApplicationServerClient) HandleDataUp(ctx context.Context, in *as.HandleDataUpRequest, opts ...grpc.CallOption) (*as.HandleDataUpResponse, error) {
+	if err := c.sleep(ctx); err != nil {
+		return nil, err
+	}
+	return c.ApplicationServerClient.HandleDataUp(ctx, in, opts...)
+}
+
+// GetDataDown calls the wrapped GetDataDown after the (injected) delay.
+func (c *ApplicationServerClient) GetDataDown(ctx context.Context, in *as.GetDataDownRequest, opts ...grpc.CallOption) (*as.GetDataDownResponse, error) {
+	if err := c.sleep(ctx); err != nil {
+		return nil, err
+	}
+	return c.ApplicationServerClient.GetDataDown(ctx, in, opts...)
+}
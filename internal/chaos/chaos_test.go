@@ -0,0 +1,83 @@
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGateway(t *testing.T) {
+	Convey("Given a test gateway backend", t, func() {
+		b := test.NewGatewayBackend()
+
+		Convey("Given a Gateway dropping all uplinks and failing all publishes", func() {
+			g := NewGateway(b, 1, 1)
+
+			Convey("Then received packets are dropped", func() {
+				b.RXPacketChan() <- gw.RXPacket{}
+				So(g.Close(), ShouldBeNil)
+
+				var count int
+				for range g.RXPacketChan() {
+					count++
+				}
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then publishing a packet fails", func() {
+				So(g.SendTXPacket(gw.TXPacket{}), ShouldEqual, ErrInjectedFailure)
+				So(b.TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Given a Gateway without faults", func() {
+			g := NewGateway(b, 0, 0)
+
+			Convey("Then received packets are forwarded", func() {
+				b.RXPacketChan() <- gw.RXPacket{}
+				So(g.Close(), ShouldBeNil)
+
+				var count int
+				for range g.RXPacketChan() {
+					count++
+				}
+				So(count, ShouldEqual, 1)
+			})
+
+			Convey("Then packets are published", func() {
+				So(g.SendTXPacket(gw.TXPacket{}), ShouldBeNil)
+				So(b.TXPacketChan, ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestApplicationServerClient(t *testing.T) {
+	Convey("Given an ApplicationServerClient delaying all calls with 50ms", t, func() {
+		appClient := test.NewApplicationClient()
+		c := NewApplicationServerClient(appClient, 50*time.Millisecond, 1)
+
+		Convey("Then HandleDataUp is delayed", func() {
+			start := time.Now()
+			_, err := c.HandleDataUp(context.Background(), &as.HandleDataUpRequest{})
+			So(err, ShouldBeNil)
+			So(time.Since(start) >= 50*time.Millisecond, ShouldBeTrue)
+			So(appClient.HandleDataUpChan, ShouldHaveLength, 1)
+		})
+
+		Convey("Then the call is aborted when the context is done first", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+
+			_, err := c.GetDataDown(ctx, &as.GetDataDownRequest{})
+			So(err, ShouldEqual, context.DeadlineExceeded)
+			So(appClient.GetDataDownChan, ShouldHaveLength, 0)
+		})
+	})
+}
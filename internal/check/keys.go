@@ -0,0 +1,186 @@
+package check
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+)
+
+// KeyGroup describes a group of Redis keys audited by AuditKeys.
+type KeyGroup struct {
+	Name    string // name of the group
+	Pattern string // pattern matching the keys of the group
+
+	// TTLBounded defines if the keys of the group must always have a TTL.
+	// Keys without TTL have been left behind (e.g. by a crashed collection
+	// routine) and are removed on cleanup.
+	TTLBounded bool
+}
+
+// KeyGroups contains the groups of Redis keys audited by AuditKeys.
+var KeyGroups = []KeyGroup{
+	{Name: "uplink-collect", Pattern: "loraserver:rx:collect:*", TTLBounded: true},
+	{Name: "uplink-lock", Pattern: "lora:ns:uplink:lock:*", TTLBounded: true},
+	{Name: "uplink-retransmission", Pattern: "lora:ns:uplink:retransmission:*:*", TTLBounded: true},
+	{Name: "uplink-ack", Pattern: "lora:ns:uplink:ack:*:*", TTLBounded: true},
+	{Name: "join-request-suppression", Pattern: "loraserver:rx:join:*", TTLBounded: true},
+	{Name: "stats-lock", Pattern: "lora:ns:stats:lock:*", TTLBounded: true},
+	{Name: "downlink-deduplication", Pattern: "lora:ns:downlink:dedup:*", TTLBounded: true},
+	{Name: "downlink-frame", Pattern: "lora:ns:downlink:frame:*", TTLBounded: true},
+	{Name: "downlink-attempts", Pattern: "lora:ns:downlink:attempts:*:*", TTLBounded: true},
+	{Name: "ack-lock", Pattern: "lora:ns:ack:lock:*", TTLBounded: true},
+	{Name: "gateway-event-lock", Pattern: "lora:ns:gw:event:lock:*", TTLBounded: true},
+	{Name: "gateway-events", Pattern: "lora:ns:gw:events:*", TTLBounded: true},
+	{Name: "join-stats", Pattern: "lora:ns:join:stats:*", TTLBounded: true},
+	{Name: "gateway-stats-received", Pattern: "lora:ns:gw:stats_received:*", TTLBounded: true},
+	{Name: "security-lock", Pattern: "lora:ns:security:lock:*:*:*", TTLBounded: true},
+	{Name: "security-events", Pattern: "lora:ns:security:events:*", TTLBounded: true},
+	{Name: "security-quarantine", Pattern: "lora:ns:security:quarantine:*"},
+	{Name: "downlink-confirmed-pending", Pattern: "lora:ns:node:confirmed:pending:*", TTLBounded: true},
+	{Name: "downlink-adr-ack-req", Pattern: "lora:ns:node:adr_ack_req:*", TTLBounded: true},
+	{Name: "downlink-fcnt-reservation", Pattern: "lora:ns:node:fcnt:reserved:*", TTLBounded: true},
+	{Name: "classc-retry", Pattern: "lora:ns:node:classc:retry:*", TTLBounded: true},
+	{Name: "rx-window-outcomes", Pattern: "lora:ns:node:rx_window:outcomes:*:*", TTLBounded: true},
+	{Name: "rx-window-pending", Pattern: "lora:ns:node:rx_window:pending:*", TTLBounded: true},
+	{Name: "gateway-downlink-slots", Pattern: "lora:ns:gw:downlink_slots:*", TTLBounded: true},
+	{Name: "gateway-tx-failures", Pattern: "lora:ns:gw:tx_failures:*", TTLBounded: true},
+	{Name: "gateway-airtime", Pattern: "lora:ns:airtime:gw:*:*", TTLBounded: true},
+	{Name: "gateway-device-airtime", Pattern: "lora:ns:airtime:gw-dev:*:*", TTLBounded: true},
+	{Name: "device-daily-airtime", Pattern: "lora:ns:airtime:device:*:*", TTLBounded: true},
+	{Name: "embedded-as-session", Pattern: "lora:as:embedded:session:*", TTLBounded: true},
+	{Name: "uplink-rule-counter", Pattern: "lora:ns:rule:*:*", TTLBounded: true},
+	{Name: "uplink-stats", Pattern: "lora:ns:uplink:stats:*", TTLBounded: true},
+	{Name: "uplink-rate", Pattern: "lora:ns:uplink:rate:*:*", TTLBounded: true},
+	{Name: "channel-noise", Pattern: "lora:ns:channel:noise:*", TTLBounded: true},
+	{Name: "bench-downlink", Pattern: "lora:ns:bench:downlink:*", TTLBounded: true},
+	{Name: "mac-command-queue", Pattern: macQueueKeyPrefix + "*"},
+	{Name: "mac-command-pending", Pattern: "lora:ns:mac:pending:*"},
+}
+
+// deleteWithoutTTLScript removes the given key, but only when it (still)
+// has no TTL (e.g. when the TTL was set between reading and removing it).
+var deleteWithoutTTLScript = redis.NewScript(1, `
+	if redis.call("PTTL", KEYS[1]) == -1 then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// KeyGroupResult contains the audit result of a single KeyGroup.
+type KeyGroupResult struct {
+	KeyGroup
+	Keys        int   // the number of keys
+	MemoryBytes int64 // memory footprint (0 when MEMORY USAGE is not supported)
+	WithoutTTL  int   // the number of keys without TTL
+	Removed     int   // the number of removed keys without TTL
+}
+
+// AuditKeys reports the cardinality and memory footprint of the
+// de-duplication / collection keys and mac-command queues. When cleanup is
+// set to true, the keys of TTL bounded groups without a TTL are removed.
+func AuditKeys(p *redis.Pool, cleanup bool) ([]KeyGroupResult, error) {
+	c := p.Get()
+	defer c.Close()
+
+	// MEMORY USAGE is only available since Redis 4.0
+	_, err := c.Do("MEMORY", "USAGE", "lora:ns:check:memory_usage")
+	memoryUsage := err == nil
+
+	var out []KeyGroupResult
+	for _, kg := range KeyGroups {
+		res, err := auditKeyGroup(p, kg, memoryUsage, cleanup)
+		if err != nil {
+			return nil, errors.Wrapf(err, "audit %s keys error", kg.Name)
+		}
+		out = append(out, res)
+	}
+
+	return out, nil
+}
+
+func auditKeyGroup(p *redis.Pool, kg KeyGroup, memoryUsage, cleanup bool) (KeyGroupResult, error) {
+	res := KeyGroupResult{KeyGroup: kg}
+
+	keys, err := common.ScanKeys(p, kg.Pattern)
+	if err != nil {
+		return res, err
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, key := range keys {
+		ttl, err := redis.Int64(c.Do("PTTL", key))
+		if err != nil {
+			return res, errors.Wrap(err, "get ttl error")
+		}
+		// the key expired in the meantime
+		if ttl == -2 {
+			continue
+		}
+		res.Keys++
+
+		if memoryUsage {
+			b, err := redis.Int64(c.Do("MEMORY", "USAGE", key))
+			if err != nil && err != redis.ErrNil {
+				return res, errors.Wrap(err, "get memory usage error")
+			}
+			res.MemoryBytes += b
+		}
+
+		if ttl != -1 {
+			continue
+		}
+		res.WithoutTTL++
+
+		if cleanup && kg.TTLBounded {
+			removed, err := redis.Int(deleteWithoutTTLScript.Do(c, key))
+			if err != nil {
+				return res, errors.Wrap(err, "delete key error")
+			}
+			res.Removed += removed
+		}
+	}
+
+	return res, nil
+}
+
+// RunKeyAudit audits the keys (with cleanup) on the given interval, until
+// the done channel is closed. The audit is only executed when the given
+// elector is the leader (or when nil).
+func RunKeyAudit(p *redis.Pool, elector *leader.Elector, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+
+			results, err := AuditKeys(p, true)
+			if err != nil {
+				log.Errorf("audit keys error: %s", err)
+				continue
+			}
+
+			for _, res := range results {
+				log.WithFields(log.Fields{
+					"group":        res.Name,
+					"keys":         res.Keys,
+					"memory_bytes": res.MemoryBytes,
+					"without_ttl":  res.WithoutTTL,
+					"removed":      res.Removed,
+				}).Info("redis key audit completed")
+			}
+		case <-done:
+			return
+		}
+	}
+}
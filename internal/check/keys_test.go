@@ -0,0 +1,79 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAuditKeys(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		c := p.Get()
+		defer c.Close()
+
+		Convey("Given a collect set with TTL, a collect set without TTL, a mac-command queue without TTL and the suppressed retransmission counter", func() {
+			_, err := c.Do("SET", "loraserver:rx:collect:01020304", "a", "PX", 10000)
+			So(err, ShouldBeNil)
+			_, err = c.Do("SET", "loraserver:rx:collect:04030201", "b")
+			So(err, ShouldBeNil)
+			_, err = c.Do("RPUSH", "lora:ns:mac:queue:0102030405060708", "c")
+			So(err, ShouldBeNil)
+			_, err = c.Do("INCR", "lora:ns:uplink:retransmission:suppressed")
+			So(err, ShouldBeNil)
+
+			getResult := func(results []KeyGroupResult, name string) KeyGroupResult {
+				for _, res := range results {
+					if res.Name == name {
+						return res
+					}
+				}
+				return KeyGroupResult{}
+			}
+
+			Convey("Then AuditKeys reports the keys without removing them", func() {
+				results, err := AuditKeys(p, false)
+				So(err, ShouldBeNil)
+				So(results, ShouldHaveLength, len(KeyGroups))
+
+				res := getResult(results, "uplink-collect")
+				So(res.Keys, ShouldEqual, 2)
+				So(res.WithoutTTL, ShouldEqual, 1)
+				So(res.Removed, ShouldEqual, 0)
+
+				res = getResult(results, "mac-command-queue")
+				So(res.Keys, ShouldEqual, 1)
+				So(res.WithoutTTL, ShouldEqual, 1)
+
+				So(getResult(results, "uplink-retransmission").Keys, ShouldEqual, 0)
+			})
+
+			Convey("When running AuditKeys with cleanup", func() {
+				results, err := AuditKeys(p, true)
+				So(err, ShouldBeNil)
+
+				Convey("Then only the collect set without TTL has been removed", func() {
+					So(getResult(results, "uplink-collect").Removed, ShouldEqual, 1)
+					So(getResult(results, "mac-command-queue").Removed, ShouldEqual, 0)
+
+					for key, exists := range map[string]bool{
+						"loraserver:rx:collect:01020304":           true,
+						"loraserver:rx:collect:04030201":           false,
+						"lora:ns:mac:queue:0102030405060708":       true,
+						"lora:ns:uplink:retransmission:suppressed": true,
+					} {
+						n, err := c.Do("EXISTS", key)
+						So(err, ShouldBeNil)
+						So(n == int64(1), ShouldEqual, exists)
+					}
+				})
+			})
+		})
+	})
+}
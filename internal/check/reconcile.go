@@ -0,0 +1,47 @@
+package check
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/downlink"
+)
+
+// ReconcileResult contains the result of Reconcile.
+type ReconcileResult struct {
+	RemovedKeys      int // orphaned keys without TTL (e.g. collect sets and locks)
+	ResumedDownlinks int // rescheduled pending confirmed downlinks
+	RemovedDownlinks int // pending confirmed downlinks of removed nodes
+}
+
+// Reconcile reconciles the Redis state left behind by a crashed LoRa Server
+// instance, so that devices are not left waiting on orphaned state. It
+// removes the half-collected frames and locks without TTL and resumes (or
+// removes) the pending confirmed downlinks. It is intended to be called on
+// startup.
+func Reconcile(p *redis.Pool) (ReconcileResult, error) {
+	var out ReconcileResult
+
+	results, err := AuditKeys(p, true)
+	if err != nil {
+		return out, errors.Wrap(err, "audit keys error")
+	}
+	for _, res := range results {
+		if res.Removed == 0 {
+			continue
+		}
+		log.WithFields(log.Fields{
+			"group":   res.Name,
+			"removed": res.Removed,
+		}).Warning("orphaned redis keys removed")
+		out.RemovedKeys += res.Removed
+	}
+
+	out.ResumedDownlinks, out.RemovedDownlinks, err = downlink.ReconcilePendingDownlinks(p)
+	if err != nil {
+		return out, errors.Wrap(err, "reconcile pending downlinks error")
+	}
+
+	return out, nil
+}
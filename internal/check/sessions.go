@@ -0,0 +1,307 @@
+// Package check implements consistency checks on the data stored by
+// LoRa Server.
+package check
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	devAddrKeyPrefix     = "lora:ns:devaddr:"
+	nodeSessionKeyPrefix = "lora:ns:session:"
+	macQueueKeyPrefix    = "lora:ns:mac:queue:"
+
+	maxRXDelay     = 15
+	maxRX1DROffset = 7 // RX1DRoffset is a 3 bit field in the DLSettings
+)
+
+// Issue describes a single consistency issue.
+type Issue struct {
+	Key         string // the Redis key containing the issue
+	Description string // human-readable description of the issue
+	Repaired    bool   // set to true when the issue has been repaired
+}
+
+// SessionsResult contains the result of CheckSessions.
+type SessionsResult struct {
+	NodeSessions int // the number of checked node-sessions
+	DevAddrs     int // the number of checked DevAddr pointer sets
+	MACQueues    int // the number of checked mac-command queues
+
+	// OrphanedDevAddrPointers contains the DevAddr -> DevEUI pointers for
+	// which the node-session does not exist or uses an other DevAddr.
+	OrphanedDevAddrPointers []Issue
+
+	// MissingDevAddrPointers contains the node-sessions which are not
+	// referenced by the DevAddr -> DevEUI pointer set.
+	MissingDevAddrPointers []Issue
+
+	// InvalidNodeSessions contains the node-sessions with an impossible
+	// MAC state (e.g. data-rate out of band range). These are never
+	// repaired automatically as this would de-sync the node.
+	InvalidNodeSessions []Issue
+
+	// MismatchedMACQueues contains the mac-command queues without
+	// node-session or containing items for an other DevEUI.
+	MismatchedMACQueues []Issue
+}
+
+// IssueCount returns the total number of issues found.
+func (r SessionsResult) IssueCount() int {
+	return len(r.OrphanedDevAddrPointers) + len(r.MissingDevAddrPointers) + len(r.InvalidNodeSessions) + len(r.MismatchedMACQueues)
+}
+
+// CheckSessions scans the Redis database for orphaned DevAddr pointers,
+// node-sessions with an impossible MAC state and mismatched mac-command
+// queues. When repair is set to true, orphaned and missing pointers and
+// mismatched mac-command queues will be repaired.
+func CheckSessions(p *redis.Pool, repair bool) (SessionsResult, error) {
+	var res SessionsResult
+	var err error
+
+	if err = checkDevAddrPointers(p, repair, &res); err != nil {
+		return res, errors.Wrap(err, "check devaddr pointers error")
+	}
+
+	if err = checkNodeSessions(p, repair, &res); err != nil {
+		return res, errors.Wrap(err, "check node-sessions error")
+	}
+
+	if err = checkMACQueues(p, repair, &res); err != nil {
+		return res, errors.Wrap(err, "check mac-command queues error")
+	}
+
+	log.WithFields(log.Fields{
+		"node_sessions": res.NodeSessions,
+		"dev_addrs":     res.DevAddrs,
+		"mac_queues":    res.MACQueues,
+		"issues":        res.IssueCount(),
+		"repair":        repair,
+	}).Info("node-session consistency check completed")
+
+	return res, nil
+}
+
+func checkDevAddrPointers(p *redis.Pool, repair bool, res *SessionsResult) error {
+	keys, err := common.ScanKeys(p, devAddrKeyPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, key := range keys {
+		res.DevAddrs++
+
+		var devAddr lorawan.DevAddr
+		if err := devAddr.UnmarshalText([]byte(strings.TrimPrefix(key, devAddrKeyPrefix))); err != nil {
+			res.OrphanedDevAddrPointers = append(res.OrphanedDevAddrPointers, Issue{
+				Key:         key,
+				Description: fmt.Sprintf("invalid DevAddr in key: %s", err),
+			})
+			continue
+		}
+
+		members, err := redis.ByteSlices(c.Do("SMEMBERS", key))
+		if err != nil {
+			return errors.Wrap(err, "get members error")
+		}
+
+		for _, b := range members {
+			var devEUI lorawan.EUI64
+			copy(devEUI[:], b)
+
+			ns, err := session.GetNodeSession(p, devEUI)
+			if err != nil && errors.Cause(err) != session.ErrDoesNotExist {
+				return errors.Wrap(err, "get node-session error")
+			}
+
+			var desc string
+			if err != nil {
+				desc = fmt.Sprintf("node-session for DevEUI %s does not exist", devEUI)
+			} else if ns.DevAddr != devAddr {
+				desc = fmt.Sprintf("node-session for DevEUI %s uses DevAddr %s", devEUI, ns.DevAddr)
+			} else {
+				continue
+			}
+
+			issue := Issue{Key: key, Description: desc}
+			if repair {
+				if _, err := c.Do("SREM", key, b); err != nil {
+					return errors.Wrap(err, "remove member error")
+				}
+				issue.Repaired = true
+			}
+			res.OrphanedDevAddrPointers = append(res.OrphanedDevAddrPointers, issue)
+		}
+	}
+
+	return nil
+}
+
+func checkNodeSessions(p *redis.Pool, repair bool, res *SessionsResult) error {
+	keys, err := common.ScanKeys(p, nodeSessionKeyPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, key := range keys {
+		res.NodeSessions++
+
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(strings.TrimPrefix(key, nodeSessionKeyPrefix))); err != nil {
+			res.InvalidNodeSessions = append(res.InvalidNodeSessions, Issue{
+				Key:         key,
+				Description: fmt.Sprintf("invalid DevEUI in key: %s", err),
+			})
+			continue
+		}
+
+		ns, err := session.GetNodeSession(p, devEUI)
+		if err != nil {
+			if errors.Cause(err) == session.ErrDoesNotExist {
+				// expired in the meantime
+				continue
+			}
+			res.InvalidNodeSessions = append(res.InvalidNodeSessions, Issue{
+				Key:         key,
+				Description: fmt.Sprintf("get node-session error: %s", err),
+			})
+			continue
+		}
+
+		for _, desc := range validateNodeSession(ns) {
+			res.InvalidNodeSessions = append(res.InvalidNodeSessions, Issue{
+				Key:         key,
+				Description: desc,
+			})
+		}
+
+		isMember, err := redis.Bool(c.Do("SISMEMBER", devAddrKeyPrefix+ns.DevAddr.String(), ns.DevEUI[:]))
+		if err != nil {
+			return errors.Wrap(err, "is member error")
+		}
+		if !isMember {
+			issue := Issue{
+				Key:         key,
+				Description: fmt.Sprintf("node-session is not referenced by DevAddr %s", ns.DevAddr),
+			}
+			if repair {
+				if err := session.SaveNodeSession(p, ns); err != nil {
+					return errors.Wrap(err, "save node-session error")
+				}
+				issue.Repaired = true
+			}
+			res.MissingDevAddrPointers = append(res.MissingDevAddrPointers, issue)
+		}
+	}
+
+	return nil
+}
+
+func checkMACQueues(p *redis.Pool, repair bool, res *SessionsResult) error {
+	keys, err := common.ScanKeys(p, macQueueKeyPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, key := range keys {
+		res.MACQueues++
+
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(strings.TrimPrefix(key, macQueueKeyPrefix))); err != nil {
+			res.MismatchedMACQueues = append(res.MismatchedMACQueues, Issue{
+				Key:         key,
+				Description: fmt.Sprintf("invalid DevEUI in key: %s", err),
+			})
+			continue
+		}
+
+		exists, err := session.NodeSessionExists(p, devEUI)
+		if err != nil {
+			return errors.Wrap(err, "node-session exists error")
+		}
+		if !exists {
+			issue := Issue{
+				Key:         key,
+				Description: fmt.Sprintf("node-session for DevEUI %s does not exist", devEUI),
+			}
+			if repair {
+				if _, err := c.Do("DEL", key); err != nil {
+					return errors.Wrap(err, "delete mac-command queue error")
+				}
+				issue.Repaired = true
+			}
+			res.MismatchedMACQueues = append(res.MismatchedMACQueues, issue)
+			continue
+		}
+
+		values, err := redis.ByteSlices(c.Do("LRANGE", key, 0, -1))
+		if err != nil {
+			return errors.Wrap(err, "read mac-command queue error")
+		}
+
+		for _, b := range values {
+			var qi maccommand.QueueItem
+			var desc string
+			if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&qi); err != nil {
+				desc = fmt.Sprintf("decode mac-command queue item error: %s", err)
+			} else if qi.DevEUI != devEUI {
+				desc = fmt.Sprintf("mac-command %s belongs to DevEUI %s", hex.EncodeToString(qi.Data), qi.DevEUI)
+			} else {
+				continue
+			}
+
+			issue := Issue{Key: key, Description: desc}
+			if repair {
+				if _, err := c.Do("LREM", key, 0, b); err != nil {
+					return errors.Wrap(err, "remove mac-command queue item error")
+				}
+				issue.Repaired = true
+			}
+			res.MismatchedMACQueues = append(res.MismatchedMACQueues, issue)
+		}
+	}
+
+	return nil
+}
+
+// validateNodeSession returns the impossible MAC states of the given
+// node-session.
+func validateNodeSession(ns session.NodeSession) []string {
+	var out []string
+
+	if maxDR := len(common.Band.DataRates) - 1; int(ns.RX2DR) > maxDR {
+		out = append(out, fmt.Sprintf("RX2DR %d exceeds the max data-rate of the band (%d)", ns.RX2DR, maxDR))
+	}
+
+	if ns.RX1DROffset > maxRX1DROffset {
+		out = append(out, fmt.Sprintf("RX1DROffset %d exceeds %d", ns.RX1DROffset, maxRX1DROffset))
+	}
+
+	if ns.RXDelay > maxRXDelay {
+		out = append(out, fmt.Sprintf("RXDelay %d exceeds %d", ns.RXDelay, maxRXDelay))
+	}
+
+	return out
+}
@@ -0,0 +1,99 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCheckSessions(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		Convey("Given a valid node-session with a mac-command queue item", func() {
+			ns := session.NodeSession{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}
+			So(session.SaveNodeSession(p, ns), ShouldBeNil)
+			So(maccommand.AddToQueue(p, maccommand.QueueItem{
+				DevEUI: ns.DevEUI,
+				Data:   []byte{1, 2, 3},
+			}), ShouldBeNil)
+
+			Convey("Then CheckSessions does not report any issues", func() {
+				res, err := CheckSessions(p, false)
+				So(err, ShouldBeNil)
+				So(res.NodeSessions, ShouldEqual, 1)
+				So(res.DevAddrs, ShouldEqual, 1)
+				So(res.MACQueues, ShouldEqual, 1)
+				So(res.IssueCount(), ShouldEqual, 0)
+			})
+
+			Convey("Given the node-session has an impossible MAC state", func() {
+				ns.RXDelay = 16
+				ns.RX2DR = uint8(len(common.Band.DataRates))
+				So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+				Convey("Then CheckSessions reports both issues", func() {
+					res, err := CheckSessions(p, true)
+					So(err, ShouldBeNil)
+					So(res.InvalidNodeSessions, ShouldHaveLength, 2)
+					So(res.InvalidNodeSessions[0].Repaired, ShouldBeFalse)
+				})
+			})
+
+			Convey("Given the node-session switched to an other DevAddr", func() {
+				ns.DevAddr = lorawan.DevAddr{4, 3, 2, 1}
+				So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+				Convey("Then CheckSessions reports the orphaned pointer", func() {
+					res, err := CheckSessions(p, false)
+					So(err, ShouldBeNil)
+					So(res.OrphanedDevAddrPointers, ShouldHaveLength, 1)
+					So(res.OrphanedDevAddrPointers[0].Repaired, ShouldBeFalse)
+
+					Convey("When running CheckSessions in repair mode", func() {
+						res, err := CheckSessions(p, true)
+						So(err, ShouldBeNil)
+						So(res.OrphanedDevAddrPointers, ShouldHaveLength, 1)
+						So(res.OrphanedDevAddrPointers[0].Repaired, ShouldBeTrue)
+
+						Convey("Then a next check does not report any issues", func() {
+							res, err := CheckSessions(p, false)
+							So(err, ShouldBeNil)
+							So(res.IssueCount(), ShouldEqual, 0)
+						})
+					})
+				})
+			})
+
+			Convey("Given the node-session has been deleted", func() {
+				So(session.DeleteNodeSession(p, ns.DevEUI), ShouldBeNil)
+
+				Convey("When running CheckSessions in repair mode", func() {
+					res, err := CheckSessions(p, true)
+					So(err, ShouldBeNil)
+
+					Convey("Then the orphaned pointer and mac-command queue are repaired", func() {
+						So(res.OrphanedDevAddrPointers, ShouldHaveLength, 1)
+						So(res.MismatchedMACQueues, ShouldHaveLength, 1)
+						So(res.MismatchedMACQueues[0].Repaired, ShouldBeTrue)
+
+						items, err := maccommand.ReadQueue(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 0)
+					})
+				})
+			})
+		})
+	})
+}
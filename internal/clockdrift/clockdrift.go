@@ -0,0 +1,258 @@
+// Package clockdrift implements the estimation of the transmission period
+// and clock drift of periodic nodes, based on the receive time and
+// frame-counter of their last uplinks. The period is estimated as the
+// slope of the least-squares fit of the receive time over the
+// frame-counter, so that lost uplinks do not affect the estimation. The
+// drift is the deviation of the estimated period from the nominal period
+// of the node (e.g. caused by the tolerance of its RTC). Class-B nodes
+// with a large drift risk missing their ping-slots.
+package clockdrift
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+const (
+	// samplesKeyTempl contains per node the frame-counter and receive time
+	// of the last uplinks (list of "fcnt:unix-nano", newest first).
+	samplesKeyTempl = "lora:ns:node:clockdrift:%s"
+
+	// Samples defines the number of uplinks kept per node.
+	Samples = 64
+
+	// MinSamples defines the min. number of uplinks needed for the
+	// estimation.
+	MinSamples = 8
+
+	// maxJitterRatio defines the max. jitter (standard deviation of the
+	// receive time from the fit), relative to the period, of a periodic
+	// node.
+	maxJitterRatio = 0.05
+
+	// BeaconPeriod defines the Class-B beacon period, over which the drift
+	// of a node accumulates before it re-synchronizes with the beacon.
+	BeaconPeriod = 128 * time.Second
+
+	// TTL defines how long the uplinks of a node are kept after its last
+	// uplink.
+	TTL = time.Hour * 24 * 30
+)
+
+// ErrNotEnoughSamples is returned when less than MinSamples uplinks have
+// been recorded for the node.
+var ErrNotEnoughSamples = errors.New("not enough uplinks for the clock drift estimation")
+
+// Sample contains the frame-counter and receive time of an uplink.
+type Sample struct {
+	FCnt uint32
+	Time time.Time
+}
+
+// Drift contains the estimated period and clock drift of a node.
+type Drift struct {
+	DevEUI        lorawan.EUI64
+	AppEUI        lorawan.EUI64
+	Samples       int
+	Period        time.Duration // estimated time between two frame-counters
+	NominalPeriod time.Duration
+	DriftPPM      float64       // deviation of the period from the nominal period
+	Jitter        time.Duration // standard deviation of the receive time from the fit
+	Periodic      bool          // the jitter is within maxJitterRatio of the period
+
+	// BeaconPeriodError is the timing error of the node accumulated over a
+	// Class-B beacon period.
+	BeaconPeriodError time.Duration
+}
+
+// Filters defines the clock drift filters.
+type Filters struct {
+	MinDriftPPM float64        // min. absolute drift
+	AppEUI      *lorawan.EUI64 // only nodes of this AppEUI
+}
+
+// RecordUplink records the frame-counter and receive time of an uplink of
+// the given node. The recorded uplinks are reset when the frame-counter is
+// lower than the frame-counter of the last recorded uplink (e.g. after a
+// re-join). Retransmissions (the frame-counter of the last recorded uplink)
+// are ignored.
+func RecordUplink(p *redis.Pool, devEUI lorawan.EUI64, fCnt uint32, t time.Time) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(samplesKeyTempl, devEUI)
+
+	last, err := redis.String(c.Do("LINDEX", key, 0))
+	if err != nil && err != redis.ErrNil {
+		return errors.Wrap(err, "get last clock drift sample error")
+	}
+
+	var reset bool
+	if err == nil {
+		s, err := parseSample(last)
+		if err != nil {
+			return err
+		}
+		if fCnt == s.FCnt {
+			return nil
+		}
+		reset = fCnt < s.FCnt
+	}
+
+	c.Send("MULTI")
+	if reset {
+		c.Send("DEL", key)
+	}
+	c.Send("LPUSH", key, fmt.Sprintf("%d:%d", fCnt, t.UnixNano()))
+	c.Send("LTRIM", key, 0, Samples-1)
+	c.Send("PEXPIRE", key, int64(TTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record clock drift sample error")
+	}
+	return nil
+}
+
+// GetSamples returns the recorded uplinks of the given node (oldest
+// first).
+func GetSamples(p *redis.Pool, devEUI lorawan.EUI64) ([]Sample, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("LRANGE", fmt.Sprintf(samplesKeyTempl, devEUI), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get clock drift samples error")
+	}
+
+	samples := make([]Sample, len(values))
+	for i, v := range values {
+		s, err := parseSample(v)
+		if err != nil {
+			return nil, err
+		}
+		samples[len(values)-1-i] = s
+	}
+	return samples, nil
+}
+
+// GetDrift returns the estimated period and clock drift of the given node.
+// See Estimate for the nominal period.
+func GetDrift(p *redis.Pool, devEUI lorawan.EUI64, nominalPeriod time.Duration) (Drift, error) {
+	samples, err := GetSamples(p, devEUI)
+	if err != nil {
+		return Drift{}, err
+	}
+
+	d, err := Estimate(samples, nominalPeriod)
+	if err != nil {
+		return d, err
+	}
+	d.DevEUI = devEUI
+	return d, nil
+}
+
+// GetNode returns the estimated period and clock drift of the given
+// node-session. It returns false when there are not enough uplinks, when
+// the node is not periodic or when it does not match the given filters.
+func GetNode(p *redis.Pool, ns session.NodeSession, filters Filters) (Drift, bool, error) {
+	if filters.AppEUI != nil && ns.AppEUI != *filters.AppEUI {
+		return Drift{}, false, nil
+	}
+
+	d, err := GetDrift(p, ns.DevEUI, 0)
+	if err != nil {
+		if err == ErrNotEnoughSamples {
+			return d, false, nil
+		}
+		return d, false, err
+	}
+	d.AppEUI = ns.AppEUI
+
+	if !d.Periodic || math.Abs(d.DriftPPM) < filters.MinDriftPPM {
+		return d, false, nil
+	}
+	return d, true, nil
+}
+
+// Estimate returns the estimated period and clock drift for the given
+// uplinks (oldest first). When no nominal period is given, the estimated
+// period rounded to the nearest second is used, in which case only a drift
+// of less than half a second per period can be detected.
+func Estimate(samples []Sample, nominalPeriod time.Duration) (Drift, error) {
+	d := Drift{Samples: len(samples)}
+	if len(samples) < MinSamples {
+		return d, ErrNotEnoughSamples
+	}
+
+	// least-squares fit of the receive time (y, seconds) over the
+	// frame-counter (x), relative to the first uplink
+	n := float64(len(samples))
+	var sumX, sumY float64
+	x := make([]float64, len(samples))
+	y := make([]float64, len(samples))
+	for i, s := range samples {
+		x[i] = float64(s.FCnt - samples[0].FCnt)
+		y[i] = s.Time.Sub(samples[0].Time).Seconds()
+		sumX += x[i]
+		sumY += y[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for i := range x {
+		sxx += (x[i] - meanX) * (x[i] - meanX)
+		sxy += (x[i] - meanX) * (y[i] - meanY)
+	}
+	if sxx == 0 {
+		return d, ErrNotEnoughSamples
+	}
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var sse float64
+	for i := range x {
+		r := y[i] - (intercept + slope*x[i])
+		sse += r * r
+	}
+	jitter := math.Sqrt(sse / (n - 2))
+
+	d.Period = time.Duration(slope * float64(time.Second))
+	d.Jitter = time.Duration(jitter * float64(time.Second))
+	d.Periodic = slope > 0 && jitter <= slope*maxJitterRatio
+
+	d.NominalPeriod = nominalPeriod
+	if d.NominalPeriod <= 0 {
+		d.NominalPeriod = time.Duration(math.Floor(slope+0.5)) * time.Second
+	}
+	if d.NominalPeriod > 0 {
+		d.DriftPPM = (slope - d.NominalPeriod.Seconds()) / d.NominalPeriod.Seconds() * 1e6
+		d.BeaconPeriodError = time.Duration(math.Abs(d.DriftPPM) / 1e6 * float64(BeaconPeriod))
+	}
+
+	return d, nil
+}
+
+func parseSample(s string) (Sample, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return Sample{}, fmt.Errorf("invalid clock drift sample: %s", s)
+	}
+	fCnt, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return Sample{}, errors.Wrap(err, "parse fcnt error")
+	}
+	nsec, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Sample{}, errors.Wrap(err, "parse time error")
+	}
+	return Sample{FCnt: uint32(fCnt), Time: time.Unix(0, nsec)}, nil
+}
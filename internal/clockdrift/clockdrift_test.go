@@ -0,0 +1,176 @@
+package clockdrift
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+// getSamples returns the given number of uplinks, starting at the given
+// frame-counter, transmitted every period (with the given offsets added to
+// the receive time of the consecutive uplinks).
+func getSamples(n int, fCnt uint32, period time.Duration, offsets ...time.Duration) []Sample {
+	start := time.Now()
+	var out []Sample
+	for i := 0; i < n; i++ {
+		t := start.Add(time.Duration(i) * period)
+		if len(offsets) > 0 {
+			t = t.Add(offsets[i%len(offsets)])
+		}
+		out = append(out, Sample{FCnt: fCnt + uint32(i), Time: t})
+	}
+	return out
+}
+
+func TestEstimate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		// 600 seconds + 60 ppm
+		drifting := 600*time.Second + 36*time.Millisecond
+
+		lost := getSamples(20, 10, drifting)
+		lost = append(lost[:5], lost[8:]...)
+
+		tests := []struct {
+			Name              string
+			Samples           []Sample
+			NominalPeriod     time.Duration
+			ExpectedPeriod    time.Duration
+			ExpectedDriftPPM  float64
+			ExpectedPeriodic  bool
+			ExpectedBeaconErr time.Duration
+			ExpectedError     error
+		}{
+			{
+				Name:          "not enough samples",
+				Samples:       getSamples(MinSamples-1, 10, time.Minute),
+				ExpectedError: ErrNotEnoughSamples,
+			},
+			{
+				Name:             "exact period",
+				Samples:          getSamples(20, 10, time.Minute),
+				ExpectedPeriod:   time.Minute,
+				ExpectedPeriodic: true,
+			},
+			{
+				Name:              "drifting clock",
+				Samples:           getSamples(20, 10, drifting),
+				ExpectedPeriod:    drifting,
+				ExpectedDriftPPM:  60,
+				ExpectedPeriodic:  true,
+				ExpectedBeaconErr: 7680 * time.Microsecond,
+			},
+			{
+				Name:              "drifting clock with lost uplinks",
+				Samples:           lost,
+				ExpectedPeriod:    drifting,
+				ExpectedDriftPPM:  60,
+				ExpectedPeriodic:  true,
+				ExpectedBeaconErr: 7680 * time.Microsecond,
+			},
+			{
+				Name:              "given nominal period",
+				Samples:           getSamples(20, 10, time.Minute),
+				NominalPeriod:     time.Minute - 6*time.Millisecond,
+				ExpectedPeriod:    time.Minute,
+				ExpectedDriftPPM:  100,
+				ExpectedPeriodic:  true,
+				ExpectedBeaconErr: 12800 * time.Microsecond,
+			},
+			{
+				Name:             "non-periodic",
+				Samples:          getSamples(20, 10, time.Minute, 0, 20*time.Second, -15*time.Second),
+				ExpectedPeriodic: false,
+			},
+		}
+
+		for i, tst := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", tst.Name, i), func() {
+				d, err := Estimate(tst.Samples, tst.NominalPeriod)
+				So(err, ShouldEqual, tst.ExpectedError)
+				if err != nil {
+					return
+				}
+				So(d.Samples, ShouldEqual, len(tst.Samples))
+				So(d.Periodic, ShouldEqual, tst.ExpectedPeriodic)
+				if tst.ExpectedPeriodic {
+					So(d.Period, ShouldAlmostEqual, tst.ExpectedPeriod, time.Millisecond)
+					So(d.DriftPPM, ShouldAlmostEqual, tst.ExpectedDriftPPM, 0.1)
+					So(d.BeaconPeriodError, ShouldAlmostEqual, tst.ExpectedBeaconErr, 20*time.Microsecond)
+				}
+			})
+		}
+	})
+}
+
+func TestRecordUplink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		}
+		samples := getSamples(Samples+10, 10, 600*time.Second+36*time.Millisecond)
+
+		Convey("When recording the uplinks of a node", func() {
+			for _, s := range samples {
+				So(RecordUplink(p, ns.DevEUI, s.FCnt, s.Time), ShouldBeNil)
+			}
+
+			Convey("Then the last Samples uplinks are kept (oldest first)", func() {
+				recorded, err := GetSamples(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(recorded, ShouldHaveLength, Samples)
+				So(recorded[0].FCnt, ShouldEqual, samples[10].FCnt)
+				So(recorded[0].Time.Equal(samples[10].Time), ShouldBeTrue)
+				So(recorded[Samples-1].FCnt, ShouldEqual, samples[len(samples)-1].FCnt)
+			})
+
+			Convey("Then a retransmission is ignored", func() {
+				last := samples[len(samples)-1]
+				So(RecordUplink(p, ns.DevEUI, last.FCnt, last.Time.Add(time.Second)), ShouldBeNil)
+				recorded, err := GetSamples(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(recorded, ShouldHaveLength, Samples)
+			})
+
+			Convey("Then the uplinks are reset on a frame-counter reset", func() {
+				So(RecordUplink(p, ns.DevEUI, 0, time.Now()), ShouldBeNil)
+				_, err := GetDrift(p, ns.DevEUI, 0)
+				So(err, ShouldEqual, ErrNotEnoughSamples)
+			})
+
+			Convey("Then the drift of the node can be retrieved", func() {
+				d, err := GetDrift(p, ns.DevEUI, 0)
+				So(err, ShouldBeNil)
+				So(d.DevEUI, ShouldEqual, ns.DevEUI)
+				So(d.DriftPPM, ShouldAlmostEqual, 60, 0.1)
+			})
+
+			Convey("Then the node matches the filters", func() {
+				d, ok, err := GetNode(p, ns, Filters{MinDriftPPM: 50, AppEUI: &ns.AppEUI})
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(d.AppEUI, ShouldEqual, ns.AppEUI)
+
+				_, ok, err = GetNode(p, ns, Filters{MinDriftPPM: 70})
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+
+				_, ok, err = GetNode(p, ns, Filters{AppEUI: &lorawan.EUI64{}})
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
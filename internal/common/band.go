@@ -0,0 +1,133 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/brocaar/lorawan/band"
+)
+
+// maxRX1DROffset defines the max RX1 data-rate offset of the precomputed
+// RX1 data-rate table (the highest offset of all bands).
+const maxRX1DROffset = 7
+
+// bandTables contains the data-rate lookup tables of the Band, so that the
+// data-rates do not have to be resolved by iterating over the band
+// configuration for every frame.
+type bandTables struct {
+	loraDR  [maxSpreadFactor + 1][3]int // [spread-factor][bandwidth index], -1 = unknown
+	otherDR map[band.DataRate]int       // e.g. the FSK data-rates
+	rx1DR   [][]int                     // [uplink data-rate][rx1 data-rate offset], -1 = invalid
+}
+
+// maxSpreadFactor defines the max LoRa spread-factor of the data-rate table.
+const maxSpreadFactor = 12
+
+// loraBandwidthIndex returns the index of the given LoRa bandwidth (kHz)
+// within the data-rate table, or -1.
+func loraBandwidthIndex(bw int) int {
+	switch bw {
+	case 125:
+		return 0
+	case 250:
+		return 1
+	case 500:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// loraDRIndex returns the position of the given data-rate within the LoRa
+// data-rate table (ok is false for other data-rates).
+func loraDRIndex(dr band.DataRate) (sf, bw int, ok bool) {
+	if dr.Modulation != band.LoRaModulation || dr.BitRate != 0 || dr.SpreadFactor < 0 || dr.SpreadFactor > maxSpreadFactor {
+		return 0, 0, false
+	}
+	bw = loraBandwidthIndex(dr.Bandwidth)
+	return dr.SpreadFactor, bw, bw != -1
+}
+
+var tables bandTables
+
+// SetBand sets the Band and precomputes its data-rate lookup tables. The
+// Band must be set using this function (and not be modified afterwards),
+// else GetDataRate and GetRX1DataRate might return stale values.
+func SetBand(b band.Band) {
+	Band = b
+	tables = newBandTables(b)
+}
+
+func newBandTables(b band.Band) bandTables {
+	t := bandTables{
+		otherDR: make(map[band.DataRate]int),
+	}
+	for sf := range t.loraDR {
+		for bw := range t.loraDR[sf] {
+			t.loraDR[sf][bw] = -1
+		}
+	}
+
+	for i := len(b.DataRates) - 1; i >= 0; i-- {
+		// the first index wins for data-rates defined multiple times
+		if sf, bw, ok := loraDRIndex(b.DataRates[i]); ok {
+			t.loraDR[sf][bw] = i
+		} else {
+			t.otherDR[b.DataRates[i]] = i
+		}
+	}
+
+	// bands defining the RX1 data-rate using a function (instead of a
+	// lookup table) don't have a table entry for data-rate 0
+	_, err := b.GetRX1DataRateForOffset(0, 0)
+	lookupTable := err == nil
+
+	for uplinkDR := range b.DataRates {
+		row := make([]int, maxRX1DROffset+1)
+		for offset := range row {
+			var dr int
+			var err error
+			if lookupTable {
+				dr, err = b.GetRX1DataRateForOffset(uplinkDR, offset)
+			} else {
+				dr, err = b.GetRX1DataRate(uplinkDR, offset)
+			}
+			if err != nil {
+				dr = -1
+			}
+			row[offset] = dr
+		}
+		t.rx1DR = append(t.rx1DR, row)
+	}
+
+	return t
+}
+
+// GetDataRate returns the index of the given data-rate within the Band.
+func GetDataRate(dr band.DataRate) (int, error) {
+	if tables.otherDR == nil {
+		return Band.GetDataRate(dr)
+	}
+
+	i := -1
+	if sf, bw, ok := loraDRIndex(dr); ok {
+		i = tables.loraDR[sf][bw]
+	} else if di, ok := tables.otherDR[dr]; ok {
+		i = di
+	}
+	if i == -1 {
+		return 0, fmt.Errorf("the given data-rate does not exist: %+v", dr)
+	}
+	return i, nil
+}
+
+// GetRX1DataRate returns the RX1 data-rate given the uplink data-rate and
+// RX1 data-rate offset.
+func GetRX1DataRate(uplinkDR, rx1DROffset int) (int, error) {
+	if tables.rx1DR == nil {
+		return Band.GetRX1DataRate(uplinkDR, rx1DROffset)
+	}
+	if uplinkDR < 0 || uplinkDR >= len(tables.rx1DR) || rx1DROffset < 0 || rx1DROffset > maxRX1DROffset || tables.rx1DR[uplinkDR][rx1DROffset] == -1 {
+		return 0, fmt.Errorf("invalid uplink data-rate %d or rx1 data-rate offset %d", uplinkDR, rx1DROffset)
+	}
+	return tables.rx1DR[uplinkDR][rx1DROffset], nil
+}
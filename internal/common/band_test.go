@@ -0,0 +1,153 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBandTables(t *testing.T) {
+	Convey("Given the band configurations", t, func() {
+		for _, name := range []band.Name{band.AS_923, band.AU_915_928, band.CN_470_510, band.CN_779_787, band.EU_433, band.EU_863_870, band.KR_920_923, band.RU_864_869, band.US_902_928} {
+			b, err := band.GetConfig(name, false, lorawan.DwellTime400ms)
+			So(err, ShouldBeNil)
+
+			Convey("When precomputing the tables of "+string(name), func() {
+				tables := newBandTables(b)
+
+				Convey("Then the data-rate indices equal the band lookup", func() {
+					for _, dr := range b.DataRates {
+						expected, err := b.GetDataRate(dr)
+						So(err, ShouldBeNil)
+						if sf, bw, ok := loraDRIndex(dr); ok {
+							So(tables.loraDR[sf][bw], ShouldEqual, expected)
+						} else {
+							So(tables.otherDR[dr], ShouldEqual, expected)
+						}
+					}
+				})
+
+				Convey("Then the RX1 data-rates equal the band lookup", func() {
+					for uplinkDR := range tables.rx1DR {
+						for offset, dr := range tables.rx1DR[uplinkDR] {
+							if dr == -1 {
+								continue
+							}
+							expected, err := b.GetRX1DataRate(uplinkDR, offset)
+							So(err, ShouldBeNil)
+							So(dr, ShouldEqual, expected)
+						}
+					}
+					So(tables.rx1DR[0][0], ShouldNotEqual, -1)
+				})
+			})
+		}
+	})
+
+	Convey("Given the EU band is set", t, func() {
+		defer func(b band.Band, t bandTables) {
+			Band = b
+			tables = t
+		}(Band, tables)
+
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+		SetBand(b)
+
+		Convey("Then GetDataRate returns the data-rate index", func() {
+			dr, err := GetDataRate(b.DataRates[3])
+			So(err, ShouldBeNil)
+			So(dr, ShouldEqual, 3)
+
+			_, err = GetDataRate(band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 6, Bandwidth: 125})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then GetRX1DataRate returns the RX1 data-rate", func() {
+			dr, err := GetRX1DataRate(5, 2)
+			So(err, ShouldBeNil)
+			So(dr, ShouldEqual, 3)
+
+			_, err = GetRX1DataRate(5, 8)
+			So(err, ShouldNotBeNil)
+			_, err = GetRX1DataRate(20, 0)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func benchmarkBand(b *testing.B) band.Band {
+	bc, err := band.GetConfig(band.US_902_928, false, lorawan.DwellTimeNoLimit)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return bc
+}
+
+// benchmarkRX1Band returns the AS923 band, of which the RX1 data-rate is
+// defined by a function instead of a lookup table.
+func benchmarkRX1Band(b *testing.B) band.Band {
+	bc, err := band.GetConfig(band.AS_923, false, lorawan.DwellTime400ms)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return bc
+}
+
+func BenchmarkBandGetDataRate(b *testing.B) {
+	bc := benchmarkBand(b)
+	dr := bc.DataRates[4]
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := bc.GetDataRate(dr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetDataRate(b *testing.B) {
+	defer func(bc band.Band, t bandTables) {
+		Band = bc
+		tables = t
+	}(Band, tables)
+
+	SetBand(benchmarkBand(b))
+	dr := Band.DataRates[4]
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GetDataRate(dr); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBandGetRX1DataRate(b *testing.B) {
+	bc := benchmarkRX1Band(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := bc.GetRX1DataRate(3, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetRX1DataRate(b *testing.B) {
+	defer func(bc band.Band, t bandTables) {
+		Band = bc
+		tables = t
+	}(Band, tables)
+
+	SetBand(benchmarkRX1Band(b))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GetRX1DataRate(3, 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
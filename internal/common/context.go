@@ -1,6 +1,9 @@
 package common
 
 import (
+	"context"
+	"time"
+
 	"github.com/garyburd/redigo/redis"
 	"github.com/jmoiron/sqlx"
 
@@ -19,4 +22,44 @@ type Context struct {
 	NetID       lorawan.NetID
 	Application as.ApplicationServerClient
 	Controller  nc.NetworkControllerClient
+
+	// requestCtx holds the context of the handled request (e.g. an uplink),
+	// see WithDeadline.
+	requestCtx context.Context
+}
+
+// WithDeadline returns a copy of the context of which the request context
+// is cancelled at the given deadline (e.g. the opening of the receive-window
+// of the handled uplink).
+func (c Context) WithDeadline(d time.Time) (Context, context.CancelFunc) {
+	requestCtx, cancel := context.WithDeadline(c.RequestContext(), d)
+	c.requestCtx = requestCtx
+	return c, cancel
+}
+
+// RequestContext returns the context of the handled request. It must be
+// used for the calls resulting in a downlink (e.g. fetching the downlink
+// payload from the application-server), so that these are cancelled when
+// the downlink would be too late. When not set, context.Background() is
+// returned.
+func (c Context) RequestContext() context.Context {
+	if c.requestCtx == nil {
+		return context.Background()
+	}
+	return c.requestCtx
+}
+
+// WithContext returns a copy of the context of which the request context
+// is set to the given context (e.g. the context of an API call, so that the
+// backend calls are cancelled when the client gives up on the call).
+func (c Context) WithContext(ctx context.Context) Context {
+	c.requestCtx = ctx
+	return c
+}
+
+// WithValue returns a copy of the context of which the request context
+// carries the given value for the given key (see context.WithValue).
+func (c Context) WithValue(key, val interface{}) Context {
+	c.requestCtx = context.WithValue(c.RequestContext(), key, val)
+	return c
 }
@@ -16,17 +16,40 @@ const (
 	redisIdleTimeoutSec = 240
 )
 
-// NewRedisPool returns a new Redis connection pool.
+// NewRedisPool returns a new Redis connection pool, prefixing the keys with
+// RedisKeyPrefix.
 func NewRedisPool(redisURL string) *redis.Pool {
-	return &redis.Pool{
+	return NewRedisPoolWithKeyPrefix(redisURL, RedisKeyPrefix)
+}
+
+// NewRedisPoolWithKeyPrefix returns a new Redis connection pool, prefixing
+// the keys with the given prefix (no prefix when empty).
+func NewRedisPoolWithKeyPrefix(redisURL, prefix string) *redis.Pool {
+	dialWithOptions := func(subscribe bool) (redis.Conn, error) {
+		c, err := redis.DialURL(redisURL, redisDialOptions(subscribe)...)
+		if err != nil {
+			return nil, fmt.Errorf("redis connection error: %s", err)
+		}
+		if prefix != "" {
+			return prefixConn{Conn: c, prefix: prefix}, nil
+		}
+		return c, err
+	}
+	dial := func() (redis.Conn, error) {
+		return dialWithOptions(false)
+	}
+
+	p := &redis.Pool{
 		MaxIdle:     redisMaxIdle,
+		MaxActive:   RedisMaxActive,
+		Wait:        RedisMaxActive > 0,
 		IdleTimeout: redisIdleTimeoutSec * time.Second,
 		Dial: func() (redis.Conn, error) {
-			c, err := redis.DialURL(redisURL)
+			c, err := dial()
 			if err != nil {
-				return nil, fmt.Errorf("redis connection error: %s", err)
+				return nil, err
 			}
-			return c, err
+			return &instrumentedConn{conn: c, dial: dial}, nil
 		},
 		TestOnBorrow: func(c redis.Conn, t time.Time) error {
 			_, err := c.Do("PING")
@@ -36,6 +59,17 @@ func NewRedisPool(redisURL string) *redis.Pool {
 			return nil
 		},
 	}
+	registerRedisPool(p, func() (redis.Conn, error) {
+		subscribeDial := func() (redis.Conn, error) {
+			return dialWithOptions(true)
+		}
+		c, err := subscribeDial()
+		if err != nil {
+			return nil, err
+		}
+		return &instrumentedConn{conn: c, dial: subscribeDial}, nil
+	})
+	return p
 }
 
 // OpenDatabase opens the database and performs a ping to make sure the
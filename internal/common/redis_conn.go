@@ -0,0 +1,220 @@
+package common
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/internal/metrics"
+)
+
+var (
+	redisCommandDuration = metrics.NewHistogram("loraserver_redis_command_duration_seconds", "Duration of the Redis commands.", metrics.DurationBuckets, "command")
+	redisCommandErrors   = metrics.NewCounter("loraserver_redis_command_errors_total", "Number of failed Redis commands.", "command", "reason")
+	redisCommandRetries  = metrics.NewCounter("loraserver_redis_command_retries_total", "Number of Redis commands retried after a connection error.", "command")
+)
+
+var (
+	redisPoolsMu        sync.Mutex
+	redisPools          []*redis.Pool
+	redisSubscribeDials = make(map[*redis.Pool]func() (redis.Conn, error))
+)
+
+func init() {
+	metrics.NewGaugeFunc("loraserver_redis_pool_active_connections", "Number of active (idle and in use) Redis connections.", func() float64 {
+		redisPoolsMu.Lock()
+		defer redisPoolsMu.Unlock()
+
+		var n int
+		for _, p := range redisPools {
+			n += p.ActiveCount()
+		}
+		return float64(n)
+	})
+	metrics.NewGaugeFunc("loraserver_redis_pool_max_active_connections", "Max. number of active Redis connections per pool (0 = unlimited).", func() float64 {
+		return float64(RedisMaxActive)
+	})
+}
+
+// registerRedisPool adds the given pool to the pool saturation metrics and
+// registers the function dialing the subscribe connections of the pool.
+func registerRedisPool(p *redis.Pool, subscribeDial func() (redis.Conn, error)) {
+	redisPoolsMu.Lock()
+	redisPools = append(redisPools, p)
+	redisSubscribeDials[p] = subscribeDial
+	redisPoolsMu.Unlock()
+}
+
+// GetRedisSubscribeConn returns a new connection to the Redis database of
+// the given pool, to be used for (P)SUBSCRIBE. Unlike the pooled connections,
+// this connection has no read timeout, as a subscription might be idle for
+// longer than the RedisCommandTimeout. The connection is not part of the
+// pool and is closed on Close. For pools not created by NewRedisPool, a
+// pooled connection is returned.
+func GetRedisSubscribeConn(p *redis.Pool) (redis.Conn, error) {
+	redisPoolsMu.Lock()
+	dial, ok := redisSubscribeDials[p]
+	redisPoolsMu.Unlock()
+
+	if !ok {
+		c := p.Get()
+		return c, c.Err()
+	}
+	return dial()
+}
+
+// redisDialOptions returns the dial options applying the RedisCommandTimeout
+// (when set). When subscribe is set, the read timeout is omitted.
+func redisDialOptions(subscribe bool) []redis.DialOption {
+	if RedisCommandTimeout == 0 {
+		return nil
+	}
+	opts := []redis.DialOption{
+		redis.DialConnectTimeout(RedisCommandTimeout),
+		redis.DialWriteTimeout(RedisCommandTimeout),
+	}
+	if !subscribe {
+		opts = append(opts, redis.DialReadTimeout(RedisCommandTimeout))
+	}
+	return opts
+}
+
+// readOnlyCommands contains the commands which are safe to retry, as
+// retrying these after a connection error can't apply them twice.
+var readOnlyCommands = map[string]bool{
+	"PING": true, "GET": true, "MGET": true, "STRLEN": true, "GETRANGE": true,
+	"EXISTS": true, "TTL": true, "PTTL": true, "TYPE": true,
+	"HGET": true, "HMGET": true, "HGETALL": true, "HEXISTS": true, "HLEN": true, "HKEYS": true, "HVALS": true,
+	"LRANGE": true, "LLEN": true, "LINDEX": true,
+	"SMEMBERS": true, "SISMEMBER": true, "SCARD": true,
+	"ZRANGE": true, "ZREVRANGE": true, "ZRANGEBYSCORE": true, "ZREVRANGEBYSCORE": true,
+	"ZCARD": true, "ZSCORE": true, "ZRANK": true, "ZREVRANK": true, "ZCOUNT": true,
+	"SCAN": true, "SSCAN": true, "HSCAN": true, "ZSCAN": true, "KEYS": true, "PFCOUNT": true,
+}
+
+// instrumentedConn implements a redis.Conn recording the duration and
+// errors of the commands executed using Do, logging the commands exceeding
+// RedisSlowCommandThreshold and retrying (on a new connection) the
+// read-only commands which failed because of a connection error, up to
+// RedisCommandRetries times. Commands are not retried while commands sent
+// using Send are pending or within a transaction.
+type instrumentedConn struct {
+	conn redis.Conn
+	dial func() (redis.Conn, error)
+
+	// mu guards pending and txn, as Send might be called (e.g. to
+	// unsubscribe) while an other goroutine is blocked in Receive
+	mu      sync.Mutex
+	pending int  // number of commands sent but not yet received
+	txn     bool // within MULTI / WATCH
+}
+
+// Do implements redis.Conn.
+func (c *instrumentedConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	name := strings.ToUpper(cmd)
+	if name == "" {
+		// flush and receive the pending replies
+		name = "PIPELINE"
+	}
+	c.mu.Lock()
+	retry := c.pending == 0 && !c.txn && readOnlyCommands[name]
+	c.pending = 0
+	c.trackTxn(name)
+	c.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		reply, err := c.conn.Do(cmd, args...)
+		duration := time.Since(start)
+
+		redisCommandDuration.Observe(duration.Seconds(), name)
+		if RedisSlowCommandThreshold > 0 && duration >= RedisSlowCommandThreshold {
+			fields := log.Fields{
+				"command":  name,
+				"duration": duration,
+			}
+			if len(args) > 0 {
+				if key, ok := args[0].(string); ok {
+					fields["key"] = key
+				}
+			}
+			log.WithFields(fields).Warning("slow redis command")
+		}
+
+		if err == nil {
+			return reply, nil
+		}
+		if _, ok := err.(redis.Error); ok {
+			// error reply of the server, the connection is still usable
+			redisCommandErrors.Inc(name, "reply")
+			return reply, err
+		}
+
+		reason := "connection"
+		if nErr, ok := err.(net.Error); ok && nErr.Timeout() {
+			reason = "timeout"
+		}
+		redisCommandErrors.Inc(name, reason)
+
+		if !retry || attempt >= RedisCommandRetries || c.conn.Err() == nil {
+			return reply, err
+		}
+
+		conn, dErr := c.dial()
+		if dErr != nil {
+			return reply, err
+		}
+		c.conn.Close()
+		c.conn = conn
+		redisCommandRetries.Inc(name)
+	}
+}
+
+// Send implements redis.Conn.
+func (c *instrumentedConn) Send(cmd string, args ...interface{}) error {
+	c.mu.Lock()
+	c.pending++
+	c.trackTxn(strings.ToUpper(cmd))
+	c.mu.Unlock()
+	return c.conn.Send(cmd, args...)
+}
+
+// Receive implements redis.Conn.
+func (c *instrumentedConn) Receive() (interface{}, error) {
+	c.mu.Lock()
+	if c.pending > 0 {
+		c.pending--
+	}
+	c.mu.Unlock()
+	return c.conn.Receive()
+}
+
+// Flush implements redis.Conn.
+func (c *instrumentedConn) Flush() error {
+	return c.conn.Flush()
+}
+
+// Err implements redis.Conn.
+func (c *instrumentedConn) Err() error {
+	return c.conn.Err()
+}
+
+// Close implements redis.Conn.
+func (c *instrumentedConn) Close() error {
+	return c.conn.Close()
+}
+
+// trackTxn keeps track of the transaction state, given the (upper-case)
+// command. The caller must hold mu.
+func (c *instrumentedConn) trackTxn(cmd string) {
+	switch cmd {
+	case "MULTI", "WATCH":
+		c.txn = true
+	case "EXEC", "DISCARD", "UNWATCH":
+		c.txn = false
+	}
+}
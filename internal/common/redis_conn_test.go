@@ -0,0 +1,138 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testConn implements a redis.Conn failing the commands with a connection
+// error when broken.
+type testConn struct {
+	broken bool
+	closed bool
+	cmds   []string
+}
+
+var errTestConnection = errors.New("connection reset by peer")
+
+func (c *testConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	c.cmds = append(c.cmds, cmd)
+	if c.broken {
+		return nil, errTestConnection
+	}
+	return "OK", nil
+}
+
+func (c *testConn) Send(cmd string, args ...interface{}) error {
+	c.cmds = append(c.cmds, cmd)
+	return nil
+}
+
+func (c *testConn) Err() error {
+	if c.broken {
+		return errTestConnection
+	}
+	return nil
+}
+
+func (c *testConn) Close() error                  { c.closed = true; return nil }
+func (c *testConn) Flush() error                  { return nil }
+func (c *testConn) Receive() (interface{}, error) { return nil, nil }
+
+func TestInstrumentedConn(t *testing.T) {
+	Convey("Given an instrumentedConn with a broken connection", t, func() {
+		broken := &testConn{broken: true}
+		var dialed []*testConn
+		c := &instrumentedConn{
+			conn: broken,
+			dial: func() (redis.Conn, error) {
+				conn := &testConn{}
+				dialed = append(dialed, conn)
+				return conn, nil
+			},
+		}
+
+		Convey("Then a read-only command is retried on a new connection", func() {
+			reply, err := c.Do("GET", "a")
+			So(err, ShouldBeNil)
+			So(reply, ShouldEqual, "OK")
+			So(broken.closed, ShouldBeTrue)
+			So(dialed, ShouldHaveLength, 1)
+			So(c.conn, ShouldEqual, dialed[0])
+		})
+
+		Convey("Then a command modifying data is not retried", func() {
+			_, err := c.Do("INCR", "a")
+			So(err, ShouldEqual, errTestConnection)
+			So(dialed, ShouldHaveLength, 0)
+		})
+
+		Convey("Then a read-only command is not retried within a transaction", func() {
+			So(c.Send("MULTI"), ShouldBeNil)
+			So(c.Send("GET", "a"), ShouldBeNil)
+			_, err := c.Do("EXEC")
+			So(err, ShouldEqual, errTestConnection)
+			So(dialed, ShouldHaveLength, 0)
+			So(c.txn, ShouldBeFalse)
+		})
+
+		Convey("Then a read-only command is not retried with pending commands", func() {
+			So(c.Send("SET", "a", "b"), ShouldBeNil)
+			_, err := c.Do("GET", "a")
+			So(err, ShouldEqual, errTestConnection)
+			So(dialed, ShouldHaveLength, 0)
+		})
+
+		Convey("When retries are disabled", func() {
+			defer func(r int) { RedisCommandRetries = r }(RedisCommandRetries)
+			RedisCommandRetries = 0
+
+			Convey("Then a read-only command is not retried", func() {
+				_, err := c.Do("GET", "a")
+				So(err, ShouldEqual, errTestConnection)
+				So(dialed, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestRedisDialOptions(t *testing.T) {
+	Convey("Given a RedisCommandTimeout", t, func() {
+		defer func(d time.Duration) { RedisCommandTimeout = d }(RedisCommandTimeout)
+		RedisCommandTimeout = time.Second
+
+		Convey("Then the pooled connections have a connect, read and write timeout", func() {
+			So(redisDialOptions(false), ShouldHaveLength, 3)
+		})
+
+		Convey("Then the subscribe connections have no read timeout", func() {
+			So(redisDialOptions(true), ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestInstrumentedConnConcurrentSend(t *testing.T) {
+	Convey("Given an instrumentedConn", t, func() {
+		c := &instrumentedConn{conn: &testConn{}}
+
+		Convey("Then Send can be called while receiving (e.g. to unsubscribe)", func() {
+			done := make(chan struct{})
+			go func() {
+				for i := 0; i < 100; i++ {
+					c.Receive()
+				}
+				close(done)
+			}()
+			for i := 0; i < 100; i++ {
+				So(c.Send("UNSUBSCRIBE"), ShouldBeNil)
+			}
+			<-done
+
+			So(c.pending, ShouldBeBetweenOrEqual, 0, 100)
+		})
+	})
+}
@@ -0,0 +1,241 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+// keyPositions defines the positions of the key arguments of a command.
+type keyPositions int
+
+const (
+	keyFirst      keyPositions = iota // first argument
+	keyFirstTwo                       // first two arguments
+	keyAll                            // all arguments
+	keyAllButLast                     // all but the last argument (timeout)
+	keyEveryOther                     // every other argument (key value pairs)
+	keyScript                         // script (sha), numkeys and keys
+	keyStore                          // destination, numkeys and keys
+	keySubcommand                     // the argument following the subcommand
+	keyChannels                       // all arguments (pub/sub channels)
+)
+
+// keySubcommands contains the subcommands of which the argument following
+// the subcommand is a key.
+var keySubcommands = map[string]bool{
+	"USAGE": true, // MEMORY USAGE key
+}
+
+// keyspaceChannelPrefix is the prefix of the channels used by Redis for the
+// keyspace and keyevent notifications. As these contain the (prefixed)
+// key, these channels are not prefixed.
+const keyspaceChannelPrefix = "__key"
+
+// commandKeys contains the key positions of the commands of which the keys
+// are prefixed. The keys of commands which are not listed are not
+// prefixed.
+var commandKeys = map[string]keyPositions{
+	"GET": keyFirst, "SET": keyFirst, "SETNX": keyFirst, "SETEX": keyFirst, "PSETEX": keyFirst, "GETSET": keyFirst,
+	"INCR": keyFirst, "INCRBY": keyFirst, "INCRBYFLOAT": keyFirst, "DECR": keyFirst, "DECRBY": keyFirst,
+	"APPEND": keyFirst, "STRLEN": keyFirst, "GETRANGE": keyFirst, "SETRANGE": keyFirst,
+	"EXPIRE": keyFirst, "PEXPIRE": keyFirst, "EXPIREAT": keyFirst, "PEXPIREAT": keyFirst,
+	"TTL": keyFirst, "PTTL": keyFirst, "PERSIST": keyFirst, "TYPE": keyFirst, "DUMP": keyFirst, "RESTORE": keyFirst,
+	"HSET": keyFirst, "HSETNX": keyFirst, "HGET": keyFirst, "HMSET": keyFirst, "HMGET": keyFirst, "HGETALL": keyFirst,
+	"HDEL": keyFirst, "HINCRBY": keyFirst, "HINCRBYFLOAT": keyFirst, "HEXISTS": keyFirst, "HLEN": keyFirst,
+	"HKEYS": keyFirst, "HVALS": keyFirst, "HSCAN": keyFirst,
+	"LPUSH": keyFirst, "RPUSH": keyFirst, "LPUSHX": keyFirst, "RPUSHX": keyFirst, "LPOP": keyFirst, "RPOP": keyFirst,
+	"LRANGE": keyFirst, "LTRIM": keyFirst, "LREM": keyFirst, "LLEN": keyFirst, "LINDEX": keyFirst, "LSET": keyFirst,
+	"LINSERT": keyFirst,
+	"SADD":    keyFirst, "SREM": keyFirst, "SMEMBERS": keyFirst, "SISMEMBER": keyFirst, "SCARD": keyFirst,
+	"SPOP": keyFirst, "SRANDMEMBER": keyFirst, "SSCAN": keyFirst,
+	"ZADD": keyFirst, "ZINCRBY": keyFirst, "ZREM": keyFirst, "ZRANGE": keyFirst, "ZREVRANGE": keyFirst,
+	"ZRANGEBYSCORE": keyFirst, "ZREVRANGEBYSCORE": keyFirst, "ZREMRANGEBYSCORE": keyFirst,
+	"ZREMRANGEBYRANK": keyFirst, "ZCARD": keyFirst, "ZSCORE": keyFirst, "ZRANK": keyFirst, "ZREVRANK": keyFirst,
+	"ZCOUNT": keyFirst, "ZSCAN": keyFirst, "PFADD": keyFirst,
+
+	"RENAME": keyFirstTwo, "RENAMENX": keyFirstTwo, "RPOPLPUSH": keyFirstTwo, "BRPOPLPUSH": keyFirstTwo,
+	"SMOVE": keyFirstTwo,
+
+	"DEL": keyAll, "UNLINK": keyAll, "EXISTS": keyAll, "TOUCH": keyAll, "MGET": keyAll, "WATCH": keyAll,
+	"SUNION": keyAll, "SINTER": keyAll, "SDIFF": keyAll, "PFCOUNT": keyAll,
+
+	"BLPOP": keyAllButLast, "BRPOP": keyAllButLast,
+
+	"MSET": keyEveryOther, "MSETNX": keyEveryOther,
+
+	"EVAL": keyScript, "EVALSHA": keyScript,
+
+	"ZUNIONSTORE": keyStore, "ZINTERSTORE": keyStore, "SUNIONSTORE": keyAll, "SINTERSTORE": keyAll,
+	"SDIFFSTORE": keyAll,
+
+	"MEMORY": keySubcommand,
+
+	"PUBLISH": keyFirst, "SUBSCRIBE": keyChannels, "UNSUBSCRIBE": keyChannels,
+	"PSUBSCRIBE": keyChannels, "PUNSUBSCRIBE": keyChannels,
+}
+
+// prefixConn implements a redis.Conn prefixing the keys of the commands
+// with the given prefix. The keys returned by the SCAN and KEYS commands
+// are returned without prefix. Note that this is only the case when these
+// are executed using Do.
+type prefixConn struct {
+	redis.Conn
+	prefix string
+}
+
+// Do implements redis.Conn.
+func (c prefixConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	switch strings.ToUpper(cmd) {
+	case "SCAN":
+		reply, err := c.Conn.Do(cmd, c.prefixScanArgs(args)...)
+		if err != nil {
+			return reply, err
+		}
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 2 {
+			return reply, err
+		}
+		return []interface{}{values[0], c.trimKeys(values[1])}, nil
+	case "KEYS":
+		if len(args) == 1 {
+			args = []interface{}{c.prefixKey(args[0])}
+		}
+		reply, err := c.Conn.Do(cmd, args...)
+		if err != nil {
+			return reply, err
+		}
+		return c.trimKeys(reply), nil
+	default:
+		return c.Conn.Do(cmd, c.prefixArgs(cmd, args)...)
+	}
+}
+
+// Send implements redis.Conn.
+func (c prefixConn) Send(cmd string, args ...interface{}) error {
+	if strings.ToUpper(cmd) == "SCAN" {
+		return c.Conn.Send(cmd, c.prefixScanArgs(args)...)
+	}
+	return c.Conn.Send(cmd, c.prefixArgs(cmd, args)...)
+}
+
+func (c prefixConn) prefixKey(key interface{}) interface{} {
+	switch k := key.(type) {
+	case string:
+		return c.prefix + k
+	case []byte:
+		return append([]byte(c.prefix), k...)
+	default:
+		return key
+	}
+}
+
+// prefixArgs returns a copy of the given arguments of which the keys are
+// prefixed.
+func (c prefixConn) prefixArgs(cmd string, args []interface{}) []interface{} {
+	pos, ok := commandKeys[strings.ToUpper(cmd)]
+	if !ok || len(args) == 0 {
+		return args
+	}
+
+	out := make([]interface{}, len(args))
+	copy(out, args)
+
+	switch pos {
+	case keyFirst:
+		out[0] = c.prefixKey(out[0])
+	case keyFirstTwo:
+		for i := 0; i < 2 && i < len(out); i++ {
+			out[i] = c.prefixKey(out[i])
+		}
+	case keyAll:
+		for i := range out {
+			out[i] = c.prefixKey(out[i])
+		}
+	case keyAllButLast:
+		for i := 0; i < len(out)-1; i++ {
+			out[i] = c.prefixKey(out[i])
+		}
+	case keyEveryOther:
+		for i := 0; i < len(out); i += 2 {
+			out[i] = c.prefixKey(out[i])
+		}
+	case keySubcommand:
+		if sub, ok := out[0].(string); ok && keySubcommands[strings.ToUpper(sub)] && len(out) > 1 {
+			out[1] = c.prefixKey(out[1])
+		}
+	case keyChannels:
+		for i := range out {
+			if ch, ok := out[i].(string); ok && strings.HasPrefix(ch, keyspaceChannelPrefix) {
+				continue
+			}
+			out[i] = c.prefixKey(out[i])
+		}
+	case keyScript, keyStore:
+		// script / destination, numkeys, keys...
+		if pos == keyStore {
+			out[0] = c.prefixKey(out[0])
+		}
+		if len(out) < 2 {
+			return out
+		}
+		n, ok := numKeys(out[1])
+		if !ok {
+			return out
+		}
+		for i := 2; i < 2+n && i < len(out); i++ {
+			out[i] = c.prefixKey(out[i])
+		}
+	}
+
+	return out
+}
+
+// numKeys returns the numkeys argument as int.
+func numKeys(arg interface{}) (int, bool) {
+	switch v := arg.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// prefixScanArgs returns a copy of the given SCAN arguments of which the
+// MATCH pattern is prefixed (a MATCH pattern is added when missing).
+func (c prefixConn) prefixScanArgs(args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	copy(out, args)
+
+	for i := 1; i < len(out)-1; i++ {
+		if s, ok := out[i].(string); ok && strings.ToUpper(s) == "MATCH" {
+			out[i+1] = c.prefixKey(out[i+1])
+			return out
+		}
+	}
+	return append(out, "MATCH", c.prefix+"*")
+}
+
+// trimKeys removes the prefix from the given keys reply.
+func (c prefixConn) trimKeys(reply interface{}) interface{} {
+	keys, ok := reply.([]interface{})
+	if !ok {
+		return reply
+	}
+
+	out := make([]interface{}, len(keys))
+	for i, k := range keys {
+		if b, ok := k.([]byte); ok && strings.HasPrefix(string(b), c.prefix) {
+			out[i] = b[len(c.prefix):]
+		} else {
+			out[i] = k
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPrefixConn(t *testing.T) {
+	Convey("Given a prefixConn with prefix test:", t, func() {
+		c := prefixConn{prefix: "test:"}
+
+		Convey("Then the keys of the commands are prefixed", func() {
+			tests := []struct {
+				Cmd      string
+				Args     []interface{}
+				Expected []interface{}
+			}{
+				{"GET", []interface{}{"a"}, []interface{}{"test:a"}},
+				{"psetex", []interface{}{"a", 100, []byte("v")}, []interface{}{"test:a", 100, []byte("v")}},
+				{"DEL", []interface{}{"a", []byte("b")}, []interface{}{"test:a", []byte("test:b")}},
+				{"RENAME", []interface{}{"a", "b"}, []interface{}{"test:a", "test:b"}},
+				{"BRPOP", []interface{}{"a", "b", 0}, []interface{}{"test:a", "test:b", 0}},
+				{"MSET", []interface{}{"a", "1", "b", "2"}, []interface{}{"test:a", "1", "test:b", "2"}},
+				{"EVALSHA", []interface{}{"sha", 2, "a", "b", "arg"}, []interface{}{"sha", 2, "test:a", "test:b", "arg"}},
+				{"PING", []interface{}{"a"}, []interface{}{"a"}},
+				{"MEMORY", []interface{}{"USAGE", "a"}, []interface{}{"USAGE", "test:a"}},
+				{"MEMORY", []interface{}{"STATS"}, []interface{}{"STATS"}},
+				{"PUBLISH", []interface{}{"ch", []byte("msg")}, []interface{}{"test:ch", []byte("msg")}},
+				{"SUBSCRIBE", []interface{}{"ch1", "ch2"}, []interface{}{"test:ch1", "test:ch2"}},
+				{"UNSUBSCRIBE", []interface{}{"ch1"}, []interface{}{"test:ch1"}},
+				{"PSUBSCRIBE", []interface{}{"ch:*"}, []interface{}{"test:ch:*"}},
+				{"PSUBSCRIBE", []interface{}{"__keyspace@0__:test:a:*"}, []interface{}{"__keyspace@0__:test:a:*"}},
+			}
+
+			for _, test := range tests {
+				So(c.prefixArgs(test.Cmd, test.Args), ShouldResemble, test.Expected)
+			}
+		})
+
+		Convey("Then the SCAN pattern is prefixed", func() {
+			So(c.prefixScanArgs([]interface{}{0, "MATCH", "lora:*", "COUNT", 10}), ShouldResemble, []interface{}{0, "MATCH", "test:lora:*", "COUNT", 10})
+			So(c.prefixScanArgs([]interface{}{0}), ShouldResemble, []interface{}{0, "MATCH", "test:*"})
+		})
+
+		Convey("Then the prefix is removed from the returned keys", func() {
+			So(c.trimKeys([]interface{}{[]byte("test:a"), []byte("b")}), ShouldResemble, []interface{}{[]byte("a"), []byte("b")})
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// scanCount defines the number of keys hinted to Redis per SCAN iteration.
+const scanCount = 1000
+
+// ScanKeys returns all the keys matching the given pattern. SCAN is used
+// instead of KEYS to avoid blocking Redis on large databases.
+func ScanKeys(p *redis.Pool, pattern string) ([]string, error) {
+	var out []string
+	cursor := 0
+
+	c := p.Get()
+	defer c.Close()
+
+	for {
+		values, err := redis.Values(c.Do("SCAN", cursor, "MATCH", pattern, "COUNT", scanCount))
+		if err != nil {
+			return nil, errors.Wrap(err, "scan keys error")
+		}
+
+		var keys []string
+		if _, err := redis.Scan(values, &cursor, &keys); err != nil {
+			return nil, errors.Wrap(err, "scan values error")
+		}
+		out = append(out, keys...)
+
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return out, nil
+}
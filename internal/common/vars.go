@@ -6,6 +6,9 @@ import (
 	"github.com/brocaar/lorawan/band"
 )
 
+// Version holds the LoRa Server version.
+var Version string
+
 // NodeTXPayloadQueueTTL defines the TTL of the node TXPayload queue
 var NodeTXPayloadQueueTTL = time.Hour * 24 * 5
 
@@ -25,12 +28,326 @@ var BandName band.Name
 // DeduplicationDelay holds the time to wait for uplink de-duplication
 var DeduplicationDelay = time.Millisecond * 200
 
+// DeduplicationGatewayCount holds the number of receptions completing the
+// uplink de-duplication when using the gateway-count or whichever-first
+// strategy.
+var DeduplicationGatewayCount = 3
+
+// DeduplicationMaxDelay holds the max. time to wait for
+// DeduplicationGatewayCount receptions when using the gateway-count
+// strategy.
+var DeduplicationMaxDelay = time.Second
+
+// JoinRequestSuppressionWindow holds the time in which join-requests with
+// an already processed DevEUI and DevNonce are ignored. This prevents that
+// copies arriving after the de-duplication delay trigger a second
+// join-request to the application-server. Set to 0 to disable.
+var JoinRequestSuppressionWindow = time.Second * 10
+
+// RetransmissionSuppressionWindow holds the time in which uplink frames
+// with an already handled DevEUI, FCnt and payload are not forwarded to the
+// application-server. This suppresses (NbTrans) retransmissions arriving
+// after the de-duplication delay. Set to 0 to disable.
+var RetransmissionSuppressionWindow = time.Second * 10
+
+// RetransmissionACKWindow holds the time in which retransmissions of an
+// acknowledged confirmed uplink (because the ACK was lost) are acknowledged
+// again, without forwarding them to the application-server. Set to 0 to
+// disable.
+var RetransmissionACKWindow = time.Minute
+
+// DropOutOfPlanRXPackets defines if uplink frames received on a frequency
+// outside the channel plan must be dropped. When false, these frames are
+// only logged and counted (per gateway).
+var DropOutOfPlanRXPackets bool
+
+// JoinAcceptTXPower holds the TX power (dBm) to use for join-accept
+// transmissions, as activation at the cell edge is the most failure-prone
+// step. It is limited to the regional max downlink TX power. When set to 0,
+// the default TX power of the band is used.
+var JoinAcceptTXPower int
+
+// JoinAcceptRetryThreshold holds the number of join-accepts sent to a node
+// without subsequent uplink, after which an OTAA_JOIN_ACCEPT_NOT_RECEIVED
+// error is sent to the application-server (repeated every threshold
+// join-accepts). When set to 0, this is disabled.
+var JoinAcceptRetryThreshold = 3
+
+// AppSKeyKEK holds the key encryption key (KEK) used to unwrap the AppSKey
+// delivered by the join-server (RFC 3394 key wrap). When set, LoRa Server
+// performs the FRMPayload encryption / decryption for the
+// application-server and exposes the plaintext payloads over the API.
+// When nil, the application-server is responsible for the payload
+// encryption.
+var AppSKeyKEK []byte
+
+// DeviceQueueOnly defines if the downlink payloads are only taken from the
+// device-queue of LoRa Server, instead of falling back to getting the
+// downlink data from the app server.
+var DeviceQueueOnly bool
+
 // GetDownlinkDataDelay holds the delay between uplink delivery to the app server and getting the downlink data from the app server (if any)
 var GetDownlinkDataDelay = time.Millisecond * 100
 
+// GetDownlinkDataTimeout defines the timeout of getting the downlink data
+// from the application-server. Set to 0 to disable.
+var GetDownlinkDataTimeout time.Duration
+
+// GetDownlinkDataBreakerThreshold defines the number of consecutive failed
+// attempts to get the downlink data from the application-server after which
+// the following attempts are skipped (no data) for the duration of
+// GetDownlinkDataBreakerCooldown. Set to 0 to disable.
+var GetDownlinkDataBreakerThreshold int
+
+// GetDownlinkDataBreakerCooldown defines the duration attempts to get the
+// downlink data are skipped (see GetDownlinkDataBreakerThreshold).
+var GetDownlinkDataBreakerCooldown time.Duration
+
+// DownlinkDeadlineMargin defines the time before the opening of the
+// receive-window at which the handling of an uplink must have resulted in
+// a downlink. Downlinks which would be later are not sent.
+var DownlinkDeadlineMargin = time.Millisecond * 100
+
+// RXWindowLearning defines if the RX window used for the downlinks of a
+// node is learned from the outcomes of its confirmed downlinks, overriding
+// the RX window of the node-session when it proves unreliable.
+var RXWindowLearning = false
+
+// BroadcastDispersalPeriod defines the default period over which the
+// downlinks of a broadcast are randomly spread per gateway (0 = sent as
+// fast as the duty-cycle allows).
+var BroadcastDispersalPeriod time.Duration
+
+// RX2MismatchDetection defines if nodes of which the RX2 parameters do not
+// match the node-session are detected, by falling back to the default RX2
+// data-rate of the band when their confirmed RX2 downlinks are not
+// acknowledged.
+var RX2MismatchDetection = false
+
+// RX2MismatchAutoFix defines if a RXParamSetupReq mac-command (containing
+// the RX2 parameters of the node-session) is enqueued for nodes detected
+// with mismatching RX2 parameters.
+var RX2MismatchAutoFix = false
+
+// FCntDownRolloverThreshold defines the value of the 16 least-significant
+// bits of the downlink frame-counter from which a node is flagged as
+// approaching the 16-bit rollover. Set to 0 to disable.
+var FCntDownRolloverThreshold uint32
+
+// FCntDownRolloverRejoinTemplateID defines the downlink template (e.g. an
+// application-layer rejoin command) which is triggered for a node flagged
+// as approaching the 16-bit rollover (0 = none).
+var FCntDownRolloverRejoinTemplateID int64
+
+// MICValidationWorkers defines the number of workers used to validate the
+// MIC of an uplink frame in parallel, in case multiple node-sessions are
+// using the same DevAddr.
+var MICValidationWorkers = 4
+
+// UplinkShards defines the number of worker shards processing the
+// de-duplicated uplink frames. The frames of the same DevAddr are always
+// processed by the same shard (one after the other), the frames of other
+// DevAddrs in parallel. Set to 0 to process each frame in its own
+// go-routine.
+var UplinkShards int
+
 // TimeLocation holds the timezone location
 var TimeLocation = time.Local
 
+// SecurityStrictMode defines if the strict security mode is enabled. In
+// this mode, the relaxed frame-counter validation of node-sessions is
+// ignored and MIC failures and frame-counter resets are emitted as
+// security events.
+var SecurityStrictMode bool
+
 // CreateGatewayOnStats defines if non-existing gateways should be created
 // automatically when receiving stats.
 var CreateGatewayOnStats = false
+
+// GatewayStatsTimeout defines the duration after which a gateway is
+// considered disconnected when no stats were received. The
+// network-controller is notified on gateway connect and stats timeout.
+// Set to 0 to disable.
+var GatewayStatsTimeout time.Duration
+
+// GatewayMinReachabilityScore defines the reachability score (0 - 1, based
+// on the regularity of the received gateway stats) below which a gateway
+// is only used for downlink when no other gateway is available. Set to 0
+// to disable.
+var GatewayMinReachabilityScore float64
+
+// GatewayMaxDownlinksPerSecond defines the max number of downlinks sent to
+// a gateway within any second. Downlinks exceeding this limit are re-routed
+// via an other gateway when possible, deferred (Class-C) or dropped. Set to
+// 0 to disable.
+var GatewayMaxDownlinksPerSecond int
+
+// GatewayNACKFallbacks defines the max number of next-best gateways via
+// which a downlink is retried (one after the other) when the gateway
+// rejects the downlink (negative TX acknowledgement, e.g. because of the
+// duty-cycle or a collision). Set to 0 to disable.
+var GatewayNACKFallbacks = 2
+
+// GatewayTXFailureThreshold defines the number of failed downlinks
+// (rejected by the gateway or failed to publish) after which a gateway is
+// excluded from the downlink gateway selection, until a downlink is
+// acknowledged by the gateway or no downlink failed within
+// GatewayTXFailureWindow. Set to 0 to disable.
+var GatewayTXFailureThreshold int
+
+// GatewayTXFailureWindow defines after how long without failed downlinks
+// the failure score of a gateway is reset.
+var GatewayTXFailureWindow = 5 * time.Minute
+
+// DownlinkDeduplicationWindow defines the window in which an identical
+// downlink payload (FPort + data) pushed for the same node is considered
+// a duplicate. Set to 0 to disable the deduplication guard.
+var DownlinkDeduplicationWindow time.Duration
+
+// DownlinkDeduplicationCoalesce defines if duplicate downlink payloads
+// (see DownlinkDeduplicationWindow) must be dropped instead of only being
+// logged.
+var DownlinkDeduplicationCoalesce = false
+
+// DevNonceAlertMargin defines the number of remaining DevNonce values
+// below which the network-controller is notified that a node using a
+// monotonic DevNonce is approaching the DevNonce ceiling.
+var DevNonceAlertMargin = 1000
+
+// DeviceClassChangeLockout defines the maximum duration Class-C downlinks
+// are paused after initiating a device class change, when the change is
+// not confirmed by an uplink of the node. Set to 0 to pause until the
+// change has been confirmed.
+var DeviceClassChangeLockout time.Duration
+
+// ClassCConfirmedRetries defines the max. number of retransmissions of a
+// confirmed Class-C downlink which has not been acknowledged. Set to 0 to
+// disable the retransmissions.
+var ClassCConfirmedRetries = 2
+
+// ClassCConfirmedACKTimeout defines the duration after which a confirmed
+// Class-C downlink which has not been acknowledged is retransmitted.
+var ClassCConfirmedACKTimeout = 30 * time.Second
+
+// ConfirmedDownlinkRetries defines the max. number of retransmissions of a
+// confirmed downlink, sent in response to an uplink, which has not been
+// acknowledged. The downlink is retransmitted in response to the next
+// uplinks of the node. Set to 0 to disable the retransmissions.
+var ConfirmedDownlinkRetries int
+
+// ConfirmedDownlinkACKTimeout defines the duration after which a confirmed
+// downlink, sent in response to an uplink, which has not been acknowledged
+// is given up. Set to 0 to only limit the number of retransmissions.
+var ConfirmedDownlinkACKTimeout time.Duration
+
+// UplinkDropOversized defines if uplink frames of which the FRMPayload
+// exceeds the max. payload size of the data-rate are dropped. These frames
+// are always logged and counted per node and gateway.
+var UplinkDropOversized = false
+
+// PassiveMonitoring defines if the uplinks of DevAddrs without node-session
+// are summarized (per gateway, frequency, data-rate and NwkID) instead of
+// being fully discarded, see the passive package.
+var PassiveMonitoring = false
+
+// SLALateThreshold defines the percentage of uplinks of which the downlink
+// was published after the RX1 deadline, over the SLAWindow, above which an
+// alert is published. Set to 0 to disable the SLA monitoring.
+var SLALateThreshold float64
+
+// SLAWindow defines the window over which the percentage of late downlinks
+// is calculated.
+var SLAWindow = 15 * time.Minute
+
+// SLAMinUplinks defines the min. number of uplinks with a downlink within
+// the SLAWindow before the SLALateThreshold is evaluated.
+var SLAMinUplinks = 100
+
+// ReadOnlyMode defines if LoRa Server runs in read-only (maintenance) mode.
+// In this mode, data uplinks are de-duplicated and forwarded to the
+// application-server, but the node-sessions are not updated and no
+// downlinks are sent. Join-requests are rejected.
+var ReadOnlyMode bool
+
+// BenchMode defines if downlinks can be enqueued for unprovisioned test
+// devices (lab / bench testing) using the EnqueueBenchDownlink API method.
+// It must not be enabled in production.
+var BenchMode bool
+
+// FrameLogMaxFrames defines the number of uplink and downlink frames kept
+// in the frame logs per node and per gateway. Set to 0 to disable the frame
+// logs.
+var FrameLogMaxFrames = 100
+
+// APIRequestTimeout defines the timeout of API calls for which the client
+// did not set a deadline. Set to 0 to only apply the deadlines set by the
+// clients.
+var APIRequestTimeout time.Duration
+
+// GeolocationBufferFrames defines the number of uplinks (received by enough
+// fine-timestamp capable gateways) buffered per node for the geolocation.
+// Set to 0 to disable the geolocation.
+var GeolocationBufferFrames = 3
+
+// GeolocationBufferTTL defines how long the buffered uplinks of a node are
+// kept after its last buffered uplink.
+var GeolocationBufferTTL = time.Hour
+
+// MACCommandRetryInterval defines the backoff of mac-commands which require
+// an answer and which have been sent before without being answered. These
+// are only retransmitted on every Nth downlink opportunity. Set to 1 to
+// retransmit on every downlink opportunity.
+var MACCommandRetryInterval = 1
+
+// JoinDevNonceHistorySize defines the number of DevNonce values kept per
+// node. Join-requests re-using one of these DevNonce values are rejected
+// before being forwarded to the application-server. Set to 0 to disable.
+var JoinDevNonceHistorySize = 10
+
+// JoinAcceptHistoryRetention defines how long the issued join-accepts
+// (DevEUI and DevNonce) are kept. Join-requests re-using the DevNonce of an
+// issued join-accept within this retention are rejected, also across
+// restarts and instances. Set to 0 to disable.
+var JoinAcceptHistoryRetention = 720 * time.Hour
+
+// JoinDeviceRateLimit defines the max. number of join-requests per minute
+// per DevEUI forwarded to the application-server. Set to 0 to disable.
+var JoinDeviceRateLimit float64
+
+// JoinDeviceRateLimitBurst defines the number of join-requests per DevEUI
+// which are allowed at once, on top of JoinDeviceRateLimit.
+var JoinDeviceRateLimitBurst = 3
+
+// JoinGatewayRateLimit defines the max. number of join-requests per minute
+// per gateway forwarded to the application-server. Join-requests are only
+// rejected when all receiving gateways exceed this limit. Set to 0 to
+// disable.
+var JoinGatewayRateLimit float64
+
+// JoinGatewayRateLimitBurst defines the number of join-requests per gateway
+// which are allowed at once, on top of JoinGatewayRateLimit.
+var JoinGatewayRateLimitBurst = 60
+
+// RedisKeyPrefix defines the prefix of all the Redis keys, so that multiple
+// LoRa Server instances or environments can share a single Redis database.
+// It is applied by the connections of the pools created by NewRedisPool.
+var RedisKeyPrefix string
+
+// RedisCommandTimeout defines the connect, read and write timeout of the
+// Redis connections, so that a hung Redis command fails instead of
+// stalling the caller. Set to 0 to disable the timeouts.
+var RedisCommandTimeout time.Duration
+
+// RedisCommandRetries defines the max. number of retries (on a new
+// connection) of a read-only Redis command which failed because of a
+// connection error.
+var RedisCommandRetries = 1
+
+// RedisSlowCommandThreshold defines the duration above which a Redis
+// command is logged as slow. Set to 0 to disable the logging.
+var RedisSlowCommandThreshold time.Duration
+
+// RedisMaxActive defines the max. number of active connections per Redis
+// pool. When reached, getting a connection waits until a connection is
+// returned to the pool. Set to 0 for no limit.
+var RedisMaxActive int
@@ -0,0 +1,196 @@
+// Package counters implements the buffering of the Redis counters which are
+// incremented for every uplink (e.g. per gateway, node and frequency).
+//
+// Instead of writing the increments of every event to Redis, the
+// increments are aggregated in memory and flushed in a single transaction
+// on a fixed interval (see Run). The aggregation is split over multiple
+// shards (by key), so that concurrent events rarely contend on the same
+// lock. When Run has not been started, the increments are written directly.
+package counters
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// shardCount defines the number of shards of the buffer.
+const shardCount = 16
+
+// counter identifies a single counter.
+type counter struct {
+	cmd   string // INCRBY, HINCRBY or ZINCRBY
+	key   string
+	field string // hash field or sorted set member
+}
+
+// incr contains a single increment.
+type incr struct {
+	counter
+	n   int64
+	ttl time.Duration
+}
+
+// Batch contains the counter increments of a single event.
+type Batch struct {
+	incrs []incr
+}
+
+// IncrBy increments the counter stored at key by n. The key expires after
+// the given ttl (0 = no expiration).
+func (b *Batch) IncrBy(key string, n int64, ttl time.Duration) {
+	b.incrs = append(b.incrs, incr{counter{"INCRBY", key, ""}, n, ttl})
+}
+
+// HIncrBy increments the given field of the hash stored at key by n. The
+// key expires after the given ttl (0 = no expiration).
+func (b *Batch) HIncrBy(key, field string, n int64, ttl time.Duration) {
+	b.incrs = append(b.incrs, incr{counter{"HINCRBY", key, field}, n, ttl})
+}
+
+// ZIncrBy increments the score of the given member of the sorted set
+// stored at key by n. The key expires after the given ttl (0 = no
+// expiration).
+func (b *Batch) ZIncrBy(key, member string, n int64, ttl time.Duration) {
+	b.incrs = append(b.incrs, incr{counter{"ZINCRBY", key, member}, n, ttl})
+}
+
+// shard contains the aggregated increments of a subset of the keys.
+type shard struct {
+	sync.Mutex
+	values map[counter]int64
+	ttls   map[string]time.Duration
+}
+
+var (
+	buffering int32
+	shards    [shardCount]shard
+)
+
+func getShard(key string) *shard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &shards[h.Sum32()%shardCount]
+}
+
+// add aggregates the given increments into the buffer.
+func add(incrs []incr) {
+	for _, i := range incrs {
+		s := getShard(i.key)
+		s.Lock()
+		if s.values == nil {
+			s.values = make(map[counter]int64)
+			s.ttls = make(map[string]time.Duration)
+		}
+		s.values[i.counter] += i.n
+		if i.ttl > s.ttls[i.key] {
+			s.ttls[i.key] = i.ttl
+		}
+		s.Unlock()
+	}
+}
+
+// Add adds the increments of the given batch to the buffer. When buffering
+// is disabled, the increments are written directly (in a single
+// transaction).
+func Add(p *redis.Pool, b Batch) error {
+	if atomic.LoadInt32(&buffering) == 1 {
+		add(b.incrs)
+		return nil
+	}
+	return write(p, b.incrs)
+}
+
+// write writes the given increments in a single transaction.
+func write(p *redis.Pool, incrs []incr) error {
+	if len(incrs) == 0 {
+		return nil
+	}
+
+	ttls := make(map[string]time.Duration)
+	var keys []string
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	for _, i := range incrs {
+		if i.cmd == "INCRBY" {
+			c.Send(i.cmd, i.key, i.n)
+		} else if i.cmd == "ZINCRBY" {
+			c.Send(i.cmd, i.key, i.n, i.field)
+		} else {
+			c.Send(i.cmd, i.key, i.field, i.n)
+		}
+
+		if _, ok := ttls[i.key]; !ok {
+			keys = append(keys, i.key)
+		}
+		if i.ttl > ttls[i.key] {
+			ttls[i.key] = i.ttl
+		}
+	}
+	for _, key := range keys {
+		if ttl := ttls[key]; ttl > 0 {
+			c.Send("PEXPIRE", key, int64(ttl/time.Millisecond))
+		}
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "write counters error")
+	}
+	return nil
+}
+
+// Flush writes the buffered increments to Redis. In case of an error, the
+// increments are kept in the buffer so that they are written on the next
+// flush.
+func Flush(p *redis.Pool) error {
+	var incrs []incr
+	for i := range shards {
+		s := &shards[i]
+		s.Lock()
+		values, ttls := s.values, s.ttls
+		s.values, s.ttls = nil, nil
+		s.Unlock()
+
+		for c, n := range values {
+			incrs = append(incrs, incr{c, n, ttls[c.key]})
+		}
+	}
+
+	if err := write(p, incrs); err != nil {
+		add(incrs)
+		return err
+	}
+	return nil
+}
+
+// Run enables the buffering and flushes the buffered increments on the
+// given interval. When done is closed, the buffering is disabled and the
+// remaining increments are flushed before returning.
+func Run(p *redis.Pool, interval time.Duration, done chan struct{}) {
+	atomic.StoreInt32(&buffering, 1)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := Flush(p); err != nil {
+				log.Errorf("flush counters error: %s", err)
+			}
+		case <-done:
+			atomic.StoreInt32(&buffering, 0)
+			if err := Flush(p); err != nil {
+				log.Errorf("flush counters error: %s", err)
+			}
+			return
+		}
+	}
+}
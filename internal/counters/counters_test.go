@@ -0,0 +1,111 @@
+package counters
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func resetShards() {
+	for i := range shards {
+		shards[i].values, shards[i].ttls = nil, nil
+	}
+}
+
+func TestAdd(t *testing.T) {
+	Convey("Given buffering is enabled", t, func() {
+		resetShards()
+		atomic.StoreInt32(&buffering, 1)
+		defer atomic.StoreInt32(&buffering, 0)
+
+		Convey("When adding increments concurrently", func() {
+			var wg sync.WaitGroup
+			errs := make(chan error, 10)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var b Batch
+					b.HIncrBy("hash", "field", 2, time.Minute)
+					b.ZIncrBy("zset", "member", 1, time.Hour)
+					errs <- Add(nil, b)
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the increments are aggregated per counter", func() {
+				s := getShard("hash")
+				So(s.values[counter{"HINCRBY", "hash", "field"}], ShouldEqual, 20)
+				So(s.ttls["hash"], ShouldEqual, time.Minute)
+
+				s = getShard("zset")
+				So(s.values[counter{"ZINCRBY", "zset", "member"}], ShouldEqual, 10)
+				So(s.ttls["zset"], ShouldEqual, time.Hour)
+			})
+		})
+	})
+}
+
+func TestFlush(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and buffering enabled", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+		resetShards()
+		atomic.StoreInt32(&buffering, 1)
+		defer atomic.StoreInt32(&buffering, 0)
+
+		Convey("When adding increments", func() {
+			var b Batch
+			b.IncrBy("counter", 1, 0)
+			b.HIncrBy("hash", "field", 5, time.Minute)
+			b.ZIncrBy("zset", "member", 3, time.Minute)
+			So(Add(p, b), ShouldBeNil)
+			So(Add(p, b), ShouldBeNil)
+
+			c := p.Get()
+			defer c.Close()
+
+			Convey("Then they are not written before the flush", func() {
+				_, err := redis.Int(c.Do("GET", "counter"))
+				So(err, ShouldEqual, redis.ErrNil)
+			})
+
+			Convey("Then they are written on flush", func() {
+				So(Flush(p), ShouldBeNil)
+
+				v, err := redis.Int(c.Do("GET", "counter"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 2)
+
+				v, err = redis.Int(c.Do("HGET", "hash", "field"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 10)
+
+				v, err = redis.Int(c.Do("ZSCORE", "zset", "member"))
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, 6)
+
+				ttl, err := redis.Int(c.Do("PTTL", "hash"))
+				So(err, ShouldBeNil)
+				So(ttl, ShouldBeGreaterThan, 0)
+
+				ttl, err = redis.Int(c.Do("PTTL", "counter"))
+				So(err, ShouldBeNil)
+				So(ttl, ShouldEqual, -1)
+			})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+// Package deviceprofile implements the device-profiles. A device-profile
+// limits the downlink usage of the nodes using it (the max. number of
+// items in their device-queue and their daily downlink airtime), so that a
+// single tenant is not able to consume the shared gateway capacity of the
+// network (see CheckEnqueue and CheckPush).
+package deviceprofile
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Profile defines a device-profile.
+type Profile struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+
+	// MaxQueueSize defines the max. number of items in the device-queue
+	// of a node (0 = unlimited).
+	MaxQueueSize int `db:"max_queue_size"`
+
+	// DailyAirtimeQuota defines the max. downlink airtime per node per day
+	// (0 = unlimited). The quota resets at midnight in the configured
+	// timezone.
+	DailyAirtimeQuota time.Duration `db:"daily_airtime_quota"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate validates the device-profile.
+func (p Profile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrInvalidName
+	}
+	if p.MaxQueueSize < 0 {
+		return ErrInvalidMaxQueueSize
+	}
+	if p.DailyAirtimeQuota < 0 {
+		return ErrInvalidAirtimeQuota
+	}
+	return nil
+}
+
+// CreateProfile validates and creates the given device-profile. On
+// success, the ID and timestamps of the device-profile are set.
+func CreateProfile(db *sqlx.DB, p *Profile) error {
+	if err := p.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	err := db.Get(&p.ID, `
+		insert into device_profile (
+			name,
+			max_queue_size,
+			daily_airtime_quota,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $4)
+		returning id`,
+		p.Name,
+		p.MaxQueueSize,
+		p.DailyAirtimeQuota,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":   p.ID,
+		"name": p.Name,
+	}).Info("device-profile created")
+	return nil
+}
+
+// GetProfile returns the device-profile for the given ID.
+func GetProfile(db *sqlx.DB, id int64) (Profile, error) {
+	var p Profile
+	err := db.Get(&p, "select * from device_profile where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return p, ErrDoesNotExist
+		}
+		return p, errors.Wrap(err, "select error")
+	}
+	return p, nil
+}
+
+// UpdateProfile validates and updates the given device-profile. The
+// updated limits apply to the next downlink pushes of the nodes using it.
+func UpdateProfile(db *sqlx.DB, p *Profile) error {
+	if err := p.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	res, err := db.Exec(`
+		update device_profile set
+			name = $2,
+			max_queue_size = $3,
+			daily_airtime_quota = $4,
+			updated_at = $5
+		where id = $1`,
+		p.ID,
+		p.Name,
+		p.MaxQueueSize,
+		p.DailyAirtimeQuota,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	p.UpdatedAt = now
+
+	log.WithField("id", p.ID).Info("device-profile updated")
+	return nil
+}
+
+// DeleteProfile deletes the device-profile for the given ID. The nodes
+// using it are no longer limited.
+func DeleteProfile(db *sqlx.DB, id int64) error {
+	res, err := db.Exec("delete from device_profile where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("device-profile deleted")
+	return nil
+}
+
+// GetProfileCount returns the number of device-profiles.
+func GetProfileCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from device_profile")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetProfiles returns a slice of device-profiles, ordered by ID and
+// respecting the given limit and offset.
+func GetProfiles(db *sqlx.DB, limit, offset int) ([]Profile, error) {
+	var profiles []Profile
+	err := db.Select(&profiles, "select * from device_profile order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return profiles, nil
+}
@@ -0,0 +1,175 @@
+package deviceprofile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestProfileValidate(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Profile       Profile
+			ExpectedError error
+		}{
+			{Profile{Name: "tenant", MaxQueueSize: 10, DailyAirtimeQuota: time.Minute}, nil},
+			{Profile{Name: "unlimited"}, nil},
+			{Profile{Name: " "}, ErrInvalidName},
+			{Profile{Name: "tenant", MaxQueueSize: -1}, ErrInvalidMaxQueueSize},
+			{Profile{Name: "tenant", DailyAirtimeQuota: -time.Second}, ErrInvalidAirtimeQuota},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				So(tst.Profile.Validate(), ShouldEqual, tst.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestProfiles(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		Convey("When creating an invalid device-profile", func() {
+			err := CreateProfile(db, &Profile{})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidName)
+			})
+		})
+
+		Convey("When creating a device-profile", func() {
+			p := Profile{
+				Name:              "tenant",
+				MaxQueueSize:      10,
+				DailyAirtimeQuota: time.Minute,
+			}
+			So(CreateProfile(db, &p), ShouldBeNil)
+			p.CreatedAt = p.CreatedAt.UTC().Truncate(time.Millisecond)
+			p.UpdatedAt = p.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+			Convey("Then it can be retrieved", func() {
+				p2, err := GetProfile(db, p.ID)
+				So(err, ShouldBeNil)
+				p2.CreatedAt = p2.CreatedAt.UTC().Truncate(time.Millisecond)
+				p2.UpdatedAt = p2.UpdatedAt.UTC().Truncate(time.Millisecond)
+				So(p2, ShouldResemble, p)
+
+				count, err := GetProfileCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				profiles, err := GetProfiles(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(profiles, ShouldHaveLength, 1)
+				So(profiles[0].ID, ShouldEqual, p.ID)
+			})
+
+			Convey("Then it can be updated", func() {
+				p.MaxQueueSize = 5
+				So(UpdateProfile(db, &p), ShouldBeNil)
+
+				p2, err := GetProfile(db, p.ID)
+				So(err, ShouldBeNil)
+				So(p2.MaxQueueSize, ShouldEqual, 5)
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteProfile(db, p.ID), ShouldBeNil)
+				_, err := GetProfile(db, p.ID)
+				So(err, ShouldEqual, ErrDoesNotExist)
+				So(DeleteProfile(db, p.ID), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
+
+func TestLimits(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database and a device-profile", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		prof := Profile{
+			Name:              "tenant",
+			MaxQueueSize:      1,
+			DailyAirtimeQuota: time.Millisecond,
+		}
+		So(CreateProfile(db, &prof), ShouldBeNil)
+
+		ns := session.NodeSession{
+			DevEUI:          lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			DeviceProfileID: prof.ID,
+		}
+
+		Convey("Then an item can be enqueued and pushed", func() {
+			So(CheckEnqueue(db, p, ns), ShouldBeNil)
+			So(CheckPush(db, p, ns), ShouldBeNil)
+		})
+
+		Convey("When the device-queue holds the max. number of items", func() {
+			So(downlink.EnqueueDeviceQueueItem(p, ns, downlink.DeviceQueueItem{FPort: 1, Data: []byte{1}}), ShouldBeNil)
+
+			Convey("Then enqueueing an item fails", func() {
+				So(errors.Cause(CheckEnqueue(db, p, ns)), ShouldEqual, ErrDeviceQueueFull)
+			})
+
+			Convey("Then a node without device-profile is not limited", func() {
+				ns.DeviceProfileID = 0
+				So(CheckEnqueue(db, p, ns), ShouldBeNil)
+			})
+
+			Convey("Then a node of a deleted device-profile is not limited", func() {
+				So(DeleteProfile(db, prof.ID), ShouldBeNil)
+				So(CheckEnqueue(db, p, ns), ShouldBeNil)
+			})
+		})
+
+		Convey("When the node consumed its daily airtime quota", func() {
+			common.BandName = band.EU_863_870
+			So(airtime.RecordDownlink(p, ns.DevEUI, gw.TXPacket{
+				TXInfo: gw.TXInfo{
+					Frequency: 868100000,
+					DataRate:  band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125},
+				},
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataDown,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &lorawan.MACPayload{},
+				},
+			}), ShouldBeNil)
+
+			Convey("Then pushing and enqueueing fail with a quota error containing the reset time", func() {
+				for _, err := range []error{CheckPush(db, p, ns), CheckEnqueue(db, p, ns)} {
+					qe, ok := err.(*QuotaError)
+					So(ok, ShouldBeTrue)
+					So(qe.Quota, ShouldEqual, time.Millisecond)
+					So(qe.ResetAt, ShouldResemble, airtime.NextDayStart(time.Now()))
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package deviceprofile
+
+import "errors"
+
+// deviceprofile errors
+var (
+	ErrDoesNotExist        = errors.New("device-profile does not exist")
+	ErrInvalidName         = errors.New("device-profile name must not be empty")
+	ErrInvalidMaxQueueSize = errors.New("device-profile max queue size must not be negative")
+	ErrInvalidAirtimeQuota = errors.New("device-profile daily airtime quota must not be negative")
+	ErrDeviceQueueFull     = errors.New("device-queue holds the max. number of items of the device-profile")
+)
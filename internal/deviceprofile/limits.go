@@ -0,0 +1,107 @@
+package deviceprofile
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// QuotaError is returned when a node consumed the daily airtime quota of
+// its device-profile. ResetAt contains the time at which the quota resets
+// and pushes are accepted again.
+type QuotaError struct {
+	Quota   time.Duration
+	Used    time.Duration
+	ResetAt time.Time
+}
+
+// Error implements the error interface.
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("daily airtime quota of the device-profile exceeded (used: %s, quota: %s, resets at: %s)", e.Used, e.Quota, e.ResetAt.Format(time.RFC3339))
+}
+
+// CheckEnqueue returns an error when an item may not be added to the
+// device-queue of the given node: ErrDeviceQueueFull when the queue holds
+// the max. number of items or a *QuotaError when the daily airtime quota
+// has been consumed.
+func CheckEnqueue(db *sqlx.DB, p *redis.Pool, ns session.NodeSession) error {
+	prof, ok, err := getNodeProfile(db, ns)
+	if err != nil || !ok {
+		return err
+	}
+
+	if prof.MaxQueueSize > 0 {
+		size, err := downlink.GetDeviceQueueSize(p, ns.DevEUI)
+		if err != nil {
+			return err
+		}
+		if size >= prof.MaxQueueSize {
+			return errors.Wrapf(ErrDeviceQueueFull, "(max: %d)", prof.MaxQueueSize)
+		}
+	}
+
+	return checkAirtimeQuota(p, ns, prof)
+}
+
+// CheckPush returns a *QuotaError when the given node consumed the daily
+// airtime quota of its device-profile, in which case a downlink may not
+// be pushed to the node.
+func CheckPush(db *sqlx.DB, p *redis.Pool, ns session.NodeSession) error {
+	prof, ok, err := getNodeProfile(db, ns)
+	if err != nil || !ok {
+		return err
+	}
+	return checkAirtimeQuota(p, ns, prof)
+}
+
+// getNodeProfile returns the device-profile of the given node and if the
+// node has one. A deleted device-profile no longer limits its nodes.
+func getNodeProfile(db *sqlx.DB, ns session.NodeSession) (Profile, bool, error) {
+	if ns.DeviceProfileID == 0 {
+		return Profile{}, false, nil
+	}
+
+	prof, err := GetProfile(db, ns.DeviceProfileID)
+	if err != nil {
+		if err == ErrDoesNotExist {
+			return prof, false, nil
+		}
+		return prof, false, errors.Wrap(err, "get device-profile error")
+	}
+	return prof, true, nil
+}
+
+func checkAirtimeQuota(p *redis.Pool, ns session.NodeSession, prof Profile) error {
+	if prof.DailyAirtimeQuota == 0 {
+		return nil
+	}
+
+	used, err := airtime.GetDeviceAirtimeToday(p, ns.DevEUI)
+	if err != nil {
+		return err
+	}
+	if used < prof.DailyAirtimeQuota {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":           ns.DevEUI,
+		"device_profile_id": prof.ID,
+		"airtime":           used,
+		"quota":             prof.DailyAirtimeQuota,
+	}).Warning("daily airtime quota of device-profile exceeded")
+
+	return &QuotaError{
+		Quota:   prof.DailyAirtimeQuota,
+		Used:    used,
+		ResetAt: airtime.NextDayStart(time.Now()),
+	}
+}
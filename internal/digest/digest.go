@@ -0,0 +1,326 @@
+// Package digest implements the operator notification digests. Instead of
+// notifying the operator of every single operational event (e.g. a gateway
+// going offline or a device being quarantined), the events are accumulated
+// per severity in Redis (shared by all LoRa Server instances) and a summary
+// is delivered to the configured publishers on the interval configured for
+// the severity (see Run). Events of severities without interval are not
+// accumulated.
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/leader"
+)
+
+// digestKeyTempl contains per severity a hash with the time of the first
+// accumulated event (field: since) and the number of events per type and
+// subject (field: event:type:subject).
+const digestKeyTempl = "lora:ns:digest:%s"
+
+// Severity defines the severity of an event.
+type Severity string
+
+// Possible severities, from high to low.
+const (
+	Critical Severity = "CRITICAL"
+	Warning  Severity = "WARNING"
+	Info     Severity = "INFO"
+)
+
+// severities contains the severities, from high to low.
+var severities = []Severity{Critical, Warning, Info}
+
+// EventType defines the type of an event.
+type EventType string
+
+// Possible event types.
+const (
+	// GatewayOffline is emitted when the stats of a gateway timed out
+	// (subject: gateway MAC).
+	GatewayOffline EventType = "GATEWAY_OFFLINE"
+
+	// DeviceQuarantined is emitted when a node is quarantined because of
+	// repeated security events (subject: DevEUI).
+	DeviceQuarantined EventType = "DEVICE_QUARANTINED"
+
+	// ADRFailure is emitted when a node did not acknowledge an ADR request
+	// (subject: DevEUI).
+	ADRFailure EventType = "ADR_FAILURE"
+
+	// DutyCycleSaturation is emitted when a gateway rejected a downlink
+	// because its duty-cycle budget is consumed (subject: gateway MAC).
+	DutyCycleSaturation EventType = "DUTY_CYCLE_SATURATION"
+)
+
+// eventSeverity contains the severity per event type.
+var eventSeverity = map[EventType]Severity{
+	GatewayOffline:      Critical,
+	DeviceQuarantined:   Warning,
+	DutyCycleSaturation: Warning,
+	ADRFailure:          Info,
+}
+
+// intervals contains per severity the interval on which the digest is
+// delivered.
+var intervals map[Severity]time.Duration
+
+// Digest contains the summary of the events of a single severity,
+// accumulated since the first event.
+type Digest struct {
+	Time       time.Time `json:"time"`
+	Severity   Severity  `json:"severity"`
+	Since      time.Time `json:"since"`
+	EventCount int       `json:"eventCount"`
+	Summaries  []Summary `json:"summaries"`
+}
+
+// Summary contains the number of events of a single type, in total and per
+// subject (gateway MAC or DevEUI).
+type Summary struct {
+	Type     EventType      `json:"type"`
+	Count    int            `json:"count"`
+	Subjects map[string]int `json:"subjects"`
+}
+
+// Text returns the digest as plain text (e.g. for e-mail).
+func (d Digest) Text() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d %s event(s) between %s and %s\n", d.EventCount, strings.ToLower(string(d.Severity)), d.Since.Format(time.RFC3339), d.Time.Format(time.RFC3339))
+
+	for _, s := range d.Summaries {
+		fmt.Fprintf(&buf, "\n%s: %d\n", s.Type, s.Count)
+
+		var subjects []string
+		for subject := range s.Subjects {
+			subjects = append(subjects, subject)
+		}
+		sort.Strings(subjects)
+		for _, subject := range subjects {
+			fmt.Fprintf(&buf, "  %s: %d\n", subject, s.Subjects[subject])
+		}
+	}
+
+	return buf.String()
+}
+
+// MustSetIntervals sets the intervals on which the digests are delivered,
+// formatted as comma separated severity=duration pairs (e.g.
+// critical=5m,warning=1h,info=24h).
+func MustSetIntervals(s string) {
+	i, err := parseIntervals(s)
+	if err != nil {
+		log.Fatal(err)
+	}
+	intervals = i
+}
+
+// parseIntervals parses the given comma separated severity=duration pairs.
+func parseIntervals(s string) (map[Severity]time.Duration, error) {
+	out := make(map[Severity]time.Duration)
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid digest interval '%s' (expected severity=duration)", item)
+		}
+
+		severity := Severity(strings.ToUpper(strings.TrimSpace(parts[0])))
+		if !isSeverity(severity) {
+			return nil, fmt.Errorf("invalid digest severity '%s'", parts[0])
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse digest interval of '%s' error", severity)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("digest interval of '%s' must be greater than 0", severity)
+		}
+
+		out[severity] = d
+	}
+
+	return out, nil
+}
+
+func isSeverity(s Severity) bool {
+	for _, severity := range severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled returns true when at least one of the severities has a digest
+// interval.
+func Enabled() bool {
+	return len(intervals) > 0
+}
+
+// Record accumulates an event of the given type for the given subject
+// (gateway MAC or DevEUI) in the digest of its severity. It is a no-op when
+// the severity has no digest interval.
+func Record(p *redis.Pool, t EventType, subject string) error {
+	severity := eventSeverity[t]
+	if _, ok := intervals[severity]; !ok {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(digestKeyTempl, severity)
+
+	c.Send("MULTI")
+	c.Send("HSETNX", key, "since", time.Now().UnixNano())
+	c.Send("HINCRBY", key, fmt.Sprintf("event:%s:%s", t, subject), 1)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record digest event error")
+	}
+	return nil
+}
+
+// take returns and removes the accumulated digest of the given severity
+// when its interval has passed since the first accumulated event at the
+// given time. It returns false when the digest is not (yet) due.
+func take(p *redis.Pool, severity Severity, now time.Time) (Digest, bool, error) {
+	d := Digest{
+		Time:     now,
+		Severity: severity,
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(digestKeyTempl, severity)
+
+	since, err := redis.Int64(c.Do("HGET", key, "since"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return d, false, nil
+		}
+		return d, false, errors.Wrap(err, "get digest since error")
+	}
+	d.Since = time.Unix(0, since)
+	if now.Sub(d.Since) < intervals[severity] {
+		return d, false, nil
+	}
+
+	c.Send("MULTI")
+	c.Send("HGETALL", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return d, false, errors.Wrap(err, "take digest error")
+	}
+	fields, err := redis.StringMap(values[0], nil)
+	if err != nil {
+		return d, false, errors.Wrap(err, "take digest error")
+	}
+
+	summaries := make(map[EventType]*Summary)
+	for field, value := range fields {
+		if !strings.HasPrefix(field, "event:") {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(field, "event:"), ":", 2)
+		if len(parts) != 2 {
+			return d, false, fmt.Errorf("invalid digest field '%s'", field)
+		}
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return d, false, errors.Wrapf(err, "parse digest field '%s' error", field)
+		}
+
+		t := EventType(parts[0])
+		s, ok := summaries[t]
+		if !ok {
+			s = &Summary{
+				Type:     t,
+				Subjects: make(map[string]int),
+			}
+			summaries[t] = s
+		}
+		s.Count += count
+		s.Subjects[parts[1]] += count
+		d.EventCount += count
+	}
+
+	for _, s := range summaries {
+		d.Summaries = append(d.Summaries, *s)
+	}
+	sort.Slice(d.Summaries, func(i, j int) bool {
+		return d.Summaries[i].Type < d.Summaries[j].Type
+	})
+
+	return d, d.EventCount > 0, nil
+}
+
+// deliver delivers the due digests at the given time to the given
+// publishers. Publish errors are logged.
+func deliver(p *redis.Pool, publishers []Publisher, now time.Time) error {
+	for _, severity := range severities {
+		if _, ok := intervals[severity]; !ok {
+			continue
+		}
+
+		d, ok, err := take(p, severity, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"severity": d.Severity,
+			"since":    d.Since,
+			"events":   d.EventCount,
+		}).Info("delivering notification digest")
+
+		for _, pub := range publishers {
+			if err := pub.Publish(d); err != nil {
+				log.WithField("severity", d.Severity).Errorf("publish notification digest error: %s", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Run delivers the due digests every minute to the given publishers. When
+// an elector is given, the digests are only delivered by the leader. It
+// returns when done is closed.
+func Run(p *redis.Pool, elector *leader.Elector, publishers []Publisher, done chan struct{}) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+			if err := deliver(p, publishers, time.Now()); err != nil {
+				log.Errorf("deliver notification digests error: %s", err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
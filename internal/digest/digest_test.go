@@ -0,0 +1,167 @@
+package digest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+type testPublisher struct {
+	digests []Digest
+}
+
+func (p *testPublisher) Publish(d Digest) error {
+	p.digests = append(p.digests, d)
+	return nil
+}
+
+func TestParseIntervals(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Intervals     string
+			Expected      map[Severity]time.Duration
+			ExpectedError error
+		}{
+			{"", map[Severity]time.Duration{}, nil},
+			{"critical=5m, WARNING=1h", map[Severity]time.Duration{Critical: 5 * time.Minute, Warning: time.Hour}, nil},
+			{"critical", nil, errors.New("invalid digest interval 'critical' (expected severity=duration)")},
+			{"debug=5m", nil, errors.New("invalid digest severity 'debug'")},
+			{"info=0s", nil, errors.New("digest interval of 'INFO' must be greater than 0")},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %s [%d]", tst.Intervals, i), func() {
+				intervals, err := parseIntervals(tst.Intervals)
+				if tst.ExpectedError != nil {
+					So(err, ShouldResemble, tst.ExpectedError)
+				} else {
+					So(err, ShouldBeNil)
+					So(intervals, ShouldResemble, tst.Expected)
+				}
+			})
+		}
+	})
+}
+
+func TestDigest(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a digest interval for critical and warning events", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		intervals = map[Severity]time.Duration{
+			Critical: time.Minute,
+			Warning:  time.Hour,
+		}
+		pub := testPublisher{}
+
+		Convey("When recording events", func() {
+			So(Record(p, GatewayOffline, "0102030405060708"), ShouldBeNil)
+			So(Record(p, GatewayOffline, "0102030405060708"), ShouldBeNil)
+			So(Record(p, GatewayOffline, "0807060504030201"), ShouldBeNil)
+			So(Record(p, DeviceQuarantined, "0101010101010101"), ShouldBeNil)
+			So(Record(p, ADRFailure, "0202020202020202"), ShouldBeNil)
+
+			Convey("Then no digest is delivered before the interval has passed", func() {
+				So(deliver(p, []Publisher{&pub}, time.Now()), ShouldBeNil)
+				So(pub.digests, ShouldHaveLength, 0)
+			})
+
+			Convey("When the interval of the critical events has passed", func() {
+				now := time.Now().Add(2 * time.Minute)
+				So(deliver(p, []Publisher{&pub}, now), ShouldBeNil)
+
+				Convey("Then the critical digest is delivered", func() {
+					So(pub.digests, ShouldHaveLength, 1)
+					d := pub.digests[0]
+					So(d.Severity, ShouldEqual, Critical)
+					So(d.EventCount, ShouldEqual, 3)
+					So(d.Summaries, ShouldResemble, []Summary{
+						{
+							Type:  GatewayOffline,
+							Count: 3,
+							Subjects: map[string]int{
+								"0102030405060708": 2,
+								"0807060504030201": 1,
+							},
+						},
+					})
+				})
+
+				Convey("Then the critical events are not delivered twice", func() {
+					So(deliver(p, []Publisher{&pub}, now), ShouldBeNil)
+					So(pub.digests, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When the interval of the warning events has passed", func() {
+				So(deliver(p, []Publisher{&pub}, time.Now().Add(2*time.Hour)), ShouldBeNil)
+
+				Convey("Then the critical and warning digests are delivered, without the info events", func() {
+					So(pub.digests, ShouldHaveLength, 2)
+					So(pub.digests[0].Severity, ShouldEqual, Critical)
+					So(pub.digests[1].Severity, ShouldEqual, Warning)
+					So(pub.digests[1].EventCount, ShouldEqual, 1)
+					So(pub.digests[1].Summaries[0].Type, ShouldEqual, DeviceQuarantined)
+				})
+			})
+		})
+	})
+}
+
+func TestPublishers(t *testing.T) {
+	Convey("Given a digest", t, func() {
+		now := time.Now().UTC().Truncate(time.Second)
+		d := Digest{
+			Time:       now,
+			Severity:   Critical,
+			Since:      now.Add(-time.Minute),
+			EventCount: 2,
+			Summaries: []Summary{
+				{Type: GatewayOffline, Count: 2, Subjects: map[string]int{"0102030405060708": 2}},
+			},
+		}
+
+		Convey("Then the text contains the summaries", func() {
+			So(d.Text(), ShouldEqual, fmt.Sprintf("2 critical event(s) between %s and %s\n\nGATEWAY_OFFLINE: 2\n  0102030405060708: 2\n", d.Since.Format(time.RFC3339), now.Format(time.RFC3339)))
+		})
+
+		Convey("Given a test HTTP server", func() {
+			bodyChan := make(chan []byte, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				bodyChan <- b
+			}))
+			defer server.Close()
+
+			Convey("Then the HTTPPublisher posts the digest as JSON", func() {
+				So(NewHTTPPublisher(server.URL).Publish(d), ShouldBeNil)
+				var d2 Digest
+				So(json.Unmarshal(<-bodyChan, &d2), ShouldBeNil)
+				So(d2, ShouldResemble, d)
+			})
+
+			Convey("Then the EmailPublisher posts the digest as e-mail message", func() {
+				So(NewEmailPublisher(server.URL, []string{"noc@example.com"}).Publish(d), ShouldBeNil)
+				var msg EmailMessage
+				So(json.Unmarshal(<-bodyChan, &msg), ShouldBeNil)
+				So(msg, ShouldResemble, EmailMessage{
+					To:      []string{"noc@example.com"},
+					Subject: "LoRa Server CRITICAL digest: 2 event(s)",
+					Body:    d.Text(),
+				})
+			})
+		})
+	})
+}
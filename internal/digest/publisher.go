@@ -0,0 +1,67 @@
+package digest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/joriwind/loraserver/internal/webhook"
+)
+
+// Publisher defines the interface for publishing the digests.
+type Publisher interface {
+	Publish(d Digest) error
+}
+
+// HTTPPublisher posts the digests as JSON (HTTP POST) to the given URL
+// (webhook).
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a new HTTPPublisher.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish posts the given digest.
+func (p *HTTPPublisher) Publish(d Digest) error {
+	return webhook.Post(p.Client, p.URL, d)
+}
+
+// EmailMessage contains the e-mail message posted to the e-mail gateway.
+type EmailMessage struct {
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// EmailPublisher posts the digests as plain text e-mail message (JSON,
+// HTTP POST) to the given URL of an e-mail gateway, which sends the
+// message to the given recipients.
+type EmailPublisher struct {
+	URL    string
+	To     []string
+	Client *http.Client
+}
+
+// NewEmailPublisher creates a new EmailPublisher.
+func NewEmailPublisher(url string, to []string) *EmailPublisher {
+	return &EmailPublisher{
+		URL:    url,
+		To:     to,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish posts the given digest as e-mail message.
+func (p *EmailPublisher) Publish(d Digest) error {
+	return webhook.Post(p.Client, p.URL, EmailMessage{
+		To:      p.To,
+		Subject: fmt.Sprintf("LoRa Server %s digest: %d event(s)", d.Severity, d.EventCount),
+		Body:    d.Text(),
+	})
+}
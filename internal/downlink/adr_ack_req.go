@@ -0,0 +1,83 @@
+package downlink
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/adr"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// adrACKReqKeyTempl contains per node (with legacy ADRACKReq handling) the
+// uplink frame-counter of the last responded ADRACKReq.
+const adrACKReqKeyTempl = "lora:ns:node:adr_ack_req:%s"
+
+// legacyADRACKReqInterval defines the number of uplinks (ADR_ACK_DELAY of
+// LoRaWAN 1.0.x) within which the ADRACKReqs of a node with legacy
+// ADRACKReq handling are responded at most once.
+const legacyADRACKReqInterval = 32
+
+// respondToADRACKReq returns true when the ADRACKReq of the given uplink
+// must be responded (unless disabled by the ADR parameters). For nodes with
+// legacy ADRACKReq handling, an ADRACKReq within legacyADRACKReqInterval
+// uplinks of the last responded ADRACKReq is not responded, as these nodes
+// keep setting the ADRACKReq bit. On error, the ADRACKReq is responded.
+func respondToADRACKReq(p *redis.Pool, ns session.NodeSession, macPL *lorawan.MACPayload) bool {
+	if !macPL.FHDR.FCtrl.ADRACKReq || !adr.GetParameters().RespondToADRACKReq {
+		return false
+	}
+	if !ns.LegacyADRACKReq {
+		return true
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	fCnt, err := redis.Int64(c.Do("GET", fmt.Sprintf(adrACKReqKeyTempl, ns.DevEUI)))
+	if err != nil {
+		if err != redis.ErrNil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("get last responded adrackreq error: %s", err)
+		}
+		return true
+	}
+
+	// the frame-counter has been reset (e.g. on re-activation)
+	if int64(macPL.FHDR.FCnt) < fCnt {
+		return true
+	}
+
+	if int64(macPL.FHDR.FCnt)-fCnt < legacyADRACKReqInterval {
+		log.WithFields(log.Fields{
+			"dev_eui":        ns.DevEUI,
+			"fcnt":           macPL.FHDR.FCnt,
+			"responded_fcnt": fCnt,
+		}).Info("not responding to repeated adrackreq of node with legacy adrackreq handling")
+		return false
+	}
+	return true
+}
+
+// setADRACKReqResponded stores the given uplink frame-counter as the last
+// responded ADRACKReq of the given node (only for nodes with legacy
+// ADRACKReq handling).
+func setADRACKReqResponded(p *redis.Pool, ns session.NodeSession, fCnt uint32) error {
+	if !ns.LegacyADRACKReq {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(adrACKReqKeyTempl, ns.DevEUI), int64(common.NodeSessionTTL/time.Millisecond), fCnt)
+	if err != nil {
+		return errors.Wrap(err, "set adrackreq responded error")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package downlink
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestRespondToADRACKReq(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		macPL := &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				FCtrl: lorawan.FCtrl{ADRACKReq: true},
+				FCnt:  100,
+			},
+		}
+
+		Convey("Then an uplink without ADRACKReq is not responded", func() {
+			So(respondToADRACKReq(p, ns, &lorawan.MACPayload{}), ShouldBeFalse)
+		})
+
+		Convey("Given a node with standard ADRACKReq handling", func() {
+			Convey("Then each ADRACKReq is responded", func() {
+				So(respondToADRACKReq(p, ns, macPL), ShouldBeTrue)
+				So(setADRACKReqResponded(p, ns, 100), ShouldBeNil)
+				macPL.FHDR.FCnt = 101
+				So(respondToADRACKReq(p, ns, macPL), ShouldBeTrue)
+			})
+		})
+
+		Convey("Given a node with legacy ADRACKReq handling", func() {
+			ns.LegacyADRACKReq = true
+
+			Convey("Then the first ADRACKReq is responded", func() {
+				So(respondToADRACKReq(p, ns, macPL), ShouldBeTrue)
+			})
+
+			Convey("When an ADRACKReq has been responded", func() {
+				So(setADRACKReqResponded(p, ns, 100), ShouldBeNil)
+
+				Convey("Then the ADRACKReqs within the interval are not responded", func() {
+					macPL.FHDR.FCnt = 100 + legacyADRACKReqInterval - 1
+					So(respondToADRACKReq(p, ns, macPL), ShouldBeFalse)
+				})
+
+				Convey("Then the ADRACKReq after the interval is responded", func() {
+					macPL.FHDR.FCnt = 100 + legacyADRACKReqInterval
+					So(respondToADRACKReq(p, ns, macPL), ShouldBeTrue)
+				})
+
+				Convey("Then the ADRACKReq after a frame-counter reset is responded", func() {
+					macPL.FHDR.FCnt = 1
+					So(respondToADRACKReq(p, ns, macPL), ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package downlink
+
+import (
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// isDailyAirtimeCapReached returns true when the given node has consumed
+// its DailyDownlinkAirtimeCap today. ACKs and mac-commands are still sent,
+// but application payloads are left in the queue until the next day. On
+// error, the cap is not enforced as the accounting must not block the
+// downlink.
+func isDailyAirtimeCapReached(ctx common.Context, ns session.NodeSession) bool {
+	if ns.DailyDownlinkAirtimeCap == 0 {
+		return false
+	}
+
+	used, err := airtime.GetDeviceAirtimeToday(ctx.RedisPool, ns.DevEUI)
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("get device airtime error: %s", err)
+		return false
+	}
+
+	if used < ns.DailyDownlinkAirtimeCap {
+		return false
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": ns.DevEUI,
+		"airtime": used,
+		"cap":     ns.DailyDownlinkAirtimeCap,
+	}).Warning("daily downlink airtime cap reached")
+	return true
+}
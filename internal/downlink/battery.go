@@ -0,0 +1,57 @@
+package downlink
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// isBatteryThrottled returns true when confirmed downlinks which are not
+// critical must be throttled for the given node, as the battery level last
+// reported by the node is below its BatteryThrottleLevel. Nodes connected to
+// an external power source (0) or which are not able to measure their
+// battery level (255) are never throttled.
+func isBatteryThrottled(ns session.NodeSession) bool {
+	if ns.BatteryThrottleLevel == 0 || ns.BatteryLevelUpdatedAt.IsZero() {
+		return false
+	}
+	if ns.BatteryLevel == 0 || ns.BatteryLevel == 255 {
+		return false
+	}
+	return ns.BatteryLevel < ns.BatteryThrottleLevel
+}
+
+// throttleConfirmed returns if the downlink must be sent as confirmed
+// downlink. A confirmed downlink which is not critical is sent as
+// unconfirmed downlink (so that the node does not need to transmit an ACK)
+// when the node is battery throttled, in which case the application-server
+// is notified.
+func throttleConfirmed(ctx common.Context, ns session.NodeSession, confirmed, critical bool) bool {
+	if !confirmed || critical || !isBatteryThrottled(ns) {
+		return confirmed
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":                ns.DevEUI,
+		"fcnt":                   ns.FCntDown,
+		"battery_level":          ns.BatteryLevel,
+		"battery_throttle_level": ns.BatteryThrottleLevel,
+	}).Warning("battery level below throttle level, sending confirmed downlink as unconfirmed")
+
+	_, err := ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: ns.AppEUI[:],
+		DevEUI: ns.DevEUI[:],
+		Type:   as.ErrorType_DATA_DOWN_BATTERY_THROTTLED,
+		Error:  fmt.Sprintf("confirmed downlink with fcnt %d sent as unconfirmed (battery level %d below %d)", ns.FCntDown, ns.BatteryLevel, ns.BatteryThrottleLevel),
+	})
+	if err != nil {
+		log.Errorf("call application-server handle error method error: %s", err)
+	}
+
+	return false
+}
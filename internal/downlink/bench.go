@@ -0,0 +1,259 @@
+package downlink
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// benchDownlinkKeyTempl contains per DevAddr the downlink enqueued for an
+// unprovisioned test device.
+const benchDownlinkKeyTempl = "lora:ns:bench:downlink:%s"
+
+// benchDownlinkTTL defines the duration an enqueued bench downlink is kept
+// when no uplink is received from the test device.
+const benchDownlinkTTL = time.Hour
+
+// BenchDownlink contains a downlink for an unprovisioned test device (lab /
+// bench testing). It is sent in response to the next uplink of the DevAddr
+// for which no node-session exists, without creating a node-session.
+type BenchDownlink struct {
+	DevAddr     lorawan.DevAddr
+	NwkSKey     lorawan.AES128Key
+	AppSKey     *lorawan.AES128Key // nil = the data is sent as-is
+	FCnt        uint32
+	FPort       uint8
+	Data        []byte
+	MACCommands [][]byte // the encoded mac-commands (CID and payload)
+	Confirmed   bool
+}
+
+// EnqueueBenchDownlink stores the given bench downlink, replacing the
+// downlink already enqueued for the DevAddr (if any). It returns an error
+// when bench mode is disabled.
+func EnqueueBenchDownlink(p *redis.Pool, d BenchDownlink) error {
+	if !common.BenchMode {
+		return ErrBenchModeDisabled
+	}
+	if common.ReadOnlyMode {
+		return ErrReadOnlyMode
+	}
+	if d.FPort == 0 && len(d.Data) > 0 {
+		return ErrFPortMustNotBeZero
+	}
+	if _, err := getBenchMACCommands(d); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		return errors.Wrap(err, "gob encode bench downlink error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("PSETEX", fmt.Sprintf(benchDownlinkKeyTempl, d.DevAddr), int64(benchDownlinkTTL/time.Millisecond), buf.Bytes()); err != nil {
+		return errors.Wrap(err, "save bench downlink error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_addr": d.DevAddr,
+		"fcnt":     d.FCnt,
+		"f_port":   d.FPort,
+	}).Warning("bench downlink enqueued")
+	return nil
+}
+
+// GetBenchDownlink returns the bench downlink enqueued for the DevAddr of
+// the given uplink, when its NwkSKey matches the MIC of the uplink. It
+// returns nil when bench mode is disabled or when there is no (matching)
+// bench downlink. Note that the MIC is validated using the 16 LSB of the
+// uplink frame-counter.
+func GetBenchDownlink(p *redis.Pool, phy lorawan.PHYPayload) (*BenchDownlink, error) {
+	if !common.BenchMode {
+		return nil, nil
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return nil, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(benchDownlinkKeyTempl, macPL.FHDR.DevAddr)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get bench downlink error")
+	}
+
+	var d BenchDownlink
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&d); err != nil {
+		return nil, errors.Wrap(err, "gob decode bench downlink error")
+	}
+
+	ok, err = phy.ValidateMIC(d.NwkSKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// SendBenchDownlink sends the given bench downlink in RX1 of the best
+// gateway which received the given uplink and removes it. As the test
+// device is not provisioned, the downlink is not tracked (no frame log,
+// airtime or downlink decision) and no node-session is updated.
+func SendBenchDownlink(ctx common.Context, d BenchDownlink, rxPacket models.RXPacket) error {
+	if len(rxPacket.RXInfoSet) == 0 {
+		return ErrNoLastRXInfoSet
+	}
+
+	c := ctx.RedisPool.Get()
+	removed, err := redis.Int(c.Do("DEL", fmt.Sprintf(benchDownlinkKeyTempl, d.DevAddr)))
+	c.Close()
+	if err != nil {
+		return errors.Wrap(err, "delete bench downlink error")
+	}
+	// the downlink has already been sent by an other process
+	if removed == 0 {
+		return nil
+	}
+
+	// a transient node-session with the defaults of the band, used for
+	// calculating the TX parameters only
+	ns := session.NodeSession{
+		DevAddr:  d.DevAddr,
+		NwkSKey:  d.NwkSKey,
+		RXWindow: session.RX1,
+	}
+	txInfo, dr, err := getDataDownTXInfoAndDR(ctx, ns, rxPacket.RXInfoSet[0])
+	if err != nil {
+		return errors.Wrap(err, "get data down txinfo error")
+	}
+
+	phy, err := getBenchPHYPayload(d, rxPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataUp)
+	if err != nil {
+		return err
+	}
+	var size int
+	for _, b := range d.MACCommands {
+		size += len(b)
+	}
+	if size+len(d.Data) > common.Band.MaxPayloadSize[dr].N || (d.FPort > 0 && size > 15) {
+		return ErrMaxPayloadSizeExceeded
+	}
+
+	if err := ctx.RequestContext().Err(); err != nil {
+		return errors.Wrap(ErrDeadlineExceeded, err.Error())
+	}
+
+	token, err := framelog.NewToken(ctx.RedisPool)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Gateway.SendTXPacket(gw.TXPacket{
+		Token:      token,
+		TXInfo:     txInfo,
+		PHYPayload: phy,
+	}); err != nil {
+		return errors.Wrap(ErrPublishTXPacket, err.Error())
+	}
+
+	log.WithFields(log.Fields{
+		"dev_addr": d.DevAddr,
+		"fcnt":     d.FCnt,
+		"mac":      txInfo.MAC,
+		"token":    token,
+	}).Warning("bench downlink sent")
+	return nil
+}
+
+// getBenchPHYPayload returns the signed (and encrypted) PHYPayload of the
+// given bench downlink.
+func getBenchPHYPayload(d BenchDownlink, ack bool) (lorawan.PHYPayload, error) {
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+	}
+	if d.Confirmed {
+		phy.MHDR.MType = lorawan.ConfirmedDataDown
+	}
+
+	macPL := &lorawan.MACPayload{
+		FHDR: lorawan.FHDR{
+			DevAddr: d.DevAddr,
+			FCtrl: lorawan.FCtrl{
+				ACK: ack,
+			},
+			FCnt: d.FCnt,
+		},
+	}
+	phy.MACPayload = macPL
+
+	commands, err := getBenchMACCommands(d)
+	if err != nil {
+		return phy, err
+	}
+
+	if d.FPort == 0 {
+		if len(commands) > 0 {
+			fPort := uint8(0)
+			macPL.FPort = &fPort
+			for i := range commands {
+				macPL.FRMPayload = append(macPL.FRMPayload, &commands[i])
+			}
+			if err := phy.EncryptFRMPayload(d.NwkSKey); err != nil {
+				return phy, errors.Wrap(err, "encrypt FRMPayload error")
+			}
+		}
+	} else {
+		macPL.FHDR.FOpts = commands
+		macPL.FPort = &d.FPort
+		macPL.FRMPayload = []lorawan.Payload{
+			&lorawan.DataPayload{Bytes: d.Data},
+		}
+		if d.AppSKey != nil {
+			if err := phy.EncryptFRMPayload(*d.AppSKey); err != nil {
+				return phy, errors.Wrap(err, "encrypt FRMPayload error")
+			}
+		}
+	}
+
+	if err := phy.SetMIC(d.NwkSKey); err != nil {
+		return phy, errors.Wrap(err, "set MIC error")
+	}
+	return phy, nil
+}
+
+// getBenchMACCommands returns the decoded mac-commands of the given bench
+// downlink.
+func getBenchMACCommands(d BenchDownlink) ([]lorawan.MACCommand, error) {
+	var out []lorawan.MACCommand
+	for _, b := range d.MACCommands {
+		var cmd lorawan.MACCommand
+		if err := cmd.UnmarshalBinary(false, b); err != nil {
+			return nil, errors.Wrap(ErrInvalidBenchMACCommand, err.Error())
+		}
+		out = append(out, cmd)
+	}
+	return out, nil
+}
@@ -0,0 +1,127 @@
+package downlink
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestBenchDownlink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a bench downlink", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		defer func(benchMode bool) {
+			common.BenchMode = benchMode
+		}(common.BenchMode)
+
+		d := BenchDownlink{
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			NwkSKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			FCnt:        5,
+			MACCommands: [][]byte{{byte(lorawan.DevStatusReq)}},
+		}
+
+		uplink := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: d.DevAddr,
+					FCnt:    10,
+				},
+			},
+		}
+		So(uplink.SetMIC(d.NwkSKey), ShouldBeNil)
+
+		Convey("When bench mode is disabled", func() {
+			common.BenchMode = false
+
+			Convey("Then enqueueing the bench downlink returns an error", func() {
+				So(EnqueueBenchDownlink(p, d), ShouldResemble, ErrBenchModeDisabled)
+			})
+		})
+
+		Convey("When bench mode is enabled", func() {
+			common.BenchMode = true
+
+			Convey("Then an invalid mac-command is rejected", func() {
+				d.MACCommands = [][]byte{{0xff}}
+				So(errors.Cause(EnqueueBenchDownlink(p, d)), ShouldResemble, ErrInvalidBenchMACCommand)
+			})
+
+			Convey("Given the bench downlink is enqueued", func() {
+				So(EnqueueBenchDownlink(p, d), ShouldBeNil)
+
+				Convey("Then it is returned for an uplink signed with the NwkSKey", func() {
+					out, err := GetBenchDownlink(p, uplink)
+					So(err, ShouldBeNil)
+					So(out, ShouldResemble, &d)
+				})
+
+				Convey("Then it is not returned for an uplink signed with an other key", func() {
+					So(uplink.SetMIC(lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1}), ShouldBeNil)
+					out, err := GetBenchDownlink(p, uplink)
+					So(err, ShouldBeNil)
+					So(out, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
+
+func TestGetBenchPHYPayload(t *testing.T) {
+	Convey("Given a bench downlink with a mac-command and FPort 0", t, func() {
+		d := BenchDownlink{
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			NwkSKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			FCnt:        5,
+			MACCommands: [][]byte{{byte(lorawan.DevStatusReq)}},
+			Confirmed:   true,
+		}
+
+		Convey("Then the mac-command is sent as FRMPayload encrypted with the NwkSKey", func() {
+			phy, err := getBenchPHYPayload(d, true)
+			So(err, ShouldBeNil)
+			So(phy.MHDR.MType, ShouldEqual, lorawan.ConfirmedDataDown)
+
+			ok, err := phy.ValidateMIC(d.NwkSKey)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			So(phy.DecryptFRMPayload(d.NwkSKey), ShouldBeNil)
+			macPL := phy.MACPayload.(*lorawan.MACPayload)
+			So(macPL.FHDR.FCtrl.ACK, ShouldBeTrue)
+			So(macPL.FHDR.FCnt, ShouldEqual, 5)
+			So(*macPL.FPort, ShouldEqual, 0)
+			So(macPL.FRMPayload, ShouldHaveLength, 1)
+			cmd, ok := macPL.FRMPayload[0].(*lorawan.MACCommand)
+			So(ok, ShouldBeTrue)
+			So(cmd.CID, ShouldEqual, lorawan.DevStatusReq)
+		})
+
+		Convey("Given FPort 10 and data", func() {
+			d.FPort = 10
+			d.Data = []byte{1, 2, 3}
+
+			Convey("Then the mac-command is sent as FOpts and the data as-is", func() {
+				phy, err := getBenchPHYPayload(d, false)
+				So(err, ShouldBeNil)
+				macPL := phy.MACPayload.(*lorawan.MACPayload)
+				So(macPL.FHDR.FOpts, ShouldHaveLength, 1)
+				So(macPL.FHDR.FOpts[0].CID, ShouldEqual, lorawan.DevStatusReq)
+				So(*macPL.FPort, ShouldEqual, 10)
+				So(macPL.FRMPayload, ShouldResemble, []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3}}})
+			})
+		})
+	})
+}
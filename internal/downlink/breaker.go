@@ -0,0 +1,70 @@
+package downlink
+
+import (
+	"sync"
+	"time"
+)
+
+// applicationBreaker is the circuit breaker of the GetDataDown calls to the
+// application-server.
+var applicationBreaker circuitBreaker
+
+// circuitBreaker short-circuits the calls to a degraded service. It opens
+// after the given number of consecutive failures. After the cooldown, a
+// single trial call is let through. A successful trial call closes the
+// breaker, a failed trial call opens it again.
+type circuitBreaker struct {
+	sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// allow returns true when the call is allowed. A threshold of 0 disables
+// the circuit breaker.
+func (b *circuitBreaker) allow(threshold int, now time.Time) bool {
+	if threshold <= 0 {
+		return true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	if b.failures < threshold {
+		return true
+	}
+
+	// open or a trial call is in progress
+	if now.Before(b.openUntil) || b.trial {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+// report reports the result of an allowed call. It returns true when the
+// call opened the breaker.
+func (b *circuitBreaker) report(threshold int, cooldown time.Duration, now time.Time, err error) bool {
+	if threshold <= 0 {
+		return false
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.trial = false
+
+	if err == nil {
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.failures < threshold {
+		return false
+	}
+
+	b.openUntil = now.Add(cooldown)
+	return true
+}
@@ -0,0 +1,72 @@
+package downlink
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	Convey("Given a circuit breaker with threshold 3 and cooldown 30s", t, func() {
+		var b circuitBreaker
+		threshold := 3
+		cooldown := 30 * time.Second
+		now := time.Now()
+		errFailed := errors.New("failed")
+
+		Convey("Then calls are allowed", func() {
+			So(b.allow(threshold, now), ShouldBeTrue)
+		})
+
+		Convey("When reporting 2 consecutive failures and a success", func() {
+			So(b.report(threshold, cooldown, now, errFailed), ShouldBeFalse)
+			So(b.report(threshold, cooldown, now, errFailed), ShouldBeFalse)
+			So(b.report(threshold, cooldown, now, nil), ShouldBeFalse)
+
+			Convey("Then a next failure does not open the breaker", func() {
+				So(b.report(threshold, cooldown, now, errFailed), ShouldBeFalse)
+				So(b.allow(threshold, now), ShouldBeTrue)
+			})
+		})
+
+		Convey("When reporting 3 consecutive failures", func() {
+			So(b.report(threshold, cooldown, now, errFailed), ShouldBeFalse)
+			So(b.report(threshold, cooldown, now, errFailed), ShouldBeFalse)
+			So(b.report(threshold, cooldown, now, errFailed), ShouldBeTrue)
+
+			Convey("Then calls are not allowed within the cooldown", func() {
+				So(b.allow(threshold, now.Add(29*time.Second)), ShouldBeFalse)
+			})
+
+			Convey("Then calls are allowed when the breaker is disabled", func() {
+				So(b.allow(0, now), ShouldBeTrue)
+			})
+
+			Convey("Then a single trial call is allowed after the cooldown", func() {
+				later := now.Add(cooldown)
+				So(b.allow(threshold, later), ShouldBeTrue)
+				So(b.allow(threshold, later), ShouldBeFalse)
+
+				Convey("When the trial call succeeds", func() {
+					So(b.report(threshold, cooldown, later, nil), ShouldBeFalse)
+
+					Convey("Then the breaker is closed", func() {
+						So(b.allow(threshold, later), ShouldBeTrue)
+						So(b.allow(threshold, later), ShouldBeTrue)
+					})
+				})
+
+				Convey("When the trial call fails", func() {
+					So(b.report(threshold, cooldown, later, errFailed), ShouldBeTrue)
+
+					Convey("Then the breaker is open for an other cooldown", func() {
+						So(b.allow(threshold, later.Add(29*time.Second)), ShouldBeFalse)
+						So(b.allow(threshold, later.Add(cooldown)), ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,232 @@
+package downlink
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// broadcastScanCount defines the number of node-sessions to fetch per
+// batch when selecting the nodes of a broadcast.
+const broadcastScanCount = 100
+
+// BroadcastFilter defines the nodes to which a broadcast is sent. Only
+// Class-C nodes of which the AppSKey encryption is offloaded to LoRa
+// Server are eligible, as the broadcast payload is encrypted per node.
+type BroadcastFilter struct {
+	AppEUI *lorawan.EUI64 // nil = all applications
+}
+
+// BroadcastResult contains the result of scheduling a broadcast.
+type BroadcastResult struct {
+	Scheduled int // the number of nodes to which the payload will be sent
+	Skipped   int // the number of matching nodes which are not eligible
+}
+
+// Broadcast sends the given (plaintext) payload as unconfirmed downlink to
+// all eligible nodes matching the given filter. The downlinks are sent in
+// the background, per gateway paced so that the duty-cycle budget of the
+// gateway is respected. When a dispersal period is given, the downlinks of
+// each gateway are spread in random order over this period (see
+// getBroadcastOffsets). It returns once the nodes have been selected.
+func Broadcast(ctx common.Context, filter BroadcastFilter, fPort uint8, data []byte, dispersalPeriod time.Duration) (BroadcastResult, error) {
+	var result BroadcastResult
+
+	if fPort == 0 {
+		return result, ErrFPortMustNotBeZero
+	}
+
+	perGateway := make(map[lorawan.EUI64][]lorawan.EUI64)
+	now := time.Now()
+
+	var cursor uint64
+	for {
+		// stop selecting the nodes when the caller gave up
+		if err := ctx.RequestContext().Err(); err != nil {
+			return result, err
+		}
+
+		sessions, next, err := session.ListNodeSessions(ctx.RedisPool, cursor, broadcastScanCount)
+		if err != nil {
+			return result, errors.Wrap(err, "list node-sessions error")
+		}
+
+		for _, ns := range sessions {
+			if filter.AppEUI != nil && ns.AppEUI != *filter.AppEUI {
+				continue
+			}
+			// like for pushed downlinks, nodes of which the device class
+			// is not known are handled as Class-C
+			if ns.GetDeviceClass(common.DeviceClassChangeLockout, now) == session.DeviceClassA {
+				continue
+			}
+
+			rxInfo, err := getBroadcastRXInfo(ctx, ns, len(data))
+			if err != nil {
+				log.WithField("dev_eui", ns.DevEUI).Warningf("broadcast skipped: %s", err)
+				result.Skipped++
+				continue
+			}
+			perGateway[rxInfo.MAC] = append(perGateway[rxInfo.MAC], ns.DevEUI)
+			result.Scheduled++
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// the downlinks are sent after returning, thus must not be cancelled
+	// together with the request context
+	bgCtx := ctx.WithContext(context.Background())
+	for mac, devEUIs := range perGateway {
+		go broadcastViaGateway(bgCtx, mac, devEUIs, fPort, data, dispersalPeriod)
+	}
+
+	log.WithFields(log.Fields{
+		"f_port":    fPort,
+		"dispersal": dispersalPeriod,
+		"scheduled": result.Scheduled,
+		"skipped":   result.Skipped,
+		"gateways":  len(perGateway),
+	}).Info("broadcast scheduled")
+
+	return result, nil
+}
+
+// getBroadcastRXInfo returns the RXInfo of the gateway via which the
+// broadcast is sent to the given node, or an error when the node is not
+// eligible.
+func getBroadcastRXInfo(ctx common.Context, ns session.NodeSession, size int) (gw.RXInfo, error) {
+	if ns.AppSKey == nil {
+		return gw.RXInfo{}, ErrAppSKeyNotOffloaded
+	}
+	if ns.DeviceClassChangePending(common.DeviceClassChangeLockout, time.Now()) {
+		return gw.RXInfo{}, ErrDeviceClassChangePending
+	}
+	if int(ns.RX2DR) > len(common.Band.DataRates)-1 {
+		return gw.RXInfo{}, ErrInvalidDataRate
+	}
+	if size > common.Band.MaxPayloadSize[ns.RX2DR].N {
+		return gw.RXInfo{}, ErrMaxPayloadSizeExceeded
+	}
+	return getAllowedRXInfo(ctx, ns, ns.LastRXInfoSet)
+}
+
+// broadcastViaGateway sends the broadcast to the given nodes, waiting
+// after each downlink until the duty-cycle budget of the gateway allows
+// the next transmission. With a dispersal period, the nodes are shuffled
+// and each downlink is additionally delayed until its random offset within
+// this period.
+func broadcastViaGateway(ctx common.Context, mac lorawan.EUI64, devEUIs []lorawan.EUI64, fPort uint8, data []byte, dispersalPeriod time.Duration) {
+	var sent int
+
+	start := time.Now()
+	var offsets []time.Duration
+	if dispersalPeriod > 0 {
+		shuffled := make([]lorawan.EUI64, len(devEUIs))
+		for i, j := range rand.Perm(len(devEUIs)) {
+			shuffled[i] = devEUIs[j]
+		}
+		devEUIs = shuffled
+		offsets = getBroadcastOffsets(len(devEUIs), dispersalPeriod)
+	}
+
+	var nextTX time.Time
+	for i, devEUI := range devEUIs {
+		if offsets != nil && start.Add(offsets[i]).After(nextTX) {
+			nextTX = start.Add(offsets[i])
+		}
+		if d := time.Until(nextTX); d > 0 {
+			time.Sleep(d)
+		}
+
+		// the node-session is fetched just before sending, as the
+		// frame-counter has most likely changed in the meantime
+		ns, err := session.GetNodeSession(ctx.RedisPool, devEUI)
+		if err != nil {
+			log.WithField("dev_eui", devEUI).Errorf("broadcast: get node-session error: %s", err)
+			continue
+		}
+
+		if err := HandlePushDataDown(ctx, ns, false, false, fPort, data, models.TXParams{}, ""); err != nil {
+			log.WithField("dev_eui", devEUI).Errorf("broadcast: push data down error: %s", err)
+			continue
+		}
+		sent++
+
+		nextTX = time.Now().Add(getBroadcastOffTime(int(ns.RX2DR), fPort, data))
+	}
+
+	log.WithFields(log.Fields{
+		"mac":      mac,
+		"nodes":    len(devEUIs),
+		"sent":     sent,
+		"duration": time.Since(start),
+	}).Info("broadcast via gateway completed")
+}
+
+// getBroadcastOffsets returns n increasing random offsets within the given
+// period. The period is divided in n equal slots and each offset lies at a
+// random position within its slot, so that the downlinks are spread evenly
+// without being transmitted at a fixed interval.
+func getBroadcastOffsets(n int, period time.Duration) []time.Duration {
+	if n == 0 {
+		return nil
+	}
+
+	slot := period / time.Duration(n)
+	offsets := make([]time.Duration, n)
+	for i := range offsets {
+		offsets[i] = slot * time.Duration(i)
+		if slot > 0 {
+			offsets[i] += time.Duration(rand.Int63n(int64(slot)))
+		}
+	}
+	return offsets
+}
+
+// getBroadcastOffTime returns the time to wait after a broadcast downlink
+// with the given data-rate and payload, before the next downlink may be
+// transmitted by the same gateway. Without duty-cycle limit, only the
+// airtime itself is taken into account.
+func getBroadcastOffTime(dr int, fPort uint8, data []byte) time.Duration {
+	txAirtime, err := airtime.GetTXPacketAirtime(gw.TXPacket{
+		TXInfo: gw.TXInfo{
+			DataRate: common.Band.DataRates[dr],
+		},
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataDown,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FPort: &fPort,
+				FRMPayload: []lorawan.Payload{
+					&lorawan.DataPayload{Bytes: data},
+				},
+			},
+		},
+	})
+	if err != nil {
+		log.Errorf("broadcast: get airtime error: %s", err)
+		return time.Second
+	}
+
+	dutyCycle := airtime.GetSubBand(common.Band.RX2Frequency).DutyCycle
+	if dutyCycle == 0 {
+		return txAirtime
+	}
+	return time.Duration(float64(txAirtime) / dutyCycle)
+}
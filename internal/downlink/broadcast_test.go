@@ -0,0 +1,31 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetBroadcastOffsets(t *testing.T) {
+	Convey("Given a dispersal period of 10 minutes and 100 nodes", t, func() {
+		period := 10 * time.Minute
+		offsets := getBroadcastOffsets(100, period)
+
+		Convey("Then an offset is returned per node, each within its own slot of the period", func() {
+			So(offsets, ShouldHaveLength, 100)
+
+			slot := period / 100
+			for i, offset := range offsets {
+				So(offset >= slot*time.Duration(i), ShouldBeTrue)
+				So(offset < slot*time.Duration(i+1), ShouldBeTrue)
+			}
+		})
+	})
+
+	Convey("Given no nodes", t, func() {
+		Convey("Then no offsets are returned", func() {
+			So(getBroadcastOffsets(0, time.Minute), ShouldHaveLength, 0)
+		})
+	})
+}
@@ -0,0 +1,257 @@
+package downlink
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+const (
+	// classCRetryKeyTempl contains per node the pending confirmed Class-C
+	// downlink (gob encoded classCRetry).
+	classCRetryKeyTempl = "lora:ns:node:classc:retry:%s"
+
+	// classCRetriesKey contains the nodes with a pending confirmed Class-C
+	// downlink (sorted set, scored by the retransmission timestamp in ms).
+	classCRetriesKey = "lora:ns:classc:retries"
+)
+
+// classCRetry contains a confirmed Class-C downlink which has not yet been
+// acknowledged.
+type classCRetry struct {
+	DevEUI    lorawan.EUI64
+	FCntDown  uint32
+	FPort     uint8
+	Data      []byte
+	TXParams  models.TXParams
+	Reference string
+	Attempt   int // number of transmissions
+}
+
+// scheduleClassCRetry stores the given confirmed Class-C downlink,
+// transmitted at the given time, and schedules its retransmission after the
+// ClassCConfirmedACKTimeout. When ClassCConfirmedRetries is 0, it is a
+// no-op.
+func scheduleClassCRetry(p *redis.Pool, r classCRetry, sentAt time.Time) error {
+	if common.ClassCConfirmedRetries == 0 {
+		return nil
+	}
+	if r.Attempt == 0 {
+		r.Attempt = 1
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r); err != nil {
+		return errors.Wrap(err, "gob encode class-c retry error")
+	}
+
+	due := sentAt.Add(common.ClassCConfirmedACKTimeout)
+	ttl := common.ClassCConfirmedACKTimeout * time.Duration(common.ClassCConfirmedRetries+2)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("PSETEX", fmt.Sprintf(classCRetryKeyTempl, r.DevEUI), int64(ttl/time.Millisecond), buf.Bytes())
+	c.Send("ZADD", classCRetriesKey, due.UnixNano()/int64(time.Millisecond), r.DevEUI.String())
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "schedule class-c retry error")
+	}
+	return nil
+}
+
+// ClearClassCRetry removes the pending confirmed Class-C downlink of the
+// given node, e.g. when it has been acknowledged.
+func ClearClassCRetry(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("DEL", fmt.Sprintf(classCRetryKeyTempl, devEUI))
+	c.Send("ZREM", classCRetriesKey, devEUI.String())
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "clear class-c retry error")
+	}
+	return nil
+}
+
+// getClassCRetry returns the pending confirmed Class-C downlink of the
+// given node (nil when there is none).
+func getClassCRetry(p *redis.Pool, devEUI lorawan.EUI64) (*classCRetry, error) {
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(classCRetryKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get class-c retry error")
+	}
+
+	var r classCRetry
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&r); err != nil {
+		return nil, errors.Wrap(err, "gob decode class-c retry error")
+	}
+	return &r, nil
+}
+
+// claimDueClassCRetries returns the nodes of which the pending confirmed
+// Class-C downlink is due for retransmission at the given time. The
+// returned nodes are removed from the schedule.
+func claimDueClassCRetries(p *redis.Pool, now time.Time) ([]lorawan.EUI64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ids, err := redis.Strings(c.Do("ZRANGEBYSCORE", classCRetriesKey, "-inf", now.UnixNano()/int64(time.Millisecond)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get due class-c retries error")
+	}
+
+	var out []lorawan.EUI64
+	for _, id := range ids {
+		// an other instance might have claimed the item in the meantime
+		removed, err := redis.Int(c.Do("ZREM", classCRetriesKey, id))
+		if err != nil {
+			return nil, errors.Wrap(err, "claim class-c retry error")
+		}
+		if removed == 0 {
+			continue
+		}
+
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(id)); err != nil {
+			return nil, errors.Wrap(err, "unmarshal DevEUI error")
+		}
+		out = append(out, devEUI)
+	}
+	return out, nil
+}
+
+// handleClassCRetry retransmits the pending confirmed Class-C downlink of
+// the given node, or gives up after ClassCConfirmedRetries
+// retransmissions. The retransmission is dropped when the downlink has
+// been superseded by an other downlink (the FCntDown or reference of the
+// node-session does not match) or the node is no longer a Class-C device.
+func handleClassCRetry(ctx common.Context, devEUI lorawan.EUI64) error {
+	r, err := getClassCRetry(ctx.RedisPool, devEUI)
+	if err != nil || r == nil {
+		return err
+	}
+
+	ns, err := session.GetNodeSession(ctx.RedisPool, devEUI)
+	if err != nil {
+		if err == session.ErrDoesNotExist {
+			return ClearClassCRetry(ctx.RedisPool, devEUI)
+		}
+		return err
+	}
+
+	now := time.Now()
+	if ns.FCntDown != r.FCntDown || ns.DownlinkReference != r.Reference || ns.GetDeviceClass(common.DeviceClassChangeLockout, now) == session.DeviceClassA {
+		return ClearClassCRetry(ctx.RedisPool, devEUI)
+	}
+
+	logFields := log.Fields{
+		"dev_eui":   devEUI,
+		"fcnt":      r.FCntDown,
+		"attempt":   r.Attempt,
+		"reference": r.Reference,
+	}
+
+	if r.Attempt > common.ClassCConfirmedRetries {
+		log.WithFields(logFields).Warning("confirmed class-c downlink not acknowledged")
+		if err := ClearClassCRetry(ctx.RedisPool, devEUI); err != nil {
+			return err
+		}
+		_, err := ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+			AppEUI: ns.AppEUI[:],
+			DevEUI: ns.DevEUI[:],
+			Error:  fmt.Sprintf("confirmed class-c downlink (fcnt: %d) not acknowledged after %d transmissions", r.FCntDown, r.Attempt),
+		})
+		if err != nil {
+			return errors.Wrap(err, "send class-c retry error to network-controller error")
+		}
+		return nil
+	}
+
+	if ns.DeviceClassChangePending(common.DeviceClassChangeLockout, now) || isDailyAirtimeCapReached(ctx, ns) {
+		// try again after the ack timeout, without counting this attempt
+		return scheduleClassCRetry(ctx.RedisPool, *r, now)
+	}
+
+	rxInfoSet, err := getAllowedRXInfoSet(ctx, ns, ns.LastRXInfoSet)
+	if err != nil {
+		return err
+	}
+
+	txInfo, _, err := getClassCTXInfo(ctx, ns, rxInfoSet[0])
+	if err != nil {
+		return err
+	}
+	if err := setTXParams(ctx, ns, &txInfo, getBandTXParams(""), r.TXParams); err != nil {
+		return errors.Wrap(err, "set tx-params error")
+	}
+
+	ddCTX := DataDownFrameContext{
+		FPort:          r.FPort,
+		Data:           r.Data,
+		Confirmed:      true,
+		Reference:      r.Reference,
+		FallbackTXInfo: getFallbackTXInfo(ctx, ns, rxInfoSet, txInfo, getBandTXParams(""), r.TXParams),
+	}
+	if err := SendDataDown(ctx, &ns, txInfo, ddCTX); err != nil {
+		return errors.Wrap(err, "send data down error")
+	}
+
+	log.WithFields(logFields).Info("confirmed class-c downlink retransmitted")
+
+	r.Attempt++
+	return scheduleClassCRetry(ctx.RedisPool, *r, now)
+}
+
+// RunClassCRetries retransmits, on the given interval, the confirmed
+// Class-C downlinks which have not been acknowledged within the
+// ClassCConfirmedACKTimeout. When an elector is given, the retransmissions
+// are only handled by the leader. It returns when done is closed.
+func RunClassCRetries(ctx common.Context, elector *leader.Elector, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+
+			devEUIs, err := claimDueClassCRetries(ctx.RedisPool, time.Now())
+			if err != nil {
+				log.Errorf("get due class-c retries error: %s", err)
+				continue
+			}
+
+			for _, devEUI := range devEUIs {
+				if err := handleClassCRetry(ctx, devEUI); err != nil {
+					log.WithField("dev_eui", devEUI).Errorf("handle class-c retry error: %s", err)
+				}
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestClassCRetry(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx := common.Context{RedisPool: p}
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		now := time.Now()
+
+		Convey("When scheduling a confirmed class-c downlink", func() {
+			r := classCRetry{
+				DevEUI:    devEUI,
+				FCntDown:  10,
+				FPort:     5,
+				Data:      []byte{1, 2, 3},
+				Reference: "ref-1",
+			}
+			So(scheduleClassCRetry(p, r, now), ShouldBeNil)
+
+			Convey("Then it is stored as first transmission", func() {
+				r.Attempt = 1
+				stored, err := getClassCRetry(p, devEUI)
+				So(err, ShouldBeNil)
+				So(stored, ShouldResemble, &r)
+			})
+
+			Convey("Then it is not due before the ack timeout", func() {
+				devEUIs, err := claimDueClassCRetries(p, now)
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldHaveLength, 0)
+			})
+
+			Convey("Then it is claimed once after the ack timeout", func() {
+				devEUIs, err := claimDueClassCRetries(p, now.Add(common.ClassCConfirmedACKTimeout))
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{devEUI})
+
+				devEUIs, err = claimDueClassCRetries(p, now.Add(common.ClassCConfirmedACKTimeout))
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldHaveLength, 0)
+			})
+
+			Convey("When the downlink has been acknowledged", func() {
+				So(ClearClassCRetry(p, devEUI), ShouldBeNil)
+
+				Convey("Then it is removed", func() {
+					stored, err := getClassCRetry(p, devEUI)
+					So(err, ShouldBeNil)
+					So(stored, ShouldBeNil)
+
+					devEUIs, err := claimDueClassCRetries(p, now.Add(common.ClassCConfirmedACKTimeout))
+					So(err, ShouldBeNil)
+					So(devEUIs, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When the downlink has been superseded by an other downlink", func() {
+				So(session.SaveNodeSession(p, session.NodeSession{
+					DevEUI:            devEUI,
+					FCntDown:          11,
+					DownlinkReference: "ref-1",
+				}), ShouldBeNil)
+
+				Convey("Then the retransmission is dropped", func() {
+					So(handleClassCRetry(ctx, devEUI), ShouldBeNil)
+
+					stored, err := getClassCRetry(p, devEUI)
+					So(err, ShouldBeNil)
+					So(stored, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,171 @@
+package downlink
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// confirmedDataDownKeyTempl contains per node the pending confirmed
+// downlink (gob encoded confirmedDataDown), sent in response to an uplink.
+const confirmedDataDownKeyTempl = "lora:ns:node:confirmed:pending:%s"
+
+// confirmedDataDown contains a confirmed downlink, sent in response to an
+// uplink, which has not yet been acknowledged.
+type confirmedDataDown struct {
+	DevEUI    lorawan.EUI64
+	FCntDown  uint32
+	FPort     uint8
+	Data      []byte
+	Reference string
+	Attempt   int       // number of transmissions
+	SentAt    time.Time // first transmission
+}
+
+// saveConfirmedDataDown stores the given pending confirmed downlink. When
+// ConfirmedDownlinkRetries is 0, it is a no-op.
+func saveConfirmedDataDown(p *redis.Pool, d confirmedDataDown) error {
+	if common.ConfirmedDownlinkRetries == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		return errors.Wrap(err, "gob encode confirmed data down error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	// the pending downlink is kept as long as the node-session
+	if _, err := c.Do("PSETEX", fmt.Sprintf(confirmedDataDownKeyTempl, d.DevEUI), int64(common.NodeSessionTTL/time.Millisecond), buf.Bytes()); err != nil {
+		return errors.Wrap(err, "save confirmed data down error")
+	}
+	return nil
+}
+
+// ClearConfirmedDataDown removes the pending confirmed downlink of the
+// given node, e.g. when it has been acknowledged.
+func ClearConfirmedDataDown(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(confirmedDataDownKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "clear confirmed data down error")
+	}
+	return nil
+}
+
+// getConfirmedDataDown returns the pending confirmed downlink of the given
+// node (nil when there is none).
+func getConfirmedDataDown(p *redis.Pool, devEUI lorawan.EUI64) (*confirmedDataDown, error) {
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(confirmedDataDownKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get confirmed data down error")
+	}
+
+	var d confirmedDataDown
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&d); err != nil {
+		return nil, errors.Wrap(err, "gob decode confirmed data down error")
+	}
+	return &d, nil
+}
+
+// getConfirmedRetransmission returns the pending confirmed downlink of the
+// given node which must be retransmitted in response to the current
+// (unacknowledging) uplink, or nil. The pending downlink is given up after
+// ConfirmedDownlinkRetries retransmissions, after the
+// ConfirmedDownlinkACKTimeout or when it has been superseded by an other
+// downlink (the FCntDown or reference of the node-session does not match).
+func getConfirmedRetransmission(ctx common.Context, ns *session.NodeSession, now time.Time) (*confirmedDataDown, error) {
+	d, err := getConfirmedDataDown(ctx.RedisPool, ns.DevEUI)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	switch {
+	case ns.FCntDown != d.FCntDown || ns.DownlinkReference != d.Reference:
+		return nil, dropConfirmedDataDown(ctx, ns, *d, "superseded by an other downlink")
+	case d.Attempt > common.ConfirmedDownlinkRetries:
+		return nil, dropConfirmedDataDown(ctx, ns, *d, fmt.Sprintf("not acknowledged after %d transmissions", d.Attempt))
+	case common.ConfirmedDownlinkACKTimeout > 0 && now.Sub(d.SentAt) > common.ConfirmedDownlinkACKTimeout:
+		return nil, dropConfirmedDataDown(ctx, ns, *d, fmt.Sprintf("not acknowledged within %s", common.ConfirmedDownlinkACKTimeout))
+	}
+
+	return d, nil
+}
+
+// supersedeConfirmedDataDown gives up the pending confirmed downlink of the
+// given node (if any), e.g. before pushing an other downlink.
+func supersedeConfirmedDataDown(ctx common.Context, ns *session.NodeSession) error {
+	if common.ConfirmedDownlinkRetries == 0 {
+		return nil
+	}
+
+	d, err := getConfirmedDataDown(ctx.RedisPool, ns.DevEUI)
+	if err != nil || d == nil {
+		return err
+	}
+	return dropConfirmedDataDown(ctx, ns, *d, "superseded by an other downlink")
+}
+
+// dropConfirmedDataDown removes the given pending confirmed downlink. The
+// FCntDown of the node-session is incremented (when still in use by the
+// pending downlink) and the application-server is notified with the given
+// reason.
+func dropConfirmedDataDown(ctx common.Context, ns *session.NodeSession, d confirmedDataDown, reason string) error {
+	log.WithFields(log.Fields{
+		"dev_eui":   ns.DevEUI,
+		"fcnt":      d.FCntDown,
+		"attempt":   d.Attempt,
+		"reference": d.Reference,
+	}).Warningf("confirmed downlink %s", reason)
+
+	if err := ClearConfirmedDataDown(ctx.RedisPool, ns.DevEUI); err != nil {
+		return err
+	}
+
+	// the frame-counter must not be re-used for the next downlink
+	if ns.FCntDown == d.FCntDown && ns.DownlinkReference == d.Reference {
+		orig := *ns
+		ns.FCntDown++
+		ns.DownlinkReference = ""
+		if err := session.SaveNodeSessionChanges(ctx.RedisPool, orig, ns); err != nil {
+			return errors.Wrap(err, "save node-session error")
+		}
+	}
+
+	msg := fmt.Sprintf("confirmed downlink (fcnt: %d) %s", d.FCntDown, reason)
+	if d.Reference != "" {
+		msg = fmt.Sprintf("confirmed downlink (fcnt: %d, reference: %s) %s", d.FCntDown, d.Reference, reason)
+	}
+	_, err := ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: ns.AppEUI[:],
+		DevEUI: ns.DevEUI[:],
+		Type:   as.ErrorType_DATA_DOWN_NOT_ACKNOWLEDGED,
+		Error:  msg,
+	})
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("publish confirmed downlink error to application-server error: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestConfirmedRetransmission(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database, a node-session and retries enabled", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		defer func(r int, t time.Duration) {
+			common.ConfirmedDownlinkRetries = r
+			common.ConfirmedDownlinkACKTimeout = t
+		}(common.ConfirmedDownlinkRetries, common.ConfirmedDownlinkACKTimeout)
+		common.ConfirmedDownlinkRetries = 2
+		common.ConfirmedDownlinkACKTimeout = time.Hour
+
+		app := test.NewApplicationClient()
+		ctx := common.Context{
+			RedisPool:   p,
+			Application: app,
+		}
+		now := time.Now()
+
+		ns := session.NodeSession{
+			DevEUI:            lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCntDown:          10,
+			DownlinkReference: "ref-1",
+		}
+		So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+		d := confirmedDataDown{
+			DevEUI:    ns.DevEUI,
+			FCntDown:  10,
+			FPort:     5,
+			Data:      []byte{1, 2, 3},
+			Reference: "ref-1",
+			Attempt:   1,
+			SentAt:    now,
+		}
+		So(saveConfirmedDataDown(p, d), ShouldBeNil)
+
+		Convey("Then the pending downlink is returned for retransmission", func() {
+			r, err := getConfirmedRetransmission(ctx, &ns, now)
+			So(err, ShouldBeNil)
+			So(r, ShouldNotBeNil)
+			So(r.Data, ShouldResemble, d.Data)
+			So(r.SentAt.Equal(d.SentAt), ShouldBeTrue)
+			So(ns.FCntDown, ShouldEqual, 10)
+		})
+
+		Convey("When the downlink has been acknowledged", func() {
+			So(ClearConfirmedDataDown(p, ns.DevEUI), ShouldBeNil)
+
+			Convey("Then there is nothing to retransmit", func() {
+				r, err := getConfirmedRetransmission(ctx, &ns, now)
+				So(err, ShouldBeNil)
+				So(r, ShouldBeNil)
+			})
+		})
+
+		for _, test := range []struct {
+			Name    string
+			Attempt int
+			Now     time.Time
+			Error   string
+		}{
+			{"the max retransmissions", 3, now, "confirmed downlink (fcnt: 10, reference: ref-1) not acknowledged after 3 transmissions"},
+			{"the ack timeout", 1, now.Add(2 * time.Hour), "confirmed downlink (fcnt: 10, reference: ref-1) not acknowledged within 1h0m0s"},
+		} {
+			Convey("When exceeding "+test.Name, func() {
+				d.Attempt = test.Attempt
+				So(saveConfirmedDataDown(p, d), ShouldBeNil)
+
+				r, err := getConfirmedRetransmission(ctx, &ns, test.Now)
+				So(err, ShouldBeNil)
+				So(r, ShouldBeNil)
+
+				Convey("Then the frame-counter is incremented", func() {
+					So(ns.FCntDown, ShouldEqual, 11)
+					So(ns.DownlinkReference, ShouldEqual, "")
+
+					stored, err := session.GetNodeSession(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(stored.FCntDown, ShouldEqual, 11)
+				})
+
+				Convey("Then the application-server is notified", func() {
+					req := <-app.HandleErrorChan
+					So(req.DevEUI, ShouldResemble, ns.DevEUI[:])
+					So(req.Type, ShouldEqual, as.ErrorType_DATA_DOWN_NOT_ACKNOWLEDGED)
+					So(req.Error, ShouldEqual, test.Error)
+				})
+
+				Convey("Then the pending downlink is removed", func() {
+					stored, err := getConfirmedDataDown(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(stored, ShouldBeNil)
+				})
+			})
+		}
+
+		Convey("When the downlink has been superseded by an other downlink", func() {
+			ns.DownlinkReference = "ref-2"
+			r, err := getConfirmedRetransmission(ctx, &ns, now)
+			So(err, ShouldBeNil)
+			So(r, ShouldBeNil)
+
+			Convey("Then the frame-counter of the other downlink is kept", func() {
+				So(ns.FCntDown, ShouldEqual, 10)
+				So(ns.DownlinkReference, ShouldEqual, "ref-2")
+			})
+
+			Convey("Then the application-server is notified", func() {
+				req := <-app.HandleErrorChan
+				So(req.Error, ShouldContainSubstring, "superseded by an other downlink")
+			})
+		})
+	})
+}
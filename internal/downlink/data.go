@@ -13,10 +13,13 @@ import (
 	"github.com/joriwind/loraserver/api/as"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/applayer"
 	"github.com/joriwind/loraserver/internal/common"
 	"github.com/joriwind/loraserver/internal/maccommand"
 	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/rx2mismatch"
 	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/sla"
 	"github.com/brocaar/lorawan"
 )
 
@@ -49,6 +52,20 @@ type DataDownFrameContext struct {
 	// Data contains the bytes to send. Note that this requires FPort to be a
 	// value other than 0.
 	Data []byte
+
+	// Reference contains the (optional) client reference of the pushed
+	// payload. For confirmed frames, it is stored in the node-session so
+	// that it can be included in the ACK notification.
+	Reference string
+
+	// DiversityTXInfo contains the (optional) TX parameters for transmitting
+	// the frame a second time via an other gateway (transmit diversity).
+	DiversityTXInfo *gw.TXInfo
+
+	// FallbackTXInfo contains the (optional) TX parameters for transmitting
+	// the frame via the next-best gateways (best first), when the gateway
+	// rejects the frame.
+	FallbackTXInfo []gw.TXInfo
 }
 
 // Validate validates the correctness of DataDownFrameContext.
@@ -84,7 +101,7 @@ func SendDataDown(ctx common.Context, ns *session.NodeSession, txInfo gw.TXInfo,
 		FHDR: lorawan.FHDR{
 			DevAddr: ns.DevAddr,
 			FCtrl: lorawan.FCtrl{
-				ADR:      ns.ADRInterval != 0,
+				ADR:      ns.ADRInterval != 0 || ns.LegacyADRACKReq,
 				ACK:      dataDown.ACK,
 				FPending: dataDown.MoreData,
 			},
@@ -116,6 +133,13 @@ func SendDataDown(ctx common.Context, ns *session.NodeSession, txInfo gw.TXInfo,
 		macPL.FRMPayload = []lorawan.Payload{
 			&lorawan.DataPayload{Bytes: dataDown.Data},
 		}
+
+		// the AppSKey encryption is offloaded to LoRa Server
+		if ns.AppSKey != nil {
+			if err := phy.EncryptFRMPayload(*ns.AppSKey); err != nil {
+				return errors.Wrap(err, "encrypt FRMPayload error")
+			}
+		}
 	}
 
 	if err := phy.SetMIC(ns.NwkSKey); err != nil {
@@ -123,33 +147,78 @@ func SendDataDown(ctx common.Context, ns *session.NodeSession, txInfo gw.TXInfo,
 	}
 
 	// send the packet to the gateway
-	if err := ctx.Gateway.SendTXPacket(gw.TXPacket{
+	if err := sendTXPacket(ctx, *ns, gw.TXPacket{
 		TXInfo:     txInfo,
 		PHYPayload: phy,
-	}); err != nil {
+	}, false, dataDown.FallbackTXInfo); err != nil {
 		return errors.Wrap(err, "send tx packet to gateway error")
 	}
 
-	// increment the FCntDown when Confirmed = false
-	if !dataDown.Confirmed {
-		ns.FCntDown++
-		if err := session.SaveNodeSession(ctx.RedisPool, *ns); err != nil {
-			return errors.Wrap(err, "save node-session error")
+	// the second transmission is best-effort, the frame has already been
+	// sent via the first gateway
+	if dataDown.DiversityTXInfo != nil {
+		if err := sendTXPacket(ctx, *ns, gw.TXPacket{
+			TXInfo:     *dataDown.DiversityTXInfo,
+			PHYPayload: phy,
+		}, true, nil); err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": ns.DevEUI,
+				"fcnt":    ns.FCntDown,
+				"mac":     dataDown.DiversityTXInfo.MAC,
+			}).Errorf("send diversity tx packet to gateway error: %s", err)
 		}
 	}
 
+	maccommand.RecordHistory(ctx.RedisPool, ns.DevEUI, false, ns.FCntDown, dataDown.MACCommands)
+
+	// increment the FCntDown when Confirmed = false, else keep the
+	// reference until the frame has been acknowledged
+	orig := *ns
+	ns.DownlinkReference = ""
+	if dataDown.Confirmed {
+		ns.DownlinkReference = dataDown.Reference
+	} else {
+		ns.FCntDown++
+	}
+	if err := session.SaveNodeSessionChanges(ctx.RedisPool, orig, ns); err != nil {
+		return errors.Wrap(err, "save node-session error")
+	}
+
 	return nil
 }
 
-// HandlePushDataDown handles requests to push data to a given node.
-func HandlePushDataDown(ctx common.Context, ns session.NodeSession, confirmed bool, fPort uint8, data []byte) error {
+// HandlePushDataDown handles requests to push data to a given node. The
+// given TX parameters override the TX parameters of the band, gateway and
+// node-session. The (optional) reference is included in the ACK
+// notification of a confirmed payload. Confirmed payloads which are not
+// critical are sent as unconfirmed payload when the node is battery
+// throttled.
+func HandlePushDataDown(ctx common.Context, ns session.NodeSession, confirmed, critical bool, fPort uint8, data []byte, txParams models.TXParams, reference string) (err error) {
 	if len(ns.LastRXInfoSet) == 0 {
 		return ErrNoLastRXInfoSet
 	}
 
-	dr := int(ns.RX2DR)
-	if dr > len(common.Band.DataRates)-1 {
-		return errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", dr, len(common.Band.DataRates)-1)
+	// pause Class-C downlinks while the node is changing its device class
+	now := time.Now()
+	if ns.DeviceClassChangePending(common.DeviceClassChangeLockout, now) {
+		return ErrDeviceClassChangePending
+	}
+	if ns.GetDeviceClass(common.DeviceClassChangeLockout, now) == session.DeviceClassA {
+		return ErrNotClassC
+	}
+
+	if isDailyAirtimeCapReached(ctx, ns) {
+		return ErrDailyAirtimeCapReached
+	}
+
+	rxInfoSet, err := getAllowedRXInfoSet(ctx, ns, ns.LastRXInfoSet)
+	if err != nil {
+		return err
+	}
+
+	txInfo, dr, err := getClassCTXInfo(ctx, ns, rxInfoSet[0])
+	if err != nil {
+		return err
 	}
 
 	remainingPayloadSize := common.Band.MaxPayloadSize[dr].N
@@ -158,42 +227,105 @@ func HandlePushDataDown(ctx common.Context, ns session.NodeSession, confirmed bo
 	}
 	remainingPayloadSize = remainingPayloadSize - len(data)
 
+	duplicate, err := isDuplicateDownlinkPayload(ctx.RedisPool, ns.DevEUI, fPort, data)
+	if err != nil {
+		return errors.Wrap(err, "downlink deduplication error")
+	}
+	if duplicate {
+		log.WithFields(log.Fields{
+			"dev_eui":   ns.DevEUI,
+			"f_port":    fPort,
+			"confirmed": confirmed,
+			"coalesce":  common.DownlinkDeduplicationCoalesce,
+		}).Warning("duplicate downlink payload within deduplication window")
+		if common.DownlinkDeduplicationCoalesce {
+			duplicatePayloadCount.Inc("coalesced")
+			return nil
+		}
+		duplicatePayloadCount.Inc("sent")
+	} else {
+		// release the payload when it could not be sent, so that a retry
+		// of the push is not considered a duplicate
+		defer func() {
+			if err == nil {
+				return
+			}
+			if rErr := releaseDownlinkPayload(ctx.RedisPool, ns.DevEUI, fPort, data); rErr != nil {
+				log.WithField("dev_eui", ns.DevEUI).Errorf("release downlink payload error: %s", rErr)
+			}
+		}()
+	}
+
 	macQueueItems, _, _, err := getAndFilterMACQueueItems(ctx, ns, false, remainingPayloadSize)
 	if err != nil {
 		return errors.Wrap(err, "get mac-commands error")
 	}
 	macCommands := macQueueItemsToMACCommands(ctx, ns, macQueueItems)
 
-	txInfo := gw.TXInfo{
-		MAC:         ns.LastRXInfoSet[0].MAC,
-		Immediately: true,
-		Frequency:   int(common.Band.RX2Frequency),
-		Power:       common.Band.DefaultTXPower,
-		DataRate:    common.Band.DataRates[dr],
-		CodeRate:    "4/5",
+	if err := setTXParams(ctx, ns, &txInfo, getBandTXParams(""), txParams); err != nil {
+		requeueMACQueueItems(ctx, ns, macQueueItems)
+		return errors.Wrap(err, "set tx-params error")
+	}
+
+	// the push supersedes the pending confirmed downlink sent in response
+	// to an uplink
+	if err := supersedeConfirmedDataDown(ctx, &ns); err != nil {
+		requeueMACQueueItems(ctx, ns, macQueueItems)
+		return errors.Wrap(err, "supersede confirmed data down error")
 	}
 
 	ddCTX := DataDownFrameContext{
 		FPort:       fPort,
 		Data:        data,
-		Confirmed:   confirmed,
+		Confirmed:   throttleConfirmed(ctx, ns, confirmed, critical),
 		MACCommands: macCommands,
+		Reference:   reference,
 	}
+	ddCTX.FallbackTXInfo = getFallbackTXInfo(ctx, ns, rxInfoSet, txInfo, getBandTXParams(""), txParams)
 
 	if err := SendDataDown(ctx, &ns, txInfo, ddCTX); err != nil {
+		requeueMACQueueItems(ctx, ns, macQueueItems)
 		return errors.Wrap(err, "send data down error")
 	}
 
-	// remove the transmitted mac commands from the queue
-	for _, qi := range macQueueItems {
-		if err = maccommand.DeleteQueueItem(ctx.RedisPool, ns.DevEUI, qi); err != nil {
-			return errors.Wrap(err, "delete mac-command queue item error")
-		}
+	// a confirmed downlink is retransmitted until it has been acknowledged,
+	// an unconfirmed downlink supersedes the pending confirmed downlink
+	if ddCTX.Confirmed {
+		err = scheduleClassCRetry(ctx.RedisPool, classCRetry{
+			DevEUI:    ns.DevEUI,
+			FCntDown:  ns.FCntDown,
+			FPort:     fPort,
+			Data:      data,
+			TXParams:  txParams,
+			Reference: reference,
+		}, time.Now())
+	} else {
+		err = ClearClassCRetry(ctx.RedisPool, ns.DevEUI)
+	}
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("update class-c retry error: %s", err)
 	}
 
 	return nil
 }
 
+// getClassCTXInfo returns the TXInfo (transmitted immediately, using the
+// RX2 parameters) and data-rate for a Class-C downlink to the given node,
+// via the gateway of the given RXInfo.
+func getClassCTXInfo(ctx common.Context, ns session.NodeSession, rxInfo gw.RXInfo) (gw.TXInfo, int, error) {
+	dr := int(ns.RX2DR)
+	if dr > len(common.Band.DataRates)-1 {
+		return gw.TXInfo{}, 0, errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", dr, len(common.Band.DataRates)-1)
+	}
+
+	return gw.TXInfo{
+		MAC:         rxInfo.MAC,
+		Immediately: true,
+		Frequency:   ns.GetRX2Frequency(),
+		DataRate:    common.Band.DataRates[dr],
+	}, dr, nil
+}
+
 // SendUplinkResponse sends the data-down response to an uplink packet.
 // A downlink response happens when: there is data in the downlink queue,
 // there are MAC commmands to send and / or when the uplink packet was of
@@ -204,17 +336,106 @@ func SendUplinkResponse(ctx common.Context, ns session.NodeSession, rxPacket mod
 		return fmt.Errorf("expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
 	}
 
-	// get data down tx properties
-	txInfo, dr, err := getDataDownTXInfoAndDR(ctx, ns, rxPacket.RXInfoSet[0])
+	rxWindow, err := GetRXWindow(ctx.RedisPool, ns)
 	if err != nil {
-		return fmt.Errorf("get data down txinfo error: %s", err)
+		return errors.Wrap(err, "get rx window error")
+	}
+
+	decision := Decision{
+		Time:        time.Now(),
+		FCntUp:      macPL.FHDR.FCnt,
+		ACKRequired: rxPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataUp,
+		RXWindow:    int(rxWindow),
+	}
+
+	rxInfoSet, err := getAllowedRXInfoSet(ctx, ns, rxPacket.RXInfoSet)
+	if err != nil {
+		if err == ErrNoAllowedGateway {
+			decision.Decision = DecisionNoAllowedGateway
+			recordDecision(ctx, ns.DevEUI, decision)
+		}
+		return errors.Wrap(err, "get allowed rx-info error")
+	}
+	rxInfo := rxInfoSet[0]
+
+	// get data down tx properties (using a copy of the node-session, as
+	// the learned RX window and RX2 data-rate must not be stored in the
+	// node-session)
+	txNS := ns
+	txNS.RXWindow = rxWindow
+	if rxWindow == session.RX2 {
+		rx2DR, err := rx2mismatch.GetDataRate(ctx.RedisPool, ns)
+		if err != nil {
+			return errors.Wrap(err, "get rx2 data-rate error")
+		}
+		// fall back to the rx2 parameters of the band
+		if rx2DR != int(ns.RX2DR) {
+			txNS.RX2Frequency = 0
+		}
+		txNS.RX2DR = uint8(rx2DR)
+	}
+	txInfo, dr, err := getDataDownTXInfoAndDR(ctx, txNS, rxInfo)
+	if err != nil {
+		return errors.Wrap(err, "get data down txinfo error")
 	}
 
 	allowEncryptedMACCommands := true
 	remainingPayloadSize := common.Band.MaxPayloadSize[dr].N
 
-	// get data down from application-server (if it has anything in its queue)
-	txPayload := getDataDownFromApplication(ctx, ns, dr)
+	// get the retransmission of the pending confirmed downlink, else the
+	// response to an application-layer package uplink handled by LoRa
+	// Server, else the first item of the device-queue or else the data down
+	// from application-server (if it has anything in its queue and unless
+	// disabled), unless the daily airtime cap has been reached
+	var txPayload *as.GetDataDownResponse
+	var queueItem *DeviceQueueItem
+	var retransmission *confirmedDataDown
+	var payloadTooLarge bool
+	capReached := isDailyAirtimeCapReached(ctx, ns)
+	if !capReached && common.ConfirmedDownlinkRetries > 0 {
+		retransmission, err = getConfirmedRetransmission(ctx, &ns, decision.Time)
+		if err != nil {
+			return errors.Wrap(err, "get confirmed downlink retransmission error")
+		}
+		if retransmission != nil {
+			if len(retransmission.Data) <= common.Band.MaxPayloadSize[dr].N {
+				txPayload = &as.GetDataDownResponse{
+					FPort:     uint32(retransmission.FPort),
+					Data:      retransmission.Data,
+					Confirmed: true,
+				}
+			} else {
+				payloadTooLarge = true
+			}
+		}
+	}
+	// no new payload is requested while the frame-counter is in use by the
+	// pending confirmed downlink
+	if !capReached && retransmission == nil {
+		txPayload, err = getAppLayerDownlink(ctx, ns, dr)
+		if err != nil {
+			return errors.Wrap(err, "get application-layer package downlink error")
+		}
+		if txPayload == nil {
+			txPayload, queueItem, err = getDataDownFromDeviceQueue(ctx, ns, dr, time.Now())
+			if err != nil {
+				return errors.Wrap(err, "get device-queue item error")
+			}
+		}
+		if txPayload == nil && !common.DeviceQueueOnly {
+			txPayload = getDataDownFromApplication(ctx, ns, dr)
+			if txPayload != nil && len(txPayload.Data) > common.Band.MaxPayloadSize[dr].N {
+				log.WithFields(log.Fields{
+					"dev_eui":          ns.DevEUI,
+					"size":             len(txPayload.Data),
+					"max_payload_size": common.Band.MaxPayloadSize[dr].N,
+					"dr":               dr,
+				}).Warning("data down from application exceeds max payload size")
+				txPayload = nil
+				payloadTooLarge = true
+			}
+		}
+	}
 
 	// get mac-commands to fill the remaining payload bytes
 	if txPayload != nil {
@@ -225,7 +446,10 @@ func SendUplinkResponse(ctx common.Context, ns session.NodeSession, rxPacket mod
 	// read mac-commands queue items
 	macQueueItems, encryptMACCommands, pendingMACCommands, err := getAndFilterMACQueueItems(ctx, ns, allowEncryptedMACCommands, remainingPayloadSize)
 	if err != nil {
-		return fmt.Errorf("get mac-commands error: %s", err)
+		if queueItem != nil {
+			requeueDeviceQueueItem(ctx, *queueItem)
+		}
+		return errors.Wrap(err, "get mac-commands error")
 	}
 	macCommands := macQueueItemsToMACCommands(ctx, ns, macQueueItems)
 
@@ -235,12 +459,22 @@ func SendUplinkResponse(ctx common.Context, ns session.NodeSession, rxPacket mod
 	}
 
 	if txPayload != nil {
-		ddCTX.Confirmed = txPayload.Confirmed
+		ddCTX.Confirmed = throttleConfirmed(ctx, ns, txPayload.Confirmed, txPayload.Critical)
 		ddCTX.MoreData = txPayload.MoreData
 		ddCTX.FPort = uint8(txPayload.FPort)
 		ddCTX.Data = txPayload.Data
 	}
 
+	if queueItem != nil {
+		ddCTX.Reference = queueItem.Reference
+	}
+
+	// a retransmission is not battery throttled
+	if retransmission != nil && txPayload != nil {
+		ddCTX.Confirmed = true
+		ddCTX.Reference = retransmission.Reference
+	}
+
 	if pendingMACCommands {
 		ddCTX.MoreData = true
 	}
@@ -249,43 +483,171 @@ func SendUplinkResponse(ctx common.Context, ns session.NodeSession, rxPacket mod
 		ddCTX.EncryptMACCommands = true
 	}
 
+	if ns.TransmitDiversity && ddCTX.Confirmed && txPayload.Critical {
+		ddCTX.DiversityTXInfo = getDiversityTXInfo(ctx, txNS, rxPacket.RXInfoSet, txInfo.MAC, ddCTX)
+	}
+
+	// the gateway used for transmit diversity is not used as fallback
+	var exclude []lorawan.EUI64
+	if ddCTX.DiversityTXInfo != nil {
+		exclude = append(exclude, ddCTX.DiversityTXInfo.MAC)
+	}
+	ddCTX.FallbackTXInfo = getFallbackTXInfo(ctx, txNS, rxInfoSet, txInfo, getBandTXParams(rxInfo.CodeRate), models.TXParams{}, exclude...)
+
 	// Uplink was unconfirmed and no downlink data in queue and no mac commands to send.
-	// Note: in case of a ADRACKReq we still need to respond.
-	if txPayload == nil && !ddCTX.ACK && len(ddCTX.MACCommands) == 0 && !macPL.FHDR.FCtrl.ADRACKReq {
+	// Note: in case of a ADRACKReq we still need to respond (unless disabled
+	// by the ADR parameters or repeated by a node with legacy ADRACKReq
+	// handling).
+	adrACKReq := respondToADRACKReq(ctx.RedisPool, ns, macPL)
+
+	decision.ApplicationPayload = txPayload != nil
+	decision.ADRACKReq = adrACKReq
+	decision.MACCommandCount = len(ddCTX.MACCommands)
+	decision.DataRate = dr
+	decision.MAC = txInfo.MAC
+
+	if txPayload == nil && !ddCTX.ACK && len(ddCTX.MACCommands) == 0 && !adrACKReq {
+		decision.Decision = DecisionNothingToSend
+		if capReached {
+			decision.Decision = DecisionDailyAirtimeCapReached
+		} else if payloadTooLarge {
+			decision.Decision = DecisionPayloadTooLarge
+		}
+		recordDecision(ctx, ns.DevEUI, decision)
 		return nil
 	}
 
 	// send the data to the node
 	if err := SendDataDown(ctx, &ns, txInfo, ddCTX); err != nil {
-		return fmt.Errorf("send data down error: %s", err)
+		requeueMACQueueItems(ctx, ns, macQueueItems)
+		if queueItem != nil {
+			requeueDeviceQueueItem(ctx, *queueItem)
+		}
+		recordSendDataDownError(ctx, ns.DevEUI, decision, err)
+		return errors.Wrap(err, "send data down error")
+	}
+
+	decision.Decision = DecisionTransmitted
+	recordDecision(ctx, ns.DevEUI, decision)
+
+	if adrACKReq {
+		if err := setADRACKReqResponded(ctx.RedisPool, ns, macPL.FHDR.FCnt); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("set adrackreq responded error: %s", err)
+		}
+	}
+
+	if ddCTX.Confirmed {
+		d := confirmedDataDown{
+			DevEUI:    ns.DevEUI,
+			FCntDown:  ns.FCntDown,
+			FPort:     ddCTX.FPort,
+			Data:      ddCTX.Data,
+			Reference: ddCTX.Reference,
+			SentAt:    decision.Time,
+		}
+		if retransmission != nil {
+			d = *retransmission
+		}
+		d.Attempt++
+		if err := saveConfirmedDataDown(ctx.RedisPool, d); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("save confirmed data down error: %s", err)
+		}
 	}
 
-	// remove the transmitted mac commands from the queue
-	for _, qi := range macQueueItems {
-		if err = maccommand.DeleteQueueItem(ctx.RedisPool, ns.DevEUI, qi); err != nil {
-			return fmt.Errorf("delete mac-command queue item from queue error: %s", err)
+	if common.RXWindowLearning && ddCTX.Confirmed {
+		if err := SetRXWindowPending(ctx.RedisPool, ns.DevEUI, rxWindow); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("set pending rx window error: %s", err)
 		}
 	}
 
+	if common.RX2MismatchDetection && ddCTX.Confirmed && rxWindow == session.RX2 {
+		if err := rx2mismatch.SetPending(ctx.RedisPool, ns.DevEUI, dr); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("set pending rx2 data-rate error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SendUplinkACK sends a downlink which only acknowledges the given
+// retransmission of an already acknowledged confirmed uplink (with the
+// given full frame-counter). The application payload and mac-commands are
+// left for the response to the next uplink of the node.
+func SendUplinkACK(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, fCntUp uint32) error {
+	rxWindow, err := GetRXWindow(ctx.RedisPool, ns)
+	if err != nil {
+		return errors.Wrap(err, "get rx window error")
+	}
+
+	decision := Decision{
+		Time:        time.Now(),
+		FCntUp:      fCntUp,
+		ACKRequired: true,
+		RXWindow:    int(rxWindow),
+	}
+
+	rxInfo, err := getAllowedRXInfo(ctx, ns, rxPacket.RXInfoSet)
+	if err != nil {
+		if err == ErrNoAllowedGateway {
+			decision.Decision = DecisionNoAllowedGateway
+			recordDecision(ctx, ns.DevEUI, decision)
+		}
+		return errors.Wrap(err, "get allowed rx-info error")
+	}
+
+	txNS := ns
+	txNS.RXWindow = rxWindow
+	txInfo, dr, err := getDataDownTXInfoAndDR(ctx, txNS, rxInfo)
+	if err != nil {
+		return errors.Wrap(err, "get data down txinfo error")
+	}
+
+	decision.DataRate = dr
+	decision.MAC = txInfo.MAC
+
+	if err := SendDataDown(ctx, &ns, txInfo, DataDownFrameContext{ACK: true}); err != nil {
+		recordSendDataDownError(ctx, ns.DevEUI, decision, err)
+		return errors.Wrap(err, "send data down error")
+	}
+
+	decision.Decision = DecisionTransmitted
+	recordDecision(ctx, ns.DevEUI, decision)
+
 	return nil
 }
 
+// recordSendDataDownError records the decision matching the given
+// SendDataDown error (if any).
+func recordSendDataDownError(ctx common.Context, devEUI lorawan.EUI64, decision Decision, err error) {
+	switch errors.Cause(err) {
+	case ErrDeadlineExceeded:
+		decision.Decision = DecisionDeadlineExceeded
+	case ErrGatewayBusy:
+		decision.Decision = DecisionGatewayBusy
+	case ErrMaxPayloadSizeExceeded:
+		decision.Decision = DecisionPayloadTooLarge
+	case ErrPublishTXPacket:
+		decision.Decision = DecisionPublishError
+	default:
+		return
+	}
+	recordDecision(ctx, devEUI, decision)
+}
+
 func getDataDownTXInfoAndDR(ctx common.Context, ns session.NodeSession, rxInfo gw.RXInfo) (gw.TXInfo, int, error) {
 	var dr int
 	txInfo := gw.TXInfo{
-		MAC:      rxInfo.MAC,
-		CodeRate: rxInfo.CodeRate,
-		Power:    common.Band.DefaultTXPower,
+		MAC: rxInfo.MAC,
 	}
 
 	if ns.RXWindow == session.RX1 {
-		uplinkDR, err := common.Band.GetDataRate(rxInfo.DataRate)
+		uplinkDR, err := common.GetDataRate(rxInfo.DataRate)
 		if err != nil {
 			return txInfo, dr, err
 		}
 
 		// get rx1 dr
-		dr, err = common.Band.GetRX1DataRate(uplinkDR, int(ns.RX1DROffset))
+		dr, err = common.GetRX1DataRate(uplinkDR, int(ns.RX1DROffset))
 		if err != nil {
 			return txInfo, dr, err
 		}
@@ -306,12 +668,12 @@ func getDataDownTXInfoAndDR(ctx common.Context, ns session.NodeSession, rxInfo g
 		// rx2 dr
 		dr = int(ns.RX2DR)
 		if dr > len(common.Band.DataRates)-1 {
-			return txInfo, 0, fmt.Errorf("invalid rx2 dr: %d (max dr: %d)", dr, len(common.Band.DataRates)-1)
+			return txInfo, 0, errors.Wrapf(ErrInvalidDataRate, "rx2 dr: %d (max dr: %d)", dr, len(common.Band.DataRates)-1)
 		}
 		txInfo.DataRate = common.Band.DataRates[dr]
 
 		// rx2 frequency
-		txInfo.Frequency = common.Band.RX2Frequency
+		txInfo.Frequency = ns.GetRX2Frequency()
 
 		// rx2 timestamp (rx1 + 1 sec)
 		txInfo.Timestamp = rxInfo.Timestamp + uint32(common.Band.ReceiveDelay1/time.Microsecond)
@@ -320,21 +682,51 @@ func getDataDownTXInfoAndDR(ctx common.Context, ns session.NodeSession, rxInfo g
 		}
 		txInfo.Timestamp = txInfo.Timestamp + uint32(time.Second/time.Microsecond)
 	} else {
-		return txInfo, dr, fmt.Errorf("unknown RXWindow option %d", ns.RXWindow)
+		return txInfo, dr, errors.Wrapf(ErrUnknownRXWindow, "RXWindow %d", ns.RXWindow)
+	}
+
+	if err := setTXParams(ctx, ns, &txInfo, getBandTXParams(rxInfo.CodeRate), models.TXParams{}); err != nil {
+		return txInfo, dr, errors.Wrap(err, "set tx-params error")
 	}
 
 	return txInfo, dr, nil
 }
 
 // getDataDownFromApplication gets the downlink data from the application
-// (if any). On error the error is logged.
+// (if any). On error the error is logged. The call is cancelled after
+// common.GetDownlinkDataTimeout and is skipped (no data) when the circuit
+// breaker is open because of previous failures, so that a degraded
+// application-server does not delay the downlink.
 func getDataDownFromApplication(ctx common.Context, ns session.NodeSession, dr int) *as.GetDataDownResponse {
-	resp, err := ctx.Application.GetDataDown(context.Background(), &as.GetDataDownRequest{
+	if !applicationBreaker.allow(common.GetDownlinkDataBreakerThreshold, time.Now()) {
+		log.WithFields(log.Fields{
+			"dev_eui": ns.DevEUI,
+			"fcnt":    ns.FCntDown,
+		}).Warning("get data down from application skipped, circuit breaker is open")
+		return nil
+	}
+
+	reqCtx := ctx.RequestContext()
+	if common.GetDownlinkDataTimeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(reqCtx, common.GetDownlinkDataTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	resp, err := ctx.Application.GetDataDown(reqCtx, &as.GetDataDownRequest{
 		AppEUI:         ns.AppEUI[:],
 		DevEUI:         ns.DevEUI[:],
 		MaxPayloadSize: uint32(common.Band.MaxPayloadSize[dr].N),
 		FCnt:           ns.FCntDown,
 	})
+	sla.FromContext(ctx).Observe(sla.StageApplicationServer, time.Since(start))
+	if applicationBreaker.report(common.GetDownlinkDataBreakerThreshold, common.GetDownlinkDataBreakerCooldown, time.Now(), err) {
+		log.WithFields(log.Fields{
+			"threshold": common.GetDownlinkDataBreakerThreshold,
+			"cooldown":  common.GetDownlinkDataBreakerCooldown,
+		}).Warning("get data down from application circuit breaker opened")
+	}
 	if err != nil {
 		log.WithFields(log.Fields{
 			"dev_eui": ns.DevEUI,
@@ -347,16 +739,6 @@ func getDataDownFromApplication(ctx common.Context, ns session.NodeSession, dr i
 		return nil
 	}
 
-	if len(resp.Data) > common.Band.MaxPayloadSize[dr].N {
-		log.WithFields(log.Fields{
-			"dev_eui":          ns.DevEUI,
-			"size":             len(resp.Data),
-			"max_payload_size": common.Band.MaxPayloadSize[dr].N,
-			"dr":               dr,
-		}).Warning("data down from application exceeds max payload size")
-		return nil
-	}
-
 	log.WithFields(log.Fields{
 		"dev_eui":     ns.DevEUI,
 		"fcnt":        ns.FCntDown,
@@ -368,10 +750,45 @@ func getDataDownFromApplication(ctx common.Context, ns session.NodeSession, dr i
 	return resp
 }
 
+// getAppLayerDownlink returns the response to an application-layer package
+// uplink handled by LoRa Server (or nil). The payload is encrypted with the
+// AppSKey by SendDataDown.
+func getAppLayerDownlink(ctx common.Context, ns session.NodeSession, dr int) (*as.GetDataDownResponse, error) {
+	dl, err := applayer.PopDownlink(ctx.RedisPool, ns.DevEUI)
+	if err != nil || dl == nil {
+		return nil, err
+	}
+
+	if ns.AppSKey == nil || len(dl.Data) > common.Band.MaxPayloadSize[dr].N {
+		log.WithFields(log.Fields{
+			"dev_eui": ns.DevEUI,
+			"f_port":  dl.FPort,
+			"size":    len(dl.Data),
+			"dr":      dr,
+		}).Warning("application-layer package downlink dropped")
+		return nil, nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":     ns.DevEUI,
+		"fcnt":        ns.FCntDown,
+		"f_port":      dl.FPort,
+		"data_base64": base64.StdEncoding.EncodeToString(dl.Data),
+	}).Info("sending application-layer package downlink")
+
+	return &as.GetDataDownResponse{
+		FPort: uint32(dl.FPort),
+		Data:  dl.Data,
+	}, nil
+}
+
 // getAndFilterMACQueueItems returns the mac-commands to send, based on the constraints:
 // - allowEncrypted: when set to true, the FRMPayload may be used for
 //   (encrypted) mac-commands, else only FOpt mac-commands will be returned
 // - remainingPayloadSize: the number of bytes that are left for mac-commands
+// The returned items are atomically popped from the queue, so that they are
+// not transmitted by a simultaneous downlink. When they are not sent, they
+// must be put back using requeueMACQueueItems.
 // It returns:
 // - a slice of mac-command queue items
 // - if the mac-commands must be put into FRMPayload
@@ -382,7 +799,25 @@ func getAndFilterMACQueueItems(ctx common.Context, ns session.NodeSession, allow
 	// read the mac payload queue
 	queueItems, err := maccommand.ReadQueue(ctx.RedisPool, ns.DevEUI)
 	if err != nil {
-		return nil, false, false, fmt.Errorf("read mac-payload tx queue error: %s", err)
+		return nil, false, false, errors.Wrap(err, "read mac-payload tx queue error")
+	}
+	macCommandQueueDepth.Observe(float64(len(queueItems)))
+	queueItems, err = dropExpiredMACQueueItems(ctx, ns, queueItems)
+	if err != nil {
+		return nil, false, false, errors.Wrap(err, "drop expired mac-commands error")
+	}
+	queueItems, err = dropUnsupportedMACQueueItems(ctx, ns, queueItems)
+	if err != nil {
+		return nil, false, false, errors.Wrap(err, "drop unsupported mac-commands error")
+	}
+	// items which are not yet due are held in the queue and are not
+	// reported as pending
+	queueItems = maccommand.FilterDueItems(queueItems, time.Now())
+	// unanswered items which are held back by the retry backoff are not
+	// reported as pending either
+	queueItems, err = maccommand.FilterRetryItems(ctx.RedisPool, ns.DevEUI, queueItems)
+	if err != nil {
+		return nil, false, false, errors.Wrap(err, "filter mac-command retry items error")
 	}
 	macCommandQueueSize := len(queueItems)
 
@@ -404,8 +839,114 @@ func getAndFilterMACQueueItems(ctx common.Context, ns session.NodeSession, allow
 		}
 		queueItems = maccommand.FilterItems(queueItems, false, maxFOptsLen)
 	}
+	pending := len(queueItems) != macCommandQueueSize
+
+	queueItems, err = maccommand.PopQueueItems(ctx.RedisPool, ns.DevEUI, queueItems)
+	if err != nil {
+		return nil, false, false, errors.Wrap(err, "pop mac-payload tx queue items error")
+	}
+
+	if err := maccommand.SetRetrySent(ctx.RedisPool, ns.DevEUI, queueItems); err != nil {
+		requeueMACQueueItems(ctx, ns, queueItems)
+		return nil, false, false, errors.Wrap(err, "set mac-command retry state error")
+	}
+
+	return queueItems, encrypted, pending, nil
+}
+
+// requeueMACQueueItems puts the given popped (but not transmitted) items
+// back in the mac-command queue. Errors are logged.
+func requeueMACQueueItems(ctx common.Context, ns session.NodeSession, items []maccommand.QueueItem) {
+	if err := maccommand.RequeueItems(ctx.RedisPool, ns.DevEUI, items); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("requeue mac-commands error: %s", err)
+	}
+}
+
+// dropExpiredMACQueueItems removes the expired items from the mac-command
+// queue and notifies the network-controller for each dropped item.
+// It returns the remaining (non-expired) items.
+func dropExpiredMACQueueItems(ctx common.Context, ns session.NodeSession, items []maccommand.QueueItem) ([]maccommand.QueueItem, error) {
+	var out, expired []maccommand.QueueItem
+	now := time.Now()
+
+	for _, qi := range items {
+		if qi.IsExpired(now) {
+			expired = append(expired, qi)
+		} else {
+			out = append(out, qi)
+		}
+	}
+
+	// only the items popped by this call are reported, as a simultaneous
+	// downlink might have dropped the same items
+	expired, err := maccommand.PopQueueItems(ctx.RedisPool, ns.DevEUI, expired)
+	if err != nil {
+		return nil, errors.Wrap(err, "pop expired mac-command queue items error")
+	}
+
+	for _, qi := range expired {
+		errStr := fmt.Sprintf("mac-command %X expired at %s", qi.Data, qi.ExpiresAt.Format(time.RFC3339))
+		log.WithFields(log.Fields{
+			"dev_eui":     ns.DevEUI,
+			"command_hex": hex.EncodeToString(qi.Data),
+			"expires_at":  qi.ExpiresAt,
+		}).Warning("expired mac-command dropped from queue")
+
+		_, err := ctx.Controller.HandleError(ctx.RequestContext(), &nc.HandleErrorRequest{
+			AppEUI: ns.AppEUI[:],
+			DevEUI: ns.DevEUI[:],
+			Error:  errStr,
+		})
+		if err != nil {
+			log.Errorf("call network-controller handle error method error: %s", err)
+		}
+	}
+
+	return out, nil
+}
+
+// dropUnsupportedMACQueueItems removes the items which are not supported by
+// the MAC version of the node from the mac-command queue and notifies the
+// network-controller for each dropped item. It returns the remaining
+// (supported) items.
+func dropUnsupportedMACQueueItems(ctx common.Context, ns session.NodeSession, items []maccommand.QueueItem) ([]maccommand.QueueItem, error) {
+	var out, unsupported []maccommand.QueueItem
+	capabilities := ns.GetMACCapabilities()
+
+	for _, qi := range items {
+		if len(qi.Data) > 0 && !capabilities.SupportsMACCommand(lorawan.CID(qi.Data[0])) {
+			unsupported = append(unsupported, qi)
+		} else {
+			out = append(out, qi)
+		}
+	}
+
+	// only the items popped by this call are reported, as a simultaneous
+	// downlink might have dropped the same items
+	unsupported, err := maccommand.PopQueueItems(ctx.RedisPool, ns.DevEUI, unsupported)
+	if err != nil {
+		return nil, errors.Wrap(err, "pop unsupported mac-command queue items error")
+	}
+
+	for _, qi := range unsupported {
+		errStr := fmt.Sprintf("mac-command %X is not supported by mac version %s", qi.Data, ns.GetMACVersion())
+		log.WithFields(log.Fields{
+			"dev_eui":     ns.DevEUI,
+			"command_hex": hex.EncodeToString(qi.Data),
+			"mac_version": ns.GetMACVersion(),
+		}).Warning("unsupported mac-command dropped from queue")
+
+		_, err := ctx.Controller.HandleError(ctx.RequestContext(), &nc.HandleErrorRequest{
+			AppEUI: ns.AppEUI[:],
+			DevEUI: ns.DevEUI[:],
+			Error:  errStr,
+		})
+		if err != nil {
+			log.Errorf("call network-controller handle error method error: %s", err)
+		}
+	}
 
-	return queueItems, encrypted, len(queueItems) != macCommandQueueSize, nil
+	return out, nil
 }
 
 // macQueueItemsToMACCommands converts a slice of queue items into lorawan
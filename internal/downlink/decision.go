@@ -0,0 +1,142 @@
+package downlink
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+// decisionLogKeyTempl contains per node a list of the last downlink
+// decisions (newest first).
+const decisionLogKeyTempl = "lora:ns:downlink:decision:%s"
+
+const (
+	// decisionLogSize defines the number of decisions kept per node.
+	decisionLogSize = 20
+
+	// DecisionLogTTL defines how long the decisions of a node are kept
+	// after its last decision.
+	DecisionLogTTL = time.Hour * 24 * 7
+)
+
+// Possible downlink decisions.
+const (
+	DecisionTransmitted            = "TRANSMITTED"
+	DecisionNothingToSend          = "NOTHING_TO_SEND"
+	DecisionNoAllowedGateway       = "NO_ALLOWED_GATEWAY"
+	DecisionDeadlineExceeded       = "DEADLINE_EXCEEDED"
+	DecisionGatewayBusy            = "GATEWAY_BUSY"
+	DecisionDailyAirtimeCapReached = "DAILY_AIRTIME_CAP_REACHED"
+	DecisionPayloadTooLarge        = "PAYLOAD_TOO_LARGE"
+	DecisionPublishError           = "PUBLISH_ERROR"
+)
+
+// Decision contains the decision on the downlink opportunity following an
+// uplink of a node, together with the inputs of this decision.
+type Decision struct {
+	Time     time.Time
+	FCntUp   uint32 // the frame-counter of the uplink
+	Decision string // one of the Decision... constants
+
+	// Inputs of the decision.
+	ApplicationPayload bool // application payload (or app-layer response) pending
+	ACKRequired        bool // the uplink was a confirmed uplink
+	ADRACKReq          bool // ADRACKReq must be responded
+	MACCommandCount    int  // the number of mac-commands to send
+	RXWindow           int  // RX window (0 = RX1, 1 = RX2)
+	DataRate           int  // downlink data-rate
+	MAC                lorawan.EUI64
+}
+
+// recordDecision logs and stores the given decision for the given node.
+// Errors are logged as they must not affect the handling of the downlink.
+func recordDecision(ctx common.Context, devEUI lorawan.EUI64, d Decision) {
+	log.WithFields(log.Fields{
+		"dev_eui":             devEUI,
+		"fcnt_up":             d.FCntUp,
+		"decision":            d.Decision,
+		"application_payload": d.ApplicationPayload,
+		"ack_required":        d.ACKRequired,
+		"adr_ack_req":         d.ADRACKReq,
+		"mac_command_count":   d.MACCommandCount,
+		"rx_window":           d.RXWindow,
+		"dr":                  d.DataRate,
+		"mac":                 d.MAC,
+	}).Info("downlink decision")
+
+	decisionCount.Inc(d.Decision, rxWindowLabel(d.RXWindow))
+
+	if err := saveDecision(ctx.RedisPool, devEUI, d); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("save downlink decision error: %s", err)
+	}
+}
+
+// saveDecision adds the given decision to the decision log of the node.
+func saveDecision(p *redis.Pool, devEUI lorawan.EUI64, d Decision) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		return errors.Wrap(err, "gob encode decision error")
+	}
+
+	key := fmt.Sprintf(decisionLogKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, buf.Bytes())
+	c.Send("LTRIM", key, 0, decisionLogSize-1)
+	c.Send("PEXPIRE", key, int64(DecisionLogTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save decision error")
+	}
+	return nil
+}
+
+// GetDecisionCount returns the number of logged downlink decisions of the
+// given node.
+func GetDecisionCount(p *redis.Pool, devEUI lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("LLEN", fmt.Sprintf(decisionLogKeyTempl, devEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get decision count error")
+	}
+	return count, nil
+}
+
+// GetDecisions returns the last downlink decisions of the given node
+// (newest first), respecting the given limit (0 = all) and offset.
+func GetDecisions(p *redis.Pool, devEUI lorawan.EUI64, limit, offset int) ([]Decision, error) {
+	stop := -1
+	if limit > 0 {
+		stop = offset + limit - 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(decisionLogKeyTempl, devEUI), offset, stop))
+	if err != nil {
+		return nil, errors.Wrap(err, "get decisions error")
+	}
+
+	var out []Decision
+	for _, b := range values {
+		var d Decision
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&d); err != nil {
+			return nil, errors.Wrap(err, "gob decode decision error")
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
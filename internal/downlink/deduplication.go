@@ -0,0 +1,101 @@
+package downlink
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	downlinkDeduplicationKeyTempl = "lora:ns:downlink:dedup:%s:%d:%s"
+	downlinkReferenceKeyTempl     = "lora:ns:downlink:reference:%s:%s"
+)
+
+// PushReferenceTTL defines how long a push reference is remembered, and
+// thus the period in which retries of the same push are detected.
+const PushReferenceTTL = time.Hour * 24
+
+// isDuplicateDownlinkPayload returns true when the same payload (FPort + data)
+// has already been pushed for the given DevEUI within the configured
+// common.DownlinkDeduplicationWindow. It always returns false when the
+// deduplication guard is disabled. When false is returned, the payload
+// must be released using releaseDownlinkPayload when it could not be sent.
+func isDuplicateDownlinkPayload(p *redis.Pool, devEUI lorawan.EUI64, fPort uint8, data []byte) (bool, error) {
+	if common.DownlinkDeduplicationWindow == 0 {
+		return false, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", downlinkDeduplicationKey(devEUI, fPort, data), "lock", "PX", int64(common.DownlinkDeduplicationWindow)/int64(time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return true, nil
+		}
+		return false, errors.Wrap(err, "set downlink deduplication key error")
+	}
+	return false, nil
+}
+
+// releaseDownlinkPayload removes the deduplication key of the given
+// payload, so that a retry of a push which failed is not considered a
+// duplicate.
+func releaseDownlinkPayload(p *redis.Pool, devEUI lorawan.EUI64, fPort uint8, data []byte) error {
+	if common.DownlinkDeduplicationWindow == 0 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", downlinkDeduplicationKey(devEUI, fPort, data))
+	if err != nil {
+		return errors.Wrap(err, "delete downlink deduplication key error")
+	}
+	return nil
+}
+
+func downlinkDeduplicationKey(devEUI lorawan.EUI64, fPort uint8, data []byte) string {
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(downlinkDeduplicationKeyTempl, devEUI, fPort, hex.EncodeToString(sum[:]))
+}
+
+// ClaimPushReference claims the given client reference of a pushed payload
+// for the given DevEUI. It returns false when the reference has already
+// been claimed within the PushReferenceTTL (e.g. the push is a retry).
+func ClaimPushReference(p *redis.Pool, devEUI lorawan.EUI64, reference string) (bool, error) {
+	key := fmt.Sprintf(downlinkReferenceKeyTempl, devEUI, reference)
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(PushReferenceTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "set push reference key error")
+	}
+	return true, nil
+}
+
+// ReleasePushReference releases the given client reference, so that the
+// push can be retried (e.g. after it failed).
+func ReleasePushReference(p *redis.Pool, devEUI lorawan.EUI64, reference string) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", fmt.Sprintf(downlinkReferenceKeyTempl, devEUI, reference))
+	if err != nil {
+		return errors.Wrap(err, "delete push reference key error")
+	}
+	return nil
+}
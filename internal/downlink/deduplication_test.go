@@ -0,0 +1,54 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestDownlinkPayloadDeduplication(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a downlink deduplication window", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		defer func(d time.Duration) { common.DownlinkDeduplicationWindow = d }(common.DownlinkDeduplicationWindow)
+		common.DownlinkDeduplicationWindow = time.Minute
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then the first payload is not a duplicate", func() {
+			duplicate, err := isDuplicateDownlinkPayload(p, devEUI, 1, []byte{1, 2, 3})
+			So(err, ShouldBeNil)
+			So(duplicate, ShouldBeFalse)
+
+			Convey("Then the same payload is a duplicate", func() {
+				duplicate, err := isDuplicateDownlinkPayload(p, devEUI, 1, []byte{1, 2, 3})
+				So(err, ShouldBeNil)
+				So(duplicate, ShouldBeTrue)
+			})
+
+			Convey("Then the same data on an other fport is not a duplicate", func() {
+				duplicate, err := isDuplicateDownlinkPayload(p, devEUI, 2, []byte{1, 2, 3})
+				So(err, ShouldBeNil)
+				So(duplicate, ShouldBeFalse)
+			})
+
+			Convey("When the payload is released (e.g. as it could not be sent)", func() {
+				So(releaseDownlinkPayload(p, devEUI, 1, []byte{1, 2, 3}), ShouldBeNil)
+
+				Convey("Then the same payload is not a duplicate", func() {
+					duplicate, err := isDuplicateDownlinkPayload(p, devEUI, 1, []byte{1, 2, 3})
+					So(err, ShouldBeNil)
+					So(duplicate, ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,303 @@
+package downlink
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// deviceQueueKeyTempl contains per node the downlink payloads to send in
+// response to the uplinks of the node (list of gob encoded
+// DeviceQueueItem).
+const deviceQueueKeyTempl = "lora:ns:node:queue:%s"
+
+// DeviceQueueItem contains a downlink payload of the device-queue.
+type DeviceQueueItem struct {
+	DevEUI     lorawan.EUI64
+	FPort      uint8
+	Data       []byte
+	FCnt       uint32
+	Confirmed  bool
+	Critical   bool
+	Reference  string
+	EnqueuedAt time.Time
+	ExpiresAt  *time.Time // the payload must not be sent after this timestamp (optional)
+	NotBefore  *time.Time // the payload must not be sent before this timestamp (optional)
+}
+
+// IsExpired returns true when the item has an expiration timestamp which
+// lies before the given time.
+func (i DeviceQueueItem) IsExpired(t time.Time) bool {
+	return i.ExpiresAt != nil && i.ExpiresAt.Before(t)
+}
+
+// IsDue returns true when the item has no not-before timestamp or when this
+// timestamp does not lie after the given time.
+func (i DeviceQueueItem) IsDue(t time.Time) bool {
+	return i.NotBefore == nil || !i.NotBefore.After(t)
+}
+
+// EnqueueDeviceQueueItem validates the given item and adds it to the
+// device-queue of the given node. The payload must fit within the max.
+// payload size of the highest data-rate of the band.
+func EnqueueDeviceQueueItem(p *redis.Pool, ns session.NodeSession, item DeviceQueueItem) error {
+	if item.FPort == 0 {
+		return ErrFPortMustNotBeZero
+	}
+
+	var maxPayloadSize int
+	for _, mps := range common.Band.MaxPayloadSize {
+		if mps.N > maxPayloadSize {
+			maxPayloadSize = mps.N
+		}
+	}
+	if len(item.Data) > maxPayloadSize {
+		return errors.Wrapf(ErrMaxPayloadSizeExceeded, "(max: %d)", maxPayloadSize)
+	}
+
+	item.DevEUI = ns.DevEUI
+	item.EnqueuedAt = time.Now()
+
+	b, err := encodeDeviceQueueItem(item)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(deviceQueueKeyTempl, ns.DevEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("RPUSH", key, b)
+	c.Send("PEXPIRE", key, int64(common.NodeSessionTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "enqueue device-queue item error")
+	}
+
+	// a held item is sent to a Class-C node once it is due
+	if item.NotBefore != nil {
+		if err := scheduleDeviceQueue(p, ns.DevEUI, *item.NotBefore); err != nil {
+			return err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":    ns.DevEUI,
+		"f_port":     item.FPort,
+		"fcnt":       item.FCnt,
+		"confirmed":  item.Confirmed,
+		"reference":  item.Reference,
+		"not_before": item.NotBefore,
+	}).Info("payload added to device-queue")
+	return nil
+}
+
+// GetDeviceQueueItems returns the items of the device-queue of the given
+// node (the first item is sent first).
+func GetDeviceQueueItems(p *redis.Pool, devEUI lorawan.EUI64) ([]DeviceQueueItem, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(deviceQueueKeyTempl, devEUI), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get device-queue items error")
+	}
+
+	var out []DeviceQueueItem
+	for _, b := range values {
+		item, err := decodeDeviceQueueItem(b)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// GetDeviceQueueSize returns the number of items in the device-queue of the
+// given node.
+func GetDeviceQueueSize(p *redis.Pool, devEUI lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	size, err := redis.Int(c.Do("LLEN", fmt.Sprintf(deviceQueueKeyTempl, devEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get device-queue length error")
+	}
+	return size, nil
+}
+
+// FlushDeviceQueue removes all the items from the device-queue of the given
+// node.
+func FlushDeviceQueue(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(deviceQueueKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "flush device-queue error")
+	}
+	return nil
+}
+
+// popDueDeviceQueueItem removes and returns the first item of the
+// device-queue of the given node which is due at the given time (nil when
+// there is none) and if there are remaining due items. Items which are not
+// yet due are skipped and stay in the queue.
+func popDueDeviceQueueItem(p *redis.Pool, devEUI lorawan.EUI64, now time.Time) (*DeviceQueueItem, bool, error) {
+	key := fmt.Sprintf(deviceQueueKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	for {
+		values, err := redis.ByteSlices(c.Do("LRANGE", key, 0, -1))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "get device-queue items error")
+		}
+
+		var item *DeviceQueueItem
+		var b []byte
+		var more bool
+		for _, v := range values {
+			qi, err := decodeDeviceQueueItem(v)
+			if err != nil {
+				return nil, false, err
+			}
+			if !qi.IsDue(now) {
+				continue
+			}
+			if item != nil {
+				more = true
+				break
+			}
+			item = &qi
+			b = v
+		}
+		if item == nil {
+			return nil, false, nil
+		}
+
+		// an other downlink might have popped the item in the meantime
+		removed, err := redis.Int(c.Do("LREM", key, 1, b))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "pop device-queue item error")
+		}
+		if removed == 1 {
+			return item, more, nil
+		}
+	}
+}
+
+// requeueDeviceQueueItem puts the given (popped) item back at the front of
+// the device-queue. This is used when the downlink containing the item
+// could not be sent.
+func requeueDeviceQueueItem(ctx common.Context, item DeviceQueueItem) {
+	b, err := encodeDeviceQueueItem(item)
+	if err == nil {
+		key := fmt.Sprintf(deviceQueueKeyTempl, item.DevEUI)
+
+		c := ctx.RedisPool.Get()
+		defer c.Close()
+
+		c.Send("MULTI")
+		c.Send("LPUSH", key, b)
+		c.Send("PEXPIRE", key, int64(common.NodeSessionTTL/time.Millisecond))
+		_, err = c.Do("EXEC")
+	}
+	if err != nil {
+		log.WithField("dev_eui", item.DevEUI).Errorf("requeue device-queue item error: %s", err)
+	}
+}
+
+// getDataDownFromDeviceQueue returns the first item of the device-queue
+// which is due at the given time and can be sent to the given node at the
+// given data-rate (nil when there is none) and the data down for it. Items
+// which are not yet due stay in the queue. Items which are expired,
+// exceeding the max. payload size of the data-rate or of which the FCnt
+// does not match the FCntDown (when the AppSKey encryption is not
+// offloaded) are dropped and reported to the application-server. The returned item is removed from
+// the queue, when it is not sent it must be put back using
+// requeueDeviceQueueItem.
+func getDataDownFromDeviceQueue(ctx common.Context, ns session.NodeSession, dr int, now time.Time) (*as.GetDataDownResponse, *DeviceQueueItem, error) {
+	for {
+		item, more, err := popDueDeviceQueueItem(ctx.RedisPool, ns.DevEUI, now)
+		if err != nil || item == nil {
+			return nil, nil, err
+		}
+
+		errType := as.ErrorType_DATA_DOWN_QUEUE_ITEM_DROPPED
+		var errStr string
+		if item.IsExpired(now) {
+			errType = as.ErrorType_DATA_DOWN_QUEUE_ITEM_EXPIRED
+			errStr = fmt.Sprintf("device-queue item expired at %s", item.ExpiresAt.Format(time.RFC3339))
+		} else if len(item.Data) > common.Band.MaxPayloadSize[dr].N {
+			errStr = fmt.Sprintf("device-queue item exceeds max payload size (size: %d, max: %d, dr: %d)", len(item.Data), common.Band.MaxPayloadSize[dr].N, dr)
+		} else if ns.AppSKey == nil && item.FCnt != ns.FCntDown {
+			errStr = fmt.Sprintf("device-queue item fcnt %d does not match fcnt down %d", item.FCnt, ns.FCntDown)
+		}
+
+		if errStr != "" {
+			log.WithFields(log.Fields{
+				"dev_eui":   ns.DevEUI,
+				"f_port":    item.FPort,
+				"reference": item.Reference,
+			}).Warningf("device-queue item dropped: %s", errStr)
+
+			_, err := ctx.Application.HandleError(ctx.RequestContext(), &as.HandleErrorRequest{
+				AppEUI: ns.AppEUI[:],
+				DevEUI: ns.DevEUI[:],
+				Type:   errType,
+				Error:  errStr,
+			})
+			if err != nil {
+				log.Errorf("call application-server handle error method error: %s", err)
+			}
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":     ns.DevEUI,
+			"fcnt":        ns.FCntDown,
+			"data_base64": base64.StdEncoding.EncodeToString(item.Data),
+			"confirmed":   item.Confirmed,
+			"more_data":   more,
+		}).Info("sending payload from device-queue")
+
+		return &as.GetDataDownResponse{
+			Data:      item.Data,
+			Confirmed: item.Confirmed,
+			FPort:     uint32(item.FPort),
+			MoreData:  more,
+			Critical:  item.Critical,
+		}, item, nil
+	}
+}
+
+func encodeDeviceQueueItem(item DeviceQueueItem) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+		return nil, errors.Wrap(err, "gob encode device-queue item error")
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeDeviceQueueItem(b []byte) (DeviceQueueItem, error) {
+	var item DeviceQueueItem
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&item); err != nil {
+		return item, errors.Wrap(err, "gob decode device-queue item error")
+	}
+	return item, nil
+}
@@ -0,0 +1,182 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// deviceQueueScheduleKey contains the nodes with held device-queue items
+// (sorted set, scored by the not-before timestamp in ms of the first held
+// item).
+const deviceQueueScheduleKey = "lora:ns:queue:schedule"
+
+// deviceQueueRetryDelay defines the delay after which the held items of a
+// Class-C node are retried when they could not be sent (e.g. while the node
+// is changing its device class).
+const deviceQueueRetryDelay = time.Minute
+
+// scheduleDeviceQueueScript schedules the given node at the given
+// timestamp, unless it is already scheduled at an earlier timestamp.
+var scheduleDeviceQueueScript = redis.NewScript(1, `
+	local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+	if score and tonumber(score) <= tonumber(ARGV[2]) then
+		return 0
+	end
+	return redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+`)
+
+// scheduleDeviceQueue schedules the sending of the held device-queue items
+// of the given node (Class-C only) at the given time.
+func scheduleDeviceQueue(p *redis.Pool, devEUI lorawan.EUI64, t time.Time) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := scheduleDeviceQueueScript.Do(c, deviceQueueScheduleKey, devEUI.String(), t.UnixNano()/int64(time.Millisecond)); err != nil {
+		return errors.Wrap(err, "schedule device-queue error")
+	}
+	return nil
+}
+
+// claimDueDeviceQueues returns the nodes of which the held device-queue
+// items are due at the given time. The returned nodes are removed from the
+// schedule.
+func claimDueDeviceQueues(p *redis.Pool, now time.Time) ([]lorawan.EUI64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ids, err := redis.Strings(c.Do("ZRANGEBYSCORE", deviceQueueScheduleKey, "-inf", now.UnixNano()/int64(time.Millisecond)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get due device-queues error")
+	}
+
+	var out []lorawan.EUI64
+	for _, id := range ids {
+		// an other instance might have claimed the node in the meantime
+		removed, err := redis.Int(c.Do("ZREM", deviceQueueScheduleKey, id))
+		if err != nil {
+			return nil, errors.Wrap(err, "claim device-queue error")
+		}
+		if removed == 0 {
+			continue
+		}
+
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(id)); err != nil {
+			return nil, errors.Wrap(err, "unmarshal DevEUI error")
+		}
+		out = append(out, devEUI)
+	}
+	return out, nil
+}
+
+// handleDueDeviceQueue sends the first due device-queue item to the given
+// Class-C node and schedules the node again for its remaining held items.
+// The items of a Class-A node are sent in response to its next uplink.
+func handleDueDeviceQueue(ctx common.Context, devEUI lorawan.EUI64, now time.Time) error {
+	ns, err := session.GetNodeSession(ctx.RedisPool, devEUI)
+	if err != nil {
+		if err == session.ErrDoesNotExist {
+			return nil
+		}
+		return err
+	}
+
+	if ns.GetDeviceClass(common.DeviceClassChangeLockout, now) == session.DeviceClassA || len(ns.LastRXInfoSet) == 0 {
+		return nil
+	}
+	if ns.DeviceClassChangePending(common.DeviceClassChangeLockout, now) {
+		return scheduleDeviceQueue(ctx.RedisPool, devEUI, now.Add(deviceQueueRetryDelay))
+	}
+
+	rxInfoSet, err := getAllowedRXInfoSet(ctx, ns, ns.LastRXInfoSet)
+	if err != nil {
+		return err
+	}
+	_, dr, err := getClassCTXInfo(ctx, ns, rxInfoSet[0])
+	if err != nil {
+		return err
+	}
+
+	_, item, err := getDataDownFromDeviceQueue(ctx, ns, dr, now)
+	if err != nil {
+		return errors.Wrap(err, "get device-queue item error")
+	}
+	if item != nil {
+		err = HandlePushDataDown(ctx, ns, item.Confirmed, item.Critical, item.FPort, item.Data, models.TXParams{}, item.Reference)
+		if err != nil {
+			requeueDeviceQueueItem(ctx, *item)
+			if sErr := scheduleDeviceQueue(ctx.RedisPool, devEUI, now.Add(deviceQueueRetryDelay)); sErr != nil {
+				log.WithField("dev_eui", devEUI).Errorf("schedule device-queue error: %s", sErr)
+			}
+			return errors.Wrap(err, "push device-queue item error")
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":   devEUI,
+			"f_port":    item.FPort,
+			"reference": item.Reference,
+		}).Info("held device-queue item sent to class-c node")
+	}
+
+	// schedule the node for the next held item
+	items, err := GetDeviceQueueItems(ctx.RedisPool, devEUI)
+	if err != nil {
+		return err
+	}
+	var next *time.Time
+	for _, qi := range items {
+		if qi.NotBefore != nil && (next == nil || qi.NotBefore.Before(*next)) {
+			next = qi.NotBefore
+		}
+	}
+	if next == nil {
+		return nil
+	}
+	if next.Before(now) {
+		next = &now
+	}
+	return scheduleDeviceQueue(ctx.RedisPool, devEUI, *next)
+}
+
+// RunDeviceQueueScheduler sends, on the given interval, the held
+// device-queue items of Class-C nodes once these are due. When an elector
+// is given, the items are only sent by the leader. It returns when done is
+// closed.
+func RunDeviceQueueScheduler(ctx common.Context, elector *leader.Elector, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+
+			now := time.Now()
+			devEUIs, err := claimDueDeviceQueues(ctx.RedisPool, now)
+			if err != nil {
+				log.Errorf("get due device-queues error: %s", err)
+				continue
+			}
+
+			for _, devEUI := range devEUIs {
+				if err := handleDueDeviceQueue(ctx, devEUI, now); err != nil {
+					log.WithField("dev_eui", devEUI).Errorf("handle due device-queue error: %s", err)
+				}
+			}
+		case <-done:
+			return
+		}
+	}
+}
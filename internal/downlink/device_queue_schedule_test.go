@@ -0,0 +1,126 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestDeviceQueueSchedule(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database and a Class-C node", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		gwBackend := test.NewGatewayBackend()
+		ctx := common.Context{
+			DB:          db,
+			RedisPool:   p,
+			Gateway:     gwBackend,
+			Application: test.NewApplicationClient(),
+			Controller:  test.NewNetworkControllerClient(),
+		}
+
+		ns := session.NodeSession{
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI:      lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			NwkSKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			FCntDown:    5,
+			DeviceClass: session.DeviceClassC,
+			LastRXInfoSet: []gw.RXInfo{
+				{MAC: lorawan.EUI64{1, 2, 1, 2, 1, 2, 1, 2}},
+			},
+			RX2DR: 1,
+		}
+		So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+		now := time.Now()
+		notBefore := now.Add(time.Hour)
+
+		Convey("When enqueueing a held item (e.g. a tariff switch)", func() {
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 1, Data: []byte{1}, FCnt: 5, NotBefore: &notBefore}), ShouldBeNil)
+
+			Convey("Then it is not sent in response to an uplink before it is due", func() {
+				txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, now)
+				So(err, ShouldBeNil)
+				So(txPayload, ShouldBeNil)
+				So(item, ShouldBeNil)
+
+				items, err := GetDeviceQueueItems(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+			})
+
+			Convey("Then a due item enqueued after it is sent first", func() {
+				So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 2, Data: []byte{2}, FCnt: 5}), ShouldBeNil)
+
+				txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, now)
+				So(err, ShouldBeNil)
+				So(item.FPort, ShouldEqual, 2)
+				So(txPayload.MoreData, ShouldBeFalse)
+			})
+
+			Convey("Then the node is not claimed before the item is due", func() {
+				devEUIs, err := claimDueDeviceQueues(p, now)
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldHaveLength, 0)
+			})
+
+			Convey("When the item is due", func() {
+				devEUIs, err := claimDueDeviceQueues(p, notBefore)
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{ns.DevEUI})
+
+				So(handleDueDeviceQueue(ctx, ns.DevEUI, notBefore), ShouldBeNil)
+
+				Convey("Then it is sent to the Class-C node", func() {
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 1)
+
+					items, err := GetDeviceQueueItems(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+			})
+		})
+
+		Convey("When enqueueing two held items", func() {
+			later := notBefore.Add(time.Hour)
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 1, Data: []byte{1}, FCnt: 5, NotBefore: &later}), ShouldBeNil)
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 2, Data: []byte{2}, FCnt: 5, NotBefore: &notBefore}), ShouldBeNil)
+
+			Convey("Then the node is scheduled at the first not-before timestamp", func() {
+				devEUIs, err := claimDueDeviceQueues(p, notBefore)
+				So(err, ShouldBeNil)
+				So(devEUIs, ShouldResemble, []lorawan.EUI64{ns.DevEUI})
+
+				Convey("When the first item has been sent", func() {
+					So(handleDueDeviceQueue(ctx, ns.DevEUI, notBefore), ShouldBeNil)
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 1)
+
+					Convey("Then the node is scheduled again for the second item", func() {
+						devEUIs, err := claimDueDeviceQueues(p, notBefore)
+						So(err, ShouldBeNil)
+						So(devEUIs, ShouldHaveLength, 0)
+
+						devEUIs, err = claimDueDeviceQueues(p, later)
+						So(err, ShouldBeNil)
+						So(devEUIs, ShouldResemble, []lorawan.EUI64{ns.DevEUI})
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,124 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestDeviceQueue(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a node-session", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		appClient := test.NewApplicationClient()
+		ctx := common.Context{RedisPool: p, Application: appClient}
+
+		ns := session.NodeSession{
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			FCntDown: 10,
+		}
+
+		Convey("Then enqueueing an item with FPort 0 fails", func() {
+			err := EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{Data: []byte{1}})
+			So(err, ShouldEqual, ErrFPortMustNotBeZero)
+		})
+
+		Convey("Then enqueueing an item exceeding the max payload size fails", func() {
+			err := EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 1, Data: make([]byte, 256)})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("When enqueueing two items", func() {
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 1, Data: []byte{1}, FCnt: 10, Confirmed: true, Reference: "ref-1"}), ShouldBeNil)
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 2, Data: []byte{2}, FCnt: 11}), ShouldBeNil)
+
+			Convey("Then both items are returned in order", func() {
+				items, err := GetDeviceQueueItems(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 2)
+				So(items[0].DevEUI, ShouldEqual, ns.DevEUI)
+				So(items[0].Reference, ShouldEqual, "ref-1")
+				So(items[1].FPort, ShouldEqual, 2)
+
+				size, err := GetDeviceQueueSize(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(size, ShouldEqual, 2)
+			})
+
+			Convey("Then the first item is sent first with more data pending", func() {
+				txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, time.Now())
+				So(err, ShouldBeNil)
+				So(item.Reference, ShouldEqual, "ref-1")
+				So(txPayload, ShouldResemble, &as.GetDataDownResponse{
+					Data:      []byte{1},
+					Confirmed: true,
+					FPort:     1,
+					MoreData:  true,
+				})
+
+				Convey("When the item is put back", func() {
+					requeueDeviceQueueItem(ctx, *item)
+
+					Convey("Then it is the first item again", func() {
+						items, err := GetDeviceQueueItems(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 2)
+						So(items[0].Reference, ShouldEqual, "ref-1")
+					})
+				})
+			})
+
+			Convey("When the FCntDown does not match the first item", func() {
+				ns.FCntDown = 11
+
+				Convey("Then the first item is dropped and reported to the application-server", func() {
+					txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, time.Now())
+					So(err, ShouldBeNil)
+					So(item.FCnt, ShouldEqual, 11)
+					So(txPayload.MoreData, ShouldBeFalse)
+
+					req := <-appClient.HandleErrorChan
+					So(req.Type, ShouldEqual, as.ErrorType_DATA_DOWN_QUEUE_ITEM_DROPPED)
+				})
+			})
+
+			Convey("When enqueueing an expired item at the front of the queue", func() {
+				expiresAt := time.Now().Add(-time.Minute)
+				item := DeviceQueueItem{DevEUI: ns.DevEUI, FPort: 3, Data: []byte{3}, FCnt: 10, ExpiresAt: &expiresAt}
+				requeueDeviceQueueItem(ctx, item)
+
+				Convey("Then the expired item is dropped and reported to the application-server", func() {
+					txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, time.Now())
+					So(err, ShouldBeNil)
+					So(item.Reference, ShouldEqual, "ref-1")
+					So(txPayload.Data, ShouldResemble, []byte{1})
+
+					req := <-appClient.HandleErrorChan
+					So(req.Type, ShouldEqual, as.ErrorType_DATA_DOWN_QUEUE_ITEM_EXPIRED)
+				})
+			})
+
+			Convey("When flushing the queue", func() {
+				So(FlushDeviceQueue(p, ns.DevEUI), ShouldBeNil)
+
+				Convey("Then there is nothing to send", func() {
+					txPayload, item, err := getDataDownFromDeviceQueue(ctx, ns, 0, time.Now())
+					So(err, ShouldBeNil)
+					So(txPayload, ShouldBeNil)
+					So(item, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package downlink
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// getDiversityTXInfo returns the TXInfo for transmitting the given frame a
+// second time (transmit diversity), via the second best allowed gateway of
+// the RXInfo set and using the other RX window than the node-session. As
+// the node does not open RX2 after receiving a frame in RX1, it receives
+// (and acknowledges) the frame at most once. Nil is returned (and the
+// reason is logged) when there is no other allowed gateway or when the
+// frame does not fit the data-rate of the other RX window.
+func getDiversityTXInfo(ctx common.Context, ns session.NodeSession, rxInfoSet []gw.RXInfo, mac lorawan.EUI64, dataDown DataDownFrameContext) *gw.TXInfo {
+	logFields := log.Fields{
+		"dev_eui": ns.DevEUI,
+		"fcnt":    ns.FCntDown,
+	}
+
+	txInfo, dr, err := getDiversityTXInfoAndDR(ctx, ns, rxInfoSet, mac)
+	if err != nil {
+		log.WithFields(logFields).Warningf("skipping transmit diversity: %s", err)
+		return nil
+	}
+
+	size, err := dataDown.payloadSize()
+	if err != nil {
+		log.WithFields(logFields).Errorf("get frame payload size error: %s", err)
+		return nil
+	}
+	if maxSize := common.Band.MaxPayloadSize[dr].N; size > maxSize {
+		log.WithFields(logFields).Warningf("skipping transmit diversity: payload size %d exceeds max payload size %d of dr %d", size, maxSize, dr)
+		return nil
+	}
+
+	return &txInfo
+}
+
+// getDiversityTXInfoAndDR returns the TXInfo and data-rate of the other RX
+// window, for the best allowed gateway of the RXInfo set other than the
+// given gateway.
+func getDiversityTXInfoAndDR(ctx common.Context, ns session.NodeSession, rxInfoSet []gw.RXInfo, mac lorawan.EUI64) (gw.TXInfo, int, error) {
+	var others []gw.RXInfo
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.MAC != mac {
+			others = append(others, rxInfo)
+		}
+	}
+	if len(others) == 0 {
+		return gw.TXInfo{}, 0, ErrNoDiversityGateway
+	}
+
+	rxInfo, err := getAllowedRXInfo(ctx, ns, others)
+	if err != nil {
+		if err == ErrNoAllowedGateway {
+			return gw.TXInfo{}, 0, ErrNoDiversityGateway
+		}
+		return gw.TXInfo{}, 0, err
+	}
+
+	if ns.RXWindow == session.RX1 {
+		ns.RXWindow = session.RX2
+	} else {
+		ns.RXWindow = session.RX1
+	}
+
+	txInfo, dr, err := getDataDownTXInfoAndDR(ctx, ns, rxInfo)
+	if err != nil {
+		return txInfo, dr, errors.Wrap(err, "get data down txinfo error")
+	}
+	return txInfo, dr, nil
+}
+
+// payloadSize returns the size of the FRMPayload and FOpts of the frame.
+func (ctx DataDownFrameContext) payloadSize() (int, error) {
+	size := len(ctx.Data)
+	for _, mac := range ctx.MACCommands {
+		b, err := mac.MarshalBinary()
+		if err != nil {
+			return 0, errors.Wrap(err, "marshal mac-command error")
+		}
+		size += len(b)
+	}
+	return size, nil
+}
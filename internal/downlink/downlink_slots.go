@@ -0,0 +1,83 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+)
+
+// downlinkSlotRetryInterval defines the interval on which a deferred
+// downlink retries to reserve a downlink slot.
+const downlinkSlotRetryInterval = 50 * time.Millisecond
+
+// demoteBusyGateways returns the RXInfo set with the RXInfo of the gateways
+// which have reached common.GatewayMaxDownlinksPerSecond moved to the end,
+// keeping the order of the set otherwise, so that the downlink is re-routed
+// via an other gateway when possible. On error, the RXInfo set is returned
+// unchanged.
+func demoteBusyGateways(ctx common.Context, rxInfoSet []gw.RXInfo) []gw.RXInfo {
+	if common.GatewayMaxDownlinksPerSecond <= 0 || len(rxInfoSet) < 2 {
+		return rxInfoSet
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	busy, err := gateway.GetBusyGateways(ctx.RedisPool, macs, time.Now(), common.GatewayMaxDownlinksPerSecond)
+	if err != nil {
+		log.Errorf("get busy gateways error: %s", err)
+		return rxInfoSet
+	}
+
+	var available, busySet []gw.RXInfo
+	for _, rxInfo := range rxInfoSet {
+		if _, ok := busy[rxInfo.MAC]; ok {
+			log.WithField("mac", rxInfo.MAC).Info("demoting gateway which reached the max downlinks per second")
+			busySet = append(busySet, rxInfo)
+			continue
+		}
+		available = append(available, rxInfo)
+	}
+
+	return append(available, busySet...)
+}
+
+// reserveDownlinkSlot reserves a downlink slot at the gateway of the given
+// TXPacket (see common.GatewayMaxDownlinksPerSecond). Downlinks which must
+// be transmitted immediately (e.g. Class-C) are deferred until a slot is
+// available, within the request deadline. ErrGatewayBusy is returned when
+// no slot is available.
+func reserveDownlinkSlot(ctx common.Context, txPacket gw.TXPacket) error {
+	if common.GatewayMaxDownlinksPerSecond <= 0 {
+		return nil
+	}
+
+	deferUntil := time.Now().Add(gateway.DownlinkSlotWindow)
+	if deadline, ok := ctx.RequestContext().Deadline(); ok && deadline.Before(deferUntil) {
+		deferUntil = deadline
+	}
+
+	for {
+		ok, err := gateway.ReserveDownlinkSlot(ctx.RedisPool, txPacket.TXInfo.MAC, txPacket.Token, time.Now(), common.GatewayMaxDownlinksPerSecond)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+
+		if !txPacket.TXInfo.Immediately || time.Now().Add(downlinkSlotRetryInterval).After(deferUntil) {
+			return errors.Wrapf(ErrGatewayBusy, "mac: %s", txPacket.TXInfo.MAC)
+		}
+
+		time.Sleep(downlinkSlotRetryInterval)
+	}
+}
@@ -4,9 +4,22 @@ import "errors"
 
 // downlink errors
 var (
-	ErrFPortMustNotBeZero     = errors.New("FPort must not be 0")
-	ErrFPortMustBeZero        = errors.New("FPort must be 0")
-	ErrNoLastRXInfoSet        = errors.New("no last RX-Info set available")
-	ErrInvalidDataRate        = errors.New("invalid data-rate")
-	ErrMaxPayloadSizeExceeded = errors.New("maximum payload size exceeded")
+	ErrFPortMustNotBeZero       = errors.New("FPort must not be 0")
+	ErrFPortMustBeZero          = errors.New("FPort must be 0")
+	ErrNoLastRXInfoSet          = errors.New("no last RX-Info set available")
+	ErrInvalidDataRate          = errors.New("invalid data-rate")
+	ErrMaxPayloadSizeExceeded   = errors.New("maximum payload size exceeded")
+	ErrUnknownRXWindow          = errors.New("unknown RXWindow option")
+	ErrDeviceClassChangePending = errors.New("device class change pending")
+	ErrNotClassC                = errors.New("node is not a Class-C device")
+	ErrNoAllowedGateway         = errors.New("no downlink capable gateway within the gateway regions of the node")
+	ErrAppSKeyNotOffloaded      = errors.New("AppSKey encryption is not offloaded")
+	ErrDeadlineExceeded         = errors.New("downlink deadline exceeded")
+	ErrNoDiversityGateway       = errors.New("no other gateway available for transmit diversity")
+	ErrGatewayBusy              = errors.New("gateway reached the max downlinks per second")
+	ErrDailyAirtimeCapReached   = errors.New("daily downlink airtime cap of the node has been reached")
+	ErrReadOnlyMode             = errors.New("downlinks are disabled in read-only mode")
+	ErrPublishTXPacket          = errors.New("publish tx packet to gateway error")
+	ErrBenchModeDisabled        = errors.New("bench mode is disabled")
+	ErrInvalidBenchMACCommand   = errors.New("invalid bench mac-command")
 )
@@ -0,0 +1,161 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// getFallbackTXInfo returns the TXInfo for transmitting the frame, sent
+// using the given TXInfo, via the next-best gateways (best first, at most
+// common.GatewayNACKFallbacks) when the gateway rejects the frame. The
+// given RXInfo set must be the allowed RXInfo set (see
+// getAllowedRXInfoSet), of which the first RXInfo is the gateway of the
+// given TXInfo. The TX parameters are resolved for each gateway using the
+// given band and per-push parameters. The given gateways are excluded.
+func getFallbackTXInfo(ctx common.Context, ns session.NodeSession, rxInfoSet []gw.RXInfo, txInfo gw.TXInfo, bandParams, pushParams models.TXParams, exclude ...lorawan.EUI64) []gw.TXInfo {
+	if common.GatewayNACKFallbacks <= 0 || len(rxInfoSet) < 2 {
+		return nil
+	}
+
+	// the timestamp is relative to the uplink timestamp of the gateway
+	offset := txInfo.Timestamp - rxInfoSet[0].Timestamp
+
+	var out []gw.TXInfo
+	for _, rxInfo := range rxInfoSet[1:] {
+		if len(out) == common.GatewayNACKFallbacks {
+			break
+		}
+		if rxInfo.MAC == txInfo.MAC || isExcludedGateway(rxInfo.MAC, exclude) {
+			continue
+		}
+
+		fallback := txInfo
+		fallback.MAC = rxInfo.MAC
+		if !fallback.Immediately {
+			fallback.Timestamp = rxInfo.Timestamp + offset
+		}
+		if err := setTXParams(ctx, ns, &fallback, bandParams, pushParams); err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": ns.DevEUI,
+				"mac":     rxInfo.MAC,
+			}).Errorf("set fallback tx-params error: %s", err)
+			continue
+		}
+		out = append(out, fallback)
+	}
+
+	return out
+}
+
+func isExcludedGateway(mac lorawan.EUI64, exclude []lorawan.EUI64) bool {
+	for _, m := range exclude {
+		if m == mac {
+			return true
+		}
+	}
+	return false
+}
+
+// setFrameFallback sets the given fallback TXInfo and the data needed for
+// re-sending the given TXPacket on the given frame log entry. The deadline
+// of the request context is used as fallback deadline.
+func setFrameFallback(ctx common.Context, f *framelog.DownlinkFrame, txPacket gw.TXPacket, fallback []gw.TXInfo) error {
+	b, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	f.Fallback = fallback
+	f.PHYPayload = b
+	if deadline, ok := ctx.RequestContext().Deadline(); ok && !txPacket.TXInfo.Immediately {
+		f.FallbackDeadline = deadline
+	}
+	return nil
+}
+
+// sendFallbackTXPacket re-sends the frame of the given (rejected) token
+// via the next-best gateway of its frame log entry. It is a no-op when the
+// frame has no (remaining) fallback or when the fallback deadline has been
+// exceeded. The new transmission is added to the frame log, with the
+// remaining fallbacks.
+func sendFallbackTXPacket(ctx common.Context, token uint16) error {
+	f, err := framelog.GetDownlinkFrame(ctx.RedisPool, token)
+	if err != nil {
+		return err
+	}
+	if len(f.Fallback) == 0 {
+		return nil
+	}
+
+	logFields := log.Fields{
+		"dev_eui":  f.DevEUI,
+		"fcnt":     f.FCnt,
+		"token":    f.Token,
+		"mac":      f.MAC,
+		"fallback": f.Fallback[0].MAC,
+	}
+
+	if !f.FallbackDeadline.IsZero() && time.Now().After(f.FallbackDeadline) {
+		log.WithFields(logFields).Warning("downlink rejected by gateway, deadline for fallback gateway exceeded")
+		return nil
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(f.PHYPayload); err != nil {
+		return errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	txPacket := gw.TXPacket{
+		TXInfo:     f.Fallback[0],
+		PHYPayload: phy,
+	}
+	if txPacket.Token, err = framelog.NewToken(ctx.RedisPool); err != nil {
+		return err
+	}
+
+	if err := reserveDownlinkSlot(ctx, txPacket); err != nil {
+		return err
+	}
+
+	if err := ctx.Gateway.SendTXPacket(txPacket); err != nil {
+		recordTXFailure(ctx, txPacket.TXInfo.MAC)
+		if err := airtime.RecordRejected(ctx.RedisPool, txPacket.TXInfo.MAC, txPacket.TXInfo.Frequency); err != nil {
+			log.WithFields(logFields).Errorf("record rejected downlink error: %s", err)
+		}
+		return errors.Wrap(err, "send tx packet to gateway error")
+	}
+
+	log.WithFields(logFields).Info("downlink rejected by gateway, retried via fallback gateway")
+
+	if err := airtime.RecordDownlink(ctx.RedisPool, f.DevEUI, txPacket); err != nil {
+		log.WithFields(logFields).Errorf("record downlink airtime error: %s", err)
+	}
+
+	next := framelog.DownlinkFrame{
+		Token:      txPacket.Token,
+		DevEUI:     f.DevEUI,
+		MAC:        txPacket.TXInfo.MAC,
+		FCnt:       f.FCnt,
+		Attempt:    1,
+		SentAt:     time.Now(),
+		JoinAccept: f.JoinAccept,
+		Session:    f.Session,
+	}
+	if len(f.Fallback) > 1 {
+		next.Fallback = f.Fallback[1:]
+		next.FallbackDeadline = f.FallbackDeadline
+		next.PHYPayload = f.PHYPayload
+	}
+
+	return framelog.LogDownlink(ctx.RedisPool, next, txPacket)
+}
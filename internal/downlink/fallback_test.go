@@ -0,0 +1,99 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestSendFallbackTXPacket(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a frame sent with two fallback gateways", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		backend := test.NewGatewayBackend()
+		ctx := common.Context{RedisPool: p, Gateway: backend}
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		macs := []lorawan.EUI64{
+			{1, 1, 1, 1, 1, 1, 1, 1},
+			{2, 2, 2, 2, 2, 2, 2, 2},
+			{3, 3, 3, 3, 3, 3, 3, 3},
+		}
+
+		txPacket := gw.TXPacket{
+			Token:  1,
+			TXInfo: gw.TXInfo{MAC: macs[0], Timestamp: 1000000, Frequency: 868100000, DataRate: common.Band.DataRates[0]},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{MType: lorawan.UnconfirmedDataDown, Major: lorawan.LoRaWANR1},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{DevAddr: lorawan.DevAddr{1, 2, 3, 4}, FCnt: 5},
+				},
+			},
+		}
+		fallback := []gw.TXInfo{txPacket.TXInfo, txPacket.TXInfo}
+		fallback[0].MAC = macs[1]
+		fallback[1].MAC = macs[2]
+
+		f := framelog.NewDownlinkFrame(session.NodeSession{DevEUI: devEUI}, txPacket, false)
+		So(setFrameFallback(ctx, &f, txPacket, fallback), ShouldBeNil)
+		So(framelog.LogDownlink(p, f, txPacket), ShouldBeNil)
+
+		Convey("When the gateway rejects the frame", func() {
+			So(HandleTXAck(p, gw.TXAck{MAC: macs[0], Token: 1, Error: "COLLISION_PACKET"}), ShouldBeNil)
+			So(sendFallbackTXPacket(ctx, 1), ShouldBeNil)
+
+			Convey("Then the frame is sent via the next-best gateway", func() {
+				So(backend.TXPacketChan, ShouldHaveLength, 1)
+				retry := <-backend.TXPacketChan
+				So(retry.TXInfo.MAC, ShouldEqual, macs[1])
+				So(retry.PHYPayload, ShouldResemble, txPacket.PHYPayload)
+
+				Convey("Then both transmissions are in the frame log", func() {
+					frames, err := framelog.GetDownlinkFrames(p, devEUI, 5)
+					So(err, ShouldBeNil)
+					So(frames, ShouldHaveLength, 2)
+					So(frames[0].Error, ShouldEqual, "COLLISION_PACKET")
+					So(frames[1].MAC, ShouldEqual, macs[1])
+					So(frames[1].Attempt, ShouldEqual, 2)
+					So(frames[1].Fallback, ShouldResemble, fallback[1:])
+				})
+
+				Convey("When the next-best gateway rejects the frame too", func() {
+					So(sendFallbackTXPacket(ctx, retry.Token), ShouldBeNil)
+
+					Convey("Then the frame is sent via the last gateway", func() {
+						So(backend.TXPacketChan, ShouldHaveLength, 1)
+						last := <-backend.TXPacketChan
+						So(last.TXInfo.MAC, ShouldEqual, macs[2])
+
+						Convey("Then there is no further fallback", func() {
+							So(sendFallbackTXPacket(ctx, last.Token), ShouldBeNil)
+							So(backend.TXPacketChan, ShouldHaveLength, 0)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("When the fallback deadline has been exceeded", func() {
+			f.FallbackDeadline = time.Now().Add(-time.Second)
+			So(framelog.LogDownlink(p, f, txPacket), ShouldBeNil)
+
+			Convey("Then the frame is not retried", func() {
+				So(sendFallbackTXPacket(ctx, 1), ShouldBeNil)
+				So(backend.TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package downlink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// fCntReservationKeyTempl contains per node the last downlink frame-counter
+// reserved by the application-server (see GetNextDownlinkFCnt).
+const fCntReservationKeyTempl = "lora:ns:node:fcnt:reserved:%s"
+
+// DefaultFCntReservationTTL defines the default validity of a downlink
+// frame-counter reservation.
+const DefaultFCntReservationTTL = time.Minute
+
+// nextDownlinkFCntScript returns the given frame-counter, or the last
+// reserved frame-counter + 1 when this is higher. When ARGV[2] is 1, the
+// returned frame-counter is reserved for ARGV[3] ms.
+var nextDownlinkFCntScript = redis.NewScript(1, `
+	local fcnt = tonumber(ARGV[1])
+	local reserved = tonumber(redis.call("GET", KEYS[1]))
+	if reserved and reserved >= fcnt then
+		fcnt = reserved + 1
+	end
+	if ARGV[2] == "1" then
+		redis.call("SET", KEYS[1], fcnt, "PX", ARGV[3])
+	end
+	return fcnt
+`)
+
+// GetNextDownlinkFCnt returns the frame-counter with which the next payload
+// enqueued in the device-queue of the given node will be transmitted, so
+// that an application-server can encrypt the payload with the AppSKey
+// before enqueueing it. This is the FCntDown of the node-session plus the
+// number of queued items, skipping the frame-counters reserved by other
+// calls. When reserve is set, the returned frame-counter is reserved for
+// the given ttl (DefaultFCntReservationTTL when 0), so that concurrent
+// callers get distinct frame-counters.
+//
+// Note that downlinks without application payload (e.g. an ACK or
+// mac-commands) also consume a frame-counter. Items which no longer match
+// the FCntDown when transmitted are dropped and reported to the
+// application-server.
+func GetNextDownlinkFCnt(p *redis.Pool, ns session.NodeSession, reserve bool, ttl time.Duration) (uint32, error) {
+	if ttl == 0 {
+		ttl = DefaultFCntReservationTTL
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	queued, err := redis.Int(c.Do("LLEN", fmt.Sprintf(deviceQueueKeyTempl, ns.DevEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get device-queue length error")
+	}
+
+	var reserveArg int
+	if reserve {
+		reserveArg = 1
+	}
+
+	fCnt, err := redis.Int64(nextDownlinkFCntScript.Do(c,
+		fmt.Sprintf(fCntReservationKeyTempl, ns.DevEUI),
+		int64(ns.FCntDown)+int64(queued),
+		reserveArg,
+		int64(ttl/time.Millisecond),
+	))
+	if err != nil {
+		return 0, errors.Wrap(err, "get next downlink fcnt error")
+	}
+	return uint32(fCnt), nil
+}
@@ -0,0 +1,80 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestGetNextDownlinkFCnt(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a node-session", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCntDown: 10,
+		}
+
+		Convey("Then the next fcnt is the FCntDown of the node-session", func() {
+			fCnt, err := GetNextDownlinkFCnt(p, ns, false, 0)
+			So(err, ShouldBeNil)
+			So(fCnt, ShouldEqual, 10)
+		})
+
+		Convey("When an item has been enqueued", func() {
+			So(EnqueueDeviceQueueItem(p, ns, DeviceQueueItem{FPort: 1, Data: []byte{1}, FCnt: 10}), ShouldBeNil)
+
+			Convey("Then the next fcnt follows the queued item", func() {
+				fCnt, err := GetNextDownlinkFCnt(p, ns, false, 0)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 11)
+			})
+		})
+
+		Convey("When two callers reserve the next fcnt", func() {
+			fCnt1, err := GetNextDownlinkFCnt(p, ns, true, 0)
+			So(err, ShouldBeNil)
+			fCnt2, err := GetNextDownlinkFCnt(p, ns, true, 0)
+			So(err, ShouldBeNil)
+
+			Convey("Then they get distinct fcnts", func() {
+				So(fCnt1, ShouldEqual, 10)
+				So(fCnt2, ShouldEqual, 11)
+			})
+
+			Convey("Then the next fcnt skips the reserved fcnts", func() {
+				fCnt, err := GetNextDownlinkFCnt(p, ns, false, 0)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 12)
+			})
+
+			Convey("Then the reservations are ignored once the FCntDown passed them", func() {
+				ns.FCntDown = 15
+				fCnt, err := GetNextDownlinkFCnt(p, ns, false, 0)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 15)
+			})
+		})
+
+		Convey("When the reservation has expired", func() {
+			_, err := GetNextDownlinkFCnt(p, ns, true, 10*time.Millisecond)
+			So(err, ShouldBeNil)
+			time.Sleep(20 * time.Millisecond)
+
+			Convey("Then the fcnt is returned again", func() {
+				fCnt, err := GetNextDownlinkFCnt(p, ns, true, 0)
+				So(err, ShouldBeNil)
+				So(fCnt, ShouldEqual, 10)
+			})
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package downlink
+
+import (
+	"sort"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// getAllowedRXInfo returns the best RXInfo of a downlink capable gateway
+// within the gateway regions of the node (see getAllowedRXInfoSet).
+func getAllowedRXInfo(ctx common.Context, ns session.NodeSession, rxInfoSet []gw.RXInfo) (gw.RXInfo, error) {
+	allowed, err := getAllowedRXInfoSet(ctx, ns, rxInfoSet)
+	if err != nil {
+		return gw.RXInfo{}, err
+	}
+	return allowed[0], nil
+}
+
+// getAllowedRXInfoSet returns the RXInfo of the downlink capable gateways
+// within the gateway regions of the node, sorted by SNR / RSSI (best
+// first). When the node has no gateway regions, all the downlink capable
+// gateways are returned. ErrNoAllowedGateway is returned when none of the
+// gateways is allowed. Gateways with a low reachability score or which
+// reached the max downlinks per second are moved to the end, so that they
+// are only selected when there is no other allowed gateway. Gateways with
+// recent tx failures are excluded (see excludeFailingGateways).
+func getAllowedRXInfoSet(ctx common.Context, ns session.NodeSession, rxInfoSet []gw.RXInfo) ([]gw.RXInfo, error) {
+	if len(rxInfoSet) == 0 {
+		return nil, ErrNoLastRXInfoSet
+	}
+
+	// sort a copy, the given set must not be modified
+	rxInfoSet = append([]gw.RXInfo(nil), rxInfoSet...)
+	sort.Stable(models.RXInfoSet(rxInfoSet))
+
+	rxInfoSet = demoteUnreachableGateways(ctx, rxInfoSet)
+	rxInfoSet = demoteBusyGateways(ctx, rxInfoSet)
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	receiveOnly, err := gateway.GetReceiveOnlyGateways(ctx.DB, macs)
+	if err != nil {
+		return nil, errors.Wrap(err, "get receive-only gateways error")
+	}
+
+	var regions map[lorawan.EUI64]string
+	if len(ns.GatewayRegions) != 0 {
+		regions, err = gateway.GetGatewayRegions(ctx.DB, macs)
+		if err != nil {
+			return nil, errors.Wrap(err, "get gateway regions error")
+		}
+	}
+
+	var allowed []gw.RXInfo
+	for _, rxInfo := range rxInfoSet {
+		if _, ok := receiveOnly[rxInfo.MAC]; ok {
+			continue
+		}
+
+		if len(ns.GatewayRegions) == 0 {
+			allowed = append(allowed, rxInfo)
+			continue
+		}
+
+		region, ok := regions[rxInfo.MAC]
+		if !ok {
+			continue
+		}
+
+		for _, r := range ns.GatewayRegions {
+			if r == region {
+				allowed = append(allowed, rxInfo)
+				break
+			}
+		}
+	}
+
+	if len(allowed) == 0 {
+		log.WithFields(log.Fields{
+			"dev_eui":         ns.DevEUI,
+			"gateway_regions": ns.GatewayRegions,
+		}).Warning("no downlink capable gateway within the gateway regions of the node")
+
+		return nil, ErrNoAllowedGateway
+	}
+
+	return excludeFailingGateways(ctx, allowed), nil
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
 	"github.com/brocaar/lorawan"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
@@ -13,22 +16,20 @@ import (
 
 func getJoinAcceptTXInfo(ctx common.Context, ns session.NodeSession, rxInfo gw.RXInfo) (gw.TXInfo, error) {
 	txInfo := gw.TXInfo{
-		MAC:      rxInfo.MAC,
-		CodeRate: rxInfo.CodeRate,
-		Power:    common.Band.DefaultTXPower,
+		MAC: rxInfo.MAC,
 	}
 
 	if ns.RXWindow == session.RX1 {
 		txInfo.Timestamp = rxInfo.Timestamp + uint32(common.Band.JoinAcceptDelay1/time.Microsecond)
 
 		// get uplink dr
-		uplinkDR, err := common.Band.GetDataRate(rxInfo.DataRate)
+		uplinkDR, err := common.GetDataRate(rxInfo.DataRate)
 		if err != nil {
 			return txInfo, err
 		}
 
 		// get RX1 DR
-		rx1DR, err := common.Band.GetRX1DataRate(uplinkDR, 0)
+		rx1DR, err := common.GetRX1DataRate(uplinkDR, 0)
 		if err != nil {
 			return txInfo, err
 
@@ -43,25 +44,46 @@ func getJoinAcceptTXInfo(ctx common.Context, ns session.NodeSession, rxInfo gw.R
 	} else if ns.RXWindow == session.RX2 {
 		txInfo.Timestamp = rxInfo.Timestamp + uint32(common.Band.JoinAcceptDelay2/time.Microsecond)
 		txInfo.DataRate = common.Band.DataRates[common.Band.RX2DataRate]
+		// the node uses the rx2 frequency of the band until it has
+		// received the RXParamSetupReq mac-command
 		txInfo.Frequency = common.Band.RX2Frequency
 	} else {
-		return txInfo, fmt.Errorf("unkonwn RXWindow option %d", ns.RXWindow)
+		return txInfo, errors.Wrapf(ErrUnknownRXWindow, "RXWindow %d", ns.RXWindow)
+	}
+
+	bandParams := getBandTXParams(rxInfo.CodeRate)
+	if common.JoinAcceptTXPower > 0 {
+		bandParams.Power = common.JoinAcceptTXPower
 	}
+
+	if err := setTXParams(ctx, ns, &txInfo, bandParams, models.TXParams{}); err != nil {
+		return txInfo, errors.Wrap(err, "set tx-params error")
+	}
+
 	return txInfo, nil
 }
 
 // SendJoinAcceptResponse sends the join-accept response.
 func SendJoinAcceptResponse(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, phy lorawan.PHYPayload) error {
-	txInfo, err := getJoinAcceptTXInfo(ctx, ns, rxPacket.RXInfoSet[0])
+	rxInfo, err := getAllowedRXInfo(ctx, ns, rxPacket.RXInfoSet)
 	if err != nil {
-		return fmt.Errorf("get join-accept txinfo error: %s", err)
+		return errors.Wrap(err, "get allowed rx-info error")
+	}
+
+	txInfo, err := getJoinAcceptTXInfo(ctx, ns, rxInfo)
+	if err != nil {
+		return errors.Wrap(err, "get join-accept txinfo error")
 	}
 	fmt.Printf("SendJoinAcceptResponse: %v\n", gw.TXPacket{TXInfo: txInfo, PHYPayload: phy})
-	if err = ctx.Gateway.SendTXPacket(gw.TXPacket{
+	if err = sendTXPacket(ctx, ns, gw.TXPacket{
 		TXInfo:     txInfo,
 		PHYPayload: phy,
-	}); err != nil {
-		return fmt.Errorf("send txpacket error: %s", err)
+	}, false, nil); err != nil {
+		return errors.Wrap(err, "send txpacket error")
+	}
+
+	if err := recordJoinAccept(ctx.RedisPool, ns.DevEUI); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("record join-accept error: %s", err)
 	}
 	return nil
 }
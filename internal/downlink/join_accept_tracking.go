@@ -0,0 +1,120 @@
+package downlink
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// joinAcceptsKeyTempl contains per node the counters of the join-accepts
+	// sent since the last uplink of the node (hash with the fields sent,
+	// acked, rejected and error).
+	joinAcceptsKeyTempl = "lora:ns:node:join_accepts:%s"
+
+	// JoinAcceptTrackingTTL defines how long the join-accept counters of a
+	// node are kept after its last join-accept.
+	JoinAcceptTrackingTTL = time.Hour * 24
+)
+
+// JoinAccepts contains the join-accepts sent to a node since its last
+// uplink (thus the join-accepts which did not result in an activation).
+type JoinAccepts struct {
+	Sent     int    // number of join-accepts sent to the gateways
+	Acked    int    // number of join-accepts of which the gateway confirmed the transmission
+	Rejected int    // number of join-accepts rejected by the gateway
+	Error    string // the last rejection reason
+}
+
+// Diagnosis returns the likely cause of the join-accepts not resulting in
+// an activation.
+func (j JoinAccepts) Diagnosis() string {
+	switch {
+	case j.Acked > 0:
+		return "join-accepts were transmitted by the gateway, likely RF asymmetry (the node does not receive the downlink) or key mismatch (the node can not validate the join-accept)"
+	case j.Rejected > 0:
+		return fmt.Sprintf("join-accepts were rejected by the gateway (%s)", j.Error)
+	default:
+		return "join-accept transmissions were not confirmed by the gateway"
+	}
+}
+
+// String implements fmt.Stringer.
+func (j JoinAccepts) String() string {
+	return fmt.Sprintf("%d join-accepts sent without subsequent uplink (%d transmitted, %d rejected by gateway): %s", j.Sent, j.Acked, j.Rejected, j.Diagnosis())
+}
+
+// recordJoinAccept increments the number of join-accepts sent to the given
+// node.
+func recordJoinAccept(p *redis.Pool, devEUI lorawan.EUI64) error {
+	return incrJoinAccepts(p, devEUI, "sent", "")
+}
+
+// recordJoinAcceptTXAck records the TXAck of a join-accept sent to the
+// given node.
+func recordJoinAcceptTXAck(p *redis.Pool, devEUI lorawan.EUI64, ackErr string) error {
+	if ackErr != "" {
+		return incrJoinAccepts(p, devEUI, "rejected", ackErr)
+	}
+	return incrJoinAccepts(p, devEUI, "acked", "")
+}
+
+func incrJoinAccepts(p *redis.Pool, devEUI lorawan.EUI64, field, ackErr string) error {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(joinAcceptsKeyTempl, devEUI)
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", key, field, 1)
+	if ackErr != "" {
+		c.Send("HSET", key, "error", ackErr)
+	}
+	c.Send("PEXPIRE", key, int64(JoinAcceptTrackingTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record join-accept error")
+	}
+	return nil
+}
+
+// GetJoinAccepts returns the join-accepts sent to the given node since its
+// last uplink.
+func GetJoinAccepts(p *redis.Pool, devEUI lorawan.EUI64) (JoinAccepts, error) {
+	var j JoinAccepts
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.StringMap(c.Do("HGETALL", fmt.Sprintf(joinAcceptsKeyTempl, devEUI)))
+	if err != nil {
+		return j, errors.Wrap(err, "get join-accepts error")
+	}
+
+	for field, dst := range map[string]*int{"sent": &j.Sent, "acked": &j.Acked, "rejected": &j.Rejected} {
+		if v, ok := values[field]; ok {
+			if *dst, err = strconv.Atoi(v); err != nil {
+				return j, errors.Wrapf(err, "parse %s error", field)
+			}
+		}
+	}
+	j.Error = values["error"]
+
+	return j, nil
+}
+
+// ResetJoinAccepts resets the join-accepts of the given node, e.g. on the
+// first uplink after the activation.
+func ResetJoinAccepts(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(joinAcceptsKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "reset join-accepts error")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package downlink
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestJoinAcceptsDiagnosis(t *testing.T) {
+	Convey("Given join-accepts acknowledged by the gateway", t, func() {
+		j := JoinAccepts{Sent: 3, Acked: 2, Rejected: 1, Error: "TOO_LATE"}
+
+		Convey("Then RF asymmetry or key mismatch is diagnosed", func() {
+			So(j.Diagnosis(), ShouldContainSubstring, "RF asymmetry")
+			So(j.String(), ShouldContainSubstring, "3 join-accepts sent without subsequent uplink (2 transmitted, 1 rejected by gateway)")
+		})
+	})
+
+	Convey("Given join-accepts rejected by the gateway", t, func() {
+		j := JoinAccepts{Sent: 3, Rejected: 3, Error: "TOO_LATE"}
+
+		Convey("Then the rejection is diagnosed", func() {
+			So(j.Diagnosis(), ShouldEqual, "join-accepts were rejected by the gateway (TOO_LATE)")
+		})
+	})
+}
+
+func TestJoinAcceptTracking(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		mac := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+		Convey("When logging two join-accepts and their TXAcks", func() {
+			for i, ackErr := range []string{"", "COLLISION_PACKET"} {
+				txPacket := gw.TXPacket{
+					Token:  uint16(i + 1),
+					TXInfo: gw.TXInfo{MAC: mac},
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{MType: lorawan.JoinAccept, Major: lorawan.LoRaWANR1},
+					},
+				}
+				So(framelog.LogDownlink(p, framelog.NewDownlinkFrame(session.NodeSession{DevEUI: devEUI}, txPacket, false), txPacket), ShouldBeNil)
+				So(recordJoinAccept(p, devEUI), ShouldBeNil)
+				So(HandleTXAck(p, gw.TXAck{MAC: mac, Token: txPacket.Token, Error: ackErr}), ShouldBeNil)
+			}
+
+			Convey("Then the join-accepts are counted", func() {
+				j, err := GetJoinAccepts(p, devEUI)
+				So(err, ShouldBeNil)
+				So(j, ShouldResemble, JoinAccepts{Sent: 2, Acked: 1, Rejected: 1, Error: "COLLISION_PACKET"})
+			})
+
+			Convey("When resetting the join-accepts", func() {
+				So(ResetJoinAccepts(p, devEUI), ShouldBeNil)
+
+				Convey("Then no join-accepts are counted", func() {
+					j, err := GetJoinAccepts(p, devEUI)
+					So(err, ShouldBeNil)
+					So(j, ShouldResemble, JoinAccepts{})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package downlink
+
+import (
+	"github.com/joriwind/loraserver/internal/metrics"
+)
+
+var (
+	decisionCount    = metrics.NewCounter("loraserver_downlink_decisions_total", "Number of downlink opportunities following an uplink, by decision and selected RX window.", "decision", "rx_window")
+	txAckCount       = metrics.NewCounter("loraserver_downlink_tx_acks_total", "Number of TX acknowledgements received from the gateways.", "mac", "result")
+	txRejectionCount = metrics.NewCounter("loraserver_downlink_tx_rejections_total", "Number of downlink frames rejected by the gateways, by reason.", "reason")
+
+	duplicatePayloadCount = metrics.NewCounter("loraserver_downlink_duplicate_payloads_total", "Number of pushed payloads which are a duplicate within the downlink deduplication window, by action.", "action")
+
+	macCommandQueueDepth = metrics.NewHistogram("loraserver_downlink_mac_command_queue_depth", "Number of mac-commands in the queue of a node on a downlink opportunity.", []float64{0, 1, 2, 5, 10, 20, 50})
+)
+
+// rxWindowLabel returns the metrics label of the given RX window.
+func rxWindowLabel(rxWindow int) string {
+	switch rxWindow {
+	case 0:
+		return "rx1"
+	case 1:
+		return "rx2"
+	default:
+		return "unknown"
+	}
+}
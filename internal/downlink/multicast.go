@@ -0,0 +1,197 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/leader"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/multicast"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// RunMulticastScheduler transmits, on the given interval, the next item of
+// the queue of each multicast group. After each transmission, the next
+// item of the group is held back for the off-time required by the
+// duty-cycle of the frequency of the group. When an elector is given, the
+// queues are only handled by the leader. It returns when done is closed.
+func RunMulticastScheduler(ctx common.Context, elector *leader.Elector, interval time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	nextTX := make(map[int64]time.Time)
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+			scheduleMulticastQueueItems(ctx, nextTX, time.Now())
+		case <-done:
+			return
+		}
+	}
+}
+
+// scheduleMulticastQueueItems transmits the next item of the queue of each
+// multicast group of which the off-time (stored in nextTX) has passed.
+func scheduleMulticastQueueItems(ctx common.Context, nextTX map[int64]time.Time, now time.Time) {
+	ids, err := multicast.GetPendingGroupIDs(ctx.RedisPool)
+	if err != nil {
+		log.Errorf("get pending multicast groups error: %s", err)
+		return
+	}
+
+	for _, id := range ids {
+		if now.Before(nextTX[id]) {
+			continue
+		}
+		delete(nextTX, id)
+
+		offTime, err := handleMulticastQueue(ctx, id)
+		if err != nil {
+			log.WithField("multicast_group_id", id).Errorf("handle multicast queue error: %s", err)
+			continue
+		}
+		if offTime > 0 {
+			nextTX[id] = now.Add(offTime)
+		}
+	}
+}
+
+// handleMulticastQueue transmits the next item of the queue of the given
+// multicast group and returns the off-time before the next item may be
+// transmitted. The queue of a deleted group is flushed.
+func handleMulticastQueue(ctx common.Context, id int64) (time.Duration, error) {
+	g, err := multicast.GetGroup(ctx.DB, id)
+	if err != nil {
+		if err == multicast.ErrDoesNotExist {
+			return 0, multicast.FlushQueue(ctx.RedisPool, id)
+		}
+		return 0, errors.Wrap(err, "get multicast group error")
+	}
+
+	item, err := multicast.PopQueueItem(ctx.RedisPool, id)
+	if err != nil || item == nil {
+		return 0, err
+	}
+
+	return sendMulticastQueueItem(ctx, g, *item)
+}
+
+// sendMulticastQueueItem transmits the given item as unconfirmed downlink
+// (using the DevAddr, session keys, frequency and data-rate of the given
+// multicast group) immediately via each gateway of the group. It returns
+// the off-time required by the duty-cycle before the next item may be
+// transmitted. A failure to transmit via one of the gateways is logged.
+func sendMulticastQueueItem(ctx common.Context, g multicast.Group, item multicast.QueueItem) (time.Duration, error) {
+	if g.DR > len(common.Band.DataRates)-1 {
+		return 0, errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", g.DR, len(common.Band.DataRates)-1)
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: g.MCAddr,
+				FCnt:    item.FCnt,
+			},
+			FPort: &item.FPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: item.Data},
+			},
+		},
+	}
+
+	// the encryption is offloaded to LoRa Server
+	if g.MCAppSKey != nil {
+		if err := phy.EncryptFRMPayload(*g.MCAppSKey); err != nil {
+			return 0, errors.Wrap(err, "encrypt FRMPayload error")
+		}
+	}
+	if err := phy.SetMIC(g.MCNwkSKey); err != nil {
+		return 0, errors.Wrap(err, "set MIC error")
+	}
+
+	var sent int
+	var txAirtime time.Duration
+	for _, mac := range g.Gateways {
+		txPacket := gw.TXPacket{
+			TXInfo: gw.TXInfo{
+				MAC:         mac,
+				Immediately: true,
+				Frequency:   g.Frequency,
+				DataRate:    common.Band.DataRates[g.DR],
+			},
+			PHYPayload: phy,
+		}
+
+		if err := sendMulticastTXPacket(ctx, &txPacket); err != nil {
+			log.WithFields(log.Fields{
+				"multicast_group_id": g.ID,
+				"mac":                mac,
+				"fcnt":               item.FCnt,
+			}).Errorf("send multicast tx packet error: %s", err)
+			continue
+		}
+		sent++
+
+		if txAirtime == 0 {
+			var err error
+			if txAirtime, err = airtime.GetTXPacketAirtime(txPacket); err != nil {
+				log.WithField("multicast_group_id", g.ID).Errorf("get multicast airtime error: %s", err)
+			}
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": g.ID,
+		"mc_addr":            g.MCAddr,
+		"fcnt":               item.FCnt,
+		"gateways":           len(g.Gateways),
+		"sent":               sent,
+	}).Info("multicast payload transmitted")
+
+	if dutyCycle := airtime.GetSubBand(g.Frequency).DutyCycle; dutyCycle > 0 {
+		return time.Duration(float64(txAirtime) / dutyCycle), nil
+	}
+	return txAirtime, nil
+}
+
+// sendMulticastTXPacket sets the TX parameters and token of the given
+// multicast packet and sends it to the gateway.
+func sendMulticastTXPacket(ctx common.Context, txPacket *gw.TXPacket) error {
+	if err := setTXParams(ctx, session.NodeSession{}, &txPacket.TXInfo, getBandTXParams(""), models.TXParams{}); err != nil {
+		return errors.Wrap(err, "set tx-params error")
+	}
+
+	token, err := framelog.NewToken(ctx.RedisPool)
+	if err != nil {
+		return err
+	}
+	txPacket.Token = token
+
+	if err := reserveDownlinkSlot(ctx, *txPacket); err != nil {
+		return err
+	}
+
+	if err := ctx.Gateway.SendTXPacket(*txPacket); err != nil {
+		recordTXFailure(ctx, txPacket.TXInfo.MAC)
+		if err := airtime.RecordRejected(ctx.RedisPool, txPacket.TXInfo.MAC, txPacket.TXInfo.Frequency); err != nil {
+			log.WithField("mac", txPacket.TXInfo.MAC).Errorf("record rejected downlink error: %s", err)
+		}
+		return errors.Wrap(err, "send tx packet to gateway error")
+	}
+	return nil
+}
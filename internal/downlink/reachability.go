@@ -0,0 +1,50 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+)
+
+// demoteUnreachableGateways returns the RXInfo set with the RXInfo of the
+// gateways with a reachability score below the configured
+// common.GatewayMinReachabilityScore moved to the end, keeping the order of
+// the set otherwise. These gateways are then only selected for downlink
+// when none of the other gateways can be used. On error, the RXInfo set is
+// returned unchanged.
+func demoteUnreachableGateways(ctx common.Context, rxInfoSet []gw.RXInfo) []gw.RXInfo {
+	if common.GatewayMinReachabilityScore <= 0 || len(rxInfoSet) < 2 {
+		return rxInfoSet
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	scores, err := gateway.GetReachabilityScores(ctx.RedisPool, macs, time.Now())
+	if err != nil {
+		log.Errorf("get gateway reachability scores error: %s", err)
+		return rxInfoSet
+	}
+
+	var reachable, unreachable []gw.RXInfo
+	for _, rxInfo := range rxInfoSet {
+		if score := scores[rxInfo.MAC]; score < common.GatewayMinReachabilityScore {
+			log.WithFields(log.Fields{
+				"mac":   rxInfo.MAC,
+				"score": score,
+			}).Info("demoting gateway with low reachability score for downlink")
+			unreachable = append(unreachable, rxInfo)
+			continue
+		}
+		reachable = append(reachable, rxInfo)
+	}
+
+	return append(reachable, unreachable...)
+}
@@ -0,0 +1,116 @@
+package downlink
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// ReconcilePendingDownlinks reconciles the pending confirmed downlinks left
+// behind by a crashed instance. The pending downlinks of nodes without
+// node-session are removed and the pending Class-C downlinks which are no
+// longer scheduled (claimed by the crashed instance before being handled)
+// are rescheduled. The pending Class-A downlinks are resumed on the next
+// uplink of the node. It returns the number of rescheduled and removed
+// pending downlinks.
+func ReconcilePendingDownlinks(p *redis.Pool) (int, int, error) {
+	var resumed, removed int
+
+	devEUIs, err := scanDevEUIs(p, confirmedDataDownKeyTempl)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, devEUI := range devEUIs {
+		exists, err := session.NodeSessionExists(p, devEUI)
+		if err != nil {
+			return 0, 0, err
+		}
+		if exists {
+			continue
+		}
+		if err := ClearConfirmedDataDown(p, devEUI); err != nil {
+			return 0, 0, err
+		}
+		log.WithField("dev_eui", devEUI).Warning("orphaned pending confirmed downlink removed")
+		removed++
+	}
+
+	devEUIs, err = scanDevEUIs(p, classCRetryKeyTempl)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, devEUI := range devEUIs {
+		exists, err := session.NodeSessionExists(p, devEUI)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !exists {
+			if err := ClearClassCRetry(p, devEUI); err != nil {
+				return 0, 0, err
+			}
+			log.WithField("dev_eui", devEUI).Warning("orphaned pending confirmed class-c downlink removed")
+			removed++
+			continue
+		}
+
+		// the retransmission is scheduled after the ack timeout, in case
+		// it is still being handled by an other instance
+		due := time.Now().Add(common.ClassCConfirmedACKTimeout)
+		added, err := rescheduleClassCRetry(p, devEUI, due)
+		if err != nil {
+			return 0, 0, err
+		}
+		if added {
+			log.WithField("dev_eui", devEUI).Warning("unscheduled pending confirmed class-c downlink rescheduled")
+			resumed++
+		}
+	}
+
+	return resumed, removed, nil
+}
+
+// rescheduleClassCRetry schedules the pending confirmed Class-C downlink of
+// the given node at the given time, unless it is already scheduled. It
+// returns true when it has been scheduled.
+func rescheduleClassCRetry(p *redis.Pool, devEUI lorawan.EUI64, due time.Time) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	added, err := redis.Int(c.Do("ZADD", classCRetriesKey, "NX", due.UnixNano()/int64(time.Millisecond), devEUI.String()))
+	if err != nil {
+		return false, errors.Wrap(err, "reschedule class-c retry error")
+	}
+	return added == 1, nil
+}
+
+// scanDevEUIs returns the DevEUIs of the keys matching the given key
+// template (containing the DevEUI as last placeholder).
+func scanDevEUIs(p *redis.Pool, keyTempl string) ([]lorawan.EUI64, error) {
+	prefix := fmt.Sprintf(keyTempl, "")
+
+	keys, err := common.ScanKeys(p, prefix+"*")
+	if err != nil {
+		return nil, err
+	}
+
+	var out []lorawan.EUI64
+	for _, key := range keys {
+		var devEUI lorawan.EUI64
+		if err := devEUI.UnmarshalText([]byte(strings.TrimPrefix(key, prefix))); err != nil {
+			log.WithField("key", key).Warningf("unmarshal DevEUI error: %s", err)
+			continue
+		}
+		out = append(out, devEUI)
+	}
+
+	return out, nil
+}
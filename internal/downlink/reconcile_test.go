@@ -0,0 +1,93 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestReconcilePendingDownlinks(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		defer func(classCRetries, retries int) {
+			common.ClassCConfirmedRetries = classCRetries
+			common.ConfirmedDownlinkRetries = retries
+		}(common.ClassCConfirmedRetries, common.ConfirmedDownlinkRetries)
+		common.ClassCConfirmedRetries = 3
+		common.ConfirmedDownlinkRetries = 3
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		orphanDevEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		now := time.Now()
+
+		So(session.SaveNodeSession(p, session.NodeSession{DevEUI: devEUI}), ShouldBeNil)
+
+		Convey("Given a claimed (unscheduled) class-c retry and a class-c retry of a removed node", func() {
+			So(scheduleClassCRetry(p, classCRetry{DevEUI: devEUI, FCntDown: 10}, now), ShouldBeNil)
+			So(scheduleClassCRetry(p, classCRetry{DevEUI: orphanDevEUI, FCntDown: 10}, now), ShouldBeNil)
+
+			devEUIs, err := claimDueClassCRetries(p, now.Add(common.ClassCConfirmedACKTimeout))
+			So(err, ShouldBeNil)
+			So(devEUIs, ShouldHaveLength, 2)
+
+			Convey("When reconciling the pending downlinks", func() {
+				resumed, removed, err := ReconcilePendingDownlinks(p)
+				So(err, ShouldBeNil)
+				So(resumed, ShouldEqual, 1)
+				So(removed, ShouldEqual, 1)
+
+				Convey("Then the retry of the removed node has been removed", func() {
+					stored, err := getClassCRetry(p, orphanDevEUI)
+					So(err, ShouldBeNil)
+					So(stored, ShouldBeNil)
+				})
+
+				Convey("Then the retry has been rescheduled after the ack timeout", func() {
+					devEUIs, err := claimDueClassCRetries(p, time.Now())
+					So(err, ShouldBeNil)
+					So(devEUIs, ShouldHaveLength, 0)
+
+					devEUIs, err = claimDueClassCRetries(p, time.Now().Add(common.ClassCConfirmedACKTimeout))
+					So(err, ShouldBeNil)
+					So(devEUIs, ShouldResemble, []lorawan.EUI64{devEUI})
+				})
+
+				Convey("Then reconciling again is a no-op", func() {
+					resumed, removed, err := ReconcilePendingDownlinks(p)
+					So(err, ShouldBeNil)
+					So(resumed, ShouldEqual, 0)
+					So(removed, ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey("Given a pending confirmed downlink of a removed node", func() {
+			So(saveConfirmedDataDown(p, confirmedDataDown{DevEUI: orphanDevEUI, FCntDown: 5}), ShouldBeNil)
+
+			Convey("When reconciling the pending downlinks", func() {
+				resumed, removed, err := ReconcilePendingDownlinks(p)
+				So(err, ShouldBeNil)
+				So(resumed, ShouldEqual, 0)
+				So(removed, ShouldEqual, 1)
+
+				Convey("Then it has been removed", func() {
+					c := p.Get()
+					defer c.Close()
+					n, err := c.Do("EXISTS", "lora:ns:node:confirmed:pending:"+orphanDevEUI.String())
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,177 @@
+package downlink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// rxWindowOutcomesKeyTempl contains per node and RX window the outcomes
+	// of the last confirmed downlinks transmitted in this window (1 =
+	// acknowledged, 0 = not acknowledged, newest first).
+	rxWindowOutcomesKeyTempl = "lora:ns:node:rx_window:outcomes:%s:%d"
+
+	// rxWindowPendingKeyTempl contains per node the RX window of the last
+	// confirmed downlink of which the outcome is not yet known.
+	rxWindowPendingKeyTempl = "lora:ns:node:rx_window:pending:%s"
+
+	// rxWindowSamples defines the number of outcomes kept per node and RX
+	// window.
+	rxWindowSamples = 10
+
+	// rxWindowMinSamples defines the minimum number of outcomes of an RX
+	// window before its success rate is taken into account.
+	rxWindowMinSamples = 3
+
+	// rxWindowMinSuccessRate defines the success rate below which the
+	// RX window of the node-session is no longer preferred.
+	rxWindowMinSuccessRate = 0.5
+
+	// RXWindowLearningTTL defines how long the outcomes of a node are kept
+	// after its last confirmed downlink.
+	RXWindowLearningTTL = time.Hour * 24 * 30
+)
+
+// GetRXWindow returns the RX window to use for the downlink in response to
+// an uplink of the given node. When common.RXWindowLearning is disabled,
+// this is the RXWindow of the node-session, else the preferred RX window
+// learned from the outcomes of the confirmed downlinks of the node (see
+// preferredRXWindow).
+func GetRXWindow(p *redis.Pool, ns session.NodeSession) (session.RXWindow, error) {
+	if !common.RXWindowLearning {
+		return ns.RXWindow, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	windows := []session.RXWindow{session.RX1, session.RX2}
+	for _, w := range windows {
+		c.Send("LRANGE", fmt.Sprintf(rxWindowOutcomesKeyTempl, ns.DevEUI, w), 0, -1)
+	}
+	if err := c.Flush(); err != nil {
+		return ns.RXWindow, errors.Wrap(err, "get rx window outcomes error")
+	}
+
+	outcomes := make(map[session.RXWindow][]bool)
+	for _, w := range windows {
+		values, err := redis.Ints(c.Receive())
+		if err != nil {
+			return ns.RXWindow, errors.Wrap(err, "get rx window outcomes error")
+		}
+		for _, v := range values {
+			outcomes[w] = append(outcomes[w], v == 1)
+		}
+	}
+
+	return preferredRXWindow(ns.RXWindow, outcomes), nil
+}
+
+// preferredRXWindow returns the preferred RX window given the RX window of
+// the node-session and the outcomes per RX window. The RX window of the
+// node-session is preferred, unless the success rate of its outcomes is
+// below rxWindowMinSuccessRate and the other RX window has not been proven
+// to be worse. RX windows with less than rxWindowMinSamples outcomes are
+// considered unknown.
+func preferredRXWindow(rxWindow session.RXWindow, outcomes map[session.RXWindow][]bool) session.RXWindow {
+	other := session.RXWindow(session.RX2)
+	if rxWindow == session.RX2 {
+		other = session.RX1
+	}
+
+	rate, ok := successRate(outcomes[rxWindow])
+	if !ok {
+		return rxWindow
+	}
+
+	if otherRate, ok := successRate(outcomes[other]); ok {
+		if otherRate > rate {
+			return other
+		}
+		return rxWindow
+	}
+
+	if rate < rxWindowMinSuccessRate {
+		return other
+	}
+	return rxWindow
+}
+
+// successRate returns the fraction of acknowledged outcomes. It returns
+// false when there are too few outcomes.
+func successRate(outcomes []bool) (float64, bool) {
+	if len(outcomes) < rxWindowMinSamples {
+		return 0, false
+	}
+
+	var acked int
+	for _, o := range outcomes {
+		if o {
+			acked++
+		}
+	}
+	return float64(acked) / float64(len(outcomes)), true
+}
+
+// SetRXWindowPending stores the RX window of the confirmed downlink sent to
+// the given node, so that its outcome can be recorded on the next uplink.
+func SetRXWindowPending(p *redis.Pool, devEUI lorawan.EUI64, rxWindow session.RXWindow) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(rxWindowPendingKeyTempl, devEUI), int64(RXWindowLearningTTL/time.Millisecond), int(rxWindow))
+	if err != nil {
+		return errors.Wrap(err, "set pending rx window error")
+	}
+	return nil
+}
+
+// RecordRXWindowOutcome records the outcome of the pending confirmed
+// downlink (if any) of the given node. The confirmed downlink was
+// acknowledged when the ACK bit of the next uplink is set.
+func RecordRXWindowOutcome(p *redis.Pool, devEUI lorawan.EUI64, acked bool) error {
+	c := p.Get()
+	defer c.Close()
+
+	pendingKey := fmt.Sprintf(rxWindowPendingKeyTempl, devEUI)
+
+	c.Send("MULTI")
+	c.Send("GET", pendingKey)
+	c.Send("DEL", pendingKey)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return errors.Wrap(err, "get pending rx window error")
+	}
+
+	rxWindow, err := redis.Int(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			// no confirmed downlink pending
+			return nil
+		}
+		return errors.Wrap(err, "get pending rx window error")
+	}
+
+	var outcome int
+	if acked {
+		outcome = 1
+	}
+
+	key := fmt.Sprintf(rxWindowOutcomesKeyTempl, devEUI, rxWindow)
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, outcome)
+	c.Send("LTRIM", key, 0, rxWindowSamples-1)
+	c.Send("PEXPIRE", key, int64(RXWindowLearningTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record rx window outcome error")
+	}
+	return nil
+}
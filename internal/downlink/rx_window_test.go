@@ -0,0 +1,115 @@
+package downlink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestPreferredRXWindow(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		acked := []bool{true, true, true}
+		lost := []bool{false, false, false}
+		mixed := []bool{true, false, false, true, true}
+
+		tests := []struct {
+			Name     string
+			RXWindow session.RXWindow
+			Outcomes map[session.RXWindow][]bool
+			Expected session.RXWindow
+		}{
+			{"no outcomes", session.RX1, nil, session.RX1},
+			{"too few outcomes", session.RX1, map[session.RXWindow][]bool{session.RX1: {false, false}}, session.RX1},
+			{"reliable rx window", session.RX1, map[session.RXWindow][]bool{session.RX1: acked}, session.RX1},
+			{"reliable enough rx window", session.RX1, map[session.RXWindow][]bool{session.RX1: mixed}, session.RX1},
+			{"unreliable rx window, other rx window unknown", session.RX1, map[session.RXWindow][]bool{session.RX1: lost}, session.RX2},
+			{"unreliable rx window (RX2), other rx window unknown", session.RX2, map[session.RXWindow][]bool{session.RX2: lost}, session.RX1},
+			{"unreliable rx window, other rx window better", session.RX1, map[session.RXWindow][]bool{session.RX1: lost, session.RX2: mixed}, session.RX2},
+			{"unreliable rx window, other rx window worse", session.RX1, map[session.RXWindow][]bool{session.RX1: {false, false, true}, session.RX2: lost}, session.RX1},
+			{"unreliable rx window, other rx window equal", session.RX1, map[session.RXWindow][]bool{session.RX1: lost, session.RX2: lost}, session.RX1},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(preferredRXWindow(test.RXWindow, test.Outcomes), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestRXWindowLearning(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and RX window learning enabled", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.RXWindowLearning = true
+		defer func() {
+			common.RXWindowLearning = false
+		}()
+
+		ns := session.NodeSession{
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			RXWindow: session.RX1,
+		}
+
+		Convey("Then the RX window of the node-session is used", func() {
+			rxWindow, err := GetRXWindow(p, ns)
+			So(err, ShouldBeNil)
+			So(rxWindow, ShouldEqual, session.RX1)
+		})
+
+		Convey("When recording an outcome without pending confirmed downlink", func() {
+			So(RecordRXWindowOutcome(p, ns.DevEUI, false), ShouldBeNil)
+
+			Convey("Then no outcome has been recorded", func() {
+				c := p.Get()
+				defer c.Close()
+				n, err := redis.Int(c.Do("LLEN", fmt.Sprintf(rxWindowOutcomesKeyTempl, ns.DevEUI, session.RX1)))
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When three confirmed downlinks in RX1 are not acknowledged", func() {
+			for i := 0; i < 3; i++ {
+				So(SetRXWindowPending(p, ns.DevEUI, session.RX1), ShouldBeNil)
+				So(RecordRXWindowOutcome(p, ns.DevEUI, false), ShouldBeNil)
+			}
+
+			Convey("Then RX2 is used", func() {
+				rxWindow, err := GetRXWindow(p, ns)
+				So(err, ShouldBeNil)
+				So(rxWindow, ShouldEqual, session.RX2)
+			})
+
+			Convey("When three confirmed downlinks in RX2 are acknowledged", func() {
+				for i := 0; i < 3; i++ {
+					So(SetRXWindowPending(p, ns.DevEUI, session.RX2), ShouldBeNil)
+					So(RecordRXWindowOutcome(p, ns.DevEUI, true), ShouldBeNil)
+				}
+
+				Convey("Then RX2 is used", func() {
+					rxWindow, err := GetRXWindow(p, ns)
+					So(err, ShouldBeNil)
+					So(rxWindow, ShouldEqual, session.RX2)
+				})
+			})
+
+			Convey("Then the RX window of the node-session is used when RX window learning is disabled", func() {
+				common.RXWindowLearning = false
+				rxWindow, err := GetRXWindow(p, ns)
+				So(err, ShouldBeNil)
+				So(rxWindow, ShouldEqual, session.RX1)
+			})
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// TestFramePayload contains the payload of the proprietary test frame, so
+// that it can be recognized by a field engineer (e.g. using a sniffer).
+var TestFramePayload = []byte("LORASERVER-TEST")
+
+// DefaultTestFrameTimeout defines how long is waited by default for the
+// TX acknowledgement of a test frame.
+const DefaultTestFrameTimeout = 5 * time.Second
+
+// testFramePollInterval defines the interval on which the frame log entry
+// of a test frame is checked for the TX acknowledgement.
+const testFramePollInterval = 100 * time.Millisecond
+
+// SendGatewayTestFrame transmits the proprietary test frame immediately via
+// the given gateway, on the given frequency (Hz) and data-rate, using the
+// given TX power (0 = the TX power of the band and gateway). It then waits
+// for the TX acknowledgement of the gateway, at most for the given timeout
+// (DefaultTestFrameTimeout when 0) and within the request deadline. The
+// frame log entry of the test frame is returned, AckedAt is zero when the
+// gateway did not acknowledge the frame in time.
+func SendGatewayTestFrame(ctx common.Context, mac lorawan.EUI64, frequency, dr, power int, timeout time.Duration) (framelog.DownlinkFrame, error) {
+	if common.ReadOnlyMode {
+		return framelog.DownlinkFrame{}, ErrReadOnlyMode
+	}
+	if dr < 0 || dr >= len(common.Band.DataRates) {
+		return framelog.DownlinkFrame{}, errors.Wrapf(ErrInvalidDataRate, "dr: %d", dr)
+	}
+	if timeout == 0 {
+		timeout = DefaultTestFrameTimeout
+	}
+
+	if _, err := gateway.GetGateway(ctx.DB, mac); err != nil {
+		return framelog.DownlinkFrame{}, err
+	}
+
+	txPacket := gw.TXPacket{
+		TXInfo: gw.TXInfo{
+			MAC:         mac,
+			Immediately: true,
+			Frequency:   frequency,
+			DataRate:    common.Band.DataRates[dr],
+		},
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.Proprietary,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.DataPayload{Bytes: TestFramePayload},
+		},
+	}
+	if err := setTXParams(ctx, session.NodeSession{}, &txPacket.TXInfo, getBandTXParams(""), models.TXParams{Power: power}); err != nil {
+		return framelog.DownlinkFrame{}, errors.Wrap(err, "set tx-params error")
+	}
+
+	token, err := framelog.NewToken(ctx.RedisPool)
+	if err != nil {
+		return framelog.DownlinkFrame{}, err
+	}
+	txPacket.Token = token
+
+	f := framelog.DownlinkFrame{
+		Token:  token,
+		MAC:    mac,
+		SentAt: time.Now(),
+	}
+	if err := framelog.SaveDownlinkFrame(ctx.RedisPool, f); err != nil {
+		return framelog.DownlinkFrame{}, err
+	}
+
+	if err := ctx.Gateway.SendTXPacket(txPacket); err != nil {
+		return framelog.DownlinkFrame{}, errors.Wrap(ErrPublishTXPacket, err.Error())
+	}
+
+	log.WithFields(log.Fields{
+		"mac":       mac,
+		"token":     token,
+		"frequency": frequency,
+		"dr":        dr,
+		"tx_power":  txPacket.TXInfo.Power,
+	}).Info("gateway test frame sent")
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.RequestContext().Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	for {
+		f, err := framelog.GetDownlinkFrame(ctx.RedisPool, token)
+		if err != nil {
+			return f, err
+		}
+		if !f.AckedAt.IsZero() || time.Now().Add(testFramePollInterval).After(deadline) {
+			return f, nil
+		}
+		time.Sleep(testFramePollInterval)
+	}
+}
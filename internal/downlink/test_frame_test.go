@@ -0,0 +1,84 @@
+package downlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestSendGatewayTestFrame(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database with a gateway", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		gwBackend := test.NewGatewayBackend()
+		ctx := common.Context{DB: db, RedisPool: p, Gateway: gwBackend}
+
+		gw1 := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Name: "gw1",
+		}
+		So(gateway.CreateGateway(db, &gw1), ShouldBeNil)
+
+		Convey("Then an unknown gateway returns an error", func() {
+			_, err := SendGatewayTestFrame(ctx, lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, 868100000, 5, 0, time.Second)
+			So(err, ShouldEqual, gateway.ErrDoesNotExist)
+		})
+
+		Convey("When the gateway rejects the test frame", func() {
+			ackErr := make(chan error, 1)
+			go func() {
+				txPacket := <-gwBackend.TXPacketChan
+				ackErr <- HandleTXAck(p, gw.TXAck{
+					MAC:   txPacket.TXInfo.MAC,
+					Token: txPacket.Token,
+					Error: "COLLISION_PACKET",
+				})
+			}()
+
+			f, err := SendGatewayTestFrame(ctx, gw1.MAC, 868100000, 5, 0, time.Second)
+			So(err, ShouldBeNil)
+			So(<-ackErr, ShouldBeNil)
+
+			Convey("Then the rejection is returned", func() {
+				So(f.MAC, ShouldEqual, gw1.MAC)
+				So(f.AckedAt.IsZero(), ShouldBeFalse)
+				So(f.ErrorCode, ShouldEqual, framelog.TXErrorCollision)
+			})
+		})
+
+		Convey("When the gateway does not acknowledge the test frame", func() {
+			f, err := SendGatewayTestFrame(ctx, gw1.MAC, 868100000, 5, 14, 200*time.Millisecond)
+			So(err, ShouldBeNil)
+
+			Convey("Then the proprietary test frame was sent immediately", func() {
+				txPacket := <-gwBackend.TXPacketChan
+				So(txPacket.Token, ShouldEqual, f.Token)
+				So(txPacket.TXInfo.Immediately, ShouldBeTrue)
+				So(txPacket.TXInfo.Frequency, ShouldEqual, 868100000)
+				So(txPacket.TXInfo.DataRate, ShouldResemble, common.Band.DataRates[5])
+				So(txPacket.TXInfo.Power, ShouldEqual, 14)
+				So(txPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.Proprietary)
+				So(txPacket.PHYPayload.MACPayload, ShouldResemble, &lorawan.DataPayload{Bytes: TestFramePayload})
+			})
+
+			Convey("Then the frame is returned without acknowledgement", func() {
+				So(f.AckedAt.IsZero(), ShouldBeTrue)
+			})
+		})
+	})
+}
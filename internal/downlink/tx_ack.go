@@ -0,0 +1,88 @@
+package downlink
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/digest"
+	"github.com/joriwind/loraserver/internal/framelog"
+)
+
+// HandleTXAck updates the downlink frame entry of the acknowledged
+// TXPacket (see framelog.AckDownlinkFrame).
+func HandleTXAck(p *redis.Pool, ack gw.TXAck) error {
+	f, err := framelog.AckDownlinkFrame(p, ack)
+	if err != nil {
+		return err
+	}
+
+	logFields := log.Fields{
+		"dev_eui": f.DevEUI,
+		"mac":     f.MAC,
+		"token":   f.Token,
+		"fcnt":    f.FCnt,
+		"attempt": f.Attempt,
+	}
+	if f.Error != "" {
+		txRejectionCount.Inc(f.ErrorCode)
+		logFields["error"] = f.Error
+		logFields["error_code"] = f.ErrorCode
+		log.WithFields(logFields).Warning("downlink rejected by gateway")
+
+		if f.ErrorCode == framelog.TXErrorDutyCycleExceeded {
+			if err := digest.Record(p, digest.DutyCycleSaturation, f.MAC.String()); err != nil {
+				log.WithField("mac", f.MAC).Errorf("record digest event error: %s", err)
+			}
+		}
+	} else {
+		log.WithFields(logFields).Info("downlink acknowledged by gateway")
+	}
+
+	if f.JoinAccept {
+		if err := recordJoinAcceptTXAck(p, f.DevEUI, f.Error); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleTXAcks consumes the tx acknowledgements received from the gateways
+// in a separate go-routine. Rejected frames are retried via the next-best
+// gateway (when available) and update the failure score of the gateway
+// (see excludeFailingGateways). Errors are logged.
+func HandleTXAcks(wg *sync.WaitGroup, ctx common.Context) {
+	for ack := range ctx.Gateway.TXAckChan() {
+		wg.Add(1)
+		go func(ack gw.TXAck) {
+			defer wg.Done()
+			if ack.Error != "" {
+				txAckCount.Inc(ack.MAC.String(), "nack")
+				recordTXFailure(ctx, ack.MAC)
+			} else {
+				txAckCount.Inc(ack.MAC.String(), "ok")
+				recordTXSuccess(ctx, ack.MAC)
+			}
+
+			if err := HandleTXAck(ctx.RedisPool, ack); err != nil {
+				log.WithFields(log.Fields{
+					"mac":   ack.MAC,
+					"token": ack.Token,
+				}).Errorf("handle tx ack error: %s", err)
+				return
+			}
+			if ack.Error != "" {
+				if err := sendFallbackTXPacket(ctx, ack.Token); err != nil {
+					log.WithFields(log.Fields{
+						"mac":   ack.MAC,
+						"token": ack.Token,
+					}).Errorf("send fallback tx packet error: %s", err)
+				}
+			}
+		}(ack)
+	}
+}
@@ -0,0 +1,72 @@
+package downlink
+
+import (
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+)
+
+// excludeFailingGateways returns the RXInfo set without the RXInfo of the
+// gateways of which the failure score reached the configured
+// common.GatewayTXFailureThreshold, so that these gateways are not selected
+// for downlink (nor as fallback or diversity gateway). When all the
+// gateways are failing, the RXInfo set is returned unchanged so that the
+// downlink is still attempted. On error, the RXInfo set is returned
+// unchanged.
+func excludeFailingGateways(ctx common.Context, rxInfoSet []gw.RXInfo) []gw.RXInfo {
+	if common.GatewayTXFailureThreshold <= 0 || len(rxInfoSet) < 2 {
+		return rxInfoSet
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	failing, err := gateway.GetFailingGateways(ctx.RedisPool, macs, common.GatewayTXFailureThreshold)
+	if err != nil {
+		log.Errorf("get failing gateways error: %s", err)
+		return rxInfoSet
+	}
+
+	var out []gw.RXInfo
+	for _, rxInfo := range rxInfoSet {
+		if _, ok := failing[rxInfo.MAC]; ok {
+			log.WithField("mac", rxInfo.MAC).Info("excluding gateway with recent tx failures for downlink")
+			continue
+		}
+		out = append(out, rxInfo)
+	}
+
+	if len(out) == 0 {
+		return rxInfoSet
+	}
+	return out
+}
+
+// recordTXFailure increments the failure score of the given gateway (see
+// common.GatewayTXFailureThreshold). Errors are logged.
+func recordTXFailure(ctx common.Context, mac lorawan.EUI64) {
+	if common.GatewayTXFailureThreshold <= 0 {
+		return
+	}
+
+	if err := gateway.RecordTXFailure(ctx.RedisPool, mac, common.GatewayTXFailureWindow); err != nil {
+		log.WithField("mac", mac).Errorf("record tx failure error: %s", err)
+	}
+}
+
+// recordTXSuccess resets the failure score of the given gateway, so that
+// it is selected again for downlink. Errors are logged.
+func recordTXSuccess(ctx common.Context, mac lorawan.EUI64) {
+	if common.GatewayTXFailureThreshold <= 0 {
+		return
+	}
+
+	if err := gateway.ResetTXFailures(ctx.RedisPool, mac); err != nil {
+		log.WithField("mac", mac).Errorf("reset tx failures error: %s", err)
+	}
+}
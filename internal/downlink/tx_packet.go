@@ -0,0 +1,80 @@
+package downlink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/airtime"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/sla"
+)
+
+// sendTXPacket sends the given packet to the gateway and records the
+// downlink airtime (or the rejection) for the given node. Each packet gets
+// a new token and is added to the frame log, together with a snapshot of
+// the given node-session (diversity must be set for
+// the second transmission of a frame in case of transmit diversity) and
+// the given (optional) fallback TXInfo, used when the gateway rejects the
+// packet. When the deadline of the request context has been exceeded, the
+// packet is not sent as it would arrive too late.
+func sendTXPacket(ctx common.Context, ns session.NodeSession, txPacket gw.TXPacket, diversity bool, fallback []gw.TXInfo) error {
+	devEUI := ns.DevEUI
+
+	timing := sla.FromContext(ctx)
+
+	if err := ctx.RequestContext().Err(); err != nil {
+		if !diversity {
+			timing.SetDropped(time.Now())
+		}
+		return errors.Wrap(ErrDeadlineExceeded, err.Error())
+	}
+
+	token, err := framelog.NewToken(ctx.RedisPool)
+	if err != nil {
+		return err
+	}
+	txPacket.Token = token
+
+	if err := reserveDownlinkSlot(ctx, txPacket); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err := ctx.Gateway.SendTXPacket(txPacket); err != nil {
+		recordTXFailure(ctx, txPacket.TXInfo.MAC)
+		if err := airtime.RecordRejected(ctx.RedisPool, txPacket.TXInfo.MAC, txPacket.TXInfo.Frequency); err != nil {
+			log.WithField("dev_eui", devEUI).Errorf("record rejected downlink error: %s", err)
+		}
+		return errors.Wrap(ErrPublishTXPacket, err.Error())
+	}
+	timing.Observe(sla.StageGateway, time.Since(start))
+	timing.SetPublished(time.Now())
+
+	if err := airtime.RecordDownlink(ctx.RedisPool, devEUI, txPacket); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("record downlink airtime error: %s", err)
+	}
+
+	f := framelog.NewDownlinkFrame(ns, txPacket, diversity)
+	if len(fallback) > 0 {
+		if err := setFrameFallback(ctx, &f, txPacket, fallback); err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"token":   token,
+			}).Errorf("set frame fallback error: %s", err)
+		}
+	}
+
+	if err := framelog.LogDownlink(ctx.RedisPool, f, txPacket); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"token":   token,
+		}).Errorf("log downlink frame error: %s", err)
+	}
+
+	return nil
+}
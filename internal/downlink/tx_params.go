@@ -0,0 +1,91 @@
+package downlink
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan/band"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// defaultCodeRate defines the code rate used when no code rate is set on
+// any level.
+const defaultCodeRate = "4/5"
+
+// getBandTXParams returns the band default TX parameters. When set, the
+// given uplink code rate is used as code rate.
+func getBandTXParams(uplinkCodeRate string) models.TXParams {
+	params := models.TXParams{
+		Power:    common.Band.DefaultTXPower,
+		CodeRate: defaultCodeRate,
+	}
+	return params.Override(models.TXParams{CodeRate: uplinkCodeRate})
+}
+
+// setTXParams sets the TX power, code rate and polarity of the given
+// TXInfo. Each level overrides the parameters set by the previous level,
+// in the following order: the given band defaults, the gateway, the
+// node-session and the given per-push parameters. The resulting TX power
+// is limited to the max downlink TX power for the frequency of the TXInfo,
+// which therefore must be set before calling this function.
+func setTXParams(ctx common.Context, ns session.NodeSession, txInfo *gw.TXInfo, bandParams, pushParams models.TXParams) error {
+	var gwParams models.TXParams
+	g, err := gateway.GetGateway(ctx.DB, txInfo.MAC)
+	if err == nil {
+		gwParams = g.TXParams()
+	} else if err != gateway.ErrDoesNotExist {
+		return errors.Wrap(err, "get gateway error")
+	}
+
+	params := bandParams.
+		Override(gwParams).
+		Override(ns.DownlinkTXParams).
+		Override(pushParams)
+
+	txInfo.Power = limitTXPower(txInfo.Frequency, params.Power)
+	txInfo.CodeRate = params.CodeRate
+	txInfo.IPol = params.IPol
+
+	return nil
+}
+
+// limitTXPower returns the given TX power, limited to the max downlink TX
+// power for the given frequency.
+func limitTXPower(frequency, power int) int {
+	max := getMaxDownlinkTXPower(frequency)
+	if power > max {
+		log.WithFields(log.Fields{
+			"frequency":    frequency,
+			"tx_power":     power,
+			"max_tx_power": max,
+		}).Warning("downlink tx power exceeds regional limit, using max tx power")
+		return max
+	}
+	return power
+}
+
+// getMaxDownlinkTXPower returns the max downlink TX power (dBm) allowed by
+// the regional regulations for the given frequency. For bands without known
+// limit, the default TX power of the band is returned.
+func getMaxDownlinkTXPower(frequency int) int {
+	switch common.BandName {
+	case band.EU_863_870:
+		// the 869.4 - 869.65 MHz sub-band (used for RX2) allows 27 dBm
+		if frequency >= 869400000 && frequency < 869650000 {
+			return 27
+		}
+		return 16
+	case band.US_902_928, band.AU_915_928:
+		return 30
+	case band.AS_923:
+		return 16
+	case band.KR_920_923:
+		return 23
+	default:
+		return common.Band.DefaultTXPower
+	}
+}
@@ -0,0 +1,99 @@
+// Package fcntrollover implements the detection of nodes of which the
+// downlink frame-counter approaches the 16-bit boundary. Although the
+// frame-counters are 32-bit, only the 16 least-significant bits are
+// transmitted and some LoRaWAN 1.0 stacks fail to reconstruct the full
+// frame-counter after the 16-bit rollover, resulting in a node which no
+// longer accepts any downlink. Flagged nodes can be made to rejoin (and
+// thus reset their frame-counters) before the rollover.
+package fcntrollover
+
+import (
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// rolloverKey contains the flagged nodes (sorted set, scored by the
+// detection timestamp).
+const rolloverKey = "lora:ns:fcntdown:rollover"
+
+// fCnt16Mask masks the 16 least-significant bits of a frame-counter (as
+// transmitted in the frame header).
+const fCnt16Mask = 0xffff
+
+// Node contains a node flagged with a downlink frame-counter approaching
+// the 16-bit boundary.
+type Node struct {
+	DevEUI     lorawan.EUI64
+	DetectedAt time.Time
+}
+
+// IsApproaching returns true when the 16 least-significant bits of the
+// given downlink frame-counter are equal to or above the given threshold
+// (0 = disabled).
+func IsApproaching(fCntDown, threshold uint32) bool {
+	return threshold > 0 && fCntDown&fCnt16Mask >= threshold
+}
+
+// Check flags or clears (e.g. after the rollover or a rejoin) the given
+// node, depending on its downlink frame-counter and the given threshold.
+// It returns true when the node is flagged for the first time.
+func Check(p *redis.Pool, ns session.NodeSession, threshold uint32) (bool, error) {
+	if !IsApproaching(ns.FCntDown, threshold) {
+		return false, Clear(p, ns.DevEUI)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	added, err := redis.Int(c.Do("ZADD", rolloverKey, "NX", time.Now().Unix(), hex.EncodeToString(ns.DevEUI[:])))
+	if err != nil {
+		return false, errors.Wrap(err, "flag fcnt down rollover error")
+	}
+	return added == 1, nil
+}
+
+// Clear removes the flag of the given node.
+func Clear(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("ZREM", rolloverKey, hex.EncodeToString(devEUI[:])); err != nil {
+		return errors.Wrap(err, "clear fcnt down rollover error")
+	}
+	return nil
+}
+
+// List returns the flagged nodes, most recently detected first.
+func List(p *redis.Pool) ([]Node, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("ZREVRANGE", rolloverKey, 0, -1, "WITHSCORES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get fcnt down rollover nodes error")
+	}
+
+	var out []Node
+	for i := 0; i+1 < len(values); i += 2 {
+		var n Node
+		if err := n.DevEUI.UnmarshalText([]byte(values[i])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal deveui error")
+		}
+		ts, err := strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse detection timestamp error")
+		}
+		n.DetectedAt = time.Unix(ts, 0)
+		out = append(out, n)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package fcntrollover
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestIsApproaching(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			FCntDown  uint32
+			Threshold uint32
+			Expected  bool
+		}{
+			{65000, 0, false},
+			{100, 65000, false},
+			{64999, 65000, false},
+			{65000, 65000, true},
+			{65535, 65000, true},
+			{65536, 65000, false},
+			{131000, 65000, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: fcnt %d, threshold %d [%d]", test.FCntDown, test.Threshold, i), func() {
+				So(IsApproaching(test.FCntDown, test.Threshold), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a node-session", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCntDown: 64000,
+		}
+
+		Convey("When the fcnt down is below the threshold", func() {
+			detected, err := Check(p, ns, 65000)
+			So(err, ShouldBeNil)
+
+			Convey("Then the node is not flagged", func() {
+				So(detected, ShouldBeFalse)
+				nodes, err := List(p)
+				So(err, ShouldBeNil)
+				So(nodes, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the fcnt down reaches the threshold", func() {
+			ns.FCntDown = 65000
+			detected, err := Check(p, ns, 65000)
+			So(err, ShouldBeNil)
+
+			Convey("Then the node is flagged", func() {
+				So(detected, ShouldBeTrue)
+				nodes, err := List(p)
+				So(err, ShouldBeNil)
+				So(nodes, ShouldHaveLength, 1)
+				So(nodes[0].DevEUI, ShouldEqual, ns.DevEUI)
+			})
+
+			Convey("Then a next check does not flag the node again", func() {
+				ns.FCntDown++
+				detected, err := Check(p, ns, 65000)
+				So(err, ShouldBeNil)
+				So(detected, ShouldBeFalse)
+			})
+
+			Convey("When the fcnt down rolled over", func() {
+				ns.FCntDown = 65536
+				detected, err := Check(p, ns, 65000)
+				So(err, ShouldBeNil)
+				So(detected, ShouldBeFalse)
+
+				Convey("Then the flag is cleared", func() {
+					nodes, err := List(p)
+					So(err, ShouldBeNil)
+					So(nodes, ShouldHaveLength, 0)
+				})
+			})
+		})
+	})
+}
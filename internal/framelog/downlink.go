@@ -0,0 +1,342 @@
+package framelog
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+const (
+	// downlinkTokenKey contains the counter used for generating the
+	// downlink tokens.
+	downlinkTokenKey = "lora:ns:downlink:token"
+
+	// downlinkFrameKeyTempl contains per token the downlink frame entry.
+	downlinkFrameKeyTempl = "lora:ns:downlink:frame:%d"
+
+	// downlinkAttemptsKeyTempl contains per node and frame-counter the list
+	// of tokens of the (re)transmissions of the frame (oldest first).
+	downlinkAttemptsKeyTempl = "lora:ns:downlink:attempts:%s:%d"
+)
+
+// DownlinkFrameTTL defines how long a downlink frame entry is kept. As the
+// tokens wrap after 65536 downlinks, it must be short enough to avoid
+// re-using the token of an entry which has not yet expired.
+const DownlinkFrameTTL = time.Hour
+
+// ErrDownlinkFrameDoesNotExist is returned when the downlink frame entry of
+// a token does not exist (e.g. expired).
+var ErrDownlinkFrameDoesNotExist = errors.New("downlink frame does not exist")
+
+// Possible reasons of the rejection of a frame by the gateway.
+const (
+	TXErrorTooLate           = "TOO_LATE"
+	TXErrorTooEarly          = "TOO_EARLY"
+	TXErrorCollision         = "COLLISION"
+	TXErrorFrequency         = "TX_FREQ"
+	TXErrorPower             = "TX_POWER"
+	TXErrorGPSUnlocked       = "GPS_UNLOCKED"
+	TXErrorDutyCycleExceeded = "DUTY_CYCLE_EXCEEDED"
+	TXErrorOther             = "OTHER"
+)
+
+// DownlinkFrame contains the entry of a single transmission of a downlink
+// frame, identified by the token of the TXPacket. Next to the frames of
+// the node (see LogDownlinkForDevice), it is used for correlating the TX
+// acknowledgement of the gateway with the transmission.
+type DownlinkFrame struct {
+	Token   uint16
+	DevEUI  lorawan.EUI64
+	MAC     lorawan.EUI64
+	FCnt    uint32 // frame-counter of the frame (0 for join-accepts)
+	Attempt int    // transmission attempt of the frame-counter (1 = first transmission)
+	SentAt  time.Time
+
+	// Diversity is set when the frame is the second transmission of the
+	// frame via an other gateway (transmit diversity).
+	Diversity bool
+
+	// JoinAccept is set when the frame is a join-accept.
+	JoinAccept bool
+
+	// Set when the TXAck of the gateway has been received.
+	AckedAt time.Time
+	Error   string // the reason of the rejection by the gateway
+
+	// ErrorCode contains the classification of Error (one of the TXError...
+	// constants, empty when not rejected).
+	ErrorCode string
+
+	// Session contains the node-session state at the time the frame was
+	// sent (zero for entries logged by older versions).
+	Session SessionSnapshot
+
+	// Fallback contains the TXInfo for transmitting the frame via the
+	// next-best gateways (best first), used when the gateway rejects the
+	// frame. PHYPayload and FallbackDeadline are only set when Fallback is
+	// not empty. The frame is not retried after the FallbackDeadline (zero
+	// for frames which are transmitted immediately).
+	Fallback         []gw.TXInfo
+	FallbackDeadline time.Time
+	PHYPayload       []byte
+}
+
+// SessionSnapshot contains the node-session state relevant for debugging
+// the (re)transmissions of a frame.
+type SessionSnapshot struct {
+	FCntUp   uint32 // next expected uplink frame-counter
+	FCntDown uint32
+
+	// DR contains the data-rate of the last uplink (-1 when unknown).
+	DR          int
+	TXPower     int
+	NbTrans     uint8
+	RXWindow    session.RXWindow
+	RXDelay     uint8
+	RX1DROffset uint8
+	RX2DR       uint8
+
+	// RX2Frequency contains the RX2 frequency (Hz) of the node.
+	RX2Frequency int
+
+	// CFList contains the extra uplink channels (frequencies) of the node,
+	// empty when the node only uses the default channels of the band.
+	CFList []uint32
+}
+
+// NewSessionSnapshot returns the snapshot of the given node-session.
+func NewSessionSnapshot(ns session.NodeSession) SessionSnapshot {
+	s := SessionSnapshot{
+		FCntUp:       ns.FCntUp,
+		FCntDown:     ns.FCntDown,
+		DR:           -1,
+		TXPower:      ns.TXPower,
+		NbTrans:      ns.NbTrans,
+		RXWindow:     ns.RXWindow,
+		RXDelay:      ns.RXDelay,
+		RX1DROffset:  ns.RX1DROffset,
+		RX2DR:        ns.RX2DR,
+		RX2Frequency: ns.GetRX2Frequency(),
+	}
+
+	if len(ns.LastRXInfoSet) > 0 {
+		if dr, err := common.GetDataRate(ns.LastRXInfoSet[0].DataRate); err == nil {
+			s.DR = dr
+		}
+	}
+
+	if ns.CFList != nil {
+		for _, f := range ns.CFList {
+			if f != 0 {
+				s.CFList = append(s.CFList, f)
+			}
+		}
+	}
+
+	return s
+}
+
+// NewToken returns a new downlink token. Tokens are generated using a
+// shared counter, so that they are unique across LoRa Server instances.
+func NewToken(p *redis.Pool) (uint16, error) {
+	c := p.Get()
+	defer c.Close()
+
+	i, err := redis.Int64(c.Do("INCR", downlinkTokenKey))
+	if err != nil {
+		return 0, errors.Wrap(err, "increment downlink token error")
+	}
+	return uint16(i), nil
+}
+
+// NewDownlinkFrame returns the downlink frame entry of the given TXPacket,
+// together with a snapshot of the given node-session.
+func NewDownlinkFrame(ns session.NodeSession, txPacket gw.TXPacket, diversity bool) DownlinkFrame {
+	f := DownlinkFrame{
+		Token:      txPacket.Token,
+		DevEUI:     ns.DevEUI,
+		MAC:        txPacket.TXInfo.MAC,
+		Attempt:    1,
+		SentAt:     time.Now(),
+		Diversity:  diversity,
+		JoinAccept: txPacket.PHYPayload.MHDR.MType == lorawan.JoinAccept,
+		Session:    NewSessionSnapshot(ns),
+	}
+	if macPL, ok := txPacket.PHYPayload.MACPayload.(*lorawan.MACPayload); ok {
+		f.FCnt = macPL.FHDR.FCnt
+	}
+	return f
+}
+
+// LogDownlink logs the given (sent) TXPacket of a node: the given downlink
+// frame entry is stored by its token and the frame is added to the frame
+// logs of the node. For data downlinks, the token is added to the
+// transmissions of the frame-counter, so that retransmissions of the same
+// frame can be traced.
+func LogDownlink(p *redis.Pool, f DownlinkFrame, txPacket gw.TXPacket) error {
+	if err := logDownlinkFrame(p, f); err != nil {
+		return err
+	}
+	return LogDownlinkForDevice(p, f.DevEUI, txPacket)
+}
+
+// logDownlinkFrame stores the given downlink frame entry, see LogDownlink.
+func logDownlinkFrame(p *redis.Pool, f DownlinkFrame) error {
+	c := p.Get()
+	defer c.Close()
+
+	if !f.JoinAccept {
+		key := fmt.Sprintf(downlinkAttemptsKeyTempl, f.DevEUI, f.FCnt)
+
+		c.Send("MULTI")
+		c.Send("RPUSH", key, f.Token)
+		c.Send("PEXPIRE", key, int64(DownlinkFrameTTL/time.Millisecond))
+		values, err := redis.Values(c.Do("EXEC"))
+		if err != nil {
+			return errors.Wrap(err, "add downlink attempt error")
+		}
+		if f.Attempt, err = redis.Int(values[0], nil); err != nil {
+			return errors.Wrap(err, "get downlink attempt error")
+		}
+	}
+
+	return saveDownlinkFrame(c, f)
+}
+
+// SaveDownlinkFrame stores the given downlink frame entry, without adding
+// it to the frame logs of a node (e.g. for a gateway test frame).
+func SaveDownlinkFrame(p *redis.Pool, f DownlinkFrame) error {
+	c := p.Get()
+	defer c.Close()
+	return saveDownlinkFrame(c, f)
+}
+
+func saveDownlinkFrame(c redis.Conn, f DownlinkFrame) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(f); err != nil {
+		return errors.Wrap(err, "gob encode frame error")
+	}
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(downlinkFrameKeyTempl, f.Token), int64(DownlinkFrameTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save frame error")
+	}
+	return nil
+}
+
+// GetDownlinkFrame returns the downlink frame entry of the given token.
+func GetDownlinkFrame(p *redis.Pool, token uint16) (DownlinkFrame, error) {
+	c := p.Get()
+	defer c.Close()
+	return getDownlinkFrame(c, token)
+}
+
+func getDownlinkFrame(c redis.Conn, token uint16) (DownlinkFrame, error) {
+	var f DownlinkFrame
+
+	b, err := redis.Bytes(c.Do("GET", fmt.Sprintf(downlinkFrameKeyTempl, token)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return f, ErrDownlinkFrameDoesNotExist
+		}
+		return f, errors.Wrap(err, "get frame error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&f); err != nil {
+		return f, errors.Wrap(err, "gob decode frame error")
+	}
+	return f, nil
+}
+
+// GetDownlinkFrames returns the downlink frame entries of all transmissions
+// of the given frame-counter of the given node (oldest first). Expired
+// entries are omitted.
+func GetDownlinkFrames(p *redis.Pool, devEUI lorawan.EUI64, fCnt uint32) ([]DownlinkFrame, error) {
+	c := p.Get()
+	defer c.Close()
+
+	tokens, err := redis.Ints(c.Do("LRANGE", fmt.Sprintf(downlinkAttemptsKeyTempl, devEUI, fCnt), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get downlink attempts error")
+	}
+
+	var out []DownlinkFrame
+	for _, token := range tokens {
+		f, err := getDownlinkFrame(c, uint16(token))
+		if err != nil {
+			if errors.Cause(err) == ErrDownlinkFrameDoesNotExist {
+				continue
+			}
+			return nil, err
+		}
+		// the token has been re-used by an other frame
+		if f.DevEUI != devEUI || f.FCnt != fCnt {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// AckDownlinkFrame sets the given TX acknowledgement of the gateway on the
+// downlink frame entry of its token and returns the updated entry. The
+// rejection reason is classified as ErrorCode.
+func AckDownlinkFrame(p *redis.Pool, ack gw.TXAck) (DownlinkFrame, error) {
+	c := p.Get()
+	defer c.Close()
+
+	f, err := getDownlinkFrame(c, ack.Token)
+	if err != nil {
+		return f, err
+	}
+	if f.MAC != ack.MAC {
+		return f, errors.Wrapf(ErrDownlinkFrameDoesNotExist, "token %d was not sent to gateway %s", ack.Token, ack.MAC)
+	}
+
+	f.AckedAt = time.Now()
+	f.Error = ack.Error
+	f.ErrorCode = classifyTXError(ack.Error)
+	if err := saveDownlinkFrame(c, f); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+// classifyTXError returns the TXError... constant matching the given
+// rejection reason of the gateway (empty when not rejected).
+func classifyTXError(reason string) string {
+	if reason == "" {
+		return ""
+	}
+
+	reason = strings.ToUpper(reason)
+	switch {
+	case strings.Contains(reason, "TOO_LATE"):
+		return TXErrorTooLate
+	case strings.Contains(reason, "TOO_EARLY"):
+		return TXErrorTooEarly
+	case strings.Contains(reason, "COLLISION"):
+		return TXErrorCollision
+	case strings.Contains(reason, "FREQ"):
+		return TXErrorFrequency
+	case strings.Contains(reason, "POWER"):
+		return TXErrorPower
+	case strings.Contains(reason, "GPS"):
+		return TXErrorGPSUnlocked
+	case strings.Contains(reason, "DUTY"):
+		return TXErrorDutyCycleExceeded
+	default:
+		return TXErrorOther
+	}
+}
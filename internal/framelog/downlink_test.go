@@ -0,0 +1,76 @@
+package framelog
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+func TestNewSessionSnapshot(t *testing.T) {
+	Convey("Given a node-session without uplink", t, func() {
+		ns := session.NodeSession{
+			FCntUp:      10,
+			FCntDown:    5,
+			TXPower:     2,
+			NbTrans:     1,
+			RXWindow:    session.RX2,
+			RXDelay:     1,
+			RX1DROffset: 2,
+			RX2DR:       3,
+		}
+
+		Convey("Then the data-rate of the snapshot is unknown", func() {
+			So(NewSessionSnapshot(ns), ShouldResemble, SessionSnapshot{
+				FCntUp:       10,
+				FCntDown:     5,
+				DR:           -1,
+				TXPower:      2,
+				NbTrans:      1,
+				RXWindow:     session.RX2,
+				RXDelay:      1,
+				RX1DROffset:  2,
+				RX2DR:        3,
+				RX2Frequency: common.Band.RX2Frequency,
+			})
+		})
+
+		Convey("When the node-session has an uplink and a CFList", func() {
+			ns.LastRXInfoSet = []gw.RXInfo{
+				{DataRate: common.Band.DataRates[4]},
+			}
+			ns.CFList = &lorawan.CFList{867100000, 867300000}
+
+			Convey("Then the snapshot contains the uplink data-rate and the CFList channels", func() {
+				s := NewSessionSnapshot(ns)
+				So(s.DR, ShouldEqual, 4)
+				So(s.CFList, ShouldResemble, []uint32{867100000, 867300000})
+			})
+		})
+	})
+}
+
+func TestClassifyTXError(t *testing.T) {
+	Convey("Given a set of gateway rejection reasons", t, func() {
+		for reason, code := range map[string]string{
+			"":                 "",
+			"TOO_LATE":         TXErrorTooLate,
+			"too_early":        TXErrorTooEarly,
+			"COLLISION_PACKET": TXErrorCollision,
+			"COLLISION_BEACON": TXErrorCollision,
+			"TX_FREQ":          TXErrorFrequency,
+			"TX_POWER":         TXErrorPower,
+			"GPS_UNLOCKED":     TXErrorGPSUnlocked,
+			"DUTY_CYCLE":       TXErrorDutyCycleExceeded,
+			"queue full":       TXErrorOther,
+		} {
+			Convey("Then "+reason+" is classified as "+code, func() {
+				So(classifyTXError(reason), ShouldEqual, code)
+			})
+		}
+	})
+}
@@ -0,0 +1,297 @@
+// Package framelog implements the frame logs: the last uplink and downlink
+// frames (PHYPayload and RX / TX meta-data) per node and per gateway, for
+// debugging nodes which are not able to join or of which the downlinks
+// fail. Every logged frame is also published, so that the frames of a node
+// or gateway can be streamed in real-time by every LoRa Server instance.
+//
+// The frame logs of a gateway contain every frame as received from or sent
+// to the gateway (see Gateway). The frame logs of a node contain the
+// de-duplicated uplink frames (with the RX info of all the receiving
+// gateways) and the downlink frames sent to the node.
+//
+// Next to the frame logs, every downlink transmission is stored by its
+// token (see DownlinkFrame), so that the TX acknowledgement of the gateway
+// can be correlated with the transmission and the (re)transmissions of a
+// frame-counter can be traced.
+package framelog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const (
+	// deviceKeyTempl and gatewayKeyTempl contain per node / gateway the
+	// last frames (list of protobuf encoded ns.FrameLog, newest first).
+	deviceKeyTempl  = "lora:ns:framelog:dev:%s"
+	gatewayKeyTempl = "lora:ns:framelog:gw:%s"
+
+	// deviceChannelTempl and gatewayChannelTempl are the Redis pub/sub
+	// channels on which the frames of a node / gateway are published.
+	deviceChannelTempl  = "lora:ns:framelog:stream:dev:%s"
+	gatewayChannelTempl = "lora:ns:framelog:stream:gw:%s"
+
+	// TTL defines how long the frames of a node or gateway are kept after
+	// its last frame.
+	TTL = time.Hour * 24 * 7
+)
+
+// LogUplinkForDevice logs the given (de-duplicated) uplink frame of the
+// given node.
+func LogUplinkForDevice(p *redis.Pool, devEUI lorawan.EUI64, phy lorawan.PHYPayload, rxInfoSet []gw.RXInfo) error {
+	fl, err := newFrameLog(phy)
+	if err != nil {
+		return err
+	}
+	fl.DevEUI = devEUI[:]
+	for _, rxInfo := range rxInfoSet {
+		fl.RxInfo = append(fl.RxInfo, rxInfoToFrameLog(rxInfo))
+	}
+	return logFrame(p, deviceKeyTempl, deviceChannelTempl, devEUI, fl)
+}
+
+// LogDownlinkForDevice logs the given downlink frame sent to the given node.
+func LogDownlinkForDevice(p *redis.Pool, devEUI lorawan.EUI64, txPacket gw.TXPacket) error {
+	fl, err := newFrameLog(txPacket.PHYPayload)
+	if err != nil {
+		return err
+	}
+	fl.DevEUI = devEUI[:]
+	fl.TxInfo = txInfoToFrameLog(txPacket)
+	return logFrame(p, deviceKeyTempl, deviceChannelTempl, devEUI, fl)
+}
+
+// LogUplinkForGateway logs the given uplink frame received by the gateway.
+func LogUplinkForGateway(p *redis.Pool, rxPacket gw.RXPacket) error {
+	fl, err := newFrameLog(rxPacket.PHYPayload)
+	if err != nil {
+		return err
+	}
+	fl.RxInfo = []*ns.FrameLogRXInfo{rxInfoToFrameLog(rxPacket.RXInfo)}
+	return logFrame(p, gatewayKeyTempl, gatewayChannelTempl, rxPacket.RXInfo.MAC, fl)
+}
+
+// LogDownlinkForGateway logs the given downlink frame sent to the gateway.
+func LogDownlinkForGateway(p *redis.Pool, txPacket gw.TXPacket) error {
+	fl, err := newFrameLog(txPacket.PHYPayload)
+	if err != nil {
+		return err
+	}
+	fl.TxInfo = txInfoToFrameLog(txPacket)
+	return logFrame(p, gatewayKeyTempl, gatewayChannelTempl, txPacket.TXInfo.MAC, fl)
+}
+
+// GetFrameLogsForDevice returns the total number of logged frames and the
+// logged frames (newest first) of the given node, respecting the given
+// limit and offset.
+func GetFrameLogsForDevice(p *redis.Pool, devEUI lorawan.EUI64, limit, offset int) (int, []*ns.FrameLog, error) {
+	return getFrameLogs(p, fmt.Sprintf(deviceKeyTempl, devEUI), limit, offset)
+}
+
+// GetFrameLogsForGateway returns the total number of logged frames and the
+// logged frames (newest first) of the given gateway, respecting the given
+// limit and offset.
+func GetFrameLogsForGateway(p *redis.Pool, mac lorawan.EUI64, limit, offset int) (int, []*ns.FrameLog, error) {
+	return getFrameLogs(p, fmt.Sprintf(gatewayKeyTempl, mac), limit, offset)
+}
+
+// SubscribeDevice subscribes to the frames of the given node and calls fn
+// for every logged frame. It blocks until the given context is cancelled
+// (in which case nil is returned) or until fn returns an error.
+func SubscribeDevice(ctx context.Context, p *redis.Pool, devEUI lorawan.EUI64, fn func(*ns.FrameLog) error) error {
+	return subscribe(ctx, p, fmt.Sprintf(deviceChannelTempl, devEUI), fn)
+}
+
+// SubscribeGateway subscribes to the frames of the given gateway and calls
+// fn for every logged frame. It blocks until the given context is
+// cancelled (in which case nil is returned) or until fn returns an error.
+func SubscribeGateway(ctx context.Context, p *redis.Pool, mac lorawan.EUI64, fn func(*ns.FrameLog) error) error {
+	return subscribe(ctx, p, fmt.Sprintf(gatewayChannelTempl, mac), fn)
+}
+
+func newFrameLog(phy lorawan.PHYPayload) (*ns.FrameLog, error) {
+	b, err := phy.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	return &ns.FrameLog{
+		CreatedAt:  time.Now().Format(time.RFC3339Nano),
+		MType:      uint32(phy.MHDR.MType),
+		PhyPayload: b,
+	}, nil
+}
+
+// logFrame adds the given frame to the frame logs of the given node or
+// gateway (keeping the last common.FrameLogMaxFrames frames) and publishes
+// it. It is a no-op when the frame logs are disabled.
+func logFrame(p *redis.Pool, keyTempl, channelTempl string, id lorawan.EUI64, fl *ns.FrameLog) error {
+	if common.FrameLogMaxFrames <= 0 {
+		return nil
+	}
+
+	b, err := proto.Marshal(fl)
+	if err != nil {
+		return errors.Wrap(err, "marshal frame log error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(keyTempl, id)
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, b)
+	c.Send("LTRIM", key, 0, common.FrameLogMaxFrames-1)
+	c.Send("PEXPIRE", key, int64(TTL/time.Millisecond))
+	c.Send("PUBLISH", fmt.Sprintf(channelTempl, id), b)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "log frame error")
+	}
+	return nil
+}
+
+func getFrameLogs(p *redis.Pool, key string, limit, offset int) (int, []*ns.FrameLog, error) {
+	if limit <= 0 || offset < 0 {
+		return 0, nil, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("LLEN", key))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "get frame log count error")
+	}
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", key, offset, offset+limit-1))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "get frame logs error")
+	}
+
+	var out []*ns.FrameLog
+	for _, b := range values {
+		var fl ns.FrameLog
+		if err := proto.Unmarshal(b, &fl); err != nil {
+			return 0, nil, errors.Wrap(err, "unmarshal frame log error")
+		}
+		out = append(out, &fl)
+	}
+	return count, out, nil
+}
+
+func subscribe(ctx context.Context, p *redis.Pool, channel string, fn func(*ns.FrameLog) error) error {
+	c, err := common.GetRedisSubscribeConn(p)
+	if err != nil {
+		return errors.Wrap(err, "get subscribe connection error")
+	}
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(channel); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	// unsubscribe on cancellation of the context, this will unblock the
+	// Receive call below
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			psc.Unsubscribe()
+		case <-done:
+		}
+	}()
+	defer wg.Wait()
+	defer close(done)
+
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			var fl ns.FrameLog
+			if err := proto.Unmarshal(v.Data, &fl); err != nil {
+				log.Errorf("unmarshal frame log error: %s", err)
+				continue
+			}
+			if err := fn(&fl); err != nil {
+				return err
+			}
+		case redis.Subscription:
+			if v.Count == 0 {
+				return nil
+			}
+		case error:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(v, "receive error")
+		}
+	}
+}
+
+func rxInfoToFrameLog(rxInfo gw.RXInfo) *ns.FrameLogRXInfo {
+	// make sure we have a copy of the MAC byte slice
+	mac := make([]byte, 8)
+	copy(mac, rxInfo.MAC[:])
+
+	out := ns.FrameLogRXInfo{
+		Mac:       mac,
+		Timestamp: rxInfo.Timestamp,
+		Frequency: int64(rxInfo.Frequency),
+		Channel:   int32(rxInfo.Channel),
+		RfChain:   int32(rxInfo.RFChain),
+		Antenna:   int32(rxInfo.Antenna),
+		CrcStatus: int32(rxInfo.CRCStatus),
+		CodeRate:  rxInfo.CodeRate,
+		Rssi:      int32(rxInfo.RSSI),
+		LoRaSNR:   rxInfo.LoRaSNR,
+		DataRate:  dataRateToFrameLog(rxInfo.DataRate),
+	}
+	if !rxInfo.Time.IsZero() {
+		out.Time = rxInfo.Time.Format(time.RFC3339Nano)
+	}
+	return &out
+}
+
+func txInfoToFrameLog(txPacket gw.TXPacket) *ns.FrameLogTXInfo {
+	// make sure we have a copy of the MAC byte slice
+	mac := make([]byte, 8)
+	copy(mac, txPacket.TXInfo.MAC[:])
+
+	return &ns.FrameLogTXInfo{
+		Mac:         mac,
+		Immediately: txPacket.TXInfo.Immediately,
+		Timestamp:   txPacket.TXInfo.Timestamp,
+		Frequency:   int64(txPacket.TXInfo.Frequency),
+		Power:       int32(txPacket.TXInfo.Power),
+		DataRate:    dataRateToFrameLog(txPacket.TXInfo.DataRate),
+		CodeRate:    txPacket.TXInfo.CodeRate,
+		Token:       uint32(txPacket.Token),
+	}
+}
+
+func dataRateToFrameLog(dr band.DataRate) *ns.FrameLogDataRate {
+	return &ns.FrameLogDataRate{
+		Modulation:   string(dr.Modulation),
+		BandWidth:    uint32(dr.Bandwidth),
+		SpreadFactor: uint32(dr.SpreadFactor),
+		Bitrate:      uint32(dr.BitRate),
+	}
+}
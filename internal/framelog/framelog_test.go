@@ -0,0 +1,158 @@
+package framelog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestFrameLogs(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a subscriber for a node and a gateway", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		mac := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		devChan := make(chan *ns.FrameLog, 10)
+		gwChan := make(chan *ns.FrameLog, 10)
+		go SubscribeDevice(ctx, p, devEUI, func(fl *ns.FrameLog) error {
+			devChan <- fl
+			return nil
+		})
+		go SubscribeGateway(ctx, p, mac, func(fl *ns.FrameLog) error {
+			gwChan <- fl
+			return nil
+		})
+		// make sure the subscriptions are active before publishing
+		time.Sleep(100 * time.Millisecond)
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.UnconfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:    10,
+				},
+			},
+		}
+		rxInfo := gw.RXInfo{
+			MAC:       mac,
+			Frequency: common.Band.UplinkChannels[0].Frequency,
+			DataRate:  common.Band.DataRates[3],
+			RSSI:      -60,
+			LoRaSNR:   5.5,
+		}
+		txPacket := gw.TXPacket{
+			Token: 123,
+			TXInfo: gw.TXInfo{
+				MAC:       mac,
+				Timestamp: 1000000,
+				Frequency: common.Band.UplinkChannels[0].Frequency,
+				DataRate:  common.Band.DataRates[3],
+				Power:     14,
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+						FCnt:    5,
+					},
+				},
+			},
+		}
+
+		Convey("When logging an uplink and downlink frame of the node", func() {
+			So(LogUplinkForDevice(p, devEUI, phy, []gw.RXInfo{rxInfo}), ShouldBeNil)
+			So(LogDownlinkForDevice(p, devEUI, txPacket), ShouldBeNil)
+
+			Convey("Then the frames are streamed", func() {
+				up := <-devChan
+				So(up.DevEUI, ShouldResemble, devEUI[:])
+				So(up.MType, ShouldEqual, uint32(lorawan.UnconfirmedDataUp))
+				So(up.RxInfo, ShouldHaveLength, 1)
+				So(up.RxInfo[0].Rssi, ShouldEqual, -60)
+				So(up.RxInfo[0].DataRate.SpreadFactor, ShouldEqual, common.Band.DataRates[3].SpreadFactor)
+
+				down := <-devChan
+				So(down.MType, ShouldEqual, uint32(lorawan.UnconfirmedDataDown))
+				So(down.TxInfo.Token, ShouldEqual, 123)
+				So(down.TxInfo.Power, ShouldEqual, 14)
+
+				So(gwChan, ShouldHaveLength, 0)
+			})
+
+			Convey("Then the frames can be retrieved (newest first)", func() {
+				count, frames, err := GetFrameLogsForDevice(p, devEUI, 10, 0)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+				So(frames, ShouldHaveLength, 2)
+				So(frames[0].TxInfo, ShouldNotBeNil)
+				So(frames[1].RxInfo, ShouldHaveLength, 1)
+
+				b, err := phy.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(frames[1].PhyPayload, ShouldResemble, b)
+
+				count, frames, err = GetFrameLogsForDevice(p, devEUI, 1, 1)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+				So(frames, ShouldHaveLength, 1)
+				So(frames[0].RxInfo, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("Given a gateway backend wrapped by Gateway", func() {
+			backend := test.NewGatewayBackend()
+			g := NewGateway(backend, p)
+
+			Convey("When sending a downlink frame", func() {
+				So(g.SendTXPacket(txPacket), ShouldBeNil)
+
+				Convey("Then it is sent to the gateway and logged", func() {
+					So(backend.TXPacketChan, ShouldHaveLength, 1)
+
+					fl := <-gwChan
+					So(fl.DevEUI, ShouldHaveLength, 0)
+					So(fl.TxInfo.Mac, ShouldResemble, mac[:])
+
+					count, _, err := GetFrameLogsForGateway(p, mac, 10, 0)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When the frame logs are disabled", func() {
+				common.FrameLogMaxFrames = 0
+				defer func() { common.FrameLogMaxFrames = 100 }()
+				So(g.SendTXPacket(txPacket), ShouldBeNil)
+
+				Convey("Then the frame is not logged", func() {
+					count, _, err := GetFrameLogsForGateway(p, mac, 10, 0)
+					So(err, ShouldBeNil)
+					So(count, ShouldEqual, 0)
+				})
+			})
+		})
+
+		Reset(cancel)
+	})
+}
@@ -0,0 +1,61 @@
+package framelog
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/backend"
+)
+
+// Gateway wraps a gateway backend, logging the frames received from and
+// sent to the gateways.
+type Gateway struct {
+	backend.Gateway
+
+	redisPool    *redis.Pool
+	rxPacketChan chan gw.RXPacket
+}
+
+// NewGateway creates a new Gateway wrapping the given backend.
+func NewGateway(b backend.Gateway, p *redis.Pool) *Gateway {
+	g := Gateway{
+		Gateway:      b,
+		redisPool:    p,
+		rxPacketChan: make(chan gw.RXPacket),
+	}
+
+	go g.forwardRXPackets()
+	return &g
+}
+
+// forwardRXPackets logs and forwards the received packets of the wrapped
+// backend. The channel is closed once the channel of the wrapped backend
+// has been closed.
+func (g *Gateway) forwardRXPackets() {
+	for rxPacket := range g.Gateway.RXPacketChan() {
+		if err := LogUplinkForGateway(g.redisPool, rxPacket); err != nil {
+			log.WithField("mac", rxPacket.RXInfo.MAC).Errorf("log uplink frame error: %s", err)
+		}
+		g.rxPacketChan <- rxPacket
+	}
+	close(g.rxPacketChan)
+}
+
+// RXPacketChan returns the channel containing the received packets.
+func (g *Gateway) RXPacketChan() chan gw.RXPacket {
+	return g.rxPacketChan
+}
+
+// SendTXPacket sends the given packet to the gateway and logs it on
+// success.
+func (g *Gateway) SendTXPacket(txPacket gw.TXPacket) error {
+	if err := g.Gateway.SendTXPacket(txPacket); err != nil {
+		return err
+	}
+
+	if err := LogDownlinkForGateway(g.redisPool, txPacket); err != nil {
+		log.WithField("mac", txPacket.TXInfo.MAC).Errorf("log downlink frame error: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package framelog
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/ns"
+)
+
+// The frame logs are exported in the pcap format using the LoRaTap
+// link-layer header type, so that they can be opened in Wireshark (which
+// decodes the PHYPayload using its LoRaWAN dissector). Every record
+// consists of the LoRaTap (version 0) header containing the radio
+// meta-data, followed by the PHYPayload.
+const (
+	pcapMagic        = 0xa1b2c3d4
+	pcapVersionMajor = 2
+	pcapVersionMinor = 4
+	pcapSnapLen      = 65535
+
+	// linkTypeLoRaTap is the pcap link-layer header type of LoRaTap.
+	linkTypeLoRaTap = 270
+
+	loRaTapHeaderLen = 15
+
+	// loRaWANSyncWord is the LoRa sync word of (public) LoRaWAN networks.
+	loRaWANSyncWord = 0x34
+)
+
+// WritePCAP writes the given frames (newest first, as returned by
+// GetFrameLogsForDevice and GetFrameLogsForGateway) in chronological order
+// as pcap file to w. For uplink frames received by multiple gateways, the
+// meta-data of the first receiving gateway is used.
+func WritePCAP(w io.Writer, frames []*ns.FrameLog) error {
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:4], pcapMagic)
+	binary.LittleEndian.PutUint16(hdr[4:6], pcapVersionMajor)
+	binary.LittleEndian.PutUint16(hdr[6:8], pcapVersionMinor)
+	binary.LittleEndian.PutUint32(hdr[16:20], pcapSnapLen)
+	binary.LittleEndian.PutUint32(hdr[20:24], linkTypeLoRaTap)
+	if _, err := w.Write(hdr); err != nil {
+		return errors.Wrap(err, "write pcap header error")
+	}
+
+	for i := len(frames) - 1; i >= 0; i-- {
+		if err := writePCAPRecord(w, frames[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePCAPRecord(w io.Writer, fl *ns.FrameLog) error {
+	ts, err := time.Parse(time.RFC3339Nano, fl.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "parse frame log timestamp error")
+	}
+
+	tap := loRaTapHeader(fl)
+	l := len(tap) + len(fl.PhyPayload)
+
+	rec := make([]byte, 16)
+	binary.LittleEndian.PutUint32(rec[0:4], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(rec[4:8], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(rec[8:12], uint32(l))
+	binary.LittleEndian.PutUint32(rec[12:16], uint32(l))
+	rec = append(rec, tap...)
+	rec = append(rec, fl.PhyPayload...)
+
+	if _, err := w.Write(rec); err != nil {
+		return errors.Wrap(err, "write pcap record error")
+	}
+	return nil
+}
+
+// loRaTapHeader returns the LoRaTap header containing the radio meta-data
+// of the given frame. The RSSI and SNR are only set for uplink frames.
+func loRaTapHeader(fl *ns.FrameLog) []byte {
+	var freq int64
+	var dr *ns.FrameLogDataRate
+	var rssi int32
+	var snr float64
+
+	if len(fl.RxInfo) > 0 {
+		rxInfo := fl.RxInfo[0]
+		freq = rxInfo.Frequency
+		dr = rxInfo.DataRate
+		rssi = rxInfo.Rssi
+		snr = rxInfo.LoRaSNR
+	} else if fl.TxInfo != nil {
+		freq = fl.TxInfo.Frequency
+		dr = fl.TxInfo.DataRate
+	}
+
+	b := make([]byte, loRaTapHeaderLen)
+	binary.BigEndian.PutUint16(b[2:4], loRaTapHeaderLen)
+	binary.BigEndian.PutUint32(b[4:8], uint32(freq))
+	if dr != nil {
+		b[8] = uint8(dr.BandWidth / 125)
+		b[9] = uint8(dr.SpreadFactor)
+	}
+	if len(fl.RxInfo) > 0 {
+		// the RSSI is encoded as offset of -139 dBm, the SNR in 0.25 dB
+		// steps
+		b[10] = uint8(clamp(int(rssi)+139, 0, 255))
+		b[11] = b[10]
+		b[12] = b[10]
+		b[13] = uint8(int8(clamp(int(snr*4), -128, 127)))
+	}
+	b[14] = loRaWANSyncWord
+	return b
+}
+
+func clamp(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+package framelog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/ns"
+)
+
+func TestWritePCAP(t *testing.T) {
+	Convey("Given an uplink and a downlink frame (newest first)", t, func() {
+		frames := []*ns.FrameLog{
+			{
+				CreatedAt:  "2017-05-01T10:00:01.5Z",
+				PhyPayload: []byte{0x60, 1, 2, 3},
+				TxInfo: &ns.FrameLogTXInfo{
+					Frequency: 869525000,
+					DataRate:  &ns.FrameLogDataRate{BandWidth: 125, SpreadFactor: 9},
+				},
+			},
+			{
+				CreatedAt:  "2017-05-01T10:00:00Z",
+				PhyPayload: []byte{0x40, 4, 5},
+				RxInfo: []*ns.FrameLogRXInfo{
+					{
+						Frequency: 868100000,
+						Rssi:      -60,
+						LoRaSNR:   5.5,
+						DataRate:  &ns.FrameLogDataRate{BandWidth: 125, SpreadFactor: 7},
+					},
+				},
+			},
+		}
+
+		Convey("When calling WritePCAP", func() {
+			var buf bytes.Buffer
+			So(WritePCAP(&buf, frames), ShouldBeNil)
+			b := buf.Bytes()
+
+			Convey("Then the pcap header uses the LoRaTap link-type", func() {
+				So(binary.LittleEndian.Uint32(b[0:4]), ShouldEqual, pcapMagic)
+				So(binary.LittleEndian.Uint32(b[20:24]), ShouldEqual, linkTypeLoRaTap)
+			})
+
+			Convey("Then the uplink frame is written first", func() {
+				rec := b[24:]
+				So(binary.LittleEndian.Uint32(rec[0:4]), ShouldEqual, 1493632800)
+				So(binary.LittleEndian.Uint32(rec[8:12]), ShouldEqual, loRaTapHeaderLen+3)
+				So(rec[16:16+loRaTapHeaderLen], ShouldResemble, []byte{
+					0, 0, 0, 15,
+					0x33, 0xbe, 0x27, 0xa0,
+					1, 7,
+					79, 79, 79,
+					22,
+					0x34,
+				})
+				So(rec[16+loRaTapHeaderLen:16+loRaTapHeaderLen+3], ShouldResemble, []byte{0x40, 4, 5})
+			})
+
+			Convey("Then the downlink frame is written second", func() {
+				rec := b[24+16+loRaTapHeaderLen+3:]
+				So(binary.LittleEndian.Uint32(rec[0:4]), ShouldEqual, 1493632801)
+				So(binary.LittleEndian.Uint32(rec[4:8]), ShouldEqual, 500000)
+				So(rec[16:16+loRaTapHeaderLen], ShouldResemble, []byte{
+					0, 0, 0, 15,
+					0x33, 0xd3, 0xe6, 0x08,
+					1, 9,
+					0, 0, 0,
+					0,
+					0x34,
+				})
+				So(rec[16+loRaTapHeaderLen:], ShouldResemble, []byte{0x60, 1, 2, 3})
+			})
+		})
+	})
+}
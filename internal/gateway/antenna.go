@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// antennaStatsKeyTempl contains per gateway a hash with the rx counters
+	// per antenna (field: antenna:counter)
+	antennaStatsKeyTempl = "lora:ns:gw:antenna:stats:%s"
+
+	// AntennaStatsRetention defines after how long the antenna stats of a
+	// gateway which has not received any uplink are removed.
+	AntennaStatsRetention = time.Hour * 24 * 7
+)
+
+// AntennaStats contains the uplink statistics of a single antenna of a
+// gateway.
+type AntennaStats struct {
+	Antenna       int
+	RXPacketCount int     // number of frames received by the antenna
+	SelectedCount int     // number of frames for which the antenna had the best metrics
+	AvgRSSI       float64 // average RSSI of the received frames
+	AvgLoRaSNR    float64 // average LoRa SNR of the received frames
+}
+
+type byAntenna []AntennaStats
+
+func (s byAntenna) Len() int           { return len(s) }
+func (s byAntenna) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byAntenna) Less(i, j int) bool { return s[i].Antenna < s[j].Antenna }
+
+// RecordAntennaRXPacket records that an uplink frame has been received by
+// the given antenna of the given gateway. Selected must be set when the
+// rx-info of this antenna has been used (e.g. it had the best metrics of
+// all antennas of the gateway which received the frame).
+func RecordAntennaRXPacket(p *redis.Pool, mac lorawan.EUI64, antenna, rssi int, loRaSNR float64, selected bool) error {
+	key := fmt.Sprintf(antennaStatsKeyTempl, mac)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("HINCRBY", key, fmt.Sprintf("%d:count", antenna), 1)
+	if selected {
+		c.Send("HINCRBY", key, fmt.Sprintf("%d:selected", antenna), 1)
+	}
+	c.Send("HINCRBY", key, fmt.Sprintf("%d:rssi", antenna), rssi)
+	c.Send("HINCRBYFLOAT", key, fmt.Sprintf("%d:snr", antenna), loRaSNR)
+	c.Send("PEXPIRE", key, int64(AntennaStatsRetention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record antenna rx packet error")
+	}
+	return nil
+}
+
+// GetAntennaStats returns the uplink statistics per antenna of the given
+// gateway, sorted by antenna.
+func GetAntennaStats(p *redis.Pool, mac lorawan.EUI64) ([]AntennaStats, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.StringMap(c.Do("HGETALL", fmt.Sprintf(antennaStatsKeyTempl, mac)))
+	if err != nil {
+		return nil, errors.Wrap(err, "get antenna stats error")
+	}
+
+	type sums struct {
+		count, selected, rssi int
+		snr                   float64
+	}
+	perAntenna := make(map[int]*sums)
+
+	for field, v := range values {
+		var antenna int
+		var counter string
+		if _, err := fmt.Sscanf(field, "%d:%s", &antenna, &counter); err != nil {
+			return nil, errors.Wrapf(err, "parse field '%s' error", field)
+		}
+
+		s, ok := perAntenna[antenna]
+		if !ok {
+			s = &sums{}
+			perAntenna[antenna] = s
+		}
+
+		switch counter {
+		case "count":
+			s.count, err = strconv.Atoi(v)
+		case "selected":
+			s.selected, err = strconv.Atoi(v)
+		case "rssi":
+			s.rssi, err = strconv.Atoi(v)
+		case "snr":
+			s.snr, err = strconv.ParseFloat(v, 64)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse value of field '%s' error", field)
+		}
+	}
+
+	var out []AntennaStats
+	for antenna, s := range perAntenna {
+		stats := AntennaStats{
+			Antenna:       antenna,
+			RXPacketCount: s.count,
+			SelectedCount: s.selected,
+		}
+		if s.count > 0 {
+			stats.AvgRSSI = float64(s.rssi) / float64(s.count)
+			stats.AvgLoRaSNR = s.snr / float64(s.count)
+		}
+		out = append(out, stats)
+	}
+	sort.Sort(byAntenna(out))
+
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAntennaStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then GetAntennaStats returns no stats", func() {
+			stats, err := GetAntennaStats(p, mac)
+			So(err, ShouldBeNil)
+			So(stats, ShouldHaveLength, 0)
+		})
+
+		Convey("When recording uplinks received by two antennas", func() {
+			So(RecordAntennaRXPacket(p, mac, 1, -70, 5.5, true), ShouldBeNil)
+			So(RecordAntennaRXPacket(p, mac, 0, -90, -1, false), ShouldBeNil)
+			So(RecordAntennaRXPacket(p, mac, 0, -80, 3, true), ShouldBeNil)
+			So(RecordAntennaRXPacket(p, mac, 1, -90, 2.5, false), ShouldBeNil)
+
+			Convey("Then GetAntennaStats returns the stats per antenna", func() {
+				stats, err := GetAntennaStats(p, mac)
+				So(err, ShouldBeNil)
+				So(stats, ShouldResemble, []AntennaStats{
+					{Antenna: 0, RXPacketCount: 2, SelectedCount: 1, AvgRSSI: -85, AvgLoRaSNR: 1},
+					{Antenna: 1, RXPacketCount: 2, SelectedCount: 1, AvgRSSI: -80, AvgLoRaSNR: 4},
+				})
+			})
+		})
+	})
+}
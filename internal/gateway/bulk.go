@@ -0,0 +1,245 @@
+package gateway
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// BulkGateway contains a single gateway of a bulk registration, e.g.
+// exported from an asset database as JSON or CSV (see ParseBulkGateways).
+type BulkGateway struct {
+	MAC           lorawan.EUI64 `json:"mac"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Latitude      float64       `json:"latitude"`
+	Longitude     float64       `json:"longitude"`
+	Altitude      float64       `json:"altitude"`
+	Region        string        `json:"region"`
+	TXPower       int           `json:"txPower"`
+	CodeRate      string        `json:"codeRate"`
+	RSSIOffset    int           `json:"rssiOffset"`
+	LoRaSNROffset float64       `json:"loRaSNROffset"`
+	HasGPS        bool          `json:"hasGPS"`
+	FineTimestamp bool          `json:"fineTimestamp"`
+	ReceiveOnly   bool          `json:"receiveOnly"`
+	Tags          models.Tags   `json:"tags"`
+}
+
+// Gateway returns the Gateway for the bulk gateway.
+func (b BulkGateway) Gateway() Gateway {
+	gw := Gateway{
+		MAC:           b.MAC,
+		Name:          b.Name,
+		Description:   b.Description,
+		Region:        b.Region,
+		TXPower:       b.TXPower,
+		CodeRate:      b.CodeRate,
+		RSSIOffset:    b.RSSIOffset,
+		LoRaSNROffset: b.LoRaSNROffset,
+		HasGPS:        b.HasGPS,
+		FineTimestamp: b.FineTimestamp,
+		ReceiveOnly:   b.ReceiveOnly,
+		Tags:          b.Tags,
+	}
+	if b.Latitude != 0 && b.Longitude != 0 {
+		gw.Location = &GPSPoint{
+			Latitude:  b.Latitude,
+			Longitude: b.Longitude,
+		}
+	}
+	if b.Altitude != 0 {
+		altitude := b.Altitude
+		gw.Altitude = &altitude
+	}
+	return gw
+}
+
+// BulkResult contains the result of a single gateway of a bulk
+// registration.
+type BulkResult struct {
+	MAC     lorawan.EUI64
+	Created bool  // false when an existing gateway was updated
+	Error   error // nil on success
+}
+
+// CreateOrUpdateGateways creates the given gateways or updates them when
+// they already exist. A failing gateway does not affect the other gateways,
+// the result is returned per gateway (in the given order). The first / last
+// seen timestamps of existing gateways are kept.
+func CreateOrUpdateGateways(db *sqlx.DB, gws []Gateway) []BulkResult {
+	out := make([]BulkResult, 0, len(gws))
+	var created, updated, failed int
+
+	for i := range gws {
+		res := BulkResult{MAC: gws[i].MAC}
+		res.Created, res.Error = createOrUpdateGateway(db, &gws[i])
+		switch {
+		case res.Error != nil:
+			failed++
+		case res.Created:
+			created++
+		default:
+			updated++
+		}
+		out = append(out, res)
+	}
+
+	log.WithFields(log.Fields{
+		"created": created,
+		"updated": updated,
+		"failed":  failed,
+	}).Info("bulk gateway registration handled")
+
+	return out
+}
+
+func createOrUpdateGateway(db *sqlx.DB, gw *Gateway) (bool, error) {
+	existing, err := GetGateway(db, gw.MAC)
+	if err != nil {
+		if err != ErrDoesNotExist {
+			return false, err
+		}
+		return true, CreateGateway(db, gw)
+	}
+
+	gw.CreatedAt = existing.CreatedAt
+	gw.FirstSeenAt = existing.FirstSeenAt
+	gw.LastSeenAt = existing.LastSeenAt
+	return false, UpdateGateway(db, gw)
+}
+
+// ParseBulkGateways parses the gateways of a bulk registration from the
+// given reader. The format must be json (an array of BulkGateway objects)
+// or csv. A csv file must start with a header containing the names of the
+// columns, which are the json field names of BulkGateway (e.g.
+// mac,name,latitude,longitude,region,tags). The tags are formatted as
+// key=value pairs, separated by a semicolon.
+func ParseBulkGateways(r io.Reader, format string) ([]BulkGateway, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		var out []BulkGateway
+		if err := json.NewDecoder(r).Decode(&out); err != nil {
+			return nil, errors.Wrap(err, "decode json error")
+		}
+		return out, nil
+	case "csv":
+		return parseBulkGatewaysCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown bulk gateway format '%s' (expected json or csv)", format)
+	}
+}
+
+func parseBulkGatewaysCSV(r io.Reader) ([]BulkGateway, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "read csv error")
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	var out []BulkGateway
+	for i, record := range records[1:] {
+		var b BulkGateway
+		for j, column := range header {
+			if err := setBulkGatewayField(&b, strings.TrimSpace(column), strings.TrimSpace(record[j])); err != nil {
+				// the header is on line 1
+				return nil, errors.Wrapf(err, "line %d", i+2)
+			}
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func setBulkGatewayField(b *BulkGateway, column, value string) error {
+	var err error
+	switch column {
+	case "mac":
+		err = b.MAC.UnmarshalText([]byte(value))
+	case "name":
+		b.Name = value
+	case "description":
+		b.Description = value
+	case "latitude":
+		b.Latitude, err = parseBulkFloat(value)
+	case "longitude":
+		b.Longitude, err = parseBulkFloat(value)
+	case "altitude":
+		b.Altitude, err = parseBulkFloat(value)
+	case "region":
+		b.Region = value
+	case "txPower":
+		b.TXPower, err = parseBulkInt(value)
+	case "codeRate":
+		b.CodeRate = value
+	case "rssiOffset":
+		b.RSSIOffset, err = parseBulkInt(value)
+	case "loRaSNROffset":
+		b.LoRaSNROffset, err = parseBulkFloat(value)
+	case "hasGPS":
+		b.HasGPS, err = parseBulkBool(value)
+	case "fineTimestamp":
+		b.FineTimestamp, err = parseBulkBool(value)
+	case "receiveOnly":
+		b.ReceiveOnly, err = parseBulkBool(value)
+	case "tags":
+		b.Tags, err = parseBulkTags(value)
+	default:
+		return fmt.Errorf("unknown column '%s'", column)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "parse column '%s' error", column)
+	}
+	return nil
+}
+
+func parseBulkFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseBulkInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func parseBulkBool(s string) (bool, error) {
+	if s == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func parseBulkTags(s string) (models.Tags, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	tags := make(models.Tags)
+	for _, pair := range strings.Split(s, ";") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid tag '%s' (expected key=value)", pair)
+		}
+		tags[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return tags, nil
+}
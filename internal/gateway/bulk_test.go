@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestParseBulkGateways(t *testing.T) {
+	Convey("Given a CSV file with two gateways", t, func() {
+		csv := "mac,name,latitude,longitude,altitude,region,hasGPS,tags\n" +
+			"0102030405060708,gw-1,1.123,1.124,15.5,EU868,true,site=a;rack=1\n" +
+			"0807060504030201,gw-2,,,,,,\n"
+
+		Convey("Then both gateways are parsed", func() {
+			gws, err := ParseBulkGateways(strings.NewReader(csv), "csv")
+			So(err, ShouldBeNil)
+			So(gws, ShouldResemble, []BulkGateway{
+				{
+					MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					Name:      "gw-1",
+					Latitude:  1.123,
+					Longitude: 1.124,
+					Altitude:  15.5,
+					Region:    "EU868",
+					HasGPS:    true,
+					Tags:      models.Tags{"site": "a", "rack": "1"},
+				},
+				{
+					MAC:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					Name: "gw-2",
+				},
+			})
+
+			alt := 15.5
+			So(gws[0].Gateway().Location, ShouldResemble, &GPSPoint{Latitude: 1.123, Longitude: 1.124})
+			So(gws[0].Gateway().Altitude, ShouldResemble, &alt)
+			So(gws[1].Gateway().Location, ShouldBeNil)
+			So(gws[1].Gateway().Altitude, ShouldBeNil)
+		})
+	})
+
+	Convey("Given a CSV file with an invalid value", t, func() {
+		csv := "mac,name,txPower\n0102030405060708,gw-1,high\n"
+
+		Convey("Then the error contains the line and column", func() {
+			_, err := ParseBulkGateways(strings.NewReader(csv), "csv")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldStartWith, "line 2: parse column 'txPower' error")
+		})
+	})
+
+	Convey("Given a JSON file with a gateway", t, func() {
+		json := `[{"mac": "0102030405060708", "name": "gw-1", "txPower": 14, "tags": {"site": "a"}}]`
+
+		Convey("Then the gateway is parsed", func() {
+			gws, err := ParseBulkGateways(strings.NewReader(json), "JSON")
+			So(err, ShouldBeNil)
+			So(gws, ShouldResemble, []BulkGateway{
+				{
+					MAC:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					Name:    "gw-1",
+					TXPower: 14,
+					Tags:    models.Tags{"site": "a"},
+				},
+			})
+		})
+	})
+
+	Convey("Then an unknown format returns an error", t, func() {
+		_, err := ParseBulkGateways(strings.NewReader(""), "xml")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestCreateOrUpdateGateways(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(db)
+
+		gw := Gateway{
+			MAC:  [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gateway",
+		}
+		So(CreateGateway(db, &gw), ShouldBeNil)
+
+		Convey("When creating or updating this and two new gateways (of which one is invalid)", func() {
+			res := CreateOrUpdateGateways(db, []Gateway{
+				{MAC: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, Name: "updated-gateway"},
+				{MAC: [8]byte{8, 7, 6, 5, 4, 3, 2, 1}, Name: "new-gateway"},
+				{MAC: [8]byte{1, 1, 1, 1, 1, 1, 1, 1}, Name: "invalid gateway"},
+			})
+
+			Convey("Then the result is returned per gateway", func() {
+				So(res, ShouldHaveLength, 3)
+				So(res[0].Error, ShouldBeNil)
+				So(res[0].Created, ShouldBeFalse)
+				So(res[1].Error, ShouldBeNil)
+				So(res[1].Created, ShouldBeTrue)
+				So(res[2].Error, ShouldNotBeNil)
+				So(errors.Cause(res[2].Error), ShouldEqual, ErrInvalidName)
+			})
+
+			Convey("Then the existing gateway has been updated", func() {
+				gw2, err := GetGateway(db, gw.MAC)
+				So(err, ShouldBeNil)
+				So(gw2.Name, ShouldEqual, "updated-gateway")
+				So(gw2.CreatedAt.Equal(gw.CreatedAt), ShouldBeTrue)
+			})
+
+			Convey("Then the new gateway has been created", func() {
+				gw2, err := GetGateway(db, [8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+				So(err, ShouldBeNil)
+				So(gw2.Name, ShouldEqual, "new-gateway")
+			})
+		})
+	})
+}
@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// ClientCertificate contains a TLS client certificate of a gateway, used by
+// the gateway to authenticate to the MQTT broker.
+type ClientCertificate struct {
+	TLSCert   []byte // PEM encoded
+	TLSKey    []byte // PEM encoded
+	CACert    []byte // PEM encoded
+	ExpiresAt time.Time
+}
+
+// clientCA contains the CA used for signing the gateway client
+// certificates.
+type clientCA struct {
+	cert     *x509.Certificate
+	certPEM  []byte
+	key      crypto.Signer
+	lifetime time.Duration
+}
+
+var gatewayClientCA *clientCA
+
+// MustSetClientCA loads the CA certificate and key (PEM encoded files) used
+// for signing the gateway client certificates, which are valid for the
+// given lifetime.
+func MustSetClientCA(caCert, caKey string, lifetime time.Duration) {
+	ca, err := loadClientCA(caCert, caKey, lifetime)
+	if err != nil {
+		log.Fatalf("load gateway client ca error: %s", err)
+	}
+	gatewayClientCA = ca
+}
+
+func loadClientCA(caCert, caKey string, lifetime time.Duration) (*clientCA, error) {
+	if lifetime <= 0 {
+		return nil, errors.New("the client certificate lifetime must be greater than 0")
+	}
+
+	certPEM, err := ioutil.ReadFile(caCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "read ca certificate error")
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("no pem data found in ca certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ca certificate error")
+	}
+
+	keyPEM, err := ioutil.ReadFile(caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "read ca key error")
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no pem data found in ca key")
+	}
+	key, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientCA{
+		cert:     cert,
+		certPEM:  certPEM,
+		key:      key,
+		lifetime: lifetime,
+	}, nil
+}
+
+// parsePrivateKey parses the given PKCS #1, SEC 1 (EC) or PKCS #8 encoded
+// private key.
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse ca key error")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported ca key type %T", key)
+	}
+	return signer, nil
+}
+
+// GenerateClientCertificate generates a TLS client certificate (with a new
+// ECDSA P-256 key) for the given gateway, signed by the CA set with
+// MustSetClientCA. The common name of the certificate is the MAC of the
+// gateway, so that the broker can restrict the gateway to its own topics.
+func GenerateClientCertificate(db *sqlx.DB, mac lorawan.EUI64) (ClientCertificate, error) {
+	if gatewayClientCA == nil {
+		return ClientCertificate{}, ErrClientCANotConfigured
+	}
+
+	if _, err := GetGateway(db, mac); err != nil {
+		return ClientCertificate{}, err
+	}
+
+	return gatewayClientCA.generate(mac, time.Now())
+}
+
+func (ca *clientCA) generate(mac lorawan.EUI64, now time.Time) (ClientCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return ClientCertificate{}, errors.Wrap(err, "generate key error")
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return ClientCertificate{}, errors.Wrap(err, "generate serial number error")
+	}
+
+	expiresAt := now.Add(ca.lifetime)
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: mac.String(),
+		},
+		NotBefore:   now.Add(-time.Minute), // allow for some clock skew
+		NotAfter:    expiresAt,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return ClientCertificate{}, errors.Wrap(err, "create certificate error")
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return ClientCertificate{}, errors.Wrap(err, "marshal key error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":        mac,
+		"expires_at": expiresAt,
+	}).Info("gateway client certificate generated")
+
+	return ClientCertificate{
+		TLSCert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		TLSKey:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		CACert:    ca.certPEM,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package gateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// mustWriteTestCA writes a self-signed CA certificate and key to the given
+// directory and returns the paths of the files.
+func mustWriteTestCA(dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+
+	certPath := filepath.Join(dir, "ca.pem")
+	keyPath := filepath.Join(dir, "ca-key.pem")
+	if err := ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600); err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		panic(err)
+	}
+	return certPath, keyPath
+}
+
+func TestClientCertificate(t *testing.T) {
+	Convey("Given a CA certificate and key", t, func() {
+		dir, err := ioutil.TempDir("", "client-ca")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		caCert, caKey := mustWriteTestCA(dir)
+
+		Convey("When loading the CA with a lifetime of 0", func() {
+			_, err := loadClientCA(caCert, caKey, 0)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When generating a client certificate for a gateway", func() {
+			ca, err := loadClientCA(caCert, caKey, 24*time.Hour)
+			So(err, ShouldBeNil)
+
+			mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+			now := time.Now()
+			cert, err := ca.generate(mac, now)
+			So(err, ShouldBeNil)
+
+			Convey("Then the certificate and key form a valid key-pair", func() {
+				_, err := tls.X509KeyPair(cert.TLSCert, cert.TLSKey)
+				So(err, ShouldBeNil)
+				So(cert.ExpiresAt.Equal(now.Add(24*time.Hour)), ShouldBeTrue)
+			})
+
+			Convey("Then the certificate has been issued for the gateway by the CA", func() {
+				block, _ := pem.Decode(cert.TLSCert)
+				So(block, ShouldNotBeNil)
+				x509Cert, err := x509.ParseCertificate(block.Bytes)
+				So(err, ShouldBeNil)
+				So(x509Cert.Subject.CommonName, ShouldEqual, "0102030405060708")
+
+				roots := x509.NewCertPool()
+				So(roots.AppendCertsFromPEM(cert.CACert), ShouldBeTrue)
+				_, err = x509Cert.Verify(x509.VerifyOptions{
+					Roots:     roots,
+					KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+				})
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,292 @@
+package gateway
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// cupsTokenBytes defines the number of random bytes of a CUPS / LNS token.
+const cupsTokenBytes = 32
+
+// CUPSCredentials contains the per-gateway credentials of the CUPS
+// (Configuration and Update Server) flow of Basics Station gateways. The
+// gateway authenticates to the CUPS endpoint using the CUPS token and to
+// the LNS using the TC token. After a rotation, the previous CUPS token is
+// accepted until the gateway has fetched its new credentials.
+type CUPSCredentials struct {
+	MAC               lorawan.EUI64 `db:"mac"`
+	CUPSToken         string        `db:"cups_token"`
+	PreviousCUPSToken *string       `db:"previous_cups_token"`
+	TCToken           string        `db:"tc_token"`
+	CreatedAt         time.Time     `db:"created_at"`
+	UpdatedAt         time.Time     `db:"updated_at"`
+}
+
+// RotateCUPSCredentials generates new CUPS credentials for the given
+// gateway. When the gateway already has credentials, its current CUPS token
+// is kept as previous token so that the gateway is able to fetch the new
+// credentials.
+func RotateCUPSCredentials(db *sqlx.DB, mac lorawan.EUI64) (CUPSCredentials, error) {
+	cupsToken, err := newCUPSToken()
+	if err != nil {
+		return CUPSCredentials{}, err
+	}
+	tcToken, err := newCUPSToken()
+	if err != nil {
+		return CUPSCredentials{}, err
+	}
+
+	if _, err := GetGateway(db, mac); err != nil {
+		return CUPSCredentials{}, err
+	}
+
+	now := time.Now()
+	var creds CUPSCredentials
+	err = db.Get(&creds, `
+		insert into gateway_cups_credentials (
+			mac,
+			cups_token,
+			tc_token,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $4)
+		on conflict (mac) do update set
+			previous_cups_token = gateway_cups_credentials.cups_token,
+			cups_token = excluded.cups_token,
+			tc_token = excluded.tc_token,
+			updated_at = excluded.updated_at
+		returning *`,
+		mac[:],
+		cupsToken,
+		tcToken,
+		now,
+	)
+	if err != nil {
+		return CUPSCredentials{}, errors.Wrap(err, "insert error")
+	}
+
+	log.WithField("mac", mac).Info("gateway cups credentials rotated")
+	return creds, nil
+}
+
+// GetCUPSCredentials returns the CUPS credentials of the given gateway.
+func GetCUPSCredentials(db *sqlx.DB, mac lorawan.EUI64) (CUPSCredentials, error) {
+	var creds CUPSCredentials
+	err := db.Get(&creds, "select * from gateway_cups_credentials where mac = $1", mac[:])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return creds, ErrCUPSCredentialsDoNotExist
+		}
+		return creds, errors.Wrap(err, "select error")
+	}
+	return creds, nil
+}
+
+// clearPreviousCUPSToken removes the previous CUPS token of the given
+// gateway, once it has authenticated using its new CUPS token.
+func clearPreviousCUPSToken(db *sqlx.DB, mac lorawan.EUI64) error {
+	_, err := db.Exec(`
+		update gateway_cups_credentials set
+			previous_cups_token = null,
+			updated_at = $2
+		where mac = $1`,
+		mac[:],
+		time.Now(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	return nil
+}
+
+func newCUPSToken() (string, error) {
+	b := make([]byte, cupsTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "read random bytes error")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CUPSConfig contains the configuration of the CUPS endpoint.
+type CUPSConfig struct {
+	CUPSURI string // the public URI of the CUPS endpoint
+	TCURI   string // the URI of the LNS (e.g. the Basics Station websocket of LoRa Gateway Bridge)
+	Trust   []byte // the (DER encoded) CA certificate of the CUPS and LNS endpoints (optional)
+}
+
+// updateInfoRequest implements the body of the CUPS update-info request.
+// Other fields (station version, model, package and keys) are ignored as
+// firmware updates are not supported.
+type updateInfoRequest struct {
+	Router      string `json:"router"`
+	CUPSURI     string `json:"cupsUri"`
+	TCURI       string `json:"tcUri"`
+	CUPSCredCRC uint32 `json:"cupsCredCrc"`
+	TCCredCRC   uint32 `json:"tcCredCrc"`
+}
+
+// CUPSHandler returns a http.Handler implementing a minimal CUPS
+// update-info flow (/update-info) for Basics Station gateways. It returns
+// the CUPS and LNS URI and credentials, for the items that differ from the
+// values reported by the gateway. Signatures and firmware updates are not
+// supported.
+func CUPSHandler(db *sqlx.DB, conf CUPSConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req updateInfoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "decode update-info request error", http.StatusBadRequest)
+			return
+		}
+
+		mac, err := parseRouterID(req.Router)
+		if err != nil {
+			http.Error(w, "invalid router id", http.StatusBadRequest)
+			return
+		}
+
+		creds, err := GetCUPSCredentials(db, mac)
+		if err != nil {
+			if err == ErrCUPSCredentialsDoNotExist {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			log.WithField("mac", mac).Errorf("get gateway cups credentials error: %s", err)
+			http.Error(w, "get cups credentials error", http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Header.Get("Authorization") {
+		case "Bearer " + creds.CUPSToken:
+			if creds.PreviousCUPSToken != nil {
+				if err := clearPreviousCUPSToken(db, mac); err != nil {
+					log.WithField("mac", mac).Errorf("clear previous cups token error: %s", err)
+				}
+			}
+		case "":
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		default:
+			if creds.PreviousCUPSToken == nil || r.Header.Get("Authorization") != "Bearer "+*creds.PreviousCUPSToken {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		b := updateInfoResponse(req, conf, creds)
+		log.WithFields(log.Fields{
+			"mac":  mac,
+			"size": len(b),
+		}).Info("cups update-info request handled")
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if _, err := w.Write(b); err != nil {
+			log.WithField("mac", mac).Errorf("write update-info response error: %s", err)
+		}
+	})
+}
+
+// cupsCredentials returns the credentials blob for the given token: the
+// trust certificate, followed by 4 zero bytes (no client certificate) and
+// the token as authorization header.
+func cupsCredentials(trust []byte, token string) []byte {
+	var b bytes.Buffer
+	b.Write(trust)
+	b.Write([]byte{0, 0, 0, 0})
+	b.WriteString("Authorization: Bearer " + token + "\r\n")
+	return b.Bytes()
+}
+
+// updateInfoResponse returns the binary update-info response. Only the
+// URIs and credentials that differ from the request are included.
+func updateInfoResponse(req updateInfoRequest, conf CUPSConfig, creds CUPSCredentials) []byte {
+	var b bytes.Buffer
+
+	writeURI := func(uri, current string) {
+		if uri == current {
+			uri = ""
+		}
+		b.WriteByte(uint8(len(uri)))
+		b.WriteString(uri)
+	}
+	writeURI(conf.CUPSURI, req.CUPSURI)
+	writeURI(conf.TCURI, req.TCURI)
+
+	writeCred := func(cred []byte, currentCRC uint32) {
+		if crc32.ChecksumIEEE(cred) == currentCRC {
+			cred = nil
+		}
+		binary.Write(&b, binary.LittleEndian, uint16(len(cred)))
+		b.Write(cred)
+	}
+	writeCred(cupsCredentials(conf.Trust, creds.CUPSToken), req.CUPSCredCRC)
+	writeCred(cupsCredentials(conf.Trust, creds.TCToken), req.TCCredCRC)
+
+	// no signature and no update data
+	binary.Write(&b, binary.LittleEndian, uint32(0))
+	binary.Write(&b, binary.LittleEndian, uint32(0))
+
+	return b.Bytes()
+}
+
+// parseRouterID parses the router id of a Basics Station gateway, either
+// as EUI (e.g. 0102030405060708) or in the ID6 format
+// (e.g. 102:304:506:708 or ::1).
+func parseRouterID(s string) (lorawan.EUI64, error) {
+	var mac lorawan.EUI64
+
+	if strings.Contains(s, ":") {
+		parts := strings.Split(s, ":")
+		if i := strings.Index(s, "::"); i != -1 {
+			head := strings.Split(strings.Trim(s[:i], ":"), ":")
+			tail := strings.Split(strings.Trim(s[i+2:], ":"), ":")
+			if head[0] == "" {
+				head = nil
+			}
+			if tail[0] == "" {
+				tail = nil
+			}
+			if len(head)+len(tail) > 3 {
+				return mac, fmt.Errorf("invalid id6: %s", s)
+			}
+			parts = append(head, make([]string, 4-len(head)-len(tail))...)
+			parts = append(parts, tail...)
+		}
+		if len(parts) != 4 {
+			return mac, fmt.Errorf("invalid id6: %s", s)
+		}
+
+		var hexStr string
+		for _, p := range parts {
+			if len(p) > 4 {
+				return mac, fmt.Errorf("invalid id6: %s", s)
+			}
+			hexStr += strings.Repeat("0", 4-len(p)) + p
+		}
+		s = hexStr
+	}
+
+	if err := mac.UnmarshalText([]byte(strings.Replace(s, "-", "", -1))); err != nil {
+		return mac, err
+	}
+	return mac, nil
+}
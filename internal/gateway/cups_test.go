@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestParseRouterID(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			RouterID    string
+			ExpectedMAC lorawan.EUI64
+			ExpectedErr bool
+		}{
+			{"0102030405060708", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, false},
+			{"01-02-03-04-05-06-07-08", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, false},
+			{"102:304:506:708", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, false},
+			{"::1", lorawan.EUI64{0, 0, 0, 0, 0, 0, 0, 1}, false},
+			{"102::708", lorawan.EUI64{1, 2, 0, 0, 0, 0, 7, 8}, false},
+			{"1:2:3:4:5", lorawan.EUI64{}, true},
+			{"010203", lorawan.EUI64{}, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.RouterID, i), func() {
+				mac, err := parseRouterID(test.RouterID)
+				So(err != nil, ShouldEqual, test.ExpectedErr)
+				So(mac, ShouldEqual, test.ExpectedMAC)
+			})
+		}
+	})
+}
+
+func TestUpdateInfoResponse(t *testing.T) {
+	Convey("Given a CUPS config and gateway credentials", t, func() {
+		conf := CUPSConfig{
+			CUPSURI: "https://cups:8443",
+			TCURI:   "wss://lns:3001",
+			Trust:   []byte{1, 2, 3},
+		}
+		creds := CUPSCredentials{
+			CUPSToken: "cupstoken",
+			TCToken:   "tctoken",
+		}
+		cupsCred := cupsCredentials(conf.Trust, creds.CUPSToken)
+		tcCred := cupsCredentials(conf.Trust, creds.TCToken)
+
+		Convey("Then the credentials blob contains the trust, no client certificate and the token", func() {
+			So(cupsCred, ShouldResemble, append([]byte{1, 2, 3, 0, 0, 0, 0}, []byte("Authorization: Bearer cupstoken\r\n")...))
+		})
+
+		Convey("When the gateway has no configuration", func() {
+			b := updateInfoResponse(updateInfoRequest{}, conf, creds)
+
+			Convey("Then all URIs and credentials are returned", func() {
+				var exp bytes.Buffer
+				exp.WriteByte(uint8(len(conf.CUPSURI)))
+				exp.WriteString(conf.CUPSURI)
+				exp.WriteByte(uint8(len(conf.TCURI)))
+				exp.WriteString(conf.TCURI)
+				binary.Write(&exp, binary.LittleEndian, uint16(len(cupsCred)))
+				exp.Write(cupsCred)
+				binary.Write(&exp, binary.LittleEndian, uint16(len(tcCred)))
+				exp.Write(tcCred)
+				exp.Write(make([]byte, 8))
+
+				So(b, ShouldResemble, exp.Bytes())
+			})
+		})
+
+		Convey("When the gateway is up-to-date", func() {
+			b := updateInfoResponse(updateInfoRequest{
+				CUPSURI:     conf.CUPSURI,
+				TCURI:       conf.TCURI,
+				CUPSCredCRC: crc32.ChecksumIEEE(cupsCred),
+				TCCredCRC:   crc32.ChecksumIEEE(tcCred),
+			}, conf, creds)
+
+			Convey("Then an empty response is returned", func() {
+				So(b, ShouldResemble, make([]byte, 14))
+			})
+		})
+	})
+}
+
+func TestCUPSHandler(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(db)
+
+		gw := Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(CreateGateway(db, &gw), ShouldBeNil)
+
+		handler := CUPSHandler(db, CUPSConfig{TCURI: "wss://lns:3001"})
+		updateInfo := func(token string) int {
+			r := httptest.NewRequest("POST", "/update-info", bytes.NewBufferString(`{"router": "102:304:506:708"}`))
+			if token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			return w.Code
+		}
+
+		Convey("Then the gateway does not have CUPS credentials", func() {
+			_, err := GetCUPSCredentials(db, gw.MAC)
+			So(err, ShouldEqual, ErrCUPSCredentialsDoNotExist)
+			So(updateInfo(""), ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("When rotating the CUPS credentials", func() {
+			creds1, err := RotateCUPSCredentials(db, gw.MAC)
+			So(err, ShouldBeNil)
+			So(creds1.CUPSToken, ShouldHaveLength, 64)
+			So(creds1.PreviousCUPSToken, ShouldBeNil)
+
+			Convey("Then the update-info request requires the CUPS token", func() {
+				So(updateInfo(""), ShouldEqual, http.StatusUnauthorized)
+				So(updateInfo(creds1.TCToken), ShouldEqual, http.StatusUnauthorized)
+				So(updateInfo(creds1.CUPSToken), ShouldEqual, http.StatusOK)
+			})
+
+			Convey("When rotating the CUPS credentials again", func() {
+				creds2, err := RotateCUPSCredentials(db, gw.MAC)
+				So(err, ShouldBeNil)
+				So(creds2.CUPSToken, ShouldNotEqual, creds1.CUPSToken)
+				So(*creds2.PreviousCUPSToken, ShouldEqual, creds1.CUPSToken)
+
+				Convey("Then the previous token is accepted until the new token is used", func() {
+					So(updateInfo(creds1.CUPSToken), ShouldEqual, http.StatusOK)
+					So(updateInfo(creds2.CUPSToken), ShouldEqual, http.StatusOK)
+					So(updateInfo(creds1.CUPSToken), ShouldEqual, http.StatusUnauthorized)
+
+					creds, err := GetCUPSCredentials(db, gw.MAC)
+					So(err, ShouldBeNil)
+					So(creds.PreviousCUPSToken, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// deviceLastSeenKeyTempl contains per gateway a sorted set of DevEUIs,
+	// scored by the unix timestamp on which the node was last received
+	deviceLastSeenKeyTempl = "lora:ns:gw:devices:last_seen:%s"
+
+	// deviceCountKeyTempl contains per gateway a hash with the number of
+	// received uplink frames per DevEUI
+	deviceCountKeyTempl = "lora:ns:gw:devices:count:%s"
+
+	// DeviceRetention defines after how long a node which has not been
+	// received by a gateway is removed from the devices of the gateway.
+	DeviceRetention = time.Hour * 24 * 7
+)
+
+// removeExpiredDevicesScript removes the nodes which have not been received
+// since the given timestamp from both the last-seen set and the count hash.
+var removeExpiredDevicesScript = redis.NewScript(2, `
+	local expired = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+	for i, devEUI in ipairs(expired) do
+		redis.call("HDEL", KEYS[2], devEUI)
+	end
+	return redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+`)
+
+// Device contains the uplink statistics of a node received by a gateway.
+type Device struct {
+	DevEUI        lorawan.EUI64
+	RXPacketCount int
+	LastSeenAt    time.Time
+}
+
+// RecordDeviceUplink records that an uplink frame of the given node has been
+// received by the given gateway.
+func RecordDeviceUplink(p *redis.Pool, mac lorawan.EUI64, devEUI lorawan.EUI64, receivedAt time.Time) error {
+	lastSeenKey := fmt.Sprintf(deviceLastSeenKeyTempl, mac)
+	countKey := fmt.Sprintf(deviceCountKeyTempl, mac)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("ZADD", lastSeenKey, receivedAt.Unix(), devEUI.String())
+	c.Send("HINCRBY", countKey, devEUI.String(), 1)
+	c.Send("PEXPIRE", lastSeenKey, int64(DeviceRetention/time.Millisecond))
+	c.Send("PEXPIRE", countKey, int64(DeviceRetention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record device uplink error")
+	}
+
+	expired := receivedAt.Add(-DeviceRetention).Unix()
+	if _, err := removeExpiredDevicesScript.Do(c, lastSeenKey, countKey, expired); err != nil {
+		return errors.Wrap(err, "remove expired devices error")
+	}
+
+	return nil
+}
+
+// GetDeviceCount returns the number of nodes received by the given gateway.
+func GetDeviceCount(p *redis.Pool, mac lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("ZCARD", fmt.Sprintf(deviceLastSeenKeyTempl, mac)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get device count error")
+	}
+	return count, nil
+}
+
+// GetDevices returns the nodes received by the given gateway, ordered by
+// last-seen timestamp (most recent first).
+func GetDevices(p *redis.Pool, mac lorawan.EUI64, limit, offset int) ([]Device, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("ZREVRANGE", fmt.Sprintf(deviceLastSeenKeyTempl, mac), offset, offset+limit-1, "WITHSCORES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get devices error")
+	}
+
+	var out []Device
+	for i := 0; i+1 < len(values); i += 2 {
+		var d Device
+		if err := d.DevEUI.UnmarshalText([]byte(values[i])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal DevEUI error")
+		}
+
+		lastSeen, err := redis.Int64(values[i+1], nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse last-seen error")
+		}
+		d.LastSeenAt = time.Unix(lastSeen, 0)
+
+		d.RXPacketCount, err = redis.Int(c.Do("HGET", fmt.Sprintf(deviceCountKeyTempl, mac), values[i]))
+		if err != nil && err != redis.ErrNil {
+			return nil, errors.Wrap(err, "get rx packet count error")
+		}
+
+		out = append(out, d)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGatewayDevices(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		devEUI1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		devEUI2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		now := time.Now().Truncate(time.Second)
+
+		Convey("When recording uplinks of two nodes", func() {
+			So(RecordDeviceUplink(p, mac, devEUI1, now.Add(-time.Minute)), ShouldBeNil)
+			So(RecordDeviceUplink(p, mac, devEUI1, now.Add(-time.Minute)), ShouldBeNil)
+			So(RecordDeviceUplink(p, mac, devEUI2, now), ShouldBeNil)
+
+			Convey("Then GetDeviceCount returns 2", func() {
+				count, err := GetDeviceCount(p, mac)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+			})
+
+			Convey("Then GetDevices returns the nodes, most recent first", func() {
+				devices, err := GetDevices(p, mac, 10, 0)
+				So(err, ShouldBeNil)
+				So(devices, ShouldResemble, []Device{
+					{DevEUI: devEUI2, RXPacketCount: 1, LastSeenAt: now},
+					{DevEUI: devEUI1, RXPacketCount: 2, LastSeenAt: now.Add(-time.Minute)},
+				})
+
+				Convey("Then the limit and offset are applied", func() {
+					devices, err := GetDevices(p, mac, 1, 1)
+					So(err, ShouldBeNil)
+					So(devices, ShouldHaveLength, 1)
+					So(devices[0].DevEUI, ShouldEqual, devEUI1)
+				})
+			})
+
+			Convey("When recording an uplink after the retention of the first node", func() {
+				So(RecordDeviceUplink(p, mac, devEUI2, now.Add(DeviceRetention)), ShouldBeNil)
+
+				Convey("Then the first node has been removed", func() {
+					devices, err := GetDevices(p, mac, 10, 0)
+					So(err, ShouldBeNil)
+					So(devices, ShouldHaveLength, 1)
+					So(devices[0].DevEUI, ShouldEqual, devEUI2)
+					So(devices[0].RXPacketCount, ShouldEqual, 2)
+				})
+			})
+		})
+	})
+}
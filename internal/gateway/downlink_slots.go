@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// downlinkSlotsKeyTempl contains per gateway a sorted set of the tokens
+	// of the downlinks sent to the gateway, scored by the unix timestamp
+	// (in ms) on which they were sent.
+	downlinkSlotsKeyTempl = "lora:ns:gw:downlink_slots:%s"
+
+	// DownlinkSlotWindow defines the window in which the number of
+	// downlinks per gateway is limited.
+	DownlinkSlotWindow = time.Second
+)
+
+// reserveDownlinkSlotScript removes the downlinks sent before the window
+// and adds the given downlink when the number of downlinks within the
+// window is below the given max. It returns 1 when the downlink was added.
+var reserveDownlinkSlotScript = redis.NewScript(1, `
+	redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+	if redis.call("ZCARD", KEYS[1]) >= tonumber(ARGV[2]) then
+		return 0
+	end
+	redis.call("ZADD", KEYS[1], ARGV[3], ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], ARGV[5])
+	return 1
+`)
+
+// ReserveDownlinkSlot reserves a downlink slot for the downlink with the
+// given token. It returns false when the given max number of downlinks
+// have already been sent to the gateway within the DownlinkSlotWindow.
+func ReserveDownlinkSlot(p *redis.Pool, mac lorawan.EUI64, token uint16, now time.Time, max int) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	nowMS := now.UnixNano() / int64(time.Millisecond)
+	windowMS := int64(DownlinkSlotWindow / time.Millisecond)
+
+	ok, err := redis.Bool(reserveDownlinkSlotScript.Do(c,
+		fmt.Sprintf(downlinkSlotsKeyTempl, mac),
+		nowMS-windowMS,
+		max,
+		nowMS,
+		fmt.Sprintf("%d:%d", nowMS, token),
+		windowMS,
+	))
+	if err != nil {
+		return false, errors.Wrap(err, "reserve downlink slot error")
+	}
+	return ok, nil
+}
+
+// GetBusyGateways returns the given gateways to which the given max number
+// of downlinks have already been sent within the DownlinkSlotWindow.
+func GetBusyGateways(p *redis.Pool, macs []lorawan.EUI64, now time.Time, max int) (map[lorawan.EUI64]struct{}, error) {
+	c := p.Get()
+	defer c.Close()
+
+	nowMS := now.UnixNano() / int64(time.Millisecond)
+	windowMS := int64(DownlinkSlotWindow / time.Millisecond)
+
+	for _, mac := range macs {
+		c.Send("ZCOUNT", fmt.Sprintf(downlinkSlotsKeyTempl, mac), fmt.Sprintf("(%d", nowMS-windowMS), "+inf")
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get downlink slots error")
+	}
+
+	out := make(map[lorawan.EUI64]struct{})
+	for _, mac := range macs {
+		count, err := redis.Int(c.Receive())
+		if err != nil {
+			return nil, errors.Wrap(err, "get downlink slots error")
+		}
+		if count >= max {
+			out[mac] = struct{}{}
+		}
+	}
+	return out, nil
+}
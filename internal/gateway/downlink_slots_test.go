@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDownlinkSlots(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		mac2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		now := time.Now().Truncate(time.Second)
+
+		Convey("When reserving two downlink slots at a gateway with a max of 2", func() {
+			ok, err := ReserveDownlinkSlot(p, mac1, 1, now, 2)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			ok, err = ReserveDownlinkSlot(p, mac1, 2, now.Add(100*time.Millisecond), 2)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			Convey("Then a third downlink slot within the window can not be reserved", func() {
+				ok, err := ReserveDownlinkSlot(p, mac1, 3, now.Add(500*time.Millisecond), 2)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then a downlink slot can be reserved once the first slot left the window", func() {
+				ok, err := ReserveDownlinkSlot(p, mac1, 3, now.Add(DownlinkSlotWindow), 2)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+			})
+
+			Convey("Then GetBusyGateways returns only the gateway at its limit", func() {
+				busy, err := GetBusyGateways(p, []lorawan.EUI64{mac1, mac2}, now.Add(500*time.Millisecond), 2)
+				So(err, ShouldBeNil)
+				So(busy, ShouldResemble, map[lorawan.EUI64]struct{}{mac1: {}})
+			})
+
+			Convey("Then GetBusyGateways returns no gateways after the window", func() {
+				busy, err := GetBusyGateways(p, []lorawan.EUI64{mac1, mac2}, now.Add(2*DownlinkSlotWindow), 2)
+				So(err, ShouldBeNil)
+				So(busy, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -4,8 +4,12 @@ import "errors"
 
 // gateway errors
 var (
-	ErrDoesNotExist               = errors.New("gateway does not exist")
-	ErrAlreadyExists              = errors.New("gateway already exists")
-	ErrInvalidAggregationInterval = errors.New("invalid aggregation interval")
-	ErrInvalidName                = errors.New("invalid gateway name")
+	ErrDoesNotExist                = errors.New("gateway does not exist")
+	ErrAlreadyExists               = errors.New("gateway already exists")
+	ErrInvalidAggregationInterval  = errors.New("invalid aggregation interval")
+	ErrInvalidName                 = errors.New("invalid gateway name")
+	ErrCUPSCredentialsDoNotExist   = errors.New("gateway cups credentials do not exist")
+	ErrClientCANotConfigured       = errors.New("gateway client certificate ca not configured")
+	ErrInvalidOnboardingToken      = errors.New("invalid, expired or already used gateway onboarding token")
+	ErrOnboardingTokenDoesNotExist = errors.New("gateway onboarding token does not exist")
 )
@@ -0,0 +1,113 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// eventLogKeyTempl contains per gateway the last events reported by
+	// the gateway (newest first).
+	eventLogKeyTempl = "lora:ns:gw:events:%s"
+
+	// eventLogSize defines the number of events kept per gateway.
+	eventLogSize = 50
+
+	// EventLogTTL defines how long the events of a gateway are kept after
+	// its last event.
+	EventLogTTL = time.Hour * 24 * 7
+)
+
+// RecordEvent adds the given event to the event log of the gateway.
+func RecordEvent(p *redis.Pool, event gw.GatewayEvent) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshal event error")
+	}
+
+	key := fmt.Sprintf(eventLogKeyTempl, event.MAC)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, b)
+	c.Send("LTRIM", key, 0, eventLogSize-1)
+	c.Send("PEXPIRE", key, int64(EventLogTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record event error")
+	}
+	return nil
+}
+
+// GetEvents returns the last events reported by the given gateway (newest
+// first).
+func GetEvents(p *redis.Pool, mac lorawan.EUI64) ([]gw.GatewayEvent, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(eventLogKeyTempl, mac), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get events error")
+	}
+
+	var out []gw.GatewayEvent
+	for _, b := range values {
+		var event gw.GatewayEvent
+		if err := json.Unmarshal(b, &event); err != nil {
+			return nil, errors.Wrap(err, "unmarshal event error")
+		}
+		out = append(out, event)
+	}
+	return out, nil
+}
+
+// handleEvents consumes the events reported by the gateways.
+func handleEvents(wg *sync.WaitGroup, ctx common.Context) {
+	for event := range ctx.Gateway.EventChan() {
+		wg.Add(1)
+		go func(event gw.GatewayEvent) {
+			defer wg.Done()
+			if err := handleEvent(ctx, event); err != nil {
+				log.Errorf("handle gateway event error: %s", err)
+			}
+		}(event)
+	}
+}
+
+// handleEvent stores the given event and publishes it to the
+// network-controller.
+func handleEvent(ctx common.Context, event gw.GatewayEvent) error {
+	log.WithFields(log.Fields{
+		"mac":         event.MAC,
+		"type":        event.Type,
+		"description": event.Description,
+	}).Warning("gateway event")
+
+	if err := RecordEvent(ctx.RedisPool, event); err != nil {
+		return err
+	}
+
+	_, err := ctx.Controller.HandleGatewayEvent(context.Background(), &nc.HandleGatewayEventRequest{
+		Mac:         event.MAC[:],
+		Time:        event.Time.Format(time.RFC3339Nano),
+		Type:        event.Type,
+		Description: event.Description,
+	})
+	if err != nil {
+		return errors.Wrap(err, "publish gateway event to network-controller error")
+	}
+	return nil
+}
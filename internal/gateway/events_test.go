@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGatewayEvents(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		controller := test.NewNetworkControllerClient()
+		ctx := common.Context{
+			RedisPool:  p,
+			Controller: controller,
+		}
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		now := time.Now().UTC().Truncate(time.Second)
+
+		Convey("Then GetEvents returns no events", func() {
+			events, err := GetEvents(p, mac)
+			So(err, ShouldBeNil)
+			So(events, ShouldHaveLength, 0)
+		})
+
+		Convey("When handling two events", func() {
+			gpsLost := gw.GatewayEvent{
+				MAC:         mac,
+				Time:        now.Add(-time.Minute),
+				Type:        gw.EventGPSLost,
+				Description: "no pps signal",
+			}
+			restart := gw.GatewayEvent{
+				MAC:  mac,
+				Time: now,
+				Type: gw.EventConcentratorRestart,
+			}
+			So(handleEvent(ctx, gpsLost), ShouldBeNil)
+			So(handleEvent(ctx, restart), ShouldBeNil)
+
+			Convey("Then GetEvents returns the events, newest first", func() {
+				events, err := GetEvents(p, mac)
+				So(err, ShouldBeNil)
+				So(events, ShouldHaveLength, 2)
+				So(events[0].Type, ShouldEqual, gw.EventConcentratorRestart)
+				So(events[0].Time.Equal(now), ShouldBeTrue)
+				So(events[1].Type, ShouldEqual, gw.EventGPSLost)
+				So(events[1].Description, ShouldEqual, "no pps signal")
+			})
+
+			Convey("Then the events have been published to the network-controller", func() {
+				req := <-controller.HandleGatewayEventChan
+				So(req.Mac, ShouldResemble, mac[:])
+				So(req.Type, ShouldEqual, gw.EventGPSLost)
+				So(req.Description, ShouldEqual, "no pps signal")
+				So(req.Time, ShouldEqual, gpsLost.Time.Format(time.RFC3339Nano))
+
+				req = <-controller.HandleGatewayEventChan
+				So(req.Type, ShouldEqual, gw.EventConcentratorRestart)
+			})
+		})
+
+		Convey("When handling more events than the event log size", func() {
+			for i := 0; i < eventLogSize+5; i++ {
+				So(RecordEvent(p, gw.GatewayEvent{MAC: mac, Time: now, Type: gw.EventJITQueueFull}), ShouldBeNil)
+			}
+
+			Convey("Then only the last events are kept", func() {
+				events, err := GetEvents(p, mac)
+				So(err, ShouldBeNil)
+				So(events, ShouldHaveLength, eventLogSize)
+			})
+		})
+	})
+}
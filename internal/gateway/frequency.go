@@ -0,0 +1,41 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/counters"
+)
+
+const outOfPlanRXPacketCountKeyTempl = "lora:ns:gw:out_of_plan:%s" // contains the number of out-of-plan uplinks of a gateway
+
+// IncrementOutOfPlanRXPacketCount increments the number of uplink frames
+// received by the given gateway on a frequency outside the channel plan.
+func IncrementOutOfPlanRXPacketCount(p *redis.Pool, mac lorawan.EUI64) error {
+	var b counters.Batch
+	b.IncrBy(fmt.Sprintf(outOfPlanRXPacketCountKeyTempl, mac), 1, 0)
+	if err := counters.Add(p, b); err != nil {
+		return errors.Wrap(err, "increment error")
+	}
+	return nil
+}
+
+// GetOutOfPlanRXPacketCount returns the number of uplink frames received by
+// the given gateway on a frequency outside the channel plan.
+func GetOutOfPlanRXPacketCount(p *redis.Pool, mac lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("GET", fmt.Sprintf(outOfPlanRXPacketCountKeyTempl, mac)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "get error")
+	}
+	return count, nil
+}
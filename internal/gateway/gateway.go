@@ -17,6 +17,8 @@ import (
 
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+	"github.com/joriwind/loraserver/internal/models"
 	"github.com/brocaar/lorawan"
 )
 
@@ -25,25 +27,28 @@ var gatewayNameRegexp = regexp.MustCompile(`^[\w-]+$`)
 // statsAggregationIntervals contains a slice of aggregation intervals.
 var statsAggregationIntervals []string
 
+// validStatsAggregationIntervals contains the valid aggregation intervals,
+// from fine to coarse.
+var validStatsAggregationIntervals = []string{
+	"SECOND",
+	"MINUTE",
+	"HOUR",
+	"DAY",
+	"WEEK",
+	"MONTH",
+	"QUARTER",
+	"YEAR",
+}
+
 // MustSetStatsAggregationIntervals sets the aggregation intervals to use.
 // Valid levels are: SECOND, MINUTE, HOUR, DAY, WEEK, MONTH, QUARTER, YEAR.
 func MustSetStatsAggregationIntervals(levels []string) {
-	valid := []string{
-		"SECOND",
-		"MINUTE",
-		"HOUR",
-		"DAY",
-		"WEEK",
-		"MONTH",
-		"QUARTER",
-		"YEAR",
-	}
 	statsAggregationIntervals = []string{}
 
 	for _, level := range levels {
 		found := false
 		lUpper := strings.ToUpper(level)
-		for _, v := range valid {
+		for _, v := range validStatsAggregationIntervals {
 			if lUpper == v {
 				statsAggregationIntervals = append(statsAggregationIntervals, lUpper)
 				found = true
@@ -79,14 +84,19 @@ func (l *GPSPoint) Scan(src interface{}) error {
 
 // StatsHandler represents a stat handler for incoming gateway stats.
 type StatsHandler struct {
-	ctx common.Context
-	wg  sync.WaitGroup
+	ctx     common.Context
+	elector *leader.Elector
+	wg      sync.WaitGroup
+	done    chan struct{}
 }
 
-// NewStatsHandler creates a new StatsHandler.
-func NewStatsHandler(ctx common.Context) *StatsHandler {
+// NewStatsHandler creates a new StatsHandler. The stats timeout check is
+// only executed when the given elector is the leader (or when nil).
+func NewStatsHandler(ctx common.Context, elector *leader.Elector) *StatsHandler {
 	return &StatsHandler{
-		ctx: ctx,
+		ctx:     ctx,
+		elector: elector,
+		done:    make(chan struct{}),
 	}
 }
 
@@ -97,16 +107,68 @@ func (s *StatsHandler) Start() error {
 		defer s.wg.Done()
 		handleStatsPackets(&s.wg, s.ctx)
 	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		handleEvents(&s.wg, s.ctx)
+	}()
+
+	if common.GatewayStatsTimeout > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.checkStatsTimeouts()
+		}()
+	}
+
+	if statsPusher != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.pushStats()
+		}()
+	}
+
+	if len(statsRetention) > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.compactStats()
+		}()
+	}
 	return nil
 }
 
 // Stop waits for the stats handler to complete the pending packets.
 // At this stage the gateway backend must already been closed.
 func (s *StatsHandler) Stop() error {
+	close(s.done)
 	s.wg.Wait()
 	return nil
 }
 
+// checkStatsTimeouts periodically checks for gateways from which no stats
+// were received within the configured stats timeout, until Stop is called.
+func (s *StatsHandler) checkStatsTimeouts() {
+	ticker := time.NewTicker(common.GatewayStatsTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if s.elector != nil && !s.elector.IsLeader() {
+				continue
+			}
+			if err := handleGatewayStatsTimeouts(s.ctx); err != nil {
+				log.Errorf("handle gateway stats timeouts error: %s", err)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
 // Gateway represents a single gateway.
 type Gateway struct {
 	MAC         lorawan.EUI64 `db:"mac"`
@@ -118,6 +180,34 @@ type Gateway struct {
 	LastSeenAt  *time.Time    `db:"last_seen_at"`
 	Location    *GPSPoint     `db:"location"`
 	Altitude    *float64      `db:"altitude"`
+	Region      string        `db:"region"`
+	TXPower     int           `db:"tx_power"`
+	CodeRate    string        `db:"code_rate"`
+	IPol        *bool         `db:"ipol"`
+
+	// RSSIOffset and LoRaSNROffset are added to the RSSI and LoRa SNR
+	// reported by the gateway, correcting for known biases of the gateway
+	// model.
+	RSSIOffset    int     `db:"rssi_offset"`
+	LoRaSNROffset float64 `db:"lora_snr_offset"`
+
+	// The hardware capabilities of the gateway.
+	HasGPS        bool `db:"has_gps"`        // GPS / PPS present
+	FineTimestamp bool `db:"fine_timestamp"` // fine-timestamp capable
+	ReceiveOnly   bool `db:"receive_only"`   // not capable of transmitting downlinks
+
+	// Tags contains the operator-defined tags of the gateway.
+	Tags models.Tags `db:"tags"`
+}
+
+// TXParams returns the downlink TX parameters of the gateway, overriding
+// the TX parameters of the band.
+func (g Gateway) TXParams() models.TXParams {
+	return models.TXParams{
+		Power:    g.TXPower,
+		CodeRate: g.CodeRate,
+		IPol:     g.IPol,
+	}
 }
 
 // Validate validates the data of the gateway.
@@ -125,6 +215,12 @@ func (g Gateway) Validate() error {
 	if !gatewayNameRegexp.MatchString(g.Name) {
 		return ErrInvalidName
 	}
+	if err := g.TXParams().Validate(); err != nil {
+		return err
+	}
+	if err := g.Tags.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -156,8 +252,18 @@ func CreateGateway(db *sqlx.DB, gw *Gateway) error {
 			first_seen_at,
 			last_seen_at,
 			location,
-			altitude
-		) values ($1, $2, $3, $4, $4, $5, $6, $7, $8)`,
+			altitude,
+			region,
+			tx_power,
+			code_rate,
+			ipol,
+			rssi_offset,
+			lora_snr_offset,
+			has_gps,
+			fine_timestamp,
+			receive_only,
+			tags
+		) values ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
 		gw.MAC[:],
 		gw.Name,
 		gw.Description,
@@ -166,6 +272,16 @@ func CreateGateway(db *sqlx.DB, gw *Gateway) error {
 		gw.LastSeenAt,
 		gw.Location,
 		gw.Altitude,
+		gw.Region,
+		gw.TXPower,
+		gw.CodeRate,
+		gw.IPol,
+		gw.RSSIOffset,
+		gw.LoRaSNROffset,
+		gw.HasGPS,
+		gw.FineTimestamp,
+		gw.ReceiveOnly,
+		gw.Tags,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -214,7 +330,17 @@ func UpdateGateway(db *sqlx.DB, gw *Gateway) error {
 			first_seen_at = $5,
 			last_seen_at = $6,
 			location = $7,
-			altitude = $8
+			altitude = $8,
+			region = $9,
+			tx_power = $10,
+			code_rate = $11,
+			ipol = $12,
+			rssi_offset = $13,
+			lora_snr_offset = $14,
+			has_gps = $15,
+			fine_timestamp = $16,
+			receive_only = $17,
+			tags = $18
 		where mac = $1`,
 		gw.MAC[:],
 		gw.Name,
@@ -224,6 +350,16 @@ func UpdateGateway(db *sqlx.DB, gw *Gateway) error {
 		gw.LastSeenAt,
 		gw.Location,
 		gw.Altitude,
+		gw.Region,
+		gw.TXPower,
+		gw.CodeRate,
+		gw.IPol,
+		gw.RSSIOffset,
+		gw.LoRaSNROffset,
+		gw.HasGPS,
+		gw.FineTimestamp,
+		gw.ReceiveOnly,
+		gw.Tags,
 	)
 	if err != nil {
 		return errors.Wrap(err, "update error")
@@ -257,21 +393,71 @@ func DeleteGateway(db *sqlx.DB, mac lorawan.EUI64) error {
 	return nil
 }
 
-// GetGatewayCount returns the number of gateways.
-func GetGatewayCount(db *sqlx.DB) (int, error) {
+// Fields the gateways can be ordered by.
+const (
+	OrderByMAC        = "mac"
+	OrderByName       = "name"
+	OrderByCreatedAt  = "created_at"
+	OrderByLastSeenAt = "last_seen_at"
+)
+
+// ListFilters contains the filter and sort options for listing gateways.
+type ListFilters struct {
+	// Search matches (case-insensitive) on the MAC (HEX encoded) or name.
+	Search string
+
+	// Tags matches the gateways having all the given tags.
+	Tags models.Tags
+
+	// OrderBy contains the field to order by (defaults to OrderByMAC).
+	OrderBy    string
+	Descending bool
+}
+
+// searchCondition is the where condition matching ListFilters.Search and
+// ListFilters.Tags.
+const searchCondition = "($1 = '' or strpos(encode(mac, 'hex'), lower($1)) > 0 or strpos(lower(name), lower($1)) > 0) and tags @> $2"
+
+// orderClause returns the order by clause for the given filters. The mac is
+// always used as last sort key, so that the pagination is stable.
+func (f ListFilters) orderClause() (string, error) {
+	dir := "asc"
+	if f.Descending {
+		dir = "desc"
+	}
+
+	switch f.OrderBy {
+	case "", OrderByMAC:
+		return "mac " + dir, nil
+	case OrderByName, OrderByCreatedAt:
+		return fmt.Sprintf("%s %s, mac %s", f.OrderBy, dir, dir), nil
+	case OrderByLastSeenAt:
+		return fmt.Sprintf("last_seen_at %s nulls last, mac %s", dir, dir), nil
+	default:
+		return "", fmt.Errorf("invalid order by field: %s", f.OrderBy)
+	}
+}
+
+// GetGatewayCount returns the number of gateways matching the given filters.
+func GetGatewayCount(db *sqlx.DB, filters ListFilters) (int, error) {
 	var count int
-	err := db.Get(&count, "select count(*) from gateway")
+	err := db.Get(&count, "select count(*) from gateway where "+searchCondition, filters.Search, filters.Tags)
 	if err != nil {
 		return 0, errors.Wrap(err, "select error")
 	}
 	return count, nil
 }
 
-// GetGateways returns a slice of gateways, order by mac and respecting the
-// given limit and offset.
-func GetGateways(db *sqlx.DB, limit, offset int) ([]Gateway, error) {
+// GetGateways returns a slice of gateways matching the given filters, in
+// the requested order and respecting the given limit and offset.
+func GetGateways(db *sqlx.DB, filters ListFilters, limit, offset int) ([]Gateway, error) {
+	order, err := filters.orderClause()
+	if err != nil {
+		return nil, err
+	}
+
 	var gws []Gateway
-	err := db.Select(&gws, "select * from gateway order by mac limit $1 offset $2", limit, offset)
+	err = db.Select(&gws, "select * from gateway where "+searchCondition+" order by "+order+" limit $3 offset $4", filters.Search, filters.Tags, limit, offset)
 	if err != nil {
 		return nil, errors.Wrap(err, "select error")
 	}
@@ -303,6 +489,105 @@ func GetGatewaysForMACs(db *sqlx.DB, macs []lorawan.EUI64) (map[lorawan.EUI64]Ga
 	return out, nil
 }
 
+// GetGatewayRegions returns a map of gateway regions given a slice of MACs.
+// Unlike GetGatewaysForMACs, gateways which do not exist are omitted from
+// the result.
+func GetGatewayRegions(db *sqlx.DB, macs []lorawan.EUI64) (map[lorawan.EUI64]string, error) {
+	out := make(map[lorawan.EUI64]string)
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var gws []Gateway
+	err := db.Select(&gws, "select * from gateway where mac = any($1)", pq.ByteaArray(macsB))
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range gws {
+		out[gws[i].MAC] = gws[i].Region
+	}
+
+	return out, nil
+}
+
+// GetGatewayTags returns a map of gateway tags given a slice of MACs.
+// Gateways which do not exist are omitted from the result.
+func GetGatewayTags(db *sqlx.DB, macs []lorawan.EUI64) (map[lorawan.EUI64]models.Tags, error) {
+	out := make(map[lorawan.EUI64]models.Tags)
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var gws []Gateway
+	err := db.Select(&gws, "select * from gateway where mac = any($1)", pq.ByteaArray(macsB))
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range gws {
+		out[gws[i].MAC] = gws[i].Tags
+	}
+
+	return out, nil
+}
+
+// GetReceiveOnlyGateways returns the MACs of the given gateways which are
+// not capable of transmitting downlinks. Unknown gateways are omitted.
+func GetReceiveOnlyGateways(db *sqlx.DB, macs []lorawan.EUI64) (map[lorawan.EUI64]struct{}, error) {
+	out := make(map[lorawan.EUI64]struct{})
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var gws []Gateway
+	err := db.Select(&gws, "select * from gateway where mac = any($1) and receive_only = true", pq.ByteaArray(macsB))
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range gws {
+		out[gws[i].MAC] = struct{}{}
+	}
+
+	return out, nil
+}
+
+// RXCalibration contains the calibration offsets of the RSSI and LoRa SNR
+// reported by a gateway.
+type RXCalibration struct {
+	RSSIOffset    int
+	LoRaSNROffset float64
+}
+
+// GetGatewayRXCalibrations returns the RX calibration offsets of the given
+// gateways. Gateways without offsets are omitted.
+func GetGatewayRXCalibrations(db *sqlx.DB, macs []lorawan.EUI64) (map[lorawan.EUI64]RXCalibration, error) {
+	out := make(map[lorawan.EUI64]RXCalibration)
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var gws []Gateway
+	err := db.Select(&gws, "select * from gateway where mac = any($1) and (rssi_offset != 0 or lora_snr_offset != 0)", pq.ByteaArray(macsB))
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range gws {
+		out[gws[i].MAC] = RXCalibration{
+			RSSIOffset:    gws[i].RSSIOffset,
+			LoRaSNROffset: gws[i].LoRaSNROffset,
+		}
+	}
+
+	return out, nil
+}
+
 // GetGatewayStats returns the stats for the given gateway.
 // Note that the stats will return a record for each interval.
 func GetGatewayStats(db *sqlx.DB, mac lorawan.EUI64, interval string, start, end time.Time) ([]Stats, error) {
@@ -383,6 +668,14 @@ func handleStatsPackets(wg *sync.WaitGroup, ctx common.Context) {
 			if err := handleStatsPacket(ctx.DB, stats); err != nil {
 				log.Errorf("handle stats packet error: %s", err)
 			}
+			if err := RecordStatsReceived(ctx.RedisPool, stats.MAC, time.Now()); err != nil {
+				log.Errorf("record stats received error: %s", err)
+			}
+			if common.GatewayStatsTimeout > 0 {
+				if err := handleGatewayStatus(ctx, stats.MAC, time.Now()); err != nil {
+					log.Errorf("handle gateway status error: %s", err)
+				}
+			}
 		}(statsPacket)
 	}
 }
@@ -407,7 +700,11 @@ func handleStatsPacket(db *sqlx.DB, stats gw.GatewayStatsPacket) error {
 	gw, err := GetGateway(db, stats.MAC)
 	if err != nil {
 		// create the gateway
-		if err == ErrDoesNotExist && common.CreateGatewayOnStats {
+		if err == ErrDoesNotExist && stats.OnboardingToken != "" {
+			if gw, err = registerGateway(db, stats, location, altitude); err != nil {
+				return errors.Wrap(err, "register gateway error")
+			}
+		} else if err == ErrDoesNotExist && common.CreateGatewayOnStats {
 			now := time.Now()
 
 			gw = Gateway{
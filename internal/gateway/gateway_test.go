@@ -1,11 +1,13 @@
 package gateway
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
 	"github.com/pkg/errors"
@@ -136,6 +138,10 @@ func TestGatewayFunctions(t *testing.T) {
 					Latitude:  1.23456789,
 					Longitude: 4.56789012,
 				},
+				Region:     "EU",
+				TXPower:    20,
+				RSSIOffset: -3,
+				Tags:       models.Tags{"site": "rooftop"},
 			}
 			So(CreateGateway(db, &gw), ShouldBeNil)
 
@@ -160,15 +166,46 @@ func TestGatewayFunctions(t *testing.T) {
 				So(gw3.MAC, ShouldResemble, gw.MAC)
 			})
 
+			Convey("Then the gateway regions can be retrieved (omitting unknown gateways)", func() {
+				regions, err := GetGatewayRegions(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+				So(err, ShouldBeNil)
+				So(regions, ShouldResemble, map[lorawan.EUI64]string{
+					gw.MAC: "EU",
+				})
+			})
+
+			Convey("Then the rx calibrations can be retrieved (omitting unknown gateways)", func() {
+				calibrations, err := GetGatewayRXCalibrations(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+				So(err, ShouldBeNil)
+				So(calibrations, ShouldResemble, map[lorawan.EUI64]RXCalibration{
+					gw.MAC: {RSSIOffset: -3},
+				})
+			})
+
+			Convey("Then it is not returned as receive-only gateway", func() {
+				receiveOnly, err := GetReceiveOnlyGateways(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+				So(err, ShouldBeNil)
+				So(receiveOnly, ShouldHaveLength, 0)
+			})
+
 			Convey("Then it can be updated", func() {
 				now := time.Now().UTC().Truncate(time.Millisecond)
 				altitude := 100.5
+				iPol := false
 
 				gw.FirstSeenAt = &now
 				gw.LastSeenAt = &now
 				gw.Location.Latitude = 1.23456780
 				gw.Location.Longitude = 5.56789012
 				gw.Altitude = &altitude
+				gw.Region = "NL"
+				gw.CodeRate = "4/6"
+				gw.IPol = &iPol
+				gw.RSSIOffset = 2
+				gw.LoRaSNROffset = -1.5
+				gw.HasGPS = true
+				gw.FineTimestamp = true
+				gw.ReceiveOnly = true
 
 				So(UpdateGateway(db, &gw), ShouldBeNil)
 
@@ -182,16 +219,51 @@ func TestGatewayFunctions(t *testing.T) {
 				So(gw2.LastSeenAt.UTC().Truncate(time.Millisecond), ShouldResemble, gw.LastSeenAt.UTC().Truncate(time.Millisecond))
 				So(gw2.Location, ShouldResemble, gw.Location)
 				So(gw2.Altitude, ShouldResemble, gw.Altitude)
+				So(gw2.Region, ShouldEqual, "NL")
+				So(gw2.TXParams(), ShouldResemble, models.TXParams{Power: 20, CodeRate: "4/6", IPol: &iPol})
+				So(gw2.RSSIOffset, ShouldEqual, 2)
+				So(gw2.LoRaSNROffset, ShouldEqual, -1.5)
+				So(gw2.HasGPS, ShouldBeTrue)
+				So(gw2.FineTimestamp, ShouldBeTrue)
+				So(gw2.ReceiveOnly, ShouldBeTrue)
+
+				Convey("Then it is returned as receive-only gateway", func() {
+					receiveOnly, err := GetReceiveOnlyGateways(db, []lorawan.EUI64{gw.MAC, {1, 2, 3, 4, 5, 6, 7, 8}})
+					So(err, ShouldBeNil)
+					So(receiveOnly, ShouldResemble, map[lorawan.EUI64]struct{}{
+						gw.MAC: {},
+					})
+				})
 			})
 
 			Convey("Then the gateway count is 1", func() {
-				count, err := GetGatewayCount(db)
+				count, err := GetGatewayCount(db, ListFilters{})
 				So(err, ShouldBeNil)
 				So(count, ShouldEqual, 1)
 			})
 
+			Convey("Then the search filter is applied to the gateway count", func() {
+				count, err := GetGatewayCount(db, ListFilters{Search: gw.MAC.String()[2:6]})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				count, err = GetGatewayCount(db, ListFilters{Search: "does-not-match"})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
+			Convey("Then the tags filter is applied to the gateway count", func() {
+				count, err := GetGatewayCount(db, ListFilters{Tags: models.Tags{"site": "rooftop"}})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				count, err = GetGatewayCount(db, ListFilters{Tags: models.Tags{"site": "basement"}})
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+
 			Convey("Then listing the gateways returns the expected item", func() {
-				gws, err := GetGateways(db, 10, 0)
+				gws, err := GetGateways(db, ListFilters{OrderBy: OrderByLastSeenAt, Descending: true}, 10, 0)
 				So(err, ShouldBeNil)
 				So(gws, ShouldHaveLength, 1)
 
@@ -208,3 +280,27 @@ func TestGatewayFunctions(t *testing.T) {
 		})
 	})
 }
+
+func TestListFiltersOrderClause(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		testTable := []struct {
+			Filters ListFilters
+			Order   string
+			Error   bool
+		}{
+			{ListFilters{}, "mac asc", false},
+			{ListFilters{Descending: true}, "mac desc", false},
+			{ListFilters{OrderBy: OrderByName}, "name asc, mac asc", false},
+			{ListFilters{OrderBy: OrderByLastSeenAt, Descending: true}, "last_seen_at desc nulls last, mac desc", false},
+			{ListFilters{OrderBy: "mac; drop table gateway"}, "", true},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				order, err := test.Filters.orderClause()
+				So(order, ShouldEqual, test.Order)
+				So(err != nil, ShouldEqual, test.Error)
+			})
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package gateway
+
+import (
+	"database/sql"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// DefaultOnboardingTokenTTL defines the validity of an onboarding token
+// when no TTL is given.
+const DefaultOnboardingTokenTTL = time.Hour * 24
+
+// OnboardingToken contains a one-time token with which a gateway, which has
+// not been created yet, registers itself on its first stats message. The
+// gateway is created using the description, region and tags of the token.
+type OnboardingToken struct {
+	Token       string         `db:"token"`
+	Description string         `db:"description"`
+	Region      string         `db:"region"`
+	Tags        models.Tags    `db:"tags"`
+	CreatedAt   time.Time      `db:"created_at"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	UsedAt      *time.Time     `db:"used_at"`
+	UsedBy      *lorawan.EUI64 `db:"used_by"`
+}
+
+// CreateOnboardingToken generates and stores a new onboarding token, valid
+// for the given duration (DefaultOnboardingTokenTTL when 0).
+func CreateOnboardingToken(db *sqlx.DB, t *OnboardingToken, ttl time.Duration) error {
+	if err := t.Tags.Validate(); err != nil {
+		return err
+	}
+	if ttl == 0 {
+		ttl = DefaultOnboardingTokenTTL
+	}
+
+	token, err := newCUPSToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t.Token = token
+	t.CreatedAt = now
+	t.ExpiresAt = now.Add(ttl)
+	t.UsedAt = nil
+	t.UsedBy = nil
+
+	_, err = db.Exec(`
+		insert into gateway_onboarding_token (
+			token,
+			description,
+			region,
+			tags,
+			created_at,
+			expires_at
+		) values ($1, $2, $3, $4, $5, $6)`,
+		t.Token,
+		t.Description,
+		t.Region,
+		t.Tags,
+		t.CreatedAt,
+		t.ExpiresAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+
+	log.WithField("expires_at", t.ExpiresAt).Info("gateway onboarding token created")
+	return nil
+}
+
+// GetOnboardingTokenCount returns the number of onboarding tokens.
+func GetOnboardingTokenCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from gateway_onboarding_token")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetOnboardingTokens returns a slice of onboarding tokens, ordered by
+// creation timestamp (newest first) and respecting the given limit and
+// offset.
+func GetOnboardingTokens(db *sqlx.DB, limit, offset int) ([]OnboardingToken, error) {
+	var tokens []OnboardingToken
+	err := db.Select(&tokens, `
+		select *
+		from gateway_onboarding_token
+		order by created_at desc, token
+		limit $1 offset $2`,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return tokens, nil
+}
+
+// DeleteOnboardingToken deletes (revokes) the given onboarding token.
+func DeleteOnboardingToken(db *sqlx.DB, token string) error {
+	res, err := db.Exec("delete from gateway_onboarding_token where token = $1", token)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrOnboardingTokenDoesNotExist
+	}
+
+	log.Info("gateway onboarding token deleted")
+	return nil
+}
+
+// claimOnboardingToken marks the given onboarding token as used by the
+// given gateway. It returns ErrInvalidOnboardingToken when the token does
+// not exist, has expired or has already been used.
+func claimOnboardingToken(db *sqlx.DB, token string, mac lorawan.EUI64, now time.Time) (OnboardingToken, error) {
+	var t OnboardingToken
+	err := db.Get(&t, `
+		update gateway_onboarding_token set
+			used_at = $3,
+			used_by = $2
+		where
+			token = $1
+			and used_at is null
+			and expires_at > $3
+		returning *`,
+		token,
+		mac[:],
+		now,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return t, ErrInvalidOnboardingToken
+		}
+		return t, errors.Wrap(err, "update error")
+	}
+	return t, nil
+}
+
+// releaseOnboardingToken makes the given (claimed) onboarding token
+// available again, e.g. when the gateway could not be created.
+func releaseOnboardingToken(db *sqlx.DB, token string) error {
+	_, err := db.Exec(`
+		update gateway_onboarding_token set
+			used_at = null,
+			used_by = null
+		where token = $1`,
+		token,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	return nil
+}
+
+// registerGateway creates the gateway of the given stats packet using the
+// onboarding token of the packet.
+func registerGateway(db *sqlx.DB, stats gw.GatewayStatsPacket, location *GPSPoint, altitude *float64) (Gateway, error) {
+	now := time.Now()
+
+	t, err := claimOnboardingToken(db, stats.OnboardingToken, stats.MAC, now)
+	if err != nil {
+		return Gateway{}, err
+	}
+
+	gateway := Gateway{
+		MAC:         stats.MAC,
+		Name:        stats.MAC.String(),
+		Description: t.Description,
+		FirstSeenAt: &now,
+		LastSeenAt:  &now,
+		Location:    location,
+		Altitude:    altitude,
+		Region:      t.Region,
+		Tags:        t.Tags,
+	}
+	if err := CreateGateway(db, &gateway); err != nil {
+		if err := releaseOnboardingToken(db, t.Token); err != nil {
+			log.WithField("mac", stats.MAC).Errorf("release onboarding token error: %s", err)
+		}
+		return Gateway{}, err
+	}
+
+	log.WithField("mac", stats.MAC).Info("gateway registered using onboarding token")
+	return gateway, nil
+}
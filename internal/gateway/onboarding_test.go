@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestOnboardingToken(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database and an onboarding token", t, func() {
+		common.CreateGatewayOnStats = false
+		test.MustResetDB(db)
+		MustSetStatsAggregationIntervals([]string{"MINUTE"})
+
+		token := OnboardingToken{
+			Description: "site A",
+			Region:      "NL",
+			Tags:        models.Tags{"customer": "acme"},
+		}
+		So(CreateOnboardingToken(db, &token, 0), ShouldBeNil)
+		So(token.Token, ShouldHaveLength, 64)
+		So(token.ExpiresAt.Sub(token.CreatedAt), ShouldEqual, DefaultOnboardingTokenTTL)
+
+		stats := gw.GatewayStatsPacket{
+			MAC:             lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			OnboardingToken: token.Token,
+		}
+
+		Convey("When a new gateway sends stats with the token", func() {
+			So(handleStatsPacket(db, stats), ShouldBeNil)
+
+			Convey("Then the gateway has been created using the defaults of the token", func() {
+				gateway, err := GetGateway(db, stats.MAC)
+				So(err, ShouldBeNil)
+				So(gateway.Name, ShouldEqual, stats.MAC.String())
+				So(gateway.Description, ShouldEqual, "site A")
+				So(gateway.Region, ShouldEqual, "NL")
+				So(gateway.Tags, ShouldResemble, models.Tags{"customer": "acme"})
+			})
+
+			Convey("Then the token has been used by the gateway", func() {
+				tokens, err := GetOnboardingTokens(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(tokens, ShouldHaveLength, 1)
+				So(tokens[0].UsedAt, ShouldNotBeNil)
+				So(tokens[0].UsedBy, ShouldResemble, &stats.MAC)
+			})
+
+			Convey("Then an other gateway can not use the same token", func() {
+				stats.MAC = lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+				err := handleStatsPacket(db, stats)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidOnboardingToken)
+
+				_, err = GetGateway(db, stats.MAC)
+				So(err, ShouldEqual, ErrDoesNotExist)
+			})
+		})
+
+		Convey("When the token has expired", func() {
+			_, err := db.Exec("update gateway_onboarding_token set expires_at = $1", time.Now().Add(-time.Minute))
+			So(err, ShouldBeNil)
+
+			Convey("Then the gateway is not created", func() {
+				err := handleStatsPacket(db, stats)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidOnboardingToken)
+			})
+		})
+
+		Convey("When the token has been deleted", func() {
+			So(DeleteOnboardingToken(db, token.Token), ShouldBeNil)
+			So(DeleteOnboardingToken(db, token.Token), ShouldEqual, ErrOnboardingTokenDoesNotExist)
+
+			Convey("Then the gateway is not created", func() {
+				err := handleStatsPacket(db, stats)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidOnboardingToken)
+
+				count, err := GetOnboardingTokenCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+	})
+}
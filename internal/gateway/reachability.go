@@ -0,0 +1,139 @@
+package gateway
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// statsReceivedKeyTempl contains per gateway a list of the unix
+	// timestamps (in ms) on which the last stats were received (newest
+	// first).
+	statsReceivedKeyTempl = "lora:ns:gw:stats_received:%s"
+
+	// reachabilitySamples defines the number of stats timestamps kept per
+	// gateway for computing the reachability score.
+	reachabilitySamples = 10
+
+	// ReachabilityRetention defines after how long the stats timestamps of
+	// a gateway which did not send any stats are removed.
+	ReachabilityRetention = time.Hour * 24
+)
+
+type byTime []time.Time
+
+func (s byTime) Len() int           { return len(s) }
+func (s byTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTime) Less(i, j int) bool { return s[i].Before(s[j]) }
+
+// RecordStatsReceived records that stats were received from the given
+// gateway at the given time.
+func RecordStatsReceived(p *redis.Pool, mac lorawan.EUI64, receivedAt time.Time) error {
+	key := fmt.Sprintf(statsReceivedKeyTempl, mac)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, receivedAt.UnixNano()/int64(time.Millisecond))
+	c.Send("LTRIM", key, 0, reachabilitySamples-1)
+	c.Send("PEXPIRE", key, int64(ReachabilityRetention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record stats received error")
+	}
+	return nil
+}
+
+// GetReachabilityScore returns the reachability score of the given gateway
+// at the given time (see reachabilityScore).
+func GetReachabilityScore(p *redis.Pool, mac lorawan.EUI64, now time.Time) (float64, error) {
+	scores, err := GetReachabilityScores(p, []lorawan.EUI64{mac}, now)
+	if err != nil {
+		return 0, err
+	}
+	return scores[mac], nil
+}
+
+// GetReachabilityScores returns the reachability score per gateway at the
+// given time (see reachabilityScore).
+func GetReachabilityScores(p *redis.Pool, macs []lorawan.EUI64, now time.Time) (map[lorawan.EUI64]float64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	for _, mac := range macs {
+		c.Send("LRANGE", fmt.Sprintf(statsReceivedKeyTempl, mac), 0, -1)
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get stats received error")
+	}
+
+	out := make(map[lorawan.EUI64]float64)
+	for _, mac := range macs {
+		values, err := redis.Strings(c.Receive())
+		if err != nil {
+			return nil, errors.Wrap(err, "get stats received error")
+		}
+
+		var timestamps []time.Time
+		for _, v := range values {
+			ms, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse stats received error")
+			}
+			timestamps = append(timestamps, time.Unix(0, ms*int64(time.Millisecond)))
+		}
+		out[mac] = reachabilityScore(timestamps, now)
+	}
+	return out, nil
+}
+
+// reachabilityScore returns the reachability score (0 - 1) of a gateway
+// given the timestamps on which its stats were received. The expected stats
+// interval is the median interval between the timestamps. The score is the
+// number of received stats divided by the number of expected stats,
+// counting the intervals spanning multiple expected intervals (including
+// the time since the last stats) as missed stats. A gateway with too few
+// timestamps for estimating its interval scores 1.
+func reachabilityScore(timestamps []time.Time, now time.Time) float64 {
+	if len(timestamps) < 3 {
+		return 1
+	}
+
+	sorted := make([]time.Time, len(timestamps))
+	copy(sorted, timestamps)
+	sort.Sort(byTime(sorted))
+
+	var intervals []float64
+	for i := 1; i < len(sorted); i++ {
+		intervals = append(intervals, float64(sorted[i].Sub(sorted[i-1])))
+	}
+
+	medians := make([]float64, len(intervals))
+	copy(medians, intervals)
+	sort.Float64s(medians)
+	median := medians[len(medians)/2]
+	if median <= 0 {
+		return 1
+	}
+
+	var missed float64
+	for _, interval := range intervals {
+		missed += math.Max(0, math.Floor(interval/median+0.5)-1)
+	}
+
+	// the next stats are not yet overdue within twice the interval
+	if sinceLast := float64(now.Sub(sorted[len(sorted)-1])); sinceLast > 0 {
+		missed += math.Max(0, math.Floor(sinceLast/median)-1)
+	}
+
+	received := float64(len(intervals))
+	return received / (received + missed)
+}
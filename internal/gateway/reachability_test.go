@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReachabilityScore(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		now := time.Now()
+
+		// every returns n timestamps with the given interval, the newest
+		// at now minus the given offset
+		every := func(n int, interval, offset time.Duration) []time.Time {
+			var out []time.Time
+			for i := 0; i < n; i++ {
+				out = append(out, now.Add(-offset-time.Duration(i)*interval))
+			}
+			return out
+		}
+
+		tests := []struct {
+			Name       string
+			Timestamps []time.Time
+			Expected   float64
+		}{
+			{"no timestamps", nil, 1},
+			{"too few timestamps", every(2, 30*time.Second, 0), 1},
+			{"regular stats", every(10, 30*time.Second, 0), 1},
+			{"regular stats, next stats not yet overdue", every(10, 30*time.Second, 50*time.Second), 1},
+			{"regular stats with jitter", []time.Time{
+				now.Add(-5 * time.Second),
+				now.Add(-33 * time.Second),
+				now.Add(-65 * time.Second),
+				now.Add(-92 * time.Second),
+			}, 1},
+			{"two missed stats", append(every(5, 30*time.Second, 0), every(5, 30*time.Second, 210*time.Second)...), 9.0 / 11.0},
+			{"no stats since 5 minutes", every(10, 30*time.Second, 5*time.Minute), 9.0 / 18.0},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(reachabilityScore(test.Timestamps, now), ShouldAlmostEqual, test.Expected)
+			})
+		}
+	})
+}
+
+func TestReachabilityScores(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac1 := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		mac2 := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		now := time.Now()
+
+		Convey("When recording regular stats for the first gateway and irregular stats for the second gateway", func() {
+			for i := 9; i >= 0; i-- {
+				So(RecordStatsReceived(p, mac1, now.Add(-time.Duration(i)*30*time.Second)), ShouldBeNil)
+			}
+			for _, i := range []int{9, 8, 7, 3, 2, 1} {
+				So(RecordStatsReceived(p, mac2, now.Add(-time.Duration(i)*30*time.Second)), ShouldBeNil)
+			}
+
+			Convey("Then GetReachabilityScores returns the score per gateway", func() {
+				scores, err := GetReachabilityScores(p, []lorawan.EUI64{mac1, mac2}, now.Add(time.Second))
+				So(err, ShouldBeNil)
+				So(scores, ShouldHaveLength, 2)
+				So(scores[mac1], ShouldAlmostEqual, 1)
+				So(scores[mac2], ShouldAlmostEqual, 5.0/8.0)
+			})
+
+			Convey("Then only the last stats timestamps are kept", func() {
+				for i := 0; i < 20; i++ {
+					So(RecordStatsReceived(p, mac1, now.Add(time.Duration(i)*30*time.Second)), ShouldBeNil)
+				}
+
+				c := p.Get()
+				defer c.Close()
+				n, err := redis.Int(c.Do("LLEN", "lora:ns:gw:stats_received:0102030405060708"))
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, reachabilitySamples)
+			})
+		})
+	})
+}
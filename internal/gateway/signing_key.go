@@ -0,0 +1,243 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/backend"
+)
+
+// signingKeyBytes defines the length of a gateway signing key.
+const signingKeyBytes = 32
+
+// signingKeyCacheTTL defines how long the signing key (or the absence of a
+// signing key) of a gateway is cached. Keys rotated or deleted by an other
+// LoRa Server instance are picked up after this duration.
+const signingKeyCacheTTL = time.Minute
+
+type signingKeyCacheItem struct {
+	key     []byte // nil when the gateway has no signing key
+	expires time.Time
+}
+
+// signingKeyCache caches the signing keys per gateway MAC, so that the
+// database is not queried for every uplink packet.
+var signingKeyCache = struct {
+	sync.RWMutex
+	items map[lorawan.EUI64]signingKeyCacheItem
+}{items: make(map[lorawan.EUI64]signingKeyCacheItem)}
+
+// RotateSigningKey generates and stores a new signing key for the given
+// gateway. Once the gateway has a signing key, only uplink packets carrying
+// a valid signature (see VerifyRXPacketSignature) are accepted from it.
+func RotateSigningKey(db *sqlx.DB, mac lorawan.EUI64) ([]byte, error) {
+	key := make([]byte, signingKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+
+	if _, err := GetGateway(db, mac); err != nil {
+		return nil, err
+	}
+
+	_, err := db.Exec(`
+		insert into gateway_signing_key (
+			mac,
+			signing_key,
+			created_at
+		) values ($1, $2, $3)
+		on conflict (mac) do update set
+			signing_key = excluded.signing_key,
+			created_at = excluded.created_at`,
+		mac[:],
+		key,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "insert error")
+	}
+	invalidateSigningKey(mac)
+
+	log.WithField("mac", mac).Info("gateway signing key rotated")
+	return key, nil
+}
+
+// DeleteSigningKey deletes the signing key of the given gateway, after
+// which its uplink packets are accepted without signature (unless signed
+// uplinks are required for all gateways).
+func DeleteSigningKey(db *sqlx.DB, mac lorawan.EUI64) error {
+	_, err := db.Exec("delete from gateway_signing_key where mac = $1", mac[:])
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	invalidateSigningKey(mac)
+
+	log.WithField("mac", mac).Info("gateway signing key deleted")
+	return nil
+}
+
+// getSigningKey returns the signing key of the given gateway or nil when
+// the gateway does not have a signing key.
+func getSigningKey(db *sqlx.DB, mac lorawan.EUI64) ([]byte, error) {
+	var key []byte
+	err := db.Get(&key, "select signing_key from gateway_signing_key where mac = $1", mac[:])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "select error")
+	}
+	return key, nil
+}
+
+// HasSigningKeys returns true when at least one gateway has a signing key.
+func HasSigningKeys(db *sqlx.DB) (bool, error) {
+	var exists bool
+	err := db.Get(&exists, "select exists(select 1 from gateway_signing_key)")
+	if err != nil {
+		return false, errors.Wrap(err, "select error")
+	}
+	return exists, nil
+}
+
+// getCachedSigningKey returns the signing key of the given gateway (or nil
+// when the gateway does not have a signing key) from the signingKeyCache,
+// retrieving it from the database when it is not cached or expired.
+func getCachedSigningKey(db *sqlx.DB, mac lorawan.EUI64) ([]byte, error) {
+	now := time.Now()
+
+	signingKeyCache.RLock()
+	item, ok := signingKeyCache.items[mac]
+	signingKeyCache.RUnlock()
+	if ok && now.Before(item.expires) {
+		return item.key, nil
+	}
+
+	key, err := getSigningKey(db, mac)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKeyCache.Lock()
+	signingKeyCache.items[mac] = signingKeyCacheItem{
+		key:     key,
+		expires: now.Add(signingKeyCacheTTL),
+	}
+	signingKeyCache.Unlock()
+
+	return key, nil
+}
+
+// invalidateSigningKey removes the signing key of the given gateway from
+// the signingKeyCache.
+func invalidateSigningKey(mac lorawan.EUI64) {
+	signingKeyCache.Lock()
+	delete(signingKeyCache.items, mac)
+	signingKeyCache.Unlock()
+}
+
+// VerifyRXPacketSignature returns true when the given packet carries a
+// valid HMAC-SHA256 signature for the given key.
+func VerifyRXPacketSignature(key []byte, rxPacket gw.RXPacket) bool {
+	if len(rxPacket.Signature) == 0 {
+		return false
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(rxPacket.SignedData)
+	return hmac.Equal(h.Sum(nil), rxPacket.Signature)
+}
+
+// SignedUplinkGateway wraps a gateway backend, dropping the received
+// packets of gateways having a signing key which do not carry a valid
+// signature. This prevents spoofed packets from being used for the
+// de-duplication and the ADR (RSSI / SNR) of the nodes. As the receive
+// time of the rxInfo is covered by the signature, replayed signed packets
+// are dropped once their receive time is older than the max. age.
+type SignedUplinkGateway struct {
+	backend.Gateway
+
+	db            *sqlx.DB
+	requireSigned bool
+	maxAge        time.Duration
+	rxPacketChan  chan gw.RXPacket
+}
+
+// NewSignedUplinkGateway creates a new SignedUplinkGateway wrapping the
+// given backend. When requireSigned is set, the packets of gateways without
+// signing key are dropped too. Signed packets must have a receive time
+// which differs at most maxAge from the current time (0 = no limit).
+func NewSignedUplinkGateway(b backend.Gateway, db *sqlx.DB, requireSigned bool, maxAge time.Duration) *SignedUplinkGateway {
+	g := SignedUplinkGateway{
+		Gateway:       b,
+		db:            db,
+		requireSigned: requireSigned,
+		maxAge:        maxAge,
+		rxPacketChan:  make(chan gw.RXPacket),
+	}
+
+	go g.forwardRXPackets()
+	return &g
+}
+
+// forwardRXPackets forwards the received packets of the wrapped backend,
+// except for the packets failing the signature verification. The channel
+// is closed once the channel of the wrapped backend has been closed.
+func (g *SignedUplinkGateway) forwardRXPackets() {
+	for rxPacket := range g.Gateway.RXPacketChan() {
+		if err := g.verify(rxPacket); err != nil {
+			log.WithField("mac", rxPacket.RXInfo.MAC).Warningf("uplink dropped: %s", err)
+			continue
+		}
+		g.rxPacketChan <- rxPacket
+	}
+	close(g.rxPacketChan)
+}
+
+func (g *SignedUplinkGateway) verify(rxPacket gw.RXPacket) error {
+	key, err := getCachedSigningKey(g.db, rxPacket.RXInfo.MAC)
+	if err != nil {
+		return errors.Wrap(err, "get signing key error")
+	}
+
+	if key == nil {
+		if g.requireSigned {
+			return errors.New("gateway has no signing key")
+		}
+		return nil
+	}
+
+	if len(rxPacket.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	if !VerifyRXPacketSignature(key, rxPacket) {
+		return errors.New("signature is invalid")
+	}
+
+	// the receive time is part of the signed rxInfo
+	if g.maxAge != 0 {
+		if rxPacket.RXInfo.Time.IsZero() {
+			return errors.New("receive time is missing")
+		}
+		if age := time.Since(rxPacket.RXInfo.Time); age > g.maxAge || age < -g.maxAge {
+			return errors.Errorf("receive time is stale (age: %s, max: %s)", age, g.maxAge)
+		}
+	}
+	return nil
+}
+
+// RXPacketChan returns the channel containing the received packets which
+// passed the signature verification.
+func (g *SignedUplinkGateway) RXPacketChan() chan gw.RXPacket {
+	return g.rxPacketChan
+}
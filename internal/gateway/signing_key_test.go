@@ -0,0 +1,144 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestSignedUplinkGateway(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database and a gateway with signing key", t, func() {
+		test.MustResetDB(db)
+
+		gateway := Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(CreateGateway(db, &gateway), ShouldBeNil)
+
+		key, err := RotateSigningKey(db, gateway.MAC)
+		So(err, ShouldBeNil)
+		So(key, ShouldHaveLength, signingKeyBytes)
+
+		sign := func(key []byte, data []byte) []byte {
+			h := hmac.New(sha256.New, key)
+			h.Write(data)
+			return h.Sum(nil)
+		}
+
+		signedPacket := func(rxTime time.Time) gw.RXPacket {
+			rxPacket := gw.RXPacket{
+				RXInfo:     gw.RXInfo{MAC: gateway.MAC, Time: rxTime, RSSI: -60},
+				SignedData: []byte(fmt.Sprintf(`{"mac":"0102030405060708","time":"%s","rssi":-60}`, rxTime.Format(time.RFC3339Nano))),
+			}
+			rxPacket.Signature = sign(key, rxPacket.SignedData)
+			return rxPacket
+		}
+		signed := signedPacket(time.Now())
+
+		forwarded := func(g *SignedUplinkGateway, b *test.GatewayBackend, rxPacket gw.RXPacket) int {
+			b.RXPacketChan() <- rxPacket
+			So(g.Close(), ShouldBeNil)
+
+			var count int
+			for range g.RXPacketChan() {
+				count++
+			}
+			return count
+		}
+
+		Convey("Then HasSigningKeys returns true", func() {
+			hasKeys, err := HasSigningKeys(db)
+			So(err, ShouldBeNil)
+			So(hasKeys, ShouldBeTrue)
+
+			Convey("Then HasSigningKeys returns false when the key has been deleted", func() {
+				So(DeleteSigningKey(db, gateway.MAC), ShouldBeNil)
+				hasKeys, err := HasSigningKeys(db)
+				So(err, ShouldBeNil)
+				So(hasKeys, ShouldBeFalse)
+			})
+		})
+
+		Convey("Then VerifyRXPacketSignature validates the signature", func() {
+			So(VerifyRXPacketSignature(key, signed), ShouldBeTrue)
+
+			tampered := signed
+			tampered.SignedData = []byte(`{"mac":"0102030405060708","time":"2017-01-01T00:00:00Z","rssi":-30}`)
+			So(VerifyRXPacketSignature(key, tampered), ShouldBeFalse)
+		})
+
+		Convey("Given a SignedUplinkGateway", func() {
+			b := test.NewGatewayBackend()
+			g := NewSignedUplinkGateway(b, db, false, time.Minute)
+
+			Convey("Then a packet with valid signature is forwarded", func() {
+				So(forwarded(g, b, signed), ShouldEqual, 1)
+			})
+
+			Convey("Then a packet without signature is dropped", func() {
+				So(forwarded(g, b, gw.RXPacket{RXInfo: gw.RXInfo{MAC: gateway.MAC}}), ShouldEqual, 0)
+			})
+
+			Convey("Then a packet signed with another key is dropped", func() {
+				rxPacket := signed
+				rxPacket.Signature = sign([]byte("other key"), signed.SignedData)
+				So(forwarded(g, b, rxPacket), ShouldEqual, 0)
+			})
+
+			Convey("Then a signed packet with a stale receive time is dropped", func() {
+				So(forwarded(g, b, signedPacket(time.Now().Add(-2*time.Minute))), ShouldEqual, 0)
+			})
+
+			Convey("Then a signed packet without receive time is dropped", func() {
+				rxPacket := gw.RXPacket{
+					RXInfo:     gw.RXInfo{MAC: gateway.MAC},
+					SignedData: []byte(`{"mac":"0102030405060708"}`),
+				}
+				rxPacket.Signature = sign(key, rxPacket.SignedData)
+				So(forwarded(g, b, rxPacket), ShouldEqual, 0)
+			})
+
+			Convey("Then an unsigned packet of a gateway without signing key is forwarded", func() {
+				So(DeleteSigningKey(db, gateway.MAC), ShouldBeNil)
+				So(forwarded(g, b, gw.RXPacket{RXInfo: gw.RXInfo{MAC: gateway.MAC}}), ShouldEqual, 1)
+			})
+
+			Convey("Then a packet signed with the rotated key is forwarded", func() {
+				// cache the current key
+				So(forwarded(g, b, signed), ShouldEqual, 1)
+
+				key, err = RotateSigningKey(db, gateway.MAC)
+				So(err, ShouldBeNil)
+
+				b := test.NewGatewayBackend()
+				g := NewSignedUplinkGateway(b, db, false, time.Minute)
+				So(forwarded(g, b, signedPacket(time.Now())), ShouldEqual, 1)
+			})
+		})
+
+		Convey("Given a SignedUplinkGateway requiring signed uplinks", func() {
+			b := test.NewGatewayBackend()
+			g := NewSignedUplinkGateway(b, db, true, time.Minute)
+
+			Convey("Then an unsigned packet of a gateway without signing key is dropped", func() {
+				So(forwarded(g, b, gw.RXPacket{RXInfo: gw.RXInfo{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}}}), ShouldEqual, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,260 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/webhook"
+)
+
+// statsPushDelay defines the delay after the end of an aggregation interval
+// before pushing its stats, so that the stats sent by the gateways at the
+// end of the interval have been aggregated.
+const statsPushDelay = 30 * time.Second
+
+// statsPushPageSize defines the number of gateways to fetch at once when
+// pushing the stats.
+const statsPushPageSize = 100
+
+// PushedStats contains the aggregated stats of a gateway for a single
+// aggregation interval, as pushed by a StatsPusher.
+type PushedStats struct {
+	Stats
+	Name     string
+	Location *GPSPoint
+	Altitude *float64
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s PushedStats) MarshalJSON() ([]byte, error) {
+	out := struct {
+		MAC                 string    `json:"mac"`
+		Name                string    `json:"name"`
+		Timestamp           time.Time `json:"timestamp"`
+		Interval            string    `json:"interval"`
+		RXPacketsReceived   int       `json:"rxPacketsReceived"`
+		RXPacketsReceivedOK int       `json:"rxPacketsReceivedOK"`
+		TXPacketsReceived   int       `json:"txPacketsReceived"`
+		TXPacketsEmitted    int       `json:"txPacketsEmitted"`
+		Latitude            *float64  `json:"latitude,omitempty"`
+		Longitude           *float64  `json:"longitude,omitempty"`
+		Altitude            *float64  `json:"altitude,omitempty"`
+	}{
+		MAC:                 s.MAC.String(),
+		Name:                s.Name,
+		Timestamp:           s.Timestamp,
+		Interval:            s.Interval,
+		RXPacketsReceived:   s.RXPacketsReceived,
+		RXPacketsReceivedOK: s.RXPacketsReceivedOK,
+		TXPacketsReceived:   s.TXPacketsReceived,
+		TXPacketsEmitted:    s.TXPacketsEmitted,
+		Altitude:            s.Altitude,
+	}
+	if s.Location != nil {
+		out.Latitude = &s.Location.Latitude
+		out.Longitude = &s.Location.Longitude
+	}
+	return json.Marshal(out)
+}
+
+// StatsPusher defines the interface for pushing the aggregated gateway
+// stats to an external endpoint.
+type StatsPusher interface {
+	PushStats(stats []PushedStats) error
+}
+
+// HTTPStatsPusher pushes the aggregated gateway stats as a JSON array
+// (HTTP POST) to the given URL.
+type HTTPStatsPusher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPStatsPusher creates a new HTTPStatsPusher.
+func NewHTTPStatsPusher(url string) *HTTPStatsPusher {
+	return &HTTPStatsPusher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// PushStats pushes the given stats.
+func (p *HTTPStatsPusher) PushStats(stats []PushedStats) error {
+	return webhook.Post(p.Client, p.URL, stats)
+}
+
+// ApplicationServerStatsPusher pushes the aggregated gateway stats to the
+// application-server (HandleGatewayStats).
+type ApplicationServerStatsPusher struct {
+	Client as.ApplicationServerClient
+}
+
+// PushStats pushes the given stats.
+func (p *ApplicationServerStatsPusher) PushStats(stats []PushedStats) error {
+	for _, s := range stats {
+		// make sure we have a copy of the MAC byte slice
+		mac := make([]byte, 8)
+		copy(mac, s.MAC[:])
+
+		req := as.HandleGatewayStatsRequest{
+			Mac:                 mac,
+			Name:                s.Name,
+			Timestamp:           s.Timestamp.Format(time.RFC3339Nano),
+			Interval:            s.Interval,
+			RxPacketsReceived:   int32(s.RXPacketsReceived),
+			RxPacketsReceivedOK: int32(s.RXPacketsReceivedOK),
+			TxPacketsReceived:   int32(s.TXPacketsReceived),
+			TxPacketsEmitted:    int32(s.TXPacketsEmitted),
+		}
+		if s.Location != nil {
+			req.Latitude = s.Location.Latitude
+			req.Longitude = s.Location.Longitude
+		}
+		if s.Altitude != nil {
+			req.Altitude = *s.Altitude
+		}
+
+		if _, err := p.Client.HandleGatewayStats(context.Background(), &req); err != nil {
+			return errors.Wrap(err, "handle gateway stats error")
+		}
+	}
+	return nil
+}
+
+var statsPushInterval string
+var statsPusher StatsPusher
+
+// MustSetStatsPusher sets the pusher to which the aggregated stats of the
+// given interval are pushed on each aggregation tick. The interval must
+// be one of the configured aggregation intervals and MINUTE, HOUR or DAY.
+func MustSetStatsPusher(interval string, pusher StatsPusher) {
+	interval = strings.ToUpper(interval)
+
+	if _, err := getStatsPushIntervalStart(time.Now(), interval); err != nil {
+		log.Fatal(err)
+	}
+
+	var valid bool
+	for _, i := range statsAggregationIntervals {
+		if i == interval {
+			valid = true
+		}
+	}
+	if !valid {
+		log.Fatalf("stats push interval '%s' is not one of the aggregation intervals", interval)
+	}
+
+	statsPushInterval = interval
+	statsPusher = pusher
+}
+
+// getStatsPushIntervalStart returns the start of the aggregation interval
+// containing the given timestamp.
+func getStatsPushIntervalStart(t time.Time, interval string) (time.Time, error) {
+	t = t.In(common.TimeLocation)
+
+	switch interval {
+	case "MINUTE":
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location()), nil
+	case "HOUR":
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()), nil
+	case "DAY":
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+	default:
+		return time.Time{}, fmt.Errorf("'%s' is not a valid stats push interval (valid: MINUTE, HOUR, DAY)", interval)
+	}
+}
+
+// getStatsPushIntervalEnd returns the end of the aggregation interval
+// starting at the given timestamp.
+func getStatsPushIntervalEnd(start time.Time, interval string) time.Time {
+	switch interval {
+	case "MINUTE":
+		return start.Add(time.Minute)
+	case "HOUR":
+		return start.Add(time.Hour)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// getPushedStats returns the aggregated stats of all gateways for the
+// aggregation interval starting at the given timestamp.
+func getPushedStats(db *sqlx.DB, interval string, start time.Time) ([]PushedStats, error) {
+	var out []PushedStats
+
+	for offset := 0; ; offset += statsPushPageSize {
+		gws, err := GetGateways(db, ListFilters{}, statsPushPageSize, offset)
+		if err != nil {
+			return nil, errors.Wrap(err, "get gateways error")
+		}
+
+		for _, gw := range gws {
+			stats, err := GetGatewayStats(db, gw.MAC, interval, start, start)
+			if err != nil {
+				return nil, errors.Wrap(err, "get gateway stats error")
+			}
+
+			for _, s := range stats {
+				out = append(out, PushedStats{
+					Stats:    s,
+					Name:     gw.Name,
+					Location: gw.Location,
+					Altitude: gw.Altitude,
+				})
+			}
+		}
+
+		if len(gws) < statsPushPageSize {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+// pushStats pushes the aggregated stats of the last completed aggregation
+// interval on each aggregation tick, until Stop is called.
+func (s *StatsHandler) pushStats() {
+	for {
+		start, _ := getStatsPushIntervalStart(time.Now(), statsPushInterval)
+		end := getStatsPushIntervalEnd(start, statsPushInterval)
+
+		select {
+		case <-time.After(end.Add(statsPushDelay).Sub(time.Now())):
+		case <-s.done:
+			return
+		}
+
+		if s.elector != nil && !s.elector.IsLeader() {
+			continue
+		}
+
+		stats, err := getPushedStats(s.ctx.DB, statsPushInterval, start)
+		if err != nil {
+			log.Errorf("get gateway stats to push error: %s", err)
+			continue
+		}
+
+		if err := statsPusher.PushStats(stats); err != nil {
+			log.WithField("interval", statsPushInterval).Errorf("push gateway stats error: %s", err)
+			continue
+		}
+
+		log.WithFields(log.Fields{
+			"interval":  statsPushInterval,
+			"timestamp": start,
+			"gateways":  len(stats),
+		}).Info("gateway stats pushed")
+	}
+}
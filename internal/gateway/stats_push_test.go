@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStatsPushInterval(t *testing.T) {
+	Convey("Given the UTC timezone", t, func() {
+		common.TimeLocation = time.UTC
+		ts := time.Date(2017, 3, 14, 10, 21, 33, 0, time.UTC)
+
+		testTable := []struct {
+			Interval string
+			Start    time.Time
+			End      time.Time
+		}{
+			{"MINUTE", time.Date(2017, 3, 14, 10, 21, 0, 0, time.UTC), time.Date(2017, 3, 14, 10, 22, 0, 0, time.UTC)},
+			{"HOUR", time.Date(2017, 3, 14, 10, 0, 0, 0, time.UTC), time.Date(2017, 3, 14, 11, 0, 0, 0, time.UTC)},
+			{"DAY", time.Date(2017, 3, 14, 0, 0, 0, 0, time.UTC), time.Date(2017, 3, 15, 0, 0, 0, 0, time.UTC)},
+		}
+
+		for _, test := range testTable {
+			Convey("Then the interval boundaries are correct for "+test.Interval, func() {
+				start, err := getStatsPushIntervalStart(ts, test.Interval)
+				So(err, ShouldBeNil)
+				So(start, ShouldResemble, test.Start)
+				So(getStatsPushIntervalEnd(start, test.Interval), ShouldResemble, test.End)
+			})
+		}
+
+		Convey("Then an unsupported interval returns an error", func() {
+			_, err := getStatsPushIntervalStart(ts, "WEEK")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestStatsPushers(t *testing.T) {
+	Convey("Given a set of pushed stats", t, func() {
+		alt := 10.5
+		stats := []PushedStats{
+			{
+				Stats: Stats{
+					MAC:                 lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					Timestamp:           time.Date(2017, 3, 14, 10, 21, 0, 0, time.UTC),
+					Interval:            "MINUTE",
+					RXPacketsReceived:   11,
+					RXPacketsReceivedOK: 9,
+					TXPacketsReceived:   3,
+					TXPacketsEmitted:    2,
+				},
+				Name:     "test-gw",
+				Location: &GPSPoint{Latitude: 1.123, Longitude: 2.123},
+				Altitude: &alt,
+			},
+		}
+
+		Convey("Given an HTTPStatsPusher", func() {
+			bodyChan := make(chan []byte, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				bodyChan <- b
+			}))
+			defer server.Close()
+			pusher := NewHTTPStatsPusher(server.URL)
+
+			Convey("When pushing the stats", func() {
+				So(pusher.PushStats(stats), ShouldBeNil)
+
+				Convey("Then the stats were posted as json", func() {
+					var out []map[string]interface{}
+					So(json.Unmarshal(<-bodyChan, &out), ShouldBeNil)
+					So(out, ShouldResemble, []map[string]interface{}{
+						{
+							"mac":                 "0102030405060708",
+							"name":                "test-gw",
+							"timestamp":           "2017-03-14T10:21:00Z",
+							"interval":            "MINUTE",
+							"rxPacketsReceived":   float64(11),
+							"rxPacketsReceivedOK": float64(9),
+							"txPacketsReceived":   float64(3),
+							"txPacketsEmitted":    float64(2),
+							"latitude":            1.123,
+							"longitude":           2.123,
+							"altitude":            10.5,
+						},
+					})
+				})
+			})
+		})
+
+		Convey("Given an HTTPStatsPusher and an endpoint returning an error", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+			pusher := NewHTTPStatsPusher(server.URL)
+
+			Convey("Then pushing the stats returns an error", func() {
+				So(pusher.PushStats(stats), ShouldNotBeNil)
+			})
+		})
+
+		Convey("Given an ApplicationServerStatsPusher", func() {
+			app := test.NewApplicationClient()
+			pusher := ApplicationServerStatsPusher{Client: app}
+
+			Convey("When pushing the stats", func() {
+				So(pusher.PushStats(stats), ShouldBeNil)
+
+				Convey("Then HandleGatewayStats was called", func() {
+					req := <-app.HandleGatewayStatsChan
+					So(req.Mac, ShouldResemble, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+					So(req.Name, ShouldEqual, "test-gw")
+					So(req.Timestamp, ShouldEqual, "2017-03-14T10:21:00Z")
+					So(req.Interval, ShouldEqual, "MINUTE")
+					So(req.RxPacketsReceived, ShouldEqual, 11)
+					So(req.RxPacketsReceivedOK, ShouldEqual, 9)
+					So(req.TxPacketsReceived, ShouldEqual, 3)
+					So(req.TxPacketsEmitted, ShouldEqual, 2)
+					So(req.Latitude, ShouldEqual, 1.123)
+					So(req.Longitude, ShouldEqual, 2.123)
+					So(req.Altitude, ShouldEqual, 10.5)
+				})
+			})
+		})
+	})
+}
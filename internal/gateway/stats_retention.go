@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// statsRetention contains per aggregation interval the duration after which
+// the aggregated gateway stats are removed. The stats of intervals without
+// retention are kept forever.
+var statsRetention map[string]time.Duration
+
+// statsCompactionInterval defines the interval on which the expired gateway
+// stats are downsampled and removed.
+var statsCompactionInterval time.Duration
+
+// MustSetStatsRetention sets the retention of the aggregated gateway stats,
+// formatted as comma separated interval=duration pairs (e.g.
+// minute=24h,hour=720h), and the interval on which the expired stats are
+// downsampled and removed. The intervals must be one of the aggregation
+// intervals.
+func MustSetStatsRetention(retention string, compactionInterval time.Duration) {
+	r, err := parseStatsRetention(retention)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(r) > 0 && compactionInterval <= 0 {
+		log.Fatal("the stats compaction interval must be greater than 0")
+	}
+
+	statsRetention = r
+	statsCompactionInterval = compactionInterval
+}
+
+// parseStatsRetention parses the given comma separated interval=duration
+// pairs.
+func parseStatsRetention(retention string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration)
+
+	for _, item := range strings.Split(retention, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid stats retention '%s' (expected interval=duration)", item)
+		}
+
+		interval := strings.ToUpper(strings.TrimSpace(parts[0]))
+		if !isStatsAggregationInterval(interval) {
+			return nil, fmt.Errorf("stats retention interval '%s' is not one of the aggregation intervals", interval)
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse stats retention of '%s' error", interval)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("stats retention of '%s' must be greater than 0", interval)
+		}
+
+		out[interval] = d
+	}
+
+	return out, nil
+}
+
+// isStatsAggregationInterval returns true when the given interval is one of
+// the configured aggregation intervals.
+func isStatsAggregationInterval(interval string) bool {
+	for _, i := range statsAggregationIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// getCoarserStatsAggregationInterval returns the first configured
+// aggregation interval which is coarser than the given interval (empty when
+// there is none).
+func getCoarserStatsAggregationInterval(interval string) string {
+	var found bool
+	for _, i := range validStatsAggregationIntervals {
+		if i == interval {
+			found = true
+			continue
+		}
+		if found && isStatsAggregationInterval(i) {
+			return i
+		}
+	}
+	return ""
+}
+
+// compactGatewayStats removes the aggregated gateway stats which have
+// exceeded the retention of their interval. Before removing, the stats are
+// downsampled into the next coarser aggregation interval (e.g. minute into
+// hour) in case these are missing for the removed period. As all configured
+// intervals are aggregated on receiving the stats, existing stats of the
+// coarser interval are left untouched. Only complete intervals of the
+// coarser interval are removed, so that the downsampled stats are never
+// partial. It returns the number of removed records.
+func compactGatewayStats(db *sqlx.DB, now time.Time) (int64, error) {
+	comitted := false
+	tx, err := db.Beginx()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin transaction error")
+	}
+	defer func() {
+		if !comitted {
+			tx.Rollback()
+		}
+	}()
+
+	// set the database timezone for this transaction
+	if common.TimeLocation != time.Local {
+		// when TimeLocation == time.Local, it would have 'Local' as name
+		_, err = tx.Exec(fmt.Sprintf("set local time zone '%s'", common.TimeLocation.String()))
+		if err != nil {
+			return 0, errors.Wrap(err, "set timezone error")
+		}
+	}
+
+	var removed int64
+
+	// from fine to coarse, so that the downsampled stats are subject to the
+	// retention of the coarser interval
+	for _, interval := range validStatsAggregationIntervals {
+		retention, ok := statsRetention[interval]
+		if !ok || !isStatsAggregationInterval(interval) {
+			continue
+		}
+		cutoff := now.Add(-retention)
+
+		var res sql.Result
+		coarser := getCoarserStatsAggregationInterval(interval)
+		if coarser != "" {
+			_, err = tx.Exec(`
+				insert into gateway_stats (
+					mac,
+					"timestamp",
+					"interval",
+					rx_packets_received,
+					rx_packets_received_ok,
+					tx_packets_received,
+					tx_packets_emitted
+				)
+				select
+					mac,
+					cast(date_trunc($2, "timestamp") as timestamp with time zone),
+					$2,
+					sum(rx_packets_received),
+					sum(rx_packets_received_ok),
+					sum(tx_packets_received),
+					sum(tx_packets_emitted)
+				from gateway_stats
+				where
+					"interval" = $1
+					and "timestamp" < cast(date_trunc($2, $3::timestamptz) as timestamp with time zone)
+				group by 1, 2
+				on conflict (mac, "timestamp", "interval") do nothing`,
+				interval,
+				coarser,
+				cutoff,
+			)
+			if err != nil {
+				return 0, errors.Wrap(err, "downsample gateway stats error")
+			}
+
+			// only complete intervals of the coarser interval are removed
+			res, err = tx.Exec(`
+				delete from gateway_stats
+				where
+					"interval" = $1
+					and "timestamp" < cast(date_trunc($2, $3::timestamptz) as timestamp with time zone)`,
+				interval,
+				coarser,
+				cutoff,
+			)
+		} else {
+			res, err = tx.Exec(`
+				delete from gateway_stats
+				where
+					"interval" = $1
+					and "timestamp" < $2`,
+				interval,
+				cutoff,
+			)
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "delete gateway stats error")
+		}
+		ra, err := res.RowsAffected()
+		if err != nil {
+			return 0, errors.Wrap(err, "get rows affected error")
+		}
+		removed += ra
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit error")
+	}
+	comitted = true
+	return removed, nil
+}
+
+// compactStats periodically downsamples and removes the expired gateway
+// stats, until Stop is called.
+func (s *StatsHandler) compactStats() {
+	ticker := time.NewTicker(statsCompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if s.elector != nil && !s.elector.IsLeader() {
+				continue
+			}
+
+			removed, err := compactGatewayStats(s.ctx.DB, time.Now())
+			if err != nil {
+				log.Errorf("compact gateway stats error: %s", err)
+				continue
+			}
+			if removed > 0 {
+				log.WithField("removed", removed).Info("expired gateway stats removed")
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseStatsRetention(t *testing.T) {
+	Convey("Given the MINUTE, HOUR and DAY aggregation intervals", t, func() {
+		MustSetStatsAggregationIntervals([]string{"MINUTE", "HOUR", "DAY"})
+
+		Convey("Then a valid retention is parsed", func() {
+			r, err := parseStatsRetention("minute=24h, HOUR=720h")
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, map[string]time.Duration{
+				"MINUTE": 24 * time.Hour,
+				"HOUR":   720 * time.Hour,
+			})
+		})
+
+		Convey("Then an empty retention is valid", func() {
+			r, err := parseStatsRetention("")
+			So(err, ShouldBeNil)
+			So(r, ShouldHaveLength, 0)
+		})
+
+		Convey("Then an invalid retention returns an error", func() {
+			for _, retention := range []string{"minute", "second=1h", "hour=abc", "hour=0s"} {
+				_, err := parseStatsRetention(retention)
+				So(err, ShouldNotBeNil)
+			}
+		})
+
+		Convey("Then the coarser interval is the next configured interval", func() {
+			So(getCoarserStatsAggregationInterval("MINUTE"), ShouldEqual, "HOUR")
+			So(getCoarserStatsAggregationInterval("HOUR"), ShouldEqual, "DAY")
+			So(getCoarserStatsAggregationInterval("DAY"), ShouldEqual, "")
+		})
+	})
+}
+
+func TestCompactGatewayStats(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(db)
+		common.TimeLocation = time.UTC
+		MustSetStatsAggregationIntervals([]string{"MINUTE", "HOUR"})
+		defer func() {
+			statsRetention = nil
+		}()
+
+		gw := Gateway{
+			MAC:  [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gateway",
+		}
+		So(CreateGateway(db, &gw), ShouldBeNil)
+
+		now := time.Date(2017, 3, 14, 10, 30, 0, 0, time.UTC)
+		start := time.Date(2017, 3, 14, 8, 0, 0, 0, time.UTC)
+
+		Convey("Given minute stats of which only the minute aggregate exists", func() {
+			// 08:00, 08:30, 09:00 and 09:30
+			for i := 0; i < 4; i++ {
+				So(aggregateGatewayStats(db, Stats{
+					MAC:                 gw.MAC,
+					Timestamp:           start.Add(time.Duration(i) * 30 * time.Minute),
+					Interval:            "MINUTE",
+					RXPacketsReceived:   10,
+					RXPacketsReceivedOK: 9,
+					TXPacketsReceived:   2,
+					TXPacketsEmitted:    1,
+				}), ShouldBeNil)
+			}
+
+			Convey("When compacting with a minute retention of 45 minutes", func() {
+				MustSetStatsRetention("minute=45m", time.Hour)
+				removed, err := compactGatewayStats(db, now)
+				So(err, ShouldBeNil)
+
+				Convey("Then only the minute stats of complete expired hours are removed", func() {
+					So(removed, ShouldEqual, 2)
+
+					stats, err := GetGatewayStats(db, gw.MAC, "MINUTE", start, start.Add(90*time.Minute))
+					So(err, ShouldBeNil)
+					var count int
+					for _, s := range stats {
+						if s.RXPacketsReceived > 0 {
+							count++
+						}
+					}
+					So(count, ShouldEqual, 2)
+				})
+
+				Convey("Then the removed minute stats are downsampled into hour stats", func() {
+					stats, err := GetGatewayStats(db, gw.MAC, "HOUR", start, start.Add(time.Hour))
+					So(err, ShouldBeNil)
+					So(stats, ShouldHaveLength, 2)
+					So(stats[0].RXPacketsReceived, ShouldEqual, 20)
+					So(stats[0].RXPacketsReceivedOK, ShouldEqual, 18)
+					So(stats[0].TXPacketsReceived, ShouldEqual, 4)
+					So(stats[0].TXPacketsEmitted, ShouldEqual, 2)
+					So(stats[1].RXPacketsReceived, ShouldEqual, 0)
+				})
+
+				Convey("Then compacting again does not change the hour stats", func() {
+					removed, err := compactGatewayStats(db, now)
+					So(err, ShouldBeNil)
+					So(removed, ShouldEqual, 0)
+
+					stats, err := GetGatewayStats(db, gw.MAC, "HOUR", start, start)
+					So(err, ShouldBeNil)
+					So(stats, ShouldHaveLength, 1)
+					So(stats[0].RXPacketsReceived, ShouldEqual, 20)
+				})
+			})
+		})
+
+		Convey("Given hour stats", func() {
+			So(aggregateGatewayStats(db, Stats{
+				MAC:               gw.MAC,
+				Timestamp:         start,
+				Interval:          "HOUR",
+				RXPacketsReceived: 10,
+			}), ShouldBeNil)
+
+			Convey("When compacting with an hour retention of 1 hour", func() {
+				MustSetStatsRetention("hour=1h", time.Hour)
+				removed, err := compactGatewayStats(db, now)
+				So(err, ShouldBeNil)
+
+				Convey("Then the expired hour stats are removed", func() {
+					So(removed, ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/digest"
+	"github.com/brocaar/lorawan"
+)
+
+// gatewayLastSeenKey contains a sorted set of gateway MACs, scored by the
+// unix timestamp on which the gateway was last seen.
+const gatewayLastSeenKey = "lora:ns:gw:last_seen"
+
+// removeTimedOutScript removes the given gateway from the last-seen set,
+// but only when it was not seen after the given timestamp (e.g. when stats
+// were received between reading and removing the timed-out gateways).
+var removeTimedOutScript = redis.NewScript(1, `
+	local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+	if score and tonumber(score) <= tonumber(ARGV[2]) then
+		return redis.call("ZREM", KEYS[1], ARGV[1])
+	end
+	return 0
+`)
+
+// handleGatewayStatus updates the last-seen timestamp of the given gateway
+// and notifies the network-controller when the gateway is (re)connected.
+func handleGatewayStatus(ctx common.Context, mac lorawan.EUI64, lastSeen time.Time) error {
+	c := ctx.RedisPool.Get()
+	defer c.Close()
+
+	added, err := redis.Int(c.Do("ZADD", gatewayLastSeenKey, lastSeen.Unix(), mac.String()))
+	if err != nil {
+		return errors.Wrap(err, "update last-seen error")
+	}
+
+	// the gateway was already known
+	if added == 0 {
+		return nil
+	}
+
+	return sendGatewayStatus(ctx, mac, nc.GatewayStatus_CONNECTED, lastSeen)
+}
+
+// handleGatewayStatsTimeouts notifies the network-controller about the
+// gateways from which no stats were received within the configured
+// common.GatewayStatsTimeout.
+func handleGatewayStatsTimeouts(ctx common.Context) error {
+	c := ctx.RedisPool.Get()
+	defer c.Close()
+
+	max := time.Now().Add(-common.GatewayStatsTimeout).Unix()
+	values, err := redis.Strings(c.Do("ZRANGEBYSCORE", gatewayLastSeenKey, "-inf", max, "WITHSCORES"))
+	if err != nil {
+		return errors.Wrap(err, "get timed-out gateways error")
+	}
+
+	for i := 0; i+1 < len(values); i += 2 {
+		var mac lorawan.EUI64
+		if err := mac.UnmarshalText([]byte(values[i])); err != nil {
+			return errors.Wrap(err, "unmarshal mac error")
+		}
+
+		lastSeen, err := redis.Int64(values[i+1], nil)
+		if err != nil {
+			return errors.Wrap(err, "parse last-seen error")
+		}
+
+		// in case of multiple LoRa Server instances, only the instance
+		// removing the gateway from the set sends the status
+		removed, err := redis.Int(removeTimedOutScript.Do(c, gatewayLastSeenKey, values[i], max))
+		if err != nil {
+			return errors.Wrap(err, "remove timed-out gateway error")
+		}
+		if removed == 0 {
+			continue
+		}
+
+		if err := digest.Record(ctx.RedisPool, digest.GatewayOffline, mac.String()); err != nil {
+			log.WithField("mac", mac).Errorf("record digest event error: %s", err)
+		}
+
+		if err := sendGatewayStatus(ctx, mac, nc.GatewayStatus_STATS_TIMEOUT, time.Unix(lastSeen, 0)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sendGatewayStatus(ctx common.Context, mac lorawan.EUI64, status nc.GatewayStatus, lastSeen time.Time) error {
+	log.WithFields(log.Fields{
+		"mac":    mac,
+		"status": status,
+	}).Info("gateway status changed")
+
+	_, err := ctx.Controller.HandleGatewayStatus(context.Background(), &nc.HandleGatewayStatusRequest{
+		Mac:        mac[:],
+		Status:     status,
+		LastSeenAt: lastSeen.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return errors.Wrap(err, "publish gateway status to network-controller error")
+	}
+	return nil
+}
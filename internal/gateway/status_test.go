@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGatewayStatus(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a stats timeout of 1 minute", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.GatewayStatsTimeout = time.Minute
+		ctrl := test.NewNetworkControllerClient()
+		ctx := common.Context{
+			RedisPool:  p,
+			Controller: ctrl,
+		}
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When handling the status of an unknown gateway", func() {
+			So(handleGatewayStatus(ctx, mac, time.Now().Add(-2*time.Minute)), ShouldBeNil)
+
+			Convey("Then the network-controller is notified that it is connected", func() {
+				req := <-ctrl.HandleGatewayStatusChan
+				So(req.Mac, ShouldResemble, mac[:])
+				So(req.Status, ShouldEqual, nc.GatewayStatus_CONNECTED)
+			})
+
+			Convey("When handling the status again", func() {
+				<-ctrl.HandleGatewayStatusChan
+				So(handleGatewayStatus(ctx, mac, time.Now().Add(-2*time.Minute)), ShouldBeNil)
+
+				Convey("Then the network-controller is not notified", func() {
+					So(ctrl.HandleGatewayStatusChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When handling the stats timeouts", func() {
+				<-ctrl.HandleGatewayStatusChan
+				So(handleGatewayStatsTimeouts(ctx), ShouldBeNil)
+
+				Convey("Then the network-controller is notified about the timeout", func() {
+					req := <-ctrl.HandleGatewayStatusChan
+					So(req.Status, ShouldEqual, nc.GatewayStatus_STATS_TIMEOUT)
+
+					Convey("When handling the stats timeouts again, no notification is sent", func() {
+						So(handleGatewayStatsTimeouts(ctx), ShouldBeNil)
+						So(ctrl.HandleGatewayStatusChan, ShouldHaveLength, 0)
+					})
+				})
+			})
+		})
+
+		Reset(func() {
+			common.GatewayStatsTimeout = 0
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// txFailuresKeyTempl contains per gateway the number of downlinks which
+// failed (rejected by the gateway or failed to publish) since the last
+// successful downlink.
+const txFailuresKeyTempl = "lora:ns:gw:tx_failures:%s"
+
+// RecordTXFailure increments the failure score of the given gateway. The
+// score expires after the given window without failures.
+func RecordTXFailure(p *redis.Pool, mac lorawan.EUI64, window time.Duration) error {
+	key := fmt.Sprintf(txFailuresKeyTempl, mac)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("INCR", key)
+	c.Send("PEXPIRE", key, int64(window/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record tx failure error")
+	}
+	return nil
+}
+
+// ResetTXFailures resets the failure score of the given gateway, e.g. after
+// a downlink was acknowledged by the gateway.
+func ResetTXFailures(p *redis.Pool, mac lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(txFailuresKeyTempl, mac)); err != nil {
+		return errors.Wrap(err, "reset tx failures error")
+	}
+	return nil
+}
+
+// GetFailingGateways returns the given gateways of which the failure score
+// reached the given threshold.
+func GetFailingGateways(p *redis.Pool, macs []lorawan.EUI64, threshold int) (map[lorawan.EUI64]struct{}, error) {
+	c := p.Get()
+	defer c.Close()
+
+	for _, mac := range macs {
+		c.Send("GET", fmt.Sprintf(txFailuresKeyTempl, mac))
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get tx failures error")
+	}
+
+	out := make(map[lorawan.EUI64]struct{})
+	for _, mac := range macs {
+		count, err := redis.Int(c.Receive())
+		if err != nil && err != redis.ErrNil {
+			return nil, errors.Wrap(err, "get tx failures error")
+		}
+		if count >= threshold {
+			out[mac] = struct{}{}
+		}
+	}
+	return out, nil
+}
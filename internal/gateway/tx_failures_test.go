@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTXFailures(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		mac2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		macs := []lorawan.EUI64{mac1, mac2}
+
+		Convey("When recording two tx failures for a gateway", func() {
+			So(RecordTXFailure(p, mac1, time.Minute), ShouldBeNil)
+			So(RecordTXFailure(p, mac1, time.Minute), ShouldBeNil)
+			So(RecordTXFailure(p, mac2, time.Minute), ShouldBeNil)
+
+			Convey("Then GetFailingGateways returns only the gateway at the threshold", func() {
+				failing, err := GetFailingGateways(p, macs, 2)
+				So(err, ShouldBeNil)
+				So(failing, ShouldResemble, map[lorawan.EUI64]struct{}{mac1: {}})
+			})
+
+			Convey("Then the gateway is no longer failing after a reset", func() {
+				So(ResetTXFailures(p, mac1), ShouldBeNil)
+
+				failing, err := GetFailingGateways(p, macs, 2)
+				So(err, ShouldBeNil)
+				So(failing, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When recording tx failures with a short window", func() {
+			So(RecordTXFailure(p, mac1, 10*time.Millisecond), ShouldBeNil)
+			So(RecordTXFailure(p, mac1, 10*time.Millisecond), ShouldBeNil)
+
+			Convey("Then the gateway is no longer failing after the window", func() {
+				time.Sleep(20 * time.Millisecond)
+
+				failing, err := GetFailingGateways(p, macs, 2)
+				So(err, ShouldBeNil)
+				So(failing, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,247 @@
+// Package geolocation implements the resolving of the location of nodes
+// from the fine-timestamps of the receiving gateways (time difference of
+// arrival, TDOA). The receptions of the uplinks received by at least
+// MinGateways fine-timestamp capable gateways (with a known location) are
+// buffered per node, so that the resolver can combine the last uplinks of
+// the node. The resolved location is sent to the application-server
+// (SetDeviceLocation). The geolocation only runs for the nodes for which it
+// has been enabled (see session.NodeSession.Geolocation).
+package geolocation
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// framesKeyTempl contains per node the buffered receptions of the last
+// uplinks (newest first).
+const framesKeyTempl = "lora:ns:node:geolocation:%s"
+
+// MinGateways defines the min. number of fine-timestamp capable gateways
+// (with a known location) which must have received an uplink before it is
+// used for the geolocation.
+const MinGateways = 3
+
+// SourceTDOA is the source of the locations resolved from the
+// fine-timestamps of the receiving gateways.
+const SourceTDOA = "TDOA"
+
+// ErrNotResolvable is returned when the location can't be resolved from
+// the given receptions (e.g. all gateways are on a single line).
+var ErrNotResolvable = errors.New("location not resolvable")
+
+// Reception contains the reception of an uplink by a gateway.
+type Reception struct {
+	GatewayMAC    lorawan.EUI64
+	Latitude      float64 // of the gateway
+	Longitude     float64 // of the gateway
+	Altitude      float64 // of the gateway
+	FineTimestamp time.Time
+	RSSI          int
+	LoRaSNR       float64
+}
+
+// Frame contains the receptions of an uplink.
+type Frame struct {
+	FCnt       uint32
+	Receptions []Reception
+}
+
+// Location contains a resolved location.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+	Accuracy  float64 // radius in meters
+}
+
+// Resolver defines the interface of a geolocation resolver.
+type Resolver interface {
+	// ResolveTDOA resolves the location of the given node from the given
+	// frames (oldest first), assuming the node did not move between
+	// these frames.
+	ResolveTDOA(ctx context.Context, devEUI lorawan.EUI64, frames []Frame) (Location, error)
+}
+
+var (
+	resolverMu sync.RWMutex
+	resolver   Resolver
+)
+
+// SetResolver sets the geolocation resolver to use. Setting it to nil
+// disables the geolocation.
+func SetResolver(r Resolver) {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	resolver = r
+}
+
+func getResolver() Resolver {
+	resolverMu.RLock()
+	defer resolverMu.RUnlock()
+	return resolver
+}
+
+// HandleUplink buffers the receptions of the given uplink when it has been
+// received by at least MinGateways fine-timestamp capable gateways, resolves
+// the location of the node from the buffered uplinks and sends it to the
+// application-server. Nothing is done when no resolver has been configured
+// or when the geolocation is disabled for the node.
+func HandleUplink(ctx common.Context, ns session.NodeSession, fCnt uint32, rxInfoSet models.RXInfoSet) error {
+	r := getResolver()
+	if r == nil || !ns.Geolocation || common.GeolocationBufferFrames == 0 {
+		return nil
+	}
+
+	frame, err := getFrame(ctx, fCnt, rxInfoSet)
+	if err != nil {
+		return err
+	}
+	if len(frame.Receptions) < MinGateways {
+		return nil
+	}
+
+	if err := saveFrame(ctx.RedisPool, ns.DevEUI, frame); err != nil {
+		return err
+	}
+
+	frames, err := GetFrames(ctx.RedisPool, ns.DevEUI)
+	if err != nil {
+		return err
+	}
+
+	loc, err := r.ResolveTDOA(context.Background(), ns.DevEUI, frames)
+	if err != nil {
+		return errors.Wrap(err, "resolve location error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   ns.DevEUI,
+		"fcnt":      fCnt,
+		"frames":    len(frames),
+		"latitude":  loc.Latitude,
+		"longitude": loc.Longitude,
+		"accuracy":  loc.Accuracy,
+	}).Info("node location resolved")
+
+	_, err = ctx.Application.SetDeviceLocation(context.Background(), &as.SetDeviceLocationRequest{
+		AppEUI:       ns.AppEUI[:],
+		DevEUI:       ns.DevEUI[:],
+		FCnt:         fCnt,
+		Latitude:     loc.Latitude,
+		Longitude:    loc.Longitude,
+		Altitude:     loc.Altitude,
+		Accuracy:     loc.Accuracy,
+		FrameCount:   uint32(len(frames)),
+		GatewayCount: uint32(len(frame.Receptions)),
+		Source:       SourceTDOA,
+	})
+	if err != nil {
+		return errors.Wrap(err, "set device location error")
+	}
+	return nil
+}
+
+// getFrame returns the receptions of the given RXInfoSet containing a
+// fine-timestamp, of the fine-timestamp capable gateways with a known
+// location.
+func getFrame(ctx common.Context, fCnt uint32, rxInfoSet models.RXInfoSet) (Frame, error) {
+	frame := Frame{FCnt: fCnt}
+	seen := make(map[lorawan.EUI64]struct{})
+
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.FineTimestamp == nil {
+			continue
+		}
+		if _, ok := seen[rxInfo.MAC]; ok {
+			continue
+		}
+		seen[rxInfo.MAC] = struct{}{}
+
+		gw, err := gateway.GetGateway(ctx.DB, rxInfo.MAC)
+		if err != nil {
+			if errors.Cause(err) == gateway.ErrDoesNotExist {
+				continue
+			}
+			return frame, errors.Wrap(err, "get gateway error")
+		}
+		if !gw.FineTimestamp || gw.Location == nil {
+			continue
+		}
+
+		rec := Reception{
+			GatewayMAC:    rxInfo.MAC,
+			Latitude:      gw.Location.Latitude,
+			Longitude:     gw.Location.Longitude,
+			FineTimestamp: *rxInfo.FineTimestamp,
+			RSSI:          rxInfo.RSSI,
+			LoRaSNR:       rxInfo.LoRaSNR,
+		}
+		if gw.Altitude != nil {
+			rec.Altitude = *gw.Altitude
+		}
+		frame.Receptions = append(frame.Receptions, rec)
+	}
+
+	return frame, nil
+}
+
+// saveFrame adds the given frame to the buffered frames of the node
+// (keeping the last common.GeolocationBufferFrames frames).
+func saveFrame(p *redis.Pool, devEUI lorawan.EUI64, frame Frame) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(frame); err != nil {
+		return errors.Wrap(err, "gob encode frame error")
+	}
+
+	key := fmt.Sprintf(framesKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, buf.Bytes())
+	c.Send("LTRIM", key, 0, common.GeolocationBufferFrames-1)
+	c.Send("PEXPIRE", key, int64(common.GeolocationBufferTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save geolocation frame error")
+	}
+	return nil
+}
+
+// GetFrames returns the buffered frames of the given node (oldest first).
+func GetFrames(p *redis.Pool, devEUI lorawan.EUI64) ([]Frame, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(framesKeyTempl, devEUI), 0, -1))
+	if err != nil {
+		return nil, errors.Wrap(err, "get geolocation frames error")
+	}
+
+	frames := make([]Frame, len(values))
+	for i, b := range values {
+		var f Frame
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&f); err != nil {
+			return nil, errors.Wrap(err, "gob decode frame error")
+		}
+		frames[len(values)-1-i] = f
+	}
+	return frames, nil
+}
@@ -0,0 +1,143 @@
+package geolocation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestHandleUplink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with three gateways and a node-session", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		asClient := test.NewApplicationClient()
+		ctx := common.Context{
+			RedisPool:   p,
+			DB:          db,
+			Application: asClient,
+		}
+
+		gateways := [][2]float64{
+			{52.3700, 4.8900},
+			{52.3900, 4.9300},
+			{52.3500, 4.9400},
+		}
+		frame := getTestFrame(10, time.Now(), 52.3650, 4.9100, gateways)
+
+		var rxInfoSet models.RXInfoSet
+		for i, rec := range frame.Receptions {
+			So(gateway.CreateGateway(db, &gateway.Gateway{
+				MAC:           rec.GatewayMAC,
+				Name:          fmt.Sprintf("gateway-%d", i),
+				FineTimestamp: true,
+				Location: &gateway.GPSPoint{
+					Latitude:  rec.Latitude,
+					Longitude: rec.Longitude,
+				},
+			}), ShouldBeNil)
+
+			ts := rec.FineTimestamp
+			rxInfoSet = append(rxInfoSet, gw.RXInfo{
+				MAC:           rec.GatewayMAC,
+				FineTimestamp: &ts,
+			})
+		}
+
+		ns := session.NodeSession{
+			DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI:      lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			Geolocation: true,
+		}
+
+		Convey("When no resolver has been configured", func() {
+			SetResolver(nil)
+			So(HandleUplink(ctx, ns, 10, rxInfoSet), ShouldBeNil)
+
+			Convey("Then no frame has been buffered", func() {
+				frames, err := GetFrames(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(frames, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Given the MultilaterationResolver has been configured", func() {
+			SetResolver(NewMultilaterationResolver())
+			defer SetResolver(nil)
+
+			Convey("When the geolocation is disabled for the node", func() {
+				ns.Geolocation = false
+				So(HandleUplink(ctx, ns, 10, rxInfoSet), ShouldBeNil)
+
+				Convey("Then no frame has been buffered", func() {
+					frames, err := GetFrames(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(frames, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When the uplink has been received by less than MinGateways gateways with a fine-timestamp", func() {
+				rxInfoSet[0].FineTimestamp = nil
+				So(HandleUplink(ctx, ns, 10, rxInfoSet), ShouldBeNil)
+
+				Convey("Then no frame has been buffered", func() {
+					frames, err := GetFrames(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(frames, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When calling HandleUplink", func() {
+				So(HandleUplink(ctx, ns, 10, rxInfoSet), ShouldBeNil)
+
+				Convey("Then the frame has been buffered", func() {
+					frames, err := GetFrames(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(frames, ShouldHaveLength, 1)
+					So(frames[0].FCnt, ShouldEqual, 10)
+					So(frames[0].Receptions, ShouldHaveLength, 3)
+				})
+
+				Convey("Then the location has been sent to the application-server", func() {
+					req := <-asClient.SetDeviceLocationChan
+					So(req.DevEUI, ShouldResemble, ns.DevEUI[:])
+					So(req.AppEUI, ShouldResemble, ns.AppEUI[:])
+					So(req.FCnt, ShouldEqual, 10)
+					So(req.FrameCount, ShouldEqual, 1)
+					So(req.GatewayCount, ShouldEqual, 3)
+					So(req.Source, ShouldEqual, SourceTDOA)
+					So(getDistance(52.3650, 4.9100, req.Latitude, req.Longitude), ShouldBeLessThan, 1)
+				})
+
+				Convey("When calling HandleUplink for more uplinks than GeolocationBufferFrames", func() {
+					for fCnt := uint32(11); fCnt < 15; fCnt++ {
+						So(HandleUplink(ctx, ns, fCnt, rxInfoSet), ShouldBeNil)
+					}
+
+					Convey("Then only the last GeolocationBufferFrames frames are buffered (oldest first)", func() {
+						frames, err := GetFrames(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(frames, ShouldHaveLength, common.GeolocationBufferFrames)
+						So(frames[0].FCnt, ShouldEqual, 12)
+						So(frames[2].FCnt, ShouldEqual, 14)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package geolocation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/geo"
+)
+
+// GRPCResolver implements a Resolver using an external geolocation server
+// (see api/geo/geo.proto).
+type GRPCResolver struct {
+	client geo.GeolocationServerClient
+}
+
+// NewGRPCResolver creates a new GRPCResolver using the given client.
+func NewGRPCResolver(client geo.GeolocationServerClient) *GRPCResolver {
+	return &GRPCResolver{client: client}
+}
+
+// ResolveTDOA implements the Resolver interface.
+func (r *GRPCResolver) ResolveTDOA(ctx context.Context, devEUI lorawan.EUI64, frames []Frame) (Location, error) {
+	req := geo.ResolveTDOARequest{
+		DevEUI: devEUI[:],
+	}
+
+	for _, f := range frames {
+		frameRXInfo := geo.FrameRXInfo{
+			FCnt: f.FCnt,
+		}
+		for _, rec := range f.Receptions {
+			// make sure we have a copy of the MAC byte slice, else every
+			// RXInfo item will get the same MAC
+			mac := make([]byte, 8)
+			copy(mac, rec.GatewayMAC[:])
+
+			frameRXInfo.RxInfo = append(frameRXInfo.RxInfo, &geo.RXInfo{
+				Mac:           mac,
+				Latitude:      rec.Latitude,
+				Longitude:     rec.Longitude,
+				Altitude:      rec.Altitude,
+				FineTimestamp: rec.FineTimestamp.UnixNano(),
+				Rssi:          int32(rec.RSSI),
+				LoRaSNR:       rec.LoRaSNR,
+			})
+		}
+		req.FrameRXInfo = append(req.FrameRXInfo, &frameRXInfo)
+	}
+
+	resp, err := r.client.ResolveTDOA(ctx, &req)
+	if err != nil {
+		return Location{}, errors.Wrap(err, "resolve tdoa error")
+	}
+
+	return Location{
+		Latitude:  resp.Latitude,
+		Longitude: resp.Longitude,
+		Altitude:  resp.Altitude,
+		Accuracy:  resp.Accuracy,
+	}, nil
+}
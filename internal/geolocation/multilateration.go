@@ -0,0 +1,250 @@
+package geolocation
+
+import (
+	"context"
+	"math"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// speedOfLight in meters per second.
+	speedOfLight = 299792458.0
+
+	// earthRadius in meters.
+	earthRadius = 6371000.0
+
+	// timestampError defines the (typical) error of the fine-timestamps,
+	// used as lower bound of the accuracy.
+	timestampError = 50e-9
+
+	// maxIterations defines the max. number of Gauss-Newton iterations.
+	maxIterations = 50
+
+	// convergence defines the step (in meters) below which the
+	// multilateration has converged.
+	convergence = 0.01
+
+	// maxDistance defines the max. distance (in meters) between the
+	// resolved location and the center of the gateways.
+	maxDistance = 100000.0
+)
+
+// MultilaterationResolver implements a built-in Resolver, resolving the
+// horizontal location of a node by a least-squares TDOA multilateration
+// (Gauss-Newton) of the receptions of the given frames. The unknown
+// transmission time of each frame is estimated together with the location.
+// The altitude of the node is not resolved. As the gateway locations are
+// projected on a plane, it is intended for networks spanning up to a few
+// tens of kilometers.
+type MultilaterationResolver struct{}
+
+// NewMultilaterationResolver creates a new MultilaterationResolver.
+func NewMultilaterationResolver() *MultilaterationResolver {
+	return &MultilaterationResolver{}
+}
+
+// point contains a location in meters, relative to the center of the
+// gateways (x = east, y = north).
+type point struct {
+	x, y float64
+}
+
+// ResolveTDOA implements the Resolver interface.
+func (r *MultilaterationResolver) ResolveTDOA(ctx context.Context, devEUI lorawan.EUI64, frames []Frame) (Location, error) {
+	var lat0, lon0 float64
+	var n int
+	for _, f := range frames {
+		for _, rec := range f.Receptions {
+			lat0 += rec.Latitude
+			lon0 += rec.Longitude
+			n++
+		}
+	}
+	if n == 0 {
+		return Location{}, ErrNotResolvable
+	}
+	lat0 /= float64(n)
+	lon0 /= float64(n)
+
+	toPoint := func(lat, lon float64) point {
+		return point{
+			x: earthRadius * toRad(lon-lon0) * math.Cos(toRad(lat0)),
+			y: earthRadius * toRad(lat-lat0),
+		}
+	}
+
+	// the gateway locations and the distance travelled by the signal
+	// (relative to the first reception of the frame)
+	gws := make([][]point, len(frames))
+	dists := make([][]float64, len(frames))
+	for i, f := range frames {
+		if len(f.Receptions) < 2 {
+			continue
+		}
+		first := f.Receptions[0].FineTimestamp
+		for _, rec := range f.Receptions {
+			gws[i] = append(gws[i], toPoint(rec.Latitude, rec.Longitude))
+			dists[i] = append(dists[i], rec.FineTimestamp.Sub(first).Seconds()*speedOfLight)
+		}
+	}
+
+	// start at the center of the gateways
+	loc, bias, err := multilaterate(point{}, gws, dists)
+	if err != nil {
+		return Location{}, err
+	}
+	if math.Hypot(loc.x, loc.y) > maxDistance {
+		return Location{}, ErrNotResolvable
+	}
+
+	// accuracy: the RMS of the residuals (when over-determined), bounded
+	// by the timestamp error
+	var sum float64
+	var m, unknowns int
+	for i := range gws {
+		if len(gws[i]) == 0 {
+			continue
+		}
+		unknowns++
+		for j := range gws[i] {
+			r := dists[i][j] - bias[i] - distance(loc, gws[i][j])
+			sum += r * r
+			m++
+		}
+	}
+	unknowns += 2
+	accuracy := timestampError * speedOfLight
+	if m > unknowns {
+		if rms := math.Sqrt(sum / float64(m-unknowns)); rms > accuracy {
+			accuracy = rms
+		}
+	}
+
+	return Location{
+		Latitude:  lat0 + toDeg(loc.y/earthRadius),
+		Longitude: lon0 + toDeg(loc.x/(earthRadius*math.Cos(toRad(lat0)))),
+		Accuracy:  accuracy,
+	}, nil
+}
+
+// multilaterate solves the location and the per frame bias (the distance
+// corresponding to the transmission time, relative to the first reception)
+// minimizing the residuals dists[i][j] - bias[i] - |loc - gws[i][j]|.
+func multilaterate(start point, gws [][]point, dists [][]float64) (point, []float64, error) {
+	loc := start
+	bias := make([]float64, len(gws))
+
+	// the frames used for the multilateration, each having a bias
+	var frames []int
+	var m int
+	for i := range gws {
+		if len(gws[i]) == 0 {
+			continue
+		}
+		frames = append(frames, i)
+		m += len(gws[i])
+
+		for j := range gws[i] {
+			bias[i] += (dists[i][j] - distance(loc, gws[i][j])) / float64(len(gws[i]))
+		}
+	}
+
+	unknowns := 2 + len(frames)
+	if m < unknowns {
+		return loc, nil, ErrNotResolvable
+	}
+
+	for iter := 0; iter < maxIterations; iter++ {
+		// normal equations (J^T J) delta = -J^T r
+		a := make([][]float64, unknowns)
+		for i := range a {
+			a[i] = make([]float64, unknowns+1)
+		}
+
+		for k, i := range frames {
+			for j, gw := range gws[i] {
+				d := distance(loc, gw)
+				if d < 1 {
+					d = 1
+				}
+				row := make([]float64, unknowns)
+				row[0] = -(loc.x - gw.x) / d
+				row[1] = -(loc.y - gw.y) / d
+				row[2+k] = -1
+				r := dists[i][j] - bias[i] - distance(loc, gw)
+
+				for p := 0; p < unknowns; p++ {
+					for q := 0; q < unknowns; q++ {
+						a[p][q] += row[p] * row[q]
+					}
+					a[p][unknowns] -= row[p] * r
+				}
+			}
+		}
+
+		delta, ok := solve(a)
+		if !ok {
+			return loc, nil, ErrNotResolvable
+		}
+
+		loc.x += delta[0]
+		loc.y += delta[1]
+		for k, i := range frames {
+			bias[i] += delta[2+k]
+		}
+
+		if math.Hypot(delta[0], delta[1]) < convergence {
+			return loc, bias, nil
+		}
+	}
+
+	return loc, nil, ErrNotResolvable
+}
+
+// solve solves the given augmented matrix by Gaussian elimination (with
+// partial pivoting). False is returned when the matrix is singular.
+func solve(a [][]float64) ([]float64, bool) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-9 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for c := col; c <= n; c++ {
+				a[row][c] -= f * a[col][c]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := a[row][n]
+		for c := row + 1; c < n; c++ {
+			sum -= a[row][c] * x[c]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
+
+func distance(a, b point) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+func toRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDeg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
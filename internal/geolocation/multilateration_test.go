@@ -0,0 +1,117 @@
+package geolocation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// getTestFrame returns the receptions of an uplink transmitted at the given
+// time by a node at the given location, by the given gateways (lat, lon
+// pairs). The given errors are added to the fine-timestamps of the
+// consecutive receptions.
+func getTestFrame(fCnt uint32, txTime time.Time, lat, lon float64, gateways [][2]float64, errs ...time.Duration) Frame {
+	frame := Frame{FCnt: fCnt}
+	for i, g := range gateways {
+		// the distance on a sphere (haversine)
+		dLat := toRad(g[0] - lat)
+		dLon := toRad(g[1] - lon)
+		h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(toRad(lat))*math.Cos(toRad(g[0]))*math.Pow(math.Sin(dLon/2), 2)
+		d := 2 * earthRadius * math.Asin(math.Sqrt(h))
+
+		ts := txTime.Add(time.Duration(d / speedOfLight * float64(time.Second)))
+		if i < len(errs) {
+			ts = ts.Add(errs[i])
+		}
+
+		frame.Receptions = append(frame.Receptions, Reception{
+			GatewayMAC:    lorawan.EUI64{byte(i + 1), 1, 1, 1, 1, 1, 1, 1},
+			Latitude:      g[0],
+			Longitude:     g[1],
+			FineTimestamp: ts,
+		})
+	}
+	return frame
+}
+
+// getDistance returns the approx. distance in meters between the given
+// locations.
+func getDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	x := earthRadius * toRad(lon2-lon1) * math.Cos(toRad((lat1+lat2)/2))
+	y := earthRadius * toRad(lat2-lat1)
+	return math.Hypot(x, y)
+}
+
+func TestMultilaterationResolver(t *testing.T) {
+	Convey("Given a MultilaterationResolver and a set of gateways", t, func() {
+		r := NewMultilaterationResolver()
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		txTime := time.Now()
+
+		gateways := [][2]float64{
+			{52.3700, 4.8900},
+			{52.3900, 4.9300},
+			{52.3500, 4.9400},
+			{52.3300, 4.8800},
+		}
+		lat, lon := 52.3650, 4.9100
+
+		tests := []struct {
+			Name             string
+			Frames           []Frame
+			ExpectedMaxError float64 // in meters
+			ExpectedError    error
+		}{
+			{
+				Name:             "three gateways",
+				Frames:           []Frame{getTestFrame(10, txTime, lat, lon, gateways[:3])},
+				ExpectedMaxError: 1,
+			},
+			{
+				Name:             "four gateways",
+				Frames:           []Frame{getTestFrame(10, txTime, lat, lon, gateways)},
+				ExpectedMaxError: 1,
+			},
+			{
+				Name: "multiple frames with timestamp errors",
+				Frames: []Frame{
+					getTestFrame(10, txTime, lat, lon, gateways, 20*time.Nanosecond, -20*time.Nanosecond, 10*time.Nanosecond, 0),
+					getTestFrame(11, txTime.Add(time.Minute), lat, lon, gateways, -10*time.Nanosecond, 20*time.Nanosecond, 0, -20*time.Nanosecond),
+					getTestFrame(12, txTime.Add(2*time.Minute), lat, lon, gateways, 0, 10*time.Nanosecond, -20*time.Nanosecond, 20*time.Nanosecond),
+				},
+				ExpectedMaxError: 20,
+			},
+			{
+				Name: "gateways on a single line",
+				Frames: []Frame{getTestFrame(10, txTime, lat, lon, [][2]float64{
+					{52.3000, 4.9000},
+					{52.3500, 4.9000},
+					{52.4000, 4.9000},
+				})},
+				ExpectedError: ErrNotResolvable,
+			},
+			{
+				Name:          "no frames",
+				ExpectedError: ErrNotResolvable,
+			},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				loc, err := r.ResolveTDOA(context.Background(), devEUI, test.Frames)
+				So(err, ShouldEqual, test.ExpectedError)
+				if test.ExpectedError != nil {
+					return
+				}
+
+				So(getDistance(lat, lon, loc.Latitude, loc.Longitude), ShouldBeLessThan, test.ExpectedMaxError)
+				So(loc.Accuracy, ShouldBeGreaterThan, 0)
+			})
+		}
+	})
+}
@@ -0,0 +1,222 @@
+// Package grpcclient implements the connection handling of the gRPC clients
+// (application-server and network-controller): TCP keepalive, a max.
+// connection age and the tracking of the connection state, exposed as
+// Prometheus metrics.
+//
+// The connections are re-established by gRPC itself (with an exponential
+// backoff) once they are closed, e.g. after the keepalive probes failed or
+// the max. connection age has been reached.
+package grpcclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/joriwind/loraserver/internal/metrics"
+)
+
+// Config contains the connection settings of a gRPC client.
+type Config struct {
+	// KeepAlive defines the TCP keepalive interval, detecting dead
+	// connections and keeping NAT / load-balancer mappings alive (0 =
+	// disabled).
+	KeepAlive time.Duration
+
+	// MaxConnectionAge defines after how long (+/- 10%) a connection is
+	// closed, so that it is re-established (0 = unlimited). Calls which
+	// are in progress on the closed connection fail.
+	MaxConnectionAge time.Duration
+
+	// ReconnectMaxBackoff defines the max. delay between two connection
+	// attempts.
+	ReconnectMaxBackoff time.Duration
+}
+
+// connState contains the connection state of a client.
+type connState struct {
+	open        int64
+	connects    uint64
+	disconnects uint64
+}
+
+var callDuration = metrics.NewHistogram("loraserver_grpc_client_call_duration_seconds", "Duration of the calls of the gRPC client.", metrics.DurationBuckets, "client", "method", "code")
+
+var (
+	statesMu sync.Mutex
+	states   = make(map[string]*connState)
+)
+
+func getState(name string) *connState {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+
+	s, ok := states[name]
+	if !ok {
+		s = &connState{}
+		states[name] = s
+	}
+	return s
+}
+
+// DialOptions returns the dial options implementing the given config for
+// the client with the given name (e.g. as or nc, used as metrics label).
+func DialOptions(name string, conf Config) []grpc.DialOption {
+	state := getState(name)
+
+	opts := []grpc.DialOption{
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			dialer := net.Dialer{
+				Timeout:   timeout,
+				KeepAlive: conf.KeepAlive,
+			}
+			conn, err := dialer.Dial("tcp", addr)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"client": name,
+					"addr":   addr,
+				}).Warningf("grpc client dial error: %s", err)
+				return nil, err
+			}
+			return newTrackedConn(name, conn, state, conf.MaxConnectionAge), nil
+		}),
+	}
+	if conf.ReconnectMaxBackoff > 0 {
+		opts = append(opts, grpc.WithBackoffMaxDelay(conf.ReconnectMaxBackoff))
+	}
+	return append(opts, grpc.WithUnaryInterceptor(callDurationInterceptor(name)))
+}
+
+// callDurationInterceptor returns an interceptor recording the duration of
+// the calls of the client with the given name.
+func callDurationInterceptor(name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		callDuration.ObserveDuration(start, name, method, grpc.Code(err).String())
+		return err
+	}
+}
+
+// trackedConn wraps a net.Conn to track its state.
+type trackedConn struct {
+	net.Conn
+	name   string
+	state  *connState
+	once   sync.Once
+	maxAge *time.Timer
+}
+
+func newTrackedConn(name string, conn net.Conn, state *connState, maxAge time.Duration) *trackedConn {
+	c := trackedConn{
+		Conn:  conn,
+		name:  name,
+		state: state,
+	}
+
+	atomic.AddInt64(&state.open, 1)
+	atomic.AddUint64(&state.connects, 1)
+	log.WithFields(log.Fields{
+		"client": name,
+		"addr":   conn.RemoteAddr(),
+	}).Info("grpc client connected")
+
+	if maxAge > 0 {
+		// spread the reconnects of the clients
+		maxAge = maxAge + time.Duration((rand.Float64()*0.2-0.1)*float64(maxAge))
+		c.maxAge = time.AfterFunc(maxAge, func() {
+			log.WithFields(log.Fields{
+				"client": name,
+				"addr":   conn.RemoteAddr(),
+			}).Info("grpc client max connection age reached")
+			c.Close()
+		})
+	}
+
+	return &c
+}
+
+// Read implements net.Conn. As the gRPC transport is always reading, a
+// read error means that the connection has been closed.
+func (c *trackedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.closed(err)
+	}
+	return n, err
+}
+
+// Close implements net.Conn.
+func (c *trackedConn) Close() error {
+	c.closed(nil)
+	return c.Conn.Close()
+}
+
+func (c *trackedConn) closed(err error) {
+	c.once.Do(func() {
+		if c.maxAge != nil {
+			c.maxAge.Stop()
+		}
+		atomic.AddInt64(&c.state.open, -1)
+		atomic.AddUint64(&c.state.disconnects, 1)
+
+		logFields := log.Fields{
+			"client": c.name,
+			"addr":   c.Conn.RemoteAddr(),
+		}
+		if err != nil {
+			log.WithFields(logFields).Warningf("grpc client connection lost: %s", err)
+		} else {
+			log.WithFields(logFields).Info("grpc client connection closed")
+		}
+	})
+}
+
+// WriteMetrics writes the connection state of the clients in the
+// Prometheus text exposition format.
+func WriteMetrics(w io.Writer) error {
+	statesMu.Lock()
+	var names []string
+	for name := range states {
+		names = append(names, name)
+	}
+	statesMu.Unlock()
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+
+	for _, m := range []struct {
+		name  string
+		help  string
+		typ   string
+		value func(s *connState) string
+	}{
+		{"loraserver_grpc_client_connections", "Number of open connections of the gRPC client.", "gauge", func(s *connState) string {
+			return fmt.Sprintf("%d", atomic.LoadInt64(&s.open))
+		}},
+		{"loraserver_grpc_client_connects_total", "Number of connections established by the gRPC client.", "counter", func(s *connState) string {
+			return fmt.Sprintf("%d", atomic.LoadUint64(&s.connects))
+		}},
+		{"loraserver_grpc_client_disconnects_total", "Number of connections of the gRPC client which were closed or lost.", "counter", func(s *connState) string {
+			return fmt.Sprintf("%d", atomic.LoadUint64(&s.disconnects))
+		}},
+	} {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s %s\n", m.name, m.typ)
+		for _, name := range names {
+			fmt.Fprintf(bw, "%s{client=%q} %s\n", m.name, name, m.value(getState(name)))
+		}
+	}
+
+	return bw.Flush()
+}
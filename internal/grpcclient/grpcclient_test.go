@@ -0,0 +1,82 @@
+package grpcclient
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTrackedConn(t *testing.T) {
+	Convey("Given a connection state", t, func() {
+		state := &connState{}
+
+		Convey("When tracking a connection", func() {
+			client, server := net.Pipe()
+			defer server.Close()
+			conn := newTrackedConn("test", client, state, 0)
+
+			Convey("Then the connection is counted as open", func() {
+				So(state.open, ShouldEqual, 1)
+				So(state.connects, ShouldEqual, 1)
+				So(state.disconnects, ShouldEqual, 0)
+			})
+
+			Convey("When the connection is closed by the remote", func() {
+				server.Close()
+				_, err := conn.Read(make([]byte, 1))
+				So(err, ShouldNotBeNil)
+
+				Convey("Then the connection is counted as disconnected once", func() {
+					So(conn.Close(), ShouldBeNil)
+					So(state.open, ShouldEqual, 0)
+					So(state.disconnects, ShouldEqual, 1)
+				})
+			})
+		})
+
+		Convey("When tracking a connection with a max. connection age", func() {
+			client, server := net.Pipe()
+			defer server.Close()
+			newTrackedConn("test", client, state, 10*time.Millisecond)
+
+			Convey("Then the connection is closed after the max. age", func() {
+				_, err := server.Read(make([]byte, 1))
+				So(err, ShouldNotBeNil)
+				So(state.open, ShouldEqual, 0)
+				So(state.disconnects, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestWriteMetrics(t *testing.T) {
+	Convey("Given the connection state of the as and nc client", t, func() {
+		statesMu.Lock()
+		states = map[string]*connState{
+			"nc": {open: 0, connects: 3, disconnects: 3},
+			"as": {open: 1, connects: 2, disconnects: 1},
+		}
+		statesMu.Unlock()
+
+		Convey("Then WriteMetrics writes the expected metrics", func() {
+			var b bytes.Buffer
+			So(WriteMetrics(&b), ShouldBeNil)
+			So(b.String(), ShouldEqual, `# HELP loraserver_grpc_client_connections Number of open connections of the gRPC client.
+# TYPE loraserver_grpc_client_connections gauge
+loraserver_grpc_client_connections{client="as"} 1
+loraserver_grpc_client_connections{client="nc"} 0
+# HELP loraserver_grpc_client_connects_total Number of connections established by the gRPC client.
+# TYPE loraserver_grpc_client_connects_total counter
+loraserver_grpc_client_connects_total{client="as"} 2
+loraserver_grpc_client_connects_total{client="nc"} 3
+# HELP loraserver_grpc_client_disconnects_total Number of connections of the gRPC client which were closed or lost.
+# TYPE loraserver_grpc_client_disconnects_total counter
+loraserver_grpc_client_disconnects_total{client="as"} 1
+loraserver_grpc_client_disconnects_total{client="nc"} 3
+`)
+		})
+	})
+}
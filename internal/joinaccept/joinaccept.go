@@ -0,0 +1,146 @@
+// Package joinaccept implements the end-to-end replay protection of the
+// join-accepts. For each issued join-accept, the DevEUI and DevNonce of the
+// join-request are stored in the database (together with a hash of the
+// join-accept), so that a replayed join-request never results in a second
+// join-accept for the same DevNonce, also not after a restart or when
+// handled by an other LoRa Server instance. As nodes using a random DevNonce
+// re-use DevNonce values over time, these records expire after the
+// configured retention.
+package joinaccept
+
+import (
+	"crypto/sha256"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/leader"
+)
+
+// IsIssued returns true when a join-accept has been issued for the given
+// DevEUI and DevNonce within the given retention.
+func IsIssued(db *sqlx.DB, devEUI lorawan.EUI64, devNonce [2]byte, retention time.Duration) (bool, error) {
+	var count int
+	err := db.Get(&count, `
+		select count(*)
+		from join_accept
+		where
+			dev_eui = $1
+			and dev_nonce = $2
+			and created_at >= $3`,
+		devEUI[:],
+		devNonce[:],
+		time.Now().Add(-retention),
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "select error")
+	}
+	return count > 0, nil
+}
+
+// Record records the given join-accept (PHYPayload bytes) for the given
+// DevEUI and DevNonce. It returns false when a join-accept has already been
+// issued for the given DevEUI and DevNonce within the given retention, in
+// which case the join-accept must not be sent. As the insert is atomic,
+// only one of the instances handling the same join-request concurrently is
+// able to record (and send) its join-accept.
+func Record(db *sqlx.DB, devEUI lorawan.EUI64, devNonce [2]byte, joinAccept []byte, retention time.Duration) (bool, error) {
+	now := time.Now()
+	hash := sha256.Sum256(joinAccept)
+
+	res, err := db.Exec(`
+		insert into join_accept (
+			dev_eui,
+			dev_nonce,
+			join_accept_hash,
+			created_at
+		) values ($1, $2, $3, $4)
+		on conflict (dev_eui, dev_nonce) do update set
+			join_accept_hash = excluded.join_accept_hash,
+			created_at = excluded.created_at
+		where join_accept.created_at < $5`,
+		devEUI[:],
+		devNonce[:],
+		hash[:],
+		now,
+		now.Add(-retention),
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "insert error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "get rows affected error")
+	}
+	return ra > 0, nil
+}
+
+// IsIdentical returns true when the issued join-accept for the given DevEUI
+// and DevNonce is identical to the given join-accept (PHYPayload bytes),
+// e.g. when the application-server replays its join-accept.
+func IsIdentical(db *sqlx.DB, devEUI lorawan.EUI64, devNonce [2]byte, joinAccept []byte) (bool, error) {
+	hash := sha256.Sum256(joinAccept)
+
+	var count int
+	err := db.Get(&count, `
+		select count(*)
+		from join_accept
+		where
+			dev_eui = $1
+			and dev_nonce = $2
+			and join_accept_hash = $3`,
+		devEUI[:],
+		devNonce[:],
+		hash[:],
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "select error")
+	}
+	return count > 0, nil
+}
+
+// DeleteExpired deletes the join-accepts issued before the given retention.
+// It returns the number of deleted join-accepts.
+func DeleteExpired(db *sqlx.DB, retention time.Duration) (int64, error) {
+	res, err := db.Exec("delete from join_accept where created_at < $1", time.Now().Add(-retention))
+	if err != nil {
+		return 0, errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "get rows affected error")
+	}
+	return ra, nil
+}
+
+// RunCleanup deletes the expired join-accepts on the given interval, until
+// the done channel is closed. The cleanup is only executed when the given
+// elector is the leader (or when nil).
+func RunCleanup(db *sqlx.DB, elector *leader.Elector, interval, retention time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+
+			deleted, err := DeleteExpired(db, retention)
+			if err != nil {
+				log.Errorf("delete expired join-accepts error: %s", err)
+				continue
+			}
+			if deleted > 0 {
+				log.WithField("count", deleted).Info("expired join-accepts deleted")
+			}
+		case <-done:
+			return
+		}
+	}
+}
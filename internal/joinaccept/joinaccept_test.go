@@ -0,0 +1,85 @@
+package joinaccept
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestJoinAccept(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		devNonce := [2]byte{1, 2}
+		retention := time.Hour
+
+		Convey("Then no join-accept has been issued", func() {
+			issued, err := IsIssued(db, devEUI, devNonce, retention)
+			So(err, ShouldBeNil)
+			So(issued, ShouldBeFalse)
+		})
+
+		Convey("When recording a join-accept", func() {
+			recorded, err := Record(db, devEUI, devNonce, []byte{1, 2, 3}, retention)
+			So(err, ShouldBeNil)
+			So(recorded, ShouldBeTrue)
+
+			Convey("Then the join-accept has been issued for the DevNonce only", func() {
+				issued, err := IsIssued(db, devEUI, devNonce, retention)
+				So(err, ShouldBeNil)
+				So(issued, ShouldBeTrue)
+
+				issued, err = IsIssued(db, devEUI, [2]byte{2, 1}, retention)
+				So(err, ShouldBeNil)
+				So(issued, ShouldBeFalse)
+			})
+
+			Convey("Then a second join-accept for the same DevNonce can not be recorded", func() {
+				recorded, err := Record(db, devEUI, devNonce, []byte{4, 5, 6}, retention)
+				So(err, ShouldBeNil)
+				So(recorded, ShouldBeFalse)
+
+				identical, err := IsIdentical(db, devEUI, devNonce, []byte{4, 5, 6})
+				So(err, ShouldBeNil)
+				So(identical, ShouldBeFalse)
+
+				identical, err = IsIdentical(db, devEUI, devNonce, []byte{1, 2, 3})
+				So(err, ShouldBeNil)
+				So(identical, ShouldBeTrue)
+			})
+
+			Convey("When the retention has expired", func() {
+				_, err := db.Exec("update join_accept set created_at = $1", time.Now().Add(-2*retention))
+				So(err, ShouldBeNil)
+
+				Convey("Then the join-accept is no longer issued", func() {
+					issued, err := IsIssued(db, devEUI, devNonce, retention)
+					So(err, ShouldBeNil)
+					So(issued, ShouldBeFalse)
+				})
+
+				Convey("Then a join-accept for the same DevNonce can be recorded", func() {
+					recorded, err := Record(db, devEUI, devNonce, []byte{4, 5, 6}, retention)
+					So(err, ShouldBeNil)
+					So(recorded, ShouldBeTrue)
+				})
+
+				Convey("Then the expired join-accept can be deleted", func() {
+					deleted, err := DeleteExpired(db, retention)
+					So(err, ShouldBeNil)
+					So(deleted, ShouldEqual, 1)
+				})
+			})
+		})
+	})
+}
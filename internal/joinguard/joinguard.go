@@ -0,0 +1,200 @@
+// Package joinguard implements the protection against replayed and flooded
+// join-requests, before these are forwarded to the application-server:
+//
+// - a history of the last DevNonce values per DevEUI, so that a join-request
+//   re-using one of these DevNonce values is rejected
+// - a token-bucket rate limiter per DevEUI and per gateway MAC
+//
+// The rejected join-requests are counted per reason and exposed as
+// Prometheus metrics.
+package joinguard
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const (
+	devNonceHistoryKeyTempl = "lora:ns:join:dev_nonces:%s"
+	deviceBucketKeyTempl    = "lora:ns:join:bucket:dev:%s"
+	gatewayBucketKeyTempl   = "lora:ns:join:bucket:gw:%s"
+)
+
+// Reason defines the reason why a join-request was rejected.
+type Reason string
+
+// Possible rejection reasons.
+const (
+	ReasonDevNonceReplay   Reason = "dev_nonce_replay"
+	ReasonDeviceRateLimit  Reason = "device_rate_limit"
+	ReasonGatewayRateLimit Reason = "gateway_rate_limit"
+)
+
+// rateLimit defines a token-bucket rate limit.
+type rateLimit struct {
+	rate  float64 // number of join-requests per minute (0 = disabled)
+	burst int     // max. number of join-requests which can be sent at once
+}
+
+// rejected contains the number of rejected join-requests per reason.
+var rejected = map[Reason]*uint64{
+	ReasonDevNonceReplay:   new(uint64),
+	ReasonDeviceRateLimit:  new(uint64),
+	ReasonGatewayRateLimit: new(uint64),
+}
+
+// addDevNonceScript adds the DevNonce to the history, unless it is already
+// in the history. It returns 1 when the DevNonce has been added.
+var addDevNonceScript = redis.NewScript(1, `
+	local nonces = redis.call("LRANGE", KEYS[1], 0, -1)
+	for _, n in ipairs(nonces) do
+		if n == ARGV[1] then
+			return 0
+		end
+	end
+	redis.call("LPUSH", KEYS[1], ARGV[1])
+	redis.call("LTRIM", KEYS[1], 0, tonumber(ARGV[2]) - 1)
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+	return 1
+`)
+
+// takeTokenScript takes a token from the bucket, after refilling it for the
+// time elapsed since the last call. It returns 1 when a token was
+// available. The bucket is removed once it has been refilled completely.
+var takeTokenScript = redis.NewScript(1, `
+	local rate = tonumber(ARGV[1])
+	local burst = tonumber(ARGV[2])
+	local now = tonumber(ARGV[3])
+
+	local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+	local tokens = tonumber(bucket[1])
+	local ts = tonumber(bucket[2])
+	if tokens == nil or ts == nil then
+		tokens = burst
+		ts = now
+	end
+
+	tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+	local allowed = 0
+	if tokens >= 1 then
+		tokens = tokens - 1
+		allowed = 1
+	end
+
+	redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+	redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate))
+	return allowed
+`)
+
+// Check returns the reason why the given join-request, received by the
+// given gateways, must be rejected. An empty reason is returned when the
+// join-request is allowed. A join-request is only rejected because of the
+// gateway rate limit when all receiving gateways exceed their limit.
+func Check(p *redis.Pool, jrPL lorawan.JoinRequestPayload, macs []lorawan.EUI64) (Reason, error) {
+	return check(p, jrPL, macs, time.Now())
+}
+
+func check(p *redis.Pool, jrPL lorawan.JoinRequestPayload, macs []lorawan.EUI64, now time.Time) (Reason, error) {
+	reason, err := getReason(p, jrPL, macs, now)
+	if err != nil {
+		return "", err
+	}
+	if reason != "" {
+		atomic.AddUint64(rejected[reason], 1)
+	}
+	return reason, nil
+}
+
+func getReason(p *redis.Pool, jrPL lorawan.JoinRequestPayload, macs []lorawan.EUI64, now time.Time) (Reason, error) {
+	c := p.Get()
+	defer c.Close()
+
+	if common.JoinDevNonceHistorySize > 0 {
+		added, err := redis.Int(addDevNonceScript.Do(c,
+			fmt.Sprintf(devNonceHistoryKeyTempl, jrPL.DevEUI),
+			hex.EncodeToString(jrPL.DevNonce[:]),
+			common.JoinDevNonceHistorySize,
+			int64(common.NodeSessionTTL)/int64(time.Millisecond),
+		))
+		if err != nil {
+			return "", errors.Wrap(err, "add dev-nonce to history error")
+		}
+		if added == 0 {
+			return ReasonDevNonceReplay, nil
+		}
+	}
+
+	if common.JoinDeviceRateLimit > 0 {
+		limit := rateLimit{rate: common.JoinDeviceRateLimit, burst: common.JoinDeviceRateLimitBurst}
+		ok, err := takeToken(c, fmt.Sprintf(deviceBucketKeyTempl, jrPL.DevEUI), limit, now)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return ReasonDeviceRateLimit, nil
+		}
+	}
+
+	if common.JoinGatewayRateLimit > 0 && len(macs) > 0 {
+		limit := rateLimit{rate: common.JoinGatewayRateLimit, burst: common.JoinGatewayRateLimitBurst}
+		var allowed bool
+		for _, mac := range macs {
+			ok, err := takeToken(c, fmt.Sprintf(gatewayBucketKeyTempl, mac), limit, now)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return ReasonGatewayRateLimit, nil
+		}
+	}
+
+	return "", nil
+}
+
+// takeToken takes a token from the bucket stored at the given key. It
+// returns false when no token was available.
+func takeToken(c redis.Conn, key string, limit rateLimit, now time.Time) (bool, error) {
+	burst := limit.burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	// the rate in tokens per millisecond
+	rate := limit.rate / float64(time.Minute/time.Millisecond)
+
+	ok, err := redis.Int(takeTokenScript.Do(c, key, rate, burst, now.UnixNano()/int64(time.Millisecond)))
+	if err != nil {
+		return false, errors.Wrap(err, "take rate-limit token error")
+	}
+	return ok == 1, nil
+}
+
+// WriteMetrics writes the number of rejected join-requests per reason in
+// the Prometheus text exposition format.
+func WriteMetrics(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	name := "loraserver_join_requests_rejected_total"
+	fmt.Fprintf(bw, "# HELP %s Number of join-requests rejected before being forwarded to the application-server.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	for _, reason := range []Reason{ReasonDevNonceReplay, ReasonDeviceRateLimit, ReasonGatewayRateLimit} {
+		fmt.Fprintf(bw, "%s{reason=%q} %d\n", name, reason, atomic.LoadUint64(rejected[reason]))
+	}
+
+	return bw.Flush()
+}
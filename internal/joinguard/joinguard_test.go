@@ -0,0 +1,110 @@
+package joinguard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestCheck(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		now := time.Now()
+		macs := []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}, {2, 2, 2, 2, 2, 2, 2, 2}}
+		jrPL := lorawan.JoinRequestPayload{
+			DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			DevNonce: [2]byte{1, 2},
+		}
+
+		Convey("Given a DevNonce history size of 2", func() {
+			common.JoinDevNonceHistorySize = 2
+
+			Convey("Then a join-request is allowed", func() {
+				reason, err := check(p, jrPL, macs, now)
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "")
+
+				Convey("Then a join-request re-using the DevNonce is rejected", func() {
+					reason, err := check(p, jrPL, macs, now)
+					So(err, ShouldBeNil)
+					So(reason, ShouldEqual, ReasonDevNonceReplay)
+				})
+
+				Convey("Then the DevNonce is allowed again once it has been pushed out of the history", func() {
+					for _, devNonce := range [][2]byte{{1, 3}, {1, 4}} {
+						jrPL := jrPL
+						jrPL.DevNonce = devNonce
+						reason, err := check(p, jrPL, macs, now)
+						So(err, ShouldBeNil)
+						So(reason, ShouldEqual, "")
+					}
+
+					reason, err := check(p, jrPL, macs, now)
+					So(err, ShouldBeNil)
+					So(reason, ShouldEqual, "")
+				})
+			})
+		})
+
+		Convey("Given a device rate limit of 1 join-request per minute with a burst of 2", func() {
+			common.JoinDevNonceHistorySize = 0
+			common.JoinDeviceRateLimit = 1
+			common.JoinDeviceRateLimitBurst = 2
+			defer func() {
+				common.JoinDeviceRateLimit = 0
+			}()
+
+			Convey("Then only the burst is allowed at once and the next join-request after a minute", func() {
+				var reasons []Reason
+				for _, ts := range []time.Time{now, now, now, now.Add(30 * time.Second), now.Add(time.Minute)} {
+					reason, err := check(p, jrPL, macs, ts)
+					So(err, ShouldBeNil)
+					reasons = append(reasons, reason)
+				}
+				So(reasons, ShouldResemble, []Reason{"", "", ReasonDeviceRateLimit, ReasonDeviceRateLimit, ""})
+			})
+		})
+
+		Convey("Given a gateway rate limit of 1 join-request per minute with a burst of 1", func() {
+			common.JoinDevNonceHistorySize = 0
+			common.JoinGatewayRateLimit = 1
+			common.JoinGatewayRateLimitBurst = 1
+			defer func() {
+				common.JoinGatewayRateLimit = 0
+			}()
+
+			Convey("Then a join-request is allowed when one of the gateways is within its limit", func() {
+				reason, err := check(p, jrPL, macs[:1], now)
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "")
+
+				reason, err = check(p, jrPL, macs, now)
+				So(err, ShouldBeNil)
+				So(reason, ShouldEqual, "")
+
+				Convey("Then a join-request is rejected when all gateways exceed their limit", func() {
+					reason, err := check(p, jrPL, macs, now)
+					So(err, ShouldBeNil)
+					So(reason, ShouldEqual, ReasonGatewayRateLimit)
+
+					Convey("Then the rejection is exposed as metric", func() {
+						var buf bytes.Buffer
+						So(WriteMetrics(&buf), ShouldBeNil)
+						So(strings.Contains(buf.String(), `loraserver_join_requests_rejected_total{reason="gateway_rate_limit"}`), ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
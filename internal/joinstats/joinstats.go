@@ -0,0 +1,245 @@
+// Package joinstats implements the join procedure statistics (join-request
+// to join-accept latency and the number of accepted and failed joins) per
+// gateway and per AppEUI.
+package joinstats
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// gatewayStatsKeyTempl contains per gateway a hash with the join counters.
+	gatewayStatsKeyTempl = "lora:ns:join:stats:gw:%s"
+
+	// appStatsKeyTempl contains per AppEUI a hash with the join counters.
+	appStatsKeyTempl = "lora:ns:join:stats:app:%s"
+
+	// gatewaysKey and appsKey contain the set of gateways / AppEUIs for
+	// which join stats have been recorded.
+	gatewaysKey = "lora:ns:join:stats:gws"
+	appsKey     = "lora:ns:join:stats:apps"
+
+	// Retention defines after how long the join stats of a gateway or
+	// AppEUI without joins are removed.
+	Retention = time.Hour * 24 * 7
+)
+
+// LatencyBuckets contains the upper bounds of the join latency histogram.
+var LatencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// Stats contains the join statistics of a gateway or AppEUI.
+type Stats struct {
+	RequestCount int           // number of handled join-requests
+	AcceptCount  int           // number of join-requests resulting in a join-accept
+	FailureCount int           // number of join-requests not resulting in a join-accept
+	LatencySum   time.Duration // sum of the join-request to join-accept latencies
+
+	// LatencyBucketCounts contains the number of accepted joins per latency
+	// bucket (see LatencyBuckets). The last item contains the number of
+	// accepted joins exceeding the last bucket.
+	LatencyBucketCounts []int
+}
+
+// AvgLatency returns the average join-request to join-accept latency.
+func (s Stats) AvgLatency() time.Duration {
+	if s.AcceptCount == 0 {
+		return 0
+	}
+	return s.LatencySum / time.Duration(s.AcceptCount)
+}
+
+// GatewayStats contains the join statistics of a gateway.
+type GatewayStats struct {
+	Stats
+	MAC lorawan.EUI64
+}
+
+// AppStats contains the join statistics of an AppEUI.
+type AppStats struct {
+	Stats
+	AppEUI lorawan.EUI64
+}
+
+type byMAC []GatewayStats
+
+func (s byMAC) Len() int           { return len(s) }
+func (s byMAC) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byMAC) Less(i, j int) bool { return s[i].MAC.String() < s[j].MAC.String() }
+
+type byAppEUI []AppStats
+
+func (s byAppEUI) Len() int           { return len(s) }
+func (s byAppEUI) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byAppEUI) Less(i, j int) bool { return s[i].AppEUI.String() < s[j].AppEUI.String() }
+
+// RecordJoin records the outcome of a join-request of the given AppEUI,
+// received by the given gateways. The latency is the time between receiving
+// the join-request and sending the join-accept and is only used when the
+// join was accepted.
+func RecordJoin(p *redis.Pool, appEUI lorawan.EUI64, macs []lorawan.EUI64, accepted bool, latency time.Duration) error {
+	keys := []string{fmt.Sprintf(appStatsKeyTempl, appEUI)}
+	for _, mac := range macs {
+		keys = append(keys, fmt.Sprintf(gatewayStatsKeyTempl, mac))
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("SADD", appsKey, appEUI.String())
+	for _, mac := range macs {
+		c.Send("SADD", gatewaysKey, mac.String())
+	}
+	for _, key := range keys {
+		c.Send("HINCRBY", key, "requests", 1)
+		if accepted {
+			c.Send("HINCRBY", key, "accepts", 1)
+			c.Send("HINCRBY", key, "latency_sum", int64(latency/time.Millisecond))
+			c.Send("HINCRBY", key, fmt.Sprintf("latency:%d", latencyBucket(latency)), 1)
+		} else {
+			c.Send("HINCRBY", key, "failures", 1)
+		}
+		c.Send("PEXPIRE", key, int64(Retention/time.Millisecond))
+	}
+	c.Send("PEXPIRE", appsKey, int64(Retention/time.Millisecond))
+	c.Send("PEXPIRE", gatewaysKey, int64(Retention/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "record join error")
+	}
+	return nil
+}
+
+// latencyBucket returns the index of the LatencyBuckets bucket of the
+// given latency (len(LatencyBuckets) when exceeding the last bucket).
+func latencyBucket(latency time.Duration) int {
+	for i, b := range LatencyBuckets {
+		if latency <= b {
+			return i
+		}
+	}
+	return len(LatencyBuckets)
+}
+
+// GetGatewayStats returns the join statistics per gateway, sorted by MAC.
+func GetGatewayStats(p *redis.Pool) ([]GatewayStats, error) {
+	var out []GatewayStats
+	err := getStats(p, gatewaysKey, gatewayStatsKeyTempl, func(id string, s Stats) error {
+		gs := GatewayStats{Stats: s}
+		if err := gs.MAC.UnmarshalText([]byte(id)); err != nil {
+			return errors.Wrap(err, "unmarshal mac error")
+		}
+		out = append(out, gs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(byMAC(out))
+	return out, nil
+}
+
+// GetAppStats returns the join statistics per AppEUI, sorted by AppEUI.
+func GetAppStats(p *redis.Pool) ([]AppStats, error) {
+	var out []AppStats
+	err := getStats(p, appsKey, appStatsKeyTempl, func(id string, s Stats) error {
+		appStats := AppStats{Stats: s}
+		if err := appStats.AppEUI.UnmarshalText([]byte(id)); err != nil {
+			return errors.Wrap(err, "unmarshal AppEUI error")
+		}
+		out = append(out, appStats)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(byAppEUI(out))
+	return out, nil
+}
+
+// getStats calls the given function with the stats of each member of the
+// given set. Members of which the stats have expired are removed from the
+// set.
+func getStats(p *redis.Pool, setKey, keyTempl string, fn func(id string, s Stats) error) error {
+	c := p.Get()
+	defer c.Close()
+
+	ids, err := redis.Strings(c.Do("SMEMBERS", setKey))
+	if err != nil {
+		return errors.Wrap(err, "get join stats members error")
+	}
+
+	for _, id := range ids {
+		values, err := redis.StringMap(c.Do("HGETALL", fmt.Sprintf(keyTempl, id)))
+		if err != nil {
+			return errors.Wrap(err, "get join stats error")
+		}
+
+		if len(values) == 0 {
+			if _, err := c.Do("SREM", setKey, id); err != nil {
+				return errors.Wrap(err, "remove join stats member error")
+			}
+			continue
+		}
+
+		s, err := parseStats(values)
+		if err != nil {
+			return errors.Wrapf(err, "parse join stats of %s error", id)
+		}
+		if err := fn(id, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseStats(values map[string]string) (Stats, error) {
+	s := Stats{
+		LatencyBucketCounts: make([]int, len(LatencyBuckets)+1),
+	}
+
+	for field, v := range values {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return s, errors.Wrapf(err, "parse value of field '%s' error", field)
+		}
+
+		switch {
+		case field == "requests":
+			s.RequestCount = i
+		case field == "accepts":
+			s.AcceptCount = i
+		case field == "failures":
+			s.FailureCount = i
+		case field == "latency_sum":
+			s.LatencySum = time.Duration(i) * time.Millisecond
+		case strings.HasPrefix(field, "latency:"):
+			var bucket int
+			if _, err := fmt.Sscanf(field, "latency:%d", &bucket); err != nil {
+				return s, errors.Wrapf(err, "parse field '%s' error", field)
+			}
+			if bucket >= 0 && bucket < len(s.LatencyBucketCounts) {
+				s.LatencyBucketCounts[bucket] = i
+			}
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,67 @@
+package joinstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJoinStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		appEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		Convey("When recording two accepted joins and a failed join", func() {
+			So(RecordJoin(p, appEUI, []lorawan.EUI64{gw1, gw2}, true, 80*time.Millisecond), ShouldBeNil)
+			So(RecordJoin(p, appEUI, []lorawan.EUI64{gw1}, true, 6*time.Second), ShouldBeNil)
+			So(RecordJoin(p, appEUI, []lorawan.EUI64{gw2}, false, time.Second), ShouldBeNil)
+
+			Convey("Then the stats per gateway are returned", func() {
+				stats, err := GetGatewayStats(p)
+				So(err, ShouldBeNil)
+				So(stats, ShouldResemble, []GatewayStats{
+					{
+						MAC: gw1,
+						Stats: Stats{
+							RequestCount:        2,
+							AcceptCount:         2,
+							LatencySum:          6080 * time.Millisecond,
+							LatencyBucketCounts: []int{0, 1, 0, 0, 0, 0, 0, 1},
+						},
+					},
+					{
+						MAC: gw2,
+						Stats: Stats{
+							RequestCount:        2,
+							AcceptCount:         1,
+							FailureCount:        1,
+							LatencySum:          80 * time.Millisecond,
+							LatencyBucketCounts: []int{0, 1, 0, 0, 0, 0, 0, 0},
+						},
+					},
+				})
+				So(stats[0].AvgLatency(), ShouldEqual, 3040*time.Millisecond)
+			})
+
+			Convey("Then the stats per AppEUI are returned", func() {
+				stats, err := GetAppStats(p)
+				So(err, ShouldBeNil)
+				So(stats, ShouldHaveLength, 1)
+				So(stats[0].AppEUI, ShouldEqual, appEUI)
+				So(stats[0].RequestCount, ShouldEqual, 3)
+				So(stats[0].AcceptCount, ShouldEqual, 2)
+				So(stats[0].FailureCount, ShouldEqual, 1)
+			})
+		})
+	})
+}
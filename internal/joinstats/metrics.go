@@ -0,0 +1,91 @@
+package joinstats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// metricsScope contains the stats of a single label value (gateway or
+// AppEUI) of the exposed metrics.
+type metricsScope struct {
+	label string
+	value string
+	Stats
+}
+
+// MetricsWriter returns a writer of the join stats in the Prometheus text
+// exposition format, to be exposed using metrics.Handler.
+func MetricsWriter(p *redis.Pool) func(io.Writer) error {
+	return func(w io.Writer) error {
+		gwStats, err := GetGatewayStats(p)
+		if err != nil {
+			return errors.Wrap(err, "get gateway join stats error")
+		}
+
+		appStats, err := GetAppStats(p)
+		if err != nil {
+			return errors.Wrap(err, "get app join stats error")
+		}
+
+		return writeMetrics(w, gwStats, appStats)
+	}
+}
+
+// writeMetrics writes the given join stats in the Prometheus text
+// exposition format.
+func writeMetrics(w io.Writer, gwStats []GatewayStats, appStats []AppStats) error {
+	bw := bufio.NewWriter(w)
+
+	var gwScopes, appScopes []metricsScope
+	for _, s := range gwStats {
+		gwScopes = append(gwScopes, metricsScope{label: "mac", value: s.MAC.String(), Stats: s.Stats})
+	}
+	for _, s := range appStats {
+		appScopes = append(appScopes, metricsScope{label: "app_eui", value: s.AppEUI.String(), Stats: s.Stats})
+	}
+
+	for _, m := range []struct {
+		prefix string
+		scopes []metricsScope
+	}{
+		{"loraserver_gateway_join", gwScopes},
+		{"loraserver_app_join", appScopes},
+	} {
+		writeCounter(bw, m.prefix+"_requests_total", "Number of handled join-requests.", m.scopes, func(s Stats) int { return s.RequestCount })
+		writeCounter(bw, m.prefix+"_accepts_total", "Number of join-requests resulting in a join-accept.", m.scopes, func(s Stats) int { return s.AcceptCount })
+		writeCounter(bw, m.prefix+"_failures_total", "Number of join-requests not resulting in a join-accept.", m.scopes, func(s Stats) int { return s.FailureCount })
+		writeLatencyHistogram(bw, m.prefix+"_latency_seconds", m.scopes)
+	}
+
+	return bw.Flush()
+}
+
+func writeCounter(w io.Writer, name, help string, scopes []metricsScope, value func(Stats) int) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	for _, s := range scopes {
+		fmt.Fprintf(w, "%s{%s=%q} %d\n", name, s.label, s.value, value(s.Stats))
+	}
+}
+
+func writeLatencyHistogram(w io.Writer, name string, scopes []metricsScope) {
+	fmt.Fprintf(w, "# HELP %s Join-request to join-accept latency.\n", name)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for _, s := range scopes {
+		var count int
+		for i, b := range LatencyBuckets {
+			count += s.LatencyBucketCounts[i]
+			le := strconv.FormatFloat(b.Seconds(), 'g', -1, 64)
+			fmt.Fprintf(w, "%s_bucket{%s=%q,le=%q} %d\n", name, s.label, s.value, le, count)
+		}
+		count += s.LatencyBucketCounts[len(LatencyBuckets)]
+		fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, s.label, s.value, count)
+		fmt.Fprintf(w, "%s_sum{%s=%q} %s\n", name, s.label, s.value, strconv.FormatFloat(s.LatencySum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(w, "%s_count{%s=%q} %d\n", name, s.label, s.value, count)
+	}
+}
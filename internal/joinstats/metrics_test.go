@@ -0,0 +1,54 @@
+package joinstats
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	Convey("Given join stats of a gateway", t, func() {
+		gwStats := []GatewayStats{
+			{
+				MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Stats: Stats{
+					RequestCount:        3,
+					AcceptCount:         2,
+					FailureCount:        1,
+					LatencySum:          1500 * time.Millisecond,
+					LatencyBucketCounts: []int{0, 1, 0, 0, 0, 1, 0, 0},
+				},
+			},
+		}
+
+		Convey("When writing the metrics", func() {
+			var buf bytes.Buffer
+			So(writeMetrics(&buf, gwStats, nil), ShouldBeNil)
+			out := buf.String()
+
+			Convey("Then the counters are written", func() {
+				So(out, ShouldContainSubstring, "# TYPE loraserver_gateway_join_requests_total counter\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_requests_total{mac=\"0102030405060708\"} 3\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_accepts_total{mac=\"0102030405060708\"} 2\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_failures_total{mac=\"0102030405060708\"} 1\n")
+			})
+
+			Convey("Then the latency histogram is written with cumulative buckets", func() {
+				So(out, ShouldContainSubstring, "# TYPE loraserver_gateway_join_latency_seconds histogram\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_bucket{mac=\"0102030405060708\",le=\"0.05\"} 0\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_bucket{mac=\"0102030405060708\",le=\"0.1\"} 1\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_bucket{mac=\"0102030405060708\",le=\"2.5\"} 2\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_bucket{mac=\"0102030405060708\",le=\"+Inf\"} 2\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_sum{mac=\"0102030405060708\"} 1.5\n")
+				So(out, ShouldContainSubstring, "loraserver_gateway_join_latency_seconds_count{mac=\"0102030405060708\"} 2\n")
+			})
+
+			Convey("Then the app metrics are written without samples", func() {
+				So(out, ShouldContainSubstring, "# TYPE loraserver_app_join_requests_total counter\n")
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+// Package keywrap implements the AES key wrap algorithm (RFC 3394), used
+// for exchanging session-keys which are wrapped by a key encryption key
+// (KEK).
+package keywrap
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+// defaultIV contains the default initial value as defined by RFC 3394.
+var defaultIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// ErrInvalidKey is returned on an unwrap integrity check failure (e.g. the
+// key was wrapped using an other KEK).
+var ErrInvalidKey = errors.New("keywrap: integrity check failed")
+
+// Wrap wraps the given key (which must be a multiple of 8 bytes and at
+// least 16 bytes long) with the given KEK.
+func Wrap(kek, key []byte) ([]byte, error) {
+	if len(key)%8 != 0 || len(key) < 16 {
+		return nil, errors.New("keywrap: key must be a multiple of 8 bytes and at least 16 bytes")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(key) / 8
+	r := make([]byte, len(key))
+	copy(r, key)
+
+	a := make([]byte, 8)
+	copy(a, defaultIV)
+
+	b := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 0; i < n; i++ {
+			copy(b[:8], a)
+			copy(b[8:], r[i*8:(i+1)*8])
+			block.Encrypt(b, b)
+
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(b[:8])^t)
+			copy(r[i*8:(i+1)*8], b[8:])
+		}
+	}
+
+	return append(a, r...), nil
+}
+
+// Unwrap unwraps the given wrapped key with the given KEK.
+func Unwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped)%8 != 0 || len(wrapped) < 24 {
+		return nil, errors.New("keywrap: wrapped key must be a multiple of 8 bytes and at least 24 bytes")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(wrapped)/8 - 1
+	r := make([]byte, n*8)
+	copy(r, wrapped[8:])
+
+	a := make([]byte, 8)
+	copy(a, wrapped[:8])
+
+	b := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n - 1; i >= 0; i-- {
+			t := uint64(n*j + i + 1)
+			binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(a)^t)
+			copy(b[8:], r[i*8:(i+1)*8])
+			block.Decrypt(b, b)
+
+			copy(a, b[:8])
+			copy(r[i*8:(i+1)*8], b[8:])
+		}
+	}
+
+	if subtle.ConstantTimeCompare(a, defaultIV) != 1 {
+		return nil, ErrInvalidKey
+	}
+
+	return r, nil
+}
@@ -0,0 +1,37 @@
+package keywrap
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKeyWrap(t *testing.T) {
+	Convey("Given the RFC 3394 128 bit KEK / 128 bit key test vector", t, func() {
+		kek := []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+		key := []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+		wrapped := []byte{0x1f, 0xa6, 0x8b, 0x0a, 0x81, 0x12, 0xb4, 0x47, 0xae, 0xf3, 0x4b, 0xd8, 0xfb, 0x5a, 0x7b, 0x82, 0x9d, 0x3e, 0x86, 0x23, 0x71, 0xd2, 0xcf, 0xe5}
+
+		Convey("Then Wrap returns the expected wrapped key", func() {
+			b, err := Wrap(kek, key)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, wrapped)
+		})
+
+		Convey("Then Unwrap returns the expected key", func() {
+			b, err := Unwrap(kek, wrapped)
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, key)
+		})
+
+		Convey("Then Unwrap with an other KEK returns ErrInvalidKey", func() {
+			_, err := Unwrap(key, wrapped)
+			So(err, ShouldEqual, ErrInvalidKey)
+		})
+
+		Convey("Then Unwrap with an invalid length returns an error", func() {
+			_, err := Unwrap(kek, wrapped[:16])
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,168 @@
+// Package leader implements a Redis based leader election, so that only
+// one of multiple LoRa Server instances runs the singleton schedulers
+// (e.g. the gateway stats timeout check) while all instances process
+// uplinks.
+package leader
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+const leaderKeyTempl = "lora:ns:leader:%s" // contains the id of the current leader
+
+// renewScript extends the leadership TTL, but only when the given id is
+// still the leader.
+var renewScript = redis.NewScript(1, `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// releaseScript removes the leadership, but only when the given id is
+// still the leader.
+var releaseScript = redis.NewScript(1, `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// Elector implements the leader election for a single election name.
+type Elector struct {
+	p   *redis.Pool
+	key string
+	id  string
+	ttl time.Duration
+
+	mu     sync.RWMutex
+	leader bool
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// NewElector creates a new Elector for the given election name. The
+// leadership is renewed every ttl / 3 and expires after ttl in case the
+// leader stops renewing (e.g. it crashed).
+func NewElector(p *redis.Pool, name string, ttl time.Duration) (*Elector, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+
+	return &Elector{
+		p:    p,
+		key:  fmt.Sprintf(leaderKeyTempl, name),
+		id:   hex.EncodeToString(b),
+		ttl:  ttl,
+		done: make(chan struct{}),
+	}, nil
+}
+
+// Start starts the election. The first campaign is executed before
+// returning, so that IsLeader returns the correct state directly after
+// starting.
+func (e *Elector) Start() error {
+	if err := e.campaign(); err != nil {
+		return errors.Wrap(err, "campaign error")
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := e.campaign(); err != nil {
+					log.WithField("key", e.key).Errorf("leader election campaign error: %s", err)
+				}
+			case <-e.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the election and releases the leadership (if leader), so that
+// an other instance can take over directly.
+func (e *Elector) Stop() error {
+	close(e.done)
+	e.wg.Wait()
+
+	e.setLeader(false)
+
+	c := e.p.Get()
+	defer c.Close()
+
+	if _, err := releaseScript.Do(c, e.key, e.id); err != nil {
+		return errors.Wrap(err, "release leadership error")
+	}
+	return nil
+}
+
+// IsLeader returns true when this instance is the leader.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.leader
+}
+
+// campaign renews the leadership when leader, or tries to become the leader
+// when there is no leader.
+func (e *Elector) campaign() error {
+	c := e.p.Get()
+	defer c.Close()
+
+	ttl := int64(e.ttl / time.Millisecond)
+
+	renewed, err := redis.Int(renewScript.Do(c, e.key, e.id, ttl))
+	if err != nil {
+		e.setLeader(false)
+		return errors.Wrap(err, "renew leadership error")
+	}
+	if renewed == 1 {
+		e.setLeader(true)
+		return nil
+	}
+
+	_, err = redis.String(c.Do("SET", e.key, e.id, "PX", ttl, "NX"))
+	if err != nil {
+		e.setLeader(false)
+		if err == redis.ErrNil {
+			// an other instance is the leader
+			return nil
+		}
+		return errors.Wrap(err, "acquire leadership error")
+	}
+
+	e.setLeader(true)
+	return nil
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.leader != leader {
+		log.WithFields(log.Fields{
+			"key":    e.key,
+			"id":     e.id,
+			"leader": leader,
+		}).Info("leadership changed")
+	}
+	e.leader = leader
+}
@@ -0,0 +1,53 @@
+package leader
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestElector(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and two electors", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		e1, err := NewElector(p, "test", time.Second)
+		So(err, ShouldBeNil)
+		e2, err := NewElector(p, "test", time.Second)
+		So(err, ShouldBeNil)
+
+		Convey("When starting both electors", func() {
+			So(e1.Start(), ShouldBeNil)
+			So(e2.Start(), ShouldBeNil)
+
+			Convey("Then only the first one is the leader", func() {
+				So(e1.IsLeader(), ShouldBeTrue)
+				So(e2.IsLeader(), ShouldBeFalse)
+
+				Convey("Then the leadership is kept after the ttl", func() {
+					time.Sleep(1500 * time.Millisecond)
+					So(e1.IsLeader(), ShouldBeTrue)
+					So(e2.IsLeader(), ShouldBeFalse)
+					So(e1.Stop(), ShouldBeNil)
+					So(e2.Stop(), ShouldBeNil)
+				})
+
+				Convey("When stopping the leader", func() {
+					So(e1.Stop(), ShouldBeNil)
+
+					Convey("Then the other elector takes over", func() {
+						time.Sleep(500 * time.Millisecond)
+						So(e1.IsLeader(), ShouldBeFalse)
+						So(e2.IsLeader(), ShouldBeTrue)
+						So(e2.Stop(), ShouldBeNil)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,119 @@
+// Package linkmargin implements the link margin audit: finding the nodes of
+// which the uplink link margin (the SNR above the demodulation floor of the
+// used spreading-factor) is consistently low. These nodes are candidates for
+// relocation or an external antenna.
+package linkmargin
+
+import (
+	"errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// ErrUnsupportedDataRate is returned for data-rates without a known
+// demodulation floor (e.g. FSK).
+var ErrUnsupportedDataRate = errors.New("data-rate not supported for link margin calculation")
+
+// requiredSNR contains the demodulation floor (in dB) per LoRa
+// spreading-factor.
+var requiredSNR = map[int]float64{
+	7:  -7.5,
+	8:  -10,
+	9:  -12.5,
+	10: -15,
+	11: -17.5,
+	12: -20,
+}
+
+// Filters defines the link margin audit filters.
+type Filters struct {
+	Threshold  float64        // link margin (dB) below which an uplink is counted as low
+	MinFrames  int            // min. number of uplinks in the history of the node
+	Percentage int            // min. percentage of the uplinks with a low link margin
+	MAC        *lorawan.EUI64 // only nodes received by this gateway (last uplink)
+	AppEUI     *lorawan.EUI64 // only nodes of this AppEUI
+}
+
+// Device contains the link margin stats of a node.
+type Device struct {
+	DevEUI        lorawan.EUI64
+	AppEUI        lorawan.EUI64
+	MAC           lorawan.EUI64 // gateway with the best reception of the last uplink
+	DataRate      band.DataRate
+	Frames        int
+	LowFrames     int // uplinks with a link margin below the threshold
+	MinMargin     float64
+	AverageMargin float64
+}
+
+// GetRequiredSNR returns the demodulation floor of the given data-rate.
+func GetRequiredSNR(dr band.DataRate) (float64, error) {
+	if dr.Modulation != band.LoRaModulation {
+		return 0, ErrUnsupportedDataRate
+	}
+	snr, ok := requiredSNR[dr.SpreadFactor]
+	if !ok {
+		return 0, ErrUnsupportedDataRate
+	}
+	return snr, nil
+}
+
+// GetDevice returns the link margin stats of the given node-session, based
+// on the max. SNR of the uplink history and the data-rate of the last
+// uplink. It returns false when the node does not match the given filters.
+func GetDevice(ns session.NodeSession, filters Filters) (Device, bool) {
+	if len(ns.LastRXInfoSet) == 0 || len(ns.UplinkHistory) == 0 || len(ns.UplinkHistory) < filters.MinFrames {
+		return Device{}, false
+	}
+	if filters.AppEUI != nil && ns.AppEUI != *filters.AppEUI {
+		return Device{}, false
+	}
+	if filters.MAC != nil {
+		var found bool
+		for _, rxInfo := range ns.LastRXInfoSet {
+			if rxInfo.MAC == *filters.MAC {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return Device{}, false
+		}
+	}
+
+	dr := ns.LastRXInfoSet[0].DataRate
+	floor, err := GetRequiredSNR(dr)
+	if err != nil {
+		return Device{}, false
+	}
+
+	d := Device{
+		DevEUI:   ns.DevEUI,
+		AppEUI:   ns.AppEUI,
+		MAC:      ns.LastRXInfoSet[0].MAC,
+		DataRate: dr,
+		Frames:   len(ns.UplinkHistory),
+	}
+
+	var sum float64
+	for i, uh := range ns.UplinkHistory {
+		margin := uh.MaxSNR - floor
+		if i == 0 || margin < d.MinMargin {
+			d.MinMargin = margin
+		}
+		if margin < filters.Threshold {
+			d.LowFrames++
+		}
+		sum += margin
+	}
+	d.AverageMargin = sum / float64(d.Frames)
+
+	if d.LowFrames == 0 || d.LowFrames*100 < filters.Percentage*d.Frames {
+		return Device{}, false
+	}
+
+	return d, true
+}
@@ -0,0 +1,104 @@
+package linkmargin
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+func TestGetRequiredSNR(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			DataRate    band.DataRate
+			ExpectedSNR float64
+			ExpectedErr error
+		}{
+			{band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 7, Bandwidth: 125}, -7.5, nil},
+			{band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 12, Bandwidth: 125}, -20, nil},
+			{band.DataRate{Modulation: band.FSKModulation, BitRate: 50000}, 0, ErrUnsupportedDataRate},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %v [%d]", test.DataRate, i), func() {
+				snr, err := GetRequiredSNR(test.DataRate)
+				So(err, ShouldResemble, test.ExpectedErr)
+				So(snr, ShouldEqual, test.ExpectedSNR)
+			})
+		}
+	})
+}
+
+func TestGetDevice(t *testing.T) {
+	Convey("Given a node-session at SF10 with an uplink history", t, func() {
+		mac1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		mac2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		appEUI := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+
+		ns := session.NodeSession{
+			DevEUI: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4},
+			AppEUI: appEUI,
+			LastRXInfoSet: []gw.RXInfo{
+				{MAC: mac1, DataRate: band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 10, Bandwidth: 125}},
+				{MAC: mac2, DataRate: band.DataRate{Modulation: band.LoRaModulation, SpreadFactor: 10, Bandwidth: 125}},
+			},
+			UplinkHistory: []session.UplinkHistory{
+				{FCnt: 1, MaxSNR: -14},   // margin 1
+				{FCnt: 2, MaxSNR: -12},   // margin 3
+				{FCnt: 3, MaxSNR: -8},    // margin 7
+				{FCnt: 4, MaxSNR: -13.5}, // margin 1.5
+			},
+		}
+
+		Convey("Then the link margin stats are returned when the node matches the filters", func() {
+			d, ok := GetDevice(ns, Filters{Threshold: 5, MinFrames: 4, Percentage: 75})
+			So(ok, ShouldBeTrue)
+			So(d, ShouldResemble, Device{
+				DevEUI:        ns.DevEUI,
+				AppEUI:        appEUI,
+				MAC:           mac1,
+				DataRate:      ns.LastRXInfoSet[0].DataRate,
+				Frames:        4,
+				LowFrames:     3,
+				MinMargin:     1,
+				AverageMargin: 3.125,
+			})
+		})
+
+		Convey("Then the node is filtered on the percentage of low uplinks", func() {
+			_, ok := GetDevice(ns, Filters{Threshold: 5, MinFrames: 4, Percentage: 80})
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then the node is filtered on the min. number of uplinks", func() {
+			_, ok := GetDevice(ns, Filters{Threshold: 5, MinFrames: 5})
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then the node is filtered on the gateway", func() {
+			_, ok := GetDevice(ns, Filters{Threshold: 5, MAC: &mac2})
+			So(ok, ShouldBeTrue)
+
+			_, ok = GetDevice(ns, Filters{Threshold: 5, MAC: &appEUI})
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then the node is filtered on the AppEUI", func() {
+			_, ok := GetDevice(ns, Filters{Threshold: 5, AppEUI: &appEUI})
+			So(ok, ShouldBeTrue)
+
+			_, ok = GetDevice(ns, Filters{Threshold: 5, AppEUI: &mac1})
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("Then the node is not returned when no uplink is below the threshold", func() {
+			_, ok := GetDevice(ns, Filters{Threshold: 1})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package maccommand
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// historyKeyTempl contains per node a list of the last mac-commands sent
+// to and received from the node (newest first).
+const historyKeyTempl = "lora:ns:mac:history:%s"
+
+const (
+	// historySize defines the number of mac-commands kept per node.
+	historySize = 50
+
+	// HistoryTTL defines how long the mac-command history of a node is kept
+	// after its last mac-command.
+	HistoryTTL = time.Hour * 24 * 30
+)
+
+// HistoryItem contains a mac-command sent to or received from a node.
+type HistoryItem struct {
+	Time   time.Time
+	Uplink bool // the mac-command was received from the node
+	CID    lorawan.CID
+	Data   []byte // binary encoded mac-command (including CID)
+	FCnt   uint32 // frame-counter of the frame containing the mac-command
+}
+
+// RecordHistory adds the given mac-commands to the mac-command history of
+// the given node. Errors are logged as they must not affect the handling of
+// the frame containing the mac-commands.
+func RecordHistory(p *redis.Pool, devEUI lorawan.EUI64, uplink bool, fCnt uint32, commands []lorawan.MACCommand) {
+	if len(commands) == 0 {
+		return
+	}
+
+	now := time.Now()
+	var items []HistoryItem
+	for _, cmd := range commands {
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": devEUI,
+				"cid":     cmd.CID,
+			}).Errorf("binary marshal mac-command error: %s", err)
+			continue
+		}
+		items = append(items, HistoryItem{
+			Time:   now,
+			Uplink: uplink,
+			CID:    cmd.CID,
+			Data:   b,
+			FCnt:   fCnt,
+		})
+	}
+
+	if err := saveHistoryItems(p, devEUI, items); err != nil {
+		log.WithField("dev_eui", devEUI).Errorf("save mac-command history error: %s", err)
+	}
+}
+
+// saveHistoryItems adds the given items to the mac-command history of the
+// node.
+func saveHistoryItems(p *redis.Pool, devEUI lorawan.EUI64, items []HistoryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf(historyKeyTempl, devEUI)
+	args := []interface{}{key}
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+			return errors.Wrap(err, "gob encode mac-command history item error")
+		}
+		args = append(args, buf.Bytes())
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", args...)
+	c.Send("LTRIM", key, 0, historySize-1)
+	c.Send("PEXPIRE", key, int64(HistoryTTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "save mac-command history error")
+	}
+	return nil
+}
+
+// GetHistoryCount returns the number of items in the mac-command history
+// of the given node.
+func GetHistoryCount(p *redis.Pool, devEUI lorawan.EUI64) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("LLEN", fmt.Sprintf(historyKeyTempl, devEUI)))
+	if err != nil {
+		return 0, errors.Wrap(err, "get mac-command history count error")
+	}
+	return count, nil
+}
+
+// GetHistory returns the mac-command history of the given node (newest
+// first), respecting the given limit (0 = all) and offset.
+func GetHistory(p *redis.Pool, devEUI lorawan.EUI64, limit, offset int) ([]HistoryItem, error) {
+	stop := -1
+	if limit > 0 {
+		stop = offset + limit - 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("LRANGE", fmt.Sprintf(historyKeyTempl, devEUI), offset, stop))
+	if err != nil {
+		return nil, errors.Wrap(err, "get mac-command history error")
+	}
+
+	var out []HistoryItem
+	for _, b := range values {
+		var item HistoryItem
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&item); err != nil {
+			return nil, errors.Wrap(err, "gob decode mac-command history item error")
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHistory(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When recording a sent LinkADRReq and a received LinkADRAns", func() {
+			req := lorawan.MACCommand{
+				CID: lorawan.LinkADRReq,
+				Payload: &lorawan.LinkADRReqPayload{
+					DataRate: 5,
+					TXPower:  2,
+					ChMask:   [16]bool{true, true, true},
+					Redundancy: lorawan.Redundancy{
+						NbRep: 1,
+					},
+				},
+			}
+			ans := lorawan.MACCommand{
+				CID: lorawan.LinkADRAns,
+				Payload: &lorawan.LinkADRAnsPayload{
+					ChannelMaskACK: true,
+					DataRateACK:    true,
+					PowerACK:       true,
+				},
+			}
+			RecordHistory(p, devEUI, false, 10, []lorawan.MACCommand{req})
+			RecordHistory(p, devEUI, true, 11, []lorawan.MACCommand{ans})
+
+			Convey("Then the history contains both mac-commands (newest first)", func() {
+				reqB, err := req.MarshalBinary()
+				So(err, ShouldBeNil)
+				ansB, err := ans.MarshalBinary()
+				So(err, ShouldBeNil)
+
+				items, err := GetHistory(p, devEUI, 0, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 2)
+
+				So(items[0].Uplink, ShouldBeTrue)
+				So(items[0].CID, ShouldEqual, lorawan.LinkADRAns)
+				So(items[0].Data, ShouldResemble, ansB)
+				So(items[0].FCnt, ShouldEqual, 11)
+				So(items[0].Time.IsZero(), ShouldBeFalse)
+
+				So(items[1].Uplink, ShouldBeFalse)
+				So(items[1].CID, ShouldEqual, lorawan.LinkADRReq)
+				So(items[1].Data, ShouldResemble, reqB)
+				So(items[1].FCnt, ShouldEqual, 10)
+			})
+
+			Convey("Then the history can be paginated", func() {
+				count, err := GetHistoryCount(p, devEUI)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 2)
+
+				items, err := GetHistory(p, devEUI, 1, 1)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+				So(items[0].FCnt, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When recording more mac-commands than the history size", func() {
+			for i := 0; i < historySize+5; i++ {
+				RecordHistory(p, devEUI, true, uint32(i), []lorawan.MACCommand{
+					{CID: lorawan.LinkCheckReq},
+				})
+			}
+
+			Convey("Then the history is capped to the history size", func() {
+				items, err := GetHistory(p, devEUI, 0, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, historySize)
+				So(items[0].FCnt, ShouldEqual, historySize+4)
+			})
+		})
+	})
+}
@@ -3,10 +3,12 @@ package maccommand
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/digest"
 	"github.com/joriwind/loraserver/internal/session"
 	"github.com/brocaar/lorawan"
 )
@@ -17,6 +19,14 @@ func Handle(ctx common.Context, ns *session.NodeSession, cmd lorawan.MACCommand)
 	switch cmd.CID {
 	case lorawan.LinkADRAns:
 		err = handleLinkADRAns(ctx, ns, cmd.Payload)
+	case lorawan.DevStatusAns:
+		err = handleDevStatusAns(ns, cmd.Payload)
+	case lorawan.RXParamSetupAns:
+		err = handleRXParamSetupAns(ctx, ns, cmd.Payload)
+	case lorawan.RXTimingSetupAns:
+		err = handleRXTimingSetupAns(ctx, ns)
+	case lorawan.NewChannelAns:
+		err = handleNewChannelAns(ctx, ns, cmd.Payload)
 	default:
 		err = fmt.Errorf("undefined CID %d", cmd.CID)
 
@@ -47,6 +57,19 @@ func handleLinkADRAns(ctx common.Context, ns *session.NodeSession, pl lorawan.MA
 		ns.TXPower = common.Band.TXPower[adrReq.TXPower]
 		ns.NbTrans = adrReq.Redundancy.NbRep
 
+		// apply the channel masks of the (block of) requests when the
+		// uplink channels of the node are tracked or restricted by a
+		// channel mask
+		if ns.UplinkChannels != nil || ns.ChannelMask != nil {
+			var payloads []lorawan.LinkADRReqPayload
+			for _, pl := range pending {
+				if p, ok := pl.(*lorawan.LinkADRReqPayload); ok {
+					payloads = append(payloads, *p)
+				}
+			}
+			ns.ApplyChMasks(payloads)
+		}
+
 		log.WithFields(log.Fields{
 			"dev_eui":  ns.DevEUI,
 			"tx_power": ns.TXPower,
@@ -60,7 +83,91 @@ func handleLinkADRAns(ctx common.Context, ns *session.NodeSession, pl lorawan.MA
 			"data_rate_ack":    adrAns.DataRateACK,
 			"power_ack":        adrAns.PowerACK,
 		}).Warning("adr request not acknowledged")
+
+		if err := digest.Record(ctx.RedisPool, digest.ADRFailure, ns.DevEUI.String()); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("record digest event error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// handleDevStatusAns stores the battery level reported by the node.
+func handleDevStatusAns(ns *session.NodeSession, pl lorawan.MACCommandPayload) error {
+	devStatusAns, ok := pl.(*lorawan.DevStatusAnsPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.DevStatusAnsPayload, got %T", pl)
+	}
+
+	ns.BatteryLevel = devStatusAns.Battery
+	ns.BatteryLevelUpdatedAt = time.Now()
+
+	log.WithFields(log.Fields{
+		"dev_eui": ns.DevEUI,
+		"battery": devStatusAns.Battery,
+		"margin":  devStatusAns.Margin,
+	}).Info("device status received")
+
+	return nil
+}
+
+// handleNewChannelAns applies the first pending NewChannelReq to the uplink
+// channels of the node-session when acknowledged by the node. The
+// remaining NewChannelReq mac-commands stay pending.
+func handleNewChannelAns(ctx common.Context, ns *session.NodeSession, pl lorawan.MACCommandPayload) error {
+	ans, ok := pl.(*lorawan.NewChannelAnsPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.NewChannelAnsPayload, got %T", pl)
 	}
 
+	pending, err := ReadPending(ctx.RedisPool, ns.DevEUI, lorawan.NewChannelReq)
+	if err != nil {
+		return fmt.Errorf("read pending mac-commands error: %s", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	req, ok := pending[0].(*lorawan.NewChannelReqPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.NewChannelReqPayload, got %T", pending[0])
+	}
+
+	if len(pending) > 1 {
+		err = SetPending(ctx.RedisPool, ns.DevEUI, lorawan.NewChannelReq, pending[1:])
+	} else {
+		err = DeletePending(ctx.RedisPool, ns.DevEUI, lorawan.NewChannelReq)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ans.ChannelFrequencyOK || !ans.DataRateRangeOK {
+		log.WithFields(log.Fields{
+			"dev_eui":              ns.DevEUI,
+			"ch_index":             req.ChIndex,
+			"channel_frequency_ok": ans.ChannelFrequencyOK,
+			"data_rate_range_ok":   ans.DataRateRangeOK,
+		}).Warning("new channel request not acknowledged")
+
+		return notifyRejected(ctx, *ns, fmt.Sprintf("new channel request for channel %d not acknowledged (channel frequency ok: %t, data-rate range ok: %t)", req.ChIndex, ans.ChannelFrequencyOK, ans.DataRateRangeOK))
+	}
+
+	// a created or modified channel is enabled by the node
+	ns.SetUplinkChannel(session.UplinkChannel{
+		Index:     int(req.ChIndex),
+		Frequency: int(req.Freq),
+		MinDR:     int(req.MinDR),
+		MaxDR:     int(req.MaxDR),
+		Enabled:   true,
+	})
+
+	log.WithFields(log.Fields{
+		"dev_eui":   ns.DevEUI,
+		"ch_index":  req.ChIndex,
+		"frequency": req.Freq,
+		"min_dr":    req.MinDR,
+		"max_dr":    req.MaxDR,
+	}).Info("new channel request acknowledged")
 	return nil
 }
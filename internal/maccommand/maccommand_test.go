@@ -18,8 +18,10 @@ func TestHandle(t *testing.T) {
 		p := common.NewRedisPool(conf.RedisURL)
 		test.MustFlushRedis(p)
 
+		ctrl := test.NewNetworkControllerClient()
 		ctx := common.Context{
-			RedisPool: p,
+			RedisPool:  p,
+			Controller: ctrl,
 		}
 
 		Convey("Given a node-session", func() {
@@ -73,6 +75,156 @@ func TestHandle(t *testing.T) {
 					})
 				})
 			})
+
+			Convey("Testing NewChannelAns", func() {
+				newChannelAnsPL := &lorawan.NewChannelAnsPayload{
+					ChannelFrequencyOK: true,
+					DataRateRangeOK:    true,
+				}
+				newChannelAns := lorawan.MACCommand{
+					CID:     lorawan.NewChannelAns,
+					Payload: newChannelAnsPL,
+				}
+				So(SetPending(p, ns.DevEUI, lorawan.NewChannelReq, []lorawan.MACCommandPayload{
+					&lorawan.NewChannelReqPayload{ChIndex: 3, Freq: 867100000, MaxDR: 5},
+					&lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5},
+				}), ShouldBeNil)
+
+				Convey("Given a positive ack", func() {
+					So(Handle(ctx, &ns, newChannelAns), ShouldBeNil)
+
+					Convey("Then the channel is added to the node-session", func() {
+						So(ns.GetEnabledUplinkChannels(), ShouldResemble, []int{0, 1, 2, 3})
+					})
+
+					Convey("Then the second request is still pending", func() {
+						pending, err := ReadPending(p, ns.DevEUI, lorawan.NewChannelReq)
+						So(err, ShouldBeNil)
+						So(pending, ShouldResemble, []lorawan.MACCommandPayload{
+							&lorawan.NewChannelReqPayload{ChIndex: 4, Freq: 867300000, MaxDR: 5},
+						})
+					})
+				})
+
+				Convey("Given a negative ack", func() {
+					newChannelAnsPL.ChannelFrequencyOK = false
+					So(Handle(ctx, &ns, newChannelAns), ShouldBeNil)
+
+					Convey("Then the uplink channels are not changed", func() {
+						So(ns.UplinkChannels, ShouldBeNil)
+					})
+
+					Convey("Then the network-controller is notified", func() {
+						req := <-ctrl.HandleErrorChan
+						So(req.DevEUI, ShouldResemble, ns.DevEUI[:])
+						So(req.Error, ShouldContainSubstring, "channel frequency ok: false")
+					})
+				})
+			})
+
+			Convey("Testing RXParamSetupAns", func() {
+				ns.RX2DR = 3
+				ns.RX1DROffset = 1
+				current := GetRXParamSetupPayload(ns)
+				requested := lorawan.RX2SetupReqPayload{
+					Frequency: 869525000,
+					DLSettings: lorawan.DLSettings{
+						RX2DataRate: 5,
+						RX1DROffset: 2,
+					},
+				}
+				ansPL := &lorawan.RX2SetupAnsPayload{
+					ChannelACK:     true,
+					RX2DataRateACK: true,
+					RX1DROffsetACK: true,
+				}
+				ans := lorawan.MACCommand{
+					CID:     lorawan.RXParamSetupAns,
+					Payload: ansPL,
+				}
+				So(SetPendingRXParamSetup(p, ns.DevEUI, requested, current), ShouldBeNil)
+
+				Convey("Given a positive ack", func() {
+					So(Handle(ctx, &ns, ans), ShouldBeNil)
+
+					Convey("Then the requested parameters are applied to the node-session", func() {
+						So(ns.RX2DR, ShouldEqual, 5)
+						So(ns.RX1DROffset, ShouldEqual, 2)
+						So(ns.GetRX2Frequency(), ShouldEqual, 869525000)
+					})
+
+					Convey("Then the pending request has been removed", func() {
+						pending, err := ReadPending(p, ns.DevEUI, lorawan.RXParamSetupReq)
+						So(err, ShouldBeNil)
+						So(pending, ShouldHaveLength, 0)
+					})
+				})
+
+				Convey("Given a negative ack and a node-session already updated to the requested parameters", func() {
+					ansPL.RX2DataRateACK = false
+					ns.RX2DR = 5
+					ns.RX1DROffset = 2
+					So(Handle(ctx, &ns, ans), ShouldBeNil)
+
+					Convey("Then the node-session is reverted to the parameters used by the node", func() {
+						So(ns.RX2DR, ShouldEqual, 3)
+						So(ns.RX1DROffset, ShouldEqual, 1)
+						So(ns.GetRX2Frequency(), ShouldEqual, common.Band.RX2Frequency)
+					})
+
+					Convey("Then the network-controller is notified", func() {
+						req := <-ctrl.HandleErrorChan
+						So(req.DevEUI, ShouldResemble, ns.DevEUI[:])
+						So(req.Error, ShouldContainSubstring, "rx2 data-rate ack: false")
+					})
+				})
+			})
+
+			Convey("Testing RXTimingSetupAns", func() {
+				ns.RXDelay = 1
+				ans := lorawan.MACCommand{CID: lorawan.RXTimingSetupAns}
+
+				Convey("Given no pending request", func() {
+					So(Handle(ctx, &ns, ans), ShouldBeNil)
+
+					Convey("Then the node-session is not updated", func() {
+						So(ns.RXDelay, ShouldEqual, 1)
+					})
+				})
+
+				Convey("Given a pending request enqueued by the network-controller", func() {
+					mac := lorawan.MACCommand{
+						CID:     lorawan.RXTimingSetupReq,
+						Payload: &lorawan.RXTimingSetupReqPayload{Delay: 5},
+					}
+					b, err := mac.MarshalBinary()
+					So(err, ShouldBeNil)
+					So(SetPendingSessionChanges(p, ns, QueueItem{DevEUI: ns.DevEUI, Data: b}), ShouldBeNil)
+					So(ns.RXDelay, ShouldEqual, 1)
+
+					So(Handle(ctx, &ns, ans), ShouldBeNil)
+
+					Convey("Then the requested delay is applied to the node-session", func() {
+						So(ns.RXDelay, ShouldEqual, 5)
+					})
+				})
+			})
+
+			Convey("Testing DevStatusAns", func() {
+				devStatusAns := lorawan.MACCommand{
+					CID: lorawan.DevStatusAns,
+					Payload: &lorawan.DevStatusAnsPayload{
+						Battery: 120,
+						Margin:  10,
+					},
+				}
+				So(Handle(ctx, &ns, devStatusAns), ShouldBeNil)
+
+				Convey("Then the battery level is stored in the node-session", func() {
+					So(ns.BatteryLevel, ShouldEqual, 120)
+					So(ns.BatteryLevelUpdatedAt.IsZero(), ShouldBeFalse)
+				})
+			})
 		})
 	})
 }
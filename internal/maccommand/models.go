@@ -1,12 +1,30 @@
 package maccommand
 
-import "github.com/brocaar/lorawan"
+import (
+	"time"
+
+	"github.com/brocaar/lorawan"
+)
 
 // QueueItem contains data from a MAC command.
 type QueueItem struct {
 	FRMPayload bool // indicating if the mac command was or must be sent as a FRMPayload (and thus encrypted)
 	DevEUI     lorawan.EUI64
 	Data       []byte
+	ExpiresAt  *time.Time // the mac command must not be sent after this timestamp (optional)
+	NotBefore  *time.Time // the mac command must not be sent before this timestamp (optional)
+}
+
+// IsExpired returns true when the item has an expiration timestamp which
+// lies before the given time.
+func (q QueueItem) IsExpired(t time.Time) bool {
+	return q.ExpiresAt != nil && q.ExpiresAt.Before(t)
+}
+
+// IsDue returns true when the item has no not-before timestamp or when this
+// timestamp does not lie after the given time.
+func (q QueueItem) IsDue(t time.Time) bool {
+	return q.NotBefore == nil || !q.NotBefore.After(t)
 }
 
 // PendingItem contains a pending MAC command. In some cases we need to wait
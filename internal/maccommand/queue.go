@@ -109,6 +109,18 @@ func FilterItems(payloads []QueueItem, frmPayload bool, maxBytes int) []QueueIte
 	return out
 }
 
+// FilterDueItems returns the items of the given slice which are due at the
+// given time (see QueueItem.IsDue).
+func FilterDueItems(payloads []QueueItem, t time.Time) []QueueItem {
+	var out []QueueItem
+	for _, pl := range payloads {
+		if pl.IsDue(t) {
+			out = append(out, pl)
+		}
+	}
+	return out
+}
+
 // DeleteQueueItem deletes the given mac-command from the tx queue
 // of the given device address.
 func DeleteQueueItem(p *redis.Pool, devEUI lorawan.EUI64, pl QueueItem) error {
@@ -138,6 +150,103 @@ func DeleteQueueItem(p *redis.Pool, devEUI lorawan.EUI64, pl QueueItem) error {
 	return nil
 }
 
+// popQueueItemsScript removes the given items (ARGV) from the queue. It
+// returns the (0 based) indices of the items which were removed, items
+// which are no longer in the queue (e.g. popped by an other downlink path)
+// are skipped.
+var popQueueItemsScript = redis.NewScript(1, `
+	local popped = {}
+	for i, item in ipairs(ARGV) do
+		if redis.call("LREM", KEYS[1], 1, item) > 0 then
+			table.insert(popped, i - 1)
+		end
+	end
+	return popped
+`)
+
+// PopQueueItems atomically removes the given items from the tx queue of the
+// given node and returns the items which were removed by this call. As only
+// one caller is able to pop an item, this prevents that simultaneous
+// downlinks transmit the same mac-commands. The returned items keep the
+// given order.
+func PopQueueItems(p *redis.Pool, devEUI lorawan.EUI64, items []QueueItem) ([]QueueItem, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{fmt.Sprintf(queueTempl, devEUI)}
+	for _, pl := range items {
+		b, err := encodeQueueItem(pl)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, b)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	indices, err := redis.Ints(popQueueItemsScript.Do(c, args...))
+	if err != nil {
+		return nil, fmt.Errorf("pop mac-payloads from tx queue for deveui %s error: %s", devEUI, err)
+	}
+
+	var out []QueueItem
+	for _, i := range indices {
+		out = append(out, items[i])
+	}
+
+	if len(out) != len(items) {
+		log.WithFields(log.Fields{
+			"dev_eui": devEUI,
+			"count":   len(items) - len(out),
+		}).Info("mac-payloads already popped from tx queue")
+	}
+	return out, nil
+}
+
+// RequeueItems puts the given (popped) items back at the front of the tx
+// queue of the given node, keeping their order. This is used when the
+// downlink containing these items could not be sent.
+func RequeueItems(p *redis.Pool, devEUI lorawan.EUI64, items []QueueItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	key := fmt.Sprintf(queueTempl, devEUI)
+	exp := int64(common.NodeSessionTTL) / int64(time.Millisecond)
+
+	// LPUSH inserts the values one after the other at the head of the
+	// list, therefore the values are given in reversed order
+	args := []interface{}{key}
+	for i := len(items) - 1; i >= 0; i-- {
+		b, err := encodeQueueItem(items[i])
+		if err != nil {
+			return err
+		}
+		args = append(args, b)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("LPUSH", args...)
+	c.Send("PEXPIRE", key, exp)
+	if _, err := c.Do("EXEC"); err != nil {
+		return fmt.Errorf("requeue mac-payloads for deveui %s error: %s", devEUI, err)
+	}
+	return nil
+}
+
+func encodeQueueItem(pl QueueItem) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pl); err != nil {
+		return nil, fmt.Errorf("gob encode tx mac-payload for node %s error: %s", pl.DevEUI, err)
+	}
+	return buf.Bytes(), nil
+}
+
 // SetPending sets one or multiple MACCommandPayload to the pending buffer.
 // It overwrites existing payloads for the given CID.
 func SetPending(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID, payloads []lorawan.MACCommandPayload) error {
@@ -198,3 +307,16 @@ func ReadPending(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) ([]lorawa
 
 	return out, nil
 }
+
+// DeletePending deletes the pending MACCommandPayload items for the given
+// CID.
+func DeletePending(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", fmt.Sprintf(pendingTempl, devEUI, cid))
+	if err != nil {
+		return fmt.Errorf("delete pending mac-commands for DevEUI %s and CID %d error: %s", devEUI, cid, err)
+	}
+	return nil
+}
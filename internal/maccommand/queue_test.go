@@ -2,6 +2,7 @@ package maccommand
 
 import (
 	"testing"
+	"time"
 
 	"github.com/joriwind/loraserver/internal/common"
 	"github.com/joriwind/loraserver/internal/session"
@@ -51,6 +52,37 @@ func TestQueue(t *testing.T) {
 						So(payloads, ShouldResemble, []QueueItem{b})
 					})
 				})
+
+				Convey("When popping mac-command a and b twice", func() {
+					popped1, err := PopQueueItems(p, ns.DevEUI, []QueueItem{a, b})
+					So(err, ShouldBeNil)
+					popped2, err := PopQueueItems(p, ns.DevEUI, []QueueItem{a, b})
+					So(err, ShouldBeNil)
+
+					Convey("Then the items are only popped by the first call", func() {
+						So(popped1, ShouldResemble, []QueueItem{a, b})
+						So(popped2, ShouldHaveLength, 0)
+
+						payloads, err := ReadQueue(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(payloads, ShouldHaveLength, 0)
+					})
+
+					Convey("When requeueing the popped items after adding mac-command c", func() {
+						c := QueueItem{
+							DevEUI: ns.DevEUI,
+							Data:   []byte{3},
+						}
+						So(AddToQueue(p, c), ShouldBeNil)
+						So(RequeueItems(p, ns.DevEUI, popped1), ShouldBeNil)
+
+						Convey("Then the popped items are at the front of the queue", func() {
+							payloads, err := ReadQueue(p, ns.DevEUI)
+							So(err, ShouldBeNil)
+							So(payloads, ShouldResemble, []QueueItem{a, b, c})
+						})
+					})
+				})
 			})
 		})
 
@@ -147,3 +179,20 @@ func TestFilterItems(t *testing.T) {
 		})
 	})
 }
+
+func TestFilterDueItems(t *testing.T) {
+	Convey("Given a set of mac-command items", t, func() {
+		now := time.Now()
+		past := now.Add(-time.Minute)
+		future := now.Add(time.Minute)
+
+		a := QueueItem{Data: []byte{1}}
+		b := QueueItem{Data: []byte{2}, NotBefore: &past}
+		c := QueueItem{Data: []byte{3}, NotBefore: &future}
+		d := QueueItem{Data: []byte{4}, NotBefore: &now}
+
+		Convey("Then FilterDueItems returns the items which are due", func() {
+			So(FilterDueItems([]QueueItem{a, b, c, d}, now), ShouldResemble, []QueueItem{a, b, d})
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package maccommand
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// resyncCIDs contains the mac-commands of which the pending requests are
+// discarded on a reset of the node, as the node lost the state these
+// requests were based on.
+var resyncCIDs = []lorawan.CID{
+	lorawan.LinkADRReq,
+	lorawan.NewChannelReq,
+	lorawan.RXParamSetupReq,
+	lorawan.RXTimingSetupReq,
+}
+
+// ResetMACState resets the mac state of the given node-session to the
+// defaults of the band, as a reset of the node wipes its mac configuration,
+// and re-enqueues the RXParamSetupReq and RXTimingSetupReq mac-commands to
+// restore the rx parameters of the node-session (these are applied once
+// the node acknowledges them). The uplink channels and ADR parameters are
+// restored by the channel-configuration reconciliation and the ADR engine
+// on the next uplinks.
+// Note: this must be called before the node-session is saved.
+func ResetMACState(p *redis.Pool, ns *session.NodeSession) error {
+	for _, cid := range resyncCIDs {
+		if err := DeletePending(p, ns.DevEUI, cid); err != nil {
+			return err
+		}
+	}
+
+	rxParams := GetRXParamSetupPayload(*ns)
+	rxTiming := lorawan.RXTimingSetupReqPayload{Delay: ns.RXDelay}
+
+	ns.UplinkChannels = nil
+	ns.TXPower = 0
+	ns.NbTrans = 0
+	ns.RX1DROffset = 0
+	ns.RX2DR = uint8(common.Band.RX2DataRate)
+	ns.RX2Frequency = 0
+	ns.RXDelay = 0
+
+	if def := GetRXParamSetupPayload(*ns); rxParams != def && !IsHandledByController(lorawan.RXParamSetupReq) {
+		if err := enqueueResync(p, ns.DevEUI, lorawan.RXParamSetupReq, &rxParams); err != nil {
+			return err
+		}
+		if err := SetPendingRXParamSetup(p, ns.DevEUI, rxParams, def); err != nil {
+			return err
+		}
+	}
+
+	if rxTiming.Delay != ns.RXDelay && !IsHandledByController(lorawan.RXTimingSetupReq) {
+		if err := enqueueResync(p, ns.DevEUI, lorawan.RXTimingSetupReq, &rxTiming); err != nil {
+			return err
+		}
+		if err := SetPendingRXTimingSetup(p, ns.DevEUI, rxTiming, lorawan.RXTimingSetupReqPayload{Delay: ns.RXDelay}); err != nil {
+			return err
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":       ns.DevEUI,
+		"rx2_dr":        rxParams.DLSettings.RX2DataRate,
+		"rx2_frequency": rxParams.Frequency,
+		"rx1_dr_offset": rxParams.DLSettings.RX1DROffset,
+		"rx_delay":      rxTiming.Delay,
+	}).Info("mac state reset, resync scheduled")
+	return nil
+}
+
+func enqueueResync(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID, pl lorawan.MACCommandPayload) error {
+	mac := lorawan.MACCommand{
+		CID:     cid,
+		Payload: pl,
+	}
+	b, err := mac.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("marshal mac-command error: %s", err)
+	}
+
+	return AddToQueue(p, QueueItem{
+		DevEUI: devEUI,
+		Data:   b,
+	})
+}
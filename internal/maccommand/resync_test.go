@@ -0,0 +1,64 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestResetMACState(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a node-session with custom mac state", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{
+			DevEUI:         lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			RX1DROffset:    2,
+			RX2DR:          3,
+			RXDelay:        5,
+			TXPower:        14,
+			NbTrans:        2,
+			UplinkChannels: session.DefaultUplinkChannels(),
+		}
+		So(SetPending(p, ns.DevEUI, lorawan.LinkADRReq, []lorawan.MACCommandPayload{&lorawan.LinkADRReqPayload{}}), ShouldBeNil)
+
+		Convey("When calling ResetMACState", func() {
+			So(ResetMACState(p, &ns), ShouldBeNil)
+
+			Convey("Then the mac state is reset to the defaults of the band", func() {
+				So(ns.RX1DROffset, ShouldEqual, 0)
+				So(ns.RX2DR, ShouldEqual, uint8(common.Band.RX2DataRate))
+				So(ns.RXDelay, ShouldEqual, 0)
+				So(ns.TXPower, ShouldEqual, 0)
+				So(ns.NbTrans, ShouldEqual, 0)
+				So(ns.UplinkChannels, ShouldBeNil)
+			})
+
+			Convey("Then the pending LinkADRReq has been discarded", func() {
+				pending, err := ReadPending(p, ns.DevEUI, lorawan.LinkADRReq)
+				So(err, ShouldBeNil)
+				So(pending, ShouldHaveLength, 0)
+			})
+
+			Convey("Then the RXParamSetupReq and RXTimingSetupReq are enqueued", func() {
+				items, err := ReadQueue(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 2)
+				So(lorawan.CID(items[0].Data[0]), ShouldEqual, lorawan.RXParamSetupReq)
+				So(lorawan.CID(items[1].Data[0]), ShouldEqual, lorawan.RXTimingSetupReq)
+
+				pending, err := ReadPending(p, ns.DevEUI, lorawan.RXTimingSetupReq)
+				So(err, ShouldBeNil)
+				So(pending, ShouldHaveLength, 2)
+				So(pending[0], ShouldResemble, &lorawan.RXTimingSetupReqPayload{Delay: 5})
+			})
+		})
+	})
+}
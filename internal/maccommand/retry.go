@@ -0,0 +1,121 @@
+package maccommand
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const retryTempl = "lora:ns:mac:retry:%s:%d"
+
+// requiresAnswer contains the (downlink) mac-commands to which the node must
+// respond with an answer.
+var requiresAnswer = map[lorawan.CID]struct{}{
+	lorawan.LinkADRReq:       struct{}{},
+	lorawan.DutyCycleReq:     struct{}{},
+	lorawan.RXParamSetupReq:  struct{}{},
+	lorawan.DevStatusReq:     struct{}{},
+	lorawan.NewChannelReq:    struct{}{},
+	lorawan.RXTimingSetupReq: struct{}{},
+	lorawan.TXParamSetupReq:  struct{}{},
+	lorawan.DLChannelReq:     struct{}{},
+}
+
+// RequiresAnswer returns true when the node must respond to the given
+// mac-command with an answer.
+func RequiresAnswer(cid lorawan.CID) bool {
+	_, ok := requiresAnswer[cid]
+	return ok
+}
+
+// FilterRetryItems applies the retry backoff (see
+// common.MACCommandRetryInterval) to the given items. A mac-command which
+// requires an answer and which has been sent before without being answered
+// is only returned on every Nth downlink opportunity. The opportunities on
+// which it is held back are counted. Items which are not returned stay in
+// the queue.
+func FilterRetryItems(p *redis.Pool, devEUI lorawan.EUI64, items []QueueItem) ([]QueueItem, error) {
+	if common.MACCommandRetryInterval <= 1 {
+		return items, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	var out []QueueItem
+	for _, qi := range items {
+		if len(qi.Data) == 0 || !RequiresAnswer(lorawan.CID(qi.Data[0])) {
+			out = append(out, qi)
+			continue
+		}
+
+		key := fmt.Sprintf(retryTempl, devEUI, qi.Data[0])
+		skipped, err := redis.Int(c.Do("GET", key))
+		if err != nil {
+			// the mac-command has not been sent or has been answered
+			if err == redis.ErrNil {
+				out = append(out, qi)
+				continue
+			}
+			return nil, fmt.Errorf("get mac-command retry state error: %s", err)
+		}
+
+		if (skipped+1)%common.MACCommandRetryInterval == 0 {
+			out = append(out, qi)
+			continue
+		}
+
+		if _, err := c.Do("INCR", key); err != nil {
+			return nil, fmt.Errorf("increment mac-command retry state error: %s", err)
+		}
+	}
+
+	return out, nil
+}
+
+// SetRetrySent marks the given items which require an answer as sent,
+// so that a retransmission (without an answer in between) is subject to
+// the retry backoff.
+func SetRetrySent(p *redis.Pool, devEUI lorawan.EUI64, items []QueueItem) error {
+	if common.MACCommandRetryInterval <= 1 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	exp := int64(common.MACPendingTTL) / int64(time.Millisecond)
+
+	c.Send("MULTI")
+	for _, qi := range items {
+		if len(qi.Data) == 0 || !RequiresAnswer(lorawan.CID(qi.Data[0])) {
+			continue
+		}
+		c.Send("PSETEX", fmt.Sprintf(retryTempl, devEUI, qi.Data[0]), exp, 0)
+	}
+	if _, err := c.Do("EXEC"); err != nil {
+		return fmt.Errorf("set mac-command retry state error: %s", err)
+	}
+	return nil
+}
+
+// ResetRetry resets the retry state of the given CID, e.g. after the
+// node has answered the mac-command.
+func ResetRetry(p *redis.Pool, devEUI lorawan.EUI64, cid lorawan.CID) error {
+	if common.MACCommandRetryInterval <= 1 {
+		return nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", fmt.Sprintf(retryTempl, devEUI, cid))
+	if err != nil {
+		return fmt.Errorf("delete mac-command retry state for DevEUI %s and CID %d error: %s", devEUI, cid, err)
+	}
+	return nil
+}
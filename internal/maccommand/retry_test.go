@@ -0,0 +1,69 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestRetry(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a retry interval of 3", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.MACCommandRetryInterval = 3
+		defer func() {
+			common.MACCommandRetryInterval = 1
+		}()
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		items := []QueueItem{
+			{DevEUI: devEUI, Data: []byte{byte(lorawan.DevStatusReq)}},
+			{DevEUI: devEUI, Data: []byte{0x80, 1, 2}}, // proprietary
+		}
+
+		Convey("When the items have not been sent before", func() {
+			Convey("Then all items are returned", func() {
+				out, err := FilterRetryItems(p, devEUI, items)
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, items)
+			})
+		})
+
+		Convey("When the items have been sent", func() {
+			So(SetRetrySent(p, devEUI, items), ShouldBeNil)
+
+			Convey("Then the mac-command requiring an answer is only returned on every 3rd downlink opportunity", func() {
+				var sent []bool
+				for i := 0; i < 6; i++ {
+					out, err := FilterRetryItems(p, devEUI, items)
+					So(err, ShouldBeNil)
+					if len(out) == 2 {
+						So(SetRetrySent(p, devEUI, out), ShouldBeNil)
+						sent = append(sent, true)
+					} else {
+						So(out, ShouldResemble, items[1:])
+						sent = append(sent, false)
+					}
+				}
+				So(sent, ShouldResemble, []bool{false, false, true, false, false, true})
+			})
+
+			Convey("When the node answered the mac-command", func() {
+				So(ResetRetry(p, devEUI, lorawan.DevStatusAns), ShouldBeNil)
+
+				Convey("Then all items are returned", func() {
+					out, err := FilterRetryItems(p, devEUI, items)
+					So(err, ShouldBeNil)
+					So(out, ShouldResemble, items)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package maccommand
+
+import (
+	"errors"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// ErrHandledByNetworkServer is returned when the network-controller tries
+// to enqueue a mac-command which is handled by LoRa Server itself.
+var ErrHandledByNetworkServer = errors.New("mac-command is handled by the network-server")
+
+// cidNames maps the mac-command names (as used in the configuration) to
+// their CID.
+var cidNames = map[string]lorawan.CID{
+	"LINKCHECK":     lorawan.LinkCheckReq,
+	"LINKADR":       lorawan.LinkADRReq,
+	"DUTYCYCLE":     lorawan.DutyCycleReq,
+	"RXPARAMSETUP":  lorawan.RXParamSetupReq,
+	"DEVSTATUS":     lorawan.DevStatusReq,
+	"NEWCHANNEL":    lorawan.NewChannelReq,
+	"RXTIMINGSETUP": lorawan.RXTimingSetupReq,
+}
+
+// nativeCIDs contains the CIDs of the mac-commands which are handled
+// (and enqueued) by LoRa Server itself, unless delegated to the
+// network-controller.
+var nativeCIDs = map[lorawan.CID]struct{}{
+	lorawan.LinkADRReq: struct{}{},
+}
+
+// controllerCIDs contains the CIDs of the mac-commands which are delegated
+// to the network-controller.
+var controllerCIDs = map[lorawan.CID]struct{}{}
+
+// MustSetControllerMACCommands sets the mac-commands which are delegated to
+// the network-controller. Valid names are: LINKCHECK, LINKADR, DUTYCYCLE,
+// RXPARAMSETUP, DEVSTATUS, NEWCHANNEL, RXTIMINGSETUP.
+func MustSetControllerMACCommands(names []string) {
+	controllerCIDs = make(map[lorawan.CID]struct{})
+
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		cid, ok := cidNames[name]
+		if !ok {
+			log.Fatalf("'%s' is not a valid mac-command", name)
+		}
+		controllerCIDs[cid] = struct{}{}
+	}
+}
+
+// IsHandledByController returns true when the mac-command with the given
+// CID is delegated to the network-controller.
+func IsHandledByController(cid lorawan.CID) bool {
+	_, ok := controllerCIDs[cid]
+	return ok
+}
+
+// IsHandledByNetworkServer returns true when the mac-command with the given
+// CID is handled by LoRa Server itself.
+func IsHandledByNetworkServer(cid lorawan.CID) bool {
+	if IsHandledByController(cid) {
+		return false
+	}
+	_, ok := nativeCIDs[cid]
+	return ok
+}
@@ -0,0 +1,37 @@
+package maccommand
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestControllerMACCommands(t *testing.T) {
+	Convey("Given no mac-commands are delegated to the network-controller", t, func() {
+		MustSetControllerMACCommands([]string{""})
+
+		Convey("Then LinkADRReq is handled by the network-server", func() {
+			So(IsHandledByController(lorawan.LinkADRReq), ShouldBeFalse)
+			So(IsHandledByNetworkServer(lorawan.LinkADRReq), ShouldBeTrue)
+		})
+
+		Convey("Then DevStatusReq is not handled by the network-server", func() {
+			So(IsHandledByController(lorawan.DevStatusReq), ShouldBeFalse)
+			So(IsHandledByNetworkServer(lorawan.DevStatusReq), ShouldBeFalse)
+		})
+
+		Convey("Given LinkADR and DevStatus are delegated to the network-controller", func() {
+			MustSetControllerMACCommands([]string{"linkadr", " DevStatus"})
+			Reset(func() {
+				MustSetControllerMACCommands(nil)
+			})
+
+			Convey("Then both are handled by the network-controller", func() {
+				So(IsHandledByController(lorawan.LinkADRReq), ShouldBeTrue)
+				So(IsHandledByNetworkServer(lorawan.LinkADRReq), ShouldBeFalse)
+				So(IsHandledByController(lorawan.DevStatusReq), ShouldBeTrue)
+			})
+		})
+	})
+}
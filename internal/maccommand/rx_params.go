@@ -0,0 +1,186 @@
+package maccommand
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/rx2mismatch"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// The pending RXParamSetupReq and RXTimingSetupReq mac-commands consist of
+// the requested parameters followed by the parameters used by the node at
+// the time of the request. The node-session is only updated to the
+// requested parameters once the node acknowledged the request and is
+// reverted to the parameters used by the node when it rejected the request.
+
+// GetRXParamSetupPayload returns the RX2 parameters and RX1 data-rate
+// offset of the given node-session as RXParamSetupReq payload.
+func GetRXParamSetupPayload(ns session.NodeSession) lorawan.RX2SetupReqPayload {
+	return lorawan.RX2SetupReqPayload{
+		Frequency: uint32(ns.GetRX2Frequency()),
+		DLSettings: lorawan.DLSettings{
+			RX2DataRate: ns.RX2DR,
+			RX1DROffset: ns.RX1DROffset,
+		},
+	}
+}
+
+// SetPendingRXParamSetup stores the given requested RXParamSetupReq
+// parameters and the parameters currently used by the node as pending.
+func SetPendingRXParamSetup(p *redis.Pool, devEUI lorawan.EUI64, requested, current lorawan.RX2SetupReqPayload) error {
+	return SetPending(p, devEUI, lorawan.RXParamSetupReq, []lorawan.MACCommandPayload{&requested, &current})
+}
+
+// SetPendingRXTimingSetup stores the given requested RXTimingSetupReq
+// delay and the delay currently used by the node as pending.
+func SetPendingRXTimingSetup(p *redis.Pool, devEUI lorawan.EUI64, requested, current lorawan.RXTimingSetupReqPayload) error {
+	return SetPending(p, devEUI, lorawan.RXTimingSetupReq, []lorawan.MACCommandPayload{&requested, &current})
+}
+
+// SetPendingSessionChanges stores the RXParamSetupReq or RXTimingSetupReq
+// mac-command of the given queue item as pending, using the parameters of
+// the given node-session as the parameters currently used by the node.
+// Other mac-commands are ignored.
+func SetPendingSessionChanges(p *redis.Pool, ns session.NodeSession, item QueueItem) error {
+	if len(item.Data) == 0 {
+		return nil
+	}
+
+	cid := lorawan.CID(item.Data[0])
+	if cid != lorawan.RXParamSetupReq && cid != lorawan.RXTimingSetupReq {
+		return nil
+	}
+
+	var mac lorawan.MACCommand
+	if err := mac.UnmarshalBinary(false, item.Data); err != nil {
+		return fmt.Errorf("unmarshal mac-command error: %s", err)
+	}
+
+	switch pl := mac.Payload.(type) {
+	case *lorawan.RX2SetupReqPayload:
+		return SetPendingRXParamSetup(p, ns.DevEUI, *pl, GetRXParamSetupPayload(ns))
+	case *lorawan.RXTimingSetupReqPayload:
+		return SetPendingRXTimingSetup(p, ns.DevEUI, *pl, lorawan.RXTimingSetupReqPayload{Delay: ns.RXDelay})
+	default:
+		return fmt.Errorf("unexpected mac-command payload %T", mac.Payload)
+	}
+}
+
+// handleRXParamSetupAns applies the pending RXParamSetupReq parameters to
+// the node-session and clears the rx2 mismatch flag of the node once it
+// acknowledged the request. When the node rejected the request, the
+// node-session is reverted to the parameters used by the node and the
+// network-controller is notified.
+func handleRXParamSetupAns(ctx common.Context, ns *session.NodeSession, pl lorawan.MACCommandPayload) error {
+	ans, ok := pl.(*lorawan.RX2SetupAnsPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.RX2SetupAnsPayload, got %T", pl)
+	}
+
+	pending, err := ReadPending(ctx.RedisPool, ns.DevEUI, lorawan.RXParamSetupReq)
+	if err != nil {
+		return fmt.Errorf("read pending mac-commands error: %s", err)
+	}
+
+	var requested, current *lorawan.RX2SetupReqPayload
+	if len(pending) == 2 {
+		requested, _ = pending[0].(*lorawan.RX2SetupReqPayload)
+		current, _ = pending[1].(*lorawan.RX2SetupReqPayload)
+	}
+
+	if len(pending) > 0 {
+		if err := DeletePending(ctx.RedisPool, ns.DevEUI, lorawan.RXParamSetupReq); err != nil {
+			return err
+		}
+	}
+
+	if !ans.ChannelACK || !ans.RX2DataRateACK || !ans.RX1DROffsetACK {
+		if current != nil {
+			applyRXParamSetup(ns, *current)
+		}
+
+		log.WithFields(log.Fields{
+			"dev_eui":           ns.DevEUI,
+			"channel_ack":       ans.ChannelACK,
+			"rx2_data_rate_ack": ans.RX2DataRateACK,
+			"rx1_dr_offset_ack": ans.RX1DROffsetACK,
+		}).Warning("rx param setup request not acknowledged")
+
+		return notifyRejected(ctx, *ns, fmt.Sprintf("rx param setup request not acknowledged (channel ack: %t, rx2 data-rate ack: %t, rx1 dr-offset ack: %t)", ans.ChannelACK, ans.RX2DataRateACK, ans.RX1DROffsetACK))
+	}
+
+	if requested != nil {
+		applyRXParamSetup(ns, *requested)
+	}
+
+	if err := rx2mismatch.Clear(ctx.RedisPool, *ns); err != nil {
+		return fmt.Errorf("clear rx2 mismatch error: %s", err)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":       ns.DevEUI,
+		"rx2_dr":        ns.RX2DR,
+		"rx2_frequency": ns.GetRX2Frequency(),
+		"rx1_dr_offset": ns.RX1DROffset,
+	}).Info("rx param setup request acknowledged")
+	return nil
+}
+
+// handleRXTimingSetupAns applies the pending RXTimingSetupReq delay to the
+// node-session.
+func handleRXTimingSetupAns(ctx common.Context, ns *session.NodeSession) error {
+	pending, err := ReadPending(ctx.RedisPool, ns.DevEUI, lorawan.RXTimingSetupReq)
+	if err != nil {
+		return fmt.Errorf("read pending mac-commands error: %s", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	requested, ok := pending[0].(*lorawan.RXTimingSetupReqPayload)
+	if !ok {
+		return fmt.Errorf("expected *lorawan.RXTimingSetupReqPayload, got %T", pending[0])
+	}
+
+	if err := DeletePending(ctx.RedisPool, ns.DevEUI, lorawan.RXTimingSetupReq); err != nil {
+		return err
+	}
+	ns.RXDelay = requested.Delay
+
+	log.WithFields(log.Fields{
+		"dev_eui":  ns.DevEUI,
+		"rx_delay": ns.RXDelay,
+	}).Info("rx timing setup request acknowledged")
+	return nil
+}
+
+func applyRXParamSetup(ns *session.NodeSession, pl lorawan.RX2SetupReqPayload) {
+	ns.RX2DR = pl.DLSettings.RX2DataRate
+	ns.RX1DROffset = pl.DLSettings.RX1DROffset
+	ns.RX2Frequency = int(pl.Frequency)
+	if ns.RX2Frequency == common.Band.RX2Frequency {
+		ns.RX2Frequency = 0
+	}
+}
+
+// notifyRejected notifies the network-controller that the node rejected a
+// mac-command.
+func notifyRejected(ctx common.Context, ns session.NodeSession, errStr string) error {
+	_, err := ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+		AppEUI: ns.AppEUI[:],
+		DevEUI: ns.DevEUI[:],
+		Error:  errStr,
+	})
+	if err != nil {
+		return fmt.Errorf("send error to network-controller error: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// Handler returns a http.Handler exposing the metrics written by the given
+// writers (e.g. WriteMetrics) in the Prometheus text exposition format.
+// The output is buffered, so that a failing writer results in an error
+// response instead of a partial scrape.
+func Handler(writers ...func(io.Writer) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		for _, write := range writers {
+			if err := write(&buf); err != nil {
+				log.Errorf("write metrics error: %s", err)
+				http.Error(w, "write metrics error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write(buf.Bytes())
+	})
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHandler(t *testing.T) {
+	Convey("Given two metrics writers", t, func() {
+		writeA := func(w io.Writer) error {
+			_, err := fmt.Fprintln(w, "a_total 1")
+			return err
+		}
+		writeB := func(w io.Writer) error {
+			_, err := fmt.Fprintln(w, "b_total 2")
+			return err
+		}
+
+		Convey("Then the handler exposes the output of both writers", func() {
+			rec := httptest.NewRecorder()
+			Handler(writeA, writeB).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+			So(rec.Code, ShouldEqual, http.StatusOK)
+			So(rec.Header().Get("Content-Type"), ShouldEqual, "text/plain; version=0.0.4")
+			So(rec.Body.String(), ShouldEqual, "a_total 1\nb_total 2\n")
+		})
+
+		Convey("When a writer fails", func() {
+			writeErr := func(w io.Writer) error {
+				return errors.New("redis error")
+			}
+
+			Convey("Then an error is returned without partial output", func() {
+				rec := httptest.NewRecorder()
+				Handler(writeA, writeErr, writeB).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+				So(rec.Code, ShouldEqual, http.StatusInternalServerError)
+				So(rec.Body.String(), ShouldNotContainSubstring, "a_total")
+			})
+		})
+	})
+}
@@ -0,0 +1,249 @@
+// Package metrics implements the counters and histograms instrumenting
+// LoRa Server (e.g. the uplink, downlink and backend calls), exposed in the
+// Prometheus text exposition format (see WriteMetrics).
+//
+// The metrics are registered on creation, so that they can be declared as
+// package variables by the instrumented packages:
+//
+//	var txAcks = metrics.NewCounter("loraserver_downlink_tx_acks_total", "Number of received TX acknowledgements.", "mac", "result")
+//
+//	txAcks.Inc(ack.MAC.String(), "ok")
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DurationBuckets contains the default buckets (in seconds) of histograms
+// observing durations.
+var DurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// labelSeparator separates the label values of a series key.
+const labelSeparator = "\xff"
+
+// metric is implemented by the registered metrics.
+type metric interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []metric
+)
+
+func register(m metric) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, m)
+}
+
+// desc contains the description of a metric.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+// key returns the series key of the given label values.
+func (d desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, labelSeparator)
+}
+
+// labelPairs returns the formatted label pairs of the given series key,
+// including the given extra pair (when not empty).
+func (d desc) labelPairs(key, extra string) string {
+	var pairs []string
+	if len(d.labels) > 0 {
+		for i, v := range strings.Split(key, labelSeparator) {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", d.labels[i], v))
+		}
+	}
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (d desc) writeHeader(w io.Writer, typ string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, d.help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, typ)
+}
+
+// Counter is a counter metric, partitioned by the given labels.
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounter creates and registers a new counter.
+func NewCounter(name, help string, labels ...string) *Counter {
+	c := Counter{
+		desc:   desc{name: name, help: help, labels: labels},
+		values: make(map[string]float64),
+	}
+	register(&c)
+	return &c
+}
+
+// Inc increments the counter of the given label values by 1.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter of the given label values by n.
+func (c *Counter) Add(n float64, labelValues ...string) {
+	key := c.key(labelValues)
+	c.mu.Lock()
+	c.values[key] += n
+	c.mu.Unlock()
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(w, "counter")
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(key, ""), formatFloat(c.values[key]))
+	}
+}
+
+// histogramValue contains the observations of a single series.
+type histogramValue struct {
+	bucketCounts []uint64 // per bucket, the last bucket is +Inf
+	sum          float64
+}
+
+// Histogram is a histogram metric, partitioned by the given labels.
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogramValue
+}
+
+// NewHistogram creates and registers a new histogram with the given
+// (sorted) bucket upper bounds.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := Histogram{
+		desc:    desc{name: name, help: help, labels: labels},
+		buckets: buckets,
+		values:  make(map[string]*histogramValue),
+	}
+	register(&h)
+	return &h
+}
+
+// Observe adds the given observation for the given label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+	i := sort.SearchFloat64s(h.buckets, v)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hv, ok := h.values[key]
+	if !ok {
+		hv = &histogramValue{bucketCounts: make([]uint64, len(h.buckets)+1)}
+		h.values[key] = hv
+	}
+	hv.bucketCounts[i]++
+	hv.sum += v
+}
+
+// ObserveDuration adds the duration since the given start time in
+// seconds, for the given label values.
+func (h *Histogram) ObserveDuration(start time.Time, labelValues ...string) {
+	h.Observe(time.Since(start).Seconds(), labelValues...)
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w, "histogram")
+	for _, key := range sortedKeys(h.values) {
+		hv := h.values[key]
+
+		var count uint64
+		for i, b := range h.buckets {
+			count += hv.bucketCounts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, fmt.Sprintf("le=%q", formatFloat(b))), count)
+		}
+		count += hv.bucketCounts[len(h.buckets)]
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, `le="+Inf"`), count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(key, ""), formatFloat(hv.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(key, ""), count)
+	}
+}
+
+// GaugeFunc is a gauge metric (without labels) of which the value is
+// returned by the given function when the metrics are written.
+type GaugeFunc struct {
+	desc
+	f func() float64
+}
+
+// NewGaugeFunc creates and registers a new gauge, sampling the given
+// function.
+func NewGaugeFunc(name, help string, f func() float64) *GaugeFunc {
+	g := GaugeFunc{
+		desc: desc{name: name, help: help},
+		f:    f,
+	}
+	register(&g)
+	return &g
+}
+
+func (g *GaugeFunc) write(w io.Writer) {
+	g.writeHeader(w, "gauge")
+	fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.f()))
+}
+
+// WriteMetrics writes the registered metrics in the Prometheus text
+// exposition format.
+func WriteMetrics(w io.Writer) error {
+	registryMu.Lock()
+	metrics := append([]metric(nil), registry...)
+	registryMu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+	return bw.Flush()
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]float64:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*histogramValue:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
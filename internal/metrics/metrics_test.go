@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMetrics(t *testing.T) {
+	Convey("Given a counter and a histogram", t, func() {
+		registry = nil
+		c := NewCounter("test_events_total", "Number of test events.", "mac", "result")
+		h := NewHistogram("test_duration_seconds", "Test duration.", []float64{0.1, 1}, "method")
+
+		Convey("When incrementing the counter and observing values", func() {
+			c.Inc("0102030405060708", "ok")
+			c.Inc("0102030405060708", "ok")
+			c.Add(3, "0807060504030201", "nack")
+
+			h.Observe(0.05, "Get")
+			h.Observe(0.5, "Get")
+			h.Observe(5, "Get")
+
+			Convey("Then WriteMetrics writes these in the Prometheus text format", func() {
+				var buf bytes.Buffer
+				So(WriteMetrics(&buf), ShouldBeNil)
+				So(buf.String(), ShouldEqual, strings.Join([]string{
+					"# HELP test_events_total Number of test events.",
+					"# TYPE test_events_total counter",
+					`test_events_total{mac="0102030405060708",result="ok"} 2`,
+					`test_events_total{mac="0807060504030201",result="nack"} 3`,
+					"# HELP test_duration_seconds Test duration.",
+					"# TYPE test_duration_seconds histogram",
+					`test_duration_seconds_bucket{method="Get",le="0.1"} 1`,
+					`test_duration_seconds_bucket{method="Get",le="1"} 2`,
+					`test_duration_seconds_bucket{method="Get",le="+Inf"} 3`,
+					`test_duration_seconds_sum{method="Get"} 5.55`,
+					`test_duration_seconds_count{method="Get"} 3`,
+					"",
+				}, "\n"))
+			})
+		})
+
+		Convey("When registering a gauge func", func() {
+			v := 2.0
+			NewGaugeFunc("test_connections", "Number of test connections.", func() float64 { return v })
+			v = 3
+
+			Convey("Then its value is sampled when writing the metrics", func() {
+				var buf bytes.Buffer
+				So(WriteMetrics(&buf), ShouldBeNil)
+				So(buf.String(), ShouldContainSubstring, strings.Join([]string{
+					"# HELP test_connections Number of test connections.",
+					"# TYPE test_connections gauge",
+					"test_connections 3",
+					"",
+				}, "\n"))
+			})
+		})
+
+		Convey("When passing the wrong number of label values", func() {
+			Convey("Then it panics", func() {
+				So(func() { c.Inc("0102030405060708") }, ShouldPanic)
+			})
+		})
+	})
+}
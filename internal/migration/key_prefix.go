@@ -0,0 +1,70 @@
+package migration
+
+import (
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// keyPrefixPatterns contains the patterns of the (unprefixed) Redis keys
+// moved by MigrateRedisKeyPrefix.
+var keyPrefixPatterns = []string{
+	"lora:*",
+	"loraserver:*",
+}
+
+// KeyPrefixResult contains the result of a key-prefix migration.
+type KeyPrefixResult struct {
+	Moved   int
+	Skipped int // the prefixed key already exists
+}
+
+// MigrateRedisKeyPrefix moves the existing Redis keys under the given
+// prefix (see common.RedisKeyPrefix). The given pool must not prefix the
+// keys itself. Keys already carrying the prefix are left untouched and
+// prefixed keys which already exist are never overwritten. The TTL of the
+// keys is retained.
+func MigrateRedisKeyPrefix(p *redis.Pool, prefix string) (KeyPrefixResult, error) {
+	var res KeyPrefixResult
+	if prefix == "" {
+		return res, errors.New("key prefix must not be empty")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for _, pattern := range keyPrefixPatterns {
+		keys, err := common.ScanKeys(p, pattern)
+		if err != nil {
+			return res, err
+		}
+
+		for _, key := range keys {
+			if strings.HasPrefix(key, prefix) && strings.HasPrefix(key[len(prefix):], "lora") {
+				continue
+			}
+
+			renamed, err := redis.Bool(c.Do("RENAMENX", key, prefix+key))
+			if err != nil {
+				// the key might have expired in the meantime
+				if _, ok := err.(redis.Error); ok {
+					log.WithField("key", key).Warningf("move key error: %s", err)
+					continue
+				}
+				return res, errors.Wrap(err, "rename key error")
+			}
+			if renamed {
+				res.Moved++
+			} else {
+				log.WithField("key", key).Warning("prefixed key already exists, skipped")
+				res.Skipped++
+			}
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,202 @@
+package migration
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// legacyImportBatchSize defines the number of node-sessions read per batch
+// from the upstream Redis database.
+const legacyImportBatchSize = 1000
+
+// LegacyImportResult contains the result of an import from an upstream
+// (brocaar/loraserver) deployment.
+type LegacyImportResult struct {
+	Imported int
+	Skipped  int // already existing and not overwritten
+	Errors   int
+}
+
+// LegacyChannelConfiguration contains an upstream channel-configuration.
+// This fork does not store channel-configurations, the enabled channels are
+// configured globally (--enabled-uplink-channels) instead.
+type LegacyChannelConfiguration struct {
+	ID            int64           `db:"id"`
+	Name          string          `db:"name"`
+	Band          string          `db:"band"`
+	Channels      pq.Int64Array   `db:"channels"`
+	ExtraChannels pq.Int64Array   `db:"extra_channels"` // frequencies
+	Gateways      []lorawan.EUI64 `db:"-"`
+}
+
+// legacyGateway contains the gateway columns shared by all upstream
+// versions.
+type legacyGateway struct {
+	MAC         lorawan.EUI64     `db:"mac"`
+	Name        string            `db:"name"`
+	Description string            `db:"description"`
+	FirstSeenAt *time.Time        `db:"first_seen_at"`
+	LastSeenAt  *time.Time        `db:"last_seen_at"`
+	Location    *gateway.GPSPoint `db:"location"`
+	Altitude    *float64          `db:"altitude"`
+}
+
+// ImportLegacyNodeSessions imports the node-sessions stored in the Redis
+// database of an upstream deployment (gob encoded under the same key
+// layout) into the given Redis database, re-encoding them in the format of
+// this fork. Existing node-sessions are only replaced when overwrite is set.
+// Note that the upstream node-sessions do not contain the AppSKey.
+func ImportLegacyNodeSessions(src, dst *redis.Pool, overwrite bool) (LegacyImportResult, error) {
+	var res LegacyImportResult
+	var cursor uint64
+
+	for {
+		sessions, next, err := session.ListNodeSessions(src, cursor, legacyImportBatchSize)
+		if err != nil {
+			return res, errors.Wrap(err, "list legacy node-sessions error")
+		}
+
+		for _, ns := range sessions {
+			if !overwrite {
+				exists, err := session.NodeSessionExists(dst, ns.DevEUI)
+				if err != nil {
+					return res, err
+				}
+				if exists {
+					log.WithField("dev_eui", ns.DevEUI).Warning("node-session already exists, skipped")
+					res.Skipped++
+					continue
+				}
+			}
+
+			if err := session.SaveNodeSession(dst, ns); err != nil {
+				log.WithField("dev_eui", ns.DevEUI).Errorf("import node-session error: %s", err)
+				res.Errors++
+				continue
+			}
+			res.Imported++
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return res, nil
+		}
+	}
+}
+
+// ImportLegacyGateways imports the gateways stored in the PostgreSQL
+// database of an upstream deployment into the given database. The
+// settings specific to this fork get their default values. Existing
+// gateways are only updated (name, description, location and altitude)
+// when overwrite is set.
+func ImportLegacyGateways(src, dst *sqlx.DB, overwrite bool) (LegacyImportResult, error) {
+	var res LegacyImportResult
+	var gws []legacyGateway
+
+	err := sqlx.Select(src, &gws, `
+		select
+			mac,
+			name,
+			description,
+			first_seen_at,
+			last_seen_at,
+			location,
+			altitude
+		from gateway
+		order by mac`)
+	if err != nil {
+		return res, errors.Wrap(err, "select legacy gateways error")
+	}
+
+	for _, lgw := range gws {
+		gw, err := gateway.GetGateway(dst, lgw.MAC)
+		switch errors.Cause(err) {
+		case nil:
+			if !overwrite {
+				log.WithField("mac", lgw.MAC).Warning("gateway already exists, skipped")
+				res.Skipped++
+				continue
+			}
+			gw.Name = lgw.Name
+			gw.Description = lgw.Description
+			gw.Location = lgw.Location
+			gw.Altitude = lgw.Altitude
+			err = gateway.UpdateGateway(dst, &gw)
+		case gateway.ErrDoesNotExist:
+			gw = gateway.Gateway{
+				MAC:         lgw.MAC,
+				Name:        lgw.Name,
+				Description: lgw.Description,
+				Location:    lgw.Location,
+				Altitude:    lgw.Altitude,
+				FirstSeenAt: lgw.FirstSeenAt,
+				LastSeenAt:  lgw.LastSeenAt,
+			}
+			err = gateway.CreateGateway(dst, &gw)
+		default:
+			return res, err
+		}
+
+		if err != nil {
+			log.WithField("mac", lgw.MAC).Errorf("import gateway error: %s", err)
+			res.Errors++
+			continue
+		}
+		res.Imported++
+	}
+
+	return res, nil
+}
+
+// GetLegacyChannelConfigurations returns the channel-configurations stored
+// in the PostgreSQL database of an upstream deployment, together with the
+// gateways using them. It returns an empty slice when the upstream version
+// does not support channel-configurations.
+func GetLegacyChannelConfigurations(src *sqlx.DB) ([]LegacyChannelConfiguration, error) {
+	var exists bool
+	err := sqlx.Get(src, &exists, "select to_regclass('channel_configuration') is not null")
+	if err != nil {
+		return nil, errors.Wrap(err, "check legacy channel-configuration table error")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var out []LegacyChannelConfiguration
+	err = sqlx.Select(src, &out, `
+		select
+			cc.id,
+			cc.name,
+			cc.band,
+			cc.channels,
+			array(
+				select ec.frequency
+				from extra_channel ec
+				where ec.channel_configuration_id = cc.id
+				order by ec.frequency
+			) as extra_channels
+		from channel_configuration cc
+		order by cc.id`)
+	if err != nil {
+		return nil, errors.Wrap(err, "select legacy channel-configurations error")
+	}
+
+	for i := range out {
+		err = sqlx.Select(src, &out[i].Gateways, "select mac from gateway where channel_configuration_id = $1 order by mac", out[i].ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "select gateways of legacy channel-configuration %d error", out[i].ID)
+		}
+	}
+
+	return out, nil
+}
@@ -46,3 +46,23 @@ func (s RXInfoSet) Less(i, j int) bool {
 
 	return s[i].LoRaSNR > s[j].LoRaSNR
 }
+
+// MergeAntennas returns the set with a single RXInfo element per gateway.
+// When a gateway reported the frame on multiple antennas, the element with
+// the best metrics (see Less) is kept.
+func (s RXInfoSet) MergeAntennas() RXInfoSet {
+	var out RXInfoSet
+	index := make(map[lorawan.EUI64]int)
+	for _, rxInfo := range s {
+		i, ok := index[rxInfo.MAC]
+		if !ok {
+			index[rxInfo.MAC] = len(out)
+			out = append(out, rxInfo)
+			continue
+		}
+		if (RXInfoSet{rxInfo, out[i]}).Less(0, 1) {
+			out[i] = rxInfo
+		}
+	}
+	return out
+}
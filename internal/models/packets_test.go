@@ -4,6 +4,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/brocaar/lorawan"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -42,3 +43,23 @@ func TestRXInfoSet(t *testing.T) {
 		})
 	})
 }
+
+func TestRXInfoSetMergeAntennas(t *testing.T) {
+	Convey("Given a RXInfoSet with a gateway reporting the frame on two antennas", t, func() {
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		rxInfoSet := RXInfoSet{
+			{MAC: gw1, Antenna: 0, LoRaSNR: 2, RSSI: -80},
+			{MAC: gw2, Antenna: 0, LoRaSNR: 1, RSSI: -90},
+			{MAC: gw1, Antenna: 1, LoRaSNR: 4, RSSI: -70},
+		}
+
+		Convey("Then merging keeps a single element per gateway with the best metrics", func() {
+			So(rxInfoSet.MergeAntennas(), ShouldResemble, RXInfoSet{
+				{MAC: gw1, Antenna: 1, LoRaSNR: 4, RSSI: -70},
+				{MAC: gw2, Antenna: 0, LoRaSNR: 1, RSSI: -90},
+			})
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidTags is returned when the tags are invalid.
+var ErrInvalidTags = errors.New("invalid tags")
+
+const (
+	maxTags           = 32  // max. number of tags
+	maxTagValueLength = 255 // max. length of a tag value
+)
+
+// tagKeyRegexp defines the valid tag keys.
+var tagKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,64}$`)
+
+// Tags contains operator-defined key / value tags (e.g. customer, site or
+// hardware batch), used for filtering the list API methods.
+type Tags map[string]string
+
+// Validate validates the tags.
+func (t Tags) Validate() error {
+	if len(t) > maxTags {
+		return ErrInvalidTags
+	}
+	for k, v := range t {
+		if !tagKeyRegexp.MatchString(k) || len(v) > maxTagValueLength {
+			return ErrInvalidTags
+		}
+	}
+	return nil
+}
+
+// Matches returns true when the tags contain all the given tags (an empty
+// filter matches all tags).
+func (t Tags) Matches(filter Tags) bool {
+	for k, v := range filter {
+		if tv, ok := t[k]; !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements the driver.Valuer interface (stored as jsonb).
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// An empty object results in nil tags.
+func (t *Tags) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("expected []byte, got %T", src)
+	}
+
+	var tags Tags
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+	*t = tags
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTags(t *testing.T) {
+	Convey("Given a testtable for validation", t, func() {
+		testTable := []struct {
+			Tags          Tags
+			ExpectedError error
+		}{
+			{nil, nil},
+			{Tags{"customer": "acme", "site:floor": "2", "batch_2017-08": ""}, nil},
+			{Tags{"": "acme"}, ErrInvalidTags},
+			{Tags{"cus tomer": "acme"}, ErrInvalidTags},
+			{Tags{"customer": strings.Repeat("a", 256)}, ErrInvalidTags},
+		}
+
+		for _, test := range testTable {
+			So(test.Tags.Validate(), ShouldEqual, test.ExpectedError)
+		}
+	})
+
+	Convey("Given a set of tags", t, func() {
+		tags := Tags{"customer": "acme", "site": "amsterdam"}
+
+		Convey("Then it matches the filters which are a subset", func() {
+			So(tags.Matches(nil), ShouldBeTrue)
+			So(tags.Matches(Tags{"customer": "acme"}), ShouldBeTrue)
+			So(tags.Matches(Tags{"customer": "acme", "site": "amsterdam"}), ShouldBeTrue)
+			So(tags.Matches(Tags{"customer": "other"}), ShouldBeFalse)
+			So(tags.Matches(Tags{"batch": "1"}), ShouldBeFalse)
+		})
+
+		Convey("Then it can be stored and scanned", func() {
+			v, err := tags.Value()
+			So(err, ShouldBeNil)
+
+			var out Tags
+			So(out.Scan(v), ShouldBeNil)
+			So(out, ShouldResemble, tags)
+		})
+
+		Convey("Then empty tags are scanned as nil", func() {
+			out := Tags{"customer": "acme"}
+			So(out.Scan([]byte("{}")), ShouldBeNil)
+			So(out, ShouldBeNil)
+		})
+	})
+}
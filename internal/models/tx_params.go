@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+)
+
+// ErrInvalidTXParams is returned when the TX parameters are invalid.
+var ErrInvalidTXParams = errors.New("invalid tx parameters")
+
+// validCodeRates contains the valid LoRa ECC code rates.
+var validCodeRates = map[string]struct{}{
+	"4/5": {},
+	"4/6": {},
+	"4/7": {},
+	"4/8": {},
+}
+
+// TXParams contains the downlink TX parameters. These can be set on
+// multiple levels (band, gateway, node-session and per push), each level
+// overriding the set parameters of the previous level.
+type TXParams struct {
+	Power    int    // TX power (dBm), 0 = not set
+	CodeRate string // ECC code rate, "" = not set
+	IPol     *bool  // polarity inversion, nil = not set
+}
+
+// Validate validates the TX parameters.
+func (p TXParams) Validate() error {
+	if p.Power < 0 {
+		return ErrInvalidTXParams
+	}
+	if _, ok := validCodeRates[p.CodeRate]; p.CodeRate != "" && !ok {
+		return ErrInvalidTXParams
+	}
+	return nil
+}
+
+// Override returns a copy of the TX parameters, overridden by the set
+// parameters of o.
+func (p TXParams) Override(o TXParams) TXParams {
+	if o.Power != 0 {
+		p.Power = o.Power
+	}
+	if o.CodeRate != "" {
+		p.CodeRate = o.CodeRate
+	}
+	if o.IPol != nil {
+		p.IPol = o.IPol
+	}
+	return p
+}
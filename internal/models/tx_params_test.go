@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTXParams(t *testing.T) {
+	Convey("Given a testtable for validation", t, func() {
+		testTable := []struct {
+			TXParams      TXParams
+			ExpectedError error
+		}{
+			{TXParams{}, nil},
+			{TXParams{Power: 14, CodeRate: "4/5"}, nil},
+			{TXParams{CodeRate: "4/8"}, nil},
+			{TXParams{Power: -1}, ErrInvalidTXParams},
+			{TXParams{CodeRate: "4/9"}, ErrInvalidTXParams},
+		}
+
+		for _, test := range testTable {
+			So(test.TXParams.Validate(), ShouldEqual, test.ExpectedError)
+		}
+	})
+
+	Convey("Given the TX parameters of a band", t, func() {
+		iPol := false
+		band := TXParams{Power: 14, CodeRate: "4/5"}
+
+		Convey("Then only the set parameters are overridden", func() {
+			So(band.Override(TXParams{}), ShouldResemble, band)
+			So(band.Override(TXParams{Power: 20}), ShouldResemble, TXParams{Power: 20, CodeRate: "4/5"})
+			So(band.Override(TXParams{CodeRate: "4/6", IPol: &iPol}), ShouldResemble, TXParams{Power: 14, CodeRate: "4/6", IPol: &iPol})
+		})
+
+		Convey("Then the last level takes precedence", func() {
+			p := band.
+				Override(TXParams{Power: 16, CodeRate: "4/6"}).
+				Override(TXParams{Power: 20})
+			So(p, ShouldResemble, TXParams{Power: 20, CodeRate: "4/6"})
+		})
+	})
+}
@@ -0,0 +1,15 @@
+package multicast
+
+import "errors"
+
+// multicast errors
+var (
+	ErrDoesNotExist           = errors.New("multicast group does not exist")
+	ErrInvalidName            = errors.New("multicast group name must not be empty")
+	ErrInvalidDataRate        = errors.New("invalid multicast group data-rate")
+	ErrInvalidFrequency       = errors.New("invalid multicast group frequency")
+	ErrNoGateways             = errors.New("multicast group must have at least one gateway")
+	ErrFPortMustNotBeZero     = errors.New("multicast payload fport must not be 0")
+	ErrMaxPayloadSizeExceeded = errors.New("multicast payload exceeds max payload size")
+	ErrInvalidFCnt            = errors.New("multicast payload fcnt must be >= the fcnt of the multicast group")
+)
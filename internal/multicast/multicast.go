@@ -0,0 +1,315 @@
+// Package multicast implements the multicast groups. A multicast group
+// shares a DevAddr and session keys between multiple nodes (e.g. for
+// firmware-updates over the air), so that a single downlink, transmitted
+// via the gateways of the group, is received by all the nodes of the group.
+package multicast
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// Group defines a multicast group.
+type Group struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+
+	// MCAddr and MCNwkSKey are the (shared) DevAddr and NwkSKey of the
+	// nodes of the group.
+	MCAddr    lorawan.DevAddr   `db:"mc_addr"`
+	MCNwkSKey lorawan.AES128Key `db:"mc_nwk_s_key"`
+
+	// MCAppSKey contains the (shared) AppSKey when the encryption of the
+	// payloads is offloaded to LoRa Server (nil when the payloads are
+	// encrypted by the application-server).
+	MCAppSKey *lorawan.AES128Key `db:"mc_app_s_key"`
+
+	// Frequency (Hz) and DR on which the payloads are transmitted.
+	Frequency int `db:"frequency"`
+	DR        int `db:"dr"`
+
+	// FCnt contains the frame-counter of the next payload.
+	FCnt uint32 `db:"f_cnt"`
+
+	// Gateways contains the gateways via which the payloads are
+	// transmitted.
+	Gateways []lorawan.EUI64 `db:"-"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate validates the multicast group.
+func (g Group) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return ErrInvalidName
+	}
+	if g.DR < 0 || g.DR > len(common.Band.DataRates)-1 {
+		return ErrInvalidDataRate
+	}
+	if g.Frequency <= 0 || session.ValidateRX2Frequency(g.Frequency) != nil {
+		return ErrInvalidFrequency
+	}
+	if len(g.Gateways) == 0 {
+		return ErrNoGateways
+	}
+	return nil
+}
+
+// mcAppSKeyBytes returns the MCAppSKey as byte slice (nil when not set).
+func (g Group) mcAppSKeyBytes() []byte {
+	if g.MCAppSKey == nil {
+		return nil
+	}
+	return g.MCAppSKey[:]
+}
+
+// CreateGroup validates and creates the given multicast group. On success,
+// the ID and timestamps of the group are set.
+func CreateGroup(db *sqlx.DB, g *Group) error {
+	if err := g.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction error")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	err = tx.Get(&g.ID, `
+		insert into multicast_group (
+			name,
+			mc_addr,
+			mc_nwk_s_key,
+			mc_app_s_key,
+			frequency,
+			dr,
+			f_cnt,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		returning id`,
+		g.Name,
+		g.MCAddr[:],
+		g.MCNwkSKey[:],
+		g.mcAppSKeyBytes(),
+		g.Frequency,
+		g.DR,
+		g.FCnt,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+
+	if err := setGroupGateways(tx, g.ID, g.Gateways); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+	g.CreatedAt = now
+	g.UpdatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":      g.ID,
+		"name":    g.Name,
+		"mc_addr": g.MCAddr,
+	}).Info("multicast group created")
+	return nil
+}
+
+// GetGroup returns the multicast group for the given ID.
+func GetGroup(db *sqlx.DB, id int64) (Group, error) {
+	var g Group
+	err := db.Get(&g, "select * from multicast_group where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return g, ErrDoesNotExist
+		}
+		return g, errors.Wrap(err, "select error")
+	}
+
+	g.Gateways, err = getGroupGateways(db, id)
+	if err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+// UpdateGroup validates and updates the given multicast group. The FCnt of
+// the group can only be increased (a lower FCnt is ignored), as the nodes
+// of the group reject frames with an already used frame-counter.
+func UpdateGroup(db *sqlx.DB, g *Group) error {
+	if err := g.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction error")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	res, err := tx.Exec(`
+		update multicast_group set
+			name = $2,
+			mc_addr = $3,
+			mc_nwk_s_key = $4,
+			mc_app_s_key = $5,
+			frequency = $6,
+			dr = $7,
+			f_cnt = greatest(f_cnt, $8),
+			updated_at = $9
+		where id = $1`,
+		g.ID,
+		g.Name,
+		g.MCAddr[:],
+		g.MCNwkSKey[:],
+		g.mcAppSKeyBytes(),
+		g.Frequency,
+		g.DR,
+		g.FCnt,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	if err := setGroupGateways(tx, g.ID, g.Gateways); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit transaction error")
+	}
+	g.UpdatedAt = now
+
+	log.WithField("id", g.ID).Info("multicast group updated")
+	return nil
+}
+
+// DeleteGroup deletes the multicast group for the given ID. Note that the
+// queue of the group must be flushed separately (see FlushQueue).
+func DeleteGroup(db *sqlx.DB, id int64) error {
+	res, err := db.Exec("delete from multicast_group where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("multicast group deleted")
+	return nil
+}
+
+// GetGroupCount returns the number of multicast groups.
+func GetGroupCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from multicast_group")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetGroups returns a slice of multicast groups, ordered by ID and
+// respecting the given limit and offset.
+func GetGroups(db *sqlx.DB, limit, offset int) ([]Group, error) {
+	var groups []Group
+	err := db.Select(&groups, "select * from multicast_group order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	for i := range groups {
+		groups[i].Gateways, err = getGroupGateways(db, groups[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// claimFCnt returns the frame-counter for the next payload of the given
+// multicast group and increments the frame-counter of the group. When a
+// frame-counter is given (the payload has been encrypted by the
+// application-server), it is used instead when it is not lower than the
+// frame-counter of the group (else ErrInvalidFCnt is returned).
+func claimFCnt(db *sqlx.DB, id int64, fCnt *uint32) (uint32, error) {
+	var err error
+	var claimed int64
+	if fCnt == nil {
+		err = db.Get(&claimed, "update multicast_group set f_cnt = f_cnt + 1 where id = $1 returning f_cnt - 1", id)
+	} else {
+		err = db.Get(&claimed, "update multicast_group set f_cnt = $2 + 1 where id = $1 and f_cnt <= $2 returning f_cnt - 1", id, int64(*fCnt))
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if fCnt == nil {
+				return 0, ErrDoesNotExist
+			}
+			if _, err := GetGroup(db, id); err != nil {
+				return 0, err
+			}
+			return 0, ErrInvalidFCnt
+		}
+		return 0, errors.Wrap(err, "update fcnt error")
+	}
+	return uint32(claimed), nil
+}
+
+// getGroupGateways returns the gateways of the given multicast group.
+func getGroupGateways(db sqlx.Queryer, id int64) ([]lorawan.EUI64, error) {
+	var macs []lorawan.EUI64
+	err := sqlx.Select(db, &macs, "select mac from multicast_group_gateway where multicast_group_id = $1 order by mac", id)
+	if err != nil {
+		return nil, errors.Wrap(err, "select gateways error")
+	}
+	return macs, nil
+}
+
+// setGroupGateways replaces the gateways of the given multicast group.
+// gateway.ErrDoesNotExist is returned for gateways unknown to LoRa Server.
+func setGroupGateways(tx *sqlx.Tx, id int64, macs []lorawan.EUI64) error {
+	if _, err := tx.Exec("delete from multicast_group_gateway where multicast_group_id = $1", id); err != nil {
+		return errors.Wrap(err, "delete gateways error")
+	}
+	for _, mac := range macs {
+		_, err := tx.Exec("insert into multicast_group_gateway (multicast_group_id, mac) values ($1, $2) on conflict do nothing", id, mac[:])
+		if err != nil {
+			if err, ok := err.(*pq.Error); ok && err.Code.Name() == "foreign_key_violation" {
+				return errors.Wrapf(gateway.ErrDoesNotExist, "mac: %s", mac)
+			}
+			return errors.Wrap(err, "insert gateway error")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package multicast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestGroupValidate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		valid := Group{
+			Name:      "firmware",
+			Frequency: common.Band.RX2Frequency,
+			DR:        common.Band.RX2DataRate,
+			Gateways:  []lorawan.EUI64{{1, 1, 1, 1, 1, 1, 1, 1}},
+		}
+
+		tests := []struct {
+			Name     string
+			Group    func(g Group) Group
+			Expected error
+		}{
+			{
+				Name:  "valid group",
+				Group: func(g Group) Group { return g },
+			},
+			{
+				Name:     "empty name",
+				Group:    func(g Group) Group { g.Name = " "; return g },
+				Expected: ErrInvalidName,
+			},
+			{
+				Name:     "invalid data-rate",
+				Group:    func(g Group) Group { g.DR = len(common.Band.DataRates); return g },
+				Expected: ErrInvalidDataRate,
+			},
+			{
+				Name:     "invalid frequency",
+				Group:    func(g Group) Group { g.Frequency = 0; return g },
+				Expected: ErrInvalidFrequency,
+			},
+			{
+				Name:     "no gateways",
+				Group:    func(g Group) Group { g.Gateways = nil; return g },
+				Expected: ErrNoGateways,
+			},
+		}
+
+		for i, tst := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", tst.Name, i), func() {
+				So(tst.Group(valid).Validate(), ShouldEqual, tst.Expected)
+			})
+		}
+	})
+}
+
+func TestGroupAndQueue(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(db)
+		test.MustFlushRedis(p)
+
+		gw1 := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Name: "gw1",
+		}
+		So(gateway.CreateGateway(db, &gw1), ShouldBeNil)
+
+		Convey("When creating a multicast group with an unknown gateway", func() {
+			g := Group{
+				Name:      "firmware",
+				Frequency: common.Band.RX2Frequency,
+				DR:        common.Band.RX2DataRate,
+				Gateways:  []lorawan.EUI64{{2, 2, 2, 2, 2, 2, 2, 2}},
+			}
+
+			Convey("Then gateway.ErrDoesNotExist is returned", func() {
+				So(errors.Cause(CreateGroup(db, &g)), ShouldEqual, gateway.ErrDoesNotExist)
+				count, err := GetGroupCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When creating a multicast group", func() {
+			appSKey := lorawan.AES128Key{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}
+			g := Group{
+				Name:      "firmware",
+				MCAddr:    lorawan.DevAddr{1, 2, 3, 4},
+				MCNwkSKey: lorawan.AES128Key{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+				MCAppSKey: &appSKey,
+				Frequency: common.Band.RX2Frequency,
+				DR:        common.Band.RX2DataRate,
+				FCnt:      10,
+				Gateways:  []lorawan.EUI64{gw1.MAC},
+			}
+			So(CreateGroup(db, &g), ShouldBeNil)
+
+			Convey("Then it can be retrieved", func() {
+				g2, err := GetGroup(db, g.ID)
+				So(err, ShouldBeNil)
+				So(g2.Name, ShouldEqual, g.Name)
+				So(g2.MCAddr, ShouldEqual, g.MCAddr)
+				So(g2.MCNwkSKey, ShouldEqual, g.MCNwkSKey)
+				So(*g2.MCAppSKey, ShouldEqual, appSKey)
+				So(g2.FCnt, ShouldEqual, 10)
+				So(g2.Gateways, ShouldResemble, g.Gateways)
+
+				groups, err := GetGroups(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(groups, ShouldHaveLength, 1)
+			})
+
+			Convey("Then the FCnt can not be decreased on update", func() {
+				g.Name = "firmware-v2"
+				g.FCnt = 5
+				So(UpdateGroup(db, &g), ShouldBeNil)
+
+				g2, err := GetGroup(db, g.ID)
+				So(err, ShouldBeNil)
+				So(g2.Name, ShouldEqual, "firmware-v2")
+				So(g2.FCnt, ShouldEqual, 10)
+			})
+
+			Convey("When enqueueing two payloads", func() {
+				items := []QueueItem{
+					{GroupID: g.ID, FPort: 1, Data: []byte{1, 2, 3}},
+					{GroupID: g.ID, FPort: 2, Data: []byte{4, 5, 6}},
+				}
+				for i := range items {
+					So(EnqueueQueueItem(db, p, &items[i]), ShouldBeNil)
+				}
+
+				Convey("Then the next frame-counters have been assigned", func() {
+					So(items[0].FCnt, ShouldEqual, 10)
+					So(items[1].FCnt, ShouldEqual, 11)
+
+					g2, err := GetGroup(db, g.ID)
+					So(err, ShouldBeNil)
+					So(g2.FCnt, ShouldEqual, 12)
+				})
+
+				Convey("Then the group is pending", func() {
+					ids, err := GetPendingGroupIDs(p)
+					So(err, ShouldBeNil)
+					So(ids, ShouldResemble, []int64{g.ID})
+				})
+
+				Convey("Then the items are popped in order", func() {
+					for _, item := range items {
+						popped, err := PopQueueItem(p, g.ID)
+						So(err, ShouldBeNil)
+						So(popped.FCnt, ShouldEqual, item.FCnt)
+						So(popped.Data, ShouldResemble, item.Data)
+					}
+
+					popped, err := PopQueueItem(p, g.ID)
+					So(err, ShouldBeNil)
+					So(popped, ShouldBeNil)
+
+					ids, err := GetPendingGroupIDs(p)
+					So(err, ShouldBeNil)
+					So(ids, ShouldHaveLength, 0)
+				})
+
+				Convey("Then the queue can be flushed", func() {
+					So(FlushQueue(p, g.ID), ShouldBeNil)
+					popped, err := PopQueueItem(p, g.ID)
+					So(err, ShouldBeNil)
+					So(popped, ShouldBeNil)
+				})
+			})
+
+			Convey("When enqueueing a payload exceeding the max payload size", func() {
+				item := QueueItem{GroupID: g.ID, FPort: 1, Data: make([]byte, common.Band.MaxPayloadSize[g.DR].N+1)}
+
+				Convey("Then ErrMaxPayloadSizeExceeded is returned", func() {
+					So(errors.Cause(EnqueueQueueItem(db, p, &item)), ShouldEqual, ErrMaxPayloadSizeExceeded)
+				})
+			})
+
+			Convey("Given the group has no MCAppSKey", func() {
+				g.MCAppSKey = nil
+				So(UpdateGroup(db, &g), ShouldBeNil)
+
+				Convey("Then a payload with an already used FCnt is rejected", func() {
+					item := QueueItem{GroupID: g.ID, FPort: 1, FCnt: 9}
+					So(EnqueueQueueItem(db, p, &item), ShouldEqual, ErrInvalidFCnt)
+				})
+
+				Convey("Then a payload with a higher FCnt is accepted", func() {
+					item := QueueItem{GroupID: g.ID, FPort: 1, FCnt: 20}
+					So(EnqueueQueueItem(db, p, &item), ShouldBeNil)
+					So(item.FCnt, ShouldEqual, 20)
+
+					g2, err := GetGroup(db, g.ID)
+					So(err, ShouldBeNil)
+					So(g2.FCnt, ShouldEqual, 21)
+				})
+			})
+
+			Convey("Then it can be deleted", func() {
+				So(DeleteGroup(db, g.ID), ShouldBeNil)
+				_, err := GetGroup(db, g.ID)
+				So(err, ShouldEqual, ErrDoesNotExist)
+				So(DeleteGroup(db, g.ID), ShouldEqual, ErrDoesNotExist)
+			})
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package multicast
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const (
+	// queueKeyTempl contains per multicast group the payloads to transmit
+	// (list of gob encoded QueueItem).
+	queueKeyTempl = "lora:ns:multicast:queue:%d"
+
+	// pendingGroupsKey contains the IDs of the multicast groups with a
+	// non-empty queue (set).
+	pendingGroupsKey = "lora:ns:multicast:pending"
+)
+
+// popQueueItemScript pops the first item of the queue and removes the
+// multicast group from the pending groups when the queue is empty, in a
+// single step so that an item enqueued concurrently is never left behind.
+var popQueueItemScript = redis.NewScript(2, `
+	local item = redis.call("LPOP", KEYS[1])
+	if redis.call("LLEN", KEYS[1]) == 0 then
+		redis.call("SREM", KEYS[2], ARGV[1])
+	end
+	return item
+`)
+
+// QueueItem contains a payload of the queue of a multicast group.
+type QueueItem struct {
+	GroupID    int64
+	FCnt       uint32
+	FPort      uint8
+	Data       []byte
+	EnqueuedAt time.Time
+}
+
+// EnqueueQueueItem validates the given item and adds it to the queue of its
+// multicast group. The payload must fit within the max. payload size of the
+// data-rate of the group. When the encryption is offloaded to LoRa Server
+// (the group has a MCAppSKey), the next frame-counter of the group is
+// assigned to the item, else the frame-counter of the item (used by the
+// application-server for encrypting the payload) must not be lower than
+// the frame-counter of the group. On success, the FCnt of the item is set.
+func EnqueueQueueItem(db *sqlx.DB, p *redis.Pool, item *QueueItem) error {
+	if item.FPort == 0 {
+		return ErrFPortMustNotBeZero
+	}
+
+	g, err := GetGroup(db, item.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if maxSize := common.Band.MaxPayloadSize[g.DR].N; len(item.Data) > maxSize {
+		return errors.Wrapf(ErrMaxPayloadSizeExceeded, "(max: %d)", maxSize)
+	}
+
+	var fCnt *uint32
+	if g.MCAppSKey == nil {
+		fCnt = &item.FCnt
+	}
+	if item.FCnt, err = claimFCnt(db, item.GroupID, fCnt); err != nil {
+		return err
+	}
+	item.EnqueuedAt = time.Now()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+		return errors.Wrap(err, "gob encode queue item error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("RPUSH", fmt.Sprintf(queueKeyTempl, item.GroupID), buf.Bytes())
+	c.Send("SADD", pendingGroupsKey, item.GroupID)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "enqueue multicast queue item error")
+	}
+
+	log.WithFields(log.Fields{
+		"multicast_group_id": item.GroupID,
+		"f_port":             item.FPort,
+		"fcnt":               item.FCnt,
+	}).Info("payload added to multicast queue")
+	return nil
+}
+
+// PopQueueItem removes and returns the first item of the queue of the given
+// multicast group (nil when the queue is empty).
+func PopQueueItem(p *redis.Pool, id int64) (*QueueItem, error) {
+	c := p.Get()
+	defer c.Close()
+
+	b, err := redis.Bytes(popQueueItemScript.Do(c, fmt.Sprintf(queueKeyTempl, id), pendingGroupsKey, id))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "pop multicast queue item error")
+	}
+
+	var item QueueItem
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&item); err != nil {
+		return nil, errors.Wrap(err, "gob decode queue item error")
+	}
+	return &item, nil
+}
+
+// GetPendingGroupIDs returns the IDs of the multicast groups which have
+// items in their queue.
+func GetPendingGroupIDs(p *redis.Pool) ([]int64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("SMEMBERS", pendingGroupsKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get pending multicast groups error")
+	}
+
+	var ids []int64
+	for _, v := range values {
+		id, err := redis.Int64(v, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "get pending multicast group id error")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// FlushQueue removes all the items from the queue of the given multicast
+// group.
+func FlushQueue(p *redis.Pool, id int64) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("DEL", fmt.Sprintf(queueKeyTempl, id))
+	c.Send("SREM", pendingGroupsKey, id)
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "flush multicast queue error")
+	}
+	return nil
+}
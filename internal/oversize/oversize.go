@@ -0,0 +1,132 @@
+// Package oversize implements the detection of uplink frames exceeding the
+// max. payload size of the regional parameters for the data-rate on which
+// they were received. As a node can not transmit such frames when
+// respecting the regional parameters, these usually indicate a node or
+// gateway firmware bug. The oversized frames are counted per node and per
+// gateway, so that the chronic offenders can be retrieved.
+package oversize
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/counters"
+)
+
+const (
+	// devicesKey and gatewaysKey contain the number of oversized frames
+	// per node / gateway (sorted set).
+	devicesKey  = "lora:ns:uplink:oversize:devs"
+	gatewaysKey = "lora:ns:uplink:oversize:gws"
+
+	// Retention defines after how long the counters are removed when no
+	// oversized frames were received.
+	Retention = time.Hour * 24 * 7
+)
+
+// Device contains the number of oversized frames of a node.
+type Device struct {
+	DevEUI lorawan.EUI64
+	Count  int
+}
+
+// Gateway contains the number of oversized frames received by a gateway.
+type Gateway struct {
+	MAC   lorawan.EUI64
+	Count int
+}
+
+// Check returns the max. FRMPayload size for the given data-rate and
+// FOpts size and true when the given FRMPayload size does not exceed it.
+func Check(dr, fOptsSize, frmPayloadSize int) (int, bool, error) {
+	if dr < 0 || dr >= len(common.Band.MaxPayloadSize) {
+		return 0, false, fmt.Errorf("invalid data-rate: %d", dr)
+	}
+
+	// N is the max. FRMPayload size when the FOpts are empty
+	max := common.Band.MaxPayloadSize[dr].N - fOptsSize
+	return max, frmPayloadSize <= max, nil
+}
+
+// Record records an oversized frame of the given node, received by the
+// given gateways.
+func Record(p *redis.Pool, devEUI lorawan.EUI64, macs []lorawan.EUI64) error {
+	var b counters.Batch
+	b.ZIncrBy(devicesKey, devEUI.String(), 1, Retention)
+	for _, mac := range macs {
+		b.ZIncrBy(gatewaysKey, mac.String(), 1, Retention)
+	}
+	if err := counters.Add(p, b); err != nil {
+		return errors.Wrap(err, "record oversized frame error")
+	}
+	return nil
+}
+
+// GetDevices returns the given number of nodes with the most oversized
+// frames (most first), having at least the given number of oversized
+// frames.
+func GetDevices(p *redis.Pool, minCount, limit int) ([]Device, error) {
+	var out []Device
+	err := getOffenders(p, devicesKey, minCount, limit, func(id string, count int) error {
+		d := Device{Count: count}
+		if err := d.DevEUI.UnmarshalText([]byte(id)); err != nil {
+			return errors.Wrap(err, "unmarshal DevEUI error")
+		}
+		out = append(out, d)
+		return nil
+	})
+	return out, err
+}
+
+// GetGateways returns the given number of gateways which received the most
+// oversized frames (most first), having at least the given number of
+// oversized frames.
+func GetGateways(p *redis.Pool, minCount, limit int) ([]Gateway, error) {
+	var out []Gateway
+	err := getOffenders(p, gatewaysKey, minCount, limit, func(id string, count int) error {
+		gw := Gateway{Count: count}
+		if err := gw.MAC.UnmarshalText([]byte(id)); err != nil {
+			return errors.Wrap(err, "unmarshal mac error")
+		}
+		out = append(out, gw)
+		return nil
+	})
+	return out, err
+}
+
+// getOffenders calls the given function for the members of the given
+// sorted set, ordered by score (highest first).
+func getOffenders(p *redis.Pool, key string, minCount, limit int, fn func(id string, count int) error) error {
+	if limit <= 0 {
+		return nil
+	}
+	if minCount < 1 {
+		minCount = 1
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	items, err := redis.Strings(c.Do("ZREVRANGEBYSCORE", key, "+inf", minCount, "WITHSCORES", "LIMIT", 0, limit))
+	if err != nil {
+		return errors.Wrap(err, "get oversized frame offenders error")
+	}
+
+	for i := 0; i+1 < len(items); i += 2 {
+		count, err := strconv.Atoi(items[i+1])
+		if err != nil {
+			return errors.Wrap(err, "parse score error")
+		}
+		if err := fn(items[i], count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
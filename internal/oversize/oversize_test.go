@@ -0,0 +1,99 @@
+package oversize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestCheck(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		maxDR0 := common.Band.MaxPayloadSize[0].N
+
+		tests := []struct {
+			Name           string
+			DR             int
+			FOptsSize      int
+			FRMPayloadSize int
+			ExpectedMax    int
+			ExpectedOK     bool
+			ExpectedError  bool
+		}{
+			{"max. size", 0, 0, maxDR0, maxDR0, true, false},
+			{"exceeding max. size", 0, 0, maxDR0 + 1, maxDR0, false, false},
+			{"max. size reduced by FOpts", 0, 5, maxDR0, maxDR0 - 5, false, false},
+			{"invalid data-rate", len(common.Band.MaxPayloadSize), 0, 10, 0, false, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				max, ok, err := Check(test.DR, test.FOptsSize, test.FRMPayloadSize)
+				if test.ExpectedError {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(max, ShouldEqual, test.ExpectedMax)
+				So(ok, ShouldEqual, test.ExpectedOK)
+			})
+		}
+	})
+}
+
+func TestOffenders(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		devEUI2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		mac1 := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		mac2 := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+		Convey("When recording oversized frames", func() {
+			So(Record(p, devEUI1, []lorawan.EUI64{mac1, mac2}), ShouldBeNil)
+			So(Record(p, devEUI1, []lorawan.EUI64{mac1}), ShouldBeNil)
+			So(Record(p, devEUI1, []lorawan.EUI64{mac1}), ShouldBeNil)
+			So(Record(p, devEUI2, []lorawan.EUI64{mac2}), ShouldBeNil)
+
+			Convey("Then the nodes are returned by number of oversized frames", func() {
+				devices, err := GetDevices(p, 0, 10)
+				So(err, ShouldBeNil)
+				So(devices, ShouldResemble, []Device{
+					{DevEUI: devEUI1, Count: 3},
+					{DevEUI: devEUI2, Count: 1},
+				})
+			})
+
+			Convey("Then the gateways are returned by number of oversized frames", func() {
+				gateways, err := GetGateways(p, 0, 10)
+				So(err, ShouldBeNil)
+				So(gateways, ShouldResemble, []Gateway{
+					{MAC: mac1, Count: 3},
+					{MAC: mac2, Count: 2},
+				})
+			})
+
+			Convey("Then the min. count and limit are applied", func() {
+				devices, err := GetDevices(p, 2, 10)
+				So(err, ShouldBeNil)
+				So(devices, ShouldResemble, []Device{
+					{DevEUI: devEUI1, Count: 3},
+				})
+
+				gateways, err := GetGateways(p, 0, 1)
+				So(err, ShouldBeNil)
+				So(gateways, ShouldResemble, []Gateway{
+					{MAC: mac1, Count: 3},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// Package passive implements the passive monitoring of the uplinks of
+// unknown DevAddrs (DevAddrs without node-session). Instead of being fully
+// discarded, these uplinks are summarized per hour, receiving gateway,
+// frequency, data-rate and NwkID, e.g. for spectrum and coverage surveys or
+// for detecting the load of foreign networks on the gateways.
+package passive
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/counters"
+)
+
+const (
+	// statsKeyTempl contains per hour a hash with the uplink counters per
+	// gateway, frequency, data-rate and NwkID (field: mac:frequency:dr:nwkid)
+	statsKeyTempl = "lora:ns:passive:stats:%d"
+
+	// Retention defines how long the passive monitoring stats are stored.
+	Retention = time.Hour * 24 * 31
+)
+
+// Stats contains the number of uplinks of unknown DevAddrs received within
+// an hour by a single gateway, on a single frequency and data-rate and of a
+// single NwkID.
+type Stats struct {
+	Timestamp   time.Time // start of the hour
+	MAC         lorawan.EUI64
+	Frequency   int  // frequency in Hz
+	DataRate    int  // data-rate index of the band
+	NwkID       byte // NwkID (7 MSB) of the DevAddrs
+	UplinkCount int
+}
+
+type byMACFrequencyAndDR []Stats
+
+func (s byMACFrequencyAndDR) Len() int      { return len(s) }
+func (s byMACFrequencyAndDR) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byMACFrequencyAndDR) Less(i, j int) bool {
+	if s[i].MAC != s[j].MAC {
+		return s[i].MAC.String() < s[j].MAC.String()
+	}
+	if s[i].Frequency != s[j].Frequency {
+		return s[i].Frequency < s[j].Frequency
+	}
+	if s[i].DataRate != s[j].DataRate {
+		return s[i].DataRate < s[j].DataRate
+	}
+	return s[i].NwkID < s[j].NwkID
+}
+
+// RecordUplink records an uplink of the given unknown DevAddr, received by
+// the given gateway on the given frequency and data-rate.
+func RecordUplink(p *redis.Pool, mac lorawan.EUI64, frequency, dr int, devAddr lorawan.DevAddr) error {
+	key := fmt.Sprintf(statsKeyTempl, time.Now().Truncate(time.Hour).Unix())
+
+	var b counters.Batch
+	b.HIncrBy(key, fmt.Sprintf("%s:%d:%d:%d", mac, frequency, dr, devAddr.NwkID()), 1, Retention)
+	if err := counters.Add(p, b); err != nil {
+		return errors.Wrap(err, "record uplink error")
+	}
+	return nil
+}
+
+// GetStats returns the passive monitoring stats per hour for the given time
+// range, sorted by time, gateway, frequency, data-rate and NwkID.
+func GetStats(p *redis.Pool, start, end time.Time) ([]Stats, error) {
+	if !end.After(start) {
+		return nil, errors.New("end must be after start")
+	}
+	if min := time.Now().Add(-Retention); start.Before(min) {
+		start = min
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	var out []Stats
+	for t := start.Truncate(time.Hour); t.Before(end); t = t.Add(time.Hour) {
+		values, err := redis.IntMap(c.Do("HGETALL", fmt.Sprintf(statsKeyTempl, t.Unix())))
+		if err != nil {
+			return nil, errors.Wrap(err, "get passive monitoring stats error")
+		}
+
+		var stats []Stats
+		for field, v := range values {
+			s := Stats{
+				Timestamp:   t,
+				UplinkCount: v,
+			}
+			var mac string
+			if _, err := fmt.Sscanf(field, "%16s:%d:%d:%d", &mac, &s.Frequency, &s.DataRate, &s.NwkID); err != nil {
+				return nil, errors.Wrapf(err, "parse field '%s' error", field)
+			}
+			if err := s.MAC.UnmarshalText([]byte(mac)); err != nil {
+				return nil, errors.Wrapf(err, "parse field '%s' error", field)
+			}
+			stats = append(stats, s)
+		}
+		sort.Sort(byMACFrequencyAndDR(stats))
+		out = append(out, stats...)
+	}
+
+	return out, nil
+}
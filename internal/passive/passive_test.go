@@ -0,0 +1,54 @@
+package passive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestPassiveStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		mac1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		mac2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+
+		Convey("When recording uplinks of unknown DevAddrs of two NwkIDs received by two gateways", func() {
+			So(RecordUplink(p, mac1, 868100000, 5, lorawan.DevAddr{0x02, 1, 2, 3}), ShouldBeNil)
+			So(RecordUplink(p, mac1, 868100000, 5, lorawan.DevAddr{0x03, 4, 5, 6}), ShouldBeNil)
+			So(RecordUplink(p, mac1, 868100000, 5, lorawan.DevAddr{0x26, 1, 2, 3}), ShouldBeNil)
+			So(RecordUplink(p, mac2, 868300000, 0, lorawan.DevAddr{0x02, 1, 2, 3}), ShouldBeNil)
+
+			Convey("Then the stats contain the uplink count per gateway, frequency, data-rate and NwkID", func() {
+				end := time.Now()
+				stats, err := GetStats(p, end.Add(-time.Hour), end)
+				So(err, ShouldBeNil)
+
+				hour := time.Now().Truncate(time.Hour)
+				for i := range stats {
+					So(stats[i].Timestamp.Equal(hour), ShouldBeTrue)
+					stats[i].Timestamp = time.Time{}
+				}
+				So(stats, ShouldResemble, []Stats{
+					{MAC: mac1, Frequency: 868100000, DataRate: 5, NwkID: 0x01, UplinkCount: 2},
+					{MAC: mac1, Frequency: 868100000, DataRate: 5, NwkID: 0x13, UplinkCount: 1},
+					{MAC: mac2, Frequency: 868300000, DataRate: 0, NwkID: 0x01, UplinkCount: 1},
+				})
+			})
+
+			Convey("Then requesting an invalid time range returns an error", func() {
+				now := time.Now()
+				_, err := GetStats(p, now, now.Add(-time.Hour))
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
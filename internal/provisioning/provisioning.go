@@ -0,0 +1,147 @@
+// Package provisioning implements the first-join events of the nodes,
+// published to the network-controller and / or a webhook so that
+// provisioning automations (e.g. asset tracking or the start of billing)
+// can be triggered without polling the application-server.
+package provisioning
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// Event contains the first successful join of a node.
+type Event struct {
+	Time      time.Time
+	DevEUI    lorawan.EUI64
+	AppEUI    lorawan.EUI64
+	DevAddr   lorawan.DevAddr
+	RXInfoSet models.RXInfoSet // the gateways which received the join-request
+}
+
+type eventRXInfo struct {
+	MAC     string  `json:"mac"`
+	RSSI    int     `json:"rssi"`
+	LoRaSNR float64 `json:"loRaSNR"`
+}
+
+type eventDataRate struct {
+	Modulation   string `json:"modulation"`
+	SpreadFactor int    `json:"spreadFactor,omitempty"`
+	Bandwidth    int    `json:"bandwidth,omitempty"`
+	BitRate      int    `json:"bitRate,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Event) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Time      time.Time      `json:"time"`
+		DevEUI    string         `json:"devEUI"`
+		AppEUI    string         `json:"appEUI"`
+		DevAddr   string         `json:"devAddr"`
+		Frequency int            `json:"frequency,omitempty"`
+		DataRate  *eventDataRate `json:"dataRate,omitempty"`
+		RXInfo    []eventRXInfo  `json:"rxInfo"`
+	}{
+		Time:    e.Time,
+		DevEUI:  e.DevEUI.String(),
+		AppEUI:  e.AppEUI.String(),
+		DevAddr: e.DevAddr.String(),
+		RXInfo:  []eventRXInfo{},
+	}
+
+	if len(e.RXInfoSet) > 0 {
+		dr := e.RXInfoSet[0].DataRate
+		out.Frequency = e.RXInfoSet[0].Frequency
+		out.DataRate = &eventDataRate{
+			Modulation:   string(dr.Modulation),
+			SpreadFactor: dr.SpreadFactor,
+			Bandwidth:    dr.Bandwidth,
+			BitRate:      dr.BitRate,
+		}
+	}
+	for _, rxInfo := range e.RXInfoSet {
+		out.RXInfo = append(out.RXInfo, eventRXInfo{
+			MAC:     rxInfo.MAC.String(),
+			RSSI:    rxInfo.RSSI,
+			LoRaSNR: rxInfo.LoRaSNR,
+		})
+	}
+
+	return json.Marshal(out)
+}
+
+var (
+	publishersMu sync.RWMutex
+	publishers   []Publisher
+)
+
+// SetPublishers sets the publishers to which the first-join events are
+// published.
+func SetPublishers(p ...Publisher) {
+	publishersMu.Lock()
+	defer publishersMu.Unlock()
+	publishers = p
+}
+
+// HandleJoin records the given (accepted) join of a node. When this is the
+// first join of the node, the event is published to the configured
+// publishers. Publish errors are logged, as the join itself succeeded.
+func HandleJoin(db *sqlx.DB, e Event) error {
+	first, err := recordFirstJoin(db, e)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  e.DevEUI,
+		"app_eui":  e.AppEUI,
+		"dev_addr": e.DevAddr,
+		"gw_count": len(e.RXInfoSet),
+	}).Info("first join of node")
+
+	publishersMu.RLock()
+	defer publishersMu.RUnlock()
+
+	for _, pub := range publishers {
+		if err := pub.Publish(e); err != nil {
+			log.WithField("dev_eui", e.DevEUI).Errorf("publish first-join event error: %s", err)
+		}
+	}
+	return nil
+}
+
+// recordFirstJoin stores the join of the node, unless an earlier join was
+// already stored. It returns true when this is the first join of the node.
+func recordFirstJoin(db *sqlx.DB, e Event) (bool, error) {
+	res, err := db.Exec(`
+		insert into device_first_join (
+			dev_eui,
+			app_eui,
+			joined_at
+		) values ($1, $2, $3)
+		on conflict (dev_eui) do nothing`,
+		e.DevEUI[:],
+		e.AppEUI[:],
+		e.Time,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "insert error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "get rows affected error")
+	}
+	return ra == 1, nil
+}
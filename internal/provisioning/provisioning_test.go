@@ -0,0 +1,130 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+type testPublisher struct {
+	events []Event
+}
+
+func (p *testPublisher) Publish(e Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestHandleJoin(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database and a test publisher", t, func() {
+		test.MustResetDB(db)
+
+		pub := testPublisher{}
+		ncClient := test.NewNetworkControllerClient()
+		SetPublishers(&pub, &ControllerPublisher{Client: ncClient})
+		defer SetPublishers()
+
+		e := Event{
+			Time:    time.Now(),
+			DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			RXInfoSet: models.RXInfoSet{
+				{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Frequency: 868100000, RSSI: -60, LoRaSNR: 5.5},
+			},
+		}
+
+		Convey("When handling the first join of a node", func() {
+			So(HandleJoin(db, e), ShouldBeNil)
+
+			Convey("Then the event is published", func() {
+				So(pub.events, ShouldHaveLength, 1)
+				So(pub.events[0].DevEUI, ShouldEqual, e.DevEUI)
+
+				So(ncClient.HandleFirstJoinChan, ShouldHaveLength, 1)
+				req := <-ncClient.HandleFirstJoinChan
+				So(req.DevEUI, ShouldResemble, e.DevEUI[:])
+				So(req.DevAddr, ShouldResemble, e.DevAddr[:])
+				So(req.TxInfo.Frequency, ShouldEqual, 868100000)
+				So(req.RxInfo, ShouldHaveLength, 1)
+				So(req.RxInfo[0].Rssi, ShouldEqual, -60)
+			})
+
+			Convey("Then a next join of the node is not published", func() {
+				e.DevAddr = lorawan.DevAddr{4, 3, 2, 1}
+				So(HandleJoin(db, e), ShouldBeNil)
+				So(pub.events, ShouldHaveLength, 1)
+			})
+
+			Convey("Then the first join of an other node is published", func() {
+				e.DevEUI = lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+				So(HandleJoin(db, e), ShouldBeNil)
+				So(pub.events, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
+
+func TestHTTPPublisher(t *testing.T) {
+	Convey("Given a webhook endpoint and an HTTPPublisher", t, func() {
+		bodies := make(chan []byte, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies <- b
+		}))
+		defer server.Close()
+
+		p := NewHTTPPublisher(server.URL)
+
+		Convey("When publishing an event", func() {
+			So(p.Publish(Event{
+				Time:    time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+				DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				AppEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				RXInfoSet: models.RXInfoSet{
+					gw.RXInfo{
+						MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+						Frequency: 868100000,
+						RSSI:      -60,
+						LoRaSNR:   5.5,
+						DataRate: band.DataRate{
+							Modulation:   band.LoRaModulation,
+							SpreadFactor: 7,
+							Bandwidth:    125,
+						},
+					},
+				},
+			}), ShouldBeNil)
+
+			Convey("Then the event is posted as json", func() {
+				var body map[string]interface{}
+				So(json.Unmarshal(<-bodies, &body), ShouldBeNil)
+				So(body["devEUI"], ShouldEqual, "0102030405060708")
+				So(body["devAddr"], ShouldEqual, "01020304")
+				So(body["frequency"], ShouldEqual, 868100000.0)
+				So(body["rxInfo"], ShouldResemble, []interface{}{
+					map[string]interface{}{"mac": "0101010101010101", "rssi": -60.0, "loRaSNR": 5.5},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package provisioning
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/webhook"
+)
+
+// Publisher defines the interface for publishing the first-join events.
+type Publisher interface {
+	Publish(e Event) error
+}
+
+// ControllerPublisher publishes the first-join events to the
+// network-controller.
+type ControllerPublisher struct {
+	Client nc.NetworkControllerClient
+}
+
+// Publish publishes the given event.
+func (p *ControllerPublisher) Publish(e Event) error {
+	req := nc.HandleFirstJoinRequest{
+		DevEUI:  e.DevEUI[:],
+		AppEUI:  e.AppEUI[:],
+		DevAddr: e.DevAddr[:],
+		Time:    e.Time.Format(time.RFC3339Nano),
+	}
+
+	if len(e.RXInfoSet) > 0 {
+		req.TxInfo = &nc.TXInfo{
+			Frequency: int64(e.RXInfoSet[0].Frequency),
+			CodeRate:  e.RXInfoSet[0].CodeRate,
+			DataRate: &nc.DataRate{
+				Modulation:   string(e.RXInfoSet[0].DataRate.Modulation),
+				BandWidth:    uint32(e.RXInfoSet[0].DataRate.Bandwidth),
+				SpreadFactor: uint32(e.RXInfoSet[0].DataRate.SpreadFactor),
+				Bitrate:      uint32(e.RXInfoSet[0].DataRate.BitRate),
+			},
+		}
+	}
+
+	for _, rxInfo := range e.RXInfoSet {
+		mac := make([]byte, 8)
+		copy(mac, rxInfo.MAC[:])
+
+		req.RxInfo = append(req.RxInfo, &nc.RXInfo{
+			Mac:     mac,
+			Time:    rxInfo.Time.Format(time.RFC3339Nano),
+			Rssi:    int32(rxInfo.RSSI),
+			LoRaSNR: rxInfo.LoRaSNR,
+		})
+	}
+
+	if _, err := p.Client.HandleFirstJoin(context.Background(), &req); err != nil {
+		return errors.Wrap(err, "handle first join error")
+	}
+	return nil
+}
+
+// HTTPPublisher posts the first-join events as JSON (HTTP POST) to the
+// given URL (webhook).
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a new HTTPPublisher.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish posts the given event.
+func (p *HTTPPublisher) Publish(e Event) error {
+	return webhook.Post(p.Client, p.URL, e)
+}
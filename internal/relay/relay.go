@@ -0,0 +1,114 @@
+// Package relay implements the relayed uplink format of the LoRaWAN relay
+// specification (TS011).
+package relay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/brocaar/lorawan"
+)
+
+// FPort defines the FPort used by the relay for exchanging forwarded
+// uplinks and downlinks with the network-server. The FRMPayload on this
+// FPort is encrypted with the NwkSKey.
+const FPort = 226
+
+const (
+	minRSSI = -142
+	maxRSSI = -15
+	minSNR  = -20
+	maxSNR  = 11
+)
+
+// UplinkMetadata contains the meta-data of the uplink as received by the
+// relay.
+type UplinkMetadata struct {
+	DR         uint8 // data-rate of the uplink
+	SNR        int   // SNR in dB (-20 - 11)
+	RSSI       int   // RSSI in dBm (-142 - -15)
+	WORChannel uint8 // wake-on-radio channel
+}
+
+// MarshalBinary marshals the object in binary form.
+func (m UplinkMetadata) MarshalBinary() ([]byte, error) {
+	if m.DR > 15 {
+		return nil, errors.New("relay: max value of DR is 15")
+	}
+	if m.SNR < minSNR || m.SNR > maxSNR {
+		return nil, fmt.Errorf("relay: SNR must be between %d and %d", minSNR, maxSNR)
+	}
+	if m.RSSI < minRSSI || m.RSSI > maxRSSI {
+		return nil, fmt.Errorf("relay: RSSI must be between %d and %d", minRSSI, maxRSSI)
+	}
+	if m.WORChannel > 3 {
+		return nil, errors.New("relay: max value of WORChannel is 3")
+	}
+
+	v := uint32(m.DR)
+	v |= uint32(m.SNR-minSNR) << 4
+	v |= uint32(-(m.RSSI - maxRSSI)) << 9
+	v |= uint32(m.WORChannel) << 16
+
+	return []byte{byte(v), byte(v >> 8), byte(v >> 16)}, nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (m *UplinkMetadata) UnmarshalBinary(data []byte) error {
+	if len(data) != 3 {
+		return errors.New("relay: 3 bytes of data are expected")
+	}
+
+	v := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16
+	m.DR = uint8(v & 0x0f)
+	m.SNR = int((v>>4)&0x1f) + minSNR
+	m.RSSI = maxRSSI - int((v>>9)&0x7f)
+	m.WORChannel = uint8((v >> 16) & 0x03)
+
+	return nil
+}
+
+// ForwardUplinkReq represents the payload of an uplink forwarded by the
+// relay.
+type ForwardUplinkReq struct {
+	Metadata   UplinkMetadata
+	Frequency  int // frequency in Hz on which the relay received the uplink
+	PHYPayload lorawan.PHYPayload
+}
+
+// MarshalBinary marshals the object in binary form.
+func (r ForwardUplinkReq) MarshalBinary() ([]byte, error) {
+	b, err := r.Metadata.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Frequency%100 != 0 || r.Frequency/100 >= 1<<24 {
+		return nil, errors.New("relay: Frequency must be a multiple of 100 and fit in 3 bytes")
+	}
+	freq := uint32(r.Frequency / 100)
+	b = append(b, byte(freq), byte(freq>>8), byte(freq>>16))
+
+	phy, err := r.PHYPayload.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, phy...), nil
+}
+
+// UnmarshalBinary decodes the object from binary form.
+func (r *ForwardUplinkReq) UnmarshalBinary(data []byte) error {
+	// metadata + frequency + at least MHDR and MIC
+	if len(data) < 11 {
+		return errors.New("relay: at least 11 bytes of data are expected")
+	}
+
+	if err := r.Metadata.UnmarshalBinary(data[0:3]); err != nil {
+		return err
+	}
+
+	r.Frequency = int(uint32(data[3])|uint32(data[4])<<8|uint32(data[5])<<16) * 100
+
+	return r.PHYPayload.UnmarshalBinary(data[6:])
+}
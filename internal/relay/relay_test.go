@@ -0,0 +1,85 @@
+package relay
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUplinkMetadata(t *testing.T) {
+	Convey("Given a set of test for UplinkMetadata", t, func() {
+		testTable := []struct {
+			Metadata UplinkMetadata
+			Bytes    []byte
+			Error    bool
+		}{
+			{UplinkMetadata{DR: 5, SNR: -20, RSSI: -15, WORChannel: 0}, []byte{5, 0, 0}, false},
+			{UplinkMetadata{DR: 3, SNR: 11, RSSI: -142, WORChannel: 3}, []byte{243, 255, 3}, false},
+			{UplinkMetadata{DR: 16}, nil, true},
+			{UplinkMetadata{SNR: 12, RSSI: -15}, nil, true},
+			{UplinkMetadata{RSSI: -143}, nil, true},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %+v [%d]", test.Metadata, i), func() {
+				b, err := test.Metadata.MarshalBinary()
+				if test.Error {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(b, ShouldResemble, test.Bytes)
+
+				var md UplinkMetadata
+				So(md.UnmarshalBinary(b), ShouldBeNil)
+				So(md, ShouldResemble, test.Metadata)
+			})
+		}
+	})
+}
+
+func TestForwardUplinkReq(t *testing.T) {
+	Convey("Given a ForwardUplinkReq", t, func() {
+		fPort := uint8(10)
+		req := ForwardUplinkReq{
+			Metadata: UplinkMetadata{
+				DR:   5,
+				SNR:  7,
+				RSSI: -80,
+			},
+			Frequency: 868100000,
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+						FCnt:    10,
+					},
+					FPort: &fPort,
+					FRMPayload: []lorawan.Payload{
+						&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+					},
+				},
+				MIC: [4]byte{1, 2, 3, 4},
+			},
+		}
+
+		Convey("Then it can be marshaled and unmarshaled", func() {
+			b, err := req.MarshalBinary()
+			So(err, ShouldBeNil)
+			So(b[3:6], ShouldResemble, []byte{0x28, 0x76, 0x84})
+
+			var out ForwardUplinkReq
+			So(out.UnmarshalBinary(b), ShouldBeNil)
+			So(out.Metadata, ShouldResemble, req.Metadata)
+			So(out.Frequency, ShouldEqual, req.Frequency)
+			So(out.PHYPayload.MHDR, ShouldResemble, req.PHYPayload.MHDR)
+			So(out.PHYPayload.MIC, ShouldEqual, req.PHYPayload.MIC)
+		})
+	})
+}
@@ -0,0 +1,273 @@
+// Package replication implements the warm standby replication of the
+// node-session and queue state stored in Redis to a secondary site.
+package replication
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+)
+
+const (
+	// keyspaceChannelPrefix is the prefix of the keyspace notification
+	// channels (of all databases).
+	keyspaceChannelPrefix = "__keyspace@*__:"
+
+	// leaderCheckInterval defines the interval on which the replication
+	// checks if the instance is still the leader.
+	leaderCheckInterval = 10 * time.Second
+
+	// retryInterval defines the interval after which the replication is
+	// restarted after an error or when the instance is not the leader.
+	retryInterval = 10 * time.Second
+)
+
+// KeyPatterns contains the patterns of the Redis keys holding the
+// node-session and queue state which is replicated.
+var KeyPatterns = []string{
+	"lora:ns:session:*",
+	"lora:ns:devaddr:*",
+	"lora:ns:dev_nonce:*",
+	"lora:ns:security:quarantine:*",
+	"lora:ns:mac:queue:*",
+	"lora:ns:mac:pending:*",
+	"lora:ns:applayer:downlink:*",
+}
+
+// Mutation contains the state of a replicated key after a mutation.
+type Mutation struct {
+	Key       string     `json:"key"`
+	Value     []byte     `json:"value,omitempty"`     // DUMP serialized value, nil when the key has been removed
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // nil when the key has no TTL
+}
+
+// Sink defines the interface of the replication target.
+type Sink interface {
+	// Apply applies the given mutation.
+	Apply(m Mutation) error
+
+	// Rotate is called before each full sync, so that the sink can discard
+	// the state superseded by the full sync.
+	Rotate() error
+}
+
+// Run replicates the node-session and queue state to the given sink until
+// the done channel is closed. The replication is only executed when the
+// given elector is the leader (or when nil). Mutations are streamed using
+// the Redis keyspace notifications (notify-keyspace-events must contain at
+// least "Kgx" and the flags of the used data types, e.g. "KA"). A full sync
+// is performed when starting the replication and on the given sync interval
+// (0 = disabled), to cover the mutations of which the notification was
+// missed (e.g. during a reconnect). The replication stops when the standby
+// has been promoted.
+func Run(p *redis.Pool, sink Sink, elector *leader.Elector, syncInterval time.Duration, done chan struct{}) {
+	for {
+		if elector == nil || elector.IsLeader() {
+			err := replicate(p, sink, elector, syncInterval, done)
+			if errors.Cause(err) == ErrStandbyPromoted {
+				log.Error("standby has been promoted, stopping replication")
+				return
+			}
+			if err != nil {
+				log.Errorf("replication error: %s", err)
+			}
+		}
+
+		select {
+		case <-done:
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// replicate replicates the state until the done channel is closed or until
+// the elector is no longer the leader (in which case nil is returned).
+func replicate(p *redis.Pool, sink Sink, elector *leader.Elector, syncInterval time.Duration, done chan struct{}) error {
+	checkKeyspaceNotifications(p)
+
+	c, err := common.GetRedisSubscribeConn(p)
+	if err != nil {
+		return errors.Wrap(err, "get subscribe connection error")
+	}
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	var channels []interface{}
+	for _, pattern := range KeyPatterns {
+		channels = append(channels, keyspaceChannelPrefix+common.RedisKeyPrefix+pattern)
+	}
+	if err := psc.PSubscribe(channels...); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	// the notifications are received before the full sync, so that no
+	// mutation is missed during the full sync
+	keys := make(chan string, 1000)
+	recvErr := make(chan error, 1)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			switch v := psc.Receive().(type) {
+			case redis.PMessage:
+				select {
+				case keys <- keyFromChannel(v.Channel):
+				case <-stop:
+				}
+			case redis.Subscription:
+				if v.Count == 0 {
+					recvErr <- errors.New("unsubscribed")
+					return
+				}
+			case error:
+				recvErr <- v
+				return
+			}
+		}
+	}()
+	// unsubscribing unblocks the Receive call above
+	defer wg.Wait()
+	defer psc.PUnsubscribe()
+	defer close(stop)
+
+	if err := FullSync(p, sink); err != nil {
+		return err
+	}
+	lastSync := time.Now()
+
+	ticker := time.NewTicker(leaderCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case key := <-keys:
+			if err := replicateKey(p, sink, key); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				return nil
+			}
+			if syncInterval > 0 && time.Since(lastSync) >= syncInterval {
+				if err := FullSync(p, sink); err != nil {
+					return err
+				}
+				lastSync = time.Now()
+			}
+		case err := <-recvErr:
+			return errors.Wrap(err, "receive error")
+		case <-done:
+			return nil
+		}
+	}
+}
+
+// FullSync replicates all the keys matching the KeyPatterns to the given
+// sink.
+func FullSync(p *redis.Pool, sink Sink) error {
+	if err := sink.Rotate(); err != nil {
+		return errors.Wrap(err, "rotate sink error")
+	}
+
+	var count int
+	for _, pattern := range KeyPatterns {
+		keys, err := common.ScanKeys(p, pattern)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := replicateKey(p, sink, key); err != nil {
+				return err
+			}
+		}
+		count += len(keys)
+	}
+
+	log.WithField("keys", count).Info("replication full sync completed")
+	return nil
+}
+
+// replicateKey applies the current state of the given key to the sink.
+func replicateKey(p *redis.Pool, sink Sink, key string) error {
+	m, err := getMutation(p, key)
+	if err != nil {
+		return err
+	}
+	if err := sink.Apply(m); err != nil {
+		return errors.Wrapf(err, "apply mutation error (key: %s)", key)
+	}
+	return nil
+}
+
+// getMutation returns the current state of the given key.
+func getMutation(p *redis.Pool, key string) (Mutation, error) {
+	c := p.Get()
+	defer c.Close()
+
+	m := Mutation{Key: key}
+
+	c.Send("MULTI")
+	c.Send("DUMP", key)
+	c.Send("PTTL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return m, errors.Wrap(err, "dump key error")
+	}
+
+	// DUMP returns nil when the key does not exist
+	if values[0] != nil {
+		m.Value, err = redis.Bytes(values[0], nil)
+		if err != nil {
+			return m, errors.Wrap(err, "dump key error")
+		}
+	}
+
+	ttl, err := redis.Int64(values[1], nil)
+	if err != nil {
+		return m, errors.Wrap(err, "get ttl error")
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(time.Duration(ttl) * time.Millisecond)
+		m.ExpiresAt = &expiresAt
+	}
+
+	return m, nil
+}
+
+// checkKeyspaceNotifications logs a warning when the keyspace notifications
+// are not enabled. Errors are ignored as the CONFIG command might be
+// disabled (e.g. on managed Redis services).
+func checkKeyspaceNotifications(p *redis.Pool) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("CONFIG", "GET", "notify-keyspace-events"))
+	if err != nil || len(values) != 2 {
+		return
+	}
+	if !strings.Contains(values[1], "K") {
+		log.Warning("redis keyspace notifications are disabled, mutations are only replicated by the full sync (set notify-keyspace-events to KA)")
+	}
+}
+
+// keyFromChannel returns the key of the given keyspace notification
+// channel, without the RedisKeyPrefix (as this is added by the connection).
+func keyFromChannel(channel string) string {
+	parts := strings.SplitN(channel, "__:", 2)
+	if len(parts) != 2 {
+		return channel
+	}
+	return strings.TrimPrefix(parts[1], common.RedisKeyPrefix)
+}
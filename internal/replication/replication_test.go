@@ -0,0 +1,175 @@
+package replication
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+type testSink struct {
+	mutations []Mutation
+}
+
+func (s *testSink) Apply(m Mutation) error {
+	s.mutations = append(s.mutations, m)
+	return nil
+}
+
+func (s *testSink) Rotate() error {
+	return nil
+}
+
+func TestKeyFromChannel(t *testing.T) {
+	Convey("Then the key is returned for a keyspace notification channel", t, func() {
+		So(keyFromChannel("__keyspace@0__:lora:ns:session:0102030405060708"), ShouldEqual, "lora:ns:session:0102030405060708")
+	})
+}
+
+func TestWALSink(t *testing.T) {
+	Convey("Given a WALSink writing to a temporary file", t, func() {
+		dir, err := ioutil.TempDir("", "wal")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "replication.wal")
+		sink, err := NewWALSink(path)
+		So(err, ShouldBeNil)
+
+		expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+
+		Convey("When applying mutations before and after a rotation", func() {
+			So(sink.Apply(Mutation{Key: "a", Value: []byte{1, 2, 3}}), ShouldBeNil)
+			So(sink.Rotate(), ShouldBeNil)
+			So(sink.Apply(Mutation{Key: "b", Value: []byte{4, 5, 6}, ExpiresAt: &expiresAt}), ShouldBeNil)
+			So(sink.Apply(Mutation{Key: "a"}), ShouldBeNil)
+			So(sink.Close(), ShouldBeNil)
+
+			Convey("Then the rotated log contains the mutations before the rotation", func() {
+				f, err := os.Open(path + ".1")
+				So(err, ShouldBeNil)
+				defer f.Close()
+
+				var ts testSink
+				n, err := replayFile(&ts, f)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 1)
+				So(ts.mutations, ShouldResemble, []Mutation{
+					{Key: "a", Value: []byte{1, 2, 3}},
+				})
+			})
+
+			Convey("Then the log contains the mutations after the rotation", func() {
+				f, err := os.Open(path)
+				So(err, ShouldBeNil)
+				defer f.Close()
+
+				var ts testSink
+				n, err := replayFile(&ts, f)
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, 2)
+				So(ts.mutations, ShouldHaveLength, 2)
+				So(ts.mutations[0].Key, ShouldEqual, "b")
+				So(ts.mutations[0].Value, ShouldResemble, []byte{4, 5, 6})
+				So(ts.mutations[0].ExpiresAt.Equal(expiresAt), ShouldBeTrue)
+				So(ts.mutations[1], ShouldResemble, Mutation{Key: "a"})
+			})
+
+			Convey("When the last line of the log is incomplete", func() {
+				f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+				So(err, ShouldBeNil)
+				_, err = f.WriteString(`{"key":"c","val`)
+				So(err, ShouldBeNil)
+				So(f.Close(), ShouldBeNil)
+
+				Convey("Then the incomplete line is ignored", func() {
+					f, err := os.Open(path)
+					So(err, ShouldBeNil)
+					defer f.Close()
+
+					var ts testSink
+					n, err := replayFile(&ts, f)
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 2)
+				})
+			})
+		})
+	})
+}
+
+func TestReplication(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database with a node-session and an unrelated key", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		c := p.Get()
+		defer c.Close()
+
+		_, err := c.Do("PSETEX", "lora:ns:session:0102030405060708", 60000, "session")
+		So(err, ShouldBeNil)
+		_, err = c.Do("SET", "lora:ns:gw:last_seen", "gateway")
+		So(err, ShouldBeNil)
+
+		dir, err := ioutil.TempDir("", "wal")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "replication.wal")
+
+		Convey("When performing a full sync to a WALSink and replaying the WAL into the flushed database", func() {
+			sink, err := NewWALSink(path)
+			So(err, ShouldBeNil)
+			So(FullSync(p, sink), ShouldBeNil)
+			So(sink.Close(), ShouldBeNil)
+
+			test.MustFlushRedis(p)
+			n, err := ReplayWAL(p, path)
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+
+			Convey("Then the node-session has been restored with its TTL", func() {
+				val, err := redis.String(c.Do("GET", "lora:ns:session:0102030405060708"))
+				So(err, ShouldBeNil)
+				So(val, ShouldEqual, "session")
+
+				ttl, err := redis.Int64(c.Do("PTTL", "lora:ns:session:0102030405060708"))
+				So(err, ShouldBeNil)
+				So(ttl, ShouldBeGreaterThan, 0)
+				So(ttl <= 60000, ShouldBeTrue)
+			})
+
+			Convey("Then the unrelated key has not been replicated", func() {
+				exists, err := redis.Bool(c.Do("EXISTS", "lora:ns:gw:last_seen"))
+				So(err, ShouldBeNil)
+				So(exists, ShouldBeFalse)
+			})
+
+			Convey("When applying a removal", func() {
+				So(NewRedisSink(p).Apply(Mutation{Key: "lora:ns:session:0102030405060708"}), ShouldBeNil)
+
+				Convey("Then the node-session has been removed", func() {
+					exists, err := redis.Bool(c.Do("EXISTS", "lora:ns:session:0102030405060708"))
+					So(err, ShouldBeNil)
+					So(exists, ShouldBeFalse)
+				})
+			})
+
+			Convey("When the standby has been promoted", func() {
+				So(Promote(p), ShouldBeNil)
+
+				Convey("Then mutations are rejected", func() {
+					err := NewRedisSink(p).Apply(Mutation{Key: "lora:ns:session:0102030405060708"})
+					So(err, ShouldEqual, ErrStandbyPromoted)
+				})
+			})
+		})
+	})
+}
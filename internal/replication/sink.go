@@ -0,0 +1,229 @@
+package replication
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+// promotedKey is set on the standby when it has been promoted. Once set,
+// the standby rejects all replicated mutations.
+const promotedKey = "lora:ns:replication:promoted"
+
+// ErrStandbyPromoted is returned when applying a mutation to a standby
+// which has been promoted.
+var ErrStandbyPromoted = errors.New("standby has been promoted")
+
+// applyMutationScript applies the given mutation, unless the standby has
+// been promoted (in which case 0 is returned). ARGV[1] is set to 1 when the
+// key must be removed, ARGV[2] contains the TTL in ms (0 = no TTL) and
+// ARGV[3] the DUMP serialized value.
+var applyMutationScript = redis.NewScript(2, `
+	if redis.call("EXISTS", KEYS[2]) == 1 then
+		return 0
+	end
+	if ARGV[1] == "1" then
+		redis.call("DEL", KEYS[1])
+	else
+		redis.call("RESTORE", KEYS[1], ARGV[2], ARGV[3], "REPLACE")
+	end
+	return 1
+`)
+
+// RedisSink replicates the mutations to a standby Redis. The standby must
+// run the same (or a newer) Redis version, as the values are replicated
+// using DUMP / RESTORE.
+type RedisSink struct {
+	p *redis.Pool
+}
+
+// NewRedisSink creates a new RedisSink.
+func NewRedisSink(p *redis.Pool) *RedisSink {
+	return &RedisSink{p: p}
+}
+
+// Apply applies the given mutation to the standby Redis.
+func (s *RedisSink) Apply(m Mutation) error {
+	var ttl int64
+	remove := m.Value == nil
+	if m.ExpiresAt != nil {
+		ttl = int64(m.ExpiresAt.Sub(time.Now()) / time.Millisecond)
+		if ttl <= 0 {
+			remove = true
+		}
+	}
+
+	var removeArg int
+	if remove {
+		removeArg = 1
+	}
+
+	c := s.p.Get()
+	defer c.Close()
+
+	ok, err := redis.Bool(applyMutationScript.Do(c, m.Key, promotedKey, removeArg, ttl, m.Value))
+	if err != nil {
+		return errors.Wrap(err, "apply mutation error")
+	}
+	if !ok {
+		return ErrStandbyPromoted
+	}
+	return nil
+}
+
+// Rotate implements the Sink interface. Keys of which the removal was
+// missed are overwritten by the next mutation or expire by their TTL.
+func (s *RedisSink) Rotate() error {
+	return nil
+}
+
+// Promote makes the given standby Redis authoritative. From then on, all
+// replicated mutations are rejected so that the old primary can not
+// overwrite the state of the standby.
+func Promote(p *redis.Pool) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("SET", promotedKey, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return errors.Wrap(err, "set promoted error")
+	}
+	return nil
+}
+
+// WALSink appends the mutations to a file-based write-ahead log, one JSON
+// encoded Mutation per line. On each full sync, the log is rotated to
+// <path>.1 so that the log contains at most two full syncs and the
+// mutations in between.
+type WALSink struct {
+	sync.Mutex
+	path string
+	f    *os.File
+	enc  *json.Encoder
+}
+
+// NewWALSink creates a new WALSink, appending to the given file.
+func NewWALSink(path string) (*WALSink, error) {
+	s := WALSink{path: path}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *WALSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "open wal error")
+	}
+	s.f = f
+	s.enc = json.NewEncoder(f)
+	return nil
+}
+
+// Apply appends the given mutation to the log.
+func (s *WALSink) Apply(m Mutation) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.enc.Encode(m); err != nil {
+		return errors.Wrap(err, "write wal error")
+	}
+	return nil
+}
+
+// Rotate moves the current log to <path>.1 and starts a new log.
+func (s *WALSink) Rotate() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.close(); err != nil {
+		return err
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return errors.Wrap(err, "rotate wal error")
+	}
+	return s.open()
+}
+
+// Close closes the log.
+func (s *WALSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.close()
+}
+
+func (s *WALSink) close() error {
+	if err := s.f.Sync(); err != nil {
+		return errors.Wrap(err, "sync wal error")
+	}
+	if err := s.f.Close(); err != nil {
+		return errors.Wrap(err, "close wal error")
+	}
+	return nil
+}
+
+// ReplayWAL applies the mutations of the write-ahead log at the given path
+// (the rotated log first) to the given Redis and returns the number of
+// applied mutations. Mutations of keys which expired in the meantime are
+// applied as removals.
+func ReplayWAL(p *redis.Pool, path string) (int, error) {
+	sink := NewRedisSink(p)
+
+	var count int
+	for _, name := range []string{path + ".1", path} {
+		f, err := os.Open(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return count, errors.Wrap(err, "open wal error")
+		}
+
+		n, err := replayFile(sink, f)
+		f.Close()
+		count += n
+		if err != nil {
+			return count, errors.Wrapf(err, "replay %s error", name)
+		}
+	}
+
+	return count, nil
+}
+
+func replayFile(sink Sink, f *os.File) (int, error) {
+	var count, line int
+	var lineErr error
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line++
+		if lineErr != nil {
+			return count, lineErr
+		}
+
+		var m Mutation
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			lineErr = errors.Wrapf(err, "unmarshal mutation error (line: %d)", line)
+			continue
+		}
+		if err := sink.Apply(m); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	// an invalid last line is ignored, as it might be incomplete when the
+	// primary crashed while writing it
+	if err := scanner.Err(); err != nil {
+		return count, errors.Wrap(err, "read wal error")
+	}
+	return count, nil
+}
@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/adr"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// counterKeyTempl contains per rule and node the number of consecutive
+// uplinks matching the conditions of the rule (rule ID and DevEUI).
+const counterKeyTempl = "lora:ns:rule:%d:%s"
+
+// HandleUplink evaluates the rules for the given uplink of the node and
+// executes the actions of the triggered rules. As actions can update the
+// node-session, this function must be called before the node-session is
+// saved.
+func HandleUplink(p *redis.Pool, ns *session.NodeSession, fPort *uint8, dr int, now time.Time) error {
+	for _, r := range getRules() {
+		if !r.appliesTo(ns.DevEUI) {
+			continue
+		}
+
+		triggered, err := countMatch(p, r, ns.DevEUI, r.matches(fPort, dr))
+		if err != nil {
+			return errors.Wrapf(err, "rule %d", r.ID)
+		}
+		if !triggered {
+			continue
+		}
+
+		if err := execute(p, r, ns, now); err != nil {
+			return errors.Wrapf(err, "execute rule %d error", r.ID)
+		}
+
+		log.WithFields(log.Fields{
+			"rule_id": r.ID,
+			"name":    r.Name,
+			"action":  r.Action,
+			"dev_eui": ns.DevEUI,
+		}).Info("uplink rule triggered")
+	}
+
+	return nil
+}
+
+// countMatch updates the consecutive match counter of the given rule and
+// node. It returns true when the rule has matched ConsecutiveFrames times
+// in a row, in which case the counter is reset.
+func countMatch(p *redis.Pool, r Rule, devEUI lorawan.EUI64, match bool) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(counterKeyTempl, r.ID, devEUI)
+
+	if !match {
+		if _, err := c.Do("DEL", key); err != nil {
+			return false, errors.Wrap(err, "delete counter error")
+		}
+		return false, nil
+	}
+
+	c.Send("MULTI")
+	c.Send("INCR", key)
+	c.Send("PEXPIRE", key, int64(common.NodeSessionTTL)/int64(time.Millisecond))
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return false, errors.Wrap(err, "increment counter error")
+	}
+	count, err := redis.Int(values[0], nil)
+	if err != nil {
+		return false, errors.Wrap(err, "read counter error")
+	}
+
+	if count < r.ConsecutiveFrames {
+		return false, nil
+	}
+
+	if _, err := c.Do("DEL", key); err != nil {
+		return false, errors.Wrap(err, "delete counter error")
+	}
+	return true, nil
+}
+
+// execute executes the action of the given rule.
+func execute(p *redis.Pool, r Rule, ns *session.NodeSession, now time.Time) error {
+	switch r.Action {
+	case ActionEnqueueLinkADRReq:
+		return enqueueLinkADRReq(p, r, ns)
+	case ActionEnqueueMACCommand:
+		if len(r.MACCommand) > 0 && !ns.GetMACCapabilities().SupportsMACCommand(lorawan.CID(r.MACCommand[0])) {
+			log.WithFields(log.Fields{
+				"rule_id":     r.ID,
+				"dev_eui":     ns.DevEUI,
+				"mac_version": ns.GetMACVersion(),
+			}).Warning("mac-command is not supported by the mac version of the node, skipping rule action")
+			return nil
+		}
+		return maccommand.AddToQueue(p, maccommand.QueueItem{
+			DevEUI: ns.DevEUI,
+			Data:   r.MACCommand,
+		})
+	case ActionOpenClassCWindow:
+		ns.ClassCUntil = now.Add(r.ClassCWindow)
+		return nil
+	default:
+		return ErrInvalidAction
+	}
+}
+
+// enqueueLinkADRReq enqueues the LinkADRReq mac-command of the given rule
+// and marks it as pending, so that the node-session is updated once the
+// node acknowledges the request.
+func enqueueLinkADRReq(p *redis.Pool, r Rule, ns *session.NodeSession) error {
+	// the LinkADRReq mac-commands are handled by the network-controller
+	if maccommand.IsHandledByController(lorawan.LinkADRReq) {
+		log.WithFields(log.Fields{
+			"rule_id": r.ID,
+			"dev_eui": ns.DevEUI,
+		}).Warning("LinkADRReq is handled by the network-controller, skipping rule action")
+		return nil
+	}
+
+	mac := lorawan.MACCommand{
+		CID: lorawan.LinkADRReq,
+		Payload: &lorawan.LinkADRReqPayload{
+			DataRate: uint8(r.DataRate),
+			TXPower:  uint8(r.TXPower),
+			ChMask:   adr.GetChMask(*ns),
+			Redundancy: lorawan.Redundancy{
+				ChMaskCntl: 0, // first block of 16 channels
+				NbRep:      ns.NbTrans,
+			},
+		},
+	}
+	b, err := mac.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal mac command error")
+	}
+
+	err = maccommand.AddToQueue(p, maccommand.QueueItem{
+		DevEUI: ns.DevEUI,
+		Data:   b,
+	})
+	if err != nil {
+		return errors.Wrap(err, "add mac-command to queue error")
+	}
+
+	err = maccommand.SetPending(p, ns.DevEUI, lorawan.LinkADRReq, []lorawan.MACCommandPayload{mac.Payload})
+	if err != nil {
+		return errors.Wrap(err, "set mac-command as pending error")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHandleUplink(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a node-session", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+		defer setRules(nil)
+
+		ns := session.NodeSession{
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		now := time.Now()
+		fPort := uint8(10)
+
+		Convey("Given a rule enqueueing a mac-command after 3 uplinks with DR <= 1", func() {
+			setRules([]Rule{
+				{ID: 1, Name: "low-dr", Enabled: true, MaxDR: intPtr(1), ConsecutiveFrames: 3, Action: ActionEnqueueMACCommand, MACCommand: []byte{0x80, 1}},
+			})
+
+			Convey("When receiving 2 matching uplinks", func() {
+				for i := 0; i < 2; i++ {
+					So(HandleUplink(p, &ns, &fPort, 0, now), ShouldBeNil)
+				}
+
+				Convey("Then the mac-command queue is empty", func() {
+					items, err := maccommand.ReadQueue(p, ns.DevEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+
+				Convey("When receiving a non-matching and 2 matching uplinks", func() {
+					So(HandleUplink(p, &ns, &fPort, 5, now), ShouldBeNil)
+					So(HandleUplink(p, &ns, &fPort, 0, now), ShouldBeNil)
+					So(HandleUplink(p, &ns, &fPort, 0, now), ShouldBeNil)
+
+					Convey("Then the mac-command queue is still empty", func() {
+						items, err := maccommand.ReadQueue(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 0)
+					})
+				})
+
+				Convey("When receiving a third matching uplink", func() {
+					So(HandleUplink(p, &ns, &fPort, 1, now), ShouldBeNil)
+
+					Convey("Then the mac-command has been enqueued", func() {
+						items, err := maccommand.ReadQueue(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 1)
+						So(items[0].Data, ShouldResemble, []byte{0x80, 1})
+					})
+				})
+			})
+		})
+
+		Convey("Given a rule opening the Class-C window on FPort 10 for an other node", func() {
+			otherDevEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+			setRules([]Rule{
+				{ID: 2, Name: "class-c", Enabled: true, DevEUI: &otherDevEUI, FPort: intPtr(10), Action: ActionOpenClassCWindow, ClassCWindow: 5 * time.Minute},
+			})
+
+			Convey("Then the rule does not apply to the node", func() {
+				So(HandleUplink(p, &ns, &fPort, 0, now), ShouldBeNil)
+				So(ns.ClassCUntil.IsZero(), ShouldBeTrue)
+			})
+
+			Convey("When the rule applies to the node", func() {
+				setRules([]Rule{
+					{ID: 2, Name: "class-c", Enabled: true, DevEUI: &ns.DevEUI, FPort: intPtr(10), Action: ActionOpenClassCWindow, ClassCWindow: 5 * time.Minute},
+				})
+				So(HandleUplink(p, &ns, &fPort, 0, now), ShouldBeNil)
+
+				Convey("Then the Class-C window has been opened", func() {
+					So(ns.ClassCUntil.Equal(now.Add(5*time.Minute)), ShouldBeTrue)
+					So(ns.GetDeviceClass(0, now), ShouldEqual, session.DeviceClassC)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package rules
+
+import "errors"
+
+// rules errors
+var (
+	ErrDoesNotExist            = errors.New("uplink rule does not exist")
+	ErrInvalidName             = errors.New("uplink rule name must not be empty")
+	ErrInvalidAction           = errors.New("invalid uplink rule action")
+	ErrInvalidCondition        = errors.New("invalid uplink rule condition")
+	ErrInvalidActionParameters = errors.New("invalid uplink rule action parameters")
+)
@@ -0,0 +1,329 @@
+// Package rules implements the uplink automation rules. A rule triggers an
+// action (e.g. enqueue a mac-command) when the uplinks of a node match the
+// conditions of the rule for a number of consecutive frames.
+package rules
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/brocaar/lorawan"
+)
+
+// Possible rule actions.
+const (
+	// ActionEnqueueLinkADRReq enqueues a LinkADRReq mac-command requesting
+	// the DataRate and TXPower (index) of the rule.
+	ActionEnqueueLinkADRReq = "ENQUEUE_LINK_ADR_REQ"
+
+	// ActionEnqueueMACCommand enqueues the MACCommand (CID + payload) of
+	// the rule.
+	ActionEnqueueMACCommand = "ENQUEUE_MAC_COMMAND"
+
+	// ActionOpenClassCWindow handles the node as Class-C device for the
+	// ClassCWindow of the rule.
+	ActionOpenClassCWindow = "OPEN_CLASS_C_WINDOW"
+)
+
+// maxDataRate and maxTXPower are the max values which can be encoded in
+// the LinkADRReq mac-command.
+const (
+	maxDataRate = 15
+	maxTXPower  = 15
+)
+
+// Rule defines an uplink automation rule. All (set) conditions must match
+// for ConsecutiveFrames uplinks in a row before the action is executed.
+type Rule struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"name"`
+	Enabled bool   `db:"enabled"`
+
+	// DevEUI restricts the rule to a single node (nil = all nodes).
+	DevEUI *lorawan.EUI64 `db:"dev_eui"`
+
+	// The conditions (nil = not evaluated).
+	FPort *int `db:"f_port"` // the uplink FPort equals FPort
+	MaxDR *int `db:"max_dr"` // the uplink data-rate is <= MaxDR
+
+	// ConsecutiveFrames defines the number of consecutive matching uplinks
+	// needed to trigger the action (0 and 1 trigger on every match).
+	ConsecutiveFrames int `db:"consecutive_frames"`
+
+	// The action and its parameters.
+	Action       string        `db:"action"`
+	DataRate     int           `db:"data_rate"`
+	TXPower      int           `db:"tx_power"`
+	MACCommand   []byte        `db:"mac_command"`
+	ClassCWindow time.Duration `db:"class_c_window"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate validates the rule.
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrInvalidName
+	}
+
+	if r.FPort != nil && (*r.FPort < 0 || *r.FPort > 255) {
+		return ErrInvalidCondition
+	}
+	if r.MaxDR != nil && (*r.MaxDR < 0 || *r.MaxDR > maxDataRate) {
+		return ErrInvalidCondition
+	}
+	if r.ConsecutiveFrames < 0 {
+		return ErrInvalidCondition
+	}
+
+	switch r.Action {
+	case ActionEnqueueLinkADRReq:
+		if r.DataRate < 0 || r.DataRate > maxDataRate || r.TXPower < 0 || r.TXPower > maxTXPower {
+			return ErrInvalidActionParameters
+		}
+	case ActionEnqueueMACCommand:
+		if len(r.MACCommand) == 0 {
+			return ErrInvalidActionParameters
+		}
+		if maccommand.IsHandledByNetworkServer(lorawan.CID(r.MACCommand[0])) {
+			return maccommand.ErrHandledByNetworkServer
+		}
+	case ActionOpenClassCWindow:
+		if r.ClassCWindow <= 0 {
+			return ErrInvalidActionParameters
+		}
+	default:
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// matches returns true when the given uplink matches the conditions of
+// the rule.
+func (r Rule) matches(fPort *uint8, dr int) bool {
+	if r.FPort != nil && (fPort == nil || int(*fPort) != *r.FPort) {
+		return false
+	}
+	if r.MaxDR != nil && dr > *r.MaxDR {
+		return false
+	}
+	return true
+}
+
+// appliesTo returns true when the rule is enabled for the given node.
+func (r Rule) appliesTo(devEUI lorawan.EUI64) bool {
+	return r.Enabled && (r.DevEUI == nil || *r.DevEUI == devEUI)
+}
+
+// devEUIBytes returns the DevEUI as byte slice (nil when not set).
+func (r Rule) devEUIBytes() []byte {
+	if r.DevEUI == nil {
+		return nil
+	}
+	return r.DevEUI[:]
+}
+
+var (
+	rulesMux sync.RWMutex
+	rules    []Rule
+)
+
+// getRules returns the (cached) enabled rules.
+func getRules() []Rule {
+	rulesMux.RLock()
+	defer rulesMux.RUnlock()
+	return rules
+}
+
+func setRules(r []Rule) {
+	rulesMux.Lock()
+	defer rulesMux.Unlock()
+	rules = r
+}
+
+// LoadRules loads the enabled rules from the database.
+func LoadRules(db *sqlx.DB) error {
+	var r []Rule
+	err := db.Select(&r, "select * from uplink_rule where enabled = true order by id")
+	if err != nil {
+		return errors.Wrap(err, "select error")
+	}
+	setRules(r)
+	return nil
+}
+
+// RefreshRules periodically re-loads the rules from the database, so that
+// changes made through an other LoRa Server instance are picked up. This
+// function blocks.
+func RefreshRules(db *sqlx.DB, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := LoadRules(db); err != nil {
+			log.Errorf("load uplink rules error: %s", err)
+		}
+	}
+}
+
+// CreateRule validates and creates the given rule. On success, the ID and
+// timestamps of the rule are set.
+func CreateRule(db *sqlx.DB, r *Rule) error {
+	if err := r.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	err := db.Get(&r.ID, `
+		insert into uplink_rule (
+			name,
+			enabled,
+			dev_eui,
+			f_port,
+			max_dr,
+			consecutive_frames,
+			action,
+			data_rate,
+			tx_power,
+			mac_command,
+			class_c_window,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		returning id`,
+		r.Name,
+		r.Enabled,
+		r.devEUIBytes(),
+		r.FPort,
+		r.MaxDR,
+		r.ConsecutiveFrames,
+		r.Action,
+		r.DataRate,
+		r.TXPower,
+		r.MACCommand,
+		r.ClassCWindow,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+	r.CreatedAt = now
+	r.UpdatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":     r.ID,
+		"name":   r.Name,
+		"action": r.Action,
+	}).Info("uplink rule created")
+	return LoadRules(db)
+}
+
+// GetRule returns the rule for the given ID.
+func GetRule(db *sqlx.DB, id int64) (Rule, error) {
+	var r Rule
+	err := db.Get(&r, "select * from uplink_rule where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return r, ErrDoesNotExist
+		}
+		return r, errors.Wrap(err, "select error")
+	}
+	return r, nil
+}
+
+// UpdateRule validates and updates the given rule.
+func UpdateRule(db *sqlx.DB, r *Rule) error {
+	if err := r.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	res, err := db.Exec(`
+		update uplink_rule set
+			name = $2,
+			enabled = $3,
+			dev_eui = $4,
+			f_port = $5,
+			max_dr = $6,
+			consecutive_frames = $7,
+			action = $8,
+			data_rate = $9,
+			tx_power = $10,
+			mac_command = $11,
+			class_c_window = $12,
+			updated_at = $13
+		where id = $1`,
+		r.ID,
+		r.Name,
+		r.Enabled,
+		r.devEUIBytes(),
+		r.FPort,
+		r.MaxDR,
+		r.ConsecutiveFrames,
+		r.Action,
+		r.DataRate,
+		r.TXPower,
+		r.MACCommand,
+		r.ClassCWindow,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	r.UpdatedAt = now
+
+	log.WithField("id", r.ID).Info("uplink rule updated")
+	return LoadRules(db)
+}
+
+// DeleteRule deletes the rule for the given ID.
+func DeleteRule(db *sqlx.DB, id int64) error {
+	res, err := db.Exec("delete from uplink_rule where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("uplink rule deleted")
+	return LoadRules(db)
+}
+
+// GetRuleCount returns the number of rules.
+func GetRuleCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from uplink_rule")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetRules returns a slice of rules, ordered by ID and respecting the given
+// limit and offset.
+func GetRules(db *sqlx.DB, limit, offset int) ([]Rule, error) {
+	var r []Rule
+	err := db.Select(&r, "select * from uplink_rule order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return r, nil
+}
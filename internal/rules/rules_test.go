@@ -0,0 +1,143 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRuleValidate(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Rule          Rule
+			ExpectedError error
+		}{
+			{Rule{Name: "adr", Action: ActionEnqueueLinkADRReq, DataRate: 3, MaxDR: intPtr(1), ConsecutiveFrames: 10}, nil},
+			{Rule{Name: "class-c", Action: ActionOpenClassCWindow, ClassCWindow: 5 * time.Minute, FPort: intPtr(10)}, nil},
+			{Rule{Name: "mac", Action: ActionEnqueueMACCommand, MACCommand: []byte{0x80, 1}}, nil},
+			{Rule{Action: ActionOpenClassCWindow, ClassCWindow: time.Minute}, ErrInvalidName},
+			{Rule{Name: "test", Action: "REBOOT"}, ErrInvalidAction},
+			{Rule{Name: "test", Action: ActionEnqueueLinkADRReq, MaxDR: intPtr(16)}, ErrInvalidCondition},
+			{Rule{Name: "test", Action: ActionEnqueueLinkADRReq, FPort: intPtr(256)}, ErrInvalidCondition},
+			{Rule{Name: "test", Action: ActionEnqueueLinkADRReq, TXPower: 16}, ErrInvalidActionParameters},
+			{Rule{Name: "test", Action: ActionEnqueueMACCommand}, ErrInvalidActionParameters},
+			{Rule{Name: "test", Action: ActionEnqueueMACCommand, MACCommand: []byte{byte(lorawan.LinkADRReq)}}, maccommand.ErrHandledByNetworkServer},
+			{Rule{Name: "test", Action: ActionOpenClassCWindow}, ErrInvalidActionParameters},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				So(tst.Rule.Validate(), ShouldEqual, tst.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestRuleMatches(t *testing.T) {
+	Convey("Given a rule matching FPort 10 and DR <= 1", t, func() {
+		r := Rule{FPort: intPtr(10), MaxDR: intPtr(1)}
+		fPort10 := uint8(10)
+		fPort11 := uint8(11)
+
+		Convey("Then it matches the expected uplinks", func() {
+			So(r.matches(&fPort10, 0), ShouldBeTrue)
+			So(r.matches(&fPort10, 1), ShouldBeTrue)
+			So(r.matches(&fPort10, 2), ShouldBeFalse)
+			So(r.matches(&fPort11, 0), ShouldBeFalse)
+			So(r.matches(nil, 0), ShouldBeFalse)
+		})
+
+		Convey("Then a rule without conditions matches all uplinks", func() {
+			So(Rule{}.matches(nil, 5), ShouldBeTrue)
+		})
+	})
+}
+
+func TestRules(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+		defer setRules(nil)
+
+		Convey("When creating an invalid rule", func() {
+			err := CreateRule(db, &Rule{Name: "test"})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrInvalidAction)
+			})
+		})
+
+		Convey("When creating an enabled rule", func() {
+			devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+			r := Rule{
+				Name:              "low-dr",
+				Enabled:           true,
+				DevEUI:            &devEUI,
+				MaxDR:             intPtr(1),
+				ConsecutiveFrames: 10,
+				Action:            ActionEnqueueLinkADRReq,
+				DataRate:          3,
+			}
+			So(CreateRule(db, &r), ShouldBeNil)
+			So(r.ID, ShouldNotEqual, 0)
+
+			Convey("Then it can be retrieved", func() {
+				r2, err := GetRule(db, r.ID)
+				So(err, ShouldBeNil)
+				So(r2.Name, ShouldEqual, r.Name)
+				So(*r2.DevEUI, ShouldEqual, devEUI)
+				So(r2.FPort, ShouldBeNil)
+				So(*r2.MaxDR, ShouldEqual, 1)
+				So(r2.ConsecutiveFrames, ShouldEqual, 10)
+			})
+
+			Convey("Then it is loaded as enabled rule", func() {
+				So(getRules(), ShouldHaveLength, 1)
+			})
+
+			Convey("Then it is listed", func() {
+				count, err := GetRuleCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				items, err := GetRules(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+			})
+
+			Convey("When disabling the rule", func() {
+				r.Enabled = false
+				So(UpdateRule(db, &r), ShouldBeNil)
+
+				Convey("Then it is no longer loaded", func() {
+					So(getRules(), ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When deleting the rule", func() {
+				So(DeleteRule(db, r.ID), ShouldBeNil)
+
+				Convey("Then it does not exist anymore", func() {
+					_, err := GetRule(db, r.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+					So(DeleteRule(db, r.ID), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,261 @@
+// Package rx2mismatch implements the detection of nodes of which the RX2
+// parameters do not match the RX2 parameters of their node-session, e.g.
+// because the node still uses its factory-default RX2 data-rate.
+//
+// When the confirmed downlinks transmitted in RX2 (using the RX2 data-rate
+// of the node-session) are not acknowledged, the next RX2 downlinks are
+// transmitted using the default RX2 data-rate of the band. When these are
+// acknowledged, the node is flagged.
+package rx2mismatch
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+const (
+	// outcomesKeyTempl contains per node and RX2 data-rate the outcomes of
+	// the last confirmed RX2 downlinks (1 = acknowledged, 0 = not
+	// acknowledged, newest first).
+	outcomesKeyTempl = "lora:ns:node:rx2:outcomes:%s:%d"
+
+	// pendingKeyTempl contains per node the RX2 data-rate of the last
+	// confirmed RX2 downlink of which the outcome is not yet known.
+	pendingKeyTempl = "lora:ns:node:rx2:pending:%s"
+
+	// mismatchKey contains the flagged nodes (sorted set, scored by the
+	// detection timestamp).
+	mismatchKey = "lora:ns:rx2:mismatch"
+
+	// samples defines the number of outcomes kept per node and RX2
+	// data-rate.
+	samples = 10
+
+	// minSamples defines the minimum number of outcomes of an RX2
+	// data-rate before its success rate is taken into account.
+	minSamples = 3
+
+	// minSuccessRate defines the success rate below which the RX2
+	// data-rate is considered not to match the node.
+	minSuccessRate = 0.5
+
+	// TTL defines how long the outcomes of a node are kept after its last
+	// confirmed RX2 downlink.
+	TTL = time.Hour * 24 * 30
+)
+
+// Node contains a node flagged with mismatching RX2 parameters.
+type Node struct {
+	DevEUI     lorawan.EUI64
+	DetectedAt time.Time
+}
+
+// GetDataRate returns the RX2 data-rate to use for the downlink to the
+// given node. This is the RX2 data-rate of the node-session, unless the
+// downlinks using this data-rate are not acknowledged and the default RX2
+// data-rate of the band has not been proven to be worse.
+func GetDataRate(p *redis.Pool, ns session.NodeSession) (int, error) {
+	if !common.RX2MismatchDetection || int(ns.RX2DR) == common.Band.RX2DataRate {
+		return int(ns.RX2DR), nil
+	}
+
+	outcomes, err := getOutcomes(p, ns)
+	if err != nil {
+		return int(ns.RX2DR), err
+	}
+
+	return preferredDataRate(int(ns.RX2DR), common.Band.RX2DataRate, outcomes), nil
+}
+
+// preferredDataRate returns the preferred RX2 data-rate given the RX2
+// data-rate of the node-session, the default RX2 data-rate and the
+// outcomes per data-rate.
+func preferredDataRate(dr, defaultDR int, outcomes map[int][]bool) int {
+	rate, ok := successRate(outcomes[dr])
+	if !ok || rate >= minSuccessRate {
+		return dr
+	}
+
+	if defaultRate, ok := successRate(outcomes[defaultDR]); ok && defaultRate <= rate {
+		return dr
+	}
+	return defaultDR
+}
+
+// isMismatch returns true when the outcomes show that the node does not
+// receive the RX2 downlinks using the RX2 data-rate of the node-session,
+// but does receive the RX2 downlinks using the default RX2 data-rate.
+func isMismatch(dr, defaultDR int, outcomes map[int][]bool) bool {
+	rate, ok := successRate(outcomes[dr])
+	if !ok || rate >= minSuccessRate {
+		return false
+	}
+
+	defaultRate, ok := successRate(outcomes[defaultDR])
+	return ok && defaultRate >= minSuccessRate
+}
+
+// successRate returns the fraction of acknowledged outcomes. It returns
+// false when there are too few outcomes.
+func successRate(outcomes []bool) (float64, bool) {
+	if len(outcomes) < minSamples {
+		return 0, false
+	}
+
+	var acked int
+	for _, o := range outcomes {
+		if o {
+			acked++
+		}
+	}
+	return float64(acked) / float64(len(outcomes)), true
+}
+
+func getOutcomes(p *redis.Pool, ns session.NodeSession) (map[int][]bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	drs := []int{int(ns.RX2DR), common.Band.RX2DataRate}
+	for _, dr := range drs {
+		c.Send("LRANGE", fmt.Sprintf(outcomesKeyTempl, ns.DevEUI, dr), 0, -1)
+	}
+	if err := c.Flush(); err != nil {
+		return nil, errors.Wrap(err, "get rx2 outcomes error")
+	}
+
+	outcomes := make(map[int][]bool)
+	for _, dr := range drs {
+		values, err := redis.Ints(c.Receive())
+		if err != nil {
+			return nil, errors.Wrap(err, "get rx2 outcomes error")
+		}
+		for _, v := range values {
+			outcomes[dr] = append(outcomes[dr], v == 1)
+		}
+	}
+	return outcomes, nil
+}
+
+// SetPending stores the RX2 data-rate of the confirmed RX2 downlink sent to
+// the given node, so that its outcome can be recorded on the next uplink.
+func SetPending(p *redis.Pool, devEUI lorawan.EUI64, dr int) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(pendingKeyTempl, devEUI), int64(TTL/time.Millisecond), dr)
+	if err != nil {
+		return errors.Wrap(err, "set pending rx2 data-rate error")
+	}
+	return nil
+}
+
+// RecordOutcome records the outcome of the pending confirmed RX2 downlink
+// (if any) of the given node. It returns true when this outcome flags the
+// node with mismatching RX2 parameters (for the first time).
+func RecordOutcome(p *redis.Pool, ns session.NodeSession, acked bool) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	pendingKey := fmt.Sprintf(pendingKeyTempl, ns.DevEUI)
+
+	c.Send("MULTI")
+	c.Send("GET", pendingKey)
+	c.Send("DEL", pendingKey)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return false, errors.Wrap(err, "get pending rx2 data-rate error")
+	}
+
+	dr, err := redis.Int(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			// no confirmed rx2 downlink pending
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get pending rx2 data-rate error")
+	}
+
+	var outcome int
+	if acked {
+		outcome = 1
+	}
+
+	key := fmt.Sprintf(outcomesKeyTempl, ns.DevEUI, dr)
+
+	c.Send("MULTI")
+	c.Send("LPUSH", key, outcome)
+	c.Send("LTRIM", key, 0, samples-1)
+	c.Send("PEXPIRE", key, int64(TTL/time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return false, errors.Wrap(err, "record rx2 outcome error")
+	}
+
+	outcomes, err := getOutcomes(p, ns)
+	if err != nil {
+		return false, err
+	}
+	if !isMismatch(int(ns.RX2DR), common.Band.RX2DataRate, outcomes) {
+		return false, nil
+	}
+
+	added, err := redis.Int(c.Do("ZADD", mismatchKey, "NX", time.Now().Unix(), hex.EncodeToString(ns.DevEUI[:])))
+	if err != nil {
+		return false, errors.Wrap(err, "flag rx2 mismatch error")
+	}
+	return added == 1, nil
+}
+
+// Clear removes the flag and the outcomes of the given node, e.g. after the
+// node acknowledged the RX2 parameters of its node-session.
+func Clear(p *redis.Pool, ns session.NodeSession) error {
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("DEL",
+		fmt.Sprintf(outcomesKeyTempl, ns.DevEUI, ns.RX2DR),
+		fmt.Sprintf(outcomesKeyTempl, ns.DevEUI, common.Band.RX2DataRate),
+		fmt.Sprintf(pendingKeyTempl, ns.DevEUI),
+	)
+	c.Send("ZREM", mismatchKey, hex.EncodeToString(ns.DevEUI[:]))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "clear rx2 mismatch error")
+	}
+	return nil
+}
+
+// List returns the flagged nodes, most recently detected first.
+func List(p *redis.Pool) ([]Node, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Strings(c.Do("ZREVRANGE", mismatchKey, 0, -1, "WITHSCORES"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get rx2 mismatch nodes error")
+	}
+
+	var out []Node
+	for i := 0; i+1 < len(values); i += 2 {
+		var n Node
+		if err := n.DevEUI.UnmarshalText([]byte(values[i])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal deveui error")
+		}
+		ts, err := strconv.ParseInt(values[i+1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse detection timestamp error")
+		}
+		n.DetectedAt = time.Unix(ts, 0)
+		out = append(out, n)
+	}
+	return out, nil
+}
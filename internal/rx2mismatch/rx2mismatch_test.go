@@ -0,0 +1,113 @@
+package rx2mismatch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestPreferredDataRate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		acked := []bool{true, true, true}
+		lost := []bool{false, false, false}
+
+		tests := []struct {
+			Name       string
+			Outcomes   map[int][]bool
+			ExpectedDR int
+			Mismatch   bool
+		}{
+			{"no outcomes", nil, 3, false},
+			{"too few outcomes", map[int][]bool{3: {false, false}}, 3, false},
+			{"acknowledged", map[int][]bool{3: acked}, 3, false},
+			{"not acknowledged, default unknown", map[int][]bool{3: lost}, 0, false},
+			{"not acknowledged, default acknowledged", map[int][]bool{3: lost, 0: acked}, 0, true},
+			{"not acknowledged, default not acknowledged", map[int][]bool{3: lost, 0: lost}, 3, false},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(preferredDataRate(3, 0, test.Outcomes), ShouldEqual, test.ExpectedDR)
+				So(isMismatch(3, 0, test.Outcomes), ShouldEqual, test.Mismatch)
+			})
+		}
+	})
+}
+
+func TestRX2MismatchDetection(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and RX2 mismatch detection enabled", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.RX2MismatchDetection = true
+		defer func() {
+			common.RX2MismatchDetection = false
+		}()
+
+		ns := session.NodeSession{
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			RX2DR:  3,
+		}
+
+		record := func(dr int, acked bool) bool {
+			So(SetPending(p, ns.DevEUI, dr), ShouldBeNil)
+			detected, err := RecordOutcome(p, ns, acked)
+			So(err, ShouldBeNil)
+			return detected
+		}
+
+		Convey("Then the RX2 data-rate of the node-session is used", func() {
+			dr, err := GetDataRate(p, ns)
+			So(err, ShouldBeNil)
+			So(dr, ShouldEqual, 3)
+		})
+
+		Convey("When the RX2 downlinks using the node-session data-rate are not acknowledged", func() {
+			for i := 0; i < minSamples; i++ {
+				So(record(3, false), ShouldBeFalse)
+			}
+
+			Convey("Then the default RX2 data-rate is used", func() {
+				dr, err := GetDataRate(p, ns)
+				So(err, ShouldBeNil)
+				So(dr, ShouldEqual, common.Band.RX2DataRate)
+			})
+
+			Convey("When the RX2 downlinks using the default data-rate are acknowledged", func() {
+				So(record(common.Band.RX2DataRate, true), ShouldBeFalse)
+				So(record(common.Band.RX2DataRate, true), ShouldBeFalse)
+				So(record(common.Band.RX2DataRate, true), ShouldBeTrue)
+				So(record(common.Band.RX2DataRate, true), ShouldBeFalse)
+
+				Convey("Then the node is flagged", func() {
+					nodes, err := List(p)
+					So(err, ShouldBeNil)
+					So(nodes, ShouldHaveLength, 1)
+					So(nodes[0].DevEUI, ShouldEqual, ns.DevEUI)
+				})
+
+				Convey("When clearing the node", func() {
+					So(Clear(p, ns), ShouldBeNil)
+
+					Convey("Then the node is no longer flagged", func() {
+						nodes, err := List(p)
+						So(err, ShouldBeNil)
+						So(nodes, ShouldHaveLength, 0)
+
+						dr, err := GetDataRate(p, ns)
+						So(err, ShouldBeNil)
+						So(dr, ShouldEqual, 3)
+					})
+				})
+			})
+		})
+	})
+}
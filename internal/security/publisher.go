@@ -0,0 +1,66 @@
+package security
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/webhook"
+)
+
+// Publisher defines the interface for publishing the security events to an
+// external log or endpoint.
+type Publisher interface {
+	Publish(e Event) error
+}
+
+// WriterPublisher writes the security events as JSON lines to the given
+// writer (e.g. a dedicated log file).
+type WriterPublisher struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// NewWriterPublisher creates a new WriterPublisher.
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// Publish writes the given event.
+func (p *WriterPublisher) Publish(e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if _, err := p.w.Write(append(b, '\n')); err != nil {
+		return errors.Wrap(err, "write security event error")
+	}
+	return nil
+}
+
+// HTTPPublisher posts the security events as JSON (HTTP POST) to the given
+// URL (webhook).
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a new HTTPPublisher.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish posts the given event.
+func (p *HTTPPublisher) Publish(e Event) error {
+	return webhook.Post(p.Client, p.URL, e)
+}
@@ -0,0 +1,246 @@
+// Package security implements the security events emitted in strict
+// security mode (e.g. on MIC failures and frame-counter resets) and the
+// quarantining of devices triggering these events repeatedly.
+package security
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/digest"
+)
+
+const (
+	// eventLockKeyTempl contains per event type, node and MIC a lock, so
+	// that the copies of a frame received by multiple gateways result in
+	// a single event.
+	eventLockKeyTempl = "lora:ns:security:lock:%s:%s:%s"
+
+	// eventCountKeyTempl contains per node the number of events within
+	// the quarantine window.
+	eventCountKeyTempl = "lora:ns:security:events:%s"
+
+	// quarantineKeyTempl is set for quarantined nodes.
+	quarantineKeyTempl = "lora:ns:security:quarantine:%s"
+
+	// eventLockTTL defines how long the lock of an event is kept.
+	eventLockTTL = time.Minute
+)
+
+// EventType defines the type of a security event.
+type EventType string
+
+// Security event types.
+const (
+	MICFailure  EventType = "MIC_FAILURE"
+	FCntReset   EventType = "FCNT_RESET"
+	Quarantined EventType = "QUARANTINED"
+)
+
+// Event contains a security event.
+type Event struct {
+	Type       EventType
+	Time       time.Time
+	DevEUI     lorawan.EUI64
+	DevAddr    lorawan.DevAddr
+	FCnt       uint32 // the (16 bit) frame-counter of the frame
+	MIC        [4]byte
+	GatewayMAC lorawan.EUI64 // the gateway which received the frame
+	Reason     string
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Event) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Type       EventType `json:"type"`
+		Time       time.Time `json:"time"`
+		DevEUI     string    `json:"devEUI"`
+		DevAddr    string    `json:"devAddr"`
+		FCnt       uint32    `json:"fCnt"`
+		MIC        string    `json:"mic"`
+		GatewayMAC string    `json:"gatewayMAC"`
+		Reason     string    `json:"reason"`
+	}{
+		Type:       e.Type,
+		Time:       e.Time,
+		DevEUI:     e.DevEUI.String(),
+		DevAddr:    e.DevAddr.String(),
+		FCnt:       e.FCnt,
+		MIC:        hex.EncodeToString(e.MIC[:]),
+		GatewayMAC: e.GatewayMAC.String(),
+		Reason:     e.Reason,
+	}
+	return json.Marshal(out)
+}
+
+var (
+	publishersMu sync.RWMutex
+	publishers   []Publisher
+
+	quarantineThreshold int
+	quarantineWindow    time.Duration
+	quarantineDuration  time.Duration
+)
+
+// SetPublishers sets the publishers to which the security events are
+// published.
+func SetPublishers(p ...Publisher) {
+	publishersMu.Lock()
+	defer publishersMu.Unlock()
+	publishers = p
+}
+
+// SetQuarantine configures the quarantining of nodes. A node is quarantined
+// for the given duration (0 = until released) when it has triggered the
+// given number of events within the given window. Set the threshold to 0
+// to disable quarantining.
+func SetQuarantine(threshold int, window, duration time.Duration) {
+	quarantineThreshold = threshold
+	quarantineWindow = window
+	quarantineDuration = duration
+}
+
+// Emit emits the given security event. Copies of the same frame (e.g.
+// received by multiple gateways) result in a single event. When the node
+// exceeds the quarantine threshold, it is quarantined and a Quarantined
+// event is emitted.
+func Emit(p *redis.Pool, e Event) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", fmt.Sprintf(eventLockKeyTempl, e.Type, e.DevEUI, hex.EncodeToString(e.MIC[:])), "lock", "PX", int64(eventLockTTL/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			// the event has already been emitted
+			return nil
+		}
+		return errors.Wrap(err, "acquire security event lock error")
+	}
+
+	publish(e)
+
+	if quarantineThreshold <= 0 {
+		return nil
+	}
+
+	count, err := countEvent(c, e.DevEUI)
+	if err != nil {
+		return err
+	}
+	if count < quarantineThreshold {
+		return nil
+	}
+
+	if err := quarantine(c, e.DevEUI); err != nil {
+		return err
+	}
+
+	publish(Event{
+		Type:       Quarantined,
+		Time:       e.Time,
+		DevEUI:     e.DevEUI,
+		DevAddr:    e.DevAddr,
+		FCnt:       e.FCnt,
+		MIC:        e.MIC,
+		GatewayMAC: e.GatewayMAC,
+		Reason:     fmt.Sprintf("%d security events within %s", count, quarantineWindow),
+	})
+
+	if err := digest.Record(p, digest.DeviceQuarantined, e.DevEUI.String()); err != nil {
+		log.WithField("dev_eui", e.DevEUI).Errorf("record digest event error: %s", err)
+	}
+
+	return nil
+}
+
+// publish logs the given event and publishes it to the configured
+// publishers. Errors are logged.
+func publish(e Event) {
+	log.WithFields(log.Fields{
+		"type":    e.Type,
+		"dev_eui": e.DevEUI,
+		"fcnt":    e.FCnt,
+		"mac":     e.GatewayMAC,
+		"reason":  e.Reason,
+	}).Warning("security event")
+
+	publishersMu.RLock()
+	defer publishersMu.RUnlock()
+
+	for _, pub := range publishers {
+		if err := pub.Publish(e); err != nil {
+			log.WithField("type", e.Type).Errorf("publish security event error: %s", err)
+		}
+	}
+}
+
+// countEvent increments the number of events of the given node within the
+// quarantine window and returns the new count.
+func countEvent(c redis.Conn, devEUI lorawan.EUI64) (int, error) {
+	key := fmt.Sprintf(eventCountKeyTempl, devEUI)
+
+	count, err := redis.Int(c.Do("INCR", key))
+	if err != nil {
+		return 0, errors.Wrap(err, "increment security event count error")
+	}
+
+	// the window starts at the first event
+	if count == 1 {
+		if _, err := c.Do("PEXPIRE", key, int64(quarantineWindow/time.Millisecond)); err != nil {
+			return 0, errors.Wrap(err, "set security event count expire error")
+		}
+	}
+
+	return count, nil
+}
+
+// quarantine quarantines the given node and resets its event count.
+func quarantine(c redis.Conn, devEUI lorawan.EUI64) error {
+	key := fmt.Sprintf(quarantineKeyTempl, devEUI)
+
+	c.Send("MULTI")
+	if quarantineDuration > 0 {
+		c.Send("PSETEX", key, int64(quarantineDuration/time.Millisecond), time.Now().Unix())
+	} else {
+		c.Send("SET", key, time.Now().Unix())
+	}
+	c.Send("DEL", fmt.Sprintf(eventCountKeyTempl, devEUI))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "quarantine node error")
+	}
+	return nil
+}
+
+// IsQuarantined returns true when the given node is quarantined.
+func IsQuarantined(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	exists, err := redis.Bool(c.Do("EXISTS", fmt.Sprintf(quarantineKeyTempl, devEUI)))
+	if err != nil {
+		return false, errors.Wrap(err, "get quarantine error")
+	}
+	return exists, nil
+}
+
+// ReleaseQuarantine releases the given node from quarantine and resets its
+// event count.
+func ReleaseQuarantine(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", fmt.Sprintf(quarantineKeyTempl, devEUI), fmt.Sprintf(eventCountKeyTempl, devEUI))
+	if err != nil {
+		return errors.Wrap(err, "release quarantine error")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package security
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testPublisher struct {
+	events []Event
+}
+
+func (p *testPublisher) Publish(e Event) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestWriterPublisher(t *testing.T) {
+	Convey("Given a WriterPublisher", t, func() {
+		var buf bytes.Buffer
+		p := NewWriterPublisher(&buf)
+
+		Convey("When publishing an event", func() {
+			So(p.Publish(Event{
+				Type:       MICFailure,
+				Time:       time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+				DevEUI:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:       10,
+				MIC:        [4]byte{1, 2, 3, 4},
+				GatewayMAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			}), ShouldBeNil)
+
+			Convey("Then the event is written as json line", func() {
+				So(buf.String(), ShouldEqual, `{"type":"MIC_FAILURE","time":"2017-06-01T12:00:00Z","devEUI":"0102030405060708","devAddr":"01020304","fCnt":10,"mic":"01020304","gatewayMAC":"0807060504030201","reason":""}`+"\n")
+			})
+		})
+	})
+}
+
+func TestEmit(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a publisher", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		var pub testPublisher
+		SetPublishers(&pub)
+		SetQuarantine(2, time.Minute, time.Hour)
+		defer SetPublishers()
+		defer SetQuarantine(0, 0, 0)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		e := Event{
+			Type:   MICFailure,
+			DevEUI: devEUI,
+			MIC:    [4]byte{1, 2, 3, 4},
+		}
+
+		Convey("When emitting the same event twice", func() {
+			So(Emit(p, e), ShouldBeNil)
+			So(Emit(p, e), ShouldBeNil)
+
+			Convey("Then the event is published once", func() {
+				So(pub.events, ShouldHaveLength, 1)
+				So(pub.events[0].Type, ShouldEqual, MICFailure)
+			})
+
+			Convey("Then the node is not quarantined", func() {
+				quarantined, err := IsQuarantined(p, devEUI)
+				So(err, ShouldBeNil)
+				So(quarantined, ShouldBeFalse)
+			})
+
+			Convey("When emitting an other event exceeding the quarantine threshold", func() {
+				e.MIC = [4]byte{4, 3, 2, 1}
+				So(Emit(p, e), ShouldBeNil)
+
+				Convey("Then the event and a quarantined event are published", func() {
+					So(pub.events, ShouldHaveLength, 3)
+					So(pub.events[1].Type, ShouldEqual, MICFailure)
+					So(pub.events[2].Type, ShouldEqual, Quarantined)
+				})
+
+				Convey("Then the node is quarantined", func() {
+					quarantined, err := IsQuarantined(p, devEUI)
+					So(err, ShouldBeNil)
+					So(quarantined, ShouldBeTrue)
+				})
+
+				Convey("Then the node can be released from quarantine", func() {
+					So(ReleaseQuarantine(p, devEUI), ShouldBeNil)
+					quarantined, err := IsQuarantined(p, devEUI)
+					So(err, ShouldBeNil)
+					So(quarantined, ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
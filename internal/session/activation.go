@@ -0,0 +1,72 @@
+package session
+
+import (
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceActivationMACVersion defines the LoRaWAN MAC version reported in
+// the device activation of node-sessions without MAC version.
+const DeviceActivationMACVersion = DefaultMACVersion
+
+// DeviceActivation contains the activation parameters of a node. The JSON
+// field names and units follow the LoRaWAN Backend Interfaces specification
+// (e.g. frequencies in MHz), so that it can be imported by other
+// network-servers.
+type DeviceActivation struct {
+	DevEUI             lorawan.EUI64     `json:"DevEUI"`
+	AppEUI             lorawan.EUI64     `json:"JoinEUI"`
+	DevAddr            lorawan.DevAddr   `json:"DevAddr"`
+	MACVersion         string            `json:"MACVersion"`
+	NwkSKey            lorawan.AES128Key `json:"NwkSKey"`
+	FCntUp             uint32            `json:"FCntUp"`
+	FCntDown           uint32            `json:"FCntDown"`
+	RXDelay1           int               `json:"RXDelay1"` // in seconds
+	RX1DROffset        int               `json:"RX1DROffset"`
+	RX2DataRate        int               `json:"RX2DataRate"`
+	RX2Freq            float64           `json:"RX2Freq"`
+	FactoryPresetFreqs []float64         `json:"FactoryPresetFreqs"` // enabled uplink channels
+	SupportsClassC     bool              `json:"SupportsClassC"`
+}
+
+// GetDeviceActivation returns the activation parameters of the given
+// node-session. The enabled uplink channels are the default channels of the
+// band and the extra channels of the CFList (if any).
+func GetDeviceActivation(ns NodeSession) DeviceActivation {
+	da := DeviceActivation{
+		DevEUI:         ns.DevEUI,
+		AppEUI:         ns.AppEUI,
+		DevAddr:        ns.DevAddr,
+		MACVersion:     ns.GetMACVersion(),
+		NwkSKey:        ns.NwkSKey,
+		FCntUp:         ns.FCntUp,
+		FCntDown:       ns.FCntDown,
+		RXDelay1:       int(ns.RXDelay),
+		RX1DROffset:    int(ns.RX1DROffset),
+		RX2DataRate:    int(ns.RX2DR),
+		RX2Freq:        hzToMHz(ns.GetRX2Frequency()),
+		SupportsClassC: ns.DeviceClass == DeviceClassC,
+	}
+
+	// a RXDelay of 0 means 1 second
+	if da.RXDelay1 == 0 {
+		da.RXDelay1 = 1
+	}
+
+	for _, c := range common.Band.UplinkChannels {
+		da.FactoryPresetFreqs = append(da.FactoryPresetFreqs, hzToMHz(c.Frequency))
+	}
+	if ns.CFList != nil {
+		for _, f := range ns.CFList {
+			if f != 0 {
+				da.FactoryPresetFreqs = append(da.FactoryPresetFreqs, hzToMHz(int(f)))
+			}
+		}
+	}
+
+	return da
+}
+
+func hzToMHz(f int) float64 {
+	return float64(f) / 1000000
+}
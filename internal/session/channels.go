@@ -0,0 +1,189 @@
+package session
+
+import (
+	"sort"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// UplinkChannel contains an uplink channel as configured on the node.
+type UplinkChannel struct {
+	Index     int
+	Frequency int // Hz
+	MinDR     int
+	MaxDR     int
+	Enabled   bool
+}
+
+// IsFixedChannelPlanBand returns true when the band uses a fixed channel
+// plan of more than 16 uplink channels (e.g. US_902_928), of which the
+// channels are enabled using a block of LinkADRReq mac-commands (see
+// ApplyChMasks).
+func IsFixedChannelPlanBand() bool {
+	return common.BandName == band.US_902_928 || common.BandName == band.AU_915_928
+}
+
+// GetUplinkChannels returns the uplink channels of the node (sorted by
+// index). When these have not been tracked yet (no channel changes have
+// been acknowledged by the node), these are the channels of the band and
+// the extra channels of the CFList, all enabled.
+func (b NodeSession) GetUplinkChannels() []UplinkChannel {
+	if b.UplinkChannels != nil {
+		return append([]UplinkChannel(nil), b.UplinkChannels...)
+	}
+
+	out := DefaultUplinkChannels()
+	if b.CFList != nil && common.Band.ImplementsCFlist && len(out) > 0 {
+		for i, f := range b.CFList {
+			if f == 0 {
+				continue
+			}
+			out = append(out, UplinkChannel{
+				Index:     len(common.Band.UplinkChannels) + i,
+				Frequency: int(f),
+				MinDR:     out[0].MinDR,
+				MaxDR:     out[0].MaxDR,
+				Enabled:   true,
+			})
+		}
+	}
+
+	return out
+}
+
+// DefaultUplinkChannels returns the (default) uplink channels of the band,
+// all enabled.
+func DefaultUplinkChannels() []UplinkChannel {
+	var out []UplinkChannel
+	for i, c := range common.Band.UplinkChannels {
+		out = append(out, uplinkChannelFromBand(i, c))
+	}
+	return out
+}
+
+// GetEnabledUplinkChannels returns the indices of the enabled uplink
+// channels of the node.
+func (b NodeSession) GetEnabledUplinkChannels() []int {
+	var out []int
+	for _, c := range b.GetUplinkChannels() {
+		if c.Enabled {
+			out = append(out, c.Index)
+		}
+	}
+	return out
+}
+
+// SetChannelMask restricts the enabled uplink channels of the node to the
+// given channel indices. An empty slice removes the restriction. It returns
+// ErrInvalidChannelMask when one of the channels is not defined on the
+// node.
+func (b *NodeSession) SetChannelMask(channels []int) error {
+	if len(channels) == 0 {
+		b.ChannelMask = nil
+		return nil
+	}
+
+	defined := make(map[int]bool)
+	for _, c := range b.GetUplinkChannels() {
+		defined[c.Index] = true
+	}
+
+	var out []int
+	seen := make(map[int]bool)
+	for _, i := range channels {
+		if !defined[i] {
+			return ErrInvalidChannelMask
+		}
+		if !seen[i] {
+			out = append(out, i)
+			seen[i] = true
+		}
+	}
+
+	sort.Ints(out)
+	b.ChannelMask = out
+	return nil
+}
+
+// InChannelMask returns true when the given channel is part of the channel
+// mask of the node or when the node has no channel mask.
+func (b NodeSession) InChannelMask(i int) bool {
+	if b.ChannelMask == nil {
+		return true
+	}
+	for _, c := range b.ChannelMask {
+		if c == i {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUplinkChannel adds or replaces (by index) the given uplink channel,
+// after it has been acknowledged by the node (NewChannelAns). A channel
+// with frequency 0 is removed.
+func (b *NodeSession) SetUplinkChannel(c UplinkChannel) {
+	channels := b.GetUplinkChannels()
+
+	var out []UplinkChannel
+	for _, uc := range channels {
+		if uc.Index != c.Index {
+			out = append(out, uc)
+		}
+	}
+	if c.Frequency != 0 {
+		out = append(out, c)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Index < out[j].Index })
+	b.UplinkChannels = out
+}
+
+// ApplyChMasks applies the channel masks of the given (block of) LinkADRReq
+// payloads, after these have been acknowledged by the node (LinkADRAns).
+func (b *NodeSession) ApplyChMasks(payloads []lorawan.LinkADRReqPayload) {
+	channels := b.GetUplinkChannels()
+	fixed := IsFixedChannelPlanBand()
+
+	for _, pl := range payloads {
+		cntl := int(pl.Redundancy.ChMaskCntl)
+
+		for i := range channels {
+			idx := channels[i].Index
+
+			switch {
+			case fixed && (cntl == 6 || cntl == 7):
+				// all 125 kHz channels on (6) or off (7), the mask
+				// applies to the 500 kHz channels 64 - 71
+				if idx < 64 {
+					channels[i].Enabled = cntl == 6
+				} else if idx-64 < len(pl.ChMask) {
+					channels[i].Enabled = pl.ChMask[idx-64]
+				}
+			case cntl == 6:
+				// all defined channels on
+				channels[i].Enabled = true
+			case idx >= cntl*16 && idx < (cntl+1)*16:
+				channels[i].Enabled = pl.ChMask[idx-cntl*16]
+			}
+		}
+	}
+
+	b.UplinkChannels = channels
+}
+
+func uplinkChannelFromBand(i int, c band.Channel) UplinkChannel {
+	out := UplinkChannel{
+		Index:     i,
+		Frequency: c.Frequency,
+		Enabled:   true,
+	}
+	if len(c.DataRates) > 0 {
+		out.MinDR = c.DataRates[0]
+		out.MaxDR = c.DataRates[len(c.DataRates)-1]
+	}
+	return out
+}
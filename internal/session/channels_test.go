@@ -0,0 +1,91 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUplinkChannels(t *testing.T) {
+	Convey("Given a node-session with a CFList (EU band)", t, func() {
+		ns := NodeSession{
+			CFList: &lorawan.CFList{867100000, 867300000},
+		}
+
+		Convey("Then GetUplinkChannels returns the band and CFList channels", func() {
+			So(ns.GetUplinkChannels(), ShouldResemble, []UplinkChannel{
+				{Index: 0, Frequency: 868100000, MinDR: 0, MaxDR: 5, Enabled: true},
+				{Index: 1, Frequency: 868300000, MinDR: 0, MaxDR: 5, Enabled: true},
+				{Index: 2, Frequency: 868500000, MinDR: 0, MaxDR: 5, Enabled: true},
+				{Index: 3, Frequency: 867100000, MinDR: 0, MaxDR: 5, Enabled: true},
+				{Index: 4, Frequency: 867300000, MinDR: 0, MaxDR: 5, Enabled: true},
+			})
+		})
+
+		Convey("When removing channel 4 and modifying channel 3", func() {
+			ns.SetUplinkChannel(UplinkChannel{Index: 4})
+			ns.SetUplinkChannel(UplinkChannel{Index: 3, Frequency: 867500000, MinDR: 1, MaxDR: 4, Enabled: true})
+
+			Convey("Then the uplink channels are tracked", func() {
+				So(ns.GetEnabledUplinkChannels(), ShouldResemble, []int{0, 1, 2, 3})
+				So(ns.UplinkChannels[3], ShouldResemble, UplinkChannel{Index: 3, Frequency: 867500000, MinDR: 1, MaxDR: 4, Enabled: true})
+			})
+
+			Convey("When applying a channel mask disabling channel 1", func() {
+				ns.ApplyChMasks([]lorawan.LinkADRReqPayload{
+					{ChMask: lorawan.ChMask{true, false, true, true}},
+				})
+
+				Convey("Then channel 1 is disabled", func() {
+					So(ns.GetEnabledUplinkChannels(), ShouldResemble, []int{0, 2, 3})
+				})
+
+				Convey("When applying ChMaskCntl 6", func() {
+					ns.ApplyChMasks([]lorawan.LinkADRReqPayload{
+						{Redundancy: lorawan.Redundancy{ChMaskCntl: 6}},
+					})
+
+					Convey("Then all channels are enabled", func() {
+						So(ns.GetEnabledUplinkChannels(), ShouldResemble, []int{0, 1, 2, 3})
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestChannelMask(t *testing.T) {
+	Convey("Given a node-session with a CFList (EU band)", t, func() {
+		ns := NodeSession{
+			CFList: &lorawan.CFList{867100000},
+		}
+
+		Convey("Then all channels are in the (unset) channel mask", func() {
+			So(ns.InChannelMask(3), ShouldBeTrue)
+		})
+
+		Convey("When setting a channel mask", func() {
+			So(ns.SetChannelMask([]int{3, 0, 3}), ShouldBeNil)
+
+			Convey("Then the channel mask is sorted and deduplicated", func() {
+				So(ns.ChannelMask, ShouldResemble, []int{0, 3})
+				So(ns.InChannelMask(0), ShouldBeTrue)
+				So(ns.InChannelMask(1), ShouldBeFalse)
+			})
+
+			Convey("When setting an empty channel mask", func() {
+				So(ns.SetChannelMask(nil), ShouldBeNil)
+
+				Convey("Then the channel mask is removed", func() {
+					So(ns.ChannelMask, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("Then a channel mask with an undefined channel is rejected", func() {
+			So(ns.SetChannelMask([]int{0, 4}), ShouldEqual, ErrInvalidChannelMask)
+			So(ns.ChannelMask, ShouldBeNil)
+		})
+	})
+}
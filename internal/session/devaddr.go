@@ -0,0 +1,131 @@
+package session
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// maxNwkAddr defines the max. NwkAddr, the 25 LSB of the DevAddr.
+const maxNwkAddr = 1<<25 - 1
+
+// NwkAddrRange defines an (inclusive) range of NwkAddr values.
+type NwkAddrRange struct {
+	Start uint32
+	End   uint32
+}
+
+// size returns the number of addresses within the range.
+func (r NwkAddrRange) size() int64 {
+	return int64(r.End) - int64(r.Start) + 1
+}
+
+// nwkAddrRanges contains the NwkAddr ranges from which the DevAddr are
+// assigned. When empty, the full NwkAddr space is used.
+var nwkAddrRanges []NwkAddrRange
+
+// MustSetNwkAddrRanges sets the NwkAddr ranges from which GetRandomDevAddr
+// assigns the DevAddr. Each range is formatted as START-END (HEX encoded,
+// e.g. 0000000-0FFFFFF). This makes it possible to partition the DevAddr
+// space of a NetID across multiple LoRa Server clusters, by configuring
+// non-overlapping ranges for each cluster.
+func MustSetNwkAddrRanges(ranges []string) {
+	nwkAddrRanges = nil
+
+	for _, s := range ranges {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		r, err := parseNwkAddrRange(s)
+		if err != nil {
+			log.Fatalf("invalid NwkAddr range '%s': %s", s, err)
+		}
+		nwkAddrRanges = append(nwkAddrRanges, r)
+	}
+
+	sort.Slice(nwkAddrRanges, func(i, j int) bool { return nwkAddrRanges[i].Start < nwkAddrRanges[j].Start })
+	for i := 1; i < len(nwkAddrRanges); i++ {
+		if nwkAddrRanges[i].Start <= nwkAddrRanges[i-1].End {
+			log.Fatalf("NwkAddr ranges %07X-%07X and %07X-%07X overlap", nwkAddrRanges[i-1].Start, nwkAddrRanges[i-1].End, nwkAddrRanges[i].Start, nwkAddrRanges[i].End)
+		}
+	}
+}
+
+func parseNwkAddrRange(s string) (NwkAddrRange, error) {
+	var r NwkAddrRange
+
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return r, fmt.Errorf("expected START-END")
+	}
+
+	start, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return r, fmt.Errorf("parse start error: %s", err)
+	}
+	end, err := strconv.ParseUint(parts[1], 16, 32)
+	if err != nil {
+		return r, fmt.Errorf("parse end error: %s", err)
+	}
+
+	if start > end {
+		return r, fmt.Errorf("start must not be greater than end")
+	}
+	if end > maxNwkAddr {
+		return r, fmt.Errorf("end must not exceed %07X", maxNwkAddr)
+	}
+
+	r.Start = uint32(start)
+	r.End = uint32(end)
+	return r, nil
+}
+
+// getRandomNwkAddr returns a random NwkAddr within the configured ranges
+// (or the full NwkAddr space when no ranges are configured). Each address
+// has the same probability.
+func getRandomNwkAddr() (uint32, error) {
+	ranges := nwkAddrRanges
+	if len(ranges) == 0 {
+		ranges = []NwkAddrRange{{Start: 0, End: maxNwkAddr}}
+	}
+
+	var total int64
+	for _, r := range ranges {
+		total += r.size()
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(total))
+	if err != nil {
+		return 0, err
+	}
+
+	i := n.Int64()
+	for _, r := range ranges {
+		if i < r.size() {
+			return r.Start + uint32(i), nil
+		}
+		i -= r.size()
+	}
+
+	// this can't happen, as i < total
+	return 0, fmt.Errorf("random NwkAddr out of range")
+}
+
+// ValidateDevAddr validates that the NwkID (7 MSB) of the given DevAddr
+// matches the given NetID, e.g. for the DevAddr of an ABP activated node.
+func ValidateDevAddr(devAddr lorawan.DevAddr, netID lorawan.NetID) error {
+	if devAddr.NwkID() != netID.NwkID() {
+		return errors.Wrapf(ErrInvalidDevAddr, "NwkID %02X of DevAddr %s does not match NwkID %02X of NetID %s", devAddr.NwkID(), devAddr, netID.NwkID(), netID)
+	}
+	return nil
+}
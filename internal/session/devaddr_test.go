@@ -0,0 +1,83 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseNwkAddrRange(t *testing.T) {
+	Convey("Given a set of test ranges", t, func() {
+		testTable := []struct {
+			Range         string
+			ExpectedRange NwkAddrRange
+			ExpectedError bool
+		}{
+			{"0000000-0FFFFFF", NwkAddrRange{Start: 0, End: 0xFFFFFF}, false},
+			{"1000000-1FFFFFF", NwkAddrRange{Start: 0x1000000, End: 0x1FFFFFF}, false},
+			{"0000010-0000010", NwkAddrRange{Start: 0x10, End: 0x10}, false},
+			{"0000020-0000010", NwkAddrRange{}, true},
+			{"0000000-2000000", NwkAddrRange{}, true},
+			{"0000000", NwkAddrRange{}, true},
+			{"foo-bar", NwkAddrRange{}, true},
+		}
+
+		for _, tst := range testTable {
+			Convey("Testing: "+tst.Range, func() {
+				r, err := parseNwkAddrRange(tst.Range)
+				So(err != nil, ShouldEqual, tst.ExpectedError)
+				So(r, ShouldResemble, tst.ExpectedRange)
+			})
+		}
+	})
+}
+
+func TestGetRandomNwkAddr(t *testing.T) {
+	Convey("Given two configured NwkAddr ranges", t, func() {
+		MustSetNwkAddrRanges([]string{"1000000-100000F", " 0000010-000001F"})
+		defer MustSetNwkAddrRanges(nil)
+
+		Convey("Then the ranges are sorted", func() {
+			So(nwkAddrRanges, ShouldResemble, []NwkAddrRange{
+				{Start: 0x10, End: 0x1F},
+				{Start: 0x1000000, End: 0x100000F},
+			})
+		})
+
+		Convey("Then the random NwkAddr are always within these ranges", func() {
+			seen := make(map[int]bool)
+			for i := 0; i < 1000; i++ {
+				nwkAddr, err := getRandomNwkAddr()
+				So(err, ShouldBeNil)
+
+				switch {
+				case nwkAddr >= 0x10 && nwkAddr <= 0x1F:
+					seen[0] = true
+				case nwkAddr >= 0x1000000 && nwkAddr <= 0x100000F:
+					seen[1] = true
+				default:
+					t.Fatalf("NwkAddr %07X out of range", nwkAddr)
+				}
+			}
+			So(seen, ShouldHaveLength, 2)
+		})
+	})
+}
+
+func TestValidateDevAddr(t *testing.T) {
+	Convey("Given NetID 010203 (NwkID 03)", t, func() {
+		netID := lorawan.NetID{1, 2, 3}
+
+		Convey("Then a DevAddr with NwkID 03 is valid", func() {
+			So(ValidateDevAddr(lorawan.DevAddr{0x07, 0xff, 0xff, 0xff}, netID), ShouldBeNil)
+		})
+
+		Convey("Then a DevAddr with an other NwkID is invalid", func() {
+			err := ValidateDevAddr(lorawan.DevAddr{0x01, 0x02, 0x03, 0x04}, netID)
+			So(errors.Cause(err), ShouldEqual, ErrInvalidDevAddr)
+		})
+	})
+}
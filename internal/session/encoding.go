@@ -0,0 +1,328 @@
+package session
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session/pb"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Node-sessions are stored as a header followed by the encoded
+// node-session. The header consists of a zero byte (a gob stream never
+// starts with a zero byte, so that gob encoded node-sessions stored by
+// previous versions can still be decoded) and the encoding version.
+const (
+	encodingHeader   byte = 0x00
+	encodingProtobuf byte = 0x01
+)
+
+// marshalNodeSession encodes the given node-session for storage.
+func marshalNodeSession(ns NodeSession) ([]byte, error) {
+	b, err := proto.Marshal(nodeSessionToPB(ns))
+	if err != nil {
+		return nil, errors.Wrap(err, "protobuf marshal error")
+	}
+	return append([]byte{encodingHeader, encodingProtobuf}, b...), nil
+}
+
+// unmarshalNodeSession decodes the given stored node-session.
+func unmarshalNodeSession(b []byte) (NodeSession, error) {
+	var ns NodeSession
+
+	if len(b) < 2 || b[0] != encodingHeader {
+		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&ns); err != nil {
+			return ns, errors.Wrap(err, "gob decode error")
+		}
+		return ns, nil
+	}
+
+	switch b[1] {
+	case encodingProtobuf:
+		var nsPB pb.NodeSession
+		if err := proto.Unmarshal(b[2:], &nsPB); err != nil {
+			return ns, errors.Wrap(err, "protobuf unmarshal error")
+		}
+		return nodeSessionFromPB(nsPB)
+	default:
+		return ns, errors.Errorf("unknown encoding version: %d", b[1])
+	}
+}
+
+func nodeSessionToPB(ns NodeSession) *pb.NodeSession {
+	out := pb.NodeSession{
+		DevAddr:                 ns.DevAddr[:],
+		AppEUI:                  ns.AppEUI[:],
+		DevEUI:                  ns.DevEUI[:],
+		NwkSKey:                 ns.NwkSKey[:],
+		FCntUp:                  ns.FCntUp,
+		FCntDown:                ns.FCntDown,
+		RelaxFCnt:               ns.RelaxFCnt,
+		RxWindow:                uint32(ns.RXWindow),
+		RxDelay:                 uint32(ns.RXDelay),
+		Rx1DROffset:             uint32(ns.RX1DROffset),
+		Rx2DR:                   uint32(ns.RX2DR),
+		AdrInterval:             ns.ADRInterval,
+		InstallationMargin:      ns.InstallationMargin,
+		AdrStrategy:             uint32(ns.ADRStrategy),
+		Relay:                   ns.Relay,
+		TxPower:                 int32(ns.TXPower),
+		NbTrans:                 uint32(ns.NbTrans),
+		DeviceClass:             int32(ns.DeviceClass),
+		PendingDeviceClass:      int32(ns.PendingDeviceClass),
+		DeviceClassChangedAt:    timeToBytes(ns.DeviceClassChangedAt),
+		GatewayRegions:          ns.GatewayRegions,
+		DownlinkTXPower:         int32(ns.DownlinkTXParams.Power),
+		DownlinkCodeRate:        ns.DownlinkTXParams.CodeRate,
+		DownlinkReference:       ns.DownlinkReference,
+		BatteryThrottleLevel:    uint32(ns.BatteryThrottleLevel),
+		BatteryLevel:            uint32(ns.BatteryLevel),
+		BatteryLevelUpdatedAt:   timeToBytes(ns.BatteryLevelUpdatedAt),
+		ClassCFPort:             uint32(ns.ClassCFPort),
+		ClassCWindow:            int64(ns.ClassCWindow),
+		ClassCUntil:             timeToBytes(ns.ClassCUntil),
+		LastUplinkAt:            timeToBytes(ns.LastUplinkAt),
+		TransmitDiversity:       ns.TransmitDiversity,
+		DailyDownlinkAirtimeCap: int64(ns.DailyDownlinkAirtimeCap),
+		Version:                 ns.Version,
+		Rx2Frequency:            uint32(ns.RX2Frequency),
+		Tags:                    ns.Tags,
+		MacVersion:              ns.MACVersion,
+		Geolocation:             ns.Geolocation,
+		ChannelConfigurationID:  ns.ChannelConfigurationID,
+		ForwardPHYPayload:       ns.ForwardPHYPayload,
+		MaxUplinksPerHour:       ns.MaxUplinksPerHour,
+		LegacyADRACKReq:         ns.LegacyADRACKReq,
+		DeviceProfileID:         ns.DeviceProfileID,
+	}
+
+	if ns.AppSKey != nil {
+		out.AppSKey = ns.AppSKey[:]
+	}
+
+	if ns.DownlinkTXParams.IPol != nil {
+		out.DownlinkIPol = 1
+		if *ns.DownlinkTXParams.IPol {
+			out.DownlinkIPol = 2
+		}
+	}
+
+	for _, h := range ns.UplinkHistory {
+		out.UplinkHistory = append(out.UplinkHistory, &pb.UplinkHistory{
+			FCnt:         h.FCnt,
+			MaxSNR:       h.MaxSNR,
+			MaxRSSI:      int32(h.MaxRSSI),
+			GatewayCount: uint32(h.GatewayCount),
+
+			FrequencyOffset: int32(h.FrequencyOffset),
+		})
+	}
+
+	if ns.CFList != nil {
+		out.CFList = ns.CFList[:]
+	}
+
+	for _, c := range ns.UplinkChannels {
+		out.UplinkChannels = append(out.UplinkChannels, &pb.UplinkChannel{
+			Index:     uint32(c.Index),
+			Frequency: uint32(c.Frequency),
+			MinDR:     uint32(c.MinDR),
+			MaxDR:     uint32(c.MaxDR),
+			Enabled:   c.Enabled,
+		})
+	}
+
+	for _, i := range ns.ChannelMask {
+		out.ChannelMask = append(out.ChannelMask, uint32(i))
+	}
+
+	for i := range ns.LastRXInfoSet {
+		rxInfo := ns.LastRXInfoSet[i]
+		out.LastRXInfoSet = append(out.LastRXInfoSet, &pb.RXInfo{
+			Mac:          rxInfo.MAC[:],
+			Time:         timeToBytes(rxInfo.Time),
+			Timestamp:    rxInfo.Timestamp,
+			Frequency:    uint32(rxInfo.Frequency),
+			Channel:      uint32(rxInfo.Channel),
+			RfChain:      uint32(rxInfo.RFChain),
+			Antenna:      uint32(rxInfo.Antenna),
+			CrcStatus:    int32(rxInfo.CRCStatus),
+			CodeRate:     rxInfo.CodeRate,
+			Rssi:         int32(rxInfo.RSSI),
+			LoRaSNR:      rxInfo.LoRaSNR,
+			Size:         uint32(rxInfo.Size),
+			Modulation:   string(rxInfo.DataRate.Modulation),
+			SpreadFactor: uint32(rxInfo.DataRate.SpreadFactor),
+			Bandwidth:    uint32(rxInfo.DataRate.Bandwidth),
+			BitRate:      uint32(rxInfo.DataRate.BitRate),
+
+			FrequencyOffset: int32(rxInfo.FrequencyOffset),
+		})
+	}
+
+	return &out
+}
+
+func nodeSessionFromPB(in pb.NodeSession) (NodeSession, error) {
+	var err error
+	out := NodeSession{
+		FCntUp:                  in.FCntUp,
+		FCntDown:                in.FCntDown,
+		RelaxFCnt:               in.RelaxFCnt,
+		RXWindow:                RXWindow(in.RxWindow),
+		RXDelay:                 uint8(in.RxDelay),
+		RX1DROffset:             uint8(in.Rx1DROffset),
+		RX2DR:                   uint8(in.Rx2DR),
+		ADRInterval:             in.AdrInterval,
+		InstallationMargin:      in.InstallationMargin,
+		ADRStrategy:             ADRStrategy(in.AdrStrategy),
+		Relay:                   in.Relay,
+		TXPower:                 int(in.TxPower),
+		NbTrans:                 uint8(in.NbTrans),
+		DeviceClass:             DeviceClass(in.DeviceClass),
+		PendingDeviceClass:      DeviceClass(in.PendingDeviceClass),
+		GatewayRegions:          in.GatewayRegions,
+		DownlinkReference:       in.DownlinkReference,
+		BatteryThrottleLevel:    uint8(in.BatteryThrottleLevel),
+		BatteryLevel:            uint8(in.BatteryLevel),
+		ClassCFPort:             uint8(in.ClassCFPort),
+		ClassCWindow:            time.Duration(in.ClassCWindow),
+		TransmitDiversity:       in.TransmitDiversity,
+		DailyDownlinkAirtimeCap: time.Duration(in.DailyDownlinkAirtimeCap),
+		Version:                 in.Version,
+		RX2Frequency:            int(in.Rx2Frequency),
+		Tags:                    in.Tags,
+		MACVersion:              in.MacVersion,
+		Geolocation:             in.Geolocation,
+		ChannelConfigurationID:  in.ChannelConfigurationID,
+		ForwardPHYPayload:       in.ForwardPHYPayload,
+		MaxUplinksPerHour:       in.MaxUplinksPerHour,
+		LegacyADRACKReq:         in.LegacyADRACKReq,
+		DeviceProfileID:         in.DeviceProfileID,
+		DownlinkTXParams: models.TXParams{
+			Power:    int(in.DownlinkTXPower),
+			CodeRate: in.DownlinkCodeRate,
+		},
+	}
+
+	copy(out.DevAddr[:], in.DevAddr)
+	copy(out.AppEUI[:], in.AppEUI)
+	copy(out.DevEUI[:], in.DevEUI)
+	copy(out.NwkSKey[:], in.NwkSKey)
+
+	if len(in.AppSKey) != 0 {
+		var key lorawan.AES128Key
+		copy(key[:], in.AppSKey)
+		out.AppSKey = &key
+	}
+
+	if in.DownlinkIPol != 0 {
+		iPol := in.DownlinkIPol == 2
+		out.DownlinkTXParams.IPol = &iPol
+	}
+
+	for _, t := range []struct {
+		b []byte
+		t *time.Time
+	}{
+		{in.DeviceClassChangedAt, &out.DeviceClassChangedAt},
+		{in.BatteryLevelUpdatedAt, &out.BatteryLevelUpdatedAt},
+		{in.ClassCUntil, &out.ClassCUntil},
+		{in.LastUplinkAt, &out.LastUplinkAt},
+	} {
+		if *t.t, err = timeFromBytes(t.b); err != nil {
+			return out, err
+		}
+	}
+
+	for _, h := range in.UplinkHistory {
+		out.UplinkHistory = append(out.UplinkHistory, UplinkHistory{
+			FCnt:         h.FCnt,
+			MaxSNR:       h.MaxSNR,
+			MaxRSSI:      int(h.MaxRSSI),
+			GatewayCount: int(h.GatewayCount),
+
+			FrequencyOffset: int(h.FrequencyOffset),
+		})
+	}
+
+	if len(in.CFList) != 0 {
+		var cFList lorawan.CFList
+		copy(cFList[:], in.CFList)
+		out.CFList = &cFList
+	}
+
+	for _, c := range in.UplinkChannels {
+		out.UplinkChannels = append(out.UplinkChannels, UplinkChannel{
+			Index:     int(c.Index),
+			Frequency: int(c.Frequency),
+			MinDR:     int(c.MinDR),
+			MaxDR:     int(c.MaxDR),
+			Enabled:   c.Enabled,
+		})
+	}
+
+	for _, i := range in.ChannelMask {
+		out.ChannelMask = append(out.ChannelMask, int(i))
+	}
+
+	for _, rxInfo := range in.LastRXInfoSet {
+		r := gw.RXInfo{
+			Timestamp: rxInfo.Timestamp,
+			Frequency: int(rxInfo.Frequency),
+			Channel:   int(rxInfo.Channel),
+			RFChain:   int(rxInfo.RfChain),
+			Antenna:   int(rxInfo.Antenna),
+			CRCStatus: int(rxInfo.CrcStatus),
+			CodeRate:  rxInfo.CodeRate,
+			RSSI:      int(rxInfo.Rssi),
+			LoRaSNR:   rxInfo.LoRaSNR,
+			Size:      int(rxInfo.Size),
+			DataRate: band.DataRate{
+				Modulation:   band.Modulation(rxInfo.Modulation),
+				SpreadFactor: int(rxInfo.SpreadFactor),
+				Bandwidth:    int(rxInfo.Bandwidth),
+				BitRate:      int(rxInfo.BitRate),
+			},
+
+			FrequencyOffset: int(rxInfo.FrequencyOffset),
+		}
+		copy(r.MAC[:], rxInfo.Mac)
+		if r.Time, err = timeFromBytes(rxInfo.Time); err != nil {
+			return out, err
+		}
+		out.LastRXInfoSet = append(out.LastRXInfoSet, r)
+	}
+
+	return out, nil
+}
+
+// timeToBytes returns the binary encoding of the given time, or nil when
+// the time is not set.
+func timeToBytes(t time.Time) []byte {
+	if t.IsZero() {
+		return nil
+	}
+	// MarshalBinary only fails for invalid timezone offsets
+	b, _ := t.MarshalBinary()
+	return b
+}
+
+// timeFromBytes decodes the given binary encoded time (see timeToBytes).
+func timeFromBytes(b []byte) (time.Time, error) {
+	var t time.Time
+	if len(b) == 0 {
+		return t, nil
+	}
+	if err := t.UnmarshalBinary(b); err != nil {
+		return t, errors.Wrap(err, "unmarshal time error")
+	}
+	return t, nil
+}
@@ -0,0 +1,214 @@
+package session
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func getEncodingTestNodeSession() NodeSession {
+	now := time.Now().Round(0) // strip the monotonic clock reading
+	appSKey := lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	iPol := false
+
+	ns := NodeSession{
+		DevAddr:              lorawan.DevAddr{1, 2, 3, 4},
+		AppEUI:               lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+		DevEUI:               lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		NwkSKey:              lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		AppSKey:              &appSKey,
+		FCntUp:               10,
+		FCntDown:             11,
+		RelaxFCnt:            true,
+		RXWindow:             RX2,
+		RXDelay:              2,
+		RX1DROffset:          1,
+		RX2DR:                3,
+		RX2Frequency:         869525000,
+		Tags:                 models.Tags{"customer": "acme"},
+		MACVersion:           MACVersion101,
+		Geolocation:          true,
+		ADRInterval:          20,
+		InstallationMargin:   5,
+		ADRStrategy:          ADRMinimizeTXPower,
+		Relay:                true,
+		TXPower:              14,
+		NbTrans:              2,
+		DeviceClass:          DeviceClassA,
+		PendingDeviceClass:   DeviceClassC,
+		DeviceClassChangedAt: now.Add(-time.Minute),
+		GatewayRegions:       []string{"eu", "nl"},
+		DownlinkTXParams: models.TXParams{
+			Power:    20,
+			CodeRate: "4/6",
+			IPol:     &iPol,
+		},
+		DownlinkReference:       "abc",
+		BatteryThrottleLevel:    50,
+		BatteryLevel:            40,
+		BatteryLevelUpdatedAt:   now.UTC(),
+		ClassCFPort:             20,
+		ClassCWindow:            time.Minute,
+		ClassCUntil:             now.Add(time.Minute),
+		TransmitDiversity:       true,
+		DailyDownlinkAirtimeCap: time.Minute,
+		UplinkHistory: []UplinkHistory{
+			{FCnt: 8, MaxSNR: 5.5, MaxRSSI: -80, GatewayCount: 2, FrequencyOffset: -1200},
+			{FCnt: 9, MaxSNR: -2, MaxRSSI: -115, GatewayCount: 1},
+		},
+		CFList:                 &lorawan.CFList{867100000, 867300000, 867500000, 0, 0},
+		ChannelConfigurationID: 3,
+		ForwardPHYPayload:      true,
+		MaxUplinksPerHour:      60,
+		LegacyADRACKReq:        true,
+		ChannelMask:            []int{0, 2},
+		DeviceProfileID:        4,
+		UplinkChannels: []UplinkChannel{
+			{Index: 0, Frequency: 868100000, MinDR: 0, MaxDR: 5, Enabled: true},
+			{Index: 3, Frequency: 867100000, MinDR: 0, MaxDR: 5},
+		},
+		LastUplinkAt: now,
+		Version:      7,
+	}
+
+	for i := 0; i < 3; i++ {
+		ns.LastRXInfoSet = append(ns.LastRXInfoSet, gw.RXInfo{
+			MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, byte(i)},
+			Time:      now,
+			Timestamp: 12345,
+			Frequency: 868100000,
+			Channel:   1,
+			RFChain:   1,
+			Antenna:   i,
+			CRCStatus: 1,
+			CodeRate:  "4/5",
+			RSSI:      -60 - i,
+			LoRaSNR:   5.5,
+			Size:      23,
+			DataRate: band.DataRate{
+				Modulation:   band.LoRaModulation,
+				SpreadFactor: 7,
+				Bandwidth:    125,
+			},
+			FrequencyOffset: 350 - 100*i,
+		})
+	}
+
+	return ns
+}
+
+func TestNodeSessionEncoding(t *testing.T) {
+	Convey("Given a node-session with all fields set", t, func() {
+		ns := getEncodingTestNodeSession()
+
+		Convey("When encoding and decoding it", func() {
+			b, err := marshalNodeSession(ns)
+			So(err, ShouldBeNil)
+			So(b[0], ShouldEqual, encodingHeader)
+			So(b[1], ShouldEqual, encodingProtobuf)
+
+			decoded, err := unmarshalNodeSession(b)
+			So(err, ShouldBeNil)
+
+			Convey("Then the decoded node-session equals the original", func() {
+				So(decoded, ShouldResemble, ns)
+			})
+
+			Convey("Then the encoded node-session is smaller than the gob encoding", func() {
+				var buf bytes.Buffer
+				So(gob.NewEncoder(&buf).Encode(ns), ShouldBeNil)
+				So(len(b), ShouldBeLessThan, buf.Len())
+			})
+		})
+
+		Convey("Given a node-session without optional fields", func() {
+			ns := NodeSession{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}
+
+			Convey("Then it is decoded without optional fields", func() {
+				b, err := marshalNodeSession(ns)
+				So(err, ShouldBeNil)
+				decoded, err := unmarshalNodeSession(b)
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, ns)
+			})
+		})
+
+		Convey("Given the node-session is gob encoded (stored by a previous version)", func() {
+			var buf bytes.Buffer
+			So(gob.NewEncoder(&buf).Encode(ns), ShouldBeNil)
+
+			Convey("Then it can be decoded", func() {
+				decoded, err := unmarshalNodeSession(buf.Bytes())
+				So(err, ShouldBeNil)
+				So(decoded.DevEUI, ShouldEqual, ns.DevEUI)
+				So(decoded.FCntUp, ShouldEqual, ns.FCntUp)
+				So(decoded.LastRXInfoSet, ShouldHaveLength, len(ns.LastRXInfoSet))
+			})
+		})
+
+		Convey("Given an unknown encoding version", func() {
+			Convey("Then decoding returns an error", func() {
+				_, err := unmarshalNodeSession([]byte{encodingHeader, 0xff})
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func BenchmarkMarshalNodeSessionGob(b *testing.B) {
+	ns := getEncodingTestNodeSession()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(ns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalNodeSessionProtobuf(b *testing.B) {
+	ns := getEncodingTestNodeSession()
+	for i := 0; i < b.N; i++ {
+		if _, err := marshalNodeSession(ns); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalNodeSessionGob(b *testing.B) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(getEncodingTestNodeSession()); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := unmarshalNodeSession(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalNodeSessionProtobuf(b *testing.B) {
+	data, err := marshalNodeSession(getEncodingTestNodeSession())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := unmarshalNodeSession(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -6,4 +6,15 @@ import "errors"
 var (
 	ErrDoesNotExistOrFCntOrMICInvalid = errors.New("node-session does not exist or invalid fcnt or mic")
 	ErrDoesNotExist                   = errors.New("node-session does not exist")
+	ErrAlreadyExists                  = errors.New("node-session already exists")
+	ErrConcurrentUpdate               = errors.New("node-session has been updated concurrently")
+	ErrAppSKeyKEKNotConfigured        = errors.New("wrapped AppSKey given but no AppSKey KEK configured")
+	ErrInvalidWrappedAppSKey          = errors.New("invalid wrapped AppSKey")
+	ErrInvalidPatch                   = errors.New("patch must not change the DevEUI or DevAddr")
+	ErrInvalidDeviceClass             = errors.New("invalid device class")
+	ErrInvalidRX2Frequency            = errors.New("invalid rx2 frequency")
+	ErrInvalidMACVersion              = errors.New("invalid mac version")
+	ErrMACCommandNotSupported         = errors.New("mac-command is not supported by the mac version of the node")
+	ErrInvalidDevAddr                 = errors.New("invalid DevAddr")
+	ErrInvalidChannelMask             = errors.New("channel mask contains channels which are not defined on the node")
 )
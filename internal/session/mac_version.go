@@ -0,0 +1,117 @@
+package session
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// LoRaWAN MAC versions.
+const (
+	MACVersion100 = "1.0.0"
+	MACVersion101 = "1.0.1"
+	MACVersion102 = "1.0.2"
+	MACVersion103 = "1.0.3"
+	MACVersion11  = "1.1"
+
+	// DefaultMACVersion defines the MAC version of node-sessions without
+	// MAC version.
+	DefaultMACVersion = MACVersion102
+)
+
+// MACCapabilities contains the capabilities of a LoRaWAN MAC version.
+type MACCapabilities struct {
+	// MACCommands contains the downlink mac-commands which can be parsed by
+	// a node implementing the MAC version.
+	MACCommands map[lorawan.CID]struct{}
+
+	// SeparateFCntDown defines if separate network and application
+	// downlink frame-counters are used (NFCntDown and AFCntDown).
+	SeparateFCntDown bool
+
+	// FOptsEncrypted defines if the FOpts mac-commands are encrypted.
+	FOptsEncrypted bool
+}
+
+// Supported returns true when the MAC version can be handled by LoRa
+// Server, which implements a single downlink frame-counter and unencrypted
+// FOpts.
+func (c MACCapabilities) Supported() bool {
+	return !c.SeparateFCntDown && !c.FOptsEncrypted
+}
+
+// SupportsMACCommand returns true when the given downlink mac-command can
+// be parsed by the node.
+func (c MACCapabilities) SupportsMACCommand(cid lorawan.CID) bool {
+	_, ok := c.MACCommands[cid]
+	return ok
+}
+
+// macCommands returns the set of the given mac-commands.
+func macCommands(cids ...lorawan.CID) map[lorawan.CID]struct{} {
+	out := make(map[lorawan.CID]struct{})
+	for _, cid := range cids {
+		out[cid] = struct{}{}
+	}
+	return out
+}
+
+var (
+	// macCommands10 contains the mac-commands of LoRaWAN 1.0 and 1.0.1.
+	macCommands10 = []lorawan.CID{
+		lorawan.LinkCheckAns,
+		lorawan.LinkADRReq,
+		lorawan.DutyCycleReq,
+		lorawan.RXParamSetupReq,
+		lorawan.DevStatusReq,
+		lorawan.NewChannelReq,
+		lorawan.RXTimingSetupReq,
+	}
+
+	// macCommands102 contains the mac-commands of LoRaWAN 1.0.2, which
+	// added the regional TXParamSetupReq and DLChannelReq mac-commands.
+	macCommands102 = append(append([]lorawan.CID{}, macCommands10...),
+		lorawan.TXParamSetupReq,
+		lorawan.DLChannelReq,
+	)
+)
+
+// macCapabilities contains the capability matrix per MAC version.
+var macCapabilities = map[string]MACCapabilities{
+	MACVersion100: {MACCommands: macCommands(macCommands10...)},
+	MACVersion101: {MACCommands: macCommands(macCommands10...)},
+	MACVersion102: {MACCommands: macCommands(macCommands102...)},
+	MACVersion103: {MACCommands: macCommands(macCommands102...)},
+	MACVersion11: {
+		MACCommands:      macCommands(macCommands102...),
+		SeparateFCntDown: true,
+		FOptsEncrypted:   true,
+	},
+}
+
+// GetMACCapabilities returns the capabilities of the given MAC version.
+func GetMACCapabilities(version string) (MACCapabilities, error) {
+	c, ok := macCapabilities[version]
+	if !ok {
+		return c, errors.Wrapf(ErrInvalidMACVersion, "unknown mac version: %s", version)
+	}
+	return c, nil
+}
+
+// ValidateMACVersion validates the given MAC version ("" = the default
+// MAC version). The MAC version must be known and supported by LoRa
+// Server.
+func ValidateMACVersion(version string) error {
+	if version == "" {
+		return nil
+	}
+
+	c, err := GetMACCapabilities(version)
+	if err != nil {
+		return err
+	}
+	if !c.Supported() {
+		return errors.Wrapf(ErrInvalidMACVersion, "mac version %s is not supported", version)
+	}
+	return nil
+}
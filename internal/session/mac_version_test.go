@@ -0,0 +1,55 @@
+package session
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateMACVersion(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			MACVersion    string
+			ExpectedError bool
+		}{
+			{"", false},
+			{MACVersion100, false},
+			{MACVersion101, false},
+			{MACVersion102, false},
+			{MACVersion103, false},
+			{MACVersion11, true},
+			{"2.0", true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.MACVersion, i), func() {
+				err := ValidateMACVersion(test.MACVersion)
+				So(err != nil, ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestMACCapabilities(t *testing.T) {
+	Convey("Given a node-session without MAC version", t, func() {
+		ns := NodeSession{}
+
+		Convey("Then the default MAC version is used", func() {
+			So(ns.GetMACVersion(), ShouldEqual, DefaultMACVersion)
+			So(ns.GetMACCapabilities().SupportsMACCommand(lorawan.DLChannelReq), ShouldBeTrue)
+		})
+
+		Convey("When setting the MAC version to 1.0.1", func() {
+			ns.MACVersion = MACVersion101
+
+			Convey("Then the LoRaWAN 1.0.2 mac-commands are not supported", func() {
+				c := ns.GetMACCapabilities()
+				So(c.SupportsMACCommand(lorawan.LinkADRReq), ShouldBeTrue)
+				So(c.SupportsMACCommand(lorawan.TXParamSetupReq), ShouldBeFalse)
+				So(c.SupportsMACCommand(lorawan.DLChannelReq), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -1,7 +1,11 @@
 package session
 
 import (
+	"time"
+
 	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
 	"github.com/brocaar/lorawan"
 )
 
@@ -14,11 +18,35 @@ const (
 	RX2
 )
 
+// ADRStrategy defines the ADR strategy.
+type ADRStrategy int8
+
+// Available ADR strategies.
+const (
+	ADRMaximizeDataRate = iota // increase the data-rate first, then decrease the tx-power
+	ADRMinimizeTXPower         // decrease the tx-power first, then increase the data-rate
+)
+
+// DeviceClass defines the device class of a node.
+type DeviceClass int8
+
+// Available device classes.
+const (
+	DeviceClassUnknown = iota // the device class has never been changed
+	DeviceClassA
+	DeviceClassC
+)
+
 // UplinkHistory contains meta-data of a transmission.
 type UplinkHistory struct {
 	FCnt         uint32
 	MaxSNR       float64
+	MaxRSSI      int
 	GatewayCount int
+
+	// FrequencyOffset contains the frequency error (Hz) of the best
+	// reception (0 when not reported by the gateway).
+	FrequencyOffset int
 }
 
 // NodeSession contains the information of a node-session (an activated node).
@@ -27,6 +55,7 @@ type NodeSession struct {
 	AppEUI    lorawan.EUI64
 	DevEUI    lorawan.EUI64
 	NwkSKey   lorawan.AES128Key
+	AppSKey   *lorawan.AES128Key // only set when the AppSKey encryption is offloaded to LoRa Server
 	FCntUp    uint32
 	FCntDown  uint32
 	RelaxFCnt bool
@@ -36,6 +65,11 @@ type NodeSession struct {
 	RX1DROffset uint8
 	RX2DR       uint8
 
+	// RX2Frequency defines the RX2 frequency (Hz) of the node, e.g. for
+	// networks using a non-default RX2 plan (0 = the RX2 frequency of the
+	// band), see GetRX2Frequency.
+	RX2Frequency int
+
 	// ADRInterval controls the interval on which to send ADR mac-commands
 	// (in case the data-rate / tx power of the node can be changed).
 	// Setting this to 0 will disable ADR, 1 means to respond to every uplink
@@ -49,6 +83,14 @@ type NodeSession struct {
 	// possible packet-loss.
 	InstallationMargin float64
 
+	// ADRStrategy defines the strategy used by the ADR engine for
+	// calculating the ideal data-rate and tx-power.
+	ADRStrategy ADRStrategy
+
+	// Relay defines if the node is a relay (LoRaWAN TS011). Uplinks
+	// received on the relay FPort are handled as forwarded uplinks.
+	Relay bool
+
 	// TXPower of the node. This value is controlled by the ADR engine.
 	// TXPower 0 means the DefaultTXPower is used as defined by the band
 	// band configuration.
@@ -59,9 +101,112 @@ type NodeSession struct {
 	// This value is controlled by the ADR engine.
 	NbTrans uint8
 
+	// DeviceClass holds the (confirmed) device class of the node.
+	DeviceClass DeviceClass
+
+	// PendingDeviceClass holds the device class the node is switching to
+	// (DeviceClassUnknown when no change is pending) and
+	// DeviceClassChangedAt the time the change was initiated.
+	PendingDeviceClass   DeviceClass
+	DeviceClassChangedAt time.Time
+
+	// GatewayRegions holds the gateway regions via which downlinks for the
+	// node may be transmitted. When empty, all gateways may be used.
+	GatewayRegions []string
+
+	// DownlinkTXParams holds the downlink TX parameters of the node,
+	// overriding the TX parameters of the band and gateway.
+	DownlinkTXParams models.TXParams
+
+	// DownlinkReference holds the client reference of the pending
+	// confirmed downlink (if any), see PushDataDown.
+	DownlinkReference string
+
+	// BatteryThrottleLevel defines the battery level below which confirmed
+	// downlinks which are not critical are sent as unconfirmed downlinks
+	// (0 = disabled).
+	BatteryThrottleLevel uint8
+
+	// BatteryLevel holds the battery level last reported by the node
+	// (DevStatusAns) and BatteryLevelUpdatedAt the time it was reported
+	// (zero when no battery level has been reported).
+	BatteryLevel          uint8
+	BatteryLevelUpdatedAt time.Time
+
+	// ClassCFPort defines the FPort on which the node signals that it is
+	// temporarily available as Class-C device (0 = disabled). After such an
+	// uplink, the node is handled as Class-C device for ClassCWindow, until
+	// ClassCUntil.
+	ClassCFPort  uint8
+	ClassCWindow time.Duration
+	ClassCUntil  time.Time
+
+	// TransmitDiversity defines if critical confirmed downlinks (in response
+	// to an uplink) are transmitted a second time via the second best
+	// gateway, using the other RX window.
+	TransmitDiversity bool
+
+	// DailyDownlinkAirtimeCap defines the max. downlink airtime of the node
+	// per day (0 = no cap). When reached, the application payloads are left
+	// in the queue until the next day.
+	DailyDownlinkAirtimeCap time.Duration
+
+	// Tags contains the operator-defined tags of the node.
+	Tags models.Tags
+
+	// MACVersion defines the LoRaWAN MAC version of the node ("" = the
+	// default MAC version), see GetMACCapabilities.
+	MACVersion string
+
+	// Geolocation defines if the location of the node is resolved from the
+	// fine-timestamps of the receiving gateways, see the geolocation
+	// package.
+	Geolocation bool
+
+	// ChannelConfigurationID defines the channel-configuration (channel
+	// plan) to which the uplink channels of the node are reconciled (0 =
+	// none), see the channelplan package.
+	ChannelConfigurationID int64
+
+	// ForwardPHYPayload defines if the raw PHYPayload of the uplink frames
+	// is included in the HandleDataUp calls to the application-server.
+	ForwardPHYPayload bool
+
+	// MaxUplinksPerHour defines the max. number of uplink frames forwarded
+	// per hour to the application-server (0 = unlimited).
+	MaxUplinksPerHour uint32
+
+	// LegacyADRACKReq defines if the node has a buggy (LoRaWAN 1.0.1)
+	// ADRACKReq handling, continuously setting the ADRACKReq bit or only
+	// resetting its ADR_ACK_CNT on a downlink with the ADR bit set. Its
+	// ADRACKReqs are then responded at most once per ADR_ACK_DELAY uplinks
+	// and the ADR bit is set in all its downlinks.
+	LegacyADRACKReq bool
+
+	// UplinkChannels contains the uplink channels of the node, as
+	// acknowledged by the node (nil = the channels of the band and CFList),
+	// see GetUplinkChannels.
+	UplinkChannels []UplinkChannel
+
+	// ChannelMask contains the indices of the uplink channels to which the
+	// enabled channels of the node are restricted, as set through the API
+	// (nil = no restriction), see SetChannelMask.
+	ChannelMask []int
+
+	// DeviceProfileID defines the device-profile of the node, limiting its
+	// downlink queue size and daily airtime (0 = none), see the
+	// deviceprofile package.
+	DeviceProfileID int64
+
 	UplinkHistory []UplinkHistory // contains the last 20 transmissions
 	CFList        *lorawan.CFList
 	LastRXInfoSet []gw.RXInfo // sorted set (best at index 0)
+	LastUplinkAt  time.Time   // time the last uplink was handled
+
+	// Version is incremented on every write of the node-session. It is
+	// used to detect that a node-session has been updated concurrently
+	// (see UpdateNodeSession).
+	Version uint64
 }
 
 // AppendUplinkHistory appends an UplinkHistory item and makes sure the list
@@ -100,3 +245,74 @@ func (b NodeSession) GetPacketLossPercentage() float64 {
 
 	return float64(lostPackets) / float64(len(b.UplinkHistory)) * 100
 }
+
+// GetMACVersion returns the LoRaWAN MAC version of the node.
+func (b NodeSession) GetMACVersion() string {
+	if b.MACVersion == "" {
+		return DefaultMACVersion
+	}
+	return b.MACVersion
+}
+
+// GetMACCapabilities returns the capabilities of the LoRaWAN MAC version
+// of the node.
+func (b NodeSession) GetMACCapabilities() MACCapabilities {
+	c, err := GetMACCapabilities(b.GetMACVersion())
+	if err != nil {
+		return macCapabilities[DefaultMACVersion]
+	}
+	return c
+}
+
+// GetRX2Frequency returns the RX2 frequency (Hz) of the node.
+func (b NodeSession) GetRX2Frequency() int {
+	if b.RX2Frequency == 0 {
+		return common.Band.RX2Frequency
+	}
+	return b.RX2Frequency
+}
+
+// DeviceClassChangePending returns true when a device class change is
+// pending and the given lockout (0 = until confirmed) has not expired.
+func (b NodeSession) DeviceClassChangePending(lockout time.Duration, now time.Time) bool {
+	if b.PendingDeviceClass == DeviceClassUnknown {
+		return false
+	}
+	return lockout == 0 || now.Sub(b.DeviceClassChangedAt) < lockout
+}
+
+// GetDeviceClass returns the device class of the node, taking into account
+// a pending change for which the given lockout has expired and the Class-C
+// window signaled by the node.
+func (b NodeSession) GetDeviceClass(lockout time.Duration, now time.Time) DeviceClass {
+	if now.Before(b.ClassCUntil) {
+		return DeviceClassC
+	}
+	if b.PendingDeviceClass != DeviceClassUnknown && !b.DeviceClassChangePending(lockout, now) {
+		return b.PendingDeviceClass
+	}
+	return b.DeviceClass
+}
+
+// ConfirmDeviceClassChange confirms the pending device class change. It
+// returns false when no change was pending.
+func (b *NodeSession) ConfirmDeviceClassChange() bool {
+	if b.PendingDeviceClass == DeviceClassUnknown {
+		return false
+	}
+	b.DeviceClass = b.PendingDeviceClass
+	b.PendingDeviceClass = DeviceClassUnknown
+	b.DeviceClassChangedAt = time.Time{}
+	return true
+}
+
+// HandleClassCSignal opens the Class-C window of the node when the given
+// uplink FPort is the ClassCFPort of the node. It returns true when the
+// window has been opened (or extended).
+func (b *NodeSession) HandleClassCSignal(fPort uint8, now time.Time) bool {
+	if b.ClassCFPort == 0 || b.ClassCWindow == 0 || fPort != b.ClassCFPort {
+		return false
+	}
+	b.ClassCUntil = now.Add(b.ClassCWindow)
+	return true
+}
@@ -0,0 +1,3 @@
+//go:generate protoc -I . --go_out=. session.proto
+
+package pb
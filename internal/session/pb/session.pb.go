@@ -0,0 +1,777 @@
+// Code generated by protoc-gen-go.
+// source: session.proto
+// DO NOT EDIT!
+
+/*
+Package pb is a generated protocol buffer package.
+
+It is generated from these files:
+	session.proto
+
+It has these top-level messages:
+	NodeSession
+	UplinkChannel
+	UplinkHistory
+	RXInfo
+*/
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// NodeSession contains the Redis storage format of a node-session (see
+// session.NodeSession). Timestamps are encoded using time.Time.MarshalBinary
+// (empty when not set).
+type NodeSession struct {
+	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
+	AppEUI  []byte `protobuf:"bytes,2,opt,name=appEUI,proto3" json:"appEUI,omitempty"`
+	DevEUI  []byte `protobuf:"bytes,3,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	NwkSKey []byte `protobuf:"bytes,4,opt,name=nwkSKey,proto3" json:"nwkSKey,omitempty"`
+	// Empty when the AppSKey encryption is not offloaded.
+	AppSKey              []byte   `protobuf:"bytes,5,opt,name=appSKey,proto3" json:"appSKey,omitempty"`
+	FCntUp               uint32   `protobuf:"varint,6,opt,name=fCntUp" json:"fCntUp,omitempty"`
+	FCntDown             uint32   `protobuf:"varint,7,opt,name=fCntDown" json:"fCntDown,omitempty"`
+	RelaxFCnt            bool     `protobuf:"varint,8,opt,name=relaxFCnt" json:"relaxFCnt,omitempty"`
+	RxWindow             uint32   `protobuf:"varint,9,opt,name=rxWindow" json:"rxWindow,omitempty"`
+	RxDelay              uint32   `protobuf:"varint,10,opt,name=rxDelay" json:"rxDelay,omitempty"`
+	Rx1DROffset          uint32   `protobuf:"varint,11,opt,name=rx1DROffset" json:"rx1DROffset,omitempty"`
+	Rx2DR                uint32   `protobuf:"varint,12,opt,name=rx2DR" json:"rx2DR,omitempty"`
+	AdrInterval          uint32   `protobuf:"varint,13,opt,name=adrInterval" json:"adrInterval,omitempty"`
+	InstallationMargin   float64  `protobuf:"fixed64,14,opt,name=installationMargin" json:"installationMargin,omitempty"`
+	AdrStrategy          uint32   `protobuf:"varint,15,opt,name=adrStrategy" json:"adrStrategy,omitempty"`
+	Relay                bool     `protobuf:"varint,16,opt,name=relay" json:"relay,omitempty"`
+	TxPower              int32    `protobuf:"zigzag32,17,opt,name=txPower" json:"txPower,omitempty"`
+	NbTrans              uint32   `protobuf:"varint,18,opt,name=nbTrans" json:"nbTrans,omitempty"`
+	DeviceClass          int32    `protobuf:"zigzag32,19,opt,name=deviceClass" json:"deviceClass,omitempty"`
+	PendingDeviceClass   int32    `protobuf:"zigzag32,20,opt,name=pendingDeviceClass" json:"pendingDeviceClass,omitempty"`
+	DeviceClassChangedAt []byte   `protobuf:"bytes,21,opt,name=deviceClassChangedAt,proto3" json:"deviceClassChangedAt,omitempty"`
+	GatewayRegions       []string `protobuf:"bytes,22,rep,name=gatewayRegions" json:"gatewayRegions,omitempty"`
+	DownlinkTXPower      int32    `protobuf:"zigzag32,23,opt,name=downlinkTXPower" json:"downlinkTXPower,omitempty"`
+	DownlinkCodeRate     string   `protobuf:"bytes,24,opt,name=downlinkCodeRate" json:"downlinkCodeRate,omitempty"`
+	// 0 = not set, 1 = not inverted, 2 = inverted.
+	DownlinkIPol          uint32           `protobuf:"varint,25,opt,name=downlinkIPol" json:"downlinkIPol,omitempty"`
+	DownlinkReference     string           `protobuf:"bytes,26,opt,name=downlinkReference" json:"downlinkReference,omitempty"`
+	BatteryThrottleLevel  uint32           `protobuf:"varint,27,opt,name=batteryThrottleLevel" json:"batteryThrottleLevel,omitempty"`
+	BatteryLevel          uint32           `protobuf:"varint,28,opt,name=batteryLevel" json:"batteryLevel,omitempty"`
+	BatteryLevelUpdatedAt []byte           `protobuf:"bytes,29,opt,name=batteryLevelUpdatedAt,proto3" json:"batteryLevelUpdatedAt,omitempty"`
+	ClassCFPort           uint32           `protobuf:"varint,30,opt,name=classCFPort" json:"classCFPort,omitempty"`
+	ClassCWindow          int64            `protobuf:"varint,31,opt,name=classCWindow" json:"classCWindow,omitempty"`
+	ClassCUntil           []byte           `protobuf:"bytes,32,opt,name=classCUntil,proto3" json:"classCUntil,omitempty"`
+	UplinkHistory         []*UplinkHistory `protobuf:"bytes,33,rep,name=uplinkHistory" json:"uplinkHistory,omitempty"`
+	// Empty when no CFList is set.
+	CFList                  []uint32          `protobuf:"varint,34,rep,packed,name=cFList" json:"cFList,omitempty"`
+	LastRXInfoSet           []*RXInfo         `protobuf:"bytes,35,rep,name=lastRXInfoSet" json:"lastRXInfoSet,omitempty"`
+	LastUplinkAt            []byte            `protobuf:"bytes,36,opt,name=lastUplinkAt,proto3" json:"lastUplinkAt,omitempty"`
+	TransmitDiversity       bool              `protobuf:"varint,37,opt,name=transmitDiversity" json:"transmitDiversity,omitempty"`
+	DailyDownlinkAirtimeCap int64             `protobuf:"varint,38,opt,name=dailyDownlinkAirtimeCap" json:"dailyDownlinkAirtimeCap,omitempty"`
+	Version                 uint64            `protobuf:"varint,39,opt,name=version" json:"version,omitempty"`
+	Rx2Frequency            uint32            `protobuf:"varint,40,opt,name=rx2Frequency" json:"rx2Frequency,omitempty"`
+	Tags                    map[string]string `protobuf:"bytes,41,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MacVersion              string            `protobuf:"bytes,42,opt,name=macVersion" json:"macVersion,omitempty"`
+	Geolocation             bool              `protobuf:"varint,43,opt,name=geolocation" json:"geolocation,omitempty"`
+	ChannelConfigurationID  int64             `protobuf:"varint,44,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Empty when the uplink channels are not tracked.
+	UplinkChannels    []*UplinkChannel `protobuf:"bytes,45,rep,name=uplinkChannels" json:"uplinkChannels,omitempty"`
+	ForwardPHYPayload bool             `protobuf:"varint,46,opt,name=forwardPHYPayload" json:"forwardPHYPayload,omitempty"`
+	MaxUplinksPerHour uint32           `protobuf:"varint,47,opt,name=maxUplinksPerHour" json:"maxUplinksPerHour,omitempty"`
+	LegacyADRACKReq   bool             `protobuf:"varint,48,opt,name=legacyADRACKReq" json:"legacyADRACKReq,omitempty"`
+	ChannelMask       []uint32         `protobuf:"varint,49,rep,packed,name=channelMask" json:"channelMask,omitempty"`
+	DeviceProfileID int64 `protobuf:"varint,50,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
+}
+
+func (m *NodeSession) Reset()                    { *m = NodeSession{} }
+func (m *NodeSession) String() string            { return proto.CompactTextString(m) }
+func (*NodeSession) ProtoMessage()               {}
+func (*NodeSession) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *NodeSession) GetDevAddr() []byte {
+	if m != nil {
+		return m.DevAddr
+	}
+	return nil
+}
+
+func (m *NodeSession) GetAppEUI() []byte {
+	if m != nil {
+		return m.AppEUI
+	}
+	return nil
+}
+
+func (m *NodeSession) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *NodeSession) GetNwkSKey() []byte {
+	if m != nil {
+		return m.NwkSKey
+	}
+	return nil
+}
+
+func (m *NodeSession) GetAppSKey() []byte {
+	if m != nil {
+		return m.AppSKey
+	}
+	return nil
+}
+
+func (m *NodeSession) GetFCntUp() uint32 {
+	if m != nil {
+		return m.FCntUp
+	}
+	return 0
+}
+
+func (m *NodeSession) GetFCntDown() uint32 {
+	if m != nil {
+		return m.FCntDown
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRelaxFCnt() bool {
+	if m != nil {
+		return m.RelaxFCnt
+	}
+	return false
+}
+
+func (m *NodeSession) GetRxWindow() uint32 {
+	if m != nil {
+		return m.RxWindow
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRxDelay() uint32 {
+	if m != nil {
+		return m.RxDelay
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRx1DROffset() uint32 {
+	if m != nil {
+		return m.Rx1DROffset
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRx2DR() uint32 {
+	if m != nil {
+		return m.Rx2DR
+	}
+	return 0
+}
+
+func (m *NodeSession) GetAdrInterval() uint32 {
+	if m != nil {
+		return m.AdrInterval
+	}
+	return 0
+}
+
+func (m *NodeSession) GetInstallationMargin() float64 {
+	if m != nil {
+		return m.InstallationMargin
+	}
+	return 0
+}
+
+func (m *NodeSession) GetAdrStrategy() uint32 {
+	if m != nil {
+		return m.AdrStrategy
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRelay() bool {
+	if m != nil {
+		return m.Relay
+	}
+	return false
+}
+
+func (m *NodeSession) GetTxPower() int32 {
+	if m != nil {
+		return m.TxPower
+	}
+	return 0
+}
+
+func (m *NodeSession) GetNbTrans() uint32 {
+	if m != nil {
+		return m.NbTrans
+	}
+	return 0
+}
+
+func (m *NodeSession) GetDeviceClass() int32 {
+	if m != nil {
+		return m.DeviceClass
+	}
+	return 0
+}
+
+func (m *NodeSession) GetPendingDeviceClass() int32 {
+	if m != nil {
+		return m.PendingDeviceClass
+	}
+	return 0
+}
+
+func (m *NodeSession) GetDeviceClassChangedAt() []byte {
+	if m != nil {
+		return m.DeviceClassChangedAt
+	}
+	return nil
+}
+
+func (m *NodeSession) GetGatewayRegions() []string {
+	if m != nil {
+		return m.GatewayRegions
+	}
+	return nil
+}
+
+func (m *NodeSession) GetDownlinkTXPower() int32 {
+	if m != nil {
+		return m.DownlinkTXPower
+	}
+	return 0
+}
+
+func (m *NodeSession) GetDownlinkCodeRate() string {
+	if m != nil {
+		return m.DownlinkCodeRate
+	}
+	return ""
+}
+
+func (m *NodeSession) GetDownlinkIPol() uint32 {
+	if m != nil {
+		return m.DownlinkIPol
+	}
+	return 0
+}
+
+func (m *NodeSession) GetDownlinkReference() string {
+	if m != nil {
+		return m.DownlinkReference
+	}
+	return ""
+}
+
+func (m *NodeSession) GetBatteryThrottleLevel() uint32 {
+	if m != nil {
+		return m.BatteryThrottleLevel
+	}
+	return 0
+}
+
+func (m *NodeSession) GetBatteryLevel() uint32 {
+	if m != nil {
+		return m.BatteryLevel
+	}
+	return 0
+}
+
+func (m *NodeSession) GetBatteryLevelUpdatedAt() []byte {
+	if m != nil {
+		return m.BatteryLevelUpdatedAt
+	}
+	return nil
+}
+
+func (m *NodeSession) GetClassCFPort() uint32 {
+	if m != nil {
+		return m.ClassCFPort
+	}
+	return 0
+}
+
+func (m *NodeSession) GetClassCWindow() int64 {
+	if m != nil {
+		return m.ClassCWindow
+	}
+	return 0
+}
+
+func (m *NodeSession) GetClassCUntil() []byte {
+	if m != nil {
+		return m.ClassCUntil
+	}
+	return nil
+}
+
+func (m *NodeSession) GetUplinkHistory() []*UplinkHistory {
+	if m != nil {
+		return m.UplinkHistory
+	}
+	return nil
+}
+
+func (m *NodeSession) GetCFList() []uint32 {
+	if m != nil {
+		return m.CFList
+	}
+	return nil
+}
+
+func (m *NodeSession) GetLastRXInfoSet() []*RXInfo {
+	if m != nil {
+		return m.LastRXInfoSet
+	}
+	return nil
+}
+
+func (m *NodeSession) GetLastUplinkAt() []byte {
+	if m != nil {
+		return m.LastUplinkAt
+	}
+	return nil
+}
+
+func (m *NodeSession) GetTransmitDiversity() bool {
+	if m != nil {
+		return m.TransmitDiversity
+	}
+	return false
+}
+
+func (m *NodeSession) GetDailyDownlinkAirtimeCap() int64 {
+	if m != nil {
+		return m.DailyDownlinkAirtimeCap
+	}
+	return 0
+}
+
+func (m *NodeSession) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *NodeSession) GetRx2Frequency() uint32 {
+	if m != nil {
+		return m.Rx2Frequency
+	}
+	return 0
+}
+
+func (m *NodeSession) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *NodeSession) GetMacVersion() string {
+	if m != nil {
+		return m.MacVersion
+	}
+	return ""
+}
+
+func (m *NodeSession) GetGeolocation() bool {
+	if m != nil {
+		return m.Geolocation
+	}
+	return false
+}
+
+func (m *NodeSession) GetChannelConfigurationID() int64 {
+	if m != nil {
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *NodeSession) GetUplinkChannels() []*UplinkChannel {
+	if m != nil {
+		return m.UplinkChannels
+	}
+	return nil
+}
+
+func (m *NodeSession) GetForwardPHYPayload() bool {
+	if m != nil {
+		return m.ForwardPHYPayload
+	}
+	return false
+}
+
+func (m *NodeSession) GetMaxUplinksPerHour() uint32 {
+	if m != nil {
+		return m.MaxUplinksPerHour
+	}
+	return 0
+}
+
+func (m *NodeSession) GetLegacyADRACKReq() bool {
+	if m != nil {
+		return m.LegacyADRACKReq
+	}
+	return false
+}
+
+func (m *NodeSession) GetChannelMask() []uint32 {
+	if m != nil {
+		return m.ChannelMask
+	}
+	return nil
+}
+
+func (m *NodeSession) GetDeviceProfileID() int64 {
+	if m != nil {
+		return m.DeviceProfileID
+	}
+	return 0
+}
+
+type UplinkChannel struct {
+	Index     uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Frequency uint32 `protobuf:"varint,2,opt,name=frequency" json:"frequency,omitempty"`
+	MinDR     uint32 `protobuf:"varint,3,opt,name=minDR" json:"minDR,omitempty"`
+	MaxDR     uint32 `protobuf:"varint,4,opt,name=maxDR" json:"maxDR,omitempty"`
+	Enabled   bool   `protobuf:"varint,5,opt,name=enabled" json:"enabled,omitempty"`
+}
+
+func (m *UplinkChannel) Reset()                    { *m = UplinkChannel{} }
+func (m *UplinkChannel) String() string            { return proto.CompactTextString(m) }
+func (*UplinkChannel) ProtoMessage()               {}
+func (*UplinkChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *UplinkChannel) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetMinDR() uint32 {
+	if m != nil {
+		return m.MinDR
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetMaxDR() uint32 {
+	if m != nil {
+		return m.MaxDR
+	}
+	return 0
+}
+
+func (m *UplinkChannel) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type UplinkHistory struct {
+	FCnt            uint32  `protobuf:"varint,1,opt,name=fCnt" json:"fCnt,omitempty"`
+	MaxSNR          float64 `protobuf:"fixed64,2,opt,name=maxSNR" json:"maxSNR,omitempty"`
+	GatewayCount    uint32  `protobuf:"varint,3,opt,name=gatewayCount" json:"gatewayCount,omitempty"`
+	MaxRSSI         int32   `protobuf:"varint,4,opt,name=maxRSSI" json:"maxRSSI,omitempty"`
+	FrequencyOffset int32   `protobuf:"zigzag32,5,opt,name=frequencyOffset" json:"frequencyOffset,omitempty"`
+}
+
+func (m *UplinkHistory) Reset()                    { *m = UplinkHistory{} }
+func (m *UplinkHistory) String() string            { return proto.CompactTextString(m) }
+func (*UplinkHistory) ProtoMessage()               {}
+func (*UplinkHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *UplinkHistory) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetMaxSNR() float64 {
+	if m != nil {
+		return m.MaxSNR
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetGatewayCount() uint32 {
+	if m != nil {
+		return m.GatewayCount
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetMaxRSSI() int32 {
+	if m != nil {
+		return m.MaxRSSI
+	}
+	return 0
+}
+
+func (m *UplinkHistory) GetFrequencyOffset() int32 {
+	if m != nil {
+		return m.FrequencyOffset
+	}
+	return 0
+}
+
+type RXInfo struct {
+	Mac             []byte  `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	Time            []byte  `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Timestamp       uint32  `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Frequency       uint32  `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	Channel         uint32  `protobuf:"varint,5,opt,name=channel" json:"channel,omitempty"`
+	RfChain         uint32  `protobuf:"varint,6,opt,name=rfChain" json:"rfChain,omitempty"`
+	Antenna         uint32  `protobuf:"varint,7,opt,name=antenna" json:"antenna,omitempty"`
+	CrcStatus       int32   `protobuf:"zigzag32,8,opt,name=crcStatus" json:"crcStatus,omitempty"`
+	CodeRate        string  `protobuf:"bytes,9,opt,name=codeRate" json:"codeRate,omitempty"`
+	Rssi            int32   `protobuf:"zigzag32,10,opt,name=rssi" json:"rssi,omitempty"`
+	LoRaSNR         float64 `protobuf:"fixed64,11,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+	Size            uint32  `protobuf:"varint,12,opt,name=size" json:"size,omitempty"`
+	Modulation      string  `protobuf:"bytes,13,opt,name=modulation" json:"modulation,omitempty"`
+	SpreadFactor    uint32  `protobuf:"varint,14,opt,name=spreadFactor" json:"spreadFactor,omitempty"`
+	Bandwidth       uint32  `protobuf:"varint,15,opt,name=bandwidth" json:"bandwidth,omitempty"`
+	BitRate         uint32  `protobuf:"varint,16,opt,name=bitRate" json:"bitRate,omitempty"`
+	FrequencyOffset int32   `protobuf:"zigzag32,17,opt,name=frequencyOffset" json:"frequencyOffset,omitempty"`
+}
+
+func (m *RXInfo) Reset()                    { *m = RXInfo{} }
+func (m *RXInfo) String() string            { return proto.CompactTextString(m) }
+func (*RXInfo) ProtoMessage()               {}
+func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *RXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *RXInfo) GetTime() []byte {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *RXInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *RXInfo) GetChannel() uint32 {
+	if m != nil {
+		return m.Channel
+	}
+	return 0
+}
+
+func (m *RXInfo) GetRfChain() uint32 {
+	if m != nil {
+		return m.RfChain
+	}
+	return 0
+}
+
+func (m *RXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *RXInfo) GetCrcStatus() int32 {
+	if m != nil {
+		return m.CrcStatus
+	}
+	return 0
+}
+
+func (m *RXInfo) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *RXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *RXInfo) GetLoRaSNR() float64 {
+	if m != nil {
+		return m.LoRaSNR
+	}
+	return 0
+}
+
+func (m *RXInfo) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *RXInfo) GetModulation() string {
+	if m != nil {
+		return m.Modulation
+	}
+	return ""
+}
+
+func (m *RXInfo) GetSpreadFactor() uint32 {
+	if m != nil {
+		return m.SpreadFactor
+	}
+	return 0
+}
+
+func (m *RXInfo) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *RXInfo) GetBitRate() uint32 {
+	if m != nil {
+		return m.BitRate
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFrequencyOffset() int32 {
+	if m != nil {
+		return m.FrequencyOffset
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*NodeSession)(nil), "pb.NodeSession")
+	proto.RegisterType((*UplinkChannel)(nil), "pb.UplinkChannel")
+	proto.RegisterType((*UplinkHistory)(nil), "pb.UplinkHistory")
+	proto.RegisterType((*RXInfo)(nil), "pb.RXInfo")
+}
+
+func init() { proto.RegisterFile("session.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1223 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x75, 0x56, 0xdd, 0x76, 0x1b, 0x35,
+	0x10, 0x3e, 0x4e, 0x9c, 0x34, 0x56, 0x7e, 0x2d, 0xd2, 0x54, 0x0d, 0xa5, 0xa4, 0x01, 0x4a, 0x28,
+	0xad, 0x69, 0x03, 0x87, 0x16, 0xee, 0x72, 0xec, 0xe6, 0x24, 0xa7, 0x3f, 0xf8, 0xc8, 0x09, 0x94,
+	0x4b, 0xd9, 0x2b, 0x3b, 0x7b, 0xb2, 0xd1, 0xba, 0x5a, 0x39, 0xb6, 0x79, 0x03, 0x2e, 0x79, 0x06,
+	0xde, 0x8a, 0xa7, 0x61, 0x66, 0xa4, 0x75, 0xd6, 0x8e, 0x7b, 0xb5, 0xfb, 0x7d, 0x33, 0x1a, 0xcd,
+	0x8f, 0x34, 0x1a, 0xb6, 0x9e, 0xe9, 0x2c, 0x8b, 0x53, 0x53, 0xeb, 0xdb, 0xd4, 0xa5, 0x7c, 0xa1,
+	0xdf, 0xde, 0xff, 0x67, 0x93, 0xad, 0xbe, 0x4f, 0x23, 0xdd, 0xf2, 0x12, 0x2e, 0xd8, 0x9d, 0x48,
+	0x5f, 0x1f, 0x45, 0x91, 0x15, 0xa5, 0xbd, 0xd2, 0xc1, 0x9a, 0xcc, 0x21, 0xdf, 0x61, 0xcb, 0xaa,
+	0xdf, 0x7f, 0x7d, 0x7e, 0x2a, 0x16, 0x48, 0x10, 0x10, 0xf2, 0xa0, 0x82, 0xfc, 0xa2, 0xe7, 0x3d,
+	0x42, 0x4b, 0x66, 0x78, 0xd9, 0x7a, 0xa3, 0xc7, 0xa2, 0xec, 0x2d, 0x05, 0x88, 0x12, 0x58, 0x4b,
+	0x92, 0x25, 0x2f, 0x09, 0x10, 0x6d, 0x75, 0xeb, 0xc6, 0x9d, 0xf7, 0xc5, 0x32, 0x08, 0xd6, 0x65,
+	0x40, 0x7c, 0x97, 0xad, 0xe0, 0x5f, 0x23, 0x1d, 0x1a, 0x71, 0x87, 0x24, 0x13, 0xcc, 0x1f, 0xb0,
+	0x8a, 0xd5, 0x89, 0x1a, 0x1d, 0x03, 0x21, 0x56, 0x40, 0xb8, 0x22, 0x6f, 0x08, 0x5c, 0x69, 0x47,
+	0x7f, 0xc4, 0x26, 0x4a, 0x87, 0xa2, 0xe2, 0x57, 0xe6, 0x18, 0xfd, 0xb0, 0xa3, 0x06, 0xa8, 0x8e,
+	0x05, 0x23, 0x51, 0x0e, 0xf9, 0x1e, 0x5b, 0xb5, 0xa3, 0x17, 0x0d, 0xf9, 0x5b, 0xb7, 0x9b, 0x69,
+	0x27, 0x56, 0x49, 0x5a, 0xa4, 0xf8, 0x36, 0x5b, 0xb2, 0xa3, 0xc3, 0x86, 0x14, 0x6b, 0x24, 0xf3,
+	0x00, 0xd7, 0xa9, 0xc8, 0x9e, 0x1a, 0xa7, 0xed, 0xb5, 0x4a, 0xc4, 0xba, 0x5f, 0x57, 0xa0, 0x78,
+	0x8d, 0xf1, 0xd8, 0x64, 0x4e, 0x25, 0x89, 0x72, 0x90, 0xef, 0x77, 0xca, 0xf6, 0x62, 0x23, 0x36,
+	0x40, 0xb1, 0x24, 0xe7, 0x48, 0x82, 0xc5, 0x96, 0xb3, 0xca, 0xe9, 0xde, 0x58, 0x6c, 0x4e, 0x2c,
+	0xe6, 0x14, 0x79, 0x42, 0x31, 0x6c, 0x51, 0xec, 0x1e, 0x60, 0x6c, 0x6e, 0xd4, 0x4c, 0x87, 0xda,
+	0x8a, 0x2a, 0xf0, 0x55, 0x99, 0x43, 0xaa, 0x4b, 0xfb, 0xcc, 0x2a, 0x93, 0x09, 0xee, 0xa3, 0x0e,
+	0x10, 0xf7, 0x82, 0xda, 0xc5, 0x1d, 0x5d, 0x4f, 0x54, 0x96, 0x89, 0xcf, 0x68, 0x5d, 0x91, 0x42,
+	0xef, 0xfb, 0xda, 0x44, 0xb1, 0xe9, 0x35, 0x0a, 0x8a, 0xdb, 0xa4, 0x38, 0x47, 0xc2, 0x0f, 0xd9,
+	0x76, 0x61, 0x79, 0xfd, 0x42, 0x99, 0x9e, 0x8e, 0x8e, 0x9c, 0xb8, 0x4b, 0x65, 0x9f, 0x2b, 0xe3,
+	0x8f, 0xd9, 0x46, 0x0f, 0x22, 0x1b, 0xaa, 0xb1, 0xd4, 0x3d, 0x48, 0x44, 0x26, 0x76, 0xf6, 0x16,
+	0x0f, 0x2a, 0x72, 0x86, 0xe5, 0x07, 0x6c, 0x13, 0x8a, 0x68, 0x92, 0xd8, 0x5c, 0x9e, 0x7d, 0xf0,
+	0x91, 0xde, 0x23, 0x47, 0x66, 0x69, 0xfe, 0x84, 0x6d, 0xe5, 0x54, 0x1d, 0x8e, 0xba, 0x04, 0x3b,
+	0x42, 0x80, 0x6a, 0x45, 0xde, 0xe2, 0xf9, 0x3e, 0x5b, 0xcb, 0xb9, 0xd3, 0x66, 0x9a, 0x88, 0xfb,
+	0x94, 0xa2, 0x29, 0x8e, 0x3f, 0x65, 0xd5, 0x1c, 0x4b, 0xdd, 0xd5, 0x56, 0x9b, 0x8e, 0x16, 0xbb,
+	0x64, 0xf0, 0xb6, 0x00, 0x73, 0xd0, 0x56, 0x0e, 0xca, 0x3f, 0x3e, 0xbb, 0x80, 0x6b, 0xe7, 0x12,
+	0xfd, 0x56, 0x5f, 0xeb, 0x44, 0x7c, 0x4e, 0x96, 0xe7, 0xca, 0xd0, 0x8b, 0xc0, 0x7b, 0xdd, 0x07,
+	0xde, 0x8b, 0x22, 0xc7, 0x7f, 0x62, 0x77, 0x8b, 0xf8, 0xbc, 0x1f, 0x81, 0xff, 0x98, 0xdc, 0x2f,
+	0x28, 0xb9, 0xf3, 0x85, 0x58, 0xe3, 0x0e, 0xe5, 0xfb, 0xb8, 0x99, 0x5a, 0x27, 0x1e, 0xfa, 0xf3,
+	0x54, 0xa0, 0x70, 0x6f, 0x0f, 0xc3, 0xad, 0xf9, 0x12, 0x54, 0x16, 0xe5, 0x14, 0x77, 0x63, 0xe5,
+	0xdc, 0xb8, 0x38, 0x11, 0x7b, 0xb4, 0x63, 0x91, 0xe2, 0x2f, 0xd9, 0xfa, 0xa0, 0x8f, 0x89, 0x38,
+	0x89, 0x33, 0x97, 0xda, 0xb1, 0x78, 0x04, 0x45, 0x5c, 0x3d, 0xac, 0xd6, 0xfa, 0xed, 0xda, 0x79,
+	0x51, 0x20, 0xa7, 0xf5, 0xb0, 0x05, 0x74, 0x8e, 0xdf, 0x02, 0x10, 0xfb, 0xb0, 0x02, 0x5a, 0x80,
+	0x47, 0xfc, 0x39, 0x5b, 0x07, 0xf3, 0x4e, 0x7e, 0x38, 0x35, 0xdd, 0xb4, 0x05, 0x97, 0xf2, 0x2b,
+	0x32, 0xc8, 0xd0, 0xa0, 0x27, 0xe5, 0xb4, 0x02, 0x06, 0x82, 0x84, 0xdf, 0x0d, 0xf2, 0xf2, 0x35,
+	0x79, 0x39, 0xc5, 0x61, 0x29, 0x1d, 0x9e, 0xfd, 0xab, 0xd8, 0x35, 0xe2, 0x6b, 0x6d, 0xb3, 0xd8,
+	0x8d, 0xc5, 0x37, 0x74, 0x91, 0x6e, 0x0b, 0xf8, 0x2b, 0x76, 0x2f, 0x52, 0x71, 0x32, 0x6e, 0x84,
+	0x22, 0x1f, 0xc5, 0xd6, 0xc5, 0x57, 0xba, 0xae, 0xfa, 0xe2, 0x31, 0x65, 0xe9, 0x53, 0x62, 0xbc,
+	0x74, 0x64, 0x24, 0x35, 0xe2, 0x5b, 0xd0, 0x2c, 0xcb, 0x1c, 0xa2, 0x97, 0xd0, 0x3b, 0x8e, 0xad,
+	0xfe, 0x38, 0x80, 0xe3, 0x32, 0x16, 0x07, 0xbe, 0xd4, 0x45, 0x8e, 0x3f, 0x63, 0x65, 0xa7, 0x7a,
+	0x99, 0xf8, 0x8e, 0x42, 0xbe, 0x8f, 0x21, 0x17, 0x7a, 0x76, 0xed, 0x0c, 0x64, 0xaf, 0x8d, 0x83,
+	0x5c, 0x92, 0x1a, 0x7f, 0xc8, 0xd8, 0x95, 0xea, 0xfc, 0x1e, 0xf6, 0x7b, 0x42, 0x07, 0xb3, 0xc0,
+	0x60, 0xf5, 0x7a, 0x3a, 0x4d, 0xd2, 0x0e, 0x35, 0x1a, 0xf1, 0x3d, 0x85, 0x5b, 0xa4, 0xf8, 0xcf,
+	0x6c, 0xa7, 0x03, 0x17, 0xd2, 0xe8, 0xa4, 0x9e, 0x9a, 0x6e, 0xdc, 0x1b, 0x58, 0xe2, 0x4f, 0x1b,
+	0xe2, 0x29, 0xc5, 0xf9, 0x09, 0x29, 0xff, 0x85, 0x6d, 0xf8, 0x6a, 0xd6, 0xbd, 0x3c, 0x13, 0xcf,
+	0x66, 0xcb, 0x1e, 0x24, 0x72, 0x46, 0x11, 0x2b, 0xd1, 0x4d, 0xed, 0x50, 0xd9, 0xa8, 0x79, 0xf2,
+	0x67, 0x53, 0x8d, 0x93, 0x54, 0x45, 0xa2, 0xe6, 0x2b, 0x71, 0x4b, 0x80, 0xda, 0x57, 0x6a, 0xe4,
+	0x2d, 0x66, 0x4d, 0x6d, 0x4f, 0xd2, 0x81, 0x15, 0x3f, 0x50, 0xea, 0x6e, 0x0b, 0xb0, 0x55, 0x24,
+	0xba, 0xa7, 0x3a, 0xe3, 0xa3, 0x86, 0x3c, 0xaa, 0xbf, 0x91, 0xfa, 0xa3, 0x78, 0x4e, 0x96, 0x67,
+	0xe9, 0xdd, 0x97, 0xac, 0x32, 0xc9, 0x26, 0xdf, 0x62, 0x8b, 0x97, 0xf0, 0x46, 0x95, 0x28, 0x81,
+	0xf8, 0x8b, 0xbd, 0x16, 0x9a, 0xf8, 0x40, 0xd3, 0x13, 0x58, 0x91, 0x1e, 0xfc, 0xba, 0xf0, 0xaa,
+	0x44, 0x37, 0xc2, 0x87, 0xf2, 0x4e, 0x65, 0x97, 0xe2, 0x05, 0x9d, 0xdd, 0x22, 0x45, 0xfd, 0x8a,
+	0xfa, 0x5d, 0xd3, 0xa6, 0xdd, 0x38, 0xd1, 0x90, 0xcc, 0x43, 0x4a, 0xe6, 0x2c, 0xbd, 0xff, 0x77,
+	0x89, 0xad, 0x4f, 0x25, 0x0b, 0xf7, 0x85, 0x8b, 0xa7, 0x47, 0xe4, 0x0b, 0xbc, 0x36, 0x04, 0xf0,
+	0xe5, 0xeb, 0x4e, 0xce, 0xcd, 0x02, 0x49, 0x6e, 0x08, 0x5c, 0x73, 0x15, 0x1b, 0x78, 0xa1, 0x16,
+	0xfd, 0x1a, 0x02, 0xc4, 0xaa, 0x11, 0xb0, 0xe5, 0xc0, 0x22, 0xc0, 0xe3, 0xa9, 0x8d, 0x6a, 0x27,
+	0x3a, 0xa2, 0x17, 0x79, 0x45, 0xe6, 0x70, 0xff, 0xdf, 0x89, 0x2f, 0xf9, 0x05, 0xe5, 0xac, 0x8c,
+	0x6f, 0x6f, 0x70, 0x85, 0xfe, 0xf1, 0xd2, 0x82, 0xa1, 0xd6, 0x7b, 0x49, 0x6e, 0x94, 0x64, 0x40,
+	0x78, 0xb8, 0x43, 0xd7, 0xae, 0xa7, 0x03, 0x58, 0xe3, 0x5d, 0x99, 0xe2, 0x70, 0x6f, 0xd0, 0x96,
+	0xad, 0xd6, 0x29, 0xf9, 0xb4, 0x24, 0x73, 0x88, 0x19, 0x9b, 0x84, 0x13, 0x5e, 0xe2, 0x25, 0xdf,
+	0xe1, 0x67, 0xe8, 0xfd, 0xff, 0x16, 0xd9, 0xb2, 0xbf, 0xf8, 0x58, 0x34, 0x38, 0xea, 0x61, 0x78,
+	0xc1, 0x5f, 0x74, 0x18, 0xaf, 0x61, 0x18, 0x5b, 0xe8, 0x1f, 0x53, 0x87, 0x5f, 0x78, 0x6e, 0xaf,
+	0xfa, 0xc1, 0xab, 0x1b, 0x62, 0x3a, 0xb1, 0xe5, 0xd9, 0xc4, 0x82, 0xc3, 0xa1, 0xae, 0xe4, 0x0e,
+	0x3c, 0xa0, 0x01, 0xd2, 0x40, 0xd1, 0x85, 0x9a, 0xc1, 0x8b, 0xbe, 0x1c, 0x06, 0x0a, 0x0f, 0x69,
+	0xe4, 0x81, 0x11, 0xc0, 0x18, 0x15, 0xe6, 0x97, 0x1c, 0xe2, 0x5e, 0x1d, 0xdb, 0x69, 0x39, 0xe5,
+	0x06, 0x19, 0x8d, 0x2f, 0x55, 0x79, 0x43, 0xe0, 0xf8, 0xd2, 0xc9, 0x9f, 0xac, 0x0a, 0x9d, 0xb9,
+	0x09, 0xc6, 0xb8, 0x2c, 0x34, 0x00, 0x9a, 0x5d, 0xaa, 0x92, 0xfe, 0x71, 0x9f, 0x24, 0x95, 0x0a,
+	0x2b, 0xb1, 0x4a, 0x95, 0xc8, 0x21, 0x6a, 0x67, 0xf1, 0x5f, 0x3a, 0xcc, 0x2b, 0xf4, 0x4f, 0x8d,
+	0x22, 0x8d, 0x06, 0x7e, 0xe0, 0xa0, 0x69, 0x05, 0x1b, 0xc5, 0x84, 0xc1, 0xf2, 0x65, 0x7d, 0xab,
+	0x55, 0x74, 0xac, 0x3a, 0x50, 0x7b, 0x1a, 0x53, 0xa0, 0x7c, 0x45, 0x0e, 0xfd, 0x6f, 0x2b, 0x13,
+	0x0d, 0xe3, 0xc8, 0x5d, 0x84, 0xf1, 0xe4, 0x86, 0x40, 0x7f, 0xda, 0xb1, 0x23, 0xf7, 0xb7, 0x7c,
+	0xdc, 0x01, 0xce, 0x2b, 0x6e, 0x75, 0x6e, 0x71, 0xdb, 0xcb, 0x34, 0xad, 0xfe, 0xf8, 0x3f, 0xe0,
+	0x80, 0x00, 0xdc, 0xbe, 0x0a, 0x00, 0x00,
+}
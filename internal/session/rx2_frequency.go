@@ -0,0 +1,49 @@
+package session
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// frequencyRange defines a frequency range (in Hz, inclusive).
+type frequencyRange struct {
+	Min int
+	Max int
+}
+
+// bandFrequencyRanges contains per ISM band the frequency range in which
+// downlinks may be transmitted.
+var bandFrequencyRanges = map[band.Name]frequencyRange{
+	band.AS_923:     {915000000, 928000000},
+	band.AU_915_928: {915000000, 928000000},
+	band.CN_470_510: {470000000, 510000000},
+	band.CN_779_787: {779000000, 787000000},
+	band.EU_433:     {433175000, 434665000},
+	band.EU_863_870: {863000000, 870000000},
+	band.KR_920_923: {920900000, 923300000},
+	band.RU_864_869: {864000000, 870000000},
+	band.US_902_928: {923300000, 927500000},
+}
+
+// ValidateRX2Frequency validates the given RX2 frequency (Hz) against the
+// configured band (0 = the RX2 frequency of the band). The frequency must
+// be within the downlink frequency range of the band and a multiple of 100
+// Hz, as it is sent to the node using the RXParamSetupReq mac-command.
+func ValidateRX2Frequency(frequency int) error {
+	if frequency == 0 {
+		return nil
+	}
+
+	if frequency%100 != 0 {
+		return errors.Wrapf(ErrInvalidRX2Frequency, "%d Hz is not a multiple of 100 Hz", frequency)
+	}
+
+	r, ok := bandFrequencyRanges[common.BandName]
+	if ok && (frequency < r.Min || frequency > r.Max) {
+		return errors.Wrapf(ErrInvalidRX2Frequency, "%d Hz is outside the %s band (%d - %d Hz)", frequency, common.BandName, r.Min, r.Max)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package session
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+func TestValidateRX2Frequency(t *testing.T) {
+	Convey("Given the EU_863_870 band and a set of tests", t, func() {
+		tests := []struct {
+			Frequency     int
+			ExpectedError bool
+		}{
+			{0, false},
+			{869525000, false},
+			{868100000, false},
+			{869525050, true},
+			{862900000, true},
+			{923300000, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %d [%d]", test.Frequency, i), func() {
+				err := ValidateRX2Frequency(test.Frequency)
+				So(err != nil, ShouldEqual, test.ExpectedError)
+			})
+		}
+	})
+
+	Convey("Given a node-session without RX2 frequency", t, func() {
+		ns := NodeSession{}
+
+		Convey("Then the RX2 frequency of the band is used", func() {
+			So(ns.GetRX2Frequency(), ShouldEqual, common.Band.RX2Frequency)
+		})
+
+		Convey("When setting the RX2 frequency", func() {
+			ns.RX2Frequency = 868100000
+
+			Convey("Then this RX2 frequency is used", func() {
+				So(ns.GetRX2Frequency(), ShouldEqual, 868100000)
+			})
+		})
+	})
+}
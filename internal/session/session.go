@@ -1,10 +1,10 @@
 package session
 
 import (
-	"bytes"
-	"crypto/rand"
-	"encoding/gob"
+	"encoding/binary"
 	"fmt"
+	"reflect"
+	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/keywrap"
 	"github.com/brocaar/lorawan"
 )
 
@@ -22,17 +23,16 @@ const (
 )
 
 // GetRandomDevAddr returns a random free DevAddr. Note that the 7 MSB will be
-// set to the NwkID (based on the configured NetID).
+// set to the NwkID (based on the configured NetID) and that the NwkAddr (25
+// LSB) is within the configured NwkAddr ranges (see MustSetNwkAddrRanges).
 func GetRandomDevAddr(p *redis.Pool, netID lorawan.NetID) (lorawan.DevAddr, error) {
 	var d lorawan.DevAddr
-	b := make([]byte, len(d))
-	if _, err := rand.Read(b); err != nil {
-		return d, errors.Wrap(err, "read random bytes error")
+	nwkAddr, err := getRandomNwkAddr()
+	if err != nil {
+		return d, errors.Wrap(err, "get random NwkAddr error")
 	}
-	copy(d[:], b)
-	d[0] = d[0] & 1                    // zero out 7 msb
-	d[0] = d[0] ^ (netID.NwkID() << 1) // set 7 msb to NwkID
 
+	binary.BigEndian.PutUint32(d[:], uint32(netID.NwkID())<<25|nwkAddr)
 	return d, nil
 }
 
@@ -51,6 +51,44 @@ func ValidateAndGetFullFCntUp(n NodeSession, fCntUp uint32) (uint32, bool) {
 	return 0, false
 }
 
+// UnwrapAppSKey unwraps the given AppSKey using the configured
+// common.AppSKeyKEK. It returns nil when no wrapped AppSKey is given,
+// meaning the AppSKey encryption is handled by the application-server.
+func UnwrapAppSKey(wrapped []byte) (*lorawan.AES128Key, error) {
+	if len(wrapped) == 0 {
+		return nil, nil
+	}
+	if common.AppSKeyKEK == nil {
+		return nil, ErrAppSKeyKEKNotConfigured
+	}
+
+	b, err := keywrap.Unwrap(common.AppSKeyKEK, wrapped)
+	if err != nil || len(b) != len(lorawan.AES128Key{}) {
+		return nil, ErrInvalidWrappedAppSKey
+	}
+
+	var key lorawan.AES128Key
+	copy(key[:], b)
+	return &key, nil
+}
+
+// WrapAppSKey wraps the given AppSKey using the configured
+// common.AppSKeyKEK. It returns nil when no AppSKey is given.
+func WrapAppSKey(key *lorawan.AES128Key) ([]byte, error) {
+	if key == nil {
+		return nil, nil
+	}
+	if common.AppSKeyKEK == nil {
+		return nil, ErrAppSKeyKEKNotConfigured
+	}
+
+	b, err := keywrap.Wrap(common.AppSKeyKEK, key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap AppSKey error")
+	}
+	return b, nil
+}
+
 // NodeSessionExists returns a bool indicating if a node session exist.
 func NodeSessionExists(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
 	c := p.Get()
@@ -66,35 +104,232 @@ func NodeSessionExists(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
 	return false, nil
 }
 
-// SaveNodeSession saves the node session. Note that the session will automatically
-// expire after NodeSessionTTL.
+// SaveNodeSession saves the node session, replacing the stored node-session
+// (if any) regardless of its version. Note that the session will
+// automatically expire after NodeSessionTTL.
 func SaveNodeSession(p *redis.Pool, s NodeSession) error {
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	if err := enc.Encode(s); err != nil {
-		return errors.Wrap(err, "gob encode error")
-	}
+	return saveNodeSession(p, &s, false)
+}
+
+// UpdateNodeSession saves the given node-session when the stored
+// node-session has not been updated since the given node-session was read
+// (the versions are equal). On success, the Version of the given
+// node-session is incremented. ErrConcurrentUpdate is returned when the
+// stored node-session has been updated in the meantime and ErrDoesNotExist
+// when it has been deleted. Use MergeNodeSession to re-apply the changes on
+// the updated node-session.
+func UpdateNodeSession(p *redis.Pool, s *NodeSession) error {
+	return saveNodeSession(p, s, true)
+}
+
+func saveNodeSession(p *redis.Pool, s *NodeSession, checkVersion bool) error {
+	key := fmt.Sprintf(nodeSessionKeyTempl, s.DevEUI)
+	exp := int64(common.NodeSessionTTL) / int64(time.Millisecond)
 
 	c := p.Get()
 	defer c.Close()
-	exp := int64(common.NodeSessionTTL) / int64(time.Millisecond)
 
-	c.Send("MULTI")
-	c.Send("PSETEX", fmt.Sprintf(nodeSessionKeyTempl, s.DevEUI), exp, buf.Bytes())
-	c.Send("SADD", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), s.DevEUI[:])
-	c.Send("PEXPIRE", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), exp)
+	for i := 0; i < patchNodeSessionRetries; i++ {
+		if _, err := c.Do("WATCH", key); err != nil {
+			return errors.Wrap(err, "watch error")
+		}
+
+		var version uint64
+		val, err := redis.Bytes(c.Do("GET", key))
+		switch err {
+		case nil:
+			stored, err := unmarshalNodeSession(val)
+			if err != nil {
+				c.Do("UNWATCH")
+				return err
+			}
+			version = stored.Version
+		case redis.ErrNil:
+			// the node-session was deleted after it has been read
+			if checkVersion && s.Version != 0 {
+				c.Do("UNWATCH")
+				return ErrDoesNotExist
+			}
+		default:
+			c.Do("UNWATCH")
+			return errors.Wrap(err, "get error")
+		}
+
+		if checkVersion && version != s.Version {
+			c.Do("UNWATCH")
+			return ErrConcurrentUpdate
+		}
 
-	if _, err := c.Do("EXEC"); err != nil {
-		return errors.Wrap(err, "exec error")
+		ns := *s
+		ns.Version = version + 1
+		b, err := marshalNodeSession(ns)
+		if err != nil {
+			c.Do("UNWATCH")
+			return err
+		}
+
+		c.Send("MULTI")
+		c.Send("PSETEX", key, exp, b)
+		c.Send("SADD", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), s.DevEUI[:])
+		c.Send("PEXPIRE", fmt.Sprintf(devAddrKeyTempl, s.DevAddr), exp)
+		reply, err := c.Do("EXEC")
+		if err != nil {
+			return errors.Wrap(err, "exec error")
+		}
+
+		// a nil reply means that the transaction was aborted because
+		// the node-session was modified after WATCH
+		if reply != nil {
+			s.Version = ns.Version
+			log.WithFields(log.Fields{
+				"dev_eui":  s.DevEUI,
+				"dev_addr": s.DevAddr,
+				"version":  s.Version,
+			}).Info("node-session saved")
+			return nil
+		}
+
+		if checkVersion {
+			return ErrConcurrentUpdate
+		}
+	}
+
+	return ErrConcurrentUpdate
+}
+
+// SaveNodeSessionChanges saves the changes made to the given node-session
+// since it was read (orig). When the node-session has been updated
+// concurrently (e.g. by the API during uplink processing), the changes are
+// re-applied on the updated node-session (see MergeNodeSession) instead of
+// overwriting it. The given node-session is set to the saved node-session.
+func SaveNodeSessionChanges(p *redis.Pool, orig NodeSession, s *NodeSession) error {
+	err := UpdateNodeSession(p, s)
+	if err != ErrConcurrentUpdate {
+		return err
+	}
+
+	updated := *s
+	merged, err := PatchNodeSession(p, s.DevEUI, func(stored *NodeSession) error {
+		MergeNodeSession(stored, orig, updated)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "merge node-session error")
 	}
 
 	log.WithFields(log.Fields{
-		"dev_eui":  s.DevEUI,
-		"dev_addr": s.DevAddr,
-	}).Info("node-session saved")
+		"dev_eui": s.DevEUI,
+		"version": merged.Version,
+	}).Warning("node-session updated concurrently, changes merged")
+	*s = merged
 	return nil
 }
 
+// MergeNodeSession re-applies the changes made to a node-session (the fields
+// of updated which differ from orig, the node-session as it was read) on
+// the given stored node-session, which has been updated concurrently. As
+// frame-counters must never decrease, the highest FCntUp and FCntDown are
+// kept. The Version of the stored node-session is not changed.
+func MergeNodeSession(stored *NodeSession, orig, updated NodeSession) {
+	fCntUp := stored.FCntUp
+	fCntDown := stored.FCntDown
+
+	storedVal := reflect.ValueOf(stored).Elem()
+	origVal := reflect.ValueOf(orig)
+	updatedVal := reflect.ValueOf(updated)
+	for i := 0; i < storedVal.NumField(); i++ {
+		if storedVal.Type().Field(i).Name == "Version" {
+			continue
+		}
+		if !reflect.DeepEqual(origVal.Field(i).Interface(), updatedVal.Field(i).Interface()) {
+			storedVal.Field(i).Set(updatedVal.Field(i))
+		}
+	}
+
+	if fCntUp > stored.FCntUp {
+		stored.FCntUp = fCntUp
+	}
+	if fCntDown > stored.FCntDown {
+		stored.FCntDown = fCntDown
+	}
+}
+
+// patchNodeSessionRetries defines the number of times a patch is retried
+// when the node-session has been modified concurrently.
+const patchNodeSessionRetries = 3
+
+// PatchNodeSession applies fn to the node-session matching the given DevEUI
+// and stores the result, using optimistic locking (WATCH) so that the
+// patch does not overwrite a concurrent update of the node-session (e.g.
+// during uplink processing). In case of a concurrent update, the patch is
+// re-applied on the updated node-session. When it still fails after
+// patchNodeSessionRetries attempts, ErrConcurrentUpdate is returned. Errors
+// returned by fn are returned as-is. Note that fn must not change the
+// DevEUI or DevAddr.
+func PatchNodeSession(p *redis.Pool, devEUI lorawan.EUI64, fn func(*NodeSession) error) (NodeSession, error) {
+	var ns NodeSession
+	key := fmt.Sprintf(nodeSessionKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	for i := 0; i < patchNodeSessionRetries; i++ {
+		if _, err := c.Do("WATCH", key); err != nil {
+			return ns, errors.Wrap(err, "watch error")
+		}
+
+		val, err := redis.Bytes(c.Do("GET", key))
+		if err != nil {
+			c.Do("UNWATCH")
+			if err == redis.ErrNil {
+				return ns, ErrDoesNotExist
+			}
+			return ns, errors.Wrap(err, "get error")
+		}
+
+		if ns, err = unmarshalNodeSession(val); err != nil {
+			c.Do("UNWATCH")
+			return ns, err
+		}
+
+		devAddr := ns.DevAddr
+		if err = fn(&ns); err != nil {
+			c.Do("UNWATCH")
+			return ns, err
+		}
+		if ns.DevEUI != devEUI || ns.DevAddr != devAddr {
+			c.Do("UNWATCH")
+			return ns, ErrInvalidPatch
+		}
+
+		ns.Version++
+		b, err := marshalNodeSession(ns)
+		if err != nil {
+			c.Do("UNWATCH")
+			return ns, err
+		}
+
+		c.Send("MULTI")
+		c.Send("PSETEX", key, int64(common.NodeSessionTTL)/int64(time.Millisecond), b)
+		reply, err := c.Do("EXEC")
+		if err != nil {
+			return ns, errors.Wrap(err, "exec error")
+		}
+
+		// a nil reply means that the transaction was aborted because
+		// the node-session was modified after WATCH
+		if reply != nil {
+			log.WithFields(log.Fields{
+				"dev_eui":  ns.DevEUI,
+				"dev_addr": ns.DevAddr,
+			}).Info("node-session patched")
+			return ns, nil
+		}
+	}
+
+	return ns, ErrConcurrentUpdate
+}
+
 // GetNodeSessionsForDevAddr returns a slice of NodeSession items using the
 // given DevAddr. When no NodeSession is using the given DevAddr, this returns
 // an empty slice without error.
@@ -132,66 +367,160 @@ func GetNodeSessionsForDevAddr(p *redis.Pool, devAddr lorawan.DevAddr) ([]NodeSe
 
 // GetNodeSessionForPHYPayload returns the node-session matching the given
 // PHYPayload. This will fetch all node-sessions associated with the used
-// DevAddr and based on FCnt and MIC decide which one to use.
-func GetNodeSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload) (NodeSession, error) {
+// DevAddr and based on FCnt and MIC decide which one to use. In case of
+// multiple node-sessions, the MIC validation is performed by
+// common.MICValidationWorkers workers in parallel. The returned bool is
+// true when the frame-counters of the node-session were reset (see
+// RelaxFCnt), which indicates that the node has been reset.
+func GetNodeSessionForPHYPayload(p *redis.Pool, phy lorawan.PHYPayload) (NodeSession, bool, error) {
 	// MACPayload must be of type *lorawan.MACPayload
 	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
 	if !ok {
-		return NodeSession{}, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+		return NodeSession{}, false, fmt.Errorf("expected *lorawan.MACPayload, got: %T", phy.MACPayload)
 	}
-	originalFCnt := macPL.FHDR.FCnt
 
 	sessions, err := GetNodeSessionsForDevAddr(p, macPL.FHDR.DevAddr)
 	if err != nil {
-		return NodeSession{}, err
-	}
-
-	for _, ns := range sessions {
-		// reset to the original FCnt
-		macPL.FHDR.FCnt = originalFCnt
-		// get full FCnt
-		fullFCnt, ok := ValidateAndGetFullFCntUp(ns, macPL.FHDR.FCnt)
-		if !ok {
-			// if RelaxFCnt is turned on, test the MIC against FCnt reset
-			if ns.RelaxFCnt && macPL.FHDR.FCnt == 0 {
-				fullFCnt = 0
-				ns.FCntUp = 0
-				ns.FCntDown = 0
-
-				// validate if the mic is valid given the FCnt reset
-				micOK, err := phy.ValidateMIC(ns.NwkSKey)
-				if err != nil {
-					return NodeSession{}, errors.Wrap(err, "validate mic error")
-				}
+		return NodeSession{}, false, err
+	}
+
+	res, err := findNodeSessionForPHYPayload(phy, *macPL, sessions)
+	if err != nil {
+		return NodeSession{}, false, err
+	}
+	macPL.FHDR.FCnt = res.fullFCnt
+
+	if res.fCntReset {
+		// we need to update the NodeSession
+		if err := SaveNodeSession(p, res.ns); err != nil {
+			return NodeSession{}, false, err
+		}
+		log.WithFields(log.Fields{
+			"dev_addr": macPL.FHDR.DevAddr,
+			"dev_eui":  res.ns.DevEUI,
+		}).Warning("frame counters reset")
+	}
 
-				if micOK {
-					// we need to update the NodeSession
-					if err := SaveNodeSession(p, ns); err != nil {
-						return NodeSession{}, err
+	return res.ns, res.fCntReset, nil
+}
+
+// micCheckResult contains the result of validating the FCnt and MIC of
+// a PHYPayload against a single node-session.
+type micCheckResult struct {
+	ns        NodeSession
+	fullFCnt  uint32
+	fCntReset bool
+	ok        bool
+	err       error
+}
+
+// findNodeSessionForPHYPayload returns the result of the first node-session
+// (lowest index) for which the FCnt and MIC are valid. When more than one
+// node-session must be validated, this is done by a bounded pool of workers
+// which stops dispatching once the node-sessions before the first match
+// have been validated, so that the result does not depend on the order in
+// which the workers complete.
+func findNodeSessionForPHYPayload(phy lorawan.PHYPayload, macPL lorawan.MACPayload, sessions []NodeSession) (micCheckResult, error) {
+	workers := common.MICValidationWorkers
+	if workers > len(sessions) {
+		workers = len(sessions)
+	}
+
+	if workers <= 1 {
+		for _, ns := range sessions {
+			res := validateFCntAndMIC(phy, macPL, ns)
+			if res.err != nil {
+				return res, res.err
+			}
+			if res.ok {
+				return res, nil
+			}
+		}
+		return micCheckResult{}, ErrDoesNotExistOrFCntOrMICInvalid
+	}
+
+	// each index is validated by a single worker, found contains the
+	// lowest index with a match (or error)
+	results := make([]micCheckResult, len(sessions))
+	var mu sync.Mutex
+	found := len(sessions)
+	isBeyondFound := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return i > found
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if isBeyondFound(i) {
+					continue
+				}
+				res := validateFCntAndMIC(phy, macPL, sessions[i])
+				results[i] = res
+				if res.ok || res.err != nil {
+					mu.Lock()
+					if i < found {
+						found = i
 					}
-					log.WithFields(log.Fields{
-						"dev_addr": macPL.FHDR.DevAddr,
-						"dev_eui":  ns.DevEUI,
-					}).Warning("frame counters reset")
-					return ns, nil
+					mu.Unlock()
 				}
 			}
-			// try the next node-session
-			continue
-		}
+		}()
+	}
 
-		// the FCnt is valid, validate the MIC
-		macPL.FHDR.FCnt = fullFCnt
-		micOK, err := phy.ValidateMIC(ns.NwkSKey)
-		if err != nil {
-			return NodeSession{}, errors.Wrap(err, "validate mic error")
+	for i := range sessions {
+		if isBeyondFound(i) {
+			break
 		}
-		if micOK {
-			return ns, nil
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if found == len(sessions) {
+		return micCheckResult{}, ErrDoesNotExistOrFCntOrMICInvalid
+	}
+	res := results[found]
+	return res, res.err
+}
+
+// validateFCntAndMIC validates the FCnt and MIC of the given PHYPayload
+// against the given node-session. The given MACPayload is a copy, so that
+// it is safe to call this function concurrently.
+func validateFCntAndMIC(phy lorawan.PHYPayload, macPL lorawan.MACPayload, ns NodeSession) micCheckResult {
+	phy.MACPayload = &macPL
+
+	// get full FCnt
+	fullFCnt, ok := ValidateAndGetFullFCntUp(ns, macPL.FHDR.FCnt)
+	if !ok {
+		// if RelaxFCnt is turned on, test the MIC against FCnt reset
+		// (relaxed frame-counters are not allowed in strict security mode)
+		if ns.RelaxFCnt && !common.SecurityStrictMode && macPL.FHDR.FCnt == 0 {
+			ns.FCntUp = 0
+			ns.FCntDown = 0
+
+			// validate if the mic is valid given the FCnt reset
+			micOK, err := phy.ValidateMIC(ns.NwkSKey)
+			if err != nil {
+				return micCheckResult{err: errors.Wrap(err, "validate mic error")}
+			}
+			return micCheckResult{ns: ns, fCntReset: true, ok: micOK}
 		}
+		return micCheckResult{}
 	}
 
-	return NodeSession{}, ErrDoesNotExistOrFCntOrMICInvalid
+	// the FCnt is valid, validate the MIC
+	macPL.FHDR.FCnt = fullFCnt
+	micOK, err := phy.ValidateMIC(ns.NwkSKey)
+	if err != nil {
+		return micCheckResult{err: errors.Wrap(err, "validate mic error")}
+	}
+	return micCheckResult{ns: ns, fullFCnt: fullFCnt, ok: micOK}
 }
 
 // GetNodeSession returns the NodeSession for the given DevEUI.
@@ -209,12 +538,7 @@ func GetNodeSession(p *redis.Pool, devEUI lorawan.EUI64) (NodeSession, error) {
 		return ns, errors.Wrap(err, "get error")
 	}
 
-	err = gob.NewDecoder(bytes.NewReader(val)).Decode(&ns)
-	if err != nil {
-		return ns, errors.Wrap(err, "gob decode error")
-	}
-
-	return ns, nil
+	return unmarshalNodeSession(val)
 }
 
 // DeleteNodeSession deletes the NodeSession matching the given DevEUI.
@@ -232,3 +556,51 @@ func DeleteNodeSession(p *redis.Pool, devEUI lorawan.EUI64) error {
 	log.WithField("dev_eui", devEUI).Info("node-session deleted")
 	return nil
 }
+
+// ListNodeSessions returns a batch of node-sessions, starting at the given
+// cursor (0 for the first batch). The returned cursor must be used to
+// retrieve the next batch and is 0 when all node-sessions have been
+// returned. Note that count is a hint, the number of returned
+// node-sessions may differ.
+func ListNodeSessions(p *redis.Pool, cursor uint64, count int) ([]NodeSession, uint64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("SCAN", cursor, "MATCH", fmt.Sprintf(nodeSessionKeyTempl, "*"), "COUNT", count))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "scan error")
+	}
+
+	var keys []interface{}
+	var keyStrings []string
+	if _, err := redis.Scan(values, &cursor, &keyStrings); err != nil {
+		return nil, 0, errors.Wrap(err, "scan values error")
+	}
+	if len(keyStrings) == 0 {
+		return nil, cursor, nil
+	}
+	for _, k := range keyStrings {
+		keys = append(keys, k)
+	}
+
+	vals, err := redis.ByteSlices(c.Do("MGET", keys...))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "mget error")
+	}
+
+	var out []NodeSession
+	for _, val := range vals {
+		if val == nil {
+			// expired in the meantime
+			continue
+		}
+
+		ns, err := unmarshalNodeSession(val)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, ns)
+	}
+
+	return out, cursor, nil
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/keywrap"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
 	. "github.com/smartystreets/goconvey/convey"
@@ -86,6 +88,182 @@ func TestUplinkHistory(t *testing.T) {
 	})
 }
 
+func TestDeviceClassChange(t *testing.T) {
+	Convey("Given a node-session switching to Class-C", t, func() {
+		now := time.Now()
+		ns := NodeSession{
+			DeviceClass:          DeviceClassA,
+			PendingDeviceClass:   DeviceClassC,
+			DeviceClassChangedAt: now.Add(-time.Minute),
+		}
+
+		Convey("Then the change is pending when the lockout is 0 or has not expired", func() {
+			So(ns.DeviceClassChangePending(0, now), ShouldBeTrue)
+			So(ns.DeviceClassChangePending(2*time.Minute, now), ShouldBeTrue)
+			So(ns.GetDeviceClass(2*time.Minute, now), ShouldEqual, DeviceClassA)
+		})
+
+		Convey("Then the change is not pending when the lockout has expired", func() {
+			So(ns.DeviceClassChangePending(30*time.Second, now), ShouldBeFalse)
+			So(ns.GetDeviceClass(30*time.Second, now), ShouldEqual, DeviceClassC)
+		})
+
+		Convey("When confirming the change", func() {
+			So(ns.ConfirmDeviceClassChange(), ShouldBeTrue)
+
+			Convey("Then the node-session is Class-C and no change is pending", func() {
+				So(ns.DeviceClass, ShouldEqual, DeviceClassC)
+				So(ns.PendingDeviceClass, ShouldEqual, DeviceClassUnknown)
+				So(ns.DeviceClassChangePending(0, now), ShouldBeFalse)
+				So(ns.ConfirmDeviceClassChange(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestClassCSignal(t *testing.T) {
+	Convey("Given a Class-A node-session with a Class-C FPort", t, func() {
+		now := time.Now()
+		ns := NodeSession{
+			DeviceClass:  DeviceClassA,
+			ClassCFPort:  10,
+			ClassCWindow: time.Minute,
+		}
+
+		Convey("Then an uplink on an other FPort does not open the Class-C window", func() {
+			So(ns.HandleClassCSignal(11, now), ShouldBeFalse)
+			So(ns.GetDeviceClass(0, now), ShouldEqual, DeviceClassA)
+		})
+
+		Convey("When handling an uplink on the Class-C FPort", func() {
+			So(ns.HandleClassCSignal(10, now), ShouldBeTrue)
+
+			Convey("Then the node is Class-C until the window has expired", func() {
+				So(ns.ClassCUntil, ShouldResemble, now.Add(time.Minute))
+				So(ns.GetDeviceClass(0, now.Add(59*time.Second)), ShouldEqual, DeviceClassC)
+				So(ns.GetDeviceClass(0, now.Add(time.Minute)), ShouldEqual, DeviceClassA)
+			})
+		})
+
+		Convey("Then the Class-C window is not opened when the FPort is disabled", func() {
+			ns.ClassCFPort = 0
+			So(ns.HandleClassCSignal(0, now), ShouldBeFalse)
+		})
+	})
+}
+
+func TestMergeNodeSession(t *testing.T) {
+	Convey("Given a node-session which has been read and updated concurrently", t, func() {
+		orig := NodeSession{FCntUp: 10, FCntDown: 5, ADRInterval: 1, TXPower: 2, Version: 3}
+		stored := orig
+		stored.FCntDown = 6
+		stored.ADRInterval = 10
+		stored.Version = 4
+
+		Convey("When merging the changes of an uplink", func() {
+			updated := orig
+			updated.FCntUp = 11
+			updated.FCntDown = 6
+			updated.TXPower = 5
+			MergeNodeSession(&stored, orig, updated)
+
+			Convey("Then only the changed fields are applied", func() {
+				So(stored.FCntUp, ShouldEqual, 11)
+				So(stored.TXPower, ShouldEqual, 5)
+				So(stored.ADRInterval, ShouldEqual, 10)
+				So(stored.Version, ShouldEqual, 4)
+			})
+		})
+
+		Convey("When merging a lower frame-counter", func() {
+			updated := orig
+			updated.FCntDown = 4
+			MergeNodeSession(&stored, orig, updated)
+
+			Convey("Then the highest frame-counter is kept", func() {
+				So(stored.FCntDown, ShouldEqual, 6)
+			})
+		})
+	})
+}
+
+func TestGetDeviceActivation(t *testing.T) {
+	Convey("Given a Class-C node-session with CFList", t, func() {
+		ns := NodeSession{
+			DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+			AppEUI:      lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			NwkSKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			FCntUp:      10,
+			FCntDown:    5,
+			RX1DROffset: 1,
+			RX2DR:       3,
+			DeviceClass: DeviceClassC,
+			CFList:      &lorawan.CFList{867100000, 867300000},
+		}
+
+		Convey("Then GetDeviceActivation returns the expected activation", func() {
+			So(GetDeviceActivation(ns), ShouldResemble, DeviceActivation{
+				DevEUI:             ns.DevEUI,
+				AppEUI:             ns.AppEUI,
+				DevAddr:            ns.DevAddr,
+				MACVersion:         DeviceActivationMACVersion,
+				NwkSKey:            ns.NwkSKey,
+				FCntUp:             10,
+				FCntDown:           5,
+				RXDelay1:           1,
+				RX1DROffset:        1,
+				RX2DataRate:        3,
+				RX2Freq:            869.525,
+				FactoryPresetFreqs: []float64{868.1, 868.3, 868.5, 867.1, 867.3},
+				SupportsClassC:     true,
+			})
+		})
+	})
+}
+
+func TestUnwrapAppSKey(t *testing.T) {
+	Convey("Given a wrapped AppSKey", t, func() {
+		kek := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+		appSKey := lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+		wrapped, err := keywrap.Wrap(kek, appSKey[:])
+		So(err, ShouldBeNil)
+
+		Reset(func() {
+			common.AppSKeyKEK = nil
+		})
+
+		Convey("When no KEK is configured", func() {
+			Convey("Then UnwrapAppSKey returns ErrAppSKeyKEKNotConfigured", func() {
+				_, err := UnwrapAppSKey(wrapped)
+				So(err, ShouldEqual, ErrAppSKeyKEKNotConfigured)
+			})
+
+			Convey("Then UnwrapAppSKey returns nil for an empty wrapped key", func() {
+				key, err := UnwrapAppSKey(nil)
+				So(err, ShouldBeNil)
+				So(key, ShouldBeNil)
+			})
+		})
+
+		Convey("When the KEK is configured", func() {
+			common.AppSKeyKEK = kek
+
+			Convey("Then UnwrapAppSKey returns the AppSKey", func() {
+				key, err := UnwrapAppSKey(wrapped)
+				So(err, ShouldBeNil)
+				So(*key, ShouldEqual, appSKey)
+			})
+
+			Convey("Then UnwrapAppSKey returns ErrInvalidWrappedAppSKey on a corrupted key", func() {
+				wrapped[0]++
+				_, err := UnwrapAppSKey(wrapped)
+				So(err, ShouldEqual, ErrInvalidWrappedAppSKey)
+			})
+		})
+	})
+}
+
 func TestNodeSession(t *testing.T) {
 	conf := test.GetConfig()
 
@@ -106,6 +284,15 @@ func TestNodeSession(t *testing.T) {
 				})
 			})
 
+			Convey("When patching a non-existing NodeSession", func() {
+				_, err := PatchNodeSession(p, ns.DevEUI, func(s *NodeSession) error {
+					return nil
+				})
+				Convey("Then ErrDoesNotExist is returned", func() {
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+
 			Convey("When checking if a non-existing NodeSession exists", func() {
 				exists, err := NodeSessionExists(p, ns.DevEUI)
 				So(err, ShouldBeNil)
@@ -117,6 +304,42 @@ func TestNodeSession(t *testing.T) {
 
 			Convey("When saving the NodeSession", func() {
 				So(SaveNodeSession(p, ns), ShouldBeNil)
+				ns.Version = 1
+
+				Convey("When updating the NodeSession with the current version", func() {
+					ns2 := ns
+					ns2.FCntUp = 10
+					So(UpdateNodeSession(p, &ns2), ShouldBeNil)
+
+					Convey("Then the version has been incremented", func() {
+						So(ns2.Version, ShouldEqual, 2)
+						ns3, err := GetNodeSession(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(ns3, ShouldResemble, ns2)
+					})
+
+					Convey("Then updating with the outdated version fails", func() {
+						stale := ns
+						stale.FCntDown = 5
+						So(UpdateNodeSession(p, &stale), ShouldEqual, ErrConcurrentUpdate)
+					})
+
+					Convey("When saving the changes of the outdated node-session", func() {
+						stale := ns
+						stale.FCntDown = 5
+						stale.FCntUp = 8
+						So(SaveNodeSessionChanges(p, ns, &stale), ShouldBeNil)
+
+						Convey("Then the changes have been merged", func() {
+							ns3, err := GetNodeSession(p, ns.DevEUI)
+							So(err, ShouldBeNil)
+							So(ns3.FCntDown, ShouldEqual, 5)
+							So(ns3.FCntUp, ShouldEqual, 10)
+							So(ns3.Version, ShouldEqual, 3)
+							So(stale, ShouldResemble, ns3)
+						})
+					})
+				})
 
 				Convey("Then when getting the NodeSessions for its DevAddr, it contains the NodeSession", func() {
 					sessions, err := GetNodeSessionsForDevAddr(p, ns.DevAddr)
@@ -140,6 +363,43 @@ func TestNodeSession(t *testing.T) {
 					})
 				})
 
+				Convey("When patching the NodeSession while it is updated concurrently", func() {
+					var calls int
+					ns2, err := PatchNodeSession(p, ns.DevEUI, func(s *NodeSession) error {
+						calls++
+						if calls == 1 {
+							// simulate a concurrent update
+							concurrent := ns
+							concurrent.FCntUp = 10
+							So(SaveNodeSession(p, concurrent), ShouldBeNil)
+						}
+						s.RXDelay = 3
+						return nil
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then the patch was re-applied on the updated NodeSession", func() {
+						So(calls, ShouldEqual, 2)
+						So(ns2.FCntUp, ShouldEqual, 10)
+						So(ns2.RXDelay, ShouldEqual, 3)
+
+						ns3, err := GetNodeSession(p, ns.DevEUI)
+						So(err, ShouldBeNil)
+						So(ns3, ShouldResemble, ns2)
+					})
+				})
+
+				Convey("When patching the NodeSession with a changed DevAddr", func() {
+					_, err := PatchNodeSession(p, ns.DevEUI, func(s *NodeSession) error {
+						s.DevAddr = lorawan.DevAddr{4, 3, 2, 1}
+						return nil
+					})
+
+					Convey("Then an error is returned", func() {
+						So(err, ShouldNotBeNil)
+					})
+				})
+
 				Convey("When deleting a NodeSession", func() {
 					So(DeleteNodeSession(p, ns.DevEUI), ShouldBeNil)
 
@@ -158,16 +418,16 @@ func TestNodeSession(t *testing.T) {
 					FullFCnt   uint32
 					Valid      bool
 				}{
-					{0, 1, 1, true},                                                               // one packet was lost
-					{1, 1, 1, true},                                                               // ideal case, the FCnt has the expected value
-					{2, 1, 0, false},                                                              // old packet received or re-transmission
-					{0, common.Band.MaxFCntGap, 0, false},                                         // gap should be less than MaxFCntGap
+					{0, 1, 1, true},                       // one packet was lost
+					{1, 1, 1, true},                       // ideal case, the FCnt has the expected value
+					{2, 1, 0, false},                      // old packet received or re-transmission
+					{0, common.Band.MaxFCntGap, 0, false}, // gap should be less than MaxFCntGap
 					{0, common.Band.MaxFCntGap - 1, common.Band.MaxFCntGap - 1, true},             // gap is exactly within the allowed MaxFCntGap
 					{65536, common.Band.MaxFCntGap - 1, common.Band.MaxFCntGap - 1 + 65536, true}, // roll-over happened, gap ix exactly within allowed MaxFCntGap
 					{65535, common.Band.MaxFCntGap, 0, false},                                     // roll-over happened, but too many lost frames
-					{65535, 0, 65536, true},                                                       // roll-over happened
-					{65536, 0, 65536, true},                                                       // re-transmission
-					{4294967295, 0, 0, true},                                                      // 32 bit roll-over happened, counter started at 0 again
+					{65535, 0, 65536, true},  // roll-over happened
+					{65536, 0, 65536, true},  // re-transmission
+					{4294967295, 0, 0, true}, // 32 bit roll-over happened, counter started at 0 again
 				}
 
 				for _, test := range testTable {
@@ -221,7 +481,9 @@ func TestGetNodeSessionForPHYPayload(t *testing.T) {
 				FCnt           uint32
 				ExpectedDevEUI lorawan.EUI64
 				ExpectedFCntUp uint32
+				ExpectedReset  bool
 				ExpectedError  error
+				StrictMode     bool
 			}{
 				{
 					Name:           "matching DevEUI 0101010101010101",
@@ -245,8 +507,17 @@ func TestGetNodeSessionForPHYPayload(t *testing.T) {
 					NwkSKey:        nodeSessions[0].NwkSKey,
 					FCnt:           0,
 					ExpectedFCntUp: 0, // has been reset
+					ExpectedReset:  true,
 					ExpectedDevEUI: nodeSessions[0].DevEUI,
 				},
+				{
+					Name:          "matching DevEUI 0101010101010101 with frame counter reset in strict security mode",
+					DevAddr:       devAddr,
+					NwkSKey:       nodeSessions[0].NwkSKey,
+					FCnt:          0,
+					StrictMode:    true,
+					ExpectedError: errors.New("node-session does not exist or invalid fcnt or mic"),
+				},
 				{
 					Name:          "matching DevEUI 0202020202020202 with invalid frame counter",
 					DevAddr:       devAddr,
@@ -270,37 +541,45 @@ func TestGetNodeSessionForPHYPayload(t *testing.T) {
 				},
 			}
 
-			for i, test := range testTable {
-				Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
-					phy := lorawan.PHYPayload{
-						MHDR: lorawan.MHDR{
-							MType: lorawan.UnconfirmedDataUp,
-							Major: lorawan.LoRaWANR1,
-						},
-						MACPayload: &lorawan.MACPayload{
-							FHDR: lorawan.FHDR{
-								DevAddr: test.DevAddr,
-								FCtrl:   lorawan.FCtrl{},
-								FCnt:    test.FCnt,
+			for _, workers := range []int{1, 2} {
+				for i, test := range testTable {
+					Convey(fmt.Sprintf("Testing: %s with %d mic validation worker(s) [%d]", test.Name, workers, i), func() {
+						defer func(w int) { common.MICValidationWorkers = w }(common.MICValidationWorkers)
+						common.MICValidationWorkers = workers
+						common.SecurityStrictMode = test.StrictMode
+						defer func() { common.SecurityStrictMode = false }()
+
+						phy := lorawan.PHYPayload{
+							MHDR: lorawan.MHDR{
+								MType: lorawan.UnconfirmedDataUp,
+								Major: lorawan.LoRaWANR1,
 							},
-						},
-					}
-					So(phy.SetMIC(test.NwkSKey), ShouldBeNil)
-
-					ns, err := GetNodeSessionForPHYPayload(p, phy)
-					So(err, ShouldResemble, test.ExpectedError)
-					if test.ExpectedError != nil {
-						return
-					}
-
-					// "refresh" the ns, to test if the FCnt has been updated
-					// in case of a frame counter reset
-					ns, err = GetNodeSession(p, ns.DevEUI)
-					So(err, ShouldBeNil)
+							MACPayload: &lorawan.MACPayload{
+								FHDR: lorawan.FHDR{
+									DevAddr: test.DevAddr,
+									FCtrl:   lorawan.FCtrl{},
+									FCnt:    test.FCnt,
+								},
+							},
+						}
+						So(phy.SetMIC(test.NwkSKey), ShouldBeNil)
+
+						ns, reset, err := GetNodeSessionForPHYPayload(p, phy)
+						So(err, ShouldResemble, test.ExpectedError)
+						if test.ExpectedError != nil {
+							return
+						}
+						So(reset, ShouldEqual, test.ExpectedReset)
+
+						// "refresh" the ns, to test if the FCnt has been updated
+						// in case of a frame counter reset
+						ns, err = GetNodeSession(p, ns.DevEUI)
+						So(err, ShouldBeNil)
 
-					So(ns.DevEUI, ShouldResemble, test.ExpectedDevEUI)
-					So(ns.FCntUp, ShouldEqual, test.ExpectedFCntUp)
-				})
+						So(ns.DevEUI, ShouldResemble, test.ExpectedDevEUI)
+						So(ns.FCntUp, ShouldEqual, test.ExpectedFCntUp)
+					})
+				}
 			}
 		})
 	})
@@ -0,0 +1,215 @@
+package sla
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/leader"
+	"github.com/joriwind/loraserver/internal/webhook"
+)
+
+// Status contains the downlink SLA status over a window.
+type Status struct {
+	Uplinks        int            `json:"uplinkCount"`
+	Late           int            `json:"lateCount"`
+	LatePercentage float64        `json:"latePercentage"`
+	Causes         map[string]int `json:"causes"`
+}
+
+// Alert contains a change of the downlink SLA state.
+type Alert struct {
+	Status
+	Time      time.Time `json:"time"`
+	Resolved  bool      `json:"resolved"`
+	Window    string    `json:"window"`
+	Threshold float64   `json:"threshold"`
+}
+
+// Publisher defines the interface for publishing the SLA alerts.
+type Publisher interface {
+	Publish(a Alert) error
+}
+
+// ControllerPublisher publishes the SLA alerts to the network-controller.
+type ControllerPublisher struct {
+	Client nc.NetworkControllerClient
+}
+
+// Publish publishes the given alert.
+func (p *ControllerPublisher) Publish(a Alert) error {
+	req := nc.HandleSLAAlertRequest{
+		Time:           a.Time.Format(time.RFC3339Nano),
+		Resolved:       a.Resolved,
+		Window:         uint32(common.SLAWindow / time.Second),
+		Threshold:      a.Threshold,
+		UplinkCount:    uint32(a.Uplinks),
+		LateCount:      uint32(a.Late),
+		LatePercentage: a.LatePercentage,
+		Causes:         make(map[string]uint32),
+	}
+	for cause, count := range a.Causes {
+		req.Causes[cause] = uint32(count)
+	}
+
+	if _, err := p.Client.HandleSLAAlert(context.Background(), &req); err != nil {
+		return errors.Wrap(err, "handle sla alert error")
+	}
+	return nil
+}
+
+// HTTPPublisher posts the SLA alerts as JSON (HTTP POST) to the given URL
+// (webhook).
+type HTTPPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPPublisher creates a new HTTPPublisher.
+func NewHTTPPublisher(url string) *HTTPPublisher {
+	return &HTTPPublisher{
+		URL:    url,
+		Client: webhook.NewClient(),
+	}
+}
+
+// Publish posts the given alert.
+func (p *HTTPPublisher) Publish(a Alert) error {
+	return webhook.Post(p.Client, p.URL, a)
+}
+
+// GetStatus returns the downlink SLA status over the common.SLAWindow
+// before the given time.
+func GetStatus(p *redis.Pool, now time.Time) (Status, error) {
+	status := Status{
+		Causes: make(map[string]int),
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	var keys []string
+	for t := now.Add(-common.SLAWindow); !t.After(now); t = t.Add(time.Minute) {
+		keys = append(keys, getBucketKey(t))
+	}
+	for _, key := range keys {
+		c.Send("HGETALL", key)
+	}
+	c.Flush()
+
+	for range keys {
+		values, err := redis.StringMap(c.Receive())
+		if err != nil {
+			return status, errors.Wrap(err, "get sla counters error")
+		}
+		for field, value := range values {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return status, errors.Wrap(err, "parse sla counter error")
+			}
+			switch {
+			case field == "uplinks":
+				status.Uplinks += n
+			case field == "late":
+				status.Late += n
+			case strings.HasPrefix(field, "cause:"):
+				status.Causes[strings.TrimPrefix(field, "cause:")] += n
+			}
+		}
+	}
+
+	if status.Uplinks > 0 {
+		status.LatePercentage = float64(status.Late) / float64(status.Uplinks) * 100
+	}
+	return status, nil
+}
+
+// monitor keeps the alert state of the downlink SLA.
+type monitor struct {
+	publishers []Publisher
+	alerting   bool
+}
+
+// check compares the SLA status at the given time against the threshold
+// and publishes an alert when the state changed. The threshold is only
+// evaluated when the window contains at least common.SLAMinUplinks
+// uplinks.
+func (m *monitor) check(p *redis.Pool, now time.Time) error {
+	status, err := GetStatus(p, now)
+	if err != nil {
+		return err
+	}
+	if status.Uplinks < common.SLAMinUplinks {
+		return nil
+	}
+
+	breached := status.LatePercentage > common.SLALateThreshold
+	if breached == m.alerting {
+		return nil
+	}
+	m.alerting = breached
+
+	a := Alert{
+		Status:    status,
+		Time:      now,
+		Resolved:  !breached,
+		Window:    common.SLAWindow.String(),
+		Threshold: common.SLALateThreshold,
+	}
+
+	logFields := log.Fields{
+		"uplinks":         status.Uplinks,
+		"late":            status.Late,
+		"late_percentage": status.LatePercentage,
+		"threshold":       common.SLALateThreshold,
+		"window":          common.SLAWindow,
+	}
+	for cause, count := range status.Causes {
+		logFields["cause_"+cause] = count
+	}
+	if breached {
+		log.WithFields(logFields).Warning("downlink sla threshold exceeded")
+	} else {
+		log.WithFields(logFields).Info("downlink sla threshold no longer exceeded")
+	}
+
+	for _, pub := range m.publishers {
+		if err := pub.Publish(a); err != nil {
+			log.Errorf("publish sla alert error: %s", err)
+		}
+	}
+	return nil
+}
+
+// Run checks the downlink SLA every minute and publishes an alert to the
+// given publishers when the percentage of late downlinks exceeds (or drops
+// below again) the common.SLALateThreshold. When an elector is given, the
+// SLA is only checked by the leader. It returns when done is closed.
+func Run(p *redis.Pool, elector *leader.Elector, publishers []Publisher, done chan struct{}) {
+	m := monitor{publishers: publishers}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if elector != nil && !elector.IsLeader() {
+				continue
+			}
+			if err := m.check(p, time.Now()); err != nil {
+				log.Errorf("check downlink sla error: %s", err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
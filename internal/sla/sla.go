@@ -0,0 +1,179 @@
+// Package sla implements the monitoring of the downlink SLA: the percentage
+// of uplinks of which the downlink was published after the RX1 deadline
+// (or dropped because of the deadline). For each uplink, the time spent in
+// the stages of the handling is measured, so that the late downlinks can be
+// broken down by cause (the stage which took the most time). The counts
+// are aggregated per minute in Redis (shared by all LoRa Server instances)
+// and an alert is published when the percentage exceeds the threshold over
+// the window (see Run).
+package sla
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/counters"
+)
+
+// Stages of the uplink handling, used as cause of a late downlink.
+const (
+	// StageCollect is the collection (de-duplication) of the uplink.
+	StageCollect = "collect"
+
+	// StageDownlinkDataDelay is the delay before getting the downlink data
+	// (common.GetDownlinkDataDelay).
+	StageDownlinkDataDelay = "downlink_data_delay"
+
+	// StageApplicationServer is getting the downlink data from the
+	// application-server.
+	StageApplicationServer = "application_server"
+
+	// StageGateway is publishing the downlink to the gateway backend.
+	StageGateway = "gateway"
+
+	// StageProcessing is the remaining time (e.g. Redis and PostgreSQL).
+	StageProcessing = "processing"
+)
+
+// bucketKeyTempl contains per minute the number of uplinks with a downlink,
+// the number of late downlinks and the late downlinks per cause (hash).
+const bucketKeyTempl = "lora:ns:sla:%d"
+
+type timingKey struct{}
+
+// Timing contains the timing of the handling of a single uplink.
+type Timing struct {
+	sync.Mutex
+	ReceivedAt  time.Time
+	PublishedAt time.Time // first publication of the downlink (if any)
+	Dropped     bool      // the downlink was dropped because of the deadline
+	DroppedAt   time.Time
+	Stages      map[string]time.Duration
+}
+
+// NewContext returns a copy of the given context carrying a new Timing of
+// the uplink received at the given time.
+func NewContext(ctx common.Context, receivedAt time.Time) (common.Context, *Timing) {
+	t := &Timing{
+		ReceivedAt: receivedAt,
+		Stages:     make(map[string]time.Duration),
+	}
+	return ctx.WithValue(timingKey{}, t), t
+}
+
+// FromContext returns the Timing of the given context (nil when the
+// context does not carry a Timing, e.g. for Class-C downlinks).
+func FromContext(ctx common.Context) *Timing {
+	t, _ := ctx.RequestContext().Value(timingKey{}).(*Timing)
+	return t
+}
+
+// Observe adds the given duration to the given stage. It is a no-op on a
+// nil Timing.
+func (t *Timing) Observe(stage string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	t.Stages[stage] += d
+}
+
+// SetPublished records the publication of the downlink at the given time.
+// Only the first publication is recorded (e.g. in case of transmit
+// diversity). It is a no-op on a nil Timing.
+func (t *Timing) SetPublished(at time.Time) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.PublishedAt.IsZero() {
+		t.PublishedAt = at
+	}
+}
+
+// SetDropped records that the downlink was dropped at the given time
+// because of the deadline. It is a no-op on a nil Timing.
+func (t *Timing) SetDropped(at time.Time) {
+	if t == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	if !t.Dropped {
+		t.Dropped = true
+		t.DroppedAt = at
+	}
+}
+
+// Late returns if the downlink was published after the given RX1 deadline
+// (or dropped because of the deadline) and the cause. It returns false
+// when no downlink was published.
+func (t *Timing) Late(rx1Deadline time.Time) (bool, string) {
+	t.Lock()
+	defer t.Unlock()
+
+	end := t.PublishedAt
+	if end.IsZero() {
+		if !t.Dropped {
+			return false, ""
+		}
+		end = t.DroppedAt
+	} else if !end.After(rx1Deadline) {
+		return false, ""
+	}
+
+	// the remaining time is accounted to the processing
+	stages := map[string]time.Duration{
+		StageProcessing: end.Sub(t.ReceivedAt),
+	}
+	for stage, d := range t.Stages {
+		stages[stage] = d
+		stages[StageProcessing] -= d
+	}
+
+	var cause string
+	var max time.Duration
+	for _, stage := range []string{StageCollect, StageDownlinkDataDelay, StageApplicationServer, StageGateway, StageProcessing} {
+		if d := stages[stage]; cause == "" || d > max {
+			cause, max = stage, d
+		}
+	}
+	return true, cause
+}
+
+// Record records the outcome of the given uplink handling, given the RX1
+// deadline of the uplink. Uplinks without downlink are not recorded. It is
+// a no-op when the SLA monitoring is disabled or on a nil Timing.
+func Record(p *redis.Pool, t *Timing, rx1Deadline time.Time) error {
+	if common.SLALateThreshold <= 0 || t == nil {
+		return nil
+	}
+
+	t.Lock()
+	published := !t.PublishedAt.IsZero() || t.Dropped
+	t.Unlock()
+	if !published {
+		return nil
+	}
+
+	key := getBucketKey(t.ReceivedAt)
+	ttl := common.SLAWindow + 2*time.Minute
+
+	var b counters.Batch
+	b.HIncrBy(key, "uplinks", 1, ttl)
+	if late, cause := t.Late(rx1Deadline); late {
+		b.HIncrBy(key, "late", 1, ttl)
+		b.HIncrBy(key, "cause:"+cause, 1, ttl)
+	}
+	return counters.Add(p, b)
+}
+
+func getBucketKey(t time.Time) string {
+	return fmt.Sprintf(bucketKeyTempl, t.Unix()/60)
+}
@@ -0,0 +1,125 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+type testPublisher struct {
+	alerts []Alert
+}
+
+func (p *testPublisher) Publish(a Alert) error {
+	p.alerts = append(p.alerts, a)
+	return nil
+}
+
+func TestTiming(t *testing.T) {
+	Convey("Given a context carrying a Timing", t, func() {
+		receivedAt := time.Now()
+		rx1Deadline := receivedAt.Add(900 * time.Millisecond)
+		ctx, timing := NewContext(common.Context{}, receivedAt)
+
+		So(FromContext(ctx), ShouldEqual, timing)
+		So(FromContext(common.Context{}), ShouldBeNil)
+
+		Convey("When no downlink was published", func() {
+			Convey("Then it is not late", func() {
+				late, _ := timing.Late(rx1Deadline)
+				So(late, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the downlink was published before the RX1 deadline", func() {
+			timing.SetPublished(receivedAt.Add(500 * time.Millisecond))
+
+			Convey("Then it is not late", func() {
+				late, _ := timing.Late(rx1Deadline)
+				So(late, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the downlink was published after the RX1 deadline", func() {
+			timing.Observe(StageCollect, 200*time.Millisecond)
+			timing.Observe(StageApplicationServer, 600*time.Millisecond)
+			timing.SetPublished(receivedAt.Add(time.Second))
+
+			Convey("Then it is late because of the application-server", func() {
+				late, cause := timing.Late(rx1Deadline)
+				So(late, ShouldBeTrue)
+				So(cause, ShouldEqual, StageApplicationServer)
+			})
+		})
+
+		Convey("When the downlink was dropped because of the deadline", func() {
+			timing.Observe(StageCollect, 100*time.Millisecond)
+			timing.SetDropped(receivedAt.Add(time.Second))
+
+			Convey("Then it is late because of the processing", func() {
+				late, cause := timing.Late(rx1Deadline)
+				So(late, ShouldBeTrue)
+				So(cause, ShouldEqual, StageProcessing)
+			})
+		})
+	})
+}
+
+func TestMonitor(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and the SLA monitoring enabled", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.SLALateThreshold = 10
+		common.SLAMinUplinks = 2
+		defer func() {
+			common.SLALateThreshold = 0
+			common.SLAMinUplinks = 100
+		}()
+
+		pub := testPublisher{}
+		m := monitor{publishers: []Publisher{&pub}}
+		now := time.Now()
+
+		Convey("When recording a late and an on-time downlink", func() {
+			for _, d := range []time.Duration{time.Second, 100 * time.Millisecond} {
+				_, timing := NewContext(common.Context{}, now)
+				timing.Observe(StageApplicationServer, d)
+				timing.SetPublished(now.Add(d))
+				So(Record(p, timing, now.Add(900*time.Millisecond)), ShouldBeNil)
+			}
+
+			Convey("Then the status contains the late downlink and its cause", func() {
+				status, err := GetStatus(p, now)
+				So(err, ShouldBeNil)
+				So(status.Uplinks, ShouldEqual, 2)
+				So(status.Late, ShouldEqual, 1)
+				So(status.LatePercentage, ShouldEqual, 50)
+				So(status.Causes, ShouldResemble, map[string]int{StageApplicationServer: 1})
+			})
+
+			Convey("Then an alert is published once", func() {
+				So(m.check(p, now), ShouldBeNil)
+				So(m.check(p, now), ShouldBeNil)
+				So(pub.alerts, ShouldHaveLength, 1)
+				So(pub.alerts[0].Resolved, ShouldBeFalse)
+
+				Convey("When the threshold is no longer exceeded", func() {
+					common.SLALateThreshold = 60
+					So(m.check(p, now), ShouldBeNil)
+
+					Convey("Then a resolved alert is published", func() {
+						So(pub.alerts, ShouldHaveLength, 2)
+						So(pub.alerts[1].Resolved, ShouldBeTrue)
+					})
+				})
+			})
+		})
+	})
+}
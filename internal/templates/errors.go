@@ -0,0 +1,14 @@
+package templates
+
+import "errors"
+
+// templates errors
+var (
+	ErrDoesNotExist      = errors.New("downlink template does not exist")
+	ErrInvalidName       = errors.New("downlink template name must not be empty")
+	ErrInvalidFPort      = errors.New("downlink template fport must be between 1 and 223")
+	ErrInvalidPayload    = errors.New("invalid downlink template payload")
+	ErrUnknownVariable   = errors.New("unknown downlink template variable")
+	ErrTagDoesNotExist   = errors.New("tag referenced by the downlink template does not exist")
+	ErrNoTargetSpecified = errors.New("devEUI or tags must be given")
+)
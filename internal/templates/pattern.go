@@ -0,0 +1,126 @@
+package templates
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// tagVariablePrefix is the prefix of the variables substituted by the
+// value of a tag of the node (e.g. {{tag:site}}).
+const tagVariablePrefix = "tag:"
+
+// variables contains the variables which can be used within a payload
+// pattern and their (binary) encoding.
+var variables = map[string]func(ns session.NodeSession, now time.Time) []byte{
+	// current Unix time (seconds), 4 bytes big-endian
+	"time": func(ns session.NodeSession, now time.Time) []byte {
+		return uint32BE(uint32(now.Unix()))
+	},
+	"dev_eui": func(ns session.NodeSession, now time.Time) []byte {
+		return ns.DevEUI[:]
+	},
+	"dev_addr": func(ns session.NodeSession, now time.Time) []byte {
+		return ns.DevAddr[:]
+	},
+	// frame-counters, 4 bytes big-endian
+	"f_cnt_up": func(ns session.NodeSession, now time.Time) []byte {
+		return uint32BE(ns.FCntUp)
+	},
+	"f_cnt_down": func(ns session.NodeSession, now time.Time) []byte {
+		return uint32BE(ns.FCntDown)
+	},
+	// 1 byte
+	"tx_power": func(ns session.NodeSession, now time.Time) []byte {
+		return []byte{byte(ns.TXPower)}
+	},
+	"rx2_dr": func(ns session.NodeSession, now time.Time) []byte {
+		return []byte{ns.RX2DR}
+	},
+	"battery_level": func(ns session.NodeSession, now time.Time) []byte {
+		return []byte{ns.BatteryLevel}
+	},
+}
+
+// segment is a part of a parsed payload pattern, either literal data or a
+// variable.
+type segment struct {
+	data     []byte
+	variable string
+}
+
+// parsePattern parses the given payload pattern. A pattern consists of HEX
+// encoded bytes and variables between double curly braces, e.g.
+// 01{{time}}{{tag:site}}.
+func parsePattern(pattern string) ([]segment, error) {
+	var out []segment
+	rest := strings.Replace(pattern, " ", "", -1)
+
+	for rest != "" {
+		i := strings.Index(rest, "{{")
+		if i == -1 {
+			i = len(rest)
+		}
+
+		if i > 0 {
+			b, err := hex.DecodeString(rest[:i])
+			if err != nil {
+				return nil, errors.Wrap(ErrInvalidPayload, err.Error())
+			}
+			out = append(out, segment{data: b})
+			rest = rest[i:]
+			continue
+		}
+
+		j := strings.Index(rest, "}}")
+		if j == -1 {
+			return nil, errors.Wrap(ErrInvalidPayload, "missing }}")
+		}
+		name := rest[2:j]
+		if _, ok := variables[name]; !ok && !(strings.HasPrefix(name, tagVariablePrefix) && len(name) > len(tagVariablePrefix)) {
+			return nil, errors.Wrap(ErrUnknownVariable, name)
+		}
+		out = append(out, segment{variable: name})
+		rest = rest[j+2:]
+	}
+
+	return out, nil
+}
+
+// Render returns the payload of the given pattern, substituting the
+// variables by the values of the given node-session.
+func Render(pattern string, ns session.NodeSession, now time.Time) ([]byte, error) {
+	segments, err := parsePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	for _, s := range segments {
+		switch {
+		case s.variable == "":
+			out = append(out, s.data...)
+		case strings.HasPrefix(s.variable, tagVariablePrefix):
+			v, ok := ns.Tags[strings.TrimPrefix(s.variable, tagVariablePrefix)]
+			if !ok {
+				return nil, errors.Wrap(ErrTagDoesNotExist, s.variable)
+			}
+			out = append(out, v...)
+		default:
+			out = append(out, variables[s.variable](ns, now)...)
+		}
+	}
+
+	return out, nil
+}
+
+func uint32BE(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
@@ -0,0 +1,54 @@
+package templates
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+func TestRender(t *testing.T) {
+	Convey("Given a node-session and a testtable", t, func() {
+		ns := session.NodeSession{
+			DevAddr:      lorawan.DevAddr{1, 2, 3, 4},
+			DevEUI:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCntUp:       258,
+			BatteryLevel: 200,
+			Tags:         models.Tags{"site": "A1"},
+		}
+		now := time.Unix(1500000000, 0)
+
+		testTable := []struct {
+			Pattern       string
+			Expected      []byte
+			ExpectedError error
+		}{
+			{"0102ff", []byte{1, 2, 255}, nil},
+			{"01 {{time}}", []byte{1, 0x59, 0x68, 0x2f, 0x00}, nil},
+			{"{{dev_addr}}{{f_cnt_up}}", []byte{1, 2, 3, 4, 0, 0, 1, 2}, nil},
+			{"{{dev_eui}}{{battery_level}}", []byte{1, 2, 3, 4, 5, 6, 7, 8, 200}, nil},
+			{"02{{tag:site}}", []byte{2, 'A', '1'}, nil},
+			{"", nil, nil},
+			{"0", nil, ErrInvalidPayload},
+			{"01{{time", nil, ErrInvalidPayload},
+			{"{{reboot}}", nil, ErrUnknownVariable},
+			{"{{tag:}}", nil, ErrUnknownVariable},
+			{"{{tag:customer}}", nil, ErrTagDoesNotExist},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Pattern, i), func() {
+				b, err := Render(test.Pattern, ns, now)
+				So(errors.Cause(err), ShouldEqual, test.ExpectedError)
+				So(b, ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
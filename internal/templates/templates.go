@@ -0,0 +1,267 @@
+// Package templates implements the downlink templates. A template defines
+// a downlink payload (FPort and payload pattern) of which the variables
+// (e.g. the current time or session values) are substituted per node, so
+// that standardized configuration payloads can be enqueued for a node or a
+// group of nodes without a round trip to the application-server.
+package templates
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// maxFPort is the max FPort which can be used by a template (224 is
+// reserved for the LoRaWAN MAC layer test protocol).
+const maxFPort = 223
+
+// listNodeSessionsBatchSize defines the number of node-sessions read per
+// batch when triggering a template for a group of nodes.
+const listNodeSessionsBatchSize = 1000
+
+// Template defines a downlink template.
+type Template struct {
+	ID        int64  `db:"id"`
+	Name      string `db:"name"`
+	FPort     int    `db:"f_port"`
+	Payload   string `db:"payload"` // payload pattern (see Render)
+	Confirmed bool   `db:"confirmed"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate validates the template.
+func (t Template) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrInvalidName
+	}
+	if t.FPort < 1 || t.FPort > maxFPort {
+		return ErrInvalidFPort
+	}
+	if _, err := parsePattern(t.Payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reference returns the reference of the device-queue items enqueued by
+// the template (included in the ACK notification of confirmed payloads).
+func (t Template) reference() string {
+	return fmt.Sprintf("template:%d", t.ID)
+}
+
+// CreateTemplate validates and creates the given template. On success, the
+// ID and timestamps of the template are set.
+func CreateTemplate(db *sqlx.DB, t *Template) error {
+	if err := t.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	err := db.Get(&t.ID, `
+		insert into downlink_template (
+			name,
+			f_port,
+			payload,
+			confirmed,
+			created_at,
+			updated_at
+		) values ($1, $2, $3, $4, $5, $5)
+		returning id`,
+		t.Name,
+		t.FPort,
+		t.Payload,
+		t.Confirmed,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	log.WithFields(log.Fields{
+		"id":   t.ID,
+		"name": t.Name,
+	}).Info("downlink template created")
+	return nil
+}
+
+// GetTemplate returns the template for the given ID.
+func GetTemplate(db *sqlx.DB, id int64) (Template, error) {
+	var t Template
+	err := db.Get(&t, "select * from downlink_template where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return t, ErrDoesNotExist
+		}
+		return t, errors.Wrap(err, "select error")
+	}
+	return t, nil
+}
+
+// UpdateTemplate validates and updates the given template.
+func UpdateTemplate(db *sqlx.DB, t *Template) error {
+	if err := t.Validate(); err != nil {
+		return errors.Wrap(err, "validate error")
+	}
+
+	now := time.Now()
+	res, err := db.Exec(`
+		update downlink_template set
+			name = $2,
+			f_port = $3,
+			payload = $4,
+			confirmed = $5,
+			updated_at = $6
+		where id = $1`,
+		t.ID,
+		t.Name,
+		t.FPort,
+		t.Payload,
+		t.Confirmed,
+		now,
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	t.UpdatedAt = now
+
+	log.WithField("id", t.ID).Info("downlink template updated")
+	return nil
+}
+
+// DeleteTemplate deletes the template for the given ID.
+func DeleteTemplate(db *sqlx.DB, id int64) error {
+	res, err := db.Exec("delete from downlink_template where id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "delete error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("downlink template deleted")
+	return nil
+}
+
+// GetTemplateCount returns the number of templates.
+func GetTemplateCount(db *sqlx.DB) (int, error) {
+	var count int
+	err := db.Get(&count, "select count(*) from downlink_template")
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetTemplates returns a slice of templates, ordered by ID and respecting
+// the given limit and offset.
+func GetTemplates(db *sqlx.DB, limit, offset int) ([]Template, error) {
+	var t []Template
+	err := db.Select(&t, "select * from downlink_template order by id limit $1 offset $2", limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return t, nil
+}
+
+// TriggerError contains the error of triggering a template for a node.
+type TriggerError struct {
+	DevEUI lorawan.EUI64
+	Err    error
+}
+
+// TriggerResult contains the result of triggering a template.
+type TriggerResult struct {
+	Enqueued int
+	Errors   []TriggerError
+}
+
+// Trigger renders the given template for the given node and adds the
+// payload to its device-queue. As the payload is encrypted by LoRa Server,
+// the AppSKey encryption must be offloaded for the node.
+func Trigger(p *redis.Pool, t Template, ns session.NodeSession) error {
+	if ns.AppSKey == nil {
+		return downlink.ErrAppSKeyNotOffloaded
+	}
+
+	data, err := Render(t.Payload, ns, time.Now())
+	if err != nil {
+		return err
+	}
+
+	err = downlink.EnqueueDeviceQueueItem(p, ns, downlink.DeviceQueueItem{
+		FPort:     uint8(t.FPort),
+		Data:      data,
+		Confirmed: t.Confirmed,
+		Reference: t.reference(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "enqueue device-queue item error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":      t.ID,
+		"dev_eui": ns.DevEUI,
+	}).Info("downlink template enqueued")
+	return nil
+}
+
+// TriggerForTags triggers the given template for all the nodes having all
+// the given tags. The nodes for which the template could not be triggered
+// are returned in the result.
+func TriggerForTags(p *redis.Pool, t Template, tags models.Tags) (TriggerResult, error) {
+	var res TriggerResult
+	if len(tags) == 0 {
+		return res, ErrNoTargetSpecified
+	}
+
+	var cursor uint64
+	for {
+		sessions, next, err := session.ListNodeSessions(p, cursor, listNodeSessionsBatchSize)
+		if err != nil {
+			return res, errors.Wrap(err, "list node-sessions error")
+		}
+
+		for _, ns := range sessions {
+			if !ns.Tags.Matches(tags) {
+				continue
+			}
+			if err := Trigger(p, t, ns); err != nil {
+				res.Errors = append(res.Errors, TriggerError{DevEUI: ns.DevEUI, Err: err})
+				continue
+			}
+			res.Enqueued++
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return res, nil
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package templates
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestTemplateValidate(t *testing.T) {
+	Convey("Given a testtable", t, func() {
+		testTable := []struct {
+			Template      Template
+			ExpectedError error
+		}{
+			{Template{Name: "config", FPort: 10, Payload: "01{{time}}"}, nil},
+			{Template{FPort: 10, Payload: "01"}, ErrInvalidName},
+			{Template{Name: "config", Payload: "01"}, ErrInvalidFPort},
+			{Template{Name: "config", FPort: 224, Payload: "01"}, ErrInvalidFPort},
+			{Template{Name: "config", FPort: 10, Payload: "0x01"}, ErrInvalidPayload},
+			{Template{Name: "config", FPort: 10, Payload: "{{reboot}}"}, ErrUnknownVariable},
+		}
+
+		for i, tst := range testTable {
+			Convey(fmt.Sprintf("Testing: %d", i), func() {
+				So(errors.Cause(tst.Template.Validate()), ShouldEqual, tst.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestTemplates(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database and Redis database", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		Convey("When creating an invalid template", func() {
+			err := CreateTemplate(db, &Template{Name: "test", FPort: 10, Payload: "{{reboot}}"})
+
+			Convey("Then an error is returned", func() {
+				So(errors.Cause(err), ShouldEqual, ErrUnknownVariable)
+			})
+		})
+
+		Convey("When creating a template", func() {
+			tmpl := Template{
+				Name:      "set-site",
+				FPort:     10,
+				Payload:   "01{{tag:site}}",
+				Confirmed: true,
+			}
+			So(CreateTemplate(db, &tmpl), ShouldBeNil)
+			So(tmpl.ID, ShouldNotEqual, 0)
+
+			Convey("Then it can be retrieved", func() {
+				tmpl2, err := GetTemplate(db, tmpl.ID)
+				So(err, ShouldBeNil)
+				So(tmpl2.Name, ShouldEqual, tmpl.Name)
+				So(tmpl2.FPort, ShouldEqual, 10)
+				So(tmpl2.Payload, ShouldEqual, tmpl.Payload)
+				So(tmpl2.Confirmed, ShouldBeTrue)
+			})
+
+			Convey("Then it is listed", func() {
+				count, err := GetTemplateCount(db)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+
+				items, err := GetTemplates(db, 10, 0)
+				So(err, ShouldBeNil)
+				So(items, ShouldHaveLength, 1)
+			})
+
+			Convey("When updating the template", func() {
+				tmpl.Payload = "02{{tag:site}}"
+				So(UpdateTemplate(db, &tmpl), ShouldBeNil)
+
+				Convey("Then the template has been updated", func() {
+					tmpl2, err := GetTemplate(db, tmpl.ID)
+					So(err, ShouldBeNil)
+					So(tmpl2.Payload, ShouldEqual, "02{{tag:site}}")
+				})
+			})
+
+			Convey("Given three node-sessions", func() {
+				appSKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+				sessions := []session.NodeSession{
+					{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, DevAddr: lorawan.DevAddr{1, 1, 1, 1}, AppSKey: &appSKey, Tags: models.Tags{"site": "A1", "batch": "1"}},
+					{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, DevAddr: lorawan.DevAddr{2, 2, 2, 2}, Tags: models.Tags{"site": "A2", "batch": "1"}},
+					{DevEUI: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, DevAddr: lorawan.DevAddr{3, 3, 3, 3}, AppSKey: &appSKey, Tags: models.Tags{"batch": "2"}},
+				}
+				for _, ns := range sessions {
+					So(session.SaveNodeSession(p, ns), ShouldBeNil)
+				}
+
+				Convey("When triggering the template for a node", func() {
+					So(Trigger(p, tmpl, sessions[0]), ShouldBeNil)
+
+					Convey("Then the rendered payload has been enqueued", func() {
+						items, err := downlink.GetDeviceQueueItems(p, sessions[0].DevEUI)
+						So(err, ShouldBeNil)
+						So(items, ShouldHaveLength, 1)
+						So(items[0].FPort, ShouldEqual, 10)
+						So(items[0].Data, ShouldResemble, []byte{1, 'A', '1'})
+						So(items[0].Confirmed, ShouldBeTrue)
+						So(items[0].Reference, ShouldEqual, fmt.Sprintf("template:%d", tmpl.ID))
+					})
+				})
+
+				Convey("When triggering the template for the nodes of batch 1", func() {
+					res, err := TriggerForTags(p, tmpl, models.Tags{"batch": "1"})
+					So(err, ShouldBeNil)
+
+					Convey("Then it has been enqueued for the node with offloaded AppSKey", func() {
+						So(res.Enqueued, ShouldEqual, 1)
+						So(res.Errors, ShouldHaveLength, 1)
+						So(res.Errors[0].DevEUI, ShouldEqual, sessions[1].DevEUI)
+						So(errors.Cause(res.Errors[0].Err), ShouldEqual, downlink.ErrAppSKeyNotOffloaded)
+					})
+				})
+
+				Convey("When triggering the template for a node without the referenced tag", func() {
+					err := Trigger(p, tmpl, sessions[2])
+
+					Convey("Then an error is returned", func() {
+						So(errors.Cause(err), ShouldEqual, ErrTagDoesNotExist)
+					})
+				})
+			})
+
+			Convey("When deleting the template", func() {
+				So(DeleteTemplate(db, tmpl.ID), ShouldBeNil)
+
+				Convey("Then it does not exist anymore", func() {
+					_, err := GetTemplate(db, tmpl.ID)
+					So(err, ShouldEqual, ErrDoesNotExist)
+					So(DeleteTemplate(db, tmpl.ID), ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
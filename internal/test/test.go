@@ -24,10 +24,11 @@ func init() {
 	var err error
 	log.SetLevel(log.ErrorLevel)
 
-	common.Band, err = band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+	b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
 	if err != nil {
 		panic(err)
 	}
+	common.SetBand(b)
 	common.BandName = band.EU_863_870
 	common.DeduplicationDelay = 5 * time.Millisecond
 	common.GetDownlinkDataDelay = 5 * time.Millisecond
@@ -94,6 +95,8 @@ type GatewayBackend struct {
 	rxPacketChan    chan gw.RXPacket
 	TXPacketChan    chan gw.TXPacket
 	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	eventChan       chan gw.GatewayEvent
 }
 
 // NewGatewayBackend returns a new GatewayBackend.
@@ -101,6 +104,8 @@ func NewGatewayBackend() *GatewayBackend {
 	return &GatewayBackend{
 		rxPacketChan: make(chan gw.RXPacket, 100),
 		TXPacketChan: make(chan gw.TXPacket, 100),
+		txAckChan:    make(chan gw.TXAck, 100),
+		eventChan:    make(chan gw.GatewayEvent, 100),
 	}
 }
 
@@ -120,40 +125,63 @@ func (b *GatewayBackend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
+// TXAckChan method.
+func (b *GatewayBackend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// EventChan method.
+func (b *GatewayBackend) EventChan() chan gw.GatewayEvent {
+	return b.eventChan
+}
+
 // Close method.
 func (b *GatewayBackend) Close() error {
 	if b.rxPacketChan != nil {
 		close(b.rxPacketChan)
 	}
+	if b.txAckChan != nil {
+		close(b.txAckChan)
+	}
+	if b.eventChan != nil {
+		close(b.eventChan)
+	}
 	return nil
 }
 
 // ApplicationClient is an application client for testing.
 type ApplicationClient struct {
-	HandleDataUpErr       error
-	JoinRequestErr        error
-	GetDataDownErr        error
-	JoinRequestChan       chan as.JoinRequestRequest
-	HandleDataUpChan      chan as.HandleDataUpRequest
-	HandleDataDownACKChan chan as.HandleDataDownACKRequest
-	HandleErrorChan       chan as.HandleErrorRequest
-	GetDataDownChan       chan as.GetDataDownRequest
-
-	JoinRequestResponse       as.JoinRequestResponse
-	HandleDataUpResponse      as.HandleDataUpResponse
-	HandleDataDownACKResponse as.HandleDataDownACKResponse
-	HandleErrorResponse       as.HandleErrorResponse
-	GetDataDownResponse       as.GetDataDownResponse
+	HandleDataUpErr        error
+	JoinRequestErr         error
+	GetDataDownErr         error
+	HandleErrorErr         error
+	JoinRequestChan        chan as.JoinRequestRequest
+	HandleDataUpChan       chan as.HandleDataUpRequest
+	HandleDataDownACKChan  chan as.HandleDataDownACKRequest
+	HandleErrorChan        chan as.HandleErrorRequest
+	GetDataDownChan        chan as.GetDataDownRequest
+	HandleGatewayStatsChan chan as.HandleGatewayStatsRequest
+	SetDeviceLocationChan  chan as.SetDeviceLocationRequest
+
+	JoinRequestResponse        as.JoinRequestResponse
+	HandleDataUpResponse       as.HandleDataUpResponse
+	HandleDataDownACKResponse  as.HandleDataDownACKResponse
+	HandleErrorResponse        as.HandleErrorResponse
+	GetDataDownResponse        as.GetDataDownResponse
+	HandleGatewayStatsResponse as.HandleGatewayStatsResponse
+	SetDeviceLocationResponse  as.SetDeviceLocationResponse
 }
 
 // NewApplicationClient returns a new ApplicationClient.
 func NewApplicationClient() *ApplicationClient {
 	return &ApplicationClient{
-		JoinRequestChan:       make(chan as.JoinRequestRequest, 100),
-		HandleDataUpChan:      make(chan as.HandleDataUpRequest, 100),
-		HandleDataDownACKChan: make(chan as.HandleDataDownACKRequest, 100),
-		HandleErrorChan:       make(chan as.HandleErrorRequest, 100),
-		GetDataDownChan:       make(chan as.GetDataDownRequest, 100),
+		JoinRequestChan:        make(chan as.JoinRequestRequest, 100),
+		HandleDataUpChan:       make(chan as.HandleDataUpRequest, 100),
+		HandleDataDownACKChan:  make(chan as.HandleDataDownACKRequest, 100),
+		HandleErrorChan:        make(chan as.HandleErrorRequest, 100),
+		GetDataDownChan:        make(chan as.GetDataDownRequest, 100),
+		HandleGatewayStatsChan: make(chan as.HandleGatewayStatsRequest, 100),
+		SetDeviceLocationChan:  make(chan as.SetDeviceLocationRequest, 100),
 	}
 }
 
@@ -192,19 +220,44 @@ func (t *ApplicationClient) HandleDataDownACK(ctx context.Context, in *as.Handle
 
 // HandleError method.
 func (t *ApplicationClient) HandleError(ctx context.Context, in *as.HandleErrorRequest, opts ...grpc.CallOption) (*as.HandleErrorResponse, error) {
+	if t.HandleErrorErr != nil {
+		return nil, t.HandleErrorErr
+	}
 	t.HandleErrorChan <- *in
 	return &t.HandleErrorResponse, nil
 }
 
+// HandleGatewayStats method.
+func (t *ApplicationClient) HandleGatewayStats(ctx context.Context, in *as.HandleGatewayStatsRequest, opts ...grpc.CallOption) (*as.HandleGatewayStatsResponse, error) {
+	t.HandleGatewayStatsChan <- *in
+	return &t.HandleGatewayStatsResponse, nil
+}
+
+// SetDeviceLocation method.
+func (t *ApplicationClient) SetDeviceLocation(ctx context.Context, in *as.SetDeviceLocationRequest, opts ...grpc.CallOption) (*as.SetDeviceLocationResponse, error) {
+	t.SetDeviceLocationChan <- *in
+	return &t.SetDeviceLocationResponse, nil
+}
+
 // NetworkControllerClient is a network-controller client for testing.
 type NetworkControllerClient struct {
 	HandleRXInfoChan           chan nc.HandleRXInfoRequest
 	HandleDataUpMACCommandChan chan nc.HandleDataUpMACCommandRequest
 	HandleErrorChan            chan nc.HandleErrorRequest
+	HandleGatewayStatusChan    chan nc.HandleGatewayStatusRequest
+	HandleGatewayEventChan     chan nc.HandleGatewayEventRequest
+
+	HandleRXInfoBatchChan           chan nc.HandleRXInfoBatchRequest
+	HandleDataUpMACCommandBatchChan chan nc.HandleDataUpMACCommandBatchRequest
+	HandleErrorBatchChan            chan nc.HandleErrorBatchRequest
+	HandleSLAAlertChan              chan nc.HandleSLAAlertRequest
+	HandleFirstJoinChan             chan nc.HandleFirstJoinRequest
 
 	HandleRXInfoResponse           nc.HandleRXInfoResponse
 	HandleDataUpMACCommandResponse nc.HandleDataUpMACCommandResponse
 	HandleErrorResponse            nc.HandleErrorResponse
+	HandleGatewayStatusResponse    nc.HandleGatewayStatusResponse
+	HandleGatewayEventResponse     nc.HandleGatewayEventResponse
 }
 
 // NewNetworkControllerClient returns a new NetworkControllerClient.
@@ -213,6 +266,14 @@ func NewNetworkControllerClient() *NetworkControllerClient {
 		HandleRXInfoChan:           make(chan nc.HandleRXInfoRequest, 100),
 		HandleDataUpMACCommandChan: make(chan nc.HandleDataUpMACCommandRequest, 100),
 		HandleErrorChan:            make(chan nc.HandleErrorRequest, 100),
+		HandleGatewayStatusChan:    make(chan nc.HandleGatewayStatusRequest, 100),
+		HandleGatewayEventChan:     make(chan nc.HandleGatewayEventRequest, 100),
+
+		HandleRXInfoBatchChan:           make(chan nc.HandleRXInfoBatchRequest, 100),
+		HandleDataUpMACCommandBatchChan: make(chan nc.HandleDataUpMACCommandBatchRequest, 100),
+		HandleErrorBatchChan:            make(chan nc.HandleErrorBatchRequest, 100),
+		HandleSLAAlertChan:              make(chan nc.HandleSLAAlertRequest, 100),
+		HandleFirstJoinChan:             make(chan nc.HandleFirstJoinRequest, 100),
 	}
 }
 
@@ -233,3 +294,45 @@ func (t *NetworkControllerClient) HandleError(ctx context.Context, in *nc.Handle
 	t.HandleErrorChan <- *in
 	return &t.HandleErrorResponse, nil
 }
+
+// HandleGatewayStatus method.
+func (t *NetworkControllerClient) HandleGatewayStatus(ctx context.Context, in *nc.HandleGatewayStatusRequest, opts ...grpc.CallOption) (*nc.HandleGatewayStatusResponse, error) {
+	t.HandleGatewayStatusChan <- *in
+	return &t.HandleGatewayStatusResponse, nil
+}
+
+// HandleGatewayEvent method.
+func (t *NetworkControllerClient) HandleGatewayEvent(ctx context.Context, in *nc.HandleGatewayEventRequest, opts ...grpc.CallOption) (*nc.HandleGatewayEventResponse, error) {
+	t.HandleGatewayEventChan <- *in
+	return &t.HandleGatewayEventResponse, nil
+}
+
+// HandleRXInfoBatch method.
+func (t *NetworkControllerClient) HandleRXInfoBatch(ctx context.Context, in *nc.HandleRXInfoBatchRequest, opts ...grpc.CallOption) (*nc.HandleRXInfoBatchResponse, error) {
+	t.HandleRXInfoBatchChan <- *in
+	return &nc.HandleRXInfoBatchResponse{}, nil
+}
+
+// HandleDataUpMACCommandBatch method.
+func (t *NetworkControllerClient) HandleDataUpMACCommandBatch(ctx context.Context, in *nc.HandleDataUpMACCommandBatchRequest, opts ...grpc.CallOption) (*nc.HandleDataUpMACCommandBatchResponse, error) {
+	t.HandleDataUpMACCommandBatchChan <- *in
+	return &nc.HandleDataUpMACCommandBatchResponse{}, nil
+}
+
+// HandleErrorBatch method.
+func (t *NetworkControllerClient) HandleErrorBatch(ctx context.Context, in *nc.HandleErrorBatchRequest, opts ...grpc.CallOption) (*nc.HandleErrorBatchResponse, error) {
+	t.HandleErrorBatchChan <- *in
+	return &nc.HandleErrorBatchResponse{}, nil
+}
+
+// HandleSLAAlert method.
+func (t *NetworkControllerClient) HandleSLAAlert(ctx context.Context, in *nc.HandleSLAAlertRequest, opts ...grpc.CallOption) (*nc.HandleSLAAlertResponse, error) {
+	t.HandleSLAAlertChan <- *in
+	return &nc.HandleSLAAlertResponse{}, nil
+}
+
+// HandleFirstJoin method.
+func (t *NetworkControllerClient) HandleFirstJoin(ctx context.Context, in *nc.HandleFirstJoinRequest, opts ...grpc.CallOption) (*nc.HandleFirstJoinResponse, error) {
+	t.HandleFirstJoinChan <- *in
+	return &nc.HandleFirstJoinResponse{}, nil
+}
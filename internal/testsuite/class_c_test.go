@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/joriwind/loraserver/api/as"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/api/ns"
 	"github.com/joriwind/loraserver/internal/api"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/framelog"
 	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/models"
 	"github.com/joriwind/loraserver/internal/session"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -37,15 +43,22 @@ type classCTestCase struct {
 
 func TestClassCScenarios(t *testing.T) {
 	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	Convey("Given a clean state", t, func() {
 		p := common.NewRedisPool(conf.RedisURL)
 		test.MustFlushRedis(p)
+		test.MustResetDB(db)
 
 		ctx := common.Context{
 			RedisPool:   p,
+			DB:          db,
 			Gateway:     test.NewGatewayBackend(),
 			Application: test.NewApplicationClient(),
+			Controller:  test.NewNetworkControllerClient(),
 		}
 
 		api := api.NewNetworkServerAPI(ctx)
@@ -73,7 +86,15 @@ func TestClassCScenarios(t *testing.T) {
 			CodeRate:    "4/5",
 		}
 
+		iPol := false
+		txInfoOverride := txInfo
+		txInfoOverride.Power = 20
+		txInfoOverride.CodeRate = "4/6"
+		txInfoOverride.IPol = &iPol
+
 		fPortTen := uint8(10)
+		expired := time.Now().Add(-time.Minute)
+		notYetDue := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
 
 		Convey("Given a set of test-scenarios for Class-C", func() {
 			tests := []classCTestCase{
@@ -179,7 +200,137 @@ func TestClassCScenarios(t *testing.T) {
 						},
 					},
 				},
+				{
+					Name:        "expired mac-command in the queue",
+					NodeSession: sess,
+					MACCommandQueue: []maccommand.QueueItem{
+						{DevEUI: sess.DevEUI, Data: []byte{6}, ExpiresAt: &expired},
+						{DevEUI: sess.DevEUI, Data: []byte{8, 3}},
+					},
+					PushDataDownRequest: ns.PushDataDownRequest{
+						DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Data:   []byte{5, 4, 3, 2, 1},
+						FPort:  10,
+						FCnt:   5,
+					},
+					ExpectedFCntUp:   8,
+					ExpectedFCntDown: 6,
+					ExpectedTXInfo:   &txInfo,
+					ExpectedPHYPayload: &lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataDown,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: sess.DevAddr,
+								FCnt:    5,
+								FCtrl:   lorawan.FCtrl{},
+								FOpts: []lorawan.MACCommand{
+									{CID: lorawan.CID(8), Payload: &lorawan.RXTimingSetupReqPayload{Delay: 3}},
+								},
+							},
+							FPort: &fPortTen,
+							FRMPayload: []lorawan.Payload{
+								&lorawan.DataPayload{Bytes: []byte{5, 4, 3, 2, 1}},
+							},
+						},
+					},
+				},
+				{
+					Name:        "mac-command in the queue which is not yet due",
+					NodeSession: sess,
+					MACCommandQueue: []maccommand.QueueItem{
+						{DevEUI: sess.DevEUI, Data: []byte{6}, NotBefore: &notYetDue},
+						{DevEUI: sess.DevEUI, Data: []byte{8, 3}},
+					},
+					PushDataDownRequest: ns.PushDataDownRequest{
+						DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Data:   []byte{5, 4, 3, 2, 1},
+						FPort:  10,
+						FCnt:   5,
+					},
+					ExpectedFCntUp:   8,
+					ExpectedFCntDown: 6,
+					ExpectedTXInfo:   &txInfo,
+					ExpectedPHYPayload: &lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataDown,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: sess.DevAddr,
+								FCnt:    5,
+								FCtrl:   lorawan.FCtrl{},
+								FOpts: []lorawan.MACCommand{
+									{CID: lorawan.CID(8), Payload: &lorawan.RXTimingSetupReqPayload{Delay: 3}},
+								},
+							},
+							FPort: &fPortTen,
+							FRMPayload: []lorawan.Payload{
+								&lorawan.DataPayload{Bytes: []byte{5, 4, 3, 2, 1}},
+							},
+						},
+					},
+					ExpectedMACCommandQueue: []maccommand.QueueItem{
+						{DevEUI: sess.DevEUI, Data: []byte{6}, NotBefore: &notYetDue},
+					},
+				},
+				{
+					Name:        "tx-params set by the node-session and push request",
+					NodeSession: sess,
+					PreFunc: func(ns *session.NodeSession) {
+						ns.DownlinkTXParams = models.TXParams{
+							Power:    12,
+							CodeRate: "4/6",
+						}
+					},
+					PushDataDownRequest: ns.PushDataDownRequest{
+						DevEUI:   []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Data:     []byte{5, 4, 3, 2, 1},
+						FPort:    10,
+						FCnt:     5,
+						TxPower:  20,
+						Polarity: ns.Polarity_NORMAL_POLARITY,
+					},
+
+					ExpectedFCntUp:   8,
+					ExpectedFCntDown: 6,
+					ExpectedTXInfo:   &txInfoOverride,
+					ExpectedPHYPayload: &lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{
+							MType: lorawan.UnconfirmedDataDown,
+							Major: lorawan.LoRaWANR1,
+						},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: sess.DevAddr,
+								FCnt:    5,
+								FCtrl:   lorawan.FCtrl{},
+							},
+							FPort: &fPortTen,
+							FRMPayload: []lorawan.Payload{
+								&lorawan.DataPayload{Bytes: []byte{5, 4, 3, 2, 1}},
+							},
+						},
+					},
+				},
 				// errors
+				{
+					Name:        "invalid code rate",
+					NodeSession: sess,
+					PushDataDownRequest: ns.PushDataDownRequest{
+						DevEUI:   []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Data:     []byte{1, 2, 3, 4, 5},
+						FPort:    10,
+						FCnt:     5,
+						CodeRate: "4/9",
+					},
+					ExpectedPushDataDownError: grpc.Errorf(codes.InvalidArgument, "invalid tx parameters"),
+					ExpectedFCntUp:            8,
+					ExpectedFCntDown:          5,
+				},
 				{
 					Name:        "maximum payload exceeded",
 					NodeSession: sess,
@@ -299,5 +450,283 @@ func TestClassCScenarios(t *testing.T) {
 				})
 			}
 		})
+
+		Convey("Given a node with offloaded AppSKey, a node without and a Class-A node", func() {
+			appSKey := lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+			sessAppSKey := sess
+			sessAppSKey.AppSKey = &appSKey
+			So(session.SaveNodeSession(ctx.RedisPool, sessAppSKey), ShouldBeNil)
+
+			sessNoAppSKey := sess
+			sessNoAppSKey.DevEUI = lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8}
+			So(session.SaveNodeSession(ctx.RedisPool, sessNoAppSKey), ShouldBeNil)
+
+			sessClassA := sessAppSKey
+			sessClassA.DevEUI = lorawan.EUI64{3, 2, 3, 4, 5, 6, 7, 8}
+			sessClassA.DeviceClass = session.DeviceClassA
+			So(session.SaveNodeSession(ctx.RedisPool, sessClassA), ShouldBeNil)
+
+			Convey("When broadcasting a payload", func() {
+				resp, err := api.BroadcastDataDown(context.Background(), &ns.BroadcastDataDownRequest{
+					AppEUI: sess.AppEUI[:],
+					FPort:  10,
+					Data:   []byte{1, 2, 3},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then only the node with offloaded AppSKey is scheduled", func() {
+					So(resp.ScheduledCount, ShouldEqual, 1)
+					So(resp.SkippedCount, ShouldEqual, 1)
+				})
+
+				Convey("Then the payload was sent encrypted with the AppSKey", func() {
+					txPacket := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+					So(&txPacket.TXInfo, ShouldResemble, &txInfo)
+					So(txPacket.PHYPayload.DecryptFRMPayload(appSKey), ShouldBeNil)
+
+					macPL, ok := txPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+					So(ok, ShouldBeTrue)
+					So(macPL.FHDR.DevAddr, ShouldEqual, sess.DevAddr)
+					So(macPL.FRMPayload, ShouldResemble, []lorawan.Payload{
+						&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+					})
+				})
+			})
+
+			Convey("When broadcasting with FPort 0", func() {
+				_, err := api.BroadcastDataDown(context.Background(), &ns.BroadcastDataDownRequest{
+					Data: []byte{1, 2, 3},
+				})
+
+				Convey("Then an invalid argument error is returned", func() {
+					So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
+				})
+			})
+		})
+
+		Convey("Given downlink deduplication is enabled with coalescing", func() {
+			common.DownlinkDeduplicationWindow = time.Minute
+			common.DownlinkDeduplicationCoalesce = true
+			defer func() {
+				common.DownlinkDeduplicationWindow = 0
+				common.DownlinkDeduplicationCoalesce = false
+			}()
+
+			So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+			req := ns.PushDataDownRequest{
+				DevEUI:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Data:      []byte{1, 2, 3, 4, 5},
+				Confirmed: true,
+				FPort:     10,
+				FCnt:      5,
+			}
+
+			Convey("When pushing the same payload twice", func() {
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+				_, err = api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+
+				Convey("Then only one frame was sent", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+				})
+			})
+		})
+
+		Convey("Given a push request with reference", func() {
+			So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+			req := ns.PushDataDownRequest{
+				DevEUI:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Data:      []byte{1, 2, 3, 4, 5},
+				FPort:     10,
+				FCnt:      5,
+				Reference: "push-1",
+			}
+
+			Convey("When pushing it twice (e.g. a retry)", func() {
+				resp, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+				So(resp.Duplicate, ShouldBeFalse)
+				resp, err = api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+				So(resp.Duplicate, ShouldBeTrue)
+
+				Convey("Then only one frame was sent", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+				})
+
+				Convey("Then the FCntDown was incremented once", func() {
+					ns, err := session.GetNodeSession(ctx.RedisPool, sess.DevEUI)
+					So(err, ShouldBeNil)
+					So(ns.FCntDown, ShouldEqual, 6)
+				})
+			})
+
+			Convey("When the first push fails", func() {
+				req.FCnt = 4
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldNotBeNil)
+
+				Convey("Then the push can be retried with the same reference", func() {
+					req.FCnt = 5
+					resp, err := api.PushDataDown(context.Background(), &req)
+					So(err, ShouldBeNil)
+					So(resp.Duplicate, ShouldBeFalse)
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When pushing it as confirmed payload", func() {
+				req.Confirmed = true
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+
+				Convey("Then the reference is stored in the node-session", func() {
+					ns, err := session.GetNodeSession(ctx.RedisPool, sess.DevEUI)
+					So(err, ShouldBeNil)
+					So(ns.DownlinkReference, ShouldEqual, "push-1")
+				})
+			})
+		})
+
+		Convey("Given a confirmed push request", func() {
+			So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+			req := ns.PushDataDownRequest{
+				DevEUI:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Data:      []byte{1, 2, 3, 4, 5},
+				Confirmed: true,
+				FPort:     10,
+				FCnt:      5,
+			}
+
+			Convey("When pushing it twice (e.g. a retransmission of the unacknowledged frame)", func() {
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+				_, err = api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+
+				So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 2)
+				txPacket1 := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+				txPacket2 := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+
+				Convey("Then each transmission has an unique token", func() {
+					So(txPacket1.Token, ShouldNotEqual, txPacket2.Token)
+				})
+
+				Convey("When the gateway acknowledges the first and rejects the second transmission", func() {
+					So(downlink.HandleTXAck(ctx.RedisPool, gw.TXAck{MAC: txPacket1.TXInfo.MAC, Token: txPacket1.Token}), ShouldBeNil)
+					So(downlink.HandleTXAck(ctx.RedisPool, gw.TXAck{MAC: txPacket2.TXInfo.MAC, Token: txPacket2.Token, Error: "TOO_LATE"}), ShouldBeNil)
+
+					Convey("Then the frame log contains the outcome of both transmissions", func() {
+						resp, err := api.GetDownlinkFrames(context.Background(), &ns.GetDownlinkFramesRequest{
+							DevEUI: req.DevEUI,
+							FCnt:   5,
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldHaveLength, 2)
+
+						So(resp.Result[0].Token, ShouldEqual, txPacket1.Token)
+						So(resp.Result[0].Attempt, ShouldEqual, 1)
+						So(resp.Result[0].AckedAt, ShouldNotEqual, "")
+						So(resp.Result[0].Error, ShouldEqual, "")
+
+						So(resp.Result[1].Token, ShouldEqual, txPacket2.Token)
+						So(resp.Result[1].Attempt, ShouldEqual, 2)
+						So(resp.Result[1].Error, ShouldEqual, "TOO_LATE")
+					})
+				})
+
+				Convey("Then an ack from an other gateway is rejected", func() {
+					err := downlink.HandleTXAck(ctx.RedisPool, gw.TXAck{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, Token: txPacket1.Token})
+					So(errors.Cause(err), ShouldEqual, framelog.ErrDownlinkFrameDoesNotExist)
+				})
+			})
+		})
+
+		Convey("Given a node with a battery level below its throttle level", func() {
+			sess.BatteryThrottleLevel = 50
+			sess.BatteryLevel = 20
+			sess.BatteryLevelUpdatedAt = time.Now()
+			So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+			req := ns.PushDataDownRequest{
+				DevEUI:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Data:      []byte{1, 2, 3, 4, 5},
+				Confirmed: true,
+				FPort:     10,
+				FCnt:      5,
+			}
+
+			Convey("When pushing a confirmed payload", func() {
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+
+				Convey("Then it was sent as unconfirmed payload", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+					txPacket := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+					So(txPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.UnconfirmedDataDown)
+
+					ns, err := session.GetNodeSession(ctx.RedisPool, sess.DevEUI)
+					So(err, ShouldBeNil)
+					So(ns.FCntDown, ShouldEqual, 6)
+				})
+
+				Convey("Then the application-server was notified", func() {
+					So(ctx.Application.(*test.ApplicationClient).HandleErrorChan, ShouldHaveLength, 1)
+					req := <-ctx.Application.(*test.ApplicationClient).HandleErrorChan
+					So(req.Type, ShouldEqual, as.ErrorType_DATA_DOWN_BATTERY_THROTTLED)
+				})
+			})
+
+			Convey("When pushing a critical confirmed payload", func() {
+				req.Critical = true
+				_, err := api.PushDataDown(context.Background(), &req)
+				So(err, ShouldBeNil)
+
+				Convey("Then it was sent as confirmed payload", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+					txPacket := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+					So(txPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.ConfirmedDataDown)
+					So(ctx.Application.(*test.ApplicationClient).HandleErrorChan, ShouldHaveLength, 0)
+				})
+			})
+		})
+
+		Convey("Given a Class-A node with a Class-C FPort", func() {
+			sess.DeviceClass = session.DeviceClassA
+			sess.ClassCFPort = 20
+			sess.ClassCWindow = time.Minute
+
+			req := ns.PushDataDownRequest{
+				DevEUI: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				Data:   []byte{1, 2, 3, 4, 5},
+				FPort:  10,
+				FCnt:   5,
+			}
+
+			Convey("When the node did not signal Class-C availability", func() {
+				So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+				Convey("Then pushing a payload fails", func() {
+					_, err := api.PushDataDown(context.Background(), &req)
+					So(err, ShouldNotBeNil)
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When the Class-C window of the node is open", func() {
+				So(sess.HandleClassCSignal(20, time.Now()), ShouldBeTrue)
+				So(session.SaveNodeSession(ctx.RedisPool, sess), ShouldBeNil)
+
+				Convey("Then the payload is sent", func() {
+					_, err := api.PushDataDown(context.Background(), &req)
+					So(err, ShouldBeNil)
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,272 @@
+package testsuite
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/jacobsa/crypto/cmac"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+// The crypto conformance tests below validate the MIC computation,
+// FRMPayload encryption and join-accept encryption against published test
+// vectors and against a reference implementation of the LoRaWAN 1.0.x
+// specification. Note that only LoRaWAN 1.0.x is covered, as the LoRaWAN
+// 1.1 key hierarchy (e.g. FNwkSIntKey / SNwkSIntKey MIC and FOpts
+// encryption) is not implemented by LoRa Server.
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func mustAES128Key(s string) lorawan.AES128Key {
+	var key lorawan.AES128Key
+	copy(key[:], mustDecodeHex(s))
+	return key
+}
+
+// referenceCMAC returns the AES-CMAC of the given data.
+func referenceCMAC(key lorawan.AES128Key, data []byte) []byte {
+	h, err := cmac.New(key[:])
+	if err != nil {
+		panic(err)
+	}
+	if _, err := h.Write(data); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil)
+}
+
+// referenceDataMIC returns the MIC of a data frame (LoRaWAN 1.0.x, 4.4).
+func referenceDataMIC(key lorawan.AES128Key, uplink bool, devAddr lorawan.DevAddr, fCnt uint32, msg []byte) []byte {
+	b0 := make([]byte, 16)
+	b0[0] = 0x49
+	if !uplink {
+		b0[5] = 0x01
+	}
+	for i := 0; i < 4; i++ {
+		b0[6+i] = devAddr[3-i] // little endian
+	}
+	binary.LittleEndian.PutUint32(b0[10:14], fCnt)
+	b0[15] = byte(len(msg))
+
+	return referenceCMAC(key, append(b0, msg...))[:4]
+}
+
+// referenceEncryptFRMPayload encrypts the given FRMPayload (LoRaWAN 1.0.x,
+// 4.3.3).
+func referenceEncryptFRMPayload(key lorawan.AES128Key, uplink bool, devAddr lorawan.DevAddr, fCnt uint32, data []byte) []byte {
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		panic(err)
+	}
+
+	out := make([]byte, len(data))
+	s := make([]byte, 16)
+	for i := 0; i*16 < len(data); i++ {
+		a := make([]byte, 16)
+		a[0] = 0x01
+		if !uplink {
+			a[5] = 0x01
+		}
+		for j := 0; j < 4; j++ {
+			a[6+j] = devAddr[3-j] // little endian
+		}
+		binary.LittleEndian.PutUint32(a[10:14], fCnt)
+		a[15] = byte(i + 1)
+		block.Encrypt(s, a)
+
+		for j := 0; j < 16 && i*16+j < len(data); j++ {
+			out[i*16+j] = data[i*16+j] ^ s[j]
+		}
+	}
+	return out
+}
+
+func TestCMACVectors(t *testing.T) {
+	Convey("Given the AES-CMAC test vectors of RFC 4493", t, func() {
+		key := mustAES128Key("2b7e151628aed2a6abf7158809cf4f3c")
+		testTable := []struct {
+			Message string
+			CMAC    string
+		}{
+			{"", "bb1d6929e95937287fa37d129b756746"},
+			{"6bc1bee22e409f96e93d7e117393172a", "070a16b46b4d4144f79bdd9dd04a287c"},
+			{"6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411", "dfa66747de9ae63030ca32611497c827"},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing message %s [%d]", test.Message, i), func() {
+				So(hex.EncodeToString(referenceCMAC(key, mustDecodeHex(test.Message))), ShouldEqual, test.CMAC)
+			})
+		}
+	})
+}
+
+func TestDataFrameVectors(t *testing.T) {
+	Convey("Given an unconfirmed data up test vector", t, func() {
+		// DevAddr 49be7df1, FCnt 2, FPort 1 and payload "test"
+		nwkSKey := mustAES128Key("44024241ed4ce9a68c6a8bc055233fd3")
+		appSKey := mustAES128Key("ec925802ae430ca77fd3dd73cb2cc588")
+		b := mustDecodeHex("40f17dbe4900020001954378762b11ff0d")
+
+		var phy lorawan.PHYPayload
+		So(phy.UnmarshalBinary(b), ShouldBeNil)
+
+		Convey("Then the MIC is valid", func() {
+			ok, err := phy.ValidateMIC(nwkSKey)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(phy.MIC[:], ShouldResemble, referenceDataMIC(nwkSKey, true, lorawan.DevAddr{0x49, 0xbe, 0x7d, 0xf1}, 2, b[:len(b)-4]))
+		})
+
+		Convey("Then the FRMPayload decrypts to the expected plaintext", func() {
+			So(phy.DecryptFRMPayload(appSKey), ShouldBeNil)
+			macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+			So(ok, ShouldBeTrue)
+			So(macPL.FRMPayload, ShouldHaveLength, 1)
+			So(macPL.FRMPayload[0].(*lorawan.DataPayload).Bytes, ShouldResemble, []byte("test"))
+
+			Convey("Then encrypting and setting the MIC results in the original frame", func() {
+				So(phy.EncryptFRMPayload(appSKey), ShouldBeNil)
+				So(phy.SetMIC(nwkSKey), ShouldBeNil)
+				out, err := phy.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, b)
+			})
+		})
+	})
+
+	Convey("Given a set of data frames", t, func() {
+		nwkSKey := mustAES128Key("2b7e151628aed2a6abf7158809cf4f3c")
+		appSKey := mustAES128Key("000102030405060708090a0b0c0d0e0f")
+		devAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+		testTable := []struct {
+			Name   string
+			MType  lorawan.MType
+			FCnt   uint32
+			FPort  uint8
+			Data   []byte
+			Uplink bool
+		}{
+			{"uplink FPort > 0", lorawan.UnconfirmedDataUp, 10, 10, []byte{1, 2, 3, 4, 5}, true},
+			{"uplink FPort > 0 (multiple blocks)", lorawan.ConfirmedDataUp, 65546, 20, []byte("this payload spans multiple AES blocks"), true},
+			{"uplink FPort 0", lorawan.UnconfirmedDataUp, 11, 0, []byte{0x02}, true},
+			{"downlink FPort > 0", lorawan.UnconfirmedDataDown, 5, 10, []byte{5, 4, 3, 2, 1}, false},
+			{"downlink FPort 0", lorawan.ConfirmedDataDown, 6, 0, []byte{0x03, 0x51, 0xff, 0x00, 0x01}, false},
+		}
+
+		for i, test := range testTable {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				key := appSKey
+				if test.FPort == 0 {
+					key = nwkSKey
+				}
+
+				fPort := test.FPort
+				phy := lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: test.MType,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: devAddr,
+							FCnt:    test.FCnt,
+						},
+						FPort: &fPort,
+						FRMPayload: []lorawan.Payload{
+							&lorawan.DataPayload{Bytes: append([]byte{}, test.Data...)},
+						},
+					},
+				}
+
+				So(phy.EncryptFRMPayload(key), ShouldBeNil)
+				So(phy.SetMIC(nwkSKey), ShouldBeNil)
+
+				Convey("Then the encrypted FRMPayload matches the reference implementation", func() {
+					macPL := phy.MACPayload.(*lorawan.MACPayload)
+					So(macPL.FRMPayload[0].(*lorawan.DataPayload).Bytes, ShouldResemble, referenceEncryptFRMPayload(key, test.Uplink, devAddr, test.FCnt, test.Data))
+				})
+
+				Convey("Then the MIC matches the reference implementation", func() {
+					b, err := phy.MarshalBinary()
+					So(err, ShouldBeNil)
+					So(phy.MIC[:], ShouldResemble, referenceDataMIC(nwkSKey, test.Uplink, devAddr, test.FCnt, b[:len(b)-4]))
+				})
+			})
+		}
+	})
+}
+
+func TestJoinAcceptVectors(t *testing.T) {
+	Convey("Given a join-accept", t, func() {
+		appKey := mustAES128Key("000102030405060708090a0b0c0d0e0f")
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinAccept,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinAcceptPayload{
+				AppNonce: [3]byte{1, 2, 3},
+				NetID:    lorawan.NetID{4, 5, 6},
+				DevAddr:  lorawan.DevAddr{1, 2, 3, 4},
+				DLSettings: lorawan.DLSettings{
+					RX2DataRate: 2,
+					RX1DROffset: 1,
+				},
+				RXDelay: 1,
+			},
+		}
+		So(phy.SetMIC(appKey), ShouldBeNil)
+
+		plain, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		Convey("Then the MIC matches the reference implementation", func() {
+			So(phy.MIC[:], ShouldResemble, referenceCMAC(appKey, plain[:len(plain)-4])[:4])
+		})
+
+		Convey("When encrypting the join-accept", func() {
+			So(phy.EncryptJoinAcceptPayload(appKey), ShouldBeNil)
+			b, err := phy.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			Convey("Then the result matches the reference implementation (AES decrypt of the payload and MIC)", func() {
+				block, err := aes.NewCipher(appKey[:])
+				So(err, ShouldBeNil)
+
+				expected := make([]byte, len(plain)-1)
+				for i := 1; i < len(plain); i += 16 {
+					block.Decrypt(expected[i-1:i+15], plain[i:i+16])
+				}
+				So(b[0], ShouldEqual, plain[0])
+				So(b[1:], ShouldResemble, expected)
+			})
+
+			Convey("Then decrypting results in the original join-accept", func() {
+				var out lorawan.PHYPayload
+				So(out.UnmarshalBinary(b), ShouldBeNil)
+				So(out.DecryptJoinAcceptPayload(appKey), ShouldBeNil)
+				ok, err := out.ValidateMIC(appKey)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				outB, err := out.MarshalBinary()
+				So(err, ShouldBeNil)
+				So(outB, ShouldResemble, plain)
+			})
+		})
+	})
+}
@@ -0,0 +1,370 @@
+package testsuite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+// The downlink interoperability tests below validate the downlink frames
+// constructed by LoRa Server (FCtrl, FOpts, FPort 0 encryption and the
+// 32 bit frame-counter) against golden frames and against a software
+// device emulating the downlink handling of the LoRaMAC-node stack (v4.x,
+// LoRaWAN 1.0.x). The golden frames are constructed byte by byte using the
+// reference implementation of crypto_test.go, not using the lorawan
+// package.
+
+// emulatorMaxFCntGap is the MAX_FCNT_GAP of LoRaMAC-node.
+const emulatorMaxFCntGap = 16384
+
+// deviceEmulator emulates the downlink frame handling of a device
+// (LoRaMAC-node ProcessRadioRxDone for data frames): the frame is rejected
+// when the device would drop it.
+type deviceEmulator struct {
+	DevAddr  lorawan.DevAddr
+	NwkSKey  lorawan.AES128Key
+	AppSKey  lorawan.AES128Key
+	FCntDown uint32 // the last received downlink frame-counter
+	Received bool   // set when a downlink has been received
+}
+
+// emulatedDownlink contains a downlink as handled by the emulated device.
+type emulatedDownlink struct {
+	Confirmed bool
+	ADR       bool
+	ACK       bool
+	FPending  bool
+	FCnt      uint32 // the full (32 bit) frame-counter
+	FOpts     []byte
+	FPort     *uint8
+	Data      []byte // the decrypted FRMPayload
+}
+
+// receive handles the given downlink frame.
+func (d *deviceEmulator) receive(b []byte) (emulatedDownlink, error) {
+	var out emulatedDownlink
+
+	// MHDR (1) + FHDR (7) + MIC (4)
+	if len(b) < 12 {
+		return out, errors.New("frame too short")
+	}
+
+	if b[0]&0x03 != 0 {
+		return out, errors.New("unsupported major version")
+	}
+	switch b[0] >> 5 {
+	case 0x03:
+	case 0x05:
+		out.Confirmed = true
+	default:
+		return out, fmt.Errorf("unexpected mtype: %d", b[0]>>5)
+	}
+
+	devAddr := lorawan.DevAddr{b[4], b[3], b[2], b[1]}
+	if devAddr != d.DevAddr {
+		return out, errors.New("devaddr mismatch")
+	}
+
+	fCtrl := b[5]
+	out.ADR = fCtrl&0x80 != 0
+	out.ACK = fCtrl&0x20 != 0
+	out.FPending = fCtrl&0x10 != 0
+	fOptsLen := int(fCtrl & 0x0f)
+
+	macPayloadEnd := len(b) - 4
+	pos := 8 + fOptsLen
+	if pos > macPayloadEnd {
+		return out, errors.New("FOpts exceed frame")
+	}
+	out.FOpts = b[8:pos]
+
+	fCnt := uint32(binary.LittleEndian.Uint16(b[6:8]))
+	if d.Received {
+		fCnt |= d.FCntDown &^ 0xffff
+		if fCnt < d.FCntDown {
+			fCnt += 0x10000
+		}
+		if fCnt-d.FCntDown >= emulatorMaxFCntGap {
+			return out, errors.New("frame-counter gap too large")
+		}
+		if fCnt == d.FCntDown && !out.Confirmed {
+			return out, errors.New("duplicate frame-counter")
+		}
+	}
+	out.FCnt = fCnt
+
+	if !bytes.Equal(referenceDataMIC(d.NwkSKey, false, d.DevAddr, fCnt, b[:macPayloadEnd]), b[macPayloadEnd:]) {
+		return out, errors.New("invalid MIC")
+	}
+
+	if pos < macPayloadEnd {
+		fPort := b[pos]
+		out.FPort = &fPort
+
+		key := d.AppSKey
+		if fPort == 0 {
+			if fOptsLen > 0 {
+				return out, errors.New("mac-commands in FOpts and FRMPayload")
+			}
+			key = d.NwkSKey
+		}
+		out.Data = referenceEncryptFRMPayload(key, false, d.DevAddr, fCnt, b[pos+1:macPayloadEnd])
+	}
+
+	d.FCntDown = fCnt
+	d.Received = true
+
+	return out, nil
+}
+
+type downlinkInteropTestCase struct {
+	Name          string
+	FCntDown      uint32 // the FCntDown of the node-session
+	ADRInterval   uint32
+	DataDown      downlink.DataDownFrameContext
+	EmulatorFCnt  *uint32 // the last frame-counter received by the emulator
+	GoldenFrame   []byte
+	ExpectedFrame emulatedDownlink
+}
+
+func getDownlinkInteropTestCases() []downlinkInteropTestCase {
+	fPort0 := uint8(0)
+	fPort1 := uint8(1)
+	fPort10 := uint8(10)
+	fPort20 := uint8(20)
+	fCnt65535 := uint32(65535)
+
+	return []downlinkInteropTestCase{
+		{
+			Name:     "unconfirmed data with ACK",
+			FCntDown: 5,
+			DataDown: downlink.DataDownFrameContext{
+				ACK:   true,
+				FPort: 10,
+				Data:  []byte{1, 2, 3, 4},
+			},
+			GoldenFrame: mustDecodeHex("60040302012005000aa9f633d739755897"),
+			ExpectedFrame: emulatedDownlink{
+				ACK:   true,
+				FCnt:  5,
+				FOpts: []byte{},
+				FPort: &fPort10,
+				Data:  []byte{1, 2, 3, 4},
+			},
+		},
+		{
+			Name:        "confirmed data with ADR, FPending and mac-commands in FOpts",
+			FCntDown:    6,
+			ADRInterval: 10,
+			DataDown: downlink.DataDownFrameContext{
+				Confirmed: true,
+				MoreData:  true,
+				FPort:     20,
+				Data:      []byte("hello"),
+				MACCommands: []lorawan.MACCommand{
+					{CID: lorawan.DevStatusReq},
+				},
+			},
+			GoldenFrame: mustDecodeHex("a00403020191060006145cbb65bddda9701ed2"),
+			ExpectedFrame: emulatedDownlink{
+				Confirmed: true,
+				ADR:       true,
+				FPending:  true,
+				FCnt:      6,
+				FOpts:     []byte{0x06},
+				FPort:     &fPort20,
+				Data:      []byte("hello"),
+			},
+		},
+		{
+			Name:     "encrypted mac-commands (FPort 0)",
+			FCntDown: 7,
+			DataDown: downlink.DataDownFrameContext{
+				EncryptMACCommands: true,
+				MACCommands: []lorawan.MACCommand{
+					{
+						CID: lorawan.LinkADRReq,
+						Payload: &lorawan.LinkADRReqPayload{
+							DataRate: 5,
+							TXPower:  1,
+							ChMask:   [16]bool{true, true, true, true, true, true, true, true},
+							Redundancy: lorawan.Redundancy{
+								NbRep: 1,
+							},
+						},
+					},
+					{CID: lorawan.DevStatusReq},
+				},
+			},
+			GoldenFrame: mustDecodeHex("600403020100070000e296283ab83984bc20c6"),
+			ExpectedFrame: emulatedDownlink{
+				FCnt:  7,
+				FOpts: []byte{},
+				FPort: &fPort0,
+				Data:  []byte{0x03, 0x51, 0xff, 0x00, 0x01, 0x06},
+			},
+		},
+		{
+			Name:     "empty frame with ACK",
+			FCntDown: 8,
+			DataDown: downlink.DataDownFrameContext{
+				ACK: true,
+			},
+			GoldenFrame: mustDecodeHex("6004030201200800ce80639e"),
+			ExpectedFrame: emulatedDownlink{
+				ACK:   true,
+				FCnt:  8,
+				FOpts: []byte{},
+			},
+		},
+		{
+			Name:     "frame-counter exceeding 16 bit",
+			FCntDown: 65541,
+			DataDown: downlink.DataDownFrameContext{
+				FPort: 1,
+				Data:  []byte{0xff},
+			},
+			EmulatorFCnt: &fCnt65535,
+			GoldenFrame:  mustDecodeHex("600403020100050001fc5f098f8e"),
+			ExpectedFrame: emulatedDownlink{
+				FCnt:  65541,
+				FOpts: []byte{},
+				FPort: &fPort1,
+				Data:  []byte{0xff},
+			},
+		},
+	}
+}
+
+func newDeviceEmulator(fCnt *uint32) *deviceEmulator {
+	d := deviceEmulator{
+		DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+		NwkSKey: mustAES128Key("2b7e151628aed2a6abf7158809cf4f3c"),
+		AppSKey: mustAES128Key("000102030405060708090a0b0c0d0e0f"),
+	}
+	if fCnt != nil {
+		d.FCntDown = *fCnt
+		d.Received = true
+	}
+	return &d
+}
+
+func TestDeviceEmulatorGoldenFrames(t *testing.T) {
+	Convey("Given a set of golden downlink frames", t, func() {
+		for i, test := range getDownlinkInteropTestCases() {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				d := newDeviceEmulator(test.EmulatorFCnt)
+
+				Convey("Then the frame is accepted by the device emulator", func() {
+					frame, err := d.receive(test.GoldenFrame)
+					So(err, ShouldBeNil)
+					So(frame, ShouldResemble, test.ExpectedFrame)
+
+					Convey("Then a replay of the frame is rejected unless confirmed", func() {
+						_, err := d.receive(test.GoldenFrame)
+						if test.ExpectedFrame.Confirmed {
+							So(err, ShouldBeNil)
+						} else {
+							So(err, ShouldNotBeNil)
+						}
+					})
+				})
+
+				Convey("Then the frame is rejected when the MIC is altered", func() {
+					b := append([]byte{}, test.GoldenFrame...)
+					b[len(b)-1] ^= 0x01
+					_, err := d.receive(b)
+					So(err, ShouldNotBeNil)
+				})
+			})
+		}
+	})
+
+	Convey("Given a downlink frame with mac-commands in both FOpts and FRMPayload (FPort 0)", t, func() {
+		d := newDeviceEmulator(nil)
+		b := []byte{0x60, 0x04, 0x03, 0x02, 0x01, 0x01, 0x09, 0x00, 0x06, 0x00, 0x06}
+		b = append(b, referenceDataMIC(d.NwkSKey, false, d.DevAddr, 9, b)...)
+
+		Convey("Then the frame is rejected by the device emulator", func() {
+			_, err := d.receive(b)
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("Given a downlink frame with an uplink MIC", t, func() {
+		d := newDeviceEmulator(nil)
+		b := []byte{0x60, 0x04, 0x03, 0x02, 0x01, 0x00, 0x0a, 0x00}
+		b = append(b, referenceDataMIC(d.NwkSKey, true, d.DevAddr, 10, b)...)
+
+		Convey("Then the frame is rejected by the device emulator", func() {
+			_, err := d.receive(b)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestDownlinkInteroperability(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean state", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx := common.Context{
+			RedisPool: p,
+			Gateway:   test.NewGatewayBackend(),
+		}
+
+		appSKey := mustAES128Key("000102030405060708090a0b0c0d0e0f")
+		txInfo := gw.TXInfo{
+			MAC:         lorawan.EUI64{1, 2, 1, 2, 1, 2, 1, 2},
+			Immediately: true,
+			Frequency:   common.Band.RX2Frequency,
+			DataRate:    common.Band.DataRates[common.Band.RX2DataRate],
+			Power:       common.Band.DefaultTXPower,
+		}
+
+		for i, t := range getDownlinkInteropTestCases() {
+			Convey(fmt.Sprintf("Testing: %s [%d]", t.Name, i), func() {
+				ns := session.NodeSession{
+					DevAddr:     lorawan.DevAddr{1, 2, 3, 4},
+					DevEUI:      lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					AppEUI:      lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					NwkSKey:     mustAES128Key("2b7e151628aed2a6abf7158809cf4f3c"),
+					AppSKey:     &appSKey,
+					FCntDown:    t.FCntDown,
+					ADRInterval: t.ADRInterval,
+				}
+				So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+				So(downlink.SendDataDown(ctx, &ns, txInfo, t.DataDown), ShouldBeNil)
+				So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+				txPacket := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+
+				b, err := txPacket.PHYPayload.MarshalBinary()
+				So(err, ShouldBeNil)
+
+				Convey("Then the frame matches the golden frame", func() {
+					So(b, ShouldResemble, t.GoldenFrame)
+				})
+
+				Convey("Then the frame is accepted by the device emulator", func() {
+					frame, err := newDeviceEmulator(t.EmulatorFCnt).receive(b)
+					So(err, ShouldBeNil)
+					So(frame, ShouldResemble, t.ExpectedFrame)
+				})
+			})
+		}
+	})
+}
@@ -0,0 +1,396 @@
+package testsuite
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/joriwind/loraserver/internal/uplink"
+)
+
+// loadTestSeed seeds the generation of the devices, gateways and uplinks so
+// that the scenarios are reproducible.
+const loadTestSeed = 1
+
+// loadTestMaxInFlight defines the max. number of uplinks handled
+// concurrently. When reached, the generation of uplinks is throttled and
+// the scenario falls behind its target rate.
+const loadTestMaxInFlight = 1000
+
+// loadTestScenario defines a load-test scenario. The uplinks are sent
+// round-robin by the devices and each uplink is received by one to three
+// (random) gateways.
+type loadTestScenario struct {
+	Name             string
+	Devices          int
+	Gateways         int
+	UplinksPerSecond int
+	Duration         time.Duration
+}
+
+var loadTestScenarios = []loadTestScenario{
+	{Name: "smoke", Devices: 100, Gateways: 5, UplinksPerSecond: 20, Duration: 10 * time.Second},
+	{Name: "10k-devices", Devices: 10000, Gateways: 50, UplinksPerSecond: 100, Duration: time.Minute},
+	{Name: "10k-devices-peak", Devices: 10000, Gateways: 50, UplinksPerSecond: 500, Duration: 30 * time.Second},
+}
+
+// loadTestReport contains the outcome of a load-test run. The latency of
+// an uplink is the time between its reception by the gateways and the
+// handling of it by all gateway copies, including the de-duplication
+// delay.
+type loadTestReport struct {
+	Uplinks   int
+	Errors    int
+	Duration  time.Duration
+	Latencies []time.Duration
+}
+
+// Throughput returns the number of handled uplinks per second.
+func (r loadTestReport) Throughput() float64 {
+	if r.Duration == 0 {
+		return 0
+	}
+	return float64(r.Uplinks) / r.Duration.Seconds()
+}
+
+// Percentile returns the given percentile (0 - 100) of the latencies.
+func (r loadTestReport) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	l := append([]time.Duration(nil), r.Latencies...)
+	sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+	i := int(float64(len(l))*p/100+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(l) {
+		i = len(l) - 1
+	}
+	return l[i]
+}
+
+func (r loadTestReport) String() string {
+	return fmt.Sprintf("uplinks: %d, errors: %d, duration: %s, throughput: %.1f uplinks/s, p50: %s, p99: %s",
+		r.Uplinks,
+		r.Errors,
+		r.Duration,
+		r.Throughput(),
+		r.Percentile(50),
+		r.Percentile(99),
+	)
+}
+
+// loadTestGenerator generates the uplinks of the load-test devices.
+type loadTestGenerator struct {
+	rand     *rand.Rand
+	devices  []session.NodeSession
+	gateways []lorawan.EUI64
+	fCnt     []uint32
+	next     int
+}
+
+// newLoadTestGenerator creates the given number of node-sessions and
+// gateways.
+func newLoadTestGenerator(ctx common.Context, devices, gateways int) (*loadTestGenerator, error) {
+	g := loadTestGenerator{
+		rand: rand.New(rand.NewSource(loadTestSeed)),
+		fCnt: make([]uint32, devices),
+	}
+
+	for i := 0; i < gateways; i++ {
+		gtw := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 0, 0, 0, 0, 0, byte(i >> 8), byte(i)},
+			Name: fmt.Sprintf("loadtest-gw-%d", i),
+		}
+		if err := gateway.CreateGateway(ctx.DB, &gtw); err != nil {
+			return nil, fmt.Errorf("create gateway error: %s", err)
+		}
+		g.gateways = append(g.gateways, gtw.MAC)
+	}
+
+	for i := 0; i < devices; i++ {
+		ns := session.NodeSession{
+			DevAddr: lorawan.DevAddr{ctx.NetID[2] << 1, byte(i >> 16), byte(i >> 8), byte(i)},
+			DevEUI:  lorawan.EUI64{1, 0, 0, 0, 0, byte(i >> 16), byte(i >> 8), byte(i)},
+			AppEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		g.rand.Read(ns.NwkSKey[:])
+		if err := session.SaveNodeSession(ctx.RedisPool, ns); err != nil {
+			return nil, fmt.Errorf("save node-session error: %s", err)
+		}
+		g.devices = append(g.devices, ns)
+	}
+
+	return &g, nil
+}
+
+// nextUplink returns the gateway copies of the next uplink.
+func (g *loadTestGenerator) nextUplink() ([]gw.RXPacket, error) {
+	i := g.next
+	g.next = (g.next + 1) % len(g.devices)
+	ns := g.devices[i]
+
+	fPort := uint8(1)
+	data := make([]byte, 10)
+	g.rand.Read(data)
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataUp,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ns.DevAddr,
+				FCnt:    g.fCnt[i],
+			},
+			FPort:      &fPort,
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: data}},
+		},
+	}
+	if err := phy.SetMIC(ns.NwkSKey); err != nil {
+		return nil, err
+	}
+	g.fCnt[i]++
+
+	channel := common.Band.UplinkChannels[g.rand.Intn(len(common.Band.UplinkChannels))]
+	now := time.Now()
+
+	var out []gw.RXPacket
+	for _, j := range g.rand.Perm(len(g.gateways))[:1+g.rand.Intn(min(3, len(g.gateways)))] {
+		out = append(out, gw.RXPacket{
+			RXInfo: gw.RXInfo{
+				MAC:       g.gateways[j],
+				Time:      now,
+				Timestamp: uint32(now.UnixNano() / 1000),
+				Frequency: channel.Frequency,
+				CRCStatus: 1,
+				CodeRate:  "4/5",
+				RSSI:      -120 + g.rand.Intn(80),
+				LoRaSNR:   float64(g.rand.Intn(20) - 10),
+				DataRate:  common.Band.DataRates[5],
+			},
+			PHYPayload: phy,
+		})
+	}
+	return out, nil
+}
+
+// runLoadTest handles the given number of uplinks at the given rate (0 =
+// as fast as possible).
+func runLoadTest(ctx common.Context, g *loadTestGenerator, uplinks, rate int) (loadTestReport, error) {
+	var report loadTestReport
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	var tick <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	inFlight := make(chan struct{}, loadTestMaxInFlight)
+
+	start := time.Now()
+	for i := 0; i < uplinks; i++ {
+		if tick != nil {
+			<-tick
+		}
+
+		rxPackets, err := g.nextUplink()
+		if err != nil {
+			return report, err
+		}
+
+		inFlight <- struct{}{}
+		wg.Add(1)
+		go func(rxPackets []gw.RXPacket) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+
+			latency, err := handleLoadTestUplink(ctx, rxPackets)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Uplinks++
+			if err != nil {
+				report.Errors++
+				return
+			}
+			report.Latencies = append(report.Latencies, latency)
+		}(rxPackets)
+	}
+	wg.Wait()
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
+
+// handleLoadTestUplink handles the gateway copies of an uplink
+// concurrently (as the gateway backend would) and returns the time it took
+// until all copies were handled.
+func handleLoadTestUplink(ctx common.Context, rxPackets []gw.RXPacket) (time.Duration, error) {
+	start := time.Now()
+	errs := make(chan error, len(rxPackets))
+	for _, rxPacket := range rxPackets {
+		go func(rxPacket gw.RXPacket) {
+			errs <- uplink.HandleRXPacket(ctx, rxPacket)
+		}(rxPacket)
+	}
+
+	var err error
+	for range rxPackets {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+		}
+	}
+	return time.Since(start), err
+}
+
+// newLoadTestContext returns a context using clean Redis and PostgreSQL
+// databases and the test clients. The requests received by the test
+// clients are discarded until done is closed.
+func newLoadTestContext(tb testing.TB, done chan struct{}) common.Context {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	p := common.NewRedisPool(conf.RedisURL)
+	test.MustFlushRedis(p)
+	test.MustResetDB(db)
+
+	ctx := common.Context{
+		NetID:       [3]byte{3, 2, 1},
+		RedisPool:   p,
+		DB:          db,
+		Gateway:     test.NewGatewayBackend(),
+		Application: test.NewApplicationClient(),
+		Controller:  test.NewNetworkControllerClient(),
+	}
+	for _, c := range []interface{}{ctx.Gateway, ctx.Application, ctx.Controller} {
+		discardChannels(c, done)
+	}
+	return ctx
+}
+
+// discardChannels reads (and discards) the values sent to the channels of
+// the given test client until done is closed, so that the test clients do
+// not block once their buffers are full.
+func discardChannels(c interface{}, done chan struct{}) {
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.Chan || f.IsNil() {
+			continue
+		}
+
+		go func(ch reflect.Value) {
+			cases := []reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: ch},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+			}
+			for {
+				if chosen, _, ok := reflect.Select(cases); chosen == 1 || !ok {
+					return
+				}
+			}
+		}(f)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// TestLoadScenarios runs the load-test scenarios set by the
+// TEST_LOAD_SCENARIOS environment variable (comma separated names or
+// "all"), e.g.:
+//
+//	TEST_LOAD_SCENARIOS=10k-devices go test -v -run TestLoadScenarios ./internal/testsuite/
+func TestLoadScenarios(t *testing.T) {
+	names := os.Getenv("TEST_LOAD_SCENARIOS")
+	if names == "" {
+		t.Skip("TEST_LOAD_SCENARIOS is not set")
+	}
+
+	for _, s := range loadTestScenarios {
+		if names != "all" && !containsString(strings.Split(names, ","), s.Name) {
+			continue
+		}
+
+		t.Run(s.Name, func(t *testing.T) {
+			done := make(chan struct{})
+			defer close(done)
+
+			ctx := newLoadTestContext(t, done)
+			g, err := newLoadTestGenerator(ctx, s.Devices, s.Gateways)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := runLoadTest(ctx, g, s.UplinksPerSecond*int(s.Duration/time.Second), s.UplinksPerSecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Logf("scenario: %s (devices: %d, gateways: %d, target: %d uplinks/s), %s", s.Name, s.Devices, s.Gateways, s.UplinksPerSecond, report)
+			if report.Errors > 0 {
+				t.Errorf("%d uplinks failed", report.Errors)
+			}
+		})
+	}
+}
+
+// BenchmarkUplinkPipeline handles b.N uplinks of 1000 devices, received by
+// 10 gateways, as fast as possible.
+func BenchmarkUplinkPipeline(b *testing.B) {
+	done := make(chan struct{})
+	defer close(done)
+
+	ctx := newLoadTestContext(b, done)
+	g, err := newLoadTestGenerator(ctx, 1000, 10)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	report, err := runLoadTest(ctx, g, b.N, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.StopTimer()
+
+	b.Logf("%s", report)
+	if report.Errors > 0 {
+		b.Errorf("%d uplinks failed", report.Errors)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if strings.TrimSpace(v) == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/joriwind/loraserver/api/as"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/joinstats"
+	"github.com/joriwind/loraserver/internal/maccommand"
 	"github.com/joriwind/loraserver/internal/session"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/joriwind/loraserver/internal/uplink"
@@ -31,14 +34,20 @@ type otaaTestCase struct {
 
 func TestOTAAScenarios(t *testing.T) {
 	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	Convey("Given a clean state", t, func() {
 		p := common.NewRedisPool(conf.RedisURL)
 		test.MustFlushRedis(p)
+		test.MustResetDB(db)
 
 		ctx := common.Context{
 			NetID:       [3]byte{3, 2, 1},
 			RedisPool:   p,
+			DB:          db,
 			Gateway:     test.NewGatewayBackend(),
 			Application: test.NewApplicationClient(),
 		}
@@ -74,7 +83,7 @@ func TestOTAAScenarios(t *testing.T) {
 			},
 			DevAddr: [4]byte{1, 2, 3, 4},
 			RXDelay: 3,
-			CFList:  &lorawan.CFList{100, 200, 300, 400, 500},
+			CFList:  &lorawan.CFList{867100000, 867300000, 867500000, 867700000, 867900000},
 		}
 		jaPHY := lorawan.PHYPayload{
 			MHDR: lorawan.MHDR{
@@ -162,6 +171,178 @@ func TestOTAAScenarios(t *testing.T) {
 
 			runOTAATests(ctx, tests)
 		})
+
+		Convey("Given the join-accept tx power is set to 20 dBm", func() {
+			common.JoinAcceptTXPower = 20
+			Reset(func() {
+				common.JoinAcceptTXPower = 0
+			})
+
+			tests := []otaaTestCase{
+				{
+					Name:       "join-accept using rx1 (limited to 16 dBm)",
+					RXInfo:     rxInfo,
+					PHYPayload: jrPayload,
+					ApplicationJoinRequestResponse: as.JoinRequestResponse{
+						PhyPayload:  jaBytes,
+						NwkSKey:     []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+						RxDelay:     uint32(jaPayload.RXDelay),
+						Rx1DROffset: uint32(jaPayload.DLSettings.RX1DROffset),
+						CFList:      jaPayload.CFList[:],
+						RxWindow:    as.RXWindow_RX1,
+						Rx2DR:       uint32(jaPayload.DLSettings.RX2DataRate),
+					},
+					AppKey: appKey,
+
+					ExpectedApplicationJoinRequestRequest: as.JoinRequestRequest{
+						PhyPayload: jrBytes,
+						NetID:      []byte{3, 2, 1},
+						DevAddr:    []byte{0, 0, 0, 0},
+					},
+					ExpectedTXInfo: gw.TXInfo{
+						MAC:       rxInfo.MAC,
+						Timestamp: rxInfo.Timestamp + 5000000,
+						Frequency: rxInfo.Frequency,
+						Power:     16,
+						DataRate:  rxInfo.DataRate,
+						CodeRate:  rxInfo.CodeRate,
+					},
+					ExpectedPHYPayload: jaPHY,
+				},
+				{
+					Name:       "join-accept using rx2",
+					RXInfo:     rxInfo,
+					PHYPayload: jrPayload,
+					ApplicationJoinRequestResponse: as.JoinRequestResponse{
+						PhyPayload:  jaBytes,
+						NwkSKey:     []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+						RxDelay:     uint32(jaPayload.RXDelay),
+						Rx1DROffset: uint32(jaPayload.DLSettings.RX1DROffset),
+						CFList:      jaPayload.CFList[:],
+						RxWindow:    as.RXWindow_RX2,
+						Rx2DR:       uint32(jaPayload.DLSettings.RX2DataRate),
+					},
+					AppKey: appKey,
+
+					ExpectedApplicationJoinRequestRequest: as.JoinRequestRequest{
+						PhyPayload: jrBytes,
+						NetID:      []byte{3, 2, 1},
+						DevAddr:    []byte{0, 0, 0, 0},
+					},
+					ExpectedTXInfo: gw.TXInfo{
+						MAC:       rxInfo.MAC,
+						Timestamp: rxInfo.Timestamp + 6000000,
+						Frequency: common.Band.RX2Frequency,
+						Power:     20,
+						DataRate:  common.Band.DataRates[common.Band.RX2DataRate],
+						CodeRate:  rxInfo.CodeRate,
+					},
+					ExpectedPHYPayload: jaPHY,
+				},
+			}
+
+			runOTAATests(ctx, tests)
+		})
+
+		Convey("When a join-request copy is received after the de-duplication delay", func() {
+			ctx.Application.(*test.ApplicationClient).JoinRequestResponse = as.JoinRequestResponse{
+				PhyPayload: jaBytes,
+				NwkSKey:    []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+				RxWindow:   as.RXWindow_RX1,
+			}
+
+			So(uplink.HandleRXPacket(ctx, gw.RXPacket{RXInfo: rxInfo, PHYPayload: jrPayload}), ShouldBeNil)
+
+			// wait until the de-duplication lock has expired
+			time.Sleep(common.DeduplicationDelay*2 + 250*time.Millisecond)
+			So(uplink.HandleRXPacket(ctx, gw.RXPacket{RXInfo: rxInfo, PHYPayload: jrPayload}), ShouldBeNil)
+
+			Convey("Then only one join-request was made to the application server", func() {
+				So(ctx.Application.(*test.ApplicationClient).JoinRequestChan, ShouldHaveLength, 1)
+				So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the application-server returns an invalid join-response", func() {
+			ctx.Application.(*test.ApplicationClient).JoinRequestResponse = as.JoinRequestResponse{
+				PhyPayload: jaBytes,
+				NwkSKey:    []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+				RxWindow:   as.RXWindow_RX1,
+				RxDelay:    16,
+			}
+
+			err := uplink.HandleRXPacket(ctx, gw.RXPacket{RXInfo: rxInfo, PHYPayload: jrPayload})
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, uplink.ErrInvalidJoinResponse.Error())
+
+			Convey("Then the application-server is notified", func() {
+				So(ctx.Application.(*test.ApplicationClient).HandleErrorChan, ShouldHaveLength, 1)
+				req := <-ctx.Application.(*test.ApplicationClient).HandleErrorChan
+				So(req.Type, ShouldEqual, as.ErrorType_OTAA_INVALID_JOIN_RESPONSE)
+				So(req.Error, ShouldContainSubstring, "rxDelay")
+			})
+
+			Convey("Then no node-session is created and no join-accept is sent", func() {
+				_, err := session.GetNodeSession(p, lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8})
+				So(err, ShouldEqual, session.ErrDoesNotExist)
+				So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Given an existing node-session with uplink history and a queued mac-command", func() {
+			devEUI := lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8}
+			So(session.SaveNodeSession(p, session.NodeSession{
+				DevAddr: lorawan.DevAddr{4, 3, 2, 1},
+				DevEUI:  devEUI,
+				UplinkHistory: []session.UplinkHistory{
+					{FCnt: 10, MaxSNR: 5, GatewayCount: 2},
+				},
+			}), ShouldBeNil)
+			So(maccommand.AddToQueue(p, maccommand.QueueItem{
+				DevEUI: devEUI,
+				Data:   []byte{6},
+			}), ShouldBeNil)
+
+			resp := as.JoinRequestResponse{
+				PhyPayload: jaBytes,
+				NwkSKey:    []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+				RxWindow:   as.RXWindow_RX1,
+			}
+
+			Convey("When the node rejoins with preserveDownlinkQueue set", func() {
+				resp.PreserveDownlinkQueue = true
+				ctx.Application.(*test.ApplicationClient).JoinRequestResponse = resp
+				So(uplink.HandleRXPacket(ctx, gw.RXPacket{RXInfo: rxInfo, PHYPayload: jrPayload}), ShouldBeNil)
+
+				Convey("Then the uplink history and mac-command queue are preserved", func() {
+					ns, err := session.GetNodeSession(p, devEUI)
+					So(err, ShouldBeNil)
+					So(ns.DevAddr, ShouldNotResemble, lorawan.DevAddr{4, 3, 2, 1})
+					So(ns.UplinkHistory, ShouldResemble, []session.UplinkHistory{
+						{FCnt: 10, MaxSNR: 5, GatewayCount: 2},
+					})
+
+					items, err := maccommand.ReadQueue(p, devEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("When the node rejoins without preserveDownlinkQueue set", func() {
+				ctx.Application.(*test.ApplicationClient).JoinRequestResponse = resp
+				So(uplink.HandleRXPacket(ctx, gw.RXPacket{RXInfo: rxInfo, PHYPayload: jrPayload}), ShouldBeNil)
+
+				Convey("Then the uplink history and mac-command queue are flushed", func() {
+					ns, err := session.GetNodeSession(p, devEUI)
+					So(err, ShouldBeNil)
+					So(ns.UplinkHistory, ShouldHaveLength, 0)
+
+					items, err := maccommand.ReadQueue(p, devEUI)
+					So(err, ShouldBeNil)
+					So(items, ShouldHaveLength, 0)
+				})
+			})
+		})
 	})
 }
 
@@ -212,6 +393,15 @@ func runOTAATests(ctx common.Context, tests []otaaTestCase) {
 				So(err, ShouldBeNil)
 				So(ns.LastRXInfoSet, ShouldResemble, []gw.RXInfo{t.RXInfo})
 			})
+
+			Convey("Then the accepted join has been recorded in the join stats", func() {
+				stats, err := joinstats.GetGatewayStats(ctx.RedisPool)
+				So(err, ShouldBeNil)
+				So(stats, ShouldHaveLength, 1)
+				So(stats[0].MAC, ShouldEqual, t.RXInfo.MAC)
+				So(stats[0].RequestCount, ShouldEqual, 1)
+				So(stats[0].AcceptCount, ShouldEqual, 1)
+			})
 		})
 	}
 }
@@ -12,8 +12,11 @@ import (
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/api/nc"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/framelog"
 	"github.com/joriwind/loraserver/internal/gateway"
 	"github.com/joriwind/loraserver/internal/maccommand"
+	"github.com/joriwind/loraserver/internal/models"
 	"github.com/joriwind/loraserver/internal/session"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/joriwind/loraserver/internal/uplink"
@@ -256,6 +259,8 @@ func TestUplinkScenarios(t *testing.T) {
 					Longitude: gw1.Location.Longitude,
 				},
 			},
+			MaxPayloadSizeRX1: 51,
+			MaxPayloadSizeRX2: 51,
 		}
 
 		expectedApplicationPushDataUpFCntRollOver := &as.HandleDataUpRequest{
@@ -285,6 +290,8 @@ func TestUplinkScenarios(t *testing.T) {
 					Longitude: gw1.Location.Longitude,
 				},
 			},
+			MaxPayloadSizeRX1: 51,
+			MaxPayloadSizeRX2: 51,
 		}
 
 		expectedApplicationPushDataUpNoData := &as.HandleDataUpRequest{
@@ -314,8 +321,13 @@ func TestUplinkScenarios(t *testing.T) {
 					Longitude: gw1.Location.Longitude,
 				},
 			},
+			MaxPayloadSizeRX1: 51,
+			MaxPayloadSizeRX2: 51,
 		}
 
+		expectedApplicationPushDataUpNoDataRX2DR3 := *expectedApplicationPushDataUpNoData
+		expectedApplicationPushDataUpNoDataRX2DR3.MaxPayloadSizeRX2 = 115
+
 		expectedGetDataDown := &as.GetDataDownRequest{
 			AppEUI:         ns.AppEUI[:],
 			DevEUI:         ns.DevEUI[:],
@@ -698,7 +710,7 @@ func TestUplinkScenarios(t *testing.T) {
 						},
 					},
 					ExpectedControllerHandleRXInfo:  expectedControllerHandleRXInfo,
-					ExpectedApplicationHandleDataUp: expectedApplicationPushDataUpNoData,
+					ExpectedApplicationHandleDataUp: &expectedApplicationPushDataUpNoDataRX2DR3,
 					ExpectedApplicationGetDataDown: &as.GetDataDownRequest{
 						AppEUI:         ns.AppEUI[:],
 						DevEUI:         ns.DevEUI[:],
@@ -1660,6 +1672,85 @@ func TestUplinkScenarios(t *testing.T) {
 
 			runUplinkTests(ctx, tests)
 		})
+
+		Convey("Given a node-session with transmit diversity enabled and an uplink received by two gateways", func() {
+			ns.TransmitDiversity = true
+			So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+			rxInfo2 := rxInfo
+			rxInfo2.MAC = lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8}
+			rxInfo2.Timestamp = 5000000
+			rxInfo2.LoRaSNR = 5
+
+			rxPacket := models.RXPacket{
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: ns.DevAddr,
+							FCnt:    10,
+						},
+					},
+				},
+				RXInfoSet: []gw.RXInfo{rxInfo, rxInfo2},
+			}
+
+			Convey("When the application-server returns a critical confirmed payload", func() {
+				ctx.Application.(*test.ApplicationClient).GetDataDownResponse = as.GetDataDownResponse{
+					FPort:     10,
+					Data:      []byte{1, 2, 3, 4},
+					Confirmed: true,
+					Critical:  true,
+				}
+				So(downlink.SendUplinkResponse(ctx, ns, rxPacket), ShouldBeNil)
+
+				Convey("Then the frame is sent via both gateways, using both RX windows", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 2)
+					txPacket1 := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+					txPacket2 := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+
+					So(txPacket1.TXInfo.MAC, ShouldEqual, rxInfo.MAC)
+					So(txPacket1.TXInfo.Frequency, ShouldEqual, rxInfo.Frequency)
+					So(txPacket1.TXInfo.Timestamp, ShouldEqual, rxInfo.Timestamp+1000000)
+
+					So(txPacket2.TXInfo.MAC, ShouldEqual, rxInfo2.MAC)
+					So(txPacket2.TXInfo.Frequency, ShouldEqual, common.Band.RX2Frequency)
+					So(txPacket2.TXInfo.DataRate, ShouldResemble, common.Band.DataRates[ns.RX2DR])
+					So(txPacket2.TXInfo.Timestamp, ShouldEqual, rxInfo2.Timestamp+2000000)
+
+					So(txPacket2.PHYPayload, ShouldResemble, txPacket1.PHYPayload)
+				})
+
+				Convey("Then the diversity transmission is recorded in the frame log", func() {
+					frames, err := framelog.GetDownlinkFrames(p, ns.DevEUI, ns.FCntDown)
+					So(err, ShouldBeNil)
+					So(frames, ShouldHaveLength, 2)
+					So(frames[0].Diversity, ShouldBeFalse)
+					So(frames[1].Diversity, ShouldBeTrue)
+					So(frames[1].MAC, ShouldEqual, rxInfo2.MAC)
+					So(frames[1].Session.FCntDown, ShouldEqual, ns.FCntDown)
+					So(frames[1].Session.RX2DR, ShouldEqual, ns.RX2DR)
+				})
+			})
+
+			Convey("When the application-server returns a confirmed payload which is not critical", func() {
+				ctx.Application.(*test.ApplicationClient).GetDataDownResponse = as.GetDataDownResponse{
+					FPort:     10,
+					Data:      []byte{1, 2, 3, 4},
+					Confirmed: true,
+				}
+				So(downlink.SendUplinkResponse(ctx, ns, rxPacket), ShouldBeNil)
+
+				Convey("Then the frame is only sent via the best gateway", func() {
+					So(ctx.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+					txPacket := <-ctx.Gateway.(*test.GatewayBackend).TXPacketChan
+					So(txPacket.TXInfo.MAC, ShouldEqual, rxInfo.MAC)
+				})
+			})
+		})
 	})
 }
 
@@ -1814,6 +1905,18 @@ func runUplinkTests(ctx common.Context, tests []uplinkTestCase) {
 					So(err, ShouldBeNil)
 					So(ns.LastRXInfoSet, ShouldResemble, []gw.RXInfo{t.RXInfo})
 				})
+
+				Convey("Then the downlink decision has been recorded", func() {
+					decisions, err := downlink.GetDecisions(ctx.RedisPool, t.NodeSession.DevEUI, 0, 0)
+					So(err, ShouldBeNil)
+					So(decisions, ShouldHaveLength, 1)
+					So(decisions[0].FCntUp, ShouldEqual, t.ExpectedFCntUp-1)
+					if t.ExpectedTXInfo != nil {
+						So(decisions[0].Decision, ShouldEqual, downlink.DecisionTransmitted)
+					} else {
+						So(decisions[0].Decision, ShouldEqual, downlink.DecisionNothingToSend)
+					}
+				})
 			}
 		})
 	}
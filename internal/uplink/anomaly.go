@@ -0,0 +1,80 @@
+package uplink
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/anomaly"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// getAnomalyFeatures returns the anomaly detection features of the given
+// uplink. It must be called before the node-session has been updated with
+// the meta-data of this uplink.
+func getAnomalyFeatures(ns session.NodeSession, rxPacket models.RXPacket, fullFCnt uint32, receivedAt time.Time) (anomaly.Features, error) {
+	dr, err := common.GetDataRate(rxPacket.RXInfoSet[0].DataRate)
+	if err != nil {
+		return anomaly.Features{}, errors.Wrap(err, "get data-rate error")
+	}
+
+	f := anomaly.Features{
+		DevEUI:     ns.DevEUI,
+		ReceivedAt: receivedAt,
+		DataRate:   dr,
+		FCnt:       fullFCnt,
+	}
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		f.GatewayMACs = append(f.GatewayMACs, rxInfo.MAC)
+	}
+
+	if !ns.LastUplinkAt.IsZero() && len(ns.LastRXInfoSet) > 0 {
+		f.HasPrevious = true
+		f.InterArrival = receivedAt.Sub(ns.LastUplinkAt)
+		f.PreviousFCnt = ns.FCntUp - 1
+		if f.PreviousDataRate, err = common.GetDataRate(ns.LastRXInfoSet[0].DataRate); err != nil {
+			return f, errors.Wrap(err, "get previous data-rate error")
+		}
+		for _, rxInfo := range ns.LastRXInfoSet {
+			f.PreviousGatewayMACs = append(f.PreviousGatewayMACs, rxInfo.MAC)
+		}
+	}
+
+	return f, nil
+}
+
+// handleAnomalyDetection runs the anomaly detector (if configured) on the
+// given uplink. Detected anomalies are logged and sent to the
+// network-controller, the uplink itself is processed as usual.
+func handleAnomalyDetection(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, fullFCnt uint32, receivedAt time.Time) error {
+	f, err := getAnomalyFeatures(ns, rxPacket, fullFCnt, receivedAt)
+	if err != nil {
+		return err
+	}
+
+	a, err := anomaly.Detect(f)
+	if err != nil || a == nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui": ns.DevEUI,
+		"fcnt":    fullFCnt,
+		"reason":  a.Reason,
+	}).Warning("uplink anomaly detected")
+
+	_, err = ctx.Controller.HandleError(ctx.RequestContext(), &nc.HandleErrorRequest{
+		AppEUI: ns.AppEUI[:],
+		DevEUI: ns.DevEUI[:],
+		Error:  fmt.Sprintf("uplink anomaly detected: %s", a.Reason),
+	})
+	if err != nil {
+		return errors.Wrap(err, "send anomaly to network-controller error")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package uplink
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// recordAntennaStats records the rx-info of each antenna in the antenna
+// stats of the gateway. The antennas of which the rx-info is in the merged
+// set (see models.RXInfoSet.MergeAntennas) are recorded as selected. Errors
+// are logged as the stats must not affect the handling of the uplink.
+func recordAntennaStats(p *redis.Pool, rxInfoSet, merged models.RXInfoSet) {
+	for _, rxInfo := range rxInfoSet {
+		var selected bool
+		for _, m := range merged {
+			if m.MAC == rxInfo.MAC && m.Antenna == rxInfo.Antenna {
+				selected = true
+				break
+			}
+		}
+
+		if err := gateway.RecordAntennaRXPacket(p, rxInfo.MAC, rxInfo.Antenna, rxInfo.RSSI, rxInfo.LoRaSNR, selected); err != nil {
+			log.WithFields(log.Fields{
+				"mac":     rxInfo.MAC,
+				"antenna": rxInfo.Antenna,
+			}).Errorf("record antenna stats error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package uplink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/applayer"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// handleAppLayerUplink decodes the uplinks sent on the FPort of a
+// registered application-layer package. This is only possible when the
+// AppSKey encryption is offloaded to LoRa Server. It returns true when the
+// uplink has been handled by LoRa Server and must not be forwarded to the
+// application-server.
+func handleAppLayerUplink(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, macPL *lorawan.MACPayload) (bool, error) {
+	if ns.AppSKey == nil || macPL.FPort == nil || len(macPL.FRMPayload) != 1 {
+		return false, nil
+	}
+
+	pkg, ok := applayer.GetPackage(*macPL.FPort)
+	if !ok {
+		return false, nil
+	}
+
+	dataPL, ok := macPL.FRMPayload[0].(*lorawan.DataPayload)
+	if !ok {
+		return false, errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.DataPayload, got: %T", macPL.FRMPayload[0])
+	}
+
+	// EncryptFRMPayload might modify the given slice in-place
+	b := make([]byte, len(dataPL.Bytes))
+	copy(b, dataPL.Bytes)
+	data, err := lorawan.EncryptFRMPayload(*ns.AppSKey, true, macPL.FHDR.DevAddr, macPL.FHDR.FCnt, b)
+	if err != nil {
+		return false, errors.Wrap(err, "decrypt FRMPayload error")
+	}
+
+	commands, err := pkg.Codec.DecodeUplink(data)
+	if err != nil {
+		return false, errors.Wrapf(err, "decode %s uplink error", pkg.Name)
+	}
+
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, cmd.Name)
+	}
+	handled := applayer.IsHandledByNetworkServer(pkg.FPort)
+
+	log.WithFields(log.Fields{
+		"dev_eui":  ns.DevEUI,
+		"package":  pkg.Name,
+		"commands": names,
+		"handled":  handled,
+	}).Info("application-layer package uplink received")
+
+	if !handled {
+		return false, nil
+	}
+
+	receivedAt := rxPacket.RXInfoSet[0].Time
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	resp, err := pkg.Codec.(applayer.Handler).HandleUplink(receivedAt, commands)
+	if err != nil {
+		return true, errors.Wrapf(err, "handle %s uplink error", pkg.Name)
+	}
+
+	if len(resp) != 0 {
+		err = applayer.SetDownlink(ctx.RedisPool, ns.DevEUI, applayer.Downlink{
+			FPort: pkg.FPort,
+			Data:  resp,
+		})
+		if err != nil {
+			return true, errors.Wrap(err, "set application-layer package downlink error")
+		}
+	}
+
+	return true, nil
+}
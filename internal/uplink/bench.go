@@ -0,0 +1,36 @@
+package uplink
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// handleBenchUplink handles the uplink of an unprovisioned test device for
+// which a bench downlink has been enqueued (see downlink.BenchDownlink). It
+// returns false when there is no bench downlink matching the uplink. The
+// uplink itself is not forwarded to the application-server.
+func handleBenchUplink(ctx common.Context, rxPacket gw.RXPacket, receivedAt time.Time) (bool, error) {
+	d, err := downlink.GetBenchDownlink(ctx.RedisPool, rxPacket.PHYPayload)
+	if err != nil || d == nil {
+		return false, err
+	}
+
+	// the downlink must be sent before RX1 (using the defaults of the band)
+	ctx, cancel := ctx.WithDeadline(getRX1Deadline(session.NodeSession{}, receivedAt))
+	defer cancel()
+
+	return true, collectAndCallOnce(ctx.RedisPool, rxPacket, func(rxPacket models.RXPacket) error {
+		log.WithFields(log.Fields{
+			"dev_addr": d.DevAddr,
+			"gw_count": len(rxPacket.RXInfoSet),
+		}).Warning("bench uplink received")
+		return downlink.SendBenchDownlink(ctx, *d, rxPacket)
+	})
+}
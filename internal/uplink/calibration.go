@@ -0,0 +1,43 @@
+package uplink
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// calibrateRXInfoSet applies the RX calibration offsets of the gateways to
+// the RSSI and LoRa SNR of the given RXInfo set and re-sorts the set, so
+// that the calibrated values are used for ADR and the downlink gateway
+// selection.
+func calibrateRXInfoSet(ctx common.Context, rxPacket *models.RXPacket) error {
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	calibrations, err := gateway.GetGatewayRXCalibrations(ctx.DB, macs)
+	if err != nil {
+		return errors.Wrap(err, "get gateway rx calibrations error")
+	}
+	if len(calibrations) == 0 {
+		return nil
+	}
+
+	for i := range rxPacket.RXInfoSet {
+		c, ok := calibrations[rxPacket.RXInfoSet[i].MAC]
+		if !ok {
+			continue
+		}
+		rxPacket.RXInfoSet[i].RSSI += c.RSSIOffset
+		rxPacket.RXInfoSet[i].LoRaSNR += c.LoRaSNROffset
+	}
+
+	sort.Sort(rxPacket.RXInfoSet)
+	return nil
+}
@@ -0,0 +1,50 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCalibrateRXInfoSet(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean database with a gateway with rx calibration offsets", t, func() {
+		db, err := common.OpenDatabase(conf.PostgresDSN)
+		So(err, ShouldBeNil)
+		test.MustResetDB(db)
+
+		ctx := common.Context{DB: db}
+
+		gw1 := gateway.Gateway{
+			MAC:           lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+			Name:          "gw1",
+			RSSIOffset:    -10,
+			LoRaSNROffset: -3,
+		}
+		So(gateway.CreateGateway(db, &gw1), ShouldBeNil)
+
+		Convey("When calibrating a RXInfo set in which this gateway has the best metrics", func() {
+			rxPacket := models.RXPacket{
+				RXInfoSet: models.RXInfoSet{
+					{MAC: gw1.MAC, RSSI: -80, LoRaSNR: 5},
+					{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RSSI: -85, LoRaSNR: 4},
+				},
+			}
+			So(calibrateRXInfoSet(ctx, &rxPacket), ShouldBeNil)
+
+			Convey("Then the offsets are applied and the set is re-sorted", func() {
+				So(rxPacket.RXInfoSet, ShouldResemble, models.RXInfoSet{
+					gw.RXInfo{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RSSI: -85, LoRaSNR: 4},
+					gw.RXInfo{MAC: gw1.MAC, RSSI: -90, LoRaSNR: 2},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,193 @@
+package uplink
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const (
+	cFListTypeChMask = 1 // CFList type byte of the channel mask CFList
+	cFListChMaskSize = 5 // number of 16 bit ChMask blocks in the CFList
+)
+
+// cFListChMaskBands contains the bands with fixed uplink channels, using
+// the channel mask CFList (type 1) in the join-accept.
+var cFListChMaskBands = map[band.Name]struct{}{
+	band.AU_915_928: {},
+	band.US_902_928: {},
+}
+
+// UnsupportedCFListAction defines the handling of a channel frequency
+// CFList returned by the application-server for a band which does not
+// implement it (e.g. US 915 and AU 915).
+type UnsupportedCFListAction string
+
+// Available unsupported CFList actions.
+const (
+	// RejectUnsupportedCFList rejects the join-request.
+	RejectUnsupportedCFList UnsupportedCFListAction = "reject"
+
+	// DropUnsupportedCFList drops the CFList from the node-session, so
+	// that the uplink channels of the node are the channels of the band.
+	DropUnsupportedCFList UnsupportedCFListAction = "drop"
+)
+
+var unsupportedCFListAction = RejectUnsupportedCFList
+
+// MustSetUnsupportedCFListAction sets the handling of unsupported CFLists
+// by name (reject or drop).
+func MustSetUnsupportedCFListAction(name string) {
+	switch a := UnsupportedCFListAction(name); a {
+	case "":
+		unsupportedCFListAction = RejectUnsupportedCFList
+	case RejectUnsupportedCFList, DropUnsupportedCFList:
+		unsupportedCFListAction = a
+	default:
+		log.Fatalf("invalid unsupported cflist action '%s' (valid options: reject, drop)", name)
+	}
+}
+
+// enabledUplinkChannels contains the uplink channels enabled by the
+// network (e.g. the sub-band of the gateways). When empty, no channel mask
+// CFList is generated.
+var enabledUplinkChannels []int
+
+// MustSetEnabledUplinkChannels sets the uplink channels enabled by the
+// network, given as channel indices or ranges (e.g. 0-7 and 65). Channels
+// must exist in the given band, which must use the channel mask CFList.
+func MustSetEnabledUplinkChannels(channels []string, name band.Name, b band.Band) {
+	enabledUplinkChannels = nil
+
+	for _, s := range channels {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if _, ok := cFListChMaskBands[name]; !ok {
+			log.Fatalf("band %s does not implement the channel mask CFList", name)
+		}
+
+		first, last, err := parseChannelRange(s)
+		if err != nil {
+			log.Fatalf("'%s' is not a valid channel (range): %s", s, err)
+		}
+		if last >= len(b.UplinkChannels) {
+			log.Fatalf("channel %d does not exist in band %s", last, name)
+		}
+
+		for c := first; c <= last; c++ {
+			enabledUplinkChannels = append(enabledUplinkChannels, c)
+		}
+	}
+}
+
+// parseChannelRange parses the given channel index or (inclusive) range.
+func parseChannelRange(s string) (int, int, error) {
+	parts := strings.SplitN(s, "-", 2)
+
+	first, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	last := first
+	if len(parts) == 2 {
+		last, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if first < 0 || last < first {
+		return 0, 0, errors.New("invalid range")
+	}
+	return first, last, nil
+}
+
+// getCFListChMask returns the channel mask CFList containing the enabled
+// uplink channels, to be used in the join-accept. It returns nil when the
+// band does not use the channel mask CFList or when no enabled uplink
+// channels are configured.
+func getCFListChMask() []byte {
+	if len(enabledUplinkChannels) == 0 {
+		return nil
+	}
+	return marshalCFListChMask(enabledUplinkChannels)
+}
+
+// marshalCFListChMask returns the channel mask CFList (type 1) enabling the
+// given channels. The CFList contains 5 ChMask blocks (little endian, each
+// covering 16 channels), followed by 5 RFU bytes and the CFList type.
+func marshalCFListChMask(channels []int) []byte {
+	out := make([]byte, 16)
+	for _, c := range channels {
+		if c >= cFListChMaskSize*16 {
+			continue
+		}
+		out[c/8] |= 1 << uint(c%8)
+	}
+	out[15] = cFListTypeChMask
+	return out
+}
+
+// handleUnsupportedCFList handles a channel frequency CFList in the given
+// join-response when the band does not implement it. Depending on the
+// configured UnsupportedCFListAction, the CFList is removed from the
+// join-response or true is returned, in which case the join-request must be
+// rejected. In both cases the application-server is notified
+// (OTAA_UNSUPPORTED_CFLIST).
+func handleUnsupportedCFList(ctx common.Context, jrPL lorawan.JoinRequestPayload, joinResp *as.JoinRequestResponse) (bool, error) {
+	if common.Band.ImplementsCFlist || joinResp.CFListType != as.CFListType_FREQUENCIES || !hasCFListFrequencies(joinResp.CFList) {
+		return false, nil
+	}
+
+	reject := unsupportedCFListAction != DropUnsupportedCFList
+	errStr := fmt.Sprintf("band %s does not implement the channel frequency CFList", common.BandName)
+
+	logFields := log.Fields{
+		"dev_eui": jrPL.DevEUI,
+		"band":    common.BandName,
+		"cflist":  joinResp.CFList,
+	}
+	if reject {
+		log.WithFields(logFields).Warning("join-request rejected, unsupported cflist")
+		errStr += ", join-request rejected"
+	} else {
+		log.WithFields(logFields).Warning("unsupported cflist dropped from node-session")
+		errStr += ", the CFList has been dropped from the node-session"
+		joinResp.CFList = nil
+	}
+
+	_, err := ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Type:   as.ErrorType_OTAA_UNSUPPORTED_CFLIST,
+		Error:  errStr,
+	})
+	if err != nil {
+		return reject, errors.Wrap(err, "send unsupported cflist error to application-server error")
+	}
+	return reject, nil
+}
+
+// hasCFListFrequencies returns true when the given CFList contains at least
+// one (non-zero) frequency.
+func hasCFListFrequencies(cFList []uint32) bool {
+	for _, f := range cFList {
+		if f != 0 {
+			return true
+		}
+	}
+	return false
+}
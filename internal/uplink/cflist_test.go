@@ -0,0 +1,118 @@
+package uplink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestParseChannelRange(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Value         string
+			ExpectedFirst int
+			ExpectedLast  int
+			ExpectedError bool
+		}{
+			{"0-7", 0, 7, false},
+			{"65", 65, 65, false},
+			{"8-8", 8, 8, false},
+			{"7-0", 0, 0, true},
+			{"-1", 0, 0, true},
+			{"a-b", 0, 0, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Value, i), func() {
+				first, last, err := parseChannelRange(test.Value)
+				So(err != nil, ShouldEqual, test.ExpectedError)
+				So(first, ShouldEqual, test.ExpectedFirst)
+				So(last, ShouldEqual, test.ExpectedLast)
+			})
+		}
+	})
+}
+
+func TestMarshalCFListChMask(t *testing.T) {
+	Convey("When marshaling the channel mask CFList for channels 0-7 and 65", t, func() {
+		b := marshalCFListChMask([]int{0, 1, 2, 3, 4, 5, 6, 7, 65})
+
+		Convey("Then the expected bytes are returned", func() {
+			So(b, ShouldResemble, []byte{
+				0xff, 0x00, // ChMask0 (channels 0 - 15)
+				0x00, 0x00, // ChMask1
+				0x00, 0x00, // ChMask2
+				0x00, 0x00, // ChMask3
+				0x02, 0x00, // ChMask4 (channels 64 - 79)
+				0x00, 0x00, 0x00, 0x00, 0x00, // RFU
+				0x01, // CFList type
+			})
+		})
+	})
+}
+
+func TestHandleUnsupportedCFList(t *testing.T) {
+	Convey("Given a band which does not implement the CFList", t, func() {
+		implementsCFList := common.Band.ImplementsCFlist
+		common.Band.ImplementsCFlist = false
+		defer func() {
+			common.Band.ImplementsCFlist = implementsCFList
+			MustSetUnsupportedCFListAction("")
+		}()
+
+		asClient := test.NewApplicationClient()
+		ctx := common.Context{Application: asClient}
+		jrPL := lorawan.JoinRequestPayload{
+			AppEUI: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+		}
+		resp := as.JoinRequestResponse{
+			CFList: []uint32{867100000, 0},
+		}
+
+		Convey("When the join-response does not contain CFList frequencies", func() {
+			resp.CFList = []uint32{0, 0}
+			rejected, err := handleUnsupportedCFList(ctx, jrPL, &resp)
+			So(err, ShouldBeNil)
+
+			Convey("Then the join-request is not rejected", func() {
+				So(rejected, ShouldBeFalse)
+				So(asClient.HandleErrorChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When using the reject action", func() {
+			MustSetUnsupportedCFListAction("reject")
+			rejected, err := handleUnsupportedCFList(ctx, jrPL, &resp)
+			So(err, ShouldBeNil)
+
+			Convey("Then the join-request is rejected and the application-server is notified", func() {
+				So(rejected, ShouldBeTrue)
+				So(asClient.HandleErrorChan, ShouldHaveLength, 1)
+				req := <-asClient.HandleErrorChan
+				So(req.Type, ShouldEqual, as.ErrorType_OTAA_UNSUPPORTED_CFLIST)
+				So(req.DevEUI, ShouldResemble, jrPL.DevEUI[:])
+			})
+		})
+
+		Convey("When using the drop action", func() {
+			MustSetUnsupportedCFListAction("drop")
+			rejected, err := handleUnsupportedCFList(ctx, jrPL, &resp)
+			So(err, ShouldBeNil)
+
+			Convey("Then the CFList is dropped and the application-server is notified", func() {
+				So(rejected, ShouldBeFalse)
+				So(resp.CFList, ShouldBeNil)
+				So(asClient.HandleErrorChan, ShouldHaveLength, 1)
+				req := <-asClient.HandleErrorChan
+				So(req.Type, ShouldEqual, as.ErrorType_OTAA_UNSUPPORTED_CFLIST)
+			})
+		})
+	})
+}
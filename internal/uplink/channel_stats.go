@@ -0,0 +1,50 @@
+package uplink
+
+import (
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/brocaar/lorawan/band"
+
+	"github.com/joriwind/loraserver/internal/channelnoise"
+	"github.com/joriwind/loraserver/internal/channelstats"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// recordChannelStats records the frequency and data-rate of the given
+// (de-duplicated) uplink in the channel utilization stats and the noise
+// floor of each receiving gateway. Errors are logged as the stats must not
+// affect the handling of the uplink.
+func recordChannelStats(ctx common.Context, rxPacket models.RXPacket) {
+	if len(rxPacket.RXInfoSet) == 0 {
+		return
+	}
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		// the SNR is only reported for LoRa modulation
+		if rxInfo.DataRate.Modulation != band.LoRaModulation {
+			continue
+		}
+		if err := channelnoise.RecordUplink(ctx.RedisPool, rxInfo); err != nil {
+			log.WithFields(log.Fields{
+				"mac":       rxInfo.MAC,
+				"frequency": rxInfo.Frequency,
+			}).Errorf("record channel noise error: %s", err)
+		}
+	}
+
+	rxInfo := rxPacket.RXInfoSet[0]
+
+	dr, err := common.GetDataRate(rxInfo.DataRate)
+	if err != nil {
+		log.WithField("data_rate", rxInfo.DataRate).Errorf("get data-rate error: %s", err)
+		return
+	}
+
+	if err := channelstats.RecordUplink(ctx.RedisPool, rxInfo.Frequency, dr); err != nil {
+		log.WithFields(log.Fields{
+			"frequency": rxInfo.Frequency,
+			"dr":        dr,
+		}).Errorf("record channel stats error: %s", err)
+	}
+}
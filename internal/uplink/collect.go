@@ -4,16 +4,13 @@ import (
 	"bytes"
 	"encoding/gob"
 	"encoding/hex"
-	"errors"
-	"fmt"
 	"sort"
-	"time"
 
 	"github.com/joriwind/loraserver/api/gw"
-	"github.com/joriwind/loraserver/internal/common"
 	"github.com/joriwind/loraserver/internal/models"
 	"github.com/brocaar/lorawan"
 	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
 )
 
 // Templates used for generating Redis keys
@@ -22,81 +19,49 @@ const (
 	CollectLockKeyTempl = "loraserver:rx:collect:%s:lock"
 )
 
-// collectAndCallOnce collects the package, sleeps the configured duraction and
-// calls the callback only once with a slice of packets, sorted by signal
-// strength (strongest at index 0). This method exists since multiple gateways
-// are able to receive the same packet, but the packet needs to processed
-// only once.
+// collectAndCallOnce collects the package using the configured Deduplicator,
+// waits until the collection completes (see DeduplicationStrategy) and calls the callback only once with a
+// slice of packets, sorted by signal strength (strongest at index 0). This
+// method exists since multiple gateways are able to receive the same packet,
+// but the packet needs to processed only once.
 // It is safe to collect the same packet received by the same gateway twice.
 // Since the underlying storage type is a set, the result will always be a
 // unique set per gateway MAC and packet MIC.
 func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packet models.RXPacket) error) error {
+	packetsReceivedCount.Inc(rxPacket.RXInfo.MAC.String())
+
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
 	if err := enc.Encode(rxPacket); err != nil {
-		return fmt.Errorf("encode rx packet error: %s", err)
+		return errors.Wrap(err, "encode rx packet error")
 	}
-	c := p.Get()
-	defer c.Close()
 
-	// store the packet in a set with DeduplicationDelay expiration
-	// in case the packet is received by multiple gateways, the set will contain
-	// each packet.
 	// since we can't trust the MIC (in case of a join-request, it will be
 	// validated after the collect), we generate a new one and use it as the
-	// hash for the storage key.
+	// identifier of the frame.
 	if err := rxPacket.PHYPayload.SetMIC(lorawan.AES128Key{}); err != nil {
-		return fmt.Errorf("set mic error: %s", err)
+		return errors.Wrap(err, "set mic error")
 	}
-
 	mic := hex.EncodeToString(rxPacket.PHYPayload.MIC[:])
-	key := fmt.Sprintf(CollectKeyTempl, mic)
-	lockKey := fmt.Sprintf(CollectLockKeyTempl, mic)
-
-	// this way we can set a really low DeduplicationDelay for testing, without
-	// the risk that the set already expired in redis on read
-	deduplicationTTL := common.DeduplicationDelay * 2
-	if deduplicationTTL < time.Millisecond*200 {
-		deduplicationTTL = time.Millisecond * 200
-	}
-
-	c.Send("MULTI")
-	c.Send("SADD", key, buf.Bytes())
-	c.Send("PEXPIRE", key, int64(deduplicationTTL)/int64(time.Millisecond))
-	_, err := c.Do("EXEC")
-	if err != nil {
-		return fmt.Errorf("add rx packet to collect set error: %s", err)
-	}
 
-	// acquire a lock on processing this packet
-	_, err = redis.String((c.Do("SET", lockKey, "lock", "PX", int64(deduplicationTTL)/int64(time.Millisecond), "NX")))
+	payloads, err := getDeduplicator().Deduplicate(p, mic, buf.Bytes(), getDeduplicationConfig())
 	if err != nil {
-		if err == redis.ErrNil {
-			// the packet processing is already locked by an other process
-			// so there is nothing to do anymore :-)
-			return nil
-		}
-		return fmt.Errorf("acquire lock error: %s", err)
+		return err
 	}
-
-	// wait the configured amount of time, more packets might be received
-	// from other gateways
-	time.Sleep(common.DeduplicationDelay)
-
-	// collect all packets from the set
-	var rxPacketWithRXInfoSet models.RXPacket
-	payloads, err := redis.ByteSlices(c.Do("SMEMBERS", key))
-	if err != nil {
-		return fmt.Errorf("get collect set members error: %s", err)
+	if payloads == nil {
+		// the packet is handled by the first reception of the frame
+		return nil
 	}
 	if len(payloads) == 0 {
-		return errors.New("zero items in collect set")
+		return ErrEmptyCollectSet
 	}
+	deduplicationSetSize.Observe(float64(len(payloads)))
 
+	var rxPacketWithRXInfoSet models.RXPacket
 	for i, b := range payloads {
 		var packet gw.RXPacket
 		if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&packet); err != nil {
-			return fmt.Errorf("decode rx packet error: %s", err)
+			return errors.Wrap(err, "decode rx packet error")
 		}
 
 		if i == 0 {
@@ -105,6 +70,12 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packe
 		rxPacketWithRXInfoSet.RXInfoSet = append(rxPacketWithRXInfoSet.RXInfoSet, packet.RXInfo)
 	}
 
+	// a gateway with multiple antennas might report the packet once per
+	// antenna, these must not be handled as different gateways
+	merged := rxPacketWithRXInfoSet.RXInfoSet.MergeAntennas()
+	recordAntennaStats(p, rxPacketWithRXInfoSet.RXInfoSet, merged)
+	rxPacketWithRXInfoSet.RXInfoSet = merged
+
 	sort.Sort(rxPacketWithRXInfoSet.RXInfoSet)
 	return callback(rxPacketWithRXInfoSet)
 }
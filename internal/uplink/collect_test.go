@@ -7,6 +7,7 @@ import (
 
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
 	"github.com/joriwind/loraserver/internal/models"
 	"github.com/joriwind/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
@@ -89,6 +90,50 @@ func TestCollectAndCallOnce(t *testing.T) {
 					So(received, ShouldEqual, test.Count)
 				})
 			}
+
+			Convey("When a gateway reports the packet on two antennas", func() {
+				mac := lorawan.EUI64{4, 1, 1, 1, 1, 1, 1, 1}
+				var rxInfoSet models.RXInfoSet
+
+				cb := func(packet models.RXPacket) error {
+					rxInfoSet = packet.RXInfoSet
+					return nil
+				}
+
+				var wg sync.WaitGroup
+				for _, rxInfo := range []gw.RXInfo{
+					{MAC: mac, Antenna: 0, LoRaSNR: 1, RSSI: -90},
+					{MAC: mac, Antenna: 1, LoRaSNR: 5, RSSI: -70},
+				} {
+					wg.Add(1)
+					packet := gw.RXPacket{
+						RXInfo:     rxInfo,
+						PHYPayload: phy,
+					}
+					go func() {
+						if err := collectAndCallOnce(p, packet, cb); err != nil {
+							t.Error(err)
+						}
+						wg.Done()
+					}()
+				}
+				wg.Wait()
+
+				Convey("Then the RXInfoSet contains the rx-info of the best antenna only", func() {
+					So(rxInfoSet, ShouldResemble, models.RXInfoSet{
+						{MAC: mac, Antenna: 1, LoRaSNR: 5, RSSI: -70},
+					})
+				})
+
+				Convey("Then the stats of both antennas have been recorded", func() {
+					stats, err := gateway.GetAntennaStats(p, mac)
+					So(err, ShouldBeNil)
+					So(stats, ShouldResemble, []gateway.AntennaStats{
+						{Antenna: 0, RXPacketCount: 1, AvgRSSI: -90, AvgLoRaSNR: 1},
+						{Antenna: 1, RXPacketCount: 1, SelectedCount: 1, AvgRSSI: -70, AvgLoRaSNR: 5},
+					})
+				})
+			})
 		})
 
 	})
@@ -1,9 +1,6 @@
 package uplink
 
 import (
-	"context"
-	"errors"
-	"fmt"
 	"strings"
 	"time"
 
@@ -11,18 +8,28 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
 
 	"github.com/brocaar/lorawan"
 	"github.com/joriwind/loraserver/api/as"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/api/nc"
 	"github.com/joriwind/loraserver/internal/adr"
+	"github.com/joriwind/loraserver/internal/channelplan"
+	"github.com/joriwind/loraserver/internal/clockdrift"
 	"github.com/joriwind/loraserver/internal/common"
 	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/geolocation"
 	"github.com/joriwind/loraserver/internal/gateway"
 	"github.com/joriwind/loraserver/internal/maccommand"
 	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/rules"
+	"github.com/joriwind/loraserver/internal/rx2mismatch"
+	"github.com/joriwind/loraserver/internal/security"
 	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/sla"
+	"github.com/joriwind/loraserver/internal/uplinkstats"
 )
 
 //hecomm configuration
@@ -34,29 +41,106 @@ var ConfHecommTransportCred credentials.TransportCredentials
 var ConfHecommAddress = "192.168.2.1:8001"
 
 func validateAndCollectDataUpRXPacket(ctx common.Context, rxPacket gw.RXPacket) error {
-	ns, err := session.GetNodeSessionForPHYPayload(ctx.RedisPool, rxPacket.PHYPayload)
+	receivedAt := time.Now()
+
+	ns, reset, err := session.GetNodeSessionForPHYPayload(ctx.RedisPool, rxPacket.PHYPayload)
+	if err != nil {
+		// a retransmission of an acknowledged confirmed uplink has an
+		// already used FCnt (not acknowledged again in read-only mode)
+		if err == session.ErrDoesNotExistOrFCntOrMICInvalid && !common.ReadOnlyMode {
+			handled, err := handleACKedRetransmission(ctx, rxPacket, receivedAt)
+			if err != nil {
+				return errors.Wrap(err, "handle acked retransmission error")
+			}
+			if handled {
+				return nil
+			}
+		}
+
+		// a downlink enqueued for an unprovisioned test device (bench mode)
+		if err == session.ErrDoesNotExistOrFCntOrMICInvalid && common.BenchMode && !common.ReadOnlyMode {
+			handled, err := handleBenchUplink(ctx, rxPacket, receivedAt)
+			if err != nil {
+				return errors.Wrap(err, "handle bench uplink error")
+			}
+			if handled {
+				return nil
+			}
+		}
+
+		if common.PassiveMonitoring && err == session.ErrDoesNotExistOrFCntOrMICInvalid {
+			recordUnknownDevAddr(ctx, rxPacket)
+		}
+
+		if common.SecurityStrictMode && err == session.ErrDoesNotExistOrFCntOrMICInvalid {
+			if err := emitSecurityEvents(ctx, rxPacket, receivedAt); err != nil {
+				log.Errorf("emit security events error: %s", err)
+			}
+		}
+		return errors.Wrap(err, "get node-session error")
+	}
+
+	if common.SecurityStrictMode {
+		quarantined, err := security.IsQuarantined(ctx.RedisPool, ns.DevEUI)
+		if err != nil {
+			return err
+		}
+		if quarantined {
+			return errors.Wrapf(ErrNodeQuarantined, "dev_eui: %s", ns.DevEUI)
+		}
+	}
+
+	// a reset of the node wipes its mac configuration, the mac state
+	// of the node is resynchronized over the next downlinks
+	if reset && !common.ReadOnlyMode {
+		if err := maccommand.ResetMACState(ctx.RedisPool, &ns); err != nil {
+			return errors.Wrap(err, "reset mac state error")
+		}
+		if err := session.SaveNodeSession(ctx.RedisPool, ns); err != nil {
+			return errors.Wrap(err, "save node-session error")
+		}
+	}
+
+	drop, err := handleOutOfPlanFrequency(ctx, rxPacket, ns.CFList)
 	if err != nil {
-		return fmt.Errorf("get node-session error: %s", err)
+		return err
+	}
+	if drop {
+		return nil
 	}
 
 	// MACPayload must be of type *lorawan.MACPayload
 	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
 	if !ok {
-		return fmt.Errorf("expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
 	}
 
 	if macPL.FPort != nil {
-		if *macPL.FPort == 0 {
-			// decrypt FRMPayload with NwkSKey when FPort == 0
+		if *macPL.FPort == 0 || isRelayForwardUplink(ns, macPL) {
+			// decrypt FRMPayload with NwkSKey when FPort == 0 or in case
+			// of a relay forwarded uplink
 			if err := rxPacket.PHYPayload.DecryptFRMPayload(ns.NwkSKey); err != nil {
-				return fmt.Errorf("decrypt FRMPayload error: %s", err)
+				return errors.Wrap(err, "decrypt FRMPayload error")
 			}
 		}
 	}
 
+	// the downlink (if any) must be sent before the receive-window opens
+	ctx, cancel := ctx.WithDeadline(getDataUpDeadline(ns, receivedAt))
+	defer cancel()
+	ctx, _ = sla.NewContext(ctx, receivedAt)
+
 	return collectAndCallOnce(ctx.RedisPool, rxPacket, func(rxPacket models.RXPacket) error {
+		if err := calibrateRXInfoSet(ctx, &rxPacket); err != nil {
+			return err
+		}
 		rxPacket.DevEUI = ns.DevEUI
-		return handleCollectedDataUpPackets(ctx, rxPacket)
+
+		// the frames of a node are processed one after the other, so that
+		// they do not overwrite each others node-session changes
+		return runOnDevAddrShard(ns.DevAddr, func() error {
+			return handleCollectedDataUpPackets(ctx, rxPacket)
+		})
 	})
 }
 
@@ -68,13 +152,19 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 
 	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
 	if !ok {
-		return fmt.Errorf("expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+	}
+
+	timing := sla.FromContext(ctx)
+	if timing != nil {
+		timing.Observe(sla.StageCollect, time.Since(timing.ReceivedAt))
 	}
 
 	ns, err := session.GetNodeSession(ctx.RedisPool, rxPacket.DevEUI)
 	if err != nil {
 		return err
 	}
+	orig := ns
 
 	// expand the FCnt, the value itself has already been validated during the
 	// collection, so there is no need to handle the ok value
@@ -87,6 +177,44 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 		"mtype":    rxPacket.PHYPayload.MHDR.MType,
 	}).Info("packet(s) collected")
 
+	if err := framelog.LogUplinkForDevice(ctx.RedisPool, ns.DevEUI, rxPacket.PHYPayload, rxPacket.RXInfoSet); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("log uplink frame error: %s", err)
+	}
+
+	// suppress retransmissions arriving after the de-duplication delay
+	// (e.g. because of the timing spread of the receiving gateways)
+	suppressed, err := handleRetransmission(ctx, rxPacket, macPL)
+	if err != nil {
+		return errors.Wrap(err, "handle retransmission error")
+	}
+	if suppressed {
+		return nil
+	}
+
+	if common.ReadOnlyMode {
+		return handleReadOnlyDataUp(ctx, ns, rxPacket, macPL)
+	}
+
+	oversized, err := handleOversizedFrame(ctx, ns, rxPacket, macPL)
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("handle oversized frame error: %s", err)
+	}
+	if oversized && common.UplinkDropOversized {
+		return errors.Wrapf(ErrOversizedFrame, "dev_eui: %s", ns.DevEUI)
+	}
+
+	recordChannelStats(ctx, rxPacket)
+
+	// update the devices served by the receiving gateways
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		if err := gateway.RecordDeviceUplink(ctx.RedisPool, rxInfo.MAC, ns.DevEUI, time.Now()); err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": ns.DevEUI,
+				"mac":     rxInfo.MAC,
+			}).Errorf("record gateway device uplink error: %s", err)
+		}
+	}
+
 	// send rx info notification to be used by the network-controller
 	if err = sendRXInfoPayload(ctx, ns, rxPacket); err != nil {
 		log.WithFields(log.Fields{
@@ -96,7 +224,7 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 
 	// handle FOpts mac commands (if any)
 	if len(macPL.FHDR.FOpts) > 0 {
-		if err := handleUplinkMACCommands(ctx, &ns, false, macPL.FHDR.FOpts); err != nil {
+		if err := handleUplinkMACCommands(ctx, &ns, false, macPL.FHDR.FCnt, macPL.FHDR.FOpts); err != nil {
 			log.WithFields(log.Fields{
 				"dev_eui": ns.DevEUI,
 				"fopts":   macPL.FHDR.FOpts,
@@ -107,37 +235,65 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 	if macPL.FPort != nil {
 		if *macPL.FPort == 0 {
 			if len(macPL.FRMPayload) == 0 {
-				return errors.New("expected mac commands, but FRMPayload is empty (FPort=0)")
+				return ErrExpectedMACCommands
 			}
 
 			// since the PHYPayload has been marshaled / unmarshaled when
 			// storing it into and retrieving it from the database, we need
 			// to decode the MAC commands from the FRMPayload.
 			if err = rxPacket.PHYPayload.DecodeFRMPayloadToMACCommands(); err != nil {
-				return fmt.Errorf("decode FRMPayload field to MACCommand items error: %s", err)
+				return errors.Wrap(err, "decode FRMPayload field to MACCommand items error")
 			}
 
 			var commands []lorawan.MACCommand
 			for _, pl := range macPL.FRMPayload {
 				cmd, ok := pl.(*lorawan.MACCommand)
 				if !ok {
-					return fmt.Errorf("expected MACPayload, but got %T", macPL.FRMPayload)
+					return errors.Wrapf(ErrUnexpectedPayloadType, "expected MACPayload, but got %T", macPL.FRMPayload)
 				}
 				commands = append(commands, *cmd)
 			}
-			if err := handleUplinkMACCommands(ctx, &ns, true, commands); err != nil {
+			if err := handleUplinkMACCommands(ctx, &ns, true, macPL.FHDR.FCnt, commands); err != nil {
 				log.WithFields(log.Fields{
 					"dev_eui":  ns.DevEUI,
 					"commands": commands,
 				}).Errorf("handle FRMPayload mac commands error: %s", err)
 			}
+		} else if isRelayForwardUplink(ns, macPL) {
+			if err := handleRelayForwardUplink(ctx, ns, rxPacket, macPL); err != nil {
+				log.WithFields(log.Fields{
+					"dev_eui": ns.DevEUI,
+				}).Errorf("handle relay forwarded uplink error: %s", err)
+			}
 		} else {
-			if err := publishDataUp(ctx, ns, rxPacket, *macPL); err != nil {
-				return err
+			handled, err := handleAppLayerUplink(ctx, ns, rxPacket, macPL)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"dev_eui": ns.DevEUI,
+					"f_port":  *macPL.FPort,
+				}).Errorf("handle application-layer package uplink error: %s", err)
+			}
+			if !handled {
+				limited, err := isUplinkRateLimited(ctx.RedisPool, ns, time.Now())
+				if err != nil {
+					log.WithField("dev_eui", ns.DevEUI).Errorf("check uplink rate limit error: %s", err)
+				}
+				if !limited {
+					if err := publishDataUp(ctx, ns, rxPacket, *macPL); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
 
+	// publish the uplink meta-data (used by the StreamUplinkMetadata api)
+	if err := publishUplinkMetadata(ctx.RedisPool, ns, rxPacket, *macPL); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": ns.DevEUI,
+		}).Errorf("publish uplink meta-data error: %s", err)
+	}
+
 	// handle ADR (should be executed before saving the node-session)
 	if err := adr.HandleADR(ctx, &ns, rxPacket, macPL.FHDR.FCnt); err != nil {
 		log.WithFields(log.Fields{
@@ -146,28 +302,147 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 		}).Warningf("handle adr error: %s", err)
 	}
 
+	// run the anomaly detection (should be executed before updating the
+	// node-session with the meta-data of this uplink)
+	receivedAt := time.Now()
+	if err := handleAnomalyDetection(ctx, ns, rxPacket, macPL.FHDR.FCnt, receivedAt); err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": ns.DevEUI,
+		}).Errorf("handle anomaly detection error: %s", err)
+	}
+
+	// an uplink received after initiating a device class change confirms
+	// that the node operates in the new device class
+	if ns.ConfirmDeviceClassChange() {
+		log.WithFields(log.Fields{
+			"dev_eui":      ns.DevEUI,
+			"device_class": ns.DeviceClass,
+		}).Info("device class change confirmed")
+	}
+
+	// an uplink on the Class-C FPort signals that the node is temporarily
+	// available as Class-C device
+	if macPL.FPort != nil && ns.HandleClassCSignal(*macPL.FPort, receivedAt) {
+		log.WithFields(log.Fields{
+			"dev_eui":       ns.DevEUI,
+			"f_port":        *macPL.FPort,
+			"class_c_until": ns.ClassCUntil,
+		}).Info("class-c window opened")
+	}
+
+	// evaluate the uplink automation rules and reconcile the uplink
+	// channels of the node with its channel-configuration (should be
+	// executed before saving the node-session)
+	if dr, err := common.GetDataRate(rxPacket.RXInfoSet[0].DataRate); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("get data-rate error: %s", err)
+	} else {
+		if err := rules.HandleUplink(ctx.RedisPool, &ns, macPL.FPort, dr, receivedAt); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("handle uplink rules error: %s", err)
+		}
+		if err := channelplan.Reconcile(ctx, &ns, dr); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("reconcile channel-configuration error: %s", err)
+		}
+	}
+
+	// record the uplink traffic stats of the node
+	if b, err := rxPacket.PHYPayload.MarshalBinary(); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("marshal phypayload error: %s", err)
+	} else if err := uplinkstats.RecordUplink(ctx.RedisPool, ns.DevEUI, macPL.FPort, len(b), receivedAt); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("record uplink stats error: %s", err)
+	}
+
+	// record the uplink for the clock drift estimation (using the receive
+	// time of the gateway when available, as it is not affected by the
+	// backhaul latency and de-duplication)
+	uplinkTime := receivedAt
+	if t := rxPacket.RXInfoSet[0].Time; !t.IsZero() {
+		uplinkTime = t
+	}
+	if err := clockdrift.RecordUplink(ctx.RedisPool, ns.DevEUI, macPL.FHDR.FCnt, uplinkTime); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("record clock drift sample error: %s", err)
+	}
+
 	// update the RXInfoSet
 	ns.LastRXInfoSet = rxPacket.RXInfoSet
+	ns.LastUplinkAt = receivedAt
 
 	// sync counter with that of the device + 1
 	ns.FCntUp = macPL.FHDR.FCnt + 1
 
-	// save node-session
-	if err := session.SaveNodeSession(ctx.RedisPool, ns); err != nil {
+	// save node-session (merging the changes in case the node-session has
+	// been updated concurrently)
+	if err := session.SaveNodeSessionChanges(ctx.RedisPool, orig, &ns); err != nil {
 		return err
 	}
 
+	// the first uplink after the activation confirms the join-accept
+	if orig.FCntUp == 0 {
+		if err := downlink.ResetJoinAccepts(ctx.RedisPool, ns.DevEUI); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("reset join-accepts error: %s", err)
+		}
+	}
+
+	if common.RXWindowLearning {
+		if err := downlink.RecordRXWindowOutcome(ctx.RedisPool, ns.DevEUI, macPL.FHDR.FCtrl.ACK); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("record rx window outcome error: %s", err)
+		}
+	}
+
+	if common.RX2MismatchDetection {
+		detected, err := rx2mismatch.RecordOutcome(ctx.RedisPool, ns, macPL.FHDR.FCtrl.ACK)
+		if err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("record rx2 outcome error: %s", err)
+		}
+		if detected {
+			log.WithFields(log.Fields{
+				"dev_eui":    ns.DevEUI,
+				"rx2_dr":     ns.RX2DR,
+				"default_dr": common.Band.RX2DataRate,
+			}).Warning("rx2 parameters mismatch detected")
+
+			if common.RX2MismatchAutoFix {
+				// the node uses the default rx2 data-rate
+				current := maccommand.GetRXParamSetupPayload(ns)
+				current.DLSettings.RX2DataRate = uint8(common.Band.RX2DataRate)
+				if err := enqueueRXParamSetupReq(ctx, ns, current); err != nil {
+					log.WithField("dev_eui", ns.DevEUI).Errorf("enqueue rx param setup request error: %s", err)
+				}
+			}
+		}
+	}
+
+	if common.FCntDownRolloverThreshold > 0 {
+		handleFCntDownRollover(ctx, ns)
+	}
+
 	// handle uplink ACK
 	if macPL.FHDR.FCtrl.ACK {
 		if err := handleUplinkACK(ctx, &ns); err != nil {
-			return fmt.Errorf("handle uplink ack error: %s", err)
+			return errors.Wrap(err, "handle uplink ack error")
 		}
 	}
 
 	// handle downlink (ACK)
 	time.Sleep(common.GetDownlinkDataDelay)
-	if err := downlink.SendUplinkResponse(ctx, ns, rxPacket); err != nil {
-		return fmt.Errorf("handling downlink data for node %s failed: %s", ns.DevEUI, err)
+	timing.Observe(sla.StageDownlinkDataDelay, common.GetDownlinkDataDelay)
+	err = downlink.SendUplinkResponse(ctx, ns, rxPacket)
+	if timing != nil {
+		if err := sla.Record(ctx.RedisPool, timing, getRX1Deadline(ns, timing.ReceivedAt)); err != nil {
+			log.WithField("dev_eui", ns.DevEUI).Errorf("record downlink sla error: %s", err)
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "handling downlink data for node %s failed", ns.DevEUI)
+	}
+
+	if err := setUplinkACKed(ctx.RedisPool, ns.DevEUI, rxPacket.PHYPayload); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("set uplink acked error: %s", err)
+	}
+
+	// resolve the location of the node (after the downlink has been sent,
+	// as the resolver might be slow)
+	if err := geolocation.HandleUplink(ctx, ns, macPL.FHDR.FCnt, rxPacket.RXInfoSet); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("handle geolocation error: %s", err)
 	}
 
 	return nil
@@ -177,7 +452,7 @@ func handleCollectedDataUpPackets(ctx common.Context, rxPacket models.RXPacket)
 func sendRXInfoPayload(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket) error {
 	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
 	if !ok {
-		return fmt.Errorf("expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
 	}
 
 	rxInfoReq := nc.HandleRXInfoRequest{
@@ -210,9 +485,9 @@ func sendRXInfoPayload(ctx common.Context, ns session.NodeSession, rxPacket mode
 		})
 	}
 
-	_, err := ctx.Controller.HandleRXInfo(context.Background(), &rxInfoReq)
+	_, err := ctx.Controller.HandleRXInfo(ctx.RequestContext(), &rxInfoReq)
 	if err != nil {
-		return fmt.Errorf("publish rxinfo to network-controller error: %s", err)
+		return errors.Wrap(err, "publish rxinfo to network-controller error")
 	}
 	log.WithFields(log.Fields{
 		"dev_eui": ns.DevEUI,
@@ -220,6 +495,25 @@ func sendRXInfoPayload(ctx common.Context, ns session.NodeSession, rxPacket mode
 	return nil
 }
 
+// getMaxDownlinkPayloadSizes returns the max payload size of a downlink
+// transmitted in RX1 and RX2 at the current parameters of the node. The
+// size is 0 when the data-rate of the receive-window can't be resolved.
+func getMaxDownlinkPayloadSizes(ns session.NodeSession, rxInfo gw.RXInfo) (uint32, uint32) {
+	var rx1, rx2 uint32
+
+	if uplinkDR, err := common.GetDataRate(rxInfo.DataRate); err == nil {
+		if dr, err := common.GetRX1DataRate(uplinkDR, int(ns.RX1DROffset)); err == nil && dr < len(common.Band.MaxPayloadSize) {
+			rx1 = uint32(common.Band.MaxPayloadSize[dr].N)
+		}
+	}
+
+	if dr := int(ns.RX2DR); dr < len(common.Band.MaxPayloadSize) {
+		rx2 = uint32(common.Band.MaxPayloadSize[dr].N)
+	}
+
+	return rx1, rx2
+}
+
 func publishDataUp(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, macPL lorawan.MACPayload) error {
 	publishDataUpReq := as.HandleDataUpRequest{
 		AppEUI: ns.AppEUI[:],
@@ -238,6 +532,16 @@ func publishDataUp(ctx common.Context, ns session.NodeSession, rxPacket models.R
 		},
 	}
 
+	publishDataUpReq.MaxPayloadSizeRX1, publishDataUpReq.MaxPayloadSizeRX2 = getMaxDownlinkPayloadSizes(ns, rxPacket.RXInfoSet[0])
+
+	if ns.ForwardPHYPayload {
+		b, err := rxPacket.PHYPayload.MarshalBinary()
+		if err != nil {
+			return errors.Wrap(err, "marshal phypayload error")
+		}
+		publishDataUpReq.PhyPayload = b
+	}
+
 	var macs []lorawan.EUI64
 	for i := range rxPacket.RXInfoSet {
 		macs = append(macs, rxPacket.RXInfoSet[i].MAC)
@@ -311,19 +615,34 @@ func publishDataUp(ctx common.Context, ns session.NodeSession, rxPacket models.R
 	if len(macPL.FRMPayload) == 1 {
 		dataPL, ok := macPL.FRMPayload[0].(*lorawan.DataPayload)
 		if !ok {
-			return fmt.Errorf("expected type *lorawan.DataPayload, got %T", macPL.FRMPayload[0])
+			return errors.Wrapf(ErrUnexpectedPayloadType, "expected type *lorawan.DataPayload, got %T", macPL.FRMPayload[0])
 		}
 		publishDataUpReq.Data = dataPL.Bytes
 
+		// the AppSKey encryption is offloaded to LoRa Server
+		if ns.AppSKey != nil && macPL.FPort != nil && *macPL.FPort > 0 {
+			// EncryptFRMPayload might modify the given slice in-place
+			b := make([]byte, len(dataPL.Bytes))
+			copy(b, dataPL.Bytes)
+
+			data, err := lorawan.EncryptFRMPayload(*ns.AppSKey, true, macPL.FHDR.DevAddr, macPL.FHDR.FCnt, b)
+			if err != nil {
+				return errors.Wrap(err, "decrypt FRMPayload error")
+			}
+			publishDataUpReq.Data = data
+			publishDataUpReq.Decrypted = true
+		}
 	}
 	//TODO: if FPort is 255 send to other application server --> Fog!
-	if _, err := ctx.Application.HandleDataUp(context.Background(), &publishDataUpReq); err != nil {
-		return fmt.Errorf("publish data up to application-server error: %s", err)
+	if _, err := ctx.Application.HandleDataUp(ctx.RequestContext(), &publishDataUpReq); err != nil {
+		return errors.Wrap(err, "publish data up to application-server error")
 	}
 	return nil
 }
 
-func handleUplinkMACCommands(ctx common.Context, ns *session.NodeSession, frmPayload bool, commands []lorawan.MACCommand) error {
+func handleUplinkMACCommands(ctx common.Context, ns *session.NodeSession, frmPayload bool, fCnt uint32, commands []lorawan.MACCommand) error {
+	maccommand.RecordHistory(ctx.RedisPool, ns.DevEUI, true, fCnt, commands)
+
 	for _, cmd := range commands {
 		logFields := log.Fields{
 			"dev_eui":     ns.DevEUI,
@@ -331,22 +650,41 @@ func handleUplinkMACCommands(ctx common.Context, ns *session.NodeSession, frmPay
 			"frm_payload": frmPayload,
 		}
 
-		// proprietary MAC commands
-		if cmd.CID >= 0x80 {
+		// the node answered the mac-command, so a next request is not
+		// subject to the retry backoff
+		if maccommand.RequiresAnswer(cmd.CID) {
+			if err := maccommand.ResetRetry(ctx.RedisPool, ns.DevEUI, cmd.CID); err != nil {
+				log.WithFields(logFields).Errorf("reset mac-command retry state error: %s", err)
+			}
+		}
+
+		// proprietary MAC commands and MAC commands delegated to the
+		// network-controller
+		if cmd.CID >= 0x80 || maccommand.IsHandledByController(cmd.CID) {
 			b, err := cmd.MarshalBinary()
 			if err != nil {
-				return fmt.Errorf("binary marshal mac command error: %s", err)
+				return errors.Wrap(err, "binary marshal mac command error")
 			}
-			_, err = ctx.Controller.HandleDataUpMACCommand(context.Background(), &nc.HandleDataUpMACCommandRequest{
+			_, err = ctx.Controller.HandleDataUpMACCommand(ctx.RequestContext(), &nc.HandleDataUpMACCommandRequest{
 				AppEUI:     ns.AppEUI[:],
 				DevEUI:     ns.DevEUI[:],
 				FrmPayload: frmPayload,
 				Data:       b,
 			})
 			if err != nil {
-				log.WithFields(logFields).Errorf("send proprietary mac-command to network-controller error: %s", err)
+				log.WithFields(logFields).Errorf("send mac-command to network-controller error: %s", err)
 			} else {
-				log.WithFields(logFields).Info("proprietary mac-command sent to network-controller")
+				log.WithFields(logFields).Info("mac-command sent to network-controller")
+			}
+
+			// the battery level is also stored by LoRa Server, as it is
+			// used for the battery-aware downlink throttling, the
+			// RXParamSetupAns and RXTimingSetupAns commit the pending
+			// changes of the RX parameters to the node-session
+			if cmd.CID == lorawan.DevStatusAns || cmd.CID == lorawan.RXParamSetupAns || cmd.CID == lorawan.RXTimingSetupAns {
+				if err := maccommand.Handle(ctx, ns, cmd); err != nil {
+					log.WithFields(logFields).Errorf("handle mac-command error: %s", err)
+				}
 			}
 		} else {
 			if err := maccommand.Handle(ctx, ns, cmd); err != nil {
@@ -357,17 +695,64 @@ func handleUplinkMACCommands(ctx common.Context, ns *session.NodeSession, frmPay
 	return nil
 }
 
+// enqueueRXParamSetupReq enqueues a RXParamSetupReq mac-command containing
+// the RX2 parameters of the node-session, so that a node with mismatching
+// RX2 parameters converges to the node-session. The given parameters,
+// used by the node, are restored when the node rejects the request.
+func enqueueRXParamSetupReq(ctx common.Context, ns session.NodeSession, current lorawan.RX2SetupReqPayload) error {
+	requested := maccommand.GetRXParamSetupPayload(ns)
+	mac := lorawan.MACCommand{
+		CID:     lorawan.RXParamSetupReq,
+		Payload: &requested,
+	}
+	b, err := mac.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal mac command error")
+	}
+
+	if err = maccommand.SetPendingRXParamSetup(ctx.RedisPool, ns.DevEUI, requested, current); err != nil {
+		return errors.Wrap(err, "set pending mac-command error")
+	}
+
+	err = maccommand.AddToQueue(ctx.RedisPool, maccommand.QueueItem{
+		DevEUI: ns.DevEUI,
+		Data:   b,
+	})
+	if err != nil {
+		return errors.Wrap(err, "add mac-command to queue error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":       ns.DevEUI,
+		"rx2_dr":        ns.RX2DR,
+		"rx2_frequency": ns.GetRX2Frequency(),
+		"rx1_dr_offset": ns.RX1DROffset,
+	}).Info("rx param setup request enqueued")
+	return nil
+}
+
 func handleUplinkACK(ctx common.Context, ns *session.NodeSession) error {
-	_, err := ctx.Application.HandleDataDownACK(context.Background(), &as.HandleDataDownACKRequest{
-		AppEUI: ns.AppEUI[:],
-		DevEUI: ns.DevEUI[:],
-		FCnt:   ns.FCntDown,
+	_, err := ctx.Application.HandleDataDownACK(ctx.RequestContext(), &as.HandleDataDownACKRequest{
+		AppEUI:    ns.AppEUI[:],
+		DevEUI:    ns.DevEUI[:],
+		FCnt:      ns.FCntDown,
+		Reference: ns.DownlinkReference,
 	})
 	if err != nil {
-		return fmt.Errorf("error publish downlink data ack to application-server: %s", err)
+		return errors.Wrap(err, "error publish downlink data ack to application-server")
 	}
+	orig := *ns
 	ns.FCntDown++
-	if err = session.SaveNodeSession(ctx.RedisPool, *ns); err != nil {
+	ns.DownlinkReference = ""
+	if err = session.SaveNodeSessionChanges(ctx.RedisPool, orig, ns); err != nil {
+		return err
+	}
+
+	// the acknowledged downlink must not be retransmitted
+	if err = downlink.ClearClassCRetry(ctx.RedisPool, ns.DevEUI); err != nil {
+		return err
+	}
+	if err = downlink.ClearConfirmedDataDown(ctx.RedisPool, ns.DevEUI); err != nil {
 		return err
 	}
 	return nil
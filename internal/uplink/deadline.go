@@ -0,0 +1,40 @@
+package uplink
+
+import (
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// getDataUpDeadline returns the deadline for handling a data uplink of the
+// given node, received at the given time: the opening of the receive-window
+// used for the downlink, minus the common.DownlinkDeadlineMargin. When the
+// RX window is learned (common.RXWindowLearning), the opening of RX1 is
+// used as the RX window is only known after collecting the uplink.
+func getDataUpDeadline(ns session.NodeSession, receivedAt time.Time) time.Time {
+	deadline := getRX1Deadline(ns, receivedAt)
+	if ns.RXWindow == session.RX2 && !common.RXWindowLearning {
+		deadline = deadline.Add(time.Second)
+	}
+	return deadline
+}
+
+// getRX1Deadline returns the opening of RX1 for a data uplink of the given
+// node, received at the given time, minus the common.DownlinkDeadlineMargin.
+func getRX1Deadline(ns session.NodeSession, receivedAt time.Time) time.Time {
+	delay := common.Band.ReceiveDelay1
+	if ns.RXDelay > 0 {
+		delay = time.Duration(ns.RXDelay) * time.Second
+	}
+	return receivedAt.Add(delay - common.DownlinkDeadlineMargin)
+}
+
+// getJoinRequestDeadline returns the deadline for handling a join-request
+// received at the given time. As the receive-window used for the
+// join-accept is only known after the join-request has been handled by
+// the application-server, the opening of the last join-accept window is
+// used.
+func getJoinRequestDeadline(receivedAt time.Time) time.Time {
+	return receivedAt.Add(common.Band.JoinAcceptDelay2 - common.DownlinkDeadlineMargin)
+}
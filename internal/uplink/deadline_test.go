@@ -0,0 +1,75 @@
+package uplink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetDataUpDeadline(t *testing.T) {
+	Convey("Given an uplink received at a given time", t, func() {
+		receivedAt := time.Now()
+		margin := common.DownlinkDeadlineMargin
+
+		tests := []struct {
+			Name             string
+			NodeSession      session.NodeSession
+			ExpectedDeadline time.Time
+		}{
+			{
+				Name:             "RX1 with default RX delay",
+				NodeSession:      session.NodeSession{RXWindow: session.RX1},
+				ExpectedDeadline: receivedAt.Add(common.Band.ReceiveDelay1 - margin),
+			},
+			{
+				Name:             "RX1 with RX delay of 3 seconds",
+				NodeSession:      session.NodeSession{RXWindow: session.RX1, RXDelay: 3},
+				ExpectedDeadline: receivedAt.Add(3*time.Second - margin),
+			},
+			{
+				Name:             "RX2 with default RX delay",
+				NodeSession:      session.NodeSession{RXWindow: session.RX2},
+				ExpectedDeadline: receivedAt.Add(common.Band.ReceiveDelay1 + time.Second - margin),
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				So(getDataUpDeadline(test.NodeSession, receivedAt), ShouldResemble, test.ExpectedDeadline)
+			})
+		}
+
+		Convey("Given RX window learning is enabled", func() {
+			common.RXWindowLearning = true
+			defer func() {
+				common.RXWindowLearning = false
+			}()
+
+			Convey("Then the opening of RX1 is used for RX2", func() {
+				So(getDataUpDeadline(session.NodeSession{RXWindow: session.RX2}, receivedAt), ShouldResemble, receivedAt.Add(common.Band.ReceiveDelay1-margin))
+			})
+		})
+	})
+}
+
+func TestContextWithDeadline(t *testing.T) {
+	Convey("Given a context without deadline", t, func() {
+		ctx := common.Context{}
+
+		Convey("Then the request context is not cancelled", func() {
+			So(ctx.RequestContext().Err(), ShouldBeNil)
+		})
+
+		Convey("When setting a deadline in the past", func() {
+			ctx, cancel := ctx.WithDeadline(time.Now().Add(-time.Second))
+			defer cancel()
+
+			Convey("Then the request context has been cancelled", func() {
+				So(ctx.RequestContext().Err(), ShouldNotBeNil)
+			})
+		})
+	})
+}
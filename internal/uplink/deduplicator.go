@@ -0,0 +1,283 @@
+package uplink
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+// Deduplicator defines the interface of the uplink de-duplication backend,
+// collecting the receptions of the same uplink frame by multiple gateways.
+type Deduplicator interface {
+	// Deduplicate adds the given (encoded) reception to the collect set
+	// of the given frame id. For exactly one of the receptions of the
+	// frame, it waits until the collection completes (see
+	// DeduplicationConfig) and returns all receptions collected in the set
+	// (unique). For the other receptions it returns nil.
+	Deduplicate(p *redis.Pool, id string, reception []byte, conf DeduplicationConfig) ([][]byte, error)
+}
+
+// DeduplicationStrategy defines the condition completing the collection of
+// the receptions of an uplink frame.
+type DeduplicationStrategy string
+
+// Available de-duplication strategies.
+const (
+	// DeduplicationTimeWindow completes after the de-duplication delay.
+	DeduplicationTimeWindow DeduplicationStrategy = "time-window"
+
+	// DeduplicationGatewayCount completes once the configured number of
+	// receptions has been collected, or after the max. de-duplication
+	// delay when the frame was received by fewer gateways.
+	DeduplicationGatewayCount DeduplicationStrategy = "gateway-count"
+
+	// DeduplicationWhicheverFirst completes once the configured number of
+	// receptions has been collected or after the de-duplication delay,
+	// whichever comes first.
+	DeduplicationWhicheverFirst DeduplicationStrategy = "whichever-first"
+)
+
+// deduplicationPollInterval defines the interval in which the size of a
+// Redis collect set is checked when waiting for a number of receptions.
+const deduplicationPollInterval = 10 * time.Millisecond
+
+// DeduplicationConfig holds the de-duplication configuration of a frame.
+type DeduplicationConfig struct {
+	Strategy     DeduplicationStrategy
+	Delay        time.Duration
+	GatewayCount int
+	MaxDelay     time.Duration
+}
+
+// wait returns the max. time to wait for receptions of the frame.
+func (c DeduplicationConfig) wait() time.Duration {
+	if c.Strategy == DeduplicationGatewayCount {
+		return c.MaxDelay
+	}
+	return c.Delay
+}
+
+// complete returns true when the given number of collected receptions
+// completes the collection before the wait time has passed.
+func (c DeduplicationConfig) complete(receptions int) bool {
+	if c.Strategy == DeduplicationTimeWindow || c.GatewayCount <= 0 {
+		return false
+	}
+	return receptions >= c.GatewayCount
+}
+
+var (
+	deduplicatorMu        sync.RWMutex
+	deduplicator          Deduplicator = RedisDeduplicator{}
+	deduplicationStrategy              = DeduplicationTimeWindow
+)
+
+// SetDeduplicator sets the uplink de-duplication backend to use.
+func SetDeduplicator(d Deduplicator) {
+	deduplicatorMu.Lock()
+	defer deduplicatorMu.Unlock()
+	deduplicator = d
+}
+
+// MustSetDeduplicator sets the uplink de-duplication backend by name
+// (redis or memory).
+func MustSetDeduplicator(name string) {
+	switch name {
+	case "", "redis":
+		SetDeduplicator(RedisDeduplicator{})
+	case "memory":
+		SetDeduplicator(NewMemoryDeduplicator())
+	default:
+		log.Fatalf("invalid deduplication backend '%s' (valid options: redis, memory)", name)
+	}
+}
+
+func getDeduplicator() Deduplicator {
+	deduplicatorMu.RLock()
+	defer deduplicatorMu.RUnlock()
+	return deduplicator
+}
+
+// SetDeduplicationStrategy sets the uplink de-duplication strategy to use.
+func SetDeduplicationStrategy(s DeduplicationStrategy) {
+	deduplicatorMu.Lock()
+	defer deduplicatorMu.Unlock()
+	deduplicationStrategy = s
+}
+
+// MustSetDeduplicationStrategy sets the uplink de-duplication strategy by
+// name (time-window, gateway-count or whichever-first). The gateway-count
+// and whichever-first strategies require a gateway count of at least 1.
+func MustSetDeduplicationStrategy(name string, gatewayCount int) {
+	s := DeduplicationStrategy(name)
+	switch s {
+	case "":
+		s = DeduplicationTimeWindow
+	case DeduplicationTimeWindow:
+	case DeduplicationGatewayCount, DeduplicationWhicheverFirst:
+		if gatewayCount < 1 {
+			log.Fatalf("deduplication strategy '%s' requires a gateway count of at least 1", name)
+		}
+	default:
+		log.Fatalf("invalid deduplication strategy '%s' (valid options: time-window, gateway-count, whichever-first)", name)
+	}
+	SetDeduplicationStrategy(s)
+}
+
+// getDeduplicationConfig returns the de-duplication configuration, using
+// the configured strategy and the de-duplication settings of the common
+// package.
+func getDeduplicationConfig() DeduplicationConfig {
+	deduplicatorMu.RLock()
+	defer deduplicatorMu.RUnlock()
+	return DeduplicationConfig{
+		Strategy:     deduplicationStrategy,
+		Delay:        common.DeduplicationDelay,
+		GatewayCount: common.DeduplicationGatewayCount,
+		MaxDelay:     common.DeduplicationMaxDelay,
+	}
+}
+
+// deduplicationTTL returns the time the collect set of the given window is
+// kept. This way we can set a really low window for testing, without the
+// risk that the set already expired on read.
+func deduplicationTTL(window time.Duration) time.Duration {
+	ttl := window * 2
+	if ttl < time.Millisecond*200 {
+		ttl = time.Millisecond * 200
+	}
+	return ttl
+}
+
+// RedisDeduplicator implements a Deduplicator storing the collect sets in
+// Redis, so that the receptions are de-duplicated over multiple LoRa Server
+// instances.
+type RedisDeduplicator struct{}
+
+// Deduplicate implements the Deduplicator interface.
+func (RedisDeduplicator) Deduplicate(p *redis.Pool, id string, reception []byte, conf DeduplicationConfig) ([][]byte, error) {
+	c := p.Get()
+	defer c.Close()
+
+	ttl := int64(deduplicationTTL(conf.wait()) / time.Millisecond)
+	key := fmt.Sprintf(CollectKeyTempl, id)
+	lockKey := fmt.Sprintf(CollectLockKeyTempl, id)
+
+	// store the reception in a set with expiration, in case the packet is
+	// received by multiple gateways, the set will contain each reception
+	c.Send("MULTI")
+	c.Send("SADD", key, reception)
+	c.Send("PEXPIRE", key, ttl)
+	if _, err := c.Do("EXEC"); err != nil {
+		return nil, errors.Wrap(err, "add rx packet to collect set error")
+	}
+
+	// acquire a lock on processing this packet
+	if _, err := redis.String(c.Do("SET", lockKey, "lock", "PX", ttl, "NX")); err != nil {
+		if err == redis.ErrNil {
+			// the packet processing is already locked by an other process
+			// so there is nothing to do anymore :-)
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "acquire lock error")
+	}
+
+	// wait until the collection completes, more packets might be received
+	// from other gateways
+	if conf.Strategy == DeduplicationTimeWindow {
+		time.Sleep(conf.wait())
+	} else {
+		deadline := time.Now().Add(conf.wait())
+		for time.Now().Before(deadline) {
+			n, err := redis.Int(c.Do("SCARD", key))
+			if err != nil {
+				return nil, errors.Wrap(err, "get collect set size error")
+			}
+			if conf.complete(n) {
+				break
+			}
+			sleep := deduplicationPollInterval
+			if remaining := deadline.Sub(time.Now()); remaining < sleep {
+				sleep = remaining
+			}
+			time.Sleep(sleep)
+		}
+	}
+
+	receptions, err := redis.ByteSlices(c.Do("SMEMBERS", key))
+	if err != nil {
+		return nil, errors.Wrap(err, "get collect set members error")
+	}
+	return receptions, nil
+}
+
+// MemoryDeduplicator implements a Deduplicator keeping the collect sets in
+// memory. It avoids the Redis round-trips (e.g. for single-gateway setups
+// with a low de-duplication delay), but it must only be used when running a
+// single LoRa Server instance.
+type MemoryDeduplicator struct {
+	mu   sync.Mutex
+	sets map[string]*memoryCollectSet
+}
+
+type memoryCollectSet struct {
+	seen       map[string]struct{}
+	receptions [][]byte
+	locked     bool
+	complete   chan struct{}
+}
+
+// NewMemoryDeduplicator returns a new MemoryDeduplicator.
+func NewMemoryDeduplicator() *MemoryDeduplicator {
+	return &MemoryDeduplicator{
+		sets: make(map[string]*memoryCollectSet),
+	}
+}
+
+// Deduplicate implements the Deduplicator interface.
+func (d *MemoryDeduplicator) Deduplicate(p *redis.Pool, id string, reception []byte, conf DeduplicationConfig) ([][]byte, error) {
+	d.mu.Lock()
+	s, ok := d.sets[id]
+	if !ok {
+		s = &memoryCollectSet{
+			seen:     make(map[string]struct{}),
+			complete: make(chan struct{}),
+		}
+		d.sets[id] = s
+		time.AfterFunc(deduplicationTTL(conf.wait()), func() {
+			d.mu.Lock()
+			delete(d.sets, id)
+			d.mu.Unlock()
+		})
+	}
+	if _, ok := s.seen[string(reception)]; !ok {
+		s.seen[string(reception)] = struct{}{}
+		s.receptions = append(s.receptions, reception)
+		if conf.complete(len(s.receptions)) && len(s.receptions) == conf.GatewayCount {
+			close(s.complete)
+		}
+	}
+	if s.locked {
+		d.mu.Unlock()
+		return nil, nil
+	}
+	s.locked = true
+	d.mu.Unlock()
+
+	timer := time.NewTimer(conf.wait())
+	select {
+	case <-timer.C:
+	case <-s.complete:
+		timer.Stop()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([][]byte(nil), s.receptions...), nil
+}
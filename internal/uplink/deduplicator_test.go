@@ -0,0 +1,123 @@
+package uplink
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryDeduplicator(t *testing.T) {
+	Convey("Given a MemoryDeduplicator", t, func() {
+		d := NewMemoryDeduplicator()
+		conf := DeduplicationConfig{
+			Strategy: DeduplicationTimeWindow,
+			Delay:    10 * time.Millisecond,
+		}
+
+		Convey("When collecting the receptions of a frame concurrently", func() {
+			receptions := [][]byte{{1}, {2}, {2}, {3}}
+			results := make([][][]byte, len(receptions))
+
+			var wg sync.WaitGroup
+			for i, r := range receptions {
+				wg.Add(1)
+				go func(i int, r []byte) {
+					defer wg.Done()
+					out, err := d.Deduplicate(nil, "frame", r, conf)
+					if err != nil {
+						panic(err)
+					}
+					results[i] = out
+				}(i, r)
+			}
+			wg.Wait()
+
+			Convey("Then the unique receptions are returned for exactly one reception", func() {
+				var handled [][]byte
+				var count int
+				for _, out := range results {
+					if out != nil {
+						handled = out
+						count++
+					}
+				}
+				So(count, ShouldEqual, 1)
+				So(handled, ShouldHaveLength, 3)
+			})
+
+			Convey("Then a late reception is not handled", func() {
+				out, err := d.Deduplicate(nil, "frame", []byte{4}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldBeNil)
+			})
+
+			Convey("Then an other frame is handled", func() {
+				out, err := d.Deduplicate(nil, "other", []byte{1}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, [][]byte{{1}})
+			})
+		})
+
+		Convey("When collecting using the gateway-count strategy", func() {
+			conf := DeduplicationConfig{
+				Strategy:     DeduplicationGatewayCount,
+				Delay:        10 * time.Millisecond,
+				GatewayCount: 2,
+				MaxDelay:     time.Second,
+			}
+
+			Convey("Then the collection completes once the gateway count has been reached", func() {
+				done := make(chan [][]byte)
+				go func() {
+					out, err := d.Deduplicate(nil, "frame", []byte{1}, conf)
+					if err != nil {
+						panic(err)
+					}
+					done <- out
+				}()
+
+				time.Sleep(20 * time.Millisecond)
+				out, err := d.Deduplicate(nil, "frame", []byte{2}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldBeNil)
+
+				start := time.Now()
+				So(<-done, ShouldHaveLength, 2)
+				So(time.Since(start), ShouldBeLessThan, conf.MaxDelay)
+			})
+
+			Convey("Then the collection completes after the max. delay when the gateway count has not been reached", func() {
+				conf.MaxDelay = 20 * time.Millisecond
+				out, err := d.Deduplicate(nil, "frame", []byte{1}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, [][]byte{{1}})
+			})
+		})
+
+		Convey("When collecting using the whichever-first strategy", func() {
+			conf := DeduplicationConfig{
+				Strategy:     DeduplicationWhicheverFirst,
+				Delay:        10 * time.Millisecond,
+				GatewayCount: 1,
+				MaxDelay:     time.Second,
+			}
+
+			Convey("Then the collection completes once the gateway count has been reached", func() {
+				start := time.Now()
+				out, err := d.Deduplicate(nil, "frame", []byte{1}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, [][]byte{{1}})
+				So(time.Since(start), ShouldBeLessThan, conf.Delay)
+			})
+
+			Convey("Then the collection completes after the delay when the gateway count has not been reached", func() {
+				conf.GatewayCount = 3
+				out, err := d.Deduplicate(nil, "frame", []byte{1}, conf)
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, [][]byte{{1}})
+			})
+		})
+	})
+}
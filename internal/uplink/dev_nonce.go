@@ -0,0 +1,127 @@
+package uplink
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+// DevNonceKeyTempl is the template used for generating the Redis key
+// containing the highest DevNonce used by a node with a monotonic
+// (counter based) DevNonce.
+const DevNonceKeyTempl = "lora:ns:dev_nonce:%s"
+
+// maxDevNonce defines the highest DevNonce value.
+const maxDevNonce = 65535
+
+// setDevNonceScript sets the highest DevNonce, but only when it is higher
+// than the stored value.
+var setDevNonceScript = redis.NewScript(1, `
+	local current = redis.call("GET", KEYS[1])
+	if current and tonumber(current) >= tonumber(ARGV[1]) then
+		return 0
+	end
+	redis.call("SET", KEYS[1], ARGV[1])
+	return 1
+`)
+
+// getDevNonce returns the DevNonce of the given join-request as counter
+// value.
+func getDevNonce(jrPL lorawan.JoinRequestPayload) uint16 {
+	return binary.BigEndian.Uint16(jrPL.DevNonce[:])
+}
+
+// isDevNonceReplay returns true when the given node uses a monotonic
+// DevNonce and the DevNonce of the join-request is not higher than the
+// highest DevNonce used by the node.
+func isDevNonceReplay(p *redis.Pool, jrPL lorawan.JoinRequestPayload) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	highest, err := redis.Int(c.Do("GET", fmt.Sprintf(DevNonceKeyTempl, jrPL.DevEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get dev-nonce error")
+	}
+
+	return int(getDevNonce(jrPL)) <= highest, nil
+}
+
+// setDevNonce stores the DevNonce of the given join-request as the highest
+// DevNonce used by the node.
+func setDevNonce(p *redis.Pool, jrPL lorawan.JoinRequestPayload) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := setDevNonceScript.Do(c, fmt.Sprintf(DevNonceKeyTempl, jrPL.DevEUI), getDevNonce(jrPL)); err != nil {
+		return errors.Wrap(err, "set dev-nonce error")
+	}
+	return nil
+}
+
+// handleDevNonceReplay returns true when the given join-request must be
+// rejected as it replays a DevNonce of a node using a monotonic DevNonce.
+// In that case the network-controller is notified.
+func handleDevNonceReplay(ctx common.Context, jrPL lorawan.JoinRequestPayload) (bool, error) {
+	replay, err := isDevNonceReplay(ctx.RedisPool, jrPL)
+	if err != nil || !replay {
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": getDevNonce(jrPL),
+	}).Warning("join-request with replayed dev-nonce rejected")
+
+	_, err = ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Error:  fmt.Sprintf("join-request rejected: dev-nonce %d has already been used", getDevNonce(jrPL)),
+	})
+	if err != nil {
+		return true, errors.Wrap(err, "send dev-nonce replay to network-controller error")
+	}
+	return true, nil
+}
+
+// handleMonotonicDevNonce records the DevNonce of the accepted join-request
+// of a node using a monotonic DevNonce. When the number of remaining
+// DevNonce values is within the configured common.DevNonceAlertMargin, the
+// network-controller is notified as the node needs to be re-provisioned
+// before it is able to join again.
+func handleMonotonicDevNonce(ctx common.Context, jrPL lorawan.JoinRequestPayload) error {
+	if err := setDevNonce(ctx.RedisPool, jrPL); err != nil {
+		return err
+	}
+
+	remaining := maxDevNonce - int(getDevNonce(jrPL))
+	if remaining > common.DevNonceAlertMargin {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": getDevNonce(jrPL),
+		"remaining": remaining,
+	}).Warning("dev-nonce approaching its maximum value")
+
+	_, err := ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Error:  fmt.Sprintf("dev-nonce approaching its maximum value (%d remaining), the node must be re-provisioned", remaining),
+	})
+	if err != nil {
+		return errors.Wrap(err, "send dev-nonce alert to network-controller error")
+	}
+	return nil
+}
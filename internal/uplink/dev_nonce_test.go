@@ -0,0 +1,78 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMonotonicDevNonce(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctrl := test.NewNetworkControllerClient()
+		ctx := common.Context{
+			RedisPool:  p,
+			Controller: ctrl,
+		}
+
+		newJRPL := func(devNonce uint16) lorawan.JoinRequestPayload {
+			return lorawan.JoinRequestPayload{
+				AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				DevEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				DevNonce: [2]byte{byte(devNonce >> 8), byte(devNonce)},
+			}
+		}
+
+		Convey("Then a join-request of an unknown node is not a replay", func() {
+			replay, err := handleDevNonceReplay(ctx, newJRPL(10))
+			So(err, ShouldBeNil)
+			So(replay, ShouldBeFalse)
+		})
+
+		Convey("Given the node used DevNonce 300", func() {
+			So(handleMonotonicDevNonce(ctx, newJRPL(300)), ShouldBeNil)
+			So(ctrl.HandleErrorChan, ShouldHaveLength, 0)
+
+			Convey("Then a higher DevNonce is not a replay", func() {
+				replay, err := handleDevNonceReplay(ctx, newJRPL(301))
+				So(err, ShouldBeNil)
+				So(replay, ShouldBeFalse)
+			})
+
+			Convey("Then an equal or lower DevNonce is a replay and the network-controller is notified", func() {
+				for _, devNonce := range []uint16{300, 1} {
+					replay, err := handleDevNonceReplay(ctx, newJRPL(devNonce))
+					So(err, ShouldBeNil)
+					So(replay, ShouldBeTrue)
+
+					req := <-ctrl.HandleErrorChan
+					So(req.DevEUI, ShouldResemble, []byte{1, 2, 3, 4, 5, 6, 7, 8})
+				}
+			})
+
+			Convey("Then recording a lower DevNonce does not decrease the highest DevNonce", func() {
+				So(handleMonotonicDevNonce(ctx, newJRPL(200)), ShouldBeNil)
+				replay, err := handleDevNonceReplay(ctx, newJRPL(250))
+				So(err, ShouldBeNil)
+				So(replay, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the node uses a DevNonce within the alert margin", func() {
+			So(handleMonotonicDevNonce(ctx, newJRPL(maxDevNonce-uint16(common.DevNonceAlertMargin))), ShouldBeNil)
+
+			Convey("Then the network-controller is alerted", func() {
+				So(ctrl.HandleErrorChan, ShouldHaveLength, 1)
+				req := <-ctrl.HandleErrorChan
+				So(req.Error, ShouldEqual, "dev-nonce approaching its maximum value (1000 remaining), the node must be re-provisioned")
+			})
+		})
+	})
+}
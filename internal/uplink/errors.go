@@ -0,0 +1,16 @@
+package uplink
+
+import "errors"
+
+// uplink errors
+var (
+	ErrUnknownMType           = errors.New("unknown MType")
+	ErrUnexpectedPayloadType  = errors.New("unexpected payload type")
+	ErrExpectedMACCommands    = errors.New("expected mac commands, but FRMPayload is empty (FPort=0)")
+	ErrEmptyCollectSet        = errors.New("zero items in collect set")
+	ErrInvalidForwardedUplink = errors.New("invalid forwarded uplink")
+	ErrInvalidJoinResponse    = errors.New("invalid join-response")
+	ErrNodeQuarantined        = errors.New("node is quarantined")
+	ErrOversizedFrame         = errors.New("frame exceeds the max. payload size of the data-rate")
+	ErrReadOnlyMode           = errors.New("join-requests are rejected in read-only mode")
+)
@@ -0,0 +1,57 @@
+package uplink
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/fcntrollover"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/templates"
+)
+
+// handleFCntDownRollover flags the node when its downlink frame-counter
+// approaches the 16-bit rollover. On detection, the application-server is
+// notified and the rejoin template (if configured) is triggered, so that
+// the node rejoins before the rollover. Errors are logged.
+func handleFCntDownRollover(ctx common.Context, ns session.NodeSession) {
+	detected, err := fcntrollover.Check(ctx.RedisPool, ns, common.FCntDownRolloverThreshold)
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("check fcnt down rollover error: %s", err)
+		return
+	}
+	if !detected {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   ns.DevEUI,
+		"fcnt_down": ns.FCntDown,
+		"threshold": common.FCntDownRolloverThreshold,
+	}).Warning("fcnt down approaching 16-bit rollover")
+
+	_, err = ctx.Application.HandleError(ctx.RequestContext(), &as.HandleErrorRequest{
+		AppEUI: ns.AppEUI[:],
+		DevEUI: ns.DevEUI[:],
+		Type:   as.ErrorType_DATA_DOWN_FCNT_ROLLOVER,
+		Error:  fmt.Sprintf("fcnt down %d is approaching the 16-bit rollover", ns.FCntDown),
+	})
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("publish fcnt down rollover error to application-server error: %s", err)
+	}
+
+	if common.FCntDownRolloverRejoinTemplateID == 0 {
+		return
+	}
+
+	t, err := templates.GetTemplate(ctx.DB, common.FCntDownRolloverRejoinTemplateID)
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("get rejoin template error: %s", err)
+		return
+	}
+	if err := templates.Trigger(ctx.RedisPool, t, ns); err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("trigger rejoin template error: %s", err)
+	}
+}
@@ -0,0 +1,34 @@
+package uplink
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+)
+
+// handleOutOfPlanFrequency checks if the frequency of the given packet is
+// part of the channel plan (the band uplink channels and the optional
+// CFList). If not, the out-of-plan counter of the gateway is incremented
+// and a warning is logged. It returns true when the packet must be dropped.
+func handleOutOfPlanFrequency(ctx common.Context, rxPacket gw.RXPacket, cFList *lorawan.CFList) (bool, error) {
+	if _, err := common.Band.GetChannel(rxPacket.RXInfo.Frequency, cFList); err == nil {
+		return false, nil
+	}
+
+	if err := gateway.IncrementOutOfPlanRXPacketCount(ctx.RedisPool, rxPacket.RXInfo.MAC); err != nil {
+		return false, errors.Wrap(err, "increment out-of-plan rx packet count error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":       rxPacket.RXInfo.MAC,
+		"frequency": rxPacket.RXInfo.Frequency,
+		"mtype":     rxPacket.PHYPayload.MHDR.MType,
+		"drop":      common.DropOutOfPlanRXPackets,
+	}).Warning("uplink frequency is outside the channel plan")
+
+	return common.DropOutOfPlanRXPackets, nil
+}
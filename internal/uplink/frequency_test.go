@@ -0,0 +1,72 @@
+package uplink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestHandleOutOfPlanFrequency(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx := common.Context{RedisPool: p}
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Given an uplink on a frequency of the channel plan", func() {
+			rxPacket := gw.RXPacket{
+				RXInfo: gw.RXInfo{
+					MAC:       mac,
+					Frequency: common.Band.UplinkChannels[0].Frequency,
+				},
+			}
+
+			Convey("Then it is not dropped and not counted", func() {
+				drop, err := handleOutOfPlanFrequency(ctx, rxPacket, nil)
+				So(err, ShouldBeNil)
+				So(drop, ShouldBeFalse)
+
+				count, err := gateway.GetOutOfPlanRXPacketCount(p, mac)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 0)
+			})
+		})
+
+		Convey("Given an uplink on a frequency outside the channel plan", func() {
+			rxPacket := gw.RXPacket{
+				RXInfo: gw.RXInfo{
+					MAC:       mac,
+					Frequency: common.Band.UplinkChannels[0].Frequency + 50000,
+				},
+			}
+
+			for _, dropOutOfPlan := range []bool{false, true} {
+				Convey(fmt.Sprintf("Given DropOutOfPlanRXPackets is %t", dropOutOfPlan), func() {
+					common.DropOutOfPlanRXPackets = dropOutOfPlan
+					Reset(func() {
+						common.DropOutOfPlanRXPackets = false
+					})
+
+					Convey("Then it is counted and dropped depending the configuration", func() {
+						drop, err := handleOutOfPlanFrequency(ctx, rxPacket, nil)
+						So(err, ShouldBeNil)
+						So(drop, ShouldEqual, dropOutOfPlan)
+
+						count, err := gateway.GetOutOfPlanRXPacketCount(p, mac)
+						So(err, ShouldBeNil)
+						So(count, ShouldEqual, 1)
+					})
+				})
+			}
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package uplink
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/api/nc"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/joinaccept"
+)
+
+// handleIssuedJoinAccept returns true when the given join-request must be
+// rejected as a join-accept has already been issued for its DevEUI and
+// DevNonce (see joinaccept), before the application-server round-trip. In
+// that case the network-controller is notified.
+func handleIssuedJoinAccept(ctx common.Context, jrPL lorawan.JoinRequestPayload) (bool, error) {
+	if common.JoinAcceptHistoryRetention == 0 {
+		return false, nil
+	}
+
+	issued, err := joinaccept.IsIssued(ctx.DB, jrPL.DevEUI, jrPL.DevNonce, common.JoinAcceptHistoryRetention)
+	if err != nil || !issued {
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": jrPL.DevNonce,
+	}).Warning("join-request rejected, join-accept already issued for dev-nonce")
+
+	_, err = ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Error:  fmt.Sprintf("join-request rejected: join-accept already issued for dev-nonce %X", jrPL.DevNonce[:]),
+	})
+	if err != nil {
+		return true, errors.Wrap(err, "send join-request rejection to network-controller error")
+	}
+	return true, nil
+}
+
+// handleJoinAcceptReplay records the join-accept of the given join-response
+// and returns true when it must not be sent, as a join-accept has already
+// been issued for the DevEUI and DevNonce of the join-request (e.g. by an
+// other instance handling the same replayed join-request). In that case the
+// application-server is notified (OTAA_JOIN_ACCEPT_REPLAY).
+func handleJoinAcceptReplay(ctx common.Context, jrPL lorawan.JoinRequestPayload, joinResp *as.JoinRequestResponse) (bool, error) {
+	if common.JoinAcceptHistoryRetention == 0 {
+		return false, nil
+	}
+
+	recorded, err := joinaccept.Record(ctx.DB, jrPL.DevEUI, jrPL.DevNonce, joinResp.PhyPayload, common.JoinAcceptHistoryRetention)
+	if err != nil || recorded {
+		return false, err
+	}
+
+	identical, err := joinaccept.IsIdentical(ctx.DB, jrPL.DevEUI, jrPL.DevNonce, joinResp.PhyPayload)
+	if err != nil {
+		return true, err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": jrPL.DevNonce,
+		"identical": identical,
+	}).Warning("join-accept not sent, join-accept already issued for dev-nonce")
+
+	errStr := fmt.Sprintf("join-accept not sent: join-accept already issued for dev-nonce %X", jrPL.DevNonce[:])
+	if identical {
+		errStr = fmt.Sprintf("join-accept not sent: identical join-accept already issued for dev-nonce %X", jrPL.DevNonce[:])
+	}
+
+	_, err = ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Type:   as.ErrorType_OTAA_JOIN_ACCEPT_REPLAY,
+		Error:  errStr,
+	})
+	if err != nil {
+		return true, errors.Wrap(err, "send join-accept replay to application-server error")
+	}
+	return true, nil
+}
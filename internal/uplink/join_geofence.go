@@ -0,0 +1,110 @@
+package uplink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/gateway"
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+// handleJoinGeofence returns true when the join-request must be rejected
+// as none of the receiving gateways is within the join gateway regions or
+// has the join gateway tags of the given join-response. In that case the
+// application-server is notified (OTAA_OUT_OF_AREA).
+func handleJoinGeofence(ctx common.Context, jrPL lorawan.JoinRequestPayload, joinResp *as.JoinRequestResponse, rxPacket models.RXPacket) (bool, error) {
+	if len(joinResp.JoinGatewayRegions) == 0 && len(joinResp.JoinGatewayTags) == 0 {
+		return false, nil
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	var regions map[lorawan.EUI64]string
+	var tags map[lorawan.EUI64]models.Tags
+	var err error
+	if len(joinResp.JoinGatewayRegions) != 0 {
+		regions, err = gateway.GetGatewayRegions(ctx.DB, macs)
+		if err != nil {
+			return false, errors.Wrap(err, "get gateway regions error")
+		}
+	}
+	if len(joinResp.JoinGatewayTags) != 0 {
+		tags, err = gateway.GetGatewayTags(ctx.DB, macs)
+		if err != nil {
+			return false, errors.Wrap(err, "get gateway tags error")
+		}
+	}
+
+	if isJoinWithinArea(macs, joinResp.JoinGatewayRegions, joinResp.JoinGatewayTags, regions, tags) {
+		return false, nil
+	}
+
+	var macStrs []string
+	for _, mac := range macs {
+		macStrs = append(macStrs, mac.String())
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": jrPL.DevNonce,
+		"gw_macs":   strings.Join(macStrs, ", "),
+	}).Warning("join-request rejected, received outside the join area")
+
+	_, err = ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Type:   as.ErrorType_OTAA_OUT_OF_AREA,
+		Error:  fmt.Sprintf("join-request received outside the join area (gateways: %s)", strings.Join(macStrs, ", ")),
+	})
+	if err != nil {
+		return true, errors.Wrap(err, "send join-request rejection to application-server error")
+	}
+	return true, nil
+}
+
+// isJoinWithinArea returns true when at least one of the given gateways is
+// within one of the given regions (when set) and has all the given tags
+// (when set). Unknown gateways (missing in gwRegions or gwTags) are never
+// within the area.
+func isJoinWithinArea(macs []lorawan.EUI64, regions []string, tags models.Tags, gwRegions map[lorawan.EUI64]string, gwTags map[lorawan.EUI64]models.Tags) bool {
+	for _, mac := range macs {
+		if len(regions) != 0 {
+			region, ok := gwRegions[mac]
+			if !ok || !containsString(regions, region) {
+				continue
+			}
+		}
+
+		if len(tags) != 0 {
+			t, ok := gwTags[mac]
+			if !ok || !t.Matches(tags) {
+				continue
+			}
+		}
+
+		return true
+	}
+	return false
+}
+
+// containsString returns true when the given slice contains the given
+// string.
+func containsString(s []string, v string) bool {
+	for _, sv := range s {
+		if sv == v {
+			return true
+		}
+	}
+	return false
+}
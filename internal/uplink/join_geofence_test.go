@@ -0,0 +1,52 @@
+package uplink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/models"
+)
+
+func TestIsJoinWithinArea(t *testing.T) {
+	Convey("Given two gateways with a region and tags", t, func() {
+		gw1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		gw2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		unknown := lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}
+
+		gwRegions := map[lorawan.EUI64]string{
+			gw1: "NL",
+			gw2: "BE",
+		}
+		gwTags := map[lorawan.EUI64]models.Tags{
+			gw1: {"site": "port"},
+			gw2: {"site": "depot"},
+		}
+
+		tests := []struct {
+			Name     string
+			MACs     []lorawan.EUI64
+			Regions  []string
+			Tags     models.Tags
+			Expected bool
+		}{
+			{"no restrictions", []lorawan.EUI64{unknown}, nil, nil, true},
+			{"gateway within region", []lorawan.EUI64{gw2}, []string{"BE"}, nil, true},
+			{"gateway outside region", []lorawan.EUI64{gw1}, []string{"BE"}, nil, false},
+			{"one of the gateways within region", []lorawan.EUI64{gw1, gw2}, []string{"BE", "DE"}, nil, true},
+			{"unknown gateway", []lorawan.EUI64{unknown}, []string{"BE"}, nil, false},
+			{"gateway having tags", []lorawan.EUI64{gw1}, nil, models.Tags{"site": "port"}, true},
+			{"gateway not having tags", []lorawan.EUI64{gw2}, nil, models.Tags{"site": "port"}, false},
+			{"region and tags on different gateways", []lorawan.EUI64{gw1, gw2}, []string{"BE"}, models.Tags{"site": "port"}, false},
+			{"region and tags on the same gateway", []lorawan.EUI64{gw1, gw2}, []string{"NL"}, models.Tags{"site": "port"}, true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				So(isJoinWithinArea(test.MACs, test.Regions, test.Tags, gwRegions, gwTags), ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
@@ -2,32 +2,61 @@ package uplink
 
 import (
 	"context"
-	"errors"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
 
 	"github.com/brocaar/lorawan"
 	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/api/nc"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
 	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/framelog"
+	"github.com/joriwind/loraserver/internal/joinguard"
+	"github.com/joriwind/loraserver/internal/joinstats"
 	"github.com/joriwind/loraserver/internal/maccommand"
 	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/provisioning"
 	"github.com/joriwind/loraserver/internal/session"
 )
 
+// JoinRequestSuppressionKeyTempl is the template used for generating the Redis
+// key of processed join-requests (DevEUI and DevNonce).
+const JoinRequestSuppressionKeyTempl = "loraserver:rx:join:%s:%s"
+
 // collectJoinRequestPacket collects a single received RXPacket of type
 // join-request.
 func collectJoinRequestPacket(ctx common.Context, rxPacket gw.RXPacket) error {
+	drop, err := handleOutOfPlanFrequency(ctx, rxPacket, nil)
+	if err != nil {
+		return err
+	}
+	if drop {
+		return nil
+	}
+
+	receivedAt := time.Now()
+	ctx, cancel := ctx.WithDeadline(getJoinRequestDeadline(receivedAt))
+	defer cancel()
+
 	return collectAndCallOnce(ctx.RedisPool, rxPacket, func(rxPacket models.RXPacket) error {
-		return handleCollectedJoinRequestPackets(ctx, rxPacket)
+		if err := calibrateRXInfoSet(ctx, &rxPacket); err != nil {
+			return err
+		}
+		return handleCollectedJoinRequestPackets(ctx, rxPacket, receivedAt)
 	})
 }
 
 // handleCollectedJoinRequestPackets handles the received join-requests.
-func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPacket) error {
+// The outcome of the join-request (and the latency since receivedAt) is
+// recorded in the join stats.
+func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPacket, receivedAt time.Time) error {
 	var macs []string
 	for _, p := range rxPacket.RXInfoSet {
 		macs = append(macs, p.MAC.String())
@@ -36,12 +65,12 @@ func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPac
 	// MACPayload must be of type *lorawan.JoinRequestPayload
 	jrPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.JoinRequestPayload)
 	if !ok {
-		return fmt.Errorf("expected *lorawan.JoinRequestPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.JoinRequestPayload, got: %T", rxPacket.PHYPayload.MACPayload)
 	}
 
 	b, err := rxPacket.PHYPayload.MarshalBinary()
 	if err != nil {
-		return fmt.Errorf("phypayload marshal binary error: %s", err)
+		return errors.Wrap(err, "phypayload marshal binary error")
 	}
 
 	log.WithFields(log.Fields{
@@ -51,39 +80,126 @@ func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPac
 		"mtype":    rxPacket.PHYPayload.MHDR.MType,
 	}).Info("packet(s) collected")
 
+	if err := framelog.LogUplinkForDevice(ctx.RedisPool, jrPL.DevEUI, rxPacket.PHYPayload, rxPacket.RXInfoSet); err != nil {
+		log.WithField("dev_eui", jrPL.DevEUI).Errorf("log uplink frame error: %s", err)
+	}
+
+	// suppress join-request copies which arrive after the de-duplication
+	// window (e.g. delayed by a gateway backhaul)
+	suppressed, err := isSuppressedJoinRequest(ctx.RedisPool, *jrPL)
+	if err != nil {
+		return errors.Wrap(err, "join-request suppression error")
+	}
+	if suppressed {
+		log.WithFields(log.Fields{
+			"dev_eui":   jrPL.DevEUI,
+			"dev_nonce": jrPL.DevNonce,
+			"gw_macs":   strings.Join(macs, ", "),
+		}).Warning("duplicate join-request suppressed")
+		return nil
+	}
+
+	recordChannelStats(ctx, rxPacket)
+
+	var accepted bool
+	defer func() {
+		recordJoinStats(ctx, *jrPL, rxPacket, accepted, time.Since(receivedAt))
+	}()
+
+	// reject replayed DevNonce values of nodes using a monotonic DevNonce
+	replay, err := handleDevNonceReplay(ctx, *jrPL)
+	if err != nil {
+		return errors.Wrap(err, "dev-nonce replay check error")
+	}
+	if replay {
+		return nil
+	}
+
+	// reject replayed DevNonce values and join-request floods before the
+	// application-server round-trip
+	rejected, err := handleJoinGuard(ctx, *jrPL, rxPacket)
+	if err != nil {
+		return errors.Wrap(err, "join-request guard error")
+	}
+	if rejected {
+		return nil
+	}
+
+	// reject join-requests for which a join-accept has already been issued,
+	// also by an other instance or before a restart
+	issued, err := handleIssuedJoinAccept(ctx, *jrPL)
+	if err != nil {
+		return errors.Wrap(err, "issued join-accept check error")
+	}
+	if issued {
+		return nil
+	}
+
+	// report nodes which keep sending join-requests after accepted joins
+	checkJoinAcceptRetries(ctx, *jrPL)
+
 	// get random DevAddr
 	devAddr, err := session.GetRandomDevAddr(ctx.RedisPool, ctx.NetID)
 	if err != nil {
-		return fmt.Errorf("get random DevAddr error: %s", err)
+		return errors.Wrap(err, "get random DevAddr error")
 	}
 
-	joinResp, err := ctx.Application.JoinRequest(context.Background(), &as.JoinRequestRequest{
-		PhyPayload: b,
-		DevAddr:    devAddr[:],
-		NetID:      ctx.NetID[:],
+	joinResp, err := ctx.Application.JoinRequest(ctx.RequestContext(), &as.JoinRequestRequest{
+		PhyPayload:   b,
+		DevAddr:      devAddr[:],
+		NetID:        ctx.NetID[:],
+		CFListChMask: getCFListChMask(),
 	})
 	if err != nil {
-		return fmt.Errorf("application server join-request error: %s", err)
+		return errors.Wrap(err, "application server join-request error")
 	}
 
-	var cFList lorawan.CFList
-	if len(joinResp.CFList) > len(cFList) {
-		errStr := fmt.Sprintf("max CFlist size %d, got %d", len(cFList), len(joinResp.CFList))
+	// handle a channel frequency CFList of a band not implementing it,
+	// before it ends up in the channel state of the node-session
+	rejected, err = handleUnsupportedCFList(ctx, *jrPL, joinResp)
+	if err != nil {
+		return errors.Wrap(err, "handle unsupported cflist error")
+	}
+	if rejected {
+		return nil
+	}
+
+	// reject invalid join-responses instead of creating a broken node-session
+	if err = validateJoinResponse(joinResp); err != nil {
 		ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
 			AppEUI: jrPL.AppEUI[:],
 			DevEUI: jrPL.DevEUI[:],
-			Type:   as.ErrorType_OTAA,
-			Error:  errStr,
+			Type:   as.ErrorType_OTAA_INVALID_JOIN_RESPONSE,
+			Error:  err.Error(),
 		})
-		return errors.New(errStr)
+		return err
+	}
+
+	// reject join-requests received outside the join area of the node
+	outOfArea, err := handleJoinGeofence(ctx, *jrPL, joinResp, rxPacket)
+	if err != nil {
+		return errors.Wrap(err, "join geofence error")
 	}
+	if outOfArea {
+		return nil
+	}
+
+	var cFList lorawan.CFList
 	for i, cf := range joinResp.CFList {
 		cFList[i] = cf
 	}
 
 	var downlinkPHY lorawan.PHYPayload
 	if err = downlinkPHY.UnmarshalBinary(joinResp.PhyPayload); err != nil {
-		errStr := fmt.Sprintf("downlink PHYPayload unmarshal error: %s", err)
+		return errors.Wrap(err, "downlink PHYPayload unmarshal error")
+	}
+
+	var nwkSKey lorawan.AES128Key
+	copy(nwkSKey[:], joinResp.NwkSKey)
+
+	appSKey, err := session.UnwrapAppSKey(joinResp.WrappedAppSKey)
+	if err != nil {
+		errStr := fmt.Sprintf("unwrap AppSKey error: %s", err)
 		ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
 			AppEUI: jrPL.AppEUI[:],
 			DevEUI: jrPL.DevEUI[:],
@@ -93,14 +209,12 @@ func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPac
 		return errors.New(errStr)
 	}
 
-	var nwkSKey lorawan.AES128Key
-	copy(nwkSKey[:], joinResp.NwkSKey)
-
 	ns := session.NodeSession{
 		DevAddr:            devAddr,
 		AppEUI:             jrPL.AppEUI,
 		DevEUI:             jrPL.DevEUI,
 		NwkSKey:            nwkSKey,
+		AppSKey:            appSKey,
 		FCntUp:             0,
 		FCntDown:           0,
 		RelaxFCnt:          joinResp.RelaxFCnt,
@@ -108,23 +222,257 @@ func handleCollectedJoinRequestPackets(ctx common.Context, rxPacket models.RXPac
 		RXDelay:            uint8(joinResp.RxDelay),
 		RX1DROffset:        uint8(joinResp.Rx1DROffset),
 		RX2DR:              uint8(joinResp.Rx2DR),
+		RX2Frequency:       int(joinResp.Rx2Frequency),
 		CFList:             &cFList,
 		ADRInterval:        joinResp.AdrInterval,
 		InstallationMargin: joinResp.InstallationMargin,
-		LastRXInfoSet:      rxPacket.RXInfoSet,
+		ADRStrategy:        session.ADRStrategy(joinResp.AdrStrategy),
+		GatewayRegions:     joinResp.GatewayRegions,
+		DownlinkTXParams: models.TXParams{
+			Power:    int(joinResp.DownlinkTXPower),
+			CodeRate: joinResp.DownlinkCodeRate,
+			IPol:     polarityToIPol(joinResp.DownlinkPolarity),
+		},
+		BatteryThrottleLevel:    uint8(joinResp.BatteryThrottleLevel),
+		ClassCFPort:             uint8(joinResp.ClassCFPort),
+		ClassCWindow:            time.Duration(joinResp.ClassCWindow) * time.Second,
+		TransmitDiversity:       joinResp.TransmitDiversity,
+		DailyDownlinkAirtimeCap: time.Duration(joinResp.DailyDownlinkAirtimeCap) * time.Millisecond,
+		Tags:                    joinResp.Tags,
+		MACVersion:              joinResp.MacVersion,
+		Geolocation:             joinResp.Geolocation,
+		ChannelConfigurationID:  joinResp.ChannelConfigurationID,
+		ForwardPHYPayload:       joinResp.ForwardPHYPayload,
+		MaxUplinksPerHour:       joinResp.MaxUplinksPerHour,
+		LegacyADRACKReq:         joinResp.LegacyADRACKReq,
+		DeviceProfileID:         joinResp.DeviceProfileID,
+		LastRXInfoSet:           rxPacket.RXInfoSet,
+	}
+
+	if err = ns.DownlinkTXParams.Validate(); err != nil {
+		return errors.Wrap(err, "validate downlink tx-params error")
+	}
+
+	if err = session.ValidateRX2Frequency(ns.RX2Frequency); err != nil {
+		return errors.Wrap(err, "validate rx2 frequency error")
+	}
+
+	if err = ns.Tags.Validate(); err != nil {
+		return errors.Wrap(err, "validate tags error")
+	}
+
+	if err = session.ValidateMACVersion(ns.MACVersion); err != nil {
+		return errors.Wrap(err, "validate mac version error")
+	}
+
+	// record the join-accept, refusing to send it when a join-accept has
+	// been issued for the same DevNonce in the meantime
+	replay, err = handleJoinAcceptReplay(ctx, *jrPL, joinResp)
+	if err != nil {
+		return errors.Wrap(err, "join-accept replay check error")
+	}
+	if replay {
+		return nil
+	}
+
+	if joinResp.PreserveDownlinkQueue {
+		if err = migrateNodeSessionState(ctx.RedisPool, &ns); err != nil {
+			return errors.Wrap(err, "migrate node-session state error")
+		}
+	}
+
+	if joinResp.MonotonicDevNonce {
+		if err = handleMonotonicDevNonce(ctx, *jrPL); err != nil {
+			return errors.Wrap(err, "handle monotonic dev-nonce error")
+		}
 	}
 
 	if err = session.SaveNodeSession(ctx.RedisPool, ns); err != nil {
-		return fmt.Errorf("save node-session error: %s", err)
+		return errors.Wrap(err, "save node-session error")
+	}
+
+	if !joinResp.PreserveDownlinkQueue {
+		if err = maccommand.FlushQueue(ctx.RedisPool, ns.DevEUI); err != nil {
+			return errors.Wrap(err, "flush mac-command queue error")
+		}
+	}
+
+	// the frame-counters of the new node-session have been reset
+	if err = downlink.ClearConfirmedDataDown(ctx.RedisPool, ns.DevEUI); err != nil {
+		return errors.Wrap(err, "clear confirmed data down error")
 	}
 
-	if err = maccommand.FlushQueue(ctx.RedisPool, ns.DevEUI); err != nil {
-		return fmt.Errorf("flush mac-command queue error: %s", err)
+	// the join-accept does not contain the rx2 frequency
+	if ns.RX2Frequency != 0 && ns.RX2Frequency != common.Band.RX2Frequency {
+		current := maccommand.GetRXParamSetupPayload(ns)
+		current.Frequency = uint32(common.Band.RX2Frequency)
+		if err = enqueueRXParamSetupReq(ctx, ns, current); err != nil {
+			return errors.Wrap(err, "enqueue rx param setup request error")
+		}
 	}
 	fmt.Println("Sending JoinAcceptResponse")
 	if err = downlink.SendJoinAcceptResponse(ctx, ns, rxPacket, downlinkPHY); err != nil {
-		return fmt.Errorf("send join-accept response error: %s", err)
+		return errors.Wrap(err, "send join-accept response error")
+	}
+	accepted = true
+
+	// publish the first join of the node for provisioning automations
+	err = provisioning.HandleJoin(ctx.DB, provisioning.Event{
+		Time:      time.Now(),
+		DevEUI:    ns.DevEUI,
+		AppEUI:    ns.AppEUI,
+		DevAddr:   ns.DevAddr,
+		RXInfoSet: rxPacket.RXInfoSet,
+	})
+	if err != nil {
+		log.WithField("dev_eui", ns.DevEUI).Errorf("handle first join error: %s", err)
+	}
+
+	return nil
+}
+
+// handleJoinGuard returns true when the given join-request must be rejected
+// as it re-uses a recent DevNonce of the node or exceeds the join-request
+// rate limit of the node or of the receiving gateways (see joinguard). In
+// that case the network-controller is notified.
+func handleJoinGuard(ctx common.Context, jrPL lorawan.JoinRequestPayload, rxPacket models.RXPacket) (bool, error) {
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	reason, err := joinguard.Check(ctx.RedisPool, jrPL, macs)
+	if err != nil || reason == "" {
+		return false, err
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":   jrPL.DevEUI,
+		"dev_nonce": jrPL.DevNonce,
+		"reason":    reason,
+	}).Warning("join-request rejected")
+
+	_, err = ctx.Controller.HandleError(context.Background(), &nc.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Error:  fmt.Sprintf("join-request rejected: %s (dev-nonce %X)", reason, jrPL.DevNonce[:]),
+	})
+	if err != nil {
+		return true, errors.Wrap(err, "send join-request rejection to network-controller error")
+	}
+	return true, nil
+}
+
+// checkJoinAcceptRetries sends an OTAA_JOIN_ACCEPT_NOT_RECEIVED error to
+// the application-server when the join-accepts sent to the node did not
+// result in an uplink, every common.JoinAcceptRetryThreshold join-accepts.
+// The error contains the diagnosis based on the TXAcks of the gateways.
+// Errors are logged.
+func checkJoinAcceptRetries(ctx common.Context, jrPL lorawan.JoinRequestPayload) {
+	if common.JoinAcceptRetryThreshold == 0 {
+		return
+	}
+
+	joinAccepts, err := downlink.GetJoinAccepts(ctx.RedisPool, jrPL.DevEUI)
+	if err != nil {
+		log.WithField("dev_eui", jrPL.DevEUI).Errorf("get join-accepts error: %s", err)
+		return
+	}
+	if joinAccepts.Sent == 0 || joinAccepts.Sent%common.JoinAcceptRetryThreshold != 0 {
+		return
 	}
 
+	log.WithFields(log.Fields{
+		"dev_eui":  jrPL.DevEUI,
+		"app_eui":  jrPL.AppEUI,
+		"sent":     joinAccepts.Sent,
+		"acked":    joinAccepts.Acked,
+		"rejected": joinAccepts.Rejected,
+	}).Warningf("join-accepts not resulting in activation: %s", joinAccepts.Diagnosis())
+
+	_, err = ctx.Application.HandleError(context.Background(), &as.HandleErrorRequest{
+		AppEUI: jrPL.AppEUI[:],
+		DevEUI: jrPL.DevEUI[:],
+		Type:   as.ErrorType_OTAA_JOIN_ACCEPT_NOT_RECEIVED,
+		Error:  joinAccepts.String(),
+	})
+	if err != nil {
+		log.WithField("dev_eui", jrPL.DevEUI).Errorf("publish join-accept error to application-server error: %s", err)
+	}
+}
+
+// recordJoinStats records the outcome of the join-request in the join stats
+// of the AppEUI and the receiving gateways. Errors are logged.
+func recordJoinStats(ctx common.Context, jrPL lorawan.JoinRequestPayload, rxPacket models.RXPacket, accepted bool, latency time.Duration) {
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  jrPL.DevEUI,
+		"app_eui":  jrPL.AppEUI,
+		"accepted": accepted,
+		"latency":  latency,
+	}).Info("join-request handled")
+
+	if err := joinstats.RecordJoin(ctx.RedisPool, jrPL.AppEUI, macs, accepted, latency); err != nil {
+		log.WithField("dev_eui", jrPL.DevEUI).Errorf("record join stats error: %s", err)
+	}
+}
+
+// polarityToIPol returns the polarity inversion for the given polarity
+// (nil when the polarity is not set).
+func polarityToIPol(p as.Polarity) *bool {
+	if p == as.Polarity_INHERIT_POLARITY {
+		return nil
+	}
+	iPol := p == as.Polarity_INVERTED_POLARITY
+	return &iPol
+}
+
+// migrateNodeSessionState migrates the state of the previous node-session
+// of a rejoining node (if any) to the given new node-session. Only the
+// uplink history is migrated, as the node resets its TX power and NbTrans
+// on (re)join.
+func migrateNodeSessionState(p *redis.Pool, ns *session.NodeSession) error {
+	oldNS, err := session.GetNodeSession(p, ns.DevEUI)
+	if err != nil {
+		if err == session.ErrDoesNotExist {
+			return nil
+		}
+		return err
+	}
+
+	ns.UplinkHistory = oldNS.UplinkHistory
+
+	log.WithFields(log.Fields{
+		"dev_eui":      ns.DevEUI,
+		"old_dev_addr": oldNS.DevAddr,
+		"dev_addr":     ns.DevAddr,
+	}).Info("node-session state migrated on rejoin")
 	return nil
 }
+
+// isSuppressedJoinRequest returns true when a join-request with the same
+// DevEUI and DevNonce has already been processed within the configured
+// common.JoinRequestSuppressionWindow. It always returns false when the
+// suppression window is disabled.
+func isSuppressedJoinRequest(p *redis.Pool, jrPL lorawan.JoinRequestPayload) (bool, error) {
+	if common.JoinRequestSuppressionWindow == 0 {
+		return false, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(JoinRequestSuppressionKeyTempl, jrPL.DevEUI, hex.EncodeToString(jrPL.DevNonce[:]))
+	_, err := redis.String(c.Do("SET", key, "lock", "PX", int64(common.JoinRequestSuppressionWindow)/int64(time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
+}
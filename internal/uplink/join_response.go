@@ -0,0 +1,124 @@
+package uplink
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	"github.com/joriwind/loraserver/api/as"
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+const (
+	maxRXDelay     = 15 // RXDelay is a 4 bit field in the RxDelay
+	maxRX1DROffset = 7  // RX1DRoffset is a 3 bit field in the DLSettings
+)
+
+// frequencyRange defines the (inclusive) frequency range of a band in Hz.
+type frequencyRange struct {
+	min, max int
+}
+
+// cFListFrequencyRanges contains per band implementing the CFList the
+// frequency range of the extra channels.
+var cFListFrequencyRanges = map[band.Name]frequencyRange{
+	band.AS_923:     {915000000, 928000000},
+	band.CN_779_787: {779500000, 786500000},
+	band.EU_433:     {433175000, 434665000},
+	band.EU_863_870: {863000000, 870000000},
+	band.KR_920_923: {920900000, 923300000},
+	band.RU_864_869: {864000000, 869200000},
+}
+
+// validateJoinResponse validates the join-response of the application-server
+// before it is used for creating the node-session. The returned error
+// (wrapping ErrInvalidJoinResponse) describes the first invalid field.
+func validateJoinResponse(resp *as.JoinRequestResponse) error {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(resp.PhyPayload); err != nil {
+		return errors.Wrapf(ErrInvalidJoinResponse, "phyPayload: %s", err)
+	}
+	if phy.MHDR.MType != lorawan.JoinAccept {
+		return errors.Wrapf(ErrInvalidJoinResponse, "phyPayload: expected MType %s, got %s", lorawan.JoinAccept, phy.MHDR.MType)
+	}
+
+	if l := len(resp.NwkSKey); l != len(lorawan.AES128Key{}) {
+		return errors.Wrapf(ErrInvalidJoinResponse, "nwkSKey: expected %d bytes, got %d", len(lorawan.AES128Key{}), l)
+	}
+
+	// a wrapped key has a 8 byte integrity check value
+	if l := len(resp.WrappedAppSKey); l != 0 && l != len(lorawan.AES128Key{})+8 {
+		return errors.Wrapf(ErrInvalidJoinResponse, "wrappedAppSKey: expected %d bytes, got %d", len(lorawan.AES128Key{})+8, l)
+	}
+
+	if resp.RxDelay > maxRXDelay {
+		return errors.Wrapf(ErrInvalidJoinResponse, "rxDelay: %d exceeds %d", resp.RxDelay, maxRXDelay)
+	}
+
+	if resp.Rx1DROffset > maxRX1DROffset {
+		return errors.Wrapf(ErrInvalidJoinResponse, "rx1DROffset: %d exceeds %d", resp.Rx1DROffset, maxRX1DROffset)
+	}
+
+	if maxDR := len(common.Band.DataRates) - 1; int(resp.Rx2DR) > maxDR {
+		return errors.Wrapf(ErrInvalidJoinResponse, "rx2DR: %d exceeds the max data-rate of the band (%d)", resp.Rx2DR, maxDR)
+	}
+
+	if resp.RxWindow != as.RXWindow_RX1 && resp.RxWindow != as.RXWindow_RX2 {
+		return errors.Wrapf(ErrInvalidJoinResponse, "rxWindow: unknown value %d", resp.RxWindow)
+	}
+
+	if err := validateCFListType(resp); err != nil {
+		return err
+	}
+
+	return validateCFList(resp.CFList)
+}
+
+// validateCFListType validates that the channel mask CFList is only used
+// when it has been generated by LoRa Server for the join-request.
+func validateCFListType(resp *as.JoinRequestResponse) error {
+	switch resp.CFListType {
+	case as.CFListType_FREQUENCIES:
+		return nil
+	case as.CFListType_CHANNEL_MASK:
+		if len(enabledUplinkChannels) == 0 {
+			return errors.Wrap(ErrInvalidJoinResponse, "cFListType: no channel mask CFList has been generated (no enabled uplink channels configured)")
+		}
+		if len(resp.CFList) != 0 {
+			return errors.Wrap(ErrInvalidJoinResponse, "cFList: must be empty when using the channel mask CFList")
+		}
+		return nil
+	default:
+		return errors.Wrapf(ErrInvalidJoinResponse, "cFListType: unknown value %d", resp.CFListType)
+	}
+}
+
+// validateCFList validates that the given CFList frequencies are valid for
+// the band. Unused channels are set to 0.
+func validateCFList(cFList []uint32) error {
+	var max lorawan.CFList
+	if len(cFList) > len(max) {
+		return errors.Wrapf(ErrInvalidJoinResponse, "cFList: max size %d, got %d", len(max), len(cFList))
+	}
+
+	for i, f := range cFList {
+		if f == 0 {
+			continue
+		}
+
+		if !common.Band.ImplementsCFlist {
+			return errors.Wrapf(ErrInvalidJoinResponse, "cFList: band %s does not implement the CFList", common.BandName)
+		}
+
+		// the CFList contains the frequencies in units of 100 Hz
+		if f%100 != 0 {
+			return errors.Wrapf(ErrInvalidJoinResponse, "cFList[%d]: frequency %d is not a multiple of 100 Hz", i, f)
+		}
+
+		if r, ok := cFListFrequencyRanges[common.BandName]; ok && (int(f) < r.min || int(f) > r.max) {
+			return errors.Wrapf(ErrInvalidJoinResponse, "cFList[%d]: frequency %d is outside the band (%d - %d)", i, f, r.min, r.max)
+		}
+	}
+
+	return nil
+}
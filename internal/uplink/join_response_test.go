@@ -0,0 +1,95 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/as"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateJoinResponse(t *testing.T) {
+	Convey("Given a valid join-response", t, func() {
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinAccept,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.DataPayload{Bytes: make([]byte, 16)},
+		}
+		phyBytes, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		resp := as.JoinRequestResponse{
+			PhyPayload:     phyBytes,
+			NwkSKey:        make([]byte, 16),
+			WrappedAppSKey: make([]byte, 24),
+			RxDelay:        1,
+			Rx1DROffset:    1,
+			Rx2DR:          3,
+			RxWindow:       as.RXWindow_RX2,
+			CFList:         []uint32{867100000, 867300000, 0},
+		}
+
+		Convey("Then it is valid", func() {
+			So(validateJoinResponse(&resp), ShouldBeNil)
+		})
+
+		tests := []struct {
+			Name   string
+			Modify func(resp *as.JoinRequestResponse)
+		}{
+			{"invalid phyPayload", func(resp *as.JoinRequestResponse) { resp.PhyPayload = nil }},
+			{"phyPayload is not a join-accept", func(resp *as.JoinRequestResponse) {
+				phy.MHDR.MType = lorawan.UnconfirmedDataDown
+				phy.MACPayload = &lorawan.MACPayload{}
+				resp.PhyPayload, _ = phy.MarshalBinary()
+			}},
+			{"nwkSKey too short", func(resp *as.JoinRequestResponse) { resp.NwkSKey = make([]byte, 15) }},
+			{"nwkSKey not set", func(resp *as.JoinRequestResponse) { resp.NwkSKey = nil }},
+			{"wrappedAppSKey invalid length", func(resp *as.JoinRequestResponse) { resp.WrappedAppSKey = make([]byte, 16) }},
+			{"rxDelay exceeds 15", func(resp *as.JoinRequestResponse) { resp.RxDelay = 16 }},
+			{"rx1DROffset exceeds 7", func(resp *as.JoinRequestResponse) { resp.Rx1DROffset = 8 }},
+			{"rx2DR exceeds the max data-rate of the band", func(resp *as.JoinRequestResponse) { resp.Rx2DR = 16 }},
+			{"unknown rxWindow", func(resp *as.JoinRequestResponse) { resp.RxWindow = 3 }},
+			{"cFList too long", func(resp *as.JoinRequestResponse) { resp.CFList = make([]uint32, 6) }},
+			{"cFList frequency outside the band", func(resp *as.JoinRequestResponse) { resp.CFList = []uint32{902300000} }},
+			{"cFList frequency not a multiple of 100 Hz", func(resp *as.JoinRequestResponse) { resp.CFList = []uint32{867100050} }},
+			{"channel mask cFListType without generated channel mask", func(resp *as.JoinRequestResponse) {
+				resp.CFList = nil
+				resp.CFListType = as.CFListType_CHANNEL_MASK
+			}},
+			{"unknown cFListType", func(resp *as.JoinRequestResponse) { resp.CFListType = 2 }},
+		}
+
+		for _, test := range tests {
+			Convey("Then it is invalid when: "+test.Name, func() {
+				test.Modify(&resp)
+				err := validateJoinResponse(&resp)
+				So(errors.Cause(err), ShouldEqual, ErrInvalidJoinResponse)
+			})
+		}
+	})
+
+	Convey("Given a join-response using the channel mask CFList and enabled uplink channels", t, func() {
+		enabledUplinkChannels = []int{0, 1, 2, 3, 4, 5, 6, 7}
+		Reset(func() {
+			enabledUplinkChannels = nil
+		})
+
+		resp := as.JoinRequestResponse{
+			CFListType: as.CFListType_CHANNEL_MASK,
+		}
+
+		Convey("Then the CFList type is valid", func() {
+			So(validateCFListType(&resp), ShouldBeNil)
+		})
+
+		Convey("Then it is invalid when the cFList frequencies are set", func() {
+			resp.CFList = []uint32{867100000}
+			So(errors.Cause(validateCFListType(&resp)), ShouldEqual, ErrInvalidJoinResponse)
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package uplink
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// uplinkMetadataChannel is the Redis pub/sub channel on which the uplink
+// meta-data is published, so that every LoRa Server instance is able
+// to stream the meta-data of all received uplink frames.
+const uplinkMetadataChannel = "lora:ns:uplink:metadata"
+
+// publishUplinkMetadata publishes the meta-data of the given uplink frame.
+func publishUplinkMetadata(p *redis.Pool, sess session.NodeSession, rxPacket models.RXPacket, macPL lorawan.MACPayload) error {
+	dr, err := common.GetDataRate(rxPacket.RXInfoSet[0].DataRate)
+	if err != nil {
+		return errors.Wrap(err, "get data-rate error")
+	}
+
+	md := ns.StreamUplinkMetadataResponse{
+		DevEUI:    sess.DevEUI[:],
+		AppEUI:    sess.AppEUI[:],
+		DevAddr:   sess.DevAddr[:],
+		MType:     uint32(rxPacket.PHYPayload.MHDR.MType),
+		FCnt:      macPL.FHDR.FCnt,
+		DataRate:  uint32(dr),
+		Frequency: int64(rxPacket.RXInfoSet[0].Frequency),
+		Adr:       macPL.FHDR.FCtrl.ADR,
+	}
+
+	if macPL.FPort != nil {
+		md.FPort = uint32(*macPL.FPort)
+	}
+
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		// make sure we have a copy of the MAC byte slice, else every RxInfo
+		// slice item will get the same Mac
+		mac := make([]byte, 8)
+		copy(mac, rxInfo.MAC[:])
+
+		md.RxInfo = append(md.RxInfo, &ns.UplinkRXInfo{
+			Mac:     mac,
+			Time:    rxInfo.Time.Format(time.RFC3339Nano),
+			Rssi:    int32(rxInfo.RSSI),
+			LoRaSNR: rxInfo.LoRaSNR,
+		})
+	}
+
+	b, err := proto.Marshal(&md)
+	if err != nil {
+		return errors.Wrap(err, "marshal uplink meta-data error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("PUBLISH", uplinkMetadataChannel, b); err != nil {
+		return errors.Wrap(err, "publish uplink meta-data error")
+	}
+	return nil
+}
+
+// SubscribeUplinkMetadata subscribes to the uplink meta-data and calls fn
+// for every received uplink frame. It blocks until the given context is
+// cancelled (in which case nil is returned) or until fn returns an error.
+func SubscribeUplinkMetadata(ctx context.Context, p *redis.Pool, fn func(*ns.StreamUplinkMetadataResponse) error) error {
+	c, err := common.GetRedisSubscribeConn(p)
+	if err != nil {
+		return errors.Wrap(err, "get subscribe connection error")
+	}
+	defer c.Close()
+
+	psc := redis.PubSubConn{Conn: c}
+	if err := psc.Subscribe(uplinkMetadataChannel); err != nil {
+		return errors.Wrap(err, "subscribe error")
+	}
+
+	// unsubscribe on cancellation of the context, this will unblock the
+	// Receive call below
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			psc.Unsubscribe()
+		case <-done:
+		}
+	}()
+	defer wg.Wait()
+	defer close(done)
+
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			var md ns.StreamUplinkMetadataResponse
+			if err := proto.Unmarshal(v.Data, &md); err != nil {
+				log.Errorf("unmarshal uplink meta-data error: %s", err)
+				continue
+			}
+			if err := fn(&md); err != nil {
+				return err
+			}
+		case redis.Subscription:
+			if v.Count == 0 {
+				return nil
+			}
+		case error:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(v, "receive error")
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package uplink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/api/ns"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUplinkMetadata(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a Redis connection pool and a subscriber", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mdChan := make(chan *ns.StreamUplinkMetadataResponse, 1)
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- SubscribeUplinkMetadata(ctx, p, func(md *ns.StreamUplinkMetadataResponse) error {
+				mdChan <- md
+				return nil
+			})
+		}()
+		// make sure the subscription is active before publishing
+		time.Sleep(100 * time.Millisecond)
+
+		Convey("When publishing the meta-data of an uplink frame", func() {
+			sess := session.NodeSession{
+				DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+				DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				AppEUI:  lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			}
+			fPort := uint8(10)
+			macPL := lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: sess.DevAddr,
+					FCnt:    12,
+				},
+				FPort: &fPort,
+			}
+			rxPacket := models.RXPacket{
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &macPL,
+				},
+				RXInfoSet: models.RXInfoSet{
+					{
+						MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+						Frequency: common.Band.UplinkChannels[0].Frequency,
+						DataRate:  common.Band.DataRates[3],
+						RSSI:      -60,
+						LoRaSNR:   5.5,
+					},
+				},
+			}
+			So(publishUplinkMetadata(p, sess, rxPacket, macPL), ShouldBeNil)
+
+			Convey("Then the subscriber receives the meta-data", func() {
+				md := <-mdChan
+				So(md.DevEUI, ShouldResemble, sess.DevEUI[:])
+				So(md.FPort, ShouldEqual, 10)
+				So(md.FCnt, ShouldEqual, 12)
+				So(md.DataRate, ShouldEqual, 3)
+				So(md.RxInfo, ShouldHaveLength, 1)
+				So(md.RxInfo[0].Rssi, ShouldEqual, -60)
+
+				Convey("When cancelling the context, the subscriber returns", func() {
+					cancel()
+					So(<-errChan, ShouldBeNil)
+				})
+			})
+		})
+
+		Reset(cancel)
+	})
+}
@@ -0,0 +1,11 @@
+package uplink
+
+import (
+	"github.com/joriwind/loraserver/internal/metrics"
+)
+
+var (
+	packetsReceivedCount = metrics.NewCounter("loraserver_uplink_packets_received_total", "Number of uplink packets received from the gateways (before de-duplication).", "mac")
+	unknownDevAddrCount  = metrics.NewCounter("loraserver_uplink_unknown_dev_addr_total", "Number of uplink packets of DevAddrs without node-session (passive monitoring), by gateway and if the NwkID does not match the NetID.", "mac", "foreign")
+	deduplicationSetSize = metrics.NewHistogram("loraserver_uplink_deduplication_set_size", "Number of packets (receptions) collected for a single uplink frame.", []float64{1, 2, 3, 4, 5, 10, 20})
+)
@@ -0,0 +1,74 @@
+package uplink
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/oversize"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// getFrameSizes returns the FOpts and FRMPayload size (in bytes) of the
+// given MACPayload.
+func getFrameSizes(macPL *lorawan.MACPayload) (int, int, error) {
+	var fOptsSize, frmPayloadSize int
+	for _, cmd := range macPL.FHDR.FOpts {
+		b, err := cmd.MarshalBinary()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "marshal FOpts error")
+		}
+		fOptsSize += len(b)
+	}
+	for _, pl := range macPL.FRMPayload {
+		b, err := pl.MarshalBinary()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "marshal FRMPayload error")
+		}
+		frmPayloadSize += len(b)
+	}
+	return fOptsSize, frmPayloadSize, nil
+}
+
+// handleOversizedFrame validates the FRMPayload size of the given uplink
+// against the max. payload size of the data-rate on which it was received.
+// Oversized frames are logged and counted per node and gateway. It returns
+// true when the frame is oversized.
+func handleOversizedFrame(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, macPL *lorawan.MACPayload) (bool, error) {
+	dr, err := common.GetDataRate(rxPacket.RXInfoSet[0].DataRate)
+	if err != nil {
+		return false, errors.Wrap(err, "get data-rate error")
+	}
+
+	fOptsSize, frmPayloadSize, err := getFrameSizes(macPL)
+	if err != nil {
+		return false, err
+	}
+
+	max, ok, err := oversize.Check(dr, fOptsSize, frmPayloadSize)
+	if err != nil || ok {
+		return false, err
+	}
+
+	var macs []lorawan.EUI64
+	for _, rxInfo := range rxPacket.RXInfoSet {
+		macs = append(macs, rxInfo.MAC)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":          ns.DevEUI,
+		"dr":               dr,
+		"fopts_size":       fOptsSize,
+		"frmpayload_size":  frmPayloadSize,
+		"max_payload_size": max,
+		"gw_macs":          macs,
+	}).Warning("oversized uplink frame received")
+
+	if err := oversize.Record(ctx.RedisPool, ns.DevEUI, macs); err != nil {
+		return true, err
+	}
+	return true, nil
+}
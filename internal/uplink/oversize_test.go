@@ -0,0 +1,30 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetFrameSizes(t *testing.T) {
+	Convey("Given a MACPayload with FOpts and FRMPayload", t, func() {
+		macPL := lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				FOpts: []lorawan.MACCommand{
+					{CID: lorawan.LinkCheckReq},
+				},
+			},
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4, 5}},
+			},
+		}
+
+		Convey("Then the FOpts and FRMPayload sizes are returned", func() {
+			fOptsSize, frmPayloadSize, err := getFrameSizes(&macPL)
+			So(err, ShouldBeNil)
+			So(fOptsSize, ShouldEqual, 1)
+			So(frmPayloadSize, ShouldEqual, 5)
+		})
+	})
+}
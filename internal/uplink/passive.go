@@ -0,0 +1,52 @@
+package uplink
+
+import (
+	"strconv"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/passive"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// recordUnknownDevAddr records the given uplink in the passive monitoring
+// stats when there is no node-session for its DevAddr (an uplink of a
+// known DevAddr with an invalid frame-counter or MIC is not recorded).
+// Errors are logged as the stats must not affect the handling of the
+// uplink.
+func recordUnknownDevAddr(ctx common.Context, rxPacket gw.RXPacket) {
+	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return
+	}
+	devAddr := macPL.FHDR.DevAddr
+
+	sessions, err := session.GetNodeSessionsForDevAddr(ctx.RedisPool, devAddr)
+	if err != nil {
+		log.WithField("dev_addr", devAddr).Errorf("get node-sessions for devaddr error: %s", err)
+		return
+	}
+	if len(sessions) > 0 {
+		return
+	}
+
+	foreign := devAddr.NwkID() != ctx.NetID.NwkID()
+	unknownDevAddrCount.Inc(rxPacket.RXInfo.MAC.String(), strconv.FormatBool(foreign))
+
+	dr, err := common.GetDataRate(rxPacket.RXInfo.DataRate)
+	if err != nil {
+		log.WithField("data_rate", rxPacket.RXInfo.DataRate).Errorf("get data-rate error: %s", err)
+		return
+	}
+
+	if err := passive.RecordUplink(ctx.RedisPool, rxPacket.RXInfo.MAC, rxPacket.RXInfo.Frequency, dr, devAddr); err != nil {
+		log.WithFields(log.Fields{
+			"dev_addr": devAddr,
+			"mac":      rxPacket.RXInfo.MAC,
+		}).Errorf("record passive monitoring stats error: %s", err)
+	}
+}
@@ -0,0 +1,61 @@
+package uplink
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/metrics"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// UplinkRateKeyTempl is the template used for generating the Redis key of
+// the number of uplink frames of a node (DevEUI) within an hour (start of
+// the hour as Unix timestamp), of which the first MaxUplinksPerHour frames
+// are forwarded to the application-server.
+const UplinkRateKeyTempl = "lora:ns:uplink:rate:%s:%d"
+
+var uplinkRateLimitedCount = metrics.NewCounter("loraserver_uplink_rate_limited_total", "Number of uplink frames not forwarded to the application-server because the max. number of uplinks per hour of the node was exceeded.")
+
+// isUplinkRateLimited returns true when the uplink frame of the given node,
+// received at the given time, exceeds the max. number of uplink frames per
+// hour of the node and must not be forwarded to the application-server.
+// It always returns false when the node has no limit.
+func isUplinkRateLimited(p *redis.Pool, ns session.NodeSession, receivedAt time.Time) (bool, error) {
+	if ns.MaxUplinksPerHour == 0 {
+		return false, nil
+	}
+
+	hour := receivedAt.Truncate(time.Hour)
+	key := fmt.Sprintf(UplinkRateKeyTempl, ns.DevEUI, hour.Unix())
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("INCR", key)
+	c.Send("PEXPIREAT", key, hour.Add(time.Hour).UnixNano()/int64(time.Millisecond))
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return false, errors.Wrap(err, "increment uplink rate error")
+	}
+	count, err := redis.Int64(values[0], nil)
+	if err != nil {
+		return false, errors.Wrap(err, "read uplink rate error")
+	}
+
+	if count <= int64(ns.MaxUplinksPerHour) {
+		return false, nil
+	}
+
+	uplinkRateLimitedCount.Inc()
+	log.WithFields(log.Fields{
+		"dev_eui":              ns.DevEUI,
+		"max_uplinks_per_hour": ns.MaxUplinksPerHour,
+		"dropped":              count - int64(ns.MaxUplinksPerHour),
+	}).Warning("uplink rate limit exceeded, uplink not forwarded to application-server")
+	return true, nil
+}
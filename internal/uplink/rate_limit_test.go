@@ -0,0 +1,53 @@
+package uplink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestIsUplinkRateLimited(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		ns := session.NodeSession{DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}}
+		now := time.Date(2017, 6, 1, 10, 30, 0, 0, time.UTC)
+
+		Convey("Then a node without limit is never rate limited", func() {
+			for i := 0; i < 3; i++ {
+				limited, err := isUplinkRateLimited(p, ns, now)
+				So(err, ShouldBeNil)
+				So(limited, ShouldBeFalse)
+			}
+		})
+
+		Convey("Given a node with a max. of 2 uplinks per hour", func() {
+			ns.MaxUplinksPerHour = 2
+
+			Convey("Then only the uplinks exceeding the limit are rate limited", func() {
+				var results []bool
+				for i := 0; i < 4; i++ {
+					limited, err := isUplinkRateLimited(p, ns, now.Add(time.Duration(i)*time.Minute))
+					So(err, ShouldBeNil)
+					results = append(results, limited)
+				}
+				So(results, ShouldResemble, []bool{false, false, true, true})
+
+				Convey("Then the uplinks of the next hour are forwarded", func() {
+					limited, err := isUplinkRateLimited(p, ns, now.Add(time.Hour))
+					So(err, ShouldBeNil)
+					So(limited, ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package uplink
+
+import (
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// handleReadOnlyDataUp handles a collected (de-duplicated) data uplink in
+// read-only mode (see common.ReadOnlyMode). The application payload is
+// forwarded to the application-server, including the payloads of the
+// application-layer packages (as these would otherwise require a
+// downlink). The MAC commands and relay forwarded uplinks are ignored, the
+// node-session is not updated and no downlink is sent. As the FCntUp of
+// the node-session is not updated, retransmissions of the uplink are only
+// suppressed within common.RetransmissionSuppressionWindow.
+func handleReadOnlyDataUp(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, macPL *lorawan.MACPayload) error {
+	logFields := log.Fields{
+		"dev_eui": ns.DevEUI,
+		"fcnt":    macPL.FHDR.FCnt,
+	}
+
+	if macPL.FPort == nil || *macPL.FPort == 0 {
+		log.WithFields(logFields).Info("read-only mode: uplink without application payload ignored")
+		return nil
+	}
+
+	if isRelayForwardUplink(ns, macPL) {
+		log.WithFields(logFields).Warning("read-only mode: relay forwarded uplink ignored")
+		return nil
+	}
+
+	if err := publishDataUp(ctx, ns, rxPacket, *macPL); err != nil {
+		return err
+	}
+
+	log.WithFields(logFields).Info("read-only mode: uplink forwarded to application-server")
+	return nil
+}
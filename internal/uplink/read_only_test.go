@@ -0,0 +1,109 @@
+package uplink
+
+import (
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database, a node-session and read-only mode", t, func() {
+		test.MustResetDB(db)
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		common.ReadOnlyMode = true
+		defer func() { common.ReadOnlyMode = false }()
+
+		asClient := test.NewApplicationClient()
+		backend := test.NewGatewayBackend()
+		ctx := common.Context{
+			DB:          db,
+			RedisPool:   p,
+			Gateway:     backend,
+			Application: asClient,
+			Controller:  test.NewNetworkControllerClient(),
+		}
+
+		ns := session.NodeSession{
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			FCntUp:  10,
+		}
+		So(session.SaveNodeSession(p, ns), ShouldBeNil)
+
+		fPort := uint8(1)
+		rxPacket := models.RXPacket{
+			DevEUI: ns.DevEUI,
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.ConfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ns.DevAddr,
+						FCnt:    10,
+						FOpts: []lorawan.MACCommand{
+							{CID: lorawan.LinkCheckReq},
+						},
+					},
+					FPort: &fPort,
+					FRMPayload: []lorawan.Payload{
+						&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+					},
+				},
+			},
+			RXInfoSet: models.RXInfoSet{
+				{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Frequency: common.Band.UplinkChannels[0].Frequency, DataRate: common.Band.DataRates[0]},
+			},
+		}
+
+		Convey("When handling a confirmed uplink with a MAC command", func() {
+			So(handleCollectedDataUpPackets(ctx, rxPacket), ShouldBeNil)
+
+			Convey("Then the payload is forwarded to the application-server", func() {
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 1)
+				req := <-asClient.HandleDataUpChan
+				So(req.FCnt, ShouldEqual, 10)
+				So(req.Data, ShouldResemble, []byte{1, 2, 3})
+			})
+
+			Convey("Then the node-session has not been updated", func() {
+				ns2, err := session.GetNodeSession(p, ns.DevEUI)
+				So(err, ShouldBeNil)
+				So(ns2.FCntUp, ShouldEqual, 10)
+				So(ns2.LastRXInfoSet, ShouldHaveLength, 0)
+			})
+
+			Convey("Then no downlink has been sent", func() {
+				So(backend.TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Then a join-request is rejected", func() {
+			joinRXPacket := gw.RXPacket{
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.JoinRequest,
+						Major: lorawan.LoRaWANR1,
+					},
+				},
+			}
+			So(HandleRXPacket(ctx, joinRXPacket), ShouldEqual, ErrReadOnlyMode)
+		})
+	})
+}
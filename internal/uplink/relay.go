@@ -0,0 +1,81 @@
+package uplink
+
+import (
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/relay"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// isRelayForwardUplink returns true when the given uplink contains an
+// uplink forwarded by a relay.
+func isRelayForwardUplink(ns session.NodeSession, macPL *lorawan.MACPayload) bool {
+	return ns.Relay && macPL.FPort != nil && *macPL.FPort == relay.FPort
+}
+
+// handleRelayForwardUplink unwraps the uplink forwarded by the relay and
+// handles it as if it was received directly by the gateway that received
+// the relay uplink (with the frequency and meta-data reported by the relay).
+// Note that downlinks for the end-device are not forwarded through the
+// relay.
+func handleRelayForwardUplink(ctx common.Context, ns session.NodeSession, rxPacket models.RXPacket, macPL *lorawan.MACPayload) error {
+	if len(macPL.FRMPayload) != 1 {
+		return errors.Wrap(ErrInvalidForwardedUplink, "expected exactly one FRMPayload item")
+	}
+
+	dataPL, ok := macPL.FRMPayload[0].(*lorawan.DataPayload)
+	if !ok {
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.DataPayload, got: %T", macPL.FRMPayload[0])
+	}
+
+	var req relay.ForwardUplinkReq
+	if err := req.UnmarshalBinary(dataPL.Bytes); err != nil {
+		return errors.Wrap(err, "unmarshal forward uplink error")
+	}
+
+	if int(req.Metadata.DR) > len(common.Band.DataRates)-1 {
+		return errors.Wrapf(ErrInvalidForwardedUplink, "dr: %d (max dr: %d)", req.Metadata.DR, len(common.Band.DataRates)-1)
+	}
+
+	// the relay uplink was received by the best gateway, the forwarded uplink
+	// inherits its gateway meta-data
+	rxInfo := rxPacket.RXInfoSet[0]
+	fwdRXPacket := gw.RXPacket{
+		RXInfo: gw.RXInfo{
+			MAC:       rxInfo.MAC,
+			Time:      rxInfo.Time,
+			Timestamp: rxInfo.Timestamp,
+			Frequency: req.Frequency,
+			CRCStatus: 1,
+			CodeRate:  rxInfo.CodeRate,
+			RSSI:      req.Metadata.RSSI,
+			LoRaSNR:   float64(req.Metadata.SNR),
+			DataRate:  common.Band.DataRates[req.Metadata.DR],
+		},
+		PHYPayload: req.PHYPayload,
+	}
+
+	log.WithFields(log.Fields{
+		"relay_dev_eui": ns.DevEUI,
+		"mtype":         req.PHYPayload.MHDR.MType,
+		"frequency":     req.Frequency,
+		"dr":            req.Metadata.DR,
+		"wor_channel":   req.Metadata.WORChannel,
+	}).Info("relay forwarded uplink received")
+
+	// the forwarded uplink is handled asynchronously so that it does not
+	// delay the downlink response to the relay
+	go func() {
+		if err := HandleRXPacket(ctx, fwdRXPacket); err != nil {
+			log.WithField("relay_dev_eui", ns.DevEUI).Errorf("processing relay forwarded uplink error: %s", err)
+		}
+	}()
+
+	return nil
+}
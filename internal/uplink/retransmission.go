@@ -0,0 +1,281 @@
+package uplink
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/security"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/brocaar/lorawan"
+)
+
+// RetransmissionKeyTempl is the template used for generating the Redis key
+// of the uplink frames forwarded to the application-server (DevEUI and
+// FCnt). The value contains the PHYPayload of the frame.
+const RetransmissionKeyTempl = "lora:ns:uplink:retransmission:%s:%d"
+
+// SuppressedRetransmissionCountKey contains the number of suppressed
+// retransmissions.
+const SuppressedRetransmissionCountKey = "lora:ns:uplink:retransmission:suppressed"
+
+// ACKKeyTempl is the template used for generating the Redis key of the
+// acknowledged confirmed uplink frames (DevAddr and the 16 LSB of the FCnt).
+// The hash contains the DevEUI, full FCnt and MIC of the frame and the
+// number of times a retransmission of the frame has been acknowledged.
+const ACKKeyTempl = "lora:ns:uplink:ack:%s:%d"
+
+// maxRetransmissionACKs defines the max number of times a retransmission
+// of an acknowledged confirmed uplink is acknowledged (the max NbTrans).
+const maxRetransmissionACKs = 15
+
+// incrementRetransmissionACKsScript increments the number of times the
+// retransmission of the acknowledged confirmed uplink has been acknowledged,
+// when the MIC matches. It returns the full FCnt of the uplink, or nil when
+// the MIC does not match or when the max number of ACKs has been reached.
+var incrementRetransmissionACKsScript = redis.NewScript(1, `
+	local v = redis.call("HMGET", KEYS[1], "mic", "f_cnt")
+	if v[1] ~= ARGV[1] then
+		return false
+	end
+	if redis.call("HINCRBY", KEYS[1], "acks", 1) > tonumber(ARGV[2]) then
+		return false
+	end
+	return v[2]
+`)
+
+// isSuppressedRetransmission returns true when an uplink frame with the same
+// DevEUI, FCnt and payload has already been handled within the configured
+// common.RetransmissionSuppressionWindow (e.g. a NbTrans retransmission
+// which was received by an other gateway after the de-duplication delay).
+// In that case, the suppressed retransmission counter is incremented.
+// It always returns false when the suppression window is disabled.
+func isSuppressedRetransmission(p *redis.Pool, rxPacket models.RXPacket, fCnt uint32) (bool, error) {
+	if common.RetransmissionSuppressionWindow == 0 {
+		return false, nil
+	}
+
+	b, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return false, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(RetransmissionKeyTempl, rxPacket.DevEUI, fCnt)
+	_, err = redis.String(c.Do("SET", key, b, "PX", int64(common.RetransmissionSuppressionWindow)/int64(time.Millisecond), "NX"))
+	if err == nil {
+		return false, nil
+	}
+	if err != redis.ErrNil {
+		return false, errors.Wrap(err, "set retransmission key error")
+	}
+
+	// a frame with the same FCnt has been handled, it is only a
+	// retransmission when the payload is equal
+	prev, err := redis.Bytes(c.Do("GET", key))
+	if err != nil {
+		if err == redis.ErrNil {
+			// expired in the meantime
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get retransmission key error")
+	}
+	if !bytes.Equal(prev, b) {
+		return false, nil
+	}
+
+	if _, err := c.Do("INCR", SuppressedRetransmissionCountKey); err != nil {
+		return false, errors.Wrap(err, "increment suppressed retransmission count error")
+	}
+
+	return true, nil
+}
+
+// GetSuppressedRetransmissionCount returns the number of suppressed
+// retransmissions.
+func GetSuppressedRetransmissionCount(p *redis.Pool) (int, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(c.Do("GET", SuppressedRetransmissionCountKey))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "get suppressed retransmission count error")
+	}
+	return count, nil
+}
+
+// handleRetransmission returns true when the given frame is a
+// retransmission which must not be forwarded to the application-server.
+func handleRetransmission(ctx common.Context, rxPacket models.RXPacket, macPL *lorawan.MACPayload) (bool, error) {
+	suppressed, err := isSuppressedRetransmission(ctx.RedisPool, rxPacket, macPL.FHDR.FCnt)
+	if err != nil {
+		return false, err
+	}
+	if suppressed {
+		log.WithFields(log.Fields{
+			"dev_eui": rxPacket.DevEUI,
+			"fcnt":    macPL.FHDR.FCnt,
+		}).Warning("uplink retransmission suppressed")
+	}
+	return suppressed, nil
+}
+
+// setUplinkACKed stores that the given confirmed uplink of the given node
+// has been acknowledged, so that its retransmissions (in case the ACK was
+// lost) can be acknowledged again within the configured
+// common.RetransmissionACKWindow.
+func setUplinkACKed(p *redis.Pool, devEUI lorawan.EUI64, phy lorawan.PHYPayload) error {
+	if common.RetransmissionACKWindow == 0 || phy.MHDR.MType != lorawan.ConfirmedDataUp {
+		return nil
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(ACKKeyTempl, macPL.FHDR.DevAddr, uint16(macPL.FHDR.FCnt))
+
+	c.Send("MULTI")
+	c.Send("DEL", key)
+	c.Send("HMSET", key, "dev_eui", devEUI[:], "mic", phy.MIC[:], "f_cnt", macPL.FHDR.FCnt)
+	c.Send("PEXPIRE", key, int64(common.RetransmissionACKWindow)/int64(time.Millisecond))
+	if _, err := c.Do("EXEC"); err != nil {
+		return errors.Wrap(err, "set uplink acked error")
+	}
+	return nil
+}
+
+// getACKedRetransmission returns the DevEUI of the node when the given
+// frame is a retransmission of an acknowledged confirmed uplink. As the
+// MIC covers the complete frame, a retransmission has the same MIC.
+func getACKedRetransmission(p *redis.Pool, phy lorawan.PHYPayload) (lorawan.EUI64, bool, error) {
+	var devEUI lorawan.EUI64
+
+	if common.RetransmissionACKWindow == 0 || phy.MHDR.MType != lorawan.ConfirmedDataUp {
+		return devEUI, false, nil
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return devEUI, false, errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.ByteSlices(c.Do("HMGET", fmt.Sprintf(ACKKeyTempl, macPL.FHDR.DevAddr, uint16(macPL.FHDR.FCnt)), "dev_eui", "mic"))
+	if err != nil {
+		return devEUI, false, errors.Wrap(err, "get uplink acked error")
+	}
+	if len(values[0]) != len(devEUI) || !bytes.Equal(values[1], phy.MIC[:]) {
+		return devEUI, false, nil
+	}
+
+	copy(devEUI[:], values[0])
+	return devEUI, true, nil
+}
+
+// incrementRetransmissionACKs increments the number of times the given
+// retransmission has been acknowledged. It returns the full FCnt of the
+// frame and false when the retransmission must not be acknowledged (again).
+func incrementRetransmissionACKs(p *redis.Pool, phy lorawan.PHYPayload) (uint32, bool, error) {
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return 0, false, errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", phy.MACPayload)
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	fCnt, err := redis.Uint64(incrementRetransmissionACKsScript.Do(c,
+		fmt.Sprintf(ACKKeyTempl, macPL.FHDR.DevAddr, uint16(macPL.FHDR.FCnt)),
+		phy.MIC[:],
+		maxRetransmissionACKs,
+	))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(err, "increment retransmission acks error")
+	}
+	return uint32(fCnt), true, nil
+}
+
+// handleACKedRetransmission acknowledges the given frame again when it is a
+// retransmission of an acknowledged confirmed uplink (in which case the ACK
+// was lost). The retransmission is not forwarded to the application-server
+// and not taken into account by the statistics. It returns false when the
+// frame is not such a retransmission.
+func handleACKedRetransmission(ctx common.Context, rxPacket gw.RXPacket, receivedAt time.Time) (bool, error) {
+	devEUI, ok, err := getACKedRetransmission(ctx.RedisPool, rxPacket.PHYPayload)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ns, err := session.GetNodeSession(ctx.RedisPool, devEUI)
+	if err != nil {
+		return false, errors.Wrap(err, "get node-session error")
+	}
+
+	if common.SecurityStrictMode {
+		quarantined, err := security.IsQuarantined(ctx.RedisPool, devEUI)
+		if err != nil {
+			return false, err
+		}
+		if quarantined {
+			return true, errors.Wrapf(ErrNodeQuarantined, "dev_eui: %s", devEUI)
+		}
+	}
+
+	// the ACK must be sent before the receive-window opens
+	ctx, cancel := ctx.WithDeadline(getDataUpDeadline(ns, receivedAt))
+	defer cancel()
+
+	return true, collectAndCallOnce(ctx.RedisPool, rxPacket, func(rxPacket models.RXPacket) error {
+		fCnt, ok, err := incrementRetransmissionACKs(ctx.RedisPool, rxPacket.PHYPayload)
+		if err != nil {
+			return err
+		}
+
+		logFields := log.Fields{
+			"dev_eui": devEUI,
+			"fcnt":    fCnt,
+		}
+		if !ok {
+			log.WithFields(logFields).Warning("max acks of confirmed uplink retransmission reached")
+			return nil
+		}
+		log.WithFields(logFields).Info("confirmed uplink retransmission, acknowledging again")
+
+		if err := calibrateRXInfoSet(ctx, &rxPacket); err != nil {
+			return err
+		}
+		rxPacket.DevEUI = devEUI
+
+		// get the node-session again, as the FCntDown might have been
+		// incremented during the collection
+		ns, err := session.GetNodeSession(ctx.RedisPool, devEUI)
+		if err != nil {
+			return errors.Wrap(err, "get node-session error")
+		}
+
+		return downlink.SendUplinkACK(ctx, ns, rxPacket, fCnt)
+	})
+}
@@ -0,0 +1,156 @@
+package uplink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/models"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsSuppressedRetransmission(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and an uplink frame", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		fPort := uint8(1)
+		newRXPacket := func(data []byte) models.RXPacket {
+			return models.RXPacket{
+				DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+							FCnt:    10,
+						},
+						FPort: &fPort,
+						FRMPayload: []lorawan.Payload{
+							&lorawan.DataPayload{Bytes: data},
+						},
+					},
+				},
+			}
+		}
+
+		Convey("Then the first frame is not suppressed", func() {
+			suppressed, err := isSuppressedRetransmission(p, newRXPacket([]byte{1, 2, 3}), 10)
+			So(err, ShouldBeNil)
+			So(suppressed, ShouldBeFalse)
+
+			Convey("Then a retransmission is suppressed and counted", func() {
+				suppressed, err := isSuppressedRetransmission(p, newRXPacket([]byte{1, 2, 3}), 10)
+				So(err, ShouldBeNil)
+				So(suppressed, ShouldBeTrue)
+
+				count, err := GetSuppressedRetransmissionCount(p)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, 1)
+			})
+
+			Convey("Then a frame with the same FCnt but an other payload is not suppressed", func() {
+				suppressed, err := isSuppressedRetransmission(p, newRXPacket([]byte{3, 2, 1}), 10)
+				So(err, ShouldBeNil)
+				So(suppressed, ShouldBeFalse)
+			})
+
+			Convey("Then a frame with an other FCnt is not suppressed", func() {
+				suppressed, err := isSuppressedRetransmission(p, newRXPacket([]byte{1, 2, 3}), 11)
+				So(err, ShouldBeNil)
+				So(suppressed, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestACKedRetransmission(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database and a confirmed uplink frame", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		fPort := uint8(1)
+		newPHYPayload := func(fCnt uint32, mic [4]byte) lorawan.PHYPayload {
+			return lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.ConfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+						FCnt:    fCnt,
+					},
+					FPort: &fPort,
+					FRMPayload: []lorawan.Payload{
+						&lorawan.DataPayload{Bytes: []byte{1, 2, 3}},
+					},
+				},
+				MIC: mic,
+			}
+		}
+
+		Convey("Then the frame is not an acknowledged retransmission", func() {
+			_, ok, err := getACKedRetransmission(p, newPHYPayload(10, [4]byte{1, 2, 3, 4}))
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("When the frame (with a full FCnt of 65546) has been acknowledged", func() {
+			So(setUplinkACKed(p, devEUI, newPHYPayload(65546, [4]byte{1, 2, 3, 4})), ShouldBeNil)
+
+			Convey("Then its retransmission is an acknowledged retransmission of the node", func() {
+				phy := newPHYPayload(10, [4]byte{1, 2, 3, 4})
+				d, ok, err := getACKedRetransmission(p, phy)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+				So(d, ShouldEqual, devEUI)
+
+				Convey("Then the retransmission is acknowledged at most maxRetransmissionACKs times", func() {
+					for i := 0; i < maxRetransmissionACKs; i++ {
+						fCnt, ok, err := incrementRetransmissionACKs(p, phy)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+						So(fCnt, ShouldEqual, 65546)
+					}
+
+					_, ok, err := incrementRetransmissionACKs(p, phy)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeFalse)
+				})
+			})
+
+			Convey("Then a frame with the same FCnt but an other MIC is not an acknowledged retransmission", func() {
+				phy := newPHYPayload(10, [4]byte{4, 3, 2, 1})
+				_, ok, err := getACKedRetransmission(p, phy)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+
+				_, ok, err = incrementRetransmissionACKs(p, phy)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then the frame is not an acknowledged retransmission when the window is disabled", func() {
+				common.RetransmissionACKWindow = 0
+				defer func() {
+					common.RetransmissionACKWindow = time.Minute
+				}()
+
+				_, ok, err := getACKedRetransmission(p, newPHYPayload(10, [4]byte{1, 2, 3, 4}))
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package uplink
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/joriwind/loraserver/api/gw"
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/security"
+	"github.com/joriwind/loraserver/internal/session"
+)
+
+// emitSecurityEvents emits the security events of a data uplink for which
+// no node-session could be found (strict security mode). For each
+// node-session of the DevAddr, a MICFailure event is emitted when the
+// frame-counter is valid but the MIC is not. A FCntReset event is emitted
+// when the frame-counter has been reset to 0 with a valid MIC.
+// Node-sessions for which the frame-counter is invalid otherwise (e.g.
+// a replayed or delayed frame) are ignored.
+func emitSecurityEvents(ctx common.Context, rxPacket gw.RXPacket, receivedAt time.Time) error {
+	macPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return errors.Wrapf(ErrUnexpectedPayloadType, "expected *lorawan.MACPayload, got: %T", rxPacket.PHYPayload.MACPayload)
+	}
+
+	sessions, err := session.GetNodeSessionsForDevAddr(ctx.RedisPool, macPL.FHDR.DevAddr)
+	if err != nil {
+		return errors.Wrap(err, "get node-sessions for devaddr error")
+	}
+
+	for _, ns := range sessions {
+		eventType, ok, err := getSecurityEventType(rxPacket.PHYPayload, *macPL, ns)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		err = security.Emit(ctx.RedisPool, security.Event{
+			Type:       eventType,
+			Time:       receivedAt,
+			DevEUI:     ns.DevEUI,
+			DevAddr:    macPL.FHDR.DevAddr,
+			FCnt:       macPL.FHDR.FCnt,
+			MIC:        rxPacket.PHYPayload.MIC,
+			GatewayMAC: rxPacket.RXInfo.MAC,
+		})
+		if err != nil {
+			return errors.Wrap(err, "emit security event error")
+		}
+	}
+
+	return nil
+}
+
+// getSecurityEventType returns the type of the security event of the given
+// frame for the given node-session. The given MACPayload is a copy, so that
+// the frame-counter of the frame is not altered.
+func getSecurityEventType(phy lorawan.PHYPayload, macPL lorawan.MACPayload, ns session.NodeSession) (security.EventType, bool, error) {
+	phy.MACPayload = &macPL
+
+	fullFCnt, ok := session.ValidateAndGetFullFCntUp(ns, macPL.FHDR.FCnt)
+	if ok {
+		macPL.FHDR.FCnt = fullFCnt
+		micOK, err := phy.ValidateMIC(ns.NwkSKey)
+		if err != nil {
+			return "", false, errors.Wrap(err, "validate mic error")
+		}
+		return security.MICFailure, !micOK, nil
+	}
+
+	if macPL.FHDR.FCnt == 0 {
+		micOK, err := phy.ValidateMIC(ns.NwkSKey)
+		if err != nil {
+			return "", false, errors.Wrap(err, "validate mic error")
+		}
+		return security.FCntReset, micOK, nil
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,63 @@
+package uplink
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joriwind/loraserver/internal/security"
+	"github.com/joriwind/loraserver/internal/session"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetSecurityEventType(t *testing.T) {
+	test.GetConfig()
+
+	Convey("Given a node-session", t, func() {
+		ns := session.NodeSession{
+			DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+			DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			NwkSKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			FCntUp:  100,
+		}
+
+		tests := []struct {
+			Name          string
+			FCnt          uint32
+			NwkSKey       lorawan.AES128Key
+			ExpectedType  security.EventType
+			ExpectedEvent bool
+		}{
+			{"valid frame", 100, ns.NwkSKey, security.MICFailure, false},
+			{"invalid mic", 100, lorawan.AES128Key{}, security.MICFailure, true},
+			{"frame-counter reset", 0, ns.NwkSKey, security.FCntReset, true},
+			{"frame-counter reset with invalid mic", 0, lorawan.AES128Key{}, security.FCntReset, false},
+			{"replayed frame", 99, ns.NwkSKey, "", false},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Name, i), func() {
+				macPL := lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ns.DevAddr,
+						FCnt:    test.FCnt,
+					},
+				}
+				phy := lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{
+						MType: lorawan.UnconfirmedDataUp,
+						Major: lorawan.LoRaWANR1,
+					},
+					MACPayload: &macPL,
+				}
+				So(phy.SetMIC(test.NwkSKey), ShouldBeNil)
+
+				eventType, ok, err := getSecurityEventType(phy, macPL, ns)
+				So(err, ShouldBeNil)
+				So(ok, ShouldEqual, test.ExpectedEvent)
+				So(eventType, ShouldEqual, test.ExpectedType)
+			})
+		}
+	})
+}
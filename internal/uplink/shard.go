@@ -0,0 +1,91 @@
+package uplink
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/joriwind/loraserver/internal/common"
+)
+
+var (
+	devAddrShardsOnce sync.Once
+	devAddrShards     *shardPool
+)
+
+// shardPool runs functions on a fixed number of worker shards. The
+// functions for the same DevAddr always run on the same shard, one after
+// the other, while the functions for DevAddrs of other shards run in
+// parallel.
+type shardPool struct {
+	shards []chan shardJob
+}
+
+type shardJob struct {
+	fn  func() error
+	err chan error
+}
+
+// newShardPool creates a shardPool with the given number of shards and
+// starts its workers.
+func newShardPool(n int) *shardPool {
+	p := shardPool{
+		shards: make([]chan shardJob, n),
+	}
+	for i := range p.shards {
+		p.shards[i] = make(chan shardJob, 1)
+		go p.work(p.shards[i])
+	}
+	return &p
+}
+
+func (p *shardPool) work(jobs chan shardJob) {
+	for job := range jobs {
+		job.err <- job.fn()
+	}
+}
+
+// run runs the given function on the shard of the given DevAddr and returns
+// its error once completed.
+func (p *shardPool) run(devAddr lorawan.DevAddr, fn func() error) error {
+	job := shardJob{
+		fn:  fn,
+		err: make(chan error, 1),
+	}
+	p.shards[shardIndex(devAddr, len(p.shards))] <- job
+	return <-job.err
+}
+
+// runOnDevAddrShard runs the given function on the shard of the given
+// DevAddr (see common.UplinkShards), so that the uplinks of a node are
+// processed one after the other. When sharding is disabled, the function is
+// run directly.
+func runOnDevAddrShard(devAddr lorawan.DevAddr, fn func() error) error {
+	if common.UplinkShards <= 0 {
+		return fn()
+	}
+
+	devAddrShardsOnce.Do(func() {
+		devAddrShards = newShardPool(common.UplinkShards)
+	})
+	return devAddrShards.run(devAddr, fn)
+}
+
+// shardIndex returns the shard (0 - n-1) of the given DevAddr, using the
+// jump consistent hash of the FNV-1a hash of the DevAddr, so that only a
+// minimal number of DevAddrs move to an other shard when the number of
+// shards changes.
+func shardIndex(devAddr lorawan.DevAddr, n int) int {
+	h := fnv.New64a()
+	h.Write(devAddr[:])
+	key := h.Sum64()
+
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
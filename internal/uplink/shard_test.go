@@ -0,0 +1,88 @@
+package uplink
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestShardIndex(t *testing.T) {
+	Convey("Given a set of DevAddrs", t, func() {
+		var devAddrs []lorawan.DevAddr
+		for i := 0; i < 1000; i++ {
+			devAddrs = append(devAddrs, lorawan.DevAddr{1, 2, byte(i >> 8), byte(i)})
+		}
+
+		Convey("Then the shard index is stable and within the number of shards", func() {
+			for _, devAddr := range devAddrs {
+				i := shardIndex(devAddr, 8)
+				So(i >= 0 && i < 8, ShouldBeTrue)
+				So(shardIndex(devAddr, 8), ShouldEqual, i)
+			}
+		})
+
+		Convey("Then the DevAddrs are distributed over all the shards", func() {
+			counts := make(map[int]int)
+			for _, devAddr := range devAddrs {
+				counts[shardIndex(devAddr, 8)]++
+			}
+			So(counts, ShouldHaveLength, 8)
+			for _, count := range counts {
+				So(count, ShouldBeGreaterThan, 50)
+			}
+		})
+
+		Convey("Then adding a shard only moves DevAddrs to the new shard", func() {
+			for _, devAddr := range devAddrs {
+				if i := shardIndex(devAddr, 9); i != 8 {
+					So(i, ShouldEqual, shardIndex(devAddr, 8))
+				}
+			}
+		})
+	})
+}
+
+func TestShardPool(t *testing.T) {
+	Convey("Given a shard pool with 4 shards", t, func() {
+		p := newShardPool(4)
+		devAddr := lorawan.DevAddr{1, 2, 3, 4}
+
+		Convey("Then the functions for the same DevAddr run one after the other", func() {
+			var mu sync.Mutex
+			var running, maxRunning int
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					p.run(devAddr, func() error {
+						mu.Lock()
+						running++
+						if running > maxRunning {
+							maxRunning = running
+						}
+						mu.Unlock()
+
+						time.Sleep(time.Millisecond)
+
+						mu.Lock()
+						running--
+						mu.Unlock()
+						return nil
+					})
+				}()
+			}
+			wg.Wait()
+			So(maxRunning, ShouldEqual, 1)
+		})
+
+		Convey("Then the error of the function is returned", func() {
+			err := p.run(devAddr, func() error { return ErrEmptyCollectSet })
+			So(err, ShouldEqual, ErrEmptyCollectSet)
+		})
+	})
+}
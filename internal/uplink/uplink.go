@@ -1,14 +1,15 @@
 package uplink
 
 import (
-	"fmt"
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
 
 	"github.com/brocaar/lorawan"
 	"github.com/joriwind/loraserver/api/gw"
 	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/downlink"
 )
 
 // Server represents a server listening for uplink packets.
@@ -31,6 +32,11 @@ func (s *Server) Start() error {
 		defer s.wg.Done()
 		HandleRXPackets(&s.wg, s.ctx)
 	}()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		downlink.HandleTXAcks(&s.wg, s.ctx)
+	}()
 	return nil
 }
 
@@ -38,7 +44,7 @@ func (s *Server) Start() error {
 // pending packets.
 func (s *Server) Stop() error {
 	if err := s.ctx.Gateway.Close(); err != nil {
-		return fmt.Errorf("close gateway backend error: %s", err)
+		return errors.Wrap(err, "close gateway backend error")
 	}
 	log.Info("waiting for pending actions to complete")
 	s.wg.Wait()
@@ -64,10 +70,14 @@ func HandleRXPackets(wg *sync.WaitGroup, ctx common.Context) {
 func HandleRXPacket(ctx common.Context, rxPacket gw.RXPacket) error {
 	switch rxPacket.PHYPayload.MHDR.MType {
 	case lorawan.JoinRequest:
+		// a join-request creates a node-session and requires a join-accept
+		if common.ReadOnlyMode {
+			return ErrReadOnlyMode
+		}
 		return collectJoinRequestPacket(ctx, rxPacket)
 	case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
 		return validateAndCollectDataUpRXPacket(ctx, rxPacket)
 	default:
-		return fmt.Errorf("unknown MType: %v", rxPacket.PHYPayload.MHDR.MType)
+		return errors.Wrapf(ErrUnknownMType, "MType %v", rxPacket.PHYPayload.MHDR.MType)
 	}
 }
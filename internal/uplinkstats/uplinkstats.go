@@ -0,0 +1,244 @@
+// Package uplinkstats implements the uplink traffic statistics: the number
+// of uplink frames and bytes per node and FPort and the nodes consuming the
+// most network capacity (top talkers), aggregated per day.
+package uplinkstats
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/counters"
+	"github.com/brocaar/lorawan"
+)
+
+const (
+	// deviceKeyTempl contains per day (YYYY-MM-DD) and node a hash with the
+	// frame and byte counters per FPort.
+	deviceKeyTempl = "lora:ns:uplink:stats:dev:%s:%s"
+
+	// framesKeyTempl and bytesKeyTempl contain per day a sorted set with
+	// the number of frames / bytes per node.
+	framesKeyTempl = "lora:ns:uplink:stats:frames:%s"
+	bytesKeyTempl  = "lora:ns:uplink:stats:bytes:%s"
+
+	// topKeyTempl is the temporary key used to aggregate the sorted sets of
+	// multiple days.
+	topKeyTempl = "lora:ns:uplink:stats:top:%d"
+
+	framesField = "frames:"
+	bytesField  = "bytes:"
+
+	// dateFormat defines the format of the day in the keys.
+	dateFormat = "2006-01-02"
+
+	// Retention defines how long the statistics are kept.
+	Retention = 31 * 24 * time.Hour
+)
+
+// Possible top talker orders.
+const (
+	OrderByFrames = "frames"
+	OrderByBytes  = "bytes"
+)
+
+// FPortStats contains the uplink statistics of a node for a single FPort.
+type FPortStats struct {
+	FPort  uint8 // 0 for uplinks without FPort (and mac-command only uplinks)
+	Frames int
+	Bytes  int // PHYPayload bytes
+}
+
+// TopTalker contains the uplink statistics of a node.
+type TopTalker struct {
+	DevEUI lorawan.EUI64
+	Frames int
+	Bytes  int
+}
+
+type byFPort []FPortStats
+
+func (s byFPort) Len() int           { return len(s) }
+func (s byFPort) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byFPort) Less(i, j int) bool { return s[i].FPort < s[j].FPort }
+
+// dates returns the dates (YYYY-MM-DD, in common.TimeLocation) of the given
+// number of days including today (newest first). Days are limited to the
+// Retention.
+func dates(days int) []string {
+	if days < 1 {
+		days = 1
+	}
+	if max := int(Retention / (24 * time.Hour)); days > max {
+		days = max
+	}
+
+	var out []string
+	now := time.Now().In(common.TimeLocation)
+	for i := 0; i < days; i++ {
+		out = append(out, now.AddDate(0, 0, -i).Format(dateFormat))
+	}
+	return out
+}
+
+// RecordUplink records an uplink of the given node. The size is the size of
+// the PHYPayload in bytes.
+func RecordUplink(p *redis.Pool, devEUI lorawan.EUI64, fPort *uint8, size int, receivedAt time.Time) error {
+	var port uint8
+	if fPort != nil {
+		port = *fPort
+	}
+
+	date := receivedAt.In(common.TimeLocation).Format(dateFormat)
+	devKey := fmt.Sprintf(deviceKeyTempl, date, devEUI)
+	framesKey := fmt.Sprintf(framesKeyTempl, date)
+	bytesKey := fmt.Sprintf(bytesKeyTempl, date)
+
+	var b counters.Batch
+	b.HIncrBy(devKey, framesField+strconv.Itoa(int(port)), 1, Retention)
+	b.HIncrBy(devKey, bytesField+strconv.Itoa(int(port)), int64(size), Retention)
+	b.ZIncrBy(framesKey, devEUI.String(), 1, Retention)
+	b.ZIncrBy(bytesKey, devEUI.String(), int64(size), Retention)
+	if err := counters.Add(p, b); err != nil {
+		return errors.Wrap(err, "record uplink stats error")
+	}
+	return nil
+}
+
+// GetDeviceStats returns the uplink statistics per FPort of the given node,
+// aggregated over the given number of days including today (sorted by
+// FPort).
+func GetDeviceStats(p *redis.Pool, devEUI lorawan.EUI64, days int) ([]FPortStats, error) {
+	c := p.Get()
+	defer c.Close()
+
+	perPort := make(map[uint8]*FPortStats)
+	for _, date := range dates(days) {
+		values, err := redis.IntMap(c.Do("HGETALL", fmt.Sprintf(deviceKeyTempl, date, devEUI)))
+		if err != nil {
+			return nil, errors.Wrap(err, "get uplink stats error")
+		}
+
+		for field, v := range values {
+			var portStr string
+			var frames bool
+			switch {
+			case strings.HasPrefix(field, framesField):
+				portStr, frames = strings.TrimPrefix(field, framesField), true
+			case strings.HasPrefix(field, bytesField):
+				portStr = strings.TrimPrefix(field, bytesField)
+			default:
+				continue
+			}
+
+			port, err := strconv.ParseUint(portStr, 10, 8)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse fport error")
+			}
+
+			s, ok := perPort[uint8(port)]
+			if !ok {
+				s = &FPortStats{FPort: uint8(port)}
+				perPort[uint8(port)] = s
+			}
+			if frames {
+				s.Frames += v
+			} else {
+				s.Bytes += v
+			}
+		}
+	}
+
+	var out []FPortStats
+	for _, s := range perPort {
+		out = append(out, *s)
+	}
+	sort.Sort(byFPort(out))
+	return out, nil
+}
+
+// GetTopTalkers returns the given number of nodes with the most uplink
+// frames or bytes (see OrderByFrames and OrderByBytes) over the given number
+// of days including today.
+func GetTopTalkers(p *redis.Pool, days, limit int, orderBy string) ([]TopTalker, error) {
+	var orderTempl, otherTempl string
+	switch orderBy {
+	case "", OrderByFrames:
+		orderTempl, otherTempl = framesKeyTempl, bytesKeyTempl
+	case OrderByBytes:
+		orderTempl, otherTempl = bytesKeyTempl, framesKeyTempl
+	default:
+		return nil, fmt.Errorf("invalid order by: %s", orderBy)
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	ds := dates(days)
+	orderKeys := []interface{}{}
+	otherKeys := []interface{}{}
+	for _, date := range ds {
+		orderKeys = append(orderKeys, fmt.Sprintf(orderTempl, date))
+		otherKeys = append(otherKeys, fmt.Sprintf(otherTempl, date))
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	// aggregate the days into a temporary key, the top talkers are returned
+	// from this key
+	topKey := fmt.Sprintf(topKeyTempl, time.Now().UnixNano())
+	c.Send("MULTI")
+	c.Send("ZUNIONSTORE", append([]interface{}{topKey, len(orderKeys)}, orderKeys...)...)
+	c.Send("ZREVRANGE", topKey, 0, limit-1, "WITHSCORES")
+	c.Send("DEL", topKey)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return nil, errors.Wrap(err, "get top talkers error")
+	}
+	items, err := redis.Strings(values[1], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "get top talkers error")
+	}
+
+	var out []TopTalker
+	for i := 0; i+1 < len(items); i += 2 {
+		var tt TopTalker
+		if err := tt.DevEUI.UnmarshalText([]byte(items[i])); err != nil {
+			return nil, errors.Wrap(err, "unmarshal DevEUI error")
+		}
+		score, err := strconv.Atoi(items[i+1])
+		if err != nil {
+			return nil, errors.Wrap(err, "parse score error")
+		}
+
+		// the other metric is summed over the days
+		for _, key := range otherKeys {
+			c.Send("ZSCORE", key, items[i])
+		}
+		c.Flush()
+		var other int
+		for range otherKeys {
+			v, err := redis.Int(c.Receive())
+			if err != nil && err != redis.ErrNil {
+				return nil, errors.Wrap(err, "get score error")
+			}
+			other += v
+		}
+
+		if orderBy == OrderByBytes {
+			tt.Bytes, tt.Frames = score, other
+		} else {
+			tt.Frames, tt.Bytes = score, other
+		}
+		out = append(out, tt)
+	}
+
+	return out, nil
+}
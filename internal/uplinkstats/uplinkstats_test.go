@@ -0,0 +1,67 @@
+package uplinkstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joriwind/loraserver/internal/common"
+	"github.com/joriwind/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUplinkStats(t *testing.T) {
+	conf := test.GetConfig()
+
+	Convey("Given a clean Redis database", t, func() {
+		p := common.NewRedisPool(conf.RedisURL)
+		test.MustFlushRedis(p)
+
+		devEUI1 := lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+		devEUI2 := lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}
+		fPort1 := uint8(1)
+		fPort2 := uint8(2)
+		now := time.Now()
+
+		Convey("When recording uplinks for two nodes", func() {
+			So(RecordUplink(p, devEUI1, &fPort1, 20, now), ShouldBeNil)
+			So(RecordUplink(p, devEUI1, &fPort2, 30, now), ShouldBeNil)
+			So(RecordUplink(p, devEUI1, nil, 12, now.AddDate(0, 0, -1)), ShouldBeNil)
+			So(RecordUplink(p, devEUI2, &fPort1, 50, now), ShouldBeNil)
+			So(RecordUplink(p, devEUI2, &fPort1, 50, now), ShouldBeNil)
+
+			Convey("Then the stats of today are returned per FPort", func() {
+				stats, err := GetDeviceStats(p, devEUI1, 0)
+				So(err, ShouldBeNil)
+				So(stats, ShouldResemble, []FPortStats{
+					{FPort: 1, Frames: 1, Bytes: 20},
+					{FPort: 2, Frames: 1, Bytes: 30},
+				})
+			})
+
+			Convey("Then the stats of the last two days include yesterday", func() {
+				stats, err := GetDeviceStats(p, devEUI1, 2)
+				So(err, ShouldBeNil)
+				So(stats, ShouldHaveLength, 3)
+				So(stats[0], ShouldResemble, FPortStats{FPort: 0, Frames: 1, Bytes: 12})
+			})
+
+			Convey("Then the top talkers by frames are returned", func() {
+				talkers, err := GetTopTalkers(p, 2, 10, OrderByFrames)
+				So(err, ShouldBeNil)
+				So(talkers, ShouldResemble, []TopTalker{
+					{DevEUI: devEUI1, Frames: 3, Bytes: 62},
+					{DevEUI: devEUI2, Frames: 2, Bytes: 100},
+				})
+			})
+
+			Convey("Then the top talkers by bytes are returned", func() {
+				talkers, err := GetTopTalkers(p, 2, 1, OrderByBytes)
+				So(err, ShouldBeNil)
+				So(talkers, ShouldResemble, []TopTalker{
+					{DevEUI: devEUI2, Frames: 2, Bytes: 100},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,41 @@
+// Package webhook implements the posting of JSON payloads to webhooks,
+// shared by the HTTP publishers (alerts, events, digests, ...).
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Timeout defines the timeout of the HTTP client returned by NewClient.
+const Timeout = 10 * time.Second
+
+// NewClient returns a new HTTP client for posting to webhooks.
+func NewClient() *http.Client {
+	return &http.Client{Timeout: Timeout}
+}
+
+// Post posts the given value as JSON (HTTP POST) to the given URL. An error
+// is returned when the response status is not 2XX.
+func Post(client *http.Client, url string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("expected 2XX response, got: %d", resp.StatusCode)
+	}
+	return nil
+}
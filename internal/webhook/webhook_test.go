@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPost(t *testing.T) {
+	Convey("Given a test HTTP server", t, func() {
+		var contentType string
+		status := http.StatusOK
+		bodyChan := make(chan []byte, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			b, _ := ioutil.ReadAll(r.Body)
+			bodyChan <- b
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		Convey("Then Post posts the value as JSON", func() {
+			So(Post(NewClient(), server.URL, map[string]int{"count": 3}), ShouldBeNil)
+			So(string(<-bodyChan), ShouldEqual, `{"count":3}`)
+			So(contentType, ShouldEqual, "application/json")
+		})
+
+		Convey("Then Post returns an error on a non-2XX response", func() {
+			status = http.StatusInternalServerError
+			So(Post(NewClient(), server.URL, nil), ShouldResemble, errors.New("expected 2XX response, got: 500"))
+		})
+	})
+}
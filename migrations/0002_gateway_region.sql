@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table gateway add column region varchar(100) not null default '';
+
+create index idx_gateway_region on gateway (region);
+
+-- +migrate Down
+drop index idx_gateway_region;
+
+alter table gateway drop column region;
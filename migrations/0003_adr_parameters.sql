@@ -0,0 +1,11 @@
+-- +migrate Up
+create table adr_parameters (
+	id smallint primary key check (id = 1),
+	installation_margin double precision not null,
+	max_dr smallint not null,
+	respond_to_adr_ack_req boolean not null,
+	updated_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table adr_parameters;
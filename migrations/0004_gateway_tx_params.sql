@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table gateway
+	add column tx_power integer not null default 0,
+	add column code_rate varchar(10) not null default '',
+	add column ipol boolean;
+
+-- +migrate Down
+alter table gateway
+	drop column ipol,
+	drop column code_rate,
+	drop column tx_power;
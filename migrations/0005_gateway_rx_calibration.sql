@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table gateway
+	add column rssi_offset integer not null default 0,
+	add column lora_snr_offset double precision not null default 0;
+
+-- +migrate Down
+alter table gateway
+	drop column lora_snr_offset,
+	drop column rssi_offset;
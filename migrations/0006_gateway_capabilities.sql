@@ -0,0 +1,11 @@
+-- +migrate Up
+alter table gateway
+	add column has_gps boolean not null default false,
+	add column fine_timestamp boolean not null default false,
+	add column receive_only boolean not null default false;
+
+-- +migrate Down
+alter table gateway
+	drop column receive_only,
+	drop column fine_timestamp,
+	drop column has_gps;
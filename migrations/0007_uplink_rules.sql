@@ -0,0 +1,20 @@
+-- +migrate Up
+create table uplink_rule (
+	id bigserial primary key,
+	name varchar(100) not null,
+	enabled boolean not null,
+	dev_eui bytea,
+	f_port smallint,
+	max_dr smallint,
+	consecutive_frames integer not null,
+	action varchar(30) not null,
+	data_rate smallint not null,
+	tx_power smallint not null,
+	mac_command bytea,
+	class_c_window bigint not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table uplink_rule;
@@ -0,0 +1,12 @@
+-- +migrate Up
+create table gateway_cups_credentials (
+	mac bytea primary key references gateway on delete cascade,
+	cups_token varchar(64) not null,
+	previous_cups_token varchar(64),
+	tc_token varchar(64) not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table gateway_cups_credentials;
@@ -0,0 +1,26 @@
+-- +migrate Up
+create table multicast_group (
+	id bigserial primary key,
+	name varchar(100) not null,
+	mc_addr bytea not null,
+	mc_nwk_s_key bytea not null,
+	mc_app_s_key bytea,
+	frequency integer not null,
+	dr smallint not null,
+	f_cnt bigint not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+create table multicast_group_gateway (
+	multicast_group_id bigint not null references multicast_group on delete cascade,
+	mac bytea not null references gateway on delete cascade,
+	primary key (multicast_group_id, mac)
+);
+
+create index idx_multicast_group_gateway_mac on multicast_group_gateway (mac);
+
+-- +migrate Down
+drop index idx_multicast_group_gateway_mac;
+drop table multicast_group_gateway;
+drop table multicast_group;
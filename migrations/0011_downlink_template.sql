@@ -0,0 +1,13 @@
+-- +migrate Up
+create table downlink_template (
+	id bigserial primary key,
+	name varchar(100) not null,
+	f_port smallint not null,
+	payload text not null,
+	confirmed boolean not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table downlink_template;
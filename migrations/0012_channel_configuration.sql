@@ -0,0 +1,22 @@
+-- +migrate Up
+create table channel_configuration (
+	id bigserial primary key,
+	name varchar(100) not null,
+	band varchar(20) not null,
+	channels smallint[] not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+create table channel_configuration_extra_channel (
+	channel_configuration_id bigint not null references channel_configuration on delete cascade,
+	position smallint not null,
+	frequency integer not null,
+	min_dr smallint not null,
+	max_dr smallint not null,
+	primary key (channel_configuration_id, position)
+);
+
+-- +migrate Down
+drop table channel_configuration_extra_channel;
+drop table channel_configuration;
@@ -0,0 +1,9 @@
+-- +migrate Up
+alter table adr_parameters
+	add column noisy_channel_threshold double precision not null default 0,
+	add column max_frequency_offset integer not null default 0;
+
+-- +migrate Down
+alter table adr_parameters
+	drop column max_frequency_offset,
+	drop column noisy_channel_threshold;
@@ -0,0 +1,14 @@
+-- +migrate Up
+create table gateway_onboarding_token (
+	token varchar(64) primary key,
+	description text not null,
+	region varchar(100) not null,
+	tags jsonb not null default '{}',
+	created_at timestamp with time zone not null,
+	expires_at timestamp with time zone not null,
+	used_at timestamp with time zone,
+	used_by bytea
+);
+
+-- +migrate Down
+drop table gateway_onboarding_token;
@@ -0,0 +1,9 @@
+-- +migrate Up
+create table gateway_signing_key (
+	mac bytea primary key references gateway on delete cascade,
+	signing_key bytea not null,
+	created_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table gateway_signing_key;
@@ -0,0 +1,9 @@
+-- +migrate Up
+create table device_first_join (
+	dev_eui bytea primary key,
+	app_eui bytea not null,
+	joined_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table device_first_join;
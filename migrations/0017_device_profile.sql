@@ -0,0 +1,12 @@
+-- +migrate Up
+create table device_profile (
+	id bigserial primary key,
+	name varchar(100) not null,
+	max_queue_size integer not null,
+	daily_airtime_quota bigint not null,
+	created_at timestamp with time zone not null,
+	updated_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table device_profile;
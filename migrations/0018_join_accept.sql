@@ -0,0 +1,14 @@
+-- +migrate Up
+create table join_accept (
+	dev_eui bytea not null,
+	dev_nonce bytea not null,
+	join_accept_hash bytea not null,
+	created_at timestamp with time zone not null,
+	primary key (dev_eui, dev_nonce)
+);
+
+create index idx_join_accept_created_at on join_accept (created_at);
+
+-- +migrate Down
+drop index idx_join_accept_created_at;
+drop table join_accept;